@@ -0,0 +1,58 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build js && wasm
+
+// Command bound-wasm is the validation core of bound compiled to
+// WebAssembly, for the in-browser spec playground. It defines
+//
+//	bound.check(spec, files?, lang?)
+//
+// on the global object, which validates the spec YAML and returns
+// playground.Result as an object. files maps the paths of referenced files,
+// relative to the spec, to their content.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/openboundary/openboundary/internal/playground"
+	"github.com/openboundary/openboundary/internal/validator"
+)
+
+func main() {
+	bound := js.Global().Get("Object").New()
+	bound.Set("check", js.FuncOf(check))
+	js.Global().Set("bound", bound)
+
+	// Keep the exported functions alive
+	select {}
+}
+
+func check(_ js.Value, args []js.Value) any {
+	if len(args) == 0 || args[0].Type() != js.TypeString {
+		return js.Global().Get("Error").New("bound.check: spec must be a string")
+	}
+
+	files := make(map[string][]byte)
+	if len(args) > 1 && args[1].Type() == js.TypeObject {
+		keys := js.Global().Get("Object").Call("keys", args[1])
+		for n := 0; n < keys.Length(); n++ {
+			name := keys.Index(n).String()
+			files[name] = []byte(args[1].Get(name).String())
+		}
+	}
+	lang := validator.DefaultLanguage
+	if len(args) > 2 && args[2].Type() == js.TypeString {
+		lang = args[2].String()
+	}
+
+	result := playground.Check(context.Background(), []byte(args[0].String()), files, lang)
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return js.Global().Get("Error").New(err.Error())
+	}
+	return js.Global().Get("JSON").Call("parse", string(encoded))
+}
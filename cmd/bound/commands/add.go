@@ -0,0 +1,328 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AddOptions configures the add command.
+type AddOptions struct {
+	SpecFile string
+
+	// usecase
+	BindsTo string
+	Goal    string
+	Actor   string
+
+	// middleware / postgres / http.server
+	Provider  string
+	Config    string
+	Model     string
+	Policy    string
+	Schema    string
+	Framework string
+	Port      int
+	OpenAPI   string
+
+	Middleware []string
+	DependsOn  []string
+
+	Compile   bool // Compile the spec after adding to scaffold implementation files
+	OutputDir string
+}
+
+// componentKinds maps the add subcommand kind to the spec kind and ID prefix.
+var componentKinds = map[string]struct{ kind, prefix string }{
+	"usecase":    {"usecase", "usecase."},
+	"middleware": {"middleware", "middleware."},
+	"postgres":   {"postgres", "postgres."},
+	"server":     {"http.server", "http.server."},
+}
+
+func Add(ctx context.Context, kind, name string, opts AddOptions) error {
+	comp, err := newComponent(kind, name, opts)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(opts.SpecFile)
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	updated, err := appendComponent(data, comp)
+	if err != nil {
+		return err
+	}
+	if err := checkSpec(opts.SpecFile, updated, comp.ID); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(opts.SpecFile, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write spec: %w", err)
+	}
+	fmt.Printf("✓ Added %s to %s\n", comp.ID, opts.SpecFile)
+
+	if opts.Compile {
+		return Compile(ctx, opts.SpecFile, CompileOptions{OutputDir: opts.OutputDir})
+	}
+	return nil
+}
+
+// addedComponent is the serialized form of a new component. Field order here
+// is the order written to the spec.
+type addedComponent struct {
+	ID   string `yaml:"id"`
+	Kind string `yaml:"kind"`
+	Spec any    `yaml:"spec"`
+}
+
+type addedUsecaseSpec struct {
+	BindsTo    string   `yaml:"binds_to"`
+	Middleware []string `yaml:"middleware,omitempty"`
+	Goal       string   `yaml:"goal"`
+	Actor      string   `yaml:"actor,omitempty"`
+}
+
+type addedMiddlewareSpec struct {
+	Provider  string   `yaml:"provider"`
+	Config    string   `yaml:"config,omitempty"`
+	Model     string   `yaml:"model,omitempty"`
+	Policy    string   `yaml:"policy,omitempty"`
+	DependsOn []string `yaml:"depends_on,omitempty"`
+}
+
+type addedPostgresSpec struct {
+	Provider string `yaml:"provider"`
+	Schema   string `yaml:"schema"`
+}
+
+type addedServerSpec struct {
+	Framework  string   `yaml:"framework"`
+	Port       int      `yaml:"port"`
+	OpenAPI    string   `yaml:"openapi,omitempty"`
+	Middleware []string `yaml:"middleware,omitempty"`
+	DependsOn  []string `yaml:"depends_on,omitempty"`
+}
+
+func newComponent(kind, name string, opts AddOptions) (*addedComponent, error) {
+	k, ok := componentKinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown component kind %q: expected usecase, middleware, postgres, or server", kind)
+	}
+
+	id := name
+	if !strings.HasPrefix(id, k.prefix) {
+		id = k.prefix + name
+	}
+	comp := &addedComponent{ID: id, Kind: k.kind}
+
+	switch kind {
+	case "usecase":
+		if opts.BindsTo == "" {
+			return nil, fmt.Errorf("usecase requires --binds-to")
+		}
+		goal := opts.Goal
+		if goal == "" {
+			goal = humanize(strings.TrimPrefix(id, k.prefix))
+		}
+		comp.Spec = addedUsecaseSpec{
+			BindsTo:    opts.BindsTo,
+			Middleware: opts.Middleware,
+			Goal:       goal,
+			Actor:      opts.Actor,
+		}
+	case "middleware":
+		if opts.Provider == "" {
+			return nil, fmt.Errorf("middleware requires --provider")
+		}
+		comp.Spec = addedMiddlewareSpec{
+			Provider:  opts.Provider,
+			Config:    opts.Config,
+			Model:     opts.Model,
+			Policy:    opts.Policy,
+			DependsOn: opts.DependsOn,
+		}
+	case "postgres":
+		if opts.Schema == "" {
+			return nil, fmt.Errorf("postgres requires --schema")
+		}
+		provider := opts.Provider
+		if provider == "" {
+			provider = "drizzle"
+		}
+		comp.Spec = addedPostgresSpec{Provider: provider, Schema: opts.Schema}
+	case "server":
+		framework := opts.Framework
+		if framework == "" {
+			framework = "hono"
+		}
+		port := opts.Port
+		if port == 0 {
+			port = 3000
+		}
+		comp.Spec = addedServerSpec{
+			Framework:  framework,
+			Port:       port,
+			OpenAPI:    opts.OpenAPI,
+			Middleware: opts.Middleware,
+			DependsOn:  opts.DependsOn,
+		}
+	}
+
+	return comp, nil
+}
+
+// humanize turns a kebab-case name into a sentence ("create-order" -> "Create order").
+func humanize(name string) string {
+	s := strings.ReplaceAll(name, "-", " ")
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+var (
+	componentsKeyPattern   = regexp.MustCompile(`^components:\s*(#.*)?$`)
+	componentsEmptyPattern = regexp.MustCompile(`^components:\s*\[\s*\]\s*(#.*)?$`)
+)
+
+// appendComponent appends comp to the components list of a spec by editing the
+// text directly, so comments and formatting elsewhere in the file are preserved.
+func appendComponent(data []byte, comp *addedComponent) ([]byte, error) {
+	item, err := encodeComponent(comp)
+	if err != nil {
+		return nil, err
+	}
+
+	text := string(data)
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if text == "" {
+		lines = nil
+	}
+
+	keyLine := -1
+	for i, line := range lines {
+		if componentsEmptyPattern.MatchString(line) {
+			lines[i] = "components:"
+			keyLine = i
+			break
+		}
+		if componentsKeyPattern.MatchString(line) {
+			keyLine = i
+			break
+		}
+	}
+
+	// No components key yet: start a new list at the end of the file.
+	if keyLine == -1 {
+		if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "components:")
+		lines = append(lines, indentLines(item, "  ")...)
+		return []byte(strings.Join(lines, "\n") + "\n"), nil
+	}
+
+	// The list runs until the next top-level key.
+	end := len(lines)
+	for i := keyLine + 1; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		end = i
+		break
+	}
+
+	indent := "  "
+	items := 0
+	separated := false
+	last := keyLine
+	for i := keyLine + 1; i < end; i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			if items == 0 {
+				indent = line[:len(line)-len(strings.TrimLeft(line, " "))]
+			} else if strings.TrimSpace(lines[i-1]) == "" {
+				separated = true
+			}
+			items++
+		}
+		// Comments at column 0 belong to whatever follows the list.
+		if strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(line, " ") {
+			continue
+		}
+		last = i
+	}
+
+	var insert []string
+	if separated {
+		insert = append(insert, "")
+	}
+	insert = append(insert, indentLines(item, indent)...)
+
+	out := make([]string, 0, len(lines)+len(insert))
+	out = append(out, lines[:last+1]...)
+	out = append(out, insert...)
+	out = append(out, lines[last+1:]...)
+	return []byte(strings.Join(out, "\n") + "\n"), nil
+}
+
+// encodeComponent renders comp as a single YAML sequence item.
+func encodeComponent(comp *addedComponent) (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode([]*addedComponent{comp}); err != nil {
+		return "", fmt.Errorf("failed to encode component: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode component: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func indentLines(s, indent string) []string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = indent + line
+	}
+	return lines
+}
+
+// checkSpec parses and schema-validates the edited spec before it is written.
+func checkSpec(filename string, data []byte, id string) error {
+	spec, err := checkSpecBytes(filename, data, fmt.Sprintf("component %q", id))
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, comp := range spec.Components {
+		if comp.ID == id {
+			count++
+		}
+	}
+	if count > 1 {
+		return fmt.Errorf("component %q already exists", id)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const addTestSpec = `# Orders service
+version: "0.1.0"
+name: orders
+
+components:
+  # The public API
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 3000
+
+  - id: usecase.list-orders
+    kind: usecase
+    spec:
+      binds_to: http.server.api:GET:/orders
+      goal: List orders # shown in docs
+`
+
+func writeSpec(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestAdd_Usecase(t *testing.T) {
+	path := writeSpec(t, addTestSpec)
+
+	err := Add(context.Background(), "usecase", "create-order", AddOptions{
+		SpecFile: path,
+		BindsTo:  "http.server.api:POST:/orders",
+	})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	expected := addTestSpec + `
+  - id: usecase.create-order
+    kind: usecase
+    spec:
+      binds_to: http.server.api:POST:/orders
+      goal: Create order
+`
+	assert.Equal(t, expected, string(content))
+}
+
+func TestAdd_PreservesFollowingKeys(t *testing.T) {
+	spec := `version: "0.1.0"
+name: orders
+components:
+- id: http.server.api
+  kind: http.server
+  spec:
+    framework: hono
+    port: 3000
+# trailing section
+description: Orders API
+`
+	path := writeSpec(t, spec)
+
+	err := Add(context.Background(), "postgres", "primary", AddOptions{SpecFile: path, Schema: "./schema.ts"})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	expected := `version: "0.1.0"
+name: orders
+components:
+- id: http.server.api
+  kind: http.server
+  spec:
+    framework: hono
+    port: 3000
+- id: postgres.primary
+  kind: postgres
+  spec:
+    provider: drizzle
+    schema: ./schema.ts
+# trailing section
+description: Orders API
+`
+	assert.Equal(t, expected, string(content))
+}
+
+func TestAdd_EmptyComponents(t *testing.T) {
+	path := writeSpec(t, "version: \"0.1.0\"\nname: orders\ncomponents: []\n")
+
+	err := Add(context.Background(), "server", "api", AddOptions{SpecFile: path, Framework: "hono", Port: 8080})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `version: "0.1.0"
+name: orders
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 8080
+`, string(content))
+}
+
+func TestAdd_NoComponentsKey(t *testing.T) {
+	path := writeSpec(t, "version: \"0.1.0\"\nname: orders\n")
+
+	err := Add(context.Background(), "middleware", "authn", AddOptions{
+		SpecFile: path,
+		Provider: "better-auth",
+		Config:   "./auth.config.ts",
+	})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "\ncomponents:\n  - id: middleware.authn\n")
+}
+
+func TestAdd_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		id      string
+		opts    AddOptions
+		wantErr string
+	}{
+		{"unknown kind", "queue", "jobs", AddOptions{}, "unknown component kind"},
+		{"usecase without binding", "usecase", "create-order", AddOptions{}, "--binds-to"},
+		{"middleware without provider", "middleware", "authn", AddOptions{}, "--provider"},
+		{"postgres without schema", "postgres", "primary", AddOptions{}, "--schema"},
+		{"duplicate id", "usecase", "list-orders", AddOptions{BindsTo: "http.server.api:GET:/orders"}, "already exists"},
+		{"schema violation", "usecase", "create-order", AddOptions{BindsTo: "api:POST:/orders"}, "is invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeSpec(t, addTestSpec)
+			tt.opts.SpecFile = path
+
+			err := Add(context.Background(), tt.kind, tt.id, tt.opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+
+			// The spec must be left untouched on failure.
+			content, readErr := os.ReadFile(path)
+			require.NoError(t, readErr)
+			assert.Equal(t, addTestSpec, string(content))
+		})
+	}
+}
+
+func TestHumanize(t *testing.T) {
+	assert.Equal(t, "Create order", humanize("create-order"))
+	assert.Equal(t, "", humanize(""))
+}
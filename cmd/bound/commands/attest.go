@@ -0,0 +1,194 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/attest"
+	"github.com/openboundary/openboundary/internal/pipeline"
+)
+
+// AttestKeyEnv holds a PEM encoded signing key for attest sign when --key
+// is not given, for CI systems that provide secrets as variables.
+const AttestKeyEnv = "OPENBOUNDARY_ATTEST_KEY"
+
+// AttestKeygenOptions configures the attest keygen command.
+type AttestKeygenOptions struct {
+	Out string // Path of the private key; the public key gets a .pub suffix
+}
+
+// AttestSignOptions configures the attest sign command.
+type AttestSignOptions struct {
+	OutputDir   string
+	Key         string // Path of the PEM encoded private key; empty reads AttestKeyEnv
+	SpecCommit  string // Commit of the spec; empty asks git
+	Attestation string // Where to write the attestation; empty uses attest.Path in OutputDir
+}
+
+// AttestVerifyOptions configures the attest verify command.
+type AttestVerifyOptions struct {
+	OutputDir        string
+	Key              string   // Path of the PEM encoded public key
+	Attestation      string   // Attestation to verify; empty uses attest.Path in OutputDir
+	CompilerVersions []string // Approved compiler versions; empty accepts any
+	SpecCommit       string   // Required spec commit; empty accepts any
+}
+
+// AttestKeygen writes a new ed25519 key pair for signing attestations.
+func AttestKeygen(opts AttestKeygenOptions) error {
+	pubPath := opts.Out + ".pub"
+	for _, path := range []string{opts.Out, pubPath} {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", path)
+		}
+	}
+	private, public, err := attest.GenerateKey()
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+	if err := os.WriteFile(opts.Out, private, 0600); err != nil {
+		return fmt.Errorf("failed to write private key: %w", err)
+	}
+	if err := os.WriteFile(pubPath, public, 0644); err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+	fmt.Printf("✓ Wrote private key %s and public key %s\n", opts.Out, pubPath)
+	return nil
+}
+
+// AttestSign signs the output of the last compile into the output
+// directory: the compiler version, the spec and its commit, and the hash of
+// every generated file. It refuses when the spec or the output changed since.
+func AttestSign(opts AttestSignOptions) error {
+	keyPEM, err := readSigningKey(opts.Key)
+	if err != nil {
+		return err
+	}
+	key, err := attest.ParsePrivateKey(keyPEM)
+	if err != nil {
+		return err
+	}
+	report, err := pipeline.LoadReport(opts.OutputDir)
+	if err != nil {
+		return fmt.Errorf("%w (run bound compile first)", err)
+	}
+	if err := attest.CheckSpec(report); err != nil {
+		return err
+	}
+
+	commit := opts.SpecCommit
+	if commit == "" {
+		commit = specCommit(report.Spec.Path)
+	}
+	st, err := attest.NewStatement(opts.OutputDir, report, commit)
+	if err != nil {
+		return err
+	}
+	env, err := attest.Sign(st, key)
+	if err != nil {
+		return err
+	}
+	path := attestationPath(opts.OutputDir, opts.Attestation)
+	if err := env.Write(path); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Attested %d file(s) generated by bound %s", len(st.Files), st.Compiler.Version)
+	if commit != "" {
+		fmt.Printf(" from spec commit %s", commit)
+	}
+	fmt.Printf("\n  %s\n", path)
+	return nil
+}
+
+// AttestVerify checks an attestation of the output directory: its
+// signature, that every attested file is unchanged, and that it was
+// produced by an approved compiler version from the expected spec commit.
+func AttestVerify(opts AttestVerifyOptions) error {
+	if opts.Key == "" {
+		return errors.New("--key is required")
+	}
+	keyPEM, err := os.ReadFile(opts.Key)
+	if err != nil {
+		return fmt.Errorf("failed to read public key: %w", err)
+	}
+	key, err := attest.ParsePublicKey(keyPEM)
+	if err != nil {
+		return err
+	}
+	env, err := attest.Load(attestationPath(opts.OutputDir, opts.Attestation))
+	if err != nil {
+		return err
+	}
+	st, err := attest.Verify(env, key)
+	if err != nil {
+		return err
+	}
+
+	if len(opts.CompilerVersions) > 0 && !slices.Contains(opts.CompilerVersions, st.Compiler.Version) {
+		return fmt.Errorf("output was generated by bound %s, which is not an approved version (%s)", st.Compiler.Version, strings.Join(opts.CompilerVersions, ", "))
+	}
+	if opts.SpecCommit != "" && st.Spec.Commit != opts.SpecCommit {
+		return fmt.Errorf("output was generated from spec commit %q, expected %q", st.Spec.Commit, opts.SpecCommit)
+	}
+	if changed := st.CheckFiles(opts.OutputDir); len(changed) > 0 {
+		for _, c := range changed {
+			fmt.Printf("✗ %s\n", c)
+		}
+		return fmt.Errorf("%d of %d attested file(s) do not match", len(changed), len(st.Files))
+	}
+
+	fmt.Printf("✓ %d file(s) match the attestation of bound %s", len(st.Files), st.Compiler.Version)
+	if st.Spec.Commit != "" {
+		fmt.Printf(" from spec commit %s", st.Spec.Commit)
+	}
+	fmt.Println()
+	return nil
+}
+
+// readSigningKey returns the PEM encoded private key at path, or the one in
+// AttestKeyEnv when path is empty.
+func readSigningKey(path string) ([]byte, error) {
+	if path == "" {
+		if key := os.Getenv(AttestKeyEnv); key != "" {
+			return []byte(key), nil
+		}
+		return nil, fmt.Errorf("pass --key or set %s", AttestKeyEnv)
+	}
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+	return key, nil
+}
+
+func attestationPath(outputDir, path string) string {
+	if path != "" {
+		return path
+	}
+	return filepath.Join(outputDir, attest.Path)
+}
+
+// specCommit returns the git commit checked out where the spec lives, or ""
+// when git is unavailable, the spec is not in a repository, or it has
+// uncommitted changes, so the commit would not describe it.
+func specCommit(specPath string) string {
+	dir := filepath.Dir(specPath)
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	status, err := exec.Command("git", "-C", dir, "status", "--porcelain", "--", filepath.Base(specPath)).Output()
+	if err != nil || len(strings.TrimSpace(string(status))) > 0 {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/attest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// attestedOutput compiles the add test spec, signs the output and returns
+// the output directory and the path of the public key.
+func attestedOutput(t *testing.T) (out, pubKey string) {
+	t.Helper()
+	out = t.TempDir()
+	require.NoError(t, Compile(context.Background(), writeSpec(t, addTestSpec), CompileOptions{
+		OutputDir: out, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON},
+	}))
+	key := filepath.Join(t.TempDir(), "attest.key")
+	require.NoError(t, AttestKeygen(AttestKeygenOptions{Out: key}))
+	require.NoError(t, AttestSign(AttestSignOptions{OutputDir: out, Key: key, SpecCommit: "abc123"}))
+	return out, key + ".pub"
+}
+
+func TestAttest_SignVerify(t *testing.T) {
+	// given
+	out, pub := attestedOutput(t)
+
+	// when
+	err := AttestVerify(AttestVerifyOptions{OutputDir: out, Key: pub, CompilerVersions: []string{Version}, SpecCommit: "abc123"})
+
+	// then
+	require.NoError(t, err)
+	env, err := attest.Load(filepath.Join(out, attest.Path))
+	require.NoError(t, err)
+	require.Len(t, env.Signatures, 1)
+	assert.Equal(t, attest.PayloadType, env.PayloadType)
+}
+
+func TestAttest_VerifyRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(t *testing.T, out string, opts *AttestVerifyOptions)
+		wantErr string
+	}{
+		{
+			name: "unapproved compiler version",
+			change: func(_ *testing.T, _ string, opts *AttestVerifyOptions) {
+				opts.CompilerVersions = []string{"9.9.9"}
+			},
+			wantErr: "not an approved version (9.9.9)",
+		},
+		{
+			name: "other spec commit",
+			change: func(_ *testing.T, _ string, opts *AttestVerifyOptions) {
+				opts.SpecCommit = "def456"
+			},
+			wantErr: `spec commit "abc123", expected "def456"`,
+		},
+		{
+			name: "modified file",
+			change: func(t *testing.T, out string, _ *AttestVerifyOptions) {
+				require.NoError(t, os.WriteFile(filepath.Join(out, "README.md"), []byte("# changed\n"), 0644))
+			},
+			wantErr: "1 of",
+		},
+		{
+			name: "added file",
+			change: func(t *testing.T, out string, _ *AttestVerifyOptions) {
+				require.NoError(t, os.WriteFile(filepath.Join(out, "extra.ts"), []byte("export {};\n"), 0644))
+			},
+			wantErr: "1 of",
+		},
+		{
+			name: "other key",
+			change: func(t *testing.T, _ string, opts *AttestVerifyOptions) {
+				other := filepath.Join(t.TempDir(), "other.key")
+				require.NoError(t, AttestKeygen(AttestKeygenOptions{Out: other}))
+				opts.Key = other + ".pub"
+			},
+			wantErr: "no valid signature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			out, pub := attestedOutput(t)
+			opts := AttestVerifyOptions{OutputDir: out, Key: pub}
+			tt.change(t, out, &opts)
+
+			// when
+			err := AttestVerify(opts)
+
+			// then
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAttestSign_KeyFromEnv(t *testing.T) {
+	// given
+	out := t.TempDir()
+	require.NoError(t, Compile(context.Background(), writeSpec(t, addTestSpec), CompileOptions{
+		OutputDir: out, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON},
+	}))
+	private, _, err := attest.GenerateKey()
+	require.NoError(t, err)
+	t.Setenv(AttestKeyEnv, string(private))
+
+	// when
+	err = AttestSign(AttestSignOptions{OutputDir: out})
+
+	// then
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(out, attest.Path))
+}
+
+func TestAttestSign_Errors(t *testing.T) {
+	t.Setenv(AttestKeyEnv, "")
+	assert.ErrorContains(t, AttestSign(AttestSignOptions{OutputDir: t.TempDir()}), "pass --key or set "+AttestKeyEnv)
+
+	key := filepath.Join(t.TempDir(), "attest.key")
+	require.NoError(t, AttestKeygen(AttestKeygenOptions{Out: key}))
+	assert.ErrorContains(t, AttestSign(AttestSignOptions{OutputDir: t.TempDir(), Key: key}), "run bound compile first")
+	assert.ErrorContains(t, AttestKeygen(AttestKeygenOptions{Out: key}), "already exists")
+}
+
+func TestAttestSign_SpecChanged(t *testing.T) {
+	// given
+	out := t.TempDir()
+	spec := writeSpec(t, addTestSpec)
+	require.NoError(t, Compile(context.Background(), spec, CompileOptions{
+		OutputDir: out, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON},
+	}))
+	require.NoError(t, os.WriteFile(spec, []byte(addTestSpec+"# edited\n"), 0644))
+	key := filepath.Join(t.TempDir(), "attest.key")
+	require.NoError(t, AttestKeygen(AttestKeygenOptions{Out: key}))
+
+	// when
+	err := AttestSign(AttestSignOptions{OutputDir: out, Key: key})
+
+	// then
+	assert.ErrorContains(t, err, "changed since the last compile")
+	assert.NoFileExists(t, filepath.Join(out, attest.Path))
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen/typescript"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/pipeline"
+)
+
+// CheckImplOptions configures the check-impl command.
+type CheckImplOptions struct {
+	OutputDir string
+	Layout    string // Component file layout used when the code was compiled
+}
+
+// implDrift is a usecase file whose signature no longer matches the spec.
+type implDrift struct {
+	Path        string
+	ComponentID string
+	Problems    []string
+}
+
+// CheckImpl compares the usecase implementations in the output directory
+// with the signatures the spec now generates: each file must still export
+// the usecase's function with the generated parameter and return types.
+// Drift is reported per file, so a spec change shows up here instead of as
+// type errors in the implementation.
+func CheckImpl(ctx context.Context, specFile string, opts CheckImplOptions) error {
+	layout, err := layoutFor(CompileOptions{Layout: opts.Layout})
+	if err != nil {
+		return err
+	}
+	stages := []pipeline.Stage{
+		pipeline.Parse(),
+		pipeline.ValidateSchema(),
+		pipeline.BuildIR(),
+		pipeline.ValidateIR(),
+		pipeline.Generate(typescript.NewPluginRegistry),
+	}
+	if layout != nil {
+		stages = append(stages, layout)
+	}
+	pc := &pipeline.Context{SpecPath: specFile, OutputDir: opts.OutputDir, Ctx: ctx}
+	if err := pipeline.New(stages...).Run(pc); err != nil {
+		reportDiagnostics(pc, err, defaultDiagnostics)
+		return err
+	}
+
+	checked := 0
+	var missing []string
+	var drifts []implDrift
+	for _, artifact := range pc.Artifacts {
+		comp := pc.IR.Components[artifact.ComponentID]
+		if comp == nil || comp.Kind != ir.KindUsecase || !strings.HasSuffix(artifact.Path, ".usecase.ts") {
+			continue
+		}
+		current, err := os.ReadFile(filepath.Join(opts.OutputDir, artifact.Path))
+		if errors.Is(err, os.ErrNotExist) {
+			missing = append(missing, artifact.Path)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", artifact.Path, err)
+		}
+		checked++
+		if problems := signatureDrift(typescript.UsecaseFunctionName(comp), artifact.Content, current); len(problems) > 0 {
+			drifts = append(drifts, implDrift{Path: artifact.Path, ComponentID: comp.ID, Problems: problems})
+		}
+	}
+	sort.Strings(missing)
+	sort.Slice(drifts, func(a, b int) bool { return drifts[a].Path < drifts[b].Path })
+
+	for _, path := range missing {
+		fmt.Printf("  - %s not generated yet (run bound compile)\n", path)
+	}
+	for _, d := range drifts {
+		fmt.Printf("✗ %s (%s)\n", d.Path, d.ComponentID)
+		for _, p := range d.Problems {
+			fmt.Printf("    %s\n", p)
+		}
+	}
+	if len(drifts) > 0 {
+		return fmt.Errorf("%d of %d usecase implementation(s) no longer match the spec", len(drifts), checked)
+	}
+	fmt.Printf("✓ %d usecase implementation(s) match the spec\n", checked)
+	return nil
+}
+
+// signatureDrift compares the function name exports in current with its
+// declaration in generated.
+func signatureDrift(name string, generated, current []byte) []string {
+	var want *typescript.FunctionSignature
+	for _, sig := range typescript.ExportedFunctions(generated) {
+		if sig.Name == name {
+			want = &sig
+			break
+		}
+	}
+	if want == nil {
+		return nil
+	}
+
+	var got *typescript.FunctionSignature
+	var exported []string
+	for _, sig := range typescript.ExportedFunctions(current) {
+		if sig.Name == name {
+			got = &sig
+			break
+		}
+		exported = append(exported, sig.Name)
+	}
+	if got == nil {
+		if len(exported) == 0 {
+			return []string{fmt.Sprintf("does not export %s", name)}
+		}
+		return []string{fmt.Sprintf("does not export %s (exports %s)", name, strings.Join(exported, ", "))}
+	}
+
+	var problems []string
+	if len(got.Params) != len(want.Params) {
+		problems = append(problems, fmt.Sprintf("takes %d parameter(s), the spec now generates %d", len(got.Params), len(want.Params)))
+	}
+	for n := 0; n < len(got.Params) && n < len(want.Params); n++ {
+		if got.Params[n].Type != want.Params[n].Type {
+			problems = append(problems, fmt.Sprintf("parameter %s is %s, the spec now generates %s", got.Params[n].Name, typeOrUntyped(got.Params[n].Type), want.Params[n].Type))
+		}
+	}
+	if got.Returns != want.Returns {
+		problems = append(problems, fmt.Sprintf("returns %s, the spec now generates %s", typeOrUntyped(got.Returns), want.Returns))
+	}
+	return problems
+}
+
+func typeOrUntyped(t string) string {
+	if t == "" {
+		return "untyped"
+	}
+	return t
+}
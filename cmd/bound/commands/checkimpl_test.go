@@ -0,0 +1,74 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckImpl(t *testing.T) {
+	// given
+	path := writeSpec(t, addTestSpec)
+	out := t.TempDir()
+	require.NoError(t, Compile(context.Background(), path, CompileOptions{OutputDir: out, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON}}))
+	require.NoError(t, CheckImpl(context.Background(), path, CheckImplOptions{OutputDir: out}))
+
+	// when the route gains a path parameter, the usecase's input type changes
+	drifted := strings.Replace(addTestSpec, "GET:/orders", "GET:/orders/{id}", 1)
+	require.NoError(t, os.WriteFile(path, []byte(drifted), 0644))
+	err := CheckImpl(context.Background(), path, CheckImplOptions{OutputDir: out})
+
+	// then
+	assert.EqualError(t, err, "1 of 1 usecase implementation(s) no longer match the spec")
+}
+
+func TestSignatureDrift(t *testing.T) {
+	generated := `export async function listOrdersUsecase(
+  input: ListOrdersUsecaseInput,
+  ctx: ListOrdersUsecaseContext
+): Promise<ListOrdersResponse> {
+  throw new Error('Not implemented');
+}
+`
+	tests := []struct {
+		name    string
+		current string
+		want    []string
+	}{
+		{
+			name:    "reformatted and renamed parameters",
+			current: "export async function listOrdersUsecase(query: ListOrdersUsecaseInput, context: ListOrdersUsecaseContext): Promise<ListOrdersResponse> {\n  return { orders: [] };\n}\n",
+		},
+		{
+			name:    "renamed function",
+			current: "export async function listOrders(input: ListOrdersUsecaseInput, ctx: ListOrdersUsecaseContext): Promise<ListOrdersResponse> {}\n",
+			want:    []string{"does not export listOrdersUsecase (exports listOrders)"},
+		},
+		{
+			name:    "changed types",
+			current: "export async function listOrdersUsecase(input: ListOrdersRequest, ctx): Promise<Order[]> {}\n",
+			want: []string{
+				"parameter input is ListOrdersRequest, the spec now generates ListOrdersUsecaseInput",
+				"parameter ctx is untyped, the spec now generates ListOrdersUsecaseContext",
+				"returns Promise<Order[]>, the spec now generates Promise<ListOrdersResponse>",
+			},
+		},
+		{
+			name:    "missing parameter",
+			current: "export async function listOrdersUsecase(input: ListOrdersUsecaseInput): Promise<ListOrdersResponse> {}\n",
+			want:    []string{"takes 1 parameter(s), the spec now generates 2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, signatureDrift("listOrdersUsecase", []byte(generated), []byte(tt.current)))
+		})
+	}
+}
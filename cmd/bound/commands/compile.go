@@ -4,44 +4,296 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/codegen/golang"
+	"github.com/openboundary/openboundary/internal/codegen/python"
 	"github.com/openboundary/openboundary/internal/codegen/typescript"
 	"github.com/openboundary/openboundary/internal/pipeline"
 )
 
-func Compile(specFile string, outputDir string) error {
-	p := pipeline.New(
+// Version is the compiler version stamped into generated files. main sets
+// it to the version of the bound binary.
+var Version = "dev"
+
+// CompileOptions configures the compile command.
+type CompileOptions struct {
+	OutputDir    string
+	Target       string   // Code generation target: "typescript" (default) or "python"
+	GoClient     bool     // Also emit a typed Go client package per http.server
+	Layout       string   // Component file layout: "flat" (default) or "component"
+	Module       string   // Module format of the package: "esm" (default), "cjs" or "dual"
+	Workspace    bool     // Emit the output as a package of the enclosing pnpm or npm workspace
+	Only         []string // Selectors restricting the written files, e.g. "kind=usecase"
+	History      int      // Compiles kept for diff and rollback; 0 keeps none
+	Touch        bool     // Update the modification time of unchanged files
+	Verify       bool     // Check that the written TypeScript files parse
+	Timestamp    bool     // Record the generation time in stamped files; off for reproducible output
+	MaxArtifacts int      // Fail instead of writing more files than this; 0 is unlimited
+	NoCache      bool     // Run every generator instead of reusing the output of unchanged ones
+	DiagnosticOptions
+}
+
+func Compile(ctx context.Context, specFile string, opts CompileOptions) error {
+	if err := validateFormat(opts.DiagnosticOptions); err != nil {
+		return err
+	}
+	newRegistry, err := pluginRegistryFor(opts)
+	if err != nil {
+		return err
+	}
+	layout, err := layoutFor(opts)
+	if err != nil {
+		return err
+	}
+	moduleFormat, err := moduleFormatFor(opts)
+	if err != nil {
+		return err
+	}
+	workspace, err := workspaceFor(opts)
+	if err != nil {
+		return err
+	}
+	verify, err := verifierFor(opts)
+	if err != nil {
+		return err
+	}
+	selectors := make([]pipeline.Selector, 0, len(opts.Only))
+	for _, only := range opts.Only {
+		sel, err := pipeline.ParseSelector(only)
+		if err != nil {
+			return err
+		}
+		selectors = append(selectors, sel)
+	}
+
+	stages := []pipeline.Stage{
 		pipeline.Parse(),
 		pipeline.ValidateSchema(),
 		pipeline.BuildIR(),
 		pipeline.ValidateIR(),
-		pipeline.Generate(typescript.NewPluginRegistry),
-		pipeline.Write(),
-	)
+		pipeline.CheckTarget(opts.Target),
+		generateFor(opts, newRegistry),
+		stamperFor(opts),
+	}
+	if len(selectors) > 0 {
+		stages = append(stages, pipeline.Select(selectors...))
+	}
+	stages = append(stages, pipeline.ScanSecrets(), pipeline.RecordADR())
+	if layout != nil {
+		stages = append(stages, layout)
+	}
+	if moduleFormat != nil {
+		stages = append(stages, moduleFormat)
+	}
+	if workspace != nil {
+		stages = append(stages, pipeline.Workspace(workspace.Package))
+	}
+	if merged := mergedFilesFor(opts); len(merged) > 0 {
+		stages = append(stages, pipeline.Merge(merged...))
+	}
+	budget := pipeline.DefaultBudget()
+	budget.MaxArtifacts = opts.MaxArtifacts
+	stages = append(stages, pipeline.CheckEngines(), pipeline.CheckBudget(budget), pipeline.Write(), pipeline.RecordPorts())
+	if verify != nil {
+		stages = append(stages, verify)
+	}
+	p := pipeline.New(stages...)
 
-	ctx := &pipeline.Context{
+	pc := &pipeline.Context{
 		SpecPath:  specFile,
-		OutputDir: outputDir,
+		OutputDir: opts.OutputDir,
+		Quiet:     opts.Format == FormatJSON,
+		Touch:     opts.Touch,
+		Ctx:       ctx,
 	}
 
-	if err := p.Run(ctx); err != nil {
-		printStageError(err)
+	err = p.Run(pc)
+	reportDiagnostics(pc, err, opts.DiagnosticOptions)
+
+	// A cancelled compile restores the output, so it leaves no report either
+	var report *pipeline.Report
+	if !errors.Is(err, pipeline.ErrCancelled) {
+		report = pipeline.NewReport(pc, err, messageLanguage, reportOptions(opts))
+		report.Compiler = Version
+		if reportErr := report.Write(opts.OutputDir); reportErr != nil && err == nil {
+			return pipeline.WithStage(pipeline.StageWrite, reportErr)
+		}
+	}
+	if err != nil {
 		return err
 	}
+	if err := pipeline.RecordHistory(opts.OutputDir, report, pc.Artifacts, opts.History); err != nil {
+		return pipeline.WithStage(pipeline.StageWrite, err)
+	}
+	if workspace != nil {
+		registered, err := workspace.Register()
+		if err != nil {
+			return pipeline.WithStage(pipeline.StageWrite, fmt.Errorf("failed to register %s with the workspace at %s: %w", workspace.Dir, workspace.Root, err))
+		}
+		if registered != "" && !pc.Quiet {
+			fmt.Printf("  → %s (added %s to the %s workspace)\n", filepath.Join(workspace.Root, registered), workspace.Dir, workspace.Manager)
+		}
+	}
+
+	if !pc.Quiet {
+		fmt.Printf("\n✓ Generated %d files in %s/ (%d written, %d unchanged)\n", len(pc.Artifacts), opts.OutputDir, report.Written, report.Skipped)
+		if len(pc.Cached) > 0 {
+			fmt.Printf("  %d of %d generators reused cached output\n", len(pc.Cached), len(pc.Generators))
+		}
+	}
+	return nil
+}
+
+// reportOptions returns the options recorded in the compile report.
+func reportOptions(opts CompileOptions) map[string]string {
+	target, layout := opts.Target, opts.Layout
+	if target == "" {
+		target = "typescript"
+	}
+	if layout == "" {
+		layout = typescript.LayoutFlat
+	}
+	options := map[string]string{
+		"target":    target,
+		"layout":    layout,
+		"go_client": strconv.FormatBool(opts.GoClient),
+	}
+	if opts.Timestamp {
+		options["timestamp"] = "true"
+	}
+	if opts.Module != "" && opts.Module != typescript.ModuleESM {
+		options["module"] = opts.Module
+	}
+	if opts.Workspace {
+		options["workspace"] = "true"
+	}
+	if len(opts.Only) > 0 {
+		options["only"] = strings.Join(opts.Only, " ")
+	}
+	return options
+}
+
+// pluginRegistryFor returns the registry constructor for the selected outputs.
+func pluginRegistryFor(opts CompileOptions) (func() (*codegen.PluginRegistry, error), error) {
+	var base func() (*codegen.PluginRegistry, error)
+	switch opts.Target {
+	case "", "typescript":
+		base = typescript.NewPluginRegistry
+	case "python":
+		base = python.NewPluginRegistry
+	default:
+		return nil, fmt.Errorf("unknown target %q (expected typescript or python)", opts.Target)
+	}
+
+	if !opts.GoClient {
+		return base, nil
+	}
+	return func() (*codegen.PluginRegistry, error) {
+		registry, err := base()
+		if err != nil {
+			return nil, err
+		}
+		if err := registry.Register(golang.ClientPlugin()); err != nil {
+			return nil, err
+		}
+		return registry, nil
+	}, nil
+}
+
+// generateFor returns the generate stage. Generators whose inputs did not
+// change reuse their cached output, except in development builds of bound,
+// whose generators can change without the version changing.
+func generateFor(opts CompileOptions, newRegistry func() (*codegen.PluginRegistry, error)) pipeline.Stage {
+	if opts.NoCache || Version == "dev" {
+		return pipeline.Generate(newRegistry)
+	}
+	return pipeline.GenerateCached(newRegistry, Version)
+}
 
-	fmt.Printf("\n✓ Generated %d files in %s/\n", len(ctx.Artifacts), outputDir)
+// stamperFor returns the stage that stamps the generated files with the
+// compiler version and spec hash, adding the target's build info file.
+func stamperFor(opts CompileOptions) pipeline.Stage {
+	var now func() time.Time
+	if opts.Timestamp {
+		now = time.Now
+	}
+	buildInfoFile := typescript.BuildInfoFile
+	if opts.Target == "python" {
+		buildInfoFile = python.BuildInfoFile
+	}
+	return pipeline.Stamp(Version, now, buildInfoFile)
+}
+
+// mergedFilesFor returns the generated files of the selected target that
+// users may edit, which compile merges instead of overwriting.
+func mergedFilesFor(opts CompileOptions) []string {
+	if opts.Target == "" || opts.Target == "typescript" {
+		return typescript.MergedFiles
+	}
 	return nil
 }
 
-func printStageError(err error) {
-	var stageErr *pipeline.StageError
-	if errors.As(err, &stageErr) {
-		fmt.Fprintf(os.Stderr, "%s with %d error(s):\n", stageErr.Message, len(stageErr.Errors))
-		for _, e := range stageErr.Errors {
-			fmt.Fprintf(os.Stderr, "  - %s\n", e.Error())
+// moduleFormatFor returns the stage that adapts the output to the selected
+// module format, or nil for ES modules, which the generators emit.
+func moduleFormatFor(opts CompileOptions) (pipeline.Stage, error) {
+	adapt, err := typescript.ModuleFormat(opts.Module)
+	if err != nil || adapt == nil {
+		return nil, err
+	}
+	if opts.Target != "" && opts.Target != "typescript" {
+		return nil, fmt.Errorf("--module is only supported for the typescript target")
+	}
+	return pipeline.ModuleFormat(adapt), nil
+}
+
+// workspaceFor returns the workspace enclosing the output directory when
+// the output is a workspace package, or nil otherwise.
+func workspaceFor(opts CompileOptions) (*typescript.Workspace, error) {
+	if !opts.Workspace {
+		return nil, nil
+	}
+	if opts.Target != "" && opts.Target != "typescript" {
+		return nil, fmt.Errorf("--workspace is only supported for the typescript target")
+	}
+	return typescript.FindWorkspace(opts.OutputDir)
+}
+
+// verifierFor returns the stage that checks the written files, or nil when
+// verification is off.
+func verifierFor(opts CompileOptions) (pipeline.Stage, error) {
+	if !opts.Verify {
+		return nil, nil
+	}
+	if opts.Target != "" && opts.Target != "typescript" {
+		return nil, fmt.Errorf("--verify is only supported for the typescript target")
+	}
+	checker, err := typescript.NewSyntaxChecker(opts.OutputDir)
+	if err != nil {
+		return nil, fmt.Errorf("--verify needs esbuild: run npm install in %s or put esbuild on PATH (%w)", opts.OutputDir, err)
+	}
+	return pipeline.Verify(checker), nil
+}
+
+// layoutFor returns the stage that rearranges artifacts for the selected
+// layout, or nil when the generators' own (flat) layout is kept.
+func layoutFor(opts CompileOptions) (pipeline.Stage, error) {
+	switch opts.Layout {
+	case "", typescript.LayoutFlat:
+		return nil, nil
+	case typescript.LayoutComponent:
+		if opts.Target != "" && opts.Target != "typescript" {
+			return nil, fmt.Errorf("layout %q is only supported for the typescript target", opts.Layout)
 		}
+		return pipeline.Layout(typescript.Colocate), nil
+	default:
+		return nil, fmt.Errorf("unknown layout %q (expected flat or component)", opts.Layout)
 	}
 }
@@ -0,0 +1,113 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+//go:build integration
+
+package commands
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openboundary/openboundary/internal/codegen/typescript"
+	"github.com/stretchr/testify/require"
+)
+
+// The integration tests compile each example, and each feature spec under
+// testdata/features, and check that the generated project installs, type
+// checks and passes its own tests. They need node and npm with network
+// access, so they only build with the integration tag:
+//
+//	go test -tags integration ./cmd/bound/commands -run Integration -v
+
+// integrationTimeout bounds each external command, npm install included.
+const integrationTimeout = 10 * time.Minute
+
+func TestIntegration_CompileExamples(t *testing.T) {
+	if _, err := exec.LookPath("npm"); err != nil {
+		t.Skip("npm not found in PATH")
+	}
+
+	specs, err := filepath.Glob("../../../examples/*/spec.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, specs, "no examples found")
+
+	for _, spec := range specs {
+		example := filepath.Base(filepath.Dir(spec))
+		t.Run(example, func(t *testing.T) {
+			// The flat layout installs the dependencies; the component layout
+			// generates the same package.json and reuses them.
+			flat := t.TempDir()
+			compileExample(t, spec, flat, typescript.LayoutFlat)
+			runInProject(t, flat, "npm", "install", "--no-audit", "--no-fund")
+			checkProject(t, flat)
+
+			t.Run(typescript.LayoutComponent, func(t *testing.T) {
+				dir := t.TempDir()
+				compileExample(t, spec, dir, typescript.LayoutComponent)
+				require.NoError(t, os.Symlink(filepath.Join(flat, "node_modules"), filepath.Join(dir, "node_modules")))
+				checkProject(t, dir)
+			})
+		})
+	}
+}
+
+// TestIntegration_CompileFeatures runs the code generated for one feature
+// at a time. Its generated tests exercise the feature's behavior, such as
+// the 412 of a stale If-Match, a response served from the server cache or
+// fixtures that satisfy their schemas.
+func TestIntegration_CompileFeatures(t *testing.T) {
+	if _, err := exec.LookPath("npm"); err != nil {
+		t.Skip("npm not found in PATH")
+	}
+
+	specs, err := filepath.Glob("testdata/features/*/spec.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, specs, "no feature specs found")
+
+	for _, spec := range specs {
+		feature := filepath.Base(filepath.Dir(spec))
+		t.Run(feature, func(t *testing.T) {
+			dir := t.TempDir()
+			compileExample(t, spec, dir, typescript.LayoutFlat)
+			runInProject(t, dir, "npm", "install", "--no-audit", "--no-fund")
+			checkProject(t, dir)
+		})
+	}
+}
+
+// compileExample compiles spec into dir with the given layout.
+func compileExample(t *testing.T, spec, dir, layout string) {
+	t.Helper()
+	err := Compile(context.Background(), spec, CompileOptions{
+		OutputDir:         dir,
+		Layout:            layout,
+		DiagnosticOptions: defaultDiagnostics,
+	})
+	require.NoError(t, err, "compile %s", spec)
+}
+
+// checkProject type checks the generated project and runs its unit tests.
+func checkProject(t *testing.T, dir string) {
+	t.Helper()
+	runInProject(t, dir, "npx", "--no-install", "tsc", "--noEmit")
+	runInProject(t, dir, "npx", "--no-install", "vitest", "run")
+}
+
+// runInProject runs a command in dir and fails the test with its output if
+// the command fails.
+func runInProject(t *testing.T, dir, name string, args ...string) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), integrationTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "CI=1")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "%s %v in %s failed:\n%s", name, args, dir, out)
+}
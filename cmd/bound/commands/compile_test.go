@@ -0,0 +1,353 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readReport(t *testing.T, outDir string) pipeline.Report {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(outDir, pipeline.ReportPath))
+	require.NoError(t, err)
+	var report pipeline.Report
+	require.NoError(t, json.Unmarshal(data, &report))
+	return report
+}
+
+func TestCompile_WritesReport(t *testing.T) {
+	// given
+	path := writeSpec(t, addTestSpec)
+	out := t.TempDir()
+	opts := CompileOptions{OutputDir: out, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON}}
+
+	// when
+	require.NoError(t, Compile(context.Background(), path, opts))
+	first := readReport(t, out)
+	require.NoError(t, Compile(context.Background(), path, opts))
+	second := readReport(t, out)
+
+	// then
+	assert.Equal(t, "ok", first.Status)
+	assert.Equal(t, "typescript", first.Options["target"])
+	assert.NotEmpty(t, first.Generators)
+	assert.NotEmpty(t, first.Stages)
+	assert.Equal(t, pipeline.StageParse, first.Stages[0].Name)
+	assert.Positive(t, first.Written)
+	assert.Zero(t, first.Skipped)
+
+	assert.Equal(t, first.CacheKey, second.CacheKey)
+	assert.Zero(t, second.Written, "an unchanged spec rewrites nothing")
+	assert.Equal(t, first.Written, second.Skipped)
+}
+
+func TestCompile_CachesGenerators(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		noCache    bool
+		wantCached bool
+	}{
+		{name: "release", version: "0.1.0", wantCached: true},
+		{name: "no cache", version: "0.1.0", noCache: true, wantCached: false},
+		{name: "development build", version: "dev", wantCached: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			defer func(version string) { Version = version }(Version)
+			Version = tt.version
+			path := writeSpec(t, addTestSpec)
+			out := t.TempDir()
+			opts := CompileOptions{OutputDir: out, NoCache: tt.noCache, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON}}
+
+			// when
+			require.NoError(t, Compile(context.Background(), path, opts))
+			first := readReport(t, out)
+			require.NoError(t, Compile(context.Background(), path, opts))
+			second := readReport(t, out)
+
+			// then
+			for _, stat := range first.GeneratorStats {
+				assert.Equal(t, "ran", stat.Status, stat.Name)
+			}
+			require.Len(t, second.GeneratorStats, len(second.Generators))
+			for _, stat := range second.GeneratorStats {
+				assert.Equal(t, tt.wantCached, stat.Status == "cached", stat.Name)
+			}
+			assert.Zero(t, second.Written, "cached output matches the files written before")
+			assert.Equal(t, first.Written, second.Skipped)
+		})
+	}
+}
+
+func TestCompile_AutoPort(t *testing.T) {
+	// given
+	path := writeSpec(t, strings.Replace(addTestSpec, "port: 3000", "port: auto", 1))
+	out := t.TempDir()
+	opts := CompileOptions{OutputDir: out, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON}}
+
+	// when
+	require.NoError(t, Compile(context.Background(), path, opts))
+	require.NoError(t, Compile(context.Background(), path, opts))
+
+	// then
+	registry, err := os.ReadFile(filepath.Join(filepath.Dir(path), pipeline.PortRegistryFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(registry), `"spec.yaml#http.server.api": 4000`)
+	for _, file := range []string{"docker-compose.yml", "playwright.config.ts", ".env.example"} {
+		content, err := os.ReadFile(filepath.Join(out, file))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "4000", file)
+	}
+}
+
+func TestCompile_Stamp(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          CompileOptions
+		buildInfoPath string
+		generatedAt   string
+	}{
+		{"typescript", CompileOptions{}, "src/buildinfo.ts", "generatedAt: null"},
+		{"typescript with timestamp", CompileOptions{Timestamp: true}, "src/buildinfo.ts", "generatedAt: \"20"},
+		{"python", CompileOptions{Target: "python"}, "app/buildinfo.py", "\"generated_at\": None"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			path := writeSpec(t, addTestSpec)
+			opts := tt.opts
+			opts.OutputDir = t.TempDir()
+			opts.DiagnosticOptions = DiagnosticOptions{Format: FormatJSON}
+
+			// when
+			err := Compile(context.Background(), path, opts)
+
+			// then
+			require.NoError(t, err)
+			buildInfo, err := os.ReadFile(filepath.Join(opts.OutputDir, tt.buildInfoPath))
+			require.NoError(t, err)
+			assert.Contains(t, string(buildInfo), "sha256:")
+			assert.Contains(t, string(buildInfo), tt.generatedAt)
+			assert.Equal(t, tt.opts.Timestamp, readReport(t, opts.OutputDir).Options["timestamp"] == "true")
+		})
+	}
+}
+
+func TestCompile_WritesReportOnFailure(t *testing.T) {
+	// given
+	path := writeSpec(t, "components: [")
+	out := t.TempDir()
+
+	// when
+	err := Compile(context.Background(), path, CompileOptions{OutputDir: out, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON}})
+
+	// then
+	require.Error(t, err)
+	report := readReport(t, out)
+	assert.Equal(t, "failed", report.Status)
+	assert.Equal(t, pipeline.StageParse, report.FailedStage)
+	assert.NotEmpty(t, report.Diagnostics)
+	assert.Empty(t, report.Files)
+}
+
+func TestCompile_TargetRejectsMiddleware(t *testing.T) {
+	// given
+	path := writeSpec(t, addTestSpec+`
+  - id: middleware.authn
+    kind: middleware
+    spec:
+      provider: better-auth
+      config: ./auth.config.ts
+`)
+	out := t.TempDir()
+
+	// when
+	err := Compile(context.Background(), path, CompileOptions{OutputDir: out, Target: "python", DiagnosticOptions: DiagnosticOptions{Format: FormatJSON}})
+
+	// then
+	require.Error(t, err)
+	report := readReport(t, out)
+	assert.Equal(t, pipeline.StageCheckTarget, report.FailedStage)
+	require.NotEmpty(t, report.Diagnostics)
+	assert.Contains(t, report.Diagnostics[0].Message, "cannot run better-auth middleware")
+	assert.Empty(t, report.Files)
+}
+
+func TestCompile_PreservesPackageJSONEdits(t *testing.T) {
+	// given
+	path := writeSpec(t, addTestSpec)
+	out := t.TempDir()
+	opts := CompileOptions{OutputDir: out, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON}}
+	require.NoError(t, Compile(context.Background(), path, opts))
+
+	pkgPath := filepath.Join(out, "package.json")
+	var pkg map[string]any
+	data, err := os.ReadFile(pkgPath)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &pkg))
+	pkg["dependencies"].(map[string]any)["lodash"] = "^4.17.0"
+	pkg["scripts"].(map[string]any)["seed"] = "tsx scripts/seed.ts"
+	pkg["scripts"].(map[string]any)["dev"] = "tsx watch src/index.ts"
+	data, err = json.MarshalIndent(pkg, "", "  ")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(pkgPath, data, 0644))
+
+	// when
+	require.NoError(t, Compile(context.Background(), path, opts))
+
+	// then
+	data, err = os.ReadFile(pkgPath)
+	require.NoError(t, err)
+	var merged map[string]any
+	require.NoError(t, json.Unmarshal(data, &merged))
+	assert.Equal(t, "^4.17.0", merged["dependencies"].(map[string]any)["lodash"])
+	assert.Equal(t, "^4.0.0", merged["dependencies"].(map[string]any)["hono"])
+	assert.Equal(t, "tsx scripts/seed.ts", merged["scripts"].(map[string]any)["seed"])
+	assert.Equal(t, "tsx watch src/index.ts", merged["scripts"].(map[string]any)["dev"])
+	assert.Empty(t, readReport(t, out).Diagnostics, "user edits alone are not conflicts")
+}
+
+func TestCompile_InvalidPackageJSON(t *testing.T) {
+	// given
+	path := writeSpec(t, addTestSpec)
+	out := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(out, "package.json"), []byte(`{"name":`), 0644))
+
+	// when
+	err := Compile(context.Background(), path, CompileOptions{OutputDir: out, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON}})
+
+	// then
+	require.Error(t, err)
+	assert.Equal(t, ExitGeneration, ExitCode(err))
+	assert.Contains(t, err.Error(), "failed to merge package.json")
+}
+
+func TestCompile_Only(t *testing.T) {
+	spec := addTestSpec + `
+  - id: usecase.refund-order
+    kind: usecase
+    labels: [team:billing]
+    spec:
+      binds_to: http.server.api:POST:/refunds
+      goal: Refund an order
+`
+	tests := []struct {
+		name     string
+		only     []string
+		wantErr  string
+		wantExit int
+	}{
+		{name: "label", only: []string{"label=team:billing"}},
+		{name: "kind and id", only: []string{"kind=usecase", "id=usecase.refund-*"}},
+		{name: "no match", only: []string{"label=team:search"}, wantErr: "no component matches label=team:search", wantExit: ExitGeneration},
+		{name: "invalid selector", only: []string{"team=billing"}, wantErr: `unknown field "team"`, wantExit: ExitFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			path := writeSpec(t, spec)
+			out := t.TempDir()
+
+			// when
+			err := Compile(context.Background(), path, CompileOptions{
+				OutputDir:         out,
+				Only:              tt.only,
+				DiagnosticOptions: DiagnosticOptions{Format: FormatJSON},
+			})
+
+			// then
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				assert.Equal(t, tt.wantExit, ExitCode(err))
+				return
+			}
+			require.NoError(t, err)
+			report := readReport(t, out)
+			require.NotEmpty(t, report.Files)
+			for _, f := range report.Files {
+				assert.Contains(t, f.Path, "refund-order", "only files of the selected usecase are written")
+			}
+			assert.NoFileExists(t, filepath.Join(out, "package.json"))
+		})
+	}
+}
+
+func TestCompile_MaxArtifacts(t *testing.T) {
+	// given
+	path := writeSpec(t, addTestSpec)
+	out := t.TempDir()
+
+	// when
+	err := Compile(context.Background(), path, CompileOptions{
+		OutputDir:         out,
+		MaxArtifacts:      3,
+		DiagnosticOptions: DiagnosticOptions{Format: FormatJSON},
+	})
+
+	// then: nothing is written
+	require.ErrorContains(t, err, "generation exceeds the limit of 3 files")
+	assert.Equal(t, ExitGeneration, ExitCode(err))
+	assert.NoFileExists(t, filepath.Join(out, "package.json"))
+}
+
+func TestCompile_Verify(t *testing.T) {
+	tests := []struct {
+		name    string
+		esbuild string // Stand-in esbuild script installed in the output, if any
+		target  string
+		wantErr string
+	}{
+		{name: "passes", esbuild: "#!/bin/sh\nexit 0\n"},
+		{name: "broken output", esbuild: "#!/bin/sh\nprintf '✘ [ERROR] Unexpected \"}\"\\n' >&2\nexit 1\n", wantErr: "generated files failed verification"},
+		{name: "no esbuild", wantErr: "--verify needs esbuild"},
+		{name: "python target", target: "python", wantErr: "only supported for the typescript target"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if runtime.GOOS == "windows" {
+				t.Skip("uses a shell script in place of esbuild")
+			}
+			// given
+			t.Setenv("PATH", t.TempDir()) // Hide any installed esbuild
+			out := t.TempDir()
+			if tt.esbuild != "" {
+				bin := filepath.Join(out, "node_modules", ".bin")
+				require.NoError(t, os.MkdirAll(bin, 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(bin, "esbuild"), []byte(tt.esbuild), 0755))
+			}
+
+			// when
+			err := Compile(context.Background(), writeSpec(t, addTestSpec), CompileOptions{
+				OutputDir:         out,
+				Target:            tt.target,
+				Verify:            true,
+				DiagnosticOptions: DiagnosticOptions{Format: FormatJSON},
+			})
+
+			// then
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+			if tt.esbuild != "" {
+				assert.Equal(t, ExitGeneration, ExitCode(err))
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/parser"
+	"github.com/openboundary/openboundary/internal/pipeline"
+	"github.com/openboundary/openboundary/internal/validator"
+)
+
+// DefaultMaxErrors is how many diagnostics are printed before the rest are
+// summarized as "and N more".
+const DefaultMaxErrors = 20
+
+// Diagnostic output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// DiagnosticOptions configures how a command reports errors and warnings.
+type DiagnosticOptions struct {
+	MaxErrors int    // Diagnostics printed in text mode; 0 prints all
+	Format    string // "text" (default) or "json"
+}
+
+// defaultDiagnostics is used by commands without diagnostic flags.
+var defaultDiagnostics = DiagnosticOptions{MaxErrors: DefaultMaxErrors}
+
+// messageLanguage is the language diagnostics are printed in.
+var messageLanguage = validator.LanguageFromEnv(os.Getenv)
+
+// SetLanguage overrides the language diagnostics are printed in, which
+// otherwise follows OPENBOUNDARY_LANG and the locale.
+func SetLanguage(lang string) {
+	messageLanguage = lang
+}
+
+// validateFormat rejects unknown diagnostic formats before any work is done.
+func validateFormat(opts DiagnosticOptions) error {
+	switch opts.Format {
+	case "", FormatText, FormatJSON:
+		return nil
+	}
+	return fmt.Errorf("unknown format %q (expected text or json)", opts.Format)
+}
+
+// diagnosticReport is the JSON document emitted in JSON mode. It always
+// holds every diagnostic, regardless of --max-errors.
+type diagnosticReport struct {
+	Errors      int                   `json:"errors"`
+	Warnings    int                   `json:"warnings"`
+	Diagnostics []pipeline.Diagnostic `json:"diagnostics"`
+}
+
+// reportDiagnostics prints the warnings collected in ctx and the errors of
+// err, the error a pipeline run returned, if any. JSON goes to stdout and
+// text to stderr.
+func reportDiagnostics(ctx *pipeline.Context, err error, opts DiagnosticOptions) {
+	diags := pipeline.Diagnostics(ctx, err, messageLanguage)
+	if opts.Format == FormatJSON {
+		writeDiagnosticsJSON(os.Stdout, diags)
+		return
+	}
+
+	// Errors outside a stage are printed by the caller
+	var stageErr *pipeline.StageError
+	if err != nil && !errors.As(err, &stageErr) {
+		diags = pipeline.Diagnostics(ctx, nil, messageLanguage)
+	}
+	writeDiagnosticsText(os.Stderr, diags, err, opts.MaxErrors, componentLocations(ctx))
+}
+
+// checkSpecBytes parses data as the spec at path and validates it against
+// the JSON Schema with the stages compile starts with, so that commands
+// writing a spec refuse one compile would reject. what names the spec in the
+// error, which lists every problem.
+func checkSpecBytes(path string, data []byte, what string) (*parser.Spec, error) {
+	pc := &pipeline.Context{SpecPath: path, Quiet: true}
+	err := pipeline.New(pipeline.ParseBytes(data), pipeline.ValidateSchema()).Run(pc)
+	if err == nil {
+		return pc.AST, nil
+	}
+
+	var msgs []string
+	for _, d := range pipeline.Diagnostics(pc, err, messageLanguage) {
+		if d.Severity != pipeline.SeverityError {
+			continue
+		}
+		msg := d.Message
+		switch {
+		case d.Component != "":
+			msg = d.Component + ": " + msg
+		case d.Path != "":
+			msg += " (at " + d.Path + ")"
+		}
+		msgs = append(msgs, msg)
+	}
+	return nil, fmt.Errorf("%s is invalid:\n  - %s", what, strings.Join(msgs, "\n  - "))
+}
+
+// componentLocations returns where each component of the parsed spec starts.
+func componentLocations(ctx *pipeline.Context) map[string]string {
+	locations := make(map[string]string)
+	if ctx.AST == nil {
+		return locations
+	}
+	for n := range ctx.AST.Components {
+		comp := &ctx.AST.Components[n]
+		if pos := comp.Pos(); pos.Line > 0 {
+			locations[comp.ID] = fmt.Sprintf("%s:%d", pos.File, pos.Line)
+		}
+	}
+	return locations
+}
+
+func writeDiagnosticsJSON(w io.Writer, diags []pipeline.Diagnostic) {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(newDiagnosticReport(diags))
+}
+
+// newDiagnosticReport counts the errors and warnings of diags.
+func newDiagnosticReport(diags []pipeline.Diagnostic) diagnosticReport {
+	report := diagnosticReport{Diagnostics: diags}
+	if report.Diagnostics == nil {
+		report.Diagnostics = []pipeline.Diagnostic{}
+	}
+	for _, d := range diags {
+		if d.Severity == pipeline.SeverityError {
+			report.Errors++
+		} else {
+			report.Warnings++
+		}
+	}
+	return report
+}
+
+// writeDiagnosticsText prints diagnostics grouped by component, in the
+// order of each component's first diagnostic, and stops after maxErrors.
+// Group headings show where a component starts, if known.
+func writeDiagnosticsText(w io.Writer, diags []pipeline.Diagnostic, err error, maxErrors int, locations map[string]string) {
+	if len(diags) == 0 {
+		return
+	}
+
+	var stageErr *pipeline.StageError
+	if errors.As(err, &stageErr) {
+		fmt.Fprintf(w, "%s with %d error(s):\n", stageErr.Message, len(stageErr.Errors))
+	}
+
+	var order []string
+	groups := make(map[string][]pipeline.Diagnostic)
+	for _, d := range diags {
+		if _, ok := groups[d.Component]; !ok {
+			order = append(order, d.Component)
+		}
+		groups[d.Component] = append(groups[d.Component], d)
+	}
+
+	printed := 0
+	for _, component := range order {
+		if maxErrors > 0 && printed >= maxErrors {
+			break
+		}
+		heading := component
+		switch {
+		case component == "":
+			heading = "spec"
+		case locations[component] != "":
+			heading = fmt.Sprintf("%s (%s)", component, locations[component])
+		}
+		fmt.Fprintf(w, "\n%s\n", heading)
+		for _, d := range groups[component] {
+			if maxErrors > 0 && printed >= maxErrors {
+				break
+			}
+			fmt.Fprintf(w, "  %s: %s\n", d.Severity, d.Message)
+			printed++
+		}
+	}
+	if rest := len(diags) - printed; rest > 0 {
+		fmt.Fprintf(w, "\n... and %d more (use --max-errors 0 to show all)\n", rest)
+	}
+}
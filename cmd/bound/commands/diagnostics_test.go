@@ -0,0 +1,106 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func manyDiagnostics(n int) []pipeline.Diagnostic {
+	diags := make([]pipeline.Diagnostic, n)
+	for i := range diags {
+		diags[i] = pipeline.Diagnostic{Severity: pipeline.SeverityError, Component: fmt.Sprintf("usecase.u%02d", i/2), Message: fmt.Sprintf("problem %d", i)}
+	}
+	return diags
+}
+
+func TestWriteDiagnosticsText_GroupsByComponent(t *testing.T) {
+	var out bytes.Buffer
+	diags := []pipeline.Diagnostic{
+		{Severity: pipeline.SeverityError, Message: "dependency cycle: a -> b -> a"},
+		{Severity: pipeline.SeverityError, Component: "http.server.api", Message: "port must be between 1 and 65535"},
+		{Severity: pipeline.SeverityWarning, Component: "http.server.api", Message: "spec.token looks like a credential"},
+	}
+	err := &pipeline.StageError{Message: "validation failed", Errors: []error{nil, nil}}
+
+	writeDiagnosticsText(&out, diags, err, 0, map[string]string{"http.server.api": "spec.yaml:4"})
+
+	assert.Equal(t, "validation failed with 2 error(s):\n"+
+		"\nspec\n  error: dependency cycle: a -> b -> a\n"+
+		"\nhttp.server.api (spec.yaml:4)\n  error: port must be between 1 and 65535\n  warning: spec.token looks like a credential\n", out.String())
+}
+
+func TestWriteDiagnosticsText_MaxErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxErrors int
+		printed   int
+		summary   string
+	}{
+		{"capped", 3, 3, "... and 7 more (use --max-errors 0 to show all)"},
+		{"unlimited", 0, 10, ""},
+		{"above total", 50, 10, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			writeDiagnosticsText(&out, manyDiagnostics(10), nil, tt.maxErrors, nil)
+
+			assert.Equal(t, tt.printed, bytes.Count(out.Bytes(), []byte("  error: ")))
+			if tt.summary == "" {
+				assert.NotContains(t, out.String(), "more")
+			} else {
+				assert.Contains(t, out.String(), tt.summary)
+			}
+		})
+	}
+}
+
+func TestWriteDiagnosticsJSON_ListsEverything(t *testing.T) {
+	var out bytes.Buffer
+	diags := append(manyDiagnostics(30), pipeline.Diagnostic{Severity: pipeline.SeverityWarning, Message: "unused"})
+
+	writeDiagnosticsJSON(&out, diags)
+
+	var report diagnosticReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, 30, report.Errors)
+	assert.Equal(t, 1, report.Warnings)
+	assert.Len(t, report.Diagnostics, 31)
+}
+
+func TestValidate_UnknownFormat(t *testing.T) {
+	path := writeSpec(t, unusedComponentSpec)
+
+	err := Validate(context.Background(), path, ValidateOptions{DiagnosticOptions: DiagnosticOptions{Format: "xml"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown format "xml"`)
+}
+
+func TestCheckSpecBytes(t *testing.T) {
+	path := writeSpec(t, addTestSpec)
+
+	spec, err := checkSpecBytes(path, []byte(addTestSpec), "spec")
+	require.NoError(t, err)
+	assert.Len(t, spec.Components, 2)
+
+	_, err = checkSpecBytes(path, []byte("name: [orders\n"), "edited spec")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "edited spec is invalid:\n  - parse error:")
+
+	invalid := addTestSpec + "\n  - id: usecase.broken\n    kind: usecase\n    spec:\n      binds_to: api:GET:/x\n"
+	_, err = checkSpecBytes(path, []byte(invalid), "edited spec")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "edited spec is invalid:\n")
+	assert.Contains(t, err.Error(), "\n  - usecase.broken: - at '/components/2/spec': missing property 'goal'")
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/openboundary/openboundary/internal/pipeline"
+)
+
+// DiffOptions configures the diff command.
+type DiffOptions struct {
+	OutputDir string
+	Against   string // "previous" or how many compiles back to compare with
+}
+
+// diffMarks are the markers diff prints before each changed file.
+var diffMarks = map[string]string{"added": "A", "modified": "M", "removed": "D"}
+
+// Diff lists the files the latest compile into the output directory changed
+// compared with an earlier compile kept in its history.
+func Diff(opts DiffOptions) error {
+	back, err := parseAgainst(opts.Against)
+	if err != nil {
+		return err
+	}
+	entries, err := pipeline.LoadHistory(opts.OutputDir)
+	if err != nil {
+		return err
+	}
+	if len(entries) <= back {
+		return fmt.Errorf("%s/ has %d compile(s) in its history, need %d to compare with %s (see compile --history)", opts.OutputDir, len(entries), back+1, opts.Against)
+	}
+	latest, older := entries[0], entries[back]
+
+	changes := pipeline.DiffFiles(older.Files, latest.Files)
+	if len(changes) == 0 {
+		fmt.Printf("✓ Compile #%d generated the same files as #%d\n", latest.Seq, older.Seq)
+		return nil
+	}
+	fmt.Printf("Compile #%d changed %d file(s) since #%d:\n", latest.Seq, len(changes), older.Seq)
+	for _, c := range changes {
+		fmt.Printf("  %s %s\n", diffMarks[c.Status], c.Path)
+	}
+	return nil
+}
+
+// parseAgainst returns how many compiles back --against refers to.
+func parseAgainst(against string) (int, error) {
+	if against == "" || against == "previous" {
+		return 1, nil
+	}
+	back, err := strconv.Atoi(against)
+	if err != nil || back < 1 {
+		return 0, fmt.Errorf("invalid --against %q (expected previous or a number of compiles back)", against)
+	}
+	return back, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SpecFileNames are the file names a specification is discovered by, in
+// the order candidates are listed.
+var SpecFileNames = []string{"spec.yaml", "bound.yaml"}
+
+// projectRootMarkers end the search for a specification: a directory
+// holding one of them is the last one searched.
+var projectRootMarkers = []string{".git", "go.mod", "pnpm-workspace.yaml"}
+
+// ResolveSpecFile returns the spec file given on the command line, or
+// discovers one from specDir when none was given.
+func ResolveSpecFile(args []string, specDir string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if specDir == "" {
+		specDir = "."
+	}
+	specFile, err := DiscoverSpec(specDir)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(os.Stderr, "Using specification %s\n", specFile)
+	return specFile, nil
+}
+
+// DiscoverSpec finds the specification of the project dir belongs to: a
+// file named like one of SpecFileNames in dir or the nearest parent that has
+// one, searching up to the project root, a directory with a .git, go.mod or
+// pnpm-workspace.yaml. A directory with several candidates is ambiguous and
+// an error that lists them. The path is relative to dir when dir is.
+func DiscoverSpec(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for current, up := abs, ""; ; current, up = filepath.Dir(current), filepath.Join(up, "..") {
+		var candidates []string
+		for _, name := range SpecFileNames {
+			if info, err := os.Stat(filepath.Join(current, name)); err == nil && !info.IsDir() {
+				candidates = append(candidates, filepath.Join(dir, up, name))
+			}
+		}
+		switch len(candidates) {
+		case 0:
+		case 1:
+			return candidates[0], nil
+		default:
+			return "", fmt.Errorf("found several specifications in %s: %s; pass the one to use", current, strings.Join(candidates, ", "))
+		}
+
+		if isProjectRoot(current) || current == filepath.Dir(current) {
+			return "", fmt.Errorf("no %s found in %s or its parents up to %s; pass the spec file or set --spec-dir", strings.Join(SpecFileNames, " or "), abs, current)
+		}
+	}
+}
+
+// isProjectRoot reports whether dir holds a project root marker.
+func isProjectRoot(dir string) bool {
+	for _, marker := range projectRootMarkers {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// makeProject creates the files and directories of a project, keyed by
+// slash-separated path; paths ending in / are directories.
+func makeProject(t *testing.T, paths ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, p := range paths {
+		full := filepath.Join(root, filepath.FromSlash(p))
+		if strings.HasSuffix(p, "/") {
+			if err := os.MkdirAll(full, 0755); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte("version: \"0.1.0\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestDiscoverSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		paths    []string
+		dir      string
+		expected string
+		wantErr  string
+	}{
+		{
+			name:     "current directory",
+			paths:    []string{".git/", "spec.yaml"},
+			expected: "spec.yaml",
+		},
+		{
+			name:     "bound.yaml",
+			paths:    []string{".git/", "bound.yaml"},
+			expected: "bound.yaml",
+		},
+		{
+			name:     "nearest parent",
+			paths:    []string{".git/", "spec.yaml", "services/api/bound.yaml", "services/api/src/"},
+			dir:      "services/api/src",
+			expected: "services/api/bound.yaml",
+		},
+		{
+			name:    "ambiguous",
+			paths:   []string{".git/", "spec.yaml", "bound.yaml", "src/"},
+			dir:     "src",
+			wantErr: "found several specifications in",
+		},
+		{
+			name:    "stops at the project root",
+			paths:   []string{"spec.yaml", "project/go.mod", "project/src/"},
+			dir:     "project/src",
+			wantErr: "no spec.yaml or bound.yaml found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			root := makeProject(t, tt.paths...)
+
+			// when
+			specFile, err := DiscoverSpec(filepath.Join(root, filepath.FromSlash(tt.dir)))
+
+			// then
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("DiscoverSpec() error = %v, expected %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DiscoverSpec() error = %v", err)
+			}
+			if want := filepath.Join(root, filepath.FromSlash(tt.expected)); specFile != want {
+				t.Errorf("DiscoverSpec() = %q, expected %q", specFile, want)
+			}
+		})
+	}
+}
+
+func TestDiscoverSpec_AmbiguousListsCandidates(t *testing.T) {
+	// given
+	root := makeProject(t, ".git/", "spec.yaml", "bound.yaml")
+
+	// when
+	_, err := DiscoverSpec(root)
+
+	// then
+	want := filepath.Join(root, "spec.yaml") + ", " + filepath.Join(root, "bound.yaml")
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("DiscoverSpec() error = %v, expected it to list %s", err, want)
+	}
+}
+
+func TestResolveSpecFile_Argument(t *testing.T) {
+	// when
+	specFile, err := ResolveSpecFile([]string{"api.yaml"}, t.TempDir())
+
+	// then
+	if err != nil || specFile != "api.yaml" {
+		t.Errorf("ResolveSpecFile() = %q, %v, expected the argument", specFile, err)
+	}
+}
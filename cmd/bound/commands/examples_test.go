@@ -0,0 +1,36 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen/codegentest"
+	"github.com/openboundary/openboundary/internal/pipeline"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExamples_Snapshots compiles every example and compares the output with
+// the generated/ directory next to its spec. Run with -update after an
+// intended change and review the diff.
+func TestExamples_Snapshots(t *testing.T) {
+	specs, err := filepath.Glob("../../../examples/*/spec.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, specs, "no examples found")
+
+	for _, spec := range specs {
+		dir := filepath.Dir(spec)
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			out := t.TempDir()
+			require.NoError(t, Compile(context.Background(), spec, CompileOptions{OutputDir: out, DiagnosticOptions: defaultDiagnostics}))
+
+			output, err := codegentest.ReadOutput(out)
+			require.NoError(t, err)
+			delete(output.Files, pipeline.ReportPath) // Holds timings
+			codegentest.Assert(t, filepath.Join(dir, "generated"), output)
+		})
+	}
+}
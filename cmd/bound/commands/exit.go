@@ -0,0 +1,60 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/openboundary/openboundary/internal/pipeline"
+)
+
+// Exit codes of the bound command. They are part of the CLI's interface:
+// CI pipelines and wrappers branch on them, so existing codes never change
+// meaning.
+const (
+	ExitFailure          = 1   // Any failure not listed below, e.g. invalid flags
+	ExitParse            = 2   // The spec could not be read or parsed
+	ExitSchemaValidation = 3   // The spec does not match the JSON Schema
+	ExitValidation       = 4   // Semantic validation failed, e.g. unresolved references
+	ExitGeneration       = 5   // A generator or a later in-memory stage failed
+	ExitWrite            = 6   // Generated files could not be written
+	ExitCancelled        = 130 // Interrupted or terminated, as shells report SIGINT
+)
+
+// stageExitCodes maps pipeline stages to the exit code of their failures.
+var stageExitCodes = map[string]int{
+	pipeline.StageParse:          ExitParse,
+	pipeline.StageValidateSchema: ExitSchemaValidation,
+	pipeline.StageBuildIR:        ExitValidation,
+	pipeline.StageValidateIR:     ExitValidation,
+	pipeline.StageGenerate:       ExitGeneration,
+	pipeline.StageStamp:          ExitGeneration,
+	pipeline.StageSelect:         ExitGeneration,
+	pipeline.StageScanSecrets:    ExitGeneration,
+	pipeline.StageRecordADR:      ExitGeneration,
+	pipeline.StageLayout:         ExitGeneration,
+	pipeline.StageWorkspace:      ExitGeneration,
+	pipeline.StageModuleFormat:   ExitGeneration,
+	pipeline.StageMerge:          ExitGeneration,
+	pipeline.StageCheckEngines:   ExitGeneration,
+	pipeline.StageBudget:         ExitGeneration,
+	pipeline.StageWrite:          ExitWrite,
+	pipeline.StageRecordPorts:    ExitWrite,
+	pipeline.StageVerify:         ExitGeneration,
+}
+
+// ExitCode returns the exit code for an error returned by a command.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, pipeline.ErrCancelled), errors.Is(err, context.Canceled):
+		return ExitCancelled
+	}
+	if code, ok := stageExitCodes[pipeline.FailedStage(err)]; ok {
+		return code
+	}
+	return ExitFailure
+}
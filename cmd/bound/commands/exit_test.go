@@ -0,0 +1,82 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"success", nil, 0},
+		{"failure", errors.New("boom"), ExitFailure},
+		{"pipeline cancelled", fmt.Errorf("%w before write", pipeline.ErrCancelled), ExitCancelled},
+		{"context cancelled", context.Canceled, ExitCancelled},
+		{"parse", &pipeline.StageError{Stage: pipeline.StageParse}, ExitParse},
+		{"schema validation", &pipeline.StageError{Stage: pipeline.StageValidateSchema}, ExitSchemaValidation},
+		{"build ir", &pipeline.StageError{Stage: pipeline.StageBuildIR}, ExitValidation},
+		{"validate ir", &pipeline.StageError{Stage: pipeline.StageValidateIR}, ExitValidation},
+		{"generate", pipeline.WithStage(pipeline.StageGenerate, errors.New("boom")), ExitGeneration},
+		{"scan secrets", &pipeline.StageError{Stage: pipeline.StageScanSecrets}, ExitGeneration},
+		{"layout", pipeline.WithStage(pipeline.StageLayout, errors.New("boom")), ExitGeneration},
+		{"write", pipeline.WithStage(pipeline.StageWrite, errors.New("boom")), ExitWrite},
+		{"verify", &pipeline.StageError{Stage: pipeline.StageVerify}, ExitGeneration},
+		{"cancelled during write", pipeline.WithStage(pipeline.StageWrite, fmt.Errorf("%w during write", pipeline.ErrCancelled)), ExitCancelled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestCompile_Cancelled(t *testing.T) {
+	// given
+	path := writeSpec(t, addTestSpec)
+	out := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	err := Compile(ctx, path, CompileOptions{OutputDir: out, DiagnosticOptions: defaultDiagnostics})
+
+	// then
+	assert.ErrorIs(t, err, pipeline.ErrCancelled)
+	assert.Equal(t, ExitCancelled, ExitCode(err))
+	assert.NoDirExists(t, out+"/src")
+}
+
+func TestExitCode_Commands(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want int
+	}{
+		{"invalid yaml", "components: [", ExitParse},
+		{"schema violation", "version: \"0.0.1\"\nname: test\ncomponents:\n  - id: x\n", ExitSchemaValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			path := writeSpec(t, tt.spec)
+
+			// when
+			err := Validate(context.Background(), path, ValidateOptions{DiagnosticOptions: defaultDiagnostics})
+
+			// then
+			assert.Error(t, err)
+			assert.Equal(t, tt.want, ExitCode(err))
+		})
+	}
+}
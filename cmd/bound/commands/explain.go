@@ -0,0 +1,149 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/parser"
+	"github.com/openboundary/openboundary/internal/pipeline"
+)
+
+// ExplainOptions configures the explain command.
+type ExplainOptions struct {
+	OutputDir string
+}
+
+// fileOrigin is where a generated file came from, as recorded by the
+// compile that last generated it.
+type fileOrigin struct {
+	File     pipeline.ReportFile
+	Spec     string // Spec file of the compile
+	Compile  int    // Number of the compile in the history; 0 when not recorded
+	Latest   bool   // The file was generated by the latest compile
+	Kind     string // Kind of the component, when the spec still declares it
+	Position parser.Position
+}
+
+// strategyNotes says what a compile does to a file of each strategy.
+var strategyNotes = map[string]string{
+	codegen.StrategyOverwrite: "regenerated by every compile; edits are lost",
+	codegen.StrategyMerge:     "your edits are merged into what each compile generates",
+}
+
+// Explain prints which generator, component and strategy produced a file in
+// the output directory, from the report of the compile that generated it.
+func Explain(file string, opts ExplainOptions) error {
+	origin, err := explainFile(opts.OutputDir, file)
+	if err != nil {
+		return err
+	}
+
+	f := origin.File
+	fmt.Println(f.Path)
+	if f.Generator == "" {
+		fmt.Println("  Compiled by a version of bound that did not record provenance; compile again to see it")
+		return nil
+	}
+	fmt.Printf("  Generator:  %s\n", f.Generator)
+	switch {
+	case f.Component == "":
+		fmt.Println("  Component:  none, the file is shared")
+	case origin.Kind == "":
+		fmt.Printf("  Component:  %s (no longer in %s)\n", f.Component, origin.Spec)
+	default:
+		fmt.Printf("  Component:  %s (%s, %s:%d)\n", f.Component, origin.Kind, origin.Spec, origin.Position.Line)
+	}
+	if note, ok := strategyNotes[f.Strategy]; ok {
+		fmt.Printf("  Strategy:   %s (%s)\n", f.Strategy, note)
+	} else {
+		fmt.Printf("  Strategy:   %s\n", f.Strategy)
+	}
+	if origin.Latest {
+		fmt.Printf("  Compiled:   from %s by the latest compile\n", origin.Spec)
+	} else {
+		fmt.Printf("  Compiled:   from %s by compile #%d; later compiles were restricted with --only\n", origin.Spec, origin.Compile)
+	}
+	return nil
+}
+
+// explainFile finds the origin of file, given relative to the output
+// directory or to the working directory, in the latest report or, for a
+// compile restricted with --only, the history.
+func explainFile(outputDir, file string) (*fileOrigin, error) {
+	rel := outputRelativePath(outputDir, file)
+
+	report, err := pipeline.LoadReport(outputDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	var origin *fileOrigin
+	if report != nil {
+		if f, ok := reportFile(report, rel); ok {
+			origin = &fileOrigin{File: f, Spec: report.Spec.Path, Latest: true}
+		}
+	}
+	if origin == nil {
+		entries, err := pipeline.LoadHistory(outputDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.Report == nil {
+				continue
+			}
+			if f, ok := reportFile(entry.Report, rel); ok {
+				origin = &fileOrigin{File: f, Spec: entry.Report.Spec.Path, Compile: entry.Seq}
+				break
+			}
+		}
+	}
+	if origin == nil {
+		if report == nil {
+			return nil, fmt.Errorf("%s/ has no compile report; run bound compile first", outputDir)
+		}
+		return nil, fmt.Errorf("%s was not generated by a compile into %s/", rel, outputDir)
+	}
+
+	if origin.File.Component != "" {
+		if spec, err := parser.NewParser(origin.Spec).Parse(); err == nil {
+			for n := range spec.Components {
+				if comp := &spec.Components[n]; comp.ID == origin.File.Component {
+					origin.Kind = comp.Kind
+					origin.Position = comp.Pos()
+				}
+			}
+		}
+	}
+	return origin, nil
+}
+
+// outputRelativePath returns the path of file relative to the output
+// directory. A path that does not lie inside it is taken as relative to it
+// already.
+func outputRelativePath(outputDir, file string) string {
+	if absOut, err := filepath.Abs(outputDir); err == nil {
+		if absFile, err := filepath.Abs(file); err == nil {
+			if rel, err := filepath.Rel(absOut, absFile); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return filepath.ToSlash(rel)
+			}
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(file))
+}
+
+// reportFile returns the entry of a report for path.
+func reportFile(report *pipeline.Report, path string) (pipeline.ReportFile, bool) {
+	for _, f := range report.Files {
+		if f.Path == path {
+			return f, true
+		}
+	}
+	return pipeline.ReportFile{}, false
+}
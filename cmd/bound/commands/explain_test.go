@@ -0,0 +1,80 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	// given
+	path := writeSpec(t, addTestSpec)
+	out := t.TempDir()
+	opts := CompileOptions{OutputDir: out, History: 5, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON}}
+	require.NoError(t, Compile(context.Background(), path, opts))
+	report, err := pipeline.LoadReport(out)
+	require.NoError(t, err)
+	var usecaseFile string
+	for _, f := range report.Files {
+		if f.Component == "usecase.list-orders" {
+			usecaseFile = f.Path
+			break
+		}
+	}
+	require.NotEmpty(t, usecaseFile)
+
+	// when
+	origin, err := explainFile(out, filepath.Join(out, usecaseFile))
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, origin.Latest)
+	assert.NotEmpty(t, origin.File.Generator)
+	assert.Equal(t, codegen.StrategyOverwrite, origin.File.Strategy)
+	assert.Equal(t, "usecase", origin.Kind)
+	assert.Equal(t, 13, origin.Position.Line)
+	assert.NoError(t, Explain(usecaseFile, ExplainOptions{OutputDir: out}))
+
+	// when a merged, shared file is explained
+	origin, err = explainFile(out, "package.json")
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, codegen.StrategyMerge, origin.File.Strategy)
+	assert.Empty(t, origin.File.Component)
+}
+
+func TestExplain_OnlyCompile(t *testing.T) {
+	// given
+	path := writeSpec(t, addTestSpec)
+	out := t.TempDir()
+	opts := CompileOptions{OutputDir: out, History: 5, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON}}
+	require.NoError(t, Compile(context.Background(), path, opts))
+	opts.Only = []string{"id=usecase.list-orders"}
+	require.NoError(t, Compile(context.Background(), path, opts))
+
+	// when
+	origin, err := explainFile(out, "README.md")
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, origin.Latest)
+	assert.Equal(t, 1, origin.Compile)
+}
+
+func TestExplain_NotGenerated(t *testing.T) {
+	out := t.TempDir()
+	assert.ErrorContains(t, Explain("src/index.ts", ExplainOptions{OutputDir: out}), "has no compile report; run bound compile first")
+
+	path := writeSpec(t, addTestSpec)
+	require.NoError(t, Compile(context.Background(), path, CompileOptions{OutputDir: out, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON}}))
+	assert.ErrorContains(t, Explain("src/handwritten.ts", ExplainOptions{OutputDir: out}), "src/handwritten.ts was not generated by a compile into")
+}
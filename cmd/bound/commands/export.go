@@ -0,0 +1,187 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/pipeline"
+	"github.com/openboundary/openboundary/internal/sqlite"
+)
+
+// ExportSQLiteOptions configures the export sqlite command.
+type ExportSQLiteOptions struct {
+	Target string // Generation target whose files are exported as artifacts
+}
+
+// exportTables are the tables of an export, in the order they are created.
+// Every table but specs has a spec_id column referencing specs.id.
+var exportTables = []struct {
+	name    string
+	columns []sqlite.Column
+}{
+	{"specs", []sqlite.Column{sqlite.Integer("id"), sqlite.Text("path"), sqlite.Text("name"), sqlite.Text("version"), sqlite.Text("description"), sqlite.Text("semantic_hash")}},
+	{"components", []sqlite.Column{sqlite.Integer("spec_id"), sqlite.Text("id"), sqlite.Text("kind"), sqlite.Text("provider"), sqlite.Text("description"), sqlite.Text("owner"), sqlite.Integer("deprecated"), sqlite.Text("replacement"), sqlite.Text("spec")}},
+	{"labels", []sqlite.Column{sqlite.Integer("spec_id"), sqlite.Text("component_id"), sqlite.Text("label")}},
+	{"edges", []sqlite.Column{sqlite.Integer("spec_id"), sqlite.Text("from_id"), sqlite.Text("to_id"), sqlite.Text("type")}},
+	{"bindings", []sqlite.Column{sqlite.Integer("spec_id"), sqlite.Text("usecase_id"), sqlite.Text("server_id"), sqlite.Text("method"), sqlite.Text("path"), sqlite.Text("operation_id")}},
+	{"operations", []sqlite.Column{sqlite.Integer("spec_id"), sqlite.Text("server_id"), sqlite.Text("method"), sqlite.Text("path"), sqlite.Text("operation_id"), sqlite.Text("summary")}},
+	{"artifacts", []sqlite.Column{sqlite.Integer("spec_id"), sqlite.Text("path"), sqlite.Text("generator"), sqlite.Text("component_id"), sqlite.Text("sha256"), sqlite.Integer("size")}},
+}
+
+// ExportSQLite writes the IR of each spec to a SQLite database at out, so
+// specs of many services can be queried together with SQL. Each spec is
+// validated and generated in memory; the files it would generate are
+// exported as artifacts.
+func ExportSQLite(ctx context.Context, out string, specFiles []string, opts ExportSQLiteOptions) error {
+	newRegistry, err := pluginRegistryFor(CompileOptions{Target: opts.Target})
+	if err != nil {
+		return err
+	}
+
+	db := sqlite.New()
+	tables := make(map[string]*sqlite.Table, len(exportTables))
+	for _, t := range exportTables {
+		table, err := db.CreateTable(t.name, t.columns...)
+		if err != nil {
+			return err
+		}
+		tables[t.name] = table
+	}
+
+	for n, specFile := range specFiles {
+		p := pipeline.New(
+			pipeline.Parse(),
+			pipeline.ValidateSchema(),
+			pipeline.BuildIR(),
+			pipeline.ValidateIR(),
+			pipeline.Generate(newRegistry),
+		)
+		pc := &pipeline.Context{SpecPath: specFile, Quiet: true, Ctx: ctx}
+		if err := p.Run(pc); err != nil {
+			reportDiagnostics(pc, err, defaultDiagnostics)
+			return fmt.Errorf("%s: %w", specFile, err)
+		}
+		if err := exportSpec(tables, n+1, specFile, pc); err != nil {
+			return fmt.Errorf("%s: %w", specFile, err)
+		}
+	}
+
+	if err := db.WriteFile(out); err != nil {
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+	fmt.Printf("✓ Exported %d spec(s) to %s\n", len(specFiles), out)
+	return nil
+}
+
+// exportSpec inserts the rows of one compiled spec, in spec order where it
+// has one and sorted otherwise, so the same specs export the same rows.
+func exportSpec(tables map[string]*sqlite.Table, specID int, specFile string, pc *pipeline.Context) error {
+	spec, i := pc.AST, pc.IR
+	hash, err := spec.SemanticHash()
+	if err != nil {
+		return err
+	}
+	if err := tables["specs"].Insert(specID, specFile, spec.Name, spec.Version, spec.Description, hash); err != nil {
+		return err
+	}
+
+	for _, decl := range spec.Components {
+		comp, ok := i.Components[decl.ID]
+		if !ok {
+			continue
+		}
+		declared, err := json.Marshal(decl.Spec)
+		if err != nil {
+			return fmt.Errorf("component %s: %w", comp.ID, err)
+		}
+		if err := tables["components"].Insert(specID, comp.ID, string(comp.Kind), componentProvider(comp),
+			comp.Description, comp.Owner, comp.Deprecated, comp.Replacement, string(declared)); err != nil {
+			return err
+		}
+		for _, label := range comp.Labels {
+			if err := tables["labels"].Insert(specID, comp.ID, label); err != nil {
+				return err
+			}
+		}
+		if comp.Usecase != nil && comp.Usecase.Binding != nil {
+			b := comp.Usecase.Binding
+			var operationID any
+			if b.Operation != nil {
+				operationID = b.Operation.OperationID
+			}
+			if err := tables["bindings"].Insert(specID, comp.ID, b.ServerID, b.Method, b.Path, operationID); err != nil {
+				return err
+			}
+		}
+		if comp.HTTPServer != nil && comp.HTTPServer.ParsedOpenAPI != nil {
+			ops := comp.HTTPServer.ParsedOpenAPI.Operations
+			keys := make([]string, 0, len(ops))
+			for key := range ops {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				op := ops[key]
+				if err := tables["operations"].Insert(specID, comp.ID, op.Method, op.Path, op.OperationID, op.Summary); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	edges := make([]ir.Edge, 0, len(i.Edges))
+	for _, edge := range i.Edges {
+		if edge.From != nil && edge.To != nil {
+			edges = append(edges, edge)
+		}
+	}
+	sort.SliceStable(edges, func(a, b int) bool {
+		if edges[a].From.ID != edges[b].From.ID {
+			return edges[a].From.ID < edges[b].From.ID
+		}
+		if edges[a].To.ID != edges[b].To.ID {
+			return edges[a].To.ID < edges[b].To.ID
+		}
+		return edges[a].Type < edges[b].Type
+	})
+	for _, edge := range edges {
+		if err := tables["edges"].Insert(specID, edge.From.ID, edge.To.ID, string(edge.Type)); err != nil {
+			return err
+		}
+	}
+
+	artifacts := append(pc.Artifacts[:0:0], pc.Artifacts...)
+	sort.Slice(artifacts, func(a, b int) bool { return artifacts[a].Path < artifacts[b].Path })
+	for _, artifact := range artifacts {
+		sum := sha256.Sum256(artifact.Content)
+		var componentID any
+		if artifact.ComponentID != "" {
+			componentID = artifact.ComponentID
+		}
+		if err := tables["artifacts"].Insert(specID, artifact.Path, artifact.Owner, componentID,
+			hex.EncodeToString(sum[:]), len(artifact.Content)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// componentProvider returns the provider a middleware or database component
+// is built on, such as casbin or drizzle, or nil for other kinds.
+func componentProvider(comp *ir.Component) any {
+	switch {
+	case comp.Middleware != nil && comp.Middleware.Provider != "":
+		return comp.Middleware.Provider
+	case comp.Postgres != nil && comp.Postgres.Provider != "":
+		return comp.Postgres.Provider
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSQLite(t *testing.T) {
+	// given
+	out := filepath.Join(t.TempDir(), "specs.db")
+	specs := []string{"../../../examples/basic/spec.yaml", "../../../examples/auth-rbac/spec.yaml"}
+
+	// when
+	err := ExportSQLite(context.Background(), out, specs, ExportSQLiteOptions{})
+
+	// then
+	require.NoError(t, err)
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("SQLite format 3\x00")))
+	for _, table := range exportTables {
+		assert.Contains(t, string(data), "CREATE TABLE "+table.name+"(", "table %s", table.name)
+	}
+	assert.Contains(t, string(data), "casbin")
+	assert.Contains(t, string(data), "usecase.")
+
+	again := filepath.Join(t.TempDir(), "specs.db")
+	require.NoError(t, ExportSQLite(context.Background(), again, specs, ExportSQLiteOptions{}))
+	repeated, err := os.ReadFile(again)
+	require.NoError(t, err)
+	assert.Equal(t, data, repeated, "the same specs export the same database")
+}
+
+func TestExportSQLite_InvalidSpec(t *testing.T) {
+	path := writeSpec(t, "version: \"0.1.0\"\nname: broken\ncomponents:\n  - id: usecase.x\n    kind: usecase\n    spec:\n      binds_to: http.server.missing:GET:/x\n")
+	out := filepath.Join(t.TempDir(), "specs.db")
+
+	err := ExportSQLite(context.Background(), out, []string{path}, ExportSQLiteOptions{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path)
+	assert.NoFileExists(t, out)
+}
+
+func TestExportSQLite_UnknownTarget(t *testing.T) {
+	err := ExportSQLite(context.Background(), filepath.Join(t.TempDir(), "specs.db"), nil, ExportSQLiteOptions{Target: "cobol"})
+
+	assert.ErrorContains(t, err, "unknown target")
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/openapi"
+	"github.com/openboundary/openboundary/internal/validator"
+)
+
+// GenerateSpecOptions configures the generate-spec command.
+type GenerateSpecOptions struct {
+	Out    string // Spec file to write
+	Name   string // Spec name; defaults to the document's title
+	Server string // Last segment of the server ID
+	Port   int
+	Force  bool // Overwrite an existing spec file
+}
+
+// operationMethods are the methods of OpenAPI operations in the order they
+// are listed for a path.
+var operationMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+var (
+	nonSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+	camelPattern   = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+)
+
+// GenerateSpec writes a spec for an existing OpenAPI document: an
+// http.server serving it and a usecase per operation, with commented
+// placeholders for middleware and a database.
+func GenerateSpec(openapiFile string, opts GenerateSpecOptions) error {
+	doc, err := openapi.NewParser(".").ParseFile(openapiFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", openapiFile, err)
+	}
+
+	ref, err := specRelativePath(opts.Out, openapiFile)
+	if err != nil {
+		return err
+	}
+	data, err := specFromOpenAPI(doc, ref, opts)
+	if err != nil {
+		return err
+	}
+	if err := writeNewSpec(opts.Out, data, opts.Force); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Wrote %s with %d usecases from %s\n", opts.Out, len(doc.Operations), openapiFile)
+	return nil
+}
+
+// writeNewSpec schema-validates a generated spec and writes it to out,
+// which must not exist unless force is set.
+func writeNewSpec(out string, data []byte, force bool) error {
+	if !force {
+		if _, err := os.Stat(out); err == nil {
+			return fmt.Errorf("%s already exists; pass --force to overwrite it", out)
+		}
+	}
+	if _, err := checkSpecBytes(out, data, "generated spec"); err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		return fmt.Errorf("failed to write spec: %w", err)
+	}
+	return nil
+}
+
+// specRelativePath returns how a spec written to out refers to file.
+func specRelativePath(out, file string) (string, error) {
+	absOut, err := filepath.Abs(out)
+	if err != nil {
+		return "", err
+	}
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(filepath.Dir(absOut), absFile)
+	if err != nil {
+		return "", fmt.Errorf("cannot refer to %s from %s: %w", file, out, err)
+	}
+	return "./" + filepath.ToSlash(rel), nil
+}
+
+// specFromOpenAPI renders the spec of a document whose file the spec refers
+// to as ref.
+func specFromOpenAPI(doc *openapi.Document, ref string, opts GenerateSpecOptions) ([]byte, error) {
+	name := opts.Name
+	if name == "" {
+		name = specSlug(doc.Title)
+	}
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = strings.TrimSuffix("api-"+name, "-")
+	}
+	serverID := "http.server." + opts.Server
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Generated by bound generate-spec from %s. Review the goals, then\n", ref)
+	sb.WriteString("# fill in the placeholders at the end.\n")
+	fmt.Fprintf(&sb, "version: %q\n", validator.SchemaVersion())
+	fmt.Fprintf(&sb, "name: %s\n", name)
+	if doc.Title != "" {
+		fmt.Fprintf(&sb, "description: %q\n", doc.Title)
+	}
+	sb.WriteString("\ncomponents:\n")
+
+	server, err := encodeComponent(&addedComponent{
+		ID:   serverID,
+		Kind: "http.server",
+		Spec: addedServerSpec{Framework: "hono", Port: opts.Port, OpenAPI: ref},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sb.WriteString(strings.Join(indentLines(server, "  "), "\n") + "\n")
+
+	seen := make(map[string]bool)
+	for _, op := range sortedOperations(doc) {
+		id := usecaseIDFor(op, seen)
+		seen[id] = true
+		goal := op.Summary
+		if goal == "" {
+			goal, _, _ = strings.Cut(strings.TrimSpace(op.Description), "\n")
+		}
+		if goal == "" {
+			goal = humanize(strings.TrimPrefix(id, "usecase."))
+		}
+		uc, err := encodeComponent(&addedComponent{
+			ID:   id,
+			Kind: "usecase",
+			Spec: addedUsecaseSpec{BindsTo: serverID + ":" + op.Method + ":" + op.Path, Goal: goal},
+		})
+		if err != nil {
+			return nil, err
+		}
+		sb.WriteString("\n" + strings.Join(indentLines(uc, "  "), "\n") + "\n")
+	}
+
+	sb.WriteString("\n  # Placeholders: uncomment what the service uses, point it at your files,\n")
+	fmt.Fprintf(&sb, "  # and list it in the middleware or depends_on of %s.\n", serverID)
+	sb.WriteString("  #\n")
+	sb.WriteString("  # - id: middleware.authn\n")
+	sb.WriteString("  #   kind: middleware\n")
+	sb.WriteString("  #   spec:\n")
+	sb.WriteString("  #     provider: better-auth\n")
+	sb.WriteString("  #     config: ./src/auth.config.ts\n")
+	sb.WriteString("  #\n")
+	sb.WriteString("  # - id: postgres.primary\n")
+	sb.WriteString("  #   kind: postgres\n")
+	sb.WriteString("  #   spec:\n")
+	sb.WriteString("  #     provider: drizzle\n")
+	sb.WriteString("  #     schema: ./src/db/schema.ts\n")
+
+	return []byte(sb.String()), nil
+}
+
+// sortedOperations returns the operations of a document by path, and by
+// method within a path.
+func sortedOperations(doc *openapi.Document) []*openapi.Operation {
+	ops := make([]*openapi.Operation, 0, len(doc.Operations))
+	for _, op := range doc.Operations {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(a, b int) bool {
+		if ops[a].Path != ops[b].Path {
+			return ops[a].Path < ops[b].Path
+		}
+		return slices.Index(operationMethods, ops[a].Method) < slices.Index(operationMethods, ops[b].Method)
+	})
+	return ops
+}
+
+// usecaseIDFor names the usecase of an operation after its operationId, or
+// its method and path without one, e.g. "usecase.list-users" for listUsers
+// and "usecase.get-users-id" for GET /users/{id}. Names in seen get a number.
+func usecaseIDFor(op *openapi.Operation, seen map[string]bool) string {
+	name := specSlug(op.OperationID)
+	if name == "" {
+		name = specSlug(op.Method + " " + op.Path)
+	}
+	if name[0] < 'a' || name[0] > 'z' {
+		name = "op-" + name
+	}
+	id := "usecase." + name
+	for n := 2; seen[id]; n++ {
+		id = fmt.Sprintf("usecase.%s-%d", name, n)
+	}
+	return id
+}
+
+// specSlug turns a title or identifier into a kebab-case name, e.g.
+// "Pet Store" and "petStore" into "pet-store".
+func specSlug(s string) string {
+	s = camelPattern.ReplaceAllString(s, "$1-$2")
+	return strings.Trim(nonSlugPattern.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
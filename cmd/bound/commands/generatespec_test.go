@@ -0,0 +1,122 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const generateSpecTestOpenAPI = `openapi: 3.0.3
+info:
+  title: Pet Store
+  version: 2.1.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      responses:
+        '200':
+          description: OK
+    post:
+      operationId: createPet
+      description: |
+        Adds a pet to the store.
+        Pets need a name.
+      responses:
+        '201':
+          description: Created
+  /pets/{id}:
+    delete:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: No Content
+`
+
+func TestGenerateSpec(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	openapiFile := filepath.Join(dir, "api", "openapi.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(openapiFile), 0755))
+	require.NoError(t, os.WriteFile(openapiFile, []byte(generateSpecTestOpenAPI), 0644))
+	out := filepath.Join(dir, "spec.yaml")
+
+	// when
+	err := GenerateSpec(openapiFile, GenerateSpecOptions{Out: out, Server: "pets", Port: 3001})
+
+	// then
+	require.NoError(t, err)
+	content, err := os.ReadFile(out)
+	require.NoError(t, err)
+	spec := string(content)
+	for _, want := range []string{
+		"version: \"0.1.0\"\nname: pet-store\ndescription: \"Pet Store\"\n",
+		"  - id: http.server.pets\n    kind: http.server\n    spec:\n      framework: hono\n      port: 3001\n      openapi: ./api/openapi.yaml\n",
+		"  - id: usecase.list-pets\n    kind: usecase\n    spec:\n      binds_to: http.server.pets:GET:/pets\n      goal: List pets\n",
+		"  - id: usecase.create-pet\n    kind: usecase\n    spec:\n      binds_to: http.server.pets:POST:/pets\n      goal: Adds a pet to the store.\n",
+		"  - id: usecase.delete-pets-id\n    kind: usecase\n    spec:\n      binds_to: http.server.pets:DELETE:/pets/{id}\n      goal: Delete pets id\n",
+		"  # - id: postgres.primary\n",
+	} {
+		assert.Contains(t, spec, want)
+	}
+	assert.NoError(t, Validate(context.Background(), out, ValidateOptions{}))
+}
+
+func TestGenerateSpec_ExistingSpec(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	openapiFile := filepath.Join(dir, "openapi.yaml")
+	require.NoError(t, os.WriteFile(openapiFile, []byte(generateSpecTestOpenAPI), 0644))
+	out := filepath.Join(dir, "spec.yaml")
+	require.NoError(t, os.WriteFile(out, []byte("# hand-written\n"), 0644))
+
+	// when
+	err := GenerateSpec(openapiFile, GenerateSpecOptions{Out: out, Server: "api", Port: 3000})
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+	content, _ := os.ReadFile(out)
+	assert.Equal(t, "# hand-written\n", string(content))
+
+	// when forced
+	require.NoError(t, GenerateSpec(openapiFile, GenerateSpecOptions{Out: out, Server: "api", Port: 3000, Force: true}))
+
+	// then
+	content, _ = os.ReadFile(out)
+	assert.Contains(t, string(content), "openapi: ./openapi.yaml")
+}
+
+func TestUsecaseIDFor(t *testing.T) {
+	seen := map[string]bool{"usecase.list-pets": true}
+	tests := []struct {
+		operationID string
+		method      string
+		path        string
+		want        string
+	}{
+		{"getPetByID", "GET", "/pets/{id}", "usecase.get-pet-by-id"},
+		{"pets_search", "GET", "/pets/search", "usecase.pets-search"},
+		{"", "PUT", "/pets/{id}/tags", "usecase.put-pets-id-tags"},
+		{"listPets", "GET", "/v2/pets", "usecase.list-pets-2"},
+		{"2fa", "POST", "/2fa", "usecase.op-2fa"},
+	}
+	for _, tt := range tests {
+		op := &openapi.Operation{OperationID: tt.operationID, Method: tt.method, Path: tt.path}
+		assert.Equal(t, tt.want, usecaseIDFor(op, seen), "operationId %q", tt.operationID)
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ImportComposeOptions configures the import compose command.
+type ImportComposeOptions struct {
+	Out   string // Spec file to write
+	Name  string // Spec name; defaults to the compose project's
+	Force bool   // Overwrite an existing spec file
+}
+
+// composeFile is the part of a docker-compose file the import reads.
+type composeFile struct {
+	Name     string                    `yaml:"name"`
+	Services map[string]composeService `yaml:"services"`
+}
+
+type composeService struct {
+	Image       string    `yaml:"image"`
+	Build       yaml.Node `yaml:"build"`       // A context path, or a mapping with one
+	Ports       yaml.Node `yaml:"ports"`       // Short or long syntax
+	Environment yaml.Node `yaml:"environment"` // A mapping or a list of NAME=value
+	DependsOn   yaml.Node `yaml:"depends_on"`  // A list or a mapping of service names
+}
+
+// Kinds of compose services the import recognizes.
+const (
+	composePostgres = "postgres"
+	composeRedis    = "redis"
+	composeNode     = "node"
+)
+
+// ImportCompose writes a starter spec for the services of a docker-compose
+// file: a postgres component per Postgres service and an http.server per
+// Node service, depending on the databases it connects to.
+func ImportCompose(composePath string, opts ImportComposeOptions) error {
+	data, err := os.ReadFile(composePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", composePath, err)
+	}
+	var compose composeFile
+	if err := yaml.Unmarshal(data, &compose); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", composePath, err)
+	}
+	if compose.Name == "" {
+		abs, _ := filepath.Abs(composePath)
+		compose.Name = filepath.Base(filepath.Dir(abs))
+	}
+
+	ref, err := specRelativePath(opts.Out, composePath)
+	if err != nil {
+		return err
+	}
+	spec, err := specFromCompose(&compose, filepath.Dir(composePath), ref, opts)
+	if err != nil {
+		return err
+	}
+	if err := writeNewSpec(opts.Out, spec, opts.Force); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Wrote %s from %s\n", opts.Out, composePath)
+	return nil
+}
+
+// specFromCompose renders the spec of a compose file in dir, which the spec
+// refers to as ref.
+func specFromCompose(compose *composeFile, dir, ref string, opts ImportComposeOptions) ([]byte, error) {
+	name := opts.Name
+	if name == "" {
+		name = specSlug(compose.Name)
+	}
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = strings.TrimSuffix("api-"+name, "-")
+	}
+
+	services := make([]string, 0, len(compose.Services))
+	for svc := range compose.Services {
+		services = append(services, svc)
+	}
+	sort.Strings(services)
+
+	kinds := make(map[string]string)
+	for _, svc := range services {
+		kinds[svc] = composeServiceKind(compose.Services[svc], dir)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Generated by bound import compose from %s. Point each postgres\n", ref)
+	sb.WriteString("# schema at its Drizzle file, then add usecases with bound add.\n")
+	sb.WriteString("version: \"0.1.0\"\n")
+	fmt.Fprintf(&sb, "name: %s\n", name)
+
+	var components []*addedComponent
+	for _, svc := range services {
+		if kinds[svc] == composePostgres {
+			components = append(components, &addedComponent{
+				ID:   "postgres." + specSlug(svc),
+				Kind: "postgres",
+				Spec: addedPostgresSpec{Provider: "drizzle", Schema: "./src/db/schema.ts"},
+			})
+		}
+	}
+	for _, svc := range services {
+		if kinds[svc] != composeNode {
+			continue
+		}
+		s := compose.Services[svc]
+		var deps []string
+		for _, other := range services {
+			if kinds[other] == composePostgres && composeUses(s, other) {
+				deps = append(deps, "postgres."+specSlug(other))
+			}
+		}
+		components = append(components, &addedComponent{
+			ID:   "http.server." + specSlug(svc),
+			Kind: "http.server",
+			Spec: addedServerSpec{Framework: "hono", Port: composePort(s), DependsOn: deps},
+		})
+	}
+	if len(components) == 0 {
+		sb.WriteString("\ncomponents: []\n")
+	} else {
+		sb.WriteString("\ncomponents:\n")
+	}
+	for n, comp := range components {
+		item, err := encodeComponent(comp)
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(strings.Join(indentLines(item, "  "), "\n") + "\n")
+	}
+
+	var skipped []string
+	for _, svc := range services {
+		switch kinds[svc] {
+		case composeRedis:
+			skipped = append(skipped, fmt.Sprintf("%s: Redis has no component kind yet", svc))
+		case "":
+			skipped = append(skipped, fmt.Sprintf("%s: neither a Node server nor a Postgres database", svc))
+		}
+	}
+	if len(skipped) > 0 {
+		sb.WriteString("\n# Services not imported:\n")
+		for _, s := range skipped {
+			fmt.Fprintf(&sb, "#   %s\n", s)
+		}
+	}
+
+	return []byte(sb.String()), nil
+}
+
+// composeServiceKind tells what a service runs: composePostgres or
+// composeRedis by its image, composeNode for a node image or a build context
+// with a package.json, or "" for anything else.
+func composeServiceKind(s composeService, dir string) string {
+	image := s.Image
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		image = image[i+1:]
+	}
+	image, _, _ = strings.Cut(image, ":")
+	image, _, _ = strings.Cut(image, "@")
+	switch {
+	case strings.HasPrefix(image, "postgres") || image == "postgis":
+		return composePostgres
+	case image == "redis" || image == "valkey" || strings.HasPrefix(image, "redis-"):
+		return composeRedis
+	case image == "node":
+		return composeNode
+	}
+
+	context := ""
+	switch s.Build.Kind {
+	case yaml.ScalarNode:
+		context = s.Build.Value
+	case yaml.MappingNode:
+		var build struct {
+			Context string `yaml:"context"`
+		}
+		if s.Build.Decode(&build) == nil {
+			context = build.Context
+		}
+	}
+	if context != "" {
+		if _, err := os.Stat(filepath.Join(dir, context, "package.json")); err == nil {
+			return composeNode
+		}
+	}
+	return ""
+}
+
+// composePort returns the port a server listens on: the host port of its
+// first published port, which compose keeps unique, then its container
+// port, then its PORT variable, then 3000.
+func composePort(s composeService) int {
+	if s.Ports.Kind == yaml.SequenceNode && len(s.Ports.Content) > 0 {
+		first := s.Ports.Content[0]
+		switch first.Kind {
+		case yaml.ScalarNode:
+			// [ip:][host:]container[/protocol]
+			spec, _, _ := strings.Cut(first.Value, "/")
+			parts := strings.Split(spec, ":")
+			candidates := parts[len(parts)-1:]
+			if len(parts) > 1 {
+				candidates = parts[len(parts)-2:]
+			}
+			for _, part := range candidates {
+				if port, err := strconv.Atoi(part); err == nil {
+					return port
+				}
+			}
+		case yaml.MappingNode:
+			var long struct {
+				Target    int    `yaml:"target"`
+				Published string `yaml:"published"`
+			}
+			if first.Decode(&long) == nil {
+				if port, err := strconv.Atoi(long.Published); err == nil {
+					return port
+				}
+				if long.Target > 0 {
+					return long.Target
+				}
+			}
+		}
+	}
+	if port, err := strconv.Atoi(composeEnvironment(s)["PORT"]); err == nil {
+		return port
+	}
+	return 3000
+}
+
+// composeUses reports whether a service depends on another, or names it as
+// a host in one of its variables, e.g. DATABASE_URL=postgres://app@db:5432/app.
+func composeUses(s composeService, other string) bool {
+	switch s.DependsOn.Kind {
+	case yaml.SequenceNode:
+		for _, n := range s.DependsOn.Content {
+			if n.Value == other {
+				return true
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(s.DependsOn.Content); i += 2 {
+			if s.DependsOn.Content[i].Value == other {
+				return true
+			}
+		}
+	}
+	for _, value := range composeEnvironment(s) {
+		if strings.Contains(value, "@"+other+":") || strings.Contains(value, "@"+other+"/") || strings.Contains(value, "//"+other+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// composeEnvironment returns the variables a service sets.
+func composeEnvironment(s composeService) map[string]string {
+	env := make(map[string]string)
+	switch s.Environment.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(s.Environment.Content); i += 2 {
+			env[s.Environment.Content[i].Value] = s.Environment.Content[i+1].Value
+		}
+	case yaml.SequenceNode:
+		for _, n := range s.Environment.Content {
+			name, value, _ := strings.Cut(n.Value, "=")
+			env[name] = value
+		}
+	}
+	return env
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const importComposeTestFile = `name: Shop
+services:
+  db:
+    image: postgres:16-alpine
+    environment:
+      POSTGRES_PASSWORD: secret
+  analytics-db:
+    image: docker.io/library/postgres:15
+  cache:
+    image: redis:7
+  api:
+    build: ./api
+    ports:
+      - "8080:3000"
+    environment:
+      - DATABASE_URL=postgres://shop:secret@db:5432/shop
+    depends_on:
+      - cache
+  admin:
+    image: node:20-slim
+    environment:
+      PORT: "4000"
+    depends_on:
+      analytics-db:
+        condition: service_healthy
+  mail:
+    image: mailhog/mailhog
+`
+
+func TestImportCompose(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	composePath := filepath.Join(dir, "docker-compose.yml")
+	require.NoError(t, os.WriteFile(composePath, []byte(importComposeTestFile), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "api"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api", "package.json"), []byte("{}"), 0644))
+	out := filepath.Join(dir, "spec.yaml")
+
+	// when
+	err := ImportCompose(composePath, ImportComposeOptions{Out: out})
+
+	// then
+	require.NoError(t, err)
+	content, err := os.ReadFile(out)
+	require.NoError(t, err)
+	spec := string(content)
+	for _, want := range []string{
+		"name: shop\n",
+		"  - id: postgres.analytics-db\n    kind: postgres\n",
+		"  - id: postgres.db\n    kind: postgres\n",
+		"  - id: http.server.admin\n    kind: http.server\n    spec:\n      framework: hono\n      port: 4000\n      depends_on:\n        - postgres.analytics-db\n",
+		"  - id: http.server.api\n    kind: http.server\n    spec:\n      framework: hono\n      port: 8080\n      depends_on:\n        - postgres.db\n",
+		"#   cache: Redis has no component kind yet\n",
+		"#   mail: neither a Node server nor a Postgres database\n",
+	} {
+		assert.Contains(t, spec, want)
+	}
+	assert.NoError(t, Validate(context.Background(), out, ValidateOptions{}))
+}
+
+func TestImportCompose_NoServices(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	composePath := filepath.Join(dir, "compose.yaml")
+	require.NoError(t, os.WriteFile(composePath, []byte("services:\n  mail:\n    image: mailhog/mailhog\n"), 0644))
+	out := filepath.Join(dir, "spec.yaml")
+
+	// when
+	err := ImportCompose(composePath, ImportComposeOptions{Out: out, Name: "mail"})
+
+	// then
+	require.NoError(t, err)
+	content, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "name: mail\n\ncomponents: []\n")
+}
+
+func TestComposePort(t *testing.T) {
+	tests := []struct {
+		service string
+		want    int
+	}{
+		{"ports: [\"3000\"]", 3000},
+		{"ports: [\"8080:3000\"]", 8080},
+		{"ports: [\"127.0.0.1:8081:3000/tcp\"]", 8081},
+		{"ports: [\"127.0.0.1::3000\"]", 3000},
+		{"ports: [{target: 3000, published: \"9000\"}]", 9000},
+		{"ports: [{target: 3000}]", 3000},
+		{"environment: [PORT=4000]", 4000},
+		{"image: node", 3000},
+	}
+	for _, tt := range tests {
+		var s composeService
+		require.NoError(t, yaml.Unmarshal([]byte(tt.service), &s))
+		assert.Equal(t, tt.want, composePort(s), tt.service)
+	}
+}
@@ -22,7 +22,7 @@ func Init(projectName, template string) error {
 	// Verify the template exists in the embedded filesystem.
 	entries, err := fs.ReadDir(templates.FS, template)
 	if err != nil {
-		return fmt.Errorf("unknown template %q: available templates are blank, basic", template)
+		return fmt.Errorf("unknown template %q: available templates are %s", template, strings.Join(templates.Names(), ", "))
 	}
 
 	if len(entries) == 0 {
@@ -4,6 +4,7 @@
 package commands
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -107,7 +108,7 @@ func TestInit_BasicTemplateValidates(t *testing.T) {
 	require.NoError(t, err)
 
 	specPath := filepath.Join(dir, "test-project", "spec.yaml")
-	err = Validate(specPath)
+	err = Validate(context.Background(), specPath, ValidateOptions{})
 	assert.NoError(t, err)
 }
 
@@ -118,7 +119,7 @@ func TestInit_BlankTemplateValidates(t *testing.T) {
 	require.NoError(t, err)
 
 	specPath := filepath.Join(dir, "test-project", "spec.yaml")
-	err = Validate(specPath)
+	err = Validate(context.Background(), specPath, ValidateOptions{})
 	assert.NoError(t, err)
 }
 
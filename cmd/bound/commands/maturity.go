@@ -0,0 +1,73 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/coverage"
+	"github.com/openboundary/openboundary/internal/maturity"
+	"github.com/openboundary/openboundary/internal/pipeline"
+)
+
+// MaturityOptions configures the maturity command.
+type MaturityOptions struct {
+	Dir      string // Directory searched for tests covering acceptance criteria; empty skips the search
+	MinLevel int    // Fail when the spec is below this level; 0 never fails
+}
+
+// Maturity scores each usecase and http.server of the spec against the
+// conformance levels and prints what keeps each from the next one. The spec
+// is at the level of its least mature component.
+func Maturity(ctx context.Context, specFile string, opts MaturityOptions) error {
+	if opts.MinLevel < 0 || opts.MinLevel > int(maturity.MaxLevel) {
+		return fmt.Errorf("invalid --min-level %d: must be between 0 and %d", opts.MinLevel, maturity.MaxLevel)
+	}
+
+	p := pipeline.New(
+		pipeline.Parse(),
+		pipeline.ValidateSchema(),
+		pipeline.BuildIR(),
+		pipeline.ValidateIR(),
+	)
+	pc := &pipeline.Context{SpecPath: specFile, Ctx: ctx}
+	if err := p.Run(pc); err != nil {
+		reportDiagnostics(pc, err, defaultDiagnostics)
+		return err
+	}
+
+	var covered map[string][]string
+	if opts.Dir != "" {
+		var err error
+		if covered, err = coverage.ScanDir(opts.Dir); err != nil {
+			return fmt.Errorf("failed to scan tests: %w", err)
+		}
+	}
+	report := maturity.Assess(pc.IR, covered)
+
+	width := 0
+	for _, c := range report.Components {
+		width = max(width, len(c.ID))
+	}
+	for _, c := range report.Components {
+		fmt.Printf("  %s  %-*s  %s\n", c.Level, width, c.ID, c.Level.Name())
+		for _, gap := range c.Gaps {
+			fmt.Printf("        %s needs: %s\n", c.Level+1, gap)
+		}
+	}
+
+	counts := make([]string, 0, maturity.MaxLevel+1)
+	for level := maturity.L0; level <= maturity.MaxLevel; level++ {
+		counts = append(counts, fmt.Sprintf("%d at %s", report.Count(level), level))
+	}
+	summary := fmt.Sprintf("%s is at %s (%s): %s", pc.IR.Spec.Name, report.Level, report.Level.Name(), strings.Join(counts, ", "))
+
+	if required := maturity.Level(opts.MinLevel); report.Level < required {
+		return fmt.Errorf("%s, below the required %s (%s)", summary, required, required.Name())
+	}
+	fmt.Printf("✓ %s\n", summary)
+	return nil
+}
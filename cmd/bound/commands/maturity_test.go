@@ -0,0 +1,107 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const maturityTestOpenAPI = `openapi: 3.0.3
+info:
+  title: Orders
+  version: 0.1.0
+paths:
+  /orders:
+    post:
+      operationId: createOrder
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                item:
+                  type: string
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id:
+                    type: string
+`
+
+const maturityTestSpec = `version: "0.1.0"
+name: orders
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 3000
+      openapi: ./openapi.yaml
+  - id: usecase.create-order
+    kind: usecase
+    spec:
+      binds_to: http.server.api:POST:/orders
+      goal: Create an order
+      acceptance_criteria:
+        - Order is stored
+`
+
+func writeMaturitySpec(t *testing.T) string {
+	t.Helper()
+	path := writeSpec(t, maturityTestSpec)
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(path), "openapi.yaml"), []byte(maturityTestOpenAPI), 0644))
+	return path
+}
+
+func TestMaturity_MinLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		minLevel int
+		wantErr  string
+	}{
+		{name: "no minimum", minLevel: 0},
+		{name: "met", minLevel: 3},
+		{name: "not met", minLevel: 4, wantErr: "orders is at L3 (acceptance criteria and tests): 0 at L0, 0 at L1, 0 at L2, 2 at L3, 0 at L4, below the required L4"},
+		{name: "out of range", minLevel: 5, wantErr: "invalid --min-level 5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeMaturitySpec(t)
+
+			err := Maturity(context.Background(), path, MaturityOptions{MinLevel: tt.minLevel})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestMaturity_Dir(t *testing.T) {
+	path := writeMaturitySpec(t)
+	dir := t.TempDir()
+
+	err := Maturity(context.Background(), path, MaturityOptions{Dir: dir, MinLevel: 3})
+	require.Error(t, err, "an uncovered criterion keeps the spec below L3")
+	assert.Contains(t, err.Error(), "orders is at L2")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "orders.test.ts"), []byte("it('AC-usecase.create-order-1: stores', () => {});\n"), 0644))
+	require.NoError(t, Maturity(context.Background(), path, MaturityOptions{Dir: dir, MinLevel: 3}))
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/parser"
+	"github.com/openboundary/openboundary/internal/validator"
+)
+
+// MigrateOptions configures the migrate command.
+type MigrateOptions struct {
+	DryRun bool // Print the migrated spec instead of writing it
+}
+
+// specMigrations rewrite a spec written in a minor version of the format
+// into the next one, keyed by the minor version they migrate from. The
+// version field is updated afterwards, so a format change that only added
+// optional fields needs no entry.
+var specMigrations = map[string]func(data []byte) ([]byte, error){}
+
+// Migrate rewrites a spec written in the spec format one minor version back
+// into the format of this compiler.
+func Migrate(specFile string, opts MigrateOptions) error {
+	data, err := os.ReadFile(specFile)
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+	spec, err := parser.NewParser(specFile).ParseBytes(data)
+	if err != nil {
+		return err
+	}
+
+	current := validator.SchemaVersion()
+	switch validator.CheckSpecVersion(spec.Version) {
+	case validator.SpecVersionCurrent:
+		fmt.Printf("✓ %s is already at spec version %s\n", specFile, spec.Version)
+		return nil
+	case validator.SpecVersionTooNew:
+		return fmt.Errorf("spec version %s is newer than this compiler, which reads spec versions %s; upgrade bound instead", spec.Version, validator.SupportedSpecVersions())
+	case validator.SpecVersionTooOld:
+		return fmt.Errorf("spec version %s is too old to migrate; this compiler migrates spec versions %s", spec.Version, validator.SupportedSpecVersions())
+	}
+
+	updated, err := migrateSpec(data, spec.Version, current)
+	if err != nil {
+		return err
+	}
+	if opts.DryRun {
+		fmt.Print(string(updated))
+		return nil
+	}
+	if err := os.WriteFile(specFile, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write spec: %w", err)
+	}
+	fmt.Printf("✓ Migrated %s from spec version %s to %s\n", specFile, spec.Version, current)
+	return nil
+}
+
+var specVersionLinePattern = regexp.MustCompile(`(?m)^version:(\s*)(["']?)[^"'#\s]+(["']?)`)
+
+// migrateSpec applies the migration from the minor version of from, then
+// sets the version field to to by editing the text directly, preserving the
+// rest of the file.
+func migrateSpec(data []byte, from, to string) ([]byte, error) {
+	minor := from
+	if i := strings.LastIndex(from, "."); i >= 0 {
+		minor = from[:i]
+	}
+	if migrate, ok := specMigrations[minor]; ok {
+		var err error
+		if data, err = migrate(data); err != nil {
+			return nil, fmt.Errorf("failed to migrate spec from %s: %w", from, err)
+		}
+	}
+
+	m := specVersionLinePattern.FindSubmatchIndex(data)
+	if m == nil {
+		return nil, fmt.Errorf("spec version must be declared as a top-level \"version:\" line to be migrated")
+	}
+	line := "version:" + string(data[m[2]:m[3]]) + string(data[m[4]:m[5]]) + to + string(data[m[6]:m[7]])
+	out := append([]byte{}, data[:m[0]]...)
+	out = append(out, line...)
+	return append(out, data[m[1]:]...), nil
+}
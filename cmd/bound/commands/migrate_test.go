@@ -0,0 +1,75 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want string
+	}{
+		{
+			name: "quoted version",
+			spec: "# orders\nversion: \"0.0.1\" # spec format\nname: orders\ncomponents: []\n",
+			want: "# orders\nversion: \"0.1.0\" # spec format\nname: orders\ncomponents: []\n",
+		},
+		{
+			name: "plain version",
+			spec: "name: orders\nversion: 0.0.3\ncomponents: []\n",
+			want: "name: orders\nversion: 0.1.0\ncomponents: []\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := migrateSpec([]byte(tt.spec), "0.0.1", "0.1.0")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    string
+		wantErr string
+	}{
+		{name: "one minor version back", version: "0.0.1", want: "0.1.0"},
+		{name: "current", version: "0.1.0", want: "0.1.0"},
+		{name: "newer", version: "0.2.0", wantErr: "upgrade bound"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			specFile := filepath.Join(t.TempDir(), "spec.yaml")
+			require.NoError(t, os.WriteFile(specFile, []byte("version: \""+tt.version+"\"\nname: orders\ncomponents: []\n"), 0644))
+
+			// when
+			err := Migrate(specFile, MigrateOptions{})
+
+			// then
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			data, err := os.ReadFile(specFile)
+			require.NoError(t, err)
+			assert.Equal(t, "version: \""+tt.want+"\"\nname: orders\ncomponents: []\n", string(data))
+		})
+	}
+}
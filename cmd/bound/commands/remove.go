@@ -0,0 +1,213 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/pipeline"
+)
+
+// RemoveOptions configures the remove command.
+type RemoveOptions struct {
+	SpecFile  string
+	OutputDir string
+	Target    string // Generation target used to find the component's files
+	Layout    string // Component file layout used when the code was compiled
+	Force     bool   // Remove even if other components reference it
+	Prune     bool   // Delete generated files owned by the component
+	Tombstone bool   // Leave a comment where the component was
+}
+
+// inboundRef is a reference to the removed component from another component.
+type inboundRef struct {
+	From string
+	Type ir.EdgeType
+}
+
+func Remove(ctx context.Context, id string, opts RemoveOptions) error {
+	compileOpts := CompileOptions{Target: opts.Target, Layout: opts.Layout}
+	newRegistry, err := pluginRegistryFor(compileOpts)
+	if err != nil {
+		return err
+	}
+	layout, err := layoutFor(compileOpts)
+	if err != nil {
+		return err
+	}
+
+	p := pipeline.New(
+		pipeline.Parse(),
+		pipeline.ValidateSchema(),
+		pipeline.BuildIR(),
+	)
+	pc := &pipeline.Context{SpecPath: opts.SpecFile, OutputDir: opts.OutputDir, Ctx: ctx}
+	if err := p.Run(pc); err != nil {
+		reportDiagnostics(pc, err, defaultDiagnostics)
+		return err
+	}
+
+	comp, ok := pc.IR.Components[id]
+	if !ok {
+		return fmt.Errorf("component %q not found in %s", id, opts.SpecFile)
+	}
+
+	refs := inboundRefs(pc.IR, id)
+	if len(refs) > 0 {
+		fmt.Fprintf(os.Stderr, "%d component(s) reference %s:\n", len(refs), id)
+		for _, ref := range refs {
+			fmt.Fprintf(os.Stderr, "  - %s (%s)\n", ref.From, ref.Type)
+		}
+		if !opts.Force {
+			return fmt.Errorf("refusing to remove %q while it is referenced; update the references or pass --force", id)
+		}
+	}
+
+	// Generate in memory to learn which files the component owns. This is
+	// best-effort: a spec that cannot generate can still have components removed.
+	var owned []string
+	if err := pipeline.Generate(newRegistry).Run(pc); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not determine generated files for %s: %v\n", id, err)
+	} else if layout != nil {
+		if err := layout.Run(pc); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not determine generated files for %s: %v\n", id, err)
+		}
+	}
+	for _, artifact := range pc.Artifacts {
+		if artifact.ComponentID == id {
+			owned = append(owned, artifact.Path)
+		}
+	}
+	sort.Strings(owned)
+
+	data, err := os.ReadFile(opts.SpecFile)
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+	tombstone := ""
+	if opts.Tombstone {
+		tombstone = fmt.Sprintf("# removed: %s (%s)", id, comp.Kind)
+	}
+	updated, err := removeComponent(data, id, tombstone)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(opts.SpecFile, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write spec: %w", err)
+	}
+	fmt.Printf("✓ Removed %s from %s\n", id, opts.SpecFile)
+
+	return pruneArtifacts(opts.OutputDir, owned, opts.Prune)
+}
+
+// inboundRefs returns the components with an edge pointing at id, sorted by ID.
+func inboundRefs(i *ir.IR, id string) []inboundRef {
+	var refs []inboundRef
+	for _, edge := range i.Edges {
+		if edge.To != nil && edge.To.ID == id && edge.From != nil {
+			refs = append(refs, inboundRef{From: edge.From.ID, Type: edge.Type})
+		}
+	}
+	sort.Slice(refs, func(a, b int) bool {
+		if refs[a].From != refs[b].From {
+			return refs[a].From < refs[b].From
+		}
+		return refs[a].Type < refs[b].Type
+	})
+	return refs
+}
+
+var componentIDLinePattern = regexp.MustCompile(`^(\s*)-\s+id:\s*["']?([^"'#\s]+)["']?\s*(#.*)?$`)
+
+// removeComponent deletes the list item whose id is id by editing the text
+// directly, preserving the rest of the file. If tombstone is non-empty it is
+// left in place of the item at the item's indentation.
+func removeComponent(data []byte, id, tombstone string) ([]byte, error) {
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+
+	start := -1
+	indent := ""
+	for i, line := range lines {
+		if m := componentIDLinePattern.FindStringSubmatch(line); m != nil && m[2] == id {
+			start, indent = i, m[1]
+			break
+		}
+	}
+	if start == -1 {
+		return nil, fmt.Errorf("component %q must be declared as a list item starting with \"- id:\" to be removed", id)
+	}
+
+	// The item ends before the next line indented at or left of its dash.
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" {
+			continue
+		}
+		lineIndent := len(lines[i]) - len(strings.TrimLeft(lines[i], " "))
+		if lineIndent <= len(indent) {
+			end = i
+			break
+		}
+	}
+	// Keep blank lines separating the next item; drop the item's own trailing ones.
+	for end > start+1 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	// Drop the separator above when nothing else is left in its place.
+	if tombstone == "" && start > 0 && strings.TrimSpace(lines[start-1]) == "" &&
+		(end == len(lines) || strings.TrimSpace(lines[end]) == "") {
+		start--
+	}
+
+	out := make([]string, 0, len(lines))
+	out = append(out, lines[:start]...)
+	if tombstone != "" {
+		out = append(out, indent+tombstone)
+	}
+	out = append(out, lines[end:]...)
+	return []byte(strings.Join(out, "\n") + "\n"), nil
+}
+
+// pruneArtifacts reports, and with prune deletes, generated files that were
+// owned by a removed component.
+func pruneArtifacts(outputDir string, paths []string, prune bool) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	if !prune {
+		fmt.Printf("\n%d generated file(s) in %s/ are no longer generated (use --prune to delete them on removal):\n", len(paths), outputDir)
+		for _, path := range paths {
+			fmt.Printf("  - %s\n", path)
+		}
+		return nil
+	}
+
+	absOutput, err := filepath.Abs(outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve output directory: %w", err)
+	}
+	for _, path := range paths {
+		fullPath := filepath.Clean(filepath.Join(absOutput, path))
+		if !strings.HasPrefix(fullPath, absOutput+string(filepath.Separator)) {
+			return fmt.Errorf("artifact path %q escapes output directory", path)
+		}
+		if err := os.Remove(fullPath); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return fmt.Errorf("failed to delete %s: %w", path, err)
+		}
+		fmt.Printf("  ✗ %s\n", path)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const removeTestSpec = `version: "0.1.0"
+name: orders
+
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 3000
+      middleware:
+        - middleware.authn
+
+  - id: middleware.authn
+    kind: middleware
+    spec:
+      provider: better-auth
+      config: ./auth.config.ts
+
+  - id: usecase.list-orders
+    kind: usecase
+    spec:
+      binds_to: http.server.api:GET:/orders
+      goal: List orders
+
+  - id: usecase.create-order
+    kind: usecase
+    spec:
+      binds_to: http.server.api:POST:/orders
+      goal: Create an order
+`
+
+func TestRemoveComponent(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		tombstone string
+		want      string
+	}{
+		{
+			name: "middle item",
+			id:   "usecase.list-orders",
+			want: `version: "0.1.0"
+name: orders
+
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 3000
+      middleware:
+        - middleware.authn
+
+  - id: middleware.authn
+    kind: middleware
+    spec:
+      provider: better-auth
+      config: ./auth.config.ts
+
+  - id: usecase.create-order
+    kind: usecase
+    spec:
+      binds_to: http.server.api:POST:/orders
+      goal: Create an order
+`,
+		},
+		{
+			name: "last item",
+			id:   "usecase.create-order",
+			want: `version: "0.1.0"
+name: orders
+
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 3000
+      middleware:
+        - middleware.authn
+
+  - id: middleware.authn
+    kind: middleware
+    spec:
+      provider: better-auth
+      config: ./auth.config.ts
+
+  - id: usecase.list-orders
+    kind: usecase
+    spec:
+      binds_to: http.server.api:GET:/orders
+      goal: List orders
+`,
+		},
+		{
+			name:      "tombstone",
+			id:        "middleware.authn",
+			tombstone: "# removed: middleware.authn (middleware)",
+			want: `version: "0.1.0"
+name: orders
+
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 3000
+      middleware:
+        - middleware.authn
+
+  # removed: middleware.authn (middleware)
+
+  - id: usecase.list-orders
+    kind: usecase
+    spec:
+      binds_to: http.server.api:GET:/orders
+      goal: List orders
+
+  - id: usecase.create-order
+    kind: usecase
+    spec:
+      binds_to: http.server.api:POST:/orders
+      goal: Create an order
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := removeComponent([]byte(removeTestSpec), tt.id, tt.tombstone)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestRemoveComponent_NotFound(t *testing.T) {
+	_, err := removeComponent([]byte(removeTestSpec), "postgres.primary", "")
+	require.Error(t, err)
+}
+
+// removeCompileSpec has no external file references so it compiles as-is.
+const removeCompileSpec = `version: "0.1.0"
+name: orders
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 3000
+  - id: usecase.list-orders
+    kind: usecase
+    spec:
+      binds_to: http.server.api:GET:/orders
+      goal: List orders
+  - id: usecase.create-order
+    kind: usecase
+    spec:
+      binds_to: http.server.api:POST:/orders
+      goal: Create an order
+`
+
+func TestRemove_RefusesReferencedComponent(t *testing.T) {
+	path := writeSpec(t, removeCompileSpec)
+
+	err := Remove(context.Background(), "http.server.api", RemoveOptions{SpecFile: path, OutputDir: t.TempDir()})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+
+	content, readErr := os.ReadFile(path)
+	require.NoError(t, readErr)
+	assert.Equal(t, removeCompileSpec, string(content))
+}
+
+func TestRemove_PrunesOwnedArtifacts(t *testing.T) {
+	path := writeSpec(t, removeCompileSpec)
+	outputDir := t.TempDir()
+	require.NoError(t, Compile(context.Background(), path, CompileOptions{OutputDir: outputDir}))
+
+	owned := filepath.Join(outputDir, "src/components/usecase-create-order.usecase.ts")
+	require.FileExists(t, owned)
+
+	err := Remove(context.Background(), "usecase.create-order", RemoveOptions{SpecFile: path, OutputDir: outputDir, Prune: true})
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, owned)
+	assert.FileExists(t, filepath.Join(outputDir, "src/components/usecase-list-orders.usecase.ts"))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "usecase.create-order")
+}
+
+func TestRemove_UnknownComponent(t *testing.T) {
+	path := writeSpec(t, removeCompileSpec)
+
+	err := Remove(context.Background(), "postgres.primary", RemoveOptions{SpecFile: path, OutputDir: t.TempDir()})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/openboundary/openboundary/internal/pipeline"
+)
+
+// RollbackOptions configures the rollback command.
+type RollbackOptions struct {
+	OutputDir string
+}
+
+// Rollback restores the output directory to the compile before the latest
+// one, using the content kept in its history.
+func Rollback(opts RollbackOptions) error {
+	changes, current, err := pipeline.Rollback(opts.OutputDir)
+	if err != nil {
+		return err
+	}
+	for _, c := range changes {
+		fmt.Printf("  %s %s\n", diffMarks[c.Status], c.Path)
+	}
+	fmt.Printf("✓ Restored %d file(s) in %s/ to compile #%d\n", len(changes), opts.OutputDir, current.Seq)
+	return nil
+}
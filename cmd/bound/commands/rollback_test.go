@@ -0,0 +1,79 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollback(t *testing.T) {
+	// given
+	path := writeSpec(t, addTestSpec)
+	out := t.TempDir()
+	opts := CompileOptions{OutputDir: out, History: 5, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON}}
+	require.NoError(t, Compile(context.Background(), path, opts))
+	before, err := os.ReadFile(filepath.Join(out, "README.md"))
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(addTestSpec+`
+  - id: usecase.refund-order
+    kind: usecase
+    spec:
+      binds_to: http.server.api:POST:/refunds
+      goal: Refund an order
+`), 0644))
+	require.NoError(t, Compile(context.Background(), path, opts))
+	require.NoError(t, Diff(DiffOptions{OutputDir: out, Against: "previous"}))
+	refundFiles := filepath.Join(out, "src", "components", "*refund-order*")
+	matches, err := filepath.Glob(refundFiles)
+	require.NoError(t, err)
+	require.NotEmpty(t, matches)
+
+	// when
+	err = Rollback(RollbackOptions{OutputDir: out})
+
+	// then
+	require.NoError(t, err)
+	after, err := os.ReadFile(filepath.Join(out, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(after))
+	matches, err = filepath.Glob(refundFiles)
+	require.NoError(t, err)
+	assert.Empty(t, matches, "files added by the rolled back compile are deleted")
+
+	assert.ErrorContains(t, Rollback(RollbackOptions{OutputDir: out}), "no previous compile")
+}
+
+func TestDiff_Against(t *testing.T) {
+	tests := []struct {
+		against string
+		wantErr string
+	}{
+		{against: "previous", wantErr: "has 1 compile(s) in its history, need 2"},
+		{against: "3", wantErr: "need 4"},
+		{against: "0", wantErr: `invalid --against "0"`},
+		{against: "last", wantErr: `invalid --against "last"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.against, func(t *testing.T) {
+			// given
+			out := t.TempDir()
+			require.NoError(t, Compile(context.Background(), writeSpec(t, addTestSpec), CompileOptions{
+				OutputDir: out, History: 5, DiagnosticOptions: DiagnosticOptions{Format: FormatJSON},
+			}))
+
+			// when
+			err := Diff(DiffOptions{OutputDir: out, Against: tt.against})
+
+			// then
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,328 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/openboundary/openboundary/internal/pipeline"
+	"github.com/openboundary/openboundary/internal/validator"
+)
+
+// DefaultMaxRequestBytes is the default size limit of a spec sent to the
+// compiler API.
+const DefaultMaxRequestBytes = 1 << 20
+
+// apiSpecPath names specs received by the compiler API in diagnostics.
+// Relative file references resolve against ServeOptions.Root.
+const apiSpecPath = "spec.yaml"
+
+// apiContentType is the media type of the specs the compiler API accepts.
+// Browsers cannot send it without a CORS preflight, so pages on other
+// origins cannot make requests the server did not allow.
+const apiContentType = "application/yaml"
+
+// ServeOptions configures the serve command.
+type ServeOptions struct {
+	API             bool     // Serve the compiler API; the only mode so far
+	Addr            string   // Address to listen on, e.g. 127.0.0.1:8787
+	MaxRequestBytes int64    // Largest spec accepted
+	WriteDir        string   // Directory compile may write into; empty disables writes
+	Root            string   // Directory the files specs reference are read from; nothing outside it is read
+	AllowedOrigins  []string // Browser origins allowed to call the API, e.g. https://editor.example.com
+}
+
+// Serve runs the compiler as an HTTP API until ctx is done.
+func Serve(ctx context.Context, opts ServeOptions) error {
+	if !opts.API {
+		return fmt.Errorf("nothing to serve: pass --api to serve the compiler API")
+	}
+	handler, err := NewAPIHandler(opts)
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	fmt.Printf("Serving the compiler API on http://%s\n", ln.Addr())
+	if opts.WriteDir != "" {
+		fmt.Printf("Compile requests may write to %s/\n", opts.WriteDir)
+	}
+
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// apiHandler serves the compiler API. Every endpoint takes a spec as the
+// POST body.
+type apiHandler struct {
+	opts      ServeOptions
+	validator pipeline.SchemaValidator
+	specFS    fs.FS      // opts.Root, which confines the files specs reference
+	writeMu   sync.Mutex // Serializes compiles that write to opts.WriteDir
+}
+
+// NewAPIHandler returns the handler of the compiler API:
+//
+//	POST /v1/validate  validate a spec and return its diagnostics
+//	POST /v1/ir        return the component graph of a valid spec
+//	POST /v1/compile   return the generated files as a tar archive, or
+//	                   write them to opts.WriteDir with ?write=true
+//
+// compile accepts the target, layout, go_client and timestamp query
+// parameters of the compile command's flags. Nothing is written to disk
+// unless opts.WriteDir is set, and writes record no report or history.
+// Specs are sent as application/yaml, and requests from browser origins
+// not in opts.AllowedOrigins are rejected.
+func NewAPIHandler(opts ServeOptions) (http.Handler, error) {
+	if opts.MaxRequestBytes <= 0 {
+		opts.MaxRequestBytes = DefaultMaxRequestBytes
+	}
+	if opts.Root == "" {
+		opts.Root = "."
+	}
+	if info, err := os.Stat(opts.Root); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("root %s is not a directory", opts.Root)
+	}
+	v, err := validator.NewJSONSchemaValidator()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize schema validator: %w", err)
+	}
+	h := &apiHandler{opts: opts, validator: v, specFS: os.DirFS(opts.Root)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/validate", h.post(h.validate))
+	mux.HandleFunc("/v1/ir", h.post(h.buildIR))
+	mux.HandleFunc("/v1/compile", h.post(h.compile))
+	return mux, nil
+}
+
+// post rejects requests from origins that are not allowed, answers their
+// CORS preflights, rejects requests other than POST of application/yaml and
+// reads the spec in the body, up to the size limit, before calling next.
+func (h *apiHandler) post(next func(w http.ResponseWriter, r *http.Request, spec []byte)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" {
+			if !slices.Contains(h.opts.AllowedOrigins, origin) {
+				writeAPIError(w, http.StatusForbidden, fmt.Sprintf("origin %s is not allowed; start serve with --allow-origin %s to allow it", origin, origin))
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Vary", "Origin")
+			if r.Method == http.MethodOptions {
+				w.Header().Set("Access-Control-Allow-Methods", http.MethodPost)
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeAPIError(w, http.StatusMethodNotAllowed, "send the spec with POST")
+			return
+		}
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != apiContentType {
+			writeAPIError(w, http.StatusUnsupportedMediaType, "send the spec with Content-Type: "+apiContentType)
+			return
+		}
+		spec, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.opts.MaxRequestBytes))
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			writeAPIError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("spec exceeds %d bytes", tooLarge.Limit))
+			return
+		case err != nil:
+			writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("failed to read spec: %v", err))
+			return
+		}
+		next(w, r, spec)
+	}
+}
+
+// run runs the stages on a spec received by the API and writes the
+// diagnostics as the response when the run fails. It reports whether the run succeeded.
+func (h *apiHandler) run(w http.ResponseWriter, r *http.Request, pc *pipeline.Context, stages ...pipeline.Stage) bool {
+	pc.SpecPath = apiSpecPath
+	pc.SpecFS = h.specFS
+	pc.Quiet = true
+	pc.Ctx = r.Context()
+	err := pipeline.New(stages...).Run(pc)
+	if err == nil {
+		return true
+	}
+
+	// Invalid specs are the client's fault; anything later is ours
+	status := http.StatusInternalServerError
+	switch pipeline.FailedStage(err) {
+	case pipeline.StageParse, pipeline.StageValidateSchema, pipeline.StageBuildIR, pipeline.StageValidateIR, pipeline.StageCheckTarget:
+		status = http.StatusUnprocessableEntity
+	}
+	writeAPIJSON(w, status, newDiagnosticReport(pipeline.Diagnostics(pc, err, messageLanguage)))
+	return false
+}
+
+// validateStages returns the stages that parse and validate spec.
+func (h *apiHandler) validateStages(spec []byte) []pipeline.Stage {
+	return []pipeline.Stage{
+		pipeline.ParseBytes(spec),
+		pipeline.ValidateSchemaWith(h.validator),
+		pipeline.BuildIR(),
+		pipeline.ValidateIR(),
+	}
+}
+
+func (h *apiHandler) validate(w http.ResponseWriter, r *http.Request, spec []byte) {
+	pc := &pipeline.Context{}
+	if h.run(w, r, pc, h.validateStages(spec)...) {
+		writeAPIJSON(w, http.StatusOK, newDiagnosticReport(pipeline.Diagnostics(pc, nil, messageLanguage)))
+	}
+}
+
+func (h *apiHandler) buildIR(w http.ResponseWriter, r *http.Request, spec []byte) {
+	pc := &pipeline.Context{}
+	if h.run(w, r, pc, h.validateStages(spec)...) {
+		writeAPIJSON(w, http.StatusOK, pc.IR.Graph())
+	}
+}
+
+// apiWriteResult is the response of a compile that wrote its files.
+type apiWriteResult struct {
+	Written int                   `json:"written"`
+	Skipped int                   `json:"skipped"`
+	Files   []pipeline.ReportFile `json:"files"`
+}
+
+func (h *apiHandler) compile(w http.ResponseWriter, r *http.Request, spec []byte) {
+	query := r.URL.Query()
+	opts := CompileOptions{
+		OutputDir: h.opts.WriteDir,
+		Target:    query.Get("target"),
+		Layout:    query.Get("layout"),
+		GoClient:  query.Get("go_client") == "true",
+		Timestamp: query.Get("timestamp") == "true",
+	}
+	write := query.Get("write") == "true"
+	if write && h.opts.WriteDir == "" {
+		writeAPIError(w, http.StatusForbidden, "writes are disabled; start serve with --write-dir to enable them")
+		return
+	}
+	newRegistry, err := pluginRegistryFor(opts)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	layout, err := layoutFor(opts)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stages := append(h.validateStages(spec), pipeline.CheckTarget(opts.Target), pipeline.Generate(newRegistry), stamperFor(opts), pipeline.ScanSecrets())
+	// The ADR and merge stages read the previous output, so only a compile
+	// that writes runs them.
+	if write {
+		stages = append(stages, pipeline.RecordADR())
+	}
+	if layout != nil {
+		stages = append(stages, layout)
+	}
+	if write {
+		if merged := mergedFilesFor(opts); len(merged) > 0 {
+			stages = append(stages, pipeline.Merge(merged...))
+		}
+		stages = append(stages, pipeline.Write())
+		h.writeMu.Lock()
+		defer h.writeMu.Unlock()
+	}
+
+	pc := &pipeline.Context{OutputDir: opts.OutputDir}
+	if !h.run(w, r, pc, stages...) {
+		return
+	}
+	if !write {
+		writeTar(w, pc)
+		return
+	}
+
+	result := apiWriteResult{Files: []pipeline.ReportFile{}}
+	for _, f := range pc.Files {
+		file := pipeline.ReportFile{Path: f.Path, SHA256: f.SHA256, Size: f.Size, Status: "written"}
+		if f.Skipped {
+			file.Status = "skipped"
+			result.Skipped++
+		} else {
+			result.Written++
+		}
+		result.Files = append(result.Files, file)
+	}
+	writeAPIJSON(w, http.StatusOK, result)
+}
+
+// writeTar responds with the artifacts of pc as a tar archive.
+func writeTar(w http.ResponseWriter, pc *pipeline.Context) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, artifact := range pc.Artifacts {
+		hdr := &tar.Header{
+			Name:    artifact.Path,
+			Mode:    0o644,
+			Size:    int64(len(artifact.Content)),
+			ModTime: time.Unix(0, 0),
+			Format:  tar.FormatPAX,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if _, err := tw.Write(artifact.Content); err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	if err := tw.Close(); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", pc.AST.Name+".tar"))
+	_, _ = w.Write(buf.Bytes())
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	writeAPIJSON(w, status, map[string]string{"error": message})
+}
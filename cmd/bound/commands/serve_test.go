@@ -0,0 +1,230 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const apiSpec = `version: "0.1.0"
+name: orders
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 3000
+  - id: usecase.list-orders
+    kind: usecase
+    spec:
+      binds_to: http.server.api:GET:/orders
+      goal: List orders
+`
+
+func newAPIServer(t *testing.T, opts ServeOptions) *httptest.Server {
+	t.Helper()
+	handler, err := NewAPIHandler(opts)
+	require.NoError(t, err)
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func postSpec(t *testing.T, url, spec string) *http.Response {
+	t.Helper()
+	resp, err := http.Post(url, apiContentType, strings.NewReader(spec))
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestServeAPI_Validate(t *testing.T) {
+	// given
+	srv := newAPIServer(t, ServeOptions{})
+
+	// when
+	valid := postSpec(t, srv.URL+"/v1/validate", apiSpec)
+	invalid := postSpec(t, srv.URL+"/v1/validate", strings.Replace(apiSpec, "binds_to: http.server.api", "binds_to: http.server.admin", 1))
+
+	// then
+	assert.Equal(t, http.StatusOK, valid.StatusCode)
+	var report diagnosticReport
+	require.NoError(t, json.NewDecoder(valid.Body).Decode(&report))
+	assert.Equal(t, 0, report.Errors)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, invalid.StatusCode)
+	require.NoError(t, json.NewDecoder(invalid.Body).Decode(&report))
+	require.Equal(t, 2, report.Errors)
+	assert.Equal(t, pipeline.StageBuildIR, report.Diagnostics[0].Stage)
+	assert.Contains(t, report.Diagnostics[0].Message+report.Diagnostics[1].Message, `server "http.server.admin" not found`)
+}
+
+func TestServeAPI_IR(t *testing.T) {
+	// given
+	srv := newAPIServer(t, ServeOptions{})
+
+	// when
+	resp := postSpec(t, srv.URL+"/v1/ir", apiSpec)
+
+	// then
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var graph ir.Graph
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&graph))
+	assert.Equal(t, "orders", graph.Name)
+	require.Len(t, graph.Components, 2)
+	assert.Equal(t, "usecase.list-orders", graph.Components[1].ID)
+	assert.Equal(t, []string{"http.server.api"}, graph.Components[1].Dependencies)
+	assert.NotEmpty(t, graph.Edges)
+}
+
+func TestServeAPI_CompileTar(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	srv := newAPIServer(t, ServeOptions{})
+
+	// when
+	resp := postSpec(t, srv.URL+"/v1/compile", apiSpec)
+
+	// then
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-tar", resp.Header.Get("Content-Type"))
+	var names []string
+	tr := tar.NewReader(resp.Body)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+	}
+	assert.Contains(t, names, "src/components/usecase-list-orders.usecase.ts")
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestServeAPI_CompileWrite(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	readOnly := newAPIServer(t, ServeOptions{})
+	writable := newAPIServer(t, ServeOptions{WriteDir: dir})
+
+	// when
+	denied := postSpec(t, readOnly.URL+"/v1/compile?write=true", apiSpec)
+	written := postSpec(t, writable.URL+"/v1/compile?write=true", apiSpec)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, denied.StatusCode)
+	require.Equal(t, http.StatusOK, written.StatusCode)
+	var result apiWriteResult
+	require.NoError(t, json.NewDecoder(written.Body).Decode(&result))
+	assert.Equal(t, len(result.Files), result.Written)
+	assert.FileExists(t, filepath.Join(dir, "src/components/usecase-list-orders.usecase.ts"))
+}
+
+func TestServeAPI_RejectsRequests(t *testing.T) {
+	// given
+	srv := newAPIServer(t, ServeOptions{MaxRequestBytes: 64})
+
+	// when
+	get, err := http.Get(srv.URL + "/v1/validate")
+	require.NoError(t, err)
+	defer get.Body.Close()
+	plain, err := http.Post(srv.URL+"/v1/validate", "text/plain", strings.NewReader("name: x\n"))
+	require.NoError(t, err)
+	defer plain.Body.Close()
+	large := postSpec(t, srv.URL+"/v1/validate", apiSpec)
+	target := postSpec(t, srv.URL+"/v1/compile?target=rust", "name: x\n")
+
+	// then
+	assert.Equal(t, http.StatusMethodNotAllowed, get.StatusCode)
+	assert.Equal(t, http.StatusUnsupportedMediaType, plain.StatusCode)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, large.StatusCode)
+	assert.Equal(t, http.StatusBadRequest, target.StatusCode)
+}
+
+func TestServeAPI_Origins(t *testing.T) {
+	// given
+	srv := newAPIServer(t, ServeOptions{AllowedOrigins: []string{"https://editor.example.com"}})
+	request := func(method, origin string) *http.Response {
+		req, err := http.NewRequest(method, srv.URL+"/v1/validate", strings.NewReader(apiSpec))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", apiContentType)
+		req.Header.Set("Origin", origin)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	// when
+	foreign := request(http.MethodPost, "https://attacker.example.com")
+	preflight := request(http.MethodOptions, "https://editor.example.com")
+	allowed := request(http.MethodPost, "https://editor.example.com")
+
+	// then
+	assert.Equal(t, http.StatusForbidden, foreign.StatusCode)
+	assert.Empty(t, foreign.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, http.StatusNoContent, preflight.StatusCode)
+	assert.Equal(t, "Content-Type", preflight.Header.Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, http.StatusOK, allowed.StatusCode)
+	assert.Equal(t, "https://editor.example.com", allowed.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestServeAPI_ConfinesFilesToRoot(t *testing.T) {
+	// given: a file next to the root that a spec must not read
+	parent := t.TempDir()
+	root := filepath.Join(parent, "root")
+	require.NoError(t, os.Mkdir(root, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(parent, "secret.ts"), []byte("secret"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "schema.ts"), []byte("export {};\n"), 0644))
+	srv := newAPIServer(t, ServeOptions{Root: root})
+	withSchema := func(schema string) string {
+		return apiSpec + `  - id: postgres.primary
+    kind: postgres
+    spec:
+      provider: drizzle
+      schema: ` + schema + "\n"
+	}
+
+	for _, schema := range []string{"./../secret.ts", "./sub/../../secret.ts"} {
+		t.Run(schema, func(t *testing.T) {
+			// when
+			resp := postSpec(t, srv.URL+"/v1/compile", withSchema(schema))
+
+			// then
+			require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), "is outside the directory the spec's files are read from")
+			assert.NotContains(t, string(body), "secret\"")
+		})
+	}
+
+	t.Run("inside the root", func(t *testing.T) {
+		resp := postSpec(t, srv.URL+"/v1/compile", withSchema("./schema.ts"))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestServe_NeedsAPI(t *testing.T) {
+	err := Serve(context.Background(), ServeOptions{})
+	assert.EqualError(t, err, "nothing to serve: pass --api to serve the compiler API")
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/pipeline"
+	"github.com/openboundary/openboundary/internal/validator"
+)
+
+// StatsOptions configures the stats command.
+type StatsOptions struct {
+	OutputDir string // Output directory whose last compile report references are compared with
+}
+
+// Stats prints how many components of each kind the spec declares, and the
+// deprecated components with the components that still reference them. A
+// reference whose warning the last compile into OutputDir did not report is
+// marked new.
+func Stats(ctx context.Context, specFile string, opts StatsOptions) error {
+	return writeStats(ctx, os.Stdout, specFile, opts)
+}
+
+func writeStats(ctx context.Context, w io.Writer, specFile string, opts StatsOptions) error {
+	p := pipeline.New(
+		pipeline.Parse(),
+		pipeline.ValidateSchema(),
+		pipeline.BuildIR(),
+		pipeline.ValidateIR(),
+	)
+	pc := &pipeline.Context{SpecPath: specFile, Ctx: ctx}
+	if err := p.Run(pc); err != nil {
+		reportDiagnostics(pc, err, defaultDiagnostics)
+		return err
+	}
+	reported, err := reportedWarnings(opts.OutputDir)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[ir.Kind]int)
+	for _, comp := range pc.IR.Components {
+		counts[comp.Kind]++
+	}
+	fmt.Fprintf(w, "%s: %d components\n", pc.IR.Spec.Name, len(pc.IR.Components))
+	for _, kind := range ir.AllKinds() {
+		if counts[kind] > 0 {
+			fmt.Fprintf(w, "  %-14s %d\n", kind, counts[kind])
+		}
+	}
+
+	referrers := make(map[string][]ir.DeprecatedReference)
+	for _, ref := range pc.IR.DeprecatedReferences() {
+		referrers[ref.To.ID] = append(referrers[ref.To.ID], ref)
+	}
+	var deprecated []*ir.Component
+	for _, comp := range pc.IR.Components {
+		if comp.Deprecated {
+			deprecated = append(deprecated, comp)
+		}
+	}
+	if len(deprecated) == 0 {
+		return nil
+	}
+	slices.SortFunc(deprecated, func(a, b *ir.Component) int { return strings.Compare(a.ID, b.ID) })
+
+	fmt.Fprintf(w, "\nDeprecated components:\n")
+	for _, comp := range deprecated {
+		heading := comp.ID
+		if comp.Replacement != "" {
+			heading += " (use " + comp.Replacement + " instead)"
+		}
+		refs := referrers[comp.ID]
+		if len(refs) == 0 {
+			fmt.Fprintf(w, "  %s: no longer referenced\n", heading)
+			continue
+		}
+		fmt.Fprintf(w, "  %s: referenced by\n", heading)
+		for _, ref := range refs {
+			warning := validator.DeprecatedReferenceWarning(ref).Localize(messageLanguage)
+			if reported != nil && !reported[ref.From.ID+"\x00"+warning.Message] {
+				fmt.Fprintf(w, "    %s (new since the last compile)\n", ref.From.ID)
+			} else {
+				fmt.Fprintf(w, "    %s\n", ref.From.ID)
+			}
+		}
+	}
+	if reported == nil {
+		fmt.Fprintf(w, "\nNo compile report in %s, so new references cannot be told from existing ones.\n", opts.OutputDir)
+	}
+	return nil
+}
+
+// reportedWarnings returns the deprecated-reference warnings of the last
+// compile into outputDir, keyed by component and message, or nil when
+// nothing was compiled there.
+func reportedWarnings(outputDir string) (map[string]bool, error) {
+	report, err := pipeline.LoadReport(outputDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	reported := make(map[string]bool)
+	for _, d := range report.Diagnostics {
+		if d.Rule == validator.RuleDeprecatedReference {
+			reported[d.Component+"\x00"+d.Message] = true
+		}
+	}
+	return reported, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/pipeline"
+	"github.com/openboundary/openboundary/internal/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const statsTestSpec = `version: "0.1.0"
+name: orders
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 3000
+  - id: http.server.legacy
+    kind: http.server
+    deprecated: true
+    replacement: http.server.api
+    spec:
+      framework: hono
+      port: 3001
+  - id: http.server.retired
+    kind: http.server
+    deprecated: true
+    spec:
+      framework: hono
+      port: 3002
+  - id: usecase.list-orders
+    kind: usecase
+    spec:
+      binds_to: http.server.legacy:GET:/orders
+      goal: List orders
+  - id: usecase.create-order
+    kind: usecase
+    spec:
+      binds_to: http.server.legacy:POST:/orders
+      goal: Create an order
+`
+
+// writeStatsReport records a compile into dir that warned about the
+// references of usecase.list-orders only.
+func writeStatsReport(t *testing.T, dir string) {
+	t.Helper()
+	report := pipeline.Report{Diagnostics: []pipeline.Diagnostic{{
+		Severity:  pipeline.SeverityWarning,
+		Component: "usecase.list-orders",
+		Message:   "references deprecated http.server http.server.legacy; use http.server.api instead",
+		Rule:      validator.RuleDeprecatedReference,
+	}}}
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	path := filepath.Join(dir, pipeline.ReportPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+func TestStats(t *testing.T) {
+	path := writeSpec(t, statsTestSpec)
+	outputDir := t.TempDir()
+	writeStatsReport(t, outputDir)
+
+	var out bytes.Buffer
+	err := writeStats(context.Background(), &out, path, StatsOptions{OutputDir: outputDir})
+	require.NoError(t, err)
+
+	assert.Equal(t, `orders: 5 components
+  http.server    3
+  usecase        2
+
+Deprecated components:
+  http.server.legacy (use http.server.api instead): referenced by
+    usecase.create-order (new since the last compile)
+    usecase.list-orders
+  http.server.retired: no longer referenced
+`, out.String())
+}
+
+func TestStats_WithoutReport(t *testing.T) {
+	path := writeSpec(t, statsTestSpec)
+	outputDir := t.TempDir()
+
+	var out bytes.Buffer
+	err := writeStats(context.Background(), &out, path, StatsOptions{OutputDir: outputDir})
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "    usecase.create-order\n    usecase.list-orders\n")
+	assert.Contains(t, out.String(), "No compile report in "+outputDir)
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/openboundary/openboundary/internal/coverage"
+	"github.com/openboundary/openboundary/internal/pipeline"
+)
+
+// TestOptions configures the test command.
+type TestOptions struct {
+	CoverageSpec bool   // Check that every acceptance criterion has an active test
+	Dir          string // Directory searched for *.test.* and *.spec.* files
+}
+
+func Test(ctx context.Context, specFile string, opts TestOptions) error {
+	if !opts.CoverageSpec {
+		return errors.New("nothing to do: bound test currently supports --coverage-spec only")
+	}
+
+	p := pipeline.New(
+		pipeline.Parse(),
+		pipeline.ValidateSchema(),
+		pipeline.BuildIR(),
+	)
+	pc := &pipeline.Context{SpecPath: specFile, Ctx: ctx}
+	if err := p.Run(pc); err != nil {
+		reportDiagnostics(pc, err, defaultDiagnostics)
+		return err
+	}
+
+	criteria := coverage.Criteria(pc.IR)
+	if len(criteria) == 0 {
+		fmt.Printf("✓ %s declares no acceptance criteria\n", specFile)
+		return nil
+	}
+
+	covered, err := coverage.ScanDir(opts.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to scan tests: %w", err)
+	}
+
+	missing := coverage.Uncovered(criteria, covered)
+	if len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d acceptance criteria have no active test in %s:\n", len(missing), len(criteria), opts.Dir)
+		for _, c := range missing {
+			fmt.Fprintf(os.Stderr, "  - %s: %s\n", c.ID, c.Text)
+		}
+		return fmt.Errorf("acceptance criteria coverage incomplete (%d missing)", len(missing))
+	}
+
+	fmt.Printf("✓ All %d acceptance criteria are covered by tests in %s\n", len(criteria), opts.Dir)
+	return nil
+}
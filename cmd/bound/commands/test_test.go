@@ -0,0 +1,76 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const coverageTestSpec = `version: "0.1.0"
+name: orders
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 3000
+  - id: usecase.create-order
+    kind: usecase
+    spec:
+      binds_to: http.server.api:POST:/orders
+      goal: Create an order
+      acceptance_criteria:
+        - Order is stored
+        - Confirmation is sent
+`
+
+func TestTest_RequiresCoverageSpec(t *testing.T) {
+	path := writeSpec(t, coverageTestSpec)
+
+	err := Test(context.Background(), path, TestOptions{Dir: t.TempDir()})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--coverage-spec")
+}
+
+func TestTest_CoverageSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr bool
+	}{
+		{
+			name: "all covered",
+			source: "it('AC-usecase.create-order-1: stores', () => {});\n" +
+				"it('AC-usecase.create-order-2: confirms', () => {});\n",
+		},
+		{
+			name: "todo does not count",
+			source: "it('AC-usecase.create-order-1: stores', () => {});\n" +
+				"it.todo('AC-usecase.create-order-2: confirms');\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeSpec(t, coverageTestSpec)
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "orders.test.ts"), []byte(tt.source), 0644))
+
+			err := Test(context.Background(), path, TestOptions{CoverageSpec: true, Dir: dir})
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "1 missing")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
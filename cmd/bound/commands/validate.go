@@ -4,27 +4,68 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/openboundary/openboundary/internal/pipeline"
+	"github.com/openboundary/openboundary/internal/validator"
 )
 
-func Validate(specFile string) error {
-	p := pipeline.New(
+// ValidateOptions configures the validate command.
+type ValidateOptions struct {
+	FailOnUnused bool          // Treat unused-component warnings as errors
+	CheckLinks   bool          // Request component links and warn about dead ones
+	LinkTimeout  time.Duration // Timeout of each link request
+	DiagnosticOptions
+}
+
+func Validate(ctx context.Context, specFile string, opts ValidateOptions) error {
+	if err := validateFormat(opts.DiagnosticOptions); err != nil {
+		return err
+	}
+	stages := []pipeline.Stage{
 		pipeline.Parse(),
 		pipeline.ValidateSchema(),
 		pipeline.BuildIR(),
 		pipeline.ValidateIR(),
-	)
+	}
+	if opts.CheckLinks {
+		stages = append(stages, pipeline.CheckLinks(opts.LinkTimeout))
+	}
+	p := pipeline.New(stages...)
 
-	ctx := &pipeline.Context{SpecPath: specFile}
+	pc := &pipeline.Context{SpecPath: specFile, Ctx: ctx}
 
-	if err := p.Run(ctx); err != nil {
-		printStageError(err)
+	err := p.Run(pc)
+	reportDiagnostics(pc, err, opts.DiagnosticOptions)
+	if err != nil {
 		return err
 	}
 
+	if opts.FailOnUnused {
+		if unused := countRule(pc.Warnings, validator.RuleUnusedComponent); unused > 0 {
+			return pipeline.WithStage(pipeline.StageValidateIR, fmt.Errorf("%d unused component(s) (--fail-on-unused)", unused))
+		}
+	}
+
+	if opts.Format == FormatJSON {
+		return nil
+	}
 	fmt.Printf("✓ %s is valid (version: %s, name: %s, %d components)\n",
-		specFile, ctx.AST.Version, ctx.AST.Name, len(ctx.AST.Components))
+		specFile, pc.AST.Version, pc.AST.Name, len(pc.AST.Components))
 	return nil
 }
+
+// countRule returns the number of warnings produced by the given rule.
+func countRule(warnings []error, rule string) int {
+	n := 0
+	for _, w := range warnings {
+		var ve validator.ValidationError
+		if errors.As(w, &ve) && ve.Rule == rule {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const unusedComponentSpec = `version: "0.1.0"
+name: orders
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 3000
+  - id: usecase.list-orders
+    kind: usecase
+    spec:
+      binds_to: http.server.api:GET:/orders
+      goal: List orders
+  - id: postgres.legacy
+    kind: postgres
+    spec:
+      provider: drizzle
+      schema: ./schema.ts
+`
+
+func TestValidate_UnusedComponentIsWarning(t *testing.T) {
+	path := writeSpec(t, unusedComponentSpec)
+
+	err := Validate(context.Background(), path, ValidateOptions{})
+	require.NoError(t, err)
+}
+
+func TestValidate_FailOnUnused(t *testing.T) {
+	path := writeSpec(t, unusedComponentSpec)
+
+	err := Validate(context.Background(), path, ValidateOptions{FailOnUnused: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 unused component(s)")
+}
+
+func TestValidate_CheckLinksReportsDeadLinksAsWarnings(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	spec := strings.Replace(unusedComponentSpec, "  - id: postgres.legacy\n",
+		"    links:\n      - title: Runbook\n        url: "+srv.URL+"/runbook\n  - id: postgres.legacy\n", 1)
+	path := writeSpec(t, spec)
+
+	err := Validate(context.Background(), path, ValidateOptions{CheckLinks: true, LinkTimeout: time.Second})
+	require.NoError(t, err)
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/codegen/golang"
+	"github.com/openboundary/openboundary/internal/codegen/python"
+	"github.com/openboundary/openboundary/internal/codegen/typescript"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/validator"
+	"github.com/openboundary/openboundary/templates"
+)
+
+// releaseVersionPattern matches the module version of a tagged release,
+// e.g. "v1.2.0" or "v1.3.0-rc.1".
+var releaseVersionPattern = regexp.MustCompile(`^v\d+\.\d+\.\d+(-[0-9A-Za-z.]+)?$`)
+
+// pseudoVersionPattern matches the end of a pseudo-version, which go gives
+// untagged commits, e.g. "v0.0.0-20261016120000-0123456789ab".
+var pseudoVersionPattern = regexp.MustCompile(`[.-]\d{14}-[0-9a-f]{12}$`)
+
+// ResolveVersion returns the version of the running binary: stamped, which
+// release builds set with -X main.version, or the module version go install
+// records for a tagged release. Builds from source are "dev", whatever their
+// commit, since their generators can differ from any release.
+func ResolveVersion(stamped string) string {
+	info, _ := debug.ReadBuildInfo()
+	return resolveVersion(stamped, info)
+}
+
+func resolveVersion(stamped string, info *debug.BuildInfo) string {
+	if stamped != "" && stamped != "dev" {
+		return stamped
+	}
+	if info != nil {
+		v := info.Main.Version
+		if releaseVersionPattern.MatchString(v) && !pseudoVersionPattern.MatchString(v) {
+			return strings.TrimPrefix(v, "v")
+		}
+	}
+	return "dev"
+}
+
+// VersionOptions configures the version command.
+type VersionOptions struct {
+	Verbose bool
+}
+
+// versionInfo is what a bound binary embeds and supports.
+type versionInfo struct {
+	GoVersion           string
+	Platform            string
+	SchemaVersion       string
+	Kinds               []string
+	Templates           []string
+	Targets             map[string][]string // Generator plugins per target
+	GoClient            string
+	MiddlewareProviders []string
+	DatabaseProviders   []string
+}
+
+// targetRegistries are the registries of the compile targets, in the order
+// they are listed.
+var targetRegistries = []struct {
+	Name        string
+	NewRegistry func() (*codegen.PluginRegistry, error)
+}{
+	{"typescript", typescript.NewPluginRegistry},
+	{"python", python.NewPluginRegistry},
+}
+
+// PrintVersion prints the compiler version and, when verbose, the schema,
+// templates, component kinds, generators and providers built into it.
+func PrintVersion(opts VersionOptions) error {
+	return writeVersion(os.Stdout, opts)
+}
+
+func writeVersion(w io.Writer, opts VersionOptions) error {
+	fmt.Fprintf(w, "bound version %s\n", Version)
+	if !opts.Verbose {
+		return nil
+	}
+
+	info, err := collectVersionInfo()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "  Go:                    %s %s\n", info.GoVersion, info.Platform)
+	fmt.Fprintf(w, "  Schema:                v%s (embedded)\n", info.SchemaVersion)
+	fmt.Fprintf(w, "  Templates:             %s (embedded)\n", strings.Join(info.Templates, ", "))
+	fmt.Fprintf(w, "  Component kinds:       %s\n", strings.Join(info.Kinds, ", "))
+	fmt.Fprintf(w, "  Middleware providers:  %s\n", strings.Join(info.MiddlewareProviders, ", "))
+	fmt.Fprintf(w, "  Database providers:    %s\n", strings.Join(info.DatabaseProviders, ", "))
+	fmt.Fprintln(w, "  Targets:")
+	for _, target := range targetRegistries {
+		fmt.Fprintf(w, "    %-12s %s\n", target.Name+":", strings.Join(info.Targets[target.Name], ", "))
+	}
+	fmt.Fprintf(w, "    %-12s %s\n", "--go-client:", info.GoClient)
+	return nil
+}
+
+// collectVersionInfo reads what the binary supports from the registries, so
+// the listing cannot fall out of step with them.
+func collectVersionInfo() (*versionInfo, error) {
+	info := &versionInfo{
+		GoVersion:           runtime.Version(),
+		Platform:            runtime.GOOS + "/" + runtime.GOARCH,
+		SchemaVersion:       validator.SchemaVersion(),
+		Templates:           templates.Names(),
+		Targets:             make(map[string][]string),
+		GoClient:            golang.ClientPlugin().Name,
+		MiddlewareProviders: typescript.MiddlewareProviders(),
+		DatabaseProviders:   typescript.DatabaseProviders(),
+	}
+	for _, kind := range ir.AllKinds() {
+		info.Kinds = append(info.Kinds, string(kind))
+	}
+	for _, target := range targetRegistries {
+		registry, err := target.NewRegistry()
+		if err != nil {
+			return nil, fmt.Errorf("%s generators: %w", target.Name, err)
+		}
+		info.Targets[target.Name] = registry.Names()
+	}
+	return info, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+
+	"github.com/openboundary/openboundary/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteVersion(t *testing.T) {
+	// when
+	var out bytes.Buffer
+	require.NoError(t, writeVersion(&out, VersionOptions{}))
+
+	// then
+	assert.Equal(t, "bound version "+Version+"\n", out.String())
+}
+
+func TestWriteVersion_Verbose(t *testing.T) {
+	// when
+	var out bytes.Buffer
+	require.NoError(t, writeVersion(&out, VersionOptions{Verbose: true}))
+
+	// then
+	for _, want := range []string{
+		"Schema:                v0.1.0 (embedded)",
+		"Templates:             basic, blank (embedded)",
+		"http.server, http.gateway, middleware, postgres",
+		"Middleware providers:  better-auth, casbin",
+		"Database providers:    drizzle",
+		"typescript:  typescript-project,",
+		"python:      python-project,",
+		"--go-client: go-client",
+	} {
+		assert.Contains(t, out.String(), want)
+	}
+}
+
+// A release binary has only what it embeds, so every template must compile
+// from a directory outside the repository.
+func TestEmbeddedTemplates_Compile(t *testing.T) {
+	names := templates.Names()
+	require.NotEmpty(t, names)
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			// given
+			dir := t.TempDir()
+			require.NoError(t, initInDir(dir, "project", name))
+
+			// when
+			err := Compile(context.Background(), filepath.Join(dir, "project", "spec.yaml"), CompileOptions{
+				OutputDir:         filepath.Join(dir, "generated"),
+				DiagnosticOptions: DiagnosticOptions{Format: FormatJSON},
+			})
+
+			// then
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		stamped string
+		module  string
+		want    string
+	}{
+		{name: "stamped release", stamped: "1.2.0", module: "(devel)", want: "1.2.0"},
+		{name: "go install of a tag", stamped: "dev", module: "v1.2.0", want: "1.2.0"},
+		{name: "go install of a release candidate", stamped: "dev", module: "v1.3.0-rc.1", want: "1.3.0-rc.1"},
+		{name: "source build", stamped: "dev", module: "(devel)", want: "dev"},
+		{name: "untagged commit", stamped: "dev", module: "v0.0.0-20261016120000-0123456789ab", want: "dev"},
+		{name: "commit after a tag", stamped: "dev", module: "v1.2.1-0.20261016120000-0123456789ab", want: "dev"},
+		{name: "modified checkout", stamped: "dev", module: "v1.2.0+dirty", want: "dev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			info := &debug.BuildInfo{Main: debug.Module{Path: "github.com/openboundary/openboundary", Version: tt.module}}
+
+			// when
+			got := resolveVersion(tt.stamped, info)
+
+			// then
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -5,16 +5,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/openboundary/openboundary/cmd/bound/commands"
+	"github.com/openboundary/openboundary/internal/pipeline"
 	"github.com/spf13/cobra"
 )
 
 var (
-	version          = "0.1.0"
-	compileOutputDir string
+	// version is set by release builds with -ldflags "-X main.version=..."
+	version     = "dev"
+	compileOpts commands.CompileOptions
 )
 
 func main() {
@@ -27,10 +33,40 @@ func main() {
 		for various target platforms.`,
 	}
 
+	// Diagnostics follow OPENBOUNDARY_LANG and the locale unless --lang is set
+	var lang string
+	rootCmd.PersistentFlags().StringVar(&lang, "lang", "", "Language of validation messages (en, de; default from OPENBOUNDARY_LANG or LANG)")
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if lang != "" {
+			commands.SetLanguage(lang)
+		}
+	}
+
+	// Commands that take a spec file discover one when it is omitted
+	var specDir string
+	rootCmd.PersistentFlags().StringVar(&specDir, "spec-dir", ".", "Directory to discover spec.yaml or bound.yaml from when no spec file is given")
+
 	// Version flag
-	rootCmd.Version = version
+	commands.Version = commands.ResolveVersion(version)
+	rootCmd.Version = commands.Version
 	rootCmd.SetVersionTemplate("bound version {{.Version}}\n")
 
+	// version command
+	var versionOpts commands.VersionOptions
+	versionCmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the compiler version",
+		Long: `Print the compiler version. With --verbose, also print what the binary
+embeds and supports: the schema version, the project templates, the component
+kinds, the middleware and database providers, and the generators of each
+target.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.PrintVersion(versionOpts)
+		},
+	}
+	versionCmd.Flags().BoolVarP(&versionOpts.Verbose, "verbose", "v", false, "Also print the embedded schema and supported kinds, providers and generators")
+
 	// init command
 	var initTemplate string
 	initCmd := &cobra.Command{
@@ -44,33 +80,457 @@ func main() {
 	}
 	initCmd.Flags().StringVarP(&initTemplate, "template", "t", "blank", "Template to use (blank, basic)")
 
+	// generate-spec command
+	var generateSpecOpts commands.GenerateSpecOptions
+	generateSpecCmd := &cobra.Command{
+		Use:   "generate-spec <openapi-file>",
+		Short: "Generate a specification from an existing OpenAPI document",
+		Long: `Generate a specification from an existing OpenAPI document: an http.server
+serving the document and a usecase per operation, named after its operationId,
+with its summary as goal. Middleware and database components are left as
+commented placeholders to fill in.`,
+		Example: `  bound generate-spec openapi.yaml
+  bound generate-spec api/openapi.yaml --out spec.yaml --server orders --port 3001`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.GenerateSpec(args[0], generateSpecOpts)
+		},
+	}
+	generateSpecCmd.Flags().StringVar(&generateSpecOpts.Out, "out", "spec.yaml", "Specification file to write")
+	generateSpecCmd.Flags().StringVar(&generateSpecOpts.Name, "name", "", "Specification name (default from the document's title)")
+	generateSpecCmd.Flags().StringVar(&generateSpecOpts.Server, "server", "api", "Server name; its ID is http.server.<name>")
+	generateSpecCmd.Flags().IntVar(&generateSpecOpts.Port, "port", 3000, "Server port")
+	generateSpecCmd.Flags().BoolVar(&generateSpecOpts.Force, "force", false, "Overwrite an existing specification file")
+
+	// import command
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Bootstrap a specification from existing infrastructure",
+	}
+
+	var importComposeOpts commands.ImportComposeOptions
+	importComposeCmd := &cobra.Command{
+		Use:   "compose <docker-compose-file>",
+		Short: "Generate a specification from a docker-compose file",
+		Long: `Generate a starter specification from the services of a docker-compose file:
+a postgres component per Postgres service, and an http.server per Node service
+(a node image, or a build context with a package.json) on its published port,
+depending on the databases it lists in depends_on or connects to in its
+environment. Other services are listed in a comment.`,
+		Example: `  bound import compose docker-compose.yml
+  bound import compose deploy/compose.yaml --out spec.yaml --name orders`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.ImportCompose(args[0], importComposeOpts)
+		},
+	}
+	importComposeCmd.Flags().StringVar(&importComposeOpts.Out, "out", "spec.yaml", "Specification file to write")
+	importComposeCmd.Flags().StringVar(&importComposeOpts.Name, "name", "", "Specification name (default from the compose project)")
+	importComposeCmd.Flags().BoolVar(&importComposeOpts.Force, "force", false, "Overwrite an existing specification file")
+	importCmd.AddCommand(importComposeCmd)
+
 	// validate command
+	var validateOpts commands.ValidateOptions
 	validateCmd := &cobra.Command{
 		Use:   "validate [spec-file]",
 		Short: "Validate a specification file",
-		Long:  `Validate a specification file against the OpenBoundary schema and semantic rules.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Validate a specification file against the OpenBoundary schema and semantic rules.
+Without a spec file, the spec.yaml or bound.yaml of the current directory or
+its nearest parent is used, up to the project root (see --spec-dir).`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return commands.Validate(args[0])
+			specFile, err := commands.ResolveSpecFile(args, specDir)
+			if err != nil {
+				return err
+			}
+			return commands.Validate(cmd.Context(), specFile, validateOpts)
 		},
 	}
+	validateCmd.Flags().BoolVar(&validateOpts.FailOnUnused, "fail-on-unused", false, "Fail when a component is not connected to any other component")
+	validateCmd.Flags().BoolVar(&validateOpts.CheckLinks, "check-links", false, "Request component links and warn about the ones that do not resolve")
+	validateCmd.Flags().DurationVar(&validateOpts.LinkTimeout, "link-timeout", 5*time.Second, "Timeout of each link request made by --check-links")
+	addDiagnosticFlags(validateCmd, &validateOpts.DiagnosticOptions)
 
 	// compile command
 	compileCmd := &cobra.Command{
 		Use:   "compile [spec-file]",
 		Short: "Compile a specification file",
-		Long:  `Compile a specification file into executable code for the target platform.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Compile a specification file into executable code for the target platform.
+Without a spec file, the spec.yaml or bound.yaml of the current directory or
+its nearest parent is used, up to the project root (see --spec-dir).`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specFile, err := commands.ResolveSpecFile(args, specDir)
+			if err != nil {
+				return err
+			}
+			return commands.Compile(cmd.Context(), specFile, compileOpts)
+		},
+	}
+	compileCmd.Flags().StringVarP(&compileOpts.OutputDir, "output", "o", "generated", "Output directory for generated code")
+	compileCmd.Flags().StringVar(&compileOpts.Target, "target", "typescript", "Code generation target (typescript, python)")
+	compileCmd.Flags().BoolVar(&compileOpts.GoClient, "go-client", false, "Also generate a typed Go client package per http.server")
+	compileCmd.Flags().StringVar(&compileOpts.Layout, "layout", "flat", "Component file layout (flat, component)")
+	compileCmd.Flags().StringVar(&compileOpts.Module, "module", "esm", "Module format of the generated package (esm, cjs, dual)")
+	compileCmd.Flags().BoolVar(&compileOpts.Workspace, "workspace", false, "Emit the output as a package of the pnpm or npm workspace enclosing the output directory")
+	compileCmd.Flags().StringArrayVar(&compileOpts.Only, "only", nil, "Only write files of matching components (kind=, label= or id= with globs; repeat to narrow)")
+	compileCmd.Flags().BoolVar(&compileOpts.Verify, "verify", false, "Check that the generated TypeScript parses, using esbuild")
+	compileCmd.Flags().BoolVar(&compileOpts.Timestamp, "timestamp", false, "Record the generation time in generated files (makes output differ between runs)")
+	compileCmd.Flags().BoolVar(&compileOpts.Touch, "touch", false, "Update the modification time of files whose content is unchanged")
+	compileCmd.Flags().IntVar(&compileOpts.MaxArtifacts, "max-artifacts", 0, "Fail without writing anything if the compile would generate more files than this (0 is unlimited)")
+	compileCmd.Flags().BoolVar(&compileOpts.NoCache, "no-cache", false, "Run every generator instead of reusing the output of generators whose inputs are unchanged")
+	compileCmd.Flags().IntVar(&compileOpts.History, "history", pipeline.DefaultHistoryLimit, "Number of compiles to keep in the output's history for diff and rollback (0 disables)")
+	addDiagnosticFlags(compileCmd, &compileOpts.DiagnosticOptions)
+
+	// diff command
+	var diffOpts commands.DiffOptions
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "List the files the latest compile changed",
+		Long: `List the files the latest compile changed in an output directory compared
+with an earlier compile kept in its history (A added, M modified, D no longer
+generated).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.Diff(diffOpts)
+		},
+	}
+	diffCmd.Flags().StringVarP(&diffOpts.OutputDir, "output", "o", "generated", "Output directory of generated code")
+	diffCmd.Flags().StringVar(&diffOpts.Against, "against", "previous", "Compile to compare with: previous or a number of compiles back")
+
+	// rollback command
+	var rollbackOpts commands.RollbackOptions
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Restore the output of the previous compile",
+		Long: `Restore the generated files of an output directory to the compile before
+the latest one, using the content kept in its history, and drop the latest
+compile from the history.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.Rollback(rollbackOpts)
+		},
+	}
+	rollbackCmd.Flags().StringVarP(&rollbackOpts.OutputDir, "output", "o", "generated", "Output directory of generated code")
+
+	// explain command
+	var explainOpts commands.ExplainOptions
+	explainCmd := &cobra.Command{
+		Use:   "explain <file>",
+		Short: "Show which spec element produced a generated file",
+		Long: `Show where a file in an output directory came from: the generator that
+wrote it, the component it belongs to and where the spec declares it, and
+whether compiles overwrite the file or merge your edits into it. The file may
+be given relative to the output directory or to the working directory.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.Explain(args[0], explainOpts)
+		},
+	}
+	explainCmd.Flags().StringVarP(&explainOpts.OutputDir, "output", "o", "generated", "Output directory of generated code")
+
+	// check-impl command
+	var checkImplOpts commands.CheckImplOptions
+	checkImplCmd := &cobra.Command{
+		Use:   "check-impl [spec-file]",
+		Short: "Check usecase implementations against a specification",
+		Long: `Check the usecase implementations in an output directory against the
+signatures the specification now generates. Each usecase file must still
+export its function with the generated parameter and return types; files
+that drifted after a spec change are listed with what changed.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specFile, err := commands.ResolveSpecFile(args, specDir)
+			if err != nil {
+				return err
+			}
+			return commands.CheckImpl(cmd.Context(), specFile, checkImplOpts)
+		},
+	}
+	checkImplCmd.Flags().StringVarP(&checkImplOpts.OutputDir, "output", "o", "generated", "Output directory of generated code")
+	checkImplCmd.Flags().StringVar(&checkImplOpts.Layout, "layout", "flat", "Component file layout used when compiling (flat, component)")
+
+	// add command
+	var addOpts commands.AddOptions
+	addCmd := &cobra.Command{
+		Use:   "add <usecase|middleware|postgres|server> <name>",
+		Short: "Add a component to a specification file",
+		Long: `Add a component to a specification file in place, preserving comments
+and formatting. The name is prefixed with the kind's ID namespace
+(e.g., "create-order" becomes "usecase.create-order").`,
+		Example: `  bound add usecase create-order --binds-to http.server.api:POST:/orders
+  bound add postgres primary --schema ./src/db/schema.ts
+  bound add middleware authn --provider better-auth --config ./auth.config.ts`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if addOpts.SpecFile == "" {
+				specFile, err := commands.ResolveSpecFile(nil, specDir)
+				if err != nil {
+					return err
+				}
+				addOpts.SpecFile = specFile
+			}
+			return commands.Add(cmd.Context(), args[0], args[1], addOpts)
+		},
+	}
+	addCmd.Flags().StringVarP(&addOpts.SpecFile, "spec", "f", "", "Specification file to edit (discovered from --spec-dir when omitted)")
+	addCmd.Flags().StringVar(&addOpts.BindsTo, "binds-to", "", "Route binding for a usecase (server:METHOD:/path)")
+	addCmd.Flags().StringVar(&addOpts.Goal, "goal", "", "Usecase goal (defaults to the humanized name)")
+	addCmd.Flags().StringVar(&addOpts.Actor, "actor", "", "Usecase actor")
+	addCmd.Flags().StringVar(&addOpts.Provider, "provider", "", "Middleware or postgres provider")
+	addCmd.Flags().StringVar(&addOpts.Config, "config", "", "Middleware config file (better-auth)")
+	addCmd.Flags().StringVar(&addOpts.Model, "model", "", "Middleware model file (casbin)")
+	addCmd.Flags().StringVar(&addOpts.Policy, "policy", "", "Middleware policy file (casbin)")
+	addCmd.Flags().StringVar(&addOpts.Schema, "schema", "", "Postgres schema file")
+	addCmd.Flags().StringVar(&addOpts.Framework, "framework", "hono", "Server framework")
+	addCmd.Flags().IntVar(&addOpts.Port, "port", 3000, "Server port")
+	addCmd.Flags().StringVar(&addOpts.OpenAPI, "openapi", "", "Server OpenAPI file")
+	addCmd.Flags().StringSliceVar(&addOpts.Middleware, "middleware", nil, "Middleware chain for a usecase or server")
+	addCmd.Flags().StringSliceVar(&addOpts.DependsOn, "depends-on", nil, "Dependencies for a middleware or server")
+	addCmd.Flags().BoolVar(&addOpts.Compile, "compile", false, "Compile the spec afterwards to scaffold implementation files")
+	addCmd.Flags().StringVarP(&addOpts.OutputDir, "output", "o", "generated", "Output directory when --compile is set")
+
+	// remove command
+	var removeOpts commands.RemoveOptions
+	removeCmd := &cobra.Command{
+		Use:   "remove <component-id>",
+		Short: "Remove a component from a specification file",
+		Long: `Remove a component from a specification file in place. Components that
+still reference it are listed and block the removal unless --force is set.
+Generated files owned by the component are listed, or deleted with --prune.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.Remove(cmd.Context(), args[0], removeOpts)
+		},
+	}
+	removeCmd.Flags().StringVarP(&removeOpts.SpecFile, "spec", "f", "spec.yaml", "Specification file to edit")
+	removeCmd.Flags().StringVarP(&removeOpts.OutputDir, "output", "o", "generated", "Output directory of generated code")
+	removeCmd.Flags().StringVar(&removeOpts.Target, "target", "typescript", "Code generation target (typescript, python)")
+	removeCmd.Flags().StringVar(&removeOpts.Layout, "layout", "flat", "Component file layout used when compiling (flat, component)")
+	removeCmd.Flags().BoolVar(&removeOpts.Force, "force", false, "Remove even if other components reference it")
+	removeCmd.Flags().BoolVar(&removeOpts.Prune, "prune", false, "Delete generated files owned by the component")
+	removeCmd.Flags().BoolVar(&removeOpts.Tombstone, "tombstone", false, "Leave a comment where the component was")
+
+	// test command
+	var testOpts commands.TestOptions
+	testCmd := &cobra.Command{
+		Use:   "test [spec-file]",
+		Short: "Check tests against a specification",
+		Long: `Check tests against a specification. With --coverage-spec, every usecase
+acceptance criterion ID (e.g., AC-usecase.create-user-2) must appear in at
+least one test that is not skipped or todo.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specFile, err := commands.ResolveSpecFile(args, specDir)
+			if err != nil {
+				return err
+			}
+			return commands.Test(cmd.Context(), specFile, testOpts)
+		},
+	}
+	testCmd.Flags().BoolVar(&testOpts.CoverageSpec, "coverage-spec", false, "Verify every acceptance criterion is covered by an active test")
+	testCmd.Flags().StringVarP(&testOpts.Dir, "dir", "d", ".", "Directory to search for test files")
+
+	// maturity command
+	var maturityOpts commands.MaturityOptions
+	maturityCmd := &cobra.Command{
+		Use:   "maturity [spec-file]",
+		Short: "Score a specification against the conformance levels",
+		Long: `Score each usecase and http.server of a specification against the
+conformance levels, and the specification as a whole at the level of its
+least mature component:
+
+  L1  routes bound: every usecase binds to a route
+  L2  full OpenAPI schemas: every route has typed payloads in an OpenAPI document
+  L3  acceptance criteria and tests: every usecase declares criteria and keeps its tests
+  L4  observability and security: metrics, an SLO and middleware or authorization
+
+Each component is listed with what it needs for the next level. With --dir,
+L3 also requires an active test for every acceptance criterion, as checked
+by bound test --coverage-spec.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specFile, err := commands.ResolveSpecFile(args, specDir)
+			if err != nil {
+				return err
+			}
+			return commands.Maturity(cmd.Context(), specFile, maturityOpts)
+		},
+	}
+	maturityCmd.Flags().StringVarP(&maturityOpts.Dir, "dir", "d", "", "Directory to search for tests covering acceptance criteria (default: tests are not searched)")
+	maturityCmd.Flags().IntVar(&maturityOpts.MinLevel, "min-level", 0, "Fail if the specification is below this level (0-4)")
+
+	// stats command
+	var statsOpts commands.StatsOptions
+	statsCmd := &cobra.Command{
+		Use:   "stats [spec-file]",
+		Short: "Count the components of a specification and list deprecated ones",
+		Long: `Count the components of a specification by kind, and list each deprecated
+component with the components that still reference it. A reference that the
+last compile into the output directory did not warn about is marked new, so
+references added since then stand out from the ones being phased out.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specFile, err := commands.ResolveSpecFile(args, specDir)
+			if err != nil {
+				return err
+			}
+			return commands.Stats(cmd.Context(), specFile, statsOpts)
+		},
+	}
+	statsCmd.Flags().StringVarP(&statsOpts.OutputDir, "output", "o", "generated", "Output directory of the compile to compare references with")
+
+	// migrate command
+	var migrateOpts commands.MigrateOptions
+	migrateCmd := &cobra.Command{
+		Use:   "migrate [spec-file]",
+		Short: "Update a specification to the current spec version",
+		Long: `Update a specification written in the spec format one minor version back
+to the format of this compiler, editing it in place. Such specs still compile
+through a compatibility shim, with a warning; older ones must first be migrated
+with the bound release that reads them.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specFile, err := commands.ResolveSpecFile(args, specDir)
+			if err != nil {
+				return err
+			}
+			return commands.Migrate(specFile, migrateOpts)
+		},
+	}
+	migrateCmd.Flags().BoolVar(&migrateOpts.DryRun, "dry-run", false, "Print the migrated spec instead of writing it")
+
+	// export command
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export specifications for use by other tools",
+	}
+
+	var exportSQLiteOpts commands.ExportSQLiteOptions
+	exportSQLiteCmd := &cobra.Command{
+		Use:   "sqlite <out.db> [spec-file...]",
+		Short: "Export specifications to a SQLite database",
+		Long: `Export the components, edges, usecase bindings, OpenAPI operations and
+generated files of one or more specifications into the tables of a SQLite
+database, so platform teams can query the specs of many services with SQL.
+Each spec must validate; nothing is written to the output directory.`,
+		Example: `  bound export sqlite specs.db services/*/spec.yaml
+  sqlite3 specs.db "SELECT s.name FROM specs s JOIN components c ON c.spec_id = s.id WHERE c.provider = 'casbin'"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specFiles := args[1:]
+			if len(specFiles) == 0 {
+				specFile, err := commands.ResolveSpecFile(nil, specDir)
+				if err != nil {
+					return err
+				}
+				specFiles = []string{specFile}
+			}
+			return commands.ExportSQLite(cmd.Context(), args[0], specFiles, exportSQLiteOpts)
+		},
+	}
+	exportSQLiteCmd.Flags().StringVar(&exportSQLiteOpts.Target, "target", "typescript", "Generation target whose files are exported as artifacts (typescript, python)")
+	exportCmd.AddCommand(exportSQLiteCmd)
+
+	// serve command
+	var serveOpts commands.ServeOptions
+	serveCmd := &cobra.Command{
+		Use:   "serve --api",
+		Short: "Serve the compiler as a local HTTP API",
+		Long: `Serve the compiler as a local HTTP API for spec editors and platforms that
+integrate without running bound. POST a spec to /v1/validate for its
+diagnostics, to /v1/ir for its component graph, or to /v1/compile for the
+generated files as a tar archive. Specs are sent as application/yaml, and the
+files they reference are read from --root; paths that leave it are rejected.
+Browsers may only call the API from an origin passed to --allow-origin.
+Nothing is written to disk unless --write-dir is set and a compile request
+passes write=true.`,
+		Example: `  bound serve --api --root specs
+  curl -H 'Content-Type: application/yaml' --data-binary @specs/spec.yaml http://127.0.0.1:8787/v1/validate
+  curl -H 'Content-Type: application/yaml' --data-binary @specs/spec.yaml 'http://127.0.0.1:8787/v1/compile?target=python' | tar -x -C generated`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.Serve(cmd.Context(), serveOpts)
+		},
+	}
+	serveCmd.Flags().BoolVar(&serveOpts.API, "api", false, "Serve the compiler API")
+	serveCmd.Flags().StringVar(&serveOpts.Addr, "addr", "127.0.0.1:8787", "Address to listen on")
+	serveCmd.Flags().Int64Var(&serveOpts.MaxRequestBytes, "max-request-size", commands.DefaultMaxRequestBytes, "Largest spec accepted, in bytes")
+	serveCmd.Flags().StringVar(&serveOpts.WriteDir, "write-dir", "", "Let compile requests with write=true write generated files into this directory")
+	serveCmd.Flags().StringVar(&serveOpts.Root, "root", ".", "Directory the files a spec references are read from")
+	serveCmd.Flags().StringArrayVar(&serveOpts.AllowedOrigins, "allow-origin", nil, "Origin a browser may call the API from, e.g. https://editor.example.com (repeatable)")
+
+	// attest command
+	attestCmd := &cobra.Command{
+		Use:   "attest",
+		Short: "Sign and verify attestations of generated output",
+		Long: `Sign the output of a compile with an ed25519 key, recording the compiler
+version, the spec and its commit, and the hash of every generated file, and
+verify such attestations before deploying the output.`,
+	}
+
+	var attestKeygenOpts commands.AttestKeygenOptions
+	attestKeygenCmd := &cobra.Command{
+		Use:   "keygen",
+		Short: "Generate a key pair for signing attestations",
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return commands.Compile(args[0], compileOutputDir)
+			return commands.AttestKeygen(attestKeygenOpts)
 		},
 	}
-	compileCmd.Flags().StringVarP(&compileOutputDir, "output", "o", "generated", "Output directory for generated code")
+	attestKeygenCmd.Flags().StringVar(&attestKeygenOpts.Out, "out", "bound-attest.key", "Path of the private key; the public key is written next to it with a .pub suffix")
 
-	rootCmd.AddCommand(compileCmd, validateCmd, initCmd)
+	var attestSignOpts commands.AttestSignOptions
+	attestSignCmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Sign the output of the last compile",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.AttestSign(attestSignOpts)
+		},
+	}
+	attestSignCmd.Flags().StringVarP(&attestSignOpts.OutputDir, "output", "o", "generated", "Output directory of generated code")
+	attestSignCmd.Flags().StringVar(&attestSignOpts.Key, "key", "", "Private key to sign with (default from "+commands.AttestKeyEnv+")")
+	attestSignCmd.Flags().StringVar(&attestSignOpts.SpecCommit, "spec-commit", "", "Commit of the spec (default from git when the spec is committed)")
+	attestSignCmd.Flags().StringVar(&attestSignOpts.Attestation, "attestation", "", "Where to write the attestation (default <output>/.bound/attestation.json)")
 
-	if err := rootCmd.Execute(); err != nil {
+	var attestVerifyOpts commands.AttestVerifyOptions
+	attestVerifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify an attestation of generated output",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.AttestVerify(attestVerifyOpts)
+		},
+	}
+	attestVerifyCmd.Flags().StringVarP(&attestVerifyOpts.OutputDir, "output", "o", "generated", "Output directory of generated code")
+	attestVerifyCmd.Flags().StringVar(&attestVerifyOpts.Key, "key", "", "Public key the attestation must be signed with")
+	attestVerifyCmd.Flags().StringVar(&attestVerifyOpts.Attestation, "attestation", "", "Attestation to verify (default <output>/.bound/attestation.json)")
+	attestVerifyCmd.Flags().StringArrayVar(&attestVerifyOpts.CompilerVersions, "compiler-version", nil, "Approved compiler version (repeatable; default accepts any)")
+	attestVerifyCmd.Flags().StringVar(&attestVerifyOpts.SpecCommit, "spec-commit", "", "Spec commit the output must be generated from")
+	attestCmd.AddCommand(attestKeygenCmd, attestSignCmd, attestVerifyCmd)
+
+	rootCmd.AddCommand(compileCmd, validateCmd, initCmd, generateSpecCmd, importCmd, addCmd, removeCmd, testCmd, maturityCmd, statsCmd, migrateCmd, exportCmd, diffCmd, rollbackCmd, explainCmd, checkImplCmd, serveCmd, attestCmd, versionCmd)
+
+	// The first SIGINT or SIGTERM cancels the running command, which stops
+	// at the next stage or file; a second one exits immediately.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(commands.ExitCode(err))
 	}
 }
+
+// addDiagnosticFlags registers the flags controlling how a command reports
+// errors and warnings.
+func addDiagnosticFlags(cmd *cobra.Command, opts *commands.DiagnosticOptions) {
+	cmd.Flags().IntVar(&opts.MaxErrors, "max-errors", commands.DefaultMaxErrors, "Errors and warnings to print before summarizing the rest (0 prints all)")
+	cmd.Flags().StringVar(&opts.Format, "format", commands.FormatText, "Diagnostic output format (text, json); json always lists every diagnostic")
+}
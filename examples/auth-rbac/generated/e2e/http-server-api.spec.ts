@@ -0,0 +1,48 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import { test, expect } from '@playwright/test';
+import { createAuthToken } from './helpers/setup';
+import { requestFixtures } from '../src/test/fixtures';
+
+const baseURL = 'http://localhost:3000';
+
+test.describe('http.server.api API', () => {
+  test('GET /health - health check', async ({ request }) => {
+    const response = await request.get(`${baseURL}/health`);
+    expect(response.status()).toBe(200);
+  });
+
+  test('POST /documents - endpoint exists', async ({ request }) => {
+    const token = createAuthToken({ userId: 'test-user' });
+    const headers = { Authorization: `Bearer ${token}` };
+
+    const response = await request.post(`${baseURL}/documents`, {
+      headers,
+      data: requestFixtures.createDocumentUsecase,
+    });
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+  test('DELETE /documents/{id} - endpoint exists', async ({ request }) => {
+    const token = createAuthToken({ userId: 'test-user' });
+    const headers = { Authorization: `Bearer ${token}` };
+
+    const response = await request.delete(`${baseURL}/documents/test-id`, { headers });
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+  test('GET /documents - endpoint exists', async ({ request }) => {
+    const token = createAuthToken({ userId: 'test-user' });
+    const headers = { Authorization: `Bearer ${token}` };
+
+    const response = await request.get(`${baseURL}/documents`, { headers });
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+});
@@ -0,0 +1,23 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import { defineConfig, devices } from '@playwright/test';
+
+export default defineConfig({
+  testDir: './e2e',
+  fullyParallel: true,
+  forbidOnly: !!process.env.CI,
+  retries: process.env.CI ? 2 : 0,
+  workers: process.env.CI ? 1 : undefined,
+  reporter: 'html',
+  use: {
+    baseURL: process.env.BASE_URL || 'http://localhost:3000',
+    trace: 'on-first-retry',
+  },
+  projects: [
+    {
+      name: 'API Tests',
+      use: { ...devices['Desktop Chrome'] },
+    },
+  ],
+  globalSetup: './e2e/global-setup.ts',
+});
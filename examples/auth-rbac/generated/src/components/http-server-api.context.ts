@@ -0,0 +1,53 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+
+import type { AuthContext as MiddlewareAuthnAuthContext } from './middleware-authn.middleware';
+import type { DrizzleClient } from './postgres.client';
+import type { Enforcer } from 'casbin';
+import type { PostgresPrimaryRepositories } from './postgres-primary.postgres.repositories';
+
+/**
+ * Context for http.server.api
+ * Contains all dependencies and middleware-provided values.
+ */
+export interface ServerContext {
+  /** Database client from postgres.primary */
+  db: DrizzleClient;
+  /** Repositories of the tables of postgres.primary */
+  repositories: PostgresPrimaryRepositories;
+  /** Context from middleware.authn */
+  auth?: MiddlewareAuthnAuthContext | null;
+  /** Context from middleware.authz */
+  enforcer?: Enforcer | null;
+}
+
+/**
+ * Pick specific fields from ServerContext.
+ * Use this to type usecase context parameters.
+ */
+export type ContextWith<K extends keyof ServerContext> = Pick<ServerContext, K>;
+
+/**
+ * Pick specific fields from ServerContext, with the fields in M required
+ * and non-null because middleware that sets them runs before the handler.
+ */
+export type ContextAfter<K extends keyof ServerContext, M extends K = never> =
+  Pick<ServerContext, Exclude<K, M>> & { [F in M]-?: NonNullable<ServerContext[F]> };
+
+/**
+ * Context of usecase.create-document (POST /documents).
+ * Set by middleware.authn, middleware.authz: auth, enforcer.
+ */
+export type CreateDocumentUsecaseContext = ContextAfter<'db' | 'repositories' | 'auth' | 'enforcer', 'auth' | 'enforcer'>;
+
+/**
+ * Context of usecase.delete-document (DELETE /documents/{id}).
+ * Set by middleware.authn, middleware.authz: auth, enforcer.
+ */
+export type DeleteDocumentUsecaseContext = ContextAfter<'db' | 'repositories' | 'auth' | 'enforcer', 'auth' | 'enforcer'>;
+
+/**
+ * Context of usecase.list-documents (GET /documents).
+ * Set by middleware.authn, middleware.authz: auth, enforcer.
+ */
+export type ListDocumentsUsecaseContext = ContextAfter<'db' | 'repositories' | 'auth' | 'enforcer', 'auth' | 'enforcer'>;
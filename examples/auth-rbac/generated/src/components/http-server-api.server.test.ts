@@ -0,0 +1,165 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { createHttpServerApiApp } from './http-server-api.server';
+import type { ServerContext } from './http-server-api.context';
+import { createInMemoryPostgresPrimaryRepositories } from './postgres-primary.postgres.repositories';
+import { requestFixtures } from '../test/fixtures';
+import * as middlewareAuthzRBAC from './middleware-authz.middleware.rbac';
+
+vi.mock('./middleware-authz.middleware.rbac', async (importOriginal) => ({
+  ...(await importOriginal<typeof import('./middleware-authz.middleware.rbac')>()),
+  isAuthorized: vi.fn().mockResolvedValue(true),
+}));
+
+describe('createHttpServerApiApp', () => {
+  it('should create a Hono app instance', () => {
+    // given
+    const mockDeps = createMockDeps();
+
+    // when
+    const app = createHttpServerApiApp(mockDeps);
+
+    // then
+    expect(app).toBeDefined();
+    expect(typeof app.fetch).toBe('function');
+  });
+
+  it('should serve the API reference at /docs', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const page = await app.fetch(new Request('http://localhost/docs'));
+    const document = await app.fetch(new Request('http://localhost/docs/openapi.yaml'));
+
+    // then
+    expect(page.status).toBe(200);
+    expect(page.headers.get('Content-Type')).toContain('text/html');
+    expect(document.status).toBe(200);
+    expect(await document.text()).toContain('openapi: 3.0.3');
+  });
+
+  it('should have POST /documents route', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/documents', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify(requestFixtures.createDocumentUsecase),
+    });
+    const res = await app.fetch(req);
+
+    // then - route should exist (may return error from unimplemented usecase)
+    expect(res.status).not.toBe(404);
+  });
+
+  it('should return 403 from POST /documents when the caller is not authorized', async () => {
+    // given
+    vi.mocked(middlewareAuthzRBAC.isAuthorized).mockResolvedValueOnce(false);
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/documents', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify(requestFixtures.createDocumentUsecase),
+    });
+    const res = await app.fetch(req);
+
+    // then
+    expect(res.status).toBe(403);
+    expect(vi.mocked(middlewareAuthzRBAC.isAuthorized).mock.lastCall?.[2]).toEqual({
+      roles: [middlewareAuthzRBAC.Role.Editor, middlewareAuthzRBAC.Role.Admin],
+      permissions: [middlewareAuthzRBAC.Permission.DocumentWrite],
+    });
+  });
+
+  it('should have DELETE /documents/:id route', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/documents/test-id', {
+      method: 'DELETE',
+    });
+    const res = await app.fetch(req);
+
+    // then - route should exist (may return error from unimplemented usecase)
+    expect(res.status).not.toBe(404);
+  });
+
+  it('should return 403 from DELETE /documents/:id when the caller is not authorized', async () => {
+    // given
+    vi.mocked(middlewareAuthzRBAC.isAuthorized).mockResolvedValueOnce(false);
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/documents/test-id', {
+      method: 'DELETE',
+    });
+    const res = await app.fetch(req);
+
+    // then
+    expect(res.status).toBe(403);
+    expect(vi.mocked(middlewareAuthzRBAC.isAuthorized).mock.lastCall?.[2]).toEqual({
+      roles: [middlewareAuthzRBAC.Role.Admin],
+    });
+  });
+
+  it('should have GET /documents route', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/documents', {
+      method: 'GET',
+    });
+    const res = await app.fetch(req);
+
+    // then - route should exist (may return error from unimplemented usecase)
+    expect(res.status).not.toBe(404);
+  });
+
+  it('should return 403 from GET /documents when the caller is not authorized', async () => {
+    // given
+    vi.mocked(middlewareAuthzRBAC.isAuthorized).mockResolvedValueOnce(false);
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/documents', {
+      method: 'GET',
+    });
+    const res = await app.fetch(req);
+
+    // then
+    expect(res.status).toBe(403);
+    expect(vi.mocked(middlewareAuthzRBAC.isAuthorized).mock.lastCall?.[2]).toEqual({
+      permissions: [middlewareAuthzRBAC.Permission.DocumentRead],
+    });
+  });
+
+});
+
+function createMockDeps(): ServerContext {
+  return {
+    db: {
+      query: {},
+      insert: vi.fn(),
+      update: vi.fn(),
+      delete: vi.fn(),
+    } as any,
+    repositories: createInMemoryPostgresPrimaryRepositories(),
+    auth: { session: null, user: null } as any,
+    enforcer: { enforce: vi.fn().mockResolvedValue(true) } as any,
+  };
+}
@@ -0,0 +1,192 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import { Hono } from 'hono';
+import type { ServerContext } from './http-server-api.context';
+import { middlewareAuthnMiddleware } from './middleware-authn.middleware';
+import { middlewareAuthzMiddleware } from './middleware-authz.middleware';
+import { createDocumentUsecase } from './usecase-create-document.usecase';
+import { deleteDocumentUsecase } from './usecase-delete-document.usecase';
+import { listDocumentsUsecase } from './usecase-list-documents.usecase';
+import * as middlewareAuthzRBAC from './middleware-authz.middleware.rbac';
+import { apiDocsPage, openapiDocument } from './http-server-api.docs';
+import { getEffectivePolicies as middlewareAuthzPolicies } from './middleware-authz.middleware.enforcer';
+
+type MiddlewareRoute = { method: string; path: RegExp };
+const middlewareMatrix: Record<string, MiddlewareRoute[]> = {
+  "middleware.authn": [
+    { method: 'POST', path: new RegExp("^/documents$") },
+    { method: 'DELETE', path: new RegExp("^/documents/[^/]+$") },
+    { method: 'GET', path: new RegExp("^/documents$") },
+    { method: 'GET', path: new RegExp("^/admin/policies$") },
+  ],
+  "middleware.authz": [
+    { method: 'POST', path: new RegExp("^/documents$") },
+    { method: 'DELETE', path: new RegExp("^/documents/[^/]+$") },
+    { method: 'GET', path: new RegExp("^/documents$") },
+    { method: 'GET', path: new RegExp("^/admin/policies$") },
+  ],
+};
+
+function routeRequiresMiddleware(mwId: string, method: string, path: string): boolean {
+  const routes = middlewareMatrix[mwId];
+  if (!routes || routes.length === 0) return false;
+  for (const route of routes) {
+    if ((route.method === 'ALL' || route.method === method) && route.path.test(path)) {
+      return true;
+    }
+  }
+  return false;
+}
+
+/**
+ * Answers 501 with a route's example response while its usecase is not
+ * implemented yet and MOCK_UNIMPLEMENTED is true, so a partly implemented
+ * service can be demoed. Any other error is rethrown.
+ */
+function mockUnimplemented(error: unknown, example: unknown): Response {
+  if (process.env.MOCK_UNIMPLEMENTED === 'true' && error instanceof Error && error.message === 'Not implemented') {
+    return new Response(JSON.stringify(example ?? { error: 'Not Implemented' }), {
+      status: 501,
+      headers: { 'Content-Type': 'application/json' },
+    });
+  }
+  throw error;
+}
+
+type Env = {
+  Variables: ServerContext;
+};
+
+/**
+ * Creates the http.server.api Hono application.
+ * @param ctx - The server context with dependencies
+ */
+export function createHttpServerApiApp(ctx: ServerContext): Hono<Env> {
+  const app = new Hono<Env>();
+
+  // Set base context from dependencies
+  app.use('*', async (c, next) => {
+    c.set('db', ctx.db);
+    c.set('repositories', ctx.repositories);
+    await next();
+  });
+
+  // Health check
+  app.get('/health', (c) => c.json({ status: 'ok' }));
+
+  // API reference
+  if (process.env.NODE_ENV !== 'production') {
+    app.get('/docs', (c) => c.html(apiDocsPage));
+    app.get('/docs/openapi.yaml', (c) => c.body(openapiDocument, 200, { 'Content-Type': 'application/yaml' }));
+  }
+
+  // Apply server-level middleware only when required by the route
+  app.use('*', async (c, next) => {
+    if (!routeRequiresMiddleware("middleware.authn", c.req.method, c.req.path)) {
+      return next();
+    }
+    return middlewareAuthnMiddleware(c, next);
+  });
+  app.use('*', async (c, next) => {
+    if (!routeRequiresMiddleware("middleware.authz", c.req.method, c.req.path)) {
+      return next();
+    }
+    return middlewareAuthzMiddleware(c, next);
+  });
+
+  // Route handlers
+
+  // usecase.create-document - Store a new document
+  app.post('/documents', async (c) => {
+    // Any of the roles and all of the permissions
+    const authorized = await middlewareAuthzRBAC.isAuthorized(c.get('enforcer'), c.get('auth')?.user?.id, {
+      roles: [middlewareAuthzRBAC.Role.Editor, middlewareAuthzRBAC.Role.Admin],
+      permissions: [middlewareAuthzRBAC.Permission.DocumentWrite],
+    });
+    if (!authorized) {
+      return c.json({ error: 'Forbidden' }, 403);
+    }
+
+    const body = await c.req.json();
+    const input = {
+      ...body,
+    };
+
+    const context = {
+      db: c.get('db'),
+      repositories: c.get('repositories'),
+      auth: c.get('auth')!,
+      enforcer: c.get('enforcer')!,
+    };
+
+    let result;
+    try {
+      result = await createDocumentUsecase(input, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.json(result, 201);
+  });
+
+  // usecase.delete-document - Delete a document
+  app.delete('/documents/:id', async (c) => {
+    // Any of the roles and all of the permissions
+    const authorized = await middlewareAuthzRBAC.isAuthorized(c.get('enforcer'), c.get('auth')?.user?.id, {
+      roles: [middlewareAuthzRBAC.Role.Admin],
+    });
+    if (!authorized) {
+      return c.json({ error: 'Forbidden' }, 403);
+    }
+
+    const id = c.req.param('id');
+    const input = {
+      id,
+    };
+
+    const context = {
+      db: c.get('db'),
+      repositories: c.get('repositories'),
+      auth: c.get('auth')!,
+      enforcer: c.get('enforcer')!,
+    };
+
+    let result;
+    try {
+      result = await deleteDocumentUsecase(input, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.body(null, 204);
+  });
+
+  // usecase.list-documents - List the documents the caller can read
+  app.get('/documents', async (c) => {
+    // Any of the roles and all of the permissions
+    const authorized = await middlewareAuthzRBAC.isAuthorized(c.get('enforcer'), c.get('auth')?.user?.id, {
+      permissions: [middlewareAuthzRBAC.Permission.DocumentRead],
+    });
+    if (!authorized) {
+      return c.json({ error: 'Forbidden' }, 403);
+    }
+
+    const context = {
+      db: c.get('db'),
+      repositories: c.get('repositories'),
+      auth: c.get('auth')!,
+      enforcer: c.get('enforcer')!,
+    };
+
+    let result;
+    try {
+      result = await listDocumentsUsecase(undefined as void, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.json(result);
+  });
+
+  // middleware.authz - effective policies
+  app.get('/admin/policies', async (c) => c.json(await middlewareAuthzPolicies()));
+
+  return app;
+}
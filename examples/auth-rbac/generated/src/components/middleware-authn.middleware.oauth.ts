@@ -0,0 +1,13 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+// OAuth providers: spread into betterAuth({ ...oauthOptions }) in the auth config
+import type { BetterAuthOptions } from 'better-auth';
+
+export const oauthOptions = {
+  socialProviders: {
+    github: {
+      clientId: process.env.GITHUB_CLIENT_ID as string,
+      clientSecret: process.env.GITHUB_CLIENT_SECRET as string,
+    },
+  },
+} satisfies Partial<BetterAuthOptions>;
@@ -0,0 +1,14 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+// Session storage (database): spread into betterAuth({ ...sessionOptions }) in the auth config
+import type { BetterAuthOptions } from 'better-auth';
+import { drizzleAdapter } from 'better-auth/adapters/drizzle';
+import { db } from './postgres-primary.postgres';
+import * as schema from './middleware-authn.middleware.schema';
+
+export const sessionOptions = {
+  database: drizzleAdapter(db, { provider: 'pg', schema }),
+  session: {
+    expiresIn: 86400,
+  },
+} satisfies Partial<BetterAuthOptions>;
@@ -0,0 +1,92 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import { newEnforcer, Enforcer } from 'casbin';
+import PostgresAdapter from 'casbin-pg-adapter';
+import { seedPolicies, seedGroupings } from './middleware-authz.middleware.rbac';
+import { watch } from 'fs';
+import path from 'path';
+import { fileURLToPath } from 'url';
+
+const __dirname = path.dirname(fileURLToPath(import.meta.url));
+
+const modelPath = path.join(__dirname, 'middleware-authz.middleware.model.conf');
+const policyPath = path.join(__dirname, 'middleware-authz.middleware.policy.csv');
+const isProduction = process.env.NODE_ENV === 'production';
+
+let enforcer: Promise<Enforcer> | null = null;
+let watching = false;
+
+async function createEnforcer(): Promise<Enforcer> {
+  if (isProduction) {
+    const adapter = await PostgresAdapter.newAdapter({
+      connectionString: process.env.DATABASE_URL,
+    });
+    const e = await newEnforcer(modelPath, adapter);
+    await seedPolicy(e);
+    return e;
+  }
+  return newEnforcer(modelPath, policyPath);
+}
+
+/** Adds the roles declared in the spec to the policy database, keeping existing rules. */
+async function seedPolicy(e: Enforcer): Promise<void> {
+  for (const rule of seedPolicies) {
+    if (!(await e.hasPolicy(...rule))) {
+      await e.addPolicy(...rule);
+    }
+  }
+  for (const rule of seedGroupings) {
+    if (!(await e.hasGroupingPolicy(...rule))) {
+      await e.addGroupingPolicy(...rule);
+    }
+  }
+}
+
+/** Returns the shared enforcer, creating it on first use. */
+export function getEnforcer(): Promise<Enforcer> {
+  if (!enforcer) {
+    enforcer = createEnforcer();
+    if (!isProduction) {
+      watchPolicy();
+    }
+  }
+  return enforcer;
+}
+
+/** Reloads policies from the policy source without restarting the server. */
+export async function reloadPolicy(): Promise<void> {
+  const e = await getEnforcer();
+  await e.loadPolicy();
+}
+
+/** Reloads policies when the policy file changes and rebuilds the enforcer when the model changes. */
+function watchPolicy(): void {
+  if (watching) return;
+  watching = true;
+
+  watch(policyPath, () => {
+    reloadPolicy().catch((err) => console.error('Failed to reload casbin policy:', err));
+  }).unref();
+  watch(modelPath, () => {
+    const next = createEnforcer();
+    next
+      .then(() => {
+        enforcer = next;
+      })
+      .catch((err) => console.error('Failed to reload casbin model:', err));
+  }).unref();
+}
+
+export interface EffectivePolicies {
+  policies: string[][];
+  groupings: string[][];
+}
+
+/** Lists the policies and role assignments the enforcer currently applies. */
+export async function getEffectivePolicies(): Promise<EffectivePolicies> {
+  const e = await getEnforcer();
+  return {
+    policies: await e.getPolicy(),
+    groupings: await e.getGroupingPolicy(),
+  };
+}
@@ -0,0 +1,68 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+// Roles and permissions declared in the spec
+import type { Enforcer } from 'casbin';
+
+export const Role = {
+  Admin: 'admin',
+  Editor: 'editor',
+  Reader: 'reader',
+} as const;
+export type Role = (typeof Role)[keyof typeof Role];
+
+export const Permission = {
+  DocumentDelete: 'document.delete',
+  DocumentRead: 'document.read',
+  DocumentWrite: 'document.write',
+} as const;
+export type Permission = (typeof Permission)[keyof typeof Permission];
+
+/** Policy object and action each permission grants */
+export const permissionRules: Record<Permission, { object: string; action: string }> = {
+  [Permission.DocumentDelete]: { object: '/documents', action: 'DELETE' },
+  [Permission.DocumentRead]: { object: '/documents', action: 'GET' },
+  [Permission.DocumentWrite]: { object: '/documents', action: 'POST' },
+};
+
+/** Policy rules seeded from the spec: [role, object, action] */
+export const seedPolicies: string[][] = [
+  [Role.Admin, '/documents', 'DELETE'],
+  [Role.Editor, '/documents', 'POST'],
+  [Role.Reader, '/documents', 'GET'],
+];
+
+/** Role inheritance seeded from the spec: [role, inherited role] */
+export const seedGroupings: string[][] = [
+  [Role.Admin, Role.Editor],
+  [Role.Editor, Role.Reader],
+];
+
+/** What a caller needs: any of the roles and all of the permissions */
+export interface Authorization {
+  roles?: Role[];
+  permissions?: Permission[];
+}
+
+/** Reports whether a subject meets an authorization requirement. */
+export async function isAuthorized(
+  enforcer: Enforcer | null | undefined,
+  subject: string | null | undefined,
+  required: Authorization,
+): Promise<boolean> {
+  if (!enforcer || !subject) {
+    return false;
+  }
+  if (required.roles?.length) {
+    const roles = await enforcer.getImplicitRolesForUser(subject);
+    if (!required.roles.some((role) => roles.includes(role))) {
+      return false;
+    }
+  }
+  for (const permission of required.permissions ?? []) {
+    const { object, action } = permissionRules[permission];
+    if (!(await enforcer.enforce(subject, object, action))) {
+      return false;
+    }
+  }
+  return true;
+}
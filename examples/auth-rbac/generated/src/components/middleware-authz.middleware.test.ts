@@ -0,0 +1,37 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { middlewareAuthzMiddleware } from './middleware-authz.middleware';
+import { getEffectivePolicies } from './middleware-authz.middleware.enforcer';
+
+describe('middlewareAuthzMiddleware', () => {
+  it('should be a middleware function', () => {
+    expect(typeof middlewareAuthzMiddleware).toBe('function');
+  });
+
+  it('should check authorization using enforcer', async () => {
+    // given
+    const mockCtx = {
+      set: vi.fn(),
+      get: vi.fn().mockReturnValue({ userId: 'user-123' }),
+      req: { method: 'GET', path: '/users' },
+    };
+    const mockNext = vi.fn().mockResolvedValue(undefined);
+
+    // when
+    await middlewareAuthzMiddleware(mockCtx as any, mockNext);
+
+    // then - middleware should call next
+    expect(mockNext).toHaveBeenCalled();
+  });
+
+  it('should list effective policies', async () => {
+    // when
+    const { policies, groupings } = await getEffectivePolicies();
+
+    // then
+    expect(Array.isArray(policies)).toBe(true);
+    expect(Array.isArray(groupings)).toBe(true);
+  });
+
+});
@@ -0,0 +1,13 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import { createMiddleware } from 'hono/factory';
+import { getEnforcer } from './middleware-authz.middleware.enforcer';
+
+export const middlewareAuthzMiddleware = createMiddleware(async (c, next) => {
+  const e = await getEnforcer();
+  c.set('enforcer', e);
+  // TODO: Implement authorization check
+  // const auth = c.get('auth');
+  // const allowed = await e.enforce(auth?.user?.id, c.req.path, c.req.method);
+  await next();
+});
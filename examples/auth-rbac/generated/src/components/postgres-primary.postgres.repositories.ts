@@ -0,0 +1,59 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import { randomUUID } from 'node:crypto';
+import { asc, eq } from 'drizzle-orm';
+import type { DrizzleClient } from './postgres.client';
+import type { Repository } from './postgres.repository';
+import { createInMemoryRepository, pageBounds } from './postgres.repository';
+import * as schema from './postgres-primary.postgres.schema';
+
+/** Row of the documents table */
+export type DocumentsRow = typeof schema.documents.$inferSelect;
+/** Values of a new row of the documents table */
+export type NewDocumentsRow = typeof schema.documents.$inferInsert;
+/** Rows of the documents table by id */
+export type DocumentsRepository = Repository<DocumentsRow, NewDocumentsRow, DocumentsRow['id']>;
+
+/** Repositories of the tables of postgres.primary */
+export interface PostgresPrimaryRepositories {
+  documents: DocumentsRepository;
+}
+
+/** Creates the repositories of postgres.primary, which query db. */
+export function createPostgresPrimaryRepositories(db: DrizzleClient): PostgresPrimaryRepositories {
+  return {
+    documents: {
+      async findById(id) {
+        const [row] = await db.select().from(schema.documents).where(eq(schema.documents.id, id)).limit(1);
+        return row;
+      },
+      async list(page) {
+        const { limit, offset } = pageBounds(page);
+        return db.select().from(schema.documents).orderBy(asc(schema.documents.id)).limit(limit).offset(offset);
+      },
+      async create(values) {
+        const [row] = await db.insert(schema.documents).values(values).returning();
+        return row;
+      },
+      async update(id, values) {
+        const [row] = await db.update(schema.documents).set(values).where(eq(schema.documents.id, id)).returning();
+        return row;
+      },
+      async delete(id) {
+        const rows = await db.delete(schema.documents).where(eq(schema.documents.id, id)).returning();
+        return rows.length > 0;
+      },
+    },
+  };
+}
+
+/**
+ * Creates repositories of postgres.primary that keep rows in memory, for unit tests.
+ * Column defaults are not applied; a row created without a key gets a new one,
+ * and the convention columns are kept as in the database.
+ */
+export function createInMemoryPostgresPrimaryRepositories(): PostgresPrimaryRepositories {
+  return {
+    documents: createInMemoryRepository<DocumentsRow, NewDocumentsRow, 'id'>('id', () => randomUUID(), { createdAt: true }),
+  };
+}
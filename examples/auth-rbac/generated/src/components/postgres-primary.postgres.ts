@@ -0,0 +1,20 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import { drizzle } from 'drizzle-orm/postgres-js';
+import postgres from 'postgres';
+import * as schema from './postgres-primary.postgres.schema';
+
+// Database connection
+const connectionString = process.env.DATABASE_URL || '';
+const client = postgres(connectionString);
+
+// Export db instance for use by auth and other modules
+export const db = drizzle(client, { schema });
+
+// Factory function for explicit initialization with validation
+export async function createPostgresPrimaryClient() {
+  if (!connectionString) {
+    throw new Error('DATABASE_URL environment variable is required');
+  }
+  return db;
+}
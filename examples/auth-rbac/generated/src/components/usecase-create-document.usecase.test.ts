@@ -0,0 +1,55 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { createDocumentUsecase } from './usecase-create-document.usecase';
+import { createMockContext } from '../test/setup';
+import { requestFixtures } from '../test/fixtures';
+
+describe('createDocumentUsecase', () => {
+  let mockCtx: ReturnType<typeof createMockContext>;
+
+  beforeEach(() => {
+    mockCtx = createMockContext();
+    vi.clearAllMocks();
+  });
+
+  it('should be a function', () => {
+    expect(typeof createDocumentUsecase).toBe('function');
+  });
+
+  it('should return a promise', () => {
+    // given
+    const input = { ...requestFixtures.createDocumentUsecase };
+
+    // when
+    const result = createDocumentUsecase(input as any, mockCtx);
+
+    // then
+    expect(result).toBeInstanceOf(Promise);
+  });
+
+  it('should throw NotImplemented error by default', async () => {
+    // given
+    const input = { ...requestFixtures.createDocumentUsecase };
+
+    // when/then
+    await expect(createDocumentUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  it('should have access to auth context', async () => {
+    // given
+    const input = {};
+    const ctxWithAuth = {
+      ...mockCtx,
+      auth: { userId: 'user-123', session: {} },
+    };
+
+    // when/then - context should include auth
+    expect(ctxWithAuth.auth).toBeDefined();
+    expect(ctxWithAuth.auth.userId).toBe('user-123');
+  });
+
+  describe('acceptance criteria', () => {
+    it.todo('AC-usecase.create-document-1: The caller is recorded as the author');
+  });
+});
@@ -0,0 +1,29 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import type { CreateDocumentUsecaseContext } from './http-server-api.context';
+import type { CreateDocumentRequest, CreateDocumentResponse } from './usecase.schemas';
+
+/**
+ * Store a new document
+ *
+ * @actor editor
+ * @requires Role.Editor
+ * @requires Role.Admin
+ * @requires Permission.DocumentWrite
+ *
+ * Acceptance Criteria:
+ * - The caller is recorded as the author
+ */
+export async function createDocumentUsecase(
+  input: CreateDocumentRequest,
+  ctx: CreateDocumentUsecaseContext
+): Promise<CreateDocumentResponse> {
+  // TODO: Implement usecase
+  //
+  // Implementation should satisfy:
+  //   - The caller is recorded as the author
+  //
+  // Example: const row = await ctx.repositories.documents.findById(id);
+
+  throw new Error('Not implemented');
+}
@@ -0,0 +1,64 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { deleteDocumentUsecase } from './usecase-delete-document.usecase';
+import { createMockContext } from '../test/setup';
+
+describe('deleteDocumentUsecase', () => {
+  let mockCtx: ReturnType<typeof createMockContext>;
+
+  beforeEach(() => {
+    mockCtx = createMockContext();
+    vi.clearAllMocks();
+  });
+
+  it('should be a function', () => {
+    expect(typeof deleteDocumentUsecase).toBe('function');
+  });
+
+  it('should return a promise', () => {
+    // given
+    const input = {};
+
+    // when
+    const result = deleteDocumentUsecase(input as any, mockCtx);
+
+    // then
+    expect(result).toBeInstanceOf(Promise);
+  });
+
+  it('should throw NotImplemented error by default', async () => {
+    // given
+    const input = {};
+
+    // when/then
+    await expect(deleteDocumentUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  it('should accept path parameters in input', async () => {
+    // given
+    const input = {
+      id: 'test-id',
+    };
+
+    // when/then - should accept input shape without type error
+    await expect(deleteDocumentUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  it('should have access to auth context', async () => {
+    // given
+    const input = {};
+    const ctxWithAuth = {
+      ...mockCtx,
+      auth: { userId: 'user-123', session: {} },
+    };
+
+    // when/then - context should include auth
+    expect(ctxWithAuth.auth).toBeDefined();
+    expect(ctxWithAuth.auth.userId).toBe('user-123');
+  });
+
+  describe('acceptance criteria', () => {
+    it.todo('AC-usecase.delete-document-1: Deleting an unknown document returns 404');
+  });
+});
@@ -0,0 +1,31 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import type { DeleteDocumentUsecaseContext } from './http-server-api.context';
+
+/** Input with path parameters */
+export interface DeleteDocumentUsecaseInput {
+  id: string;
+}
+
+/**
+ * Delete a document
+ *
+ * @actor admin
+ * @requires Role.Admin
+ *
+ * Acceptance Criteria:
+ * - Deleting an unknown document returns 404
+ */
+export async function deleteDocumentUsecase(
+  input: DeleteDocumentUsecaseInput,
+  ctx: DeleteDocumentUsecaseContext
+): Promise<void> {
+  // TODO: Implement usecase
+  //
+  // Implementation should satisfy:
+  //   - Deleting an unknown document returns 404
+  //
+  // Example: const row = await ctx.repositories.documents.findById(id);
+
+  throw new Error('Not implemented');
+}
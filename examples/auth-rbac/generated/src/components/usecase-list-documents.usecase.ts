@@ -0,0 +1,27 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import type { ListDocumentsUsecaseContext } from './http-server-api.context';
+import type { ListDocumentsResponse } from './usecase.schemas';
+
+/**
+ * List the documents the caller can read
+ *
+ * @actor reader
+ * @requires Permission.DocumentRead
+ *
+ * Acceptance Criteria:
+ * - Returns documents newest first
+ */
+export async function listDocumentsUsecase(
+  input: void,
+  ctx: ListDocumentsUsecaseContext
+): Promise<ListDocumentsResponse> {
+  // TODO: Implement usecase
+  //
+  // Implementation should satisfy:
+  //   - Returns documents newest first
+  //
+  // Example: const row = await ctx.repositories.documents.findById(id);
+
+  throw new Error('Not implemented');
+}
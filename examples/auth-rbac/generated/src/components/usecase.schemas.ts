@@ -0,0 +1,17 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+// Types and zod schemas derived from OpenAPI documents.
+
+import { z } from 'zod';
+
+export const CreateDocumentRequestSchema = z.object({
+  body: z.string(),
+  title: z.string(),
+});
+export type CreateDocumentRequest = z.infer<typeof CreateDocumentRequestSchema>;
+
+export const CreateDocumentResponseSchema = z.record(z.unknown());
+export type CreateDocumentResponse = z.infer<typeof CreateDocumentResponseSchema>;
+
+export const ListDocumentsResponseSchema = z.record(z.unknown());
+export type ListDocumentsResponse = z.infer<typeof ListDocumentsResponseSchema>;
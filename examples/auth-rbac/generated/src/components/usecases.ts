@@ -0,0 +1,7 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+// Re-exports all usecases for convenient importing
+
+export { createDocumentUsecase } from './usecase-create-document.usecase';
+export { deleteDocumentUsecase } from './usecase-delete-document.usecase';
+export { listDocumentsUsecase } from './usecase-list-documents.usecase';
@@ -0,0 +1,48 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import { serve } from '@hono/node-server';
+import { Hono } from 'hono';
+import { cors } from 'hono/cors';
+import { auth } from './components/middleware-authn.middleware.config';
+import { createHttpServerApiApp } from './components/http-server-api.server';
+import { createPostgresPrimaryClient } from './components/postgres-primary.postgres';
+import { createPostgresPrimaryRepositories } from './components/postgres-primary.postgres.repositories';
+
+async function main() {
+  // Initialize dependencies
+  const postgresPrimaryClient = await createPostgresPrimaryClient();
+  const postgresPrimaryRepositories = createPostgresPrimaryRepositories(postgresPrimaryClient);
+
+  // Start http.server.api
+  const httpServerApiContext = {
+    db: postgresPrimaryClient,
+    repositories: postgresPrimaryRepositories,
+    auth: null,
+    enforcer: null,
+  };
+
+  const httpServerApiApp = createHttpServerApiApp(httpServerApiContext);
+
+  // Create root app with auth routes
+  const httpServerApiRootApp = new Hono();
+
+  // CORS for auth routes
+  httpServerApiRootApp.use('/api/auth/*', cors({
+    origin: process.env.CORS_ORIGIN || 'http://localhost:3000',
+    allowHeaders: ['Content-Type', 'Authorization'],
+    allowMethods: ['POST', 'GET', 'OPTIONS'],
+    credentials: true,
+  }));
+
+  // Mount better-auth routes
+  httpServerApiRootApp.on(['POST', 'GET'], '/api/auth/*', (c) => auth.handler(c.req.raw));
+
+  // Mount API routes
+  httpServerApiRootApp.route('/', httpServerApiApp);
+
+  serve({ fetch: httpServerApiRootApp.fetch, port: Number(process.env.PORT ?? 3000) }, (info) => {
+    console.log(`http.server.api listening on http://localhost:${info.port}`);
+  });
+}
+
+main().catch(console.error);
@@ -0,0 +1,21 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+import { describe, it, expect } from 'vitest';
+import type { z } from 'zod';
+import { CreateDocumentRequestSchema } from '../components/usecase.schemas';
+import { requestFixtures } from './fixtures';
+
+const checks: [string, z.ZodTypeAny, unknown][] = [
+  ['requestFixtures.createDocumentUsecase', CreateDocumentRequestSchema, requestFixtures.createDocumentUsecase],
+];
+
+describe('fixtures', () => {
+  it.each(checks)('%s should match its schema', (_name, schema, fixture) => {
+    // when
+    const result = schema.safeParse(fixture);
+
+    // then
+    expect(result.success ? [] : result.error.issues).toEqual([]);
+    expect(result.success && result.data).toEqual(fixture);
+  });
+});
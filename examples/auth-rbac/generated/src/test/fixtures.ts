@@ -0,0 +1,19 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+// Deterministic example data derived from OpenAPI schemas, shared by unit and E2E tests.
+
+/** Example values for each components/schemas entry. */
+export const schemaFixtures = {
+};
+
+/** Example request bodies, keyed by usecase function name. */
+export const requestFixtures = {
+  createDocumentUsecase: {
+    body: 'example-body',
+    title: 'Example title',
+  },
+};
+
+/** Example success responses, keyed by usecase function name. */
+export const responseFixtures = {
+};
@@ -0,0 +1,69 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dd180b0d9143ad88dbabd2e90f800e1a350e4afe731fe2835024483acd5718a3
+// Vitest test setup and utilities
+
+import { vi } from 'vitest';
+import { createInMemoryPostgresPrimaryRepositories } from '../components/postgres-primary.postgres.repositories';
+
+// Suppress expected 'Not implemented' errors from usecase stubs
+// These are expected when testing route existence before implementation
+const originalUnhandledRejection = process.listeners('unhandledRejection');
+process.removeAllListeners('unhandledRejection');
+process.on('unhandledRejection', (error: any) => {
+  if (error?.message === 'Not implemented') {
+    // Expected from usecase stubs, ignore
+    return;
+  }
+  // Re-throw other unhandled rejections
+  originalUnhandledRejection.forEach(listener => {
+    if (typeof listener === 'function') listener(error, Promise.reject(error));
+  });
+});
+
+/**
+ * Creates a mock context for testing usecases.
+ * Includes mocked db, auth, and enforcer, and in-memory repositories.
+ * Cast as any to allow use with different ContextWith<K> types.
+ */
+export function createMockContext(): any {
+  return {
+    db: {
+      query: {},
+      insert: vi.fn().mockReturnValue({ values: vi.fn().mockReturnValue({ returning: vi.fn() }) }),
+      update: vi.fn().mockReturnValue({ set: vi.fn().mockReturnValue({ where: vi.fn() }) }),
+      delete: vi.fn().mockReturnValue({ where: vi.fn() }),
+    },
+    repositories: createInMemoryPostgresPrimaryRepositories(),
+    auth: { session: null, user: null },
+    enforcer: {
+      enforce: vi.fn().mockResolvedValue(true),
+      addPolicy: vi.fn().mockResolvedValue(true),
+      removePolicy: vi.fn().mockResolvedValue(true),
+    },
+  };
+}
+
+/**
+ * Creates a mock Hono context for testing middleware.
+ */
+export function createMockHonoContext(overrides = {}) {
+  const variables: Record<string, unknown> = {};
+  return {
+    req: {
+      method: 'GET',
+      path: '/',
+      header: vi.fn().mockReturnValue(null),
+      json: vi.fn().mockResolvedValue({}),
+      param: vi.fn().mockReturnValue({}),
+      query: vi.fn().mockReturnValue({}),
+    },
+    res: undefined,
+    set: vi.fn((key: string, value: unknown) => { variables[key] = value; }),
+    get: vi.fn((key: string) => variables[key]),
+    json: vi.fn(),
+    text: vi.fn(),
+    status: vi.fn(),
+    header: vi.fn(),
+    ...overrides,
+  };
+}
@@ -0,0 +1,11 @@
+import { betterAuth } from 'better-auth';
+import { sessionOptions } from './middleware-authn.middleware.session';
+import { oauthOptions } from './middleware-authn.middleware.oauth';
+
+export const auth = betterAuth({
+  ...sessionOptions,
+  ...oauthOptions,
+  emailAndPassword: {
+    enabled: true,
+  },
+});
@@ -0,0 +1,9 @@
+import { pgTable, uuid, text, timestamp } from 'drizzle-orm/pg-core';
+
+export const documents = pgTable('documents', {
+  id: uuid('id').primaryKey().defaultRandom(),
+  title: text('title').notNull(),
+  body: text('body').notNull(),
+  authorId: text('author_id').notNull(),
+  createdAt: timestamp('created_at').defaultNow(),
+});
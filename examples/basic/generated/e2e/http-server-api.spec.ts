@@ -0,0 +1,53 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+import { test, expect } from '@playwright/test';
+import { createAuthToken } from './helpers/setup';
+
+const baseURL = 'http://localhost:3000';
+
+test.describe('http.server.api API', () => {
+  test('GET /health - health check', async ({ request }) => {
+    const response = await request.get(`${baseURL}/health`);
+    expect(response.status()).toBe(200);
+  });
+
+  test('POST /users - endpoint exists', async ({ request }) => {
+    const response = await request.post(`${baseURL}/users`, {
+      data: {},
+    });
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+  test('DELETE /users/{id} - endpoint exists', async ({ request }) => {
+    const token = createAuthToken({ userId: 'test-user' });
+    const headers = { Authorization: `Bearer ${token}` };
+
+    const response = await request.delete(`${baseURL}/users/test-id`, { headers });
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+  test('GET /users/{id} - endpoint exists', async ({ request }) => {
+    const token = createAuthToken({ userId: 'test-user' });
+    const headers = { Authorization: `Bearer ${token}` };
+
+    const response = await request.get(`${baseURL}/users/test-id`, { headers });
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+  test('GET /users - endpoint exists', async ({ request }) => {
+    const token = createAuthToken({ userId: 'test-user' });
+    const headers = { Authorization: `Bearer ${token}` };
+
+    const response = await request.get(`${baseURL}/users`, { headers });
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+});
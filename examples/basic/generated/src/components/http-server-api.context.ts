@@ -0,0 +1,58 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+
+import type { AuthContext as MiddlewareAuthnAuthContext } from './middleware-authn.middleware';
+import type { DrizzleClient } from './postgres.client';
+import type { Enforcer } from 'casbin';
+import type { PostgresPrimaryRepositories } from './postgres-primary.postgres.repositories';
+
+/**
+ * Context for http.server.api
+ * Contains all dependencies and middleware-provided values.
+ */
+export interface ServerContext {
+  /** Database client from postgres.primary */
+  db: DrizzleClient;
+  /** Repositories of the tables of postgres.primary */
+  repositories: PostgresPrimaryRepositories;
+  /** Context from middleware.authn */
+  auth?: MiddlewareAuthnAuthContext | null;
+  /** Context from middleware.authz */
+  enforcer?: Enforcer | null;
+}
+
+/**
+ * Pick specific fields from ServerContext.
+ * Use this to type usecase context parameters.
+ */
+export type ContextWith<K extends keyof ServerContext> = Pick<ServerContext, K>;
+
+/**
+ * Pick specific fields from ServerContext, with the fields in M required
+ * and non-null because middleware that sets them runs before the handler.
+ */
+export type ContextAfter<K extends keyof ServerContext, M extends K = never> =
+  Pick<ServerContext, Exclude<K, M>> & { [F in M]-?: NonNullable<ServerContext[F]> };
+
+/**
+ * Context of usecase.create-user (POST /users).
+ */
+export type CreateUserUsecaseContext = ContextWith<'db' | 'repositories'>;
+
+/**
+ * Context of usecase.delete-user (DELETE /users/{id}).
+ * Set by middleware.authn, middleware.authz: auth, enforcer.
+ */
+export type DeleteUserUsecaseContext = ContextAfter<'db' | 'repositories' | 'auth' | 'enforcer', 'auth' | 'enforcer'>;
+
+/**
+ * Context of usecase.get-user (GET /users/{id}).
+ * Set by middleware.authn, middleware.authz: auth, enforcer.
+ */
+export type GetUserUsecaseContext = ContextAfter<'db' | 'repositories' | 'auth' | 'enforcer', 'auth' | 'enforcer'>;
+
+/**
+ * Context of usecase.list-users (GET /users).
+ * Set by middleware.authn, middleware.authz: auth, enforcer.
+ */
+export type ListUsersUsecaseContext = ContextAfter<'db' | 'repositories' | 'auth' | 'enforcer', 'auth' | 'enforcer'>;
@@ -0,0 +1,158 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
+import { createHttpServerApiApp } from './http-server-api.server';
+import type { ServerContext } from './http-server-api.context';
+import { createInMemoryPostgresPrimaryRepositories } from './postgres-primary.postgres.repositories';
+
+vi.mock('./usecase-create-user.usecase', () => ({
+  createUserUsecase: vi.fn().mockRejectedValue(new Error('Not implemented')),
+}));
+
+describe('createHttpServerApiApp', () => {
+  afterEach(() => {
+    vi.unstubAllEnvs();
+  });
+
+  it('should create a Hono app instance', () => {
+    // given
+    const mockDeps = createMockDeps();
+
+    // when
+    const app = createHttpServerApiApp(mockDeps);
+
+    // then
+    expect(app).toBeDefined();
+    expect(typeof app.fetch).toBe('function');
+  });
+
+  it('should serve the API reference at /docs', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const page = await app.fetch(new Request('http://localhost/docs'));
+    const document = await app.fetch(new Request('http://localhost/docs/openapi.yaml'));
+
+    // then
+    expect(page.status).toBe(200);
+    expect(page.headers.get('Content-Type')).toContain('text/html');
+    expect(document.status).toBe(200);
+    expect(await document.text()).toContain('openapi: 3.0.3');
+  });
+
+  it('should have POST /users route', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/users', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({}),
+    });
+    const res = await app.fetch(req);
+
+    // then - route should exist (may return error from unimplemented usecase)
+    expect(res.status).not.toBe(404);
+  });
+
+  it('should fail POST /users while its usecase is not implemented', async () => {
+    // given
+    vi.stubEnv('MOCK_UNIMPLEMENTED', 'false');
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/users', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({}),
+    });
+    const res = await app.fetch(req);
+
+    // then
+    expect(res.status).toBe(500);
+  });
+
+  it('should answer POST /users with 501 and its example when MOCK_UNIMPLEMENTED is true', async () => {
+    // given
+    vi.stubEnv('MOCK_UNIMPLEMENTED', 'true');
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/users', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({}),
+    });
+    const res = await app.fetch(req);
+
+    // then
+    expect(res.status).toBe(501);
+    expect(await res.json()).toEqual({ error: 'Not Implemented' });
+  });
+
+  it('should have DELETE /users/:id route', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/users/test-id', {
+      method: 'DELETE',
+    });
+    const res = await app.fetch(req);
+
+    // then - route should exist (may return error from unimplemented usecase)
+    expect(res.status).not.toBe(404);
+  });
+
+  it('should have GET /users/:id route', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/users/test-id', {
+      method: 'GET',
+    });
+    const res = await app.fetch(req);
+
+    // then - route should exist (may return error from unimplemented usecase)
+    expect(res.status).not.toBe(404);
+  });
+
+  it('should have GET /users route', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/users', {
+      method: 'GET',
+    });
+    const res = await app.fetch(req);
+
+    // then - route should exist (may return error from unimplemented usecase)
+    expect(res.status).not.toBe(404);
+  });
+
+});
+
+function createMockDeps(): ServerContext {
+  return {
+    db: {
+      query: {},
+      insert: vi.fn(),
+      update: vi.fn(),
+      delete: vi.fn(),
+    } as any,
+    repositories: createInMemoryPostgresPrimaryRepositories(),
+    auth: { session: null, user: null } as any,
+    enforcer: { enforce: vi.fn().mockResolvedValue(true) } as any,
+  };
+}
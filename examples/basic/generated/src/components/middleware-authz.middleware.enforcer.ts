@@ -0,0 +1,68 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+import { newEnforcer, Enforcer } from 'casbin';
+import { watch } from 'fs';
+import path from 'path';
+import { fileURLToPath } from 'url';
+
+const __dirname = path.dirname(fileURLToPath(import.meta.url));
+
+const modelPath = path.join(__dirname, 'middleware-authz.middleware.model.conf');
+const policyPath = path.join(__dirname, 'middleware-authz.middleware.policy.csv');
+const isProduction = process.env.NODE_ENV === 'production';
+
+let enforcer: Promise<Enforcer> | null = null;
+let watching = false;
+
+async function createEnforcer(): Promise<Enforcer> {
+  return newEnforcer(modelPath, policyPath);
+}
+
+/** Returns the shared enforcer, creating it on first use. */
+export function getEnforcer(): Promise<Enforcer> {
+  if (!enforcer) {
+    enforcer = createEnforcer();
+    if (!isProduction) {
+      watchPolicy();
+    }
+  }
+  return enforcer;
+}
+
+/** Reloads policies from the policy source without restarting the server. */
+export async function reloadPolicy(): Promise<void> {
+  const e = await getEnforcer();
+  await e.loadPolicy();
+}
+
+/** Reloads policies when the policy file changes and rebuilds the enforcer when the model changes. */
+function watchPolicy(): void {
+  if (watching) return;
+  watching = true;
+
+  watch(policyPath, () => {
+    reloadPolicy().catch((err) => console.error('Failed to reload casbin policy:', err));
+  }).unref();
+  watch(modelPath, () => {
+    const next = createEnforcer();
+    next
+      .then(() => {
+        enforcer = next;
+      })
+      .catch((err) => console.error('Failed to reload casbin model:', err));
+  }).unref();
+}
+
+export interface EffectivePolicies {
+  policies: string[][];
+  groupings: string[][];
+}
+
+/** Lists the policies and role assignments the enforcer currently applies. */
+export async function getEffectivePolicies(): Promise<EffectivePolicies> {
+  const e = await getEnforcer();
+  return {
+    policies: await e.getPolicy(),
+    groupings: await e.getGroupingPolicy(),
+  };
+}
@@ -0,0 +1,13 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+import { createMiddleware } from 'hono/factory';
+import { getEnforcer } from './middleware-authz.middleware.enforcer';
+
+export const middlewareAuthzMiddleware = createMiddleware(async (c, next) => {
+  const e = await getEnforcer();
+  c.set('enforcer', e);
+  // TODO: Implement authorization check
+  // const auth = c.get('auth');
+  // const allowed = await e.enforce(auth?.user?.id, c.req.path, c.req.method);
+  await next();
+});
@@ -0,0 +1,90 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+import { randomUUID } from 'node:crypto';
+import { asc, eq } from 'drizzle-orm';
+import type { DrizzleClient } from './postgres.client';
+import type { Repository } from './postgres.repository';
+import { createInMemoryRepository, pageBounds } from './postgres.repository';
+import * as schema from './postgres-primary.postgres.schema';
+
+/** Row of the users table */
+export type UsersRow = typeof schema.users.$inferSelect;
+/** Values of a new row of the users table */
+export type NewUsersRow = typeof schema.users.$inferInsert;
+/** Rows of the users table by id */
+export type UsersRepository = Repository<UsersRow, NewUsersRow, UsersRow['id']>;
+
+/** Row of the projects table */
+export type ProjectsRow = typeof schema.projects.$inferSelect;
+/** Values of a new row of the projects table */
+export type NewProjectsRow = typeof schema.projects.$inferInsert;
+/** Rows of the projects table by id */
+export type ProjectsRepository = Repository<ProjectsRow, NewProjectsRow, ProjectsRow['id']>;
+
+/** Repositories of the tables of postgres.primary */
+export interface PostgresPrimaryRepositories {
+  users: UsersRepository;
+  projects: ProjectsRepository;
+}
+
+/** Creates the repositories of postgres.primary, which query db. */
+export function createPostgresPrimaryRepositories(db: DrizzleClient): PostgresPrimaryRepositories {
+  return {
+    users: {
+      async findById(id) {
+        const [row] = await db.select().from(schema.users).where(eq(schema.users.id, id)).limit(1);
+        return row;
+      },
+      async list(page) {
+        const { limit, offset } = pageBounds(page);
+        return db.select().from(schema.users).orderBy(asc(schema.users.id)).limit(limit).offset(offset);
+      },
+      async create(values) {
+        const [row] = await db.insert(schema.users).values(values).returning();
+        return row;
+      },
+      async update(id, values) {
+        const [row] = await db.update(schema.users).set(values).where(eq(schema.users.id, id)).returning();
+        return row;
+      },
+      async delete(id) {
+        const rows = await db.delete(schema.users).where(eq(schema.users.id, id)).returning();
+        return rows.length > 0;
+      },
+    },
+    projects: {
+      async findById(id) {
+        const [row] = await db.select().from(schema.projects).where(eq(schema.projects.id, id)).limit(1);
+        return row;
+      },
+      async list(page) {
+        const { limit, offset } = pageBounds(page);
+        return db.select().from(schema.projects).orderBy(asc(schema.projects.id)).limit(limit).offset(offset);
+      },
+      async create(values) {
+        const [row] = await db.insert(schema.projects).values(values).returning();
+        return row;
+      },
+      async update(id, values) {
+        const [row] = await db.update(schema.projects).set(values).where(eq(schema.projects.id, id)).returning();
+        return row;
+      },
+      async delete(id) {
+        const rows = await db.delete(schema.projects).where(eq(schema.projects.id, id)).returning();
+        return rows.length > 0;
+      },
+    },
+  };
+}
+
+/**
+ * Creates repositories of postgres.primary that keep rows in memory, for unit tests.
+ * Column defaults are not applied; a row created without a key gets a new one,
+ * and the convention columns are kept as in the database.
+ */
+export function createInMemoryPostgresPrimaryRepositories(): PostgresPrimaryRepositories {
+  return {
+    users: createInMemoryRepository<UsersRow, NewUsersRow, 'id'>('id', () => randomUUID(), { createdAt: true }),
+    projects: createInMemoryRepository<ProjectsRow, NewProjectsRow, 'id'>('id', () => randomUUID(), { createdAt: true }),
+  };
+}
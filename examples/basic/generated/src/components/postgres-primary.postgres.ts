@@ -0,0 +1,20 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+import { drizzle } from 'drizzle-orm/postgres-js';
+import postgres from 'postgres';
+import * as schema from './postgres-primary.postgres.schema';
+
+// Database connection
+const connectionString = process.env.DATABASE_URL || '';
+const client = postgres(connectionString);
+
+// Export db instance for use by auth and other modules
+export const db = drizzle(client, { schema });
+
+// Factory function for explicit initialization with validation
+export async function createPostgresPrimaryClient() {
+  if (!connectionString) {
+    throw new Error('DATABASE_URL environment variable is required');
+  }
+  return db;
+}
@@ -0,0 +1,91 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+import { describe, it, expect } from 'vitest';
+import { MAX_PAGE_SIZE, createInMemoryRepository, pageBounds, sequence } from './postgres.repository';
+
+interface Item {
+  id: number;
+  name: string;
+}
+
+interface Entry extends Item {
+  createdAt: Date;
+  updatedAt: Date;
+  deletedAt: Date | null;
+}
+
+describe('createInMemoryRepository', () => {
+  it('should create, find, update and delete rows', async () => {
+    // given
+    const items = createInMemoryRepository<Item, Partial<Item>, 'id'>('id', sequence());
+
+    // when
+    const created = await items.create({ name: 'first' });
+    const updated = await items.update(created.id, { name: 'renamed' });
+
+    // then
+    expect(created).toEqual({ id: 1, name: 'first' });
+    expect(updated).toEqual({ id: 1, name: 'renamed' });
+    expect(await items.findById(1)).toEqual({ id: 1, name: 'renamed' });
+    expect(await items.delete(1)).toBe(true);
+    expect(await items.findById(1)).toBeUndefined();
+    expect(await items.update(1, { name: 'gone' })).toBeUndefined();
+    expect(await items.delete(1)).toBe(false);
+  });
+
+  it('should list rows a page at a time', async () => {
+    // given
+    const items = createInMemoryRepository<Item, Partial<Item>, 'id'>('id', sequence());
+    for (const name of ['a', 'b', 'c']) {
+      await items.create({ name });
+    }
+
+    // when
+    const page = await items.list({ limit: 2, offset: 1 });
+
+    // then
+    expect(page.map((item) => item.name)).toEqual(['b', 'c']);
+    expect(await items.list()).toHaveLength(3);
+  });
+
+  it('should set createdAt and updatedAt', async () => {
+    // given
+    const entries = createInMemoryRepository<Entry, Partial<Entry>, 'id'>('id', sequence(), {
+      createdAt: true,
+      updatedAt: true,
+    });
+    const created = await entries.create({ name: 'first', createdAt: new Date(0), updatedAt: new Date(0) });
+
+    // when
+    const updated = await entries.update(created.id, { name: 'renamed' });
+
+    // then
+    expect(updated?.createdAt).toEqual(new Date(0));
+    expect(updated?.updatedAt.getTime()).toBeGreaterThan(0);
+    expect((await entries.create({ name: 'second' })).createdAt).toBeInstanceOf(Date);
+  });
+
+  it('should keep soft-deleted rows out of finds, lists and updates', async () => {
+    // given
+    const entries = createInMemoryRepository<Entry, Partial<Entry>, 'id'>('id', sequence(), { deletedAt: true });
+    const kept = await entries.create({ name: 'kept', deletedAt: null });
+    const deleted = await entries.create({ name: 'deleted', deletedAt: null });
+
+    // when
+    const existed = await entries.delete(deleted.id);
+
+    // then
+    expect(existed).toBe(true);
+    expect(await entries.findById(deleted.id)).toBeUndefined();
+    expect(await entries.list()).toEqual([kept]);
+    expect(await entries.update(deleted.id, { name: 'back' })).toBeUndefined();
+    expect(await entries.delete(deleted.id)).toBe(false);
+  });
+});
+
+describe('pageBounds', () => {
+  it('should keep the limit between 1 and MAX_PAGE_SIZE', () => {
+    expect(pageBounds({ limit: 0, offset: -5 })).toEqual({ limit: 1, offset: 0 });
+    expect(pageBounds({ limit: MAX_PAGE_SIZE + 1 }).limit).toBe(MAX_PAGE_SIZE);
+  });
+});
@@ -0,0 +1,44 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { createUserUsecase } from './usecase-create-user.usecase';
+import { createMockContext } from '../test/setup';
+
+describe('createUserUsecase', () => {
+  let mockCtx: ReturnType<typeof createMockContext>;
+
+  beforeEach(() => {
+    mockCtx = createMockContext();
+    vi.clearAllMocks();
+  });
+
+  it('should be a function', () => {
+    expect(typeof createUserUsecase).toBe('function');
+  });
+
+  it('should return a promise', () => {
+    // given
+    const input = {};
+
+    // when
+    const result = createUserUsecase(input as any, mockCtx);
+
+    // then
+    expect(result).toBeInstanceOf(Promise);
+  });
+
+  it('should throw NotImplemented error by default', async () => {
+    // given
+    const input = {};
+
+    // when/then
+    await expect(createUserUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  describe('acceptance criteria', () => {
+    it.todo('AC-usecase.create-user-1: User record is created with provided email');
+    it.todo('AC-usecase.create-user-2: Password is hashed before storage');
+    it.todo('AC-usecase.create-user-3: Confirmation email is queued for delivery');
+    it.todo('AC-usecase.create-user-4: Response includes user ID but not password');
+  });
+});
@@ -0,0 +1,41 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+import type { CreateUserUsecaseContext } from './http-server-api.context';
+import type { CreateUserRequest, CreateUserResponse } from './usecase.schemas';
+
+/**
+ * Register a new user account in the system
+ *
+ * @actor anonymous
+ *
+ * Preconditions:
+ * - Email address is not already registered
+ *
+ * Acceptance Criteria:
+ * - User record is created with provided email
+ * - Password is hashed before storage
+ * - Confirmation email is queued for delivery
+ * - Response includes user ID but not password
+ *
+ * Postconditions:
+ * - User exists in database with status 'pending_verification'
+ */
+export async function createUserUsecase(
+  input: CreateUserRequest,
+  ctx: CreateUserUsecaseContext
+): Promise<CreateUserResponse> {
+  // TODO: Implement usecase
+  //
+  // Preconditions to check:
+  //   - Email address is not already registered
+  //
+  // Implementation should satisfy:
+  //   - User record is created with provided email
+  //   - Password is hashed before storage
+  //   - Confirmation email is queued for delivery
+  //   - Response includes user ID but not password
+  //
+  // Example: const row = await ctx.repositories.users.findById(id);
+
+  throw new Error('Not implemented');
+}
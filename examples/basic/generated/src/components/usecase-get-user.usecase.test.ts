@@ -0,0 +1,65 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { getUserUsecase } from './usecase-get-user.usecase';
+import { createMockContext } from '../test/setup';
+
+describe('getUserUsecase', () => {
+  let mockCtx: ReturnType<typeof createMockContext>;
+
+  beforeEach(() => {
+    mockCtx = createMockContext();
+    vi.clearAllMocks();
+  });
+
+  it('should be a function', () => {
+    expect(typeof getUserUsecase).toBe('function');
+  });
+
+  it('should return a promise', () => {
+    // given
+    const input = {};
+
+    // when
+    const result = getUserUsecase(input as any, mockCtx);
+
+    // then
+    expect(result).toBeInstanceOf(Promise);
+  });
+
+  it('should throw NotImplemented error by default', async () => {
+    // given
+    const input = {};
+
+    // when/then
+    await expect(getUserUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  it('should accept path parameters in input', async () => {
+    // given
+    const input = {
+      id: 'test-id',
+    };
+
+    // when/then - should accept input shape without type error
+    await expect(getUserUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  it('should have access to auth context', async () => {
+    // given
+    const input = {};
+    const ctxWithAuth = {
+      ...mockCtx,
+      auth: { userId: 'user-123', session: {} },
+    };
+
+    // when/then - context should include auth
+    expect(ctxWithAuth.auth).toBeDefined();
+    expect(ctxWithAuth.auth.userId).toBe('user-123');
+  });
+
+  describe('acceptance criteria', () => {
+    it.todo('AC-usecase.get-user-1: Returns user profile data');
+    it.todo('AC-usecase.get-user-2: Excludes sensitive fields (password hash)');
+  });
+});
@@ -0,0 +1,17 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+// Types and zod schemas derived from OpenAPI documents.
+
+import { z } from 'zod';
+
+export const CreateUserRequestSchema = z.record(z.unknown());
+export type CreateUserRequest = z.infer<typeof CreateUserRequestSchema>;
+
+export const CreateUserResponseSchema = z.record(z.unknown());
+export type CreateUserResponse = z.infer<typeof CreateUserResponseSchema>;
+
+export const GetUserResponseSchema = z.record(z.unknown());
+export type GetUserResponse = z.infer<typeof GetUserResponseSchema>;
+
+export const ListUsersResponseSchema = z.record(z.unknown());
+export type ListUsersResponse = z.infer<typeof ListUsersResponseSchema>;
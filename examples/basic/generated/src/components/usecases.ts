@@ -0,0 +1,8 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+// Re-exports all usecases for convenient importing
+
+export { createUserUsecase } from './usecase-create-user.usecase';
+export { deleteUserUsecase } from './usecase-delete-user.usecase';
+export { getUserUsecase } from './usecase-get-user.usecase';
+export { listUsersUsecase } from './usecase-list-users.usecase';
@@ -0,0 +1,15 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:dc08292d1bf0f845d122ceba1e795a13995e3b4a2d6ab734cb50da2cda0d4759
+// Deterministic example data derived from OpenAPI schemas, shared by unit and E2E tests.
+
+/** Example values for each components/schemas entry. */
+export const schemaFixtures = {
+};
+
+/** Example request bodies, keyed by usecase function name. */
+export const requestFixtures = {
+};
+
+/** Example success responses, keyed by usecase function name. */
+export const responseFixtures = {
+};
@@ -0,0 +1,38 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+// E2E test helpers and setup utilities
+
+/**
+ * Creates test data for API endpoints.
+ */
+export function createTestData(type: string): Record<string, unknown> {
+  const timestamp = Date.now();
+
+  switch (type) {
+    case 'user':
+      return {
+        email: `test-${timestamp}@example.com`,
+        name: `Test User ${timestamp}`,
+      };
+    default:
+      return {};
+  }
+}
+
+/**
+ * Waits for a condition to be true.
+ */
+export async function waitForCondition(
+  condition: () => Promise<boolean>,
+  timeout = 5000,
+  interval = 100
+): Promise<void> {
+  const start = Date.now();
+  while (Date.now() - start < timeout) {
+    if (await condition()) {
+      return;
+    }
+    await new Promise((resolve) => setTimeout(resolve, interval));
+  }
+  throw new Error('Condition not met within timeout');
+}
@@ -0,0 +1,44 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import { test, expect } from '@playwright/test';
+import { requestFixtures } from '../src/test/fixtures';
+
+const baseURL = 'http://localhost:3000';
+
+test.describe('http.server.api API', () => {
+  test('GET /health - health check', async ({ request }) => {
+    const response = await request.get(`${baseURL}/health`);
+    expect(response.status()).toBe(200);
+  });
+
+  test('GET /reports/daily-revenue - endpoint exists', async ({ request }) => {
+    const response = await request.get(`${baseURL}/reports/daily-revenue`);
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+  test('GET /orders/{id} - endpoint exists', async ({ request }) => {
+    const response = await request.get(`${baseURL}/orders/test-id`);
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+  test('GET /status - endpoint exists', async ({ request }) => {
+    const response = await request.get(`${baseURL}/status`);
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+  test('POST /orders - endpoint exists', async ({ request }) => {
+    const response = await request.post(`${baseURL}/orders`, {
+      data: requestFixtures.placeOrderUsecase,
+    });
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+});
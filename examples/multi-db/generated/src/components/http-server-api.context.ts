@@ -0,0 +1,54 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+
+import type { DrizzleClient } from './postgres.client';
+import type { PostgresAnalyticsRepositories } from './postgres-analytics.postgres.repositories';
+import type { PostgresPrimaryRepositories } from './postgres-primary.postgres.repositories';
+
+/**
+ * Context for http.server.api
+ * Contains all dependencies and middleware-provided values.
+ */
+export interface ServerContext {
+  /** Database client from postgres.primary */
+  primaryDb: DrizzleClient;
+  /** Repositories of the tables of postgres.primary */
+  primaryRepositories: PostgresPrimaryRepositories;
+  /** Database client from postgres.analytics */
+  analyticsDb: DrizzleClient;
+  /** Repositories of the tables of postgres.analytics */
+  analyticsRepositories: PostgresAnalyticsRepositories;
+}
+
+/**
+ * Pick specific fields from ServerContext.
+ * Use this to type usecase context parameters.
+ */
+export type ContextWith<K extends keyof ServerContext> = Pick<ServerContext, K>;
+
+/**
+ * Pick specific fields from ServerContext, with the fields in M required
+ * and non-null because middleware that sets them runs before the handler.
+ */
+export type ContextAfter<K extends keyof ServerContext, M extends K = never> =
+  Pick<ServerContext, Exclude<K, M>> & { [F in M]-?: NonNullable<ServerContext[F]> };
+
+/**
+ * Context of usecase.daily-revenue (GET /reports/daily-revenue).
+ */
+export type DailyRevenueUsecaseContext = ContextWith<'analyticsDb' | 'analyticsRepositories'>;
+
+/**
+ * Context of usecase.get-order (GET /orders/{id}).
+ */
+export type GetOrderUsecaseContext = ContextWith<'primaryDb' | 'primaryRepositories'>;
+
+/**
+ * Context of usecase.health-summary (GET /status).
+ */
+export type HealthSummaryUsecaseContext = ContextWith<never>;
+
+/**
+ * Context of usecase.place-order (POST /orders).
+ */
+export type PlaceOrderUsecaseContext = ContextWith<'primaryDb' | 'primaryRepositories'>;
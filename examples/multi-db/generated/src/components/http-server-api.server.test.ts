@@ -0,0 +1,276 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
+import { createHttpServerApiApp } from './http-server-api.server';
+import type { ServerContext } from './http-server-api.context';
+import { createInMemoryPostgresPrimaryRepositories } from './postgres-primary.postgres.repositories';
+import { createInMemoryPostgresAnalyticsRepositories } from './postgres-analytics.postgres.repositories';
+import { requestFixtures } from '../test/fixtures';
+
+vi.mock('./usecase-daily-revenue.usecase', () => ({
+  dailyRevenueUsecase: vi.fn().mockRejectedValue(new Error('Not implemented')),
+}));
+
+vi.mock('./usecase-get-order.usecase', () => ({
+  getOrderUsecase: vi.fn().mockRejectedValue(new Error('Not implemented')),
+}));
+
+vi.mock('./usecase-health-summary.usecase', () => ({
+  healthSummaryUsecase: vi.fn().mockRejectedValue(new Error('Not implemented')),
+}));
+
+vi.mock('./usecase-place-order.usecase', () => ({
+  placeOrderUsecase: vi.fn().mockRejectedValue(new Error('Not implemented')),
+}));
+
+describe('createHttpServerApiApp', () => {
+  afterEach(() => {
+    vi.unstubAllEnvs();
+  });
+
+  it('should create a Hono app instance', () => {
+    // given
+    const mockDeps = createMockDeps();
+
+    // when
+    const app = createHttpServerApiApp(mockDeps);
+
+    // then
+    expect(app).toBeDefined();
+    expect(typeof app.fetch).toBe('function');
+  });
+
+  it('should serve the API reference at /docs', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const page = await app.fetch(new Request('http://localhost/docs'));
+    const document = await app.fetch(new Request('http://localhost/docs/openapi.yaml'));
+
+    // then
+    expect(page.status).toBe(200);
+    expect(page.headers.get('Content-Type')).toContain('text/html');
+    expect(document.status).toBe(200);
+    expect(await document.text()).toContain('openapi: 3.0.3');
+  });
+
+  it('should have GET /reports/daily-revenue route', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/reports/daily-revenue', {
+      method: 'GET',
+    });
+    const res = await app.fetch(req);
+
+    // then - route should exist (may return error from unimplemented usecase)
+    expect(res.status).not.toBe(404);
+  });
+
+  it('should fail GET /reports/daily-revenue while its usecase is not implemented', async () => {
+    // given
+    vi.stubEnv('MOCK_UNIMPLEMENTED', 'false');
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/reports/daily-revenue', {
+      method: 'GET',
+    });
+    const res = await app.fetch(req);
+
+    // then
+    expect(res.status).toBe(500);
+  });
+
+  it('should answer GET /reports/daily-revenue with 501 and its example when MOCK_UNIMPLEMENTED is true', async () => {
+    // given
+    vi.stubEnv('MOCK_UNIMPLEMENTED', 'true');
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/reports/daily-revenue', {
+      method: 'GET',
+    });
+    const res = await app.fetch(req);
+
+    // then
+    expect(res.status).toBe(501);
+    expect(await res.json()).toEqual({ error: 'Not Implemented' });
+  });
+
+  it('should have GET /orders/:id route', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/orders/test-id', {
+      method: 'GET',
+    });
+    const res = await app.fetch(req);
+
+    // then - route should exist (may return error from unimplemented usecase)
+    expect(res.status).not.toBe(404);
+  });
+
+  it('should fail GET /orders/:id while its usecase is not implemented', async () => {
+    // given
+    vi.stubEnv('MOCK_UNIMPLEMENTED', 'false');
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/orders/test-id', {
+      method: 'GET',
+    });
+    const res = await app.fetch(req);
+
+    // then
+    expect(res.status).toBe(500);
+  });
+
+  it('should answer GET /orders/:id with 501 and its example when MOCK_UNIMPLEMENTED is true', async () => {
+    // given
+    vi.stubEnv('MOCK_UNIMPLEMENTED', 'true');
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/orders/test-id', {
+      method: 'GET',
+    });
+    const res = await app.fetch(req);
+
+    // then
+    expect(res.status).toBe(501);
+    expect(await res.json()).toEqual({ error: 'Not Implemented' });
+  });
+
+  it('should have GET /status route', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/status', {
+      method: 'GET',
+    });
+    const res = await app.fetch(req);
+
+    // then - route should exist (may return error from unimplemented usecase)
+    expect(res.status).not.toBe(404);
+  });
+
+  it('should fail GET /status while its usecase is not implemented', async () => {
+    // given
+    vi.stubEnv('MOCK_UNIMPLEMENTED', 'false');
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/status', {
+      method: 'GET',
+    });
+    const res = await app.fetch(req);
+
+    // then
+    expect(res.status).toBe(500);
+  });
+
+  it('should answer GET /status with 501 and its example when MOCK_UNIMPLEMENTED is true', async () => {
+    // given
+    vi.stubEnv('MOCK_UNIMPLEMENTED', 'true');
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/status', {
+      method: 'GET',
+    });
+    const res = await app.fetch(req);
+
+    // then
+    expect(res.status).toBe(501);
+    expect(await res.json()).toEqual({ error: 'Not Implemented' });
+  });
+
+  it('should have POST /orders route', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/orders', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify(requestFixtures.placeOrderUsecase),
+    });
+    const res = await app.fetch(req);
+
+    // then - route should exist (may return error from unimplemented usecase)
+    expect(res.status).not.toBe(404);
+  });
+
+  it('should fail POST /orders while its usecase is not implemented', async () => {
+    // given
+    vi.stubEnv('MOCK_UNIMPLEMENTED', 'false');
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/orders', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify(requestFixtures.placeOrderUsecase),
+    });
+    const res = await app.fetch(req);
+
+    // then
+    expect(res.status).toBe(500);
+  });
+
+  it('should answer POST /orders with 501 and its example when MOCK_UNIMPLEMENTED is true', async () => {
+    // given
+    vi.stubEnv('MOCK_UNIMPLEMENTED', 'true');
+    const mockDeps = createMockDeps();
+    const app = createHttpServerApiApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/orders', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify(requestFixtures.placeOrderUsecase),
+    });
+    const res = await app.fetch(req);
+
+    // then
+    expect(res.status).toBe(501);
+    expect(await res.json()).toEqual({ error: 'Not Implemented' });
+  });
+
+});
+
+function createMockDeps(): ServerContext {
+  return {
+    primaryDb: {
+      query: {},
+      insert: vi.fn(),
+      update: vi.fn(),
+      delete: vi.fn(),
+    } as any,
+    primaryRepositories: createInMemoryPostgresPrimaryRepositories(),
+    analyticsDb: {
+      query: {},
+      insert: vi.fn(),
+      update: vi.fn(),
+      delete: vi.fn(),
+    } as any,
+    analyticsRepositories: createInMemoryPostgresAnalyticsRepositories(),
+  };
+}
@@ -0,0 +1,129 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import { Hono } from 'hono';
+import type { ServerContext } from './http-server-api.context';
+import { dailyRevenueUsecase } from './usecase-daily-revenue.usecase';
+import { getOrderUsecase } from './usecase-get-order.usecase';
+import { healthSummaryUsecase } from './usecase-health-summary.usecase';
+import { placeOrderUsecase } from './usecase-place-order.usecase';
+import { apiDocsPage, openapiDocument } from './http-server-api.docs';
+
+/**
+ * Answers 501 with a route's example response while its usecase is not
+ * implemented yet and MOCK_UNIMPLEMENTED is true, so a partly implemented
+ * service can be demoed. Any other error is rethrown.
+ */
+function mockUnimplemented(error: unknown, example: unknown): Response {
+  if (process.env.MOCK_UNIMPLEMENTED === 'true' && error instanceof Error && error.message === 'Not implemented') {
+    return new Response(JSON.stringify(example ?? { error: 'Not Implemented' }), {
+      status: 501,
+      headers: { 'Content-Type': 'application/json' },
+    });
+  }
+  throw error;
+}
+
+type Env = {
+  Variables: ServerContext;
+};
+
+/**
+ * Creates the http.server.api Hono application.
+ * @param ctx - The server context with dependencies
+ */
+export function createHttpServerApiApp(ctx: ServerContext): Hono<Env> {
+  const app = new Hono<Env>();
+
+  // Set base context from dependencies
+  app.use('*', async (c, next) => {
+    c.set('primaryDb', ctx.primaryDb);
+    c.set('primaryRepositories', ctx.primaryRepositories);
+    c.set('analyticsDb', ctx.analyticsDb);
+    c.set('analyticsRepositories', ctx.analyticsRepositories);
+    await next();
+  });
+
+  // Health check
+  app.get('/health', (c) => c.json({ status: 'ok' }));
+
+  // API reference
+  if (process.env.NODE_ENV !== 'production') {
+    app.get('/docs', (c) => c.html(apiDocsPage));
+    app.get('/docs/openapi.yaml', (c) => c.body(openapiDocument, 200, { 'Content-Type': 'application/yaml' }));
+  }
+
+  // Route handlers
+
+  // usecase.daily-revenue - Report revenue per day
+  app.get('/reports/daily-revenue', async (c) => {
+    const context = {
+      analyticsDb: c.get('analyticsDb'),
+      analyticsRepositories: c.get('analyticsRepositories'),
+    };
+
+    let result;
+    try {
+      result = await dailyRevenueUsecase(undefined as void, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.json(result);
+  });
+
+  // usecase.get-order - Show an order and its lines
+  app.get('/orders/:id', async (c) => {
+    const id = c.req.param('id');
+    const input = {
+      id,
+    };
+
+    const context = {
+      primaryDb: c.get('primaryDb'),
+      primaryRepositories: c.get('primaryRepositories'),
+    };
+
+    let result;
+    try {
+      result = await getOrderUsecase(input, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.json(result);
+  });
+
+  // usecase.health-summary - Report whether the service is accepting orders
+  app.get('/status', async (c) => {
+    const context = {};
+
+    let result;
+    try {
+      result = await healthSummaryUsecase(undefined as void, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.json(result);
+  });
+
+  // usecase.place-order - Place an order
+  app.post('/orders', async (c) => {
+    const body = await c.req.json();
+    const input = {
+      ...body,
+    };
+
+    const context = {
+      primaryDb: c.get('primaryDb'),
+      primaryRepositories: c.get('primaryRepositories'),
+    };
+
+    let result;
+    try {
+      result = await placeOrderUsecase(input, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.json(result, 201);
+  });
+
+  return app;
+}
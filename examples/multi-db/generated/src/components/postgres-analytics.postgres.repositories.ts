@@ -0,0 +1,59 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import { randomUUID } from 'node:crypto';
+import { asc, eq } from 'drizzle-orm';
+import type { DrizzleClient } from './postgres.client';
+import type { Repository } from './postgres.repository';
+import { createInMemoryRepository, pageBounds } from './postgres.repository';
+import * as schema from './postgres-analytics.postgres.schema';
+
+/** Row of the dailyRevenue table */
+export type DailyRevenueRow = typeof schema.dailyRevenue.$inferSelect;
+/** Values of a new row of the dailyRevenue table */
+export type NewDailyRevenueRow = typeof schema.dailyRevenue.$inferInsert;
+/** Rows of the dailyRevenue table by day */
+export type DailyRevenueRepository = Repository<DailyRevenueRow, NewDailyRevenueRow, DailyRevenueRow['day']>;
+
+/** Repositories of the tables of postgres.analytics */
+export interface PostgresAnalyticsRepositories {
+  dailyRevenue: DailyRevenueRepository;
+}
+
+/** Creates the repositories of postgres.analytics, which query db. */
+export function createPostgresAnalyticsRepositories(db: DrizzleClient): PostgresAnalyticsRepositories {
+  return {
+    dailyRevenue: {
+      async findById(id) {
+        const [row] = await db.select().from(schema.dailyRevenue).where(eq(schema.dailyRevenue.day, id)).limit(1);
+        return row;
+      },
+      async list(page) {
+        const { limit, offset } = pageBounds(page);
+        return db.select().from(schema.dailyRevenue).orderBy(asc(schema.dailyRevenue.day)).limit(limit).offset(offset);
+      },
+      async create(values) {
+        const [row] = await db.insert(schema.dailyRevenue).values(values).returning();
+        return row;
+      },
+      async update(id, values) {
+        const [row] = await db.update(schema.dailyRevenue).set(values).where(eq(schema.dailyRevenue.day, id)).returning();
+        return row;
+      },
+      async delete(id) {
+        const rows = await db.delete(schema.dailyRevenue).where(eq(schema.dailyRevenue.day, id)).returning();
+        return rows.length > 0;
+      },
+    },
+  };
+}
+
+/**
+ * Creates repositories of postgres.analytics that keep rows in memory, for unit tests.
+ * Column defaults are not applied; a row created without a key gets a new one,
+ * and the convention columns are kept as in the database.
+ */
+export function createInMemoryPostgresAnalyticsRepositories(): PostgresAnalyticsRepositories {
+  return {
+    dailyRevenue: createInMemoryRepository<DailyRevenueRow, NewDailyRevenueRow, 'day'>('day', () => randomUUID()),
+  };
+}
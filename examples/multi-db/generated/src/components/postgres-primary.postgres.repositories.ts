@@ -0,0 +1,90 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import { randomUUID } from 'node:crypto';
+import { asc, eq } from 'drizzle-orm';
+import type { DrizzleClient } from './postgres.client';
+import type { Repository } from './postgres.repository';
+import { createInMemoryRepository, pageBounds } from './postgres.repository';
+import * as schema from './postgres-primary.postgres.schema';
+
+/** Row of the orders table */
+export type OrdersRow = typeof schema.orders.$inferSelect;
+/** Values of a new row of the orders table */
+export type NewOrdersRow = typeof schema.orders.$inferInsert;
+/** Rows of the orders table by id */
+export type OrdersRepository = Repository<OrdersRow, NewOrdersRow, OrdersRow['id']>;
+
+/** Row of the orderLines table */
+export type OrderLinesRow = typeof schema.orderLines.$inferSelect;
+/** Values of a new row of the orderLines table */
+export type NewOrderLinesRow = typeof schema.orderLines.$inferInsert;
+/** Rows of the orderLines table by id */
+export type OrderLinesRepository = Repository<OrderLinesRow, NewOrderLinesRow, OrderLinesRow['id']>;
+
+/** Repositories of the tables of postgres.primary */
+export interface PostgresPrimaryRepositories {
+  orders: OrdersRepository;
+  orderLines: OrderLinesRepository;
+}
+
+/** Creates the repositories of postgres.primary, which query db. */
+export function createPostgresPrimaryRepositories(db: DrizzleClient): PostgresPrimaryRepositories {
+  return {
+    orders: {
+      async findById(id) {
+        const [row] = await db.select().from(schema.orders).where(eq(schema.orders.id, id)).limit(1);
+        return row;
+      },
+      async list(page) {
+        const { limit, offset } = pageBounds(page);
+        return db.select().from(schema.orders).orderBy(asc(schema.orders.id)).limit(limit).offset(offset);
+      },
+      async create(values) {
+        const [row] = await db.insert(schema.orders).values(values).returning();
+        return row;
+      },
+      async update(id, values) {
+        const [row] = await db.update(schema.orders).set(values).where(eq(schema.orders.id, id)).returning();
+        return row;
+      },
+      async delete(id) {
+        const rows = await db.delete(schema.orders).where(eq(schema.orders.id, id)).returning();
+        return rows.length > 0;
+      },
+    },
+    orderLines: {
+      async findById(id) {
+        const [row] = await db.select().from(schema.orderLines).where(eq(schema.orderLines.id, id)).limit(1);
+        return row;
+      },
+      async list(page) {
+        const { limit, offset } = pageBounds(page);
+        return db.select().from(schema.orderLines).orderBy(asc(schema.orderLines.id)).limit(limit).offset(offset);
+      },
+      async create(values) {
+        const [row] = await db.insert(schema.orderLines).values(values).returning();
+        return row;
+      },
+      async update(id, values) {
+        const [row] = await db.update(schema.orderLines).set(values).where(eq(schema.orderLines.id, id)).returning();
+        return row;
+      },
+      async delete(id) {
+        const rows = await db.delete(schema.orderLines).where(eq(schema.orderLines.id, id)).returning();
+        return rows.length > 0;
+      },
+    },
+  };
+}
+
+/**
+ * Creates repositories of postgres.primary that keep rows in memory, for unit tests.
+ * Column defaults are not applied; a row created without a key gets a new one,
+ * and the convention columns are kept as in the database.
+ */
+export function createInMemoryPostgresPrimaryRepositories(): PostgresPrimaryRepositories {
+  return {
+    orders: createInMemoryRepository<OrdersRow, NewOrdersRow, 'id'>('id', () => randomUUID(), { createdAt: true }),
+    orderLines: createInMemoryRepository<OrderLinesRow, NewOrderLinesRow, 'id'>('id', () => randomUUID()),
+  };
+}
@@ -0,0 +1,16 @@
+import { pgTable, uuid, text, integer, timestamp } from 'drizzle-orm/pg-core';
+
+export const orders = pgTable('orders', {
+  id: uuid('id').primaryKey().defaultRandom(),
+  status: text('status').notNull().default('placed'),
+  totalCents: integer('total_cents').notNull(),
+  createdAt: timestamp('created_at').defaultNow(),
+});
+
+export const orderLines = pgTable('order_lines', {
+  id: uuid('id').primaryKey().defaultRandom(),
+  orderId: uuid('order_id').notNull().references(() => orders.id),
+  sku: text('sku').notNull(),
+  quantity: integer('quantity').notNull(),
+  unitCents: integer('unit_cents').notNull(),
+});
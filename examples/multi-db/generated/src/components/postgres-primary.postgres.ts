@@ -0,0 +1,20 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import { drizzle } from 'drizzle-orm/postgres-js';
+import postgres from 'postgres';
+import * as schema from './postgres-primary.postgres.schema';
+
+// Database connection
+const connectionString = process.env.PRIMARY_DATABASE_URL || '';
+const client = postgres(connectionString);
+
+// Export db instance for use by auth and other modules
+export const db = drizzle(client, { schema });
+
+// Factory function for explicit initialization with validation
+export async function createPostgresPrimaryClient() {
+  if (!connectionString) {
+    throw new Error('PRIMARY_DATABASE_URL environment variable is required');
+  }
+  return db;
+}
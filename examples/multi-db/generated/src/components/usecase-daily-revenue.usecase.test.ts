@@ -0,0 +1,41 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { dailyRevenueUsecase } from './usecase-daily-revenue.usecase';
+import { createMockContext } from '../test/setup';
+
+describe('dailyRevenueUsecase', () => {
+  let mockCtx: ReturnType<typeof createMockContext>;
+
+  beforeEach(() => {
+    mockCtx = createMockContext();
+    vi.clearAllMocks();
+  });
+
+  it('should be a function', () => {
+    expect(typeof dailyRevenueUsecase).toBe('function');
+  });
+
+  it('should return a promise', () => {
+    // given
+    const input = {};
+
+    // when
+    const result = dailyRevenueUsecase(input as any, mockCtx);
+
+    // then
+    expect(result).toBeInstanceOf(Promise);
+  });
+
+  it('should throw NotImplemented error by default', async () => {
+    // given
+    const input = {};
+
+    // when/then
+    await expect(dailyRevenueUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  describe('acceptance criteria', () => {
+    it.todo('AC-usecase.daily-revenue-1: Returns one row per day, newest first');
+  });
+});
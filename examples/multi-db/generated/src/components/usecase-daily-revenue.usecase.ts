@@ -0,0 +1,26 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import type { DailyRevenueUsecaseContext } from './http-server-api.context';
+import type { DailyRevenueResponse } from './usecase.schemas';
+
+/**
+ * Report revenue per day
+ *
+ * @actor analyst
+ *
+ * Acceptance Criteria:
+ * - Returns one row per day, newest first
+ */
+export async function dailyRevenueUsecase(
+  input: void,
+  ctx: DailyRevenueUsecaseContext
+): Promise<DailyRevenueResponse> {
+  // TODO: Implement usecase
+  //
+  // Implementation should satisfy:
+  //   - Returns one row per day, newest first
+  //
+  // Example: const row = await ctx.analyticsRepositories.dailyRevenue.findById(id);
+
+  throw new Error('Not implemented');
+}
@@ -0,0 +1,51 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { getOrderUsecase } from './usecase-get-order.usecase';
+import { createMockContext } from '../test/setup';
+
+describe('getOrderUsecase', () => {
+  let mockCtx: ReturnType<typeof createMockContext>;
+
+  beforeEach(() => {
+    mockCtx = createMockContext();
+    vi.clearAllMocks();
+  });
+
+  it('should be a function', () => {
+    expect(typeof getOrderUsecase).toBe('function');
+  });
+
+  it('should return a promise', () => {
+    // given
+    const input = {};
+
+    // when
+    const result = getOrderUsecase(input as any, mockCtx);
+
+    // then
+    expect(result).toBeInstanceOf(Promise);
+  });
+
+  it('should throw NotImplemented error by default', async () => {
+    // given
+    const input = {};
+
+    // when/then
+    await expect(getOrderUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  it('should accept path parameters in input', async () => {
+    // given
+    const input = {
+      id: 'test-id',
+    };
+
+    // when/then - should accept input shape without type error
+    await expect(getOrderUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  describe('acceptance criteria', () => {
+    it.todo('AC-usecase.get-order-1: Returns 404 for unknown orders');
+  });
+});
@@ -0,0 +1,31 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import type { GetOrderUsecaseContext } from './http-server-api.context';
+import type { GetOrderResponse } from './usecase.schemas';
+
+/** Input with path parameters */
+export interface GetOrderUsecaseInput {
+  id: string;
+}
+
+/**
+ * Show an order and its lines
+ *
+ * @actor customer
+ *
+ * Acceptance Criteria:
+ * - Returns 404 for unknown orders
+ */
+export async function getOrderUsecase(
+  input: GetOrderUsecaseInput,
+  ctx: GetOrderUsecaseContext
+): Promise<GetOrderResponse> {
+  // TODO: Implement usecase
+  //
+  // Implementation should satisfy:
+  //   - Returns 404 for unknown orders
+  //
+  // Example: const row = await ctx.primaryRepositories.orders.findById(id);
+
+  throw new Error('Not implemented');
+}
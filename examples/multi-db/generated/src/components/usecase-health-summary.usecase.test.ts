@@ -0,0 +1,41 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { healthSummaryUsecase } from './usecase-health-summary.usecase';
+import { createMockContext } from '../test/setup';
+
+describe('healthSummaryUsecase', () => {
+  let mockCtx: ReturnType<typeof createMockContext>;
+
+  beforeEach(() => {
+    mockCtx = createMockContext();
+    vi.clearAllMocks();
+  });
+
+  it('should be a function', () => {
+    expect(typeof healthSummaryUsecase).toBe('function');
+  });
+
+  it('should return a promise', () => {
+    // given
+    const input = {};
+
+    // when
+    const result = healthSummaryUsecase(input as any, mockCtx);
+
+    // then
+    expect(result).toBeInstanceOf(Promise);
+  });
+
+  it('should throw NotImplemented error by default', async () => {
+    // given
+    const input = {};
+
+    // when/then
+    await expect(healthSummaryUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  describe('acceptance criteria', () => {
+    it.todo('AC-usecase.health-summary-1: Responds without touching either database');
+  });
+});
@@ -0,0 +1,24 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import type { HealthSummaryUsecaseContext } from './http-server-api.context';
+import type { GetStatusResponse } from './usecase.schemas';
+
+/**
+ * Report whether the service is accepting orders
+ *
+ * @actor operator
+ *
+ * Acceptance Criteria:
+ * - Responds without touching either database
+ */
+export async function healthSummaryUsecase(
+  input: void,
+  ctx: HealthSummaryUsecaseContext
+): Promise<GetStatusResponse> {
+  // TODO: Implement usecase
+  //
+  // Implementation should satisfy:
+  //   - Responds without touching either database
+  //
+  throw new Error('Not implemented');
+}
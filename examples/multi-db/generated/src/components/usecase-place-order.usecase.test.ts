@@ -0,0 +1,42 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { placeOrderUsecase } from './usecase-place-order.usecase';
+import { createMockContext } from '../test/setup';
+import { requestFixtures } from '../test/fixtures';
+
+describe('placeOrderUsecase', () => {
+  let mockCtx: ReturnType<typeof createMockContext>;
+
+  beforeEach(() => {
+    mockCtx = createMockContext();
+    vi.clearAllMocks();
+  });
+
+  it('should be a function', () => {
+    expect(typeof placeOrderUsecase).toBe('function');
+  });
+
+  it('should return a promise', () => {
+    // given
+    const input = { ...requestFixtures.placeOrderUsecase };
+
+    // when
+    const result = placeOrderUsecase(input as any, mockCtx);
+
+    // then
+    expect(result).toBeInstanceOf(Promise);
+  });
+
+  it('should throw NotImplemented error by default', async () => {
+    // given
+    const input = { ...requestFixtures.placeOrderUsecase };
+
+    // when/then
+    await expect(placeOrderUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  describe('acceptance criteria', () => {
+    it.todo('AC-usecase.place-order-1: The order total is the sum of its lines');
+  });
+});
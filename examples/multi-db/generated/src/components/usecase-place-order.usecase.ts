@@ -0,0 +1,29 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import type { PlaceOrderUsecaseContext } from './http-server-api.context';
+import type { PlaceOrderRequest, PlaceOrderResponse } from './usecase.schemas';
+
+/**
+ * Place an order
+ *
+ * @actor customer
+ *
+ * Acceptance Criteria:
+ * - The order total is the sum of its lines
+ *
+ * Postconditions:
+ * - The order is stored with status 'placed'
+ */
+export async function placeOrderUsecase(
+  input: PlaceOrderRequest,
+  ctx: PlaceOrderUsecaseContext
+): Promise<PlaceOrderResponse> {
+  // TODO: Implement usecase
+  //
+  // Implementation should satisfy:
+  //   - The order total is the sum of its lines
+  //
+  // Example: const row = await ctx.primaryRepositories.orders.findById(id);
+
+  throw new Error('Not implemented');
+}
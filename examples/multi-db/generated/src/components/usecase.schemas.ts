@@ -0,0 +1,25 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+// Types and zod schemas derived from OpenAPI documents.
+
+import { z } from 'zod';
+
+export const DailyRevenueResponseSchema = z.record(z.unknown());
+export type DailyRevenueResponse = z.infer<typeof DailyRevenueResponseSchema>;
+
+export const GetOrderResponseSchema = z.record(z.unknown());
+export type GetOrderResponse = z.infer<typeof GetOrderResponseSchema>;
+
+export const GetStatusResponseSchema = z.record(z.unknown());
+export type GetStatusResponse = z.infer<typeof GetStatusResponseSchema>;
+
+export const PlaceOrderRequestSchema = z.object({
+  lines: z.array(z.object({
+    quantity: z.number().int(),
+    sku: z.string(),
+  })),
+});
+export type PlaceOrderRequest = z.infer<typeof PlaceOrderRequestSchema>;
+
+export const PlaceOrderResponseSchema = z.record(z.unknown());
+export type PlaceOrderResponse = z.infer<typeof PlaceOrderResponseSchema>;
@@ -0,0 +1,8 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+// Re-exports all usecases for convenient importing
+
+export { dailyRevenueUsecase } from './usecase-daily-revenue.usecase';
+export { getOrderUsecase } from './usecase-get-order.usecase';
+export { healthSummaryUsecase } from './usecase-health-summary.usecase';
+export { placeOrderUsecase } from './usecase-place-order.usecase';
@@ -0,0 +1,31 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import { serve } from '@hono/node-server';
+import { createHttpServerApiApp } from './components/http-server-api.server';
+import { createPostgresAnalyticsClient } from './components/postgres-analytics.postgres';
+import { createPostgresAnalyticsRepositories } from './components/postgres-analytics.postgres.repositories';
+import { createPostgresPrimaryClient } from './components/postgres-primary.postgres';
+import { createPostgresPrimaryRepositories } from './components/postgres-primary.postgres.repositories';
+
+async function main() {
+  // Initialize dependencies
+  const postgresAnalyticsClient = await createPostgresAnalyticsClient();
+  const postgresAnalyticsRepositories = createPostgresAnalyticsRepositories(postgresAnalyticsClient);
+  const postgresPrimaryClient = await createPostgresPrimaryClient();
+  const postgresPrimaryRepositories = createPostgresPrimaryRepositories(postgresPrimaryClient);
+
+  // Start http.server.api
+  const httpServerApiContext = {
+    primaryDb: postgresPrimaryClient,
+    primaryRepositories: postgresPrimaryRepositories,
+    analyticsDb: postgresAnalyticsClient,
+    analyticsRepositories: postgresAnalyticsRepositories,
+  };
+
+  const httpServerApiApp = createHttpServerApiApp(httpServerApiContext);
+  serve({ fetch: httpServerApiApp.fetch, port: Number(process.env.PORT ?? 3000) }, (info) => {
+    console.log(`http.server.api listening on http://localhost:${info.port}`);
+  });
+}
+
+main().catch(console.error);
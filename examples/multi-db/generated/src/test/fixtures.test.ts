@@ -0,0 +1,21 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+import { describe, it, expect } from 'vitest';
+import type { z } from 'zod';
+import { PlaceOrderRequestSchema } from '../components/usecase.schemas';
+import { requestFixtures } from './fixtures';
+
+const checks: [string, z.ZodTypeAny, unknown][] = [
+  ['requestFixtures.placeOrderUsecase', PlaceOrderRequestSchema, requestFixtures.placeOrderUsecase],
+];
+
+describe('fixtures', () => {
+  it.each(checks)('%s should match its schema', (_name, schema, fixture) => {
+    // when
+    const result = schema.safeParse(fixture);
+
+    // then
+    expect(result.success ? [] : result.error.issues).toEqual([]);
+    expect(result.success && result.data).toEqual(fixture);
+  });
+});
@@ -0,0 +1,21 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:6358fce199e9b7128e39a7685b10c38eeb7fea5c5e16945e58acea13338cc9dd
+// Deterministic example data derived from OpenAPI schemas, shared by unit and E2E tests.
+
+/** Example values for each components/schemas entry. */
+export const schemaFixtures = {
+};
+
+/** Example request bodies, keyed by usecase function name. */
+export const requestFixtures = {
+  placeOrderUsecase: {
+    lines: [{
+      quantity: 1,
+      sku: 'example-sku',
+    }],
+  },
+};
+
+/** Example success responses, keyed by usecase function name. */
+export const responseFixtures = {
+};
@@ -0,0 +1,7 @@
+import { pgTable, date, integer } from 'drizzle-orm/pg-core';
+
+export const dailyRevenue = pgTable('daily_revenue', {
+  day: date('day').primaryKey(),
+  orders: integer('orders').notNull(),
+  revenueCents: integer('revenue_cents').notNull(),
+});
@@ -0,0 +1,48 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+// E2E test helpers and setup utilities
+
+/**
+ * Creates a mock authentication token for testing.
+ * In production, integrate with your actual auth provider.
+ */
+export function createAuthToken(payload: { userId: string }): string {
+  // TODO: Implement actual token creation based on your auth provider
+  // For now, return a mock token
+  return 'mock-token-' + payload.userId;
+}
+
+/**
+ * Creates test data for API endpoints.
+ */
+export function createTestData(type: string): Record<string, unknown> {
+  const timestamp = Date.now();
+
+  switch (type) {
+    case 'user':
+      return {
+        email: `test-${timestamp}@example.com`,
+        name: `Test User ${timestamp}`,
+      };
+    default:
+      return {};
+  }
+}
+
+/**
+ * Waits for a condition to be true.
+ */
+export async function waitForCondition(
+  condition: () => Promise<boolean>,
+  timeout = 5000,
+  interval = 100
+): Promise<void> {
+  const start = Date.now();
+  while (Date.now() - start < timeout) {
+    if (await condition()) {
+      return;
+    }
+    await new Promise((resolve) => setTimeout(resolve, interval));
+  }
+  throw new Error('Condition not met within timeout');
+}
@@ -0,0 +1,41 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import { test, expect } from '@playwright/test';
+import { createAuthToken } from './helpers/setup';
+import { requestFixtures } from '../src/test/fixtures';
+
+const baseURL = 'http://localhost:3001';
+
+test.describe('http.server.backoffice API', () => {
+  test('GET /health - health check', async ({ request }) => {
+    const response = await request.get(`${baseURL}/health`);
+    expect(response.status()).toBe(200);
+  });
+
+  test('POST /products - endpoint exists', async ({ request }) => {
+    const token = createAuthToken({ userId: 'test-user' });
+    const headers = { Authorization: `Bearer ${token}` };
+
+    const response = await request.post(`${baseURL}/products`, {
+      headers,
+      data: requestFixtures.createProductUsecase,
+    });
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+  test('POST /products/{id}/publish - endpoint exists', async ({ request }) => {
+    const token = createAuthToken({ userId: 'test-user' });
+    const headers = { Authorization: `Bearer ${token}` };
+
+    const response = await request.post(`${baseURL}/products/test-id/publish`, {
+      headers,
+      data: {},
+    });
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+});
@@ -0,0 +1,27 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import { test, expect } from '@playwright/test';
+
+const baseURL = 'http://localhost:3000';
+
+test.describe('http.server.public API', () => {
+  test('GET /health - health check', async ({ request }) => {
+    const response = await request.get(`${baseURL}/health`);
+    expect(response.status()).toBe(200);
+  });
+
+  test('GET /products/{id} - endpoint exists', async ({ request }) => {
+    const response = await request.get(`${baseURL}/products/test-id`);
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+  test('GET /products - endpoint exists', async ({ request }) => {
+    const response = await request.get(`${baseURL}/products`);
+
+    // Route should exist (may return error from unimplemented usecase)
+    expect(response.status()).not.toBe(404);
+  });
+
+});
@@ -0,0 +1,23 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import { defineConfig, devices } from '@playwright/test';
+
+export default defineConfig({
+  testDir: './e2e',
+  fullyParallel: true,
+  forbidOnly: !!process.env.CI,
+  retries: process.env.CI ? 2 : 0,
+  workers: process.env.CI ? 1 : undefined,
+  reporter: 'html',
+  use: {
+    baseURL: process.env.BASE_URL || 'http://localhost:3001',
+    trace: 'on-first-retry',
+  },
+  projects: [
+    {
+      name: 'API Tests',
+      use: { ...devices['Desktop Chrome'] },
+    },
+  ],
+  globalSetup: './e2e/global-setup.ts',
+});
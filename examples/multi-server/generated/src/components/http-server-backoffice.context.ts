@@ -0,0 +1,44 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+
+import type { AuthContext as MiddlewareAuthnAuthContext } from './middleware-authn.middleware';
+import type { DrizzleClient } from './postgres.client';
+import type { PostgresPrimaryRepositories } from './postgres-primary.postgres.repositories';
+
+/**
+ * Context for http.server.backoffice
+ * Contains all dependencies and middleware-provided values.
+ */
+export interface ServerContext {
+  /** Database client from postgres.primary */
+  db: DrizzleClient;
+  /** Repositories of the tables of postgres.primary */
+  repositories: PostgresPrimaryRepositories;
+  /** Context from middleware.authn */
+  auth?: MiddlewareAuthnAuthContext | null;
+}
+
+/**
+ * Pick specific fields from ServerContext.
+ * Use this to type usecase context parameters.
+ */
+export type ContextWith<K extends keyof ServerContext> = Pick<ServerContext, K>;
+
+/**
+ * Pick specific fields from ServerContext, with the fields in M required
+ * and non-null because middleware that sets them runs before the handler.
+ */
+export type ContextAfter<K extends keyof ServerContext, M extends K = never> =
+  Pick<ServerContext, Exclude<K, M>> & { [F in M]-?: NonNullable<ServerContext[F]> };
+
+/**
+ * Context of usecase.create-product (POST /products).
+ * Set by middleware.authn: auth.
+ */
+export type CreateProductUsecaseContext = ContextAfter<'db' | 'repositories' | 'auth', 'auth'>;
+
+/**
+ * Context of usecase.publish-product (POST /products/{id}/publish).
+ * Set by middleware.authn: auth.
+ */
+export type PublishProductUsecaseContext = ContextAfter<'db' | 'repositories' | 'auth', 'auth'>;
@@ -0,0 +1,85 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { createHttpServerBackofficeApp } from './http-server-backoffice.server';
+import type { ServerContext } from './http-server-backoffice.context';
+import { createInMemoryPostgresPrimaryRepositories } from './postgres-primary.postgres.repositories';
+import { requestFixtures } from '../test/fixtures';
+
+describe('createHttpServerBackofficeApp', () => {
+  it('should create a Hono app instance', () => {
+    // given
+    const mockDeps = createMockDeps();
+
+    // when
+    const app = createHttpServerBackofficeApp(mockDeps);
+
+    // then
+    expect(app).toBeDefined();
+    expect(typeof app.fetch).toBe('function');
+  });
+
+  it('should serve the API reference at /docs', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerBackofficeApp(mockDeps);
+
+    // when
+    const page = await app.fetch(new Request('http://localhost/docs'));
+    const document = await app.fetch(new Request('http://localhost/docs/openapi.yaml'));
+
+    // then
+    expect(page.status).toBe(200);
+    expect(page.headers.get('Content-Type')).toContain('text/html');
+    expect(document.status).toBe(200);
+    expect(await document.text()).toContain('openapi: 3.0.3');
+  });
+
+  it('should have POST /products route', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerBackofficeApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/products', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify(requestFixtures.createProductUsecase),
+    });
+    const res = await app.fetch(req);
+
+    // then - route should exist (may return error from unimplemented usecase)
+    expect(res.status).not.toBe(404);
+  });
+
+  it('should have POST /products/:id/publish route', async () => {
+    // given
+    const mockDeps = createMockDeps();
+    const app = createHttpServerBackofficeApp(mockDeps);
+
+    // when
+    const req = new Request('http://localhost/products/test-id/publish', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({}),
+    });
+    const res = await app.fetch(req);
+
+    // then - route should exist (may return error from unimplemented usecase)
+    expect(res.status).not.toBe(404);
+  });
+
+});
+
+function createMockDeps(): ServerContext {
+  return {
+    db: {
+      query: {},
+      insert: vi.fn(),
+      update: vi.fn(),
+      delete: vi.fn(),
+    } as any,
+    repositories: createInMemoryPostgresPrimaryRepositories(),
+    auth: { session: null, user: null } as any,
+  };
+}
@@ -0,0 +1,128 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import { Hono } from 'hono';
+import type { ServerContext } from './http-server-backoffice.context';
+import { middlewareAuthnMiddleware } from './middleware-authn.middleware';
+import { createProductUsecase } from './usecase-create-product.usecase';
+import { publishProductUsecase } from './usecase-publish-product.usecase';
+import { apiDocsPage, openapiDocument } from './http-server-backoffice.docs';
+
+type MiddlewareRoute = { method: string; path: RegExp };
+const middlewareMatrix: Record<string, MiddlewareRoute[]> = {
+  "middleware.authn": [
+    { method: 'POST', path: new RegExp("^/products$") },
+    { method: 'POST', path: new RegExp("^/products/[^/]+/publish$") },
+  ],
+};
+
+function routeRequiresMiddleware(mwId: string, method: string, path: string): boolean {
+  const routes = middlewareMatrix[mwId];
+  if (!routes || routes.length === 0) return false;
+  for (const route of routes) {
+    if ((route.method === 'ALL' || route.method === method) && route.path.test(path)) {
+      return true;
+    }
+  }
+  return false;
+}
+
+/**
+ * Answers 501 with a route's example response while its usecase is not
+ * implemented yet and MOCK_UNIMPLEMENTED is true, so a partly implemented
+ * service can be demoed. Any other error is rethrown.
+ */
+function mockUnimplemented(error: unknown, example: unknown): Response {
+  if (process.env.MOCK_UNIMPLEMENTED === 'true' && error instanceof Error && error.message === 'Not implemented') {
+    return new Response(JSON.stringify(example ?? { error: 'Not Implemented' }), {
+      status: 501,
+      headers: { 'Content-Type': 'application/json' },
+    });
+  }
+  throw error;
+}
+
+type Env = {
+  Variables: ServerContext;
+};
+
+/**
+ * Creates the http.server.backoffice Hono application.
+ * @param ctx - The server context with dependencies
+ */
+export function createHttpServerBackofficeApp(ctx: ServerContext): Hono<Env> {
+  const app = new Hono<Env>();
+
+  // Set base context from dependencies
+  app.use('*', async (c, next) => {
+    c.set('db', ctx.db);
+    c.set('repositories', ctx.repositories);
+    await next();
+  });
+
+  // Health check
+  app.get('/health', (c) => c.json({ status: 'ok' }));
+
+  // API reference
+  if (process.env.NODE_ENV !== 'production') {
+    app.get('/docs', (c) => c.html(apiDocsPage));
+    app.get('/docs/openapi.yaml', (c) => c.body(openapiDocument, 200, { 'Content-Type': 'application/yaml' }));
+  }
+
+  // Apply server-level middleware only when required by the route
+  app.use('*', async (c, next) => {
+    if (!routeRequiresMiddleware("middleware.authn", c.req.method, c.req.path)) {
+      return next();
+    }
+    return middlewareAuthnMiddleware(c, next);
+  });
+
+  // Route handlers
+
+  // usecase.create-product - Add a product to the catalog
+  app.post('/products', async (c) => {
+    const body = await c.req.json();
+    const input = {
+      ...body,
+    };
+
+    const context = {
+      db: c.get('db'),
+      repositories: c.get('repositories'),
+      auth: c.get('auth')!,
+    };
+
+    let result;
+    try {
+      result = await createProductUsecase(input, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.json(result, 201);
+  });
+
+  // usecase.publish-product - Make a product visible in the storefront
+  app.post('/products/:id/publish', async (c) => {
+    const id = c.req.param('id');
+    const body = await c.req.json();
+    const input = {
+      id,
+      ...body,
+    };
+
+    const context = {
+      db: c.get('db'),
+      repositories: c.get('repositories'),
+      auth: c.get('auth')!,
+    };
+
+    let result;
+    try {
+      result = await publishProductUsecase(input, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.json(result, 201);
+  });
+
+  return app;
+}
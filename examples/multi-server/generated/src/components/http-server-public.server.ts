@@ -0,0 +1,91 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import { Hono } from 'hono';
+import type { ServerContext } from './http-server-public.context';
+import { getProductUsecase } from './usecase-get-product.usecase';
+import { listProductsUsecase } from './usecase-list-products.usecase';
+import { apiDocsPage, openapiDocument } from './http-server-public.docs';
+
+/**
+ * Answers 501 with a route's example response while its usecase is not
+ * implemented yet and MOCK_UNIMPLEMENTED is true, so a partly implemented
+ * service can be demoed. Any other error is rethrown.
+ */
+function mockUnimplemented(error: unknown, example: unknown): Response {
+  if (process.env.MOCK_UNIMPLEMENTED === 'true' && error instanceof Error && error.message === 'Not implemented') {
+    return new Response(JSON.stringify(example ?? { error: 'Not Implemented' }), {
+      status: 501,
+      headers: { 'Content-Type': 'application/json' },
+    });
+  }
+  throw error;
+}
+
+type Env = {
+  Variables: ServerContext;
+};
+
+/**
+ * Creates the http.server.public Hono application.
+ * @param ctx - The server context with dependencies
+ */
+export function createHttpServerPublicApp(ctx: ServerContext): Hono<Env> {
+  const app = new Hono<Env>();
+
+  // Set base context from dependencies
+  app.use('*', async (c, next) => {
+    c.set('db', ctx.db);
+    c.set('repositories', ctx.repositories);
+    await next();
+  });
+
+  // Health check
+  app.get('/health', (c) => c.json({ status: 'ok' }));
+
+  // API reference
+  if (process.env.NODE_ENV !== 'production') {
+    app.get('/docs', (c) => c.html(apiDocsPage));
+    app.get('/docs/openapi.yaml', (c) => c.body(openapiDocument, 200, { 'Content-Type': 'application/yaml' }));
+  }
+
+  // Route handlers
+
+  // usecase.get-product - Show a single product
+  app.get('/products/:id', async (c) => {
+    const id = c.req.param('id');
+    const input = {
+      id,
+    };
+
+    const context = {
+      db: c.get('db'),
+      repositories: c.get('repositories'),
+    };
+
+    let result;
+    try {
+      result = await getProductUsecase(input, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.json(result);
+  });
+
+  // usecase.list-products - List the products on sale
+  app.get('/products', async (c) => {
+    const context = {
+      db: c.get('db'),
+      repositories: c.get('repositories'),
+    };
+
+    let result;
+    try {
+      result = await listProductsUsecase(undefined as void, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.json(result);
+  });
+
+  return app;
+}
@@ -0,0 +1,32 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import { createMiddleware } from 'hono/factory';
+import { auth } from './middleware-authn.middleware.config';
+
+type AuthSessionResult = Awaited<ReturnType<typeof auth.api.getSession>>;
+export type AuthContext = AuthSessionResult extends { session: infer S; user: infer U }
+  ? { session: S | null; user: U | null }
+  : { session: unknown; user: unknown };
+
+export const middlewareAuthnMiddleware = createMiddleware(async (c, next) => {
+  const session = await auth.api.getSession({ headers: c.req.raw.headers });
+
+  if (!session) {
+    c.set('auth', { session: null, user: null });
+  } else {
+    c.set('auth', { session: session.session, user: session.user });
+  }
+
+  await next();
+});
+
+/** Middleware that requires authentication - returns 401 if not authenticated */
+export const requireAuth = createMiddleware(async (c, next) => {
+  const authCtx = c.get('auth');
+
+  if (!authCtx?.session || !authCtx?.user) {
+    return c.json({ error: 'Unauthorized' }, 401);
+  }
+
+  await next();
+});
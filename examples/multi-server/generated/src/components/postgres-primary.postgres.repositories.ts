@@ -0,0 +1,59 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import { randomUUID } from 'node:crypto';
+import { asc, eq } from 'drizzle-orm';
+import type { DrizzleClient } from './postgres.client';
+import type { Repository } from './postgres.repository';
+import { createInMemoryRepository, pageBounds } from './postgres.repository';
+import * as schema from './postgres-primary.postgres.schema';
+
+/** Row of the products table */
+export type ProductsRow = typeof schema.products.$inferSelect;
+/** Values of a new row of the products table */
+export type NewProductsRow = typeof schema.products.$inferInsert;
+/** Rows of the products table by id */
+export type ProductsRepository = Repository<ProductsRow, NewProductsRow, ProductsRow['id']>;
+
+/** Repositories of the tables of postgres.primary */
+export interface PostgresPrimaryRepositories {
+  products: ProductsRepository;
+}
+
+/** Creates the repositories of postgres.primary, which query db. */
+export function createPostgresPrimaryRepositories(db: DrizzleClient): PostgresPrimaryRepositories {
+  return {
+    products: {
+      async findById(id) {
+        const [row] = await db.select().from(schema.products).where(eq(schema.products.id, id)).limit(1);
+        return row;
+      },
+      async list(page) {
+        const { limit, offset } = pageBounds(page);
+        return db.select().from(schema.products).orderBy(asc(schema.products.id)).limit(limit).offset(offset);
+      },
+      async create(values) {
+        const [row] = await db.insert(schema.products).values(values).returning();
+        return row;
+      },
+      async update(id, values) {
+        const [row] = await db.update(schema.products).set(values).where(eq(schema.products.id, id)).returning();
+        return row;
+      },
+      async delete(id) {
+        const rows = await db.delete(schema.products).where(eq(schema.products.id, id)).returning();
+        return rows.length > 0;
+      },
+    },
+  };
+}
+
+/**
+ * Creates repositories of postgres.primary that keep rows in memory, for unit tests.
+ * Column defaults are not applied; a row created without a key gets a new one,
+ * and the convention columns are kept as in the database.
+ */
+export function createInMemoryPostgresPrimaryRepositories(): PostgresPrimaryRepositories {
+  return {
+    products: createInMemoryRepository<ProductsRow, NewProductsRow, 'id'>('id', () => randomUUID(), { createdAt: true }),
+  };
+}
@@ -0,0 +1,117 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+// Repositories find, list, create, update and delete the rows of a table by
+// their primary key. Usecases use the repositories in their context.
+
+/** Rows a list returns: at most limit, after skipping offset. */
+export interface Page {
+  limit?: number;
+  offset?: number;
+}
+
+/** Rows a list returns when the page sets no limit. */
+export const DEFAULT_PAGE_SIZE = 50;
+/** Most rows a list returns. */
+export const MAX_PAGE_SIZE = 500;
+
+/** Rows of a table of type Row, created from values of type New, by key Id. */
+export interface Repository<Row, New, Id> {
+  /** Returns the row with the key, or undefined. */
+  findById(id: Id): Promise<Row | undefined>;
+  /** Returns a page of rows, ordered by key in the database. */
+  list(page?: Page): Promise<Row[]>;
+  /** Creates a row and returns it. */
+  create(values: New): Promise<Row>;
+  /** Sets values of the row with the key and returns it, or undefined. */
+  update(id: Id, values: Partial<New>): Promise<Row | undefined>;
+  /** Deletes the row with the key and reports whether it existed. */
+  delete(id: Id): Promise<boolean>;
+}
+
+/** Convention columns of a table, which its repository maintains. */
+export interface Conventions {
+  /** Has createdAt, set when a row is created */
+  createdAt?: boolean;
+  /** Has updatedAt, set when a row is created or updated */
+  updatedAt?: boolean;
+  /** Has deletedAt: delete sets it, and rows that have it are not found */
+  deletedAt?: boolean;
+}
+
+/** Returns the limit and offset of a page, with the limit between 1 and MAX_PAGE_SIZE. */
+export function pageBounds(page: Page = {}): { limit: number; offset: number } {
+  const limit = Math.min(Math.max(Math.trunc(page.limit ?? DEFAULT_PAGE_SIZE), 1), MAX_PAGE_SIZE);
+  const offset = Math.max(Math.trunc(page.offset ?? 0), 0);
+  return { limit, offset };
+}
+
+/** Returns a function that counts up from 1, the keys of serial columns. */
+export function sequence(): () => number {
+  let last = 0;
+  return () => ++last;
+}
+
+/**
+ * Creates a repository that keeps rows in memory, keyed by their key column.
+ * A row created without a key gets one from nextId. Lists return rows in the
+ * order they were created. The convention columns are set like the database
+ * repositories set them.
+ */
+export function createInMemoryRepository<Row, New, K extends keyof Row>(
+  key: K,
+  nextId: () => Row[K],
+  conventions: Conventions = {},
+): Repository<Row, New, Row[K]> {
+  const rows = new Map<Row[K], Row>();
+  const columns = (row: Row) => row as unknown as Record<string, unknown>;
+  // Soft-deleted rows are kept but not found
+  const found = (row: Row | undefined) =>
+    row !== undefined && !(conventions.deletedAt && columns(row).deletedAt != null) ? row : undefined;
+  return {
+    async findById(id) {
+      return found(rows.get(id));
+    },
+    async list(page) {
+      const { limit, offset } = pageBounds(page);
+      return [...rows.values()].filter((row) => found(row) !== undefined).slice(offset, offset + limit);
+    },
+    async create(values) {
+      const row = { ...values } as unknown as Row;
+      if (row[key] === undefined || row[key] === null) {
+        row[key] = nextId();
+      }
+      const now = new Date();
+      for (const column of ['createdAt', 'updatedAt'] as const) {
+        if (conventions[column] && columns(row)[column] === undefined) {
+          columns(row)[column] = now;
+        }
+      }
+      rows.set(row[key], row);
+      return row;
+    },
+    async update(id, values) {
+      const row = found(rows.get(id));
+      if (row === undefined) {
+        return undefined;
+      }
+      const updated = { ...row, ...values } as Row;
+      updated[key] = id;
+      if (conventions.updatedAt) {
+        columns(updated).updatedAt = new Date();
+      }
+      rows.set(id, updated);
+      return updated;
+    },
+    async delete(id) {
+      const row = found(rows.get(id));
+      if (row === undefined) {
+        return false;
+      }
+      if (conventions.deletedAt) {
+        rows.set(id, { ...row, deletedAt: new Date() });
+        return true;
+      }
+      return rows.delete(id);
+    },
+  };
+}
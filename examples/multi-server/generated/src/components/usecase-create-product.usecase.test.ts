@@ -0,0 +1,55 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { createProductUsecase } from './usecase-create-product.usecase';
+import { createMockContext } from '../test/setup';
+import { requestFixtures } from '../test/fixtures';
+
+describe('createProductUsecase', () => {
+  let mockCtx: ReturnType<typeof createMockContext>;
+
+  beforeEach(() => {
+    mockCtx = createMockContext();
+    vi.clearAllMocks();
+  });
+
+  it('should be a function', () => {
+    expect(typeof createProductUsecase).toBe('function');
+  });
+
+  it('should return a promise', () => {
+    // given
+    const input = { ...requestFixtures.createProductUsecase };
+
+    // when
+    const result = createProductUsecase(input as any, mockCtx);
+
+    // then
+    expect(result).toBeInstanceOf(Promise);
+  });
+
+  it('should throw NotImplemented error by default', async () => {
+    // given
+    const input = { ...requestFixtures.createProductUsecase };
+
+    // when/then
+    await expect(createProductUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  it('should have access to auth context', async () => {
+    // given
+    const input = {};
+    const ctxWithAuth = {
+      ...mockCtx,
+      auth: { userId: 'user-123', session: {} },
+    };
+
+    // when/then - context should include auth
+    expect(ctxWithAuth.auth).toBeDefined();
+    expect(ctxWithAuth.auth.userId).toBe('user-123');
+  });
+
+  describe('acceptance criteria', () => {
+    it.todo('AC-usecase.create-product-1: New products start unpublished');
+  });
+});
@@ -0,0 +1,35 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import type { CreateProductUsecaseContext } from './http-server-backoffice.context';
+import type { CreateProductRequest, CreateProductResponse } from './usecase.schemas';
+
+/**
+ * Add a product to the catalog
+ *
+ * @actor staff
+ *
+ * Preconditions:
+ * - Staff member is signed in
+ *
+ * Acceptance Criteria:
+ * - New products start unpublished
+ *
+ * Postconditions:
+ * - Product exists in the catalog
+ */
+export async function createProductUsecase(
+  input: CreateProductRequest,
+  ctx: CreateProductUsecaseContext
+): Promise<CreateProductResponse> {
+  // TODO: Implement usecase
+  //
+  // Preconditions to check:
+  //   - Staff member is signed in
+  //
+  // Implementation should satisfy:
+  //   - New products start unpublished
+  //
+  // Example: const row = await ctx.repositories.products.findById(id);
+
+  throw new Error('Not implemented');
+}
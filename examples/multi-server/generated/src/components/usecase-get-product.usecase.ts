@@ -0,0 +1,31 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import type { GetProductUsecaseContext } from './http-server-public.context';
+import type { GetProductResponse } from './usecase.schemas';
+
+/** Input with path parameters */
+export interface GetProductUsecaseInput {
+  id: string;
+}
+
+/**
+ * Show a single product
+ *
+ * @actor shopper
+ *
+ * Acceptance Criteria:
+ * - Returns 404 for unknown or unpublished products
+ */
+export async function getProductUsecase(
+  input: GetProductUsecaseInput,
+  ctx: GetProductUsecaseContext
+): Promise<GetProductResponse> {
+  // TODO: Implement usecase
+  //
+  // Implementation should satisfy:
+  //   - Returns 404 for unknown or unpublished products
+  //
+  // Example: const row = await ctx.repositories.products.findById(id);
+
+  throw new Error('Not implemented');
+}
@@ -0,0 +1,28 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import type { ListProductsUsecaseContext } from './http-server-public.context';
+import type { ListProductsResponse } from './usecase.schemas';
+
+/**
+ * List the products on sale
+ *
+ * @actor shopper
+ *
+ * Acceptance Criteria:
+ * - Returns only products that are published
+ * - Products are ordered by name
+ */
+export async function listProductsUsecase(
+  input: void,
+  ctx: ListProductsUsecaseContext
+): Promise<ListProductsResponse> {
+  // TODO: Implement usecase
+  //
+  // Implementation should satisfy:
+  //   - Returns only products that are published
+  //   - Products are ordered by name
+  //
+  // Example: const row = await ctx.repositories.products.findById(id);
+
+  throw new Error('Not implemented');
+}
@@ -0,0 +1,37 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import type { PublishProductUsecaseContext } from './http-server-backoffice.context';
+import type { PublishProductRequest, PublishProductResponse } from './usecase.schemas';
+
+/** Input combining path params and request body */
+export interface PublishProductUsecaseInput extends PublishProductRequest {
+  id: string;
+}
+
+/**
+ * Make a product visible in the storefront
+ *
+ * @actor staff
+ *
+ * Preconditions:
+ * - Staff member is signed in
+ *
+ * Acceptance Criteria:
+ * - Published products appear in the public listing
+ */
+export async function publishProductUsecase(
+  input: PublishProductUsecaseInput,
+  ctx: PublishProductUsecaseContext
+): Promise<PublishProductResponse> {
+  // TODO: Implement usecase
+  //
+  // Preconditions to check:
+  //   - Staff member is signed in
+  //
+  // Implementation should satisfy:
+  //   - Published products appear in the public listing
+  //
+  // Example: const row = await ctx.repositories.products.findById(id);
+
+  throw new Error('Not implemented');
+}
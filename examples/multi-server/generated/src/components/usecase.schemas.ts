@@ -0,0 +1,26 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+// Types and zod schemas derived from OpenAPI documents.
+
+import { z } from 'zod';
+
+export const CreateProductRequestSchema = z.object({
+  name: z.string(),
+  priceCents: z.number().int(),
+});
+export type CreateProductRequest = z.infer<typeof CreateProductRequestSchema>;
+
+export const CreateProductResponseSchema = z.record(z.unknown());
+export type CreateProductResponse = z.infer<typeof CreateProductResponseSchema>;
+
+export const GetProductResponseSchema = z.record(z.unknown());
+export type GetProductResponse = z.infer<typeof GetProductResponseSchema>;
+
+export const ListProductsResponseSchema = z.record(z.unknown());
+export type ListProductsResponse = z.infer<typeof ListProductsResponseSchema>;
+
+export const PublishProductRequestSchema = z.record(z.unknown());
+export type PublishProductRequest = z.infer<typeof PublishProductRequestSchema>;
+
+export const PublishProductResponseSchema = z.record(z.unknown());
+export type PublishProductResponse = z.infer<typeof PublishProductResponseSchema>;
@@ -0,0 +1,8 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+// Re-exports all usecases for convenient importing
+
+export { createProductUsecase } from './usecase-create-product.usecase';
+export { getProductUsecase } from './usecase-get-product.usecase';
+export { listProductsUsecase } from './usecase-list-products.usecase';
+export { publishProductUsecase } from './usecase-publish-product.usecase';
@@ -0,0 +1,76 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import { serve } from '@hono/node-server';
+import { Hono } from 'hono';
+import { cors } from 'hono/cors';
+import { auth } from './components/middleware-authn.middleware.config';
+import { createHttpServerBackofficeApp } from './components/http-server-backoffice.server';
+import { createHttpServerPublicApp } from './components/http-server-public.server';
+import { createPostgresPrimaryClient } from './components/postgres-primary.postgres';
+import { createPostgresPrimaryRepositories } from './components/postgres-primary.postgres.repositories';
+
+async function main() {
+  // Initialize dependencies
+  const postgresPrimaryClient = await createPostgresPrimaryClient();
+  const postgresPrimaryRepositories = createPostgresPrimaryRepositories(postgresPrimaryClient);
+
+  // Start http.server.backoffice
+  const httpServerBackofficeContext = {
+    db: postgresPrimaryClient,
+    repositories: postgresPrimaryRepositories,
+    auth: null,
+  };
+
+  const httpServerBackofficeApp = createHttpServerBackofficeApp(httpServerBackofficeContext);
+
+  // Create root app with auth routes
+  const httpServerBackofficeRootApp = new Hono();
+
+  // CORS for auth routes
+  httpServerBackofficeRootApp.use('/api/auth/*', cors({
+    origin: process.env.CORS_ORIGIN || 'http://localhost:3000',
+    allowHeaders: ['Content-Type', 'Authorization'],
+    allowMethods: ['POST', 'GET', 'OPTIONS'],
+    credentials: true,
+  }));
+
+  // Mount better-auth routes
+  httpServerBackofficeRootApp.on(['POST', 'GET'], '/api/auth/*', (c) => auth.handler(c.req.raw));
+
+  // Mount API routes
+  httpServerBackofficeRootApp.route('/', httpServerBackofficeApp);
+
+  serve({ fetch: httpServerBackofficeRootApp.fetch, port: Number(process.env.BACKOFFICE_PORT ?? 3001) }, (info) => {
+    console.log(`http.server.backoffice listening on http://localhost:${info.port}`);
+  });
+  // Start http.server.public
+  const httpServerPublicContext = {
+    db: postgresPrimaryClient,
+    repositories: postgresPrimaryRepositories,
+  };
+
+  const httpServerPublicApp = createHttpServerPublicApp(httpServerPublicContext);
+
+  // Create root app with auth routes
+  const httpServerPublicRootApp = new Hono();
+
+  // CORS for auth routes
+  httpServerPublicRootApp.use('/api/auth/*', cors({
+    origin: process.env.CORS_ORIGIN || 'http://localhost:3000',
+    allowHeaders: ['Content-Type', 'Authorization'],
+    allowMethods: ['POST', 'GET', 'OPTIONS'],
+    credentials: true,
+  }));
+
+  // Mount better-auth routes
+  httpServerPublicRootApp.on(['POST', 'GET'], '/api/auth/*', (c) => auth.handler(c.req.raw));
+
+  // Mount API routes
+  httpServerPublicRootApp.route('/', httpServerPublicApp);
+
+  serve({ fetch: httpServerPublicRootApp.fetch, port: Number(process.env.PUBLIC_PORT ?? 3000) }, (info) => {
+    console.log(`http.server.public listening on http://localhost:${info.port}`);
+  });
+}
+
+main().catch(console.error);
@@ -0,0 +1,21 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+import { describe, it, expect } from 'vitest';
+import type { z } from 'zod';
+import { CreateProductRequestSchema } from '../components/usecase.schemas';
+import { requestFixtures } from './fixtures';
+
+const checks: [string, z.ZodTypeAny, unknown][] = [
+  ['requestFixtures.createProductUsecase', CreateProductRequestSchema, requestFixtures.createProductUsecase],
+];
+
+describe('fixtures', () => {
+  it.each(checks)('%s should match its schema', (_name, schema, fixture) => {
+    // when
+    const result = schema.safeParse(fixture);
+
+    // then
+    expect(result.success ? [] : result.error.issues).toEqual([]);
+    expect(result.success && result.data).toEqual(fixture);
+  });
+});
@@ -0,0 +1,19 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// bound dev, spec sha256:aa03099a3ccf709c3f5379ad98312d60934114a403248345618d3c7f71bac3dc
+// Deterministic example data derived from OpenAPI schemas, shared by unit and E2E tests.
+
+/** Example values for each components/schemas entry. */
+export const schemaFixtures = {
+};
+
+/** Example request bodies, keyed by usecase function name. */
+export const requestFixtures = {
+  createProductUsecase: {
+    name: 'Jane Doe',
+    priceCents: 1,
+  },
+};
+
+/** Example success responses, keyed by usecase function name. */
+export const responseFixtures = {
+};
@@ -0,0 +1,7 @@
+import { betterAuth } from 'better-auth';
+
+export const auth = betterAuth({
+  emailAndPassword: {
+    enabled: true,
+  },
+});
@@ -0,0 +1,9 @@
+import { pgTable, uuid, text, integer, boolean, timestamp } from 'drizzle-orm/pg-core';
+
+export const products = pgTable('products', {
+  id: uuid('id').primaryKey().defaultRandom(),
+  name: text('name').notNull(),
+  priceCents: integer('price_cents').notNull(),
+  published: boolean('published').notNull().default(false),
+  createdAt: timestamp('created_at').defaultNow(),
+});
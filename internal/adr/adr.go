@@ -0,0 +1,245 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package adr detects component changes between compiles and writes
+// architecture decision record (ADR) stubs describing them.
+package adr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+// SnapshotPath is where the output directory keeps the components of the
+// last compile, relative to its root.
+const SnapshotPath = ".openboundary/components.json"
+
+// Dir is the directory ADRs are written to, relative to the output directory.
+const Dir = "docs/adr"
+
+// Snapshot records the components of a spec, keyed by component ID.
+type Snapshot map[string]ComponentState
+
+// ComponentState is what a snapshot remembers about a component.
+type ComponentState struct {
+	Kind    string            `json:"kind"`
+	BindsTo string            `json:"binds_to,omitempty"`
+	Fields  map[string]string `json:"fields"` // JSON encoding of each top-level spec field
+}
+
+// Take returns the snapshot of a spec.
+func Take(spec *parser.Spec) (Snapshot, error) {
+	snapshot := make(Snapshot, len(spec.Components))
+	for _, comp := range spec.Components {
+		state := ComponentState{Kind: comp.Kind, Fields: make(map[string]string, len(comp.Spec))}
+		for key, value := range comp.Spec {
+			if key == "binds_to" {
+				state.BindsTo, _ = value.(string)
+				continue
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("component %q: failed to encode %s: %w", comp.ID, key, err)
+			}
+			state.Fields[key] = string(encoded)
+		}
+		snapshot[comp.ID] = state
+	}
+	return snapshot, nil
+}
+
+// Load reads the snapshot at path. A missing file yields a nil snapshot.
+func Load(path string) (Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return snapshot, nil
+}
+
+// Encode returns the snapshot as indented JSON.
+func (s Snapshot) Encode() ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// ChangeKind classifies a component change.
+type ChangeKind string
+
+const (
+	Added    ChangeKind = "added"
+	Removed  ChangeKind = "removed"
+	Rebound  ChangeKind = "rebound" // A usecase's binds_to changed
+	Modified ChangeKind = "modified"
+)
+
+// Change is a difference between two snapshots for one component. A
+// component whose binding and other fields both changed yields two changes.
+type Change struct {
+	Kind        ChangeKind
+	ComponentID string
+	State       ComponentState // The state after the change, or before a removal
+	OldBindsTo  string         // For Rebound
+	Fields      []string       // For Modified, the spec fields that changed
+}
+
+// Diff returns the changes from prev to next, sorted by component ID.
+func Diff(prev, next Snapshot) []Change {
+	var changes []Change
+	for id, state := range next {
+		old, ok := prev[id]
+		if !ok {
+			changes = append(changes, Change{Kind: Added, ComponentID: id, State: state})
+			continue
+		}
+		if old.BindsTo != state.BindsTo {
+			changes = append(changes, Change{Kind: Rebound, ComponentID: id, State: state, OldBindsTo: old.BindsTo})
+		}
+		if fields := changedFields(old, state); len(fields) > 0 || old.Kind != state.Kind {
+			changes = append(changes, Change{Kind: Modified, ComponentID: id, State: state, Fields: fields})
+		}
+	}
+	for id, state := range prev {
+		if _, ok := next[id]; !ok {
+			changes = append(changes, Change{Kind: Removed, ComponentID: id, State: state})
+		}
+	}
+
+	order := map[ChangeKind]int{Added: 0, Rebound: 1, Modified: 2, Removed: 3}
+	sort.Slice(changes, func(a, b int) bool {
+		if changes[a].ComponentID != changes[b].ComponentID {
+			return changes[a].ComponentID < changes[b].ComponentID
+		}
+		return order[changes[a].Kind] < order[changes[b].Kind]
+	})
+	return changes
+}
+
+// changedFields returns the sorted names of spec fields added, removed or
+// changed between two states of a component.
+func changedFields(before, after ComponentState) []string {
+	var fields []string
+	for key, value := range after.Fields {
+		if before.Fields[key] != value {
+			fields = append(fields, key)
+		}
+	}
+	for key := range before.Fields {
+		if _, ok := after.Fields[key]; !ok {
+			fields = append(fields, key)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Summary describes a change in one line of markdown.
+func (c Change) Summary() string {
+	switch c.Kind {
+	case Added:
+		if c.State.BindsTo != "" {
+			return fmt.Sprintf("Added `%s` (%s) bound to `%s`", c.ComponentID, c.State.Kind, c.State.BindsTo)
+		}
+		return fmt.Sprintf("Added `%s` (%s)", c.ComponentID, c.State.Kind)
+	case Removed:
+		return fmt.Sprintf("Removed `%s` (%s)", c.ComponentID, c.State.Kind)
+	case Rebound:
+		return fmt.Sprintf("Rebound `%s` from `%s` to `%s`", c.ComponentID, c.OldBindsTo, c.State.BindsTo)
+	}
+	if len(c.Fields) == 0 {
+		return fmt.Sprintf("Changed the kind of `%s` to %s", c.ComponentID, c.State.Kind)
+	}
+	quoted := make([]string, len(c.Fields))
+	for n, field := range c.Fields {
+		quoted[n] = "`" + field + "`"
+	}
+	return fmt.Sprintf("Changed `%s`: %s", c.ComponentID, strings.Join(quoted, ", "))
+}
+
+// title names an ADR after its only change, or generically for several.
+func title(changes []Change) string {
+	if len(changes) != 1 {
+		return "Architecture changes"
+	}
+	c := changes[0]
+	switch c.Kind {
+	case Added:
+		return "Add " + c.ComponentID
+	case Removed:
+		return "Remove " + c.ComponentID
+	case Rebound:
+		return "Rebind " + c.ComponentID
+	}
+	return "Change " + c.ComponentID
+}
+
+var numberedPattern = regexp.MustCompile(`^(\d{4})-`)
+
+// NextNumber returns the number of the next ADR in dir: one more than the
+// highest numbered file, or 1 when there is none.
+func NextNumber(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 1, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	highest := 0
+	for _, entry := range entries {
+		if m := numberedPattern.FindStringSubmatch(entry.Name()); m != nil {
+			if n, _ := strconv.Atoi(m[1]); n > highest {
+				highest = n
+			}
+		}
+	}
+	return highest + 1, nil
+}
+
+var slugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Stub returns the path, relative to the output directory, and content of
+// an ADR describing changes to specFile. The sections after Context are
+// left for the team to fill in.
+func Stub(number int, date time.Time, specFile string, changes []Change) (string, []byte) {
+	t := title(changes)
+	slug := strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(t), "-"), "-")
+	path := filepath.ToSlash(filepath.Join(Dir, fmt.Sprintf("%04d-%s-%s.md", number, date.Format("2006-01-02"), slug)))
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %d. %s\n\n", number, t))
+	sb.WriteString(fmt.Sprintf("Date: %s\n\n", date.Format("2006-01-02")))
+	sb.WriteString("## Status\n\n")
+	sb.WriteString("Proposed\n\n")
+	sb.WriteString("## Context\n\n")
+	sb.WriteString(fmt.Sprintf("`bound compile` detected these changes to %s:\n\n", specFile))
+	for _, c := range changes {
+		sb.WriteString("- " + c.Summary() + "\n")
+	}
+	sb.WriteString("\n<!-- Why were these changes needed? -->\n\n")
+	sb.WriteString("## Decision\n\n")
+	sb.WriteString("<!-- What was decided, and which alternatives were considered? -->\n\n")
+	sb.WriteString("## Consequences\n\n")
+	sb.WriteString("<!-- What becomes easier or harder because of this change? -->\n")
+	return path, []byte(sb.String())
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package adr
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+func TestDiff(t *testing.T) {
+	// given
+	prev, err := Take(&parser.Spec{Components: []parser.Component{
+		{ID: "postgres.cache", Kind: "postgres", Spec: map[string]any{"provider": "drizzle"}},
+		{ID: "middleware.authz", Kind: "middleware", Spec: map[string]any{"provider": "casbin", "policy": "./policy.csv"}},
+		{ID: "usecase.get-user", Kind: "usecase", Spec: map[string]any{"binds_to": "http.server.api:GET:/users/{id}", "goal": "Get a user"}},
+	}})
+	if err != nil {
+		t.Fatalf("Take() error = %v", err)
+	}
+	next, err := Take(&parser.Spec{Components: []parser.Component{
+		{ID: "middleware.authz", Kind: "middleware", Spec: map[string]any{"provider": "casbin", "model": "./model.conf"}},
+		{ID: "usecase.get-user", Kind: "usecase", Spec: map[string]any{"binds_to": "http.server.api:GET:/v2/users/{id}", "goal": "Get a user"}},
+		{ID: "usecase.list-users", Kind: "usecase", Spec: map[string]any{"binds_to": "http.server.api:GET:/users"}},
+	}})
+	if err != nil {
+		t.Fatalf("Take() error = %v", err)
+	}
+
+	// when
+	changes := Diff(prev, next)
+
+	// then
+	var summaries []string
+	for _, c := range changes {
+		summaries = append(summaries, c.Summary())
+	}
+	expected := []string{
+		"Changed `middleware.authz`: `model`, `policy`",
+		"Removed `postgres.cache` (postgres)",
+		"Rebound `usecase.get-user` from `http.server.api:GET:/users/{id}` to `http.server.api:GET:/v2/users/{id}`",
+		"Added `usecase.list-users` (usecase) bound to `http.server.api:GET:/users`",
+	}
+	if !reflect.DeepEqual(summaries, expected) {
+		t.Errorf("Diff() = %q, expected %q", summaries, expected)
+	}
+}
+
+func TestDiff_Unchanged(t *testing.T) {
+	// given
+	spec := &parser.Spec{Components: []parser.Component{
+		{ID: "http.server.api", Kind: "http.server", Spec: map[string]any{"framework": "hono", "port": 3000}},
+	}}
+	snapshot, err := Take(spec)
+	if err != nil {
+		t.Fatalf("Take() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "components.json")
+	encoded, err := snapshot.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if err := os.WriteFile(path, encoded, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// when
+	loaded, err := Load(path)
+
+	// then
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if changes := Diff(loaded, snapshot); len(changes) != 0 {
+		t.Errorf("Diff() = %v, expected no changes", changes)
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	snapshot, err := Load(filepath.Join(t.TempDir(), "components.json"))
+	if err != nil || snapshot != nil {
+		t.Errorf("Load() = %v, %v, expected nil, nil", snapshot, err)
+	}
+}
+
+func TestNextNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		expected int
+	}{
+		{"no directory", nil, 1},
+		{"existing records", []string{"0001-2026-01-01-add-x.md", "0007-2026-02-01-change-y.md", "notes.md"}, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			dir := filepath.Join(t.TempDir(), "adr")
+			for _, name := range tt.files {
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// when
+			n, err := NextNumber(dir)
+
+			// then
+			if err != nil {
+				t.Fatalf("NextNumber() error = %v", err)
+			}
+			if n != tt.expected {
+				t.Errorf("NextNumber() = %d, expected %d", n, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStub(t *testing.T) {
+	tests := []struct {
+		name         string
+		changes      []Change
+		expectedPath string
+		expectedHead string
+	}{
+		{
+			name:         "single change",
+			changes:      []Change{{Kind: Added, ComponentID: "postgres.primary", State: ComponentState{Kind: "postgres"}}},
+			expectedPath: "docs/adr/0003-2026-10-15-add-postgres-primary.md",
+			expectedHead: "# 3. Add postgres.primary\n\nDate: 2026-10-15\n",
+		},
+		{
+			name: "several changes",
+			changes: []Change{
+				{Kind: Added, ComponentID: "postgres.primary", State: ComponentState{Kind: "postgres"}},
+				{Kind: Removed, ComponentID: "postgres.cache", State: ComponentState{Kind: "postgres"}},
+			},
+			expectedPath: "docs/adr/0003-2026-10-15-architecture-changes.md",
+			expectedHead: "# 3. Architecture changes\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			path, content := Stub(3, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), "spec.yaml", tt.changes)
+
+			// then
+			if path != tt.expectedPath {
+				t.Errorf("path = %q, expected %q", path, tt.expectedPath)
+			}
+			if !strings.HasPrefix(string(content), tt.expectedHead) {
+				t.Errorf("content = %q, expected prefix %q", content, tt.expectedHead)
+			}
+			for _, want := range []string{"## Status\n\nProposed\n", "detected these changes to spec.yaml:\n\n- Added `postgres.primary` (postgres)\n", "## Decision\n", "## Consequences\n"} {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("content missing %q\n%s", want, content)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package attest signs and verifies attestations of generated output: which
+// compiler produced which files from which spec, signed with an ed25519 key
+// so deploy pipelines can check them before shipping the output.
+package attest
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/openboundary/openboundary/internal/pipeline"
+)
+
+// Path is where attestations are written by default, relative to the output
+// directory.
+const Path = ".bound/attestation.json"
+
+// PayloadType identifies the statement inside an envelope.
+const PayloadType = "application/vnd.openboundary.attestation+json"
+
+// StatementVersion is the version of the statement format.
+const StatementVersion = 1
+
+// Statement is what an attestation vouches for.
+type Statement struct {
+	Version  int               `json:"version"`
+	Compiler Compiler          `json:"compiler"`
+	Spec     Spec              `json:"spec"`
+	Options  map[string]string `json:"options,omitempty"` // Compile options, as in the report
+	Files    []File            `json:"files"`
+}
+
+// Compiler identifies the compiler that generated the output.
+type Compiler struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Spec identifies the spec the output was generated from.
+type Spec struct {
+	Path         string `json:"path"`
+	SHA256       string `json:"sha256"`
+	SemanticHash string `json:"semantic_hash,omitempty"`
+	Commit       string `json:"commit,omitempty"` // Version control commit of the spec, when known
+}
+
+// File is a generated file and the SHA-256 of its content.
+type File struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// Envelope is a signed statement, in the DSSE envelope format, so tools
+// that understand DSSE can verify it with the public key.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     []byte      `json:"payload"` // The JSON statement, base64 encoded
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is an ed25519 signature over the DSSE pre-authentication
+// encoding of an envelope's payload.
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   []byte `json:"sig"`
+}
+
+// NewStatement returns the statement for the output of the last compile
+// into outputDir, as recorded in its report. It fails when that compile
+// failed or was restricted with --only, or when the output has changed since.
+func NewStatement(outputDir string, report *pipeline.Report, commit string) (*Statement, error) {
+	if report.Status != "ok" {
+		return nil, fmt.Errorf("the last compile into %s/ failed; only successful output can be attested", outputDir)
+	}
+	if report.Options["only"] != "" {
+		return nil, fmt.Errorf("the last compile into %s/ was restricted with --only; attest a full compile", outputDir)
+	}
+	if report.Spec.SHA256 == "" {
+		return nil, fmt.Errorf("the report of %s/ does not record the spec hash", outputDir)
+	}
+
+	st := &Statement{
+		Version:  StatementVersion,
+		Compiler: Compiler{Name: "bound", Version: report.Compiler},
+		Spec: Spec{
+			Path:         report.Spec.Path,
+			SHA256:       report.Spec.SHA256,
+			SemanticHash: report.Spec.SemanticHash,
+			Commit:       commit,
+		},
+		Options: report.Options,
+		Files:   make([]File, 0, len(report.Files)),
+	}
+	for _, f := range report.Files {
+		st.Files = append(st.Files, File{Path: f.Path, SHA256: f.SHA256})
+	}
+	sort.Slice(st.Files, func(a, b int) bool { return st.Files[a].Path < st.Files[b].Path })
+
+	if changed := st.CheckFiles(outputDir); len(changed) > 0 {
+		return nil, fmt.Errorf("%d file(s) changed since the last compile, e.g. %s; recompile before attesting", len(changed), changed[0])
+	}
+	return st, nil
+}
+
+// CheckSpec fails when the spec recorded in report is missing or differs
+// from the one the report was written for, so the statement would vouch
+// for a spec the output was not generated from.
+func CheckSpec(report *pipeline.Report) error {
+	content, err := os.ReadFile(report.Spec.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read spec of the last compile: %w", err)
+	}
+	if hashHex(content) != report.Spec.SHA256 {
+		return fmt.Errorf("spec %s changed since the last compile; recompile before attesting", report.Spec.Path)
+	}
+	return nil
+}
+
+// CheckFiles returns the attested files whose content in outputDir differs
+// from the statement, including missing ones, and the files in outputDir
+// the statement does not list, each with the reason. Files under .bound/,
+// where compile keeps its report and the attestation, are not checked.
+func (st *Statement) CheckFiles(outputDir string) []string {
+	var changed []string
+	attested := make(map[string]bool, len(st.Files))
+	for _, f := range st.Files {
+		attested[f.Path] = true
+		content, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(f.Path)))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			changed = append(changed, f.Path+" is missing")
+		case err != nil:
+			changed = append(changed, fmt.Sprintf("%s could not be read: %v", f.Path, err))
+		case hashHex(content) != f.SHA256:
+			changed = append(changed, f.Path+" was modified")
+		}
+	}
+
+	err := filepath.WalkDir(outputDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(outputDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		switch {
+		case d.IsDir() && rel == filepath.Dir(Path):
+			return filepath.SkipDir
+		case !d.IsDir() && !attested[rel]:
+			changed = append(changed, rel+" is not attested")
+		}
+		return nil
+	})
+	if err != nil {
+		changed = append(changed, fmt.Sprintf("%s could not be listed: %v", outputDir, err))
+	}
+	return changed
+}
+
+// Sign signs st with key.
+func Sign(st *Statement, key ed25519.PrivateKey) (*Envelope, error) {
+	payload, err := json.Marshal(st)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode statement: %w", err)
+	}
+	keyID, err := KeyID(key.Public().(ed25519.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		PayloadType: PayloadType,
+		Payload:     payload,
+		Signatures:  []Signature{{KeyID: keyID, Sig: ed25519.Sign(key, pae(PayloadType, payload))}},
+	}, nil
+}
+
+// Verify checks that env is signed by pub and returns its statement.
+func Verify(env *Envelope, pub ed25519.PublicKey) (*Statement, error) {
+	if env.PayloadType != PayloadType {
+		return nil, fmt.Errorf("unexpected payload type %q (expected %s)", env.PayloadType, PayloadType)
+	}
+	keyID, err := KeyID(pub)
+	if err != nil {
+		return nil, err
+	}
+	message := pae(env.PayloadType, env.Payload)
+	verified := false
+	for _, sig := range env.Signatures {
+		if (sig.KeyID == "" || sig.KeyID == keyID) && ed25519.Verify(pub, message, sig.Sig) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("no valid signature by key %s", keyID)
+	}
+
+	var st Statement
+	if err := json.Unmarshal(env.Payload, &st); err != nil {
+		return nil, fmt.Errorf("failed to decode statement: %w", err)
+	}
+	if st.Version != StatementVersion {
+		return nil, fmt.Errorf("unsupported statement version %d", st.Version)
+	}
+	return &st, nil
+}
+
+// Load reads the envelope at path.
+func Load(path string) (*Envelope, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attestation: %w", err)
+	}
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("failed to decode attestation %s: %w", path, err)
+	}
+	return &env, nil
+}
+
+// Write writes env to path.
+func (env *Envelope) Write(path string) error {
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode attestation: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write attestation %s: %w", path, err)
+	}
+	return nil
+}
+
+// GenerateKey returns a new key pair, PEM encoded: the private key as
+// PKCS #8 and the public key as PKIX.
+func GenerateKey() (private, public []byte, err error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	private = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})
+	public = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+	return private, public, nil
+}
+
+// ParsePrivateKey parses a PEM encoded PKCS #8 ed25519 private key.
+func ParsePrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("not a PEM encoded private key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T (expected ed25519)", key)
+	}
+	return priv, nil
+}
+
+// ParsePublicKey parses a PEM encoded PKIX ed25519 public key.
+func ParsePublicKey(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("not a PEM encoded public key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T (expected ed25519)", key)
+	}
+	return pub, nil
+}
+
+// KeyID identifies pub: the first 16 hex digits of the SHA-256 of its PKIX
+// encoding.
+func KeyID(pub ed25519.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+	return hashHex(der)[:16], nil
+}
+
+// pae returns the DSSE pre-authentication encoding of a payload, which is
+// what gets signed.
+func pae(payloadType string, payload []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("DSSEv1 ")
+	b.WriteString(strconv.Itoa(len(payloadType)))
+	b.WriteString(" ")
+	b.WriteString(payloadType)
+	b.WriteString(" ")
+	b.WriteString(strconv.Itoa(len(payload)))
+	b.WriteString(" ")
+	b.Write(payload)
+	return b.Bytes()
+}
+
+func hashHex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package attest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/pipeline"
+)
+
+// writeOutput writes files to a new output directory and returns it with a
+// report of a successful compile that generated them.
+func writeOutput(t *testing.T, files map[string]string) (string, *pipeline.Report) {
+	t.Helper()
+	dir := t.TempDir()
+	report := &pipeline.Report{
+		Status:   "ok",
+		Compiler: "0.1.0",
+		Spec:     pipeline.ReportSpec{Path: "spec.yaml", SHA256: "5ec", SemanticHash: "5e3"},
+		Options:  map[string]string{"target": "typescript"},
+	}
+	for path, content := range files {
+		full := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		report.Files = append(report.Files, pipeline.ReportFile{Path: path, SHA256: hashHex([]byte(content)), Status: "written"})
+	}
+	return dir, report
+}
+
+func generateKey(t *testing.T) (private, public []byte) {
+	t.Helper()
+	private, public, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return private, public
+}
+
+func TestSignVerify(t *testing.T) {
+	// given
+	dir, report := writeOutput(t, map[string]string{"src/index.ts": "export {};\n", "package.json": "{}\n"})
+	st, err := NewStatement(dir, report, "abc123")
+	if err != nil {
+		t.Fatalf("NewStatement() error = %v", err)
+	}
+	privPEM, pubPEM := generateKey(t)
+	priv, err := ParsePrivateKey(privPEM)
+	if err != nil {
+		t.Fatalf("ParsePrivateKey() error = %v", err)
+	}
+	pub, err := ParsePublicKey(pubPEM)
+	if err != nil {
+		t.Fatalf("ParsePublicKey() error = %v", err)
+	}
+
+	// when
+	env, err := Sign(st, priv)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	path := filepath.Join(dir, Path)
+	if err := env.Write(path); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	verified, err := Verify(loaded, pub)
+
+	// then
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if verified.Compiler.Version != "0.1.0" {
+		t.Errorf("Compiler.Version = %q, expected %q", verified.Compiler.Version, "0.1.0")
+	}
+	if verified.Spec.Commit != "abc123" || verified.Spec.SemanticHash != "5e3" {
+		t.Errorf("Spec = %+v, expected commit abc123 and semantic hash 5e3", verified.Spec)
+	}
+	if len(verified.Files) != 2 || verified.Files[0].Path != "package.json" {
+		t.Errorf("Files = %+v, expected package.json and src/index.ts", verified.Files)
+	}
+	if changed := verified.CheckFiles(dir); len(changed) != 0 {
+		t.Errorf("CheckFiles() = %v, expected none", changed)
+	}
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	dir, report := writeOutput(t, map[string]string{"src/index.ts": "export {};\n"})
+	st, err := NewStatement(dir, report, "")
+	if err != nil {
+		t.Fatalf("NewStatement() error = %v", err)
+	}
+	privPEM, pubPEM := generateKey(t)
+	priv, _ := ParsePrivateKey(privPEM)
+	pub, _ := ParsePublicKey(pubPEM)
+	_, otherPEM := generateKey(t)
+	other, _ := ParsePublicKey(otherPEM)
+
+	tests := []struct {
+		name   string
+		key    []byte
+		tamper func(env *Envelope)
+		want   string
+	}{
+		{"other key", otherPEM, func(*Envelope) {}, "no valid signature"},
+		{"tampered payload", nil, func(env *Envelope) {
+			env.Payload = []byte(strings.Replace(string(env.Payload), "0.1.0", "0.2.0", 1))
+		}, "no valid signature"},
+		{"payload type", nil, func(env *Envelope) { env.PayloadType = "application/json" }, "unexpected payload type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			env, err := Sign(st, priv)
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			tt.tamper(env)
+			key := pub
+			if tt.key != nil {
+				key = other
+			}
+
+			// when
+			_, err = Verify(env, key)
+
+			// then
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Verify() error = %v, expected it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStatement_Rejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(dir string, report *pipeline.Report)
+		want   string
+	}{
+		{"failed compile", func(_ string, r *pipeline.Report) { r.Status = "failed" }, "failed"},
+		{"only compile", func(_ string, r *pipeline.Report) { r.Options["only"] = "kind=usecase" }, "--only"},
+		{"modified file", func(dir string, _ *pipeline.Report) {
+			_ = os.WriteFile(filepath.Join(dir, "src", "index.ts"), []byte("export const x = 1;\n"), 0644)
+		}, "src/index.ts was modified"},
+		{"missing file", func(dir string, _ *pipeline.Report) {
+			_ = os.Remove(filepath.Join(dir, "src", "index.ts"))
+		}, "src/index.ts is missing"},
+		{"unattested file", func(dir string, _ *pipeline.Report) {
+			_ = os.WriteFile(filepath.Join(dir, "src", "backdoor.ts"), []byte("export {};\n"), 0644)
+		}, "src/backdoor.ts is not attested"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			dir, report := writeOutput(t, map[string]string{"src/index.ts": "export {};\n"})
+			tt.change(dir, report)
+
+			// when
+			_, err := NewStatement(dir, report, "")
+
+			// then
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("NewStatement() error = %v, expected it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSpec(t *testing.T) {
+	// given
+	spec := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(spec, []byte("name: orders\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report := &pipeline.Report{Spec: pipeline.ReportSpec{Path: spec, SHA256: hashHex([]byte("name: orders\n"))}}
+
+	// when
+	unchanged := CheckSpec(report)
+	if err := os.WriteFile(spec, []byte("name: payments\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	changed := CheckSpec(report)
+	report.Spec.Path = filepath.Join(t.TempDir(), "missing.yaml")
+	missing := CheckSpec(report)
+
+	// then
+	if unchanged != nil {
+		t.Errorf("CheckSpec(unchanged) error = %v, expected none", unchanged)
+	}
+	if changed == nil || !strings.Contains(changed.Error(), "changed since the last compile") {
+		t.Errorf("CheckSpec(changed) error = %v, expected the spec to have changed", changed)
+	}
+	if missing == nil || !strings.Contains(missing.Error(), "failed to read spec") {
+		t.Errorf("CheckSpec(missing) error = %v, expected the spec to be unreadable", missing)
+	}
+}
+
+func TestParseKeys_Rejects(t *testing.T) {
+	privPEM, pubPEM := generateKey(t)
+
+	if _, err := ParsePrivateKey(pubPEM); err == nil {
+		t.Errorf("ParsePrivateKey(public key) error = nil, expected an error")
+	}
+	if _, err := ParsePublicKey(privPEM); err == nil {
+		t.Errorf("ParsePublicKey(private key) error = nil, expected an error")
+	}
+	if _, err := ParsePublicKey([]byte("not pem")); err == nil {
+		t.Errorf("ParsePublicKey(garbage) error = nil, expected an error")
+	}
+}
+
+func TestPAE(t *testing.T) {
+	// The example from the DSSE specification
+	got := string(pae("http://example.com/HelloWorld", []byte("hello world")))
+	want := "DSSEv1 29 http://example.com/HelloWorld 11 hello world"
+	if got != want {
+		t.Errorf("pae() = %q, expected %q", got, want)
+	}
+}
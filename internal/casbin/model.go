@@ -0,0 +1,252 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package casbin checks Casbin model files so that grammar mistakes are
+// reported when a spec is compiled rather than when the server starts.
+package casbin
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Section names of a Casbin model file.
+const (
+	SectionRequest = "request_definition"
+	SectionPolicy  = "policy_definition"
+	SectionRole    = "role_definition"
+	SectionEffect  = "policy_effect"
+	SectionMatcher = "matchers"
+)
+
+// sectionKeyPrefix is the prefix every key in a section must start with
+// (e.g., "r" or "r2" in request_definition).
+var sectionKeyPrefix = map[string]string{
+	SectionRequest: "r",
+	SectionPolicy:  "p",
+	SectionRole:    "g",
+	SectionEffect:  "e",
+	SectionMatcher: "m",
+}
+
+var requiredSections = []string{SectionRequest, SectionPolicy, SectionEffect, SectionMatcher}
+
+// supportedEffects are the policy effects Casbin implements, with whitespace
+// removed and the policy type normalized to "p".
+var supportedEffects = map[string]bool{
+	"some(where(p.eft==allow))":                            true,
+	"!some(where(p.eft==deny))":                            true,
+	"some(where(p.eft==allow))&&!some(where(p.eft==deny))": true,
+	"priority(p.eft)||deny":                                true,
+	"subjectPriority(p.eft)||deny":                         true,
+}
+
+var (
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	keyPattern        = regexp.MustCompile(`^([a-z])([0-9]*)$`)
+	attributePattern  = regexp.MustCompile(`\b([rp][0-9]*)\.([A-Za-z_][A-Za-z0-9_]*)`)
+	roleCallPattern   = regexp.MustCompile(`\b(g[0-9]*)\s*\(`)
+	effectTypePattern = regexp.MustCompile(`\bp[0-9]*\.eft\b`)
+)
+
+// Issue is a grammar problem found in a model file.
+type Issue struct {
+	Message string
+	Line    int // 1-indexed line number; 0 when the problem is the file as a whole
+}
+
+func (i Issue) String() string {
+	return i.Message
+}
+
+// Assertion is a "key = value" line of a model file.
+type Assertion struct {
+	Key   string
+	Value string
+	Line  int
+}
+
+// Model is a parsed Casbin model file.
+type Model struct {
+	File     string
+	Sections map[string][]Assertion
+	Issues   []Issue
+}
+
+// ParseModelFile reads and parses the model file at path.
+func ParseModelFile(path string) (*Model, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := ParseModel(data)
+	m.File = path
+	return m, nil
+}
+
+// ParseModel parses a Casbin model and records grammar problems in Issues:
+// unknown or missing sections, malformed assertions, unsupported policy
+// effects and matchers that use undefined request, policy or role names.
+func ParseModel(data []byte) *Model {
+	m := &Model{Sections: make(map[string][]Assertion)}
+
+	section := ""
+	lines := strings.Split(string(data), "\n")
+	for n := 0; n < len(lines); n++ {
+		lineNo := n + 1
+		line := strings.TrimSpace(lines[n])
+		// A trailing backslash continues the assertion on the next line.
+		for strings.HasSuffix(line, "\\") && n+1 < len(lines) {
+			n++
+			line = strings.TrimSpace(strings.TrimSuffix(line, "\\")) + " " + strings.TrimSpace(lines[n])
+		}
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				m.report(lineNo, "malformed section header %q", line)
+				section = ""
+				continue
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := sectionKeyPrefix[section]; !ok {
+				m.report(lineNo, "unknown section [%s]", section)
+				section = ""
+				continue
+			}
+			if _, ok := m.Sections[section]; ok {
+				m.report(lineNo, "duplicate section [%s]", section)
+			} else {
+				m.Sections[section] = nil
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" {
+			m.report(lineNo, "expected \"key = value\", got %q", line)
+			continue
+		}
+		if section == "" {
+			m.report(lineNo, "assertion %q is outside a section", key)
+			continue
+		}
+		m.addAssertion(section, Assertion{Key: key, Value: value, Line: lineNo})
+	}
+
+	for _, name := range requiredSections {
+		if len(m.Sections[name]) == 0 {
+			m.report(0, "missing section [%s]", name)
+		}
+	}
+	m.checkMatchers()
+
+	sort.SliceStable(m.Issues, func(a, b int) bool {
+		return m.Issues[a].Line < m.Issues[b].Line
+	})
+	return m
+}
+
+func (m *Model) report(line int, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if line > 0 {
+		msg = fmt.Sprintf("line %d: %s", line, msg)
+	}
+	m.Issues = append(m.Issues, Issue{Message: msg, Line: line})
+}
+
+// Assertion returns the assertion with key in section, if any.
+func (m *Model) Assertion(section, key string) (Assertion, bool) {
+	for _, a := range m.Sections[section] {
+		if a.Key == key {
+			return a, true
+		}
+	}
+	return Assertion{}, false
+}
+
+func (m *Model) addAssertion(section string, a Assertion) {
+	prefix := sectionKeyPrefix[section]
+	if match := keyPattern.FindStringSubmatch(a.Key); match == nil || match[1] != prefix {
+		m.report(a.Line, "key %q in [%s] must be %q optionally followed by a number", a.Key, section, prefix)
+		return
+	}
+	if _, ok := m.Assertion(section, a.Key); ok {
+		m.report(a.Line, "duplicate key %q in [%s]", a.Key, section)
+		return
+	}
+	if a.Value == "" {
+		m.report(a.Line, "%s has no value", a.Key)
+		return
+	}
+
+	switch section {
+	case SectionRequest, SectionPolicy:
+		for _, token := range strings.Split(a.Value, ",") {
+			if token = strings.TrimSpace(token); !identifierPattern.MatchString(token) {
+				m.report(a.Line, "%s: invalid token %q", a.Key, token)
+			}
+		}
+	case SectionRole:
+		tokens := strings.Split(a.Value, ",")
+		valid := len(tokens) >= 2
+		for _, token := range tokens {
+			if strings.TrimSpace(token) != "_" {
+				valid = false
+			}
+		}
+		if !valid {
+			m.report(a.Line, "%s: role definition must be \"_, _\" or \"_, _, _\", got %q", a.Key, a.Value)
+		}
+	case SectionEffect:
+		normalized := effectTypePattern.ReplaceAllString(strings.Join(strings.Fields(a.Value), ""), "p.eft")
+		if !supportedEffects[normalized] {
+			m.report(a.Line, "%s: unsupported policy effect %q", a.Key, a.Value)
+		}
+	}
+
+	m.Sections[section] = append(m.Sections[section], a)
+}
+
+// checkMatchers reports matcher references to request and policy tokens or
+// role definitions that the model does not define.
+func (m *Model) checkMatchers() {
+	tokens := make(map[string]map[string]bool)
+	for _, section := range []string{SectionRequest, SectionPolicy} {
+		for _, a := range m.Sections[section] {
+			tokens[a.Key] = make(map[string]bool)
+			for _, token := range strings.Split(a.Value, ",") {
+				tokens[a.Key][strings.TrimSpace(token)] = true
+			}
+		}
+	}
+
+	for _, a := range m.Sections[SectionMatcher] {
+		seen := make(map[string]bool)
+		for _, match := range attributePattern.FindAllStringSubmatch(a.Value, -1) {
+			key, attr := match[1], match[2]
+			if seen[match[0]] {
+				continue
+			}
+			seen[match[0]] = true
+			defined, ok := tokens[key]
+			switch {
+			case !ok:
+				m.report(a.Line, "%s: %s.%s refers to undefined %q", a.Key, key, attr, key)
+			case !defined[attr] && !(strings.HasPrefix(key, "p") && attr == "eft"):
+				m.report(a.Line, "%s: %s.%s is not defined by %s", a.Key, key, attr, key)
+			}
+		}
+		for _, match := range roleCallPattern.FindAllStringSubmatch(a.Value, -1) {
+			if _, ok := m.Assertion(SectionRole, match[1]); !ok {
+				m.report(a.Line, "%s: %s() is used but no role definition %q exists", a.Key, match[1], match[1])
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package casbin
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const rbacModel = `[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && keyMatch2(r.obj, p.obj) && \
+    r.act == p.act
+`
+
+func TestParseModel_Valid(t *testing.T) {
+	// when
+	m := ParseModel([]byte(rbacModel))
+
+	// then
+	if len(m.Issues) != 0 {
+		t.Fatalf("ParseModel() issues = %v, want none", m.Issues)
+	}
+	matcher, ok := m.Assertion(SectionMatcher, "m")
+	if !ok {
+		t.Fatal("matcher m not found")
+	}
+	if matcher.Value != "g(r.sub, p.sub) && keyMatch2(r.obj, p.obj) && r.act == p.act" {
+		t.Errorf("continued matcher = %q", matcher.Value)
+	}
+	if matcher.Line != 14 {
+		t.Errorf("matcher line = %d, want 14", matcher.Line)
+	}
+}
+
+func TestParseModel_Issues(t *testing.T) {
+	tests := []struct {
+		name  string
+		model string
+		want  []Issue
+	}{
+		{
+			name: "missing sections",
+			model: "[request_definition]\nr = sub, obj, act\n\n" +
+				"[policy_definition]\np = sub, obj, act\n",
+			want: []Issue{
+				{Message: "missing section [policy_effect]"},
+				{Message: "missing section [matchers]"},
+			},
+		},
+		{
+			name:  "unknown section and key outside a section",
+			model: "r = sub\n[requests]\n" + rbacModel,
+			want: []Issue{
+				{Message: `line 1: assertion "r" is outside a section`, Line: 1},
+				{Message: "line 2: unknown section [requests]", Line: 2},
+			},
+		},
+		{
+			name: "wrong key prefix and malformed line",
+			model: "[request_definition]\np = sub, obj, act\nr sub\n" +
+				"[policy_definition]\np = sub, obj, act\n" +
+				"[policy_effect]\ne = some(where (p.eft == allow))\n" +
+				"[matchers]\nm = p.sub == p.obj\n",
+			want: []Issue{
+				{Message: "missing section [request_definition]"},
+				{Message: `line 2: key "p" in [request_definition] must be "r" optionally followed by a number`, Line: 2},
+				{Message: `line 3: expected "key = value", got "r sub"`, Line: 3},
+			},
+		},
+		{
+			name: "unsupported effect",
+			model: "[request_definition]\nr = sub\n[policy_definition]\np = sub\n" +
+				"[policy_effect]\ne = all(where (p.eft == allow))\n" +
+				"[matchers]\nm = r.sub == p.sub\n",
+			want: []Issue{
+				{Message: `line 6: e: unsupported policy effect "all(where (p.eft == allow))"`, Line: 6},
+			},
+		},
+		{
+			name: "matcher uses undefined names",
+			model: "[request_definition]\nr = sub, obj\n[policy_definition]\np = sub, obj\n" +
+				"[policy_effect]\ne = some(where (p.eft == allow))\n" +
+				"[matchers]\nm = g(r.sub, p.sub) && r.act == p.act && r2.obj == p.obj && r.act == p.act\n",
+			want: []Issue{
+				{Message: `line 8: m: r.act is not defined by r`, Line: 8},
+				{Message: `line 8: m: p.act is not defined by p`, Line: 8},
+				{Message: `line 8: m: r2.obj refers to undefined "r2"`, Line: 8},
+				{Message: `line 8: m: g() is used but no role definition "g" exists`, Line: 8},
+			},
+		},
+		{
+			name:  "invalid role definition",
+			model: rbacModel + "\n[role_definition]\ng2 = _\n",
+			want: []Issue{
+				{Message: "line 17: duplicate section [role_definition]", Line: 17},
+				{Message: `line 18: g2: role definition must be "_, _" or "_, _, _", got "_"`, Line: 18},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := ParseModel([]byte(tt.model))
+			if !reflect.DeepEqual(m.Issues, tt.want) {
+				t.Errorf("ParseModel() issues =\n%v\nwant\n%v", m.Issues, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseModelFile(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "model.conf")
+	if err := os.WriteFile(path, []byte(rbacModel), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// when
+	m, err := ParseModelFile(path)
+
+	// then
+	if err != nil {
+		t.Fatalf("ParseModelFile() error = %v", err)
+	}
+	if m.File != path {
+		t.Errorf("File = %q, want %q", m.File, path)
+	}
+
+	if _, err := ParseModelFile(filepath.Join(t.TempDir(), "missing.conf")); !os.IsNotExist(err) {
+		t.Errorf("ParseModelFile() error = %v, want not-exist error", err)
+	}
+}
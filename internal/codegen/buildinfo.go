@@ -0,0 +1,53 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package codegen
+
+import (
+	"time"
+)
+
+// BuildInfo records what produced a set of generated files, so a deployed
+// service can be traced back to the spec and compiler behind it.
+type BuildInfo struct {
+	Version     string    // Compiler version
+	SpecHash    string    // Semantic hash of the spec, see parser.Spec.SemanticHash
+	GeneratedAt time.Time // Zero unless timestamps are enabled, to keep output reproducible
+}
+
+// String returns the provenance line stamped into file headers, e.g.
+// "bound 0.1.0, spec sha256:4f2a…".
+func (b BuildInfo) String() string {
+	s := "bound " + b.Version + ", spec sha256:" + b.SpecHash
+	if !b.GeneratedAt.IsZero() {
+		s += ", generated " + b.GeneratedAt.UTC().Format(time.RFC3339)
+	}
+	return s
+}
+
+// Stamp adds the provenance line of info below the Header banner that
+// starts content, in the banner's comment syntax. Content without a banner,
+// such as JSON or copied user files, is returned as is.
+func Stamp(content []byte, info BuildInfo) []byte {
+	style, ok := headerStyle(content)
+	if !ok {
+		return content
+	}
+
+	var stamp string
+	switch style {
+	case HashComments:
+		stamp = "# " + info.String()
+	case HTMLComments:
+		stamp = "<!-- " + info.String() + " -->"
+	default:
+		stamp = "// " + info.String()
+	}
+
+	banner := Header(style)
+	stamped := make([]byte, 0, len(content)+len(stamp)+1)
+	stamped = append(stamped, banner...)
+	stamped = append(stamped, stamp...)
+	stamped = append(stamped, '\n')
+	return append(stamped, content[len(banner):]...)
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package codegen
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildInfo_String(t *testing.T) {
+	tests := []struct {
+		name string
+		info BuildInfo
+		want string
+	}{
+		{
+			name: "without timestamp",
+			info: BuildInfo{Version: "0.1.0", SpecHash: "abc"},
+			want: "bound 0.1.0, spec sha256:abc",
+		},
+		{
+			name: "with timestamp in UTC",
+			info: BuildInfo{Version: "0.1.0", SpecHash: "abc", GeneratedAt: time.Date(2026, 3, 4, 6, 7, 8, 0, time.FixedZone("CET", 3600))},
+			want: "bound 0.1.0, spec sha256:abc, generated 2026-03-04T05:07:08Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			got := tt.info.String()
+
+			// then
+			if got != tt.want {
+				t.Errorf("String() = %q, expected %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStamp(t *testing.T) {
+	info := BuildInfo{Version: "0.1.0", SpecHash: "abc"}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "typescript banner",
+			content: "// Generated by OpenBoundary - DO NOT EDIT\n\nexport {};\n",
+			want:    "// Generated by OpenBoundary - DO NOT EDIT\n// bound 0.1.0, spec sha256:abc\n\nexport {};\n",
+		},
+		{
+			name:    "hash banner",
+			content: "# Generated by OpenBoundary - DO NOT EDIT\nPORT=3000\n",
+			want:    "# Generated by OpenBoundary - DO NOT EDIT\n# bound 0.1.0, spec sha256:abc\nPORT=3000\n",
+		},
+		{
+			name:    "html banner",
+			content: Header(HTMLComments) + "# app\n",
+			want:    Header(HTMLComments) + "<!-- bound 0.1.0, spec sha256:abc -->\n# app\n",
+		},
+		{
+			name:    "go banner",
+			content: "// Code generated by OpenBoundary. DO NOT EDIT.\n\npackage api\n",
+			want:    "// Code generated by OpenBoundary. DO NOT EDIT.\n// bound 0.1.0, spec sha256:abc\n\npackage api\n",
+		},
+		{
+			name:    "banner only",
+			content: "# Generated by OpenBoundary - DO NOT EDIT\n",
+			want:    "# Generated by OpenBoundary - DO NOT EDIT\n# bound 0.1.0, spec sha256:abc\n",
+		},
+		{
+			name:    "other banners are left alone",
+			content: "# Generated by OpenBoundary\nPORT=3000\n",
+			want:    "# Generated by OpenBoundary\nPORT=3000\n",
+		},
+		{
+			name:    "json is left alone",
+			content: "{\n  \"name\": \"app\"\n}\n",
+			want:    "{\n  \"name\": \"app\"\n}\n",
+		},
+		{
+			name:    "banner below the first line is left alone",
+			content: "export {};\n// Generated by OpenBoundary - DO NOT EDIT\n",
+			want:    "export {};\n// Generated by OpenBoundary - DO NOT EDIT\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			got := Stamp([]byte(tt.content), info)
+
+			// then
+			if string(got) != tt.want {
+				t.Errorf("Stamp() = %q, expected %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package codegentest runs generators against shared spec fixtures and
+// compares their output with golden files, so that generator changes are
+// reviewed as diffs of the files they emit.
+//
+// Each case is a directory under testdata/specs of this package holding a
+// spec.yaml and the files it references. The expected output of generator
+// G for case C is the file tree testdata/G/C of the package under test.
+// Run the tests with -update to rewrite the golden files from the current
+// output:
+//
+//	go test ./internal/codegen/typescript -run Golden -update
+package codegentest
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+	"github.com/openboundary/openboundary/internal/validator"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the generated output")
+
+// SpecsDir returns the directory holding the spec fixtures.
+func SpecsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "testdata", "specs")
+}
+
+// Cases returns the names of the spec fixtures, sorted.
+func Cases(t testing.TB) []string {
+	t.Helper()
+	entries, err := os.ReadDir(SpecsDir())
+	if err != nil {
+		t.Fatalf("failed to read spec fixtures: %v", err)
+	}
+	var cases []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			cases = append(cases, entry.Name())
+		}
+	}
+	return cases
+}
+
+// BuildIR parses, builds and validates the spec of a fixture, failing the
+// test on any error.
+func BuildIR(t testing.TB, name string) *ir.IR {
+	t.Helper()
+	dir := filepath.Join(SpecsDir(), name)
+	spec, err := parser.NewParser(filepath.Join(dir, "spec.yaml")).Parse()
+	if err != nil {
+		t.Fatalf("case %s: %v", name, err)
+	}
+	i, errs := ir.NewBuilder().WithBaseDir(dir).Build(spec)
+	if len(errs) > 0 {
+		t.Fatalf("case %s: build failed: %v", name, errs)
+	}
+	if verrs := validator.NewIRValidator().Validate(i); len(verrs) > 0 {
+		t.Fatalf("case %s: validation failed: %v", name, verrs)
+	}
+	return i
+}
+
+// Run generates every fixture with each generator of the registry that
+// applies to it, compares the output with testdata/<generator>/<case>, and
+// lints it.
+func Run(t *testing.T, newRegistry func() (*codegen.PluginRegistry, error)) {
+	for _, name := range Cases(t) {
+		i := BuildIR(t, name)
+		registry, err := newRegistry()
+		if err != nil {
+			t.Fatalf("failed to create plugin registry: %v", err)
+		}
+		generators, err := registry.GeneratorsForIR(i)
+		if err != nil {
+			t.Fatalf("case %s: %v", name, err)
+		}
+		for _, gen := range generators {
+			t.Run(gen.Name()+"/"+name, func(t *testing.T) {
+				output, err := gen.Generate(i)
+				if err != nil {
+					t.Fatalf("Generate() error = %v", err)
+				}
+				Assert(t, filepath.Join("testdata", gen.Name(), name), output)
+				Lint(t, filepath.Join(SpecsDir(), name), output)
+			})
+		}
+	}
+}
+
+// Deterministic generates every fixture twice with each generator of the
+// registry, from separately built IRs, and fails when the outputs differ.
+// Generators must not depend on map order, the clock or random sources.
+func Deterministic(t *testing.T, newRegistry func() (*codegen.PluginRegistry, error)) {
+	for _, name := range Cases(t) {
+		first, second := BuildIR(t, name), BuildIR(t, name)
+		registry, err := newRegistry()
+		if err != nil {
+			t.Fatalf("failed to create plugin registry: %v", err)
+		}
+		generators, err := registry.GeneratorsForIR(first)
+		if err != nil {
+			t.Fatalf("case %s: %v", name, err)
+		}
+		for _, gen := range generators {
+			t.Run(gen.Name()+"/"+name, func(t *testing.T) {
+				want, err := gen.Generate(first)
+				if err != nil {
+					t.Fatalf("Generate() error = %v", err)
+				}
+				got, err := gen.Generate(second)
+				if err != nil {
+					t.Fatalf("Generate() error = %v", err)
+				}
+				for path, file := range got.Files {
+					expected, ok := want.Files[path]
+					switch {
+					case !ok:
+						t.Errorf("%s: only generated the second time", path)
+					case !bytes.Equal(file.Content, expected.Content):
+						t.Errorf("%s: content differs between runs\n%s", path, firstDifference(file.Content, expected.Content))
+					case file.ComponentID != expected.ComponentID:
+						t.Errorf("%s: owned by %q, then by %q", path, expected.ComponentID, file.ComponentID)
+					}
+				}
+				for path := range want.Files {
+					if _, ok := got.Files[path]; !ok {
+						t.Errorf("%s: only generated the first time", path)
+					}
+				}
+			})
+		}
+	}
+}
+
+// Assert compares output with the file tree under dir. With -update it
+// replaces the tree with output instead.
+func Assert(t *testing.T, dir string, output *codegen.Output) {
+	t.Helper()
+	if *update {
+		if err := write(dir, output); err != nil {
+			t.Fatalf("failed to update %s: %v", dir, err)
+		}
+		return
+	}
+
+	want, err := read(dir)
+	if err != nil {
+		t.Fatalf("failed to read golden files: %v", err)
+	}
+
+	var paths []string
+	for path := range output.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		expected, ok := want[path]
+		if !ok {
+			t.Errorf("%s: unexpected file", path)
+			continue
+		}
+		if got := output.Files[path].Content; !bytes.Equal(got, expected) {
+			t.Errorf("%s: content differs from %s\n%s", path, filepath.Join(dir, path), firstDifference(got, expected))
+		}
+	}
+	for path := range want {
+		if _, ok := output.Files[path]; !ok {
+			t.Errorf("%s: missing file", path)
+		}
+	}
+	if t.Failed() {
+		t.Log("run with -update to accept the new output, then review the diff")
+	}
+}
+
+// ReadOutput returns the files under dir as generator output, so that a
+// compiled project can be compared with Assert.
+func ReadOutput(dir string) (*codegen.Output, error) {
+	files, err := read(dir)
+	if err != nil {
+		return nil, err
+	}
+	output := codegen.NewOutput()
+	for path, content := range files {
+		output.AddFile(path, content)
+	}
+	return output, nil
+}
+
+// firstDifference describes the first line where got and want differ.
+func firstDifference(got, want []byte) string {
+	gotLines := strings.Split(string(got), "\n")
+	wantLines := strings.Split(string(want), "\n")
+	for n := 0; ; n++ {
+		if n >= len(gotLines) || n >= len(wantLines) {
+			return fmt.Sprintf("got %d lines, want %d", len(gotLines), len(wantLines))
+		}
+		if gotLines[n] != wantLines[n] {
+			return fmt.Sprintf("line %d:\n  got:  %q\n  want: %q", n+1, gotLines[n], wantLines[n])
+		}
+	}
+}
+
+// read returns the files under dir keyed by slash-separated relative path.
+// A missing dir holds no files.
+func read(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return files, nil
+	}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	return files, err
+}
+
+// write replaces the tree under dir with output.
+func write(dir string, output *codegen.Output) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	for path, file := range output.Files {
+		full := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(full, file.Content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package codegentest
+
+import (
+	"bytes"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+// noHeaderExts are formats without comments, whose files carry no banner.
+var noHeaderExts = map[string]bool{".json": true, ".csv": true}
+
+// noHeaderFiles are files whose first line is reserved: a Dockerfile may
+// only start with its parser directives.
+var noHeaderFiles = map[string]bool{"Dockerfile": true}
+
+// Lint checks the conventions every generated file follows: LF line
+// endings, no trailing whitespace, a final newline, and a codegen.Header
+// banner on the first line. Formats without comments carry no banner, and
+// files copied unchanged from the fixture in specDir are the user's and
+// are not checked.
+func Lint(t *testing.T, specDir string, output *codegen.Output) {
+	t.Helper()
+	fixture, err := read(specDir)
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	copied := make(map[string]bool, len(fixture))
+	for _, content := range fixture {
+		copied[string(content)] = true
+	}
+
+	var paths []string
+	for p := range output.Files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		content := output.Files[p].Content
+		if len(content) == 0 || copied[string(content)] {
+			continue
+		}
+		if bytes.Contains(content, []byte("\r")) {
+			t.Errorf("%s: has CR line endings", p)
+		}
+		if !bytes.HasSuffix(content, []byte("\n")) {
+			t.Errorf("%s: does not end with a newline", p)
+		}
+		for n, line := range strings.Split(string(content), "\n") {
+			if strings.TrimRight(line, " \t") != line {
+				t.Errorf("%s:%d: trailing whitespace", p, n+1)
+			}
+		}
+		base := path.Base(p)
+		if !noHeaderExts[path.Ext(base)] && !noHeaderFiles[base] && !codegen.HasHeader(content) {
+			t.Errorf("%s: does not start with a codegen.Header banner", p)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package codegentest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+// versionsFile records, per generator, its Version and a digest of its
+// golden files, relative to the testdata of the package under test.
+const versionsFile = "versions.json"
+
+// recordedVersion is the Version a generator had when its golden files had
+// Digest.
+type recordedVersion struct {
+	Version string `json:"version"`
+	Digest  string `json:"digest"`
+}
+
+// Versions fails when the golden output of a generator of the registry
+// changed but its Version did not, which would let compiles reuse output
+// cached by the previous generator. Run it with -update, after TestGolden
+// rewrote the golden files and the Version was bumped, to record both.
+func Versions(t *testing.T, newRegistry func() (*codegen.PluginRegistry, error)) {
+	registry, err := newRegistry()
+	if err != nil {
+		t.Fatalf("failed to create plugin registry: %v", err)
+	}
+	path := filepath.Join("testdata", versionsFile)
+	recorded := make(map[string]recordedVersion)
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &recorded); err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+
+	current := make(map[string]recordedVersion)
+	for _, plugin := range registry.Plugins() {
+		if plugin.Version == "" {
+			t.Errorf("%s: no Version", plugin.Name)
+			continue
+		}
+		digest, err := goldenDigest(filepath.Join("testdata", plugin.Name))
+		if err != nil {
+			t.Fatalf("%s: %v", plugin.Name, err)
+		}
+		current[plugin.Name] = recordedVersion{Version: plugin.Version, Digest: digest}
+		was, ok := recorded[plugin.Name]
+		switch {
+		case ok && was.Digest != digest && was.Version == plugin.Version:
+			t.Errorf("%s: golden output changed, bump its Version from %q", plugin.Name, plugin.Version)
+		case !*update && (!ok || was != current[plugin.Name]):
+			t.Errorf("%s: Version %q not recorded in %s, run with -update", plugin.Name, plugin.Version, path)
+		}
+	}
+	if *update && !t.Failed() {
+		data, err := json.MarshalIndent(current, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+			t.Fatalf("failed to update %s: %v", path, err)
+		}
+	}
+}
+
+// goldenDigest hashes the golden files under dir, with their paths.
+func goldenDigest(dir string) (string, error) {
+	files, err := read(dir)
+	if err != nil {
+		return "", err
+	}
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	h := sha256.New()
+	for _, path := range paths {
+		sum := sha256.Sum256(files[path])
+		h.Write([]byte(path + "\x00" + hex.EncodeToString(sum[:]) + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
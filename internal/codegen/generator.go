@@ -11,10 +11,29 @@ type Generator interface {
 	// Name returns the generator name.
 	Name() string
 
-	// Generate produces code from the IR.
+	// Generate produces code from the IR. The same IR must always give the
+	// same bytes, so generators iterate in sorted order and read neither the
+	// clock nor random sources; the stamp stage adds the generation time,
+	// from the clock it is given, when asked to.
 	Generate(i *ir.IR) (*Output, error)
 }
 
+// ComponentGenerator is implemented by generators whose files, apart from a
+// few shared ones, each belong to a single component. The generate stage
+// then produces every component's files as a separate task, concurrently
+// with other components and generators.
+type ComponentGenerator interface {
+	Generator
+
+	// GenerateShared produces the files that belong to no single component.
+	GenerateShared(i *ir.IR) (*Output, error)
+
+	// GenerateComponent produces the files of comp, or an empty output when
+	// the generator has none for it. It runs concurrently with other calls,
+	// so it must only read the IR.
+	GenerateComponent(i *ir.IR, comp *ir.Component) (*Output, error)
+}
+
 // OutputFile represents a single generated file with optional component association.
 type OutputFile struct {
 	Content     []byte
@@ -0,0 +1,387 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package golang provides Go code generation.
+package golang
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+// ClientGenerator generates a typed Go client package per http.server.
+type ClientGenerator struct{}
+
+// NewClientGenerator creates a new Go client generator.
+func NewClientGenerator() *ClientGenerator {
+	return &ClientGenerator{}
+}
+
+// Name returns the generator name.
+func (g *ClientGenerator) Name() string {
+	return "go-client"
+}
+
+// Generate produces one Go client package for each http.server component.
+func (g *ClientGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+
+	for _, server := range i.HTTPServers() {
+		pkg := packageName(server.ID)
+		types := newTypeRegistry()
+
+		var doc *openapi.Document
+		if server.HTTPServer.ParsedOpenAPI != nil {
+			doc = server.HTTPServer.ParsedOpenAPI
+			types.addComponentSchemas(doc)
+		}
+
+		// Catch-all routes have no single request to send, so they get no method
+		methods := make([]clientMethod, 0)
+		for _, uc := range i.UsecasesBoundTo(server.ID) {
+			if uc.Usecase.Binding.IsCatchAll() {
+				continue
+			}
+			methods = append(methods, newClientMethod(uc, server, types))
+		}
+
+		clientCode, err := formatSource(server.ID, g.generateClient(pkg, server))
+		if err != nil {
+			return nil, err
+		}
+		output.AddComponentFile(clientSourcePath(server.ID), clientCode, server.ID)
+
+		operationsCode, err := formatSource(server.ID, g.generateOperations(pkg, methods))
+		if err != nil {
+			return nil, err
+		}
+		output.AddComponentFile(clientOperationsPath(server.ID), operationsCode, server.ID)
+
+		typesCode, err := formatSource(server.ID, g.generateTypes(pkg, types))
+		if err != nil {
+			return nil, err
+		}
+		output.AddComponentFile(clientTypesPath(server.ID), typesCode, server.ID)
+	}
+
+	return output, nil
+}
+
+func (g *ClientGenerator) generateClient(pkg string, server *ir.Component) string {
+	var sb strings.Builder
+
+	sb.WriteString(generatedHeader)
+	fmt.Fprintf(&sb, "// Package %s is a typed HTTP client for %s.\n", pkg, server.ID)
+	fmt.Fprintf(&sb, "package %s\n\n", pkg)
+	sb.WriteString(clientRuntime)
+
+	return sb.String()
+}
+
+func (g *ClientGenerator) generateOperations(pkg string, methods []clientMethod) string {
+	var sb strings.Builder
+
+	sb.WriteString(generatedHeader)
+	fmt.Fprintf(&sb, "package %s\n\n", pkg)
+
+	if len(methods) == 0 {
+		return sb.String()
+	}
+
+	imports := []string{"context"}
+	for _, m := range methods {
+		if len(m.pathParams) > 0 {
+			imports = append(imports, "net/url")
+			break
+		}
+	}
+	for _, m := range methods {
+		if m.output == "json.RawMessage" {
+			imports = append(imports, "encoding/json")
+			break
+		}
+	}
+	sort.Strings(imports)
+	sb.WriteString("import (\n")
+	for _, imp := range imports {
+		fmt.Fprintf(&sb, "\t%q\n", imp)
+	}
+	sb.WriteString(")\n")
+
+	for _, m := range methods {
+		sb.WriteString("\n")
+		m.write(&sb)
+	}
+
+	return sb.String()
+}
+
+func (g *ClientGenerator) generateTypes(pkg string, types *typeRegistry) string {
+	var sb strings.Builder
+
+	sb.WriteString(generatedHeader)
+	fmt.Fprintf(&sb, "package %s\n", pkg)
+
+	if types.usesTime {
+		sb.WriteString("\nimport \"time\"\n")
+	}
+
+	for _, name := range types.sortedNames() {
+		sb.WriteString("\n")
+		sb.WriteString(types.decls[name])
+	}
+
+	return sb.String()
+}
+
+// clientMethod describes a single generated client method bound to a usecase.
+type clientMethod struct {
+	usecaseID  string
+	goal       string
+	name       string
+	method     string
+	path       string
+	pathParams []string
+	input      string // Go type of the request body (empty when no body)
+	output     string // Go type of the decoded response (empty when no content)
+}
+
+func newClientMethod(uc, server *ir.Component, types *typeRegistry) clientMethod {
+	binding := uc.Usecase.Binding
+	m := clientMethod{
+		usecaseID:  uc.ID,
+		goal:       uc.Usecase.Goal,
+		method:     binding.Method,
+		path:       server.HTTPServer.RoutePath(binding.Path),
+		pathParams: binding.PathParams(),
+	}
+
+	op := binding.Operation
+	if op != nil && op.OperationID != "" {
+		m.name = exportedName(op.OperationID)
+	} else {
+		parts := strings.Split(uc.ID, ".")
+		m.name = exportedName(parts[len(parts)-1])
+	}
+
+	hasBody := binding.Method == "POST" || binding.Method == "PUT" || binding.Method == "PATCH"
+
+	if op == nil {
+		// Without an OpenAPI operation the payload shapes are unknown.
+		if hasBody {
+			m.input = "any"
+		}
+		if binding.Method != "DELETE" {
+			m.output = "json.RawMessage"
+		}
+		return m
+	}
+
+	if op.RequestBody != nil {
+		if media, ok := op.RequestBody.Content["application/json"]; ok && media.Schema != nil {
+			m.input = types.goType(media.Schema, m.name+"Request")
+		} else {
+			m.input = "any"
+		}
+	}
+
+	if schema := op.SuccessResponseSchema(); schema != nil {
+		m.output = types.goType(schema, m.name+"Response")
+	}
+
+	return m
+}
+
+func (m clientMethod) write(sb *strings.Builder) {
+	params := []string{"ctx context.Context"}
+	for _, p := range m.pathParams {
+		params = append(params, unexportedName(p)+" string")
+	}
+	bodyArg := "nil"
+	if m.input != "" {
+		params = append(params, "body "+m.input)
+		bodyArg = "body"
+	}
+
+	fmt.Fprintf(sb, "// %s calls %s %s (%s).\n", m.name, m.method, m.path, m.usecaseID)
+	if m.goal != "" {
+		fmt.Fprintf(sb, "//\n// %s\n", m.goal)
+	}
+
+	pathExpr := m.pathExpression()
+	if m.output == "" {
+		fmt.Fprintf(sb, "func (c *Client) %s(%s) error {\n", m.name, strings.Join(params, ", "))
+		fmt.Fprintf(sb, "\treturn c.do(ctx, %q, %s, %s, nil)\n", m.method, pathExpr, bodyArg)
+		sb.WriteString("}\n")
+		return
+	}
+
+	fmt.Fprintf(sb, "func (c *Client) %s(%s) (*%s, error) {\n", m.name, strings.Join(params, ", "), m.output)
+	fmt.Fprintf(sb, "\tvar out %s\n", m.output)
+	fmt.Fprintf(sb, "\tif err := c.do(ctx, %q, %s, %s, &out); err != nil {\n", m.method, pathExpr, bodyArg)
+	sb.WriteString("\t\treturn nil, err\n")
+	sb.WriteString("\t}\n")
+	sb.WriteString("\treturn &out, nil\n")
+	sb.WriteString("}\n")
+}
+
+// pathExpression renders the request path as a Go string expression with
+// escaped path parameters (e.g., "/users/" + url.PathEscape(id)).
+func (m clientMethod) pathExpression() string {
+	var parts []string
+	rest := m.path
+	for {
+		start := strings.Index(rest, "{")
+		end := strings.Index(rest, "}")
+		if start == -1 || end == -1 || end < start {
+			break
+		}
+		if start > 0 {
+			parts = append(parts, fmt.Sprintf("%q", rest[:start]))
+		}
+		parts = append(parts, fmt.Sprintf("url.PathEscape(%s)", unexportedName(rest[start+1:end])))
+		rest = rest[end+1:]
+	}
+	if rest != "" || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%q", rest))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func formatSource(componentID, src string) ([]byte, error) {
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return nil, fmt.Errorf("component %q: generated Go client does not compile: %w", componentID, err)
+	}
+	return formatted, nil
+}
+
+func clientSourcePath(id string) string {
+	return fmt.Sprintf("clients/go/%s/client.go", componentIDSlug(id))
+}
+
+func clientOperationsPath(id string) string {
+	return fmt.Sprintf("clients/go/%s/operations.go", componentIDSlug(id))
+}
+
+func clientTypesPath(id string) string {
+	return fmt.Sprintf("clients/go/%s/types.go", componentIDSlug(id))
+}
+
+func componentIDSlug(id string) string {
+	return strings.NewReplacer(".", "-", "/", "-").Replace(id)
+}
+
+var generatedHeader = codegen.Header(codegen.GoComments) + "\n"
+
+const clientRuntime = `import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Client calls the generated API over HTTP.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	editors    []RequestEditorFn
+}
+
+// RequestEditorFn mutates an outgoing request, e.g. to add authentication headers.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the underlying HTTP client (defaults to http.DefaultClient).
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithRequestEditor registers a function applied to every outgoing request.
+func WithRequestEditor(fn RequestEditorFn) Option {
+	return func(c *Client) {
+		c.editors = append(c.editors, fn)
+	}
+}
+
+// NewClient creates a client for the API served at baseURL.
+func NewClient(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// APIError is returned when the server responds with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, strings.TrimSpace(string(e.Body)))
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, edit := range c.editors {
+		if err := edit(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &APIError{StatusCode: resp.StatusCode, Body: data}
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response body: %w", err)
+	}
+	return nil
+}
+`
@@ -0,0 +1,324 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+const testOpenAPI = `
+openapi: 3.0.3
+info:
+  title: User API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      operationId: createUser
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [email]
+              properties:
+                email:
+                  type: string
+                  format: email
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /users/{id}:
+    delete:
+      operationId: deleteUser
+      responses:
+        '204':
+          description: No Content
+components:
+  schemas:
+    User:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+        created_at:
+          type: string
+          format: date-time
+`
+
+func newTestIR(t *testing.T) *ir.IR {
+	t.Helper()
+
+	doc, err := openapi.NewParser("").ParseBytes([]byte(testOpenAPI))
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+
+	server := &ir.Component{
+		ID:   "http.server.api",
+		Kind: ir.KindHTTPServer,
+		HTTPServer: &ir.HTTPServerSpec{
+			Framework:     "hono",
+			Port:          3000,
+			ParsedOpenAPI: doc,
+		},
+	}
+	createUser := &ir.Component{
+		ID:   "usecase.create-user",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			Goal: "Create a user",
+			Binding: &ir.Binding{
+				ServerID:  "http.server.api",
+				Method:    "POST",
+				Path:      "/users",
+				Operation: doc.Operations["POST:/users"],
+			},
+		},
+	}
+	deleteUser := &ir.Component{
+		ID:   "usecase.delete-user",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			Goal: "Delete a user",
+			Binding: &ir.Binding{
+				ServerID:  "http.server.api",
+				Method:    "DELETE",
+				Path:      "/users/{id}",
+				Operation: doc.Operations["DELETE:/users/{id}"],
+			},
+		},
+	}
+
+	return &ir.IR{
+		Components: map[string]*ir.Component{
+			server.ID:     server,
+			createUser.ID: createUser,
+			deleteUser.ID: deleteUser,
+		},
+	}
+}
+
+func TestClientGenerator_Name(t *testing.T) {
+	// given
+	g := NewClientGenerator()
+
+	// when
+	name := g.Name()
+
+	// then
+	if name != "go-client" {
+		t.Errorf("Name() = %q, want %q", name, "go-client")
+	}
+}
+
+func TestClientGenerator_Generate_Files(t *testing.T) {
+	// given
+	g := NewClientGenerator()
+
+	// when
+	output, err := g.Generate(newTestIR(t))
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, path := range []string{
+		"clients/go/http-server-api/client.go",
+		"clients/go/http-server-api/operations.go",
+		"clients/go/http-server-api/types.go",
+	} {
+		file, ok := output.Files[path]
+		if !ok {
+			t.Errorf("missing %s", path)
+			continue
+		}
+		if file.ComponentID != "http.server.api" {
+			t.Errorf("%s ComponentID = %q, want %q", path, file.ComponentID, "http.server.api")
+		}
+		if !strings.HasPrefix(string(file.Content), "// Code generated by OpenBoundary. DO NOT EDIT.") {
+			t.Errorf("%s missing generated header", path)
+		}
+		if !strings.Contains(string(file.Content), "package apiclient") {
+			t.Errorf("%s missing package clause", path)
+		}
+	}
+}
+
+func TestClientGenerator_Generate_Operations(t *testing.T) {
+	// given
+	g := NewClientGenerator()
+
+	// when
+	output, err := g.Generate(newTestIR(t))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["clients/go/http-server-api/operations.go"].Content)
+
+	// then
+	expected := []string{
+		"func (c *Client) CreateUser(ctx context.Context, body CreateUserRequest) (*User, error) {",
+		`c.do(ctx, "POST", "/users", body, &out)`,
+		"func (c *Client) DeleteUser(ctx context.Context, id string) error {",
+		`c.do(ctx, "DELETE", "/users/"+url.PathEscape(id), nil, nil)`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("operations.go missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestClientGenerator_Generate_BasePath(t *testing.T) {
+	// given
+	i := newTestIR(t)
+	i.Components["http.server.api"].HTTPServer.BasePath = "/api/v1"
+
+	// when
+	output, err := NewClientGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["clients/go/http-server-api/operations.go"].Content)
+
+	// then
+	for _, want := range []string{
+		`c.do(ctx, "POST", "/api/v1/users", body, &out)`,
+		`c.do(ctx, "DELETE", "/api/v1/users/"+url.PathEscape(id), nil, nil)`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("operations.go missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestClientGenerator_Generate_Types(t *testing.T) {
+	// given
+	g := NewClientGenerator()
+
+	// when
+	output, err := g.Generate(newTestIR(t))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["clients/go/http-server-api/types.go"].Content)
+
+	// then
+	expected := []string{
+		`import "time"`,
+		"type User struct {",
+		"CreatedAt time.Time `json:\"created_at,omitempty\"`",
+		"ID        string    `json:\"id\"`",
+		"type CreateUserRequest struct {",
+		"Email string `json:\"email\"`",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("types.go missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestTypeRegistry_Composition(t *testing.T) {
+	// given
+	doc := &openapi.Document{Schemas: map[string]*openapi.Schema{
+		"Named": {Type: "object", Required: []string{"name"}, Properties: map[string]*openapi.Schema{"name": {Type: "string"}}},
+		"Cat": {AllOf: []*openapi.Schema{
+			{Ref: "#/components/schemas/Named"},
+			{Type: "object", Properties: map[string]*openapi.Schema{"lives": {Type: "integer"}}},
+		}},
+		"Pet":    {OneOf: []*openapi.Schema{{Ref: "#/components/schemas/Cat"}, {Type: "string"}}},
+		"Labels": {Type: "object", AdditionalProperties: &openapi.Schema{Type: "string"}},
+	}}
+	types := newTypeRegistry()
+
+	// when
+	types.addComponentSchemas(doc)
+
+	// then
+	expected := map[string]string{
+		"Cat":    "type Cat struct {\n\tLives int64 `json:\"lives,omitempty\"`\n\tName string `json:\"name\"`\n}\n",
+		"Pet":    "type Pet = any\n",
+		"Labels": "type Labels = map[string]string\n",
+	}
+	for name, want := range expected {
+		if got := types.decls[name]; got != want {
+			t.Errorf("decls[%q] = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestClientGenerator_Generate_WithoutOpenAPI(t *testing.T) {
+	// given
+	i := newTestIR(t)
+	i.Components["http.server.api"].HTTPServer.ParsedOpenAPI = nil
+	for _, comp := range i.Components {
+		if comp.Usecase != nil {
+			comp.Usecase.Binding.Operation = nil
+		}
+	}
+
+	// when
+	output, err := NewClientGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["clients/go/http-server-api/operations.go"].Content)
+	if !strings.Contains(content, "CreateUser(ctx context.Context, body any) (*json.RawMessage, error)") {
+		t.Errorf("expected untyped CreateUser method\n%s", content)
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"createUser", "CreateUser"},
+		{"create-user", "CreateUser"},
+		{"user_id", "UserID"},
+		{"api_url", "APIURL"},
+		{"2fa", "X2fa"},
+		{"", "X"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := exportedName(tt.in); got != tt.want {
+				t.Errorf("exportedName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnexportedName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"id", "id"},
+		{"user-id", "userID"},
+		{"type", "typeParam"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := unexportedName(tt.in); got != tt.want {
+				t.Errorf("unexportedName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
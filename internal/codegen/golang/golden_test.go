@@ -0,0 +1,37 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package golang
+
+import (
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/codegen/codegentest"
+)
+
+// TestGolden compares the output of the client generator with testdata/<generator>/<case>.
+// Run with -update after an intended change and review the diff.
+func TestGolden(t *testing.T) {
+	codegentest.Run(t, newClientRegistry)
+}
+
+// TestDeterministic checks that the client generator gives byte-identical
+// output for identical input.
+func TestDeterministic(t *testing.T) {
+	codegentest.Deterministic(t, newClientRegistry)
+}
+
+// TestVersions checks that the client generator's Version changes with its
+// golden output, so that compiles do not reuse output it cached before.
+func TestVersions(t *testing.T) {
+	codegentest.Versions(t, newClientRegistry)
+}
+
+func newClientRegistry() (*codegen.PluginRegistry, error) {
+	registry := codegen.NewPluginRegistry()
+	if err := registry.Register(ClientPlugin()); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package golang
+
+import (
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// initialisms are rendered fully upper-cased in exported Go identifiers,
+// following the conventions of golint (e.g., "user_id" -> "UserID").
+var initialisms = map[string]bool{
+	"api":  true,
+	"http": true,
+	"id":   true,
+	"json": true,
+	"url":  true,
+	"uuid": true,
+}
+
+// exportedName converts an arbitrary identifier (kebab-case, snake_case,
+// camelCase, dotted IDs) into an exported Go identifier.
+func exportedName(s string) string {
+	var sb strings.Builder
+	for _, word := range splitWords(s) {
+		lower := strings.ToLower(word)
+		if initialisms[lower] {
+			sb.WriteString(strings.ToUpper(lower))
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+
+	name := sb.String()
+	if name == "" {
+		return "X"
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// unexportedName converts an identifier into a Go identifier suitable for
+// parameters and local variables. Keywords get a "Param" suffix.
+func unexportedName(s string) string {
+	words := splitWords(s)
+	if len(words) == 0 {
+		return "param"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(words[0]))
+	for _, word := range words[1:] {
+		sb.WriteString(exportedName(word))
+	}
+
+	name := sb.String()
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = "p" + name
+	}
+	if token.IsKeyword(name) {
+		name += "Param"
+	}
+	return name
+}
+
+// packageName derives a Go package name from a component ID
+// (e.g., "http.server.api" -> "apiclient").
+func packageName(componentID string) string {
+	parts := strings.Split(componentID, ".")
+	last := parts[len(parts)-1]
+
+	var sb strings.Builder
+	for _, r := range strings.ToLower(last) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	name := sb.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "api" + name
+	}
+	return name + "client"
+}
+
+// splitWords splits an identifier on separators and lower-to-upper case transitions.
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) && unicode.IsLower(runes[i-1]) {
+			flush()
+		}
+		current = append(current, r)
+	}
+	flush()
+
+	return words
+}
@@ -0,0 +1,19 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package golang
+
+import (
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// ClientPlugin returns the opt-in Go client generator plugin.
+func ClientPlugin() codegen.GeneratorPlugin {
+	return codegen.GeneratorPlugin{
+		Name:         "go-client",
+		Version:      "2",
+		NewGenerator: func() codegen.Generator { return NewClientGenerator() },
+		Supports:     []ir.Kind{ir.KindHTTPServer},
+	}
+}
@@ -0,0 +1,36 @@
+// Code generated by OpenBoundary. DO NOT EDIT.
+
+package apiclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// CreateUser calls POST /users (usecase.create-user).
+//
+// Register a new user account in the system
+func (c *Client) CreateUser(ctx context.Context) error {
+	return c.do(ctx, "POST", "/users", nil, nil)
+}
+
+// DeleteUser calls DELETE /users/{id} (usecase.delete-user).
+//
+// Remove a user account from the system
+func (c *Client) DeleteUser(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", "/users/"+url.PathEscape(id), nil, nil)
+}
+
+// GetUser calls GET /users/{id} (usecase.get-user).
+//
+// Retrieve a user's profile information
+func (c *Client) GetUser(ctx context.Context, id string) error {
+	return c.do(ctx, "GET", "/users/"+url.PathEscape(id), nil, nil)
+}
+
+// ListUsers calls GET /users (usecase.list-users).
+//
+// List all users with pagination
+func (c *Client) ListUsers(ctx context.Context) error {
+	return c.do(ctx, "GET", "/users", nil, nil)
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package golang
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+// typeRegistry collects named Go type declarations derived from OpenAPI schemas.
+type typeRegistry struct {
+	doc      *openapi.Document // Resolves the parts of allOf schemas
+	decls    map[string]string
+	usesTime bool
+}
+
+func newTypeRegistry() *typeRegistry {
+	return &typeRegistry{doc: &openapi.Document{}, decls: make(map[string]string)}
+}
+
+// addComponentSchemas declares a named type for every components/schemas
+// entry of doc.
+func (r *typeRegistry) addComponentSchemas(doc *openapi.Document) {
+	r.doc = doc
+	for _, name := range doc.SchemaNames() {
+		r.declare(exportedName(name), doc.Schemas[name])
+	}
+}
+
+// goType returns the Go type expression for a schema. Inline objects are
+// declared as named types using nameHint.
+func (r *typeRegistry) goType(s *openapi.Schema, nameHint string) string {
+	if s == nil {
+		return "any"
+	}
+	if s.IsRef() {
+		return exportedName(s.RefName())
+	}
+	// Go has no union types, so alternatives decode into any
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		return "any"
+	}
+	if len(s.AllOf) > 0 {
+		merged := r.doc.MergeAllOf(s)
+		if merged == nil {
+			return "any"
+		}
+		return r.goType(merged, nameHint)
+	}
+
+	switch s.Type {
+	case "string":
+		if s.Format == "date-time" {
+			r.usesTime = true
+			return "time.Time"
+		}
+		return "string"
+	case "integer":
+		if s.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + r.goType(s.Items, nameHint+"Item")
+	case "object":
+		if len(s.Properties) == 0 {
+			if s.AdditionalProperties != nil {
+				return "map[string]" + r.goType(s.AdditionalProperties, nameHint+"Value")
+			}
+			return "map[string]any"
+		}
+		r.declare(nameHint, s)
+		return nameHint
+	default:
+		return "any"
+	}
+}
+
+// declare registers a named type for the schema unless already declared.
+func (r *typeRegistry) declare(name string, s *openapi.Schema) {
+	if _, ok := r.decls[name]; ok {
+		return
+	}
+	// Reserve the name first so recursive schemas terminate.
+	r.decls[name] = ""
+	if s != nil && len(s.AllOf) > 0 {
+		if merged := r.doc.MergeAllOf(s); merged != nil {
+			s = merged
+		}
+	}
+
+	var sb strings.Builder
+	if s != nil && s.Description != "" {
+		fmt.Fprintf(&sb, "// %s %s\n", name, strings.TrimSpace(s.Description))
+	}
+
+	if s == nil || s.Type != "object" || len(s.Properties) == 0 || s.IsRef() {
+		fmt.Fprintf(&sb, "type %s = %s\n", name, r.goTypeForAlias(s, name))
+		r.decls[name] = sb.String()
+		return
+	}
+
+	required := make(map[string]bool, len(s.Required))
+	for _, req := range s.Required {
+		required[req] = true
+	}
+
+	props := make([]string, 0, len(s.Properties))
+	for prop := range s.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	fmt.Fprintf(&sb, "type %s struct {\n", name)
+	for _, prop := range props {
+		fieldName := exportedName(prop)
+		fieldType := r.goType(s.Properties[prop], name+fieldName)
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&sb, "\t%s %s `json:%q`\n", fieldName, fieldType, tag)
+	}
+	sb.WriteString("}\n")
+
+	r.decls[name] = sb.String()
+}
+
+func (r *typeRegistry) goTypeForAlias(s *openapi.Schema, name string) string {
+	if s != nil && s.Type == "array" {
+		return "[]" + r.goType(s.Items, name+"Item")
+	}
+	if s != nil && s.Type == "object" && len(s.AllOf) == 0 {
+		if s.AdditionalProperties != nil {
+			return "map[string]" + r.goType(s.AdditionalProperties, name+"Value")
+		}
+		return "map[string]any"
+	}
+	return r.goType(s, name+"Value")
+}
+
+func (r *typeRegistry) sortedNames() []string {
+	names := make([]string, 0, len(r.decls))
+	for name := range r.decls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
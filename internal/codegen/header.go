@@ -0,0 +1,51 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package codegen
+
+import "strings"
+
+// CommentStyle is the comment syntax of a generated file's language.
+type CommentStyle int
+
+const (
+	SlashComments CommentStyle = iota // TypeScript and JavaScript
+	HashComments                      // Python, YAML, TOML, shell and ignore files
+	HTMLComments                      // Markdown
+	GoComments                        // Go, whose tools recognize "Code generated ... DO NOT EDIT."
+)
+
+// Header returns the banner, with its newline, that starts every file the
+// compiler owns and overwrites on each compile. Generated files carry no
+// license header: they belong to the project they are generated into. Stamp
+// adds the build provenance below the banner.
+func Header(style CommentStyle) string {
+	switch style {
+	case HashComments:
+		return "# Generated by OpenBoundary - DO NOT EDIT\n"
+	case HTMLComments:
+		return "<!-- Generated by OpenBoundary - DO NOT EDIT. Regenerated from the spec by `bound compile`. -->\n"
+	case GoComments:
+		return "// Code generated by OpenBoundary. DO NOT EDIT.\n"
+	}
+	return "// Generated by OpenBoundary - DO NOT EDIT\n"
+}
+
+// headerStyles are the comment styles of Header, for recognizing banners.
+var headerStyles = []CommentStyle{SlashComments, HashComments, HTMLComments, GoComments}
+
+// HasHeader reports whether content starts with a banner of Header.
+func HasHeader(content []byte) bool {
+	_, ok := headerStyle(content)
+	return ok
+}
+
+// headerStyle returns the comment style of the banner content starts with.
+func headerStyle(content []byte) (CommentStyle, bool) {
+	for _, style := range headerStyles {
+		if strings.HasPrefix(string(content), Header(style)) {
+			return style, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package codegen
+
+import "testing"
+
+func TestHasHeader(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected bool
+	}{
+		{Header(SlashComments) + "export {};\n", true},
+		{Header(HashComments) + "openapi: 3.0.3\n", true},
+		{Header(HTMLComments) + "# API\n", true},
+		{Header(GoComments) + "\npackage api\n", true},
+		{"# Generated by OpenBoundary\n", false},
+		{"export {};\n" + Header(SlashComments), false},
+		{"{}\n", false},
+	}
+
+	for _, tt := range tests {
+		if got := HasHeader([]byte(tt.content)); got != tt.expected {
+			t.Errorf("HasHeader(%q) = %v, expected %v", tt.content, got, tt.expected)
+		}
+	}
+}
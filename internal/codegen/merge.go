@@ -0,0 +1,237 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MergeConflict is a key both the user and the generator changed. The
+// merge keeps the user's value.
+type MergeConflict struct {
+	Key       string // Dotted path, e.g. "dependencies.hono"
+	Current   string // The value in the file, or "" when the user removed it
+	Generated string // The value the spec now generates, or "" when it no longer does
+}
+
+func (c MergeConflict) Error() string {
+	switch {
+	case c.Current == "":
+		return fmt.Sprintf("%s was removed from the file but the spec now generates %s; keeping it removed", c.Key, c.Generated)
+	case c.Generated == "":
+		return fmt.Sprintf("%s was changed to %s but the spec no longer generates it; keeping %s", c.Key, c.Current, c.Current)
+	}
+	return fmt.Sprintf("%s was changed to %s but the spec now generates %s; keeping %s", c.Key, c.Current, c.Generated, c.Current)
+}
+
+// MergeJSON merges user edits to a generated JSON object into its newly
+// generated content. base is what was generated last time, or nil when
+// unknown, and current is the file as the user left it.
+//
+// Objects are merged key by key, at any depth. A key takes the generated
+// value unless the user changed it from base, so user-added keys survive,
+// keys the generator adds or updates are applied, and keys the user removed
+// stay removed. When the user and the generator changed a key differently
+// the user's value is kept and a conflict is reported. Keys keep the file's
+// order; new keys are appended, or sorted in when the file's keys are sorted.
+func MergeJSON(base, generated, current []byte) ([]byte, []MergeConflict, error) {
+	var b, g, c any
+	if len(base) > 0 {
+		if err := json.Unmarshal(base, &b); err != nil {
+			return nil, nil, fmt.Errorf("invalid base: %w", err)
+		}
+	}
+	if err := json.Unmarshal(generated, &g); err != nil {
+		return nil, nil, fmt.Errorf("invalid generated content: %w", err)
+	}
+	if err := json.Unmarshal(current, &c); err != nil {
+		return nil, nil, fmt.Errorf("not valid JSON: %w", err)
+	}
+	if bytes.Equal(current, base) {
+		return generated, nil, nil
+	}
+
+	m := &jsonMerger{}
+	var out bytes.Buffer
+	if err := m.merge(&out, "", b, g, c, current, generated); err != nil {
+		return nil, nil, err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, out.Bytes(), "", "  "); err != nil {
+		return nil, nil, err
+	}
+	if bytes.HasSuffix(current, []byte("\n")) {
+		indented.WriteByte('\n')
+	}
+	return indented.Bytes(), m.conflicts, nil
+}
+
+type jsonMerger struct {
+	conflicts []MergeConflict
+}
+
+// merge writes the compact merge of one value. currentRaw and generatedRaw
+// are the encoded objects, used only to recover key order.
+func (m *jsonMerger) merge(out *bytes.Buffer, path string, base, generated, current any, currentRaw, generatedRaw []byte) error {
+	gObj, gOK := generated.(map[string]any)
+	cObj, cOK := current.(map[string]any)
+	bObj, bOK := base.(map[string]any)
+	if !gOK || !cOK || (base != nil && !bOK) {
+		return writeJSON(out, current)
+	}
+
+	order, err := mergedKeys(currentRaw, generatedRaw)
+	if err != nil {
+		return err
+	}
+	out.WriteByte('{')
+	first := true
+	for _, key := range order {
+		b, inBase := bObj[key]
+		g, inGenerated := gObj[key]
+		c, inCurrent := cObj[key]
+		keyPath := joinKey(path, key)
+
+		var value any
+		switch {
+		case inGenerated && inCurrent && isObject(g) && isObject(c) && (!inBase || isObject(b)):
+			var nested bytes.Buffer
+			if err := m.merge(&nested, keyPath, b, g, c, rawField(currentRaw, key), rawField(generatedRaw, key)); err != nil {
+				return err
+			}
+			value = json.RawMessage(nested.Bytes())
+		case same(c, inCurrent, b, inBase):
+			if !inGenerated {
+				continue
+			}
+			value = g
+		case same(g, inGenerated, b, inBase), same(c, inCurrent, g, inGenerated):
+			if !inCurrent {
+				continue
+			}
+			value = c
+		default:
+			m.conflicts = append(m.conflicts, MergeConflict{Key: keyPath, Current: encode(c, inCurrent), Generated: encode(g, inGenerated)})
+			if !inCurrent {
+				continue
+			}
+			value = c
+		}
+
+		if !first {
+			out.WriteByte(',')
+		}
+		first = false
+		if err := writeJSON(out, key); err != nil {
+			return err
+		}
+		out.WriteByte(':')
+		if err := writeJSON(out, value); err != nil {
+			return err
+		}
+	}
+	out.WriteByte('}')
+	return nil
+}
+
+// mergedKeys returns the keys of the current object in file order followed
+// by the keys only the generated object has. When the current keys are
+// sorted the result is sorted too.
+func mergedKeys(currentRaw, generatedRaw []byte) ([]string, error) {
+	current, err := objectKeys(currentRaw)
+	if err != nil {
+		return nil, err
+	}
+	generated, err := objectKeys(generatedRaw)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(current))
+	for _, key := range current {
+		seen[key] = true
+	}
+	keys := append([]string{}, current...)
+	for _, key := range generated {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+	if sort.StringsAreSorted(current) {
+		sort.Strings(keys)
+	}
+	return keys, nil
+}
+
+// objectKeys returns the keys of an encoded JSON object in order.
+func objectKeys(raw []byte) ([]string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(fields))
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, tok.(string))
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// rawField returns the encoded value of key in an encoded JSON object.
+func rawField(raw []byte, key string) []byte {
+	var fields map[string]json.RawMessage
+	_ = json.Unmarshal(raw, &fields)
+	return fields[key]
+}
+
+func same(a any, aOK bool, b any, bOK bool) bool {
+	if aOK != bOK {
+		return false
+	}
+	return !aOK || encode(a, true) == encode(b, true)
+}
+
+func isObject(v any) bool {
+	_, ok := v.(map[string]any)
+	return ok
+}
+
+func encode(v any, ok bool) string {
+	if !ok {
+		return ""
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + strings.ReplaceAll(key, ".", `\.`)
+}
+
+func writeJSON(out *bytes.Buffer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	out.Truncate(out.Len() - 1) // Encode appends a newline
+	return nil
+}
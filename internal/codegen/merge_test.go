@@ -0,0 +1,139 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package codegen
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergeJSON(t *testing.T) {
+	base := `{"name":"app","scripts":{"dev":"tsx"},"dependencies":{"hono":"^4.0.0","zod":"^3.0.0"}}`
+
+	tests := []struct {
+		name      string
+		base      string
+		generated string
+		current   string
+		want      string
+		conflicts []string
+	}{
+		{
+			name:      "unedited file takes generated content",
+			base:      base,
+			generated: `{"name":"app","dependencies":{"hono":"^4.1.0"}}`,
+			current:   base,
+			want:      `{"name":"app","dependencies":{"hono":"^4.1.0"}}`,
+		},
+		{
+			name:      "user additions survive",
+			base:      base,
+			generated: `{"name":"app","scripts":{"dev":"tsx"},"dependencies":{"hono":"^4.0.0","zod":"^3.0.0","casbin":"^5.0.0"}}`,
+			current:   `{"name":"app","private":true,"scripts":{"dev":"tsx","seed":"tsx seed.ts"},"dependencies":{"hono":"^4.0.0","lodash":"^4.0.0","zod":"^3.0.0"}}`,
+			want:      `{"name":"app","private":true,"scripts":{"dev":"tsx","seed":"tsx seed.ts"},"dependencies":{"casbin":"^5.0.0","hono":"^4.0.0","lodash":"^4.0.0","zod":"^3.0.0"}}`,
+		},
+		{
+			name:      "user change kept when generator is unchanged",
+			base:      base,
+			generated: base,
+			current:   `{"name":"app","scripts":{"dev":"tsx watch"},"dependencies":{"hono":"^4.5.0","zod":"^3.0.0"}}`,
+			want:      `{"name":"app","scripts":{"dev":"tsx watch"},"dependencies":{"hono":"^4.5.0","zod":"^3.0.0"}}`,
+		},
+		{
+			name:      "user removal kept",
+			base:      base,
+			generated: base,
+			current:   `{"name":"app","scripts":{"dev":"tsx"},"dependencies":{"hono":"^4.0.0"}}`,
+			want:      `{"name":"app","scripts":{"dev":"tsx"},"dependencies":{"hono":"^4.0.0"}}`,
+		},
+		{
+			name:      "generator removal applied",
+			base:      base,
+			generated: `{"name":"app","scripts":{"dev":"tsx"},"dependencies":{"hono":"^4.0.0"}}`,
+			current:   `{"name":"app","scripts":{"dev":"tsx","seed":"x"},"dependencies":{"hono":"^4.0.0","zod":"^3.0.0"}}`,
+			want:      `{"name":"app","scripts":{"dev":"tsx","seed":"x"},"dependencies":{"hono":"^4.0.0"}}`,
+		},
+		{
+			name:      "both changed keeps the file and reports a conflict",
+			base:      base,
+			generated: `{"name":"app","scripts":{"dev":"tsx"},"dependencies":{"hono":"^4.2.0","zod":"^3.0.0"}}`,
+			current:   `{"name":"app","scripts":{"dev":"tsx"},"dependencies":{"hono":"^4.5.0","zod":"^3.0.0"}}`,
+			want:      `{"name":"app","scripts":{"dev":"tsx"},"dependencies":{"hono":"^4.5.0","zod":"^3.0.0"}}`,
+			conflicts: []string{"dependencies.hono"},
+		},
+		{
+			name:      "no base keeps the file's values and reports differences",
+			generated: `{"name":"app","version":"0.2.0","dependencies":{"hono":"^4.0.0"}}`,
+			current:   `{"name":"app","version":"0.1.0","dependencies":{"lodash":"^4.0.0"}}`,
+			want:      `{"name":"app","version":"0.1.0","dependencies":{"hono":"^4.0.0","lodash":"^4.0.0"}}`,
+			conflicts: []string{"version"},
+		},
+		{
+			name:      "unsorted keys keep file order",
+			base:      `{"b":1,"a":1}`,
+			generated: `{"b":1,"a":1,"c":1}`,
+			current:   `{"b":1,"a":2}`,
+			want:      `{"b":1,"a":2,"c":1}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var base []byte
+			if tt.base != "" {
+				base = []byte(tt.base)
+			}
+			got, conflicts, err := MergeJSON(base, []byte(tt.generated), []byte(tt.current))
+			if err != nil {
+				t.Fatalf("MergeJSON() error = %v", err)
+			}
+			if compact(t, got) != tt.want {
+				t.Errorf("MergeJSON() = %s, expected %s", compact(t, got), tt.want)
+			}
+			var keys []string
+			for _, c := range conflicts {
+				keys = append(keys, c.Key)
+			}
+			if !reflect.DeepEqual(keys, tt.conflicts) {
+				t.Errorf("conflicts = %v, expected %v", keys, tt.conflicts)
+			}
+		})
+	}
+}
+
+func TestMergeJSON_InvalidCurrent(t *testing.T) {
+	_, _, err := MergeJSON(nil, []byte(`{}`), []byte(`{"name":`))
+	if err == nil {
+		t.Fatal("expected error for invalid JSON")
+	}
+}
+
+func TestMergeConflict_Error(t *testing.T) {
+	tests := []struct {
+		conflict MergeConflict
+		want     string
+	}{
+		{MergeConflict{Key: "version", Current: `"0.1.0"`, Generated: `"0.2.0"`}, `version was changed to "0.1.0" but the spec now generates "0.2.0"; keeping "0.1.0"`},
+		{MergeConflict{Key: "dependencies.hono", Generated: `"^4.2.0"`}, `dependencies.hono was removed from the file but the spec now generates "^4.2.0"; keeping it removed`},
+		{MergeConflict{Key: "scripts.dev", Current: `"tsx"`}, `scripts.dev was changed to "tsx" but the spec no longer generates it; keeping "tsx"`},
+	}
+	for _, tt := range tests {
+		if got := tt.conflict.Error(); got != tt.want {
+			t.Errorf("Error() = %q, expected %q", got, tt.want)
+		}
+	}
+}
+
+func compact(t *testing.T, data []byte) string {
+	t.Helper()
+	var v json.RawMessage
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("invalid JSON %s: %v", data, err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
@@ -0,0 +1,232 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// GenerateComponents produces the output of a ComponentGenerator one
+// component at a time, for implementing Generate.
+func GenerateComponents(g ComponentGenerator, i *ir.IR) (*Output, error) {
+	shared, err := g.GenerateShared(i)
+	if err != nil {
+		return nil, err
+	}
+	outputs := []*Output{shared}
+	for _, comp := range sortedComponents(i) {
+		output, err := g.GenerateComponent(i, comp)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", comp.ID, err)
+		}
+		outputs = append(outputs, output)
+	}
+	return mergeOutputs(outputs)
+}
+
+// GeneratorHooks is told when each generator passed to RunGenerators starts
+// and finishes. Generators run concurrently, so the methods may be called
+// from several goroutines at once.
+type GeneratorHooks interface {
+	GeneratorStart(generator string)
+	// GeneratorEnd reports how long the generator ran, from its first task
+	// starting to its last finishing, and the first error of its tasks.
+	GeneratorEnd(generator string, d time.Duration, err error)
+}
+
+// generatorRun tracks the tasks of one generator for GeneratorHooks.
+type generatorRun struct {
+	name    string
+	start   sync.Once
+	began   time.Time
+	pending int   // Tasks not finished yet, guarded by RunGenerators' mutex
+	err     error // First task error, guarded likewise
+}
+
+// generateTask is one unit of work of RunGenerators: a whole generator, the
+// shared files of a ComponentGenerator, or one of its components.
+type generateTask struct {
+	comp *ir.Component // nil for whole generators and shared files
+	gen  *generatorRun
+	run  func() (*Output, error)
+
+	pending    int // Dependencies not generated yet
+	dependents []*generateTask
+	output     *Output
+	err        error
+}
+
+// RunGenerators runs generators on up to workers goroutines and returns
+// their outputs in the order of gens. Generators implementing
+// ComponentGenerator run as one task per component, in dependency order:
+// a component's files are generated after those of the components it
+// depends on. Other generators run as a single task. The outputs do not
+// depend on scheduling, and the error returned is that of the first
+// failing generator in gens. hooks, if not nil, is told when each
+// generator starts and finishes.
+func RunGenerators(ctx context.Context, i *ir.IR, gens []Generator, workers int, hooks GeneratorHooks) ([]*Output, error) {
+	if cycles := i.DetectCycles(); len(cycles) > 0 {
+		return nil, &ir.CycleError{Cycles: cycles}
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	components := sortedComponents(i)
+
+	var tasks []*generateTask
+	byGen := make([][]*generateTask, len(gens))
+	for n, gen := range gens {
+		cg, ok := gen.(ComponentGenerator)
+		if !ok {
+			byGen[n] = []*generateTask{{run: func() (*Output, error) { return gen.Generate(i) }}}
+			tasks = append(tasks, byGen[n]...)
+			continue
+		}
+		byGen[n] = append(byGen[n], &generateTask{run: func() (*Output, error) { return cg.GenerateShared(i) }})
+		byComponent := make(map[string]*generateTask, len(components))
+		for _, comp := range components {
+			task := &generateTask{comp: comp, run: func() (*Output, error) { return cg.GenerateComponent(i, comp) }}
+			byComponent[comp.ID] = task
+			byGen[n] = append(byGen[n], task)
+		}
+		for _, task := range byGen[n][1:] {
+			for _, dep := range task.comp.Dependencies {
+				if depTask, ok := byComponent[dep.ID]; ok && depTask != task {
+					task.pending++
+					depTask.dependents = append(depTask.dependents, task)
+				}
+			}
+		}
+		tasks = append(tasks, byGen[n]...)
+	}
+	for n, gen := range gens {
+		run := &generatorRun{name: gen.Name(), pending: len(byGen[n])}
+		for _, task := range byGen[n] {
+			task.gen = run
+		}
+	}
+
+	ready := make(chan *generateTask, len(tasks))
+	for _, task := range tasks {
+		if task.pending == 0 {
+			ready <- task
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	remaining := len(tasks)
+	if remaining == 0 {
+		close(ready)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range ready {
+				run := task.gen
+				if hooks != nil {
+					run.start.Do(func() {
+						run.began = time.Now()
+						hooks.GeneratorStart(run.name)
+					})
+				}
+				if ctx != nil && ctx.Err() != nil {
+					task.err = ctx.Err()
+				} else {
+					task.output, task.err = task.run()
+				}
+
+				mu.Lock()
+				run.pending--
+				if task.err != nil && run.err == nil {
+					run.err = task.err
+					if task.comp != nil {
+						run.err = fmt.Errorf("%s: %w", task.comp.ID, task.err)
+					}
+				}
+				finished := run.pending == 0
+				for _, dependent := range task.dependents {
+					dependent.pending--
+					if dependent.pending == 0 {
+						ready <- dependent
+					}
+				}
+				remaining--
+				if remaining == 0 {
+					close(ready)
+				}
+				runErr := run.err
+				mu.Unlock()
+
+				if hooks != nil && finished {
+					hooks.GeneratorEnd(run.name, time.Since(run.began), runErr)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	outputs := make([]*Output, len(gens))
+	for n, gen := range gens {
+		var parts []*Output
+		for _, task := range byGen[n] {
+			if task.err != nil {
+				if task.comp != nil {
+					return nil, fmt.Errorf("generator %s failed: %s: %w", gen.Name(), task.comp.ID, task.err)
+				}
+				return nil, fmt.Errorf("generator %s failed: %w", gen.Name(), task.err)
+			}
+			parts = append(parts, task.output)
+		}
+		output, err := mergeOutputs(parts)
+		if err != nil {
+			return nil, fmt.Errorf("generator %s failed: %w", gen.Name(), err)
+		}
+		outputs[n] = output
+	}
+	return outputs, nil
+}
+
+// mergeOutputs combines the outputs of one generator's tasks. The same path
+// from two tasks is a generator bug and reported as an error.
+func mergeOutputs(parts []*Output) (*Output, error) {
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+	merged := NewOutput()
+	for _, part := range parts {
+		if part == nil {
+			continue
+		}
+		paths := make([]string, 0, len(part.Files))
+		for path := range part.Files {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			if _, ok := merged.Files[path]; ok {
+				return nil, fmt.Errorf("%s is generated more than once", path)
+			}
+			merged.Files[path] = part.Files[path]
+		}
+	}
+	return merged, nil
+}
+
+// sortedComponents returns the IR's components sorted by ID.
+func sortedComponents(i *ir.IR) []*ir.Component {
+	components := make([]*ir.Component, 0, len(i.Components))
+	for _, comp := range i.Components {
+		components = append(components, comp)
+	}
+	sort.Slice(components, func(a, b int) bool { return components[a].ID < components[b].ID })
+	return components
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package codegen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// componentMock generates one file per component and records the order in
+// which components were generated.
+type componentMock struct {
+	mu    sync.Mutex
+	order []string
+	fail  string
+}
+
+func (m *componentMock) Name() string { return "component-mock" }
+
+func (m *componentMock) Generate(i *ir.IR) (*Output, error) { return GenerateComponents(m, i) }
+
+func (m *componentMock) GenerateShared(i *ir.IR) (*Output, error) {
+	output := NewOutput()
+	output.AddFile("index.ts", []byte(fmt.Sprintf("%d components", len(i.Components))))
+	return output, nil
+}
+
+func (m *componentMock) GenerateComponent(i *ir.IR, comp *ir.Component) (*Output, error) {
+	if comp.ID == m.fail {
+		return nil, errors.New("boom")
+	}
+	m.mu.Lock()
+	m.order = append(m.order, comp.ID)
+	m.mu.Unlock()
+	output := NewOutput()
+	output.AddComponentFile(comp.ID+".ts", []byte(comp.ID), comp.ID)
+	return output, nil
+}
+
+// chainIR returns an IR with n components, each depending on the one before.
+func chainIR(n int) *ir.IR {
+	i := &ir.IR{Components: map[string]*ir.Component{}}
+	var prev *ir.Component
+	for k := 0; k < n; k++ {
+		comp := &ir.Component{ID: fmt.Sprintf("usecase.u%03d", n-k)}
+		if prev != nil {
+			comp.Dependencies = []*ir.Component{prev}
+		}
+		i.Components[comp.ID] = comp
+		prev = comp
+	}
+	return i
+}
+
+func TestRunGenerators(t *testing.T) {
+	// given
+	i := chainIR(50)
+	mock := &componentMock{}
+	whole := &mockGenerator{name: "whole", output: NewOutput()}
+	whole.output.AddFile("test.ts", []byte("test"))
+
+	// when
+	outputs, err := RunGenerators(context.Background(), i, []Generator{mock, whole}, 8, nil)
+
+	// then
+	if err != nil {
+		t.Fatalf("RunGenerators() error = %v", err)
+	}
+	if len(outputs) != 2 {
+		t.Fatalf("RunGenerators() returned %d outputs, expected 2", len(outputs))
+	}
+	expected, err := GenerateComponents(&componentMock{}, i)
+	if err != nil {
+		t.Fatalf("GenerateComponents() error = %v", err)
+	}
+	if !reflect.DeepEqual(outputs[0], expected) {
+		t.Errorf("RunGenerators() output differs from sequential generation")
+	}
+	if _, ok := outputs[1].Files["test.ts"]; !ok {
+		t.Errorf("RunGenerators() output of whole generator = %v, expected test.ts", outputs[1].Files)
+	}
+	// Each component depends on the one generated before it
+	for k, id := range mock.order {
+		if want := fmt.Sprintf("usecase.u%03d", 50-k); id != want {
+			t.Fatalf("component %d generated = %s, expected %s (dependencies first)", k, id, want)
+		}
+	}
+}
+
+// recordingHooks records generator events.
+type recordingHooks struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (h *recordingHooks) GeneratorStart(generator string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, "start "+generator)
+}
+
+func (h *recordingHooks) GeneratorEnd(generator string, _ time.Duration, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, fmt.Sprintf("end %s: %v", generator, err))
+}
+
+func TestRunGenerators_Hooks(t *testing.T) {
+	// given
+	hooks := &recordingHooks{}
+	gens := []Generator{&componentMock{fail: "usecase.u002"}, &mockGenerator{name: "whole", output: NewOutput()}}
+
+	// when
+	_, _ = RunGenerators(context.Background(), chainIR(3), gens, 1, hooks)
+
+	// then
+	sort.Strings(hooks.events)
+	expected := []string{
+		"end component-mock: usecase.u002: boom",
+		"end whole: <nil>",
+		"start component-mock",
+		"start whole",
+	}
+	if !reflect.DeepEqual(hooks.events, expected) {
+		t.Errorf("hook events = %v, expected %v", hooks.events, expected)
+	}
+}
+
+func TestRunGenerators_Error(t *testing.T) {
+	// given
+	i := chainIR(3)
+	gens := []Generator{&componentMock{fail: "usecase.u002"}, &mockGenerator{name: "broken", err: errors.New("bad")}}
+
+	// when
+	_, err := RunGenerators(context.Background(), i, gens, 4, nil)
+
+	// then
+	if err == nil || err.Error() != "generator component-mock failed: usecase.u002: boom" {
+		t.Errorf("RunGenerators() error = %v, expected the first generator's error", err)
+	}
+}
+
+func TestRunGenerators_Cancelled(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	_, err := RunGenerators(ctx, chainIR(3), []Generator{&componentMock{}}, 2, nil)
+
+	// then
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RunGenerators() error = %v, expected context.Canceled", err)
+	}
+}
+
+func TestRunGenerators_Cycle(t *testing.T) {
+	// given
+	i := chainIR(2)
+	first, second := i.Components["usecase.u001"], i.Components["usecase.u002"]
+	second.Dependencies = []*ir.Component{first}
+
+	// when
+	_, err := RunGenerators(context.Background(), i, []Generator{&componentMock{}}, 2, nil)
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "dependency cycle") {
+		t.Errorf("RunGenerators() error = %v, expected a dependency cycle", err)
+	}
+}
+
+func TestMergeOutputs_Duplicate(t *testing.T) {
+	a, b := NewOutput(), NewOutput()
+	a.AddFile("index.ts", []byte("a"))
+	b.AddComponentFile("index.ts", []byte("b"), "usecase.u001")
+
+	if _, err := mergeOutputs([]*Output{a, b}); err == nil || err.Error() != "index.ts is generated more than once" {
+		t.Errorf("mergeOutputs() error = %v, expected a duplicate path", err)
+	}
+}
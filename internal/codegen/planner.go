@@ -10,12 +10,19 @@ import (
 
 // Artifact represents a single planned output artifact.
 type Artifact struct {
-	Owner       string
+	Owner       string // The generator, or pipeline stage, that produced the artifact
 	Path        string
 	Content     []byte
 	ComponentID string // The component that this artifact belongs to (empty for shared artifacts)
+	Strategy    string // How the artifact replaces the file in the output; empty means StrategyOverwrite
 }
 
+// Strategies for writing an artifact over the file already in the output.
+const (
+	StrategyOverwrite = "overwrite" // The artifact replaces the file
+	StrategyMerge     = "merge"     // User edits to the file are merged into the artifact; see MergeJSON
+)
+
 // ArtifactConflictError is returned when two generators write the same path.
 type ArtifactConflictError struct {
 	Path          string
@@ -64,6 +71,7 @@ func (p *ArtifactPlanner) Add(owner, path string, content []byte, componentID st
 		Path:        path,
 		Content:     artifactContent,
 		ComponentID: componentID,
+		Strategy:    StrategyOverwrite,
 	}
 
 	return nil
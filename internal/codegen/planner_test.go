@@ -21,6 +21,9 @@ func TestArtifactPlanner_Add(t *testing.T) {
 	if artifacts[0].ComponentID != "comp-1" {
 		t.Errorf("componentID = %q, expected %q", artifacts[0].ComponentID, "comp-1")
 	}
+	if artifacts[0].Strategy != StrategyOverwrite {
+		t.Errorf("strategy = %q, expected %q", artifacts[0].Strategy, StrategyOverwrite)
+	}
 }
 
 func TestArtifactPlanner_Add_Conflict(t *testing.T) {
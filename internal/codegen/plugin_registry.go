@@ -14,6 +14,18 @@ type GeneratorPlugin struct {
 	Name         string
 	NewGenerator func() Generator
 	Supports     []ir.Kind // Empty means always enabled.
+
+	// Reads lists the kinds of the components the generator's output
+	// depends on, for reusing it while they are unchanged. Empty means every
+	// component.
+	Reads []ir.Kind
+
+	// Version changes whenever the generator's output for the same inputs
+	// changes, so that its cached output is not reused. Compiler releases
+	// invalidate every cached output on their own; Version covers the
+	// generators of a release, and codegentest.Versions fails when their
+	// golden output changes without it.
+	Version string
 }
 
 // PluginRegistry stores ordered generator plugins.
@@ -47,20 +59,43 @@ func (r *PluginRegistry) Register(plugin GeneratorPlugin) error {
 	return nil
 }
 
+// Names returns the names of the registered plugins in registration order.
+func (r *PluginRegistry) Names() []string {
+	names := make([]string, len(r.plugins))
+	for n, plugin := range r.plugins {
+		names[n] = plugin.Name
+	}
+	return names
+}
+
+// Plugins returns the registered plugins in registration order.
+func (r *PluginRegistry) Plugins() []GeneratorPlugin {
+	return append([]GeneratorPlugin(nil), r.plugins...)
+}
+
 // GeneratorsForIR returns generators enabled for the provided IR.
 func (r *PluginRegistry) GeneratorsForIR(i *ir.IR) ([]Generator, error) {
-	generators := make([]Generator, 0, len(r.plugins))
-
-	for _, plugin := range r.plugins {
-		if !pluginEnabledForIR(plugin, i) {
-			continue
-		}
+	plugins := r.PluginsForIR(i)
+	generators := make([]Generator, 0, len(plugins))
+	for _, plugin := range plugins {
 		generators = append(generators, plugin.NewGenerator())
 	}
 
 	return generators, nil
 }
 
+// PluginsForIR returns the plugins enabled for the provided IR, in
+// registration order.
+func (r *PluginRegistry) PluginsForIR(i *ir.IR) []GeneratorPlugin {
+	plugins := make([]GeneratorPlugin, 0, len(r.plugins))
+	for _, plugin := range r.plugins {
+		if pluginEnabledForIR(plugin, i) {
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins
+}
+
 func pluginEnabledForIR(plugin GeneratorPlugin, i *ir.IR) bool {
 	if len(plugin.Supports) == 0 {
 		return true
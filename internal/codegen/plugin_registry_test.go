@@ -40,6 +40,9 @@ func TestPluginRegistry_RegisterAndResolve(t *testing.T) {
 	if err := r.Register(serverOnly); err != nil {
 		t.Fatalf("register server-only error = %v", err)
 	}
+	if got := r.Names(); len(got) != 2 || got[0] != "always" || got[1] != "server-only" {
+		t.Errorf("Names() = %v, expected [always server-only]", got)
+	}
 
 	i := &ir.IR{
 		Spec: &parser.Spec{Name: "test", Version: "0.0.1"},
@@ -0,0 +1,33 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+// BuildInfoPath is where the generated service records its build info.
+const BuildInfoPath = "app/buildinfo.py"
+
+// BuildInfoFile returns app/buildinfo.py, which defines BUILD_INFO so a
+// running service can report the spec and compiler that produced it.
+func BuildInfoFile(info codegen.BuildInfo) codegen.Artifact {
+	generatedAt := "None"
+	if !info.GeneratedAt.IsZero() {
+		generatedAt = strconv.Quote(info.GeneratedAt.UTC().Format(time.RFC3339))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(generatedHeader + "\n")
+	sb.WriteString("BUILD_INFO = {\n")
+	sb.WriteString("    \"compiler_version\": " + strconv.Quote(info.Version) + ",\n")
+	sb.WriteString("    \"spec_hash\": " + strconv.Quote("sha256:"+info.SpecHash) + ",\n")
+	sb.WriteString("    \"generated_at\": " + generatedAt + ",\n")
+	sb.WriteString("}\n")
+	return codegen.Artifact{Owner: "python-buildinfo", Path: BuildInfoPath, Content: []byte(sb.String())}
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen/codegentest"
+)
+
+// TestGolden compares the output of every generator with testdata/<generator>/<case>.
+// Run with -update after an intended change and review the diff.
+func TestGolden(t *testing.T) {
+	codegentest.Run(t, NewPluginRegistry)
+}
+
+// TestDeterministic checks that every generator gives byte-identical output
+// for identical input.
+func TestDeterministic(t *testing.T) {
+	codegentest.Deterministic(t, NewPluginRegistry)
+}
+
+// TestVersions checks that a generator's Version changes with its golden
+// output, so that compiles do not reuse output it cached before.
+func TestVersions(t *testing.T) {
+	codegentest.Versions(t, NewPluginRegistry)
+}
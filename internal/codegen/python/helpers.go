@@ -0,0 +1,124 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// effectiveMiddleware mirrors the TypeScript target: nil usecase middleware
+// inherits the server chain, an explicit empty list makes the route public.
+func effectiveMiddleware(uc *ir.Component, server *ir.Component) []string {
+	if uc.Usecase.Middleware == nil {
+		if server != nil && server.HTTPServer != nil {
+			return server.HTTPServer.Middleware
+		}
+		return nil
+	}
+	return uc.Usecase.Middleware
+}
+
+func hasPostgres(i *ir.IR) bool {
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindPostgres && comp.Postgres != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func serverHasPostgres(i *ir.IR, server *ir.Component) bool {
+	for _, depID := range server.HTTPServer.DependsOn {
+		if dep, ok := i.Components[depID]; ok && dep.Kind == ir.KindPostgres {
+			return true
+		}
+	}
+	return false
+}
+
+func middlewareComponents(i *ir.IR) []*ir.Component {
+	var mws []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindMiddleware && comp.Middleware != nil {
+			mws = append(mws, comp)
+		}
+	}
+	sort.Slice(mws, func(a, b int) bool {
+		return mws[a].ID < mws[b].ID
+	})
+	return mws
+}
+
+// fastAPIPath converts a trailing wildcard to a path parameter matching the
+// rest of the path, e.g. /files/* to /files/{rest:path}.
+func fastAPIPath(path string) string {
+	if prefix, ok := strings.CutSuffix(path, "/*"); ok {
+		return prefix + "/{rest:path}"
+	}
+	return path
+}
+
+func hasRequestBody(method string) bool {
+	return method == "POST" || method == "PUT" || method == "PATCH"
+}
+
+func successStatus(method string) int {
+	switch method {
+	case "POST":
+		return 201
+	case "DELETE":
+		return 204
+	default:
+		return 200
+	}
+}
+
+func routerPath(serverID string) string {
+	return fmt.Sprintf("app/routers/%s.py", moduleName(serverID))
+}
+
+func schemasPath(serverID string) string {
+	return fmt.Sprintf("app/schemas/%s.py", moduleName(serverID))
+}
+
+func usecasePath(usecaseID string) string {
+	return fmt.Sprintf("app/usecases/%s.py", moduleName(usecaseID))
+}
+
+func openAPIPath(serverID string) string {
+	return fmt.Sprintf("openapi/%s.yaml", moduleName(serverID))
+}
+
+func serverTestPath(serverID string) string {
+	return fmt.Sprintf("tests/test_%s.py", moduleName(serverID))
+}
+
+var generatedHeader = codegen.Header(codegen.HashComments)
+
+// componentHeader returns comment lines with a component's description,
+// owner and links, each when set, to follow generatedHeader.
+func componentHeader(comp *ir.Component) string {
+	var sb strings.Builder
+	if comp.Description != "" {
+		for _, line := range strings.Split(strings.TrimSpace(comp.Description), "\n") {
+			fmt.Fprintf(&sb, "# %s\n", strings.TrimSpace(line))
+		}
+	}
+	if comp.Owner != "" {
+		fmt.Fprintf(&sb, "# Owner: %s\n", comp.Owner)
+	}
+	for _, link := range comp.Links {
+		title := link.Title
+		if title == "" {
+			title = "See"
+		}
+		fmt.Fprintf(&sb, "# %s: %s\n", title, link.URL)
+	}
+	return sb.String()
+}
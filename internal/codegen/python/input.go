@@ -0,0 +1,121 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// mappedInputField is a keyword argument of a usecase set by input_mapping;
+// an optional one may be None.
+type mappedInputField struct {
+	ir.MappedInput
+}
+
+// mappedInputFields returns the keyword arguments of a usecase's
+// input_mapping, nil when it has none.
+func mappedInputFields(i *ir.IR, uc *ir.Component) []mappedInputField {
+	inputs := i.MappedInputs(uc)
+	if inputs == nil {
+		return nil
+	}
+	fields := make([]mappedInputField, len(inputs))
+	for n, in := range inputs {
+		fields[n] = mappedInputField{in}
+	}
+	return fields
+}
+
+// arg returns the keyword argument name of the field.
+func (f mappedInputField) arg() string {
+	return toSnakeCase(f.Field)
+}
+
+// queryParam returns the name of the route parameter a query field is read
+// into.
+func (f mappedInputField) queryParam() string {
+	return toSnakeCase(f.Name) + "_query"
+}
+
+// pythonType returns the annotation of the argument.
+func (f mappedInputField) pythonType() string {
+	t := "str"
+	switch {
+	case f.Transform == "number":
+		t = "float"
+	case f.Source == ir.InputFromBody && f.Transform == "":
+		return "Any"
+	}
+	if f.Optional {
+		t += " | None"
+	}
+	return t
+}
+
+// expr returns the expression a route reads the argument's value with, the
+// request body being available as the dict payload.
+func (f mappedInputField) expr() string {
+	var raw string
+	switch f.Source {
+	case ir.InputFromPath:
+		raw = toSnakeCase(f.Name)
+	case ir.InputFromQuery:
+		raw = f.queryParam()
+	default:
+		raw = fmt.Sprintf("payload.get(%q)", f.Name)
+	}
+
+	var converted string
+	switch f.Transform {
+	case "trim":
+		converted = raw + ".strip()"
+	case "lowercase":
+		converted = raw + ".lower()"
+	case "uppercase":
+		converted = raw + ".upper()"
+	case "number":
+		converted = "float(" + raw + ")"
+	default:
+		return raw
+	}
+	if f.Optional || f.Source == ir.InputFromBody {
+		return fmt.Sprintf("None if %s is None else %s", raw, converted)
+	}
+	return converted
+}
+
+// mapsQuery reports whether any field of a server's usecases is read from
+// the query string.
+func mapsQuery(i *ir.IR, usecases []*ir.Component) bool {
+	for _, uc := range usecases {
+		for _, f := range mappedInputFields(i, uc) {
+			if f.Source == ir.InputFromQuery {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readsPayload reports whether any field is read from the body.
+func readsPayload(fields []mappedInputField) bool {
+	for _, f := range fields {
+		if f.Source == ir.InputFromBody {
+			return true
+		}
+	}
+	return false
+}
+
+// payloadExpr returns how a route turns the body parameter of type input
+// into a dict keyed by the JSON field names.
+func payloadExpr(input string) string {
+	if strings.HasPrefix(input, "dict[") {
+		return "body"
+	}
+	return "body.model_dump(by_alias=True)"
+}
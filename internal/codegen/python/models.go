@@ -0,0 +1,305 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+// ModelsGenerator generates pydantic models from each server's OpenAPI document.
+type ModelsGenerator struct{}
+
+// NewModelsGenerator creates a new pydantic models generator.
+func NewModelsGenerator() *ModelsGenerator {
+	return &ModelsGenerator{}
+}
+
+// Name returns the generator name.
+func (g *ModelsGenerator) Name() string {
+	return "python-models"
+}
+
+// Generate produces one schemas module per http.server component.
+func (g *ModelsGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+
+	for _, server := range i.HTTPServers() {
+		models := buildServerModels(i, server)
+		output.AddComponentFile(schemasPath(server.ID), []byte(models.render()), server.ID)
+	}
+	output.AddFile("app/schemas/__init__.py", []byte(generatedHeader))
+
+	return output, nil
+}
+
+// operationTypes holds the Python types used by a usecase's route and implementation.
+type operationTypes struct {
+	Input  string // Request body type (empty when the route has no body)
+	Output string // Response type ("None" when the route returns no content)
+}
+
+// serverModels collects pydantic declarations and per-usecase types for a server.
+type serverModels struct {
+	doc        *openapi.Document // Resolves the parts of allOf schemas
+	classes    map[string]string
+	aliases    map[string]string
+	operations map[string]operationTypes
+}
+
+func buildServerModels(i *ir.IR, server *ir.Component) *serverModels {
+	m := &serverModels{
+		doc:        &openapi.Document{},
+		classes:    make(map[string]string),
+		aliases:    make(map[string]string),
+		operations: make(map[string]operationTypes),
+	}
+
+	if doc := server.HTTPServer.ParsedOpenAPI; doc != nil {
+		m.doc = doc
+		names := make([]string, 0, len(doc.Schemas))
+		for name := range doc.Schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			m.declare(toPascalCase(name), doc.Schemas[name])
+		}
+	}
+
+	for _, uc := range i.UsecasesBoundTo(server.ID) {
+		m.operations[uc.ID] = m.operationTypesFor(uc)
+	}
+
+	return m
+}
+
+func (m *serverModels) operationTypesFor(uc *ir.Component) operationTypes {
+	binding := uc.Usecase.Binding
+	op := binding.Operation
+	base := toPascalCase(usecaseFunctionName(uc.ID))
+	if op != nil && op.OperationID != "" {
+		base = toPascalCase(op.OperationID)
+	}
+
+	var types operationTypes
+	// Catch-all routes hand the raw request to the usecase, which answers it
+	if binding.IsCatchAll() {
+		types.Output = "Response"
+		return types
+	}
+	if op == nil {
+		if hasRequestBody(binding.Method) {
+			types.Input = "dict[str, Any]"
+		}
+		types.Output = "Any"
+		if binding.Method == "DELETE" {
+			types.Output = "None"
+		}
+		return types
+	}
+
+	if op.RequestBody != nil {
+		types.Input = "dict[str, Any]"
+		if media, ok := op.RequestBody.Content["application/json"]; ok && media.Schema != nil {
+			types.Input = m.pythonType(media.Schema, base+"Request")
+		}
+	}
+
+	types.Output = "None"
+	if schema := op.SuccessResponseSchema(); schema != nil {
+		types.Output = m.pythonType(schema, base+"Response")
+	}
+
+	return types
+}
+
+// pythonType returns the annotation for a schema, declaring inline objects as classes.
+func (m *serverModels) pythonType(s *openapi.Schema, nameHint string) string {
+	if s == nil {
+		return "Any"
+	}
+	if s.IsRef() {
+		return toPascalCase(s.RefName())
+	}
+	if len(s.AllOf) > 0 {
+		merged := m.doc.MergeAllOf(s)
+		if merged == nil {
+			return "Any"
+		}
+		return m.pythonType(merged, nameHint)
+	}
+	if options := append(append([]*openapi.Schema{}, s.OneOf...), s.AnyOf...); len(options) > 0 {
+		types := make([]string, len(options))
+		for n, option := range options {
+			types[n] = m.pythonType(option, fmt.Sprintf("%sOption%d", nameHint, n+1))
+		}
+		if len(types) == 1 {
+			return types[0]
+		}
+		return fmt.Sprintf("Union[%s]", strings.Join(types, ", "))
+	}
+
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "date-time":
+			return "datetime"
+		case "date":
+			return "date"
+		case "uuid":
+			return "UUID"
+		}
+		return "str"
+	case "integer":
+		return "int"
+	case "number":
+		return "float"
+	case "boolean":
+		return "bool"
+	case "array":
+		return fmt.Sprintf("list[%s]", m.pythonType(s.Items, nameHint+"Item"))
+	case "object":
+		if len(s.Properties) == 0 {
+			if s.AdditionalProperties != nil {
+				return fmt.Sprintf("dict[str, %s]", m.pythonType(s.AdditionalProperties, nameHint+"Value"))
+			}
+			return "dict[str, Any]"
+		}
+		m.declare(nameHint, s)
+		return nameHint
+	default:
+		return "Any"
+	}
+}
+
+func (m *serverModels) declare(name string, s *openapi.Schema) {
+	if _, ok := m.classes[name]; ok {
+		return
+	}
+	if _, ok := m.aliases[name]; ok {
+		return
+	}
+	if s != nil && len(s.AllOf) > 0 {
+		if merged := m.doc.MergeAllOf(s); merged != nil {
+			s = merged
+		}
+	}
+
+	if s == nil || s.IsRef() || s.Type != "object" || len(s.Properties) == 0 {
+		m.aliases[name] = ""
+		m.aliases[name] = fmt.Sprintf("%s = %s\n", name, m.pythonType(s, name+"Value"))
+		return
+	}
+
+	// Reserve the name first so recursive schemas terminate.
+	m.classes[name] = ""
+
+	required := make(map[string]bool, len(s.Required))
+	for _, req := range s.Required {
+		required[req] = true
+	}
+	props := make([]string, 0, len(s.Properties))
+	for prop := range s.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	var fields strings.Builder
+	usesAlias := false
+	for _, prop := range props {
+		fieldName := prop
+		if !isIdentifier(prop) {
+			fieldName = toSnakeCase(prop)
+		}
+		fieldType := m.pythonType(s.Properties[prop], name+toPascalCase(prop))
+
+		var args []string
+		if fieldName != prop {
+			args = append(args, fmt.Sprintf("alias=%q", prop))
+			usesAlias = true
+		}
+		if !required[prop] {
+			fieldType = fmt.Sprintf("Optional[%s]", fieldType)
+			args = append([]string{"default=None"}, args...)
+		}
+
+		switch {
+		case len(args) == 0:
+			fmt.Fprintf(&fields, "    %s: %s\n", fieldName, fieldType)
+		case len(args) == 1 && args[0] == "default=None":
+			fmt.Fprintf(&fields, "    %s: %s = None\n", fieldName, fieldType)
+		default:
+			fmt.Fprintf(&fields, "    %s: %s = Field(%s)\n", fieldName, fieldType, strings.Join(args, ", "))
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "class %s(BaseModel):\n", name)
+	if s.Description != "" {
+		fmt.Fprintf(&sb, "    \"\"\"%s\"\"\"\n\n", strings.TrimSpace(s.Description))
+	}
+	var config []string
+	if usesAlias {
+		config = append(config, "populate_by_name=True")
+	}
+	if s.NoAdditionalProperties {
+		config = append(config, `extra="forbid"`)
+	}
+	if len(config) > 0 {
+		fmt.Fprintf(&sb, "    model_config = ConfigDict(%s)\n\n", strings.Join(config, ", "))
+	}
+	sb.WriteString(fields.String())
+
+	m.classes[name] = sb.String()
+}
+
+func (m *serverModels) render() string {
+	var sb strings.Builder
+
+	sb.WriteString(generatedHeader)
+	sb.WriteString("from __future__ import annotations\n\n")
+	sb.WriteString("from datetime import date, datetime\n")
+	sb.WriteString("from typing import Any, Optional, Union\n")
+	sb.WriteString("from uuid import UUID\n\n")
+	sb.WriteString("from pydantic import BaseModel, ConfigDict, Field\n")
+
+	classNames := sortedKeys(m.classes)
+	for _, name := range classNames {
+		sb.WriteString("\n\n")
+		sb.WriteString(m.classes[name])
+	}
+
+	aliasNames := sortedKeys(m.aliases)
+	if len(aliasNames) > 0 {
+		sb.WriteString("\n\n")
+		for _, name := range aliasNames {
+			sb.WriteString(m.aliases[name])
+		}
+	}
+
+	if len(classNames) > 0 {
+		sb.WriteString("\n\n")
+		sb.WriteString("# Resolve forward references between models.\n")
+		for _, name := range classNames {
+			fmt.Fprintf(&sb, "%s.model_rebuild()\n", name)
+		}
+	}
+
+	return sb.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
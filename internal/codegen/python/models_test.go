@@ -0,0 +1,288 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+const testOpenAPI = `
+openapi: 3.0.3
+info:
+  title: User API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      operationId: createUser
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [email]
+              properties:
+                email:
+                  type: string
+                display-name:
+                  type: string
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /users/{id}:
+    delete:
+      operationId: deleteUser
+      responses:
+        '204':
+          description: No Content
+components:
+  schemas:
+    User:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+          format: uuid
+        created_at:
+          type: string
+          format: date-time
+`
+
+// newTestIR builds an IR with one server, two bound usecases, a postgres
+// dependency, and an auth middleware applied to the server.
+func newTestIR(t *testing.T) *ir.IR {
+	t.Helper()
+
+	doc, err := openapi.NewParser("").ParseBytes([]byte(testOpenAPI))
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+
+	server := &ir.Component{
+		ID:   "http.server.api",
+		Kind: ir.KindHTTPServer,
+		HTTPServer: &ir.HTTPServerSpec{
+			Framework:     "hono",
+			Port:          3000,
+			Middleware:    []string{"middleware.authn"},
+			DependsOn:     []string{"postgres.primary"},
+			ParsedOpenAPI: doc,
+		},
+	}
+	authn := &ir.Component{
+		ID:         "middleware.authn",
+		Kind:       ir.KindMiddleware,
+		Middleware: &ir.MiddlewareSpec{Provider: "better-auth"},
+	}
+	db := &ir.Component{
+		ID:       "postgres.primary",
+		Kind:     ir.KindPostgres,
+		Postgres: &ir.PostgresSpec{Provider: "drizzle"},
+	}
+	createUser := &ir.Component{
+		ID:   "usecase.create-user",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			Goal:       "Create a user",
+			Actor:      "anonymous",
+			Middleware: []string{},
+			Binding: &ir.Binding{
+				ServerID:  "http.server.api",
+				Method:    "POST",
+				Path:      "/users",
+				Operation: doc.Operations["POST:/users"],
+			},
+		},
+	}
+	deleteUser := &ir.Component{
+		ID:   "usecase.delete-user",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			Goal:          "Delete a user",
+			Preconditions: []string{"User exists"},
+			Binding: &ir.Binding{
+				ServerID:  "http.server.api",
+				Method:    "DELETE",
+				Path:      "/users/{id}",
+				Operation: doc.Operations["DELETE:/users/{id}"],
+			},
+		},
+	}
+
+	return &ir.IR{
+		Spec: &parser.Spec{Name: "user-service", Version: "1.2.3"},
+		Components: map[string]*ir.Component{
+			server.ID:     server,
+			authn.ID:      authn,
+			db.ID:         db,
+			createUser.ID: createUser,
+			deleteUser.ID: deleteUser,
+		},
+	}
+}
+
+func TestModelsGenerator_Name(t *testing.T) {
+	// given
+	g := NewModelsGenerator()
+
+	// when
+	name := g.Name()
+
+	// then
+	if name != "python-models" {
+		t.Errorf("Name() = %q, want %q", name, "python-models")
+	}
+}
+
+func TestModelsGenerator_Generate(t *testing.T) {
+	// given
+	g := NewModelsGenerator()
+
+	// when
+	output, err := g.Generate(newTestIR(t))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	file, ok := output.Files["app/schemas/http_server_api.py"]
+	if !ok {
+		t.Fatal("missing app/schemas/http_server_api.py")
+	}
+	if file.ComponentID != "http.server.api" {
+		t.Errorf("ComponentID = %q, want %q", file.ComponentID, "http.server.api")
+	}
+	if _, ok := output.Files["app/schemas/__init__.py"]; !ok {
+		t.Error("missing app/schemas/__init__.py")
+	}
+
+	content := string(file.Content)
+	expected := []string{
+		"from pydantic import BaseModel, ConfigDict, Field",
+		"class User(BaseModel):",
+		"    id: UUID\n",
+		"    created_at: Optional[datetime] = None\n",
+		"class CreateUserRequest(BaseModel):",
+		"    model_config = ConfigDict(populate_by_name=True)",
+		"    email: str\n",
+		`    display_name: Optional[str] = Field(default=None, alias="display-name")`,
+		"User.model_rebuild()",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("schemas module missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestBuildServerModels_OperationTypes(t *testing.T) {
+	// given
+	i := newTestIR(t)
+
+	// when
+	models := buildServerModels(i, i.Components["http.server.api"])
+
+	// then
+	tests := []struct {
+		usecase string
+		want    operationTypes
+	}{
+		{"usecase.create-user", operationTypes{Input: "CreateUserRequest", Output: "User"}},
+		{"usecase.delete-user", operationTypes{Output: "None"}},
+	}
+	for _, tt := range tests {
+		if got := models.operations[tt.usecase]; got != tt.want {
+			t.Errorf("operations[%q] = %+v, want %+v", tt.usecase, got, tt.want)
+		}
+	}
+}
+
+func TestBuildServerModels_WithoutOpenAPI(t *testing.T) {
+	// given
+	i := newTestIR(t)
+	i.Components["http.server.api"].HTTPServer.ParsedOpenAPI = nil
+	for _, comp := range i.Components {
+		if comp.Usecase != nil {
+			comp.Usecase.Binding.Operation = nil
+		}
+	}
+
+	// when
+	models := buildServerModels(i, i.Components["http.server.api"])
+
+	// then
+	if got := models.operations["usecase.create-user"]; got.Input != "dict[str, Any]" || got.Output != "Any" {
+		t.Errorf("create-user types = %+v, want untyped dict input and Any output", got)
+	}
+	if got := models.operations["usecase.delete-user"]; got.Input != "" || got.Output != "None" {
+		t.Errorf("delete-user types = %+v, want no input and None output", got)
+	}
+}
+
+func TestBuildServerModels_Composition(t *testing.T) {
+	// given
+	doc, err := openapi.NewParser("").ParseBytes([]byte(`
+openapi: 3.0.3
+info:
+  title: Pet API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+    Named:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+    Cat:
+      allOf:
+        - $ref: '#/components/schemas/Named'
+        - type: object
+          properties:
+            lives: {type: integer}
+    Dog:
+      type: object
+      additionalProperties: false
+      properties:
+        bark: {type: string}
+    Labels:
+      type: object
+      additionalProperties: {type: string}
+`))
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+	server := &ir.Component{ID: "http.server.api", Kind: ir.KindHTTPServer, HTTPServer: &ir.HTTPServerSpec{ParsedOpenAPI: doc}}
+	i := &ir.IR{Components: map[string]*ir.Component{server.ID: server}}
+
+	// when
+	content := buildServerModels(i, server).render()
+
+	// then
+	expected := []string{
+		"class Cat(BaseModel):\n    lives: Optional[int] = None\n    name: str\n",
+		"class Dog(BaseModel):\n    model_config = ConfigDict(extra=\"forbid\")\n\n",
+		"Labels = dict[str, str]\n",
+		"Pet = Union[Cat, Dog]\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("schemas module missing %q\n%s", want, content)
+		}
+	}
+}
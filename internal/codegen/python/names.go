@@ -0,0 +1,115 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"strings"
+	"unicode"
+)
+
+// pythonKeywords are reserved words that cannot be used as identifiers.
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true,
+	"assert": true, "async": true, "await": true, "break": true, "class": true,
+	"continue": true, "def": true, "del": true, "elif": true, "else": true,
+	"except": true, "finally": true, "for": true, "from": true, "global": true,
+	"if": true, "import": true, "in": true, "is": true, "lambda": true,
+	"nonlocal": true, "not": true, "or": true, "pass": true, "raise": true,
+	"return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+// toSnakeCase converts an identifier (kebab-case, camelCase, dotted) to a
+// valid Python snake_case identifier.
+func toSnakeCase(s string) string {
+	words := splitWords(s)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+
+	name := strings.Join(words, "_")
+	if name == "" {
+		return "value"
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = "_" + name
+	}
+	if pythonKeywords[name] {
+		name += "_"
+	}
+	return name
+}
+
+// toPascalCase converts an identifier to a Python class name.
+func toPascalCase(s string) string {
+	var sb strings.Builder
+	for _, w := range splitWords(s) {
+		runes := []rune(w)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+
+	name := sb.String()
+	if name == "" {
+		return "Model"
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = "M" + name
+	}
+	return name
+}
+
+// moduleName derives a Python module name from a component ID
+// (e.g., "http.server.api" -> "http_server_api").
+func moduleName(componentID string) string {
+	return toSnakeCase(componentID)
+}
+
+// usecaseFunctionName derives the usecase function name from its ID
+// (e.g., "usecase.create-user" -> "create_user").
+func usecaseFunctionName(usecaseID string) string {
+	parts := strings.Split(usecaseID, ".")
+	return toSnakeCase(parts[len(parts)-1])
+}
+
+// isIdentifier reports whether s can be used verbatim as a Python attribute name.
+func isIdentifier(s string) bool {
+	if s == "" || pythonKeywords[s] {
+		return false
+	}
+	for i, r := range s {
+		if r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// splitWords splits an identifier on separators and lower-to-upper case transitions.
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) && unicode.IsLower(runes[i-1]) {
+			flush()
+		}
+		current = append(current, r)
+	}
+	flush()
+
+	return words
+}
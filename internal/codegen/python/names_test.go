@@ -0,0 +1,65 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import "testing"
+
+func TestToSnakeCase(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"http.server.api", "http_server_api"},
+		{"create-user", "create_user"},
+		{"createdAt", "created_at"},
+		{"class", "class_"},
+		{"2fa", "_2fa"},
+		{"", "value"},
+	}
+	for _, tt := range tests {
+		if got := toSnakeCase(tt.input); got != tt.want {
+			t.Errorf("toSnakeCase(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestToPascalCase(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"createUser", "CreateUser"},
+		{"user_profile", "UserProfile"},
+		{"404-error", "M404Error"},
+	}
+	for _, tt := range tests {
+		if got := toPascalCase(tt.input); got != tt.want {
+			t.Errorf("toPascalCase(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestUsecaseFunctionName(t *testing.T) {
+	if got := usecaseFunctionName("usecase.create-user"); got != "create_user" {
+		t.Errorf("usecaseFunctionName() = %q, want %q", got, "create_user")
+	}
+}
+
+func TestIsIdentifier(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"email", true},
+		{"created_at", true},
+		{"display-name", false},
+		{"1st", false},
+		{"from", false},
+	}
+	for _, tt := range tests {
+		if got := isIdentifier(tt.input); got != tt.want {
+			t.Errorf("isIdentifier(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
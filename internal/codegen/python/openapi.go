@@ -0,0 +1,44 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"fmt"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// OpenAPIGenerator writes the OpenAPI document synthesized for each
+// http.server without an openapi file, for clients and docs tooling.
+type OpenAPIGenerator struct{}
+
+// NewOpenAPIGenerator creates a new OpenAPI document generator.
+func NewOpenAPIGenerator() *OpenAPIGenerator {
+	return &OpenAPIGenerator{}
+}
+
+// Name returns the generator name.
+func (g *OpenAPIGenerator) Name() string {
+	return "python-openapi"
+}
+
+// Generate writes openapi/<server>.yaml for every synthesized document.
+func (g *OpenAPIGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+
+	for _, server := range i.HTTPServers() {
+		doc := server.HTTPServer.ParsedOpenAPI
+		if doc == nil || !doc.Synthesized {
+			continue
+		}
+		content, err := doc.Encode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode OpenAPI document of %s: %w", server.ID, err)
+		}
+		output.AddComponentFile(openAPIPath(server.ID), append([]byte(generatedHeader), content...), server.ID)
+	}
+
+	return output, nil
+}
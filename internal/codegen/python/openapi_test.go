@@ -0,0 +1,69 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+func TestOpenAPIGenerator_Name(t *testing.T) {
+	// given
+	g := NewOpenAPIGenerator()
+
+	// when
+	name := g.Name()
+
+	// then
+	if name != "python-openapi" {
+		t.Errorf("Name() = %q, want %q", name, "python-openapi")
+	}
+}
+
+func TestOpenAPIGenerator_Generate(t *testing.T) {
+	tests := []struct {
+		name        string
+		synthesized bool
+		wantFile    bool
+	}{
+		{"writes synthesized document", true, true},
+		{"skips the server's own document", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			i := newTestIR(t)
+			server := i.Components["http.server.api"].HTTPServer
+			if tt.synthesized {
+				server.ParsedOpenAPI = openapi.Synthesize("http.server.api", []openapi.Route{
+					{OperationID: "createUser", Method: "POST", Path: "/users"},
+				})
+			}
+
+			// when
+			output, err := NewOpenAPIGenerator().Generate(i)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			// then
+			file, ok := output.Files["openapi/http_server_api.yaml"]
+			if ok != tt.wantFile {
+				t.Fatalf("openapi/http_server_api.yaml written = %v, want %v", ok, tt.wantFile)
+			}
+			if !ok {
+				return
+			}
+			content := string(file.Content)
+			for _, want := range []string{generatedHeader, "operationId: createUser", "  /users:\n    post:\n"} {
+				if !strings.Contains(content, want) {
+					t.Errorf("document missing %q\n%s", want, content)
+				}
+			}
+		})
+	}
+}
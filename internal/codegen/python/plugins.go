@@ -0,0 +1,60 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// NewPluginRegistry returns the default Python (FastAPI) generator plugin registry.
+func NewPluginRegistry() (*codegen.PluginRegistry, error) {
+	registry := codegen.NewPluginRegistry()
+
+	plugins := []codegen.GeneratorPlugin{
+		{
+			Name:         "python-project",
+			Version:      "1",
+			NewGenerator: func() codegen.Generator { return NewProjectGenerator() },
+		},
+		{
+			Name:         "python-models",
+			Version:      "2",
+			NewGenerator: func() codegen.Generator { return NewModelsGenerator() },
+			Supports:     []ir.Kind{ir.KindHTTPServer},
+		},
+		{
+			Name:         "python-fastapi",
+			Version:      "5",
+			NewGenerator: func() codegen.Generator { return NewFastAPIServerGenerator() },
+			Supports:     []ir.Kind{ir.KindHTTPServer, ir.KindMiddleware, ir.KindPostgres},
+		},
+		{
+			Name:         "python-usecase",
+			Version:      "2",
+			NewGenerator: func() codegen.Generator { return NewUsecaseGenerator() },
+			Supports:     []ir.Kind{ir.KindUsecase},
+		},
+		{
+			Name:         "python-openapi",
+			Version:      "1",
+			NewGenerator: func() codegen.Generator { return NewOpenAPIGenerator() },
+			Supports:     []ir.Kind{ir.KindHTTPServer},
+		},
+		{
+			Name:         "python-tests",
+			Version:      "1",
+			NewGenerator: func() codegen.Generator { return NewTestGenerator() },
+			Supports:     []ir.Kind{ir.KindHTTPServer},
+		},
+	}
+
+	for _, plugin := range plugins {
+		if err := registry.Register(plugin); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
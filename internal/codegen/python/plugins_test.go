@@ -0,0 +1,33 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"testing"
+)
+
+func TestNewPluginRegistry(t *testing.T) {
+	r, err := NewPluginRegistry()
+	if err != nil {
+		t.Fatalf("NewPluginRegistry() error = %v", err)
+	}
+	if r == nil {
+		t.Fatal("NewPluginRegistry() returned nil")
+	}
+}
+
+func TestNewPluginRegistry_GeneratorsForIR(t *testing.T) {
+	r, err := NewPluginRegistry()
+	if err != nil {
+		t.Fatalf("NewPluginRegistry() error = %v", err)
+	}
+
+	gens, err := r.GeneratorsForIR(newTestIR(t))
+	if err != nil {
+		t.Fatalf("GeneratorsForIR() error = %v", err)
+	}
+	if len(gens) != 6 {
+		t.Errorf("GeneratorsForIR() returned %d generators, want 6", len(gens))
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProjectGenerator_Name(t *testing.T) {
+	// given
+	g := NewProjectGenerator()
+
+	// when
+	name := g.Name()
+
+	// then
+	if name != "python-project" {
+		t.Errorf("Name() = %q, want %q", name, "python-project")
+	}
+}
+
+func TestProjectGenerator_Generate(t *testing.T) {
+	// given
+	g := NewProjectGenerator()
+
+	// when
+	output, err := g.Generate(newTestIR(t))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	pyproject := string(output.Files["pyproject.toml"].Content)
+	for _, want := range []string{
+		`name = "user-service"`,
+		`version = "1.2.3"`,
+		`"fastapi>=0.110"`,
+		`"sqlalchemy>=2.0"`,
+		`testpaths = ["tests"]`,
+	} {
+		if !strings.Contains(pyproject, want) {
+			t.Errorf("pyproject.toml missing %q\n%s", want, pyproject)
+		}
+	}
+	if !strings.Contains(string(output.Files[".env.example"].Content), "DATABASE_URL=") {
+		t.Error(".env.example missing DATABASE_URL")
+	}
+	if _, ok := output.Files[".gitignore"]; !ok {
+		t.Error("missing .gitignore")
+	}
+}
+
+func TestProjectGenerator_Generate_WithoutPostgres(t *testing.T) {
+	// given
+	g := NewProjectGenerator()
+	i := newTestIR(t)
+	delete(i.Components, "postgres.primary")
+
+	// when
+	output, err := g.Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	if strings.Contains(string(output.Files["pyproject.toml"].Content), "sqlalchemy") {
+		t.Error("pyproject.toml should not depend on sqlalchemy without postgres")
+	}
+	if strings.Contains(string(output.Files[".env.example"].Content), "DATABASE_URL") {
+		t.Error(".env.example should not contain DATABASE_URL without postgres")
+	}
+}
@@ -0,0 +1,334 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// FastAPIServerGenerator generates FastAPI routers, the app entrypoint, and
+// dependency modules.
+type FastAPIServerGenerator struct{}
+
+// NewFastAPIServerGenerator creates a new FastAPI server generator.
+func NewFastAPIServerGenerator() *FastAPIServerGenerator {
+	return &FastAPIServerGenerator{}
+}
+
+// Name returns the generator name.
+func (g *FastAPIServerGenerator) Name() string {
+	return "python-fastapi"
+}
+
+// Generate produces FastAPI application code from the IR.
+func (g *FastAPIServerGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+
+	for _, server := range i.HTTPServers() {
+		output.AddComponentFile(routerPath(server.ID), []byte(g.generateRouter(i, server)), server.ID)
+	}
+
+	output.AddFile("app/__init__.py", []byte(generatedHeader))
+	output.AddFile("app/routers/__init__.py", []byte(generatedHeader))
+	output.AddFile("app/main.py", []byte(g.generateMain(i)))
+
+	if hasPostgres(i) {
+		output.AddFile("app/db.py", []byte(generatedHeader+fmt.Sprintf(dbModule, i.EnvVar("DATABASE_URL"))))
+	}
+	if mws := middlewareComponents(i); len(mws) > 0 {
+		output.AddFile("app/middleware.py", []byte(g.generateMiddleware(mws)))
+	}
+
+	return output, nil
+}
+
+func (g *FastAPIServerGenerator) generateRouter(i *ir.IR, server *ir.Component) string {
+	var sb strings.Builder
+
+	usecases := i.UsecasesBoundTo(server.ID)
+	models := buildServerModels(i, server)
+	withDB := serverHasPostgres(i, server)
+
+	middlewareUsed := make(map[string]bool)
+	for _, uc := range usecases {
+		for _, mw := range effectiveMiddleware(uc, server) {
+			middlewareUsed[mw] = true
+		}
+	}
+
+	sb.WriteString(generatedHeader)
+	sb.WriteString(componentHeader(server))
+	sb.WriteString("from __future__ import annotations\n\n")
+	catchAll, cached := false, false
+	for _, uc := range usecases {
+		catchAll = catchAll || uc.Usecase.Binding.IsCatchAll()
+		cached = cached || uc.Usecase.Cache != nil
+	}
+	fastapiNames := []string{"APIRouter", "Depends"}
+	if mapsQuery(i, usecases) {
+		fastapiNames = append(fastapiNames, "Query")
+	}
+	if catchAll {
+		fastapiNames = append(fastapiNames, "Request")
+	}
+	if catchAll || cached {
+		fastapiNames = append(fastapiNames, "Response")
+	}
+	fmt.Fprintf(&sb, "from fastapi import %s\n", strings.Join(fastapiNames, ", "))
+	if withDB {
+		sb.WriteString("from sqlalchemy.orm import Session\n")
+	}
+	sb.WriteString("\n")
+	if withDB {
+		sb.WriteString("from app.db import get_session\n")
+	}
+	for _, mw := range middlewareComponents(i) {
+		if middlewareUsed[mw.ID] {
+			fmt.Fprintf(&sb, "from app.middleware import %s\n", toSnakeCase(mw.ID))
+		}
+	}
+	fmt.Fprintf(&sb, "from app.schemas.%s import *  # noqa: F403\n", moduleName(server.ID))
+	for _, uc := range usecases {
+		fmt.Fprintf(&sb, "from app.usecases.%s import %s\n", moduleName(uc.ID), usecaseFunctionName(uc.ID))
+	}
+
+	sb.WriteString("\n")
+	fmt.Fprintf(&sb, "router = APIRouter(tags=[%q])\n", server.ID)
+	for _, uc := range usecases {
+		if uc.Usecase.Binding.Method == ir.MethodAll {
+			sb.WriteString("\nALL_METHODS = [\"GET\", \"POST\", \"PUT\", \"PATCH\", \"DELETE\", \"HEAD\", \"OPTIONS\"]\n")
+			break
+		}
+	}
+
+	for _, uc := range usecases {
+		binding := uc.Usecase.Binding
+		types := models.operations[uc.ID]
+		funcName := usecaseFunctionName(uc.ID)
+
+		var decorator []string
+		decorator = append(decorator, fmt.Sprintf("%q", fastAPIPath(server.HTTPServer.RoutePath(binding.Path))))
+		if binding.Method == ir.MethodAll {
+			decorator = append(decorator, "methods=ALL_METHODS")
+		}
+		if !binding.IsCatchAll() {
+			decorator = append(decorator, fmt.Sprintf("status_code=%d", successStatus(binding.Method)))
+		}
+		if uc.Deprecated {
+			decorator = append(decorator, "deprecated=True")
+		}
+		if mws := effectiveMiddleware(uc, server); len(mws) > 0 {
+			deps := make([]string, 0, len(mws))
+			for _, mw := range mws {
+				deps = append(deps, fmt.Sprintf("Depends(%s)", toSnakeCase(mw)))
+			}
+			decorator = append(decorator, fmt.Sprintf("dependencies=[%s]", strings.Join(deps, ", ")))
+		}
+
+		var params, args []string
+		mapped := mappedInputFields(i, uc)
+		if binding.IsCatchAll() {
+			params = append(params, "request: Request")
+			args = append(args, "request=request")
+		}
+		if uc.Usecase.Cache != nil {
+			params = append(params, "response: Response")
+		}
+		for _, p := range binding.PathParams() {
+			params = append(params, fmt.Sprintf("%s: str", toSnakeCase(p)))
+			if mapped == nil {
+				args = append(args, fmt.Sprintf("%s=%s", toSnakeCase(p), toSnakeCase(p)))
+			}
+		}
+		if types.Input != "" && (mapped == nil || readsPayload(mapped)) {
+			params = append(params, fmt.Sprintf("body: %s", types.Input))
+			if mapped == nil {
+				args = append(args, "body=body")
+			}
+		}
+		// input_mapping reads the usecase's arguments from the request
+		for _, f := range mapped {
+			if f.Source == ir.InputFromQuery {
+				if f.Optional {
+					params = append(params, fmt.Sprintf("%s: str | None = Query(default=None, alias=%q)", f.queryParam(), f.Name))
+				} else {
+					params = append(params, fmt.Sprintf("%s: str = Query(alias=%q)", f.queryParam(), f.Name))
+				}
+			}
+			args = append(args, fmt.Sprintf("%s=%s", f.arg(), f.expr()))
+		}
+		if withDB {
+			params = append(params, "session: Session = Depends(get_session)")
+			args = append(args, "session=session")
+		}
+
+		route := strings.ToLower(binding.Method)
+		if binding.Method == ir.MethodAll {
+			route = "api_route"
+		}
+		fmt.Fprintf(&sb, "\n\n@router.%s(%s)\n", route, strings.Join(decorator, ", "))
+		fmt.Fprintf(&sb, "async def %s_route(%s) -> %s:\n", funcName, strings.Join(params, ", "), types.Output)
+		if uc.Usecase.Goal != "" {
+			fmt.Fprintf(&sb, "    \"\"\"%s\"\"\"\n", uc.Usecase.Goal)
+		}
+		writeCacheHeaders(&sb, uc.Usecase.Cache)
+		if mapped == nil {
+			fmt.Fprintf(&sb, "    return await %s(%s)\n", funcName, strings.Join(args, ", "))
+			continue
+		}
+		if readsPayload(mapped) {
+			fmt.Fprintf(&sb, "    payload = %s\n", payloadExpr(types.Input))
+		}
+		fmt.Fprintf(&sb, "    return await %s(\n", funcName)
+		for _, arg := range args {
+			fmt.Fprintf(&sb, "        %s,\n", arg)
+		}
+		sb.WriteString("    )\n")
+	}
+
+	return sb.String()
+}
+
+// writeCacheHeaders writes how a route sets the caching headers of its cache
+// directive on its response, if it has one.
+func writeCacheHeaders(sb *strings.Builder, cache *ir.CacheSpec) {
+	if cache == nil {
+		return
+	}
+	fmt.Fprintf(sb, "    response.headers[\"Cache-Control\"] = %q\n", cache.CacheControl())
+	if len(cache.Vary) > 0 {
+		fmt.Fprintf(sb, "    response.headers[\"Vary\"] = %q\n", strings.Join(cache.Vary, ", "))
+	}
+}
+
+func (g *FastAPIServerGenerator) generateMain(i *ir.IR) string {
+	var sb strings.Builder
+
+	servers := i.HTTPServers()
+	title := "generated-api"
+	version := "0.0.1"
+	if i.Spec != nil {
+		if i.Spec.Name != "" {
+			title = i.Spec.Name
+		}
+		if i.Spec.Version != "" {
+			version = i.Spec.Version
+		}
+	}
+
+	deprecated := false
+	for _, server := range servers {
+		deprecated = deprecated || len(i.DeprecatedComponentsRunBy(server)) > 0
+	}
+
+	sb.WriteString(generatedHeader)
+	if deprecated {
+		sb.WriteString("import warnings\n\n")
+	}
+	sb.WriteString("from fastapi import FastAPI\n")
+	if len(servers) > 0 {
+		sb.WriteString("\n")
+		mods := make([]string, 0, len(servers))
+		for _, server := range servers {
+			mods = append(mods, moduleName(server.ID))
+		}
+		fmt.Fprintf(&sb, "from app.routers import %s\n", strings.Join(mods, ", "))
+	}
+
+	for _, server := range servers {
+		mod := moduleName(server.ID)
+		fmt.Fprintf(&sb, "\n\ndef create_%s_app() -> FastAPI:\n", mod)
+		fmt.Fprintf(&sb, "    \"\"\"Creates the %s FastAPI application.\"\"\"\n", server.ID)
+		fmt.Fprintf(&sb, "    app = FastAPI(title=%q, version=%q)\n", title, version)
+		// DeprecationWarning shows in development mode (python -X dev) and
+		// under pytest, not in production.
+		for _, comp := range i.DeprecatedComponentsRunBy(server) {
+			fmt.Fprintf(&sb, "    warnings.warn(%q, DeprecationWarning, stacklevel=2)\n", comp.DeprecationNotice())
+		}
+		sb.WriteString("\n")
+		fmt.Fprintf(&sb, "    @app.get(%q)\n", server.HTTPServer.RoutePath("/health"))
+		sb.WriteString("    async def health() -> dict[str, str]:\n")
+		sb.WriteString("        return {\"status\": \"ok\"}\n\n")
+		fmt.Fprintf(&sb, "    app.include_router(%s.router)\n", mod)
+		sb.WriteString("    return app\n")
+	}
+
+	if len(servers) == 0 {
+		return sb.String()
+	}
+
+	sb.WriteString("\n\n")
+	for _, server := range servers {
+		mod := moduleName(server.ID)
+		fmt.Fprintf(&sb, "%s_app = create_%s_app()\n", mod, mod)
+	}
+
+	first := servers[0]
+	port := first.HTTPServer.Port
+	if port == 0 {
+		port = 8000
+	}
+	fmt.Fprintf(&sb, "\n# Default ASGI app (uvicorn app.main:app). Other servers are exposed as\n")
+	sb.WriteString("# app.main:<server>_app and can be started on their own ports.\n")
+	fmt.Fprintf(&sb, "app = %s_app\n", moduleName(first.ID))
+	sb.WriteString("\nif __name__ == \"__main__\":\n")
+	sb.WriteString("    import uvicorn\n\n")
+	fmt.Fprintf(&sb, "    uvicorn.run(app, host=\"0.0.0.0\", port=%d)\n", port)
+
+	return sb.String()
+}
+
+func (g *FastAPIServerGenerator) generateMiddleware(mws []*ir.Component) string {
+	var sb strings.Builder
+
+	sb.WriteString(generatedHeader)
+	sb.WriteString("# Middleware components are exposed as FastAPI dependencies.\n")
+	sb.WriteString("from fastapi import HTTPException, Request\n")
+
+	for _, mw := range mws {
+		fmt.Fprintf(&sb, "\n\nasync def %s(request: Request) -> None:\n", toSnakeCase(mw.ID))
+		if chain := mw.Middleware.Chain; len(chain) > 0 {
+			fmt.Fprintf(&sb, "    \"\"\"Middleware chain (%s): runs %s in order.\"\"\"\n", mw.ID, strings.Join(chain, ", then "))
+			for _, member := range chain {
+				fmt.Fprintf(&sb, "    await %s(request)\n", toSnakeCase(member))
+			}
+			continue
+		}
+		fmt.Fprintf(&sb, "    \"\"\"%s middleware (%s).\n\n", mw.Middleware.Provider, mw.ID)
+		sb.WriteString("    The provider has no Python runtime, so the routes it guards refuse\n")
+		sb.WriteString("    every request rather than serve them unchecked.\n")
+		sb.WriteString("    \"\"\"\n")
+		fmt.Fprintf(&sb, "    raise HTTPException(status_code=501, detail=\"%s middleware is not supported by the python target\")\n", mw.Middleware.Provider)
+	}
+
+	return sb.String()
+}
+
+// dbModule is the session factory; %[1]s is the connection string variable.
+const dbModule = `import os
+from collections.abc import Iterator
+
+from sqlalchemy import create_engine
+from sqlalchemy.orm import Session, sessionmaker
+
+DATABASE_URL = os.environ.get("%[1]s", "")
+
+engine = create_engine(DATABASE_URL, pool_pre_ping=True) if DATABASE_URL else None
+SessionLocal = sessionmaker(bind=engine, autoflush=False)
+
+
+def get_session() -> Iterator[Session]:
+    """Yields a database session per request."""
+    if engine is None:
+        raise RuntimeError("%[1]s environment variable is required")
+    session = SessionLocal()
+    try:
+        yield session
+    finally:
+        session.close()
+`
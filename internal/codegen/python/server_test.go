@@ -0,0 +1,367 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+func TestFastAPIServerGenerator_Name(t *testing.T) {
+	// given
+	g := NewFastAPIServerGenerator()
+
+	// when
+	name := g.Name()
+
+	// then
+	if name != "python-fastapi" {
+		t.Errorf("Name() = %q, want %q", name, "python-fastapi")
+	}
+}
+
+func TestFastAPIServerGenerator_Generate_Files(t *testing.T) {
+	// given
+	g := NewFastAPIServerGenerator()
+
+	// when
+	output, err := g.Generate(newTestIR(t))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	for _, path := range []string{
+		"app/__init__.py",
+		"app/main.py",
+		"app/db.py",
+		"app/middleware.py",
+		"app/routers/__init__.py",
+		"app/routers/http_server_api.py",
+	} {
+		if _, ok := output.Files[path]; !ok {
+			t.Errorf("missing %s", path)
+		}
+	}
+}
+
+func TestFastAPIServerGenerator_Generate_NamespacedEnv(t *testing.T) {
+	// given
+	i := newTestIR(t)
+	i.Spec.Env = &parser.Env{Namespaced: true}
+
+	// when
+	output, err := NewFastAPIServerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	db := string(output.Files["app/db.py"].Content)
+	for _, want := range []string{
+		`DATABASE_URL = os.environ.get("USER_SERVICE_DATABASE_URL", "")`,
+		`RuntimeError("USER_SERVICE_DATABASE_URL environment variable is required")`,
+	} {
+		if !strings.Contains(db, want) {
+			t.Errorf("app/db.py missing %q\n%s", want, db)
+		}
+	}
+}
+
+func TestFastAPIServerGenerator_Generate_ComponentMetadata(t *testing.T) {
+	// given
+	i := newTestIR(t)
+	server := i.Components["http.server.api"]
+	server.Description = "Public API"
+	server.Owner = "team-platform"
+	server.Links = []parser.Link{{Title: "Runbook", URL: "https://example.com/rb"}}
+
+	// when
+	output, err := NewFastAPIServerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	router := string(output.Files["app/routers/http_server_api.py"].Content)
+	want := "# Generated by OpenBoundary - DO NOT EDIT\n# Public API\n# Owner: team-platform\n# Runbook: https://example.com/rb\nfrom __future__"
+	if !strings.HasPrefix(router, want) {
+		t.Errorf("router header = %q, expected prefix %q", router[:min(len(router), len(want))], want)
+	}
+}
+
+func TestFastAPIServerGenerator_Generate_Router(t *testing.T) {
+	// given
+	g := NewFastAPIServerGenerator()
+
+	// when
+	output, err := g.Generate(newTestIR(t))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["app/routers/http_server_api.py"].Content)
+
+	// then
+	expected := []string{
+		"from app.db import get_session",
+		"from app.middleware import middleware_authn",
+		"from app.usecases.usecase_create_user import create_user",
+		`@router.post("/users", status_code=201)`,
+		"async def create_user_route(body: CreateUserRequest, session: Session = Depends(get_session)) -> User:",
+		"return await create_user(body=body, session=session)",
+		`@router.delete("/users/{id}", status_code=204, dependencies=[Depends(middleware_authn)])`,
+		"async def delete_user_route(id: str, session: Session = Depends(get_session)) -> None:",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("router missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestFastAPIServerGenerator_Generate_BasePath(t *testing.T) {
+	// given
+	i := newTestIR(t)
+	i.Components["http.server.api"].HTTPServer.BasePath = "/api/v1"
+
+	// when
+	output, err := NewFastAPIServerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	router := string(output.Files["app/routers/http_server_api.py"].Content)
+	if want := `@router.post("/api/v1/users", status_code=201)`; !strings.Contains(router, want) {
+		t.Errorf("router missing %q\n%s", want, router)
+	}
+	main := string(output.Files["app/main.py"].Content)
+	if want := `@app.get("/api/v1/health")`; !strings.Contains(main, want) {
+		t.Errorf("main.py missing %q\n%s", want, main)
+	}
+}
+
+func TestFastAPIServerGenerator_Generate_CatchAll(t *testing.T) {
+	// given
+	i := newTestIR(t)
+	i.Components["usecase.proxy"] = &ir.Component{
+		ID:   "usecase.proxy",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			Goal:       "Proxy requests",
+			Middleware: []string{},
+			Binding:    &ir.Binding{ServerID: "http.server.api", Method: ir.MethodAll, Path: "/proxy/*"},
+		},
+	}
+
+	// when
+	output, err := NewFastAPIServerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	router := string(output.Files["app/routers/http_server_api.py"].Content)
+	for _, want := range []string{
+		"from fastapi import APIRouter, Depends, Request, Response",
+		`ALL_METHODS = ["GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"]`,
+		`@router.api_route("/proxy/{rest:path}", methods=ALL_METHODS)`,
+		"async def proxy_route(request: Request, session: Session = Depends(get_session)) -> Response:",
+		"return await proxy(request=request, session=session)",
+	} {
+		if !strings.Contains(router, want) {
+			t.Errorf("router missing %q\n%s", want, router)
+		}
+	}
+}
+
+func TestFastAPIServerGenerator_Generate_Main(t *testing.T) {
+	// given
+	g := NewFastAPIServerGenerator()
+
+	// when
+	output, err := g.Generate(newTestIR(t))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["app/main.py"].Content)
+
+	// then
+	expected := []string{
+		"from app.routers import http_server_api",
+		"def create_http_server_api_app() -> FastAPI:",
+		`app = FastAPI(title="user-service", version="1.2.3")`,
+		`@app.get("/health")`,
+		"app = http_server_api_app",
+		`uvicorn.run(app, host="0.0.0.0", port=3000)`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("main.py missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestFastAPIServerGenerator_Generate_Deprecated(t *testing.T) {
+	// given
+	i := newTestIR(t)
+	i.Components["usecase.delete-user"].Deprecated = true
+	i.Components["postgres.primary"].Deprecated = true
+	i.Components["postgres.primary"].Replacement = "postgres.users"
+
+	// when
+	output, err := NewFastAPIServerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	main := string(output.Files["app/main.py"].Content)
+	for _, want := range []string{
+		"import warnings\n\nfrom fastapi import FastAPI\n",
+		"    app = FastAPI(title=\"user-service\", version=\"1.2.3\")\n" +
+			"    warnings.warn(\"postgres.primary is deprecated; use postgres.users instead\", DeprecationWarning, stacklevel=2)\n" +
+			"    warnings.warn(\"usecase.delete-user is deprecated\", DeprecationWarning, stacklevel=2)\n\n",
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.py missing %q\n%s", want, main)
+		}
+	}
+	router := string(output.Files["app/routers/http_server_api.py"].Content)
+	if want := `@router.delete("/users/{id}", status_code=204, deprecated=True, `; !strings.Contains(router, want) {
+		t.Errorf("router missing %q\n%s", want, router)
+	}
+}
+
+func TestFastAPIServerGenerator_Generate_Cache(t *testing.T) {
+	// given
+	i := newTestIR(t)
+	i.Components["usecase.list-users"] = &ir.Component{
+		ID:   "usecase.list-users",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			Goal:    "List users",
+			Binding: &ir.Binding{ServerID: "http.server.api", Method: "GET", Path: "/users"},
+			Cache:   &ir.CacheSpec{TTL: 300, Vary: []string{"Accept-Language"}, Visibility: ir.CachePublic},
+		},
+	}
+
+	// when
+	output, err := NewFastAPIServerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	router := string(output.Files["app/routers/http_server_api.py"].Content)
+	for _, want := range []string{
+		"from fastapi import APIRouter, Depends, Response\n",
+		"async def list_users_route(response: Response, session: Session = Depends(get_session))",
+		"    \"\"\"List users\"\"\"\n" +
+			"    response.headers[\"Cache-Control\"] = \"public, max-age=300\"\n" +
+			"    response.headers[\"Vary\"] = \"Accept-Language\"\n" +
+			"    return await list_users(session=session)\n",
+	} {
+		if !strings.Contains(router, want) {
+			t.Errorf("router missing %q\n%s", want, router)
+		}
+	}
+	if strings.Contains(router, "def create_user_route(response") {
+		t.Errorf("router sets cache headers on an uncached route\n%s", router)
+	}
+}
+
+func TestFastAPIServerGenerator_Generate_WithoutPostgres(t *testing.T) {
+	// given
+	g := NewFastAPIServerGenerator()
+	i := newTestIR(t)
+	delete(i.Components, "postgres.primary")
+	i.Components["http.server.api"].HTTPServer.DependsOn = nil
+
+	// when
+	output, err := g.Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	if _, ok := output.Files["app/db.py"]; ok {
+		t.Error("app/db.py should not be generated without postgres")
+	}
+	if strings.Contains(string(output.Files["app/routers/http_server_api.py"].Content), "get_session") {
+		t.Error("router should not depend on get_session without postgres")
+	}
+}
+
+func TestFastAPIServerGenerator_Generate_MiddlewareChain(t *testing.T) {
+	// given
+	i := newTestIR(t)
+	i.Components["middleware.authn"].Middleware = &ir.MiddlewareSpec{Chain: []string{"middleware.authn.better-auth", "middleware.authn.casbin"}}
+	i.Components["middleware.authn.better-auth"] = &ir.Component{
+		ID: "middleware.authn.better-auth", Kind: ir.KindMiddleware, Middleware: &ir.MiddlewareSpec{Provider: "better-auth"},
+	}
+	i.Components["middleware.authn.casbin"] = &ir.Component{
+		ID: "middleware.authn.casbin", Kind: ir.KindMiddleware, Middleware: &ir.MiddlewareSpec{Provider: "casbin"},
+	}
+
+	// when
+	output, err := NewFastAPIServerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	middleware := string(output.Files["app/middleware.py"].Content)
+	want := "async def middleware_authn(request: Request) -> None:\n" +
+		"    \"\"\"Middleware chain (middleware.authn): runs middleware.authn.better-auth, then middleware.authn.casbin in order.\"\"\"\n" +
+		"    await middleware_authn_better_auth(request)\n" +
+		"    await middleware_authn_casbin(request)\n"
+	if !strings.Contains(middleware, want) {
+		t.Errorf("middleware.py missing %q\n%s", want, middleware)
+	}
+}
+
+// withInputMapping maps the input of both usecases of newTestIR.
+func withInputMapping(i *ir.IR) *ir.IR {
+	i.Components["usecase.create-user"].Usecase.InputMapping = []ir.InputMapping{
+		{Field: "displayName", Source: ir.InputFromBody, Name: "display-name"},
+		{Field: "email", Source: ir.InputFromBody, Name: "email", Transform: "lowercase"},
+		{Field: "invite", Source: ir.InputFromQuery, Name: "invite"},
+	}
+	i.Components["usecase.delete-user"].Usecase.InputMapping = []ir.InputMapping{
+		{Field: "userId", Source: ir.InputFromPath, Name: "id"},
+	}
+	return i
+}
+
+func TestFastAPIServerGenerator_Generate_InputMapping(t *testing.T) {
+	// given
+	i := withInputMapping(newTestIR(t))
+
+	// when
+	output, err := NewFastAPIServerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	router := string(output.Files["app/routers/http_server_api.py"].Content)
+	for _, want := range []string{
+		"from fastapi import APIRouter, Depends, Query\n",
+		`invite_query: str | None = Query(default=None, alias="invite")`,
+		"    payload = body.model_dump(by_alias=True)\n" +
+			"    return await create_user(\n" +
+			"        display_name=payload.get(\"display-name\"),\n" +
+			"        email=None if payload.get(\"email\") is None else payload.get(\"email\").lower(),\n" +
+			"        invite=invite_query,\n",
+		"    return await delete_user(\n        user_id=id,\n",
+	} {
+		if !strings.Contains(router, want) {
+			t.Errorf("router missing %q\n%s", want, router)
+		}
+	}
+}
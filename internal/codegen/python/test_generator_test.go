@@ -0,0 +1,81 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+func TestTestGenerator_Name(t *testing.T) {
+	// given
+	g := NewTestGenerator()
+
+	// when
+	name := g.Name()
+
+	// then
+	if name != "python-tests" {
+		t.Errorf("Name() = %q, want %q", name, "python-tests")
+	}
+}
+
+func TestTestGenerator_Generate(t *testing.T) {
+	// given
+	g := NewTestGenerator()
+
+	// when
+	output, err := g.Generate(newTestIR(t))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	file, ok := output.Files["tests/test_http_server_api.py"]
+	if !ok {
+		t.Fatal("missing tests/test_http_server_api.py")
+	}
+	content := string(file.Content)
+	expected := []string{
+		"from app.main import create_http_server_api_app",
+		"app.dependency_overrides[get_session] = lambda: MagicMock()",
+		"TestClient(app, raise_server_exceptions=False)",
+		"def test_health(client: TestClient) -> None:",
+		`response = client.request("POST", "/users", json={})`,
+		`response = client.request("DELETE", "/users/test-id")`,
+		"assert response.status_code != 404",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("test module missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestTestGenerator_Generate_UsecaseTesting(t *testing.T) {
+	// given
+	i := newTestIR(t)
+	create := i.Components["usecase.create-user"].Usecase
+	create.Testing = &ir.TestingSpec{Unit: true, E2E: ir.E2EFull}
+	create.AcceptanceCriteria = []string{"User record is created"}
+	i.Components["usecase.delete-user"].Usecase.Testing = &ir.TestingSpec{Unit: true, E2E: ir.E2ESkip}
+
+	// when
+	output, err := NewTestGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["tests/test_http_server_api.py"].Content)
+	want := "@pytest.mark.skip(reason=\"AC-usecase.create-user-1: User record is created\")\ndef test_create_user_criterion_1(client: TestClient) -> None:\n    ...\n"
+	if !strings.Contains(content, want) {
+		t.Errorf("test module missing %q\n%s", want, content)
+	}
+	if strings.Contains(content, "/users/test-id") {
+		t.Errorf("test module tests usecase.delete-user, which sets e2e: skip\n%s", content)
+	}
+}
@@ -0,0 +1,22 @@
+# Generated by OpenBoundary - DO NOT EDIT
+import os
+from collections.abc import Iterator
+
+from sqlalchemy import create_engine
+from sqlalchemy.orm import Session, sessionmaker
+
+DATABASE_URL = os.environ.get("DATABASE_URL", "")
+
+engine = create_engine(DATABASE_URL, pool_pre_ping=True) if DATABASE_URL else None
+SessionLocal = sessionmaker(bind=engine, autoflush=False)
+
+
+def get_session() -> Iterator[Session]:
+    """Yields a database session per request."""
+    if engine is None:
+        raise RuntimeError("DATABASE_URL environment variable is required")
+    session = SessionLocal()
+    try:
+        yield session
+    finally:
+        session.close()
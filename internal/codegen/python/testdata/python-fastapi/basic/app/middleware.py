@@ -0,0 +1,21 @@
+# Generated by OpenBoundary - DO NOT EDIT
+# Middleware components are exposed as FastAPI dependencies.
+from fastapi import HTTPException, Request
+
+
+async def middleware_authn(request: Request) -> None:
+    """better-auth middleware (middleware.authn).
+
+    The provider has no Python runtime, so the routes it guards refuse
+    every request rather than serve them unchecked.
+    """
+    raise HTTPException(status_code=501, detail="better-auth middleware is not supported by the python target")
+
+
+async def middleware_authz(request: Request) -> None:
+    """casbin middleware (middleware.authz).
+
+    The provider has no Python runtime, so the routes it guards refuse
+    every request rather than serve them unchecked.
+    """
+    raise HTTPException(status_code=501, detail="casbin middleware is not supported by the python target")
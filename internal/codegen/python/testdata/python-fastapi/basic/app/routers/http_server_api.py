@@ -0,0 +1,40 @@
+# Generated by OpenBoundary - DO NOT EDIT
+from __future__ import annotations
+
+from fastapi import APIRouter, Depends
+from sqlalchemy.orm import Session
+
+from app.db import get_session
+from app.middleware import middleware_authn
+from app.middleware import middleware_authz
+from app.schemas.http_server_api import *  # noqa: F403
+from app.usecases.usecase_create_user import create_user
+from app.usecases.usecase_delete_user import delete_user
+from app.usecases.usecase_get_user import get_user
+from app.usecases.usecase_list_users import list_users
+
+router = APIRouter(tags=["http.server.api"])
+
+
+@router.post("/users", status_code=201)
+async def create_user_route(session: Session = Depends(get_session)) -> None:
+    """Register a new user account in the system"""
+    return await create_user(session=session)
+
+
+@router.delete("/users/{id}", status_code=204, dependencies=[Depends(middleware_authn), Depends(middleware_authz)])
+async def delete_user_route(id: str, session: Session = Depends(get_session)) -> None:
+    """Remove a user account from the system"""
+    return await delete_user(id=id, session=session)
+
+
+@router.get("/users/{id}", status_code=200, dependencies=[Depends(middleware_authn), Depends(middleware_authz)])
+async def get_user_route(id: str, session: Session = Depends(get_session)) -> None:
+    """Retrieve a user's profile information"""
+    return await get_user(id=id, session=session)
+
+
+@router.get("/users", status_code=200, dependencies=[Depends(middleware_authn), Depends(middleware_authz)])
+async def list_users_route(session: Session = Depends(get_session)) -> None:
+    """List all users with pagination"""
+    return await list_users(session=session)
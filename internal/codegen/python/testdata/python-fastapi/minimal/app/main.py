@@ -0,0 +1,28 @@
+# Generated by OpenBoundary - DO NOT EDIT
+from fastapi import FastAPI
+
+from app.routers import http_server_api
+
+
+def create_http_server_api_app() -> FastAPI:
+    """Creates the http.server.api FastAPI application."""
+    app = FastAPI(title="minimal-api", version="0.1.0")
+
+    @app.get("/health")
+    async def health() -> dict[str, str]:
+        return {"status": "ok"}
+
+    app.include_router(http_server_api.router)
+    return app
+
+
+http_server_api_app = create_http_server_api_app()
+
+# Default ASGI app (uvicorn app.main:app). Other servers are exposed as
+# app.main:<server>_app and can be started on their own ports.
+app = http_server_api_app
+
+if __name__ == "__main__":
+    import uvicorn
+
+    uvicorn.run(app, host="0.0.0.0", port=8080)
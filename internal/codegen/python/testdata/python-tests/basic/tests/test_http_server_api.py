@@ -0,0 +1,46 @@
+# Generated by OpenBoundary - DO NOT EDIT
+from unittest.mock import MagicMock
+
+import pytest
+from fastapi.testclient import TestClient
+
+from app.db import get_session
+from app.main import create_http_server_api_app
+
+
+@pytest.fixture
+def client() -> TestClient:
+    app = create_http_server_api_app()
+    app.dependency_overrides[get_session] = lambda: MagicMock()
+    # Unimplemented usecases raise; surface them as 500 responses instead.
+    return TestClient(app, raise_server_exceptions=False)
+
+
+def test_health(client: TestClient) -> None:
+    response = client.get("/health")
+    assert response.status_code == 200
+    assert response.json() == {"status": "ok"}
+
+
+def test_create_user_route_exists(client: TestClient) -> None:
+    """POST /users is routed to usecase.create-user."""
+    response = client.request("POST", "/users", json={})
+    assert response.status_code != 404
+
+
+def test_delete_user_route_exists(client: TestClient) -> None:
+    """DELETE /users/{id} is routed to usecase.delete-user."""
+    response = client.request("DELETE", "/users/test-id")
+    assert response.status_code != 404
+
+
+def test_get_user_route_exists(client: TestClient) -> None:
+    """GET /users/{id} is routed to usecase.get-user."""
+    response = client.request("GET", "/users/test-id")
+    assert response.status_code != 404
+
+
+def test_list_users_route_exists(client: TestClient) -> None:
+    """GET /users is routed to usecase.list-users."""
+    response = client.request("GET", "/users")
+    assert response.status_code != 404
@@ -0,0 +1,18 @@
+# Generated by OpenBoundary - DO NOT EDIT
+import pytest
+from fastapi.testclient import TestClient
+
+from app.main import create_http_server_api_app
+
+
+@pytest.fixture
+def client() -> TestClient:
+    app = create_http_server_api_app()
+    # Unimplemented usecases raise; surface them as 500 responses instead.
+    return TestClient(app, raise_server_exceptions=False)
+
+
+def test_health(client: TestClient) -> None:
+    response = client.get("/health")
+    assert response.status_code == 200
+    assert response.json() == {"status": "ok"}
@@ -0,0 +1,29 @@
+# Generated by OpenBoundary - DO NOT EDIT
+from __future__ import annotations
+
+from typing import Any  # noqa: F401
+
+from sqlalchemy.orm import Session
+
+from app.schemas.http_server_api import *  # noqa: F403
+
+
+async def create_user(*, session: Session) -> None:
+    """Register a new user account in the system
+
+    Actor: anonymous
+
+    Preconditions:
+    - Email address is not already registered
+
+    Acceptance criteria:
+    - User record is created with provided email
+    - Password is hashed before storage
+    - Confirmation email is queued for delivery
+    - Response includes user ID but not password
+
+    Postconditions:
+    - User exists in database with status 'pending_verification'
+    """
+    # TODO: Implement usecase
+    raise NotImplementedError("Not implemented")
@@ -0,0 +1,29 @@
+# Generated by OpenBoundary - DO NOT EDIT
+from __future__ import annotations
+
+from typing import Any  # noqa: F401
+
+from sqlalchemy.orm import Session
+
+from app.schemas.http_server_api import *  # noqa: F403
+
+
+async def delete_user(*, id: str, session: Session) -> None:
+    """Remove a user account from the system
+
+    Actor: admin
+
+    Preconditions:
+    - User is authenticated
+    - User has admin role
+    - Target user exists
+
+    Acceptance criteria:
+    - User record is soft-deleted
+    - Associated data is marked for cleanup
+
+    Postconditions:
+    - User no longer appears in active user lists
+    """
+    # TODO: Implement usecase
+    raise NotImplementedError("Not implemented")
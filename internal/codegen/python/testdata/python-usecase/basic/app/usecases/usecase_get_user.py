@@ -0,0 +1,25 @@
+# Generated by OpenBoundary - DO NOT EDIT
+from __future__ import annotations
+
+from typing import Any  # noqa: F401
+
+from sqlalchemy.orm import Session
+
+from app.schemas.http_server_api import *  # noqa: F403
+
+
+async def get_user(*, id: str, session: Session) -> None:
+    """Retrieve a user's profile information
+
+    Actor: authenticated_user
+
+    Preconditions:
+    - User is authenticated
+    - User has permission to view the requested profile
+
+    Acceptance criteria:
+    - Returns user profile data
+    - Excludes sensitive fields (password hash)
+    """
+    # TODO: Implement usecase
+    raise NotImplementedError("Not implemented")
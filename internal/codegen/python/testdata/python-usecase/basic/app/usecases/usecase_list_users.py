@@ -0,0 +1,25 @@
+# Generated by OpenBoundary - DO NOT EDIT
+from __future__ import annotations
+
+from typing import Any  # noqa: F401
+
+from sqlalchemy.orm import Session
+
+from app.schemas.http_server_api import *  # noqa: F403
+
+
+async def list_users(*, session: Session) -> None:
+    """List all users with pagination
+
+    Actor: admin
+
+    Preconditions:
+    - User is authenticated
+    - User has admin role
+
+    Acceptance criteria:
+    - Returns paginated list of users
+    - Supports limit and offset parameters
+    """
+    # TODO: Implement usecase
+    raise NotImplementedError("Not implemented")
@@ -0,0 +1,137 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// UsecaseGenerator generates Python usecase implementation stubs.
+type UsecaseGenerator struct{}
+
+// NewUsecaseGenerator creates a new usecase generator.
+func NewUsecaseGenerator() *UsecaseGenerator {
+	return &UsecaseGenerator{}
+}
+
+// Name returns the generator name.
+func (g *UsecaseGenerator) Name() string {
+	return "python-usecase"
+}
+
+// Generate produces one module per usecase component.
+func (g *UsecaseGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+
+	var usecases []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindUsecase && comp.Usecase != nil {
+			usecases = append(usecases, comp)
+		}
+	}
+	sort.Slice(usecases, func(a, b int) bool {
+		return usecases[a].ID < usecases[b].ID
+	})
+
+	models := make(map[string]*serverModels)
+	for _, uc := range usecases {
+		var server *ir.Component
+		if uc.Usecase.Binding != nil {
+			server = i.Components[uc.Usecase.Binding.ServerID]
+		}
+		if server != nil && server.HTTPServer != nil && models[server.ID] == nil {
+			models[server.ID] = buildServerModels(i, server)
+		}
+		code := g.generateUsecase(i, uc, server, models)
+		output.AddComponentFile(usecasePath(uc.ID), []byte(code), uc.ID)
+	}
+	output.AddFile("app/usecases/__init__.py", []byte(generatedHeader))
+
+	return output, nil
+}
+
+func (g *UsecaseGenerator) generateUsecase(i *ir.IR, uc *ir.Component, server *ir.Component, models map[string]*serverModels) string {
+	var sb strings.Builder
+
+	types := operationTypes{Output: "Any"}
+	withDB := false
+	var pathParams []string
+	if server != nil && server.HTTPServer != nil {
+		types = models[server.ID].operations[uc.ID]
+		withDB = serverHasPostgres(i, server)
+		pathParams = uc.Usecase.Binding.PathParams()
+	}
+	catchAll := uc.Usecase.Binding != nil && uc.Usecase.Binding.IsCatchAll()
+
+	sb.WriteString(generatedHeader)
+	sb.WriteString(componentHeader(uc))
+	sb.WriteString("from __future__ import annotations\n\n")
+	sb.WriteString("from typing import Any  # noqa: F401\n")
+	if catchAll {
+		sb.WriteString("\nfrom fastapi import Request, Response\n")
+	}
+	if withDB {
+		sb.WriteString("\nfrom sqlalchemy.orm import Session\n")
+	}
+	if server != nil && server.HTTPServer != nil {
+		fmt.Fprintf(&sb, "\nfrom app.schemas.%s import *  # noqa: F403\n", moduleName(server.ID))
+	}
+
+	var params []string
+	if catchAll {
+		params = append(params, "request: Request")
+	}
+	if mapped := mappedInputFields(i, uc); mapped != nil {
+		// input_mapping replaces the path parameters and body
+		for _, f := range mapped {
+			params = append(params, fmt.Sprintf("%s: %s", f.arg(), f.pythonType()))
+		}
+	} else {
+		for _, p := range pathParams {
+			params = append(params, fmt.Sprintf("%s: str", toSnakeCase(p)))
+		}
+		if types.Input != "" {
+			params = append(params, fmt.Sprintf("body: %s", types.Input))
+		}
+	}
+	if withDB {
+		params = append(params, "session: Session")
+	}
+
+	signature := ""
+	if len(params) > 0 {
+		signature = "*, " + strings.Join(params, ", ")
+	}
+
+	fmt.Fprintf(&sb, "\n\nasync def %s(%s) -> %s:\n", usecaseFunctionName(uc.ID), signature, types.Output)
+	sb.WriteString("    \"\"\"")
+	sb.WriteString(uc.Usecase.Goal)
+	sb.WriteString("\n")
+	if uc.Usecase.Actor != "" {
+		fmt.Fprintf(&sb, "\n    Actor: %s\n", uc.Usecase.Actor)
+	}
+	writeDocList(&sb, "Preconditions", uc.Usecase.Preconditions)
+	writeDocList(&sb, "Acceptance criteria", uc.Usecase.AcceptanceCriteria)
+	writeDocList(&sb, "Postconditions", uc.Usecase.Postconditions)
+	sb.WriteString("    \"\"\"\n")
+	sb.WriteString("    # TODO: Implement usecase\n")
+	sb.WriteString("    raise NotImplementedError(\"Not implemented\")\n")
+
+	return sb.String()
+}
+
+func writeDocList(sb *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "\n    %s:\n", title)
+	for _, item := range items {
+		fmt.Fprintf(sb, "    - %s\n", item)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package python
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUsecaseGenerator_Name(t *testing.T) {
+	// given
+	g := NewUsecaseGenerator()
+
+	// when
+	name := g.Name()
+
+	// then
+	if name != "python-usecase" {
+		t.Errorf("Name() = %q, want %q", name, "python-usecase")
+	}
+}
+
+func TestUsecaseGenerator_Generate(t *testing.T) {
+	// given
+	g := NewUsecaseGenerator()
+
+	// when
+	output, err := g.Generate(newTestIR(t))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{
+			path: "app/usecases/usecase_create_user.py",
+			expected: []string{
+				"from app.schemas.http_server_api import *  # noqa: F403",
+				"async def create_user(*, body: CreateUserRequest, session: Session) -> User:",
+				`"""Create a user`,
+				"Actor: anonymous",
+				`raise NotImplementedError("Not implemented")`,
+			},
+		},
+		{
+			path: "app/usecases/usecase_delete_user.py",
+			expected: []string{
+				"async def delete_user(*, id: str, session: Session) -> None:",
+				"Preconditions:\n    - User exists",
+			},
+		},
+	}
+	for _, tt := range tests {
+		file, ok := output.Files[tt.path]
+		if !ok {
+			t.Errorf("missing %s", tt.path)
+			continue
+		}
+		content := string(file.Content)
+		for _, want := range tt.expected {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q\n%s", tt.path, want, content)
+			}
+		}
+	}
+	if _, ok := output.Files["app/usecases/__init__.py"]; !ok {
+		t.Error("missing app/usecases/__init__.py")
+	}
+}
+
+func TestUsecaseGenerator_Generate_InputMapping(t *testing.T) {
+	// given
+	i := withInputMapping(newTestIR(t))
+
+	// when
+	output, err := NewUsecaseGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	for path, want := range map[string]string{
+		"app/usecases/usecase_create_user.py": "async def create_user(*, display_name: Any, email: str, invite: str | None, session: Session) -> User:",
+		"app/usecases/usecase_delete_user.py": "async def delete_user(*, user_id: str, session: Session) -> None:",
+	} {
+		content := string(output.Files[path].Content)
+		if !strings.Contains(content, want) {
+			t.Errorf("%s missing %q\n%s", path, want, content)
+		}
+	}
+}
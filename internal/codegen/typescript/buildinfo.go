@@ -0,0 +1,33 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+// BuildInfoPath is where the generated service records its build info.
+const BuildInfoPath = "src/buildinfo.ts"
+
+// BuildInfoFile returns src/buildinfo.ts, which exports info so a running
+// service can report the spec and compiler that produced it.
+func BuildInfoFile(info codegen.BuildInfo) codegen.Artifact {
+	generatedAt := "null"
+	if !info.GeneratedAt.IsZero() {
+		generatedAt = strconv.Quote(info.GeneratedAt.UTC().Format(time.RFC3339))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(codegen.Header(codegen.SlashComments) + "\n")
+	sb.WriteString("export const buildInfo = {\n")
+	sb.WriteString("  compilerVersion: " + strconv.Quote(info.Version) + ",\n")
+	sb.WriteString("  specHash: " + strconv.Quote("sha256:"+info.SpecHash) + ",\n")
+	sb.WriteString("  generatedAt: " + generatedAt + ",\n")
+	sb.WriteString("} as const;\n")
+	return codegen.Artifact{Owner: "typescript-buildinfo", Path: BuildInfoPath, Content: []byte(sb.String())}
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+func TestBuildInfoFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		info        codegen.BuildInfo
+		generatedAt string
+	}{
+		{"without timestamp", codegen.BuildInfo{Version: "0.1.0", SpecHash: "abc"}, "  generatedAt: null,\n"},
+		{
+			"with timestamp",
+			codegen.BuildInfo{Version: "0.1.0", SpecHash: "abc", GeneratedAt: time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)},
+			"  generatedAt: \"2026-03-04T05:06:07Z\",\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			artifact := BuildInfoFile(tt.info)
+
+			// then
+			content := string(artifact.Content)
+			if artifact.Path != BuildInfoPath {
+				t.Errorf("Path = %q, expected %q", artifact.Path, BuildInfoPath)
+			}
+			for _, want := range []string{
+				"// Generated by OpenBoundary - DO NOT EDIT\n",
+				"  compilerVersion: \"0.1.0\",\n",
+				"  specHash: \"sha256:abc\",\n",
+				tt.generatedAt,
+			} {
+				if !strings.Contains(content, want) {
+					t.Errorf("content = %q, expected it to contain %q", content, want)
+				}
+			}
+		})
+	}
+}
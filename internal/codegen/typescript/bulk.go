@@ -0,0 +1,161 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// bulkChunkSize is how many items the generated usecase stubs suggest
+// handling at once.
+const bulkChunkSize = 100
+
+// usecaseBulkPath is the module with the helpers of bulk usecases.
+func usecaseBulkPath() string {
+	return "src/components/usecase.bulk.ts"
+}
+
+func usecaseBulkTestPath() string {
+	return "src/components/usecase.bulk.test.ts"
+}
+
+// isBulk reports whether a usecase's route takes an array of items that
+// succeed or fail one by one.
+func isBulk(uc *ir.Component) bool {
+	return uc.Usecase != nil && uc.Usecase.Bulk && uc.Usecase.Binding != nil && !uc.Usecase.Binding.IsCatchAll()
+}
+
+// hasBulkUsecases reports whether any usecase is a bulk usecase.
+func hasBulkUsecases(i *ir.IR) bool {
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindUsecase && isBulk(comp) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateBulkModule generates the result types of bulk usecases and the
+// helper that handles their items in chunks.
+func generateBulkModule() string {
+	var sb strings.Builder
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("// Helpers for bulk usecases, whose request body is an array of items that\n")
+	sb.WriteString("// succeed or fail one by one. Their routes answer 207 Multi-Status when any\n")
+	sb.WriteString("// item failed.\n\n")
+
+	sb.WriteString("/** Thrown while handling one item to fail it with a status, 422 by default. */\n")
+	sb.WriteString("export class BulkItemError extends Error {\n")
+	sb.WriteString("  constructor(message: string, readonly status = 422) {\n")
+	sb.WriteString("    super(message);\n")
+	sb.WriteString("    this.name = 'BulkItemError';\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/** Outcome of the item at index in the request. */\n")
+	sb.WriteString("export type BulkItemResult<T> =\n")
+	sb.WriteString("  | { index: number; status: number; result: T }\n")
+	sb.WriteString("  | { index: number; status: number; error: string };\n\n")
+
+	sb.WriteString("/** Result of a bulk usecase: one outcome per item, in request order. */\n")
+	sb.WriteString("export interface BulkResult<T> {\n")
+	sb.WriteString("  results: BulkItemResult<T>[];\n")
+	sb.WriteString("  succeeded: number;\n")
+	sb.WriteString("  failed: number;\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/**\n")
+	sb.WriteString(" * Handles items in chunks of chunkSize: the items of a chunk concurrently,\n")
+	sb.WriteString(" * the chunks one after another. An item whose handler throws fails with the\n")
+	sb.WriteString(" * status of its BulkItemError, or 500, and the other items carry on.\n")
+	sb.WriteString(" */\n")
+	sb.WriteString("export async function processInChunks<I, T>(\n")
+	sb.WriteString("  items: I[],\n")
+	sb.WriteString("  chunkSize: number,\n")
+	sb.WriteString("  handle: (item: I, index: number) => Promise<T>,\n")
+	sb.WriteString("): Promise<BulkResult<T>> {\n")
+	sb.WriteString("  const results: BulkItemResult<T>[] = [];\n")
+	sb.WriteString("  for (let start = 0; start < items.length; start += chunkSize) {\n")
+	sb.WriteString("    const chunk = items.slice(start, start + chunkSize);\n")
+	sb.WriteString("    const settled = await Promise.allSettled(chunk.map((item, n) => handle(item, start + n)));\n")
+	sb.WriteString("    settled.forEach((outcome, n) => {\n")
+	sb.WriteString("      const index = start + n;\n")
+	sb.WriteString("      if (outcome.status === 'fulfilled') {\n")
+	sb.WriteString("        results.push({ index, status: 200, result: outcome.value });\n")
+	sb.WriteString("        return;\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      const error = outcome.reason;\n")
+	sb.WriteString("      results.push({\n")
+	sb.WriteString("        index,\n")
+	sb.WriteString("        status: error instanceof BulkItemError ? error.status : 500,\n")
+	sb.WriteString("        error: error instanceof Error ? error.message : String(error),\n")
+	sb.WriteString("      });\n")
+	sb.WriteString("    });\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("  const failed = results.filter((r) => 'error' in r).length;\n")
+	sb.WriteString("  return { results, succeeded: results.length - failed, failed };\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// generateBulkTest generates the test of the bulk helpers.
+func generateBulkTest() string {
+	var sb strings.Builder
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("import { describe, it, expect } from 'vitest';\n")
+	sb.WriteString("import { BulkItemError, processInChunks } from './usecase.bulk';\n\n")
+
+	sb.WriteString("describe('processInChunks', () => {\n")
+	sb.WriteString("  it('should report each item in request order', async () => {\n")
+	sb.WriteString("    // given\n")
+	sb.WriteString("    const items = [1, 2, 3, 4, 5];\n\n")
+	sb.WriteString("    // when\n")
+	sb.WriteString("    const result = await processInChunks(items, 2, async (item) => item * 10);\n\n")
+	sb.WriteString("    // then\n")
+	sb.WriteString("    expect(result.succeeded).toBe(5);\n")
+	sb.WriteString("    expect(result.failed).toBe(0);\n")
+	sb.WriteString("    expect(result.results.map((r) => r.index)).toEqual([0, 1, 2, 3, 4]);\n")
+	sb.WriteString("  });\n\n")
+
+	sb.WriteString("  it('should fail single items without failing the others', async () => {\n")
+	sb.WriteString("    // given\n")
+	sb.WriteString("    const items = ['ok', 'invalid', 'broken'];\n\n")
+	sb.WriteString("    // when\n")
+	sb.WriteString("    const result = await processInChunks(items, 2, async (item) => {\n")
+	sb.WriteString("      if (item === 'invalid') {\n")
+	sb.WriteString("        throw new BulkItemError('Invalid item');\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      if (item === 'broken') {\n")
+	sb.WriteString("        throw new Error('Unexpected');\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      return item;\n")
+	sb.WriteString("    });\n\n")
+	sb.WriteString("    // then\n")
+	sb.WriteString("    expect(result.succeeded).toBe(1);\n")
+	sb.WriteString("    expect(result.failed).toBe(2);\n")
+	sb.WriteString("    expect(result.results).toEqual([\n")
+	sb.WriteString("      { index: 0, status: 200, result: 'ok' },\n")
+	sb.WriteString("      { index: 1, status: 422, error: 'Invalid item' },\n")
+	sb.WriteString("      { index: 2, status: 500, error: 'Unexpected' },\n")
+	sb.WriteString("    ]);\n")
+	sb.WriteString("  });\n")
+	sb.WriteString("});\n")
+
+	return sb.String()
+}
+
+// bulkSuccessStatus returns the status a bulk route answers with when every
+// item succeeded.
+func bulkSuccessStatus(method string) string {
+	if method == "post" {
+		return "201"
+	}
+	return "200"
+}
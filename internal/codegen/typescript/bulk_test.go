@@ -0,0 +1,163 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+// withBulkImport binds a bulk POST /orgs/{orgId}/users whose operation takes
+// an array of users. It runs without middleware so server tests reach it.
+func withBulkImport(i *ir.IR) {
+	i.Components["usecase.import-users"] = &ir.Component{
+		ID:   "usecase.import-users",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			BindsTo:    "http.server.api:POST:/orgs/{orgId}/users",
+			Middleware: []string{},
+			Goal:       "Import users",
+			Bulk:       true,
+			Binding: &ir.Binding{
+				ServerID: "http.server.api",
+				Method:   "POST",
+				Path:     "/orgs/{orgId}/users",
+				Operation: &openapi.Operation{
+					OperationID: "importUsers",
+					RequestBody: &openapi.RequestBody{Content: map[string]*openapi.MediaType{
+						"application/json": {Schema: &openapi.Schema{Type: "array", Items: &openapi.Schema{Type: "object"}}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestUsecaseGenerator_Bulk(t *testing.T) {
+	// given
+	i := newTestIR(withBulkImport)
+
+	// when
+	output, err := NewUsecaseGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files["src/components/usecase.bulk.ts"]; !ok {
+		t.Error("missing src/components/usecase.bulk.ts")
+	}
+	usecase := string(output.Files["src/components/usecase-import-users.usecase.ts"].Content)
+	for _, want := range []string{
+		"import type { BulkResult } from './usecase.bulk';\n",
+		"export interface ImportUsersUsecaseInput {\n  items: ImportUsersRequest;\n  orgId: string;\n}\n",
+		"): Promise<BulkResult<ImportUsersResponse>> {\n",
+		"  //   return processInChunks(input.items, 100, async (item, index) => { ... });\n",
+	} {
+		if !strings.Contains(usecase, want) {
+			t.Errorf("usecase file missing %q\n%s", want, usecase)
+		}
+	}
+}
+
+func TestUsecaseGenerator_NoBulk(t *testing.T) {
+	// given
+	i := createTestIR()
+
+	// when
+	output, err := NewUsecaseGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files["src/components/usecase.bulk.ts"]; ok {
+		t.Error("bulk helpers generated without bulk usecases")
+	}
+}
+
+func TestHonoServerGenerator_Generate_Bulk(t *testing.T) {
+	// given
+	i := newTestIR(withBulkImport)
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	for _, want := range []string{
+		"    const body = await c.req.json();\n" +
+			"    if (!Array.isArray(body)) {\n" +
+			"      return c.json({ error: 'Request body must be an array' }, 400);\n" +
+			"    }\n" +
+			"    const input = {\n" +
+			"      items: body,\n" +
+			"      orgId,\n" +
+			"    };\n",
+		"    return c.json(result, result.failed > 0 ? 207 : 201);\n",
+	} {
+		if !strings.Contains(server, want) {
+			t.Errorf("server file missing %q\n%s", want, server)
+		}
+	}
+}
+
+func TestOpenAPIGenerator_Generate_Bulk(t *testing.T) {
+	// given
+	i := newTestIR(withBulkImport)
+
+	// when
+	output, err := NewOpenAPIGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	spec := string(output.Files["src/components/http-server-api.openapi.yaml"].Content)
+	for _, want := range []string{
+		"        '207':\n          description: Multi-Status; some items failed, see the status of each result\n",
+		"    ImportUsersRequest:\n      type: array\n      items:\n        type: object\n",
+	} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("OpenAPI spec missing %q\n%s", want, spec)
+		}
+	}
+}
+
+func TestTestGenerator_Bulk(t *testing.T) {
+	// given
+	i := newTestIR(withBulkImport)
+
+	// when
+	output, err := NewTestGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	helpers, ok := output.Files["src/components/usecase.bulk.test.ts"]
+	if !ok {
+		t.Fatal("missing src/components/usecase.bulk.test.ts")
+	}
+	if want := "  it('should fail single items without failing the others', async () => {\n"; !strings.Contains(string(helpers.Content), want) {
+		t.Errorf("bulk test missing %q\n%s", want, helpers.Content)
+	}
+	server := string(output.Files["src/components/http-server-api.server.test.ts"].Content)
+	if want := "    const req = new Request('http://localhost/orgs/test-orgId/users', {\n" +
+		"      method: 'POST',\n" +
+		"      headers: { 'Content-Type': 'application/json' },\n" +
+		"      body: JSON.stringify(requestFixtures.importUsersUsecase),\n"; !strings.Contains(server, want) {
+		t.Errorf("server test missing %q\n%s", want, server)
+	}
+	usecase := string(output.Files["src/components/usecase-import-users.usecase.test.ts"].Content)
+	if want := "    const input = { items: requestFixtures.importUsersUsecase };\n"; !strings.Contains(usecase, want) {
+		t.Errorf("usecase test missing %q\n%s", want, usecase)
+	}
+}
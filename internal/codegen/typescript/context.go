@@ -27,25 +27,29 @@ func (g *ContextGenerator) Name() string {
 
 // Generate produces context type definitions.
 func (g *ContextGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
-	output := codegen.NewOutput()
+	return codegen.GenerateComponents(g, i)
+}
 
-	// Generate context types colocated with each http.server
-	for _, comp := range i.Components {
-		if comp.Kind != ir.KindHTTPServer || comp.HTTPServer == nil {
-			continue
-		}
+// GenerateShared produces nothing: every context file belongs to a server.
+func (g *ContextGenerator) GenerateShared(i *ir.IR) (*codegen.Output, error) {
+	return codegen.NewOutput(), nil
+}
 
-		contextFile := g.generateServerContext(i, comp)
-		output.AddComponentFile(serverContextPath(comp.ID), []byte(contextFile), comp.ID)
+// GenerateComponent produces the context types colocated with an http.server.
+func (g *ContextGenerator) GenerateComponent(i *ir.IR, comp *ir.Component) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	if comp.Kind == ir.KindHTTPServer && comp.HTTPServer != nil {
+		output.AddComponentFile(serverContextPath(comp.ID), []byte(g.generateServerContext(i, comp)), comp.ID)
 	}
-
 	return output, nil
 }
 
 func (g *ContextGenerator) generateServerContext(i *ir.IR, server *ir.Component) string {
 	var sb strings.Builder
 
-	sb.WriteString("// Generated by OpenBoundary - DO NOT EDIT\n\n")
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString(componentHeader(server))
+	sb.WriteString("\n")
 
 	// Collect imports based on dependencies
 	imports := g.collectImports(i, server)
@@ -64,15 +68,37 @@ func (g *ContextGenerator) generateServerContext(i *ir.IR, server *ir.Component)
 
 	// Add database dependencies
 	for _, dep := range getServerPostgresDependencies(i, server) {
-		if dep.Postgres != nil {
-			fieldName := g.extractFieldName(dep.ID, "db")
+		if p := databaseProviderFor(dep); p != nil {
+			fieldName := postgresContextField(i, dep)
 			sb.WriteString(fmt.Sprintf("  /** Database client from %s */\n", dep.ID))
-			sb.WriteString(fmt.Sprintf("  %s: DrizzleClient;\n", fieldName))
+			sb.WriteString(fmt.Sprintf("  %s: %s;\n", fieldName, p.ClientType()))
+		}
+		if len(drizzleTables(i, dep)) > 0 {
+			sb.WriteString(fmt.Sprintf("  /** Repositories of the tables of %s */\n", dep.ID))
+			sb.WriteString(fmt.Sprintf("  %s: %s;\n", repositoryContextField(i, dep), repositoriesTypeName(dep)))
 		}
 	}
 
+	// Add the emitters of the webhooks usecases send events to
+	for _, wh := range serverWebhooks(i, server) {
+		sb.WriteString(fmt.Sprintf("  /** Event emitter of %s */\n", wh.ID))
+		sb.WriteString(fmt.Sprintf("  %s: %sEmitter;\n", webhookContextField(wh), webhookTypeName(wh)))
+	}
+
+	// Add the clients of the services usecases call
+	for _, client := range serverHTTPClients(i, server) {
+		sb.WriteString(fmt.Sprintf("  /** Client of %s */\n", client.ID))
+		sb.WriteString(fmt.Sprintf("  %s: %s;\n", httpClientContextField(client), httpClientTypeName(client)))
+	}
+
+	// Add the cache of the routes with cache.server
+	if serverCachesResponses(i, server) {
+		sb.WriteString("  /** Responses of the routes with cache.server */\n")
+		sb.WriteString("  responseCache: ResponseCache;\n")
+	}
+
 	// Add middleware dependencies (from server and bound usecases)
-	for _, mwRef := range collectServerMiddleware(i, server) {
+	for _, mwRef := range i.ExpandMiddleware(collectServerMiddleware(i, server)) {
 		mwComp, ok := i.Components[mwRef]
 		if !ok || mwComp.Middleware == nil {
 			continue
@@ -90,38 +116,72 @@ func (g *ContextGenerator) generateServerContext(i *ir.IR, server *ir.Component)
 	// Generate helper type for extracting partial context
 	sb.WriteString("/**\n * Pick specific fields from ServerContext.\n")
 	sb.WriteString(" * Use this to type usecase context parameters.\n */\n")
-	sb.WriteString("export type ContextWith<K extends keyof ServerContext> = Pick<ServerContext, K>;\n")
+	sb.WriteString("export type ContextWith<K extends keyof ServerContext> = Pick<ServerContext, K>;\n\n")
+
+	sb.WriteString("/**\n * Pick specific fields from ServerContext, with the fields in M required\n")
+	sb.WriteString(" * and non-null because middleware that sets them runs before the handler.\n */\n")
+	sb.WriteString("export type ContextAfter<K extends keyof ServerContext, M extends K = never> =\n")
+	sb.WriteString("  Pick<ServerContext, Exclude<K, M>> & { [F in M]-?: NonNullable<ServerContext[F]> };\n")
+
+	g.writeUsecaseContexts(&sb, i, server)
 
 	return sb.String()
 }
 
+// writeUsecaseContexts emits the context type of each usecase bound to the
+// server, narrowed by the middleware of its route.
+func (g *ContextGenerator) writeUsecaseContexts(sb *strings.Builder, i *ir.IR, server *ir.Component) {
+	for _, uc := range i.UsecasesBoundTo(server.ID) {
+		fields := contextFieldsForUsecase(i, uc, server)
+		narrowed := middlewareFieldsForUsecase(i, uc, server)
+
+		fmt.Fprintf(sb, "\n/**\n * Context of %s (%s %s).\n", uc.ID, uc.Usecase.Binding.Method, uc.Usecase.Binding.Path)
+		if mws := effectiveUsecaseMiddleware(uc, server); len(narrowed) > 0 {
+			fmt.Fprintf(sb, " * Set by %s: %s.\n", strings.Join(mws, ", "), strings.Join(narrowed, ", "))
+		}
+		sb.WriteString(" */\n")
+		if len(narrowed) == 0 {
+			fmt.Fprintf(sb, "export type %s = ContextWith<%s>;\n", usecaseContextTypeName(uc), fieldUnion(fields))
+		} else {
+			fmt.Fprintf(sb, "export type %s = ContextAfter<%s, %s>;\n", usecaseContextTypeName(uc), fieldUnion(fields), fieldUnion(narrowed))
+		}
+	}
+}
+
 func (g *ContextGenerator) collectImports(i *ir.IR, server *ir.Component) []string {
 	imports := make(map[string]bool)
 
 	// Check for postgres dependencies
 	for _, dep := range getServerPostgresDependencies(i, server) {
-		if dep.Postgres != nil && dep.Postgres.Provider == "drizzle" {
-			imports[fmt.Sprintf("import type { DrizzleClient } from '%s';", postgresClientImportPath())] = true
+		if p := databaseProviderFor(dep); p != nil {
+			imports[fmt.Sprintf("import type { %s } from '%s';", p.ClientType(), postgresClientImportPath())] = true
+		}
+		if len(drizzleTables(i, dep)) > 0 {
+			imports[fmt.Sprintf("import type { %s } from './%s.postgres.repositories';", repositoriesTypeName(dep), componentIDSlug(dep.ID))] = true
 		}
 	}
 
+	for _, wh := range serverWebhooks(i, server) {
+		imports[fmt.Sprintf("import type { %sEmitter } from './%s.webhook';", webhookTypeName(wh), componentIDSlug(wh.ID))] = true
+	}
+	for _, client := range serverHTTPClients(i, server) {
+		imports[fmt.Sprintf("import type { %s } from './%s.client';", httpClientTypeName(client), componentIDSlug(client.ID))] = true
+	}
+	if serverCachesResponses(i, server) {
+		imports["import type { ResponseCache } from './response.cache';"] = true
+	}
+
 	// Check middleware
-	for _, mwRef := range collectServerMiddleware(i, server) {
+	for _, mwRef := range i.ExpandMiddleware(collectServerMiddleware(i, server)) {
 		mwComp, ok := i.Components[mwRef]
 		if !ok || mwComp.Middleware == nil {
 			continue
 		}
 
-		switch mwComp.Middleware.Provider {
-		case "better-auth":
-			// Import auth context type from the generated middleware module.
-			imports[fmt.Sprintf(
-				"import type { AuthContext as %s } from './%s.middleware';",
-				g.betterAuthContextAlias(mwComp.ID),
-				componentIDSlug(mwComp.ID),
-			)] = true
-		case "casbin":
-			imports["import type { Enforcer } from 'casbin';"] = true
+		if p := middlewareProviderFor(mwComp); p != nil {
+			for _, imp := range p.ContextImports(mwComp) {
+				imports[imp] = true
+			}
 		}
 	}
 
@@ -135,31 +195,15 @@ func (g *ContextGenerator) collectImports(i *ir.IR, server *ir.Component) []stri
 }
 
 func (g *ContextGenerator) getMiddlewareContextField(mw *ir.Component) (name, typeDef string) {
-	if mw.Middleware == nil {
+	p := middlewareProviderFor(mw)
+	if p == nil {
 		return "", ""
 	}
-
-	// Make middleware context fields optional (?) since they're populated at runtime
-	switch mw.Middleware.Provider {
-	case "better-auth":
-		return "auth?", fmt.Sprintf("%s | null", g.betterAuthContextAlias(mw.ID))
-	case "casbin":
-		return "enforcer?", "Enforcer | null"
-	default:
+	key, typ := p.ContextField(mw)
+	if key == "" {
 		return "", ""
 	}
-}
 
-func (g *ContextGenerator) betterAuthContextAlias(componentID string) string {
-	return toPascalCase(componentID) + "AuthContext"
-}
-
-func (g *ContextGenerator) extractFieldName(componentID, fallback string) string {
-	// Extract a sensible field name from component ID
-	// e.g., "postgres.primary" -> "db" or "primaryDb"
-	parts := strings.Split(componentID, ".")
-	if len(parts) > 1 {
-		return fallback // Use fallback for simplicity
-	}
-	return fallback
+	// Make middleware context fields optional (?) since they're populated at runtime
+	return key + "?", typ + " | null"
 }
@@ -10,29 +10,6 @@ import (
 	"github.com/openboundary/openboundary/internal/ir"
 )
 
-func getUsecasesBoundToServer(i *ir.IR, serverID string) []*ir.Component {
-	var usecases []*ir.Component
-	if i == nil {
-		return usecases
-	}
-
-	for _, comp := range i.Components {
-		if comp.Kind != ir.KindUsecase || comp.Usecase == nil {
-			continue
-		}
-		if comp.Usecase.Binding != nil && comp.Usecase.Binding.ServerID == serverID {
-			usecases = append(usecases, comp)
-		}
-	}
-
-	// Sort for deterministic output
-	sort.Slice(usecases, func(i, j int) bool {
-		return usecases[i].ID < usecases[j].ID
-	})
-
-	return usecases
-}
-
 func effectiveUsecaseMiddleware(uc *ir.Component, server *ir.Component) []string {
 	if uc == nil || uc.Usecase == nil {
 		return nil
@@ -65,7 +42,7 @@ func collectServerMiddleware(i *ir.IR, server *ir.Component) []string {
 	}
 
 	// Add middleware referenced by usecases (preserve deterministic order)
-	for _, uc := range getUsecasesBoundToServer(i, server.ID) {
+	for _, uc := range i.UsecasesBoundTo(server.ID) {
 		for _, mw := range effectiveUsecaseMiddleware(uc, server) {
 			if mw == "" || seen[mw] {
 				continue
@@ -78,25 +55,6 @@ func collectServerMiddleware(i *ir.IR, server *ir.Component) []string {
 	return ordered
 }
 
-func serverHasPostgres(i *ir.IR, server *ir.Component) bool {
-	if server == nil {
-		return false
-	}
-	for _, dep := range server.Dependencies {
-		if dep.Kind == ir.KindPostgres {
-			return true
-		}
-	}
-	if server.HTTPServer != nil && i != nil {
-		for _, depID := range server.HTTPServer.DependsOn {
-			if dep, ok := i.Components[depID]; ok && dep.Kind == ir.KindPostgres {
-				return true
-			}
-		}
-	}
-	return false
-}
-
 func getServerPostgresDependencies(i *ir.IR, server *ir.Component) []*ir.Component {
 	var deps []*ir.Component
 	if server == nil {
@@ -125,11 +83,18 @@ func middlewareContextKeys(i *ir.IR, mwID string) []string {
 	}
 	if i != nil {
 		if comp, ok := i.Components[mwID]; ok && comp.Middleware != nil {
-			switch comp.Middleware.Provider {
-			case "better-auth":
-				return []string{"auth"}
-			case "casbin":
-				return []string{"enforcer"}
+			if len(comp.Middleware.Chain) > 0 {
+				var keys []string
+				for _, member := range comp.Middleware.Chain {
+					keys = append(keys, middlewareContextKeys(i, member)...)
+				}
+				return keys
+			}
+			if p := middlewareProviderFor(comp); p != nil {
+				if key, _ := p.ContextField(comp); key != "" {
+					return []string{key}
+				}
+				return nil
 			}
 		}
 	}
@@ -145,7 +110,25 @@ func middlewareContextKeys(i *ir.IR, mwID string) []string {
 }
 
 func contextFieldsForUsecase(i *ir.IR, uc *ir.Component, server *ir.Component) []string {
-	hasDB := serverHasPostgres(i, server)
+	var fields []string
+	for _, pg := range usecasePostgresDependencies(i, uc, server) {
+		fields = append(fields, postgresContextField(i, pg))
+		if len(drizzleTables(i, pg)) > 0 {
+			fields = append(fields, repositoryContextField(i, pg))
+		}
+	}
+	for _, wh := range usecaseWebhooks(i, uc) {
+		fields = append(fields, webhookContextField(wh))
+	}
+	for _, client := range usecaseHTTPClients(i, uc) {
+		fields = append(fields, httpClientContextField(client))
+	}
+	return append(fields, middlewareFieldsForUsecase(i, uc, server)...)
+}
+
+// middlewareFieldsForUsecase returns the context fields set by the
+// middleware that runs before a usecase's handler.
+func middlewareFieldsForUsecase(i *ir.IR, uc *ir.Component, server *ir.Component) []string {
 	hasAuth := false
 	hasEnforcer := false
 
@@ -161,9 +144,6 @@ func contextFieldsForUsecase(i *ir.IR, uc *ir.Component, server *ir.Component) [
 	}
 
 	var fields []string
-	if hasDB {
-		fields = append(fields, "db")
-	}
 	if hasAuth {
 		fields = append(fields, "auth")
 	}
@@ -172,3 +152,182 @@ func contextFieldsForUsecase(i *ir.IR, uc *ir.Component, server *ir.Component) [
 	}
 	return fields
 }
+
+// usecaseContextTypeName returns the name of the context type of a usecase
+// (e.g., "usecase.create-user" -> "CreateUserUsecaseContext").
+func usecaseContextTypeName(uc *ir.Component) string {
+	return toPascalCase(toFunctionName(uc.ID)) + "Context"
+}
+
+// fieldUnion renders context fields as a union of string literal types.
+func fieldUnion(fields []string) string {
+	if len(fields) == 0 {
+		return "never"
+	}
+	quoted := make([]string, len(fields))
+	for n, field := range fields {
+		quoted[n] = "'" + field + "'"
+	}
+	return strings.Join(quoted, " | ")
+}
+
+// usecasePostgresDependencies returns the databases available to a usecase:
+// its own depends_on when declared, otherwise every database of its server.
+func usecasePostgresDependencies(i *ir.IR, uc *ir.Component, server *ir.Component) []*ir.Component {
+	if uc == nil || uc.Usecase == nil || uc.Usecase.DependsOn == nil {
+		return getServerPostgresDependencies(i, server)
+	}
+
+	var deps []*ir.Component
+	for _, ref := range uc.Usecase.DependsOn {
+		if dep, ok := i.Components[ref]; ok && dep.Kind == ir.KindPostgres {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// casbinPolicyDatabase returns the database a casbin middleware loads
+// policies from in production, or nil when it uses its policy file.
+func casbinPolicyDatabase(i *ir.IR, mw *ir.Component) *ir.Component {
+	if mw.Middleware == nil || mw.Middleware.PolicyAdapter != "postgres" {
+		return nil
+	}
+	for _, ref := range mw.Middleware.DependsOn {
+		if dep, ok := i.Components[ref]; ok && dep.Kind == ir.KindPostgres {
+			return dep
+		}
+	}
+	return nil
+}
+
+// policyAdminMiddleware returns the casbin middleware of a server that
+// declare an admin_route, in middleware order.
+func policyAdminMiddleware(i *ir.IR, middlewareRefs []string) []*ir.Component {
+	var mws []*ir.Component
+	for _, ref := range i.ExpandMiddleware(middlewareRefs) {
+		mw, ok := i.Components[ref]
+		if ok && mw.Middleware != nil && mw.Middleware.Provider == "casbin" && mw.Middleware.AdminRoute != "" {
+			mws = append(mws, mw)
+		}
+	}
+	return mws
+}
+
+// usecaseAuthorizer returns the casbin middleware that checks a usecase's
+// authorization: the first one in its middleware chain. It returns nil when
+// the usecase declares no authorization.
+func usecaseAuthorizer(i *ir.IR, uc *ir.Component, server *ir.Component) *ir.Component {
+	if uc == nil || uc.Usecase == nil || uc.Usecase.Authorization == nil {
+		return nil
+	}
+	for _, ref := range i.ExpandMiddleware(effectiveUsecaseMiddleware(uc, server)) {
+		mw, ok := i.Components[ref]
+		if ok && mw.Middleware != nil && mw.Middleware.Provider == "casbin" {
+			return mw
+		}
+	}
+	return nil
+}
+
+// serverAuthorizers returns the casbin middleware that check the
+// authorization of a server's usecases, sorted by ID.
+func serverAuthorizers(i *ir.IR, server *ir.Component) []*ir.Component {
+	seen := make(map[string]bool)
+	var mws []*ir.Component
+	for _, uc := range i.UsecasesBoundTo(server.ID) {
+		if mw := usecaseAuthorizer(i, uc, server); mw != nil && !seen[mw.ID] {
+			seen[mw.ID] = true
+			mws = append(mws, mw)
+		}
+	}
+	sort.Slice(mws, func(a, b int) bool {
+		return mws[a].ID < mws[b].ID
+	})
+	return mws
+}
+
+// postgresComponents returns every postgres component, sorted by ID.
+func postgresComponents(i *ir.IR) []*ir.Component {
+	var pgs []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindPostgres && comp.Postgres != nil {
+			pgs = append(pgs, comp)
+		}
+	}
+	sort.Slice(pgs, func(a, b int) bool {
+		return pgs[a].ID < pgs[b].ID
+	})
+	return pgs
+}
+
+// postgresContextField returns the context field holding a database client.
+// A spec with a single database keeps the "db" field; with several, each
+// field is named after its component (e.g., "postgres.analytics" -> "analyticsDb").
+func postgresContextField(i *ir.IR, pg *ir.Component) string {
+	if len(postgresComponents(i)) <= 1 {
+		return "db"
+	}
+	return componentNameCamel(pg.ID) + "Db"
+}
+
+// componentNameCamel returns the last segment of a component ID in camel
+// case, e.g. "readReplica" for "postgres.read-replica".
+func componentNameCamel(id string) string {
+	words := strings.Split(postgresName(id), "-")
+	for n, word := range words {
+		if n == 0 {
+			words[n] = strings.ToLower(word)
+		} else {
+			words[n] = titleCase(word)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// usesRedisSessions reports whether any better-auth middleware stores
+// sessions in redis.
+func usesRedisSessions(i *ir.IR) bool {
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindMiddleware && comp.Middleware != nil && comp.Middleware.Session != nil &&
+			comp.Middleware.Session.Storage == ir.SessionStorageRedis {
+			return true
+		}
+	}
+	return false
+}
+
+// oauthMiddleware returns the better-auth middleware that declare OAuth
+// providers, sorted by ID.
+func oauthMiddleware(i *ir.IR) []*ir.Component {
+	var mws []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindMiddleware && comp.Middleware != nil && len(comp.Middleware.OAuth) > 0 {
+			mws = append(mws, comp)
+		}
+	}
+	sort.Slice(mws, func(a, b int) bool {
+		return mws[a].ID < mws[b].ID
+	})
+	return mws
+}
+
+// postgresEnvVar returns the connection string variable for a database:
+// DATABASE_URL for a single database, otherwise e.g. ANALYTICS_DATABASE_URL.
+func postgresEnvVar(i *ir.IR, pg *ir.Component) string {
+	if len(postgresComponents(i)) <= 1 {
+		return i.EnvVar("DATABASE_URL")
+	}
+	return i.EnvVar(postgresEnvPrefix(pg) + "_DATABASE_URL")
+}
+
+// postgresEnvPrefix returns the upper snake case name of a database
+// (e.g., "postgres.read-replica" -> "READ_REPLICA").
+func postgresEnvPrefix(pg *ir.Component) string {
+	return strings.ToUpper(strings.ReplaceAll(postgresName(pg.ID), "-", "_"))
+}
+
+// postgresName returns the last segment of a postgres component ID.
+func postgresName(id string) string {
+	return id[strings.LastIndex(id, ".")+1:]
+}
@@ -223,6 +223,121 @@ func TestContextGenerator_Generate_ContextWithHelper(t *testing.T) {
 	}
 }
 
+func TestContextGenerator_Generate_UsecaseContextsNarrowedByMiddleware(t *testing.T) {
+	// given: one usecase behind authn and one public usecase
+	i := &ir.IR{
+		Spec: &parser.Spec{Name: "test"},
+		Components: map[string]*ir.Component{
+			"http.server.api": {
+				ID:   "http.server.api",
+				Kind: ir.KindHTTPServer,
+				HTTPServer: &ir.HTTPServerSpec{
+					Framework:  "hono",
+					Port:       3000,
+					Middleware: []string{"middleware.authn"},
+				},
+			},
+			"middleware.authn": {
+				ID:         "middleware.authn",
+				Kind:       ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{Provider: "better-auth"},
+			},
+			"usecase.get-profile": {
+				ID:   "usecase.get-profile",
+				Kind: ir.KindUsecase,
+				Usecase: &ir.UsecaseSpec{
+					Goal:    "Get the caller's profile",
+					Binding: &ir.Binding{ServerID: "http.server.api", Method: "GET", Path: "/me"},
+				},
+			},
+			"usecase.get-status": {
+				ID:   "usecase.get-status",
+				Kind: ir.KindUsecase,
+				Usecase: &ir.UsecaseSpec{
+					Goal:       "Get the service status",
+					Middleware: []string{},
+					Binding:    &ir.Binding{ServerID: "http.server.api", Method: "GET", Path: "/status"},
+				},
+			},
+		},
+	}
+
+	// when
+	output, err := NewContextGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["src/components/http-server-api.context.ts"].Content)
+	for _, want := range []string{
+		"export type ContextAfter<K extends keyof ServerContext, M extends K = never> =",
+		"{ [F in M]-?: NonNullable<ServerContext[F]> }",
+		" * Set by middleware.authn: auth.\n */\nexport type GetProfileUsecaseContext = ContextAfter<'auth', 'auth'>;",
+		"export type GetStatusUsecaseContext = ContextWith<never>;",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("context file should contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestContextGenerator_Generate_MiddlewareChain(t *testing.T) {
+	// given: a usecase behind a chain of better-auth and casbin
+	i := &ir.IR{
+		Spec: &parser.Spec{Name: "test"},
+		Components: map[string]*ir.Component{
+			"http.server.api": {
+				ID:         "http.server.api",
+				Kind:       ir.KindHTTPServer,
+				HTTPServer: &ir.HTTPServerSpec{Framework: "hono", Port: 3000, Middleware: []string{"middleware.auth"}},
+			},
+			"middleware.auth": {
+				ID:         "middleware.auth",
+				Kind:       ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{Chain: []string{"middleware.auth.better-auth", "middleware.auth.casbin"}},
+			},
+			"middleware.auth.better-auth": {
+				ID:         "middleware.auth.better-auth",
+				Kind:       ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{Provider: "better-auth"},
+			},
+			"middleware.auth.casbin": {
+				ID:         "middleware.auth.casbin",
+				Kind:       ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{Provider: "casbin"},
+			},
+			"usecase.get-profile": {
+				ID:   "usecase.get-profile",
+				Kind: ir.KindUsecase,
+				Usecase: &ir.UsecaseSpec{
+					Goal:    "Get the caller's profile",
+					Binding: &ir.Binding{ServerID: "http.server.api", Method: "GET", Path: "/me"},
+				},
+			},
+		},
+	}
+
+	// when
+	output, err := NewContextGenerator().Generate(i)
+
+	// then - the chain sets the context of each of its providers
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["src/components/http-server-api.context.ts"].Content)
+	for _, want := range []string{
+		"import type { AuthContext as MiddlewareAuthBetterauthAuthContext } from './middleware-auth-better-auth.middleware';",
+		"  auth?: MiddlewareAuthBetterauthAuthContext | null;",
+		"  enforcer?: Enforcer | null;",
+		"export type GetProfileUsecaseContext = ContextAfter<'auth' | 'enforcer', 'auth' | 'enforcer'>;",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("context file should contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
 func TestContextGenerator_Generate_NoHTTPServers(t *testing.T) {
 	// given: IR with no http.server components
 	i := &ir.IR{
@@ -275,3 +390,112 @@ func TestSanitizeFilename(t *testing.T) {
 		})
 	}
 }
+
+// newMultiDatabaseIR returns a server with two databases and usecases that
+// each declare the database they use.
+func newMultiDatabaseIR() *ir.IR {
+	primary := &ir.Component{
+		ID:       "postgres.primary",
+		Kind:     ir.KindPostgres,
+		Postgres: &ir.PostgresSpec{Provider: "drizzle", Schema: "./schema.ts"},
+	}
+	analytics := &ir.Component{
+		ID:       "postgres.read-replica",
+		Kind:     ir.KindPostgres,
+		Postgres: &ir.PostgresSpec{Provider: "drizzle", Schema: "./replica.ts"},
+	}
+	server := &ir.Component{
+		ID:   "http.server.api",
+		Kind: ir.KindHTTPServer,
+		HTTPServer: &ir.HTTPServerSpec{
+			Framework: "hono",
+			Port:      3000,
+			DependsOn: []string{"postgres.primary", "postgres.read-replica"},
+		},
+		Dependencies: []*ir.Component{primary, analytics},
+	}
+
+	return &ir.IR{
+		Spec: &parser.Spec{Name: "test"},
+		Components: map[string]*ir.Component{
+			"http.server.api":       server,
+			"postgres.primary":      primary,
+			"postgres.read-replica": analytics,
+			"usecase.create-order": {
+				ID:   "usecase.create-order",
+				Kind: ir.KindUsecase,
+				Usecase: &ir.UsecaseSpec{
+					Goal:      "Create an order",
+					DependsOn: []string{"postgres.primary"},
+					Binding:   &ir.Binding{ServerID: "http.server.api", Method: "POST", Path: "/orders"},
+				},
+			},
+			"usecase.sales-report": {
+				ID:   "usecase.sales-report",
+				Kind: ir.KindUsecase,
+				Usecase: &ir.UsecaseSpec{
+					Goal:      "Report sales",
+					DependsOn: []string{"postgres.read-replica"},
+					Binding:   &ir.Binding{ServerID: "http.server.api", Method: "GET", Path: "/reports/sales"},
+				},
+			},
+		},
+	}
+}
+
+func TestContextGenerator_Generate_MultipleDatabases(t *testing.T) {
+	// given
+	i := newMultiDatabaseIR()
+
+	// when
+	output, err := NewContextGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["src/components/http-server-api.context.ts"].Content)
+	for _, want := range []string{"  primaryDb: DrizzleClient;", "  readReplicaDb: DrizzleClient;"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("context file missing %q\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "  db: DrizzleClient;") {
+		t.Error("context file should not have an ambiguous db field")
+	}
+}
+
+func TestContextFieldsForUsecase_DeclaredDatabase(t *testing.T) {
+	// given
+	i := newMultiDatabaseIR()
+	server := i.Components["http.server.api"]
+
+	// when
+	fields := contextFieldsForUsecase(i, i.Components["usecase.sales-report"], server)
+
+	// then
+	if len(fields) != 1 || fields[0] != "readReplicaDb" {
+		t.Errorf("contextFieldsForUsecase() = %v, want [readReplicaDb]", fields)
+	}
+}
+
+func TestPostgresNaming(t *testing.T) {
+	i := newMultiDatabaseIR()
+	pg := i.Components["postgres.read-replica"]
+
+	if got := postgresContextField(i, pg); got != "readReplicaDb" {
+		t.Errorf("postgresContextField() = %q, want %q", got, "readReplicaDb")
+	}
+	if got := postgresEnvVar(i, pg); got != "READ_REPLICA_DATABASE_URL" {
+		t.Errorf("postgresEnvVar() = %q, want %q", got, "READ_REPLICA_DATABASE_URL")
+	}
+
+	// A single database keeps the historical names
+	delete(i.Components, "postgres.primary")
+	if got := postgresContextField(i, pg); got != "db" {
+		t.Errorf("postgresContextField() = %q, want %q", got, "db")
+	}
+	if got := postgresEnvVar(i, pg); got != "DATABASE_URL" {
+		t.Errorf("postgresEnvVar() = %q, want %q", got, "DATABASE_URL")
+	}
+}
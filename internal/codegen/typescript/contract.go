@@ -0,0 +1,186 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// FunctionSignature is the declaration of an exported function. Types are
+// kept as written, with whitespace collapsed; an untyped parameter or return
+// has an empty type.
+type FunctionSignature struct {
+	Name    string
+	Params  []Param
+	Returns string
+}
+
+// Param is a function parameter.
+type Param struct {
+	Name string
+	Type string
+}
+
+// UsecaseFunctionName returns the name of the function a usecase's file exports.
+func UsecaseFunctionName(comp *ir.Component) string {
+	return toFunctionName(comp.ID)
+}
+
+var exportedFunctionPattern = regexp.MustCompile(
+	`(?m)^[ \t]*export\s+(?:default\s+)?(?:` +
+		`(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)\s*(?:<[^(]*>)?\s*\(|` +
+		`(?:const|let)\s+([A-Za-z_$][\w$]*)\s*=\s*(?:async\s+)?\()`)
+
+// ExportedFunctions returns the signatures of the functions content exports,
+// in order: function declarations and arrow functions assigned to an
+// exported const. It reads declarations only and does not check that the
+// rest of the file parses.
+func ExportedFunctions(content []byte) []FunctionSignature {
+	src := stripComments(string(content))
+	var sigs []FunctionSignature
+	for _, m := range exportedFunctionPattern.FindAllStringSubmatchIndex(src, -1) {
+		var name string
+		if m[2] >= 0 {
+			name = src[m[2]:m[3]]
+		} else {
+			name = src[m[4]:m[5]]
+		}
+		params, rest, ok := splitParams(src[m[1]:])
+		if !ok {
+			continue
+		}
+		sig := FunctionSignature{Name: name, Returns: returnType(rest)}
+		for _, p := range params {
+			sig.Params = append(sig.Params, parseParam(p))
+		}
+		sigs = append(sigs, sig)
+	}
+	return sigs
+}
+
+// splitParams splits the parameter list starting after "(" at top-level
+// commas and returns the text after the closing ")".
+func splitParams(src string) ([]string, string, bool) {
+	var params []string
+	depth, start := 0, 0
+	for i := 0; i < len(src); i++ {
+		switch src[i] {
+		case '(', '<', '{', '[':
+			depth++
+		case ']', '}':
+			depth--
+		case '>':
+			if i == 0 || src[i-1] != '=' {
+				depth--
+			}
+		case ')':
+			if depth == 0 {
+				if p := strings.TrimSpace(src[start:i]); p != "" {
+					params = append(params, p)
+				}
+				return params, src[i+1:], true
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				params = append(params, strings.TrimSpace(src[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return nil, "", false
+}
+
+// returnType returns the annotation between a parameter list and the
+// function body or arrow.
+func returnType(rest string) string {
+	rest = strings.TrimLeft(rest, " \t\r\n")
+	if !strings.HasPrefix(rest, ":") {
+		return ""
+	}
+	depth := 0
+	for i := 1; i < len(rest); i++ {
+		switch {
+		case rest[i] == '<' || rest[i] == '(' || rest[i] == '[':
+			depth++
+		case rest[i] == ')' || rest[i] == ']':
+			depth--
+		case rest[i] == '>' && rest[i-1] != '=':
+			depth--
+		case depth == 0 && rest[i] == '{', depth == 0 && strings.HasPrefix(rest[i:], "=>"):
+			return collapse(rest[1:i])
+		}
+	}
+	return ""
+}
+
+// parseParam splits "name: Type", dropping a default value.
+func parseParam(p string) Param {
+	if before, _, ok := cutTopLevel(p, '='); ok {
+		p = before
+	}
+	name, typ, _ := cutTopLevel(p, ':')
+	return Param{Name: strings.TrimSuffix(collapse(name), "?"), Type: collapse(typ)}
+}
+
+// cutTopLevel cuts s around the first sep outside brackets.
+func cutTopLevel(s string, sep byte) (string, string, bool) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '<', '{', '[':
+			depth++
+		case ')', '}', ']':
+			depth--
+		case '>':
+			if i > 0 && s[i-1] != '=' {
+				depth--
+			}
+		case sep:
+			if depth == 0 && (sep != '=' || i+1 >= len(s) || s[i+1] != '>') {
+				return s[:i], s[i+1:], true
+			}
+		}
+	}
+	return s, "", false
+}
+
+// stripComments replaces comments with spaces, leaving string literals alone.
+func stripComments(src string) string {
+	out := []byte(src)
+	for i := 0; i < len(out); i++ {
+		switch {
+		case out[i] == '"' || out[i] == '\'' || out[i] == '`':
+			quote := out[i]
+			for i++; i < len(out) && out[i] != quote; i++ {
+				if out[i] == '\\' {
+					i++
+				}
+			}
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '*':
+			end := strings.Index(string(out[i+2:]), "*/")
+			if end < 0 {
+				end = len(out) - i - 4
+			}
+			for j := i; j < i+end+4; j++ {
+				if out[j] != '\n' {
+					out[j] = ' '
+				}
+			}
+			i += end + 3
+		}
+	}
+	return string(out)
+}
+
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
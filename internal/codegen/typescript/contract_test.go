@@ -0,0 +1,99 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExportedFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []FunctionSignature
+	}{
+		{
+			name: "generated usecase",
+			content: `// Generated by OpenBoundary - DO NOT EDIT
+import type { CreateUserRequest, CreateUserResponse } from './usecase.schemas';
+
+/**
+ * export function notAFunction(x: string): void {}
+ */
+export async function createUserUsecase(
+  input: CreateUserRequest,
+  ctx: CreateUserUsecaseContext
+): Promise<CreateUserResponse> {
+  throw new Error('Not implemented');
+}
+`,
+			expected: []FunctionSignature{{
+				Name:    "createUserUsecase",
+				Params:  []Param{{"input", "CreateUserRequest"}, {"ctx", "CreateUserUsecaseContext"}},
+				Returns: "Promise<CreateUserResponse>",
+			}},
+		},
+		{
+			name:    "arrow function with nested types and defaults",
+			content: "export const listUsers = async (input: { page?: number, tags: Array<string> }, ctx?: Ctx<{ a: 1 }>, limit: number = 10): Promise<Map<string, User[]>> => {\n",
+			expected: []FunctionSignature{{
+				Name: "listUsers",
+				Params: []Param{
+					{"input", "{ page?: number, tags: Array<string> }"},
+					{"ctx", "Ctx<{ a: 1 }>"},
+					{"limit", "number"},
+				},
+				Returns: "Promise<Map<string, User[]>>",
+			}},
+		},
+		{
+			name:     "untyped",
+			content:  "export function helper(a, b) {\n  return a + b;\n}\nfunction internal(x: string): void {}\n",
+			expected: []FunctionSignature{{Name: "helper", Params: []Param{{"a", ""}, {"b", ""}}}},
+		},
+		{
+			name:     "string containing a comment marker",
+			content:  "const url = 'http://example.com';\nexport function ping(): Promise<void> {}\n",
+			expected: []FunctionSignature{{Name: "ping", Returns: "Promise<void>"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExportedFunctions([]byte(tt.content))
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ExportedFunctions() = %+v, expected %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExportedFunctions_GeneratedUsecases(t *testing.T) {
+	// given
+	i := createTestIR()
+	output, err := NewUsecaseGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for _, comp := range i.Components {
+		file, ok := output.Files[usecaseSourcePath(comp.ID)]
+		if !ok {
+			continue
+		}
+
+		// when
+		sigs := ExportedFunctions(file.Content)
+
+		// then
+		if len(sigs) != 1 || sigs[0].Name != UsecaseFunctionName(comp) {
+			t.Errorf("ExportedFunctions(%s) = %+v, expected %s", comp.ID, sigs, UsecaseFunctionName(comp))
+			continue
+		}
+		if len(sigs[0].Params) != 2 || sigs[0].Returns == "" {
+			t.Errorf("signature of %s = %+v, expected two typed parameters and a return type", comp.ID, sigs[0])
+		}
+	}
+}
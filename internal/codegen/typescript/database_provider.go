@@ -0,0 +1,209 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// DatabaseProvider renders the provider-specific parts of a postgres
+// component, like MiddlewareProvider does for middleware. The server,
+// context, project, docker and e2e generators look a database's provider up
+// by name, so a provider registered with RegisterDatabaseProvider needs no
+// changes to them.
+type DatabaseProvider interface {
+	// ClientType returns the TypeScript type of a database client, which
+	// the shared postgres client module exports.
+	ClientType() string
+	// ClientTypeModule returns the declarations of ClientType in the shared
+	// postgres client module.
+	ClientTypeModule() string
+	// WriteClient writes the module that connects to a database after its
+	// component header. The module must export db and
+	// create<PascalCaseID>Client.
+	WriteClient(sb *strings.Builder, i *ir.IR, pg *ir.Component)
+	// Dependencies adds the npm packages a database needs to deps and devDeps.
+	Dependencies(pg *ir.Component, deps, devDeps map[string]string)
+	// Scripts adds the package.json scripts that manage a database. A
+	// db:push script, if added, creates its tables.
+	Scripts(pg *ir.Component, scripts map[string]string)
+	// DockerImage returns the image of the database's compose service.
+	DockerImage(pg *ir.Component) string
+	// WriteE2ESetup writes the statements of the e2e global setup that
+	// create a database's tables once its service is healthy. The database
+	// URLs are in env.
+	WriteE2ESetup(sb *strings.Builder, i *ir.IR, pg *ir.Component)
+}
+
+// defaultDatabaseProvider is the provider whose client type the shared
+// postgres client module exports when the spec declares no database.
+const defaultDatabaseProvider = "drizzle"
+
+var (
+	databaseProvidersMu sync.RWMutex
+	databaseProviders   = map[string]DatabaseProvider{
+		"drizzle": drizzleProvider{},
+	}
+)
+
+// RegisterDatabaseProvider makes a database provider available to the
+// TypeScript generators under name. Providers are registered before
+// generation starts, typically from an init function.
+func RegisterDatabaseProvider(name string, p DatabaseProvider) error {
+	if name == "" {
+		return fmt.Errorf("database provider name cannot be empty")
+	}
+	if p == nil {
+		return fmt.Errorf("database provider %q is nil", name)
+	}
+
+	databaseProvidersMu.Lock()
+	defer databaseProvidersMu.Unlock()
+	if _, ok := databaseProviders[name]; ok {
+		return fmt.Errorf("database provider %q already registered", name)
+	}
+	databaseProviders[name] = p
+	return nil
+}
+
+// DatabaseProviders returns the names of the registered database providers,
+// sorted.
+func DatabaseProviders() []string {
+	databaseProvidersMu.RLock()
+	defer databaseProvidersMu.RUnlock()
+	names := make([]string, 0, len(databaseProviders))
+	for name := range databaseProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// databaseProviderFor returns the provider of a postgres component, or nil
+// if it is not registered.
+func databaseProviderFor(pg *ir.Component) DatabaseProvider {
+	if pg == nil || pg.Postgres == nil {
+		return nil
+	}
+	databaseProvidersMu.RLock()
+	defer databaseProvidersMu.RUnlock()
+	return databaseProviders[pg.Postgres.Provider]
+}
+
+// checkDatabaseProvider reports a postgres component whose provider is not
+// registered, naming the providers that are.
+func checkDatabaseProvider(pg *ir.Component) error {
+	if databaseProviderFor(pg) != nil {
+		return nil
+	}
+	return fmt.Errorf("component %q: unsupported database provider %q (supported: %s)",
+		pg.ID, pg.Postgres.Provider, strings.Join(DatabaseProviders(), ", "))
+}
+
+// usedDatabaseProviders returns the providers of the spec's databases,
+// ordered by name, or the default provider if it declares none.
+func usedDatabaseProviders(i *ir.IR) []DatabaseProvider {
+	names := map[string]bool{}
+	for _, pg := range postgresComponents(i) {
+		if databaseProviderFor(pg) != nil {
+			names[pg.Postgres.Provider] = true
+		}
+	}
+	if len(names) == 0 {
+		names[defaultDatabaseProvider] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	databaseProvidersMu.RLock()
+	defer databaseProvidersMu.RUnlock()
+	providers := make([]DatabaseProvider, 0, len(sorted))
+	for _, name := range sorted {
+		providers = append(providers, databaseProviders[name])
+	}
+	return providers
+}
+
+// generatePostgresClientTypes renders the shared module exporting the client
+// type of every database provider in use.
+func generatePostgresClientTypes(i *ir.IR) string {
+	modules := make([]string, 0)
+	for _, p := range usedDatabaseProviders(i) {
+		modules = append(modules, p.ClientTypeModule())
+	}
+	return codegen.Header(codegen.SlashComments) + strings.Join(modules, "\n")
+}
+
+// drizzleProvider connects with postgres.js and queries through Drizzle.
+type drizzleProvider struct{}
+
+func (drizzleProvider) ClientType() string {
+	return "DrizzleClient"
+}
+
+func (drizzleProvider) ClientTypeModule() string {
+	return `import type { PostgresJsDatabase } from 'drizzle-orm/postgres-js';
+
+// eslint-disable-next-line @typescript-eslint/no-explicit-any
+export type DrizzleClient = PostgresJsDatabase<any>;
+`
+}
+
+func (drizzleProvider) WriteClient(sb *strings.Builder, i *ir.IR, pg *ir.Component) {
+	sb.WriteString("import { drizzle } from 'drizzle-orm/postgres-js';\n")
+	sb.WriteString("import postgres from 'postgres';\n")
+	// Import from the colocated schema file
+	sb.WriteString(fmt.Sprintf("import * as schema from './%s.postgres.schema';\n\n", componentIDSlug(pg.ID)))
+
+	sb.WriteString("// Database connection\n")
+	envVar := postgresEnvVar(i, pg)
+	sb.WriteString(fmt.Sprintf("const connectionString = process.env.%s || '';\n", envVar))
+	sb.WriteString("const client = postgres(connectionString);\n\n")
+
+	sb.WriteString("// Export db instance for use by auth and other modules\n")
+	sb.WriteString("export const db = drizzle(client, { schema });\n\n")
+
+	sb.WriteString("// Factory function for explicit initialization with validation\n")
+	sb.WriteString(fmt.Sprintf("export async function create%sClient() {\n", toPascalCase(pg.ID)))
+	sb.WriteString("  if (!connectionString) {\n")
+	sb.WriteString(fmt.Sprintf("    throw new Error('%s environment variable is required');\n", envVar))
+	sb.WriteString("  }\n")
+	sb.WriteString("  return db;\n")
+	sb.WriteString("}\n")
+}
+
+func (drizzleProvider) Dependencies(pg *ir.Component, deps, devDeps map[string]string) {
+	deps["drizzle-orm"] = "^0.41.0"
+	deps["postgres"] = "^3.4.0"
+	devDeps["drizzle-kit"] = "^0.31.0"
+}
+
+func (drizzleProvider) Scripts(pg *ir.Component, scripts map[string]string) {
+	scripts["db:migrate"] = "drizzle-kit migrate"
+	scripts["db:push"] = "drizzle-kit push"
+	scripts["db:studio"] = "drizzle-kit studio"
+}
+
+func (drizzleProvider) DockerImage(pg *ir.Component) string {
+	return "postgres:16-alpine"
+}
+
+func (drizzleProvider) WriteE2ESetup(sb *strings.Builder, i *ir.IR, pg *ir.Component) {
+	fmt.Fprintf(sb, "  // Create the tables of %s\n", pg.ID)
+	sb.WriteString("  execFileSync('npx', [\n")
+	sb.WriteString("    'drizzle-kit', 'push', '--force', '--dialect=postgresql',\n")
+	fmt.Fprintf(sb, "    '--schema=%s',\n", drizzleSchemaGlob(i, pg))
+	fmt.Fprintf(sb, "    `--url=${env.%s}`,\n", postgresEnvVar(i, pg))
+	sb.WriteString("  ], { stdio: 'inherit', env });\n\n")
+}
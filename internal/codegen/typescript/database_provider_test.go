@@ -0,0 +1,147 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+// kyselyProvider is a third-party provider registered by the tests.
+type kyselyProvider struct{}
+
+func (kyselyProvider) ClientType() string { return "KyselyClient" }
+
+func (kyselyProvider) ClientTypeModule() string {
+	return "import type { Kysely } from 'kysely';\n\nexport type KyselyClient = Kysely<any>;\n"
+}
+
+func (kyselyProvider) WriteClient(sb *strings.Builder, i *ir.IR, pg *ir.Component) {
+	fmt.Fprintf(sb, "export const db = new Kysely({ dialect });\n")
+}
+
+func (kyselyProvider) Dependencies(pg *ir.Component, deps, devDeps map[string]string) {
+	deps["kysely"] = "^0.27.0"
+}
+
+func (kyselyProvider) Scripts(pg *ir.Component, scripts map[string]string) {
+	scripts["db:migrate"] = "kysely migrate:latest"
+}
+
+func (kyselyProvider) DockerImage(pg *ir.Component) string { return "postgres:17-alpine" }
+
+func (kyselyProvider) WriteE2ESetup(sb *strings.Builder, i *ir.IR, pg *ir.Component) {
+	sb.WriteString("  execFileSync('npx', ['kysely', 'migrate:latest'], { stdio: 'inherit', env });\n\n")
+}
+
+func databaseProviderTestIR(provider string) *ir.IR {
+	return &ir.IR{
+		Spec: &parser.Spec{Name: "test"},
+		Components: map[string]*ir.Component{
+			"http.server.api": {
+				ID:         "http.server.api",
+				Kind:       ir.KindHTTPServer,
+				HTTPServer: &ir.HTTPServerSpec{Framework: "hono", Port: 3000, DependsOn: []string{"postgres.primary"}},
+			},
+			"postgres.primary": {
+				ID:       "postgres.primary",
+				Kind:     ir.KindPostgres,
+				Postgres: &ir.PostgresSpec{Provider: provider, Schema: "./schema.ts"},
+			},
+		},
+	}
+}
+
+func TestRegisterDatabaseProvider_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		p        DatabaseProvider
+		want     string
+	}{
+		{"empty name", "", kyselyProvider{}, "cannot be empty"},
+		{"nil provider", "kysely", nil, "is nil"},
+		{"duplicate", "drizzle", kyselyProvider{}, "already registered"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterDatabaseProvider(tt.provider, tt.p)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("RegisterDatabaseProvider() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHonoServerGenerator_UnsupportedDatabaseProvider(t *testing.T) {
+	// given: a database whose provider is not registered
+	i := databaseProviderTestIR("prisma")
+
+	// when
+	_, err := NewHonoServerGenerator().Generate(i)
+
+	// then: the error lists the registered providers
+	if err == nil {
+		t.Fatal("Generate() should fail for an unregistered provider")
+	}
+	if !strings.Contains(err.Error(), `unsupported database provider "prisma" (supported: drizzle)`) {
+		t.Errorf("Generate() error = %v", err)
+	}
+}
+
+func TestDatabaseProvider_Registered(t *testing.T) {
+	// given: a database using a registered third-party provider
+	if err := RegisterDatabaseProvider("kysely", kyselyProvider{}); err != nil {
+		t.Fatalf("RegisterDatabaseProvider() error = %v", err)
+	}
+	t.Cleanup(func() {
+		databaseProvidersMu.Lock()
+		delete(databaseProviders, "kysely")
+		databaseProvidersMu.Unlock()
+	})
+	i := databaseProviderTestIR("kysely")
+
+	// when
+	outputs := map[string]*codegen.Output{}
+	for _, g := range []codegen.Generator{
+		NewHonoServerGenerator(), NewContextGenerator(), NewProjectGenerator(), NewDockerGenerator(), NewE2ETestGenerator(),
+	} {
+		output, err := g.Generate(i)
+		if err != nil {
+			t.Fatalf("%s: Generate() error = %v", g.Name(), err)
+		}
+		outputs[g.Name()] = output
+	}
+
+	// then: every generator renders the provider's parts
+	checks := []struct {
+		generator, path, want string
+	}{
+		{"typescript-hono", "src/components/postgres-primary.postgres.ts", "export const db = new Kysely({ dialect });"},
+		{"typescript-hono", "src/components/postgres.client.ts", "export type KyselyClient = Kysely<any>;"},
+		{"typescript-context", "src/components/http-server-api.context.ts", "import type { KyselyClient } from './postgres.client';"},
+		{"typescript-project", "package.json", `"kysely": "^0.27.0"`},
+		{"typescript-project", "package.json", `"db:migrate": "kysely migrate:latest"`},
+		{"typescript-docker", "docker-compose.yml", "image: postgres:17-alpine"},
+		{"typescript-e2e", "e2e/global-setup.ts", "['kysely', 'migrate:latest']"},
+	}
+	for _, c := range checks {
+		file, ok := outputs[c.generator].Files[c.path]
+		if !ok {
+			t.Errorf("%s: %s not generated", c.generator, c.path)
+			continue
+		}
+		if !strings.Contains(string(file.Content), c.want) {
+			t.Errorf("%s should contain %q, got:\n%s", c.path, c.want, file.Content)
+		}
+	}
+	if strings.Contains(string(outputs["typescript-hono"].Files["src/components/postgres.client.ts"].Content), "DrizzleClient") {
+		t.Error("postgres.client.ts should only export the client types of providers in use")
+	}
+}
@@ -5,7 +5,7 @@ package typescript
 
 import (
 	"fmt"
-	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/openboundary/openboundary/internal/codegen"
@@ -30,7 +30,7 @@ func (g *DockerGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
 	output := codegen.NewOutput()
 
 	// Generate Dockerfile
-	dockerfile := g.generateDockerfile()
+	dockerfile := g.generateDockerfile(i)
 	output.AddFile("Dockerfile", []byte(dockerfile))
 
 	// Generate docker-compose.yml
@@ -44,13 +44,13 @@ func (g *DockerGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
 	return output, nil
 }
 
-func (g *DockerGenerator) generateDockerfile() string {
+func (g *DockerGenerator) generateDockerfile(i *ir.IR) string {
 	var sb strings.Builder
 
 	sb.WriteString(`# syntax=docker/dockerfile:1
 
 # Build stage
-FROM node:20-alpine AS builder
+FROM node:` + nodeVersion(i) + `-alpine AS builder
 
 WORKDIR /app
 
@@ -63,14 +63,11 @@ RUN npm ci
 # Copy source code
 COPY . .
 
-# Generate TypeScript types from OpenAPI
-RUN npm run generate:types
-
 # Build the application
 RUN npm run build
 
 # Production stage
-FROM node:20-alpine AS production
+FROM node:` + nodeVersion(i) + `-alpine AS production
 
 WORKDIR /app
 
@@ -88,70 +85,100 @@ RUN addgroup -g 1001 -S nodejs && \
 
 USER nodejs
 
-# Expose port (default 3000, override with PORT env var)
+# Expose port (default 3000, override with ` + firstServerPortEnvVar(i) + ` env var)
 EXPOSE 3000
 
 # Health check
 HEALTHCHECK --interval=30s --timeout=3s --start-period=5s --retries=3 \
-  CMD node -e "require('http').get('http://localhost:' + (process.env.PORT || 3000) + '/health', (r) => process.exit(r.statusCode === 200 ? 0 : 1))"
+  CMD ` + dockerHealthCheck(i) + `
 
 # Start the application
 CMD ["node", "dist/index.js"]
 `)
 
+	// The worker image runs the cron jobs instead of the servers. As the
+	// last stage it is what a build without --target produces, so the
+	// docker:build script names the production target.
+	if len(cronComponents(i)) > 0 {
+		sb.WriteString(`
+# Worker stage: runs the scheduled jobs in a process of their own
+FROM production AS worker
+
+HEALTHCHECK NONE
+
+CMD ["node", "dist/worker.js"]
+`)
+	}
+
 	return sb.String()
 }
 
+// dockerHealthCheck returns the command that checks the first server's
+// /health route. Over HTTPS it skips verifying the certificate, which is
+// issued for the server's public name rather than localhost.
+func dockerHealthCheck(i *ir.IR) string {
+	port := "process.env." + firstServerPortEnvVar(i) + " || 3000"
+	path := firstServerBasePath(i) + "/health"
+	if servers := i.HTTPServers(); len(servers) > 0 && servers[0].HTTPServer.TLS != nil {
+		return `node -e "require('https').get({ host: 'localhost', port: ` + port + `, path: '` + path + `', rejectUnauthorized: false }, (r) => process.exit(r.statusCode === 200 ? 0 : 1))"`
+	}
+	return `node -e "require('http').get('http://localhost:' + (` + port + `) + '` + path + `', (r) => process.exit(r.statusCode === 200 ? 0 : 1))"`
+}
+
 func (g *DockerGenerator) generateDockerCompose(i *ir.IR) string {
 	var sb strings.Builder
 
-	// Detect postgres components
-	hasPostgres := false
-	for _, comp := range i.Components {
-		if comp.Kind == ir.KindPostgres && comp.Postgres != nil {
-			hasPostgres = true
-			break
-		}
-	}
-
-	// Get all HTTP servers (sorted for deterministic output)
-	var servers []*ir.Component
-	for _, comp := range i.Components {
-		if comp.Kind == ir.KindHTTPServer && comp.HTTPServer != nil {
-			servers = append(servers, comp)
-		}
-	}
-	sort.Slice(servers, func(i, j int) bool {
-		return servers[i].ID < servers[j].ID
-	})
+	// One compose service per postgres component
+	pgs := postgresComponents(i)
 
-	// Determine port for first server (default 3000)
-	port := 3000
-	if len(servers) > 0 && servers[0].HTTPServer.Port > 0 {
-		port = servers[0].HTTPServer.Port
-	}
+	// Expose the first server (default 3000)
+	port := firstServerPort(i)
+	portVar := firstServerPortEnvVar(i)
 
+	sb.WriteString(codegen.Header(codegen.HashComments))
 	sb.WriteString("version: '3.8'\n\n")
 	sb.WriteString("services:\n")
 
-	// Postgres service
-	if hasPostgres {
-		sb.WriteString("  postgres:\n")
-		sb.WriteString("    image: postgres:16-alpine\n")
+	// Postgres services, each with its own host port and volume
+	for n, pg := range pgs {
+		service := postgresService(i, pg)
+		portVar := postgresPortEnvVar(i, pg)
+		sb.WriteString(fmt.Sprintf("  %s:\n", service))
+		if p := databaseProviderFor(pg); p != nil {
+			sb.WriteString(fmt.Sprintf("    image: %s\n", p.DockerImage(pg)))
+		}
 		sb.WriteString("    environment:\n")
 		sb.WriteString("      POSTGRES_USER: ${POSTGRES_USER:-postgres}\n")
 		sb.WriteString("      POSTGRES_PASSWORD: ${POSTGRES_PASSWORD:-postgres}\n")
 		sb.WriteString("      POSTGRES_DB: ${POSTGRES_DB:-app}\n")
 		sb.WriteString("    ports:\n")
-		sb.WriteString("      - \"${POSTGRES_PORT:-5432}:5432\"\n")
+		sb.WriteString(fmt.Sprintf("      - \"${%s:-%d}:5432\"\n", portVar, 5432+n))
 		sb.WriteString("    volumes:\n")
-		sb.WriteString("      - postgres_data:/var/lib/postgresql/data\n")
+		sb.WriteString(fmt.Sprintf("      - %s:/var/lib/postgresql/data\n", postgresVolume(i, pg)))
 		sb.WriteString("    healthcheck:\n")
 		sb.WriteString("      test: [\"CMD-SHELL\", \"pg_isready -U ${POSTGRES_USER:-postgres}\"]\n")
 		sb.WriteString("      interval: 10s\n")
 		sb.WriteString("      timeout: 5s\n")
 		sb.WriteString("      retries: 5\n")
 		sb.WriteString("    networks:\n")
+		sb.WriteString("      - app_network\n")
+		writeDeploy(&sb, pg.Resources)
+		sb.WriteString("\n")
+	}
+
+	// Redis service for session storage and the server's cache
+	redis := usesRedis(i)
+	if redis {
+		sb.WriteString("  redis:\n")
+		sb.WriteString("    image: redis:7-alpine\n")
+		sb.WriteString("    ports:\n")
+		sb.WriteString("      - \"${REDIS_PORT:-6379}:6379\"\n")
+		sb.WriteString("    healthcheck:\n")
+		sb.WriteString("      test: [\"CMD\", \"redis-cli\", \"ping\"]\n")
+		sb.WriteString("      interval: 10s\n")
+		sb.WriteString("      timeout: 5s\n")
+		sb.WriteString("      retries: 5\n")
+		sb.WriteString("    networks:\n")
 		sb.WriteString("      - app_network\n\n")
 	}
 
@@ -161,22 +188,81 @@ func (g *DockerGenerator) generateDockerCompose(i *ir.IR) string {
 	sb.WriteString("      context: .\n")
 	sb.WriteString("      dockerfile: Dockerfile\n")
 	sb.WriteString("      target: production\n")
-	sb.WriteString(fmt.Sprintf("    ports:\n      - \"${PORT:-%d}:%d\"\n", port, port))
+	// Gateways are the public entrypoints, so their ports are published too
+	app := appResources(i)
+	gateways := gatewayComponents(i)
+	sb.WriteString("    ports:\n")
+	writePublishedPort(&sb, app, portVar, port)
+	for _, gw := range gateways {
+		writePublishedPort(&sb, app, gatewayPortEnvVar(i, gw), gw.HTTPGateway.Port)
+	}
 	sb.WriteString("    environment:\n")
-	sb.WriteString(fmt.Sprintf("      PORT: ${PORT:-%d}\n", port))
+	sb.WriteString(fmt.Sprintf("      %s: ${%s:-%d}\n", portVar, portVar, port))
+	for _, gw := range gateways {
+		sb.WriteString(fmt.Sprintf("      %s: ${%s:-%d}\n", gatewayPortEnvVar(i, gw), gatewayPortEnvVar(i, gw), gw.HTTPGateway.Port))
+	}
 	sb.WriteString("      NODE_ENV: ${NODE_ENV:-production}\n")
+	// Keep the heap below the memory limit, leaving room for the rest of node
+	if app != nil && app.MemoryMiB > 0 {
+		sb.WriteString(fmt.Sprintf("      NODE_OPTIONS: --max-old-space-size=%d\n", app.MemoryMiB*3/4))
+	}
+	// Production never self-signs, so servers serving HTTPS read the
+	// certificates mounted from ./certs
+	for _, server := range i.HTTPServers() {
+		if tls := server.HTTPServer.TLS; tls != nil {
+			sb.WriteString(fmt.Sprintf("      %s: %s\n", tls.CertEnv, tlsCertFile(server)))
+			sb.WriteString(fmt.Sprintf("      %s: %s\n", tls.KeyEnv, tlsKeyFile(server)))
+		}
+	}
 
-	if hasPostgres {
-		// Construct DATABASE_URL
-		sb.WriteString("      DATABASE_URL: postgres://${POSTGRES_USER:-postgres}:${POSTGRES_PASSWORD:-postgres}@postgres:5432/${POSTGRES_DB:-app}\n")
+	// Construct a connection string per database
+	for _, pg := range pgs {
+		sb.WriteString(fmt.Sprintf("      %s: postgres://${POSTGRES_USER:-postgres}:${POSTGRES_PASSWORD:-postgres}@%s:5432/${POSTGRES_DB:-app}\n",
+			postgresEnvVar(i, pg), postgresService(i, pg)))
+	}
+	if redis {
+		sb.WriteString(fmt.Sprintf("      %s: redis://redis:6379\n", i.EnvVar("REDIS_URL")))
+	}
+	// Pass OAuth credentials through from the host environment
+	for _, mw := range oauthMiddleware(i) {
+		for _, p := range mw.Middleware.OAuth {
+			sb.WriteString(fmt.Sprintf("      %s: ${%s}\n", p.ClientIDEnv, p.ClientIDEnv))
+			sb.WriteString(fmt.Sprintf("      %s: ${%s}\n", p.ClientSecretEnv, p.ClientSecretEnv))
+		}
+	}
+	// Pass webhook targets and secrets through the same way
+	for _, wh := range webhookComponents(i) {
+		sb.WriteString(fmt.Sprintf("      %s: ${%s}\n", wh.Webhook.URLEnv, wh.Webhook.URLEnv))
+		sb.WriteString(fmt.Sprintf("      %s: ${%s}\n", wh.Webhook.SecretEnv, wh.Webhook.SecretEnv))
+	}
+	for _, client := range httpClientComponents(i) {
+		sb.WriteString(fmt.Sprintf("      %s: ${%s}\n", client.HTTPClient.BaseURLEnv, client.HTTPClient.BaseURLEnv))
+	}
+	if len(pgs) > 0 || redis {
 		sb.WriteString("    depends_on:\n")
-		sb.WriteString("      postgres:\n")
-		sb.WriteString("        condition: service_healthy\n")
+		for _, pg := range pgs {
+			sb.WriteString(fmt.Sprintf("      %s:\n", postgresService(i, pg)))
+			sb.WriteString("        condition: service_healthy\n")
+		}
+		if redis {
+			sb.WriteString("      redis:\n")
+			sb.WriteString("        condition: service_healthy\n")
+		}
+	}
+
+	if hasTLSServers(i) {
+		sb.WriteString("    volumes:\n")
+		sb.WriteString(fmt.Sprintf("      - ./certs:%s:ro\n", tlsCertsDir))
 	}
 
 	sb.WriteString("    networks:\n")
 	sb.WriteString("      - app_network\n")
 	sb.WriteString("    restart: unless-stopped\n")
+	writeDeploy(&sb, app)
+
+	if crons := cronComponents(i); len(crons) > 0 {
+		writeWorkerService(&sb, i, crons)
+	}
 
 	// Networks
 	sb.WriteString("\nnetworks:\n")
@@ -184,16 +270,141 @@ func (g *DockerGenerator) generateDockerCompose(i *ir.IR) string {
 	sb.WriteString("    driver: bridge\n")
 
 	// Volumes
-	if hasPostgres {
+	if len(pgs) > 0 {
 		sb.WriteString("\nvolumes:\n")
-		sb.WriteString("  postgres_data:\n")
+		for _, pg := range pgs {
+			sb.WriteString(fmt.Sprintf("  %s:\n", postgresVolume(i, pg)))
+		}
 	}
 
 	return sb.String()
 }
 
+// writeWorkerService writes the compose service of the worker, which gets
+// the connection strings of the databases its jobs use.
+func writeWorkerService(sb *strings.Builder, i *ir.IR, crons []*ir.Component) {
+	var pgs []*ir.Component
+	seen := make(map[string]bool)
+	for _, pg := range postgresComponents(i) {
+		for _, cron := range crons {
+			if !seen[pg.ID] && stringInSlice(pg.ID, cron.Cron.DependsOn) {
+				seen[pg.ID] = true
+				pgs = append(pgs, pg)
+			}
+		}
+	}
+
+	sb.WriteString("\n  worker:\n")
+	sb.WriteString("    build:\n")
+	sb.WriteString("      context: .\n")
+	sb.WriteString("      dockerfile: Dockerfile\n")
+	sb.WriteString("      target: worker\n")
+	sb.WriteString("    environment:\n")
+	sb.WriteString("      NODE_ENV: ${NODE_ENV:-production}\n")
+	for _, pg := range pgs {
+		sb.WriteString(fmt.Sprintf("      %s: postgres://${POSTGRES_USER:-postgres}:${POSTGRES_PASSWORD:-postgres}@%s:5432/${POSTGRES_DB:-app}\n",
+			postgresEnvVar(i, pg), postgresService(i, pg)))
+	}
+	// Its repository writes invalidate the server's cache
+	redis := invalidatesServerCache(i, pgs)
+	if redis {
+		sb.WriteString(fmt.Sprintf("      %s: redis://redis:6379\n", i.EnvVar("REDIS_URL")))
+	}
+	if len(pgs) > 0 {
+		sb.WriteString("    depends_on:\n")
+		for _, pg := range pgs {
+			sb.WriteString(fmt.Sprintf("      %s:\n", postgresService(i, pg)))
+			sb.WriteString("        condition: service_healthy\n")
+		}
+		if redis {
+			sb.WriteString("      redis:\n")
+			sb.WriteString("        condition: service_healthy\n")
+		}
+	}
+	sb.WriteString("    networks:\n")
+	sb.WriteString("      - app_network\n")
+	sb.WriteString("    restart: unless-stopped\n")
+	sb.WriteString("    # Time for running jobs to finish after SIGTERM before the worker is killed\n")
+	sb.WriteString("    stop_grace_period: 1m\n")
+}
+
+// appResources returns the resources of the app container, which runs every
+// server and gateway: the sum of their CPU and memory and their replicas, or
+// nil when none declares resources.
+func appResources(i *ir.IR) *ir.Resources {
+	var app *ir.Resources
+	for _, comp := range sortedComponents(i) {
+		if (comp.Kind != ir.KindHTTPServer && comp.Kind != ir.KindHTTPGateway) || comp.Resources == nil {
+			continue
+		}
+		if app == nil {
+			app = &ir.Resources{}
+		}
+		app.MilliCPU += comp.Resources.MilliCPU
+		app.MemoryMiB += comp.Resources.MemoryMiB
+		app.Replicas = max(app.Replicas, comp.Resources.Replicas)
+	}
+	return app
+}
+
+// writePublishedPort writes the port mapping of a server or gateway. Each
+// replica needs a host port of its own, so several replicas publish a range
+// starting at the default port.
+func writePublishedPort(sb *strings.Builder, app *ir.Resources, envVar string, port int) {
+	if app != nil && app.Replicas > 1 {
+		sb.WriteString(fmt.Sprintf("      - \"%d-%d:%d\"\n", port, port+app.Replicas-1, port))
+		return
+	}
+	sb.WriteString(fmt.Sprintf("      - \"${%s:-%d}:%d\"\n", envVar, port, port))
+}
+
+// writeDeploy writes the replicas and resource limits of a compose service,
+// if it declares any.
+func writeDeploy(sb *strings.Builder, r *ir.Resources) {
+	if r == nil || (r.Replicas == 0 && r.MilliCPU == 0 && r.MemoryMiB == 0) {
+		return
+	}
+	sb.WriteString("    deploy:\n")
+	if r.Replicas > 0 {
+		sb.WriteString(fmt.Sprintf("      replicas: %d\n", r.Replicas))
+	}
+	if r.MilliCPU == 0 && r.MemoryMiB == 0 {
+		return
+	}
+	sb.WriteString("      resources:\n")
+	sb.WriteString("        limits:\n")
+	if r.MilliCPU > 0 {
+		sb.WriteString(fmt.Sprintf("          cpus: '%s'\n", strconv.FormatFloat(float64(r.MilliCPU)/1000, 'f', -1, 64)))
+	}
+	if r.MemoryMiB > 0 {
+		sb.WriteString(fmt.Sprintf("          memory: %dM\n", r.MemoryMiB))
+	}
+}
+
+// postgresService returns the compose service name of a database: "postgres"
+// for a single database, otherwise e.g. "postgres-analytics".
+func postgresService(i *ir.IR, pg *ir.Component) string {
+	if len(postgresComponents(i)) <= 1 {
+		return "postgres"
+	}
+	return "postgres-" + postgresName(pg.ID)
+}
+
+// postgresPortEnvVar returns the variable docker-compose reads the host port
+// of a database from; the default port is 5432 plus its index.
+func postgresPortEnvVar(i *ir.IR, pg *ir.Component) string {
+	if len(postgresComponents(i)) <= 1 {
+		return "POSTGRES_PORT"
+	}
+	return postgresEnvPrefix(pg) + "_POSTGRES_PORT"
+}
+
+func postgresVolume(i *ir.IR, pg *ir.Component) string {
+	return strings.ReplaceAll(postgresService(i, pg), "-", "_") + "_data"
+}
+
 func (g *DockerGenerator) generateDockerignore() string {
-	return `# Dependencies
+	return codegen.Header(codegen.HashComments) + `# Dependencies
 node_modules/
 npm-debug.log*
 yarn-debug.log*
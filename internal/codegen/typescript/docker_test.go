@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
 )
 
 func TestDockerGenerator_Name(t *testing.T) {
@@ -173,6 +174,11 @@ func TestDockerGenerator_Generate(t *testing.T) {
 				if !strings.Contains(dockerfile, "EXPOSE 3000") {
 					t.Error("Dockerfile should expose port 3000")
 				}
+
+				// Types are generated by bound compile, not at image build time
+				if strings.Contains(dockerfile, "generate:types") {
+					t.Error("Dockerfile should not run a type generation script")
+				}
 			},
 		},
 		{
@@ -261,7 +267,184 @@ func TestDockerGenerator_generateDockerCompose_MultipleServers(t *testing.T) {
 	compose := string(output.Files["docker-compose.yml"].Content)
 
 	// Should use first server's port (admin=4000, alphabetically first) with env var template
-	if !strings.Contains(compose, ":-4000}:4000") {
+	if !strings.Contains(compose, "      - \"${ADMIN_PORT:-4000}:4000\"\n") {
 		t.Error("docker-compose.yml should use first (alphabetically) server port 4000")
 	}
+	if !strings.Contains(compose, "      ADMIN_PORT: ${ADMIN_PORT:-4000}\n") {
+		t.Error("docker-compose.yml should pass the port variable the admin server reads")
+	}
+}
+
+func TestDockerGenerator_Generate_NamespacedEnv(t *testing.T) {
+	// given
+	i := createTestIR()
+	i.Spec.Env = &parser.Env{Prefix: "SHOP"}
+
+	// when
+	output, err := NewDockerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	compose := string(output.Files["docker-compose.yml"].Content)
+	for _, want := range []string{
+		"      - \"${SHOP_PORT:-3000}:3000\"\n",
+		"      SHOP_PORT: ${SHOP_PORT:-3000}\n",
+		"      SHOP_DATABASE_URL: postgres://",
+	} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yml missing %q\n%s", want, compose)
+		}
+	}
+	if dockerfile := string(output.Files["Dockerfile"].Content); !strings.Contains(dockerfile, "process.env.SHOP_PORT") {
+		t.Errorf("Dockerfile health check should read SHOP_PORT\n%s", dockerfile)
+	}
+}
+
+func TestDockerGenerator_Generate_Node(t *testing.T) {
+	// given
+	i := createTestIR()
+	i.Spec.Node = "22"
+
+	// when
+	output, err := NewDockerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	dockerfile := string(output.Files["Dockerfile"].Content)
+	if strings.Count(dockerfile, "FROM node:22-alpine AS ") != 2 || strings.Contains(dockerfile, "node:20") {
+		t.Errorf("Dockerfile should build and run on node:22-alpine\n%s", dockerfile)
+	}
+}
+
+func TestDockerGenerator_generateDockerCompose_RedisSessions(t *testing.T) {
+	// given
+	i := createTestIR()
+	i.Components["middleware.authn"].Middleware.Session = &ir.SessionSpec{Storage: ir.SessionStorageRedis}
+
+	// when
+	output, err := NewDockerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	compose := string(output.Files["docker-compose.yml"].Content)
+	for _, want := range []string{
+		"  redis:\n    image: redis:7-alpine\n",
+		"      REDIS_URL: redis://redis:6379\n",
+		"      redis:\n        condition: service_healthy\n",
+	} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yml missing %q\n%s", want, compose)
+		}
+	}
+}
+
+func TestDockerGenerator_generateDockerCompose_OAuthCredentials(t *testing.T) {
+	// given
+	i := createTestIR()
+	i.Components["middleware.authn"].Middleware.OAuth = []ir.OAuthProvider{
+		{Provider: "github", ClientIDEnv: "GITHUB_CLIENT_ID", ClientSecretEnv: "GITHUB_CLIENT_SECRET"},
+	}
+
+	// when
+	output, err := NewDockerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	compose := string(output.Files["docker-compose.yml"].Content)
+	want := "      GITHUB_CLIENT_ID: ${GITHUB_CLIENT_ID}\n      GITHUB_CLIENT_SECRET: ${GITHUB_CLIENT_SECRET}\n"
+	if !strings.Contains(compose, want) {
+		t.Errorf("docker-compose.yml missing %q\n%s", want, compose)
+	}
+}
+
+func TestDockerGenerator_generateDockerCompose_MultipleDatabases(t *testing.T) {
+	// given
+	i := newMultiDatabaseIR()
+
+	// when
+	output, err := NewDockerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	compose := string(output.Files["docker-compose.yml"].Content)
+	expected := []string{
+		"  postgres-primary:\n",
+		"      - \"${PRIMARY_POSTGRES_PORT:-5432}:5432\"\n",
+		"      - postgres_primary_data:/var/lib/postgresql/data\n",
+		"  postgres-read-replica:\n",
+		"      - \"${READ_REPLICA_POSTGRES_PORT:-5433}:5432\"\n",
+		"      - postgres_read_replica_data:/var/lib/postgresql/data\n",
+		"      PRIMARY_DATABASE_URL: postgres://${POSTGRES_USER:-postgres}:${POSTGRES_PASSWORD:-postgres}@postgres-primary:5432/",
+		"      READ_REPLICA_DATABASE_URL: postgres://${POSTGRES_USER:-postgres}:${POSTGRES_PASSWORD:-postgres}@postgres-read-replica:5432/",
+		"      postgres-read-replica:\n        condition: service_healthy\n",
+		"\nvolumes:\n  postgres_primary_data:\n  postgres_read_replica_data:\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yml missing %q\n%s", want, compose)
+		}
+	}
+}
+
+func TestDockerGenerator_generateDockerCompose_Resources(t *testing.T) {
+	// given: the app container runs both the server and the gateway
+	i := newTestIR(withGateway)
+	i.Components["http.server.api"].Resources = &ir.Resources{MilliCPU: 500, MemoryMiB: 512, Replicas: 3}
+	i.Components["http.gateway.edge"].Resources = &ir.Resources{MilliCPU: 250, MemoryMiB: 256, Replicas: 3}
+	i.Components["postgres.primary"].Resources = &ir.Resources{MemoryMiB: 1024}
+
+	// when
+	output, err := NewDockerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	compose := string(output.Files["docker-compose.yml"].Content)
+	for _, want := range []string{
+		"      - \"3000-3002:3000\"\n",
+		"      NODE_OPTIONS: --max-old-space-size=576\n",
+		"    restart: unless-stopped\n" +
+			"    deploy:\n" +
+			"      replicas: 3\n" +
+			"      resources:\n" +
+			"        limits:\n" +
+			"          cpus: '0.75'\n" +
+			"          memory: 768M\n",
+		"      - app_network\n" +
+			"    deploy:\n" +
+			"      resources:\n" +
+			"        limits:\n" +
+			"          memory: 1024M\n",
+	} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yml missing %q\n%s", want, compose)
+		}
+	}
+}
+
+func TestDockerGenerator_generateDockerCompose_NoResources(t *testing.T) {
+	// given
+	i := createTestIR()
+
+	// when
+	output, err := NewDockerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if compose := string(output.Files["docker-compose.yml"].Content); strings.Contains(compose, "deploy:") {
+		t.Errorf("docker-compose.yml has deploy settings without resources\n%s", compose)
+	}
 }
@@ -0,0 +1,126 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// usesIfMatch reports whether any of a server's usecases updates only with
+// If-Match.
+func usesIfMatch(usecases []*ir.Component) bool {
+	return slices.ContainsFunc(usecases, requiresIfMatch)
+}
+
+// usesETags reports whether any of a server's usecases declares optimistic
+// concurrency.
+func usesETags(usecases []*ir.Component) bool {
+	for _, uc := range usecases {
+		if uc.Usecase != nil && uc.Usecase.Concurrency == ir.ConcurrencyETag {
+			return true
+		}
+	}
+	return false
+}
+
+// requiresIfMatch reports whether a usecase's route only updates a resource
+// whose ETag the caller sends in If-Match.
+func requiresIfMatch(uc *ir.Component) bool {
+	s := uc.Usecase
+	if s == nil || s.Binding == nil || s.Concurrency != ir.ConcurrencyETag {
+		return false
+	}
+	return s.Binding.Method == "PUT" || s.Binding.Method == "PATCH"
+}
+
+// writeETagHelpers writes the functions routes with optimistic concurrency
+// compute and compare ETags with, and with usesIfMatch the lock conditional
+// updates run under.
+func writeETagHelpers(sb *strings.Builder, usesIfMatch bool) {
+	sb.WriteString("/**\n")
+	sb.WriteString(" * Returns the strong ETag of a JSON response body.\n")
+	sb.WriteString(" */\n")
+	sb.WriteString("function etagOf(body: unknown): string {\n")
+	sb.WriteString("  return `\"${createHash('sha256').update(JSON.stringify(body) ?? '').digest('base64url')}\"`;\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/**\n")
+	sb.WriteString(" * Reports whether an If-Match or If-None-Match header lists etag, or is *.\n")
+	sb.WriteString(" */\n")
+	sb.WriteString("function etagMatches(header: string | undefined, etag: string | null): boolean {\n")
+	sb.WriteString("  if (!header || !etag) {\n")
+	sb.WriteString("    return false;\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("  return header.split(',').some((tag) => tag.trim() === '*' || tag.trim() === etag);\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/**\n")
+	sb.WriteString(" * Returns the ETag the GET route of a request's path answers with now, or\n")
+	sb.WriteString(" * null if it does not answer 200. The GET runs with the caller's headers, so\n")
+	sb.WriteString(" * it sees the resource the way the caller does.\n")
+	sb.WriteString(" */\n")
+	sb.WriteString("async function currentETag(app: Hono<Env>, request: Request): Promise<string | null> {\n")
+	sb.WriteString("  const headers = new Headers(request.headers);\n")
+	sb.WriteString("  for (const name of ['Content-Length', 'Content-Type', 'If-Match', 'If-None-Match']) {\n")
+	sb.WriteString("    headers.delete(name);\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("  const res = await app.request(new URL(request.url).pathname, { headers });\n")
+	sb.WriteString("  return res.status === 200 ? res.headers.get('ETag') : null;\n")
+	sb.WriteString("}\n\n")
+
+	if usesIfMatch {
+		sb.WriteString("// Tail of the queue of conditional updates of each path\n")
+		sb.WriteString("const pathLocks = new Map<string, Promise<void>>();\n\n")
+		sb.WriteString("/**\n")
+		sb.WriteString(" * Runs fn once the conditional updates of path queued before it are done,\n")
+		sb.WriteString(" * so that no update lands between another's If-Match check and its write.\n")
+		sb.WriteString(" * The queue is per process: server instances behind a load balancer, and\n")
+		sb.WriteString(" * writes made outside the route, are not held back by it.\n")
+		sb.WriteString(" */\n")
+		sb.WriteString("async function withPathLock<T>(path: string, fn: () => Promise<T>): Promise<T> {\n")
+		sb.WriteString("  const previous = pathLocks.get(path) ?? Promise.resolve();\n")
+		sb.WriteString("  let release = () => {};\n")
+		sb.WriteString("  const done = new Promise<void>((resolve) => {\n")
+		sb.WriteString("    release = resolve;\n")
+		sb.WriteString("  });\n")
+		sb.WriteString("  const tail = previous.then(() => done);\n")
+		sb.WriteString("  pathLocks.set(path, tail);\n")
+		sb.WriteString("  await previous;\n")
+		sb.WriteString("  try {\n")
+		sb.WriteString("    return await fn();\n")
+		sb.WriteString("  } finally {\n")
+		sb.WriteString("    release();\n")
+		sb.WriteString("    if (pathLocks.get(path) === tail) {\n")
+		sb.WriteString("      pathLocks.delete(path);\n")
+		sb.WriteString("    }\n")
+		sb.WriteString("  }\n")
+		sb.WriteString("}\n\n")
+	}
+}
+
+// writeIfMatchCheck writes the check that keeps a route from updating a
+// resource that changed since the caller read it.
+func writeIfMatchCheck(sb *strings.Builder) {
+	sb.WriteString("    // Optimistic concurrency: update only what the caller last read\n")
+	sb.WriteString("    const ifMatch = c.req.header('If-Match');\n")
+	sb.WriteString("    if (!ifMatch) {\n")
+	sb.WriteString("      return c.json({ error: 'Precondition Required' }, 428);\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("    if (!etagMatches(ifMatch, await currentETag(app, c.req.raw))) {\n")
+	sb.WriteString("      return c.json({ error: 'Precondition Failed' }, 412);\n")
+	sb.WriteString("    }\n\n")
+}
+
+// writeETagResponse writes how a GET route answers with the ETag of its
+// result, or 304 when the caller already has it.
+func writeETagResponse(sb *strings.Builder) {
+	sb.WriteString("    const etag = etagOf(result);\n")
+	sb.WriteString("    c.header('ETag', etag);\n")
+	sb.WriteString("    if (etagMatches(c.req.header('If-None-Match'), etag)) {\n")
+	sb.WriteString("      return c.body(null, 304);\n")
+	sb.WriteString("    }\n")
+}
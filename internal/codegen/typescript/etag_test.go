@@ -0,0 +1,148 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// withETags puts usecase.get-user and a PUT on its path under optimistic
+// concurrency. The update runs without middleware so server tests reach it.
+func withETags(i *ir.IR) {
+	i.Components["usecase.get-user"].Usecase.Concurrency = ir.ConcurrencyETag
+	i.Components["usecase.update-user"] = &ir.Component{
+		ID:   "usecase.update-user",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			BindsTo:     "http.server.api:PUT:/users/{id}",
+			Middleware:  []string{},
+			Goal:        "Update a user",
+			Concurrency: ir.ConcurrencyETag,
+			Binding: &ir.Binding{
+				ServerID: "http.server.api",
+				Method:   "PUT",
+				Path:     "/users/{id}",
+			},
+		},
+	}
+}
+
+func TestHonoServerGenerator_Generate_ETags(t *testing.T) {
+	// given
+	i := newTestIR(withETags)
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	for _, want := range []string{
+		"import { createHash } from 'crypto';\n",
+		"async function currentETag(app: Hono<Env>, request: Request): Promise<string | null> {\n",
+		"async function withPathLock<T>(path: string, fn: () => Promise<T>): Promise<T> {\n",
+		"    const etag = etagOf(result);\n" +
+			"    c.header('ETag', etag);\n" +
+			"    if (etagMatches(c.req.header('If-None-Match'), etag)) {\n" +
+			"      return c.body(null, 304);\n" +
+			"    }\n" +
+			"    return c.json(result);\n",
+		"  app.put('/users/:id', async (c) => withPathLock(c.req.path, async () => {\n" +
+			"    // Optimistic concurrency: update only what the caller last read\n" +
+			"    const ifMatch = c.req.header('If-Match');\n" +
+			"    if (!ifMatch) {\n" +
+			"      return c.json({ error: 'Precondition Required' }, 428);\n" +
+			"    }\n" +
+			"    if (!etagMatches(ifMatch, await currentETag(app, c.req.raw))) {\n" +
+			"      return c.json({ error: 'Precondition Failed' }, 412);\n" +
+			"    }\n\n" +
+			"    const id = c.req.param('id');\n",
+	} {
+		if !strings.Contains(server, want) {
+			t.Errorf("server file missing %q\n%s", want, server)
+		}
+	}
+	if !strings.Contains(server, "    return c.json(result);\n  }));\n") {
+		t.Errorf("the PUT route should end its path lock\n%s", server)
+	}
+	if strings.Count(server, "c.header('ETag'") != 1 {
+		t.Errorf("only the GET route should set an ETag\n%s", server)
+	}
+}
+
+func TestHonoServerGenerator_Generate_NoETags(t *testing.T) {
+	// given
+	i := createTestIR()
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	if strings.Contains(server, "etag") || strings.Contains(server, "crypto") {
+		t.Errorf("server without concurrency has ETag helpers\n%s", server)
+	}
+}
+
+func TestOpenAPIGenerator_Generate_ETags(t *testing.T) {
+	// given
+	i := newTestIR(withETags)
+
+	// when
+	output, err := NewOpenAPIGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	spec := string(output.Files["src/components/http-server-api.openapi.yaml"].Content)
+	for _, want := range []string{
+		"        - name: If-None-Match\n          in: header\n          required: false\n",
+		"          headers:\n            ETag:\n              schema:\n                type: string\n",
+		"        '304':\n          description: Not Modified\n",
+		"        - name: If-Match\n          in: header\n          required: true\n",
+		"        '412':\n          description: Precondition Failed\n",
+		"        '428':\n          description: Precondition Required\n",
+	} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("OpenAPI spec missing %q\n%s", want, spec)
+		}
+	}
+}
+
+func TestTestGenerator_ConcurrencyTests(t *testing.T) {
+	// given
+	i := newTestIR(withETags)
+
+	// when
+	output, err := NewTestGenerator().Generate(i)
+
+	// then: the conflict paths of the update are tested, not its mock modes
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	test := string(output.Files["src/components/http-server-api.server.test.ts"].Content)
+	for _, want := range []string{
+		"  it('should return 428 from PUT /users/:id without If-Match', async () => {\n",
+		"    req.headers.set('If-Match', '\"stale\"');\n" +
+			"    const res = await app.fetch(req);\n\n" +
+			"    // then\n" +
+			"    expect(res.status).toBe(412);\n",
+	} {
+		if !strings.Contains(test, want) {
+			t.Errorf("server test missing %q\n%s", want, test)
+		}
+	}
+	if strings.Contains(test, "vi.mock('./usecase-update-user.usecase'") {
+		t.Error("routes requiring If-Match should not be tested in mock modes")
+	}
+}
@@ -0,0 +1,509 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+// fixturesPath is the shared fixtures module imported by unit and E2E tests.
+const fixturesPath = "src/test/fixtures.ts"
+
+// fixturesTestPath checks the shared fixtures against the zod schemas of the
+// usecase schemas module.
+const fixturesTestPath = "src/test/fixtures.test.ts"
+
+// maxFixtureDepth bounds $ref expansion so recursive schemas terminate.
+// Past it, arrays stay empty and optional properties are left out, so only
+// a required property of a recursive schema gets a placeholder.
+const maxFixtureDepth = 4
+
+var tsIdentifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// fixtureBuilder renders deterministic example values from OpenAPI schemas.
+type fixtureBuilder struct {
+	doc *openapi.Document
+}
+
+// requestFixture returns the example request body for a usecase, if its
+// operation declares a JSON request body.
+func requestFixture(uc *ir.Component, server *ir.Component) (string, bool) {
+	if uc.Usecase == nil || uc.Usecase.Binding == nil || uc.Usecase.Binding.Operation == nil {
+		return "", false
+	}
+	body := uc.Usecase.Binding.Operation.RequestBody
+	if body == nil {
+		return "", false
+	}
+	media, ok := body.Content["application/json"]
+	if !ok || media.Schema == nil {
+		return "", false
+	}
+	return newFixtureBuilder(server).value(media.Schema, "", 0, "  "), true
+}
+
+// hasRequestFixture reports whether requestFixture would produce a value.
+func hasRequestFixture(uc *ir.Component, server *ir.Component) bool {
+	_, ok := requestFixture(uc, server)
+	return ok
+}
+
+func newFixtureBuilder(server *ir.Component) *fixtureBuilder {
+	b := &fixtureBuilder{doc: &openapi.Document{}}
+	if server != nil && server.HTTPServer != nil && server.HTTPServer.ParsedOpenAPI != nil {
+		b.doc = server.HTTPServer.ParsedOpenAPI
+	}
+	return b
+}
+
+// expandable reports whether value can render s at depth: anything but a
+// reference past maxFixtureDepth or to a schema the document lacks.
+func (b *fixtureBuilder) expandable(s *openapi.Schema, depth int) bool {
+	if !s.IsRef() {
+		return true
+	}
+	_, ok := b.doc.Schemas[s.RefName()]
+	return ok && depth < maxFixtureDepth
+}
+
+// value renders s as a TypeScript literal: the schema's example if it has
+// one, otherwise a value made up within its bounds. name is the enclosing
+// property name, used to pick plausible strings; indent is the current
+// indentation.
+func (b *fixtureBuilder) value(s *openapi.Schema, name string, depth int, indent string) string {
+	if s == nil {
+		return "null"
+	}
+	if !b.expandable(s, depth) {
+		if s.Nullable {
+			return "null"
+		}
+		return "{}"
+	}
+	if s.IsRef() {
+		return b.value(b.doc.Schemas[s.RefName()], name, depth+1, indent)
+	}
+	if s.Example != nil {
+		return exampleLiteral(s.Example)
+	}
+	if len(s.Enum) > 0 {
+		return tsLiteral(s.Enum[0])
+	}
+	// A value of the first alternative matches oneOf and anyOf; allOf
+	// needs every property its parts list
+	switch {
+	case len(s.OneOf) > 0:
+		return b.value(s.OneOf[0], name, depth, indent)
+	case len(s.AnyOf) > 0:
+		return b.value(s.AnyOf[0], name, depth, indent)
+	case len(s.AllOf) > 0:
+		merged := b.doc.MergeAllOf(s)
+		if merged == nil {
+			return "{}"
+		}
+		return b.value(merged, name, depth, indent)
+	}
+
+	switch s.Type {
+	case "string":
+		return tsLiteral(fitLength(exampleString(s.Format, name), s.MinLength, s.MaxLength))
+	case "integer":
+		return strconv.FormatFloat(clamp(1, s, true), 'f', -1, 64)
+	case "number":
+		return strconv.FormatFloat(clamp(9.99, s, false), 'f', -1, 64)
+	case "boolean":
+		return "true"
+	case "array":
+		if s.Items == nil || !b.expandable(s.Items, depth) || (s.MaxItems != nil && *s.MaxItems == 0) {
+			return "[]"
+		}
+		item := b.value(s.Items, singular(name), depth, indent)
+		items := make([]string, max(s.MinItems, 1))
+		for n := range items {
+			items[n] = item
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case "object", "":
+		if len(s.Properties) == 0 {
+			return "{}"
+		}
+		props := make([]string, 0, len(s.Properties))
+		for prop, schema := range s.Properties {
+			if b.expandable(schema, depth) || isRequired(s, prop) {
+				props = append(props, prop)
+			}
+		}
+		sort.Strings(props)
+
+		inner := indent + "  "
+		var sb strings.Builder
+		sb.WriteString("{\n")
+		for _, prop := range props {
+			key := prop
+			if !tsIdentifierPattern.MatchString(prop) {
+				key = tsLiteral(prop)
+			}
+			fmt.Fprintf(&sb, "%s%s: %s,\n", inner, key, b.value(s.Properties[prop], prop, depth, inner))
+		}
+		sb.WriteString(indent + "}")
+		return sb.String()
+	default:
+		return "null"
+	}
+}
+
+// exampleLiteral renders the example a schema gives as a TypeScript
+// literal: scalars as tsLiteral does, objects and arrays as JSON.
+func exampleLiteral(example any) string {
+	switch example.(type) {
+	case map[string]any, []any:
+		data, err := json.Marshal(example)
+		if err != nil {
+			return "null"
+		}
+		return string(data)
+	}
+	return tsLiteral(example)
+}
+
+// clamp moves v into the bounds of a number schema, or for an integer
+// schema to the nearest integer inside them, which v is if it already is.
+func clamp(v float64, s *openapi.Schema, integer bool) float64 {
+	lo, hi := math.Inf(-1), math.Inf(1)
+	if s.Minimum != nil {
+		lo = *s.Minimum
+	}
+	if s.Maximum != nil {
+		hi = *s.Maximum
+	}
+	inside := func(x float64) bool {
+		aboveLo := x > lo || (!s.ExclusiveMinimum && x == lo)
+		belowHi := x < hi || (!s.ExclusiveMaximum && x == hi)
+		return aboveLo && belowHi
+	}
+	if inside(v) {
+		return v
+	}
+
+	below := v <= lo
+	if integer {
+		if below {
+			v = math.Ceil(lo)
+			if !inside(v) {
+				v++
+			}
+		} else {
+			v = math.Floor(hi)
+			if !inside(v) {
+				v--
+			}
+		}
+		return v
+	}
+	switch {
+	case below && !s.ExclusiveMinimum:
+		return lo
+	case !below && !s.ExclusiveMaximum:
+		return hi
+	case !math.IsInf(lo, 0) && !math.IsInf(hi, 0):
+		return (lo + hi) / 2
+	case below:
+		return lo + 1
+	default:
+		return hi - 1
+	}
+}
+
+// fitLength pads s with "x" to at least min characters and cuts it to at
+// most max.
+func fitLength(s string, min uint64, max *uint64) string {
+	if n := uint64(len(s)); n < min {
+		s += strings.Repeat("x", int(min-n))
+	}
+	if max != nil && uint64(len(s)) > *max {
+		s = s[:*max]
+	}
+	return s
+}
+
+// exampleString picks a realistic value for a string format, falling back to
+// hints from the property name.
+func exampleString(format, name string) string {
+	switch format {
+	case "email":
+		return "jane.doe@example.com"
+	case "uuid":
+		return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	case "date-time":
+		return "2024-01-15T09:30:00.000Z"
+	case "date":
+		return "2024-01-15"
+	case "time":
+		return "09:30:00"
+	case "uri", "url":
+		return "https://example.com/resource"
+	case "hostname":
+		return "example.com"
+	case "ipv4":
+		return "192.0.2.1"
+	case "ipv6":
+		return "2001:db8::1"
+	case "password":
+		return "S3cure-pa55word!"
+	case "byte":
+		return "ZXhhbXBsZQ=="
+	}
+
+	lower := strings.ToLower(name)
+	switch {
+	case lower == "":
+		return "example"
+	case strings.Contains(lower, "email"):
+		return "jane.doe@example.com"
+	case lower == "id" || strings.HasSuffix(lower, "id") || strings.HasSuffix(lower, "_id"):
+		return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	case strings.Contains(lower, "firstname") || strings.Contains(lower, "first_name"):
+		return "Jane"
+	case strings.Contains(lower, "lastname") || strings.Contains(lower, "last_name"):
+		return "Doe"
+	case strings.Contains(lower, "name"):
+		return "Jane Doe"
+	case strings.Contains(lower, "phone"):
+		return "+1-555-0100"
+	case strings.Contains(lower, "url") || strings.Contains(lower, "link"):
+		return "https://example.com/resource"
+	case strings.Contains(lower, "password"):
+		return "S3cure-pa55word!"
+	case strings.Contains(lower, "title"):
+		return "Example title"
+	case strings.Contains(lower, "description"):
+		return "An example description."
+	}
+	return "example-" + name
+}
+
+func singular(name string) string {
+	return strings.TrimSuffix(name, "s")
+}
+
+// tsLiteral renders a scalar as a TypeScript literal.
+func tsLiteral(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		escaped := strings.ReplaceAll(val, `\`, `\\`)
+		escaped = strings.ReplaceAll(escaped, "'", `\'`)
+		return "'" + escaped + "'"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// generateFixtures renders src/test/fixtures.ts with example schemas,
+// request bodies and responses derived from every server's OpenAPI document.
+func generateFixtures(i *ir.IR) string {
+	var sb strings.Builder
+
+	var servers []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindHTTPServer && comp.HTTPServer != nil {
+			servers = append(servers, comp)
+		}
+	}
+	sort.Slice(servers, func(a, b int) bool {
+		return servers[a].ID < servers[b].ID
+	})
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("// Deterministic example data derived from OpenAPI schemas, shared by unit and E2E tests.\n\n")
+
+	// Schema names are global in the generated schemas module, so the first
+	// server (by ID) declaring a name wins.
+	sb.WriteString("/** Example values for each components/schemas entry. */\n")
+	sb.WriteString("export const schemaFixtures = {\n")
+	seen := make(map[string]bool)
+	for _, server := range servers {
+		doc := server.HTTPServer.ParsedOpenAPI
+		if doc == nil {
+			continue
+		}
+		names := make([]string, 0, len(doc.Schemas))
+		for name := range doc.Schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		b := newFixtureBuilder(server)
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			fmt.Fprintf(&sb, "  %s: %s,\n", fixtureKey(name), b.value(doc.Schemas[name], "", 0, "  "))
+		}
+	}
+	sb.WriteString("};\n\n")
+
+	var usecases []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindUsecase && comp.Usecase != nil && comp.Usecase.Binding != nil {
+			usecases = append(usecases, comp)
+		}
+	}
+	sort.Slice(usecases, func(a, b int) bool {
+		return usecases[a].ID < usecases[b].ID
+	})
+
+	sb.WriteString("/** Example request bodies, keyed by usecase function name. */\n")
+	sb.WriteString("export const requestFixtures = {\n")
+	for _, uc := range usecases {
+		if body, ok := requestFixture(uc, i.Components[uc.Usecase.Binding.ServerID]); ok {
+			fmt.Fprintf(&sb, "  %s: %s,\n", toFunctionName(uc.ID), body)
+		}
+	}
+	sb.WriteString("};\n\n")
+
+	sb.WriteString("/** Example success responses, keyed by usecase function name. */\n")
+	sb.WriteString("export const responseFixtures = {\n")
+	for _, uc := range usecases {
+		op := uc.Usecase.Binding.Operation
+		if op == nil {
+			continue
+		}
+		schema := op.SuccessResponseSchema()
+		if schema == nil {
+			continue
+		}
+		b := newFixtureBuilder(i.Components[uc.Usecase.Binding.ServerID])
+		fmt.Fprintf(&sb, "  %s: %s,\n", toFunctionName(uc.ID), b.value(schema, "", 0, "  "))
+	}
+	sb.WriteString("};\n")
+
+	return sb.String()
+}
+
+// generateFixturesTest renders src/test/fixtures.test.ts, which parses every
+// fixture with the zod schema it stands for, so fixtures that drift from
+// their OpenAPI schemas fail the tests instead of the code under test. It
+// reports false when there is no fixture to check.
+func generateFixturesTest(i *ir.IR) (string, bool) {
+	type check struct{ schema, fixture string }
+	var checks []check
+
+	var servers []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindHTTPServer && comp.HTTPServer != nil && comp.HTTPServer.ParsedOpenAPI != nil {
+			servers = append(servers, comp)
+		}
+	}
+	sort.Slice(servers, func(a, b int) bool {
+		return servers[a].ID < servers[b].ID
+	})
+	seen := make(map[string]bool)
+	for _, server := range servers {
+		for _, name := range server.HTTPServer.ParsedOpenAPI.SchemaNames() {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			fixture := "schemaFixtures." + name
+			if !tsIdentifierPattern.MatchString(name) {
+				fixture = "schemaFixtures[" + tsLiteral(name) + "]"
+			}
+			checks = append(checks, check{schemaTypeName(name) + "Schema", fixture})
+		}
+	}
+
+	var usecases []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindUsecase && comp.Usecase != nil && comp.Usecase.Binding != nil {
+			usecases = append(usecases, comp)
+		}
+	}
+	sort.Slice(usecases, func(a, b int) bool {
+		return usecases[a].ID < usecases[b].ID
+	})
+	// Only the operations the schemas module declares types for are checked,
+	// each against the fixture of the first usecase bound to it.
+	declared := make(map[string]bool)
+	for _, uc := range usecases {
+		op := uc.Usecase.Binding.Operation
+		if op == nil || op.OperationID == "" {
+			continue
+		}
+		pascalOp := toPascalCase(op.OperationID)
+		method := strings.ToLower(uc.Usecase.Binding.Method)
+		fn := toFunctionName(uc.ID)
+		if (method == "post" || method == "put" || method == "patch") && !declared[pascalOp+"Request"] &&
+			hasRequestFixture(uc, i.Components[uc.Usecase.Binding.ServerID]) {
+			declared[pascalOp+"Request"] = true
+			checks = append(checks, check{pascalOp + "RequestSchema", "requestFixtures." + fn})
+		}
+		if method != "delete" && !declared[pascalOp+"Response"] && op.SuccessResponseSchema() != nil {
+			declared[pascalOp+"Response"] = true
+			checks = append(checks, check{pascalOp + "ResponseSchema", "responseFixtures." + fn})
+		}
+	}
+
+	if len(checks) == 0 {
+		return "", false
+	}
+
+	schemas := make([]string, len(checks))
+	for n, c := range checks {
+		schemas[n] = c.schema
+	}
+	sort.Strings(schemas)
+	var fixtures []string
+	for _, name := range []string{"requestFixtures", "responseFixtures", "schemaFixtures"} {
+		for _, c := range checks {
+			if strings.HasPrefix(c.fixture, name) {
+				fixtures = append(fixtures, name)
+				break
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("import { describe, it, expect } from 'vitest';\n")
+	sb.WriteString("import type { z } from 'zod';\n")
+	fmt.Fprintf(&sb, "import { %s } from '../components/usecase.schemas';\n", strings.Join(schemas, ", "))
+	fmt.Fprintf(&sb, "import { %s } from './fixtures';\n\n", strings.Join(fixtures, ", "))
+
+	sb.WriteString("const checks: [string, z.ZodTypeAny, unknown][] = [\n")
+	for _, c := range checks {
+		fmt.Fprintf(&sb, "  [%s, %s, %s],\n", tsLiteral(c.fixture), c.schema, c.fixture)
+	}
+	sb.WriteString("];\n\n")
+
+	sb.WriteString("describe('fixtures', () => {\n")
+	sb.WriteString("  it.each(checks)('%s should match its schema', (_name, schema, fixture) => {\n")
+	sb.WriteString("    // when\n")
+	sb.WriteString("    const result = schema.safeParse(fixture);\n\n")
+	sb.WriteString("    // then\n")
+	sb.WriteString("    expect(result.success ? [] : result.error.issues).toEqual([]);\n")
+	sb.WriteString("    expect(result.success && result.data).toEqual(fixture);\n")
+	sb.WriteString("  });\n")
+	sb.WriteString("});\n")
+
+	return sb.String(), true
+}
+
+func fixtureKey(name string) string {
+	if tsIdentifierPattern.MatchString(name) {
+		return name
+	}
+	return tsLiteral(name)
+}
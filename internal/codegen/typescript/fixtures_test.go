@@ -0,0 +1,355 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+const fixturesTestOpenAPI = `
+openapi: 3.0.3
+info:
+  title: User API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      operationId: createUser
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [email]
+              properties:
+                email:
+                  type: string
+                  format: email
+                role:
+                  type: string
+                  enum: [admin, member]
+                tags:
+                  type: array
+                  items:
+                    type: string
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /users/{id}:
+    get:
+      operationId: getUser
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        id:
+          type: string
+          format: uuid
+        createdAt:
+          type: string
+          format: date-time
+        age:
+          type: integer
+        manager:
+          $ref: '#/components/schemas/User'
+`
+
+func newFixturesTestIR(t *testing.T) *ir.IR {
+	t.Helper()
+
+	doc, err := openapi.NewParser("").ParseBytes([]byte(fixturesTestOpenAPI))
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+
+	return &ir.IR{
+		Spec: &parser.Spec{Name: "test"},
+		Components: map[string]*ir.Component{
+			"http.server.api": {
+				ID:   "http.server.api",
+				Kind: ir.KindHTTPServer,
+				HTTPServer: &ir.HTTPServerSpec{
+					Framework:     "hono",
+					Port:          3000,
+					ParsedOpenAPI: doc,
+				},
+			},
+			"usecase.create-user": {
+				ID:   "usecase.create-user",
+				Kind: ir.KindUsecase,
+				Usecase: &ir.UsecaseSpec{
+					Goal: "Create a new user",
+					Binding: &ir.Binding{
+						ServerID:  "http.server.api",
+						Method:    "POST",
+						Path:      "/users",
+						Operation: doc.Operations["POST:/users"],
+					},
+				},
+			},
+			"usecase.get-user": {
+				ID:   "usecase.get-user",
+				Kind: ir.KindUsecase,
+				Usecase: &ir.UsecaseSpec{
+					Goal: "Get a user",
+					Binding: &ir.Binding{
+						ServerID:  "http.server.api",
+						Method:    "GET",
+						Path:      "/users/{id}",
+						Operation: doc.Operations["GET:/users/{id}"],
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGenerateFixtures(t *testing.T) {
+	// given
+	i := newFixturesTestIR(t)
+
+	// when
+	content := generateFixtures(i)
+
+	// then
+	expected := []string{
+		"export const schemaFixtures = {",
+		"id: '3fa85f64-5717-4562-b3fc-2c963f66afa6',",
+		"createdAt: '2024-01-15T09:30:00.000Z',",
+		"age: 1,",
+		"export const requestFixtures = {",
+		"  createUserUsecase: {\n    email: 'jane.doe@example.com',\n    role: 'admin',\n    tags: ['example-tag'],\n  },",
+		"export const responseFixtures = {",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("fixtures missing %q\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "getUserUsecase: {\n    email") {
+		t.Error("GET usecase without a request body should have no request fixture")
+	}
+}
+
+func TestFixtureBuilder_Composition(t *testing.T) {
+	// given
+	b := &fixtureBuilder{doc: &openapi.Document{Schemas: map[string]*openapi.Schema{
+		"Named": {Type: "object", Required: []string{"name"}, Properties: map[string]*openapi.Schema{"name": {Type: "string"}}},
+	}}}
+	cat := &openapi.Schema{AllOf: []*openapi.Schema{
+		{Ref: "#/components/schemas/Named"},
+		{Type: "object", Properties: map[string]*openapi.Schema{"lives": {Type: "integer"}}},
+	}}
+
+	// when
+	merged := b.value(cat, "", 0, "")
+	option := b.value(&openapi.Schema{OneOf: []*openapi.Schema{cat, {Type: "string"}}}, "", 0, "")
+
+	// then
+	want := "{\n  lives: 1,\n  name: 'Jane Doe',\n}"
+	if merged != want {
+		t.Errorf("allOf fixture = %q, want %q", merged, want)
+	}
+	if option != want {
+		t.Errorf("oneOf fixture = %q, want the first alternative %q", option, want)
+	}
+}
+
+func TestFixtureBuilder_DepthLimit(t *testing.T) {
+	// given: a tree whose nodes hold their children and an optional parent
+	b := &fixtureBuilder{doc: &openapi.Document{Schemas: map[string]*openapi.Schema{
+		"Node": {Type: "object", Required: []string{"name", "children"}, Properties: map[string]*openapi.Schema{
+			"name":     {Type: "string"},
+			"children": {Type: "array", Items: &openapi.Schema{Ref: "#/components/schemas/Node"}},
+			"parent":   {Ref: "#/components/schemas/Node"},
+		}},
+	}}}
+
+	// when
+	content := b.value(&openapi.Schema{Ref: "#/components/schemas/Node"}, "", maxFixtureDepth-1, "")
+
+	// then: past the limit the array stays empty and the parent is left out
+	want := "{\n  children: [],\n  name: 'Jane Doe',\n}"
+	if content != want {
+		t.Errorf("fixture at the depth limit = %q, want %q", content, want)
+	}
+}
+
+func TestFixtureBuilder_Example(t *testing.T) {
+	// given
+	b := &fixtureBuilder{doc: &openapi.Document{}}
+	tests := []struct {
+		schema *openapi.Schema
+		want   string
+	}{
+		{&openapi.Schema{Type: "string", Example: "teal"}, "'teal'"},
+		{&openapi.Schema{Type: "integer", Minimum: ptr(5.0), Example: float64(7)}, "7"},
+		{&openapi.Schema{Type: "object", Example: map[string]any{"b": float64(1), "a": "x"}}, `{"a":"x","b":1}`},
+		{&openapi.Schema{Type: "array", Example: []any{"x", "y"}}, `["x","y"]`},
+	}
+
+	for _, tt := range tests {
+		// when
+		got := b.value(tt.schema, "color", 0, "")
+
+		// then
+		if got != tt.want {
+			t.Errorf("value(%v) = %s, want %s", tt.schema.Example, got, tt.want)
+		}
+	}
+}
+
+func TestFixtureBuilder_Bounds(t *testing.T) {
+	// given
+	b := &fixtureBuilder{doc: &openapi.Document{}}
+	tests := []struct {
+		name   string
+		schema *openapi.Schema
+		want   string
+	}{
+		{"integer above an exclusive minimum", &openapi.Schema{Type: "integer", Minimum: ptr(5.0), ExclusiveMinimum: true}, "6"},
+		{"integer below a maximum", &openapi.Schema{Type: "integer", Maximum: ptr(-3.5)}, "-4"},
+		{"integer within bounds", &openapi.Schema{Type: "integer", Minimum: ptr(0.0), Maximum: ptr(10.0)}, "1"},
+		{"number at an inclusive minimum", &openapi.Schema{Type: "number", Minimum: ptr(20.0)}, "20"},
+		{"number between exclusive bounds", &openapi.Schema{Type: "number", Minimum: ptr(0.0), Maximum: ptr(1.0), ExclusiveMaximum: true}, "0.5"},
+		{"number below an exclusive maximum", &openapi.Schema{Type: "number", Maximum: ptr(5.0), ExclusiveMaximum: true}, "4"},
+		{"padded string", &openapi.Schema{Type: "string", MinLength: 10}, "'Jane Doexx'"},
+		{"truncated string", &openapi.Schema{Type: "string", MaxLength: ptr(uint64(4))}, "'Jane'"},
+		{"array with minimum items", &openapi.Schema{Type: "array", MinItems: 2, Items: &openapi.Schema{Type: "boolean"}}, "[true, true]"},
+		{"array without items", &openapi.Schema{Type: "array", MaxItems: ptr(uint64(0)), Items: &openapi.Schema{Type: "boolean"}}, "[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			got := b.value(tt.schema, "name", 0, "")
+
+			// then
+			if got != tt.want {
+				t.Errorf("value() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateFixturesTest(t *testing.T) {
+	// given
+	i := newFixturesTestIR(t)
+
+	// when
+	content, ok := generateFixturesTest(i)
+
+	// then
+	if !ok {
+		t.Fatal("generateFixturesTest() reported no fixtures to check")
+	}
+	for _, want := range []string{
+		"import { CreateUserRequestSchema, CreateUserResponseSchema, UserSchema } from '../components/usecase.schemas';\n",
+		"import { requestFixtures, responseFixtures, schemaFixtures } from './fixtures';\n",
+		"  ['schemaFixtures.User', UserSchema, schemaFixtures.User],\n",
+		"  ['requestFixtures.createUserUsecase', CreateUserRequestSchema, requestFixtures.createUserUsecase],\n",
+		"  ['responseFixtures.createUserUsecase', CreateUserResponseSchema, responseFixtures.createUserUsecase],\n",
+		"    const result = schema.safeParse(fixture);\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("fixtures test missing %q\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "getUserUsecase") {
+		t.Error("an operation without a response body should not be checked")
+	}
+}
+
+func TestGenerateFixtures_Deterministic(t *testing.T) {
+	// given
+	i := newFixturesTestIR(t)
+
+	// when
+	first := generateFixtures(i)
+	second := generateFixtures(i)
+
+	// then
+	if first != second {
+		t.Error("generateFixtures() output should be deterministic")
+	}
+}
+
+func TestExampleString(t *testing.T) {
+	tests := []struct {
+		format string
+		name   string
+		want   string
+	}{
+		{"email", "", "jane.doe@example.com"},
+		{"uuid", "", "3fa85f64-5717-4562-b3fc-2c963f66afa6"},
+		{"date", "", "2024-01-15"},
+		{"", "userId", "3fa85f64-5717-4562-b3fc-2c963f66afa6"},
+		{"", "displayName", "Jane Doe"},
+		{"", "contactEmail", "jane.doe@example.com"},
+		{"", "color", "example-color"},
+	}
+	for _, tt := range tests {
+		if got := exampleString(tt.format, tt.name); got != tt.want {
+			t.Errorf("exampleString(%q, %q) = %q, want %q", tt.format, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTestGenerator_Generate_UsesRequestFixtures(t *testing.T) {
+	// given
+	i := newFixturesTestIR(t)
+
+	// when
+	output, err := NewTestGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	usecaseTest := string(output.Files["src/components/usecase-create-user.usecase.test.ts"].Content)
+	if !strings.Contains(usecaseTest, "import { requestFixtures } from '../test/fixtures';") {
+		t.Error("usecase test should import request fixtures")
+	}
+	if !strings.Contains(usecaseTest, "const input = { ...requestFixtures.createUserUsecase };") {
+		t.Error("usecase test should build input from the request fixture")
+	}
+
+	serverTest := string(output.Files["src/components/http-server-api.server.test.ts"].Content)
+	if !strings.Contains(serverTest, "body: JSON.stringify(requestFixtures.createUserUsecase),") {
+		t.Error("server test should send the request fixture")
+	}
+}
+
+func TestE2ETestGenerator_Generate_UsesRequestFixtures(t *testing.T) {
+	// given
+	i := newFixturesTestIR(t)
+
+	// when
+	output, err := NewE2ETestGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	content := string(output.Files["e2e/http-server-api.spec.ts"].Content)
+	if !strings.Contains(content, "import { requestFixtures } from '../src/test/fixtures';") {
+		t.Error("E2E test should import request fixtures")
+	}
+	if !strings.Contains(content, "data: requestFixtures.createUserUsecase,") {
+		t.Error("E2E test should send the request fixture")
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// GatewayGenerator generates the Hono proxy of each http.gateway component,
+// which forwards requests to the servers behind it by path prefix.
+type GatewayGenerator struct{}
+
+// NewGatewayGenerator creates a new gateway generator.
+func NewGatewayGenerator() *GatewayGenerator {
+	return &GatewayGenerator{}
+}
+
+// Name returns the generator name.
+func (g *GatewayGenerator) Name() string {
+	return "typescript-gateway"
+}
+
+// Generate produces the gateway proxies from the IR.
+func (g *GatewayGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
+	return codegen.GenerateComponents(g, i)
+}
+
+// GenerateShared produces nothing; the entrypoint starts the gateways.
+func (g *GatewayGenerator) GenerateShared(i *ir.IR) (*codegen.Output, error) {
+	return codegen.NewOutput(), nil
+}
+
+// GenerateComponent produces the proxy of a gateway component.
+func (g *GatewayGenerator) GenerateComponent(i *ir.IR, comp *ir.Component) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	if comp.Kind == ir.KindHTTPGateway && comp.HTTPGateway != nil {
+		output.AddComponentFile(gatewaySourcePath(comp.ID), []byte(g.generateGateway(i, comp)), comp.ID)
+	}
+	return output, nil
+}
+
+func (g *GatewayGenerator) generateGateway(i *ir.IR, gw *ir.Component) string {
+	var sb strings.Builder
+	s := gw.HTTPGateway
+	authMw := gatewayAuth(i, gw)
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString(componentHeader(gw))
+	sb.WriteString("import { Hono } from 'hono';\n")
+	if authMw != nil {
+		sb.WriteString("import type { Context, Next } from 'hono';\n")
+		sb.WriteString("import { cors } from 'hono/cors';\n")
+		fmt.Fprintf(&sb, "import { auth } from './%s.middleware.config';\n", componentIDSlug(authMw.ID))
+	} else {
+		sb.WriteString("import type { Context } from 'hono';\n")
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("// Headers that describe one connection rather than the request\n")
+	sb.WriteString("const hopByHopHeaders = ['connection', 'keep-alive', 'transfer-encoding', 'upgrade', 'host', 'content-length'];\n\n")
+
+	sb.WriteString("/** Sends a request on to a server, without prefix when given one. */\n")
+	sb.WriteString("async function forward(c: Context, upstream: string, prefix = ''): Promise<Response> {\n")
+	sb.WriteString("  const url = new URL(c.req.url);\n")
+	sb.WriteString("  const path = url.pathname.slice(prefix.length) || '/';\n")
+	sb.WriteString("  const headers = new Headers(c.req.raw.headers);\n")
+	sb.WriteString("  for (const name of hopByHopHeaders) {\n")
+	sb.WriteString("    headers.delete(name);\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("  headers.set('X-Forwarded-Host', url.host);\n")
+	sb.WriteString("  headers.set('X-Forwarded-Proto', url.protocol.slice(0, -1));\n\n")
+	sb.WriteString("  let res: Response;\n")
+	sb.WriteString("  try {\n")
+	sb.WriteString("    res = await fetch(upstream + path + url.search, {\n")
+	sb.WriteString("      method: c.req.method,\n")
+	sb.WriteString("      headers,\n")
+	sb.WriteString("      body: c.req.method === 'GET' || c.req.method === 'HEAD' ? undefined : await c.req.arrayBuffer(),\n")
+	sb.WriteString("      redirect: 'manual',\n")
+	sb.WriteString("    });\n")
+	sb.WriteString("  } catch {\n")
+	sb.WriteString("    return c.json({ error: 'Bad Gateway' }, 502);\n")
+	sb.WriteString("  }\n\n")
+	sb.WriteString("  // fetch decoded the body, so its encoding and length no longer apply\n")
+	sb.WriteString("  const resHeaders = new Headers(res.headers);\n")
+	sb.WriteString("  resHeaders.delete('content-encoding');\n")
+	sb.WriteString("  resHeaders.delete('content-length');\n")
+	sb.WriteString("  return new Response(res.body, { status: res.status, statusText: res.statusText, headers: resHeaders });\n")
+	sb.WriteString("}\n\n")
+
+	if authMw != nil {
+		fmt.Fprintf(&sb, "/** Rejects requests without a session of %s. */\n", authMw.ID)
+		sb.WriteString("async function requireSession(c: Context, next: Next) {\n")
+		sb.WriteString("  const session = await auth.api.getSession({ headers: c.req.raw.headers });\n")
+		sb.WriteString("  if (!session) {\n")
+		sb.WriteString("    return c.json({ error: 'Unauthorized' }, 401);\n")
+		sb.WriteString("  }\n")
+		sb.WriteString("  await next();\n")
+		sb.WriteString("}\n\n")
+	}
+
+	sb.WriteString("/**\n")
+	fmt.Fprintf(&sb, " * Creates the %s Hono application.\n", gw.ID)
+	sb.WriteString(" */\n")
+	fmt.Fprintf(&sb, "export function create%sGateway(): Hono {\n", toPascalCase(gw.ID))
+	sb.WriteString("  const app = new Hono();\n\n")
+
+	sb.WriteString("  // Servers behind the gateway; each runs in this process unless its URL is set\n")
+	for _, server := range gatewayServers(i, gw) {
+		fmt.Fprintf(&sb, "  const %s = process.env.%s ?? `%s://localhost:${process.env.%s ?? %d}`;\n",
+			gatewayUpstreamVar(server), gatewayUpstreamEnvVar(i, server), serverScheme(server), serverPortEnvVar(i, server), serverPort(server))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("  // Health check\n")
+	sb.WriteString("  app.get('/health', (c) => c.json({ status: 'ok' }));\n\n")
+
+	if authMw != nil {
+		fmt.Fprintf(&sb, "  // Sign-in and session routes of %s\n", authMw.ID)
+		sb.WriteString("  app.use('/api/auth/*', cors({\n")
+		fmt.Fprintf(&sb, "    origin: process.env.%s || 'http://localhost:%d',\n", i.EnvVar("CORS_ORIGIN"), s.Port)
+		sb.WriteString("    allowHeaders: ['Content-Type', 'Authorization'],\n")
+		sb.WriteString("    allowMethods: ['POST', 'GET', 'OPTIONS'],\n")
+		sb.WriteString("    credentials: true,\n")
+		sb.WriteString("  }));\n")
+		sb.WriteString("  app.on(['POST', 'GET'], '/api/auth/*', (c) => auth.handler(c.req.raw));\n\n")
+	}
+
+	sb.WriteString("  // Longest prefix first, so it wins over the prefixes it extends\n")
+	for _, r := range gatewayRoutes(i, gw) {
+		pattern := r.Prefix + "/*"
+		if r.Prefix == "/" {
+			pattern = "/*"
+		}
+		var args []string
+		args = append(args, "c", gatewayUpstreamVar(i.Components[r.Server]))
+		if r.StripPrefix && r.Prefix != "/" {
+			args = append(args, tsLiteral(r.Prefix))
+		}
+		handler := fmt.Sprintf("(c) => forward(%s)", strings.Join(args, ", "))
+		if authMw != nil && !r.Public {
+			handler = "requireSession, " + handler
+		}
+		fmt.Fprintf(&sb, "  app.all(%s, %s);\n", tsLiteral(pattern), handler)
+	}
+	sb.WriteString("\n")
+	sb.WriteString("  return app;\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// gatewayComponents returns every http.gateway component, sorted by ID.
+func gatewayComponents(i *ir.IR) []*ir.Component {
+	var gateways []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindHTTPGateway && comp.HTTPGateway != nil {
+			gateways = append(gateways, comp)
+		}
+	}
+	sort.Slice(gateways, func(a, b int) bool {
+		return gateways[a].ID < gateways[b].ID
+	})
+	return gateways
+}
+
+// gatewayAuth returns the better-auth middleware a gateway checks sessions
+// of, or nil.
+func gatewayAuth(i *ir.IR, gw *ir.Component) *ir.Component {
+	mw, ok := i.Components[gw.HTTPGateway.Auth]
+	if !ok || mw.Middleware == nil || mw.Middleware.Provider != "better-auth" {
+		return nil
+	}
+	return mw
+}
+
+// gatewayRoutes returns the routes of a gateway to known servers in the order
+// they are registered: longest prefix first, then by prefix.
+func gatewayRoutes(i *ir.IR, gw *ir.Component) []ir.GatewayRoute {
+	routes := make([]ir.GatewayRoute, 0, len(gw.HTTPGateway.Routes))
+	for _, r := range gw.HTTPGateway.Routes {
+		if server, ok := i.Components[r.Server]; ok && server.HTTPServer != nil {
+			routes = append(routes, r)
+		}
+	}
+	sort.SliceStable(routes, func(a, b int) bool {
+		if len(routes[a].Prefix) != len(routes[b].Prefix) {
+			return len(routes[a].Prefix) > len(routes[b].Prefix)
+		}
+		return routes[a].Prefix < routes[b].Prefix
+	})
+	return routes
+}
+
+// gatewayServers returns the servers behind a gateway, sorted by ID.
+func gatewayServers(i *ir.IR, gw *ir.Component) []*ir.Component {
+	seen := make(map[string]bool)
+	var servers []*ir.Component
+	for _, r := range gw.HTTPGateway.Routes {
+		server, ok := i.Components[r.Server]
+		if !ok || server.HTTPServer == nil || seen[server.ID] {
+			continue
+		}
+		seen[server.ID] = true
+		servers = append(servers, server)
+	}
+	sort.Slice(servers, func(a, b int) bool {
+		return servers[a].ID < servers[b].ID
+	})
+	return servers
+}
+
+// gatewayUpstreamVar returns the local holding the URL of a server behind a
+// gateway, e.g. "ordersUpstream" for "http.server.orders".
+func gatewayUpstreamVar(server *ir.Component) string {
+	return componentNameCamel(server.ID) + "Upstream"
+}
+
+// gatewayUpstreamEnvVar returns the variable that points a gateway at a
+// server running elsewhere, e.g. ORDERS_UPSTREAM_URL for "http.server.orders".
+func gatewayUpstreamEnvVar(i *ir.IR, server *ir.Component) string {
+	return i.EnvVar(strings.ToUpper(strings.ReplaceAll(postgresName(server.ID), "-", "_")) + "_UPSTREAM_URL")
+}
+
+// gatewayPortEnvVar returns the variable overriding a gateway's port, e.g.
+// EDGE_GATEWAY_PORT for "http.gateway.edge".
+func gatewayPortEnvVar(i *ir.IR, gw *ir.Component) string {
+	return i.EnvVar(strings.ToUpper(strings.ReplaceAll(postgresName(gw.ID), "-", "_")) + "_GATEWAY_PORT")
+}
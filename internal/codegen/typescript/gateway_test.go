@@ -0,0 +1,126 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// withGateway puts http.gateway.edge in front of the test IR's server:
+// /users needs a session of middleware.authn, everything else is public.
+func withGateway(i *ir.IR) {
+	i.Components["http.gateway.edge"] = &ir.Component{
+		ID:   "http.gateway.edge",
+		Kind: ir.KindHTTPGateway,
+		HTTPGateway: &ir.HTTPGatewaySpec{
+			Port: 8080,
+			Auth: "middleware.authn",
+			Routes: []ir.GatewayRoute{
+				{Prefix: "/", Server: "http.server.api", Public: true},
+				{Prefix: "/v1/users", Server: "http.server.api", StripPrefix: true},
+			},
+		},
+	}
+}
+
+func TestGatewayGenerator_Name(t *testing.T) {
+	g := NewGatewayGenerator()
+	if got := g.Name(); got != "typescript-gateway" {
+		t.Errorf("Name() = %v, want %v", got, "typescript-gateway")
+	}
+}
+
+func TestGatewayGenerator_ImplementsComponentGenerator(t *testing.T) {
+	var _ codegen.ComponentGenerator = NewGatewayGenerator()
+}
+
+func TestGatewayGenerator_Generate(t *testing.T) {
+	// given
+	i := newTestIR(withGateway)
+
+	// when
+	output, err := NewGatewayGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	file, ok := output.Files["src/components/http-gateway-edge.gateway.ts"]
+	if !ok {
+		t.Fatal("gateway not generated")
+	}
+	content := string(file.Content)
+	for _, want := range []string{
+		"import { auth } from './middleware-authn.middleware.config';\n",
+		"export function createHttpGatewayEdgeGateway(): Hono {\n",
+		"  const apiUpstream = process.env.API_UPSTREAM_URL ?? `http://localhost:${process.env.PORT ?? 3000}`;\n",
+		"  app.get('/health', (c) => c.json({ status: 'ok' }));\n",
+		"  app.on(['POST', 'GET'], '/api/auth/*', (c) => auth.handler(c.req.raw));\n",
+		"  app.all('/v1/users/*', requireSession, (c) => forward(c, apiUpstream, '/v1/users'));\n" +
+			"  app.all('/*', (c) => forward(c, apiUpstream));\n",
+		"    return c.json({ error: 'Bad Gateway' }, 502);\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("gateway missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestGatewayGenerator_Generate_WithoutAuth(t *testing.T) {
+	// given
+	i := newTestIR(withGateway)
+	i.Components["http.gateway.edge"].HTTPGateway.Auth = ""
+
+	// when
+	output, err := NewGatewayGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["src/components/http-gateway-edge.gateway.ts"].Content)
+	if strings.Contains(content, "requireSession") || strings.Contains(content, "/api/auth/*") {
+		t.Errorf("gateway without auth should not check sessions\n%s", content)
+	}
+}
+
+func TestGateway_IndexAndCompose(t *testing.T) {
+	// given
+	i := newTestIR(withGateway)
+
+	// when
+	server, err := NewHonoServerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	docker, err := NewDockerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	index := string(server.Files["src/index.ts"].Content)
+	for _, want := range []string{
+		"import { createHttpGatewayEdgeGateway } from './components/http-gateway-edge.gateway';\n",
+		"  const httpGatewayEdgeApp = createHttpGatewayEdgeGateway();\n",
+		"  serve({ fetch: httpGatewayEdgeApp.fetch, port: Number(process.env.EDGE_GATEWAY_PORT ?? 8080) }, (info) => {\n",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index missing %q\n%s", want, index)
+		}
+	}
+	compose := string(docker.Files["docker-compose.yml"].Content)
+	for _, want := range []string{
+		"      - \"${EDGE_GATEWAY_PORT:-8080}:8080\"\n",
+		"      EDGE_GATEWAY_PORT: ${EDGE_GATEWAY_PORT:-8080}\n",
+	} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yml missing %q\n%s", want, compose)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// HTTPClientGenerator generates the client of each http.client component:
+// a fetch wrapper that retries failed requests and stops calling a failing
+// service, and the tests of that behavior.
+type HTTPClientGenerator struct{}
+
+// NewHTTPClientGenerator creates a new http.client generator.
+func NewHTTPClientGenerator() *HTTPClientGenerator {
+	return &HTTPClientGenerator{}
+}
+
+// Name returns the generator name.
+func (g *HTTPClientGenerator) Name() string {
+	return "typescript-http-clients"
+}
+
+// Generate produces the clients and their tests from the IR.
+func (g *HTTPClientGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
+	return codegen.GenerateComponents(g, i)
+}
+
+// GenerateShared produces nothing; every file belongs to a client.
+func (g *HTTPClientGenerator) GenerateShared(i *ir.IR) (*codegen.Output, error) {
+	return codegen.NewOutput(), nil
+}
+
+// GenerateComponent produces the client of an http.client component and its
+// tests.
+func (g *HTTPClientGenerator) GenerateComponent(i *ir.IR, comp *ir.Component) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	if comp.Kind == ir.KindHTTPClient && comp.HTTPClient != nil {
+		output.AddComponentFile(httpClientSourcePath(comp.ID), []byte(g.generateClient(comp)), comp.ID)
+		output.AddComponentFile(httpClientTestPath(comp.ID), []byte(g.generateTest(comp)), comp.ID)
+	}
+	return output, nil
+}
+
+func (g *HTTPClientGenerator) generateClient(client *ir.Component) string {
+	var sb strings.Builder
+	s := client.HTTPClient
+	name := httpClientTypeName(client)
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString(componentHeader(client))
+
+	fmt.Fprintf(&sb, "/** Rejects a request while the circuit to %s is open, without sending it */\n", client.ID)
+	fmt.Fprintf(&sb, "export class %sCircuitOpenError extends Error {\n", name)
+	sb.WriteString("  constructor() {\n")
+	fmt.Fprintf(&sb, "    super('%s is failing; requests are not sent until the circuit closes');\n", client.ID)
+	fmt.Fprintf(&sb, "    this.name = '%sCircuitOpenError';\n", name)
+	sb.WriteString("  }\n")
+	sb.WriteString("}\n\n")
+
+	fmt.Fprintf(&sb, "/** Sends requests to the service at the base URL in %s */\n", s.BaseURLEnv)
+	fmt.Fprintf(&sb, "export interface %s {\n", name)
+	sb.WriteString("  /**\n")
+	sb.WriteString("   * Sends a request to path, relative to the base URL. Failed GET, HEAD,\n")
+	sb.WriteString("   * OPTIONS, PUT and DELETE requests are retried with exponential backoff,\n")
+	sb.WriteString("   * and the last response is returned when every attempt failed with one.\n")
+	fmt.Fprintf(&sb, "   * Rejects with %sCircuitOpenError while the circuit is open.\n", name)
+	sb.WriteString("   */\n")
+	sb.WriteString("  fetch(path: string, init?: RequestInit): Promise<Response>;\n")
+	sb.WriteString("}\n\n")
+
+	fmt.Fprintf(&sb, "const retries = %d;\n", s.Retries)
+	fmt.Fprintf(&sb, "const backoffMs = %d;\n", s.Backoff())
+	fmt.Fprintf(&sb, "const failureThreshold = %d;\n", s.Threshold())
+	fmt.Fprintf(&sb, "const resetMs = %d;\n\n", s.Reset())
+
+	sb.WriteString("// Sending these twice has the same effect as sending them once\n")
+	sb.WriteString("const idempotentMethods = new Set(['GET', 'HEAD', 'OPTIONS', 'PUT', 'DELETE']);\n\n")
+
+	sb.WriteString("// Client errors other than timeouts and rate limits are the caller's, not the service's\n")
+	sb.WriteString("function isFailure(status: number): boolean {\n")
+	sb.WriteString("  return status >= 500 || status === 408 || status === 429;\n")
+	sb.WriteString("}\n\n")
+
+	fmt.Fprintf(&sb, "export function create%s(): %s {\n", name, name)
+	fmt.Fprintf(&sb, "  const baseUrl = process.env.%s;\n", s.BaseURLEnv)
+	sb.WriteString("  if (!baseUrl) {\n")
+	fmt.Fprintf(&sb, "    throw new Error('%s environment variable is required');\n", s.BaseURLEnv)
+	sb.WriteString("  }\n\n")
+	sb.WriteString("  // The circuit opens after failureThreshold consecutive failed attempts. After\n")
+	sb.WriteString("  // resetMs requests go through again: a success closes it, a failure reopens it.\n")
+	sb.WriteString("  let failures = 0;\n")
+	sb.WriteString("  let openUntil = 0;\n\n")
+	sb.WriteString("  return {\n")
+	sb.WriteString("    async fetch(path, init = {}) {\n")
+	sb.WriteString("      const url = baseUrl.replace(/\\/+$/, '') + path;\n")
+	sb.WriteString("      const method = (init.method ?? 'GET').toUpperCase();\n")
+	sb.WriteString("      const attempts = idempotentMethods.has(method) ? retries + 1 : 1;\n")
+	sb.WriteString("      let last: Response | undefined;\n")
+	sb.WriteString("      let lastError: unknown;\n")
+	sb.WriteString("      for (let attempt = 1; attempt <= attempts; attempt++) {\n")
+	sb.WriteString("        if (attempt > 1) {\n")
+	sb.WriteString("          await new Promise((resolve) => setTimeout(resolve, backoffMs * 2 ** (attempt - 2)));\n")
+	sb.WriteString("        }\n")
+	sb.WriteString("        if (failures >= failureThreshold && Date.now() < openUntil) {\n")
+	fmt.Fprintf(&sb, "          throw new %sCircuitOpenError();\n", name)
+	sb.WriteString("        }\n")
+	sb.WriteString("        try {\n")
+	sb.WriteString("          last = await globalThis.fetch(url, init);\n")
+	sb.WriteString("          lastError = undefined;\n")
+	sb.WriteString("          if (!isFailure(last.status)) {\n")
+	sb.WriteString("            failures = 0;\n")
+	sb.WriteString("            return last;\n")
+	sb.WriteString("          }\n")
+	sb.WriteString("        } catch (error) {\n")
+	sb.WriteString("          last = undefined;\n")
+	sb.WriteString("          lastError = error;\n")
+	sb.WriteString("        }\n")
+	sb.WriteString("        failures++;\n")
+	sb.WriteString("        if (failures >= failureThreshold) {\n")
+	sb.WriteString("          openUntil = Date.now() + resetMs;\n")
+	sb.WriteString("        }\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      if (last) {\n")
+	sb.WriteString("        return last;\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      throw lastError;\n")
+	sb.WriteString("    },\n")
+	sb.WriteString("  };\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// generateTest renders the vitest suite of a client. It mocks the global
+// fetch and runs the backoff on fake timers, and only asserts what the
+// client's own retries and threshold allow: a single-failure threshold opens
+// the circuit before any retry.
+func (g *HTTPClientGenerator) generateTest(client *ir.Component) string {
+	var sb strings.Builder
+	s := client.HTTPClient
+	name := httpClientTypeName(client)
+	module := strings.TrimSuffix(strings.TrimPrefix(httpClientSourcePath(client.ID), "src/components/"), ".ts")
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("import { afterEach, beforeEach, describe, expect, it, vi } from 'vitest';\n")
+	fmt.Fprintf(&sb, "import { create%s, %sCircuitOpenError, type %s } from './%s';\n\n", name, name, name, module)
+
+	fmt.Fprintf(&sb, "describe('%s', () => {\n", client.ID)
+	sb.WriteString("  const fetchMock = vi.fn();\n\n")
+	sb.WriteString("  beforeEach(() => {\n")
+	sb.WriteString("    vi.useFakeTimers();\n")
+	fmt.Fprintf(&sb, "    vi.stubEnv('%s', 'http://service.test');\n", s.BaseURLEnv)
+	sb.WriteString("    vi.stubGlobal('fetch', fetchMock);\n")
+	sb.WriteString("  });\n\n")
+	sb.WriteString("  afterEach(() => {\n")
+	sb.WriteString("    fetchMock.mockReset();\n")
+	sb.WriteString("    vi.unstubAllGlobals();\n")
+	sb.WriteString("    vi.unstubAllEnvs();\n")
+	sb.WriteString("    vi.useRealTimers();\n")
+	sb.WriteString("  });\n\n")
+	sb.WriteString("  // Sends a request and runs the backoff between its attempts\n")
+	fmt.Fprintf(&sb, "  async function send(client: %s, path: string, init?: RequestInit): Promise<Response> {\n", name)
+	sb.WriteString("    const response = client.fetch(path, init);\n")
+	sb.WriteString("    response.catch(() => {}); // Rejections are asserted by the caller\n")
+	sb.WriteString("    await vi.runAllTimersAsync();\n")
+	sb.WriteString("    return response;\n")
+	sb.WriteString("  }\n\n")
+
+	if s.Retries > 0 && s.Threshold() > 1 {
+		sb.WriteString("  it('retries a failed GET', async () => {\n")
+		sb.WriteString("    fetchMock.mockResolvedValueOnce(new Response(null, { status: 503 }));\n")
+		sb.WriteString("    fetchMock.mockResolvedValueOnce(new Response(null, { status: 200 }));\n")
+		fmt.Fprintf(&sb, "    const client = create%s();\n\n", name)
+		sb.WriteString("    const res = await send(client, '/items');\n\n")
+		sb.WriteString("    expect(res.status).toBe(200);\n")
+		sb.WriteString("    expect(fetchMock).toHaveBeenCalledTimes(2);\n")
+		sb.WriteString("    expect(fetchMock).toHaveBeenCalledWith('http://service.test/items', {});\n")
+		sb.WriteString("  });\n\n")
+	}
+
+	if attempts := s.Retries + 1; attempts <= s.Threshold() {
+		sb.WriteString("  it('returns the last response when every attempt failed', async () => {\n")
+		sb.WriteString("    fetchMock.mockResolvedValue(new Response(null, { status: 503 }));\n")
+		fmt.Fprintf(&sb, "    const client = create%s();\n\n", name)
+		sb.WriteString("    const res = await send(client, '/items');\n\n")
+		sb.WriteString("    expect(res.status).toBe(503);\n")
+		fmt.Fprintf(&sb, "    expect(fetchMock).toHaveBeenCalledTimes(%d);\n", attempts)
+		sb.WriteString("  });\n\n")
+	}
+
+	sb.WriteString("  it('does not retry a POST', async () => {\n")
+	sb.WriteString("    fetchMock.mockResolvedValue(new Response(null, { status: 503 }));\n")
+	fmt.Fprintf(&sb, "    const client = create%s();\n\n", name)
+	sb.WriteString("    const res = await send(client, '/items', { method: 'POST' });\n\n")
+	sb.WriteString("    expect(res.status).toBe(503);\n")
+	sb.WriteString("    expect(fetchMock).toHaveBeenCalledTimes(1);\n")
+	sb.WriteString("  });\n\n")
+
+	sb.WriteString("  it('opens the circuit at the failure threshold and closes it after a success', async () => {\n")
+	sb.WriteString("    fetchMock.mockRejectedValue(new TypeError('fetch failed'));\n")
+	fmt.Fprintf(&sb, "    const client = create%s();\n", name)
+	fmt.Fprintf(&sb, "    for (let n = 0; n < %d; n++) {\n", s.Threshold())
+	sb.WriteString("      await expect(send(client, '/items', { method: 'POST' })).rejects.toThrow(TypeError);\n")
+	sb.WriteString("    }\n\n")
+	fmt.Fprintf(&sb, "    await expect(send(client, '/items', { method: 'POST' })).rejects.toThrow(%sCircuitOpenError);\n", name)
+	fmt.Fprintf(&sb, "    expect(fetchMock).toHaveBeenCalledTimes(%d);\n\n", s.Threshold())
+	fmt.Fprintf(&sb, "    vi.advanceTimersByTime(%d);\n", s.Reset())
+	sb.WriteString("    fetchMock.mockResolvedValue(new Response(null, { status: 200 }));\n")
+	sb.WriteString("    expect((await send(client, '/items', { method: 'POST' })).status).toBe(200);\n")
+	sb.WriteString("    expect((await send(client, '/items', { method: 'POST' })).status).toBe(200);\n")
+	sb.WriteString("  });\n")
+	sb.WriteString("});\n")
+
+	return sb.String()
+}
+
+// httpClientComponents returns every http.client component, sorted by ID.
+func httpClientComponents(i *ir.IR) []*ir.Component {
+	var clients []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindHTTPClient && comp.HTTPClient != nil {
+			clients = append(clients, comp)
+		}
+	}
+	sort.Slice(clients, func(a, b int) bool {
+		return clients[a].ID < clients[b].ID
+	})
+	return clients
+}
+
+// usecaseHTTPClients returns the http.clients a usecase calls, sorted by ID.
+func usecaseHTTPClients(i *ir.IR, uc *ir.Component) []*ir.Component {
+	if uc == nil || uc.Usecase == nil {
+		return nil
+	}
+	var clients []*ir.Component
+	for _, ref := range uc.Usecase.Calls {
+		if client, ok := i.Components[ref]; ok && client.Kind == ir.KindHTTPClient && client.HTTPClient != nil {
+			clients = append(clients, client)
+		}
+	}
+	sort.Slice(clients, func(a, b int) bool {
+		return clients[a].ID < clients[b].ID
+	})
+	return clients
+}
+
+// serverHTTPClients returns the http.clients the usecases bound to a server
+// call, sorted by ID; the server's context holds a client for each.
+func serverHTTPClients(i *ir.IR, server *ir.Component) []*ir.Component {
+	seen := make(map[string]bool)
+	var clients []*ir.Component
+	for _, uc := range i.UsecasesBoundTo(server.ID) {
+		for _, client := range usecaseHTTPClients(i, uc) {
+			if !seen[client.ID] {
+				seen[client.ID] = true
+				clients = append(clients, client)
+			}
+		}
+	}
+	sort.Slice(clients, func(a, b int) bool {
+		return clients[a].ID < clients[b].ID
+	})
+	return clients
+}
+
+// httpClientContextField returns the context field holding an http.client,
+// named after its component (e.g., "http.client.payments" -> "paymentsClient").
+func httpClientContextField(client *ir.Component) string {
+	return componentNameCamel(client.ID) + "Client"
+}
+
+// httpClientTypeName returns the type name of an http.client
+// (e.g., "http.client.payments" -> "PaymentsClient").
+func httpClientTypeName(client *ir.Component) string {
+	return titleCase(httpClientContextField(client))
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// withHTTPClient adds http.client.payments to the test IR and has
+// usecase.create-user call it.
+func withHTTPClient(spec *ir.HTTPClientSpec) testIRFeature {
+	return func(i *ir.IR) {
+		i.Components["http.client.payments"] = &ir.Component{
+			ID:         "http.client.payments",
+			Kind:       ir.KindHTTPClient,
+			HTTPClient: spec,
+		}
+		i.Components["usecase.create-user"].Usecase.Calls = []string{"http.client.payments"}
+	}
+}
+
+func TestHTTPClientGenerator_Name(t *testing.T) {
+	g := NewHTTPClientGenerator()
+	if got := g.Name(); got != "typescript-http-clients" {
+		t.Errorf("Name() = %v, want %v", got, "typescript-http-clients")
+	}
+}
+
+func TestHTTPClientGenerator_ImplementsComponentGenerator(t *testing.T) {
+	var _ codegen.ComponentGenerator = NewHTTPClientGenerator()
+}
+
+func TestHTTPClientGenerator_Generate_Client(t *testing.T) {
+	// given
+	i := newTestIR(withHTTPClient(&ir.HTTPClientSpec{BaseURLEnv: "PAYMENTS_URL", Retries: 3, FailureThreshold: 10}))
+
+	// when
+	output, err := NewHTTPClientGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	file, ok := output.Files["src/components/http-client-payments.client.ts"]
+	if !ok {
+		t.Fatal("client not generated")
+	}
+	content := string(file.Content)
+	for _, want := range []string{
+		"export class PaymentsClientCircuitOpenError extends Error {\n",
+		"export interface PaymentsClient {\n",
+		"fetch(path: string, init?: RequestInit): Promise<Response>;",
+		"const retries = 3;\n",
+		"const backoffMs = 200;\n",
+		"const failureThreshold = 10;\n",
+		"const resetMs = 30000;\n",
+		"export function createPaymentsClient(): PaymentsClient {\n",
+		"  const baseUrl = process.env.PAYMENTS_URL;\n",
+		"    throw new Error('PAYMENTS_URL environment variable is required');\n",
+		"idempotentMethods.has(method) ? retries + 1 : 1",
+		"backoffMs * 2 ** (attempt - 2)",
+		"throw new PaymentsClientCircuitOpenError();",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("client missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestHTTPClientGenerator_Generate_Tests(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *ir.HTTPClientSpec
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "defaults",
+			spec: &ir.HTTPClientSpec{BaseURLEnv: "PAYMENTS_URL", Retries: ir.DefaultHTTPClientRetries},
+			want: []string{
+				"import { createPaymentsClient, PaymentsClientCircuitOpenError, type PaymentsClient } from './http-client-payments.client';",
+				"    vi.stubEnv('PAYMENTS_URL', 'http://service.test');\n",
+				"  it('retries a failed GET', async () => {\n",
+				"    expect(fetchMock).toHaveBeenCalledTimes(3);\n",
+				"  it('does not retry a POST', async () => {\n",
+				"    for (let n = 0; n < 5; n++) {\n",
+				"    vi.advanceTimersByTime(30000);\n",
+			},
+		},
+		{
+			name:    "without retries",
+			spec:    &ir.HTTPClientSpec{BaseURLEnv: "PAYMENTS_URL"},
+			want:    []string{"  it('returns the last response when every attempt failed', async () => {\n"},
+			notWant: []string{"retries a failed GET"},
+		},
+		{
+			name:    "circuit opens before the retries are used up",
+			spec:    &ir.HTTPClientSpec{BaseURLEnv: "PAYMENTS_URL", Retries: 3, FailureThreshold: 1},
+			want:    []string{"    for (let n = 0; n < 1; n++) {\n"},
+			notWant: []string{"retries a failed GET", "returns the last response"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			i := newTestIR(withHTTPClient(tt.spec))
+
+			// when
+			output, err := NewHTTPClientGenerator().Generate(i)
+
+			// then
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			file, ok := output.Files["src/components/http-client-payments.client.test.ts"]
+			if !ok {
+				t.Fatal("client tests not generated")
+			}
+			content := string(file.Content)
+			for _, want := range tt.want {
+				if !strings.Contains(content, want) {
+					t.Errorf("tests missing %q\n%s", want, content)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(content, notWant) {
+					t.Errorf("tests should not contain %q\n%s", notWant, content)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPClientGenerator_Generate_NoClients(t *testing.T) {
+	// given
+	i := createTestIR()
+
+	// when
+	output, err := NewHTTPClientGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(output.Files) != 0 {
+		t.Errorf("Generate() produced %d files, expected none", len(output.Files))
+	}
+}
+
+func TestHTTPClient_ServerWiring(t *testing.T) {
+	// given
+	i := newTestIR(withHTTPClient(&ir.HTTPClientSpec{BaseURLEnv: "PAYMENTS_URL"}))
+
+	// when
+	server, err := NewHonoServerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	context, err := NewContextGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	ctx := string(context.Files["src/components/http-server-api.context.ts"].Content)
+	for _, want := range []string{
+		"import type { PaymentsClient } from './http-client-payments.client';",
+		"  paymentsClient: PaymentsClient;\n",
+		"'paymentsClient'",
+	} {
+		if !strings.Contains(ctx, want) {
+			t.Errorf("context missing %q\n%s", want, ctx)
+		}
+	}
+	index := string(server.Files["src/index.ts"].Content)
+	for _, want := range []string{
+		"import { createPaymentsClient } from './components/http-client-payments.client';",
+		"  const paymentsClient = createPaymentsClient();\n",
+		"    paymentsClient,\n",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index missing %q\n%s", want, index)
+		}
+	}
+	srv := string(server.Files["src/components/http-server-api.server.ts"].Content)
+	if !strings.Contains(srv, "    c.set('paymentsClient', ctx.paymentsClient);\n") {
+		t.Errorf("server should set the client on the request context\n%s", srv)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// httpFixturesPath is the module that records and replays the server's calls
+// to external APIs.
+const httpFixturesPath = "src/http-fixtures.ts"
+
+// httpFixturesDir is where recorded responses are kept, relative to the
+// project root. The files are written by the tests, not the generator, and
+// belong in version control.
+const httpFixturesDir = "e2e/fixtures/http"
+
+// httpFixturesEnvVar is the variable that switches the server to recording
+// or replaying its calls to external APIs.
+func httpFixturesEnvVar(i *ir.IR) string {
+	return i.EnvVar("HTTP_FIXTURES")
+}
+
+// httpFixturesMode returns the mode the E2E tests run the server in when the
+// environment does not set one: the spec's testing.http_fixtures, or "" when
+// it is unset or no external or http.client component is declared for the
+// server to call.
+func httpFixturesMode(i *ir.IR) string {
+	if i.Spec == nil || i.Spec.Testing == nil || i.Spec.Testing.HTTPFixtures == "" {
+		return ""
+	}
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindExternal || comp.Kind == ir.KindHTTPClient {
+			return i.Spec.Testing.HTTPFixtures
+		}
+	}
+	return ""
+}
+
+// writeHTTPFixturesEnv writes the entry that passes the fixture mode to the
+// server the E2E tests start, keeping a mode set in the environment.
+func writeHTTPFixturesEnv(sb *strings.Builder, i *ir.IR, indent string) {
+	envVar := httpFixturesEnvVar(i)
+	fmt.Fprintf(sb, "%s%s: process.env.%s ?? '%s',\n", indent, envVar, envVar, httpFixturesMode(i))
+}
+
+// generateHTTPFixtures generates the fetch interceptor the server entrypoint
+// installs when the fixture mode is set.
+func generateHTTPFixtures(i *ir.IR) string {
+	var sb strings.Builder
+
+	envVar := httpFixturesEnvVar(i)
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("// Records the server's calls to external APIs during E2E tests and replays\n")
+	sb.WriteString("// them, so CI runs deterministically without reaching the APIs. With\n")
+	fmt.Fprintf(&sb, "// %s=record every call goes out and its response is saved to\n", envVar)
+	fmt.Fprintf(&sb, "// %s; with %s=replay the saved response is returned and\n", httpFixturesDir, envVar)
+	sb.WriteString("// a call without one fails. Calls to localhost pass through in both modes.\n")
+	sb.WriteString("import { createHash } from 'crypto';\n")
+	sb.WriteString("import { existsSync, mkdirSync, readFileSync, writeFileSync } from 'fs';\n")
+	sb.WriteString("import { dirname, join } from 'path';\n\n")
+
+	sb.WriteString("export type HttpFixturesMode = 'record' | 'replay';\n\n")
+
+	sb.WriteString("interface Fixture {\n")
+	sb.WriteString("  request: { method: string; url: string; body?: string };\n")
+	sb.WriteString("  response: { status: number; headers: Record<string, string>; body: string };\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("const localHosts = new Set(['localhost', '127.0.0.1', '[::1]']);\n\n")
+	sb.WriteString("// Statuses whose responses cannot have a body\n")
+	sb.WriteString("const nullBodyStatuses = new Set([101, 204, 205, 304]);\n\n")
+
+	sb.WriteString("/**\n")
+	sb.WriteString(" * Returns the file of a call: one directory per host, and a name from the\n")
+	sb.WriteString(" * method and a hash of the URL and body.\n")
+	sb.WriteString(" */\n")
+	sb.WriteString("function fixturePath(dir: string, method: string, url: URL, body: string | undefined): string {\n")
+	sb.WriteString("  const hash = createHash('sha256').update(`${method} ${url.href}\\n${body ?? ''}`).digest('hex').slice(0, 16);\n")
+	sb.WriteString("  return join(dir, url.host.replace(/[^A-Za-z0-9.-]/g, '_'), `${method.toLowerCase()}-${hash}.json`);\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/**\n")
+	sb.WriteString(" * Replaces the global fetch with one that records or replays calls to\n")
+	sb.WriteString(" * hosts other than localhost.\n")
+	sb.WriteString(" */\n")
+	fmt.Fprintf(&sb, "export function installHttpFixtures(mode: HttpFixturesMode, dir = '%s'): void {\n", httpFixturesDir)
+	sb.WriteString("  const realFetch = globalThis.fetch;\n")
+	sb.WriteString("  globalThis.fetch = async (input, init) => {\n")
+	sb.WriteString("    const request = new Request(input, init);\n")
+	sb.WriteString("    const url = new URL(request.url);\n")
+	sb.WriteString("    if (localHosts.has(url.hostname)) {\n")
+	sb.WriteString("      return realFetch(request);\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("    const body = request.body ? await request.clone().text() : undefined;\n")
+	sb.WriteString("    const file = fixturePath(dir, request.method, url, body);\n\n")
+
+	sb.WriteString("    if (mode === 'replay') {\n")
+	sb.WriteString("      if (!existsSync(file)) {\n")
+	fmt.Fprintf(&sb, "        throw new Error(`No recorded response for ${request.method} ${url.href} in ${file}; run the E2E tests with %s=record`);\n", envVar)
+	sb.WriteString("      }\n")
+	sb.WriteString("      const fixture: Fixture = JSON.parse(readFileSync(file, 'utf8'));\n")
+	sb.WriteString("      const { status, headers } = fixture.response;\n")
+	sb.WriteString("      return new Response(nullBodyStatuses.has(status) ? null : fixture.response.body, { status, headers });\n")
+	sb.WriteString("    }\n\n")
+
+	sb.WriteString("    const response = await realFetch(request);\n")
+	sb.WriteString("    // The body is saved decoded, so it no longer matches these headers\n")
+	sb.WriteString("    const headers = Object.fromEntries(response.headers);\n")
+	sb.WriteString("    delete headers['content-encoding'];\n")
+	sb.WriteString("    delete headers['content-length'];\n")
+	sb.WriteString("    const fixture: Fixture = {\n")
+	sb.WriteString("      request: { method: request.method, url: url.href, body },\n")
+	sb.WriteString("      response: { status: response.status, headers, body: await response.clone().text() },\n")
+	sb.WriteString("    };\n")
+	sb.WriteString("    mkdirSync(dirname(file), { recursive: true });\n")
+	sb.WriteString("    writeFileSync(file, JSON.stringify(fixture, null, 2) + '\\n');\n")
+	sb.WriteString("    return response;\n")
+	sb.WriteString("  };\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+// withHTTPFixtures declares an external API and sets the spec's fixture mode.
+func withHTTPFixtures(mode string) testIRFeature {
+	return func(i *ir.IR) {
+		i.Components["external.payments"] = &ir.Component{
+			ID:       "external.payments",
+			Kind:     ir.KindExternal,
+			External: &ir.ExternalSpec{Properties: map[string]any{}},
+		}
+		i.Spec.Testing = &parser.Testing{HTTPFixtures: mode}
+	}
+}
+
+func TestHonoServerGenerator_HTTPFixtures(t *testing.T) {
+	// given
+	i := newTestIR(withHTTPFixtures("replay"))
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	fixtures, ok := output.Files["src/http-fixtures.ts"]
+	if !ok {
+		t.Fatal("missing src/http-fixtures.ts")
+	}
+	for _, want := range []string{
+		"export function installHttpFixtures(mode: HttpFixturesMode, dir = 'e2e/fixtures/http'): void {\n",
+		"run the E2E tests with HTTP_FIXTURES=record",
+		"    if (localHosts.has(url.hostname)) {\n      return realFetch(request);\n",
+	} {
+		if !strings.Contains(string(fixtures.Content), want) {
+			t.Errorf("http-fixtures.ts missing %q\n%s", want, fixtures.Content)
+		}
+	}
+	index := string(output.Files["src/index.ts"].Content)
+	for _, want := range []string{
+		"import { installHttpFixtures } from './http-fixtures';\n",
+		"  const httpFixtures = process.env.HTTP_FIXTURES;\n" +
+			"  if (httpFixtures === 'record' || httpFixtures === 'replay') {\n" +
+			"    installHttpFixtures(httpFixtures);\n",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index.ts missing %q\n%s", want, index)
+		}
+	}
+}
+
+func TestE2ETestGenerator_HTTPFixtures(t *testing.T) {
+	// given
+	i := newTestIR(withHTTPFixtures("record"))
+
+	// when
+	output, err := NewE2ETestGenerator().Generate(i)
+
+	// then: the server the tests start gets the spec's mode by default
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	setup := string(output.Files["e2e/global-setup.ts"].Content)
+	if want := "    HTTP_FIXTURES: process.env.HTTP_FIXTURES ?? 'record',\n"; !strings.Contains(setup, want) {
+		t.Errorf("global setup missing %q\n%s", want, setup)
+	}
+
+	// given: no Docker services, so Playwright starts the server
+	delete(i.Components, "postgres.primary")
+
+	// when
+	output, err = NewE2ETestGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	config := string(output.Files["playwright.config.ts"].Content)
+	if want := "    env: {\n      HTTP_FIXTURES: process.env.HTTP_FIXTURES ?? 'record',\n    },\n"; !strings.Contains(config, want) {
+		t.Errorf("Playwright config missing %q\n%s", want, config)
+	}
+}
+
+func TestHTTPFixturesMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		ir       func() *ir.IR
+		expected string
+	}{
+		{"unset", createTestIR, ""},
+		{"external and mode", func() *ir.IR { return newTestIR(withHTTPFixtures("replay")) }, "replay"},
+		{"mode without external", func() *ir.IR {
+			i := newTestIR(withHTTPFixtures("replay"))
+			delete(i.Components, "external.payments")
+			return i
+		}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			mode := httpFixturesMode(tt.ir())
+
+			// then
+			if mode != tt.expected {
+				t.Errorf("httpFixturesMode() = %q, expected %q", mode, tt.expected)
+			}
+		})
+	}
+}
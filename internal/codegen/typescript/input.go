@@ -0,0 +1,111 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// mappedInputField is a field of a usecase input set by input_mapping; an
+// optional one may be undefined.
+type mappedInputField struct {
+	ir.MappedInput
+}
+
+// mappedInputFields returns the fields of a usecase's input_mapping, nil
+// when it has none.
+func mappedInputFields(i *ir.IR, uc *ir.Component) []mappedInputField {
+	inputs := i.MappedInputs(uc)
+	if inputs == nil {
+		return nil
+	}
+	fields := make([]mappedInputField, len(inputs))
+	for n, in := range inputs {
+		fields[n] = mappedInputField{in}
+	}
+	return fields
+}
+
+// readsBody reports whether any field of an input_mapping reads the body.
+func readsBody(fields []mappedInputField) bool {
+	for _, f := range fields {
+		if f.Source == ir.InputFromBody {
+			return true
+		}
+	}
+	return false
+}
+
+// mapsRequestType reports whether a field takes its type from the
+// operation's request type: an untransformed body field.
+func mapsRequestType(fields []mappedInputField) bool {
+	for _, f := range fields {
+		if f.Source == ir.InputFromBody && f.Transform == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// tsType returns the TypeScript type of the field. Untransformed body fields
+// take their type from requestType, the operation's request type, when the
+// usecase has one.
+func (f mappedInputField) tsType(requestType string) string {
+	switch f.Transform {
+	case "number":
+		return "number"
+	case "trim", "lowercase", "uppercase":
+		return "string"
+	}
+	if f.Source != ir.InputFromBody {
+		return "string"
+	}
+	if requestType == "" {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s[%s]", requestType, tsLiteral(f.Name))
+}
+
+// expr returns the expression a route handler reads the field's value with,
+// the request body being parsed into body.
+func (f mappedInputField) expr() string {
+	var raw string
+	switch f.Source {
+	case ir.InputFromPath:
+		raw = fmt.Sprintf("c.req.param('%s')", f.Name)
+	case ir.InputFromQuery:
+		raw = fmt.Sprintf("c.req.query('%s')", f.Name)
+		if !f.Optional {
+			raw += "!"
+		}
+	default:
+		if tsIdentifierPattern.MatchString(f.Name) {
+			raw = "body." + f.Name
+		} else {
+			raw = fmt.Sprintf("body[%s]", tsLiteral(f.Name))
+		}
+	}
+
+	// Body values are untyped until the usecase receives them
+	chain := "."
+	if f.Optional || f.Source == ir.InputFromBody {
+		chain = "?."
+	}
+	switch f.Transform {
+	case "trim":
+		return raw + chain + "trim()"
+	case "lowercase":
+		return raw + chain + "toLowerCase()"
+	case "uppercase":
+		return raw + chain + "toUpperCase()"
+	case "number":
+		if !f.Optional {
+			return fmt.Sprintf("Number(%s)", raw)
+		}
+		return fmt.Sprintf("%[1]s == null ? undefined : Number(%[1]s)", raw)
+	}
+	return raw
+}
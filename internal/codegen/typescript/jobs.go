@@ -0,0 +1,273 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// jobStatuses are the statuses of a job of an async usecase, in the order
+// it goes through them.
+var jobStatuses = []string{"pending", "running", "succeeded", "failed"}
+
+// usecaseJobsPath is the module async routes start and report jobs with.
+func usecaseJobsPath() string {
+	return "src/components/usecase.jobs.ts"
+}
+
+// usecaseJobsSchemaPath is the Drizzle schema of the jobs table, pushed to
+// every database that keeps jobs.
+func usecaseJobsSchemaPath() string {
+	return "src/components/usecase.jobs.schema.ts"
+}
+
+// isAsync reports whether a usecase runs as a job its route answers 202
+// with.
+func isAsync(uc *ir.Component) bool {
+	return uc.Usecase != nil && uc.Usecase.JobStatus() != nil && !uc.Usecase.Binding.IsCatchAll()
+}
+
+// hasAsyncUsecases reports whether any usecase is an async usecase.
+func hasAsyncUsecases(i *ir.IR) bool {
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindUsecase && isAsync(comp) {
+			return true
+		}
+	}
+	return false
+}
+
+// jobsDatabase returns the database an async usecase keeps its jobs in:
+// the first one it uses, or nil if it uses none.
+func jobsDatabase(i *ir.IR, uc *ir.Component, server *ir.Component) *ir.Component {
+	deps := usecasePostgresDependencies(i, uc, server)
+	if len(deps) == 0 {
+		return nil
+	}
+	return deps[0]
+}
+
+// holdsJobs reports whether any async usecase keeps its jobs in pg.
+func holdsJobs(i *ir.IR, pg *ir.Component) bool {
+	for _, uc := range sortedComponents(i) {
+		if uc.Kind != ir.KindUsecase || !isAsync(uc) {
+			continue
+		}
+		if jobsDatabase(i, uc, i.Components[uc.Usecase.Binding.ServerID]) == pg {
+			return true
+		}
+	}
+	return false
+}
+
+// checkJobsDatabase reports a database keeping jobs whose provider the
+// generated jobs module cannot query.
+func checkJobsDatabase(i *ir.IR, pg *ir.Component) error {
+	if pg.Postgres.Provider == "drizzle" || !holdsJobs(i, pg) {
+		return nil
+	}
+	return fmt.Errorf("component %q: async usecases keep their jobs with the drizzle provider, not %q",
+		pg.ID, pg.Postgres.Provider)
+}
+
+// generateJobsSchema generates the Drizzle schema of the jobs table.
+func generateJobsSchema() string {
+	var sb strings.Builder
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("// Jobs of async usecases\n")
+	sb.WriteString("import { pgTable, text, timestamp, jsonb } from 'drizzle-orm/pg-core';\n\n")
+
+	sb.WriteString("/** Statuses of a job, in the order it goes through them. */\n")
+	quoted := make([]string, len(jobStatuses))
+	for n, status := range jobStatuses {
+		quoted[n] = "'" + status + "'"
+	}
+	fmt.Fprintf(&sb, "export const jobStatuses = [%s] as const;\n\n", strings.Join(quoted, ", "))
+
+	sb.WriteString("export const jobs = pgTable('jobs', {\n")
+	sb.WriteString("  id: text('id').primaryKey(),\n")
+	sb.WriteString("  usecase: text('usecase').notNull(),\n")
+	sb.WriteString("  status: text('status', { enum: jobStatuses }).notNull().default('pending'),\n")
+	sb.WriteString("  result: jsonb('result'),\n")
+	sb.WriteString("  error: text('error'),\n")
+	sb.WriteString("  createdAt: timestamp('created_at').notNull().defaultNow(),\n")
+	sb.WriteString("  updatedAt: timestamp('updated_at').notNull().defaultNow(),\n")
+	sb.WriteString("});\n")
+
+	return sb.String()
+}
+
+// generateJobsModule generates the functions async routes start jobs with
+// and their status routes read them with.
+func generateJobsModule() string {
+	var sb strings.Builder
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("// Jobs of async usecases. Their routes answer 202 with a pending job, run\n")
+	sb.WriteString("// the usecase in the background and record how it ends in the jobs table.\n")
+	sb.WriteString("import { randomUUID } from 'crypto';\n")
+	sb.WriteString("import { and, eq } from 'drizzle-orm';\n")
+	fmt.Fprintf(&sb, "import type { DrizzleClient } from '%s';\n", postgresClientImportPath())
+	sb.WriteString("import { jobs, jobStatuses } from './usecase.jobs.schema';\n\n")
+
+	sb.WriteString("/** Status of a job: pending until it runs, then succeeded or failed. */\n")
+	sb.WriteString("export type JobStatus = (typeof jobStatuses)[number];\n\n")
+
+	sb.WriteString("/** A job of an async usecase, as its status route reports it. */\n")
+	sb.WriteString("export interface Job<T = unknown> {\n")
+	sb.WriteString("  id: string;\n")
+	sb.WriteString("  status: JobStatus;\n")
+	sb.WriteString("  result?: T;\n")
+	sb.WriteString("  error?: string;\n")
+	sb.WriteString("  createdAt: string;\n")
+	sb.WriteString("  updatedAt: string;\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/**\n")
+	sb.WriteString(" * Records a pending job of a usecase and runs it in the background. The job\n")
+	sb.WriteString(" * is running while run is, then succeeded with its result or failed with its\n")
+	sb.WriteString(" * error.\n")
+	sb.WriteString(" */\n")
+	sb.WriteString("export async function startJob<T>(db: DrizzleClient, usecase: string, run: () => Promise<T>): Promise<Job<T>> {\n")
+	sb.WriteString("  const [row] = await db.insert(jobs).values({ id: randomUUID(), usecase }).returning();\n")
+	sb.WriteString("  const update = (values: Partial<typeof jobs.$inferInsert>) =>\n")
+	sb.WriteString("    db.update(jobs).set({ ...values, updatedAt: new Date() }).where(eq(jobs.id, row.id));\n")
+	sb.WriteString("  void (async () => {\n")
+	sb.WriteString("    await update({ status: 'running' });\n")
+	sb.WriteString("    try {\n")
+	sb.WriteString("      await update({ status: 'succeeded', result: await run() });\n")
+	sb.WriteString("    } catch (error) {\n")
+	sb.WriteString("      await update({ status: 'failed', error: error instanceof Error ? error.message : String(error) });\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("  })().catch((error) => console.error(`Job ${row.id} of ${usecase} could not be recorded:`, error));\n")
+	sb.WriteString("  return toJob<T>(row);\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/** Returns the job of a usecase with an ID, or null if it has none. */\n")
+	sb.WriteString("export async function getJob<T>(db: DrizzleClient, usecase: string, id: string): Promise<Job<T> | null> {\n")
+	sb.WriteString("  const [row] = await db.select().from(jobs).where(and(eq(jobs.id, id), eq(jobs.usecase, usecase)));\n")
+	sb.WriteString("  return row ? toJob<T>(row) : null;\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("function toJob<T>(row: typeof jobs.$inferSelect): Job<T> {\n")
+	sb.WriteString("  const job: Job<T> = {\n")
+	sb.WriteString("    id: row.id,\n")
+	sb.WriteString("    status: row.status,\n")
+	sb.WriteString("    createdAt: row.createdAt.toISOString(),\n")
+	sb.WriteString("    updatedAt: row.updatedAt.toISOString(),\n")
+	sb.WriteString("  };\n")
+	sb.WriteString("  if (row.result !== null) {\n")
+	sb.WriteString("    job.result = row.result as T;\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("  if (row.error !== null) {\n")
+	sb.WriteString("    job.error = row.error;\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("  return job;\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// writeStartJob writes how an async route answers: 202 with the job that
+// runs its usecase, and where its status route reports it.
+func writeStartJob(sb *strings.Builder, i *ir.IR, uc *ir.Component, server *ir.Component, call string) {
+	db := postgresContextField(i, jobsDatabase(i, uc, server))
+	fmt.Fprintf(sb, "    const job = await startJob(c.get('%s'), '%s', () => %s);\n", db, uc.ID, call)
+	sb.WriteString("    c.header('Location', `${c.req.path}/jobs/${job.id}`);\n")
+	sb.WriteString("    return c.json(job, 202);\n")
+}
+
+// writeJobStatusRoute writes the route that reports the jobs of an async
+// usecase. It runs the usecase's middleware and authorization, and only
+// finds the usecase's own jobs.
+func (g *HonoServerGenerator) writeJobStatusRoute(sb *strings.Builder, i *ir.IR, uc *ir.Component, server *ir.Component) {
+	status := uc.Usecase.JobStatus()
+	fmt.Fprintf(sb, "\n  // %s - status of its jobs\n", uc.ID)
+	fmt.Fprintf(sb, "  app.get('%s', async (c) => {\n", convertPathParams(status.Path))
+	writeAuthorizationCheck(sb, i, uc, server)
+	db := postgresContextField(i, jobsDatabase(i, uc, server))
+	fmt.Fprintf(sb, "    const job = await getJob(c.get('%s'), '%s', c.req.param('jobId'));\n", db, uc.ID)
+	sb.WriteString("    if (!job) {\n")
+	sb.WriteString("      return c.json({ error: 'Not Found' }, 404);\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("    return c.json(job);\n")
+	sb.WriteString("  });\n")
+}
+
+// writeJobStatusOperation documents the status route of an async usecase.
+func writeJobStatusOperation(sb *strings.Builder, uc *ir.Component, server *ir.Component, operationID string) {
+	sb.WriteString("    get:\n")
+	fmt.Fprintf(sb, "      operationId: %sJob\n", operationID)
+	fmt.Fprintf(sb, "      summary: Status of a job of %s\n", uc.ID)
+	sb.WriteString("      tags:\n")
+	fmt.Fprintf(sb, "        - %s\n", server.ID)
+	sb.WriteString("      parameters:\n")
+	for _, param := range uc.Usecase.JobStatus().PathParams() {
+		fmt.Fprintf(sb, "        - name: %s\n", param)
+		sb.WriteString("          in: path\n")
+		sb.WriteString("          required: true\n")
+		sb.WriteString("          schema:\n")
+		sb.WriteString("            type: string\n")
+	}
+	if uc.Usecase.Authorization != nil {
+		sb.WriteString("      security:\n")
+		sb.WriteString("        - session: []\n")
+	}
+	sb.WriteString("      responses:\n")
+	sb.WriteString("        '200':\n")
+	sb.WriteString("          description: OK\n")
+	writeJobContent(sb)
+	if uc.Usecase.Authorization != nil {
+		sb.WriteString("        '403':\n")
+		sb.WriteString("          description: Forbidden\n")
+	}
+	sb.WriteString("        '404':\n")
+	sb.WriteString("          description: Not Found\n")
+}
+
+// writeJobContent documents a response body that is a job.
+func writeJobContent(sb *strings.Builder) {
+	sb.WriteString("          content:\n")
+	sb.WriteString("            application/json:\n")
+	sb.WriteString("              schema:\n")
+	sb.WriteString("                $ref: '#/components/schemas/Job'\n")
+}
+
+// writeJobSchemas writes the component schemas of jobs and their statuses.
+func writeJobSchemas(sb *strings.Builder) {
+	sb.WriteString("    JobStatus:\n")
+	sb.WriteString("      type: string\n")
+	sb.WriteString("      enum:\n")
+	for _, status := range jobStatuses {
+		fmt.Fprintf(sb, "        - %s\n", status)
+	}
+	sb.WriteString("    Job:\n")
+	sb.WriteString("      type: object\n")
+	sb.WriteString("      required:\n")
+	sb.WriteString("        - id\n")
+	sb.WriteString("        - status\n")
+	sb.WriteString("        - createdAt\n")
+	sb.WriteString("        - updatedAt\n")
+	sb.WriteString("      properties:\n")
+	sb.WriteString("        id:\n")
+	sb.WriteString("          type: string\n")
+	sb.WriteString("        status:\n")
+	sb.WriteString("          $ref: '#/components/schemas/JobStatus'\n")
+	sb.WriteString("        result:\n")
+	sb.WriteString("          description: What the usecase returned, once the job succeeded\n")
+	sb.WriteString("        error:\n")
+	sb.WriteString("          type: string\n")
+	sb.WriteString("          description: Why the job failed\n")
+	sb.WriteString("        createdAt:\n")
+	sb.WriteString("          type: string\n")
+	sb.WriteString("          format: date-time\n")
+	sb.WriteString("        updatedAt:\n")
+	sb.WriteString("          type: string\n")
+	sb.WriteString("          format: date-time\n")
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// withAsyncCreate makes usecase.create-user, which runs without middleware,
+// an async usecase keeping its jobs in postgres.primary.
+func withAsyncCreate(i *ir.IR) {
+	i.Components["usecase.create-user"].Usecase.Async = true
+}
+
+func TestHonoServerGenerator_Generate_Async(t *testing.T) {
+	// given
+	i := newTestIR(withAsyncCreate)
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	jobs, ok := output.Files["src/components/usecase.jobs.ts"]
+	if !ok {
+		t.Fatal("missing src/components/usecase.jobs.ts")
+	}
+	if want := "export async function startJob<T>(db: DrizzleClient, usecase: string, run: () => Promise<T>): Promise<Job<T>> {\n"; !strings.Contains(string(jobs.Content), want) {
+		t.Errorf("usecase.jobs.ts missing %q\n%s", want, jobs.Content)
+	}
+	schema, ok := output.Files["src/components/usecase.jobs.schema.ts"]
+	if !ok {
+		t.Fatal("missing src/components/usecase.jobs.schema.ts")
+	}
+	if want := "export const jobStatuses = ['pending', 'running', 'succeeded', 'failed'] as const;\n"; !strings.Contains(string(schema.Content), want) {
+		t.Errorf("usecase.jobs.schema.ts missing %q\n%s", want, schema.Content)
+	}
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	for _, want := range []string{
+		"import { getJob, startJob } from './usecase.jobs';\n",
+		"    const job = await startJob(c.get('db'), 'usecase.create-user', () => createUserUsecase(input, context));\n" +
+			"    c.header('Location', `${c.req.path}/jobs/${job.id}`);\n" +
+			"    return c.json(job, 202);\n",
+		"  app.get('/users/jobs/:jobId', async (c) => {\n" +
+			"    const job = await getJob(c.get('db'), 'usecase.create-user', c.req.param('jobId'));\n" +
+			"    if (!job) {\n" +
+			"      return c.json({ error: 'Not Found' }, 404);\n" +
+			"    }\n",
+	} {
+		if !strings.Contains(server, want) {
+			t.Errorf("server file missing %q\n%s", want, server)
+		}
+	}
+}
+
+func TestHonoServerGenerator_Generate_AsyncStatusRouteMiddleware(t *testing.T) {
+	// given: the async usecase runs behind the server's middleware
+	i := newTestIR(withAsyncCreate)
+	i.Components["usecase.create-user"].Usecase.Middleware = nil
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then: so does its status route
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	want := "  \"middleware.authn\": [\n" +
+		"    { method: 'POST', path: new RegExp(\"^/users$\") },\n" +
+		"    { method: 'GET', path: new RegExp(\"^/users/jobs/[^/]+$\") },\n"
+	if !strings.Contains(server, want) {
+		t.Errorf("server file missing %q\n%s", want, server)
+	}
+}
+
+func TestHonoServerGenerator_Generate_NoAsync(t *testing.T) {
+	// given
+	i := createTestIR()
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files["src/components/usecase.jobs.ts"]; ok {
+		t.Error("jobs module generated without async usecases")
+	}
+}
+
+func TestOpenAPIGenerator_Generate_Async(t *testing.T) {
+	// given
+	i := newTestIR(withAsyncCreate)
+
+	// when
+	output, err := NewOpenAPIGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	spec := string(output.Files["src/components/http-server-api.openapi.yaml"].Content)
+	for _, want := range []string{
+		"        '202':\n          description: Accepted\n          headers:\n            Location:\n",
+		"  /users/jobs/{jobId}:\n    get:\n      operationId: createUserUsecaseJob\n",
+		"    JobStatus:\n      type: string\n      enum:\n        - pending\n        - running\n        - succeeded\n        - failed\n",
+	} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("OpenAPI spec missing %q\n%s", want, spec)
+		}
+	}
+	if strings.Contains(spec, "'201'") {
+		t.Errorf("async POST should answer 202 only\n%s", spec)
+	}
+}
+
+func TestTestGenerator_Async(t *testing.T) {
+	// given
+	i := newTestIR(withAsyncCreate)
+
+	// when
+	output, err := NewTestGenerator().Generate(i)
+
+	// then: the status route is tested, the mock modes are not
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	test := string(output.Files["src/components/http-server-api.server.test.ts"].Content)
+	if want := "  it('should have GET /users/jobs/:jobId route', async () => {\n"; !strings.Contains(test, want) {
+		t.Errorf("server test missing %q\n%s", want, test)
+	}
+	if strings.Contains(test, "vi.mock('./usecase-create-user.usecase'") {
+		t.Error("async routes answer before their usecase runs and should not be tested in mock modes")
+	}
+}
+
+func TestE2ETestGenerator_Async(t *testing.T) {
+	// given
+	i := newTestIR(withAsyncCreate)
+
+	// when
+	output, err := NewE2ETestGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	spec := string(output.Files["e2e/http-server-api.spec.ts"].Content)
+	for _, want := range []string{
+		"  test('POST /users - job finishes', async ({ request }) => {\n",
+		"    expect(response.status()).toBe(202);\n",
+		"        const job = await request.get(`${baseURL}${location}`);\n",
+		"      .toMatch(/^(succeeded|failed)$/);\n",
+	} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("E2E spec missing %q\n%s", want, spec)
+		}
+	}
+	setup := string(output.Files["e2e/global-setup.ts"].Content)
+	if want := "'--schema=./src/components/{postgres-primary.postgres.schema,usecase.jobs.schema}.ts',"; !strings.Contains(setup, want) {
+		t.Errorf("global setup missing %q\n%s", want, setup)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+// Output layouts for generated component files.
+const (
+	// LayoutFlat places every component file directly in src/components/.
+	LayoutFlat = "flat"
+	// LayoutComponent places each component's files in src/components/<slug>/.
+	LayoutComponent = "component"
+)
+
+const componentsDir = "src/components"
+
+// relativeImportPattern matches relative module specifiers in import and
+// export statements, dynamic imports and side-effect imports.
+var relativeImportPattern = regexp.MustCompile(`(\bfrom\s+|\bimport\s*\(\s*|\bimport\s+)(['"])(\.\.?/[^'"]*)['"]`)
+
+// importExtensions are tried, in order, when resolving an extensionless specifier.
+var importExtensions = []string{"", ".ts", ".tsx", "/index.ts"}
+
+// Colocate moves every component's files (implementation, context, tests,
+// copied schemas and configs) from src/components/ into a folder per
+// component and rewrites relative imports so they still resolve. Files keep
+// their names, so paths built from __dirname remain valid. Shared files such
+// as usecases.ts and usecase.schemas.ts stay in src/components/. Paths quoted
+// as inline code in markdown files, such as the README, are updated too.
+func Colocate(artifacts []codegen.Artifact) ([]codegen.Artifact, error) {
+	slugs := componentSlugs(artifacts)
+
+	moved := make(map[string]string)
+	for _, artifact := range artifacts {
+		if dir, name := path.Split(artifact.Path); dir == componentsDir+"/" {
+			if slug := owningSlug(name, slugs); slug != "" {
+				moved[artifact.Path] = path.Join(componentsDir, slug, name)
+			}
+		}
+	}
+
+	known := make(map[string]bool, len(artifacts))
+	for _, artifact := range artifacts {
+		known[artifact.Path] = true
+	}
+
+	pairs := make([]string, 0, 2*len(moved))
+	for from, to := range moved {
+		pairs = append(pairs, "`"+from+"`", "`"+to+"`")
+	}
+	markdownPaths := strings.NewReplacer(pairs...)
+
+	result := make([]codegen.Artifact, len(artifacts))
+	for n, artifact := range artifacts {
+		newPath, ok := moved[artifact.Path]
+		if !ok {
+			newPath = artifact.Path
+		}
+		if isTypeScriptFile(artifact.Path) {
+			artifact.Content = rewriteImports(artifact.Content, artifact.Path, newPath, moved, known)
+		}
+		if strings.HasSuffix(artifact.Path, ".md") {
+			artifact.Content = []byte(markdownPaths.Replace(string(artifact.Content)))
+		}
+		artifact.Path = newPath
+		result[n] = artifact
+	}
+
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].Path < result[b].Path
+	})
+	return result, nil
+}
+
+// componentSlugs returns the file slugs of all components that own artifacts,
+// longest first so that "a-b" wins over "a" when matching file names.
+func componentSlugs(artifacts []codegen.Artifact) []string {
+	seen := make(map[string]bool)
+	var slugs []string
+	for _, artifact := range artifacts {
+		if artifact.ComponentID == "" {
+			continue
+		}
+		slug := componentIDSlug(artifact.ComponentID)
+		if !seen[slug] {
+			seen[slug] = true
+			slugs = append(slugs, slug)
+		}
+	}
+	sort.Slice(slugs, func(a, b int) bool {
+		if len(slugs[a]) != len(slugs[b]) {
+			return len(slugs[a]) > len(slugs[b])
+		}
+		return slugs[a] < slugs[b]
+	})
+	return slugs
+}
+
+// owningSlug returns the component slug a file name starts with, if any.
+func owningSlug(name string, slugs []string) string {
+	for _, slug := range slugs {
+		if strings.HasPrefix(name, slug+".") {
+			return slug
+		}
+	}
+	return ""
+}
+
+func isTypeScriptFile(p string) bool {
+	return strings.HasSuffix(p, ".ts") || strings.HasSuffix(p, ".tsx")
+}
+
+// rewriteImports updates the relative specifiers in a file that moves from
+// oldPath to newPath, or that imports a file which moved.
+func rewriteImports(content []byte, oldPath, newPath string, moved map[string]string, known map[string]bool) []byte {
+	return relativeImportPattern.ReplaceAllFunc(content, func(match []byte) []byte {
+		m := relativeImportPattern.FindSubmatch(match)
+		prefix, quote, spec := string(m[1]), string(m[2]), string(m[3])
+
+		target := path.Join(path.Dir(oldPath), spec)
+		newTarget := target
+		for _, ext := range importExtensions {
+			if !known[target+ext] {
+				continue
+			}
+			if dest, ok := moved[target+ext]; ok {
+				newTarget = strings.TrimSuffix(dest, ext)
+			}
+			break
+		}
+		if newTarget == target && newPath == oldPath {
+			return match
+		}
+
+		rel, err := filepath.Rel(filepath.FromSlash(path.Dir(newPath)), filepath.FromSlash(newTarget))
+		if err != nil {
+			return match
+		}
+		rel = filepath.ToSlash(rel)
+		if !strings.HasPrefix(rel, "../") {
+			rel = "./" + rel
+		}
+		return []byte(prefix + quote + rel + quote)
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+func TestColocate(t *testing.T) {
+	// given
+	artifacts := []codegen.Artifact{
+		{Path: "src/index.ts", Content: []byte("import { createApp } from './components/http-server-api.server';\n")},
+		{Path: "src/components/http-server-api.server.ts", ComponentID: "http.server.api", Content: []byte(
+			"import type { AppContext } from './http-server-api.context';\n" +
+				"import { createUser } from './usecase-create-user.usecase';\n" +
+				"import { Hono } from 'hono';\n")},
+		{Path: "src/components/http-server-api.context.ts", ComponentID: "http.server.api", Content: []byte(
+			"import type { PostgresClient } from './postgres.client';\n")},
+		{Path: "src/components/http-server-api.openapi.yaml", ComponentID: "http.server.api", Content: []byte("openapi: 3.0.0\n")},
+		{Path: "src/components/usecase-create-user.usecase.ts", ComponentID: "usecase.create-user", Content: []byte(
+			"import type { CreateUserResponse } from './usecase.schemas';\n")},
+		{Path: "src/components/usecase-create-user.usecase.test.ts", ComponentID: "usecase.create-user", Content: []byte(
+			"import { createUser } from './usecase-create-user.usecase';\n" +
+				"import { createTestContext } from '../test/setup';\n" +
+				"const lazy = await import('./usecase-create-user.usecase');\n")},
+		{Path: "src/components/postgres-primary.postgres.schema.ts", Content: []byte("export {};\n")},
+		{Path: "src/components/postgres-primary.postgres.ts", ComponentID: "postgres.primary", Content: []byte(
+			"import * as schema from './postgres-primary.postgres.schema';\n")},
+		{Path: "src/components/usecases.ts", Content: []byte("export { createUser } from './usecase-create-user.usecase';\n")},
+		{Path: "src/components/usecase.schemas.ts", Content: []byte("import { z } from 'zod';\n")},
+		{Path: "src/components/postgres.client.ts", Content: []byte("export {};\n")},
+		{Path: "src/test/setup.ts", Content: []byte("export {};\n")},
+		{Path: "README.md", Content: []byte("| `http.server.api` | `src/components/http-server-api.server.ts`, `src/components/usecases.ts` |\n")},
+	}
+
+	// when
+	result, err := Colocate(artifacts)
+
+	// then
+	if err != nil {
+		t.Fatalf("Colocate() error = %v", err)
+	}
+	byPath := make(map[string]string)
+	for _, artifact := range result {
+		byPath[artifact.Path] = string(artifact.Content)
+	}
+
+	want := map[string]string{
+		"src/index.ts": "import { createApp } from './components/http-server-api/http-server-api.server';\n",
+		"src/components/http-server-api/http-server-api.server.ts": "import type { AppContext } from './http-server-api.context';\n" +
+			"import { createUser } from '../usecase-create-user/usecase-create-user.usecase';\n" +
+			"import { Hono } from 'hono';\n",
+		"src/components/http-server-api/http-server-api.context.ts":         "import type { PostgresClient } from '../postgres.client';\n",
+		"src/components/http-server-api/http-server-api.openapi.yaml":       "openapi: 3.0.0\n",
+		"src/components/usecase-create-user/usecase-create-user.usecase.ts": "import type { CreateUserResponse } from '../usecase.schemas';\n",
+		"src/components/usecase-create-user/usecase-create-user.usecase.test.ts": "import { createUser } from './usecase-create-user.usecase';\n" +
+			"import { createTestContext } from '../../test/setup';\n" +
+			"const lazy = await import('./usecase-create-user.usecase');\n",
+		"src/components/postgres-primary/postgres-primary.postgres.schema.ts": "export {};\n",
+		"src/components/postgres-primary/postgres-primary.postgres.ts":        "import * as schema from './postgres-primary.postgres.schema';\n",
+		"src/components/usecases.ts":                                          "export { createUser } from './usecase-create-user/usecase-create-user.usecase';\n",
+		"src/components/usecase.schemas.ts":                                   "import { z } from 'zod';\n",
+		"src/components/postgres.client.ts":                                   "export {};\n",
+		"src/test/setup.ts":                                                   "export {};\n",
+		"README.md":                                                           "| `http.server.api` | `src/components/http-server-api/http-server-api.server.ts`, `src/components/usecases.ts` |\n",
+	}
+	if len(byPath) != len(want) {
+		t.Errorf("Colocate() returned %d artifacts, want %d", len(byPath), len(want))
+	}
+	for path, content := range want {
+		got, ok := byPath[path]
+		if !ok {
+			t.Errorf("missing artifact %s", path)
+			continue
+		}
+		if got != content {
+			t.Errorf("%s =\n%s\nwant\n%s", path, got, content)
+		}
+	}
+}
+
+func TestColocate_PreservesComponentIDs(t *testing.T) {
+	// given
+	artifacts := []codegen.Artifact{
+		{Path: "src/components/usecase-a.usecase.ts", ComponentID: "usecase.a", Owner: "typescript-usecase"},
+	}
+
+	// when
+	result, err := Colocate(artifacts)
+
+	// then
+	if err != nil {
+		t.Fatalf("Colocate() error = %v", err)
+	}
+	if result[0].ComponentID != "usecase.a" || result[0].Owner != "typescript-usecase" {
+		t.Errorf("Colocate() = %+v, want ComponentID and Owner preserved", result[0])
+	}
+}
+
+func TestOwningSlug_PrefersLongestSlug(t *testing.T) {
+	slugs := componentSlugs([]codegen.Artifact{
+		{ComponentID: "usecase.a"},
+		{ComponentID: "usecase.a-b"},
+	})
+
+	if got := owningSlug("usecase-a-b.usecase.ts", slugs); got != "usecase-a-b" {
+		t.Errorf("owningSlug() = %q, want %q", got, "usecase-a-b")
+	}
+	if got := owningSlug("usecases.ts", slugs); got != "" {
+		t.Errorf("owningSlug() = %q, want shared file", got)
+	}
+}
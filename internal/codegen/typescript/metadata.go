@@ -0,0 +1,62 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// componentHeader returns comment lines documenting a component, placed
+// below the generated-file banner of its files: its description, owner and
+// links, each when set. It is empty for components without metadata.
+func componentHeader(comp *ir.Component) string {
+	var sb strings.Builder
+	if comp.Description != "" {
+		for _, line := range strings.Split(strings.TrimSpace(comp.Description), "\n") {
+			fmt.Fprintf(&sb, "// %s\n", strings.TrimSpace(line))
+		}
+	}
+	if comp.Owner != "" {
+		fmt.Fprintf(&sb, "// Owner: %s\n", comp.Owner)
+	}
+	for _, link := range comp.Links {
+		title := link.Title
+		if title == "" {
+			title = "See"
+		}
+		fmt.Fprintf(&sb, "// %s: %s\n", title, link.URL)
+	}
+	return sb.String()
+}
+
+// componentSummary returns a component's description followed by its
+// owner, for docs that show metadata inline, or "" without either.
+func componentSummary(comp *ir.Component) string {
+	summary := strings.Join(strings.Fields(comp.Description), " ")
+	if comp.Owner != "" {
+		if summary != "" {
+			summary += " "
+		}
+		summary += "(owner: " + comp.Owner + ")"
+	}
+	return summary
+}
+
+// writeExternalDocs writes an OpenAPI externalDocs object for a component's
+// first link, at the given indent. OpenAPI allows one per tag or operation.
+func writeExternalDocs(sb *strings.Builder, indent string, comp *ir.Component) {
+	if len(comp.Links) == 0 {
+		return
+	}
+	link := comp.Links[0]
+	fmt.Fprintf(sb, "%sexternalDocs:\n", indent)
+	fmt.Fprintf(sb, "%s  url: %s\n", indent, link.URL)
+	if link.Title != "" {
+		fmt.Fprintf(sb, "%s  description: %s\n", indent, strconv.Quote(link.Title))
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+func TestComponentHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		comp     *ir.Component
+		expected string
+	}{
+		{"no metadata", &ir.Component{}, ""},
+		{"description", &ir.Component{Description: "Public API\nfor partners"}, "// Public API\n// for partners\n"},
+		{
+			"owner and links",
+			&ir.Component{Owner: "team-platform", Links: []parser.Link{{Title: "Runbook", URL: "https://example.com/rb"}, {URL: "https://example.com/adr"}}},
+			"// Owner: team-platform\n// Runbook: https://example.com/rb\n// See: https://example.com/adr\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := componentHeader(tt.comp); got != tt.expected {
+				t.Errorf("componentHeader() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComponentMetadata_Outputs(t *testing.T) {
+	// given
+	i := createTestIR()
+	server := i.Components["http.server.api"]
+	server.Description = "Public API"
+	server.Owner = "team-platform"
+	server.Links = []parser.Link{{Title: "Runbook", URL: "https://example.com/rb"}}
+	i.Components["usecase.create-user"].Description = "Registers a user: sends a welcome mail"
+	i.Components["usecase.create-user"].Links = []parser.Link{{URL: "https://example.com/signup"}}
+	i.Components["usecase.create-user"].Deprecated = true
+
+	tests := []struct {
+		name      string
+		generator codegen.Generator
+		path      string
+		want      []string
+	}{
+		{
+			name:      "server header",
+			generator: NewHonoServerGenerator(),
+			path:      serverSourcePath("http.server.api"),
+			want:      []string{"// Generated by OpenBoundary - DO NOT EDIT\n// Public API\n// Owner: team-platform\n// Runbook: https://example.com/rb\nimport"},
+		},
+		{
+			name:      "usecase header",
+			generator: NewUsecaseGenerator(),
+			path:      usecaseSourcePath("usecase.create-user"),
+			want:      []string{"// Generated by OpenBoundary - DO NOT EDIT\n// Registers a user: sends a welcome mail\n"},
+		},
+		{
+			name:      "readme",
+			generator: NewReadmeGenerator(),
+			path:      "README.md",
+			want:      []string{"| `http.server.api` | http.server | hono on port 3000; Public API (owner: team-platform) [Runbook](https://example.com/rb) |"},
+		},
+		{
+			name:      "openapi tags",
+			generator: NewOpenAPIGenerator(),
+			path:      "src/components/http-server-api.openapi.yaml",
+			want: []string{
+				"tags:\n  - name: http.server.api\n    description: \"Public API (owner: team-platform)\"\n    externalDocs:\n      url: https://example.com/rb\n      description: \"Runbook\"\npaths:\n",
+				"      description: \"Registers a user: sends a welcome mail\"\n      externalDocs:\n        url: https://example.com/signup\n      deprecated: true\n      tags:\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			output, err := tt.generator.Generate(i)
+
+			// then
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			file, ok := output.Files[tt.path]
+			if !ok {
+				t.Fatalf("missing %s", tt.path)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(file.Content), want) {
+					t.Errorf("%s missing %q\n%s", tt.path, want, file.Content)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// Metrics every server with observability.metrics records, so dashboards
+// and alerts work the same for every service. The request metrics have the
+// labels server, usecase, method and route, and requests also status; the
+// SLO targets have server and usecase.
+const (
+	metricRequests       = "usecase_requests_total"
+	metricDuration       = "usecase_request_duration_seconds"
+	metricSLOLatency     = "usecase_slo_p99_latency_seconds"
+	metricSLOErrorBudget = "usecase_slo_error_budget_ratio"
+)
+
+// sloWindowDays is the period over which error budgets are spent.
+const sloWindowDays = 30
+
+// latencyBuckets are the upper bounds, in seconds, of the duration
+// histogram. The p99 targets of a server's usecases are added, so the share
+// of requests within a target is counted exactly.
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// sloDocumentPath is the summary of the SLOs of the service.
+const sloDocumentPath = "SLO.md"
+
+// MetricsGenerator generates the Prometheus metrics of servers with
+// observability.metrics and a summary of the SLOs usecases declare.
+type MetricsGenerator struct{}
+
+// NewMetricsGenerator creates a new metrics generator.
+func NewMetricsGenerator() *MetricsGenerator {
+	return &MetricsGenerator{}
+}
+
+// Name returns the generator name.
+func (g *MetricsGenerator) Name() string {
+	return "typescript-metrics"
+}
+
+// Generate produces the metrics modules and SLO summary from the IR.
+func (g *MetricsGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
+	return codegen.GenerateComponents(g, i)
+}
+
+// GenerateShared produces SLO.md when a usecase declares an SLO.
+func (g *MetricsGenerator) GenerateShared(i *ir.IR) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	if usecases := sloUsecases(i); len(usecases) > 0 {
+		output.AddFile(sloDocumentPath, []byte(generateSLODocument(i, usecases)))
+	}
+	return output, nil
+}
+
+// GenerateComponent produces the metrics module of a server.
+func (g *MetricsGenerator) GenerateComponent(i *ir.IR, comp *ir.Component) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	if comp.HTTPServer != nil && comp.HTTPServer.Metrics != nil {
+		output.AddComponentFile(serverMetricsPath(comp.ID), []byte(generateMetrics(i, comp)), comp.ID)
+	}
+	return output, nil
+}
+
+// hasMetrics reports whether any server records metrics.
+func hasMetrics(i *ir.IR) bool {
+	return slices.ContainsFunc(i.HTTPServers(), func(server *ir.Component) bool {
+		return server.HTTPServer.Metrics != nil
+	})
+}
+
+// sloUsecases returns the bound usecases that declare an SLO, sorted by ID.
+func sloUsecases(i *ir.IR) []*ir.Component {
+	var usecases []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Usecase != nil && comp.Usecase.SLO != nil && comp.Usecase.Binding != nil {
+			usecases = append(usecases, comp)
+		}
+	}
+	sort.Slice(usecases, func(a, b int) bool { return usecases[a].ID < usecases[b].ID })
+	return usecases
+}
+
+// serverLatencyBuckets returns latencyBuckets with the p99 targets of the
+// server's usecases, in ascending order.
+func serverLatencyBuckets(usecases []*ir.Component) []float64 {
+	buckets := slices.Clone(latencyBuckets)
+	for _, uc := range usecases {
+		if slo := uc.Usecase.SLO; slo != nil && slo.P99LatencyMS > 0 {
+			buckets = append(buckets, float64(slo.P99LatencyMS)/1000)
+		}
+	}
+	slices.Sort(buckets)
+	return slices.Compact(buckets)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func generateMetrics(i *ir.IR, server *ir.Component) string {
+	var sb strings.Builder
+	usecases := i.UsecasesBoundTo(server.ID)
+	serverLabel := "'" + server.ID + "'"
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString(componentHeader(server))
+	sb.WriteString("import type { MiddlewareHandler } from 'hono';\n")
+	sb.WriteString("import { Counter, Gauge, Histogram, Registry, collectDefaultMetrics } from 'prom-client';\n\n")
+
+	fmt.Fprintf(&sb, "/** Metrics of %s, served at %s. */\n", server.ID, server.HTTPServer.RoutePath(server.HTTPServer.Metrics.Path))
+	sb.WriteString("export const registry = new Registry();\n")
+	sb.WriteString("collectDefaultMetrics({ register: registry });\n\n")
+
+	sb.WriteString("const labelNames = ['server', 'usecase', 'method', 'route'] as const;\n\n")
+	sb.WriteString("const requests = new Counter({\n")
+	fmt.Fprintf(&sb, "  name: '%s',\n", metricRequests)
+	sb.WriteString("  help: 'Requests answered by each usecase, by status',\n")
+	sb.WriteString("  labelNames: [...labelNames, 'status'] as const,\n")
+	sb.WriteString("  registers: [registry],\n")
+	sb.WriteString("});\n\n")
+	sb.WriteString("const duration = new Histogram({\n")
+	fmt.Fprintf(&sb, "  name: '%s',\n", metricDuration)
+	sb.WriteString("  help: 'Seconds each usecase took to answer, middleware included',\n")
+	sb.WriteString("  labelNames,\n")
+	buckets := serverLatencyBuckets(usecases)
+	bounds := make([]string, len(buckets))
+	for n, b := range buckets {
+		bounds[n] = formatFloat(b)
+	}
+	fmt.Fprintf(&sb, "  buckets: [%s],\n", strings.Join(bounds, ", "))
+	sb.WriteString("  registers: [registry],\n")
+	sb.WriteString("});\n\n")
+
+	writeSLOGauges(&sb, usecases, serverLabel)
+
+	sb.WriteString("interface MetricRoute {\n")
+	sb.WriteString("  usecase: string;\n")
+	sb.WriteString("  method: string;\n")
+	sb.WriteString("  route: string;\n")
+	sb.WriteString("  path: RegExp;\n")
+	sb.WriteString("}\n\n")
+	sb.WriteString("const routes: MetricRoute[] = [\n")
+	for _, uc := range usecases {
+		if uc.Usecase == nil || uc.Usecase.Binding == nil {
+			continue
+		}
+		b := uc.Usecase.Binding
+		route := server.HTTPServer.RoutePath(b.Path)
+		fmt.Fprintf(&sb, "  { usecase: '%s', method: '%s', route: '%s', path: %s },\n",
+			uc.ID, strings.ToUpper(b.Method), route, honoPathToRegexLiteral(convertPathParams(route)))
+	}
+	sb.WriteString("];\n\n")
+
+	sb.WriteString("/**\n")
+	sb.WriteString(" * Records the duration and status of the requests of every route. Requests\n")
+	sb.WriteString(" * are labeled with the route they match rather than their path, so IDs in\n")
+	sb.WriteString(" * paths do not become labels; requests no usecase serves are not recorded.\n")
+	sb.WriteString(" */\n")
+	sb.WriteString("export const metricsMiddleware: MiddlewareHandler = async (c, next) => {\n")
+	sb.WriteString("  const route = routes.find((r) => (r.method === 'ALL' || r.method === c.req.method) && r.path.test(c.req.path));\n")
+	sb.WriteString("  if (!route) {\n")
+	sb.WriteString("    return next();\n")
+	sb.WriteString("  }\n")
+	fmt.Fprintf(&sb, "  const labels = { server: %s, usecase: route.usecase, method: c.req.method, route: route.route };\n", serverLabel)
+	sb.WriteString("  const end = duration.startTimer(labels);\n")
+	sb.WriteString("  try {\n")
+	sb.WriteString("    await next();\n")
+	sb.WriteString("  } finally {\n")
+	sb.WriteString("    end();\n")
+	sb.WriteString("    requests.inc({ ...labels, status: String(c.res.status) });\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("};\n")
+
+	return sb.String()
+}
+
+// writeSLOGauges writes the gauges exporting the SLO targets of a server's
+// usecases, so alerts compare the measurements with them.
+func writeSLOGauges(sb *strings.Builder, usecases []*ir.Component, serverLabel string) {
+	var latency, budget []string
+	for _, uc := range usecases {
+		slo := uc.Usecase.SLO
+		if slo == nil {
+			continue
+		}
+		labels := fmt.Sprintf("{ server: %s, usecase: '%s' }", serverLabel, uc.ID)
+		if slo.P99LatencyMS > 0 {
+			latency = append(latency, fmt.Sprintf("sloLatency.set(%s, %s);\n", labels, formatFloat(float64(slo.P99LatencyMS)/1000)))
+		}
+		if slo.ErrorBudget > 0 {
+			budget = append(budget, fmt.Sprintf("sloErrorBudget.set(%s, %s);\n", labels, formatFloat(slo.ErrorBudget/100)))
+		}
+	}
+
+	if len(latency) > 0 {
+		sb.WriteString("const sloLatency = new Gauge({\n")
+		fmt.Fprintf(sb, "  name: '%s',\n", metricSLOLatency)
+		sb.WriteString("  help: 'Seconds within which each usecase answers 99% of requests',\n")
+		sb.WriteString("  labelNames: ['server', 'usecase'] as const,\n")
+		sb.WriteString("  registers: [registry],\n")
+		sb.WriteString("});\n")
+		sb.WriteString(strings.Join(latency, ""))
+		sb.WriteString("\n")
+	}
+	if len(budget) > 0 {
+		sb.WriteString("const sloErrorBudget = new Gauge({\n")
+		fmt.Fprintf(sb, "  name: '%s',\n", metricSLOErrorBudget)
+		fmt.Fprintf(sb, "  help: 'Share of requests of each usecase that may fail with a 5xx status over %d days',\n", sloWindowDays)
+		sb.WriteString("  labelNames: ['server', 'usecase'] as const,\n")
+		sb.WriteString("  registers: [registry],\n")
+		sb.WriteString("});\n")
+		sb.WriteString(strings.Join(budget, ""))
+		sb.WriteString("\n")
+	}
+}
+
+// generateSLODocument writes the SLOs of the usecases and the PromQL
+// queries that measure them.
+func generateSLODocument(i *ir.IR, usecases []*ir.Component) string {
+	var sb strings.Builder
+
+	sb.WriteString(codegen.Header(codegen.HTMLComments))
+	sb.WriteString("# Service Level Objectives\n\n")
+	fmt.Fprintf(&sb, "The objectives the usecases declare. Error budgets are spent over %d days;\n", sloWindowDays)
+	sb.WriteString("a request spends it when it fails with a 5xx status.\n\n")
+	sb.WriteString("| Usecase | Route | p99 latency | Error budget | Measured by |\n")
+	sb.WriteString("|---------|-------|-------------|--------------|-------------|\n")
+	for _, uc := range usecases {
+		slo := uc.Usecase.SLO
+		server := i.Components[uc.Usecase.Binding.ServerID]
+		latency, budget := "—", "—"
+		if slo.P99LatencyMS > 0 {
+			latency = fmt.Sprintf("%d ms", slo.P99LatencyMS)
+		}
+		if slo.ErrorBudget > 0 {
+			budget = fmt.Sprintf("%s%% (%s%% success)", formatFloat(slo.ErrorBudget), formatFloat(100-slo.ErrorBudget))
+		}
+		measured := "not measured: metrics are off"
+		if server != nil && server.HTTPServer != nil && server.HTTPServer.Metrics != nil {
+			measured = fmt.Sprintf("`%s` of %s", server.HTTPServer.RoutePath(server.HTTPServer.Metrics.Path), server.ID)
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n", uc.ID, sloRoute(i, uc), latency, budget, measured)
+	}
+
+	sb.WriteString("\n## Metrics\n\n")
+	sb.WriteString("Servers with `observability.metrics` record:\n\n")
+	fmt.Fprintf(&sb, "- `%s`: requests answered, labeled `server`, `usecase`, `method`, `route` and `status`\n", metricRequests)
+	fmt.Fprintf(&sb, "- `%s`: seconds taken to answer, labeled `server`, `usecase`, `method` and `route`\n", metricDuration)
+	fmt.Fprintf(&sb, "- `%s` and `%s`: the targets below, labeled `server` and `usecase`\n\n", metricSLOLatency, metricSLOErrorBudget)
+
+	for _, uc := range usecases {
+		slo := uc.Usecase.SLO
+		selector := fmt.Sprintf(`usecase="%s"`, uc.ID)
+		fmt.Fprintf(&sb, "## %s\n\n", uc.ID)
+		if goal := uc.Usecase.Goal; goal != "" {
+			fmt.Fprintf(&sb, "%s\n\n", goal)
+		}
+		if slo.P99LatencyMS > 0 {
+			fmt.Fprintf(&sb, "99%% of requests are answered within %d ms. p99 latency over 5 minutes:\n\n", slo.P99LatencyMS)
+			sb.WriteString("```promql\n")
+			fmt.Fprintf(&sb, "histogram_quantile(0.99, sum by (le) (rate(%s_bucket{%s}[5m])))\n", metricDuration, selector)
+			sb.WriteString("```\n\n")
+		}
+		if slo.ErrorBudget > 0 {
+			fmt.Fprintf(&sb, "At most %s%% of requests fail. Share of failed requests over %d days:\n\n", formatFloat(slo.ErrorBudget), sloWindowDays)
+			sb.WriteString("```promql\n")
+			fmt.Fprintf(&sb, "sum(increase(%s{%s,status=~\"5..\"}[%dd])) / sum(increase(%s{%s}[%dd]))\n",
+				metricRequests, selector, sloWindowDays, metricRequests, selector, sloWindowDays)
+			sb.WriteString("```\n\n")
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n") + "\n"
+}
+
+// sloRoute returns the method and path a usecase is served on.
+func sloRoute(i *ir.IR, uc *ir.Component) string {
+	b := uc.Usecase.Binding
+	path := b.Path
+	if server, ok := i.Components[b.ServerID]; ok && server.HTTPServer != nil {
+		path = server.HTTPServer.RoutePath(path)
+	}
+	return fmt.Sprintf("`%s %s`", strings.ToUpper(b.Method), path)
+}
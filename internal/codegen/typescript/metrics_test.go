@@ -0,0 +1,123 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+// metricsTestIR returns a server on /api with a usecase that declares slo.
+func metricsTestIR(metrics *ir.MetricsSpec, slo *ir.SLOSpec) *ir.IR {
+	server := &ir.Component{
+		ID:         "http.server.api",
+		Kind:       ir.KindHTTPServer,
+		HTTPServer: &ir.HTTPServerSpec{Framework: "hono", Port: 3000, BasePath: "/api", Metrics: metrics},
+	}
+	uc := &ir.Component{
+		ID:   "usecase.get-order",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			Goal:    "Get an order",
+			SLO:     slo,
+			Binding: &ir.Binding{ServerID: server.ID, Method: "GET", Path: "/orders/{id}"},
+		},
+	}
+	return &ir.IR{
+		Spec:       &parser.Spec{Name: "test", Version: "0.0.1"},
+		Components: map[string]*ir.Component{server.ID: server, uc.ID: uc},
+	}
+}
+
+func TestMetricsGenerator_Generate(t *testing.T) {
+	// given
+	i := metricsTestIR(&ir.MetricsSpec{Path: "/metrics"}, &ir.SLOSpec{P99LatencyMS: 150, ErrorBudget: 0.5})
+
+	// when
+	output, err := NewMetricsGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	metrics := string(output.Files[serverMetricsPath("http.server.api")].Content)
+	for _, want := range []string{
+		"/** Metrics of http.server.api, served at /api/metrics. */",
+		"buckets: [0.005, 0.01, 0.025, 0.05, 0.1, 0.15, 0.25, 0.5, 1, 2.5, 5, 10],",
+		"sloLatency.set({ server: 'http.server.api', usecase: 'usecase.get-order' }, 0.15);",
+		"sloErrorBudget.set({ server: 'http.server.api', usecase: 'usecase.get-order' }, 0.005);",
+		`{ usecase: 'usecase.get-order', method: 'GET', route: '/api/orders/{id}', path: new RegExp("^/api/orders/[^/]+$") },`,
+	} {
+		if !strings.Contains(metrics, want) {
+			t.Errorf("metrics missing %q:\n%s", want, metrics)
+		}
+	}
+	document := string(output.Files[sloDocumentPath].Content)
+	for _, want := range []string{
+		"| usecase.get-order | `GET /api/orders/{id}` | 150 ms | 0.5% (99.5% success) | `/api/metrics` of http.server.api |",
+		`sum(increase(usecase_requests_total{usecase="usecase.get-order",status=~"5.."}[30d]))`,
+	} {
+		if !strings.Contains(document, want) {
+			t.Errorf("SLO.md missing %q:\n%s", want, document)
+		}
+	}
+}
+
+func TestMetricsGenerator_Generate_MetricsOff(t *testing.T) {
+	// given
+	i := metricsTestIR(nil, &ir.SLOSpec{ErrorBudget: 1})
+
+	// when
+	output, err := NewMetricsGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files[serverMetricsPath("http.server.api")]; ok {
+		t.Error("metrics module generated for a server without metrics")
+	}
+	if document := string(output.Files[sloDocumentPath].Content); !strings.Contains(document, "| — | 1% (99% success) | not measured: metrics are off |") {
+		t.Errorf("SLO.md =\n%s", document)
+	}
+}
+
+func TestMetricsGenerator_Generate_NoSLOs(t *testing.T) {
+	// given
+	i := metricsTestIR(&ir.MetricsSpec{Path: "/metrics"}, nil)
+
+	// when
+	output, err := NewMetricsGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files[sloDocumentPath]; ok {
+		t.Error("SLO.md generated without SLOs")
+	}
+	metrics := string(output.Files[serverMetricsPath("http.server.api")].Content)
+	if strings.Contains(metrics, "Gauge({") {
+		t.Errorf("metrics declare SLO gauges without SLOs:\n%s", metrics)
+	}
+}
+
+func TestServerLatencyBuckets(t *testing.T) {
+	usecases := []*ir.Component{
+		{Usecase: &ir.UsecaseSpec{SLO: &ir.SLOSpec{P99LatencyMS: 250}}},
+		{Usecase: &ir.UsecaseSpec{SLO: &ir.SLOSpec{P99LatencyMS: 2000}}},
+		{Usecase: &ir.UsecaseSpec{}},
+	}
+
+	got := serverLatencyBuckets(usecases)
+
+	want := []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2, 2.5, 5, 10}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("serverLatencyBuckets() = %v, want %v", got, want)
+	}
+}
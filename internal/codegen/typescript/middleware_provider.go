@@ -0,0 +1,252 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// MiddlewareProvider renders the provider-specific parts of a middleware
+// component. The server, context, project and test generators look a
+// middleware's provider up instead of switching on its name, so a provider
+// registered with RegisterMiddlewareProvider needs no changes to them.
+type MiddlewareProvider interface {
+	// ContextField returns the context variable the middleware sets and its
+	// TypeScript type, or an empty key if it sets none.
+	ContextField(mw *ir.Component) (key, typ string)
+	// ContextImports returns the imports the context type needs.
+	ContextImports(mw *ir.Component) []string
+	// Dependencies adds the npm packages the middleware needs to deps.
+	Dependencies(mw *ir.Component, deps map[string]string)
+	// WriteMiddleware writes the middleware module after its createMiddleware
+	// import. The module must export <camelCaseID>Middleware.
+	WriteMiddleware(sb *strings.Builder, mw *ir.Component)
+	// AddFiles adds the modules the middleware needs besides its own.
+	AddFiles(output *codegen.Output, i *ir.IR, mw *ir.Component)
+	// TestImports returns the imports the middleware's tests need.
+	TestImports(mw *ir.Component) []string
+	// WriteTests writes the provider's tests into the middleware's describe
+	// block; funcName is the exported middleware.
+	WriteTests(sb *strings.Builder, mw *ir.Component, funcName string)
+}
+
+var (
+	middlewareProvidersMu sync.RWMutex
+	middlewareProviders   = map[string]MiddlewareProvider{
+		"better-auth": betterAuthProvider{},
+		"casbin":      casbinProvider{},
+	}
+)
+
+// RegisterMiddlewareProvider makes a middleware provider available to the
+// TypeScript generators under name. Providers are registered before
+// generation starts, typically from an init function.
+func RegisterMiddlewareProvider(name string, p MiddlewareProvider) error {
+	if name == "" {
+		return fmt.Errorf("middleware provider name cannot be empty")
+	}
+	if p == nil {
+		return fmt.Errorf("middleware provider %q is nil", name)
+	}
+
+	middlewareProvidersMu.Lock()
+	defer middlewareProvidersMu.Unlock()
+	if _, ok := middlewareProviders[name]; ok {
+		return fmt.Errorf("middleware provider %q already registered", name)
+	}
+	middlewareProviders[name] = p
+	return nil
+}
+
+// MiddlewareProviders returns the names of the registered middleware
+// providers, sorted.
+func MiddlewareProviders() []string {
+	middlewareProvidersMu.RLock()
+	defer middlewareProvidersMu.RUnlock()
+	names := make([]string, 0, len(middlewareProviders))
+	for name := range middlewareProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// middlewareProviderFor returns the provider of a middleware component, or
+// nil for chains and unknown providers.
+func middlewareProviderFor(mw *ir.Component) MiddlewareProvider {
+	if mw == nil || mw.Middleware == nil || mw.Middleware.Provider == "" {
+		return nil
+	}
+	middlewareProvidersMu.RLock()
+	defer middlewareProvidersMu.RUnlock()
+	return middlewareProviders[mw.Middleware.Provider]
+}
+
+// betterAuthProvider authenticates requests with a better-auth session.
+type betterAuthProvider struct{}
+
+func (betterAuthProvider) ContextField(mw *ir.Component) (string, string) {
+	return "auth", betterAuthContextAlias(mw.ID)
+}
+
+func (betterAuthProvider) ContextImports(mw *ir.Component) []string {
+	// Import auth context type from the generated middleware module.
+	return []string{fmt.Sprintf(
+		"import type { AuthContext as %s } from './%s.middleware';",
+		betterAuthContextAlias(mw.ID),
+		componentIDSlug(mw.ID),
+	)}
+}
+
+func (betterAuthProvider) Dependencies(mw *ir.Component, deps map[string]string) {
+	deps["better-auth"] = "^1.4.0"
+	if mw.Middleware.Session != nil && mw.Middleware.Session.Storage == ir.SessionStorageRedis {
+		deps["ioredis"] = "^5.4.0"
+	}
+}
+
+func (betterAuthProvider) WriteMiddleware(sb *strings.Builder, mw *ir.Component) {
+	mwFilename := sanitizeFilename(mw.ID)
+	sb.WriteString(fmt.Sprintf("import { auth } from './%s.middleware.config';\n\n", mwFilename))
+	sb.WriteString("type AuthSessionResult = Awaited<ReturnType<typeof auth.api.getSession>>;\n")
+	sb.WriteString("export type AuthContext = AuthSessionResult extends { session: infer S; user: infer U }\n")
+	sb.WriteString("  ? { session: S | null; user: U | null }\n")
+	sb.WriteString("  : { session: unknown; user: unknown };\n\n")
+	sb.WriteString(fmt.Sprintf("export const %sMiddleware = createMiddleware(async (c, next) => {\n", toCamelCase(mw.ID)))
+	sb.WriteString("  const session = await auth.api.getSession({ headers: c.req.raw.headers });\n\n")
+	sb.WriteString("  if (!session) {\n")
+	sb.WriteString("    c.set('auth', { session: null, user: null });\n")
+	sb.WriteString("  } else {\n")
+	sb.WriteString("    c.set('auth', { session: session.session, user: session.user });\n")
+	sb.WriteString("  }\n\n")
+	sb.WriteString("  await next();\n")
+	sb.WriteString("});\n\n")
+	// Also export a requireAuth middleware
+	sb.WriteString("/** Middleware that requires authentication - returns 401 if not authenticated */\n")
+	sb.WriteString("export const requireAuth = createMiddleware(async (c, next) => {\n")
+	sb.WriteString("  const authCtx = c.get('auth');\n\n")
+	sb.WriteString("  if (!authCtx?.session || !authCtx?.user) {\n")
+	sb.WriteString("    return c.json({ error: 'Unauthorized' }, 401);\n")
+	sb.WriteString("  }\n\n")
+	sb.WriteString("  await next();\n")
+	sb.WriteString("});\n")
+}
+
+func (betterAuthProvider) AddFiles(output *codegen.Output, i *ir.IR, mw *ir.Component) {
+	output.AddComponentFile(middlewareSchemaPath(mw.ID), []byte(generateBetterAuthSchema(mw)), mw.ID)
+
+	// Generate session storage options
+	if mw.Middleware.Session != nil {
+		output.AddComponentFile(middlewareSessionPath(mw.ID), []byte(generateBetterAuthSession(i, mw)), mw.ID)
+	}
+
+	// Generate OAuth provider options
+	if len(mw.Middleware.OAuth) > 0 {
+		output.AddComponentFile(middlewareOAuthPath(mw.ID), []byte(generateBetterAuthOAuth(mw)), mw.ID)
+	}
+}
+
+func (betterAuthProvider) TestImports(mw *ir.Component) []string {
+	return nil
+}
+
+func (betterAuthProvider) WriteTests(sb *strings.Builder, mw *ir.Component, funcName string) {
+	sb.WriteString("  it('should set auth in context when session is valid', async () => {\n")
+	sb.WriteString("    // given\n")
+	sb.WriteString("    const mockHeaders = new Headers();\n")
+	sb.WriteString("    mockHeaders.set('Authorization', 'Bearer valid-token');\n")
+	sb.WriteString("    const mockCtx = {\n")
+	sb.WriteString("      set: vi.fn(),\n")
+	sb.WriteString("      req: {\n")
+	sb.WriteString("        raw: { headers: mockHeaders },\n")
+	sb.WriteString("        header: vi.fn().mockReturnValue('Bearer valid-token'),\n")
+	sb.WriteString("      },\n")
+	sb.WriteString("    };\n")
+	sb.WriteString("    const mockNext = vi.fn().mockResolvedValue(undefined);\n\n")
+	sb.WriteString("    // when\n")
+	sb.WriteString(fmt.Sprintf("    await %s(mockCtx as any, mockNext);\n\n", funcName))
+	sb.WriteString("    // then - middleware should call next and set auth context\n")
+	sb.WriteString("    expect(mockNext).toHaveBeenCalled();\n")
+	sb.WriteString("    expect(mockCtx.set).toHaveBeenCalledWith('auth', expect.any(Object));\n")
+	sb.WriteString("  });\n\n")
+}
+
+// betterAuthContextAlias is the name the server context imports a
+// better-auth middleware's AuthContext under.
+func betterAuthContextAlias(componentID string) string {
+	return toPascalCase(componentID) + "AuthContext"
+}
+
+// casbinProvider authorizes requests with a casbin enforcer.
+type casbinProvider struct{}
+
+func (casbinProvider) ContextField(mw *ir.Component) (string, string) {
+	return "enforcer", "Enforcer"
+}
+
+func (casbinProvider) ContextImports(mw *ir.Component) []string {
+	return []string{"import type { Enforcer } from 'casbin';"}
+}
+
+func (casbinProvider) Dependencies(mw *ir.Component, deps map[string]string) {
+	deps["casbin"] = "^5.0.0"
+	if mw.Middleware.PolicyAdapter == "postgres" {
+		deps["casbin-pg-adapter"] = "^1.4.0"
+	}
+}
+
+func (casbinProvider) WriteMiddleware(sb *strings.Builder, mw *ir.Component) {
+	// Policy loading and reloading live in the enforcer module
+	sb.WriteString(fmt.Sprintf("import { getEnforcer } from './%s.middleware.enforcer';\n\n", sanitizeFilename(mw.ID)))
+	sb.WriteString(fmt.Sprintf("export const %sMiddleware = createMiddleware(async (c, next) => {\n", toCamelCase(mw.ID)))
+	sb.WriteString("  const e = await getEnforcer();\n")
+	sb.WriteString("  c.set('enforcer', e);\n")
+	sb.WriteString("  // TODO: Implement authorization check\n")
+	sb.WriteString("  // const auth = c.get('auth');\n")
+	sb.WriteString("  // const allowed = await e.enforce(auth?.user?.id, c.req.path, c.req.method);\n")
+	sb.WriteString("  await next();\n")
+	sb.WriteString("});\n")
+}
+
+func (casbinProvider) AddFiles(output *codegen.Output, i *ir.IR, mw *ir.Component) {
+	output.AddComponentFile(middlewareEnforcerPath(mw.ID), []byte(generateCasbinEnforcer(i, mw)), mw.ID)
+
+	if hasRBAC(mw) {
+		output.AddComponentFile(middlewareRBACPath(mw.ID), []byte(generateRBACModule(mw)), mw.ID)
+	}
+}
+
+func (casbinProvider) TestImports(mw *ir.Component) []string {
+	return []string{fmt.Sprintf("import { getEffectivePolicies } from './%s.middleware.enforcer';", sanitizeFilename(mw.ID))}
+}
+
+func (casbinProvider) WriteTests(sb *strings.Builder, mw *ir.Component, funcName string) {
+	sb.WriteString("  it('should check authorization using enforcer', async () => {\n")
+	sb.WriteString("    // given\n")
+	sb.WriteString("    const mockCtx = {\n")
+	sb.WriteString("      set: vi.fn(),\n")
+	sb.WriteString("      get: vi.fn().mockReturnValue({ userId: 'user-123' }),\n")
+	sb.WriteString("      req: { method: 'GET', path: '/users' },\n")
+	sb.WriteString("    };\n")
+	sb.WriteString("    const mockNext = vi.fn().mockResolvedValue(undefined);\n\n")
+	sb.WriteString("    // when\n")
+	sb.WriteString(fmt.Sprintf("    await %s(mockCtx as any, mockNext);\n\n", funcName))
+	sb.WriteString("    // then - middleware should call next\n")
+	sb.WriteString("    expect(mockNext).toHaveBeenCalled();\n")
+	sb.WriteString("  });\n\n")
+
+	sb.WriteString("  it('should list effective policies', async () => {\n")
+	sb.WriteString("    // when\n")
+	sb.WriteString("    const { policies, groupings } = await getEffectivePolicies();\n\n")
+	sb.WriteString("    // then\n")
+	sb.WriteString("    expect(Array.isArray(policies)).toBe(true);\n")
+	sb.WriteString("    expect(Array.isArray(groupings)).toBe(true);\n")
+	sb.WriteString("  });\n\n")
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+// rateLimitProvider is a third-party provider registered by the tests.
+type rateLimitProvider struct{}
+
+func (rateLimitProvider) ContextField(mw *ir.Component) (string, string) {
+	return "rateLimit", "RateLimitInfo"
+}
+
+func (rateLimitProvider) ContextImports(mw *ir.Component) []string {
+	return []string{"import type { RateLimitInfo } from 'hono-rate-limiter';"}
+}
+
+func (rateLimitProvider) Dependencies(mw *ir.Component, deps map[string]string) {
+	deps["hono-rate-limiter"] = "^0.4.0"
+}
+
+func (rateLimitProvider) WriteMiddleware(sb *strings.Builder, mw *ir.Component) {
+	fmt.Fprintf(sb, "export const %sMiddleware = createMiddleware(async (c, next) => {\n", toCamelCase(mw.ID))
+	sb.WriteString("  await next();\n")
+	sb.WriteString("});\n")
+}
+
+func (rateLimitProvider) AddFiles(output *codegen.Output, i *ir.IR, mw *ir.Component) {
+	output.AddComponentFile("src/components/"+componentIDSlug(mw.ID)+".middleware.store.ts", []byte("export {};\n"), mw.ID)
+}
+
+func (rateLimitProvider) TestImports(mw *ir.Component) []string {
+	return []string{"import { RateLimitInfo } from 'hono-rate-limiter';"}
+}
+
+func (rateLimitProvider) WriteTests(sb *strings.Builder, mw *ir.Component, funcName string) {
+	fmt.Fprintf(sb, "  it('should limit requests', () => {\n    expect(%s).toBeDefined();\n  });\n\n", funcName)
+}
+
+// registerTestMiddlewareProvider registers p under name for the duration of
+// the test.
+func registerTestMiddlewareProvider(t *testing.T, name string, p MiddlewareProvider) {
+	t.Helper()
+	if err := RegisterMiddlewareProvider(name, p); err != nil {
+		t.Fatalf("RegisterMiddlewareProvider() error = %v", err)
+	}
+	t.Cleanup(func() {
+		middlewareProvidersMu.Lock()
+		delete(middlewareProviders, name)
+		middlewareProvidersMu.Unlock()
+	})
+}
+
+func TestRegisterMiddlewareProvider_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		p        MiddlewareProvider
+		want     string
+	}{
+		{"empty name", "", rateLimitProvider{}, "cannot be empty"},
+		{"nil provider", "rate-limit", nil, "is nil"},
+		{"duplicate", "casbin", rateLimitProvider{}, "already registered"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterMiddlewareProvider(tt.provider, tt.p)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("RegisterMiddlewareProvider() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddlewareProviders_BuiltIn(t *testing.T) {
+	got := strings.Join(MiddlewareProviders(), ",")
+	if got != "better-auth,casbin" {
+		t.Errorf("MiddlewareProviders() = %q, want %q", got, "better-auth,casbin")
+	}
+}
+
+func TestMiddlewareProvider_Registered(t *testing.T) {
+	// given: a server using middleware of a registered third-party provider
+	registerTestMiddlewareProvider(t, "rate-limit", rateLimitProvider{})
+	i := &ir.IR{
+		Spec: &parser.Spec{Name: "test"},
+		Components: map[string]*ir.Component{
+			"http.server.api": {
+				ID:   "http.server.api",
+				Kind: ir.KindHTTPServer,
+				HTTPServer: &ir.HTTPServerSpec{
+					Framework:  "hono",
+					Port:       3000,
+					Middleware: []string{"middleware.ratelimit"},
+				},
+			},
+			"middleware.ratelimit": {
+				ID:         "middleware.ratelimit",
+				Kind:       ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{Provider: "rate-limit"},
+			},
+		},
+	}
+
+	// when
+	server, err := NewHonoServerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("HonoServerGenerator.Generate() error = %v", err)
+	}
+	context, err := NewContextGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("ContextGenerator.Generate() error = %v", err)
+	}
+	tests, err := NewTestGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("TestGenerator.Generate() error = %v", err)
+	}
+	project, err := NewProjectGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("ProjectGenerator.Generate() error = %v", err)
+	}
+
+	// then: every generator renders the provider's parts
+	checks := []struct {
+		output *codegen.Output
+		path   string
+		want   string
+	}{
+		{server, "src/components/middleware-ratelimit.middleware.ts", "export const middlewareRatelimitMiddleware = createMiddleware"},
+		{server, "src/components/middleware-ratelimit.middleware.store.ts", "export {};"},
+		{context, "src/components/http-server-api.context.ts", "import type { RateLimitInfo } from 'hono-rate-limiter';"},
+		{context, "src/components/http-server-api.context.ts", "rateLimit?: RateLimitInfo | null;"},
+		{tests, "src/components/middleware-ratelimit.middleware.test.ts", "it('should limit requests'"},
+		{project, "package.json", `"hono-rate-limiter": "^0.4.0"`},
+	}
+	for _, c := range checks {
+		file, ok := c.output.Files[c.path]
+		if !ok {
+			t.Errorf("%s not generated", c.path)
+			continue
+		}
+		if !strings.Contains(string(file.Content), c.want) {
+			t.Errorf("%s should contain %q, got:\n%s", c.path, c.want, file.Content)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+// mockUnimplementedEnvVar is the variable that makes routes answer 501 with
+// their OpenAPI example while their usecase is not implemented.
+func mockUnimplementedEnvVar(i *ir.IR) string {
+	return i.EnvVar("MOCK_UNIMPLEMENTED")
+}
+
+// mockedUsecases returns the usecases whose routes can answer with a mock
+// response: all but catch-all routes, which return the usecase's own
+// response, and async routes, which answer before their usecase runs.
+func mockedUsecases(usecases []*ir.Component) []*ir.Component {
+	var mocked []*ir.Component
+	for _, uc := range usecases {
+		if uc.Usecase != nil && uc.Usecase.Binding != nil && !uc.Usecase.Binding.IsCatchAll() && !isAsync(uc) {
+			mocked = append(mocked, uc)
+		}
+	}
+	return mocked
+}
+
+// mockTestedUsecases returns the mocked usecases whose routes a server test
+// reaches without middleware, so it can check both answers of the route.
+// Routes requiring If-Match answer 428 before their usecase runs.
+func mockTestedUsecases(i *ir.IR, server *ir.Component, usecases []*ir.Component) []*ir.Component {
+	var tested []*ir.Component
+	for _, uc := range mockedUsecases(usecases) {
+		if len(effectiveUsecaseMiddleware(uc, server)) == 0 && usecaseAuthorizer(i, uc, server) == nil && !requiresIfMatch(uc) {
+			tested = append(tested, uc)
+		}
+	}
+	return tested
+}
+
+// hasMockedRoutes reports whether any server has a route that can answer
+// with a mock response.
+func hasMockedRoutes(i *ir.IR) bool {
+	for _, server := range i.HTTPServers() {
+		if len(mockedUsecases(i.UsecasesBoundTo(server.ID))) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// mockResponseLiteral renders the example of a usecase's success response as
+// a TypeScript expression, or undefined if its OpenAPI document has none.
+func mockResponseLiteral(uc *ir.Component) string {
+	op := uc.Usecase.Binding.Operation
+	if op == nil {
+		return "undefined"
+	}
+	example := successResponseExample(op)
+	if example == nil {
+		return "undefined"
+	}
+	data, err := json.Marshal(example)
+	if err != nil {
+		return "undefined"
+	}
+	return string(data)
+}
+
+// successResponseExample returns the JSON example of the lowest 2xx response
+// with one, if any.
+func successResponseExample(op *openapi.Operation) any {
+	statuses := make([]string, 0, len(op.Responses))
+	for status := range op.Responses {
+		if strings.HasPrefix(status, "2") {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+
+	for _, status := range statuses {
+		if resp := op.Responses[status]; resp != nil {
+			if media, ok := resp.Content["application/json"]; ok && media.Example != nil {
+				return media.Example
+			}
+		}
+	}
+	return nil
+}
+
+// writeMockUnimplemented writes the function routes hand their usecase's
+// errors to.
+func writeMockUnimplemented(sb *strings.Builder, i *ir.IR) {
+	envVar := mockUnimplementedEnvVar(i)
+	sb.WriteString("/**\n")
+	sb.WriteString(" * Answers 501 with a route's example response while its usecase is not\n")
+	fmt.Fprintf(sb, " * implemented yet and %s is true, so a partly implemented\n", envVar)
+	sb.WriteString(" * service can be demoed. Any other error is rethrown.\n")
+	sb.WriteString(" */\n")
+	sb.WriteString("function mockUnimplemented(error: unknown, example: unknown): Response {\n")
+	fmt.Fprintf(sb, "  if (process.env.%s === 'true' && error instanceof Error && error.message === 'Not implemented') {\n", envVar)
+	sb.WriteString("    return new Response(JSON.stringify(example ?? { error: 'Not Implemented' }), {\n")
+	sb.WriteString("      status: 501,\n")
+	sb.WriteString("      headers: { 'Content-Type': 'application/json' },\n")
+	sb.WriteString("    });\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("  throw error;\n")
+	sb.WriteString("}\n\n")
+}
+
+// writeUsecaseCall writes a route's call of its usecase, which assigns
+// result or answers with the mock response.
+func writeUsecaseCall(sb *strings.Builder, uc *ir.Component, call string) {
+	sb.WriteString("    let result;\n")
+	sb.WriteString("    try {\n")
+	fmt.Fprintf(sb, "      result = await %s;\n", call)
+	sb.WriteString("    } catch (error) {\n")
+	fmt.Fprintf(sb, "      return mockUnimplemented(error, %s);\n", mockResponseLiteral(uc))
+	sb.WriteString("    }\n")
+}
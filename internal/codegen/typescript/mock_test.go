@@ -0,0 +1,123 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+// withPublicStatus binds a GET /status usecase without middleware whose
+// OpenAPI response has an example.
+func withPublicStatus(i *ir.IR) {
+	i.Components["usecase.get-status"] = &ir.Component{
+		ID:   "usecase.get-status",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			Goal:       "Report the service status",
+			Middleware: []string{},
+			Binding: &ir.Binding{
+				ServerID: "http.server.api",
+				Method:   "GET",
+				Path:     "/status",
+				Operation: &openapi.Operation{
+					Responses: map[string]*openapi.Response{
+						"200": {Content: map[string]*openapi.MediaType{
+							"application/json": {
+								Schema:  &openapi.Schema{Type: "object"},
+								Example: map[string]any{"status": "ok", "uptime": 42},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestHonoServerGenerator_MockUnimplemented(t *testing.T) {
+	// given
+	i := newTestIR(withPublicStatus)
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then: the route answers with its example while the usecase is a stub
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	for _, want := range []string{
+		"function mockUnimplemented(error: unknown, example: unknown): Response {\n" +
+			"  if (process.env.MOCK_UNIMPLEMENTED === 'true' && error instanceof Error && error.message === 'Not implemented') {\n",
+		"    let result;\n" +
+			"    try {\n" +
+			"      result = await getStatusUsecase(undefined as void, context);\n" +
+			"    } catch (error) {\n" +
+			"      return mockUnimplemented(error, {\"status\":\"ok\",\"uptime\":42});\n" +
+			"    }\n" +
+			"    return c.json(result);\n",
+		"      return mockUnimplemented(error, undefined);\n",
+	} {
+		if !strings.Contains(server, want) {
+			t.Errorf("server file missing %q\n%s", want, server)
+		}
+	}
+}
+
+func TestTestGenerator_MockModes(t *testing.T) {
+	// given
+	i := newTestIR(withPublicStatus)
+
+	// when
+	output, err := NewTestGenerator().Generate(i)
+
+	// then: the route without middleware is tested in both modes
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	test := string(output.Files["src/components/http-server-api.server.test.ts"].Content)
+	for _, want := range []string{
+		"vi.mock('./usecase-get-status.usecase', () => ({\n" +
+			"  getStatusUsecase: vi.fn().mockRejectedValue(new Error('Not implemented')),\n" +
+			"}));\n",
+		"  it('should fail GET /status while its usecase is not implemented', async () => {\n" +
+			"    // given\n" +
+			"    vi.stubEnv('MOCK_UNIMPLEMENTED', 'false');\n",
+		"    expect(res.status).toBe(501);\n" +
+			"    expect(await res.json()).toEqual({\"status\":\"ok\",\"uptime\":42});\n",
+	} {
+		if !strings.Contains(test, want) {
+			t.Errorf("server test missing %q\n%s", want, test)
+		}
+	}
+	if strings.Contains(test, "vi.mock('./usecase-get-user.usecase'") {
+		t.Error("routes behind middleware should not be tested in mock modes")
+	}
+}
+
+func TestProjectEnvGroups_MockUnimplemented(t *testing.T) {
+	// given: a spec without routes
+	i := createTestIR()
+	for id, comp := range i.Components {
+		if comp.Kind == ir.KindUsecase {
+			delete(i.Components, id)
+		}
+	}
+
+	// when
+	groups := projectEnvGroups(i)
+
+	// then
+	for _, g := range groups {
+		for _, v := range g.Vars {
+			if v.Name == "MOCK_UNIMPLEMENTED" {
+				t.Errorf("MOCK_UNIMPLEMENTED listed without routes to mock")
+			}
+		}
+	}
+}
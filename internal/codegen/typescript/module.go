@@ -0,0 +1,196 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+// Module formats of the generated service.
+const (
+	// ModuleESM builds ES modules, the format the generators emit.
+	ModuleESM = "esm"
+	// ModuleCJS builds CommonJS modules, for tooling that cannot load ES modules.
+	ModuleCJS = "cjs"
+	// ModuleDual builds the package both ways, into dist/esm and dist/cjs.
+	ModuleDual = "dual"
+)
+
+// dualCJSConfig is the tsconfig of the CommonJS build of a dual package.
+const dualCJSConfig = "tsconfig.cjs.json"
+
+// dualBuild builds both halves of a dual package. dist/cjs gets a
+// package.json of its own, so Node loads the files there as CommonJS.
+const dualBuild = `tsc && tsc -p ` + dualCJSConfig + ` && node -e "require('fs').writeFileSync('dist/cjs/package.json', JSON.stringify({ type: 'commonjs' }))"`
+
+// tsConfigOverride is a tsconfig that changes some compiler options of the
+// config it extends.
+type tsConfigOverride struct {
+	Extends         string            `json:"extends"`
+	CompilerOptions map[string]string `json:"compilerOptions"`
+}
+
+// ModuleFormat returns the adapter of the generated files to a module
+// format, or nil for ModuleESM, which needs none.
+func ModuleFormat(format string) (func([]codegen.Artifact) ([]codegen.Artifact, error), error) {
+	switch format {
+	case "", ModuleESM:
+		return nil, nil
+	case ModuleCJS:
+		return toCommonJS, nil
+	case ModuleDual:
+		return toDual, nil
+	default:
+		return nil, fmt.Errorf("unknown module format %q (expected esm, cjs or dual)", format)
+	}
+}
+
+// toCommonJS makes the package CommonJS: tsc emits CommonJS modules, which
+// resolve extensionless imports as they are, and files use the __dirname
+// CommonJS provides. The Vitest config becomes an .mts file, so Vite still
+// loads it as an ES module.
+func toCommonJS(artifacts []codegen.Artifact) ([]codegen.Artifact, error) {
+	result := make([]codegen.Artifact, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		switch {
+		case artifact.Path == "package.json":
+			content, err := editPackageJSON(artifact.Content, func(pkg *PackageJSON) {
+				pkg.Type = "commonjs"
+			})
+			if err != nil {
+				return nil, err
+			}
+			artifact.Content = content
+		case artifact.Path == "tsconfig.json":
+			content, err := editTSConfig(artifact.Content, func(config *TSConfig) {
+				config.CompilerOptions.Module = "CommonJS"
+				config.CompilerOptions.ModuleResolution = "node10"
+			})
+			if err != nil {
+				return nil, err
+			}
+			artifact.Content = content
+		case artifact.Path == "vitest.config.ts":
+			artifact.Path = "vitest.config.mts"
+		case isTypeScriptFile(artifact.Path):
+			content := strings.Replace(string(artifact.Content), esmDirnameImport, "", 1)
+			artifact.Content = []byte(strings.Replace(content, esmDirname, "", 1))
+		}
+		result = append(result, artifact)
+	}
+	return result, nil
+}
+
+// toDual builds the package as ES modules into dist/esm and as CommonJS
+// into dist/cjs, exporting each to the importers that expect it. The ES
+// build resolves modules as Node does, so relative imports get the .js
+// extension of the file they compile to. Both builds run the same source,
+// so files that read the files next to them locate them from the package
+// directory, where the service runs.
+func toDual(artifacts []codegen.Artifact) ([]codegen.Artifact, error) {
+	known := make(map[string]bool, len(artifacts))
+	for _, artifact := range artifacts {
+		known[artifact.Path] = true
+	}
+
+	result := make([]codegen.Artifact, 0, len(artifacts)+1)
+	for _, artifact := range artifacts {
+		switch {
+		case artifact.Path == "package.json":
+			content, err := editPackageJSON(artifact.Content, func(pkg *PackageJSON) {
+				pkg.Main = "dist/cjs/index.js"
+				pkg.Exports = map[string]map[string]string{
+					".": {"import": "./dist/esm/index.js", "require": "./dist/cjs/index.js"},
+				}
+				pkg.Scripts["build"] = dualBuild
+				pkg.Scripts["start"] = "node dist/esm/index.js"
+			})
+			if err != nil {
+				return nil, err
+			}
+			artifact.Content = content
+		case artifact.Path == "tsconfig.json":
+			content, err := editTSConfig(artifact.Content, func(config *TSConfig) {
+				config.CompilerOptions.Module = "NodeNext"
+				config.CompilerOptions.ModuleResolution = "NodeNext"
+				config.CompilerOptions.OutDir = "./dist/esm"
+			})
+			if err != nil {
+				return nil, err
+			}
+			artifact.Content = content
+
+			cjs, err := marshalIndent(tsConfigOverride{
+				Extends: "./tsconfig.json",
+				CompilerOptions: map[string]string{
+					"module":           "CommonJS",
+					"moduleResolution": "node10",
+					"outDir":           "./dist/cjs",
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, codegen.Artifact{Owner: artifact.Owner, Path: dualCJSConfig, Content: cjs})
+		case artifact.Path == "Dockerfile":
+			artifact.Content = []byte(strings.Replace(string(artifact.Content), `"dist/index.js"`, `"dist/esm/index.js"`, 1))
+		case isTypeScriptFile(artifact.Path):
+			content := strings.Replace(string(artifact.Content), esmDirnameImport, "", 1)
+			dir := fmt.Sprintf("const __dirname = path.resolve('%s');\n\n", path.Dir(artifact.Path))
+			content = strings.Replace(content, esmDirname, dir, 1)
+			artifact.Content = addImportExtensions([]byte(content), artifact.Path, known)
+		}
+		result = append(result, artifact)
+	}
+	return result, nil
+}
+
+// addImportExtensions gives the relative specifiers of a file the .js
+// extension Node resolves ES modules by: "./db" becomes "./db.js", and a
+// directory "./schema/index.js". Specifiers of files that are not
+// generated are taken to name TypeScript modules.
+func addImportExtensions(content []byte, filePath string, known map[string]bool) []byte {
+	return relativeImportPattern.ReplaceAllFunc(content, func(match []byte) []byte {
+		m := relativeImportPattern.FindSubmatch(match)
+		prefix, quote, spec := string(m[1]), string(m[2]), string(m[3])
+
+		target := path.Join(path.Dir(filePath), spec)
+		switch {
+		case known[target+".ts"], known[target+".tsx"]:
+			spec += ".js"
+		case known[target+"/index.ts"]:
+			spec = strings.TrimSuffix(spec, "/") + "/index.js"
+		case known[target], strings.HasSuffix(spec, ".js"):
+			return match
+		default:
+			spec += ".js"
+		}
+		return []byte(prefix + quote + spec + quote)
+	})
+}
+
+// editPackageJSON applies edit to a generated package.json.
+func editPackageJSON(content []byte, edit func(*PackageJSON)) ([]byte, error) {
+	var pkg PackageJSON
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, fmt.Errorf("package.json: %w", err)
+	}
+	edit(&pkg)
+	return marshalIndent(pkg)
+}
+
+// editTSConfig applies edit to a generated tsconfig.json.
+func editTSConfig(content []byte, edit func(*TSConfig)) ([]byte, error) {
+	var config TSConfig
+	if err := json.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("tsconfig.json: %w", err)
+	}
+	edit(&config)
+	return marshalIndent(config)
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+// moduleTestArtifacts returns the project files and an ES module that
+// defines __dirname and imports a file, a directory and a package.
+func moduleTestArtifacts(t *testing.T) []codegen.Artifact {
+	t.Helper()
+	project, err := NewProjectGenerator().Generate(createTestIR())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []codegen.Artifact{
+		{Path: "package.json", Content: project.Files["package.json"].Content},
+		{Path: "tsconfig.json", Content: project.Files["tsconfig.json"].Content},
+		{Path: "vitest.config.ts", Content: project.Files["vitest.config.ts"].Content},
+		{Path: "Dockerfile", Content: []byte(`CMD ["node", "dist/index.js"]` + "\n")},
+		{Path: "src/components/enforcer.ts", Content: []byte("import { z } from 'zod';\n" +
+			"import { db } from './db';\n" +
+			"import * as schema from './schema';\n" +
+			"import { handler } from './handwritten';\n" +
+			"import path from 'path';\n" + esmDirnameImport + esmDirname +
+			"const model = path.join(__dirname, 'model.conf');\n")},
+		{Path: "src/components/db.ts", Content: []byte("export const db = {};\n")},
+		{Path: "src/components/schema/index.ts", Content: []byte("export {};\n")},
+	}
+}
+
+func byPath(artifacts []codegen.Artifact) map[string][]byte {
+	files := make(map[string][]byte, len(artifacts))
+	for _, artifact := range artifacts {
+		files[artifact.Path] = artifact.Content
+	}
+	return files
+}
+
+func TestModuleFormat_CommonJS(t *testing.T) {
+	// given
+	adapt, err := ModuleFormat(ModuleCJS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// when
+	result, err := adapt(moduleTestArtifacts(t))
+
+	// then
+	if err != nil {
+		t.Fatalf("adapt() error = %v", err)
+	}
+	files := byPath(result)
+	var pkg PackageJSON
+	if err := json.Unmarshal(files["package.json"], &pkg); err != nil {
+		t.Fatal(err)
+	}
+	if pkg.Type != "commonjs" || pkg.Exports != nil {
+		t.Errorf("package.json type = %q, exports = %v", pkg.Type, pkg.Exports)
+	}
+	var config TSConfig
+	if err := json.Unmarshal(files["tsconfig.json"], &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.CompilerOptions.Module != "CommonJS" || config.CompilerOptions.ModuleResolution != "node10" {
+		t.Errorf("tsconfig.json module = %q, moduleResolution = %q", config.CompilerOptions.Module, config.CompilerOptions.ModuleResolution)
+	}
+	if _, ok := files["vitest.config.mts"]; !ok {
+		t.Error("vitest.config.ts was not renamed to vitest.config.mts")
+	}
+	enforcer := string(files["src/components/enforcer.ts"])
+	if strings.Contains(enforcer, "import.meta") || !strings.Contains(enforcer, "from './db';") {
+		t.Errorf("enforcer.ts =\n%s", enforcer)
+	}
+}
+
+func TestModuleFormat_Dual(t *testing.T) {
+	// given
+	adapt, err := ModuleFormat(ModuleDual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// when
+	result, err := adapt(moduleTestArtifacts(t))
+
+	// then
+	if err != nil {
+		t.Fatalf("adapt() error = %v", err)
+	}
+	files := byPath(result)
+	var pkg PackageJSON
+	if err := json.Unmarshal(files["package.json"], &pkg); err != nil {
+		t.Fatal(err)
+	}
+	if pkg.Type != "module" || pkg.Exports["."]["require"] != "./dist/cjs/index.js" || pkg.Exports["."]["import"] != "./dist/esm/index.js" {
+		t.Errorf("package.json type = %q, exports = %v", pkg.Type, pkg.Exports)
+	}
+	if pkg.Scripts["build"] != dualBuild || pkg.Scripts["start"] != "node dist/esm/index.js" {
+		t.Errorf("package.json scripts = %v", pkg.Scripts)
+	}
+	if !strings.Contains(string(files["package.json"]), "tsc && tsc") {
+		t.Errorf("package.json escapes the build script:\n%s", files["package.json"])
+	}
+	var config TSConfig
+	if err := json.Unmarshal(files["tsconfig.json"], &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.CompilerOptions.Module != "NodeNext" || config.CompilerOptions.OutDir != "./dist/esm" {
+		t.Errorf("tsconfig.json module = %q, outDir = %q", config.CompilerOptions.Module, config.CompilerOptions.OutDir)
+	}
+	var cjs tsConfigOverride
+	if err := json.Unmarshal(files[dualCJSConfig], &cjs); err != nil {
+		t.Fatal(err)
+	}
+	if cjs.Extends != "./tsconfig.json" || cjs.CompilerOptions["module"] != "CommonJS" || cjs.CompilerOptions["outDir"] != "./dist/cjs" {
+		t.Errorf("%s = %+v", dualCJSConfig, cjs)
+	}
+	if !strings.Contains(string(files["Dockerfile"]), `"dist/esm/index.js"`) {
+		t.Errorf("Dockerfile =\n%s", files["Dockerfile"])
+	}
+
+	want := "import { z } from 'zod';\n" +
+		"import { db } from './db.js';\n" +
+		"import * as schema from './schema/index.js';\n" +
+		"import { handler } from './handwritten.js';\n" +
+		"import path from 'path';\n" +
+		"const __dirname = path.resolve('src/components');\n\n" +
+		"const model = path.join(__dirname, 'model.conf');\n"
+	if got := string(files["src/components/enforcer.ts"]); got != want {
+		t.Errorf("enforcer.ts =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestModuleFormat(t *testing.T) {
+	for _, format := range []string{"", ModuleESM} {
+		if adapt, err := ModuleFormat(format); adapt != nil || err != nil {
+			t.Errorf("ModuleFormat(%q) = %v, %v; want no adapter", format, adapt != nil, err)
+		}
+	}
+	if _, err := ModuleFormat("umd"); err == nil || !strings.Contains(err.Error(), "unknown module format") {
+		t.Errorf("ModuleFormat(umd) error = %v", err)
+	}
+}
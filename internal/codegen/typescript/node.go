@@ -0,0 +1,34 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import "github.com/openboundary/openboundary/internal/ir"
+
+// DefaultNodeVersion is the Node.js major version a service runs on when its
+// spec targets none.
+const DefaultNodeVersion = "20"
+
+// nodeEditions are the newest ECMAScript editions each Node.js version a
+// spec can target implements, which tsc compiles to and type-checks against.
+var nodeEditions = map[string]string{
+	"20": "ES2023",
+	"22": "ES2024",
+}
+
+// targetNode returns the Node.js version the spec of i targets, or "" if it
+// targets none.
+func targetNode(i *ir.IR) string {
+	if i.Spec == nil {
+		return ""
+	}
+	return i.Spec.Node
+}
+
+// nodeVersion returns the Node.js version the service of i runs on.
+func nodeVersion(i *ir.IR) string {
+	if node := targetNode(i); node != "" {
+		return node
+	}
+	return DefaultNodeVersion
+}
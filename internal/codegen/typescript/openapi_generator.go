@@ -5,14 +5,16 @@ package typescript
 
 import (
 	"fmt"
+	"html"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/openboundary/openboundary/internal/codegen"
 	"github.com/openboundary/openboundary/internal/ir"
 )
 
-// OpenAPIGenerator generates a complete OpenAPI spec for orval type generation.
+// OpenAPIGenerator generates a complete OpenAPI spec for each server.
 type OpenAPIGenerator struct{}
 
 // NewOpenAPIGenerator creates a new OpenAPI generator.
@@ -35,19 +37,59 @@ func (g *OpenAPIGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
 		if comp.Kind == ir.KindHTTPServer && comp.HTTPServer != nil {
 			spec := g.generateOpenAPISpec(i, comp)
 			output.AddComponentFile(serverOpenAPIPath(comp.ID), []byte(spec), comp.ID)
+			if comp.HTTPServer.APIDocs != nil {
+				docs := g.generateAPIDocs(comp, spec)
+				output.AddComponentFile(serverDocsPath(comp.ID), []byte(docs), comp.ID)
+			}
 		}
 	}
 
 	return output, nil
 }
 
+// generateAPIDocs generates the module a server serves its API reference
+// from. The document is embedded rather than read from the colocated YAML
+// file, which the TypeScript build does not copy.
+func (g *OpenAPIGenerator) generateAPIDocs(server *ir.Component, spec string) string {
+	docs := server.HTTPServer.APIDocs
+	specURL := server.HTTPServer.RoutePath(docs.Path + "/openapi.yaml")
+
+	title := server.ID + " API reference"
+	if parsed := server.HTTPServer.ParsedOpenAPI; parsed != nil && !parsed.Synthesized && parsed.Title != "" {
+		title = parsed.Title
+	}
+
+	var page strings.Builder
+	page.WriteString("<!doctype html>\n<html>\n<head>\n")
+	fmt.Fprintf(&page, "<title>%s</title>\n", html.EscapeString(title))
+	page.WriteString("<meta charset=\"utf-8\" />\n")
+	page.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\" />\n")
+	page.WriteString("</head>\n<body>\n")
+	switch docs.UI {
+	case ir.APIDocsRedoc:
+		fmt.Fprintf(&page, "<redoc spec-url=\"%s\"></redoc>\n", html.EscapeString(specURL))
+		page.WriteString("<script src=\"https://cdn.redoc.ly/redoc/latest/bundles/redoc.standalone.js\"></script>\n")
+	default:
+		fmt.Fprintf(&page, "<script id=\"api-reference\" data-url=\"%s\"></script>\n", html.EscapeString(specURL))
+		page.WriteString("<script src=\"https://cdn.jsdelivr.net/npm/@scalar/api-reference\"></script>\n")
+	}
+	page.WriteString("</body>\n</html>\n")
+
+	var sb strings.Builder
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	fmt.Fprintf(&sb, "// API reference of %s, served at %s.\n\n", server.ID, server.HTTPServer.RoutePath(docs.Path))
+	fmt.Fprintf(&sb, "export const openapiDocument = %s;\n\n", strconv.Quote(spec))
+	fmt.Fprintf(&sb, "export const apiDocsPage = %s;\n", strconv.Quote(page.String()))
+	return sb.String()
+}
+
 func (g *OpenAPIGenerator) generateOpenAPISpec(i *ir.IR, server *ir.Component) string {
 	var sb strings.Builder
 
 	// Get title/version from parsed OpenAPI if available
 	title := "API"
 	version := "0.0.1"
-	if server.HTTPServer.ParsedOpenAPI != nil {
+	if server.HTTPServer.ParsedOpenAPI != nil && !server.HTTPServer.ParsedOpenAPI.Synthesized {
 		if server.HTTPServer.ParsedOpenAPI.Title != "" {
 			title = server.HTTPServer.ParsedOpenAPI.Title
 		}
@@ -56,17 +98,30 @@ func (g *OpenAPIGenerator) generateOpenAPISpec(i *ir.IR, server *ir.Component) s
 		}
 	}
 
-	sb.WriteString("# Generated by OpenBoundary - DO NOT EDIT\n")
+	sb.WriteString(codegen.Header(codegen.HashComments))
 	sb.WriteString("openapi: 3.0.3\n")
 	sb.WriteString("info:\n")
 	sb.WriteString(fmt.Sprintf("  title: %s\n", title))
 	sb.WriteString(fmt.Sprintf("  version: %s\n", version))
+	if base := server.HTTPServer.BasePath; base != "" {
+		sb.WriteString("servers:\n")
+		sb.WriteString(fmt.Sprintf("  - url: %s\n", base))
+	}
+	if server.Description != "" || len(server.Links) > 0 {
+		sb.WriteString("tags:\n")
+		sb.WriteString(fmt.Sprintf("  - name: %s\n", server.ID))
+		if summary := componentSummary(server); summary != "" {
+			sb.WriteString(fmt.Sprintf("    description: %s\n", strconv.Quote(summary)))
+		}
+		writeExternalDocs(&sb, "    ", server)
+	}
 	sb.WriteString("paths:\n")
 
-	// Collect all usecases bound to this server, grouped by path
+	// Collect all usecases bound to this server, grouped by path. OpenAPI
+	// cannot describe catch-all routes, so the document leaves them out.
 	pathOps := make(map[string][]*ir.Component)
 	for _, comp := range i.Components {
-		if comp.Kind == ir.KindUsecase && comp.Usecase != nil && comp.Usecase.Binding != nil {
+		if comp.Kind == ir.KindUsecase && comp.Usecase != nil && comp.Usecase.Binding != nil && !comp.Usecase.Binding.IsCatchAll() {
 			if comp.Usecase.Binding.ServerID == server.ID {
 				path := comp.Usecase.Binding.Path
 				pathOps[path] = append(pathOps[path], comp)
@@ -74,49 +129,87 @@ func (g *OpenAPIGenerator) generateOpenAPISpec(i *ir.IR, server *ir.Component) s
 		}
 	}
 
+	// Async usecases add the route reporting their jobs
+	jobStatusOps := make(map[string]*ir.Component)
+	for _, ops := range pathOps {
+		for _, uc := range ops {
+			if isAsync(uc) {
+				jobStatusOps[uc.Usecase.JobStatus().Path] = uc
+			}
+		}
+	}
+
 	// Sort paths for deterministic output
-	paths := make([]string, 0, len(pathOps))
+	paths := make([]string, 0, len(pathOps)+len(jobStatusOps))
 	for path := range pathOps {
 		paths = append(paths, path)
 	}
+	for path := range jobStatusOps {
+		if _, ok := pathOps[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
 	sort.Strings(paths)
 
+	var bound []*ir.Component
+	for _, ops := range pathOps {
+		bound = append(bound, ops...)
+	}
+	operationIDs := stableOperationIDs(bound)
+
+	hasAuthorization := false
 	for _, path := range paths {
 		ops := pathOps[path]
 		sb.WriteString(fmt.Sprintf("  %s:\n", path))
 
-		// Extract path parameters once for this path
-		pathParams := extractPathParams(path)
-
 		// Sort operations by method for deterministic output
 		sort.Slice(ops, func(i, j int) bool {
 			return ops[i].Usecase.Binding.Method < ops[j].Usecase.Binding.Method
 		})
 
+		if uc, ok := jobStatusOps[path]; ok {
+			writeJobStatusOperation(&sb, uc, server, operationIDs[uc.ID])
+		}
+
 		for _, uc := range ops {
 			method := strings.ToLower(uc.Usecase.Binding.Method)
 			sb.WriteString(fmt.Sprintf("    %s:\n", method))
 
-			// Operation ID from OpenAPI or generate from usecase
-			operationID := ""
-			if uc.Usecase.Binding.Operation != nil && uc.Usecase.Binding.Operation.OperationID != "" {
-				operationID = uc.Usecase.Binding.Operation.OperationID
-			} else {
-				operationID = toFunctionName(uc.ID)
-			}
+			operationID := operationIDs[uc.ID]
 			sb.WriteString(fmt.Sprintf("      operationId: %s\n", operationID))
 
 			// Summary from goal
 			if uc.Usecase.Goal != "" {
 				sb.WriteString(fmt.Sprintf("      summary: %s\n", uc.Usecase.Goal))
 			}
+			hasBody := method == "post" || method == "put" || method == "patch"
+			limits := ir.RouteLimits(server.HTTPServer, uc.Usecase)
+			if limits.MaxBodyKB > 0 && !hasBody {
+				limits.MaxBodyKB = 0
+			}
+			description := componentSummary(uc)
+			if note := limitsDescription(limits); note != "" {
+				description = strings.TrimSpace(description + "\n\n" + note)
+			}
+			if cache := uc.Usecase.Cache; cache != nil {
+				description = strings.TrimSpace(description + "\n\n" + cacheDescription(cache))
+			}
+			if description != "" {
+				sb.WriteString(fmt.Sprintf("      description: %s\n", strconv.Quote(description)))
+			}
+			writeExternalDocs(&sb, "      ", uc)
+			if uc.Deprecated {
+				sb.WriteString("      deprecated: true\n")
+			}
 
 			// Tags
 			sb.WriteString("      tags:\n")
 			sb.WriteString(fmt.Sprintf("        - %s\n", server.ID))
 
 			// Parameters
-			if len(pathParams) > 0 {
+			pathParams := uc.Usecase.Binding.PathParams()
+			etag := uc.Usecase.Concurrency == ir.ConcurrencyETag
+			if len(pathParams) > 0 || etag {
 				sb.WriteString("      parameters:\n")
 				for _, param := range pathParams {
 					sb.WriteString(fmt.Sprintf("        - name: %s\n", param))
@@ -125,10 +218,13 @@ func (g *OpenAPIGenerator) generateOpenAPISpec(i *ir.IR, server *ir.Component) s
 					sb.WriteString("          schema:\n")
 					sb.WriteString("            type: string\n")
 				}
+				if etag {
+					writeETagParameter(&sb, requiresIfMatch(uc))
+				}
 			}
 
 			// Request body for POST/PUT/PATCH
-			if method == "post" || method == "put" || method == "patch" {
+			if hasBody {
 				sb.WriteString("      requestBody:\n")
 				sb.WriteString("        required: true\n")
 				sb.WriteString("        content:\n")
@@ -137,18 +233,83 @@ func (g *OpenAPIGenerator) generateOpenAPISpec(i *ir.IR, server *ir.Component) s
 				sb.WriteString(fmt.Sprintf("              $ref: '#/components/schemas/%sRequest'\n", toPascalCase(operationID)))
 			}
 
+			// Authorization requirements
+			if a := uc.Usecase.Authorization; a != nil {
+				hasAuthorization = true
+				sb.WriteString("      security:\n")
+				sb.WriteString("        - session: []\n")
+				sb.WriteString("      x-authorization:\n")
+				if len(a.Roles) > 0 {
+					sb.WriteString("        roles:\n")
+					for _, r := range a.Roles {
+						sb.WriteString(fmt.Sprintf("          - %s\n", r))
+					}
+				}
+				if len(a.Permissions) > 0 {
+					sb.WriteString("        permissions:\n")
+					for _, p := range a.Permissions {
+						sb.WriteString(fmt.Sprintf("          - %s\n", p))
+					}
+				}
+			}
+
 			// Responses
 			sb.WriteString("      responses:\n")
 			statusCode := g.getSuccessStatus(method)
+			if isAsync(uc) {
+				statusCode = "202"
+			}
 			sb.WriteString(fmt.Sprintf("        '%s':\n", statusCode))
 			sb.WriteString(fmt.Sprintf("          description: %s\n", g.getStatusDescription(statusCode)))
+			if isAsync(uc) {
+				sb.WriteString("          headers:\n")
+				writeResponseHeader(&sb, "Location", server.HTTPServer.RoutePath(uc.Usecase.JobStatus().Path))
+				writeJobContent(&sb)
+			}
+			if cache := uc.Usecase.Cache; cache != nil || (etag && method == "get") {
+				sb.WriteString("          headers:\n")
+				if cache != nil {
+					writeCacheHeaders(&sb, cache)
+				}
+				if etag {
+					writeResponseHeader(&sb, "ETag", `"RBNvo1WzZ4oRRq0W9-hknpT7T8If536DEMBg9hyq_4o"`)
+				}
+			}
 
-			if statusCode != "204" {
+			if statusCode != "204" && statusCode != "202" {
 				sb.WriteString("          content:\n")
 				sb.WriteString("            application/json:\n")
 				sb.WriteString("              schema:\n")
 				sb.WriteString(fmt.Sprintf("                $ref: '#/components/schemas/%sResponse'\n", toPascalCase(operationID)))
 			}
+			if isBulk(uc) && !isAsync(uc) {
+				sb.WriteString("        '207':\n")
+				sb.WriteString("          description: Multi-Status; some items failed, see the status of each result\n")
+			}
+			if etag && method == "get" {
+				sb.WriteString("        '304':\n")
+				sb.WriteString("          description: Not Modified\n")
+			}
+			if uc.Usecase.Authorization != nil {
+				sb.WriteString("        '403':\n")
+				sb.WriteString("          description: Forbidden\n")
+			}
+			if requiresIfMatch(uc) {
+				sb.WriteString("        '412':\n")
+				sb.WriteString("          description: Precondition Failed\n")
+			}
+			if limits.MaxBodyKB > 0 {
+				sb.WriteString("        '413':\n")
+				sb.WriteString("          description: Payload Too Large\n")
+			}
+			if requiresIfMatch(uc) {
+				sb.WriteString("        '428':\n")
+				sb.WriteString("          description: Precondition Required\n")
+			}
+			if limits.TimeoutMS > 0 {
+				sb.WriteString("        '504':\n")
+				sb.WriteString("          description: Gateway Timeout\n")
+			}
 		}
 	}
 
@@ -160,22 +321,22 @@ func (g *OpenAPIGenerator) generateOpenAPISpec(i *ir.IR, server *ir.Component) s
 	for _, path := range paths {
 		for _, uc := range pathOps[path] {
 			method := strings.ToLower(uc.Usecase.Binding.Method)
-			operationID := ""
-			if uc.Usecase.Binding.Operation != nil && uc.Usecase.Binding.Operation.OperationID != "" {
-				operationID = uc.Usecase.Binding.Operation.OperationID
-			} else {
-				operationID = toFunctionName(uc.ID)
-			}
-			pascalID := toPascalCase(operationID)
+			pascalID := toPascalCase(operationIDs[uc.ID])
 
 			// Request schema for POST/PUT/PATCH
 			if method == "post" || method == "put" || method == "patch" {
 				sb.WriteString(fmt.Sprintf("    %sRequest:\n", pascalID))
-				sb.WriteString("      type: object\n")
-				sb.WriteString("      properties:\n")
-				sb.WriteString("        # TODO: Define request properties\n")
-				sb.WriteString("        data:\n")
-				sb.WriteString("          type: object\n")
+				indent := "      "
+				if isBulk(uc) {
+					sb.WriteString("      type: array\n")
+					sb.WriteString("      items:\n")
+					indent = "        "
+				}
+				sb.WriteString(indent + "type: object\n")
+				sb.WriteString(indent + "properties:\n")
+				sb.WriteString(indent + "  # TODO: Define request properties\n")
+				sb.WriteString(indent + "  data:\n")
+				sb.WriteString(indent + "    type: object\n")
 			}
 
 			// Response schema (except for 204)
@@ -190,9 +351,133 @@ func (g *OpenAPIGenerator) generateOpenAPISpec(i *ir.IR, server *ir.Component) s
 		}
 	}
 
+	if len(jobStatusOps) > 0 {
+		writeJobSchemas(&sb)
+	}
+
+	// Authorized operations identify the caller by their better-auth session
+	if hasAuthorization {
+		sb.WriteString("  securitySchemes:\n")
+		sb.WriteString("    session:\n")
+		sb.WriteString("      type: apiKey\n")
+		sb.WriteString("      in: cookie\n")
+		sb.WriteString("      name: better-auth.session_token\n")
+	}
+
 	return sb.String()
 }
 
+// stableOperationIDs names the operation of each usecase, keyed by usecase
+// ID. Its Request and Response schemas are named after it, so a name must
+// not change when unrelated operations do:
+//
+//  1. The operationId of the usecase's OpenAPI operation, if any.
+//  2. Otherwise, one derived from the usecase ID, e.g. "createUserUsecase"
+//     for usecase.create-user, which does not depend on the route.
+//  3. When that schema name is taken, one derived from the full usecase ID,
+//     e.g. "usecaseOrdersCreate", which component IDs keep unique.
+//
+// operationIds from the document are assigned first, and each step assigns
+// in usecase ID order, so adding a usecase never renames the schemas of an
+// operation that has an operationId.
+func stableOperationIDs(usecases []*ir.Component) map[string]string {
+	sorted := append([]*ir.Component(nil), usecases...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].ID < sorted[b].ID })
+
+	ids := make(map[string]string, len(sorted))
+	taken := make(map[string]bool, len(sorted))
+	for _, uc := range sorted {
+		if op := uc.Usecase.Binding.Operation; op != nil && op.OperationID != "" && !taken[toPascalCase(op.OperationID)] {
+			ids[uc.ID] = op.OperationID
+			taken[toPascalCase(op.OperationID)] = true
+		}
+	}
+	for _, uc := range sorted {
+		if _, ok := ids[uc.ID]; ok {
+			continue
+		}
+		id := toFunctionName(uc.ID)
+		if taken[toPascalCase(id)] {
+			id = toCamelCase(uc.ID)
+		}
+		ids[uc.ID] = id
+		taken[toPascalCase(id)] = true
+	}
+	return ids
+}
+
+// limitsDescription describes the limits of a route for its operation, or
+// returns "" when it has none.
+func limitsDescription(l ir.Limits) string {
+	var parts []string
+	if l.TimeoutMS > 0 {
+		parts = append(parts, fmt.Sprintf("times out after %d ms", l.TimeoutMS))
+	}
+	if l.MaxBodyKB > 0 {
+		parts = append(parts, fmt.Sprintf("accepts bodies of at most %d KB", l.MaxBodyKB))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "Limits: " + strings.Join(parts, " and ") + "."
+}
+
+// cacheDescription describes how responses of an operation may be cached.
+func cacheDescription(c *ir.CacheSpec) string {
+	who := "the caller's cache"
+	if c.Visibility == ir.CachePublic {
+		who = "any cache"
+	}
+	desc := fmt.Sprintf("Caching: %s may reuse responses for %d s", who, c.TTL)
+	if len(c.Vary) > 0 {
+		desc += ", keyed by " + strings.Join(c.Vary, " and ")
+	}
+	desc += "."
+	if c.Server {
+		desc += " The server answers from its own cache for as long"
+		if len(c.Tables) > 0 {
+			desc += ", until " + strings.Join(c.Tables, " or ") + " is written"
+		}
+		desc += "."
+	}
+	return desc
+}
+
+// writeCacheHeaders documents the caching headers of a successful response.
+func writeCacheHeaders(sb *strings.Builder, c *ir.CacheSpec) {
+	writeResponseHeader(sb, "Cache-Control", c.CacheControl())
+	if len(c.Vary) > 0 {
+		writeResponseHeader(sb, "Vary", strings.Join(c.Vary, ", "))
+	}
+}
+
+// writeResponseHeader documents a string header of a successful response.
+func writeResponseHeader(sb *strings.Builder, name, example string) {
+	fmt.Fprintf(sb, "            %s:\n", name)
+	sb.WriteString("              schema:\n")
+	sb.WriteString("                type: string\n")
+	fmt.Fprintf(sb, "                example: %s\n", strconv.Quote(example))
+}
+
+// writeETagParameter documents the conditional request header of a route
+// with optimistic concurrency: the If-Match an update requires, or the
+// If-None-Match a read answers 304 to.
+func writeETagParameter(sb *strings.Builder, ifMatch bool) {
+	if ifMatch {
+		sb.WriteString("        - name: If-Match\n")
+		sb.WriteString("          in: header\n")
+		sb.WriteString("          required: true\n")
+		sb.WriteString("          description: ETag of the resource as last read; 412 if it changed since\n")
+	} else {
+		sb.WriteString("        - name: If-None-Match\n")
+		sb.WriteString("          in: header\n")
+		sb.WriteString("          required: false\n")
+		sb.WriteString("          description: ETag of the resource as last read; 304 if it is unchanged\n")
+	}
+	sb.WriteString("          schema:\n")
+	sb.WriteString("            type: string\n")
+}
+
 func (g *OpenAPIGenerator) getSuccessStatus(method string) string {
 	switch method {
 	case "post":
@@ -210,6 +495,8 @@ func (g *OpenAPIGenerator) getStatusDescription(status string) string {
 		return "OK"
 	case "201":
 		return "Created"
+	case "202":
+		return "Accepted"
 	case "204":
 		return "No Content"
 	default:
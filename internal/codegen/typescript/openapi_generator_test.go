@@ -0,0 +1,231 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+func TestOpenAPIGenerator_Generate_UsecaseAuthorization(t *testing.T) {
+	// given
+	i := newTestIR(withUsecaseAuthorization)
+
+	// when
+	output, err := NewOpenAPIGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	spec := string(output.Files["src/components/http-server-api.openapi.yaml"].Content)
+	for _, want := range []string{
+		"      security:\n        - session: []\n" +
+			"      x-authorization:\n        roles:\n          - admin\n        permissions:\n          - user.read\n",
+		"        '403':\n          description: Forbidden\n",
+		"  securitySchemes:\n    session:\n      type: apiKey\n      in: cookie\n      name: better-auth.session_token\n",
+	} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("OpenAPI spec missing %q\n%s", want, spec)
+		}
+	}
+	if strings.Count(spec, "security:") != 1 {
+		t.Error("only the usecase declaring authorization should require security")
+	}
+}
+
+func TestOpenAPIGenerator_Generate_Limits(t *testing.T) {
+	// given
+	i := newTestIR(withLimits)
+
+	// when
+	output, err := NewOpenAPIGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	spec := string(output.Files["src/components/http-server-api.openapi.yaml"].Content)
+	for _, want := range []string{
+		`      description: "Limits: times out after 5000 ms and accepts bodies of at most 64 KB."` + "\n",
+		`      description: "Limits: times out after 30000 ms."` + "\n",
+		"        '413':\n          description: Payload Too Large\n",
+		"        '504':\n          description: Gateway Timeout\n",
+	} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("OpenAPI spec missing %q\n%s", want, spec)
+		}
+	}
+	if strings.Count(spec, "'413':") != 1 {
+		t.Error("only operations with a request body should answer 413")
+	}
+}
+
+func TestOpenAPIGenerator_Generate_Cache(t *testing.T) {
+	// given
+	i := newTestIR(withCache)
+
+	// when
+	output, err := NewOpenAPIGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	spec := string(output.Files["src/components/http-server-api.openapi.yaml"].Content)
+	for _, want := range []string{
+		`      description: "Caching: any cache may reuse responses for 60 s, keyed by Accept-Language and Cookie."` + "\n",
+		"          headers:\n" +
+			"            Cache-Control:\n              schema:\n                type: string\n                example: \"public, max-age=60\"\n" +
+			"            Vary:\n              schema:\n                type: string\n                example: \"Accept-Language, Cookie\"\n",
+	} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("OpenAPI spec missing %q\n%s", want, spec)
+		}
+	}
+}
+
+func TestCacheDescription(t *testing.T) {
+	tests := []struct {
+		cache ir.CacheSpec
+		want  string
+	}{
+		{ir.CacheSpec{TTL: 60, Visibility: ir.CachePrivate}, "Caching: the caller's cache may reuse responses for 60 s."},
+		{
+			ir.CacheSpec{TTL: 300, Visibility: ir.CachePublic, Server: true},
+			"Caching: any cache may reuse responses for 300 s. The server answers from its own cache for as long.",
+		},
+		{
+			ir.CacheSpec{TTL: 300, Visibility: ir.CachePublic, Server: true, Tables: []string{"products", "prices"}},
+			"Caching: any cache may reuse responses for 300 s. The server answers from its own cache for as long, until products or prices is written.",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := cacheDescription(&tt.cache); got != tt.want {
+			t.Errorf("cacheDescription(%+v) = %q, want %q", tt.cache, got, tt.want)
+		}
+	}
+}
+
+func TestOpenAPIGenerator_Generate_BasePath(t *testing.T) {
+	// given
+	i := createTestIR()
+	i.Components["http.server.api"].HTTPServer.BasePath = "/api/v1"
+
+	// when
+	output, err := NewOpenAPIGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	spec := string(output.Files["src/components/http-server-api.openapi.yaml"].Content)
+	for _, want := range []string{
+		"servers:\n  - url: /api/v1\npaths:\n",
+		"  /users:\n",
+	} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("OpenAPI spec missing %q\n%s", want, spec)
+		}
+	}
+}
+
+func TestOpenAPIGenerator_Generate_APIDocs(t *testing.T) {
+	tests := []struct {
+		name    string
+		docs    *ir.APIDocsSpec
+		wantAll []string
+	}{
+		{
+			name: "scalar",
+			docs: &ir.APIDocsSpec{UI: ir.APIDocsScalar, Path: "/docs"},
+			wantAll: []string{
+				`<script id=\"api-reference\" data-url=\"/api/v1/docs/openapi.yaml\"></script>`,
+				"https://cdn.jsdelivr.net/npm/@scalar/api-reference",
+			},
+		},
+		{
+			name: "redoc",
+			docs: &ir.APIDocsSpec{UI: ir.APIDocsRedoc, Path: "/reference"},
+			wantAll: []string{
+				`<redoc spec-url=\"/api/v1/reference/openapi.yaml\"></redoc>`,
+				"redoc.standalone.js",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			i := createTestIR()
+			i.Components["http.server.api"].HTTPServer.BasePath = "/api/v1"
+			i.Components["http.server.api"].HTTPServer.APIDocs = tt.docs
+
+			// when
+			output, err := NewOpenAPIGenerator().Generate(i)
+
+			// then
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			docs := string(output.Files["src/components/http-server-api.docs.ts"].Content)
+			for _, want := range append(tt.wantAll, `export const openapiDocument = "# Generated by OpenBoundary - DO NOT EDIT\nopenapi: 3.0.3\n`) {
+				if !strings.Contains(docs, want) {
+					t.Errorf("docs module missing %q\n%s", want, docs)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenAPIGenerator_Generate_NoAPIDocs(t *testing.T) {
+	// given
+	i := createTestIR()
+
+	// when
+	output, err := NewOpenAPIGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files["src/components/http-server-api.docs.ts"]; ok {
+		t.Error("docs module generated for a server without api_docs")
+	}
+}
+
+func TestStableOperationIDs(t *testing.T) {
+	// given - an operationId, a usecase without one, and one whose derived
+	// name an operationId already takes
+	usecase := func(id, operationID string) *ir.Component {
+		return &ir.Component{ID: id, Kind: ir.KindUsecase, Usecase: &ir.UsecaseSpec{
+			Binding: &ir.Binding{Operation: &openapi.Operation{OperationID: operationID}},
+		}}
+	}
+	usecases := []*ir.Component{
+		usecase("usecase.orders.create", ""),
+		usecase("usecase.create", "createUsecase"),
+		usecase("usecase.list-orders", "listOrders"),
+		usecase("usecase.get-order", ""),
+	}
+
+	// when
+	ids := stableOperationIDs(usecases)
+
+	// then
+	want := map[string]string{
+		"usecase.create":        "createUsecase",
+		"usecase.get-order":     "getOrderUsecase",
+		"usecase.list-orders":   "listOrders",
+		"usecase.orders.create": "usecaseOrdersCreate",
+	}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("stableOperationIDs() = %v, expected %v", ids, want)
+	}
+}
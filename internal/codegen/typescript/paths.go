@@ -32,6 +32,14 @@ func serverOpenAPIPath(id string) string {
 	return fmt.Sprintf("src/components/%s.openapi.yaml", componentIDSlug(id))
 }
 
+func serverDocsPath(id string) string {
+	return fmt.Sprintf("src/components/%s.docs.ts", componentIDSlug(id))
+}
+
+func serverMetricsPath(id string) string {
+	return fmt.Sprintf("src/components/%s.metrics.ts", componentIDSlug(id))
+}
+
 func serverTestPath(id string) string {
 	return fmt.Sprintf("src/components/%s.server.test.ts", componentIDSlug(id))
 }
@@ -56,6 +64,22 @@ func middlewarePolicyPath(id string) string {
 	return fmt.Sprintf("src/components/%s.middleware.policy.csv", componentIDSlug(id))
 }
 
+func middlewareSessionPath(id string) string {
+	return fmt.Sprintf("src/components/%s.middleware.session.ts", componentIDSlug(id))
+}
+
+func middlewareOAuthPath(id string) string {
+	return fmt.Sprintf("src/components/%s.middleware.oauth.ts", componentIDSlug(id))
+}
+
+func middlewareRBACPath(id string) string {
+	return fmt.Sprintf("src/components/%s.middleware.rbac.ts", componentIDSlug(id))
+}
+
+func middlewareEnforcerPath(id string) string {
+	return fmt.Sprintf("src/components/%s.middleware.enforcer.ts", componentIDSlug(id))
+}
+
 func middlewareTestPath(id string) string {
 	return fmt.Sprintf("src/components/%s.middleware.test.ts", componentIDSlug(id))
 }
@@ -91,3 +115,47 @@ func usecaseIndexPath() string {
 func usecaseSchemasPath() string {
 	return "src/components/usecase.schemas.ts"
 }
+
+func gatewaySourcePath(id string) string {
+	return fmt.Sprintf("src/components/%s.gateway.ts", componentIDSlug(id))
+}
+
+func webhookSourcePath(id string) string {
+	return fmt.Sprintf("src/components/%s.webhook.ts", componentIDSlug(id))
+}
+
+func httpClientSourcePath(id string) string {
+	return fmt.Sprintf("src/components/%s.client.ts", componentIDSlug(id))
+}
+
+func httpClientTestPath(id string) string {
+	return fmt.Sprintf("src/components/%s.client.test.ts", componentIDSlug(id))
+}
+
+func cronSourcePath(id string) string {
+	return fmt.Sprintf("src/components/%s.cron.ts", componentIDSlug(id))
+}
+
+func workerPath() string {
+	return "src/worker.ts"
+}
+
+func webhookDocsPath() string {
+	return "WEBHOOKS.md"
+}
+
+func postgresRepositoriesPath(id string) string {
+	return fmt.Sprintf("src/components/%s.postgres.repositories.ts", componentIDSlug(id))
+}
+
+func repositoryPath() string {
+	return "src/components/postgres.repository.ts"
+}
+
+func repositoryTestPath() string {
+	return "src/components/postgres.repository.test.ts"
+}
+
+func postgresColumnsPath() string {
+	return "src/components/postgres.columns.ts"
+}
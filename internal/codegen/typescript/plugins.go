@@ -15,48 +15,104 @@ func NewPluginRegistry() (*codegen.PluginRegistry, error) {
 	plugins := []codegen.GeneratorPlugin{
 		{
 			Name:         "typescript-project",
+			Version:      "3",
 			NewGenerator: func() codegen.Generator { return NewProjectGenerator() },
 		},
 		{
 			Name:         "typescript-schemas",
+			Version:      "3",
 			NewGenerator: func() codegen.Generator { return NewSchemaGenerator() },
-			Supports:     []ir.Kind{ir.KindPostgres, ir.KindMiddleware},
+			Supports:     []ir.Kind{ir.KindPostgres, ir.KindMiddleware, ir.KindHTTPServer, ir.KindUsecase},
+		},
+		{
+			Name:         "typescript-repositories",
+			Version:      "2",
+			NewGenerator: func() codegen.Generator { return NewRepositoryGenerator() },
+			Supports:     []ir.Kind{ir.KindPostgres},
+			Reads:        []ir.Kind{ir.KindPostgres},
 		},
 		{
 			Name:         "typescript-openapi",
+			Version:      "2",
 			NewGenerator: func() codegen.Generator { return NewOpenAPIGenerator() },
 			Supports:     []ir.Kind{ir.KindHTTPServer, ir.KindUsecase},
 		},
 		{
 			Name:         "typescript-context",
+			Version:      "2",
 			NewGenerator: func() codegen.Generator { return NewContextGenerator() },
 			Supports:     []ir.Kind{ir.KindHTTPServer},
 		},
 		{
 			Name:         "typescript-hono",
+			Version:      "5",
 			NewGenerator: func() codegen.Generator { return NewHonoServerGenerator() },
 			Supports:     []ir.Kind{ir.KindHTTPServer, ir.KindMiddleware, ir.KindPostgres},
 		},
+		{
+			Name:         "typescript-metrics",
+			Version:      "1",
+			NewGenerator: func() codegen.Generator { return NewMetricsGenerator() },
+			Supports:     []ir.Kind{ir.KindHTTPServer},
+			Reads:        []ir.Kind{ir.KindHTTPServer, ir.KindUsecase},
+		},
 		{
 			Name:         "typescript-usecase",
+			Version:      "2",
 			NewGenerator: func() codegen.Generator { return NewUsecaseGenerator() },
 			Supports:     []ir.Kind{ir.KindUsecase},
 		},
 		{
 			Name:         "typescript-tests",
+			Version:      "4",
 			NewGenerator: func() codegen.Generator { return NewTestGenerator() },
 			Supports:     []ir.Kind{ir.KindHTTPServer, ir.KindMiddleware, ir.KindUsecase},
 		},
+		{
+			Name:         "typescript-gateway",
+			Version:      "1",
+			NewGenerator: func() codegen.Generator { return NewGatewayGenerator() },
+			Supports:     []ir.Kind{ir.KindHTTPGateway},
+			Reads:        []ir.Kind{ir.KindHTTPGateway, ir.KindHTTPServer, ir.KindMiddleware},
+		},
+		{
+			Name:         "typescript-webhooks",
+			Version:      "1",
+			NewGenerator: func() codegen.Generator { return NewWebhookGenerator() },
+			Supports:     []ir.Kind{ir.KindWebhook},
+			Reads:        []ir.Kind{ir.KindWebhook, ir.KindUsecase, ir.KindHTTPServer},
+		},
+		{
+			Name:         "typescript-http-clients",
+			Version:      "1",
+			NewGenerator: func() codegen.Generator { return NewHTTPClientGenerator() },
+			Supports:     []ir.Kind{ir.KindHTTPClient},
+			Reads:        []ir.Kind{ir.KindHTTPClient},
+		},
+		{
+			Name:         "typescript-worker",
+			Version:      "2",
+			NewGenerator: func() codegen.Generator { return NewWorkerGenerator() },
+			Supports:     []ir.Kind{ir.KindCron},
+			Reads:        []ir.Kind{ir.KindCron, ir.KindPostgres},
+		},
 		{
 			Name:         "typescript-docker",
+			Version:      "2",
 			NewGenerator: func() codegen.Generator { return NewDockerGenerator() },
-			Supports:     []ir.Kind{ir.KindHTTPServer, ir.KindPostgres},
+			Supports:     []ir.Kind{ir.KindHTTPServer, ir.KindPostgres, ir.KindCron},
 		},
 		{
 			Name:         "typescript-e2e",
+			Version:      "2",
 			NewGenerator: func() codegen.Generator { return NewE2ETestGenerator() },
 			Supports:     []ir.Kind{ir.KindHTTPServer},
 		},
+		{
+			Name:         "typescript-readme",
+			Version:      "2",
+			NewGenerator: func() codegen.Generator { return NewReadmeGenerator() },
+		},
 	}
 
 	for _, plugin := range plugins {
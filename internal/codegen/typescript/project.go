@@ -26,18 +26,22 @@ func (g *ProjectGenerator) Name() string {
 
 // PackageJSON represents the package.json structure.
 type PackageJSON struct {
-	Name            string            `json:"name"`
-	Version         string            `json:"version"`
-	Description     string            `json:"description,omitempty"`
-	Type            string            `json:"type"`
-	Main            string            `json:"main"`
-	Scripts         map[string]string `json:"scripts"`
-	Dependencies    map[string]string `json:"dependencies"`
-	DevDependencies map[string]string `json:"devDependencies"`
+	Name            string                       `json:"name"`
+	Version         string                       `json:"version"`
+	Private         bool                         `json:"private,omitempty"`
+	Description     string                       `json:"description,omitempty"`
+	Type            string                       `json:"type"`
+	Main            string                       `json:"main"`
+	Exports         map[string]map[string]string `json:"exports,omitempty"` // Entry points by condition, for dual packages
+	Engines         map[string]string            `json:"engines,omitempty"` // Runtime versions, set when the spec targets a Node.js version
+	Scripts         map[string]string            `json:"scripts"`
+	Dependencies    map[string]string            `json:"dependencies"`
+	DevDependencies map[string]string            `json:"devDependencies"`
 }
 
 // TSConfig represents the tsconfig.json structure.
 type TSConfig struct {
+	Extends         string                  `json:"extends,omitempty"`
 	CompilerOptions TSConfigCompilerOptions `json:"compilerOptions"`
 	Include         []string                `json:"include"`
 	Exclude         []string                `json:"exclude"`
@@ -45,23 +49,18 @@ type TSConfig struct {
 
 // TSConfigCompilerOptions represents TypeScript compiler options.
 type TSConfigCompilerOptions struct {
-	Target                           string `json:"target"`
-	Module                           string `json:"module"`
-	ModuleResolution                 string `json:"moduleResolution"`
-	Strict                           bool   `json:"strict"`
-	ESModuleInterop                  bool   `json:"esModuleInterop"`
-	SkipLibCheck                     bool   `json:"skipLibCheck"`
-	ForceConsistentCasingInFileNames bool   `json:"forceConsistentCasingInFileNames"`
-	OutDir                           string `json:"outDir"`
-	RootDir                          string `json:"rootDir"`
-	Declaration                      bool   `json:"declaration"`
-	ResolveJsonModule                bool   `json:"resolveJsonModule"`
-}
-
-// OrvalConfig represents the orval.config.ts configuration.
-type OrvalConfig struct {
-	OutputPath string
-	InputPath  string
+	Target                           string   `json:"target"`
+	Lib                              []string `json:"lib,omitempty"`
+	Module                           string   `json:"module"`
+	ModuleResolution                 string   `json:"moduleResolution"`
+	Strict                           bool     `json:"strict"`
+	ESModuleInterop                  bool     `json:"esModuleInterop"`
+	SkipLibCheck                     bool     `json:"skipLibCheck"`
+	ForceConsistentCasingInFileNames bool     `json:"forceConsistentCasingInFileNames"`
+	OutDir                           string   `json:"outDir"`
+	RootDir                          string   `json:"rootDir"`
+	Declaration                      bool     `json:"declaration"`
+	ResolveJsonModule                bool     `json:"resolveJsonModule"`
 }
 
 // Generate produces project configuration files.
@@ -76,47 +75,39 @@ func (g *ProjectGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
 	output.AddFile("package.json", pkgJSON)
 
 	// Generate tsconfig.json
-	tsConfig, err := g.generateTSConfig()
+	tsConfig, err := g.generateTSConfig(i)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tsconfig.json: %w", err)
 	}
 	output.AddFile("tsconfig.json", tsConfig)
 
-	// Generate orval.config.ts for each server with OpenAPI
-	for _, comp := range i.Components {
-		if comp.Kind != ir.KindHTTPServer || comp.HTTPServer == nil {
-			continue
-		}
-		if comp.HTTPServer.OpenAPI == "" {
-			continue
-		}
-
-		orvalConfig := g.generateOrvalConfig(comp)
-		output.AddFile("orval.config.ts", []byte(orvalConfig))
-		break // Only one orval config needed
-	}
-
 	// Generate vitest.config.ts
 	output.AddFile("vitest.config.ts", []byte(g.generateVitestConfig()))
 
 	// Generate .gitignore
-	output.AddFile(".gitignore", []byte(gitignoreContent))
+	output.AddFile(".gitignore", []byte(codegen.Header(codegen.HashComments)+gitignoreContent))
 
 	return output, nil
 }
 
+// MergedFiles are the generated files users are expected to edit, e.g. to
+// add dependencies or scripts. Compile merges their edits instead of
+// overwriting them.
+var MergedFiles = []string{"package.json"}
+
 func (g *ProjectGenerator) generatePackageJSON(i *ir.IR) ([]byte, error) {
 	// Determine dependencies based on components
 	deps := map[string]string{
 		"hono":              "^4.0.0",
 		"@hono/node-server": "^1.13.0",
+		"zod":               "^3.23.0",
 	}
 	devDeps := map[string]string{
 		"typescript":       "^5.0.0",
-		"@types/node":      "^20.0.0",
+		"@types/node":      "^" + nodeVersion(i) + ".0.0",
 		"vitest":           "^2.0.0",
-		"orval":            "^7.0.0",
 		"tsx":              "^4.0.0",
+		"dotenv":           "^16.4.0",
 		"@playwright/test": "^1.42.0",
 	}
 
@@ -124,23 +115,36 @@ func (g *ProjectGenerator) generatePackageJSON(i *ir.IR) ([]byte, error) {
 	for _, comp := range i.Components {
 		switch comp.Kind {
 		case ir.KindPostgres:
-			if comp.Postgres != nil && comp.Postgres.Provider == "drizzle" {
-				deps["drizzle-orm"] = "^0.41.0"
-				deps["postgres"] = "^3.4.0"
-				devDeps["drizzle-kit"] = "^0.31.0"
+			if p := databaseProviderFor(comp); p != nil {
+				p.Dependencies(comp, deps, devDeps)
 			}
 		case ir.KindMiddleware:
-			if comp.Middleware != nil {
-				switch comp.Middleware.Provider {
-				case "better-auth":
-					deps["better-auth"] = "^1.4.0"
-				case "casbin":
-					deps["casbin"] = "^5.0.0"
-				}
+			if p := middlewareProviderFor(comp); p != nil {
+				p.Dependencies(comp, deps)
 			}
 		}
 	}
 
+	// Servers with metrics serve them with the Prometheus client
+	if hasMetrics(i) {
+		deps["prom-client"] = "^15.1.0"
+	}
+
+	// The server's cache keeps responses in redis
+	if hasServerCache(i) {
+		deps["ioredis"] = "^5.4.0"
+	}
+
+	// The worker schedules the cron jobs with croner
+	if len(cronComponents(i)) > 0 {
+		deps["croner"] = "^9.0.0"
+	}
+
+	// Development generates the certificates of self-signed servers
+	if hasSelfSignedServers(i) {
+		devDeps["selfsigned"] = "^2.4.1"
+	}
+
 	name := "generated-api"
 	version := "0.0.1"
 	description := ""
@@ -156,36 +160,39 @@ func (g *ProjectGenerator) generatePackageJSON(i *ir.IR) ([]byte, error) {
 		}
 	}
 
+	// Only the dev script loads .env; production reads its environment as is
 	scripts := map[string]string{
-		"build":          "tsc",
-		"dev":            "tsx watch src/index.ts",
-		"start":          "node dist/index.js",
-		"test":           "vitest run",
-		"test:watch":     "vitest",
-		"test:e2e":       "playwright test",
-		"test:e2e:ui":    "playwright test --ui",
-		"generate:types": "orval",
-		"lint":           "tsc --noEmit",
-		"docker:build":   "docker build -t app .",
-		"docker:up":      "docker-compose up -d",
-		"docker:down":    "docker-compose down",
-		"docker:logs":    "docker-compose logs -f",
-		"docker:ps":      "docker-compose ps",
-		"docker:clean":   "docker-compose down -v",
+		"build":        "tsc",
+		"dev":          "tsx watch --import dotenv/config src/index.ts",
+		"start":        "node dist/index.js",
+		"test":         "vitest run",
+		"test:watch":   "vitest",
+		"test:e2e":     "playwright test",
+		"test:e2e:ui":  "playwright test --ui",
+		"lint":         "tsc --noEmit",
+		"docker:build": "docker build -t app .",
+		"docker:up":    "docker-compose up -d",
+		"docker:down":  "docker-compose down",
+		"docker:logs":  "docker-compose logs -f",
+		"docker:ps":    "docker-compose ps",
+		"docker:clean": "docker-compose down -v",
 	}
 
 	// Add conditional database scripts if postgres is present
-	for _, comp := range i.Components {
-		if comp.Kind == ir.KindPostgres && comp.Postgres != nil {
-			if comp.Postgres.Provider == "drizzle" {
-				scripts["db:migrate"] = "drizzle-kit migrate"
-				scripts["db:push"] = "drizzle-kit push"
-				scripts["db:studio"] = "drizzle-kit studio"
-			}
-			break
+	for _, pg := range postgresComponents(i) {
+		if p := databaseProviderFor(pg); p != nil {
+			p.Scripts(pg, scripts)
 		}
 	}
 
+	// The worker runs the cron jobs in a process and image of its own
+	if len(cronComponents(i)) > 0 {
+		scripts["worker"] = "node dist/worker.js"
+		scripts["dev:worker"] = "tsx watch --import dotenv/config src/worker.ts"
+		scripts["docker:build"] = "docker build --target production -t app ."
+		scripts["docker:build:worker"] = "docker build --target worker -t app-worker ."
+	}
+
 	pkg := PackageJSON{
 		Name:            name,
 		Version:         version,
@@ -196,14 +203,22 @@ func (g *ProjectGenerator) generatePackageJSON(i *ir.IR) ([]byte, error) {
 		Dependencies:    deps,
 		DevDependencies: devDeps,
 	}
+	if node := targetNode(i); node != "" {
+		pkg.Engines = map[string]string{"node": ">=" + node}
+	}
 
-	return json.MarshalIndent(pkg, "", "  ")
+	return marshalIndent(pkg)
 }
 
-func (g *ProjectGenerator) generateTSConfig() ([]byte, error) {
+func (g *ProjectGenerator) generateTSConfig(i *ir.IR) ([]byte, error) {
+	target, lib := "ES2022", []string(nil)
+	if edition, ok := nodeEditions[targetNode(i)]; ok {
+		target, lib = edition, []string{edition}
+	}
 	config := TSConfig{
 		CompilerOptions: TSConfigCompilerOptions{
-			Target:                           "ES2022",
+			Target:                           target,
+			Lib:                              lib,
 			Module:                           "ESNext",
 			ModuleResolution:                 "bundler",
 			Strict:                           true,
@@ -219,33 +234,12 @@ func (g *ProjectGenerator) generateTSConfig() ([]byte, error) {
 		Exclude: []string{"node_modules", "dist"},
 	}
 
-	return json.MarshalIndent(config, "", "  ")
-}
-
-func (g *ProjectGenerator) generateOrvalConfig(server *ir.Component) string {
-	// OpenAPI spec and generated schema types are flattened under src/components.
-	serverFilename := componentIDSlug(server.ID)
-	return fmt.Sprintf(`import { defineConfig } from 'orval';
-
-export default defineConfig({
-  api: {
-    input: './src/components/%s.openapi.yaml',
-    output: {
-      mode: 'single',
-      target: './%s',
-      client: 'fetch',
-      override: {
-        // Only generate types, not implementation
-        mutator: undefined,
-      },
-    },
-  },
-});
-`, serverFilename, usecaseSchemasPath())
+	data, err := json.MarshalIndent(config, "", "  ")
+	return append(data, '\n'), err
 }
 
 func (g *ProjectGenerator) generateVitestConfig() string {
-	return `import { defineConfig } from 'vitest/config';
+	return codegen.Header(codegen.SlashComments) + `import { defineConfig } from 'vitest/config';
 
 export default defineConfig({
   test: {
@@ -284,6 +278,6 @@ Thumbs.db
 # Test coverage
 coverage/
 
-# Generated types (regenerate with npm run generate:types)
-# src/components/usecase.schemas.ts
+# Compile report (bound compile)
+.bound/
 `
@@ -96,6 +96,20 @@ func TestProjectGenerator_Generate_PackageJSON(t *testing.T) {
 	if _, ok := pkg.Scripts["build"]; !ok {
 		t.Error("package.json missing build script")
 	}
+
+	// Only the dev script loads .env
+	if got := pkg.Scripts["dev"]; got != "tsx watch --import dotenv/config src/index.ts" {
+		t.Errorf("dev script = %q, expected it to load .env through dotenv", got)
+	}
+	if got := pkg.Scripts["start"]; got != "node dist/index.js" {
+		t.Errorf("start script = %q, expected production to read its environment as is", got)
+	}
+	if _, ok := pkg.DevDependencies["dotenv"]; !ok {
+		t.Error("package.json missing dotenv dev dependency")
+	}
+	if _, ok := pkg.Dependencies["dotenv"]; ok {
+		t.Error("dotenv should not be a production dependency")
+	}
 }
 
 func TestProjectGenerator_Generate_TSConfig(t *testing.T) {
@@ -135,7 +149,66 @@ func TestProjectGenerator_Generate_TSConfig(t *testing.T) {
 	}
 }
 
-func TestProjectGenerator_Generate_OrvalConfig(t *testing.T) {
+func TestProjectGenerator_Generate_Node(t *testing.T) {
+	// given
+	i := &ir.IR{
+		Spec:       &parser.Spec{Name: "test", Node: "22"},
+		Components: map[string]*ir.Component{},
+	}
+
+	// when
+	output, err := NewProjectGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	var pkg PackageJSON
+	if err := json.Unmarshal(output.Files["package.json"].Content, &pkg); err != nil {
+		t.Fatalf("Failed to parse package.json: %v", err)
+	}
+	if pkg.Engines["node"] != ">=22" {
+		t.Errorf("package.json engines = %v, want node >=22", pkg.Engines)
+	}
+	if !strings.Contains(string(output.Files["package.json"].Content), `"node": ">=22"`) {
+		t.Errorf("package.json escapes engines:\n%s", output.Files["package.json"].Content)
+	}
+	if pkg.DevDependencies["@types/node"] != "^22.0.0" {
+		t.Errorf("@types/node = %q, want %q", pkg.DevDependencies["@types/node"], "^22.0.0")
+	}
+	var config TSConfig
+	if err := json.Unmarshal(output.Files["tsconfig.json"].Content, &config); err != nil {
+		t.Fatalf("Failed to parse tsconfig.json: %v", err)
+	}
+	if config.CompilerOptions.Target != "ES2024" || len(config.CompilerOptions.Lib) != 1 || config.CompilerOptions.Lib[0] != "ES2024" {
+		t.Errorf("tsconfig target = %q, lib = %v, want ES2024", config.CompilerOptions.Target, config.CompilerOptions.Lib)
+	}
+}
+
+func TestProjectGenerator_Generate_DefaultNode(t *testing.T) {
+	// given
+	i := &ir.IR{
+		Spec:       &parser.Spec{Name: "test"},
+		Components: map[string]*ir.Component{},
+	}
+
+	// when
+	output, err := NewProjectGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["package.json"].Content)
+	if strings.Contains(content, `"engines"`) || !strings.Contains(content, `"@types/node": "^20.0.0"`) {
+		t.Errorf("package.json should declare no engines and @types/node 20\n%s", content)
+	}
+	if tsconfig := string(output.Files["tsconfig.json"].Content); strings.Contains(tsconfig, `"lib"`) {
+		t.Errorf("tsconfig.json should not set lib\n%s", tsconfig)
+	}
+}
+
+func TestProjectGenerator_Generate_ZodWithoutOrval(t *testing.T) {
 	// given
 	i := &ir.IR{
 		Spec: &parser.Spec{Name: "test"},
@@ -161,17 +234,22 @@ func TestProjectGenerator_Generate_OrvalConfig(t *testing.T) {
 		t.Fatalf("Generate() error = %v", err)
 	}
 
-	orvalContent, ok := output.Files["orval.config.ts"]
-	if !ok {
-		t.Fatal("orval.config.ts not found in output")
+	if _, ok := output.Files["orval.config.ts"]; ok {
+		t.Error("orval.config.ts should not be generated; types are emitted directly")
 	}
 
-	content := string(orvalContent.Content)
-	if !strings.Contains(content, "./src/components/http-server-api.openapi.yaml") {
-		t.Error("orval.config.ts should contain the generated schema path")
+	var pkg PackageJSON
+	if err := json.Unmarshal(output.Files["package.json"].Content, &pkg); err != nil {
+		t.Fatalf("Failed to parse package.json: %v", err)
+	}
+	if _, ok := pkg.Dependencies["zod"]; !ok {
+		t.Error("package.json should depend on zod")
 	}
-	if !strings.Contains(content, "defineConfig") {
-		t.Error("orval.config.ts should use defineConfig")
+	if _, ok := pkg.DevDependencies["orval"]; ok {
+		t.Error("package.json should not depend on orval")
+	}
+	if _, ok := pkg.Scripts["generate:types"]; ok {
+		t.Error("package.json should not have a generate:types script")
 	}
 }
 
@@ -287,6 +365,73 @@ func TestProjectGenerator_Generate_CasbinDependencies(t *testing.T) {
 	}
 }
 
+func TestProjectGenerator_Generate_CasbinPostgresAdapterDependency(t *testing.T) {
+	// given
+	i := &ir.IR{
+		Spec: &parser.Spec{Name: "test"},
+		Components: map[string]*ir.Component{
+			"middleware.authz": {
+				ID:   "middleware.authz",
+				Kind: ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{
+					Provider:      "casbin",
+					Model:         "./model.conf",
+					Policy:        "./policy.csv",
+					PolicyAdapter: "postgres",
+				},
+			},
+		},
+	}
+
+	// when
+	output, err := NewProjectGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	var pkg PackageJSON
+	if err := json.Unmarshal(output.Files["package.json"].Content, &pkg); err != nil {
+		t.Fatalf("Failed to parse package.json: %v", err)
+	}
+	if _, ok := pkg.Dependencies["casbin-pg-adapter"]; !ok {
+		t.Error("package.json should include casbin-pg-adapter for the postgres policy adapter")
+	}
+}
+
+func TestProjectGenerator_Generate_RedisSessionDependency(t *testing.T) {
+	// given
+	i := &ir.IR{
+		Spec: &parser.Spec{Name: "test"},
+		Components: map[string]*ir.Component{
+			"middleware.authn": {
+				ID:   "middleware.authn",
+				Kind: ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{
+					Provider: "better-auth",
+					Config:   "./auth.config.ts",
+					Session:  &ir.SessionSpec{Storage: ir.SessionStorageRedis},
+				},
+			},
+		},
+	}
+
+	// when
+	output, err := NewProjectGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	var pkg PackageJSON
+	if err := json.Unmarshal(output.Files["package.json"].Content, &pkg); err != nil {
+		t.Fatalf("Failed to parse package.json: %v", err)
+	}
+	if _, ok := pkg.Dependencies["ioredis"]; !ok {
+		t.Error("package.json should include ioredis for redis session storage")
+	}
+}
+
 func TestProjectGenerator_Generate_GitIgnore(t *testing.T) {
 	// given
 	i := &ir.IR{
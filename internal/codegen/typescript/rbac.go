@@ -0,0 +1,234 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// hasRBAC reports whether a casbin middleware declares roles or permissions.
+func hasRBAC(mw *ir.Component) bool {
+	return mw.Middleware != nil && (len(mw.Middleware.Roles) > 0 || len(mw.Middleware.Permissions) > 0)
+}
+
+// rbacConstantName converts a role or permission name to a TypeScript
+// constant key (e.g., "user.read" -> "UserRead", "super-admin" -> "SuperAdmin").
+func rbacConstantName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '.' || r == ':' || r == '_' || r == '-'
+	})
+	for n, word := range words {
+		words[n] = titleCase(word)
+	}
+	return strings.Join(words, "")
+}
+
+// rbacPolicyRows returns the casbin policy lines seeded from the declared
+// roles: "p, role, object, action" per granted permission and
+// "g, role, parent" per inherited role.
+func rbacPolicyRows(mw *ir.Component) []string {
+	permissions := make(map[string]ir.Permission, len(mw.Middleware.Permissions))
+	for _, p := range mw.Middleware.Permissions {
+		permissions[p.Name] = p
+	}
+
+	var rows []string
+	for _, r := range mw.Middleware.Roles {
+		for _, name := range r.Permissions {
+			if p, ok := permissions[name]; ok {
+				rows = append(rows, fmt.Sprintf("p, %s, %s, %s", r.Name, p.Object, p.Action))
+			}
+		}
+	}
+	for _, r := range mw.Middleware.Roles {
+		for _, parent := range r.Inherits {
+			rows = append(rows, fmt.Sprintf("g, %s, %s", r.Name, parent))
+		}
+	}
+	return rows
+}
+
+// seedPolicyFile appends the seeded policy lines the policy file does not
+// already contain.
+func seedPolicyFile(content []byte, mw *ir.Component) []byte {
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		existing[normalizePolicyLine(line)] = true
+	}
+
+	var missing []string
+	for _, row := range rbacPolicyRows(mw) {
+		if !existing[normalizePolicyLine(row)] {
+			missing = append(missing, row)
+		}
+	}
+	if len(missing) == 0 {
+		return content
+	}
+
+	var sb strings.Builder
+	sb.Write(content)
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n# Seeded from the roles declared in the spec\n")
+	for _, row := range missing {
+		sb.WriteString(row + "\n")
+	}
+	return []byte(sb.String())
+}
+
+func normalizePolicyLine(line string) string {
+	fields := strings.Split(line, ",")
+	for n, field := range fields {
+		fields[n] = strings.TrimSpace(field)
+	}
+	return strings.Join(fields, ",")
+}
+
+// generateRBACModule renders typed constants for the roles and permissions of
+// a casbin middleware, plus the rules seeded into its policy.
+func generateRBACModule(mw *ir.Component) string {
+	var sb strings.Builder
+	s := mw.Middleware
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("// Roles and permissions declared in the spec\n")
+	sb.WriteString("import type { Enforcer } from 'casbin';\n\n")
+
+	var roles []string
+	for _, r := range s.Roles {
+		roles = append(roles, fmt.Sprintf("%s: %s", rbacConstantName(r.Name), singleQuote(r.Name)))
+	}
+	sb.WriteString("export const Role = " + tsBlock("{", roles, "}") + " as const;\n")
+	sb.WriteString("export type Role = (typeof Role)[keyof typeof Role];\n\n")
+
+	var names, rules []string
+	for _, p := range s.Permissions {
+		names = append(names, fmt.Sprintf("%s: %s", rbacConstantName(p.Name), singleQuote(p.Name)))
+		rules = append(rules, fmt.Sprintf("[Permission.%s]: { object: %s, action: %s }",
+			rbacConstantName(p.Name), singleQuote(p.Object), singleQuote(p.Action)))
+	}
+	sb.WriteString("export const Permission = " + tsBlock("{", names, "}") + " as const;\n")
+	sb.WriteString("export type Permission = (typeof Permission)[keyof typeof Permission];\n\n")
+
+	sb.WriteString("/** Policy object and action each permission grants */\n")
+	sb.WriteString("export const permissionRules: Record<Permission, { object: string; action: string }> = " + tsBlock("{", rules, "}") + ";\n\n")
+
+	permissions := make(map[string]ir.Permission, len(s.Permissions))
+	for _, p := range s.Permissions {
+		permissions[p.Name] = p
+	}
+
+	var policies, groupings []string
+	for _, r := range s.Roles {
+		for _, name := range r.Permissions {
+			if p, ok := permissions[name]; ok {
+				policies = append(policies, fmt.Sprintf("[Role.%s, %s, %s]", rbacConstantName(r.Name), singleQuote(p.Object), singleQuote(p.Action)))
+			}
+		}
+		for _, parent := range r.Inherits {
+			groupings = append(groupings, fmt.Sprintf("[Role.%s, Role.%s]", rbacConstantName(r.Name), rbacConstantName(parent)))
+		}
+	}
+
+	sb.WriteString("/** Policy rules seeded from the spec: [role, object, action] */\n")
+	sb.WriteString("export const seedPolicies: string[][] = " + tsBlock("[", policies, "]") + ";\n\n")
+	sb.WriteString("/** Role inheritance seeded from the spec: [role, inherited role] */\n")
+	sb.WriteString("export const seedGroupings: string[][] = " + tsBlock("[", groupings, "]") + ";\n\n")
+
+	sb.WriteString(rbacAuthorizeFunc)
+
+	return sb.String()
+}
+
+// rbacAuthorizeFunc checks a usecase's authorization requirement in routes.
+const rbacAuthorizeFunc = `/** What a caller needs: any of the roles and all of the permissions */
+export interface Authorization {
+  roles?: Role[];
+  permissions?: Permission[];
+}
+
+/** Reports whether a subject meets an authorization requirement. */
+export async function isAuthorized(
+  enforcer: Enforcer | null | undefined,
+  subject: string | null | undefined,
+  required: Authorization,
+): Promise<boolean> {
+  if (!enforcer || !subject) {
+    return false;
+  }
+  if (required.roles?.length) {
+    const roles = await enforcer.getImplicitRolesForUser(subject);
+    if (!required.roles.some((role) => roles.includes(role))) {
+      return false;
+    }
+  }
+  for (const permission of required.permissions ?? []) {
+    const { object, action } = permissionRules[permission];
+    if (!(await enforcer.enforce(subject, object, action))) {
+      return false;
+    }
+  }
+  return true;
+}
+`
+
+// rbacModuleAlias returns the namespace a casbin middleware's RBAC module
+// is imported as (e.g., "middleware.authz" -> "middlewareAuthzRBAC").
+func rbacModuleAlias(mw *ir.Component) string {
+	return toCamelCase(mw.ID) + "RBAC"
+}
+
+// authorizationArgument renders a usecase's authorization requirement as the
+// argument of isAuthorized, referencing the constants of the RBAC module.
+func authorizationArgument(uc *ir.Component, mw *ir.Component, indent string) string {
+	alias := rbacModuleAlias(mw)
+	a := uc.Usecase.Authorization
+
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	if len(a.Roles) > 0 {
+		var roles []string
+		for _, r := range a.Roles {
+			roles = append(roles, fmt.Sprintf("%s.Role.%s", alias, rbacConstantName(r)))
+		}
+		sb.WriteString(fmt.Sprintf("%s  roles: [%s],\n", indent, strings.Join(roles, ", ")))
+	}
+	if len(a.Permissions) > 0 {
+		var permissions []string
+		for _, p := range a.Permissions {
+			permissions = append(permissions, fmt.Sprintf("%s.Permission.%s", alias, rbacConstantName(p)))
+		}
+		sb.WriteString(fmt.Sprintf("%s  permissions: [%s],\n", indent, strings.Join(permissions, ", ")))
+	}
+	sb.WriteString(indent + "}")
+	return sb.String()
+}
+
+// tsBlock renders an object or array literal with one entry per line, or
+// an empty literal when there are no entries.
+func tsBlock(opening string, entries []string, closing string) string {
+	if len(entries) == 0 {
+		return opening + closing
+	}
+	var sb strings.Builder
+	sb.WriteString(opening + "\n")
+	for _, entry := range entries {
+		sb.WriteString("  " + entry + ",\n")
+	}
+	sb.WriteString(closing)
+	return sb.String()
+}
+
+// singleQuote renders s as a single-quoted TypeScript string literal.
+func singleQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	return "'" + s + "'"
+}
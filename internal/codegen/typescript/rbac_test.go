@@ -0,0 +1,114 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+func newRBACMiddleware() *ir.Component {
+	return &ir.Component{
+		ID:   "middleware.authz",
+		Kind: ir.KindMiddleware,
+		Middleware: &ir.MiddlewareSpec{
+			Provider: "casbin",
+			Model:    "./model.conf",
+			Policy:   "./policy.csv",
+			Permissions: []ir.Permission{
+				{Name: "user.read", Object: "/users", Action: "GET"},
+				{Name: "user.write", Object: "/users", Action: "POST"},
+			},
+			Roles: []ir.Role{
+				{Name: "super-admin", Permissions: []string{"user.write"}, Inherits: []string{"viewer"}},
+				{Name: "viewer", Permissions: []string{"user.read"}},
+			},
+		},
+	}
+}
+
+func TestRBACConstantName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"admin", "Admin"},
+		{"super-admin", "SuperAdmin"},
+		{"user.read", "UserRead"},
+		{"user:read_all", "UserReadAll"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := rbacConstantName(tt.input); got != tt.want {
+				t.Errorf("rbacConstantName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateRBACModule(t *testing.T) {
+	// when
+	module := generateRBACModule(newRBACMiddleware())
+
+	// then
+	for _, want := range []string{
+		"export const Role = {\n  SuperAdmin: 'super-admin',\n  Viewer: 'viewer',\n} as const;\n",
+		"export const Permission = {\n  UserRead: 'user.read',\n  UserWrite: 'user.write',\n} as const;\n",
+		"  [Permission.UserRead]: { object: '/users', action: 'GET' },\n",
+		"export const seedPolicies: string[][] = [\n  [Role.SuperAdmin, '/users', 'POST'],\n  [Role.Viewer, '/users', 'GET'],\n];\n",
+		"export const seedGroupings: string[][] = [\n  [Role.SuperAdmin, Role.Viewer],\n];\n",
+		"export async function isAuthorized(\n",
+		"    const roles = await enforcer.getImplicitRolesForUser(subject);\n",
+	} {
+		if !strings.Contains(module, want) {
+			t.Errorf("RBAC module missing %q\n%s", want, module)
+		}
+	}
+}
+
+func TestAuthorizationArgument(t *testing.T) {
+	// given
+	uc := &ir.Component{ID: "usecase.delete-user", Usecase: &ir.UsecaseSpec{
+		Authorization: &ir.AuthorizationSpec{Roles: []string{"super-admin"}, Permissions: []string{"user.write"}},
+	}}
+
+	// when
+	got := authorizationArgument(uc, newRBACMiddleware(), "    ")
+
+	// then
+	want := "{\n" +
+		"      roles: [middlewareAuthzRBAC.Role.SuperAdmin],\n" +
+		"      permissions: [middlewareAuthzRBAC.Permission.UserWrite],\n" +
+		"    }"
+	if got != want {
+		t.Errorf("authorizationArgument() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSeedPolicyFile(t *testing.T) {
+	// given
+	policy := "p, viewer,/users,GET\ng, alice, viewer"
+
+	// when
+	seeded := string(seedPolicyFile([]byte(policy), newRBACMiddleware()))
+
+	// then
+	want := policy + "\n\n# Seeded from the roles declared in the spec\n" +
+		"p, super-admin, /users, POST\n" +
+		"g, super-admin, viewer\n"
+	if seeded != want {
+		t.Errorf("seedPolicyFile() =\n%s\nwant\n%s", seeded, want)
+	}
+}
+
+func TestSeedPolicyFile_NoRoles(t *testing.T) {
+	mw := &ir.Component{ID: "middleware.authz", Middleware: &ir.MiddlewareSpec{Provider: "casbin"}}
+
+	if got := string(seedPolicyFile([]byte("p, a, b, c\n"), mw)); got != "p, a, b, c\n" {
+		t.Errorf("seedPolicyFile() = %q, want the policy unchanged", got)
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// ReadmeGenerator generates the README and runbook of the output project.
+// Everything in it is derived from the spec, and it is rewritten on every
+// compile so it never goes stale.
+type ReadmeGenerator struct{}
+
+// NewReadmeGenerator creates a new README generator.
+func NewReadmeGenerator() *ReadmeGenerator {
+	return &ReadmeGenerator{}
+}
+
+// Name returns the generator name.
+func (g *ReadmeGenerator) Name() string {
+	return "typescript-readme"
+}
+
+// Generate produces README.md from the IR.
+func (g *ReadmeGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	output.AddFile("README.md", []byte(g.generateReadme(i)))
+	return output, nil
+}
+
+func (g *ReadmeGenerator) generateReadme(i *ir.IR) string {
+	var sb strings.Builder
+
+	name := "generated-api"
+	if i.Spec != nil && i.Spec.Name != "" {
+		name = i.Spec.Name
+	}
+
+	sb.WriteString(codegen.Header(codegen.HTMLComments))
+	sb.WriteString(fmt.Sprintf("# %s\n\n", name))
+	if i.Spec != nil && i.Spec.Description != "" {
+		sb.WriteString(i.Spec.Description + "\n\n")
+	}
+	if i.Spec != nil && i.Spec.Version != "" {
+		sb.WriteString(fmt.Sprintf("Spec version %s.\n\n", i.Spec.Version))
+	}
+
+	components := sortedComponents(i)
+	g.writeArchitecture(&sb, components)
+	g.writeRunbook(&sb, i)
+	g.writeRoutes(&sb, i)
+	g.writeFiles(&sb, i, components)
+
+	return sb.String()
+}
+
+func (g *ReadmeGenerator) writeArchitecture(sb *strings.Builder, components []*ir.Component) {
+	sb.WriteString("## Architecture\n\n")
+	sb.WriteString("The components declared in the spec and what each depends on.\n\n")
+	sb.WriteString("| Component | Kind | Details | Depends on |\n")
+	sb.WriteString("|-----------|------|---------|------------|\n")
+	for _, comp := range components {
+		var deps []string
+		for _, dep := range comp.Dependencies {
+			deps = append(deps, dep.ID)
+		}
+		sort.Strings(deps)
+		details := markdownCell(componentDetails(comp))
+		if summary := componentSummary(comp); summary != "" {
+			details = strings.TrimPrefix(details+"; "+markdownCell(summary), "; ")
+		}
+		for _, link := range comp.Links {
+			title := link.Title
+			if title == "" {
+				title = link.URL
+			}
+			details += fmt.Sprintf(" [%s](%s)", markdownCell(title), link.URL)
+		}
+		fmt.Fprintf(sb, "| `%s` | %s | %s | %s |\n", comp.ID, comp.Kind, strings.TrimSpace(details), codeList(deps))
+	}
+	sb.WriteString("\n")
+}
+
+// componentDetails summarizes the spec of a component in a few words.
+func componentDetails(comp *ir.Component) string {
+	switch {
+	case comp.HTTPServer != nil:
+		return fmt.Sprintf("%s on port %d", comp.HTTPServer.Framework, serverPort(comp))
+	case comp.HTTPGateway != nil:
+		prefixes := make([]string, len(comp.HTTPGateway.Routes))
+		for n, r := range comp.HTTPGateway.Routes {
+			prefixes[n] = r.Prefix
+		}
+		return fmt.Sprintf("port %d, forwards %s", comp.HTTPGateway.Port, strings.Join(prefixes, ", "))
+	case comp.Middleware != nil && len(comp.Middleware.Chain) > 0:
+		return "chain of " + strings.Join(comp.Middleware.Chain, ", ")
+	case comp.Middleware != nil:
+		return comp.Middleware.Provider
+	case comp.Postgres != nil:
+		return comp.Postgres.Provider
+	case comp.Webhook != nil:
+		names := make([]string, len(comp.Webhook.Events))
+		for n, ev := range comp.Webhook.Events {
+			names[n] = ev.Name
+		}
+		return strings.Join(names, ", ")
+	case comp.HTTPClient != nil:
+		return fmt.Sprintf("base URL in %s, %d retries", comp.HTTPClient.BaseURLEnv, comp.HTTPClient.Retries)
+	case comp.Cron != nil:
+		return "runs `" + comp.Cron.Schedule + "` in the worker"
+	case comp.Usecase != nil:
+		return comp.Usecase.Goal
+	case comp.External != nil:
+		return "external, not generated"
+	}
+	return ""
+}
+
+func (g *ReadmeGenerator) writeRunbook(sb *strings.Builder, i *ir.IR) {
+	sb.WriteString("## Running\n\n")
+
+	sb.WriteString("### Development\n\n")
+	sb.WriteString("```bash\n")
+	sb.WriteString("npm install\n")
+	sb.WriteString("cp .env.example .env  # then fill in the values\n")
+	if hasDBPushScript(i) {
+		sb.WriteString("npm run db:push       # create the database tables\n")
+	}
+	sb.WriteString("npm run dev\n")
+	if len(cronComponents(i)) > 0 {
+		sb.WriteString("npm run dev:worker    # run the scheduled jobs, in a second terminal\n")
+	}
+	sb.WriteString("```\n\n")
+	sb.WriteString("`.env.example` lists every environment variable the code reads. Only `npm run dev` loads `.env`; `npm start` reads the real environment.\n\n")
+
+	sb.WriteString("### Tests\n\n")
+	sb.WriteString("```bash\n")
+	sb.WriteString("npm test              # unit tests (vitest)\n")
+	if len(e2eServices(i)) > 0 {
+		sb.WriteString("npm run test:e2e      # end-to-end tests (playwright); starts the Docker services and the server\n")
+	} else {
+		sb.WriteString("npm run test:e2e      # end-to-end tests (playwright) against a running server\n")
+	}
+	sb.WriteString("npm run lint          # type check\n")
+	sb.WriteString("```\n\n")
+
+	sb.WriteString("### Docker\n\n")
+	sb.WriteString("```bash\n")
+	sb.WriteString("npm run docker:up     # build and start the app")
+	if len(cronComponents(i)) > 0 {
+		sb.WriteString(", the worker")
+	}
+	if len(postgresComponents(i)) > 0 || usesRedis(i) {
+		sb.WriteString(" and its services")
+	}
+	sb.WriteString("\n")
+	sb.WriteString("npm run docker:logs   # follow the logs\n")
+	sb.WriteString("npm run docker:down   # stop everything\n")
+	sb.WriteString("npm run docker:clean  # stop everything and delete the volumes\n")
+	sb.WriteString("```\n\n")
+}
+
+func (g *ReadmeGenerator) writeRoutes(sb *strings.Builder, i *ir.IR) {
+	servers := i.HTTPServers()
+	if len(servers) == 0 {
+		return
+	}
+
+	sb.WriteString("## Routes\n\n")
+	for _, server := range servers {
+		fmt.Fprintf(sb, "### `%s` (port %d)\n\n", server.ID, serverPort(server))
+		sb.WriteString("| Method | Path | Usecase | Middleware | Authorization |\n")
+		sb.WriteString("|--------|------|---------|------------|---------------|\n")
+		fmt.Fprintf(sb, "| GET | `%s` | — | — | — |\n", server.HTTPServer.RoutePath("/health"))
+		if docs := server.HTTPServer.APIDocs; docs != nil {
+			fmt.Fprintf(sb, "| GET | `%s` | — | — | — |\n", server.HTTPServer.RoutePath(docs.Path))
+		}
+		if metrics := server.HTTPServer.Metrics; metrics != nil {
+			fmt.Fprintf(sb, "| GET | `%s` | — | — | — |\n", server.HTTPServer.RoutePath(metrics.Path))
+		}
+
+		usecases := i.UsecasesBoundTo(server.ID)
+		sort.SliceStable(usecases, func(a, b int) bool {
+			x, y := usecases[a].Usecase.Binding, usecases[b].Usecase.Binding
+			if x.Path != y.Path {
+				return x.Path < y.Path
+			}
+			return x.Method < y.Method
+		})
+		for _, uc := range usecases {
+			fmt.Fprintf(sb, "| %s | `%s` | `%s` | %s | %s |\n",
+				uc.Usecase.Binding.Method, server.HTTPServer.RoutePath(uc.Usecase.Binding.Path), uc.ID,
+				codeList(effectiveUsecaseMiddleware(uc, server)), authorizationSummary(uc.Usecase.Authorization))
+		}
+
+		// Admin routes run behind the casbin middleware and the middleware it depends on
+		for _, mw := range policyAdminMiddleware(i, collectServerMiddleware(i, server)) {
+			chain := append(append([]string{}, mw.Middleware.DependsOn...), mw.ID)
+			fmt.Fprintf(sb, "| GET | `%s` | effective policies of `%s` | %s | — |\n",
+				server.HTTPServer.RoutePath(mw.Middleware.AdminRoute), mw.ID, codeList(chain))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// authorizationSummary describes a usecase's authorization requirement.
+func authorizationSummary(a *ir.AuthorizationSpec) string {
+	if a == nil {
+		return "—"
+	}
+	var parts []string
+	if len(a.Roles) > 0 {
+		parts = append(parts, "any role of "+codeList(a.Roles))
+	}
+	if len(a.Permissions) > 0 {
+		parts = append(parts, "all permissions of "+codeList(a.Permissions))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (g *ReadmeGenerator) writeFiles(sb *strings.Builder, i *ir.IR, components []*ir.Component) {
+	sb.WriteString("## Files\n\n")
+	sb.WriteString("Files marked DO NOT EDIT are regenerated by `bound compile`. `src/index.ts` starts the servers.\n\n")
+	sb.WriteString("| Component | Files |\n")
+	sb.WriteString("|-----------|-------|\n")
+	for _, comp := range components {
+		fmt.Fprintf(sb, "| `%s` | %s |\n", comp.ID, codeList(componentFiles(i, comp)))
+	}
+}
+
+// componentFiles returns the generated files of a component, mirroring the
+// generators that produce them.
+func componentFiles(i *ir.IR, comp *ir.Component) []string {
+	switch {
+	case comp.HTTPServer != nil:
+		files := []string{
+			serverSourcePath(comp.ID),
+			serverContextPath(comp.ID),
+			serverOpenAPIPath(comp.ID),
+		}
+		if comp.HTTPServer.APIDocs != nil {
+			files = append(files, serverDocsPath(comp.ID))
+		}
+		if comp.HTTPServer.Metrics != nil {
+			files = append(files, serverMetricsPath(comp.ID))
+		}
+		return append(files, serverTestPath(comp.ID), fmt.Sprintf("e2e/%s.spec.ts", sanitizeFilename(comp.ID)))
+	case comp.Middleware != nil:
+		s := comp.Middleware
+		var files []string
+		switch s.Provider {
+		case "":
+			if len(s.Chain) > 0 {
+				files = append(files, middlewareSourcePath(comp.ID))
+			}
+		case "better-auth":
+			files = append(files, middlewareSourcePath(comp.ID))
+			if s.Config != "" {
+				files = append(files, middlewareConfigPath(comp.ID))
+			}
+			files = append(files, middlewareSchemaPath(comp.ID))
+			if s.Session != nil {
+				files = append(files, middlewareSessionPath(comp.ID))
+			}
+			if len(s.OAuth) > 0 {
+				files = append(files, middlewareOAuthPath(comp.ID))
+			}
+		case "casbin":
+			files = append(files, middlewareSourcePath(comp.ID), middlewareEnforcerPath(comp.ID))
+			if s.Model != "" {
+				files = append(files, middlewareModelPath(comp.ID))
+			}
+			if s.Policy != "" {
+				files = append(files, middlewarePolicyPath(comp.ID))
+			}
+			if hasRBAC(comp) {
+				files = append(files, middlewareRBACPath(comp.ID))
+			}
+		}
+		return append(files, middlewareTestPath(comp.ID))
+	case comp.Postgres != nil:
+		files := []string{postgresSourcePath(comp.ID)}
+		if comp.Postgres.Schema != "" {
+			files = append(files, postgresSchemaPath(comp.ID))
+		}
+		if len(drizzleTables(i, comp)) > 0 {
+			files = append(files, postgresRepositoriesPath(comp.ID))
+		}
+		return files
+	case comp.HTTPGateway != nil:
+		return []string{gatewaySourcePath(comp.ID)}
+	case comp.Webhook != nil:
+		return []string{webhookSourcePath(comp.ID)}
+	case comp.HTTPClient != nil:
+		return []string{httpClientSourcePath(comp.ID), httpClientTestPath(comp.ID)}
+	case comp.Cron != nil:
+		return []string{cronSourcePath(comp.ID)}
+	case comp.Usecase != nil:
+		if !comp.Usecase.UnitTests() {
+			return []string{usecaseSourcePath(comp.ID)}
+		}
+		return []string{usecaseSourcePath(comp.ID), usecaseTestPath(comp.ID)}
+	}
+	return nil
+}
+
+// sortedComponents returns every component, sorted by ID.
+func sortedComponents(i *ir.IR) []*ir.Component {
+	components := make([]*ir.Component, 0, len(i.Components))
+	for _, comp := range i.Components {
+		components = append(components, comp)
+	}
+	sort.Slice(components, func(a, b int) bool {
+		return components[a].ID < components[b].ID
+	})
+	return components
+}
+
+// hasDBPushScript reports whether the provider of any database adds a
+// db:push script creating its tables.
+func hasDBPushScript(i *ir.IR) bool {
+	scripts := map[string]string{}
+	for _, pg := range postgresComponents(i) {
+		if p := databaseProviderFor(pg); p != nil {
+			p.Scripts(pg, scripts)
+		}
+	}
+	_, ok := scripts["db:push"]
+	return ok
+}
+
+// codeList renders items as comma-separated inline code, or a dash when empty.
+func codeList(items []string) string {
+	if len(items) == 0 {
+		return "—"
+	}
+	quoted := make([]string, len(items))
+	for n, item := range items {
+		quoted[n] = "`" + item + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// markdownCell escapes text for a markdown table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
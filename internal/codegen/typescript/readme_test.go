@@ -0,0 +1,45 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+func TestReadmeGenerator_Generate(t *testing.T) {
+	// given
+	i := newTestIR(withUsecaseAuthorization)
+	i.Spec = &parser.Spec{Name: "user-service", Description: "Manages users", Version: "1.0.0"}
+
+	// when
+	output, err := NewReadmeGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	readme := string(output.Files["README.md"].Content)
+	for _, want := range []string{
+		"<!-- Generated by OpenBoundary - DO NOT EDIT",
+		"# user-service\n\nManages users\n",
+		"| `http.server.api` | http.server | hono on port 3000 | `middleware.authn`, `middleware.authz`, `postgres.primary` |\n",
+		"| `usecase.create-user` | usecase | Create a new user | — |\n",
+		"npm run db:push",
+		"npm run docker:up     # build and start the app and its services\n",
+		"### `http.server.api` (port 3000)\n",
+		"| POST | `/users` | `usecase.create-user` | — | — |\n",
+		"| GET | `/users/{id}` | `usecase.get-user` | `middleware.authn`, `middleware.authz` | any role of `admin`; all permissions of `user.read` |\n",
+		"| `middleware.authz` | `src/components/middleware-authz.middleware.ts`, `src/components/middleware-authz.middleware.enforcer.ts`, " +
+			"`src/components/middleware-authz.middleware.model.conf`, `src/components/middleware-authz.middleware.policy.csv`, " +
+			"`src/components/middleware-authz.middleware.rbac.ts`, `src/components/middleware-authz.middleware.test.ts` |\n",
+		"| `postgres.primary` | `src/components/postgres-primary.postgres.ts`, `src/components/postgres-primary.postgres.schema.ts` |\n",
+	} {
+		if !strings.Contains(readme, want) {
+			t.Errorf("README missing %q\n%s", want, readme)
+		}
+	}
+}
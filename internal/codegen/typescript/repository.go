@@ -0,0 +1,609 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// RepositoryGenerator generates a repository per table of the Drizzle schema
+// of each postgres component, which usecases find, list, create, update and
+// delete rows through instead of querying the client themselves.
+type RepositoryGenerator struct{}
+
+// NewRepositoryGenerator creates a new repository generator.
+func NewRepositoryGenerator() *RepositoryGenerator {
+	return &RepositoryGenerator{}
+}
+
+// Name returns the generator name.
+func (g *RepositoryGenerator) Name() string {
+	return "typescript-repositories"
+}
+
+// Generate produces the repository modules from the IR.
+func (g *RepositoryGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
+	return codegen.GenerateComponents(g, i)
+}
+
+// GenerateShared produces the repository interface and its in-memory
+// implementation, which every repository module imports, and the convention
+// columns Drizzle schemas spread into their tables.
+func (g *RepositoryGenerator) GenerateShared(i *ir.IR) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	if hasRepositories(i) {
+		output.AddFile(repositoryPath(), []byte(generateRepositoryModule()))
+	}
+	if hasDrizzleSchemas(i) {
+		output.AddFile(postgresColumnsPath(), []byte(generateColumnsModule()))
+	}
+	return output, nil
+}
+
+// GenerateComponent produces the repositories of a postgres component.
+func (g *RepositoryGenerator) GenerateComponent(i *ir.IR, comp *ir.Component) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	if tables := drizzleTables(i, comp); len(tables) > 0 {
+		output.AddComponentFile(postgresRepositoriesPath(comp.ID), []byte(g.generateRepositories(comp, tables, hasServerCache(i))), comp.ID)
+	}
+	return output, nil
+}
+
+// drizzleTable is a table the Drizzle schema of a database exports, with a
+// single-column primary key, and the convention columns it has.
+type drizzleTable struct {
+	Export     string // Name of the exported table, e.g. "users"
+	Key        string // Property of the primary key column, e.g. "id"
+	NumericKey bool   // The key is a number, e.g. a serial column
+	CreatedAt  bool   // Has createdAt, set when a row is created
+	UpdatedAt  bool   // Has updatedAt, set when a row is updated
+	SoftDelete bool   // Has deletedAt: delete sets it, and queries skip rows that have it
+}
+
+// hasConventions reports whether the repositories maintain any column of t.
+func (t drizzleTable) hasConventions() bool {
+	return t.CreatedAt || t.UpdatedAt || t.SoftDelete
+}
+
+// drizzleConventionSpreads are the convention columns of the spreads of
+// postgres.columns.ts, e.g. "...timestamps" for createdAt and updatedAt.
+var drizzleConventionSpreads = map[string][]string{
+	"...timestamps": {"createdAt", "updatedAt"},
+	"...softDelete": {"deletedAt"},
+}
+
+// drizzleTablePattern matches the start of a table definition up to the
+// brace opening its columns: group 1 is the exported name.
+var drizzleTablePattern = regexp.MustCompile(`export\s+const\s+([A-Za-z_$][\w$]*)\s*=\s*pgTable\(\s*['"][^'"]+['"]\s*,\s*\{`)
+
+// drizzleColumnPattern matches a column of a table definition: group 1 is
+// the property and group 2 the column builder, e.g. "uuid".
+var drizzleColumnPattern = regexp.MustCompile(`^\s*([A-Za-z_$][\w$]*)\s*:\s*([A-Za-z_$][\w$]*)\(`)
+
+// numericKeyBuilders are the column builders of keys that are numbers.
+var numericKeyBuilders = map[string]bool{
+	"serial": true, "smallserial": true, "integer": true, "smallint": true,
+}
+
+// drizzleTables returns the tables of a drizzle database's schema file in
+// the order it exports them. Tables without a single-column primary key,
+// and files that cannot be read, have no repositories.
+func drizzleTables(i *ir.IR, pg *ir.Component) []drizzleTable {
+	if pg == nil || pg.Postgres == nil || pg.Postgres.Provider != "drizzle" || pg.Postgres.Schema == "" {
+		return nil
+	}
+	content, err := i.ReadFile(pg.Postgres.Schema)
+	if err != nil {
+		return nil
+	}
+	return parseDrizzleTables(string(content))
+}
+
+// parseDrizzleTables finds the pgTable definitions of a Drizzle schema.
+func parseDrizzleTables(source string) []drizzleTable {
+	var tables []drizzleTable
+	for _, m := range drizzleTablePattern.FindAllStringSubmatchIndex(source, -1) {
+		table := drizzleTable{Export: source[m[2]:m[3]]}
+		keys := 0
+		for _, column := range splitTopLevel(source[m[1]:]) {
+			properties := drizzleConventionSpreads[strings.TrimSpace(column)]
+			c := drizzleColumnPattern.FindStringSubmatch(column)
+			if c != nil {
+				properties = []string{c[1]}
+			}
+			for _, property := range properties {
+				switch property {
+				case "createdAt":
+					table.CreatedAt = true
+				case "updatedAt":
+					table.UpdatedAt = true
+				case "deletedAt":
+					table.SoftDelete = true
+				}
+			}
+			if c == nil || !strings.Contains(column, ".primaryKey(") {
+				continue
+			}
+			table.Key, table.NumericKey = c[1], numericKeyBuilders[c[2]]
+			keys++
+		}
+		if keys == 1 {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+// splitTopLevel splits the body of an object literal, which source starts
+// with, at its top-level commas, up to the brace closing it.
+func splitTopLevel(source string) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	for n := 0; n < len(source); n++ {
+		ch := source[n]
+		switch {
+		case quote != 0:
+			if ch == '\\' {
+				n++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+		case ch == '(' || ch == '[' || ch == '{':
+			depth++
+		case ch == ')' || ch == ']' || ch == '}':
+			if depth == 0 {
+				return append(parts, source[start:n])
+			}
+			depth--
+		case ch == ',' && depth == 0:
+			parts = append(parts, source[start:n])
+			start = n + 1
+		}
+	}
+	return append(parts, source[start:])
+}
+
+// hasRepositories reports whether any database has repositories.
+func hasRepositories(i *ir.IR) bool {
+	for _, pg := range postgresComponents(i) {
+		if len(drizzleTables(i, pg)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDrizzleSchemas reports whether any database has a Drizzle schema,
+// which may spread the convention columns.
+func hasDrizzleSchemas(i *ir.IR) bool {
+	for _, pg := range postgresComponents(i) {
+		if pg.Postgres.Provider == "drizzle" && pg.Postgres.Schema != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// repositoryContextField returns the context field holding the repositories
+// of a database: "repositories" for a single database, otherwise named after
+// its component like its client (e.g., "analyticsRepositories").
+func repositoryContextField(i *ir.IR, pg *ir.Component) string {
+	if len(postgresComponents(i)) <= 1 {
+		return "repositories"
+	}
+	return componentNameCamel(pg.ID) + "Repositories"
+}
+
+// repositoriesTypeName returns the name of the type holding the repositories
+// of a database (e.g., "postgres.primary" -> "PostgresPrimaryRepositories").
+func repositoriesTypeName(pg *ir.Component) string {
+	return toPascalCase(pg.ID) + "Repositories"
+}
+
+// generateRepositories generates the repositories of a database's tables.
+// With a server cache, they report every write to onWrite, which drops the
+// cached responses reading the table.
+func (g *RepositoryGenerator) generateRepositories(pg *ir.Component, tables []drizzleTable, onWrite bool) string {
+	var sb strings.Builder
+	typeName := repositoriesTypeName(pg)
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString(componentHeader(pg))
+	// Keys of the in-memory repositories are UUIDs, or counted up for numbers
+	numbers, uuids, softDelete := false, false, false
+	for _, t := range tables {
+		numbers = numbers || t.NumericKey
+		uuids = uuids || !t.NumericKey
+		softDelete = softDelete || t.SoftDelete
+	}
+	helpers := "createInMemoryRepository, pageBounds"
+	if numbers {
+		helpers += ", sequence"
+	}
+	if uuids {
+		sb.WriteString("import { randomUUID } from 'node:crypto';\n")
+	}
+	if softDelete {
+		sb.WriteString("import { and, asc, eq, isNull } from 'drizzle-orm';\n")
+	} else {
+		sb.WriteString("import { asc, eq } from 'drizzle-orm';\n")
+	}
+	fmt.Fprintf(&sb, "import type { DrizzleClient } from '%s';\n", postgresClientImportPath())
+	sb.WriteString("import type { Repository } from './postgres.repository';\n")
+	fmt.Fprintf(&sb, "import { %s } from './postgres.repository';\n", helpers)
+	fmt.Fprintf(&sb, "import * as schema from './%s.postgres.schema';\n", componentIDSlug(pg.ID))
+
+	for _, t := range tables {
+		name := titleCase(t.Export)
+		fmt.Fprintf(&sb, "\n/** Row of the %s table */\n", t.Export)
+		fmt.Fprintf(&sb, "export type %sRow = typeof schema.%s.$inferSelect;\n", name, t.Export)
+		fmt.Fprintf(&sb, "/** Values of a new row of the %s table */\n", t.Export)
+		fmt.Fprintf(&sb, "export type New%sRow = typeof schema.%s.$inferInsert;\n", name, t.Export)
+		fmt.Fprintf(&sb, "/** Rows of the %s table by %s */\n", t.Export, t.Key)
+		fmt.Fprintf(&sb, "export type %sRepository = Repository<%sRow, New%sRow, %sRow['%s']>;\n", name, name, name, name, t.Key)
+		if t.SoftDelete {
+			fmt.Fprintf(&sb, "/** Matches the rows of the %s table that are not deleted, for queries of your own */\n", t.Export)
+			fmt.Fprintf(&sb, "export const %sNotDeleted = isNull(schema.%s.deletedAt);\n", t.Export, t.Export)
+		}
+	}
+
+	fmt.Fprintf(&sb, "\n/** Repositories of the tables of %s */\n", pg.ID)
+	fmt.Fprintf(&sb, "export interface %s {\n", typeName)
+	for _, t := range tables {
+		fmt.Fprintf(&sb, "  %s: %sRepository;\n", t.Export, titleCase(t.Export))
+	}
+	sb.WriteString("}\n\n")
+
+	if onWrite {
+		fmt.Fprintf(&sb, "/** Creates the repositories of %s, which query db and report the tables they write to onWrite. */\n", pg.ID)
+		fmt.Fprintf(&sb, "export function create%s(db: DrizzleClient, onWrite: (table: string) => Promise<void>): %s {\n", typeName, typeName)
+	} else {
+		fmt.Fprintf(&sb, "/** Creates the repositories of %s, which query db. */\n", pg.ID)
+		fmt.Fprintf(&sb, "export function create%s(db: DrizzleClient): %s {\n", typeName, typeName)
+	}
+	sb.WriteString("  return {\n")
+	for _, t := range tables {
+		table := "schema." + t.Export
+		key := table + "." + t.Key
+		// Soft-deleted rows are neither found, listed, updated nor deleted again
+		byKey, all := fmt.Sprintf("eq(%s, id)", key), ""
+		if t.SoftDelete {
+			byKey = fmt.Sprintf("and(eq(%s, id), %sNotDeleted)", key, t.Export)
+			all = fmt.Sprintf(".where(%sNotDeleted)", t.Export)
+		}
+		set := "values"
+		if t.UpdatedAt {
+			set = "{ ...values, updatedAt: new Date() }"
+		}
+		fmt.Fprintf(&sb, "    %s: {\n", t.Export)
+		sb.WriteString("      async findById(id) {\n")
+		fmt.Fprintf(&sb, "        const [row] = await db.select().from(%s).where(%s).limit(1);\n", table, byKey)
+		sb.WriteString("        return row;\n")
+		sb.WriteString("      },\n")
+		sb.WriteString("      async list(page) {\n")
+		sb.WriteString("        const { limit, offset } = pageBounds(page);\n")
+		fmt.Fprintf(&sb, "        return db.select().from(%s)%s.orderBy(asc(%s)).limit(limit).offset(offset);\n", table, all, key)
+		sb.WriteString("      },\n")
+		sb.WriteString("      async create(values) {\n")
+		fmt.Fprintf(&sb, "        const [row] = await db.insert(%s).values(values).returning();\n", table)
+		writeReportWrite(&sb, onWrite, t, "")
+		sb.WriteString("        return row;\n")
+		sb.WriteString("      },\n")
+		sb.WriteString("      async update(id, values) {\n")
+		fmt.Fprintf(&sb, "        const [row] = await db.update(%s).set(%s).where(%s).returning();\n", table, set, byKey)
+		writeReportWrite(&sb, onWrite, t, "row")
+		sb.WriteString("        return row;\n")
+		sb.WriteString("      },\n")
+		sb.WriteString("      async delete(id) {\n")
+		if t.SoftDelete {
+			fmt.Fprintf(&sb, "        const rows = await db.update(%s).set({ deletedAt: new Date() }).where(%s).returning();\n", table, byKey)
+		} else {
+			fmt.Fprintf(&sb, "        const rows = await db.delete(%s).where(%s).returning();\n", table, byKey)
+		}
+		writeReportWrite(&sb, onWrite, t, "rows.length > 0")
+		sb.WriteString("        return rows.length > 0;\n")
+		sb.WriteString("      },\n")
+		sb.WriteString("    },\n")
+	}
+	sb.WriteString("  };\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/**\n")
+	fmt.Fprintf(&sb, " * Creates repositories of %s that keep rows in memory, for unit tests.\n", pg.ID)
+	sb.WriteString(" * Column defaults are not applied; a row created without a key gets a new one,\n")
+	sb.WriteString(" * and the convention columns are kept as in the database.\n")
+	sb.WriteString(" */\n")
+	fmt.Fprintf(&sb, "export function createInMemory%s(): %s {\n", typeName, typeName)
+	sb.WriteString("  return {\n")
+	for _, t := range tables {
+		name := titleCase(t.Export)
+		nextID := "() => randomUUID()"
+		if t.NumericKey {
+			nextID = "sequence()"
+		}
+		conventions := ""
+		if t.hasConventions() {
+			var columns []string
+			for _, c := range []struct {
+				name string
+				on   bool
+			}{{"createdAt", t.CreatedAt}, {"updatedAt", t.UpdatedAt}, {"deletedAt", t.SoftDelete}} {
+				if c.on {
+					columns = append(columns, c.name+": true")
+				}
+			}
+			conventions = ", { " + strings.Join(columns, ", ") + " }"
+		}
+		fmt.Fprintf(&sb, "    %s: createInMemoryRepository<%sRow, New%sRow, '%s'>('%s', %s%s),\n", t.Export, name, name, t.Key, t.Key, nextID, conventions)
+	}
+	sb.WriteString("  };\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// writeReportWrite writes how a repository reports a write to its table to
+// onWrite, if the repositories have one: always, or when changed holds.
+func writeReportWrite(sb *strings.Builder, onWrite bool, t drizzleTable, changed string) {
+	if !onWrite {
+		return
+	}
+	if changed == "" {
+		fmt.Fprintf(sb, "        await onWrite('%s');\n", t.Export)
+		return
+	}
+	fmt.Fprintf(sb, "        if (%s) {\n", changed)
+	fmt.Fprintf(sb, "          await onWrite('%s');\n", t.Export)
+	sb.WriteString("        }\n")
+}
+
+// generateRepositoryModule generates the interface every repository
+// implements, its pagination and its in-memory implementation.
+func generateRepositoryModule() string {
+	var sb strings.Builder
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("// Repositories find, list, create, update and delete the rows of a table by\n")
+	sb.WriteString("// their primary key. Usecases use the repositories in their context.\n\n")
+
+	sb.WriteString("/** Rows a list returns: at most limit, after skipping offset. */\n")
+	sb.WriteString("export interface Page {\n")
+	sb.WriteString("  limit?: number;\n")
+	sb.WriteString("  offset?: number;\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/** Rows a list returns when the page sets no limit. */\n")
+	sb.WriteString("export const DEFAULT_PAGE_SIZE = 50;\n")
+	sb.WriteString("/** Most rows a list returns. */\n")
+	sb.WriteString("export const MAX_PAGE_SIZE = 500;\n\n")
+
+	sb.WriteString("/** Rows of a table of type Row, created from values of type New, by key Id. */\n")
+	sb.WriteString("export interface Repository<Row, New, Id> {\n")
+	sb.WriteString("  /** Returns the row with the key, or undefined. */\n")
+	sb.WriteString("  findById(id: Id): Promise<Row | undefined>;\n")
+	sb.WriteString("  /** Returns a page of rows, ordered by key in the database. */\n")
+	sb.WriteString("  list(page?: Page): Promise<Row[]>;\n")
+	sb.WriteString("  /** Creates a row and returns it. */\n")
+	sb.WriteString("  create(values: New): Promise<Row>;\n")
+	sb.WriteString("  /** Sets values of the row with the key and returns it, or undefined. */\n")
+	sb.WriteString("  update(id: Id, values: Partial<New>): Promise<Row | undefined>;\n")
+	sb.WriteString("  /** Deletes the row with the key and reports whether it existed. */\n")
+	sb.WriteString("  delete(id: Id): Promise<boolean>;\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/** Convention columns of a table, which its repository maintains. */\n")
+	sb.WriteString("export interface Conventions {\n")
+	sb.WriteString("  /** Has createdAt, set when a row is created */\n")
+	sb.WriteString("  createdAt?: boolean;\n")
+	sb.WriteString("  /** Has updatedAt, set when a row is created or updated */\n")
+	sb.WriteString("  updatedAt?: boolean;\n")
+	sb.WriteString("  /** Has deletedAt: delete sets it, and rows that have it are not found */\n")
+	sb.WriteString("  deletedAt?: boolean;\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/** Returns the limit and offset of a page, with the limit between 1 and MAX_PAGE_SIZE. */\n")
+	sb.WriteString("export function pageBounds(page: Page = {}): { limit: number; offset: number } {\n")
+	sb.WriteString("  const limit = Math.min(Math.max(Math.trunc(page.limit ?? DEFAULT_PAGE_SIZE), 1), MAX_PAGE_SIZE);\n")
+	sb.WriteString("  const offset = Math.max(Math.trunc(page.offset ?? 0), 0);\n")
+	sb.WriteString("  return { limit, offset };\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/** Returns a function that counts up from 1, the keys of serial columns. */\n")
+	sb.WriteString("export function sequence(): () => number {\n")
+	sb.WriteString("  let last = 0;\n")
+	sb.WriteString("  return () => ++last;\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/**\n")
+	sb.WriteString(" * Creates a repository that keeps rows in memory, keyed by their key column.\n")
+	sb.WriteString(" * A row created without a key gets one from nextId. Lists return rows in the\n")
+	sb.WriteString(" * order they were created. The convention columns are set like the database\n")
+	sb.WriteString(" * repositories set them.\n")
+	sb.WriteString(" */\n")
+	sb.WriteString("export function createInMemoryRepository<Row, New, K extends keyof Row>(\n")
+	sb.WriteString("  key: K,\n")
+	sb.WriteString("  nextId: () => Row[K],\n")
+	sb.WriteString("  conventions: Conventions = {},\n")
+	sb.WriteString("): Repository<Row, New, Row[K]> {\n")
+	sb.WriteString("  const rows = new Map<Row[K], Row>();\n")
+	sb.WriteString("  const columns = (row: Row) => row as unknown as Record<string, unknown>;\n")
+	sb.WriteString("  // Soft-deleted rows are kept but not found\n")
+	sb.WriteString("  const found = (row: Row | undefined) =>\n")
+	sb.WriteString("    row !== undefined && !(conventions.deletedAt && columns(row).deletedAt != null) ? row : undefined;\n")
+	sb.WriteString("  return {\n")
+	sb.WriteString("    async findById(id) {\n")
+	sb.WriteString("      return found(rows.get(id));\n")
+	sb.WriteString("    },\n")
+	sb.WriteString("    async list(page) {\n")
+	sb.WriteString("      const { limit, offset } = pageBounds(page);\n")
+	sb.WriteString("      return [...rows.values()].filter((row) => found(row) !== undefined).slice(offset, offset + limit);\n")
+	sb.WriteString("    },\n")
+	sb.WriteString("    async create(values) {\n")
+	sb.WriteString("      const row = { ...values } as unknown as Row;\n")
+	sb.WriteString("      if (row[key] === undefined || row[key] === null) {\n")
+	sb.WriteString("        row[key] = nextId();\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      const now = new Date();\n")
+	sb.WriteString("      for (const column of ['createdAt', 'updatedAt'] as const) {\n")
+	sb.WriteString("        if (conventions[column] && columns(row)[column] === undefined) {\n")
+	sb.WriteString("          columns(row)[column] = now;\n")
+	sb.WriteString("        }\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      rows.set(row[key], row);\n")
+	sb.WriteString("      return row;\n")
+	sb.WriteString("    },\n")
+	sb.WriteString("    async update(id, values) {\n")
+	sb.WriteString("      const row = found(rows.get(id));\n")
+	sb.WriteString("      if (row === undefined) {\n")
+	sb.WriteString("        return undefined;\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      const updated = { ...row, ...values } as Row;\n")
+	sb.WriteString("      updated[key] = id;\n")
+	sb.WriteString("      if (conventions.updatedAt) {\n")
+	sb.WriteString("        columns(updated).updatedAt = new Date();\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      rows.set(id, updated);\n")
+	sb.WriteString("      return updated;\n")
+	sb.WriteString("    },\n")
+	sb.WriteString("    async delete(id) {\n")
+	sb.WriteString("      const row = found(rows.get(id));\n")
+	sb.WriteString("      if (row === undefined) {\n")
+	sb.WriteString("        return false;\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      if (conventions.deletedAt) {\n")
+	sb.WriteString("        rows.set(id, { ...row, deletedAt: new Date() });\n")
+	sb.WriteString("        return true;\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      return rows.delete(id);\n")
+	sb.WriteString("    },\n")
+	sb.WriteString("  };\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// generateColumnsModule generates the convention columns a Drizzle schema
+// spreads into its tables, which the repositories recognize like columns the
+// table declares itself.
+func generateColumnsModule() string {
+	var sb strings.Builder
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("// Columns tables spread into their pgTable definitions, e.g.\n")
+	sb.WriteString("//   pgTable('users', { id: uuid('id').primaryKey(), ...timestamps, ...softDelete })\n")
+	sb.WriteString("// The repositories set them: createdAt and updatedAt when a row is created or\n")
+	sb.WriteString("// updated, deletedAt when it is deleted, which they skip afterwards.\n")
+	sb.WriteString("import { timestamp } from 'drizzle-orm/pg-core';\n\n")
+
+	sb.WriteString("/** When a row was created and last updated. */\n")
+	sb.WriteString("export const timestamps = {\n")
+	sb.WriteString("  createdAt: timestamp('created_at', { withTimezone: true }).notNull().defaultNow(),\n")
+	sb.WriteString("  updatedAt: timestamp('updated_at', { withTimezone: true }).notNull().defaultNow(),\n")
+	sb.WriteString("};\n\n")
+
+	sb.WriteString("/** When a row was deleted, or null while it is not. */\n")
+	sb.WriteString("export const softDelete = {\n")
+	sb.WriteString("  deletedAt: timestamp('deleted_at', { withTimezone: true }),\n")
+	sb.WriteString("};\n")
+
+	return sb.String()
+}
+
+// generateRepositoryTest generates the test of the in-memory repository.
+func generateRepositoryTest() string {
+	var sb strings.Builder
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("import { describe, it, expect } from 'vitest';\n")
+	sb.WriteString("import { MAX_PAGE_SIZE, createInMemoryRepository, pageBounds, sequence } from './postgres.repository';\n\n")
+
+	sb.WriteString("interface Item {\n")
+	sb.WriteString("  id: number;\n")
+	sb.WriteString("  name: string;\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("interface Entry extends Item {\n")
+	sb.WriteString("  createdAt: Date;\n")
+	sb.WriteString("  updatedAt: Date;\n")
+	sb.WriteString("  deletedAt: Date | null;\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("describe('createInMemoryRepository', () => {\n")
+	sb.WriteString("  it('should create, find, update and delete rows', async () => {\n")
+	sb.WriteString("    // given\n")
+	sb.WriteString("    const items = createInMemoryRepository<Item, Partial<Item>, 'id'>('id', sequence());\n\n")
+	sb.WriteString("    // when\n")
+	sb.WriteString("    const created = await items.create({ name: 'first' });\n")
+	sb.WriteString("    const updated = await items.update(created.id, { name: 'renamed' });\n\n")
+	sb.WriteString("    // then\n")
+	sb.WriteString("    expect(created).toEqual({ id: 1, name: 'first' });\n")
+	sb.WriteString("    expect(updated).toEqual({ id: 1, name: 'renamed' });\n")
+	sb.WriteString("    expect(await items.findById(1)).toEqual({ id: 1, name: 'renamed' });\n")
+	sb.WriteString("    expect(await items.delete(1)).toBe(true);\n")
+	sb.WriteString("    expect(await items.findById(1)).toBeUndefined();\n")
+	sb.WriteString("    expect(await items.update(1, { name: 'gone' })).toBeUndefined();\n")
+	sb.WriteString("    expect(await items.delete(1)).toBe(false);\n")
+	sb.WriteString("  });\n\n")
+
+	sb.WriteString("  it('should list rows a page at a time', async () => {\n")
+	sb.WriteString("    // given\n")
+	sb.WriteString("    const items = createInMemoryRepository<Item, Partial<Item>, 'id'>('id', sequence());\n")
+	sb.WriteString("    for (const name of ['a', 'b', 'c']) {\n")
+	sb.WriteString("      await items.create({ name });\n")
+	sb.WriteString("    }\n\n")
+	sb.WriteString("    // when\n")
+	sb.WriteString("    const page = await items.list({ limit: 2, offset: 1 });\n\n")
+	sb.WriteString("    // then\n")
+	sb.WriteString("    expect(page.map((item) => item.name)).toEqual(['b', 'c']);\n")
+	sb.WriteString("    expect(await items.list()).toHaveLength(3);\n")
+	sb.WriteString("  });\n\n")
+
+	sb.WriteString("  it('should set createdAt and updatedAt', async () => {\n")
+	sb.WriteString("    // given\n")
+	sb.WriteString("    const entries = createInMemoryRepository<Entry, Partial<Entry>, 'id'>('id', sequence(), {\n")
+	sb.WriteString("      createdAt: true,\n")
+	sb.WriteString("      updatedAt: true,\n")
+	sb.WriteString("    });\n")
+	sb.WriteString("    const created = await entries.create({ name: 'first', createdAt: new Date(0), updatedAt: new Date(0) });\n\n")
+	sb.WriteString("    // when\n")
+	sb.WriteString("    const updated = await entries.update(created.id, { name: 'renamed' });\n\n")
+	sb.WriteString("    // then\n")
+	sb.WriteString("    expect(updated?.createdAt).toEqual(new Date(0));\n")
+	sb.WriteString("    expect(updated?.updatedAt.getTime()).toBeGreaterThan(0);\n")
+	sb.WriteString("    expect((await entries.create({ name: 'second' })).createdAt).toBeInstanceOf(Date);\n")
+	sb.WriteString("  });\n\n")
+
+	sb.WriteString("  it('should keep soft-deleted rows out of finds, lists and updates', async () => {\n")
+	sb.WriteString("    // given\n")
+	sb.WriteString("    const entries = createInMemoryRepository<Entry, Partial<Entry>, 'id'>('id', sequence(), { deletedAt: true });\n")
+	sb.WriteString("    const kept = await entries.create({ name: 'kept', deletedAt: null });\n")
+	sb.WriteString("    const deleted = await entries.create({ name: 'deleted', deletedAt: null });\n\n")
+	sb.WriteString("    // when\n")
+	sb.WriteString("    const existed = await entries.delete(deleted.id);\n\n")
+	sb.WriteString("    // then\n")
+	sb.WriteString("    expect(existed).toBe(true);\n")
+	sb.WriteString("    expect(await entries.findById(deleted.id)).toBeUndefined();\n")
+	sb.WriteString("    expect(await entries.list()).toEqual([kept]);\n")
+	sb.WriteString("    expect(await entries.update(deleted.id, { name: 'back' })).toBeUndefined();\n")
+	sb.WriteString("    expect(await entries.delete(deleted.id)).toBe(false);\n")
+	sb.WriteString("  });\n")
+	sb.WriteString("});\n\n")
+
+	sb.WriteString("describe('pageBounds', () => {\n")
+	sb.WriteString("  it('should keep the limit between 1 and MAX_PAGE_SIZE', () => {\n")
+	sb.WriteString("    expect(pageBounds({ limit: 0, offset: -5 })).toEqual({ limit: 1, offset: 0 });\n")
+	sb.WriteString("    expect(pageBounds({ limit: MAX_PAGE_SIZE + 1 }).limit).toBe(MAX_PAGE_SIZE);\n")
+	sb.WriteString("  });\n")
+	sb.WriteString("});\n")
+
+	return sb.String()
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+func TestParseDrizzleTables(t *testing.T) {
+	// given
+	source := `import { pgTable, serial, text, uuid, primaryKey } from 'drizzle-orm/pg-core';
+
+export const users = pgTable('users', {
+  id: uuid('id').primaryKey().defaultRandom(),
+  name: text('name').default('a, b }'),
+});
+
+export const orders = pgTable("orders", {
+  orderId: serial('order_id').primaryKey(),
+  meta: text('meta').$type<{ a: string }>(),
+});
+
+export const memberships = pgTable('memberships', {
+  userId: uuid('user_id'),
+  projectId: uuid('project_id'),
+}, (t) => [primaryKey({ columns: [t.userId, t.projectId] })]);
+
+export const logs = pgTable('logs', {
+  message: text('message'),
+});
+
+export const posts = pgTable('posts', {
+  id: uuid('id').primaryKey(),
+  ...timestamps,
+  ...softDelete,
+});
+
+export const notes = pgTable('notes', {
+  id: serial('id').primaryKey(),
+  updatedAt: timestamp('updated_at').notNull().defaultNow(),
+  deletedAt: timestamp('deleted_at'),
+});
+`
+
+	// when
+	tables := parseDrizzleTables(source)
+
+	// then
+	want := []drizzleTable{
+		{Export: "users", Key: "id"},
+		{Export: "orders", Key: "orderId", NumericKey: true},
+		{Export: "posts", Key: "id", CreatedAt: true, UpdatedAt: true, SoftDelete: true},
+		{Export: "notes", Key: "id", NumericKey: true, UpdatedAt: true, SoftDelete: true},
+	}
+	if !reflect.DeepEqual(tables, want) {
+		t.Errorf("parseDrizzleTables() = %+v, want %+v", tables, want)
+	}
+}
+
+func repositoryTestIR(t *testing.T, schemas ...string) *ir.IR {
+	t.Helper()
+	i := &ir.IR{
+		BaseDir:    t.TempDir(),
+		Spec:       &parser.Spec{Name: "test", Version: "0.0.1"},
+		Components: make(map[string]*ir.Component),
+	}
+	for n, schema := range schemas {
+		id := []string{"postgres.primary", "postgres.analytics"}[n]
+		file := componentNameCamel(id) + ".schema.ts"
+		if err := os.WriteFile(filepath.Join(i.BaseDir, file), []byte(schema), 0644); err != nil {
+			t.Fatal(err)
+		}
+		i.Components[id] = &ir.Component{
+			ID:       id,
+			Kind:     ir.KindPostgres,
+			Postgres: &ir.PostgresSpec{Provider: "drizzle", Schema: "./" + file},
+		}
+	}
+	return i
+}
+
+func TestRepositoryGenerator_Generate(t *testing.T) {
+	// given
+	i := repositoryTestIR(t, "export const orders = pgTable('orders', {\n  id: serial('id').primaryKey(),\n});\n")
+
+	// when
+	output, err := NewRepositoryGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files[repositoryPath()]; !ok {
+		t.Errorf("missing %s", repositoryPath())
+	}
+	content := string(output.Files[postgresRepositoriesPath("postgres.primary")].Content)
+	for _, want := range []string{
+		"export type OrdersRepository = Repository<OrdersRow, NewOrdersRow, OrdersRow['id']>;",
+		"export function createPostgresPrimaryRepositories(db: DrizzleClient): PostgresPrimaryRepositories {",
+		"orders: createInMemoryRepository<OrdersRow, NewOrdersRow, 'id'>('id', sequence()),",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("repositories missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "randomUUID") {
+		t.Errorf("repositories import randomUUID without uuid keys:\n%s", content)
+	}
+}
+
+func TestRepositoryGenerator_Generate_Conventions(t *testing.T) {
+	// given
+	i := repositoryTestIR(t, "export const posts = pgTable('posts', {\n  id: uuid('id').primaryKey(),\n  ...timestamps,\n  ...softDelete,\n});\n")
+
+	// when
+	output, err := NewRepositoryGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files[postgresRepositoriesPath("postgres.primary")].Content)
+	for _, want := range []string{
+		"import { and, asc, eq, isNull } from 'drizzle-orm';",
+		"export const postsNotDeleted = isNull(schema.posts.deletedAt);",
+		"db.select().from(schema.posts).where(and(eq(schema.posts.id, id), postsNotDeleted)).limit(1);",
+		"db.select().from(schema.posts).where(postsNotDeleted).orderBy(asc(schema.posts.id))",
+		"db.update(schema.posts).set({ ...values, updatedAt: new Date() }).where(and(eq(schema.posts.id, id), postsNotDeleted)).returning();",
+		"db.update(schema.posts).set({ deletedAt: new Date() }).where(and(eq(schema.posts.id, id), postsNotDeleted)).returning();",
+		"createInMemoryRepository<PostsRow, NewPostsRow, 'id'>('id', () => randomUUID(), { createdAt: true, updatedAt: true, deletedAt: true }),",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("repositories missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "db.delete(") {
+		t.Errorf("soft-deleted rows should not be deleted:\n%s", content)
+	}
+	columns := string(output.Files[postgresColumnsPath()].Content)
+	for _, want := range []string{"export const timestamps = {", "export const softDelete = {", "deletedAt: timestamp('deleted_at', { withTimezone: true }),"} {
+		if !strings.Contains(columns, want) {
+			t.Errorf("columns missing %q:\n%s", want, columns)
+		}
+	}
+}
+
+func TestRepositoryGenerator_Generate_NoTables(t *testing.T) {
+	// given: a schema whose tables have no single-column key
+	i := repositoryTestIR(t, "export const logs = pgTable('logs', {\n  message: text('message'),\n});\n")
+
+	// when
+	output, err := NewRepositoryGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files[postgresColumnsPath()]; !ok || len(output.Files) != 1 {
+		t.Errorf("Generate() files = %d, want only %s", len(output.Files), postgresColumnsPath())
+	}
+	if hasRepositories(i) {
+		t.Error("hasRepositories() = true, want false")
+	}
+}
+
+func TestRepositoryContextField(t *testing.T) {
+	table := "export const t = pgTable('t', {\n  id: uuid('id').primaryKey(),\n});\n"
+	single := repositoryTestIR(t, table)
+	if got := repositoryContextField(single, single.Components["postgres.primary"]); got != "repositories" {
+		t.Errorf("repositoryContextField() = %q, want repositories", got)
+	}
+	multi := repositoryTestIR(t, table, table)
+	if got := repositoryContextField(multi, multi.Components["postgres.analytics"]); got != "analyticsRepositories" {
+		t.Errorf("repositoryContextField() = %q, want analyticsRepositories", got)
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// responseCachePath is the module routes with cache.server keep their
+// responses with.
+func responseCachePath() string {
+	return "src/components/response.cache.ts"
+}
+
+// responseCacheTestPath is the test of the in-memory response cache.
+func responseCacheTestPath() string {
+	return "src/components/response.cache.test.ts"
+}
+
+// cachesOnServer reports whether a usecase's route keeps its responses in
+// the server's cache. Catch-all routes return the usecase's own response and
+// async routes answer before their usecase runs, so neither has one to keep.
+func cachesOnServer(uc *ir.Component) bool {
+	s := uc.Usecase
+	return s != nil && s.Cache != nil && s.Cache.Server && s.Binding != nil && !s.Binding.IsCatchAll() && !isAsync(uc)
+}
+
+// serverCachesResponses reports whether any route of a server keeps its
+// responses in the server's cache.
+func serverCachesResponses(i *ir.IR, server *ir.Component) bool {
+	for _, uc := range i.UsecasesBoundTo(server.ID) {
+		if cachesOnServer(uc) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasServerCache reports whether any route keeps its responses in the
+// server's cache.
+func hasServerCache(i *ir.IR) bool {
+	for _, server := range i.HTTPServers() {
+		if serverCachesResponses(i, server) {
+			return true
+		}
+	}
+	return false
+}
+
+// usesRedis reports whether the service needs redis: for sessions or for
+// the server's cache.
+func usesRedis(i *ir.IR) bool {
+	return usesRedisSessions(i) || hasServerCache(i)
+}
+
+// invalidatesServerCache reports whether an entry point using the
+// databases pgs invalidates the server's cache: there is one, and it writes
+// through the repositories of one of them.
+func invalidatesServerCache(i *ir.IR, pgs []*ir.Component) bool {
+	return hasServerCache(i) && slices.ContainsFunc(pgs, func(pg *ir.Component) bool {
+		return len(drizzleTables(i, pg)) > 0
+	})
+}
+
+// writeResponseCacheInit writes how an entry point creates the redis
+// response cache its repositories invalidate.
+func writeResponseCacheInit(sb *strings.Builder, i *ir.IR) {
+	fmt.Fprintf(sb, "  const responseCache = createRedisResponseCache(process.env.%s ?? 'redis://localhost:6379');\n", i.EnvVar("REDIS_URL"))
+}
+
+// repositoriesArgs returns the arguments an entry point creates the
+// repositories of a database with: its client and, with a server cache,
+// how a write drops the cached responses reading the table.
+func repositoriesArgs(i *ir.IR, client string) string {
+	if !hasServerCache(i) {
+		return client
+	}
+	return client + ", (table) => responseCache.invalidate(table)"
+}
+
+// writeCachedUsecaseCall writes a route's call of its usecase that answers
+// from the server's cache while a response is fresh, and otherwise keeps the
+// result there for the cache's TTL. The key holds the request's URL and the
+// headers responses vary by, so it differs wherever the response may.
+func writeCachedUsecaseCall(sb *strings.Builder, uc *ir.Component, call string) {
+	cache := uc.Usecase.Cache
+	vary := make([]string, len(cache.Vary))
+	for n, header := range cache.Vary {
+		vary[n] = fmt.Sprintf("c.req.header(%s)", tsLiteral(header))
+	}
+	tables := make([]string, len(cache.Tables))
+	for n, table := range cache.Tables {
+		tables[n] = tsLiteral(table)
+	}
+
+	sb.WriteString("    // Answer from the server's cache while a response is fresh\n")
+	fmt.Fprintf(sb, "    const cacheKey = responseCacheKey(%s, c.req.url, [%s]);\n", tsLiteral(uc.ID), strings.Join(vary, ", "))
+	sb.WriteString("    const cached = await ctx.responseCache.get(cacheKey);\n")
+	sb.WriteString("    let result;\n")
+	sb.WriteString("    if (cached !== null) {\n")
+	sb.WriteString("      result = JSON.parse(cached);\n")
+	sb.WriteString("    } else {\n")
+	sb.WriteString("      try {\n")
+	fmt.Fprintf(sb, "        result = await %s;\n", call)
+	sb.WriteString("      } catch (error) {\n")
+	fmt.Fprintf(sb, "        return mockUnimplemented(error, %s);\n", mockResponseLiteral(uc))
+	sb.WriteString("      }\n")
+	fmt.Fprintf(sb, "      await ctx.responseCache.set(cacheKey, JSON.stringify(result), %d, [%s]);\n", cache.TTL, strings.Join(tables, ", "))
+	sb.WriteString("    }\n")
+}
+
+// generateResponseCacheModule generates the response cache interface, its
+// redis implementation the service runs with and its in-memory one for unit
+// tests.
+func generateResponseCacheModule() string {
+	var sb strings.Builder
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("// Responses of routes with cache.server. They are kept for the TTL of the\n")
+	sb.WriteString("// route's cache and dropped early when a repository writes a table the\n")
+	sb.WriteString("// route declares it reads.\n")
+	sb.WriteString("import Redis from 'ioredis';\n\n")
+
+	sb.WriteString("/** Keeps the responses of routes with cache.server. */\n")
+	sb.WriteString("export interface ResponseCache {\n")
+	sb.WriteString("  /** Returns the body kept under key, or null if there is none or it expired. */\n")
+	sb.WriteString("  get(key: string): Promise<string | null>;\n")
+	sb.WriteString("  /** Keeps body under key for ttl seconds, or until one of tables is written. */\n")
+	sb.WriteString("  set(key: string, body: string, ttl: number, tables: readonly string[]): Promise<void>;\n")
+	sb.WriteString("  /** Drops the responses that read table. */\n")
+	sb.WriteString("  invalidate(table: string): Promise<void>;\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/**\n")
+	sb.WriteString(" * Returns the key of a response: the usecase answering it, the request's\n")
+	sb.WriteString(" * URL and the values of the request headers responses vary by, in order.\n")
+	sb.WriteString(" */\n")
+	sb.WriteString("export function responseCacheKey(usecase: string, url: string, vary: readonly (string | undefined)[]): string {\n")
+	sb.WriteString("  return JSON.stringify([usecase, url, ...vary.map((value) => value ?? null)]);\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("// Namespace keys so the cache can share a redis with sessions\n")
+	sb.WriteString("const keyPrefix = 'response:';\n")
+	sb.WriteString("const tablePrefix = 'response-table:';\n\n")
+
+	sb.WriteString("/**\n")
+	sb.WriteString(" * Creates a cache that keeps responses in the redis at url. Each table has\n")
+	sb.WriteString(" * a set of the keys of the responses reading it, which invalidate empties.\n")
+	sb.WriteString(" */\n")
+	sb.WriteString("export function createRedisResponseCache(url: string): ResponseCache {\n")
+	sb.WriteString("  const redis = new Redis(url);\n")
+	sb.WriteString("  return {\n")
+	sb.WriteString("    get: (key) => redis.get(keyPrefix + key),\n")
+	sb.WriteString("    async set(key, body, ttl, tables) {\n")
+	sb.WriteString("      const multi = redis.multi().set(keyPrefix + key, body, 'EX', ttl);\n")
+	sb.WriteString("      for (const table of tables) {\n")
+	sb.WriteString("        // A table's set lives as long as its longest-lived response\n")
+	sb.WriteString("        multi.sadd(tablePrefix + table, keyPrefix + key);\n")
+	sb.WriteString("        multi.expire(tablePrefix + table, ttl, 'NX');\n")
+	sb.WriteString("        multi.expire(tablePrefix + table, ttl, 'GT');\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      await multi.exec();\n")
+	sb.WriteString("    },\n")
+	sb.WriteString("    async invalidate(table) {\n")
+	sb.WriteString("      // Take the set in one step, so keys added meanwhile start a new one\n")
+	sb.WriteString("      const replies = await redis.multi().smembers(tablePrefix + table).del(tablePrefix + table).exec();\n")
+	sb.WriteString("      const keys = (replies?.[0]?.[1] ?? []) as string[];\n")
+	sb.WriteString("      if (keys.length > 0) {\n")
+	sb.WriteString("        await redis.del(...keys);\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("    },\n")
+	sb.WriteString("  };\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/** Creates a cache that keeps responses in memory, for unit tests. */\n")
+	sb.WriteString("export function createInMemoryResponseCache(): ResponseCache {\n")
+	sb.WriteString("  const entries = new Map<string, { body: string; expires: number; tables: readonly string[] }>();\n")
+	sb.WriteString("  return {\n")
+	sb.WriteString("    async get(key) {\n")
+	sb.WriteString("      const entry = entries.get(key);\n")
+	sb.WriteString("      if (!entry || entry.expires <= Date.now()) {\n")
+	sb.WriteString("        entries.delete(key);\n")
+	sb.WriteString("        return null;\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      return entry.body;\n")
+	sb.WriteString("    },\n")
+	sb.WriteString("    async set(key, body, ttl, tables) {\n")
+	sb.WriteString("      entries.set(key, { body, expires: Date.now() + ttl * 1000, tables });\n")
+	sb.WriteString("    },\n")
+	sb.WriteString("    async invalidate(table) {\n")
+	sb.WriteString("      for (const [key, entry] of entries) {\n")
+	sb.WriteString("        if (entry.tables.includes(table)) {\n")
+	sb.WriteString("          entries.delete(key);\n")
+	sb.WriteString("        }\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("    },\n")
+	sb.WriteString("  };\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// generateResponseCacheTest generates the test of the in-memory response
+// cache.
+func generateResponseCacheTest() string {
+	var sb strings.Builder
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("import { describe, it, expect, vi, afterEach } from 'vitest';\n")
+	sb.WriteString("import { createInMemoryResponseCache, responseCacheKey } from './response.cache';\n\n")
+
+	sb.WriteString("describe('createInMemoryResponseCache', () => {\n")
+	sb.WriteString("  afterEach(() => {\n")
+	sb.WriteString("    vi.useRealTimers();\n")
+	sb.WriteString("  });\n\n")
+
+	sb.WriteString("  it('should keep a response for its ttl', async () => {\n")
+	sb.WriteString("    // given\n")
+	sb.WriteString("    vi.useFakeTimers();\n")
+	sb.WriteString("    const cache = createInMemoryResponseCache();\n")
+	sb.WriteString("    await cache.set('key', '{\"id\":1}', 60, []);\n\n")
+	sb.WriteString("    // when\n")
+	sb.WriteString("    const fresh = await cache.get('key');\n")
+	sb.WriteString("    vi.advanceTimersByTime(60_000);\n")
+	sb.WriteString("    const expired = await cache.get('key');\n\n")
+	sb.WriteString("    // then\n")
+	sb.WriteString("    expect(fresh).toBe('{\"id\":1}');\n")
+	sb.WriteString("    expect(expired).toBeNull();\n")
+	sb.WriteString("  });\n\n")
+
+	sb.WriteString("  it('should drop the responses that read a written table', async () => {\n")
+	sb.WriteString("    // given\n")
+	sb.WriteString("    const cache = createInMemoryResponseCache();\n")
+	sb.WriteString("    await cache.set('products', '[]', 60, ['products']);\n")
+	sb.WriteString("    await cache.set('orders', '[]', 60, ['orders', 'products']);\n")
+	sb.WriteString("    await cache.set('users', '[]', 60, ['users']);\n\n")
+	sb.WriteString("    // when\n")
+	sb.WriteString("    await cache.invalidate('products');\n\n")
+	sb.WriteString("    // then\n")
+	sb.WriteString("    expect(await cache.get('products')).toBeNull();\n")
+	sb.WriteString("    expect(await cache.get('orders')).toBeNull();\n")
+	sb.WriteString("    expect(await cache.get('users')).toBe('[]');\n")
+	sb.WriteString("  });\n")
+	sb.WriteString("});\n\n")
+
+	sb.WriteString("describe('responseCacheKey', () => {\n")
+	sb.WriteString("  it('should differ by usecase, URL and varied headers', () => {\n")
+	sb.WriteString("    // given\n")
+	sb.WriteString("    const key = responseCacheKey('usecase.list', 'http://localhost/items', ['en']);\n\n")
+	sb.WriteString("    // then\n")
+	sb.WriteString("    expect(responseCacheKey('usecase.list', 'http://localhost/items', ['en'])).toBe(key);\n")
+	sb.WriteString("    expect(responseCacheKey('usecase.other', 'http://localhost/items', ['en'])).not.toBe(key);\n")
+	sb.WriteString("    expect(responseCacheKey('usecase.list', 'http://localhost/items?page=2', ['en'])).not.toBe(key);\n")
+	sb.WriteString("    expect(responseCacheKey('usecase.list', 'http://localhost/items', ['de'])).not.toBe(key);\n")
+	sb.WriteString("    expect(responseCacheKey('usecase.list', 'http://localhost/items', [undefined])).not.toBe(key);\n")
+	sb.WriteString("  });\n")
+	sb.WriteString("});\n")
+
+	return sb.String()
+}
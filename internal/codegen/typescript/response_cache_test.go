@@ -0,0 +1,220 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// serverCacheTestIR returns a service whose GET /orders keeps its responses
+// in the server's cache until the orders table is written.
+func serverCacheTestIR(t *testing.T) *ir.IR {
+	t.Helper()
+	i := repositoryTestIR(t, "export const orders = pgTable('orders', {\n  id: serial('id').primaryKey(),\n});\n")
+	i.Components["http.server.api"] = &ir.Component{
+		ID:   "http.server.api",
+		Kind: ir.KindHTTPServer,
+		HTTPServer: &ir.HTTPServerSpec{
+			Framework: "hono",
+			Port:      3000,
+			DependsOn: []string{"postgres.primary"},
+		},
+	}
+	i.Components["usecase.list-orders"] = &ir.Component{
+		ID:   "usecase.list-orders",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			Goal:    "List orders",
+			Binding: &ir.Binding{ServerID: "http.server.api", Method: "GET", Path: "/orders"},
+			Cache: &ir.CacheSpec{
+				TTL:        300,
+				Vary:       []string{"Accept-Language"},
+				Visibility: ir.CachePublic,
+				Server:     true,
+				Tables:     []string{"orders"},
+			},
+		},
+	}
+	return i
+}
+
+func TestHonoServerGenerator_Generate_ServerCache(t *testing.T) {
+	// given
+	i := serverCacheTestIR(t)
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	cache, ok := output.Files[responseCachePath()]
+	if !ok {
+		t.Fatalf("missing %s", responseCachePath())
+	}
+	for _, want := range []string{
+		"export function createRedisResponseCache(url: string): ResponseCache {\n",
+		"export function createInMemoryResponseCache(): ResponseCache {\n",
+	} {
+		if !strings.Contains(string(cache.Content), want) {
+			t.Errorf("response cache missing %q\n%s", want, cache.Content)
+		}
+	}
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	for _, want := range []string{
+		"import { responseCacheKey } from './response.cache';\n",
+		"    const cacheKey = responseCacheKey('usecase.list-orders', c.req.url, [c.req.header('Accept-Language')]);\n" +
+			"    const cached = await ctx.responseCache.get(cacheKey);\n" +
+			"    let result;\n" +
+			"    if (cached !== null) {\n" +
+			"      result = JSON.parse(cached);\n" +
+			"    } else {\n",
+		"      await ctx.responseCache.set(cacheKey, JSON.stringify(result), 300, ['orders']);\n" +
+			"    }\n" +
+			"    c.header('Cache-Control', 'public, max-age=300');\n",
+	} {
+		if !strings.Contains(server, want) {
+			t.Errorf("server file missing %q\n%s", want, server)
+		}
+	}
+	index := string(output.Files["src/index.ts"].Content)
+	for _, want := range []string{
+		"import { createRedisResponseCache } from './components/response.cache';\n",
+		"  const responseCache = createRedisResponseCache(process.env.REDIS_URL ?? 'redis://localhost:6379');\n",
+		"  const postgresPrimaryRepositories = createPostgresPrimaryRepositories(postgresPrimaryClient, (table) => responseCache.invalidate(table));\n",
+		"    responseCache,\n",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index.ts missing %q\n%s", want, index)
+		}
+	}
+}
+
+func TestHonoServerGenerator_Generate_NoServerCache(t *testing.T) {
+	// given: the route is cached by clients only
+	i := serverCacheTestIR(t)
+	i.Components["usecase.list-orders"].Usecase.Cache = &ir.CacheSpec{TTL: 300, Visibility: ir.CachePublic}
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files[responseCachePath()]; ok {
+		t.Errorf("unexpected %s", responseCachePath())
+	}
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	if strings.Contains(server, "responseCache") {
+		t.Errorf("server file uses the server cache\n%s", server)
+	}
+}
+
+func TestRepositoryGenerator_Generate_ServerCache(t *testing.T) {
+	// given
+	i := serverCacheTestIR(t)
+
+	// when
+	output, err := NewRepositoryGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files[postgresRepositoriesPath("postgres.primary")].Content)
+	for _, want := range []string{
+		"export function createPostgresPrimaryRepositories(db: DrizzleClient, onWrite: (table: string) => Promise<void>): PostgresPrimaryRepositories {\n",
+		"        const [row] = await db.insert(schema.orders).values(values).returning();\n" +
+			"        await onWrite('orders');\n",
+		"        const [row] = await db.update(schema.orders).set(values).where(eq(schema.orders.id, id)).returning();\n" +
+			"        if (row) {\n" +
+			"          await onWrite('orders');\n" +
+			"        }\n",
+		"        if (rows.length > 0) {\n" +
+			"          await onWrite('orders');\n" +
+			"        }\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("repositories missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestContextGenerator_Generate_ServerCache(t *testing.T) {
+	// given
+	i := serverCacheTestIR(t)
+
+	// when
+	output, err := NewContextGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["src/components/http-server-api.context.ts"].Content)
+	for _, want := range []string{
+		"import type { ResponseCache } from './response.cache';\n",
+		"  responseCache: ResponseCache;\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("context missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestTestGenerator_ServerCache(t *testing.T) {
+	// given
+	i := serverCacheTestIR(t)
+
+	// when
+	output, err := NewTestGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files[responseCacheTestPath()]; !ok {
+		t.Errorf("missing %s", responseCacheTestPath())
+	}
+	content := string(output.Files["src/components/http-server-api.server.test.ts"].Content)
+	for _, want := range []string{
+		"import { createInMemoryResponseCache, responseCacheKey } from './response.cache';\n",
+		"  it('should answer GET /orders from the server cache', async () => {\n",
+		"    const key = responseCacheKey('usecase.list-orders', 'http://localhost/orders', [undefined]);\n" +
+			"    await mockDeps.responseCache.set(key, JSON.stringify(cached), 300, []);\n",
+		"    expect(await res.json()).toEqual(cached);\n",
+		"    responseCache: createInMemoryResponseCache(),\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("server test missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestDockerGenerator_Generate_ServerCache(t *testing.T) {
+	// given
+	i := serverCacheTestIR(t)
+
+	// when
+	output, err := NewDockerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	compose := string(output.Files["docker-compose.yml"].Content)
+	for _, want := range []string{
+		"  redis:\n    image: redis:7-alpine\n",
+		"      REDIS_URL: redis://redis:6379\n",
+	} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yml missing %q\n%s", want, compose)
+		}
+	}
+}
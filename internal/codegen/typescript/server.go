@@ -7,7 +7,7 @@ package typescript
 import (
 	"fmt"
 	"regexp"
-	"sort"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -30,68 +30,109 @@ func (g *HonoServerGenerator) Name() string {
 
 // Generate produces Hono server code from the IR.
 func (g *HonoServerGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
-	output := codegen.NewOutput()
-
-	// Generate server files for each http.server component
-	for _, comp := range i.Components {
-		if comp.Kind != ir.KindHTTPServer || comp.HTTPServer == nil {
-			continue
-		}
+	return codegen.GenerateComponents(g, i)
+}
 
-		// Generate the server file
-		serverCode := g.generateServer(i, comp)
-		output.AddComponentFile(serverSourcePath(comp.ID), []byte(serverCode), comp.ID)
+// GenerateShared produces the main index.ts that wires everything and the
+// postgres client type.
+func (g *HonoServerGenerator) GenerateShared(i *ir.IR) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	output.AddFile("src/index.ts", []byte(g.generateIndex(i)))
+	output.AddFile(postgresClientPath(), []byte(generatePostgresClientTypes(i)))
+	if httpFixturesMode(i) != "" {
+		output.AddFile(httpFixturesPath, []byte(generateHTTPFixtures(i)))
 	}
+	if hasTLSServers(i) {
+		output.AddFile(tlsPath, []byte(generateTLSModule()))
+	}
+	if hasAsyncUsecases(i) {
+		output.AddFile(usecaseJobsPath(), []byte(generateJobsModule()))
+		output.AddFile(usecaseJobsSchemaPath(), []byte(generateJobsSchema()))
+	}
+	if hasServerCache(i) {
+		output.AddFile(responseCachePath(), []byte(generateResponseCacheModule()))
+	}
+	return output, nil
+}
 
-	// Generate main index.ts that wires everything (shared file)
-	indexCode := g.generateIndex(i)
-	output.AddFile("src/index.ts", []byte(indexCode))
-
-	// Generate middleware files
-	for _, comp := range i.Components {
-		if comp.Kind != ir.KindMiddleware || comp.Middleware == nil {
-			continue
+// GenerateComponent produces the files of a server, middleware or postgres
+// component.
+func (g *HonoServerGenerator) GenerateComponent(i *ir.IR, comp *ir.Component) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	switch {
+	case comp.Kind == ir.KindHTTPServer && comp.HTTPServer != nil:
+		output.AddComponentFile(serverSourcePath(comp.ID), []byte(g.generateServer(i, comp)), comp.ID)
+	case comp.Kind == ir.KindMiddleware && comp.Middleware != nil:
+		g.generateMiddlewareFiles(output, i, comp)
+	case comp.Kind == ir.KindPostgres && comp.Postgres != nil:
+		if err := checkDatabaseProvider(comp); err != nil {
+			return nil, err
 		}
-
-		mwCode := g.generateMiddleware(comp)
-		if mwCode != "" {
-			output.AddComponentFile(middlewareSourcePath(comp.ID), []byte(mwCode), comp.ID)
-		}
-
-		// Generate additional files for better-auth
-		if comp.Middleware.Provider == "better-auth" {
-			// Generate auth schema
-			schemaCode := g.generateBetterAuthSchema()
-			output.AddComponentFile(middlewareSchemaPath(comp.ID), []byte(schemaCode), comp.ID)
+		if err := checkJobsDatabase(i, comp); err != nil {
+			return nil, err
 		}
+		output.AddComponentFile(postgresSourcePath(comp.ID), []byte(g.generatePostgresClient(i, comp)), comp.ID)
 	}
+	return output, nil
+}
 
-	// Generate postgres client if needed
-	for _, comp := range i.Components {
-		if comp.Kind != ir.KindPostgres || comp.Postgres == nil {
-			continue
-		}
-
-		pgCode := g.generatePostgresClient(comp)
-		output.AddComponentFile(postgresSourcePath(comp.ID), []byte(pgCode), comp.ID)
+// generateMiddlewareFiles adds the files of a middleware component.
+func (g *HonoServerGenerator) generateMiddlewareFiles(output *codegen.Output, i *ir.IR, comp *ir.Component) {
+	mwCode := g.generateMiddleware(comp)
+	if mwCode != "" {
+		output.AddComponentFile(middlewareSourcePath(comp.ID), []byte(mwCode), comp.ID)
 	}
 
-	// Generate postgres client type file (shared)
-	output.AddFile(postgresClientPath(), []byte(postgresClientType))
+	if p := middlewareProviderFor(comp); p != nil {
+		p.AddFiles(output, i, comp)
+	}
+}
 
-	return output, nil
+// writeAPIDocsRoutes registers the API reference page and the document it
+// renders. Like /health they bypass the server's middleware.
+func writeAPIDocsRoutes(sb *strings.Builder, docs *ir.APIDocsSpec) {
+	indent := "  "
+	sb.WriteString("  // API reference\n")
+	if !docs.Production {
+		sb.WriteString("  if (process.env.NODE_ENV !== 'production') {\n")
+		indent = "    "
+	}
+	fmt.Fprintf(sb, "%sapp.get('%s', (c) => c.html(apiDocsPage));\n", indent, docs.Path)
+	fmt.Fprintf(sb, "%sapp.get('%s/openapi.yaml', (c) => c.body(openapiDocument, 200, { 'Content-Type': 'application/yaml' }));\n", indent, docs.Path)
+	if !docs.Production {
+		sb.WriteString("  }\n")
+	}
+	sb.WriteString("\n")
 }
 
 func (g *HonoServerGenerator) generateServer(i *ir.IR, server *ir.Component) string {
 	var sb strings.Builder
 
-	sb.WriteString("// Generated by OpenBoundary - DO NOT EDIT\n")
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString(componentHeader(server))
 	sb.WriteString("import { Hono } from 'hono';\n")
 
 	// Collect usecases bound to this server
-	usecases := getUsecasesBoundToServer(i, server.ID)
+	usecases := i.UsecasesBoundTo(server.ID)
+	if usesETags(usecases) {
+		sb.WriteString("import { createHash } from 'crypto';\n")
+	}
 	middlewareRefs := collectServerMiddleware(i, server)
 
+	// Import the limit middleware the server or its routes use
+	bodyLimits, timeouts := serverLimitsUsed(server, usecases)
+	if bodyLimits {
+		sb.WriteString("import { bodyLimit } from 'hono/body-limit';\n")
+	}
+	if timeouts {
+		sb.WriteString("import { timeout } from 'hono/timeout';\n")
+	}
+
+	// Import the metrics of the server's routes
+	if server.HTTPServer.Metrics != nil {
+		sb.WriteString(fmt.Sprintf("import { metricsMiddleware, registry } from './%s.metrics';\n", componentIDSlug(server.ID)))
+	}
+
 	// Import context type (colocated with server)
 	sb.WriteString(fmt.Sprintf("import type { ServerContext } from './%s.context';\n", componentIDSlug(server.ID)))
 
@@ -106,39 +147,121 @@ func (g *HonoServerGenerator) generateServer(i *ir.IR, server *ir.Component) str
 		sb.WriteString(fmt.Sprintf("import { %s } from './%s.usecase';\n",
 			toFunctionName(uc.ID), componentIDSlug(uc.ID)))
 	}
+	if slices.ContainsFunc(usecases, isAsync) {
+		sb.WriteString("import { getJob, startJob } from './usecase.jobs';\n")
+	}
+	if slices.ContainsFunc(usecases, cachesOnServer) {
+		sb.WriteString("import { responseCacheKey } from './response.cache';\n")
+	}
+
+	// Import RBAC constants for usecase authorization checks
+	for _, mw := range serverAuthorizers(i, server) {
+		sb.WriteString(fmt.Sprintf("import * as %s from './%s.middleware.rbac';\n",
+			rbacModuleAlias(mw), componentIDSlug(mw.ID)))
+	}
+
+	// Import the API reference
+	if server.HTTPServer.APIDocs != nil {
+		sb.WriteString(fmt.Sprintf("import { apiDocsPage, openapiDocument } from './%s.docs';\n", componentIDSlug(server.ID)))
+	}
+
+	// Import policy listings for admin routes
+	adminMiddleware := policyAdminMiddleware(i, middlewareRefs)
+	for _, mw := range adminMiddleware {
+		sb.WriteString(fmt.Sprintf("import { getEffectivePolicies as %sPolicies } from './%s.middleware.enforcer';\n",
+			toCamelCase(mw.ID), componentIDSlug(mw.ID)))
+	}
 
 	sb.WriteString("\n")
 	// Middleware matrix (route -> requirements)
-	g.writeMiddlewareMatrix(&sb, server, usecases, middlewareRefs)
+	g.writeMiddlewareMatrix(&sb, server, usecases, middlewareRefs, adminMiddleware)
+
+	if len(mockedUsecases(usecases)) > 0 {
+		writeMockUnimplemented(&sb, i)
+	}
 
 	// Define Hono env type
 	sb.WriteString("type Env = {\n")
 	sb.WriteString("  Variables: ServerContext;\n")
 	sb.WriteString("};\n\n")
 
+	if usesETags(usecases) {
+		writeETagHelpers(&sb, usesIfMatch(usecases))
+	}
+
 	// Generate createApp function
 	createAppName := "create" + toPascalCase(server.ID) + "App"
 	sb.WriteString(fmt.Sprintf("/**\n * Creates the %s Hono application.\n", server.ID))
 	sb.WriteString(" * @param ctx - The server context with dependencies\n */\n")
 	sb.WriteString(fmt.Sprintf("export function %s(ctx: ServerContext): Hono<Env> {\n", createAppName))
-	sb.WriteString("  const app = new Hono<Env>();\n\n")
+	if base := server.HTTPServer.BasePath; base != "" {
+		fmt.Fprintf(&sb, "  const app = new Hono<Env>().basePath('%s');\n\n", base)
+	} else {
+		sb.WriteString("  const app = new Hono<Env>();\n\n")
+	}
+
+	if deprecated := i.DeprecatedComponentsRunBy(server); len(deprecated) > 0 {
+		sb.WriteString("  // Deprecated components\n")
+		sb.WriteString("  if (process.env.NODE_ENV !== 'production') {\n")
+		for _, comp := range deprecated {
+			fmt.Fprintf(&sb, "    console.warn(%s);\n", strconv.Quote("[deprecated] "+comp.DeprecationNotice()))
+		}
+		sb.WriteString("  }\n\n")
+	}
 
 	// Apply base context middleware
 	sb.WriteString("  // Set base context from dependencies\n")
 	sb.WriteString("  app.use('*', async (c, next) => {\n")
 
 	// Set context fields from dependencies
-	if len(getServerPostgresDependencies(i, server)) > 0 {
-		sb.WriteString("    c.set('db', ctx.db);\n")
+	for _, dep := range getServerPostgresDependencies(i, server) {
+		field := postgresContextField(i, dep)
+		sb.WriteString(fmt.Sprintf("    c.set('%s', ctx.%s);\n", field, field))
+		if len(drizzleTables(i, dep)) > 0 {
+			field := repositoryContextField(i, dep)
+			sb.WriteString(fmt.Sprintf("    c.set('%s', ctx.%s);\n", field, field))
+		}
+	}
+	for _, wh := range serverWebhooks(i, server) {
+		field := webhookContextField(wh)
+		sb.WriteString(fmt.Sprintf("    c.set('%s', ctx.%s);\n", field, field))
+	}
+	for _, client := range serverHTTPClients(i, server) {
+		field := httpClientContextField(client)
+		sb.WriteString(fmt.Sprintf("    c.set('%s', ctx.%s);\n", field, field))
 	}
 
 	sb.WriteString("    await next();\n")
 	sb.WriteString("  });\n\n")
 
+	// Record every route, including the time its middleware and limits take
+	if server.HTTPServer.Metrics != nil {
+		sb.WriteString("  // Record the duration and status of every route\n")
+		sb.WriteString("  app.use('*', metricsMiddleware);\n\n")
+	}
+
+	// Apply the server's limits to every route
+	if mws := limitMiddleware(server.HTTPServer.Limits); len(mws) > 0 {
+		sb.WriteString("  // Limits of every route; routes may lower them\n")
+		for _, mw := range mws {
+			fmt.Fprintf(&sb, "  app.use('*', %s);\n", mw)
+		}
+		sb.WriteString("\n")
+	}
+
 	// Generate health endpoint for readiness checks and E2E tests.
 	sb.WriteString("  // Health check\n")
 	sb.WriteString("  app.get('/health', (c) => c.json({ status: 'ok' }));\n\n")
 
+	if metrics := server.HTTPServer.Metrics; metrics != nil {
+		sb.WriteString("  // Prometheus metrics\n")
+		fmt.Fprintf(&sb, "  app.get('%s', async (c) => c.body(await registry.metrics(), 200, { 'Content-Type': registry.contentType }));\n\n", metrics.Path)
+	}
+
+	if docs := server.HTTPServer.APIDocs; docs != nil {
+		writeAPIDocsRoutes(&sb, docs)
+	}
+
 	// Apply server-level middleware only when required by the route
 	if len(middlewareRefs) > 0 {
 		sb.WriteString("  // Apply server-level middleware only when required by the route\n")
@@ -157,6 +280,16 @@ func (g *HonoServerGenerator) generateServer(i *ir.IR, server *ir.Component) str
 	sb.WriteString("  // Route handlers\n")
 	for _, uc := range usecases {
 		g.generateRoute(&sb, i, uc, server)
+		if isAsync(uc) {
+			g.writeJobStatusRoute(&sb, i, uc, server)
+		}
+	}
+
+	// Admin routes run behind the casbin middleware and the middleware it depends on
+	for _, mw := range adminMiddleware {
+		fmt.Fprintf(&sb, "\n  // %s - effective policies\n", mw.ID)
+		fmt.Fprintf(&sb, "  app.get('%s', async (c) => c.json(await %sPolicies()));\n",
+			convertPathParams(mw.Middleware.AdminRoute), toCamelCase(mw.ID))
 	}
 
 	sb.WriteString("\n  return app;\n")
@@ -180,21 +313,77 @@ func (g *HonoServerGenerator) generateRoute(sb *strings.Builder, i *ir.IR, uc *i
 
 	fmt.Fprintf(sb, "\n  // %s - %s\n", uc.ID, uc.Usecase.Goal)
 
-	// Routes rely on the middleware matrix for execution
-	fmt.Fprintf(sb, "  app.%s('%s', async (c) => {\n", method, honoPath)
+	// Routes rely on the middleware matrix for execution; their own limits
+	// run first
+	var handlers string
+	for _, mw := range limitMiddleware(uc.Usecase.Limits) {
+		handlers += mw + ", "
+	}
+	// Conditional updates of a path run one at a time, from their If-Match
+	// check to their answer
+	closing := "  });\n"
+	if requiresIfMatch(uc) {
+		fmt.Fprintf(sb, "  app.%s('%s', %sasync (c) => withPathLock(c.req.path, async () => {\n", method, honoPath, handlers)
+		closing = "  }));\n"
+	} else {
+		fmt.Fprintf(sb, "  app.%s('%s', %sasync (c) => {\n", method, honoPath, handlers)
+	}
+
+	// Check authorization before anything reaches the usecase
+	writeAuthorizationCheck(sb, i, uc, server)
+
+	if requiresIfMatch(uc) {
+		writeIfMatchCheck(sb)
+	}
+
+	// Read the input as input_mapping declares
+	if len(uc.Usecase.InputMapping) > 0 && !binding.IsCatchAll() {
+		mapped := mappedInputFields(i, uc)
+		if readsBody(mapped) {
+			sb.WriteString("    const body = await c.req.json();\n")
+		}
+		sb.WriteString("    const input = {\n")
+		for _, f := range mapped {
+			fmt.Fprintf(sb, "      %s: %s,\n", f.Field, f.expr())
+		}
+		sb.WriteString("    };\n\n")
+		g.writeRouteContext(sb, i, uc, server)
+		writeRouteResult(sb, i, uc, server, funcName+"(input, context)")
+		sb.WriteString(closing)
+		return
+	}
 
 	// Extract path parameters
-	pathParams := extractPathParams(path)
+	pathParams := uc.Usecase.Binding.PathParams()
 	if len(pathParams) > 0 {
 		for _, param := range pathParams {
 			fmt.Fprintf(sb, "    const %s = c.req.param('%s');\n", param, param)
 		}
 	}
 
+	// Catch-all routes pass the request through and return the usecase's response
+	if binding.IsCatchAll() {
+		sb.WriteString("    const input = {\n")
+		sb.WriteString("      request: c.req.raw,\n")
+		for _, param := range pathParams {
+			fmt.Fprintf(sb, "      %s,\n", param)
+		}
+		sb.WriteString("    };\n\n")
+		g.writeRouteContext(sb, i, uc, server)
+		fmt.Fprintf(sb, "    return %s(input, context);\n", funcName)
+		sb.WriteString(closing)
+		return
+	}
+
 	// Parse request body for methods that have one
 	if method == "post" || method == "put" || method == "patch" {
 		sb.WriteString("    const body = await c.req.json();\n")
 	}
+	if isBulk(uc) {
+		sb.WriteString("    if (!Array.isArray(body)) {\n")
+		sb.WriteString("      return c.json({ error: 'Request body must be an array' }, 400);\n")
+		sb.WriteString("    }\n")
+	}
 
 	// Determine if we need an input object
 	hasBody := method == "post" || method == "put" || method == "patch"
@@ -203,42 +392,76 @@ func (g *HonoServerGenerator) generateRoute(sb *strings.Builder, i *ir.IR, uc *i
 	// Build input object (only if needed)
 	if hasInput {
 		sb.WriteString("    const input = {\n")
+		if isBulk(uc) {
+			sb.WriteString("      items: body,\n")
+		}
 		for _, param := range pathParams {
 			fmt.Fprintf(sb, "      %s,\n", param)
 		}
-		if hasBody {
+		if hasBody && !isBulk(uc) {
 			sb.WriteString("      ...body,\n")
 		}
 		sb.WriteString("    };\n\n")
 	}
 
 	// Build context for usecase
-	contextFields := contextFieldsForUsecase(i, uc, server)
-	if len(contextFields) == 0 {
-		sb.WriteString("    const context = {};\n\n")
-	} else {
-		sb.WriteString("    const context = {\n")
-		for _, field := range contextFields {
-			switch field {
-			case "db":
-				sb.WriteString("      db: c.get('db'),\n")
-			case "auth":
-				sb.WriteString("      auth: c.get('auth'),\n")
-			case "enforcer":
-				sb.WriteString("      enforcer: c.get('enforcer'),\n")
-			}
-		}
-		sb.WriteString("    };\n\n")
-	}
+	g.writeRouteContext(sb, i, uc, server)
 
 	// Call usecase
 	if hasInput {
-		fmt.Fprintf(sb, "    const result = await %s(input, context);\n", funcName)
+		writeRouteResult(sb, i, uc, server, funcName+"(input, context)")
+	} else {
+		writeRouteResult(sb, i, uc, server, funcName+"(undefined as void, context)")
+	}
+	sb.WriteString(closing)
+}
+
+// writeAuthorizationCheck writes the check that answers 403 unless the
+// caller has the roles and permissions a usecase's authorization requires.
+func writeAuthorizationCheck(sb *strings.Builder, i *ir.IR, uc *ir.Component, server *ir.Component) {
+	mw := usecaseAuthorizer(i, uc, server)
+	if mw == nil {
+		return
+	}
+	sb.WriteString("    // Any of the roles and all of the permissions\n")
+	fmt.Fprintf(sb, "    const authorized = await %s.isAuthorized(c.get('enforcer'), c.get('auth')?.user?.id, %s);\n",
+		rbacModuleAlias(mw), authorizationArgument(uc, mw, "    "))
+	sb.WriteString("    if (!authorized) {\n")
+	sb.WriteString("      return c.json({ error: 'Forbidden' }, 403);\n")
+	sb.WriteString("    }\n\n")
+}
+
+// writeRouteResult writes how a route calls its usecase and answers with
+// the result, or with the job running it for async usecases.
+func writeRouteResult(sb *strings.Builder, i *ir.IR, uc *ir.Component, server *ir.Component, call string) {
+	if isAsync(uc) {
+		writeStartJob(sb, i, uc, server, call)
+		return
+	}
+	if cachesOnServer(uc) {
+		writeCachedUsecaseCall(sb, uc, call)
 	} else {
-		fmt.Fprintf(sb, "    const result = await %s(undefined as void, context);\n", funcName)
+		writeUsecaseCall(sb, uc, call)
 	}
+	writeRouteResponse(sb, strings.ToLower(uc.Usecase.Binding.Method), uc.Usecase)
+}
 
-	// Return response
+// writeRouteResponse writes how a route answers with the usecase's result,
+// with the caching headers of its cache directive and its ETag, if any.
+func writeRouteResponse(sb *strings.Builder, method string, s *ir.UsecaseSpec) {
+	if cache := s.Cache; cache != nil {
+		fmt.Fprintf(sb, "    c.header('Cache-Control', %s);\n", tsLiteral(cache.CacheControl()))
+		if len(cache.Vary) > 0 {
+			fmt.Fprintf(sb, "    c.header('Vary', %s);\n", tsLiteral(strings.Join(cache.Vary, ", ")))
+		}
+	}
+	if s.Concurrency == ir.ConcurrencyETag && method == "get" {
+		writeETagResponse(sb)
+	}
+	if s.Bulk && method != "get" && method != "delete" {
+		fmt.Fprintf(sb, "    return c.json(result, result.failed > 0 ? 207 : %s);\n", bulkSuccessStatus(method))
+		return
+	}
 	switch method {
 	case "post":
 		sb.WriteString("    return c.json(result, 201);\n")
@@ -247,23 +470,35 @@ func (g *HonoServerGenerator) generateRoute(sb *strings.Builder, i *ir.IR, uc *i
 	default:
 		sb.WriteString("    return c.json(result);\n")
 	}
+}
 
-	sb.WriteString("  });\n")
+// writeRouteContext writes the context a route passes to its usecase.
+func (g *HonoServerGenerator) writeRouteContext(sb *strings.Builder, i *ir.IR, uc *ir.Component, server *ir.Component) {
+	contextFields := contextFieldsForUsecase(i, uc, server)
+	if len(contextFields) == 0 {
+		sb.WriteString("    const context = {};\n\n")
+		return
+	}
+	// The route's middleware has set these, as its context type declares
+	narrowed := middlewareFieldsForUsecase(i, uc, server)
+	sb.WriteString("    const context = {\n")
+	for _, field := range contextFields {
+		if stringInSlice(field, narrowed) {
+			fmt.Fprintf(sb, "      %s: c.get('%s')!,\n", field, field)
+		} else {
+			fmt.Fprintf(sb, "      %s: c.get('%s'),\n", field, field)
+		}
+	}
+	sb.WriteString("    };\n\n")
 }
 
 func (g *HonoServerGenerator) generateIndex(i *ir.IR) string {
 	var sb strings.Builder
 
 	// Check if we have better-auth middleware
-	var betterAuthMw *ir.Component
-	for _, comp := range i.Components {
-		if comp.Kind == ir.KindMiddleware && comp.Middleware != nil && comp.Middleware.Provider == "better-auth" {
-			betterAuthMw = comp
-			break
-		}
-	}
+	betterAuthMw := betterAuthMiddleware(i)
 
-	sb.WriteString("// Generated by OpenBoundary - DO NOT EDIT\n")
+	sb.WriteString(codegen.Header(codegen.SlashComments))
 	sb.WriteString("import { serve } from '@hono/node-server';\n")
 
 	// Import Hono and cors if we have better-auth (need to mount auth routes)
@@ -275,40 +510,95 @@ func (g *HonoServerGenerator) generateIndex(i *ir.IR) string {
 	}
 
 	// Import server creators
-	servers := g.getHTTPServers(i)
+	servers := i.HTTPServers()
 	for _, server := range servers {
 		sb.WriteString(fmt.Sprintf("import { create%sApp } from './components/%s.server';\n",
 			toPascalCase(server.ID), componentIDSlug(server.ID)))
 	}
 
+	// Import gateways
+	gateways := gatewayComponents(i)
+	for _, gw := range gateways {
+		sb.WriteString(fmt.Sprintf("import { create%sGateway } from './components/%s.gateway';\n",
+			toPascalCase(gw.ID), componentIDSlug(gw.ID)))
+	}
+
+	// Import the server's response cache
+	serverCache := hasServerCache(i)
+	if serverCache {
+		sb.WriteString("import { createRedisResponseCache } from './components/response.cache';\n")
+	}
+
 	// Import postgres clients
-	for _, comp := range i.Components {
-		if comp.Kind == ir.KindPostgres && comp.Postgres != nil {
-			sb.WriteString(fmt.Sprintf("import { create%sClient } from './components/%s.postgres';\n",
-				toPascalCase(comp.ID), componentIDSlug(comp.ID)))
+	for _, comp := range postgresComponents(i) {
+		sb.WriteString(fmt.Sprintf("import { create%sClient } from './components/%s.postgres';\n",
+			toPascalCase(comp.ID), componentIDSlug(comp.ID)))
+		if len(drizzleTables(i, comp)) > 0 {
+			sb.WriteString(fmt.Sprintf("import { create%s } from './components/%s.postgres.repositories';\n",
+				repositoriesTypeName(comp), componentIDSlug(comp.ID)))
 		}
 	}
 
+	// Import webhook emitters
+	for _, wh := range webhookComponents(i) {
+		sb.WriteString(fmt.Sprintf("import { create%sEmitter } from './components/%s.webhook';\n",
+			webhookTypeName(wh), componentIDSlug(wh.ID)))
+	}
+
+	// Import the clients of called services
+	for _, client := range httpClientComponents(i) {
+		sb.WriteString(fmt.Sprintf("import { create%s } from './components/%s.client';\n",
+			httpClientTypeName(client), componentIDSlug(client.ID)))
+	}
+
+	if httpFixturesMode(i) != "" {
+		sb.WriteString("import { installHttpFixtures } from './http-fixtures';\n")
+	}
+	if hasTLSServers(i) {
+		sb.WriteString("import { httpsOptions } from './tls';\n")
+	}
+
 	sb.WriteString("\nasync function main() {\n")
+	if httpFixturesMode(i) != "" {
+		envVar := httpFixturesEnvVar(i)
+		sb.WriteString("  // Record or replay calls to external APIs, as the E2E tests ask\n")
+		fmt.Fprintf(&sb, "  const httpFixtures = process.env.%s;\n", envVar)
+		sb.WriteString("  if (httpFixtures === 'record' || httpFixtures === 'replay') {\n")
+		sb.WriteString("    installHttpFixtures(httpFixtures);\n")
+		sb.WriteString("  }\n\n")
+	}
 	sb.WriteString("  // Initialize dependencies\n")
 
+	// Initialize the server's response cache, which repository writes invalidate
+	if serverCache {
+		writeResponseCacheInit(&sb, i)
+	}
+
 	// Initialize postgres clients
-	for _, comp := range i.Components {
-		if comp.Kind == ir.KindPostgres && comp.Postgres != nil {
-			varName := toCamelCase(comp.ID) + "Client"
-			sb.WriteString(fmt.Sprintf("  const %s = await create%sClient();\n", varName, toPascalCase(comp.ID)))
+	for _, comp := range postgresComponents(i) {
+		varName := toCamelCase(comp.ID) + "Client"
+		sb.WriteString(fmt.Sprintf("  const %s = await create%sClient();\n", varName, toPascalCase(comp.ID)))
+		if len(drizzleTables(i, comp)) > 0 {
+			sb.WriteString(fmt.Sprintf("  const %sRepositories = create%s(%s);\n", toCamelCase(comp.ID), repositoriesTypeName(comp), repositoriesArgs(i, varName)))
 		}
 	}
 
+	// Initialize webhook emitters
+	for _, wh := range webhookComponents(i) {
+		sb.WriteString(fmt.Sprintf("  const %sEmitter = create%sEmitter();\n", webhookContextField(wh), webhookTypeName(wh)))
+	}
+
+	// Initialize the clients of called services
+	for _, client := range httpClientComponents(i) {
+		sb.WriteString(fmt.Sprintf("  const %s = create%s();\n", httpClientContextField(client), httpClientTypeName(client)))
+	}
+
 	sb.WriteString("\n")
 
 	// Create and start servers
 	for _, server := range servers {
 		middlewareRefs := collectServerMiddleware(i, server)
-		port := server.HTTPServer.Port
-		if port == 0 {
-			port = 3000
-		}
+		port := fmt.Sprintf("Number(process.env.%s ?? %d)", serverPortEnvVar(i, server), serverPort(server))
 
 		sb.WriteString(fmt.Sprintf("  // Start %s\n", server.ID))
 		serverContextVar := toCamelCase(server.ID) + "Context"
@@ -316,7 +606,19 @@ func (g *HonoServerGenerator) generateIndex(i *ir.IR) string {
 
 		// Add dependencies to context
 		for _, dep := range getServerPostgresDependencies(i, server) {
-			sb.WriteString(fmt.Sprintf("    db: %sClient,\n", toCamelCase(dep.ID)))
+			sb.WriteString(fmt.Sprintf("    %s: %sClient,\n", postgresContextField(i, dep), toCamelCase(dep.ID)))
+			if len(drizzleTables(i, dep)) > 0 {
+				sb.WriteString(fmt.Sprintf("    %s: %sRepositories,\n", repositoryContextField(i, dep), toCamelCase(dep.ID)))
+			}
+		}
+		for _, wh := range serverWebhooks(i, server) {
+			sb.WriteString(fmt.Sprintf("    %s: %sEmitter,\n", webhookContextField(wh), webhookContextField(wh)))
+		}
+		for _, client := range serverHTTPClients(i, server) {
+			sb.WriteString(fmt.Sprintf("    %s,\n", httpClientContextField(client)))
+		}
+		if serverCachesResponses(i, server) {
+			sb.WriteString("    responseCache,\n")
 		}
 
 		// Add null for middleware context (will be set by middleware)
@@ -344,6 +646,12 @@ func (g *HonoServerGenerator) generateIndex(i *ir.IR) string {
 		appVar := toCamelCase(server.ID) + "App"
 		sb.WriteString(fmt.Sprintf("  const %s = create%sApp(%s);\n", appVar, toPascalCase(server.ID), serverContextVar))
 
+		// Serve HTTPS with the server's certificate
+		serveOptions := port
+		if server.HTTPServer.TLS != nil {
+			serveOptions = fmt.Sprintf("%s, ...%s", port, writeHTTPSOptions(&sb, server))
+		}
+
 		// If we have better-auth, create a root app that mounts auth routes
 		if betterAuthMw != nil {
 			serverRootAppVar := toCamelCase(server.ID) + "RootApp"
@@ -351,7 +659,7 @@ func (g *HonoServerGenerator) generateIndex(i *ir.IR) string {
 			sb.WriteString(fmt.Sprintf("  const %s = new Hono();\n\n", serverRootAppVar))
 			sb.WriteString("  // CORS for auth routes\n")
 			sb.WriteString(fmt.Sprintf("  %s.use('/api/auth/*', cors({\n", serverRootAppVar))
-			sb.WriteString("    origin: process.env.CORS_ORIGIN || 'http://localhost:3000',\n")
+			sb.WriteString(fmt.Sprintf("    origin: process.env.%s || 'http://localhost:3000',\n", i.EnvVar("CORS_ORIGIN")))
 			sb.WriteString("    allowHeaders: ['Content-Type', 'Authorization'],\n")
 			sb.WriteString("    allowMethods: ['POST', 'GET', 'OPTIONS'],\n")
 			sb.WriteString("    credentials: true,\n")
@@ -359,12 +667,23 @@ func (g *HonoServerGenerator) generateIndex(i *ir.IR) string {
 			sb.WriteString("  // Mount better-auth routes\n")
 			sb.WriteString(fmt.Sprintf("  %s.on(['POST', 'GET'], '/api/auth/*', (c) => auth.handler(c.req.raw));\n\n", serverRootAppVar))
 			sb.WriteString(fmt.Sprintf("  // Mount API routes\n  %s.route('/', %s);\n\n", serverRootAppVar, appVar))
-			sb.WriteString(fmt.Sprintf("  serve({ fetch: %s.fetch, port: %d }, (info) => {\n", serverRootAppVar, port))
+			sb.WriteString(fmt.Sprintf("  serve({ fetch: %s.fetch, port: %s }, (info) => {\n", serverRootAppVar, serveOptions))
 		} else {
-			sb.WriteString(fmt.Sprintf("  serve({ fetch: %s.fetch, port: %d }, (info) => {\n", appVar, port))
+			sb.WriteString(fmt.Sprintf("  serve({ fetch: %s.fetch, port: %s }, (info) => {\n", appVar, serveOptions))
 		}
 
-		sb.WriteString(fmt.Sprintf("    console.log(`%s listening on http://localhost:${info.port}`);\n", server.ID))
+		sb.WriteString(fmt.Sprintf("    console.log(`%s listening on %s://localhost:${info.port}`);\n", server.ID, serverScheme(server)))
+		sb.WriteString("  });\n")
+	}
+
+	// Start gateways in front of the servers
+	for _, gw := range gateways {
+		appVar := toCamelCase(gw.ID) + "App"
+		sb.WriteString(fmt.Sprintf("\n  // Start %s\n", gw.ID))
+		sb.WriteString(fmt.Sprintf("  const %s = create%sGateway();\n", appVar, toPascalCase(gw.ID)))
+		sb.WriteString(fmt.Sprintf("  serve({ fetch: %s.fetch, port: Number(process.env.%s ?? %d) }, (info) => {\n",
+			appVar, gatewayPortEnvVar(i, gw), gw.HTTPGateway.Port))
+		sb.WriteString(fmt.Sprintf("    console.log(`%s listening on http://localhost:${info.port}`);\n", gw.ID))
 		sb.WriteString("  });\n")
 	}
 
@@ -381,109 +700,184 @@ func (g *HonoServerGenerator) generateMiddleware(mw *ir.Component) string {
 
 	var sb strings.Builder
 
-	sb.WriteString("// Generated by OpenBoundary - DO NOT EDIT\n")
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString(componentHeader(mw))
 	sb.WriteString("import { createMiddleware } from 'hono/factory';\n")
 
-	switch mw.Middleware.Provider {
-	case "better-auth":
-		mwFilename := sanitizeFilename(mw.ID)
-		sb.WriteString(fmt.Sprintf("import { auth } from './%s.middleware.config';\n\n", mwFilename))
-		sb.WriteString("type AuthSessionResult = Awaited<ReturnType<typeof auth.api.getSession>>;\n")
-		sb.WriteString("export type AuthContext = AuthSessionResult extends { session: infer S; user: infer U }\n")
-		sb.WriteString("  ? { session: S | null; user: U | null }\n")
-		sb.WriteString("  : { session: unknown; user: unknown };\n\n")
-		sb.WriteString(fmt.Sprintf("export const %sMiddleware = createMiddleware(async (c, next) => {\n", toCamelCase(mw.ID)))
-		sb.WriteString("  const session = await auth.api.getSession({ headers: c.req.raw.headers });\n\n")
-		sb.WriteString("  if (!session) {\n")
-		sb.WriteString("    c.set('auth', { session: null, user: null });\n")
-		sb.WriteString("  } else {\n")
-		sb.WriteString("    c.set('auth', { session: session.session, user: session.user });\n")
-		sb.WriteString("  }\n\n")
-		sb.WriteString("  await next();\n")
-		sb.WriteString("});\n\n")
-		// Also export a requireAuth middleware
-		sb.WriteString("/** Middleware that requires authentication - returns 401 if not authenticated */\n")
-		sb.WriteString("export const requireAuth = createMiddleware(async (c, next) => {\n")
-		sb.WriteString("  const authCtx = c.get('auth');\n\n")
-		sb.WriteString("  if (!authCtx?.session || !authCtx?.user) {\n")
-		sb.WriteString("    return c.json({ error: 'Unauthorized' }, 401);\n")
-		sb.WriteString("  }\n\n")
-		sb.WriteString("  await next();\n")
-		sb.WriteString("});\n")
-
-	case "casbin":
-		// Config files are colocated with middleware (paths from project root)
-		mwFilename := sanitizeFilename(mw.ID)
-		sb.WriteString("import { newEnforcer, Enforcer } from 'casbin';\n")
-		sb.WriteString("import path from 'path';\n")
-		sb.WriteString("import { fileURLToPath } from 'url';\n\n")
-		sb.WriteString("const __dirname = path.dirname(fileURLToPath(import.meta.url));\n\n")
-		sb.WriteString("let enforcer: Enforcer | null = null;\n\n")
-		sb.WriteString("async function getEnforcer(): Promise<Enforcer> {\n")
-		sb.WriteString("  if (!enforcer) {\n")
-		sb.WriteString(fmt.Sprintf("    const modelPath = path.join(__dirname, '%s.middleware.model.conf');\n", mwFilename))
-		sb.WriteString(fmt.Sprintf("    const policyPath = path.join(__dirname, '%s.middleware.policy.csv');\n", mwFilename))
-		sb.WriteString("    enforcer = await newEnforcer(modelPath, policyPath);\n")
-		sb.WriteString("  }\n")
-		sb.WriteString("  return enforcer;\n")
-		sb.WriteString("}\n\n")
-		sb.WriteString(fmt.Sprintf("export const %sMiddleware = createMiddleware(async (c, next) => {\n", toCamelCase(mw.ID)))
-		sb.WriteString("  const e = await getEnforcer();\n")
-		sb.WriteString("  c.set('enforcer', e);\n")
-		sb.WriteString("  // TODO: Implement authorization check\n")
-		sb.WriteString("  // const auth = c.get('auth');\n")
-		sb.WriteString("  // const allowed = await e.enforce(auth?.user?.id, c.req.path, c.req.method);\n")
-		sb.WriteString("  await next();\n")
-		sb.WriteString("});\n")
-
-	default:
+	if len(mw.Middleware.Chain) > 0 {
+		writeMiddlewareChain(&sb, mw)
+		return sb.String()
+	}
+	p := middlewareProviderFor(mw)
+	if p == nil {
 		return ""
 	}
+	p.WriteMiddleware(&sb, mw)
 
 	return sb.String()
 }
 
-func (g *HonoServerGenerator) generatePostgresClient(pg *ir.Component) string {
-	var sb strings.Builder
+// writeMiddlewareChain renders a middleware that runs the providers of a
+// chain in order, stopping at the first that responds instead of calling
+// next.
+func writeMiddlewareChain(sb *strings.Builder, mw *ir.Component) {
+	var names []string
+	for _, member := range mw.Middleware.Chain {
+		name := toCamelCase(member) + "Middleware"
+		fmt.Fprintf(sb, "import { %s } from './%s.middleware';\n", name, componentIDSlug(member))
+		names = append(names, name)
+	}
+	sb.WriteString("\n")
+	fmt.Fprintf(sb, "const chain = [%s];\n\n", strings.Join(names, ", "))
+	fmt.Fprintf(sb, "/** Runs %s in order. */\n", strings.Join(mw.Middleware.Chain, ", then "))
+	fmt.Fprintf(sb, "export const %sMiddleware = createMiddleware(async (c, next) => {\n", toCamelCase(mw.ID))
+	sb.WriteString("  const run = async (n: number): Promise<void> => {\n")
+	sb.WriteString("    if (n === chain.length) {\n")
+	sb.WriteString("      await next();\n")
+	sb.WriteString("      return;\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("    const res = await chain[n](c, () => run(n + 1));\n")
+	sb.WriteString("    if (res) {\n")
+	sb.WriteString("      c.res = res;\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("  };\n")
+	sb.WriteString("  await run(0);\n")
+	sb.WriteString("});\n")
+}
 
-	sb.WriteString("// Generated by OpenBoundary - DO NOT EDIT\n")
+// The lines that define __dirname in an ES module, which ModuleFormat
+// rewrites for CommonJS output.
+const (
+	esmDirnameImport = "import { fileURLToPath } from 'url';\n\n"
+	esmDirname       = "const __dirname = path.dirname(fileURLToPath(import.meta.url));\n\n"
+)
 
-	if pg.Postgres.Provider == "drizzle" {
-		sb.WriteString("import { drizzle } from 'drizzle-orm/postgres-js';\n")
-		sb.WriteString("import postgres from 'postgres';\n")
-		// Import from the colocated schema file
-		sb.WriteString(fmt.Sprintf("import * as schema from './%s.postgres.schema';\n\n", componentIDSlug(pg.ID)))
+// generateCasbinEnforcer renders the policy management module of a casbin
+// middleware. In development policies come from the policy file, which is
+// watched and reloaded on change; in production they come from the
+// configured policy adapter.
+func generateCasbinEnforcer(i *ir.IR, mw *ir.Component) string {
+	var sb strings.Builder
 
-		sb.WriteString("// Database connection\n")
-		sb.WriteString("const connectionString = process.env.DATABASE_URL || '';\n")
-		sb.WriteString("const client = postgres(connectionString);\n\n")
+	// Config files are colocated with the module
+	mwFilename := sanitizeFilename(mw.ID)
+	adapterDB := casbinPolicyDatabase(i, mw)
+	seed := adapterDB != nil && len(rbacPolicyRows(mw)) > 0
 
-		sb.WriteString("// Export db instance for use by auth and other modules\n")
-		sb.WriteString("export const db = drizzle(client, { schema });\n\n")
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("import { newEnforcer, Enforcer } from 'casbin';\n")
+	if adapterDB != nil {
+		sb.WriteString("import PostgresAdapter from 'casbin-pg-adapter';\n")
+	}
+	if seed {
+		sb.WriteString(fmt.Sprintf("import { seedPolicies, seedGroupings } from './%s.middleware.rbac';\n", mwFilename))
+	}
+	sb.WriteString("import { watch } from 'fs';\n")
+	sb.WriteString("import path from 'path';\n")
+	sb.WriteString(esmDirnameImport)
+	sb.WriteString(esmDirname)
+	sb.WriteString(fmt.Sprintf("const modelPath = path.join(__dirname, '%s.middleware.model.conf');\n", mwFilename))
+	sb.WriteString(fmt.Sprintf("const policyPath = path.join(__dirname, '%s.middleware.policy.csv');\n", mwFilename))
+	sb.WriteString("const isProduction = process.env.NODE_ENV === 'production';\n\n")
+
+	sb.WriteString("let enforcer: Promise<Enforcer> | null = null;\n")
+	sb.WriteString("let watching = false;\n\n")
+
+	sb.WriteString("async function createEnforcer(): Promise<Enforcer> {\n")
+	if adapterDB != nil {
+		sb.WriteString("  if (isProduction) {\n")
+		sb.WriteString("    const adapter = await PostgresAdapter.newAdapter({\n")
+		sb.WriteString(fmt.Sprintf("      connectionString: process.env.%s,\n", postgresEnvVar(i, adapterDB)))
+		sb.WriteString("    });\n")
+		if seed {
+			sb.WriteString("    const e = await newEnforcer(modelPath, adapter);\n")
+			sb.WriteString("    await seedPolicy(e);\n")
+			sb.WriteString("    return e;\n")
+		} else {
+			sb.WriteString("    return newEnforcer(modelPath, adapter);\n")
+		}
+		sb.WriteString("  }\n")
+	}
+	sb.WriteString("  return newEnforcer(modelPath, policyPath);\n")
+	sb.WriteString("}\n\n")
 
-		sb.WriteString("// Factory function for explicit initialization with validation\n")
-		sb.WriteString(fmt.Sprintf("export async function create%sClient() {\n", toPascalCase(pg.ID)))
-		sb.WriteString("  if (!connectionString) {\n")
-		sb.WriteString("    throw new Error('DATABASE_URL environment variable is required');\n")
+	if seed {
+		sb.WriteString("/** Adds the roles declared in the spec to the policy database, keeping existing rules. */\n")
+		sb.WriteString("async function seedPolicy(e: Enforcer): Promise<void> {\n")
+		sb.WriteString("  for (const rule of seedPolicies) {\n")
+		sb.WriteString("    if (!(await e.hasPolicy(...rule))) {\n")
+		sb.WriteString("      await e.addPolicy(...rule);\n")
+		sb.WriteString("    }\n")
+		sb.WriteString("  }\n")
+		sb.WriteString("  for (const rule of seedGroupings) {\n")
+		sb.WriteString("    if (!(await e.hasGroupingPolicy(...rule))) {\n")
+		sb.WriteString("      await e.addGroupingPolicy(...rule);\n")
+		sb.WriteString("    }\n")
 		sb.WriteString("  }\n")
-		sb.WriteString("  return db;\n")
-		sb.WriteString("}\n")
+		sb.WriteString("}\n\n")
 	}
 
+	sb.WriteString("/** Returns the shared enforcer, creating it on first use. */\n")
+	sb.WriteString("export function getEnforcer(): Promise<Enforcer> {\n")
+	sb.WriteString("  if (!enforcer) {\n")
+	sb.WriteString("    enforcer = createEnforcer();\n")
+	sb.WriteString("    if (!isProduction) {\n")
+	sb.WriteString("      watchPolicy();\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("  return enforcer;\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/** Reloads policies from the policy source without restarting the server. */\n")
+	sb.WriteString("export async function reloadPolicy(): Promise<void> {\n")
+	sb.WriteString("  const e = await getEnforcer();\n")
+	sb.WriteString("  await e.loadPolicy();\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/** Reloads policies when the policy file changes and rebuilds the enforcer when the model changes. */\n")
+	sb.WriteString("function watchPolicy(): void {\n")
+	sb.WriteString("  if (watching) return;\n")
+	sb.WriteString("  watching = true;\n\n")
+	sb.WriteString("  watch(policyPath, () => {\n")
+	sb.WriteString("    reloadPolicy().catch((err) => console.error('Failed to reload casbin policy:', err));\n")
+	sb.WriteString("  }).unref();\n")
+	sb.WriteString("  watch(modelPath, () => {\n")
+	sb.WriteString("    const next = createEnforcer();\n")
+	sb.WriteString("    next\n")
+	sb.WriteString("      .then(() => {\n")
+	sb.WriteString("        enforcer = next;\n")
+	sb.WriteString("      })\n")
+	sb.WriteString("      .catch((err) => console.error('Failed to reload casbin model:', err));\n")
+	sb.WriteString("  }).unref();\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("export interface EffectivePolicies {\n")
+	sb.WriteString("  policies: string[][];\n")
+	sb.WriteString("  groupings: string[][];\n")
+	sb.WriteString("}\n\n")
+	sb.WriteString("/** Lists the policies and role assignments the enforcer currently applies. */\n")
+	sb.WriteString("export async function getEffectivePolicies(): Promise<EffectivePolicies> {\n")
+	sb.WriteString("  const e = await getEnforcer();\n")
+	sb.WriteString("  return {\n")
+	sb.WriteString("    policies: await e.getPolicy(),\n")
+	sb.WriteString("    groupings: await e.getGroupingPolicy(),\n")
+	sb.WriteString("  };\n")
+	sb.WriteString("}\n")
+
 	return sb.String()
 }
 
-func (g *HonoServerGenerator) getHTTPServers(i *ir.IR) []*ir.Component {
-	var servers []*ir.Component
-	for _, comp := range i.Components {
-		if comp.Kind == ir.KindHTTPServer && comp.HTTPServer != nil {
-			servers = append(servers, comp)
-		}
+func (g *HonoServerGenerator) generatePostgresClient(i *ir.IR, pg *ir.Component) string {
+	var sb strings.Builder
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString(componentHeader(pg))
+
+	if p := databaseProviderFor(pg); p != nil {
+		p.WriteClient(&sb, i, pg)
 	}
-	sort.Slice(servers, func(i, j int) bool {
-		return servers[i].ID < servers[j].ID
-	})
-	return servers
+
+	return sb.String()
 }
 
 // Helper functions
@@ -493,7 +887,7 @@ type routeRequirement struct {
 	regexLiteral string
 }
 
-func (g *HonoServerGenerator) writeMiddlewareMatrix(sb *strings.Builder, server *ir.Component, usecases []*ir.Component, middlewareRefs []string) {
+func (g *HonoServerGenerator) writeMiddlewareMatrix(sb *strings.Builder, server *ir.Component, usecases []*ir.Component, middlewareRefs []string, adminMiddleware []*ir.Component) {
 	if len(middlewareRefs) == 0 {
 		return
 	}
@@ -502,6 +896,14 @@ func (g *HonoServerGenerator) writeMiddlewareMatrix(sb *strings.Builder, server
 	sb.WriteString("const middlewareMatrix: Record<string, MiddlewareRoute[]> = {\n")
 	for _, mwID := range middlewareRefs {
 		routes := g.collectRoutesForMiddleware(usecases, server, mwID)
+		for _, admin := range adminMiddleware {
+			if admin.ID == mwID || stringInSlice(mwID, admin.Middleware.DependsOn) {
+				routes = append(routes, routeRequirement{
+					method:       "GET",
+					regexLiteral: honoPathToRegexLiteral(convertPathParams(server.HTTPServer.RoutePath(admin.Middleware.AdminRoute))),
+				})
+			}
+		}
 		fmt.Fprintf(sb, "  %s: [\n", strconv.Quote(mwID))
 		for _, route := range routes {
 			fmt.Fprintf(sb, "    { method: '%s', path: %s },\n", route.method, route.regexLiteral)
@@ -514,7 +916,7 @@ func (g *HonoServerGenerator) writeMiddlewareMatrix(sb *strings.Builder, server
 	sb.WriteString("  const routes = middlewareMatrix[mwId];\n")
 	sb.WriteString("  if (!routes || routes.length === 0) return false;\n")
 	sb.WriteString("  for (const route of routes) {\n")
-	sb.WriteString("    if (route.method === method && route.path.test(path)) {\n")
+	sb.WriteString("    if ((route.method === 'ALL' || route.method === method) && route.path.test(path)) {\n")
 	sb.WriteString("      return true;\n")
 	sb.WriteString("    }\n")
 	sb.WriteString("  }\n")
@@ -531,12 +933,17 @@ func (g *HonoServerGenerator) collectRoutesForMiddleware(usecases []*ir.Componen
 		if !stringInSlice(mwID, effectiveUsecaseMiddleware(uc, server)) {
 			continue
 		}
-		method := strings.ToUpper(uc.Usecase.Binding.Method)
-		honoPath := convertPathParams(uc.Usecase.Binding.Path)
-		routes = append(routes, routeRequirement{
-			method:       method,
-			regexLiteral: honoPathToRegexLiteral(honoPath),
-		})
+		bindings := []*ir.Binding{uc.Usecase.Binding}
+		if status := uc.Usecase.JobStatus(); status != nil {
+			bindings = append(bindings, status)
+		}
+		for _, b := range bindings {
+			honoPath := convertPathParams(server.HTTPServer.RoutePath(b.Path))
+			routes = append(routes, routeRequirement{
+				method:       strings.ToUpper(b.Method),
+				regexLiteral: honoPathToRegexLiteral(honoPath),
+			})
+		}
 	}
 	return routes
 }
@@ -612,7 +1019,9 @@ func convertPathParams(path string) string {
 }
 
 func honoPathToRegexLiteral(path string) string {
-	segments := strings.Split(path, "/")
+	// A trailing wildcard also matches the path without it, as in Hono
+	prefix, wildcard := strings.CutSuffix(path, "/*")
+	segments := strings.Split(prefix, "/")
 	for i, segment := range segments {
 		if strings.HasPrefix(segment, ":") && len(segment) > 1 {
 			segments[i] = "[^/]+"
@@ -620,7 +1029,11 @@ func honoPathToRegexLiteral(path string) string {
 		}
 		segments[i] = regexp.QuoteMeta(segment)
 	}
-	pattern := "^" + strings.Join(segments, "/") + "$"
+	pattern := "^" + strings.Join(segments, "/")
+	if wildcard {
+		pattern += "(?:/.*)?"
+	}
+	pattern += "$"
 	return fmt.Sprintf("new RegExp(%s)", strconv.Quote(pattern))
 }
 
@@ -633,28 +1046,112 @@ func stringInSlice(value string, items []string) bool {
 	return false
 }
 
-func extractPathParams(path string) []string {
-	var params []string
-	for {
-		start := strings.Index(path, "{")
-		if start == -1 {
-			break
-		}
-		end := strings.Index(path, "}")
-		if end == -1 {
-			break
-		}
-		params = append(params, path[start+1:end])
-		path = path[end+1:]
+// defaultSessionMaxAge is the better-auth session lifetime in seconds (7 days).
+const defaultSessionMaxAge = 604800
+
+// generateBetterAuthSession renders the session storage options of a
+// better-auth middleware. The auth config spreads them into betterAuth().
+func generateBetterAuthSession(i *ir.IR, mw *ir.Component) string {
+	var sb strings.Builder
+
+	session := mw.Middleware.Session
+	maxAge := session.MaxAge
+	if maxAge == 0 {
+		maxAge = defaultSessionMaxAge
+	}
+	mwFilename := sanitizeFilename(mw.ID)
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString(fmt.Sprintf("// Session storage (%s): spread into betterAuth({ ...sessionOptions }) in the auth config\n", session.Storage))
+	sb.WriteString("import type { BetterAuthOptions } from 'better-auth';\n")
+
+	switch session.Storage {
+	case ir.SessionStorageDatabase:
+		sb.WriteString("import { drizzleAdapter } from 'better-auth/adapters/drizzle';\n")
+		sb.WriteString(fmt.Sprintf("import { db } from './%s.postgres';\n", componentIDSlug(session.Store)))
+		sb.WriteString(fmt.Sprintf("import * as schema from './%s.middleware.schema';\n\n", mwFilename))
+		sb.WriteString("export const sessionOptions = {\n")
+		sb.WriteString("  database: drizzleAdapter(db, { provider: 'pg', schema }),\n")
+		sb.WriteString("  session: {\n")
+		sb.WriteString(fmt.Sprintf("    expiresIn: %d,\n", maxAge))
+		sb.WriteString("  },\n")
+		sb.WriteString("} satisfies Partial<BetterAuthOptions>;\n")
+
+	case ir.SessionStorageRedis:
+		sb.WriteString("import Redis from 'ioredis';\n\n")
+		sb.WriteString(fmt.Sprintf("const redis = new Redis(process.env.%s ?? 'redis://localhost:6379');\n", i.EnvVar("REDIS_URL")))
+		sb.WriteString("// Namespace keys so several auth middlewares can share one redis\n")
+		sb.WriteString(fmt.Sprintf("const keyPrefix = '%s:';\n\n", mwFilename))
+		sb.WriteString("export const sessionOptions = {\n")
+		sb.WriteString("  secondaryStorage: {\n")
+		sb.WriteString("    get: (key: string) => redis.get(keyPrefix + key),\n")
+		sb.WriteString("    set: async (key: string, value: string, ttl?: number) => {\n")
+		sb.WriteString("      if (ttl) {\n")
+		sb.WriteString("        await redis.set(keyPrefix + key, value, 'EX', ttl);\n")
+		sb.WriteString("      } else {\n")
+		sb.WriteString("        await redis.set(keyPrefix + key, value);\n")
+		sb.WriteString("      }\n")
+		sb.WriteString("    },\n")
+		sb.WriteString("    delete: async (key: string) => {\n")
+		sb.WriteString("      await redis.del(keyPrefix + key);\n")
+		sb.WriteString("    },\n")
+		sb.WriteString("  },\n")
+		sb.WriteString("  session: {\n")
+		sb.WriteString(fmt.Sprintf("    expiresIn: %d,\n", maxAge))
+		sb.WriteString("    storeSessionInDatabase: false,\n")
+		sb.WriteString("  },\n")
+		sb.WriteString("} satisfies Partial<BetterAuthOptions>;\n")
+
+	case ir.SessionStorageCookie:
+		sb.WriteString("\n// Sessions and OAuth state live in encrypted cookies; nothing is stored server-side\n")
+		sb.WriteString("export const sessionOptions = {\n")
+		sb.WriteString("  session: {\n")
+		sb.WriteString(fmt.Sprintf("    expiresIn: %d,\n", maxAge))
+		sb.WriteString("    cookieCache: {\n")
+		sb.WriteString("      enabled: true,\n")
+		sb.WriteString(fmt.Sprintf("      maxAge: %d,\n", maxAge))
+		sb.WriteString("      strategy: 'jwe',\n")
+		sb.WriteString("      refreshCache: true,\n")
+		sb.WriteString("    },\n")
+		sb.WriteString("  },\n")
+		sb.WriteString("  account: {\n")
+		sb.WriteString("    storeStateStrategy: 'cookie',\n")
+		sb.WriteString("    storeAccountCookie: true,\n")
+		sb.WriteString("  },\n")
+		sb.WriteString("} satisfies Partial<BetterAuthOptions>;\n")
 	}
-	return params
+
+	return sb.String()
+}
+
+// generateBetterAuthOAuth renders the OAuth provider options of a better-auth
+// middleware. Credentials are read from the environment variables named in the spec.
+func generateBetterAuthOAuth(mw *ir.Component) string {
+	var sb strings.Builder
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("// OAuth providers: spread into betterAuth({ ...oauthOptions }) in the auth config\n")
+	sb.WriteString("import type { BetterAuthOptions } from 'better-auth';\n\n")
+	sb.WriteString("export const oauthOptions = {\n")
+	sb.WriteString("  socialProviders: {\n")
+	for _, p := range mw.Middleware.OAuth {
+		sb.WriteString(fmt.Sprintf("    %s: {\n", p.Provider))
+		sb.WriteString(fmt.Sprintf("      clientId: process.env.%s as string,\n", p.ClientIDEnv))
+		sb.WriteString(fmt.Sprintf("      clientSecret: process.env.%s as string,\n", p.ClientSecretEnv))
+		sb.WriteString("    },\n")
+	}
+	sb.WriteString("  },\n")
+	sb.WriteString("} satisfies Partial<BetterAuthOptions>;\n")
+
+	return sb.String()
 }
 
 // generateBetterAuthSchema generates the Drizzle schema for better-auth tables.
-func (g *HonoServerGenerator) generateBetterAuthSchema() string {
+// The session table is omitted when sessions are stored in redis or cookies.
+func generateBetterAuthSchema(mw *ir.Component) string {
 	var sb strings.Builder
 
-	sb.WriteString("// Generated by OpenBoundary - DO NOT EDIT\n")
+	sb.WriteString(codegen.Header(codegen.SlashComments))
 	sb.WriteString("// Better-auth required schema tables\n")
 	sb.WriteString("import { pgTable, text, timestamp, boolean } from 'drizzle-orm/pg-core';\n\n")
 
@@ -670,16 +1167,18 @@ func (g *HonoServerGenerator) generateBetterAuthSchema() string {
 	sb.WriteString("});\n\n")
 
 	// Session table
-	sb.WriteString("export const session = pgTable('session', {\n")
-	sb.WriteString("  id: text('id').primaryKey(),\n")
-	sb.WriteString("  userId: text('user_id').notNull().references(() => user.id, { onDelete: 'cascade' }),\n")
-	sb.WriteString("  token: text('token').notNull().unique(),\n")
-	sb.WriteString("  expiresAt: timestamp('expires_at').notNull(),\n")
-	sb.WriteString("  ipAddress: text('ip_address'),\n")
-	sb.WriteString("  userAgent: text('user_agent'),\n")
-	sb.WriteString("  createdAt: timestamp('created_at').notNull().defaultNow(),\n")
-	sb.WriteString("  updatedAt: timestamp('updated_at').notNull().defaultNow(),\n")
-	sb.WriteString("});\n\n")
+	if session := mw.Middleware.Session; session == nil || session.Storage == ir.SessionStorageDatabase {
+		sb.WriteString("export const session = pgTable('session', {\n")
+		sb.WriteString("  id: text('id').primaryKey(),\n")
+		sb.WriteString("  userId: text('user_id').notNull().references(() => user.id, { onDelete: 'cascade' }),\n")
+		sb.WriteString("  token: text('token').notNull().unique(),\n")
+		sb.WriteString("  expiresAt: timestamp('expires_at').notNull(),\n")
+		sb.WriteString("  ipAddress: text('ip_address'),\n")
+		sb.WriteString("  userAgent: text('user_agent'),\n")
+		sb.WriteString("  createdAt: timestamp('created_at').notNull().defaultNow(),\n")
+		sb.WriteString("  updatedAt: timestamp('updated_at').notNull().defaultNow(),\n")
+		sb.WriteString("});\n\n")
+	}
 
 	// Account table (stores credentials)
 	sb.WriteString("export const account = pgTable('account', {\n")
@@ -711,9 +1210,34 @@ func (g *HonoServerGenerator) generateBetterAuthSchema() string {
 	return sb.String()
 }
 
-const postgresClientType = `// Generated by OpenBoundary - DO NOT EDIT
-import type { PostgresJsDatabase } from 'drizzle-orm/postgres-js';
+// serverLimitsUsed reports whether a server or any of its routes limits
+// request bodies and handling time.
+func serverLimitsUsed(server *ir.Component, usecases []*ir.Component) (bodyLimits, timeouts bool) {
+	limits := []*ir.Limits{server.HTTPServer.Limits}
+	for _, uc := range usecases {
+		limits = append(limits, uc.Usecase.Limits)
+	}
+	for _, l := range limits {
+		if l != nil {
+			bodyLimits = bodyLimits || l.MaxBodyKB > 0
+			timeouts = timeouts || l.TimeoutMS > 0
+		}
+	}
+	return bodyLimits, timeouts
+}
 
-// eslint-disable-next-line @typescript-eslint/no-explicit-any
-export type DrizzleClient = PostgresJsDatabase<any>;
-`
+// limitMiddleware returns the Hono middleware enforcing limits: a body limit
+// answering 413 and a timeout answering 504.
+func limitMiddleware(l *ir.Limits) []string {
+	if l == nil {
+		return nil
+	}
+	var mws []string
+	if l.MaxBodyKB > 0 {
+		mws = append(mws, fmt.Sprintf("bodyLimit({ maxSize: %d * 1024, onError: (c) => c.json({ error: 'Payload Too Large' }, 413) })", l.MaxBodyKB))
+	}
+	if l.TimeoutMS > 0 {
+		mws = append(mws, fmt.Sprintf("timeout(%d)", l.TimeoutMS))
+	}
+	return mws
+}
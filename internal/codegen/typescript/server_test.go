@@ -9,6 +9,7 @@ import (
 
 	"github.com/openboundary/openboundary/internal/codegen"
 	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
 	"github.com/openboundary/openboundary/internal/parser"
 )
 
@@ -60,6 +61,9 @@ func TestHonoServerGenerator_Generate_Index(t *testing.T) {
 	if !strings.Contains(content, "main()") {
 		t.Error("index.ts should have main function")
 	}
+	if !strings.Contains(content, "port: Number(process.env.PORT ?? 3000) }") {
+		t.Error("index.ts should read the port from PORT")
+	}
 }
 
 func TestHonoServerGenerator_Generate_ServerFile(t *testing.T) {
@@ -153,6 +157,69 @@ func TestHonoServerGenerator_Generate_MiddlewareFile(t *testing.T) {
 	}
 }
 
+func TestHonoServerGenerator_Generate_MiddlewareChain(t *testing.T) {
+	// given: a server behind a chain of better-auth and casbin
+	i := &ir.IR{
+		Spec: &parser.Spec{Name: "test"},
+		Components: map[string]*ir.Component{
+			"http.server.api": {
+				ID:         "http.server.api",
+				Kind:       ir.KindHTTPServer,
+				HTTPServer: &ir.HTTPServerSpec{Framework: "hono", Port: 3000, Middleware: []string{"middleware.auth"}},
+			},
+			"middleware.auth": {
+				ID:   "middleware.auth",
+				Kind: ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{
+					Chain: []string{"middleware.auth.better-auth", "middleware.auth.casbin"},
+				},
+			},
+			"middleware.auth.better-auth": {
+				ID:         "middleware.auth.better-auth",
+				Kind:       ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{Provider: "better-auth"},
+			},
+			"middleware.auth.casbin": {
+				ID:         "middleware.auth.casbin",
+				Kind:       ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{Provider: "casbin"},
+			},
+		},
+	}
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	chain := string(output.Files["src/components/middleware-auth.middleware.ts"].Content)
+	for _, want := range []string{
+		"import { middlewareAuthBetterauthMiddleware } from './middleware-auth-better-auth.middleware';",
+		"import { middlewareAuthCasbinMiddleware } from './middleware-auth-casbin.middleware';",
+		"const chain = [middlewareAuthBetterauthMiddleware, middlewareAuthCasbinMiddleware];",
+		"export const middlewareAuthMiddleware = createMiddleware(",
+		"const res = await chain[n](c, () => run(n + 1));",
+	} {
+		if !strings.Contains(chain, want) {
+			t.Errorf("chain middleware should contain %q, got:\n%s", want, chain)
+		}
+	}
+	for _, path := range []string{
+		"src/components/middleware-auth-better-auth.middleware.ts",
+		"src/components/middleware-auth-casbin.middleware.enforcer.ts",
+	} {
+		if _, ok := output.Files[path]; !ok {
+			t.Errorf("%s not found in output", path)
+		}
+	}
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	if !strings.Contains(server, "import { middlewareAuthMiddleware } from './middleware-auth.middleware';") {
+		t.Errorf("server should use the composed middleware, got:\n%s", server)
+	}
+}
+
 func TestHonoServerGenerator_Generate_PostgresClient(t *testing.T) {
 	// given: IR with postgres
 	i := createTestIR()
@@ -211,35 +278,6 @@ func TestConvertPathParams(t *testing.T) {
 	}
 }
 
-func TestExtractPathParams(t *testing.T) {
-	tests := []struct {
-		input string
-		want  []string
-	}{
-		{"/users", nil},
-		{"/users/{id}", []string{"id"}},
-		{"/users/{userId}/posts/{postId}", []string{"userId", "postId"}},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			// when
-			got := extractPathParams(tt.input)
-
-			// then
-			if len(got) != len(tt.want) {
-				t.Errorf("extractPathParams(%q) = %v, want %v", tt.input, got, tt.want)
-				return
-			}
-			for i := range got {
-				if got[i] != tt.want[i] {
-					t.Errorf("extractPathParams(%q)[%d] = %q, want %q", tt.input, i, got[i], tt.want[i])
-				}
-			}
-		})
-	}
-}
-
 func TestToFunctionName(t *testing.T) {
 	tests := []struct {
 		input string
@@ -360,91 +398,615 @@ func TestHonoServerGenerator_MultiServerIndexUsesUniqueLocalNames(t *testing.T)
 	}
 }
 
-// Helper to create a test IR
-func createTestIR() *ir.IR {
-	postgres := &ir.Component{
-		ID:   "postgres.primary",
-		Kind: ir.KindPostgres,
-		Postgres: &ir.PostgresSpec{
-			Provider: "drizzle",
-			Schema:   "./src/db/schema.ts",
-		},
+func TestHonoServerGenerator_Generate_MultipleDatabases(t *testing.T) {
+	// given
+	i := newMultiDatabaseIR()
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
 	}
 
-	authn := &ir.Component{
-		ID:   "middleware.authn",
-		Kind: ir.KindMiddleware,
-		Middleware: &ir.MiddlewareSpec{
-			Provider: "better-auth",
-			Config:   "./auth.config.ts",
-		},
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	for _, want := range []string{
+		"    c.set('primaryDb', ctx.primaryDb);\n",
+		"    c.set('readReplicaDb', ctx.readReplicaDb);\n",
+		"      readReplicaDb: c.get('readReplicaDb'),\n",
+	} {
+		if !strings.Contains(server, want) {
+			t.Errorf("server file missing %q", want)
+		}
 	}
 
-	authz := &ir.Component{
-		ID:   "middleware.authz",
-		Kind: ir.KindMiddleware,
-		Middleware: &ir.MiddlewareSpec{
-			Provider: "casbin",
-			Model:    "./model.conf",
-			Policy:   "./policy.csv",
+	index := string(output.Files["src/index.ts"].Content)
+	for _, want := range []string{
+		"    primaryDb: postgresPrimaryClient,\n",
+		"    readReplicaDb: postgresReadreplicaClient,\n",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index.ts missing %q\n%s", want, index)
+		}
+	}
+
+	client := string(output.Files["src/components/postgres-read-replica.postgres.ts"].Content)
+	if !strings.Contains(client, "process.env.READ_REPLICA_DATABASE_URL") {
+		t.Error("postgres client should read its own connection string variable")
+	}
+}
+
+func TestHonoServerGenerator_Generate_CasbinEnforcer(t *testing.T) {
+	// given
+	i := createTestIR()
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	mw := string(output.Files["src/components/middleware-authz.middleware.ts"].Content)
+	if !strings.Contains(mw, "import { getEnforcer } from './middleware-authz.middleware.enforcer';") {
+		t.Error("casbin middleware should use the enforcer module")
+	}
+
+	enforcer := string(output.Files["src/components/middleware-authz.middleware.enforcer.ts"].Content)
+	for _, want := range []string{
+		"const policyPath = path.join(__dirname, 'middleware-authz.middleware.policy.csv');",
+		"export async function reloadPolicy(): Promise<void> {",
+		"export async function getEffectivePolicies(): Promise<EffectivePolicies> {",
+		"  watch(policyPath, () => {",
+	} {
+		if !strings.Contains(enforcer, want) {
+			t.Errorf("enforcer module missing %q", want)
+		}
+	}
+	if strings.Contains(enforcer, "casbin-pg-adapter") {
+		t.Error("file policy adapter should not import casbin-pg-adapter")
+	}
+}
+
+func TestHonoServerGenerator_Generate_CasbinPostgresAdapter(t *testing.T) {
+	// given
+	i := createTestIR()
+	authz := i.Components["middleware.authz"]
+	authz.Middleware.PolicyAdapter = "postgres"
+	authz.Middleware.DependsOn = []string{"middleware.authn", "postgres.primary"}
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	enforcer := string(output.Files["src/components/middleware-authz.middleware.enforcer.ts"].Content)
+	for _, want := range []string{
+		"import PostgresAdapter from 'casbin-pg-adapter';",
+		"      connectionString: process.env.DATABASE_URL,\n",
+		"    return newEnforcer(modelPath, adapter);",
+	} {
+		if !strings.Contains(enforcer, want) {
+			t.Errorf("enforcer module missing %q\n%s", want, enforcer)
+		}
+	}
+}
+
+func TestHonoServerGenerator_Generate_BetterAuthSession(t *testing.T) {
+	tests := []struct {
+		name         string
+		session      *ir.SessionSpec
+		want         []string
+		sessionTable bool
+	}{
+		{
+			name:    "database",
+			session: &ir.SessionSpec{Storage: ir.SessionStorageDatabase, Store: "postgres.primary"},
+			want: []string{
+				"import { db } from './postgres-primary.postgres';",
+				"  database: drizzleAdapter(db, { provider: 'pg', schema }),",
+				"    expiresIn: 604800,",
+			},
+			sessionTable: true,
+		},
+		{
+			name:    "redis",
+			session: &ir.SessionSpec{Storage: ir.SessionStorageRedis, MaxAge: 3600},
+			want: []string{
+				"const redis = new Redis(process.env.REDIS_URL ?? 'redis://localhost:6379');",
+				"const keyPrefix = 'middleware-authn:';",
+				"        await redis.set(keyPrefix + key, value, 'EX', ttl);",
+				"    expiresIn: 3600,\n    storeSessionInDatabase: false,",
+			},
+		},
+		{
+			name:    "cookie",
+			session: &ir.SessionSpec{Storage: ir.SessionStorageCookie, MaxAge: 900},
+			want: []string{
+				"      maxAge: 900,\n      strategy: 'jwe',",
+				"    storeStateStrategy: 'cookie',",
+			},
 		},
-		Dependencies: []*ir.Component{authn},
 	}
 
-	server := &ir.Component{
-		ID:   "http.server.api",
-		Kind: ir.KindHTTPServer,
-		HTTPServer: &ir.HTTPServerSpec{
-			Framework:  "hono",
-			Port:       3000,
-			Middleware: []string{"middleware.authn", "middleware.authz"},
-			DependsOn:  []string{"postgres.primary"},
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			i := createTestIR()
+			i.Components["middleware.authn"].Middleware.Session = tt.session
+
+			// when
+			output, err := NewHonoServerGenerator().Generate(i)
+
+			// then
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			session := string(output.Files["src/components/middleware-authn.middleware.session.ts"].Content)
+			for _, want := range tt.want {
+				if !strings.Contains(session, want) {
+					t.Errorf("session module missing %q\n%s", want, session)
+				}
+			}
+			schema := string(output.Files["src/components/middleware-authn.middleware.schema.ts"].Content)
+			if got := strings.Contains(schema, "export const session = pgTable('session'"); got != tt.sessionTable {
+				t.Errorf("schema has session table = %v, want %v", got, tt.sessionTable)
+			}
+		})
+	}
+}
+
+func TestHonoServerGenerator_Generate_BetterAuthOAuth(t *testing.T) {
+	// given
+	i := createTestIR()
+	i.Components["middleware.authn"].Middleware.OAuth = []ir.OAuthProvider{
+		{Provider: "github", ClientIDEnv: "GITHUB_CLIENT_ID", ClientSecretEnv: "GITHUB_CLIENT_SECRET"},
+		{Provider: "google", ClientIDEnv: "GOOGLE_CLIENT_ID", ClientSecretEnv: "GOOGLE_CLIENT_SECRET"},
+	}
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	oauth := string(output.Files["src/components/middleware-authn.middleware.oauth.ts"].Content)
+	want := "  socialProviders: {\n" +
+		"    github: {\n" +
+		"      clientId: process.env.GITHUB_CLIENT_ID as string,\n" +
+		"      clientSecret: process.env.GITHUB_CLIENT_SECRET as string,\n" +
+		"    },\n" +
+		"    google: {\n" +
+		"      clientId: process.env.GOOGLE_CLIENT_ID as string,\n" +
+		"      clientSecret: process.env.GOOGLE_CLIENT_SECRET as string,\n" +
+		"    },\n" +
+		"  },\n"
+	if !strings.Contains(oauth, want) {
+		t.Errorf("oauth module missing providers\n%s", oauth)
+	}
+}
+
+func TestHonoServerGenerator_Generate_CasbinSeedsPolicyDatabase(t *testing.T) {
+	// given
+	i := createTestIR()
+	authz := i.Components["middleware.authz"]
+	authz.Middleware.PolicyAdapter = "postgres"
+	authz.Middleware.DependsOn = []string{"middleware.authn", "postgres.primary"}
+	authz.Middleware.Permissions = []ir.Permission{{Name: "user.read", Object: "/users", Action: "GET"}}
+	authz.Middleware.Roles = []ir.Role{{Name: "admin", Permissions: []string{"user.read"}}}
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files["src/components/middleware-authz.middleware.rbac.ts"]; !ok {
+		t.Error("missing RBAC module")
+	}
+	enforcer := string(output.Files["src/components/middleware-authz.middleware.enforcer.ts"].Content)
+	for _, want := range []string{
+		"import { seedPolicies, seedGroupings } from './middleware-authz.middleware.rbac';",
+		"    const e = await newEnforcer(modelPath, adapter);\n    await seedPolicy(e);\n    return e;\n",
+		"    if (!(await e.hasPolicy(...rule))) {\n      await e.addPolicy(...rule);\n",
+	} {
+		if !strings.Contains(enforcer, want) {
+			t.Errorf("enforcer module missing %q\n%s", want, enforcer)
+		}
+	}
+}
+
+// withUsecaseAuthorization declares RBAC on the casbin middleware of
+// createTestIR and requires it for usecase.get-user.
+func withUsecaseAuthorization(i *ir.IR) {
+	authz := i.Components["middleware.authz"]
+	authz.Middleware.Permissions = []ir.Permission{{Name: "user.read", Object: "/users", Action: "GET"}}
+	authz.Middleware.Roles = []ir.Role{{Name: "admin", Permissions: []string{"user.read"}}}
+	i.Components["usecase.get-user"].Usecase.Authorization = &ir.AuthorizationSpec{
+		Roles:       []string{"admin"},
+		Permissions: []string{"user.read"},
+	}
+}
+
+func TestHonoServerGenerator_Generate_UsecaseAuthorization(t *testing.T) {
+	// given
+	i := newTestIR(withUsecaseAuthorization)
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	for _, want := range []string{
+		"import * as middlewareAuthzRBAC from './middleware-authz.middleware.rbac';\n",
+		"  app.get('/users/:id', async (c) => {\n" +
+			"    // Any of the roles and all of the permissions\n" +
+			"    const authorized = await middlewareAuthzRBAC.isAuthorized(c.get('enforcer'), c.get('auth')?.user?.id, {\n" +
+			"      roles: [middlewareAuthzRBAC.Role.Admin],\n" +
+			"      permissions: [middlewareAuthzRBAC.Permission.UserRead],\n" +
+			"    });\n" +
+			"    if (!authorized) {\n" +
+			"      return c.json({ error: 'Forbidden' }, 403);\n" +
+			"    }\n",
+		// The middleware of the route has set these, as its narrowed context type declares
+		"      auth: c.get('auth')!,\n      enforcer: c.get('enforcer')!,\n",
+	} {
+		if !strings.Contains(server, want) {
+			t.Errorf("server module missing %q\n%s", want, server)
+		}
+	}
+	if strings.Count(server, "isAuthorized(") != 1 {
+		t.Error("only the usecase declaring authorization should be checked")
+	}
+}
+
+func TestHonoServerGenerator_Generate_PolicyAdminRoute(t *testing.T) {
+	// given
+	i := createTestIR()
+	authz := i.Components["middleware.authz"]
+	authz.Middleware.AdminRoute = "/admin/policies"
+	authz.Middleware.DependsOn = []string{"middleware.authn"}
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	for _, want := range []string{
+		"import { getEffectivePolicies as middlewareAuthzPolicies } from './middleware-authz.middleware.enforcer';",
+		"  app.get('/admin/policies', async (c) => c.json(await middlewareAuthzPolicies()));",
+	} {
+		if !strings.Contains(server, want) {
+			t.Errorf("server file missing %q", want)
+		}
+	}
+	// The route is guarded by authz and by authn, which authz depends on.
+	if got := strings.Count(server, `{ method: 'GET', path: new RegExp("^/admin/policies$") }`); got != 2 {
+		t.Errorf("admin route appears in %d middleware matrix entries, want 2\n%s", got, server)
+	}
+}
+
+func TestHonoServerGenerator_Generate_BasePath(t *testing.T) {
+	// given
+	i := createTestIR()
+	i.Components["http.server.api"].HTTPServer.BasePath = "/api/v1"
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	for _, want := range []string{
+		"  const app = new Hono<Env>().basePath('/api/v1');",
+		"app.post('/users'",
+		"app.get('/health'",
+		`path: new RegExp("^/api/v1/users/[^/]+$")`,
+	} {
+		if !strings.Contains(server, want) {
+			t.Errorf("server file missing %q\n%s", want, server)
+		}
+	}
+}
+
+// withLimits caps every route of http.server.api and lowers the limits of
+// usecase.create-user.
+func withLimits(i *ir.IR) {
+	i.Components["http.server.api"].HTTPServer.Limits = &ir.Limits{TimeoutMS: 30000, MaxBodyKB: 1024}
+	i.Components["usecase.create-user"].Usecase.Limits = &ir.Limits{TimeoutMS: 5000, MaxBodyKB: 64}
+}
+
+func TestHonoServerGenerator_Generate_Limits(t *testing.T) {
+	// given
+	i := newTestIR(withLimits)
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	for _, want := range []string{
+		"import { bodyLimit } from 'hono/body-limit';\n",
+		"import { timeout } from 'hono/timeout';\n",
+		"  app.use('*', bodyLimit({ maxSize: 1024 * 1024, onError: (c) => c.json({ error: 'Payload Too Large' }, 413) }));\n" +
+			"  app.use('*', timeout(30000));\n",
+		"  app.post('/users', bodyLimit({ maxSize: 64 * 1024, onError: (c) => c.json({ error: 'Payload Too Large' }, 413) }), timeout(5000), async (c) => {\n",
+		"  app.get('/users/:id', async (c) => {\n",
+	} {
+		if !strings.Contains(server, want) {
+			t.Errorf("server file missing %q\n%s", want, server)
+		}
+	}
+}
+
+func TestHonoServerGenerator_Generate_NoLimits(t *testing.T) {
+	// given
+	i := createTestIR()
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	if strings.Contains(server, "bodyLimit") || strings.Contains(server, "timeout") {
+		t.Errorf("server without limits should not import limit middleware\n%s", server)
+	}
+}
+
+// withCache lets shared caches keep usecase.get-user for a minute.
+func withCache(i *ir.IR) {
+	i.Components["usecase.get-user"].Usecase.Cache = &ir.CacheSpec{
+		TTL:        60,
+		Vary:       []string{"Accept-Language", "Cookie"},
+		Visibility: ir.CachePublic,
+	}
+}
+
+func TestHonoServerGenerator_Generate_Cache(t *testing.T) {
+	// given
+	i := newTestIR(withCache)
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	want := "    c.header('Cache-Control', 'public, max-age=60');\n" +
+		"    c.header('Vary', 'Accept-Language, Cookie');\n" +
+		"    return c.json(result);\n"
+	if !strings.Contains(server, want) {
+		t.Errorf("server file missing %q\n%s", want, server)
+	}
+	if strings.Count(server, "Cache-Control") != 1 {
+		t.Error("only the cached route should set Cache-Control")
+	}
+}
+
+func TestHonoServerGenerator_Generate_APIDocs(t *testing.T) {
+	tests := []struct {
+		name    string
+		docs    *ir.APIDocsSpec
+		wantAll []string
+		wantNot []string
+	}{
+		{
+			name: "outside production",
+			docs: &ir.APIDocsSpec{UI: ir.APIDocsScalar, Path: "/docs"},
+			wantAll: []string{
+				"import { apiDocsPage, openapiDocument } from './http-server-api.docs';",
+				"  if (process.env.NODE_ENV !== 'production') {\n    app.get('/docs', (c) => c.html(apiDocsPage));\n",
+				"    app.get('/docs/openapi.yaml', (c) => c.body(openapiDocument, 200, { 'Content-Type': 'application/yaml' }));\n  }\n",
+			},
+		},
+		{
+			name:    "in production",
+			docs:    &ir.APIDocsSpec{UI: ir.APIDocsRedoc, Path: "/reference", Production: true},
+			wantAll: []string{"  // API reference\n  app.get('/reference', (c) => c.html(apiDocsPage));\n"},
+			wantNot: []string{"process.env.NODE_ENV"},
 		},
-		Dependencies: []*ir.Component{postgres, authn, authz},
 	}
 
-	createUser := &ir.Component{
-		ID:   "usecase.create-user",
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			i := createTestIR()
+			i.Components["http.server.api"].HTTPServer.APIDocs = tt.docs
+
+			// when
+			output, err := NewHonoServerGenerator().Generate(i)
+
+			// then
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+			for _, want := range tt.wantAll {
+				if !strings.Contains(server, want) {
+					t.Errorf("server file missing %q\n%s", want, server)
+				}
+			}
+			for _, unwanted := range tt.wantNot {
+				if strings.Contains(server, unwanted) {
+					t.Errorf("server file contains %q\n%s", unwanted, server)
+				}
+			}
+		})
+	}
+}
+
+func TestHonoServerGenerator_Generate_Deprecated(t *testing.T) {
+	// given
+	i := createTestIR()
+	i.Components["postgres.primary"].Deprecated = true
+	i.Components["postgres.primary"].Replacement = "postgres.users"
+	i.Components["usecase.create-user"].Deprecated = true
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	want := "  // Deprecated components\n" +
+		"  if (process.env.NODE_ENV !== 'production') {\n" +
+		"    console.warn(\"[deprecated] postgres.primary is deprecated; use postgres.users instead\");\n" +
+		"    console.warn(\"[deprecated] usecase.create-user is deprecated\");\n" +
+		"  }\n"
+	if !strings.Contains(server, want) {
+		t.Errorf("server file missing %q\n%s", want, server)
+	}
+}
+
+func TestHonoServerGenerator_Generate_CatchAll(t *testing.T) {
+	// given
+	i := createTestIR()
+	i.Components["usecase.proxy"] = &ir.Component{
+		ID:   "usecase.proxy",
 		Kind: ir.KindUsecase,
 		Usecase: &ir.UsecaseSpec{
-			BindsTo:    "http.server.api:POST:/users",
-			Middleware: []string{},
-			Goal:       "Create a new user",
-			Binding: &ir.Binding{
-				ServerID: "http.server.api",
-				Method:   "POST",
-				Path:     "/users",
-			},
+			Goal:    "Proxy requests",
+			Binding: &ir.Binding{ServerID: "http.server.api", Method: ir.MethodAll, Path: "/proxy/{tenant}/*"},
 		},
 	}
 
-	getUser := &ir.Component{
-		ID:   "usecase.get-user",
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	for _, want := range []string{
+		"  app.all('/proxy/:tenant/*', async (c) => {\n",
+		"    const input = {\n      request: c.req.raw,\n      tenant,\n    };\n",
+		"    return proxyUsecase(input, context);\n",
+		`{ method: 'ALL', path: new RegExp("^/proxy/[^/]+(?:/.*)?$") }`,
+		"if ((route.method === 'ALL' || route.method === method) && route.path.test(path)) {",
+	} {
+		if !strings.Contains(server, want) {
+			t.Errorf("server file missing %q\n%s", want, server)
+		}
+	}
+}
+
+func TestHonoPathToRegexLiteral(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/users/:id", `new RegExp("^/users/[^/]+$")`},
+		{"/files/*", `new RegExp("^/files(?:/.*)?$")`},
+		{"/*", `new RegExp("^(?:/.*)?$")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := honoPathToRegexLiteral(tt.path); got != tt.expected {
+				t.Errorf("honoPathToRegexLiteral(%q) = %s, expected %s", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+// withInputMapping binds a PATCH /users/{id} usecase that maps its input
+// from each request source.
+func withInputMapping(i *ir.IR) {
+	i.Components["usecase.update-user"] = &ir.Component{
+		ID:   "usecase.update-user",
 		Kind: ir.KindUsecase,
 		Usecase: &ir.UsecaseSpec{
-			BindsTo:    "http.server.api:GET:/users/{id}",
-			Middleware: []string{"middleware.authn", "middleware.authz"},
-			Goal:       "Get user by ID",
+			Goal: "Update a user",
 			Binding: &ir.Binding{
 				ServerID: "http.server.api",
-				Method:   "GET",
+				Method:   "PATCH",
 				Path:     "/users/{id}",
+				Operation: &openapi.Operation{
+					OperationID: "updateUser",
+					Parameters: []openapi.Parameter{
+						{Name: "id", In: "path", Required: true},
+						{Name: "notify", In: "query"},
+					},
+					RequestBody: &openapi.RequestBody{Content: map[string]*openapi.MediaType{
+						"application/json": {Schema: &openapi.Schema{
+							Type:     "object",
+							Required: []string{"email_address"},
+							Properties: map[string]*openapi.Schema{
+								"email_address": {Type: "string"},
+								"display-name":  {Type: "string"},
+								"age":           {Type: "string"},
+							},
+						}},
+					}},
+				},
+			},
+			InputMapping: []ir.InputMapping{
+				{Field: "age", Source: ir.InputFromBody, Name: "age", Transform: "number"},
+				{Field: "displayName", Source: ir.InputFromBody, Name: "display-name"},
+				{Field: "email", Source: ir.InputFromBody, Name: "email_address", Transform: "lowercase"},
+				{Field: "notify", Source: ir.InputFromQuery, Name: "notify"},
+				{Field: "userId", Source: ir.InputFromPath, Name: "id"},
 			},
 		},
 	}
+}
 
-	return &ir.IR{
-		Spec: &parser.Spec{
-			Name:    "test-api",
-			Version: "1.0.0",
-		},
-		Components: map[string]*ir.Component{
-			"http.server.api":     server,
-			"middleware.authn":    authn,
-			"middleware.authz":    authz,
-			"postgres.primary":    postgres,
-			"usecase.create-user": createUser,
-			"usecase.get-user":    getUser,
-		},
+func TestHonoServerGenerator_Generate_InputMapping(t *testing.T) {
+	// given
+	i := newTestIR(withInputMapping)
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	server := string(output.Files["src/components/http-server-api.server.ts"].Content)
+	want := "  app.patch('/users/:id', async (c) => {\n" +
+		"    const body = await c.req.json();\n" +
+		"    const input = {\n" +
+		"      age: body.age == null ? undefined : Number(body.age),\n" +
+		"      displayName: body['display-name'],\n" +
+		"      email: body.email_address?.toLowerCase(),\n" +
+		"      notify: c.req.query('notify'),\n" +
+		"      userId: c.req.param('id'),\n" +
+		"    };\n"
+	if !strings.Contains(server, want) {
+		t.Errorf("server file missing %q\n%s", want, server)
+	}
+	if !strings.Contains(server, "      result = await updateUserUsecase(input, context);\n") {
+		t.Errorf("route should pass the mapped input\n%s", server)
 	}
 }
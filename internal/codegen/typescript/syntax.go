@@ -0,0 +1,92 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNoSyntaxChecker is returned by NewSyntaxChecker when esbuild cannot be found.
+var ErrNoSyntaxChecker = errors.New("esbuild not found")
+
+// SyntaxChecker checks that TypeScript files parse, using esbuild's
+// transform, which strips types without type-checking or resolving imports.
+type SyntaxChecker struct {
+	esbuild string
+}
+
+// NewSyntaxChecker finds esbuild in the output directory's node_modules,
+// where tsx installs it, or on PATH.
+func NewSyntaxChecker(outputDir string) (*SyntaxChecker, error) {
+	local := filepath.Join(outputDir, "node_modules", ".bin", "esbuild")
+	if info, err := os.Stat(local); err == nil && !info.IsDir() {
+		return &SyntaxChecker{esbuild: local}, nil
+	}
+	if path, err := exec.LookPath("esbuild"); err == nil {
+		return &SyntaxChecker{esbuild: path}, nil
+	}
+	return nil, ErrNoSyntaxChecker
+}
+
+// Checks reports whether path is a TypeScript file.
+func (c *SyntaxChecker) Checks(path string) bool {
+	return strings.HasSuffix(path, ".ts") || strings.HasSuffix(path, ".tsx")
+}
+
+// Check returns the first syntax error in content as "path:line:column: message".
+func (c *SyntaxChecker) Check(path string, content []byte) error {
+	loader := "ts"
+	if strings.HasSuffix(path, ".tsx") {
+		loader = "tsx"
+	}
+	cmd := exec.Command(c.esbuild, "--loader="+loader, "--sourcefile="+path, "--log-level=error", "--color=false")
+	cmd.Stdin = bytes.NewReader(content)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return fmt.Errorf("failed to run esbuild: %w", err)
+		}
+		return parseEsbuildError(path, stderr.String())
+	}
+	return nil
+}
+
+// parseEsbuildError turns esbuild's first reported error, e.g.
+//
+//	✘ [ERROR] Expected ";" but found "x"
+//
+//	    src/index.ts:3:4:
+//
+// into "src/index.ts:3:4: Expected ";" but found "x"".
+func parseEsbuildError(path, stderr string) error {
+	message, location := "", ""
+	for _, line := range strings.Split(stderr, "\n") {
+		line = strings.TrimSpace(line)
+		if message == "" {
+			if _, msg, ok := strings.Cut(line, "[ERROR] "); ok {
+				message = msg
+			}
+			continue
+		}
+		if strings.HasPrefix(line, path+":") {
+			location = strings.TrimSuffix(line, ":")
+			break
+		}
+	}
+	if message == "" {
+		return fmt.Errorf("%s: %s", path, strings.TrimSpace(stderr))
+	}
+	if location == "" {
+		location = path
+	}
+	return fmt.Errorf("%s: %s", location, message)
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestParseEsbuildError(t *testing.T) {
+	tests := []struct {
+		name   string
+		stderr string
+		want   string
+	}{
+		{
+			name:   "message with location",
+			stderr: "✘ [ERROR] Expected \";\" but found \"x\"\n\n    src/index.ts:3:4:\n      3 │ let a b\n        ╵     ^\n\n1 error\n",
+			want:   `src/index.ts:3:4: Expected ";" but found "x"`,
+		},
+		{
+			name:   "message without location",
+			stderr: "✘ [ERROR] Unexpected end of file\n",
+			want:   "src/index.ts: Unexpected end of file",
+		},
+		{
+			name:   "unrecognized output",
+			stderr: "esbuild crashed\n",
+			want:   "src/index.ts: esbuild crashed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseEsbuildError("src/index.ts", tt.stderr).Error()
+			if got != tt.want {
+				t.Errorf("parseEsbuildError() = %q, expected %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyntaxChecker(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script in place of esbuild")
+	}
+	// A stand-in for esbuild that rejects input containing "broken"
+	out := t.TempDir()
+	bin := filepath.Join(out, "node_modules", ".bin")
+	if err := os.MkdirAll(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\nif grep -q broken; then printf '✘ [ERROR] Unexpected \"broken\"\\n\\n    src/a.ts:1:0:\\n' >&2; exit 1; fi\n"
+	if err := os.WriteFile(filepath.Join(bin, "esbuild"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	checker, err := NewSyntaxChecker(out)
+	if err != nil {
+		t.Fatalf("NewSyntaxChecker() error = %v", err)
+	}
+	if !checker.Checks("src/a.ts") || checker.Checks("package.json") {
+		t.Error("Checks() should apply to .ts files only")
+	}
+	if err := checker.Check("src/a.ts", []byte("export const a = 1;\n")); err != nil {
+		t.Errorf("Check() error = %v, expected nil", err)
+	}
+	err = checker.Check("src/a.ts", []byte("broken\n"))
+	if err == nil || err.Error() != `src/a.ts:1:0: Unexpected "broken"` {
+		t.Errorf("Check() error = %v, expected the esbuild error", err)
+	}
+}
@@ -428,9 +428,12 @@ func TestTestGenerator_Generate_NoComponents(t *testing.T) {
 		t.Error("should generate test setup file even with no components")
 	}
 
-	// Should have only 1 file (setup)
-	if len(output.Files) != 1 {
-		t.Errorf("expected 1 file (setup), got %d", len(output.Files))
+	// Should have only the shared files (setup, fixtures)
+	if _, ok := output.Files["src/test/fixtures.ts"]; !ok {
+		t.Error("should generate fixtures file even with no components")
+	}
+	if len(output.Files) != 2 {
+		t.Errorf("expected 2 files (setup, fixtures), got %d", len(output.Files))
 	}
 }
 
@@ -472,3 +475,94 @@ func TestTestGenerator_Generate_ServerWithAuthMiddleware(t *testing.T) {
 		t.Error("server test should mock enforcer for authz middleware")
 	}
 }
+
+func TestTestGenerator_Generate_ServerAuthorizationDenied(t *testing.T) {
+	// given
+	i := newTestIR(withUsecaseAuthorization)
+
+	// when
+	output, err := NewTestGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["src/components/http-server-api.server.test.ts"].Content)
+	expected := []string{
+		"import * as middlewareAuthzRBAC from './middleware-authz.middleware.rbac';\n",
+		"vi.mock('./middleware-authz.middleware.rbac', async (importOriginal) => ({\n",
+		"  isAuthorized: vi.fn().mockResolvedValue(true),\n",
+		"  it('should return 403 from GET /users/:id when the caller is not authorized', async () => {\n",
+		"    vi.mocked(middlewareAuthzRBAC.isAuthorized).mockResolvedValueOnce(false);\n",
+		"    expect(res.status).toBe(403);\n",
+		"    expect(vi.mocked(middlewareAuthzRBAC.isAuthorized).mock.lastCall?.[2]).toEqual({\n" +
+			"      roles: [middlewareAuthzRBAC.Role.Admin],\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("server test missing %q\n%s", want, content)
+		}
+	}
+	if strings.Count(content, "should return 403") != 1 {
+		t.Error("only the usecase declaring authorization should get a 403 test")
+	}
+}
+
+func TestTestGenerator_Generate_AcceptanceCriteriaTodos(t *testing.T) {
+	// given: usecase with acceptance criteria
+	i := &ir.IR{
+		Spec: &parser.Spec{Name: "test"},
+		Components: map[string]*ir.Component{
+			"usecase.create-user": {
+				ID:   "usecase.create-user",
+				Kind: ir.KindUsecase,
+				Usecase: &ir.UsecaseSpec{
+					Goal: "Create a new user",
+					AcceptanceCriteria: []string{
+						"User record is created",
+						"User's password is hashed",
+					},
+				},
+			},
+		},
+	}
+
+	// when
+	output, err := NewTestGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	content := string(output.Files["src/components/usecase-create-user.usecase.test.ts"].Content)
+	expected := []string{
+		"describe('acceptance criteria', () => {",
+		"it.todo('AC-usecase.create-user-1: User record is created');",
+		`it.todo('AC-usecase.create-user-2: User\'s password is hashed');`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("usecase test missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestTestGenerator_Generate_UnitTestsDisabled(t *testing.T) {
+	// given
+	i := createTestIR()
+	i.Components["usecase.get-user"].Usecase.Testing = &ir.TestingSpec{Unit: false, E2E: ir.E2ESmoke}
+
+	// when
+	output, err := NewTestGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files[usecaseTestPath("usecase.get-user")]; ok {
+		t.Error("unit test generated for usecase.get-user, which sets unit: false")
+	}
+	if _, ok := output.Files[usecaseTestPath("usecase.create-user")]; !ok {
+		t.Error("unit test missing for usecase.create-user")
+	}
+}
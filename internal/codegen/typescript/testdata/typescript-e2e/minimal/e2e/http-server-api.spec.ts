@@ -0,0 +1,12 @@
+// Generated by OpenBoundary - DO NOT EDIT
+import { test, expect } from '@playwright/test';
+
+const baseURL = 'http://localhost:8080';
+
+test.describe('http.server.api API', () => {
+  test('GET /health - health check', async ({ request }) => {
+    const response = await request.get(`${baseURL}/health`);
+    expect(response.status()).toBe(200);
+  });
+
+});
@@ -0,0 +1,188 @@
+// Generated by OpenBoundary - DO NOT EDIT
+import { Hono } from 'hono';
+import { metricsMiddleware, registry } from './http-server-api.metrics';
+import type { ServerContext } from './http-server-api.context';
+import { middlewareAuthnMiddleware } from './middleware-authn.middleware';
+import { middlewareAuthzMiddleware } from './middleware-authz.middleware';
+import { createUserUsecase } from './usecase-create-user.usecase';
+import { deleteUserUsecase } from './usecase-delete-user.usecase';
+import { getUserUsecase } from './usecase-get-user.usecase';
+import { listUsersUsecase } from './usecase-list-users.usecase';
+import { apiDocsPage, openapiDocument } from './http-server-api.docs';
+
+type MiddlewareRoute = { method: string; path: RegExp };
+const middlewareMatrix: Record<string, MiddlewareRoute[]> = {
+  "middleware.authn": [
+    { method: 'DELETE', path: new RegExp("^/users/[^/]+$") },
+    { method: 'GET', path: new RegExp("^/users/[^/]+$") },
+    { method: 'GET', path: new RegExp("^/users$") },
+  ],
+  "middleware.authz": [
+    { method: 'DELETE', path: new RegExp("^/users/[^/]+$") },
+    { method: 'GET', path: new RegExp("^/users/[^/]+$") },
+    { method: 'GET', path: new RegExp("^/users$") },
+  ],
+};
+
+function routeRequiresMiddleware(mwId: string, method: string, path: string): boolean {
+  const routes = middlewareMatrix[mwId];
+  if (!routes || routes.length === 0) return false;
+  for (const route of routes) {
+    if ((route.method === 'ALL' || route.method === method) && route.path.test(path)) {
+      return true;
+    }
+  }
+  return false;
+}
+
+/**
+ * Answers 501 with a route's example response while its usecase is not
+ * implemented yet and MOCK_UNIMPLEMENTED is true, so a partly implemented
+ * service can be demoed. Any other error is rethrown.
+ */
+function mockUnimplemented(error: unknown, example: unknown): Response {
+  if (process.env.MOCK_UNIMPLEMENTED === 'true' && error instanceof Error && error.message === 'Not implemented') {
+    return new Response(JSON.stringify(example ?? { error: 'Not Implemented' }), {
+      status: 501,
+      headers: { 'Content-Type': 'application/json' },
+    });
+  }
+  throw error;
+}
+
+type Env = {
+  Variables: ServerContext;
+};
+
+/**
+ * Creates the http.server.api Hono application.
+ * @param ctx - The server context with dependencies
+ */
+export function createHttpServerApiApp(ctx: ServerContext): Hono<Env> {
+  const app = new Hono<Env>();
+
+  // Set base context from dependencies
+  app.use('*', async (c, next) => {
+    c.set('db', ctx.db);
+    c.set('repositories', ctx.repositories);
+    await next();
+  });
+
+  // Record the duration and status of every route
+  app.use('*', metricsMiddleware);
+
+  // Health check
+  app.get('/health', (c) => c.json({ status: 'ok' }));
+
+  // Prometheus metrics
+  app.get('/metrics', async (c) => c.body(await registry.metrics(), 200, { 'Content-Type': registry.contentType }));
+
+  // API reference
+  if (process.env.NODE_ENV !== 'production') {
+    app.get('/docs', (c) => c.html(apiDocsPage));
+    app.get('/docs/openapi.yaml', (c) => c.body(openapiDocument, 200, { 'Content-Type': 'application/yaml' }));
+  }
+
+  // Apply server-level middleware only when required by the route
+  app.use('*', async (c, next) => {
+    if (!routeRequiresMiddleware("middleware.authn", c.req.method, c.req.path)) {
+      return next();
+    }
+    return middlewareAuthnMiddleware(c, next);
+  });
+  app.use('*', async (c, next) => {
+    if (!routeRequiresMiddleware("middleware.authz", c.req.method, c.req.path)) {
+      return next();
+    }
+    return middlewareAuthzMiddleware(c, next);
+  });
+
+  // Route handlers
+
+  // usecase.create-user - Register a new user account in the system
+  app.post('/users', async (c) => {
+    const body = await c.req.json();
+    const input = {
+      ...body,
+    };
+
+    const context = {
+      db: c.get('db'),
+      repositories: c.get('repositories'),
+    };
+
+    let result;
+    try {
+      result = await createUserUsecase(input, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.json(result, 201);
+  });
+
+  // usecase.delete-user - Remove a user account from the system
+  app.delete('/users/:id', async (c) => {
+    const id = c.req.param('id');
+    const input = {
+      id,
+    };
+
+    const context = {
+      db: c.get('db'),
+      repositories: c.get('repositories'),
+      auth: c.get('auth')!,
+      enforcer: c.get('enforcer')!,
+    };
+
+    let result;
+    try {
+      result = await deleteUserUsecase(input, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.body(null, 204);
+  });
+
+  // usecase.get-user - Retrieve a user's profile information
+  app.get('/users/:id', async (c) => {
+    const id = c.req.param('id');
+    const input = {
+      id,
+    };
+
+    const context = {
+      db: c.get('db'),
+      repositories: c.get('repositories'),
+      auth: c.get('auth')!,
+      enforcer: c.get('enforcer')!,
+    };
+
+    let result;
+    try {
+      result = await getUserUsecase(input, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.json(result);
+  });
+
+  // usecase.list-users - List all users with pagination
+  app.get('/users', async (c) => {
+    const context = {
+      db: c.get('db'),
+      repositories: c.get('repositories'),
+      auth: c.get('auth')!,
+      enforcer: c.get('enforcer')!,
+    };
+
+    let result;
+    try {
+      result = await listUsersUsecase(undefined as void, context);
+    } catch (error) {
+      return mockUnimplemented(error, undefined);
+    }
+    return c.json(result);
+  });
+
+  return app;
+}
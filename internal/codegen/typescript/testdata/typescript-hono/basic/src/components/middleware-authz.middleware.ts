@@ -0,0 +1,12 @@
+// Generated by OpenBoundary - DO NOT EDIT
+import { createMiddleware } from 'hono/factory';
+import { getEnforcer } from './middleware-authz.middleware.enforcer';
+
+export const middlewareAuthzMiddleware = createMiddleware(async (c, next) => {
+  const e = await getEnforcer();
+  c.set('enforcer', e);
+  // TODO: Implement authorization check
+  // const auth = c.get('auth');
+  // const allowed = await e.enforce(auth?.user?.id, c.req.path, c.req.method);
+  await next();
+});
@@ -0,0 +1,34 @@
+// Generated by OpenBoundary - DO NOT EDIT
+import { Hono } from 'hono';
+import type { ServerContext } from './http-server-api.context';
+import { apiDocsPage, openapiDocument } from './http-server-api.docs';
+
+type Env = {
+  Variables: ServerContext;
+};
+
+/**
+ * Creates the http.server.api Hono application.
+ * @param ctx - The server context with dependencies
+ */
+export function createHttpServerApiApp(ctx: ServerContext): Hono<Env> {
+  const app = new Hono<Env>();
+
+  // Set base context from dependencies
+  app.use('*', async (c, next) => {
+    await next();
+  });
+
+  // Health check
+  app.get('/health', (c) => c.json({ status: 'ok' }));
+
+  // API reference
+  if (process.env.NODE_ENV !== 'production') {
+    app.get('/docs', (c) => c.html(apiDocsPage));
+    app.get('/docs/openapi.yaml', (c) => c.body(openapiDocument, 200, { 'Content-Type': 'application/yaml' }));
+  }
+
+  // Route handlers
+
+  return app;
+}
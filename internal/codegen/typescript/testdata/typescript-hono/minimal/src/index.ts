@@ -0,0 +1,18 @@
+// Generated by OpenBoundary - DO NOT EDIT
+import { serve } from '@hono/node-server';
+import { createHttpServerApiApp } from './components/http-server-api.server';
+
+async function main() {
+  // Initialize dependencies
+
+  // Start http.server.api
+  const httpServerApiContext = {
+  };
+
+  const httpServerApiApp = createHttpServerApiApp(httpServerApiContext);
+  serve({ fetch: httpServerApiApp.fetch, port: Number(process.env.PORT ?? 8080) }, (info) => {
+    console.log(`http.server.api listening on http://localhost:${info.port}`);
+  });
+}
+
+main().catch(console.error);
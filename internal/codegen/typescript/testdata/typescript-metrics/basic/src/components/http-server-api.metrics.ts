@@ -0,0 +1,75 @@
+// Generated by OpenBoundary - DO NOT EDIT
+import type { MiddlewareHandler } from 'hono';
+import { Counter, Gauge, Histogram, Registry, collectDefaultMetrics } from 'prom-client';
+
+/** Metrics of http.server.api, served at /metrics. */
+export const registry = new Registry();
+collectDefaultMetrics({ register: registry });
+
+const labelNames = ['server', 'usecase', 'method', 'route'] as const;
+
+const requests = new Counter({
+  name: 'usecase_requests_total',
+  help: 'Requests answered by each usecase, by status',
+  labelNames: [...labelNames, 'status'] as const,
+  registers: [registry],
+});
+
+const duration = new Histogram({
+  name: 'usecase_request_duration_seconds',
+  help: 'Seconds each usecase took to answer, middleware included',
+  labelNames,
+  buckets: [0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.3, 0.5, 0.8, 1, 2.5, 5, 10],
+  registers: [registry],
+});
+
+const sloLatency = new Gauge({
+  name: 'usecase_slo_p99_latency_seconds',
+  help: 'Seconds within which each usecase answers 99% of requests',
+  labelNames: ['server', 'usecase'] as const,
+  registers: [registry],
+});
+sloLatency.set({ server: 'http.server.api', usecase: 'usecase.get-user' }, 0.3);
+sloLatency.set({ server: 'http.server.api', usecase: 'usecase.list-users' }, 0.8);
+
+const sloErrorBudget = new Gauge({
+  name: 'usecase_slo_error_budget_ratio',
+  help: 'Share of requests of each usecase that may fail with a 5xx status over 30 days',
+  labelNames: ['server', 'usecase'] as const,
+  registers: [registry],
+});
+sloErrorBudget.set({ server: 'http.server.api', usecase: 'usecase.get-user' }, 0.001);
+
+interface MetricRoute {
+  usecase: string;
+  method: string;
+  route: string;
+  path: RegExp;
+}
+
+const routes: MetricRoute[] = [
+  { usecase: 'usecase.create-user', method: 'POST', route: '/users', path: new RegExp("^/users$") },
+  { usecase: 'usecase.delete-user', method: 'DELETE', route: '/users/{id}', path: new RegExp("^/users/[^/]+$") },
+  { usecase: 'usecase.get-user', method: 'GET', route: '/users/{id}', path: new RegExp("^/users/[^/]+$") },
+  { usecase: 'usecase.list-users', method: 'GET', route: '/users', path: new RegExp("^/users$") },
+];
+
+/**
+ * Records the duration and status of the requests of every route. Requests
+ * are labeled with the route they match rather than their path, so IDs in
+ * paths do not become labels; requests no usecase serves are not recorded.
+ */
+export const metricsMiddleware: MiddlewareHandler = async (c, next) => {
+  const route = routes.find((r) => (r.method === 'ALL' || r.method === c.req.method) && r.path.test(c.req.path));
+  if (!route) {
+    return next();
+  }
+  const labels = { server: 'http.server.api', usecase: route.usecase, method: c.req.method, route: route.route };
+  const end = duration.startTimer(labels);
+  try {
+    await next();
+  } finally {
+    end();
+    requests.inc({ ...labels, status: String(c.res.status) });
+  }
+};
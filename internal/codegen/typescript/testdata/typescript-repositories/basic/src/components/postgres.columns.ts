@@ -0,0 +1,17 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// Columns tables spread into their pgTable definitions, e.g.
+//   pgTable('users', { id: uuid('id').primaryKey(), ...timestamps, ...softDelete })
+// The repositories set them: createdAt and updatedAt when a row is created or
+// updated, deletedAt when it is deleted, which they skip afterwards.
+import { timestamp } from 'drizzle-orm/pg-core';
+
+/** When a row was created and last updated. */
+export const timestamps = {
+  createdAt: timestamp('created_at', { withTimezone: true }).notNull().defaultNow(),
+  updatedAt: timestamp('updated_at', { withTimezone: true }).notNull().defaultNow(),
+};
+
+/** When a row was deleted, or null while it is not. */
+export const softDelete = {
+  deletedAt: timestamp('deleted_at', { withTimezone: true }),
+};
@@ -0,0 +1,16 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// Types and zod schemas derived from OpenAPI documents.
+
+import { z } from 'zod';
+
+export const CreateUserRequestSchema = z.record(z.unknown());
+export type CreateUserRequest = z.infer<typeof CreateUserRequestSchema>;
+
+export const CreateUserResponseSchema = z.record(z.unknown());
+export type CreateUserResponse = z.infer<typeof CreateUserResponseSchema>;
+
+export const GetUserResponseSchema = z.record(z.unknown());
+export type GetUserResponse = z.infer<typeof GetUserResponseSchema>;
+
+export const ListUsersResponseSchema = z.record(z.unknown());
+export type ListUsersResponse = z.infer<typeof ListUsersResponseSchema>;
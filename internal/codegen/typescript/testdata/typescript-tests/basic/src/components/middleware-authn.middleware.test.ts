@@ -0,0 +1,31 @@
+// Generated by OpenBoundary - DO NOT EDIT
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { middlewareAuthnMiddleware } from './middleware-authn.middleware';
+
+describe('middlewareAuthnMiddleware', () => {
+  it('should be a middleware function', () => {
+    expect(typeof middlewareAuthnMiddleware).toBe('function');
+  });
+
+  it('should set auth in context when session is valid', async () => {
+    // given
+    const mockHeaders = new Headers();
+    mockHeaders.set('Authorization', 'Bearer valid-token');
+    const mockCtx = {
+      set: vi.fn(),
+      req: {
+        raw: { headers: mockHeaders },
+        header: vi.fn().mockReturnValue('Bearer valid-token'),
+      },
+    };
+    const mockNext = vi.fn().mockResolvedValue(undefined);
+
+    // when
+    await middlewareAuthnMiddleware(mockCtx as any, mockNext);
+
+    // then - middleware should call next and set auth context
+    expect(mockNext).toHaveBeenCalled();
+    expect(mockCtx.set).toHaveBeenCalledWith('auth', expect.any(Object));
+  });
+
+});
@@ -0,0 +1,64 @@
+// Generated by OpenBoundary - DO NOT EDIT
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { deleteUserUsecase } from './usecase-delete-user.usecase';
+import { createMockContext } from '../test/setup';
+
+describe('deleteUserUsecase', () => {
+  let mockCtx: ReturnType<typeof createMockContext>;
+
+  beforeEach(() => {
+    mockCtx = createMockContext();
+    vi.clearAllMocks();
+  });
+
+  it('should be a function', () => {
+    expect(typeof deleteUserUsecase).toBe('function');
+  });
+
+  it('should return a promise', () => {
+    // given
+    const input = {};
+
+    // when
+    const result = deleteUserUsecase(input as any, mockCtx);
+
+    // then
+    expect(result).toBeInstanceOf(Promise);
+  });
+
+  it('should throw NotImplemented error by default', async () => {
+    // given
+    const input = {};
+
+    // when/then
+    await expect(deleteUserUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  it('should accept path parameters in input', async () => {
+    // given
+    const input = {
+      id: 'test-id',
+    };
+
+    // when/then - should accept input shape without type error
+    await expect(deleteUserUsecase(input as any, mockCtx)).rejects.toThrow('Not implemented');
+  });
+
+  it('should have access to auth context', async () => {
+    // given
+    const input = {};
+    const ctxWithAuth = {
+      ...mockCtx,
+      auth: { userId: 'user-123', session: {} },
+    };
+
+    // when/then - context should include auth
+    expect(ctxWithAuth.auth).toBeDefined();
+    expect(ctxWithAuth.auth.userId).toBe('user-123');
+  });
+
+  describe('acceptance criteria', () => {
+    it.todo('AC-usecase.delete-user-1: User record is soft-deleted');
+    it.todo('AC-usecase.delete-user-2: Associated data is marked for cleanup');
+  });
+});
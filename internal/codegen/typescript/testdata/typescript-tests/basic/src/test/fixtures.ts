@@ -0,0 +1,14 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// Deterministic example data derived from OpenAPI schemas, shared by unit and E2E tests.
+
+/** Example values for each components/schemas entry. */
+export const schemaFixtures = {
+};
+
+/** Example request bodies, keyed by usecase function name. */
+export const requestFixtures = {
+};
+
+/** Example success responses, keyed by usecase function name. */
+export const responseFixtures = {
+};
@@ -0,0 +1,44 @@
+// Generated by OpenBoundary - DO NOT EDIT
+import type { DeleteUserUsecaseContext } from './http-server-api.context';
+
+/** Input with path parameters */
+export interface DeleteUserUsecaseInput {
+  id: string;
+}
+
+/**
+ * Remove a user account from the system
+ *
+ * @actor admin
+ *
+ * Preconditions:
+ * - User is authenticated
+ * - User has admin role
+ * - Target user exists
+ *
+ * Acceptance Criteria:
+ * - User record is soft-deleted
+ * - Associated data is marked for cleanup
+ *
+ * Postconditions:
+ * - User no longer appears in active user lists
+ */
+export async function deleteUserUsecase(
+  input: DeleteUserUsecaseInput,
+  ctx: DeleteUserUsecaseContext
+): Promise<void> {
+  // TODO: Implement usecase
+  //
+  // Preconditions to check:
+  //   - User is authenticated
+  //   - User has admin role
+  //   - Target user exists
+  //
+  // Implementation should satisfy:
+  //   - User record is soft-deleted
+  //   - Associated data is marked for cleanup
+  //
+  // Example: const row = await ctx.repositories.users.findById(id);
+
+  throw new Error('Not implemented');
+}
@@ -0,0 +1,40 @@
+// Generated by OpenBoundary - DO NOT EDIT
+import type { GetUserUsecaseContext } from './http-server-api.context';
+import type { GetUserResponse } from './usecase.schemas';
+
+/** Input with path parameters */
+export interface GetUserUsecaseInput {
+  id: string;
+}
+
+/**
+ * Retrieve a user's profile information
+ *
+ * @actor authenticated_user
+ *
+ * Preconditions:
+ * - User is authenticated
+ * - User has permission to view the requested profile
+ *
+ * Acceptance Criteria:
+ * - Returns user profile data
+ * - Excludes sensitive fields (password hash)
+ */
+export async function getUserUsecase(
+  input: GetUserUsecaseInput,
+  ctx: GetUserUsecaseContext
+): Promise<GetUserResponse> {
+  // TODO: Implement usecase
+  //
+  // Preconditions to check:
+  //   - User is authenticated
+  //   - User has permission to view the requested profile
+  //
+  // Implementation should satisfy:
+  //   - Returns user profile data
+  //   - Excludes sensitive fields (password hash)
+  //
+  // Example: const row = await ctx.repositories.users.findById(id);
+
+  throw new Error('Not implemented');
+}
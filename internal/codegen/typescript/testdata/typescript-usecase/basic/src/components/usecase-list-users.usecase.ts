@@ -0,0 +1,35 @@
+// Generated by OpenBoundary - DO NOT EDIT
+import type { ListUsersUsecaseContext } from './http-server-api.context';
+import type { ListUsersResponse } from './usecase.schemas';
+
+/**
+ * List all users with pagination
+ *
+ * @actor admin
+ *
+ * Preconditions:
+ * - User is authenticated
+ * - User has admin role
+ *
+ * Acceptance Criteria:
+ * - Returns paginated list of users
+ * - Supports limit and offset parameters
+ */
+export async function listUsersUsecase(
+  input: void,
+  ctx: ListUsersUsecaseContext
+): Promise<ListUsersResponse> {
+  // TODO: Implement usecase
+  //
+  // Preconditions to check:
+  //   - User is authenticated
+  //   - User has admin role
+  //
+  // Implementation should satisfy:
+  //   - Returns paginated list of users
+  //   - Supports limit and offset parameters
+  //
+  // Example: const row = await ctx.repositories.users.findById(id);
+
+  throw new Error('Not implemented');
+}
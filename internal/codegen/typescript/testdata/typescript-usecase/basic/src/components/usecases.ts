@@ -0,0 +1,7 @@
+// Generated by OpenBoundary - DO NOT EDIT
+// Re-exports all usecases for convenient importing
+
+export { createUserUsecase } from './usecase-create-user.usecase';
+export { deleteUserUsecase } from './usecase-delete-user.usecase';
+export { getUserUsecase } from './usecase-get-user.usecase';
+export { listUsersUsecase } from './usecase-list-users.usecase';
@@ -0,0 +1,113 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+// testIRFeature adds a feature to a test IR, such as a component or a
+// setting of one of createTestIR's components.
+type testIRFeature func(i *ir.IR)
+
+// newTestIR returns createTestIR's service with features applied in order.
+func newTestIR(features ...testIRFeature) *ir.IR {
+	i := createTestIR()
+	for _, feature := range features {
+		feature(i)
+	}
+	return i
+}
+
+// createTestIR returns the service the generator tests start from: an API
+// server behind better-auth and casbin, backed by postgres, that creates
+// and gets users.
+func createTestIR() *ir.IR {
+	postgres := &ir.Component{
+		ID:   "postgres.primary",
+		Kind: ir.KindPostgres,
+		Postgres: &ir.PostgresSpec{
+			Provider: "drizzle",
+			Schema:   "./src/db/schema.ts",
+		},
+	}
+
+	authn := &ir.Component{
+		ID:   "middleware.authn",
+		Kind: ir.KindMiddleware,
+		Middleware: &ir.MiddlewareSpec{
+			Provider: "better-auth",
+			Config:   "./auth.config.ts",
+		},
+	}
+
+	authz := &ir.Component{
+		ID:   "middleware.authz",
+		Kind: ir.KindMiddleware,
+		Middleware: &ir.MiddlewareSpec{
+			Provider: "casbin",
+			Model:    "./model.conf",
+			Policy:   "./policy.csv",
+		},
+		Dependencies: []*ir.Component{authn},
+	}
+
+	server := &ir.Component{
+		ID:   "http.server.api",
+		Kind: ir.KindHTTPServer,
+		HTTPServer: &ir.HTTPServerSpec{
+			Framework:  "hono",
+			Port:       3000,
+			Middleware: []string{"middleware.authn", "middleware.authz"},
+			DependsOn:  []string{"postgres.primary"},
+		},
+		Dependencies: []*ir.Component{postgres, authn, authz},
+	}
+
+	createUser := &ir.Component{
+		ID:   "usecase.create-user",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			BindsTo:    "http.server.api:POST:/users",
+			Middleware: []string{},
+			Goal:       "Create a new user",
+			Binding: &ir.Binding{
+				ServerID: "http.server.api",
+				Method:   "POST",
+				Path:     "/users",
+			},
+		},
+	}
+
+	getUser := &ir.Component{
+		ID:   "usecase.get-user",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			BindsTo:    "http.server.api:GET:/users/{id}",
+			Middleware: []string{"middleware.authn", "middleware.authz"},
+			Goal:       "Get user by ID",
+			Binding: &ir.Binding{
+				ServerID: "http.server.api",
+				Method:   "GET",
+				Path:     "/users/{id}",
+			},
+		},
+	}
+
+	return &ir.IR{
+		Spec: &parser.Spec{
+			Name:    "test-api",
+			Version: "1.0.0",
+		},
+		Components: map[string]*ir.Component{
+			"http.server.api":     server,
+			"middleware.authn":    authn,
+			"middleware.authz":    authz,
+			"postgres.primary":    postgres,
+			"usecase.create-user": createUser,
+			"usecase.get-user":    getUser,
+		},
+	}
+}
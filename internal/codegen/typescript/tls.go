@@ -0,0 +1,142 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// tlsPath is the module that loads the certificates of servers serving HTTPS.
+const tlsPath = "src/tls.ts"
+
+// tlsCertsDir is where the app container of docker-compose finds the
+// certificates, mounted read-only from ./certs.
+const tlsCertsDir = "/app/certs"
+
+// hasTLSServers reports whether any server serves HTTPS.
+func hasTLSServers(i *ir.IR) bool {
+	for _, server := range i.HTTPServers() {
+		if server.HTTPServer.TLS != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSelfSignedServers reports whether any server may generate a
+// self-signed certificate in development.
+func hasSelfSignedServers(i *ir.IR) bool {
+	for _, server := range i.HTTPServers() {
+		if isSelfSigned(server) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSelfSigned reports whether a server may generate a self-signed
+// certificate in development, which clients do not trust.
+func isSelfSigned(server *ir.Component) bool {
+	return server.HTTPServer.TLS != nil && server.HTTPServer.TLS.SelfSigned
+}
+
+// serverScheme returns "https" for a server serving HTTPS, otherwise "http".
+func serverScheme(server *ir.Component) string {
+	if server.HTTPServer.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// serverOrigin returns the origin a server listens on in development, e.g.
+// "https://localhost:3000".
+func serverOrigin(server *ir.Component) string {
+	return fmt.Sprintf("%s://localhost:%d", serverScheme(server), serverPort(server))
+}
+
+// firstServerOrigin returns the origin of the first server, or the default
+// one when there is none.
+func firstServerOrigin(i *ir.IR) string {
+	if servers := i.HTTPServers(); len(servers) > 0 {
+		return serverOrigin(servers[0])
+	}
+	return "http://localhost:3000"
+}
+
+// firstServerSelfSigned reports whether the first server, which the E2E
+// tests and the container health check reach, may serve a self-signed
+// certificate.
+func firstServerSelfSigned(i *ir.IR) bool {
+	servers := i.HTTPServers()
+	return len(servers) > 0 && isSelfSigned(servers[0])
+}
+
+// tlsCertFile and tlsKeyFile return where the app container of
+// docker-compose reads a server's certificate and key from.
+func tlsCertFile(server *ir.Component) string {
+	return fmt.Sprintf("%s/%s.crt", tlsCertsDir, componentIDSlug(server.ID))
+}
+
+func tlsKeyFile(server *ir.Component) string {
+	return fmt.Sprintf("%s/%s.key", tlsCertsDir, componentIDSlug(server.ID))
+}
+
+// writeHTTPSOptions writes the constant holding the options that make a
+// server serve HTTPS, named e.g. httpServerApiHttps.
+func writeHTTPSOptions(sb *strings.Builder, server *ir.Component) string {
+	tls := server.HTTPServer.TLS
+	name := toCamelCase(server.ID) + "Https"
+	fmt.Fprintf(sb, "  const %s = await httpsOptions({ server: '%s', certEnv: '%s', keyEnv: '%s', selfSigned: %t });\n",
+		name, server.ID, tls.CertEnv, tls.KeyEnv, tls.SelfSigned)
+	return name
+}
+
+// generateTLSModule generates the module that loads the certificate and key
+// of a server serving HTTPS, or generates a self-signed pair in development.
+func generateTLSModule() string {
+	var sb strings.Builder
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("// Loads the certificates of servers serving HTTPS. A server reads its\n")
+	sb.WriteString("// certificate and key from the files its variables point to. Outside\n")
+	sb.WriteString("// production a server with self_signed generates a certificate for\n")
+	sb.WriteString("// localhost when they are unset; production never serves one.\n")
+	sb.WriteString("import { readFileSync } from 'fs';\n")
+	sb.WriteString("import { createServer } from 'https';\n\n")
+
+	sb.WriteString("/** Where a server finds its certificate and key. */\n")
+	sb.WriteString("export interface TlsConfig {\n")
+	sb.WriteString("  server: string;\n")
+	sb.WriteString("  certEnv: string;\n")
+	sb.WriteString("  keyEnv: string;\n")
+	sb.WriteString("  selfSigned: boolean;\n")
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("/** Returns the options that make @hono/node-server serve HTTPS. */\n")
+	sb.WriteString("export async function httpsOptions(config: TlsConfig) {\n")
+	sb.WriteString("  const certFile = process.env[config.certEnv];\n")
+	sb.WriteString("  const keyFile = process.env[config.keyEnv];\n")
+	sb.WriteString("  if (certFile && keyFile) {\n")
+	sb.WriteString("    return { createServer, serverOptions: { cert: readFileSync(certFile), key: readFileSync(keyFile) } };\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("  if (!config.selfSigned || process.env.NODE_ENV === 'production') {\n")
+	sb.WriteString("    throw new Error(`${config.server} serves HTTPS: set ${config.certEnv} and ${config.keyEnv} to its certificate and key files`);\n")
+	sb.WriteString("  }\n\n")
+	sb.WriteString("  // selfsigned is a dev dependency, so it is only loaded here\n")
+	sb.WriteString("  const { generate } = await import('selfsigned');\n")
+	sb.WriteString("  const pems = generate([{ name: 'commonName', value: 'localhost' }], {\n")
+	sb.WriteString("    days: 30,\n")
+	sb.WriteString("    keySize: 2048,\n")
+	sb.WriteString("    extensions: [{ name: 'subjectAltName', altNames: [{ type: 2, value: 'localhost' }, { type: 7, ip: '127.0.0.1' }] }],\n")
+	sb.WriteString("  });\n")
+	sb.WriteString("  console.warn(`${config.server} serves a self-signed certificate; set ${config.certEnv} and ${config.keyEnv} to use your own`);\n")
+	sb.WriteString("  return { createServer, serverOptions: { cert: pems.cert, key: pems.private } };\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// withTLS makes http.server.api serve HTTPS, generating a self-signed
+// certificate in development when selfSigned is set.
+func withTLS(selfSigned bool) testIRFeature {
+	return func(i *ir.IR) {
+		i.Components["http.server.api"].HTTPServer.TLS = &ir.TLSSpec{
+			CertEnv:    "API_TLS_CERT",
+			KeyEnv:     "API_TLS_KEY",
+			SelfSigned: selfSigned,
+		}
+	}
+}
+
+func TestHonoServerGenerator_Generate_TLS(t *testing.T) {
+	// given
+	i := newTestIR(withTLS(true))
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	tls, ok := output.Files["src/tls.ts"]
+	if !ok {
+		t.Fatal("missing src/tls.ts")
+	}
+	for _, want := range []string{
+		"  if (!config.selfSigned || process.env.NODE_ENV === 'production') {\n",
+		"  const { generate } = await import('selfsigned');\n",
+	} {
+		if !strings.Contains(string(tls.Content), want) {
+			t.Errorf("tls.ts missing %q\n%s", want, tls.Content)
+		}
+	}
+	index := string(output.Files["src/index.ts"].Content)
+	for _, want := range []string{
+		"import { httpsOptions } from './tls';\n",
+		"  const httpServerApiHttps = await httpsOptions({ server: 'http.server.api', certEnv: 'API_TLS_CERT', keyEnv: 'API_TLS_KEY', selfSigned: true });\n",
+		"...httpServerApiHttps }, (info) => {\n",
+		"    console.log(`http.server.api listening on https://localhost:${info.port}`);\n",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index.ts missing %q\n%s", want, index)
+		}
+	}
+}
+
+func TestHonoServerGenerator_Generate_NoTLS(t *testing.T) {
+	// given
+	i := createTestIR()
+
+	// when
+	output, err := NewHonoServerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files["src/tls.ts"]; ok {
+		t.Error("tls module generated without servers serving HTTPS")
+	}
+	if index := string(output.Files["src/index.ts"].Content); strings.Contains(index, "https") {
+		t.Errorf("index.ts serves HTTPS without tls\n%s", index)
+	}
+}
+
+func TestProjectGenerator_TLS(t *testing.T) {
+	tests := []struct {
+		name       string
+		selfSigned bool
+		wantDep    bool
+	}{
+		{name: "self-signed", selfSigned: true, wantDep: true},
+		{name: "certificate files only", selfSigned: false, wantDep: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			i := newTestIR(withTLS(tt.selfSigned))
+
+			// when
+			output, err := NewProjectGenerator().Generate(i)
+
+			// then
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			pkg := string(output.Files["package.json"].Content)
+			if got := strings.Contains(pkg, `"selfsigned"`); got != tt.wantDep {
+				t.Errorf("package.json has selfsigned = %v, expected %v\n%s", got, tt.wantDep, pkg)
+			}
+		})
+	}
+}
+
+func TestSchemaGenerator_generateEnvExample_TLS(t *testing.T) {
+	// given
+	i := newTestIR(withTLS(false))
+
+	// when
+	env := NewSchemaGenerator().generateEnvExample(i)
+
+	// then
+	for _, want := range []string{
+		"API_TLS_CERT=./certs/http-server-api.crt\n",
+		"API_TLS_KEY=./certs/http-server-api.key\n",
+		"BASE_URL=https://localhost:3000\n",
+	} {
+		if !strings.Contains(env, want) {
+			t.Errorf(".env.example missing %q\n%s", want, env)
+		}
+	}
+}
+
+func TestDockerGenerator_TLS(t *testing.T) {
+	// given
+	i := newTestIR(withTLS(true))
+
+	// when
+	output, err := NewDockerGenerator().Generate(i)
+
+	// then: production reads the mounted certificates
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	compose := string(output.Files["docker-compose.yml"].Content)
+	for _, want := range []string{
+		"      API_TLS_CERT: /app/certs/http-server-api.crt\n",
+		"      API_TLS_KEY: /app/certs/http-server-api.key\n",
+		"    volumes:\n      - ./certs:/app/certs:ro\n",
+	} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yml missing %q\n%s", want, compose)
+		}
+	}
+	dockerfile := string(output.Files["Dockerfile"].Content)
+	if want := "require('https').get({ host: 'localhost', port: process.env.PORT || 3000, path: '/health', rejectUnauthorized: false }"; !strings.Contains(dockerfile, want) {
+		t.Errorf("Dockerfile missing %q\n%s", want, dockerfile)
+	}
+}
+
+func TestE2ETestGenerator_TLS(t *testing.T) {
+	// given
+	i := newTestIR(withTLS(true))
+
+	// when
+	output, err := NewE2ETestGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	config := string(output.Files["playwright.config.ts"].Content)
+	if want := "    baseURL: process.env.BASE_URL || 'https://localhost:3000',\n    ignoreHTTPSErrors: true,\n"; !strings.Contains(config, want) {
+		t.Errorf("playwright.config.ts missing %q\n%s", want, config)
+	}
+	spec := string(output.Files["e2e/http-server-api.spec.ts"].Content)
+	if want := "'https://localhost:3000'"; !strings.Contains(spec, want) {
+		t.Errorf("E2E spec missing %q\n%s", want, spec)
+	}
+	setup := string(output.Files["e2e/global-setup.ts"].Content)
+	for _, want := range []string{
+		"const healthURL = 'https://localhost:3000/health';\n",
+		"process.env.NODE_TLS_REJECT_UNAUTHORIZED = '0';\n",
+	} {
+		if !strings.Contains(setup, want) {
+			t.Errorf("global setup missing %q\n%s", want, setup)
+		}
+	}
+}
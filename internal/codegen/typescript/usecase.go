@@ -27,29 +27,33 @@ func (g *UsecaseGenerator) Name() string {
 
 // Generate produces usecase files from the IR.
 func (g *UsecaseGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
-	output := codegen.NewOutput()
-
-	// Generate usecase files for each usecase component
-	for _, comp := range i.Components {
-		if comp.Kind != ir.KindUsecase || comp.Usecase == nil {
-			continue
-		}
+	return codegen.GenerateComponents(g, i)
+}
 
-		usecaseCode := g.generateUsecase(i, comp)
-		output.AddComponentFile(usecaseSourcePath(comp.ID), []byte(usecaseCode), comp.ID)
+// GenerateShared produces the index file that exports all usecases.
+func (g *UsecaseGenerator) GenerateShared(i *ir.IR) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	output.AddFile(usecaseIndexPath(), []byte(g.generateIndex(i)))
+	if hasBulkUsecases(i) {
+		output.AddFile(usecaseBulkPath(), []byte(generateBulkModule()))
 	}
+	return output, nil
+}
 
-	// Generate index file that exports all usecases
-	indexCode := g.generateIndex(i)
-	output.AddFile(usecaseIndexPath(), []byte(indexCode))
-
+// GenerateComponent produces the file of a usecase component.
+func (g *UsecaseGenerator) GenerateComponent(i *ir.IR, comp *ir.Component) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	if comp.Kind == ir.KindUsecase && comp.Usecase != nil {
+		output.AddComponentFile(usecaseSourcePath(comp.ID), []byte(g.generateUsecase(i, comp)), comp.ID)
+	}
 	return output, nil
 }
 
 func (g *UsecaseGenerator) generateUsecase(i *ir.IR, uc *ir.Component) string {
 	var sb strings.Builder
 
-	sb.WriteString("// Generated by OpenBoundary - DO NOT EDIT\n")
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString(componentHeader(uc))
 
 	// Determine which server this usecase is bound to
 	var server *ir.Component
@@ -57,10 +61,10 @@ func (g *UsecaseGenerator) generateUsecase(i *ir.IR, uc *ir.Component) string {
 		server = i.Components[uc.Usecase.Binding.ServerID]
 	}
 
-	// Import context type from the server (colocated with servers)
+	// Import the usecase's context type from the server (colocated with servers)
 	if server != nil {
-		sb.WriteString(fmt.Sprintf("import type { ContextWith } from './%s.context';\n",
-			componentIDSlug(server.ID)))
+		sb.WriteString(fmt.Sprintf("import type { %s } from './%s.context';\n",
+			usecaseContextTypeName(uc), componentIDSlug(server.ID)))
 	}
 
 	// Determine type names based on OpenAPI operation
@@ -74,10 +78,21 @@ func (g *UsecaseGenerator) generateUsecase(i *ir.IR, uc *ir.Component) string {
 		}
 	}
 
-	// Build imports from Orval-generated schemas
+	// Catch-all routes hand the raw request to the usecase, which answers it
+	catchAll := uc.Usecase.Binding != nil && uc.Usecase.Binding.IsCatchAll()
+	// Bulk routes pass the items of the request body and the path parameters
+	bulk := isBulk(uc)
+	// input_mapping replaces the request body and path parameters as input
+	var mapped []mappedInputField
+	if !catchAll && !bulk && len(uc.Usecase.InputMapping) > 0 {
+		mapped = mappedInputFields(i, uc)
+	}
+
+	// Build imports from the generated usecase schemas
 	schemaImports := []string{}
 	inputTypeName := "void"
 	outputTypeName := "void"
+	requestTypeName := ""
 
 	if operationID != "" {
 		pascalOp := toPascalCase(operationID)
@@ -85,7 +100,10 @@ func (g *UsecaseGenerator) generateUsecase(i *ir.IR, uc *ir.Component) string {
 		// Request type for POST/PUT/PATCH
 		if method == "post" || method == "put" || method == "patch" {
 			inputTypeName = pascalOp + "Request"
-			schemaImports = append(schemaImports, inputTypeName)
+			requestTypeName = inputTypeName
+			if mapped == nil || mapsRequestType(mapped) {
+				schemaImports = append(schemaImports, inputTypeName)
+			}
 		}
 
 		// Response type (except for 204 No Content)
@@ -98,17 +116,74 @@ func (g *UsecaseGenerator) generateUsecase(i *ir.IR, uc *ir.Component) string {
 	// Add path params to input type
 	pathParams := []string{}
 	if uc.Usecase.Binding != nil {
-		pathParams = extractPathParams(uc.Usecase.Binding.Path)
+		pathParams = uc.Usecase.Binding.PathParams()
 	}
 
-	// Import from Orval schemas (colocated with usecases)
+	// Import from the generated schemas (colocated with usecases)
 	if len(schemaImports) > 0 {
 		sb.WriteString(fmt.Sprintf("import type { %s } from './usecase.schemas';\n", strings.Join(schemaImports, ", ")))
 	}
+	if bulk {
+		sb.WriteString("import type { BulkResult } from './usecase.bulk';\n")
+	}
 	sb.WriteString("\n")
 
+	if bulk {
+		items := "unknown[]"
+		if requestTypeName != "" {
+			items = requestTypeName
+		}
+		inputTypeName = toPascalCase(funcName) + "Input"
+		sb.WriteString("/** Input of a bulk route: the items of the request body and the path parameters */\n")
+		sb.WriteString(fmt.Sprintf("export interface %s {\n", inputTypeName))
+		sb.WriteString(fmt.Sprintf("  items: %s;\n", items))
+		for _, param := range pathParams {
+			sb.WriteString(fmt.Sprintf("  %s: string;\n", param))
+		}
+		sb.WriteString("}\n\n")
+
+		// Each item's result has the type of the operation's response
+		result := "unknown"
+		if outputTypeName != "void" {
+			result = outputTypeName
+		}
+		outputTypeName = "BulkResult<" + result + ">"
+	}
+
+	if catchAll {
+		inputTypeName = toPascalCase(funcName) + "Input"
+		outputTypeName = "Response"
+		sb.WriteString("/** Input of a catch-all route: the request and its path parameters */\n")
+		sb.WriteString(fmt.Sprintf("export interface %s {\n", inputTypeName))
+		sb.WriteString("  request: Request;\n")
+		for _, param := range pathParams {
+			sb.WriteString(fmt.Sprintf("  %s: string;\n", param))
+		}
+		sb.WriteString("}\n\n")
+	}
+
+	// The input has exactly the mapped fields
+	if mapped != nil {
+		inputTypeName = toPascalCase(funcName) + "Input"
+		sb.WriteString("/** Input read from the request as input_mapping declares */\n")
+		sb.WriteString(fmt.Sprintf("export interface %s {\n", inputTypeName))
+		for _, f := range mapped {
+			sb.WriteString(fmt.Sprintf("  /** From %s.%s", f.Source, f.Name))
+			if f.Transform != "" {
+				sb.WriteString(", " + f.Transform)
+			}
+			sb.WriteString(" */\n")
+			optional := ""
+			if f.Optional {
+				optional = "?"
+			}
+			sb.WriteString(fmt.Sprintf("  %s%s: %s;\n", f.Field, optional, f.tsType(requestTypeName)))
+		}
+		sb.WriteString("}\n\n")
+	}
+
 	// Generate combined input type if we have path params
-	if len(pathParams) > 0 {
+	if len(pathParams) > 0 && !catchAll && !bulk && mapped == nil {
 		localInputTypeName := toPascalCase(funcName) + "Input"
 		if inputTypeName != "void" {
 			// Combine path params with request body
@@ -134,6 +209,20 @@ func (g *UsecaseGenerator) generateUsecase(i *ir.IR, uc *ir.Component) string {
 		sb.WriteString(fmt.Sprintf(" * @actor %s\n", uc.Usecase.Actor))
 	}
 
+	if isAsync(uc) {
+		sb.WriteString(fmt.Sprintf(" * Runs as a job: the route answers 202 and GET %s\n", uc.Usecase.JobStatus().Path))
+		sb.WriteString(" * reports the result once it returns.\n")
+	}
+
+	if a := uc.Usecase.Authorization; a != nil {
+		for _, r := range a.Roles {
+			sb.WriteString(fmt.Sprintf(" * @requires Role.%s\n", rbacConstantName(r)))
+		}
+		for _, p := range a.Permissions {
+			sb.WriteString(fmt.Sprintf(" * @requires Permission.%s\n", rbacConstantName(p)))
+		}
+	}
+
 	if len(uc.Usecase.Preconditions) > 0 {
 		sb.WriteString(" *\n * Preconditions:\n")
 		for _, pre := range uc.Usecase.Preconditions {
@@ -157,9 +246,11 @@ func (g *UsecaseGenerator) generateUsecase(i *ir.IR, uc *ir.Component) string {
 
 	sb.WriteString(" */\n")
 
-	// Generate context type based on usecase needs
-	contextFields := contextFieldsForUsecase(i, uc, server)
-	contextType := g.contextTypeForFields(contextFields)
+	// The server's context file declares the usecase's context type
+	contextType := "ContextWith<never>"
+	if server != nil {
+		contextType = usecaseContextTypeName(uc)
+	}
 
 	// Generate the function signature
 	sb.WriteString(fmt.Sprintf("export async function %s(\n", funcName))
@@ -187,11 +278,18 @@ func (g *UsecaseGenerator) generateUsecase(i *ir.IR, uc *ir.Component) string {
 		sb.WriteString("  //\n")
 	}
 
+	if bulk {
+		sb.WriteString("  // Handle the items in chunks; throw a BulkItemError to fail a single item:\n")
+		sb.WriteString(fmt.Sprintf("  //   return processInChunks(input.items, %d, async (item, index) => { ... });\n", bulkChunkSize))
+		sb.WriteString("  //\n")
+	}
+
 	// Add example database access
-	for _, field := range contextFields {
-		if field == "db" {
-			sb.WriteString("  // Example: const result = await ctx.db.query.users.findFirst(...);\n\n")
-			break
+	if dbs := usecasePostgresDependencies(i, uc, server); len(dbs) > 0 {
+		if tables := drizzleTables(i, dbs[0]); len(tables) > 0 {
+			sb.WriteString(fmt.Sprintf("  // Example: const row = await ctx.%s.%s.findById(id);\n\n", repositoryContextField(i, dbs[0]), tables[0].Export))
+		} else {
+			sb.WriteString(fmt.Sprintf("  // Example: const result = await ctx.%s.query.users.findFirst(...);\n\n", postgresContextField(i, dbs[0])))
 		}
 	}
 
@@ -201,27 +299,10 @@ func (g *UsecaseGenerator) generateUsecase(i *ir.IR, uc *ir.Component) string {
 	return sb.String()
 }
 
-func (g *UsecaseGenerator) contextTypeForFields(fields []string) string {
-	if len(fields) == 0 {
-		return "ContextWith<never>"
-	}
-
-	quoted := make([]string, 0, len(fields))
-	for _, field := range fields {
-		quoted = append(quoted, fmt.Sprintf("'%s'", field))
-	}
-
-	if len(quoted) == 1 {
-		return fmt.Sprintf("ContextWith<%s>", quoted[0])
-	}
-
-	return fmt.Sprintf("ContextWith<%s>", strings.Join(quoted, " | "))
-}
-
 func (g *UsecaseGenerator) generateIndex(i *ir.IR) string {
 	var sb strings.Builder
 
-	sb.WriteString("// Generated by OpenBoundary - DO NOT EDIT\n")
+	sb.WriteString(codegen.Header(codegen.SlashComments))
 	sb.WriteString("// Re-exports all usecases for convenient importing\n\n")
 
 	// Collect and sort usecases for deterministic output
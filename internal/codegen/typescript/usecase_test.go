@@ -55,6 +55,10 @@ func TestUsecaseGenerator_Generate_UsecaseFile(t *testing.T) {
 					Goal:       "Create a new user in the system",
 					Actor:      "anonymous",
 					Middleware: []string{},
+					Authorization: &ir.AuthorizationSpec{
+						Roles:       []string{"admin"},
+						Permissions: []string{"user.write"},
+					},
 					Preconditions: []string{
 						"Email is not already registered",
 					},
@@ -110,6 +114,11 @@ func TestUsecaseGenerator_Generate_UsecaseFile(t *testing.T) {
 	if !strings.Contains(contentStr, "Password hashed") {
 		t.Error("usecase file should contain acceptance criteria")
 	}
+
+	// Check for required permissions
+	if !strings.Contains(contentStr, " * @requires Role.Admin\n * @requires Permission.UserWrite\n") {
+		t.Error("usecase file should list required permissions")
+	}
 }
 
 func TestUsecaseGenerator_Generate_WithPathParams(t *testing.T) {
@@ -200,9 +209,12 @@ func TestUsecaseGenerator_Generate_WithAuthMiddleware(t *testing.T) {
 
 	content := string(output.Files["src/components/usecase-get-user.usecase.ts"].Content)
 
-	// Check for auth in context type
-	if !strings.Contains(content, "'auth'") {
-		t.Error("usecase context should include auth when middleware.authn is used")
+	// The context type, narrowed by middleware.authn, comes from the server's context file
+	if !strings.Contains(content, "import type { GetUserUsecaseContext } from './http-server-api.context';") {
+		t.Error("usecase should import its context type from the server's context file")
+	}
+	if !strings.Contains(content, "ctx: GetUserUsecaseContext") {
+		t.Error("usecase context should use its per-route context type")
 	}
 }
 
@@ -306,3 +318,66 @@ func TestUsecaseGenerator_Generate_NoUsecases(t *testing.T) {
 		t.Errorf("expected 1 file, got %d", len(output.Files))
 	}
 }
+
+func TestUsecaseGenerator_Generate_CatchAll(t *testing.T) {
+	// given
+	i := createTestIR()
+	i.Components["usecase.preflight"] = &ir.Component{
+		ID:   "usecase.preflight",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			Goal:    "Answer preflight requests",
+			Binding: &ir.Binding{ServerID: "http.server.api", Method: "OPTIONS", Path: "/files/*"},
+		},
+	}
+
+	// when
+	output, err := NewUsecaseGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	usecase := string(output.Files["src/components/usecase-preflight.usecase.ts"].Content)
+	for _, want := range []string{
+		"export interface PreflightUsecaseInput {\n  request: Request;\n}\n",
+		"  input: PreflightUsecaseInput,\n",
+		"): Promise<Response> {\n",
+	} {
+		if !strings.Contains(usecase, want) {
+			t.Errorf("usecase file missing %q\n%s", want, usecase)
+		}
+	}
+}
+
+func TestUsecaseGenerator_Generate_InputMapping(t *testing.T) {
+	// given
+	i := newTestIR(withInputMapping)
+
+	// when
+	output, err := NewUsecaseGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	usecase := string(output.Files["src/components/usecase-update-user.usecase.ts"].Content)
+	for _, want := range []string{
+		"import type { UpdateUserRequest, UpdateUserResponse } from './usecase.schemas';\n",
+		"export interface UpdateUserUsecaseInput {\n" +
+			"  /** From body.age, number */\n  age?: number;\n" +
+			"  /** From body.display-name */\n  displayName?: UpdateUserRequest['display-name'];\n" +
+			"  /** From body.email_address, lowercase */\n  email: string;\n" +
+			"  /** From query.notify */\n  notify?: string;\n" +
+			"  /** From path.id */\n  userId: string;\n" +
+			"}\n",
+		"  input: UpdateUserUsecaseInput,\n",
+	} {
+		if !strings.Contains(usecase, want) {
+			t.Errorf("usecase file missing %q\n%s", want, usecase)
+		}
+	}
+	if strings.Contains(usecase, "Input combining path params") {
+		t.Error("mapped input should not combine path params and body")
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// WebhookGenerator generates the emitter of each webhook component and the
+// docs consumers of the webhooks read.
+type WebhookGenerator struct{}
+
+// NewWebhookGenerator creates a new webhook generator.
+func NewWebhookGenerator() *WebhookGenerator {
+	return &WebhookGenerator{}
+}
+
+// Name returns the generator name.
+func (g *WebhookGenerator) Name() string {
+	return "typescript-webhooks"
+}
+
+// Generate produces the webhook emitters and docs from the IR.
+func (g *WebhookGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
+	return codegen.GenerateComponents(g, i)
+}
+
+// GenerateShared produces WEBHOOKS.md, which lists every outbound webhook.
+func (g *WebhookGenerator) GenerateShared(i *ir.IR) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	if webhooks := webhookComponents(i); len(webhooks) > 0 {
+		output.AddFile(webhookDocsPath(), []byte(g.generateDocs(i, webhooks)))
+	}
+	return output, nil
+}
+
+// GenerateComponent produces the emitter of a webhook component.
+func (g *WebhookGenerator) GenerateComponent(i *ir.IR, comp *ir.Component) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	if comp.Kind == ir.KindWebhook && comp.Webhook != nil {
+		output.AddComponentFile(webhookSourcePath(comp.ID), []byte(g.generateEmitter(comp)), comp.ID)
+	}
+	return output, nil
+}
+
+func (g *WebhookGenerator) generateEmitter(wh *ir.Component) string {
+	var sb strings.Builder
+	s := wh.Webhook
+	name := webhookTypeName(wh)
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString(componentHeader(wh))
+	sb.WriteString("import { createHmac, randomUUID } from 'node:crypto';\n\n")
+
+	fmt.Fprintf(&sb, "/** Events %s delivers */\n", wh.ID)
+	fmt.Fprintf(&sb, "export type %sEvent =\n", name)
+	for n, e := range s.Events {
+		fmt.Fprintf(&sb, "  | %s", tsLiteral(e.Name))
+		if n == len(s.Events)-1 {
+			sb.WriteString(";")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	fmt.Fprintf(&sb, "/** Sends events to the endpoint in %s */\n", s.URLEnv)
+	fmt.Fprintf(&sb, "export interface %sEmitter {\n", name)
+	sb.WriteString("  /**\n")
+	fmt.Fprintf(&sb, "   * Delivers an event signed with %s, retrying failed deliveries\n", s.SecretEnv)
+	sb.WriteString("   * with exponential backoff. Rejects when no attempt succeeded.\n")
+	sb.WriteString("   */\n")
+	fmt.Fprintf(&sb, "  emit(event: %sEvent, data: unknown): Promise<void>;\n", name)
+	sb.WriteString("}\n\n")
+
+	fmt.Fprintf(&sb, "const maxAttempts = %d;\n", s.Attempts())
+	fmt.Fprintf(&sb, "const backoffMs = %d;\n\n", s.Backoff())
+
+	sb.WriteString("// Client errors other than timeouts and rate limits fail on every retry\n")
+	sb.WriteString("function isRetryable(status: number): boolean {\n")
+	sb.WriteString("  return status >= 500 || status === 408 || status === 429;\n")
+	sb.WriteString("}\n\n")
+
+	fmt.Fprintf(&sb, "export function create%sEmitter(): %sEmitter {\n", name, name)
+	for _, v := range []struct{ name, env string }{{"url", s.URLEnv}, {"secret", s.SecretEnv}} {
+		fmt.Fprintf(&sb, "  const %s = process.env.%s;\n", v.name, v.env)
+		fmt.Fprintf(&sb, "  if (!%s) {\n", v.name)
+		fmt.Fprintf(&sb, "    throw new Error('%s environment variable is required');\n", v.env)
+		sb.WriteString("  }\n")
+	}
+	sb.WriteString("\n")
+	sb.WriteString("  return {\n")
+	sb.WriteString("    async emit(event, data) {\n")
+	sb.WriteString("      const id = randomUUID();\n")
+	sb.WriteString("      const body = JSON.stringify({ id, event, created_at: new Date().toISOString(), data });\n")
+	sb.WriteString("      let lastError: unknown;\n")
+	sb.WriteString("      for (let attempt = 1; attempt <= maxAttempts; attempt++) {\n")
+	sb.WriteString("        if (attempt > 1) {\n")
+	sb.WriteString("          await new Promise((resolve) => setTimeout(resolve, backoffMs * 2 ** (attempt - 2)));\n")
+	sb.WriteString("        }\n")
+	sb.WriteString("        // Each attempt is signed with the current time, so receivers can reject replays\n")
+	sb.WriteString("        const timestamp = Math.floor(Date.now() / 1000).toString();\n")
+	sb.WriteString("        const signature = createHmac('sha256', secret).update(`${timestamp}.${body}`).digest('hex');\n")
+	sb.WriteString("        try {\n")
+	sb.WriteString("          const res = await fetch(url, {\n")
+	sb.WriteString("            method: 'POST',\n")
+	sb.WriteString("            headers: {\n")
+	sb.WriteString("              'Content-Type': 'application/json',\n")
+	sb.WriteString("              'Webhook-Id': id,\n")
+	sb.WriteString("              'Webhook-Event': event,\n")
+	sb.WriteString("              'Webhook-Timestamp': timestamp,\n")
+	sb.WriteString("              'Webhook-Signature': `sha256=${signature}`,\n")
+	sb.WriteString("            },\n")
+	sb.WriteString("            body,\n")
+	sb.WriteString("          });\n")
+	sb.WriteString("          if (res.ok) {\n")
+	sb.WriteString("            return;\n")
+	sb.WriteString("          }\n")
+	fmt.Fprintf(&sb, "          lastError = new Error(`%s responded ${res.status} to ${event}`);\n", wh.ID)
+	sb.WriteString("          if (!isRetryable(res.status)) {\n")
+	sb.WriteString("            break;\n")
+	sb.WriteString("          }\n")
+	sb.WriteString("        } catch (error) {\n")
+	sb.WriteString("          lastError = error;\n")
+	sb.WriteString("        }\n")
+	sb.WriteString("      }\n")
+	sb.WriteString("      throw lastError;\n")
+	sb.WriteString("    },\n")
+	sb.WriteString("  };\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// generateDocs renders WEBHOOKS.md for the receivers of the webhooks: the
+// events each sends, the delivery format and how to verify a signature.
+func (g *WebhookGenerator) generateDocs(i *ir.IR, webhooks []*ir.Component) string {
+	var sb strings.Builder
+
+	name := "generated-api"
+	if i.Spec != nil && i.Spec.Name != "" {
+		name = i.Spec.Name
+	}
+
+	sb.WriteString(codegen.Header(codegen.HTMLComments))
+	sb.WriteString("# Outbound webhooks\n\n")
+	fmt.Fprintf(&sb, "%s sends these webhooks. Every delivery is a signed JSON `POST`; see [Deliveries](#deliveries) for the format and [Verifying signatures](#verifying-signatures) for how to check it.\n\n", name)
+
+	for _, wh := range webhooks {
+		s := wh.Webhook
+		fmt.Fprintf(&sb, "## `%s`\n\n", wh.ID)
+		if summary := componentSummary(wh); summary != "" {
+			sb.WriteString(summary + "\n\n")
+		}
+		fmt.Fprintf(&sb, "Sent to the URL in `%s` and signed with the secret in `%s`. ", s.URLEnv, s.SecretEnv)
+		fmt.Fprintf(&sb, "Failed deliveries are retried with exponential backoff: %d attempts in all, waiting %d ms before the first retry and twice as long before each further one.\n\n", s.Attempts(), s.Backoff())
+		if emitters := webhookEmitters(i, wh); len(emitters) > 0 {
+			fmt.Fprintf(&sb, "Emitted by %s.\n\n", codeList(emitters))
+		}
+		sb.WriteString("| Event | Description |\n")
+		sb.WriteString("|-------|-------------|\n")
+		for _, e := range s.Events {
+			description := markdownCell(strings.Join(strings.Fields(e.Description), " "))
+			if description == "" {
+				description = "—"
+			}
+			fmt.Fprintf(&sb, "| `%s` | %s |\n", e.Name, description)
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Deliveries\n\n")
+	sb.WriteString("Each event is a `POST` with a JSON body:\n\n")
+	sb.WriteString("```json\n")
+	sb.WriteString("{\n")
+	sb.WriteString("  \"id\": \"5f0c6d1e-2a4b-4c8e-9f3a-7b1d2e4f6a8c\",\n")
+	sb.WriteString("  \"event\": \"order.created\",\n")
+	sb.WriteString("  \"created_at\": \"2026-01-01T12:00:00.000Z\",\n")
+	sb.WriteString("  \"data\": {}\n")
+	sb.WriteString("}\n")
+	sb.WriteString("```\n\n")
+	sb.WriteString("| Header | Value |\n")
+	sb.WriteString("|--------|-------|\n")
+	sb.WriteString("| `Webhook-Id` | The `id` of the event, the same on every attempt |\n")
+	sb.WriteString("| `Webhook-Event` | The `event` name |\n")
+	sb.WriteString("| `Webhook-Timestamp` | Unix time of the attempt in seconds |\n")
+	sb.WriteString("| `Webhook-Signature` | `sha256=` and the hex HMAC-SHA256 of `<timestamp>.<body>` keyed with the secret |\n\n")
+	sb.WriteString("Respond with a 2xx status once the event is stored. Other 4xx statuses than 408 and 429 are not retried. ")
+	sb.WriteString("An attempt can succeed after the sender gave up waiting for it, so the same event may arrive twice: deduplicate by `Webhook-Id`.\n\n")
+
+	sb.WriteString("## Verifying signatures\n\n")
+	sb.WriteString("Compute the signature over the raw body, before parsing it, and reject old timestamps so captured deliveries cannot be replayed:\n\n")
+	sb.WriteString("```ts\n")
+	sb.WriteString("import { createHmac, timingSafeEqual } from 'node:crypto';\n\n")
+	sb.WriteString("export function verifyWebhook(secret: string, headers: Headers, rawBody: string): boolean {\n")
+	sb.WriteString("  const timestamp = headers.get('Webhook-Timestamp') ?? '';\n")
+	sb.WriteString("  if (Math.abs(Date.now() / 1000 - Number(timestamp)) > 300) {\n")
+	sb.WriteString("    return false;\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("  const expected = 'sha256=' + createHmac('sha256', secret).update(`${timestamp}.${rawBody}`).digest('hex');\n")
+	sb.WriteString("  const actual = headers.get('Webhook-Signature') ?? '';\n")
+	sb.WriteString("  return actual.length === expected.length && timingSafeEqual(Buffer.from(actual), Buffer.from(expected));\n")
+	sb.WriteString("}\n")
+	sb.WriteString("```\n")
+
+	return sb.String()
+}
+
+// webhookComponents returns every webhook component, sorted by ID.
+func webhookComponents(i *ir.IR) []*ir.Component {
+	var webhooks []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindWebhook && comp.Webhook != nil {
+			webhooks = append(webhooks, comp)
+		}
+	}
+	sort.Slice(webhooks, func(a, b int) bool {
+		return webhooks[a].ID < webhooks[b].ID
+	})
+	return webhooks
+}
+
+// usecaseWebhooks returns the webhooks a usecase emits to, sorted by ID.
+func usecaseWebhooks(i *ir.IR, uc *ir.Component) []*ir.Component {
+	if uc == nil || uc.Usecase == nil {
+		return nil
+	}
+	var webhooks []*ir.Component
+	for _, ref := range uc.Usecase.Emits {
+		if wh, ok := i.Components[ref]; ok && wh.Kind == ir.KindWebhook && wh.Webhook != nil {
+			webhooks = append(webhooks, wh)
+		}
+	}
+	sort.Slice(webhooks, func(a, b int) bool {
+		return webhooks[a].ID < webhooks[b].ID
+	})
+	return webhooks
+}
+
+// serverWebhooks returns the webhooks the usecases bound to a server emit
+// to, sorted by ID; the server's context holds an emitter for each.
+func serverWebhooks(i *ir.IR, server *ir.Component) []*ir.Component {
+	seen := make(map[string]bool)
+	var webhooks []*ir.Component
+	for _, uc := range i.UsecasesBoundTo(server.ID) {
+		for _, wh := range usecaseWebhooks(i, uc) {
+			if !seen[wh.ID] {
+				seen[wh.ID] = true
+				webhooks = append(webhooks, wh)
+			}
+		}
+	}
+	sort.Slice(webhooks, func(a, b int) bool {
+		return webhooks[a].ID < webhooks[b].ID
+	})
+	return webhooks
+}
+
+// webhookEmitters returns the IDs of the usecases that emit to a webhook,
+// sorted.
+func webhookEmitters(i *ir.IR, wh *ir.Component) []string {
+	var ids []string
+	for _, comp := range i.Components {
+		if comp.Usecase != nil && stringInSlice(wh.ID, comp.Usecase.Emits) {
+			ids = append(ids, comp.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// webhookContextField returns the context field holding a webhook's emitter,
+// named after its component (e.g., "webhook.order-events" -> "orderEventsWebhook").
+func webhookContextField(wh *ir.Component) string {
+	return componentNameCamel(wh.ID) + "Webhook"
+}
+
+// webhookTypeName returns the prefix of a webhook's type names
+// (e.g., "webhook.order-events" -> "OrderEventsWebhook").
+func webhookTypeName(wh *ir.Component) string {
+	return titleCase(webhookContextField(wh))
+}
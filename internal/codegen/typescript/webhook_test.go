@@ -0,0 +1,187 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// withWebhook adds webhook.order-events to the test IR and has
+// usecase.create-user emit to it.
+func withWebhook(i *ir.IR) {
+	i.Components["webhook.order-events"] = &ir.Component{
+		ID:   "webhook.order-events",
+		Kind: ir.KindWebhook,
+		Webhook: &ir.WebhookSpec{
+			URLEnv:    "ORDER_WEBHOOK_URL",
+			SecretEnv: "ORDER_WEBHOOK_SECRET",
+			Events: []ir.WebhookEvent{
+				{Name: "order.created", Description: "An order was placed"},
+				{Name: "order.cancelled"},
+			},
+			MaxAttempts: 3,
+		},
+	}
+	i.Components["usecase.create-user"].Usecase.Emits = []string{"webhook.order-events"}
+}
+
+func TestWebhookGenerator_Name(t *testing.T) {
+	g := NewWebhookGenerator()
+	if got := g.Name(); got != "typescript-webhooks" {
+		t.Errorf("Name() = %v, want %v", got, "typescript-webhooks")
+	}
+}
+
+func TestWebhookGenerator_ImplementsComponentGenerator(t *testing.T) {
+	var _ codegen.ComponentGenerator = NewWebhookGenerator()
+}
+
+func TestWebhookGenerator_Generate_Emitter(t *testing.T) {
+	// given
+	i := newTestIR(withWebhook)
+
+	// when
+	output, err := NewWebhookGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	file, ok := output.Files["src/components/webhook-order-events.webhook.ts"]
+	if !ok {
+		t.Fatal("emitter not generated")
+	}
+	content := string(file.Content)
+	for _, want := range []string{
+		"export type OrderEventsWebhookEvent =\n  | 'order.created'\n  | 'order.cancelled';\n",
+		"export interface OrderEventsWebhookEmitter {\n",
+		"emit(event: OrderEventsWebhookEvent, data: unknown): Promise<void>;",
+		"const maxAttempts = 3;\n",
+		"const backoffMs = 1000;\n",
+		"export function createOrderEventsWebhookEmitter(): OrderEventsWebhookEmitter {\n",
+		"  const url = process.env.ORDER_WEBHOOK_URL;\n",
+		"    throw new Error('ORDER_WEBHOOK_SECRET environment variable is required');\n",
+		"createHmac('sha256', secret).update(`${timestamp}.${body}`)",
+		"'Webhook-Signature': `sha256=${signature}`,",
+		"backoffMs * 2 ** (attempt - 2)",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("emitter missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestWebhookGenerator_Generate_Docs(t *testing.T) {
+	// given
+	i := newTestIR(withWebhook)
+
+	// when
+	output, err := NewWebhookGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	file, ok := output.Files["WEBHOOKS.md"]
+	if !ok {
+		t.Fatal("WEBHOOKS.md not generated")
+	}
+	content := string(file.Content)
+	for _, want := range []string{
+		"## `webhook.order-events`\n",
+		"Sent to the URL in `ORDER_WEBHOOK_URL` and signed with the secret in `ORDER_WEBHOOK_SECRET`.",
+		"3 attempts in all, waiting 1000 ms before the first retry",
+		"Emitted by `usecase.create-user`.",
+		"| `order.created` | An order was placed |\n",
+		"| `order.cancelled` | — |\n",
+		"## Verifying signatures\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("WEBHOOKS.md missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestWebhookGenerator_Generate_NoWebhooks(t *testing.T) {
+	// given
+	i := createTestIR()
+
+	// when
+	output, err := NewWebhookGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(output.Files) != 0 {
+		t.Errorf("Generate() produced %d files, expected none", len(output.Files))
+	}
+}
+
+func TestWebhookContextField(t *testing.T) {
+	tests := []struct {
+		id       string
+		field    string
+		typeName string
+	}{
+		{"webhook.order-events", "orderEventsWebhook", "OrderEventsWebhook"},
+		{"webhook.billing", "billingWebhook", "BillingWebhook"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			wh := &ir.Component{ID: tt.id, Kind: ir.KindWebhook}
+			if got := webhookContextField(wh); got != tt.field {
+				t.Errorf("webhookContextField() = %v, expected %v", got, tt.field)
+			}
+			if got := webhookTypeName(wh); got != tt.typeName {
+				t.Errorf("webhookTypeName() = %v, expected %v", got, tt.typeName)
+			}
+		})
+	}
+}
+
+func TestWebhook_ServerWiring(t *testing.T) {
+	// given
+	i := newTestIR(withWebhook)
+
+	// when
+	server, err := NewHonoServerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	context, err := NewContextGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	ctx := string(context.Files["src/components/http-server-api.context.ts"].Content)
+	for _, want := range []string{
+		"import type { OrderEventsWebhookEmitter } from './webhook-order-events.webhook';",
+		"  orderEventsWebhook: OrderEventsWebhookEmitter;\n",
+		"'orderEventsWebhook'",
+	} {
+		if !strings.Contains(ctx, want) {
+			t.Errorf("context missing %q\n%s", want, ctx)
+		}
+	}
+	index := string(server.Files["src/index.ts"].Content)
+	for _, want := range []string{
+		"import { createOrderEventsWebhookEmitter } from './components/webhook-order-events.webhook';",
+		"  const orderEventsWebhookEmitter = createOrderEventsWebhookEmitter();\n",
+		"    orderEventsWebhook: orderEventsWebhookEmitter,\n",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index missing %q\n%s", want, index)
+		}
+	}
+	srv := string(server.Files["src/components/http-server-api.server.ts"].Content)
+	if !strings.Contains(srv, "    c.set('orderEventsWebhook', ctx.orderEventsWebhook);\n") {
+		t.Errorf("server should set the emitter on the request context\n%s", srv)
+	}
+}
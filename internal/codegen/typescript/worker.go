@@ -0,0 +1,252 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// WorkerGenerator generates the worker process that runs the cron
+// components on their schedules, apart from the HTTP servers, and the job
+// each cron component runs.
+type WorkerGenerator struct{}
+
+// NewWorkerGenerator creates a new worker generator.
+func NewWorkerGenerator() *WorkerGenerator {
+	return &WorkerGenerator{}
+}
+
+// Name returns the generator name.
+func (g *WorkerGenerator) Name() string {
+	return "typescript-worker"
+}
+
+// Generate produces the worker entrypoint and the jobs from the IR.
+func (g *WorkerGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
+	return codegen.GenerateComponents(g, i)
+}
+
+// GenerateShared produces src/worker.ts, which schedules every job.
+func (g *WorkerGenerator) GenerateShared(i *ir.IR) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	if crons := cronComponents(i); len(crons) > 0 {
+		output.AddFile(workerPath(), []byte(g.generateWorker(i, crons)))
+	}
+	return output, nil
+}
+
+// GenerateComponent produces the job of a cron component.
+func (g *WorkerGenerator) GenerateComponent(i *ir.IR, comp *ir.Component) (*codegen.Output, error) {
+	output := codegen.NewOutput()
+	if comp.Kind == ir.KindCron && comp.Cron != nil {
+		output.AddComponentFile(cronSourcePath(comp.ID), []byte(g.generateJob(i, comp)), comp.ID)
+	}
+	return output, nil
+}
+
+func (g *WorkerGenerator) generateJob(i *ir.IR, cron *ir.Component) string {
+	var sb strings.Builder
+	name := cronJobName(cron)
+	pgs := cronPostgresDependencies(i, cron)
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString(componentHeader(cron))
+
+	imports := make(map[string]bool)
+	for _, pg := range pgs {
+		if p := databaseProviderFor(pg); p != nil {
+			imports[fmt.Sprintf("import type { %s } from '%s';", p.ClientType(), postgresClientImportPath())] = true
+		}
+		if len(drizzleTables(i, pg)) > 0 {
+			imports[fmt.Sprintf("import type { %s } from './%s.postgres.repositories';", repositoriesTypeName(pg), componentIDSlug(pg.ID))] = true
+		}
+	}
+	sorted := make([]string, 0, len(imports))
+	for imp := range imports {
+		sorted = append(sorted, imp)
+	}
+	sort.Strings(sorted)
+	for _, imp := range sorted {
+		sb.WriteString(imp + "\n")
+	}
+	if len(sorted) > 0 {
+		sb.WriteString("\n")
+	}
+
+	fmt.Fprintf(&sb, "/** Dependencies of %s */\n", cron.ID)
+	if len(pgs) == 0 {
+		fmt.Fprintf(&sb, "export type %sContext = Record<string, never>;\n\n", titleCase(name))
+	} else {
+		fmt.Fprintf(&sb, "export interface %sContext {\n", titleCase(name))
+		for _, pg := range pgs {
+			if p := databaseProviderFor(pg); p != nil {
+				fmt.Fprintf(&sb, "  /** Database client from %s */\n", pg.ID)
+				fmt.Fprintf(&sb, "  %s: %s;\n", postgresContextField(i, pg), p.ClientType())
+			}
+			if len(drizzleTables(i, pg)) > 0 {
+				fmt.Fprintf(&sb, "  /** Repositories of the tables of %s */\n", pg.ID)
+				fmt.Fprintf(&sb, "  %s: %s;\n", repositoryContextField(i, pg), repositoriesTypeName(pg))
+			}
+		}
+		sb.WriteString("}\n\n")
+	}
+
+	sb.WriteString("/**\n")
+	fmt.Fprintf(&sb, " * Runs on the schedule %q in the worker process. A run is\n", cron.Cron.Schedule)
+	sb.WriteString(" * skipped while the previous one is still going, and a rejection is\n")
+	sb.WriteString(" * logged without stopping the schedule.\n")
+	sb.WriteString(" */\n")
+	fmt.Fprintf(&sb, "export async function %s(ctx: %sContext): Promise<void> {\n", name, titleCase(name))
+	sb.WriteString("  // TODO: Implement job\n")
+	sb.WriteString("  throw new Error('Not implemented');\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// generateWorker renders src/worker.ts: it creates the clients the jobs
+// use, schedules each job, and on SIGTERM or SIGINT stops scheduling and
+// exits once the running jobs finish.
+func (g *WorkerGenerator) generateWorker(i *ir.IR, crons []*ir.Component) string {
+	var sb strings.Builder
+
+	var pgs []*ir.Component
+	seen := make(map[string]bool)
+	for _, cron := range crons {
+		for _, pg := range cronPostgresDependencies(i, cron) {
+			if !seen[pg.ID] {
+				seen[pg.ID] = true
+				pgs = append(pgs, pg)
+			}
+		}
+	}
+	sort.Slice(pgs, func(a, b int) bool {
+		return pgs[a].ID < pgs[b].ID
+	})
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("import { Cron } from 'croner';\n")
+	serverCache := invalidatesServerCache(i, pgs)
+	if serverCache {
+		sb.WriteString("import { createRedisResponseCache } from './components/response.cache';\n")
+	}
+	for _, pg := range pgs {
+		fmt.Fprintf(&sb, "import { create%sClient } from './components/%s.postgres';\n", toPascalCase(pg.ID), componentIDSlug(pg.ID))
+		if len(drizzleTables(i, pg)) > 0 {
+			fmt.Fprintf(&sb, "import { create%s } from './components/%s.postgres.repositories';\n", repositoriesTypeName(pg), componentIDSlug(pg.ID))
+		}
+	}
+	for _, cron := range crons {
+		fmt.Fprintf(&sb, "import { %s } from './components/%s.cron';\n", cronJobName(cron), componentIDSlug(cron.ID))
+	}
+
+	sb.WriteString("\nasync function main() {\n")
+	if len(pgs) > 0 {
+		sb.WriteString("  // Initialize dependencies\n")
+		if serverCache {
+			sb.WriteString("  // Jobs writing through the repositories invalidate the servers' cached responses\n")
+			writeResponseCacheInit(&sb, i)
+		}
+		for _, pg := range pgs {
+			varName := toCamelCase(pg.ID) + "Client"
+			fmt.Fprintf(&sb, "  const %s = await create%sClient();\n", varName, toPascalCase(pg.ID))
+			if len(drizzleTables(i, pg)) > 0 {
+				fmt.Fprintf(&sb, "  const %sRepositories = create%s(%s);\n", toCamelCase(pg.ID), repositoriesTypeName(pg), repositoriesArgs(i, varName))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("  // Runs that have not finished yet, which shutdown waits for\n")
+	sb.WriteString("  const running = new Set<Promise<void>>();\n")
+	sb.WriteString("  function run(id: string, job: () => Promise<void>): Promise<void> {\n")
+	sb.WriteString("    const done: Promise<void> = job()\n")
+	sb.WriteString("      .catch((error) => console.error(`${id} failed:`, error))\n")
+	sb.WriteString("      .finally(() => running.delete(done));\n")
+	sb.WriteString("    running.add(done);\n")
+	sb.WriteString("    return done;\n")
+	sb.WriteString("  }\n\n")
+
+	sb.WriteString("  // protect skips a run while the previous one is still going\n")
+	sb.WriteString("  const jobs = [\n")
+	for _, cron := range crons {
+		var fields []string
+		for _, pg := range cronPostgresDependencies(i, cron) {
+			fields = append(fields, fmt.Sprintf("%s: %sClient", postgresContextField(i, pg), toCamelCase(pg.ID)))
+			if len(drizzleTables(i, pg)) > 0 {
+				fields = append(fields, fmt.Sprintf("%s: %sRepositories", repositoryContextField(i, pg), toCamelCase(pg.ID)))
+			}
+		}
+		ctx := "{}"
+		if len(fields) > 0 {
+			ctx = "{ " + strings.Join(fields, ", ") + " }"
+		}
+		fmt.Fprintf(&sb, "    new Cron(%s, { name: %s, protect: true }, () =>\n", tsLiteral(cron.Cron.Schedule), tsLiteral(cron.ID))
+		fmt.Fprintf(&sb, "      run(%s, () => %s(%s)),\n", tsLiteral(cron.ID), cronJobName(cron), ctx)
+		sb.WriteString("    ),\n")
+	}
+	sb.WriteString("  ];\n")
+	sb.WriteString("  console.log(`worker scheduled ${jobs.length} jobs`);\n\n")
+
+	sb.WriteString("  // Stop scheduling and exit once the running jobs finish\n")
+	sb.WriteString("  let stopping = false;\n")
+	sb.WriteString("  async function shutdown(signal: string) {\n")
+	sb.WriteString("    if (stopping) {\n")
+	sb.WriteString("      return;\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("    stopping = true;\n")
+	sb.WriteString("    console.log(`${signal} received, waiting for ${running.size} running jobs`);\n")
+	sb.WriteString("    for (const job of jobs) {\n")
+	sb.WriteString("      job.stop();\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("    await Promise.allSettled(running);\n")
+	sb.WriteString("    process.exit(0);\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("  process.on('SIGTERM', () => void shutdown('SIGTERM'));\n")
+	sb.WriteString("  process.on('SIGINT', () => void shutdown('SIGINT'));\n")
+	sb.WriteString("}\n\n")
+	sb.WriteString("main().catch((error) => {\n")
+	sb.WriteString("  console.error(error);\n")
+	sb.WriteString("  process.exit(1);\n")
+	sb.WriteString("});\n")
+
+	return sb.String()
+}
+
+// cronComponents returns every cron component, sorted by ID.
+func cronComponents(i *ir.IR) []*ir.Component {
+	var crons []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindCron && comp.Cron != nil {
+			crons = append(crons, comp)
+		}
+	}
+	sort.Slice(crons, func(a, b int) bool {
+		return crons[a].ID < crons[b].ID
+	})
+	return crons
+}
+
+// cronPostgresDependencies returns the databases a job uses, in the order
+// of its depends_on.
+func cronPostgresDependencies(i *ir.IR, cron *ir.Component) []*ir.Component {
+	var pgs []*ir.Component
+	for _, ref := range cron.Cron.DependsOn {
+		if pg, ok := i.Components[ref]; ok && pg.Kind == ir.KindPostgres && pg.Postgres != nil {
+			pgs = append(pgs, pg)
+		}
+	}
+	return pgs
+}
+
+// cronJobName returns the function a cron component runs, named after its
+// component (e.g., "cron.nightly-cleanup" -> "nightlyCleanupJob").
+func cronJobName(cron *ir.Component) string {
+	return componentNameCamel(cron.ID) + "Job"
+}
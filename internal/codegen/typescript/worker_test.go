@@ -0,0 +1,183 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// withCron adds cron.nightly-cleanup, which uses postgres.primary, and
+// cron.heartbeat, which uses nothing, to the test IR.
+func withCron(i *ir.IR) {
+	i.Components["cron.nightly-cleanup"] = &ir.Component{
+		ID:   "cron.nightly-cleanup",
+		Kind: ir.KindCron,
+		Cron: &ir.CronSpec{Schedule: "0 3 * * *", DependsOn: []string{"postgres.primary"}},
+	}
+	i.Components["cron.heartbeat"] = &ir.Component{
+		ID:   "cron.heartbeat",
+		Kind: ir.KindCron,
+		Cron: &ir.CronSpec{Schedule: "*/5 * * * *"},
+	}
+}
+
+func TestWorkerGenerator_Name(t *testing.T) {
+	g := NewWorkerGenerator()
+	if got := g.Name(); got != "typescript-worker" {
+		t.Errorf("Name() = %v, want %v", got, "typescript-worker")
+	}
+}
+
+func TestWorkerGenerator_ImplementsComponentGenerator(t *testing.T) {
+	var _ codegen.ComponentGenerator = NewWorkerGenerator()
+}
+
+func TestWorkerGenerator_Generate_Jobs(t *testing.T) {
+	// given
+	i := newTestIR(withCron)
+
+	// when
+	output, err := NewWorkerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	cleanup := string(output.Files["src/components/cron-nightly-cleanup.cron.ts"].Content)
+	for _, want := range []string{
+		"import type { DrizzleClient } from './postgres.client';\n",
+		"export interface NightlyCleanupJobContext {\n  /** Database client from postgres.primary */\n  db: DrizzleClient;\n}\n",
+		` * Runs on the schedule "0 3 * * *" in the worker process.`,
+		"export async function nightlyCleanupJob(ctx: NightlyCleanupJobContext): Promise<void> {\n",
+	} {
+		if !strings.Contains(cleanup, want) {
+			t.Errorf("job missing %q\n%s", want, cleanup)
+		}
+	}
+	heartbeat := string(output.Files["src/components/cron-heartbeat.cron.ts"].Content)
+	if !strings.Contains(heartbeat, "export type HeartbeatJobContext = Record<string, never>;\n") {
+		t.Errorf("job without dependencies should have an empty context\n%s", heartbeat)
+	}
+}
+
+func TestWorkerGenerator_Generate_Worker(t *testing.T) {
+	// given
+	i := newTestIR(withCron)
+
+	// when
+	output, err := NewWorkerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	file, ok := output.Files["src/worker.ts"]
+	if !ok {
+		t.Fatal("worker not generated")
+	}
+	content := string(file.Content)
+	for _, want := range []string{
+		"import { Cron } from 'croner';\n",
+		"import { createPostgresPrimaryClient } from './components/postgres-primary.postgres';\n",
+		"import { heartbeatJob } from './components/cron-heartbeat.cron';\n",
+		"import { nightlyCleanupJob } from './components/cron-nightly-cleanup.cron';\n",
+		"  const postgresPrimaryClient = await createPostgresPrimaryClient();\n",
+		"    new Cron('*/5 * * * *', { name: 'cron.heartbeat', protect: true }, () =>\n      run('cron.heartbeat', () => heartbeatJob({})),\n",
+		"    new Cron('0 3 * * *', { name: 'cron.nightly-cleanup', protect: true }, () =>\n      run('cron.nightly-cleanup', () => nightlyCleanupJob({ db: postgresPrimaryClient })),\n",
+		"  process.on('SIGTERM', () => void shutdown('SIGTERM'));\n",
+		"    await Promise.allSettled(running);\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("worker missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestWorkerGenerator_Generate_NoCrons(t *testing.T) {
+	// given
+	i := createTestIR()
+
+	// when
+	output, err := NewWorkerGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(output.Files) != 0 {
+		t.Errorf("Generate() produced %d files, expected none", len(output.Files))
+	}
+}
+
+func TestWorker_Deployment(t *testing.T) {
+	// given
+	i := newTestIR(withCron)
+
+	// when
+	docker, err := NewDockerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	project, err := NewProjectGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	dockerfile := string(docker.Files["Dockerfile"].Content)
+	if !strings.Contains(dockerfile, "FROM production AS worker\n\nHEALTHCHECK NONE\n\nCMD [\"node\", \"dist/worker.js\"]\n") {
+		t.Errorf("Dockerfile should have a worker target\n%s", dockerfile)
+	}
+	compose := string(docker.Files["docker-compose.yml"].Content)
+	for _, want := range []string{
+		"  worker:\n    build:\n      context: .\n      dockerfile: Dockerfile\n      target: worker\n",
+		"      DATABASE_URL: postgres://${POSTGRES_USER:-postgres}:${POSTGRES_PASSWORD:-postgres}@postgres:5432/${POSTGRES_DB:-app}\n    depends_on:\n      postgres:\n",
+		"    stop_grace_period: 1m\n",
+	} {
+		if !strings.Contains(compose, want) {
+			t.Errorf("docker-compose.yml missing %q\n%s", want, compose)
+		}
+	}
+	var pkg PackageJSON
+	if err := json.Unmarshal(project.Files["package.json"].Content, &pkg); err != nil {
+		t.Fatalf("Failed to parse package.json: %v", err)
+	}
+	for script, want := range map[string]string{
+		"worker":              "node dist/worker.js",
+		"dev:worker":          "tsx watch --import dotenv/config src/worker.ts",
+		"docker:build":        "docker build --target production -t app .",
+		"docker:build:worker": "docker build --target worker -t app-worker .",
+	} {
+		if got := pkg.Scripts[script]; got != want {
+			t.Errorf("scripts[%q] = %q, want %q", script, got, want)
+		}
+	}
+	if _, ok := pkg.Dependencies["croner"]; !ok {
+		t.Error("croner should be a dependency")
+	}
+}
+
+func TestWorker_NoCronsNoWorker(t *testing.T) {
+	// given
+	i := createTestIR()
+
+	// when
+	docker, err := NewDockerGenerator().Generate(i)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// then
+	if content := string(docker.Files["Dockerfile"].Content); strings.Contains(content, "AS worker") {
+		t.Errorf("Dockerfile should have no worker target without cron components\n%s", content)
+	}
+	if content := string(docker.Files["docker-compose.yml"].Content); strings.Contains(content, "worker:") {
+		t.Errorf("docker-compose.yml should have no worker without cron components\n%s", content)
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+// Package managers whose workspaces a generated service can join.
+const (
+	WorkspacePNPM = "pnpm"
+	WorkspaceNPM  = "npm"
+)
+
+// pnpmWorkspaceFile declares the packages of a pnpm workspace.
+const pnpmWorkspaceFile = "pnpm-workspace.yaml"
+
+// baseTSConfigs are the root configs a workspace package extends, in order
+// of preference.
+var baseTSConfigs = []string{"tsconfig.base.json", "tsconfig.json"}
+
+// invalidPackageNameChars matches what npm does not allow in a package name.
+var invalidPackageNameChars = regexp.MustCompile(`[^a-z0-9._~-]+`)
+
+// Workspace is a pnpm or npm monorepo that the generated service joins as a
+// package, instead of being a standalone project.
+type Workspace struct {
+	Root       string // Directory of the workspace root
+	Manager    string // WorkspacePNPM or WorkspaceNPM
+	Dir        string // Package directory relative to Root, slash-separated
+	Scope      string // Scope of the root package, e.g. "@acme"; empty when unscoped
+	BaseConfig string // Root tsconfig the package extends, relative to Root; empty for none
+}
+
+// FindWorkspace returns the workspace enclosing outputDir: the nearest
+// parent directory with a pnpm-workspace.yaml, or with a package.json that
+// declares workspaces.
+func FindWorkspace(outputDir string) (*Workspace, error) {
+	abs, err := filepath.Abs(outputDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve output directory: %w", err)
+	}
+
+	for dir := filepath.Dir(abs); ; dir = filepath.Dir(dir) {
+		manager, rootPkg, err := workspaceManager(dir)
+		if err != nil {
+			return nil, err
+		}
+		if manager != "" {
+			rel, err := filepath.Rel(dir, abs)
+			if err != nil {
+				return nil, err
+			}
+			w := &Workspace{Root: dir, Manager: manager, Dir: filepath.ToSlash(rel)}
+			if name, _ := rootPkg["name"].(string); strings.HasPrefix(name, "@") && strings.Contains(name, "/") {
+				w.Scope = name[:strings.Index(name, "/")]
+			}
+			for _, name := range baseTSConfigs {
+				if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+					w.BaseConfig = name
+					break
+				}
+			}
+			return w, nil
+		}
+		if dir == filepath.Dir(dir) {
+			return nil, fmt.Errorf("no workspace encloses %s: expected a %s or a package.json with workspaces in a parent directory", outputDir, pnpmWorkspaceFile)
+		}
+	}
+}
+
+// workspaceManager returns the package manager whose workspace dir is the
+// root of, or "" if none, and the root's package.json if it has one.
+func workspaceManager(dir string) (string, map[string]any, error) {
+	var pkg map[string]any
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &pkg); err != nil {
+			return "", nil, fmt.Errorf("%s: %w", filepath.Join(dir, "package.json"), err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return "", nil, err
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, pnpmWorkspaceFile)); err == nil {
+		return WorkspacePNPM, pkg, nil
+	}
+	if _, ok := pkg["workspaces"]; ok {
+		return WorkspaceNPM, pkg, nil
+	}
+	return "", nil, nil
+}
+
+// PackageName returns the name the generated package.json gets in the
+// workspace: name made valid for npm and placed in the root package's scope.
+// Names that already have a scope are kept.
+func (w *Workspace) PackageName(name string) string {
+	if strings.HasPrefix(name, "@") {
+		return name
+	}
+	slug := strings.Trim(invalidPackageNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-._")
+	if slug == "" {
+		slug = path.Base(w.Dir)
+	}
+	if w.Scope == "" {
+		return slug
+	}
+	return w.Scope + "/" + slug
+}
+
+// Package adapts the generated project files to a package of the
+// workspace: package.json is named in the workspace's scope and marked
+// private, and tsconfig.json extends the root's shared config through a
+// relative path.
+func (w *Workspace) Package(artifacts []codegen.Artifact) ([]codegen.Artifact, error) {
+	result := make([]codegen.Artifact, len(artifacts))
+	for n, artifact := range artifacts {
+		switch artifact.Path {
+		case "package.json":
+			var pkg PackageJSON
+			if err := json.Unmarshal(artifact.Content, &pkg); err != nil {
+				return nil, fmt.Errorf("package.json: %w", err)
+			}
+			pkg.Name = w.PackageName(pkg.Name)
+			pkg.Private = true
+			content, err := marshalIndent(pkg)
+			if err != nil {
+				return nil, err
+			}
+			artifact.Content = content
+		case "tsconfig.json":
+			if w.BaseConfig == "" {
+				break
+			}
+			var config TSConfig
+			if err := json.Unmarshal(artifact.Content, &config); err != nil {
+				return nil, fmt.Errorf("tsconfig.json: %w", err)
+			}
+			config.Extends = w.relativeToPackage(w.BaseConfig)
+			content, err := marshalIndent(config)
+			if err != nil {
+				return nil, err
+			}
+			artifact.Content = content
+		}
+		result[n] = artifact
+	}
+	return result, nil
+}
+
+// relativeToPackage returns the path of a file of the root, relative to the
+// package directory.
+func (w *Workspace) relativeToPackage(name string) string {
+	depth := strings.Count(w.Dir, "/") + 1
+	return strings.Repeat("../", depth) + name
+}
+
+// Register adds the package directory to the packages of the workspace
+// unless one of its patterns already matches it. The root file is merged,
+// not rewritten: other entries, keys and comments stay as they are. It
+// returns the updated file relative to Root, or "" when the package was
+// already registered.
+func (w *Workspace) Register() (string, error) {
+	var file string
+	var changed bool
+	var err error
+	switch w.Manager {
+	case WorkspacePNPM:
+		file = pnpmWorkspaceFile
+		changed, err = w.registerPNPM()
+	case WorkspaceNPM:
+		file = "package.json"
+		changed, err = w.registerNPM()
+	default:
+		err = fmt.Errorf("unknown workspace manager %q", w.Manager)
+	}
+	if err != nil || !changed {
+		return "", err
+	}
+	return file, nil
+}
+
+// registerPNPM appends the package to the packages list of
+// pnpm-workspace.yaml, copying the indentation and quoting of its last
+// entry.
+func (w *Workspace) registerPNPM() (bool, error) {
+	file := filepath.Join(w.Root, pnpmWorkspaceFile)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false, fmt.Errorf("%s: %w", pnpmWorkspaceFile, err)
+	}
+
+	var packages *yaml.Node
+	if len(doc.Content) > 0 && doc.Content[0].Kind == yaml.MappingNode {
+		root := doc.Content[0]
+		for n := 0; n+1 < len(root.Content); n += 2 {
+			if root.Content[n].Value == "packages" {
+				packages = root.Content[n+1]
+			}
+		}
+	}
+
+	var patterns []string
+	if packages != nil {
+		if packages.Kind != yaml.SequenceNode {
+			return false, fmt.Errorf("%s: packages is not a list; add %q to it", pnpmWorkspaceFile, w.Dir)
+		}
+		for _, item := range packages.Content {
+			patterns = append(patterns, item.Value)
+		}
+	}
+	if workspaceIncludes(patterns, w.Dir) {
+		return false, nil
+	}
+
+	var out []byte
+	switch {
+	case packages == nil:
+		out = append(bytes.TrimRight(data, "\n"), []byte(fmt.Sprintf("\npackages:\n  - '%s'\n", w.Dir))...)
+		out = bytes.TrimLeft(out, "\n")
+	case len(packages.Content) == 0 || packages.Style&yaml.FlowStyle != 0:
+		return false, fmt.Errorf("%s: packages is not a block list; add %q to it", pnpmWorkspaceFile, w.Dir)
+	default:
+		lines := strings.SplitAfter(string(data), "\n")
+		last := packages.Content[len(packages.Content)-1]
+		line := lines[last.Line-1]
+		prefix := line[:last.Column-1]
+		quote := ""
+		switch last.Style {
+		case yaml.SingleQuotedStyle:
+			quote = "'"
+		case yaml.DoubleQuotedStyle:
+			quote = `"`
+		}
+		entry := prefix + quote + w.Dir + quote + "\n"
+		if !strings.HasSuffix(line, "\n") {
+			entry = "\n" + entry
+		}
+		lines = append(lines[:last.Line], append([]string{entry}, lines[last.Line:]...)...)
+		out = []byte(strings.Join(lines, ""))
+	}
+	return true, os.WriteFile(file, out, 0644)
+}
+
+// registerNPM adds the package to the workspaces of the root package.json,
+// merging the change into the file so its other keys keep their order.
+func (w *Workspace) registerNPM() (bool, error) {
+	file := filepath.Join(w.Root, "package.json")
+	current, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	var pkg struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(current, &pkg); err != nil {
+		return false, fmt.Errorf("package.json: %w", err)
+	}
+
+	// Workspaces are a list of patterns, or an object listing them in packages
+	var patterns []string
+	wrap := func(list []string) any { return map[string]any{"workspaces": list} }
+	if err := json.Unmarshal(pkg.Workspaces, &patterns); err != nil {
+		var object struct {
+			Packages []string `json:"packages"`
+		}
+		if err := json.Unmarshal(pkg.Workspaces, &object); err != nil {
+			return false, fmt.Errorf("package.json: workspaces is neither a list nor an object with packages; add %q to it", w.Dir)
+		}
+		patterns = object.Packages
+		wrap = func(list []string) any { return map[string]any{"workspaces": map[string]any{"packages": list}} }
+	}
+	if workspaceIncludes(patterns, w.Dir) {
+		return false, nil
+	}
+
+	base, err := json.Marshal(wrap(patterns))
+	if err != nil {
+		return false, err
+	}
+	generated, err := json.Marshal(wrap(append(patterns, w.Dir)))
+	if err != nil {
+		return false, err
+	}
+	merged, _, err := codegen.MergeJSON(base, generated, current)
+	if err != nil {
+		return false, fmt.Errorf("package.json: %w", err)
+	}
+	return true, os.WriteFile(file, merged, 0644)
+}
+
+// workspaceIncludes reports whether a pattern of a workspace matches dir.
+// Patterns are globs per path segment, where a trailing /** matches any
+// depth; negated patterns are not considered.
+func workspaceIncludes(patterns []string, dir string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "./"), "/")
+		if strings.HasPrefix(pattern, "!") {
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+			if matched, _ := path.Match(prefix, dir); matched {
+				return true
+			}
+			for p := dir; strings.Contains(p, "/"); {
+				p = p[:strings.LastIndex(p, "/")]
+				if matched, _ := path.Match(prefix, p); matched {
+					return true
+				}
+			}
+			continue
+		}
+		if matched, _ := path.Match(pattern, dir); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// marshalIndent encodes v the way the project generator writes JSON files,
+// leaving characters such as the & of a script's && as they are.
+func marshalIndent(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	err := enc.Encode(v)
+	return buf.Bytes(), err
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+// writeWorkspaceFiles writes files, keyed by path relative to root.
+func writeWorkspaceFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFindWorkspace(t *testing.T) {
+	// given - a pnpm workspace with a scoped root package and a base config
+	root := t.TempDir()
+	writeWorkspaceFiles(t, root, map[string]string{
+		"package.json":        `{"name": "@acme/monorepo", "private": true}`,
+		"pnpm-workspace.yaml": "packages:\n  - 'packages/*'\n",
+		"tsconfig.base.json":  "{}",
+	})
+
+	// when
+	w, err := FindWorkspace(filepath.Join(root, "services", "orders"))
+
+	// then
+	if err != nil {
+		t.Fatalf("FindWorkspace() error = %v", err)
+	}
+	if w.Root != root || w.Manager != WorkspacePNPM || w.Dir != "services/orders" || w.Scope != "@acme" || w.BaseConfig != "tsconfig.base.json" {
+		t.Errorf("FindWorkspace() = %+v", w)
+	}
+}
+
+func TestFindWorkspace_None(t *testing.T) {
+	// given - a parent with a package.json that declares no workspaces
+	root := t.TempDir()
+	writeWorkspaceFiles(t, root, map[string]string{"package.json": `{"name": "app"}`})
+
+	// when
+	_, err := FindWorkspace(filepath.Join(root, "generated"))
+
+	// then
+	if err == nil || !strings.Contains(err.Error(), "no workspace encloses") {
+		t.Errorf("FindWorkspace() error = %v, expected no workspace", err)
+	}
+}
+
+func TestWorkspace_Package(t *testing.T) {
+	// given
+	w := &Workspace{Manager: WorkspaceNPM, Dir: "services/orders", Scope: "@acme", BaseConfig: "tsconfig.base.json"}
+	project, err := NewProjectGenerator().Generate(createTestIR())
+	if err != nil {
+		t.Fatal(err)
+	}
+	artifacts := []codegen.Artifact{
+		{Path: "package.json", Content: project.Files["package.json"].Content},
+		{Path: "tsconfig.json", Content: project.Files["tsconfig.json"].Content},
+	}
+
+	// when
+	result, err := w.Package(artifacts)
+
+	// then
+	if err != nil {
+		t.Fatalf("Package() error = %v", err)
+	}
+	var pkg PackageJSON
+	if err := json.Unmarshal(result[0].Content, &pkg); err != nil {
+		t.Fatal(err)
+	}
+	if pkg.Name != "@acme/test-api" || !pkg.Private {
+		t.Errorf("package.json name = %q, private = %v", pkg.Name, pkg.Private)
+	}
+	var config TSConfig
+	if err := json.Unmarshal(result[1].Content, &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Extends != "../../tsconfig.base.json" || config.CompilerOptions.OutDir != "./dist" {
+		t.Errorf("tsconfig.json extends = %q, outDir = %q", config.Extends, config.CompilerOptions.OutDir)
+	}
+}
+
+func TestWorkspace_PackageName(t *testing.T) {
+	tests := []struct {
+		scope, name, expected string
+	}{
+		{"@acme", "Orders API", "@acme/orders-api"},
+		{"", "orders", "orders"},
+		{"@acme", "@other/orders", "@other/orders"},
+		{"@acme", "!!!", "@acme/orders"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			w := &Workspace{Dir: "services/orders", Scope: tt.scope}
+
+			// when
+			name := w.PackageName(tt.name)
+
+			// then
+			if name != tt.expected {
+				t.Errorf("PackageName(%q) = %q, expected %q", tt.name, name, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWorkspace_Register(t *testing.T) {
+	tests := []struct {
+		name     string
+		manager  string
+		file     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "pnpm list",
+			manager:  WorkspacePNPM,
+			file:     "pnpm-workspace.yaml",
+			content:  "# Workspace packages\npackages:\n  - \"packages/*\" # libraries\n\ncatalog:\n  zod: ^3.23.0\n",
+			expected: "# Workspace packages\npackages:\n  - \"packages/*\" # libraries\n  - \"services/orders\"\n\ncatalog:\n  zod: ^3.23.0\n",
+		},
+		{
+			name:     "pnpm without packages",
+			manager:  WorkspacePNPM,
+			file:     "pnpm-workspace.yaml",
+			content:  "catalog:\n  zod: ^3.23.0\n",
+			expected: "catalog:\n  zod: ^3.23.0\npackages:\n  - 'services/orders'\n",
+		},
+		{
+			name:     "pnpm already matched",
+			manager:  WorkspacePNPM,
+			file:     "pnpm-workspace.yaml",
+			content:  "packages:\n  - 'services/**'\n",
+			expected: "packages:\n  - 'services/**'\n",
+		},
+		{
+			name:     "npm list",
+			manager:  WorkspaceNPM,
+			file:     "package.json",
+			content:  "{\n  \"name\": \"root\",\n  \"workspaces\": [\"packages/*\"],\n  \"devDependencies\": {}\n}\n",
+			expected: "{\n  \"name\": \"root\",\n  \"workspaces\": [\n    \"packages/*\",\n    \"services/orders\"\n  ],\n  \"devDependencies\": {}\n}\n",
+		},
+		{
+			name:     "npm object",
+			manager:  WorkspaceNPM,
+			file:     "package.json",
+			content:  "{\n  \"workspaces\": {\n    \"packages\": [],\n    \"nohoist\": [\"**/react\"]\n  }\n}\n",
+			expected: "{\n  \"workspaces\": {\n    \"packages\": [\n      \"services/orders\"\n    ],\n    \"nohoist\": [\n      \"**/react\"\n    ]\n  }\n}\n",
+		},
+		{
+			name:     "npm already matched",
+			manager:  WorkspaceNPM,
+			file:     "package.json",
+			content:  "{\"workspaces\": [\"services/*\"]}",
+			expected: "{\"workspaces\": [\"services/*\"]}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			root := t.TempDir()
+			writeWorkspaceFiles(t, root, map[string]string{tt.file: tt.content})
+			w := &Workspace{Root: root, Manager: tt.manager, Dir: "services/orders"}
+
+			// when
+			registered, err := w.Register()
+
+			// then
+			if err != nil {
+				t.Fatalf("Register() error = %v", err)
+			}
+			got, err := os.ReadFile(filepath.Join(root, tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("%s =\n%s\nexpected\n%s", tt.file, got, tt.expected)
+			}
+			if changed := tt.content != tt.expected; changed != (registered == tt.file) {
+				t.Errorf("Register() = %q, file changed: %v", registered, changed)
+			}
+		})
+	}
+}
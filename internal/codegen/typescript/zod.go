@@ -0,0 +1,461 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+// hasOpenAPITypes reports whether any server carries a parsed OpenAPI document
+// to derive the usecase schemas module from. Synthesized documents declare no
+// types.
+func hasOpenAPITypes(i *ir.IR) bool {
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindHTTPServer && comp.HTTPServer != nil && comp.HTTPServer.ParsedOpenAPI != nil &&
+			!comp.HTTPServer.ParsedOpenAPI.Synthesized {
+			return true
+		}
+	}
+	return false
+}
+
+// generateSchemasModule renders src/components/usecase.schemas.ts: a zod
+// schema and TypeScript type for every components/schemas entry, plus the
+// <Operation>Request and <Operation>Response types imported by usecases.
+func generateSchemasModule(i *ir.IR) string {
+	var sb strings.Builder
+
+	var servers []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindHTTPServer && comp.HTTPServer != nil && comp.HTTPServer.ParsedOpenAPI != nil {
+			servers = append(servers, comp)
+		}
+	}
+	sort.Slice(servers, func(a, b int) bool {
+		return servers[a].ID < servers[b].ID
+	})
+
+	sb.WriteString(codegen.Header(codegen.SlashComments))
+	sb.WriteString("// Types and zod schemas derived from OpenAPI documents.\n\n")
+	sb.WriteString("import { z } from 'zod';\n")
+
+	// Component schemas are declared as interfaces first so that recursive
+	// and out-of-order references type-check, then as lazily evaluated zod
+	// schemas annotated with those interfaces. As with the fixtures, the
+	// first server (by ID) declaring a name wins.
+	seen := make(map[string]bool)
+	for _, server := range servers {
+		doc := server.HTTPServer.ParsedOpenAPI
+		for _, name := range doc.SchemaNames() {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			schema := doc.Schemas[name]
+			typeName := schemaTypeName(name)
+
+			sb.WriteString("\n")
+			if schema != nil && schema.Description != "" {
+				fmt.Fprintf(&sb, "/** %s */\n", schema.Description)
+			}
+			if isObjectSchema(schema) {
+				fmt.Fprintf(&sb, "export interface %s %s\n", typeName, tsType(schema, ""))
+			} else {
+				fmt.Fprintf(&sb, "export type %s = %s;\n", typeName, tsType(schema, ""))
+			}
+			fmt.Fprintf(&sb, "export const %sSchema: z.ZodType<%s> = z.lazy(() =>\n  %s,\n);\n",
+				typeName, typeName, zodSchema(schema, "  "))
+		}
+	}
+
+	var usecases []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindUsecase && comp.Usecase != nil && comp.Usecase.Binding != nil {
+			usecases = append(usecases, comp)
+		}
+	}
+	sort.Slice(usecases, func(a, b int) bool {
+		return usecases[a].ID < usecases[b].ID
+	})
+
+	declared := make(map[string]bool)
+	for _, uc := range usecases {
+		op := uc.Usecase.Binding.Operation
+		if op == nil || op.OperationID == "" {
+			continue
+		}
+		pascalOp := toPascalCase(op.OperationID)
+		method := strings.ToLower(uc.Usecase.Binding.Method)
+
+		// Mirror the types the usecase generator imports.
+		if method == "post" || method == "put" || method == "patch" {
+			writeOperationSchema(&sb, declared, pascalOp+"Request", requestBodySchema(op))
+		}
+		if method != "delete" {
+			writeOperationSchema(&sb, declared, pascalOp+"Response", op.SuccessResponseSchema())
+		}
+	}
+
+	return sb.String()
+}
+
+func writeOperationSchema(sb *strings.Builder, declared map[string]bool, typeName string, schema *openapi.Schema) {
+	if declared[typeName] {
+		return
+	}
+	declared[typeName] = true
+
+	expr := "z.record(z.unknown())"
+	if schema != nil {
+		expr = zodSchema(schema, "")
+	}
+	fmt.Fprintf(sb, "\nexport const %sSchema = %s;\n", typeName, expr)
+	fmt.Fprintf(sb, "export type %s = z.infer<typeof %sSchema>;\n", typeName, typeName)
+}
+
+// requestBodySchema returns the JSON schema of an operation's request body, if any.
+func requestBodySchema(op *openapi.Operation) *openapi.Schema {
+	if op.RequestBody == nil {
+		return nil
+	}
+	if media, ok := op.RequestBody.Content["application/json"]; ok {
+		return media.Schema
+	}
+	return nil
+}
+
+// schemaTypeName converts a components/schemas name to a TypeScript identifier.
+func schemaTypeName(name string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9' && sb.Len() > 0:
+			if upper && r >= 'a' && r <= 'z' {
+				r -= 'a' - 'A'
+			}
+			sb.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	return sb.String()
+}
+
+func isObjectSchema(s *openapi.Schema) bool {
+	return s != nil && !s.IsRef() && !s.Nullable && len(s.Enum) == 0 && !isComposed(s) && (s.Type == "object" || s.Type == "") && len(s.Properties) > 0
+}
+
+// isComposed reports whether s combines other schemas with allOf, oneOf or
+// anyOf.
+func isComposed(s *openapi.Schema) bool {
+	return len(s.AllOf) > 0 || len(s.OneOf) > 0 || len(s.AnyOf) > 0
+}
+
+// composedTerms splits a composed schema into the schemas a value must all
+// match: its own properties, if any, each allOf schema, and the oneOf and
+// anyOf alternatives. render renders one schema, and union a list of
+// alternatives.
+func composedTerms(s *openapi.Schema, render func(*openapi.Schema) string, union func([]string) string) []string {
+	var terms []string
+	if len(s.Properties) > 0 || s.AdditionalProperties != nil {
+		own := *s
+		own.AllOf, own.OneOf, own.AnyOf = nil, nil, nil
+		own.Nullable = false
+		// allOf parts add properties the own object does not list, so it
+		// can only forbid others when nothing is merged into it
+		if len(s.AllOf) > 0 {
+			own.NoAdditionalProperties = false
+		}
+		terms = append(terms, render(&own))
+	}
+	for _, part := range s.AllOf {
+		terms = append(terms, render(part))
+	}
+	for _, options := range [][]*openapi.Schema{s.OneOf, s.AnyOf} {
+		if len(options) == 0 {
+			continue
+		}
+		rendered := make([]string, len(options))
+		for n, option := range options {
+			rendered[n] = render(option)
+		}
+		terms = append(terms, union(rendered))
+	}
+	return terms
+}
+
+// zodNumberBounds renders the minimum and maximum of a number schema.
+func zodNumberBounds(s *openapi.Schema) string {
+	var z string
+	if s.Minimum != nil {
+		check := "min"
+		if s.ExclusiveMinimum {
+			check = "gt"
+		}
+		z += fmt.Sprintf(".%s(%s)", check, strconv.FormatFloat(*s.Minimum, 'f', -1, 64))
+	}
+	if s.Maximum != nil {
+		check := "max"
+		if s.ExclusiveMaximum {
+			check = "lt"
+		}
+		z += fmt.Sprintf(".%s(%s)", check, strconv.FormatFloat(*s.Maximum, 'f', -1, 64))
+	}
+	return z
+}
+
+// zodLengthBounds renders the bounds of the length of a string or array.
+func zodLengthBounds(min uint64, max *uint64) string {
+	var z string
+	if min > 0 {
+		z += fmt.Sprintf(".min(%d)", min)
+	}
+	if max != nil {
+		z += fmt.Sprintf(".max(%d)", *max)
+	}
+	return z
+}
+
+func isRequired(s *openapi.Schema, prop string) bool {
+	for _, name := range s.Required {
+		if name == prop {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedProperties(s *openapi.Schema) []string {
+	props := make([]string, 0, len(s.Properties))
+	for prop := range s.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+	return props
+}
+
+// tsType renders s as a TypeScript type expression.
+func tsType(s *openapi.Schema, indent string) string {
+	if s == nil {
+		return "unknown"
+	}
+	if s.IsRef() {
+		return withNull(schemaTypeName(s.RefName()), s.Nullable)
+	}
+	if len(s.Enum) > 0 {
+		values := make([]string, len(s.Enum))
+		for n, v := range s.Enum {
+			values[n] = tsLiteral(v)
+		}
+		return withNull(strings.Join(values, " | "), s.Nullable)
+	}
+	if isComposed(s) {
+		render := func(part *openapi.Schema) string { return tsType(part, indent) }
+		union := func(options []string) string { return strings.Join(tsGroups(options), " | ") }
+		terms := composedTerms(s, render, union)
+		if len(terms) == 1 {
+			return withNull(terms[0], s.Nullable)
+		}
+		return withNull(strings.Join(tsGroups(terms), " & "), s.Nullable)
+	}
+
+	var t string
+	switch s.Type {
+	case "string":
+		t = "string"
+	case "integer", "number":
+		t = "number"
+	case "boolean":
+		t = "boolean"
+	case "array":
+		t = fmt.Sprintf("Array<%s>", tsType(s.Items, indent))
+	case "object", "":
+		if len(s.Properties) == 0 {
+			switch {
+			case s.AdditionalProperties != nil:
+				t = fmt.Sprintf("Record<string, %s>", tsType(s.AdditionalProperties, indent))
+			case s.NoAdditionalProperties:
+				t = "Record<string, never>"
+			default:
+				t = "Record<string, unknown>"
+			}
+			break
+		}
+		inner := indent + "  "
+		var sb strings.Builder
+		sb.WriteString("{\n")
+		for _, prop := range sortedProperties(s) {
+			propSchema := s.Properties[prop]
+			if propSchema != nil && propSchema.Description != "" {
+				fmt.Fprintf(&sb, "%s/** %s */\n", inner, propSchema.Description)
+			}
+			optional := "?"
+			if isRequired(s, prop) {
+				optional = ""
+			}
+			fmt.Fprintf(&sb, "%s%s%s: %s;\n", inner, fixtureKey(prop), optional, tsType(propSchema, inner))
+		}
+		// An index signature must admit every listed property, so the
+		// additional ones can only be typed as unknown
+		if s.AdditionalProperties != nil {
+			fmt.Fprintf(&sb, "%s[key: string]: unknown;\n", inner)
+		}
+		sb.WriteString(indent + "}")
+		t = sb.String()
+	default:
+		t = "unknown"
+	}
+	return withNull(t, s.Nullable)
+}
+
+// tsGroups parenthesizes the unions and intersections among types so they
+// can be combined.
+func tsGroups(types []string) []string {
+	grouped := make([]string, len(types))
+	for n, t := range types {
+		grouped[n] = t
+		if strings.Contains(t, " | ") || strings.Contains(t, " & ") {
+			grouped[n] = "(" + t + ")"
+		}
+	}
+	return grouped
+}
+
+func withNull(t string, nullable bool) string {
+	if nullable {
+		return t + " | null"
+	}
+	return t
+}
+
+// zodSchema renders s as a zod schema expression.
+func zodSchema(s *openapi.Schema, indent string) string {
+	if s == nil {
+		return "z.unknown()"
+	}
+	if s.IsRef() {
+		return withNullable(schemaTypeName(s.RefName())+"Schema", s.Nullable)
+	}
+	if len(s.Enum) > 0 {
+		return withNullable(zodEnum(s.Enum), s.Nullable)
+	}
+	// zod has no exclusive union, so oneOf accepts a value matching several
+	// alternatives, as anyOf does
+	if isComposed(s) {
+		render := func(part *openapi.Schema) string { return zodSchema(part, indent) }
+		terms := composedTerms(s, render, zodUnion)
+		z := terms[0]
+		for _, term := range terms[1:] {
+			z += fmt.Sprintf(".and(%s)", term)
+		}
+		return withNullable(z, s.Nullable)
+	}
+
+	var z string
+	switch s.Type {
+	case "string":
+		z = "z.string()"
+		switch s.Format {
+		case "email":
+			z += ".email()"
+		case "uuid":
+			z += ".uuid()"
+		case "uri", "url":
+			z += ".url()"
+		case "date-time":
+			z += ".datetime()"
+		case "date":
+			z += ".date()"
+		}
+		z += zodLengthBounds(s.MinLength, s.MaxLength)
+	case "integer":
+		z = "z.number().int()" + zodNumberBounds(s)
+	case "number":
+		z = "z.number()" + zodNumberBounds(s)
+	case "boolean":
+		z = "z.boolean()"
+	case "array":
+		z = fmt.Sprintf("z.array(%s)", zodSchema(s.Items, indent)) + zodLengthBounds(s.MinItems, s.MaxItems)
+	case "object", "":
+		if len(s.Properties) == 0 {
+			switch {
+			case s.AdditionalProperties != nil:
+				z = fmt.Sprintf("z.record(%s)", zodSchema(s.AdditionalProperties, indent))
+			case s.NoAdditionalProperties:
+				z = "z.object({}).strict()"
+			default:
+				z = "z.record(z.unknown())"
+			}
+			break
+		}
+		inner := indent + "  "
+		var sb strings.Builder
+		sb.WriteString("z.object({\n")
+		for _, prop := range sortedProperties(s) {
+			value := zodSchema(s.Properties[prop], inner)
+			if !isRequired(s, prop) {
+				value += ".optional()"
+			}
+			fmt.Fprintf(&sb, "%s%s: %s,\n", inner, fixtureKey(prop), value)
+		}
+		sb.WriteString(indent + "})")
+		switch {
+		case s.AdditionalProperties != nil:
+			sb.WriteString(fmt.Sprintf(".catchall(%s)", zodSchema(s.AdditionalProperties, indent)))
+		case s.NoAdditionalProperties:
+			sb.WriteString(".strict()")
+		}
+		z = sb.String()
+	default:
+		z = "z.unknown()"
+	}
+	return withNullable(z, s.Nullable)
+}
+
+// zodUnion renders alternatives as one schema; z.union needs at least two.
+func zodUnion(options []string) string {
+	if len(options) == 1 {
+		return options[0]
+	}
+	return fmt.Sprintf("z.union([%s])", strings.Join(options, ", "))
+}
+
+// zodEnum renders enum values, using z.enum for all-string enums.
+func zodEnum(values []interface{}) string {
+	literals := make([]string, len(values))
+	allStrings := true
+	for n, v := range values {
+		if _, ok := v.(string); !ok {
+			allStrings = false
+		}
+		literals[n] = tsLiteral(v)
+	}
+	if allStrings {
+		return fmt.Sprintf("z.enum([%s])", strings.Join(literals, ", "))
+	}
+	if len(literals) == 1 {
+		return fmt.Sprintf("z.literal(%s)", literals[0])
+	}
+	options := make([]string, len(literals))
+	for n, literal := range literals {
+		options[n] = fmt.Sprintf("z.literal(%s)", literal)
+	}
+	return fmt.Sprintf("z.union([%s])", strings.Join(options, ", "))
+}
+
+func withNullable(z string, nullable bool) string {
+	if nullable {
+		return z + ".nullable()"
+	}
+	return z
+}
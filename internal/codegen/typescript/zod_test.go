@@ -0,0 +1,126 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+func TestGenerateSchemasModule(t *testing.T) {
+	// given
+	i := newFixturesTestIR(t)
+
+	// when
+	content := generateSchemasModule(i)
+
+	// then
+	expected := []string{
+		"import { z } from 'zod';",
+		"export interface User {\n  age?: number;\n  createdAt?: string;\n  id?: string;\n  manager?: User;\n}",
+		"export const UserSchema: z.ZodType<User> = z.lazy(() =>\n  z.object({\n" +
+			"    age: z.number().int().optional(),\n" +
+			"    createdAt: z.string().datetime().optional(),\n" +
+			"    id: z.string().uuid().optional(),\n" +
+			"    manager: UserSchema.optional(),\n  }),\n);",
+		"export const CreateUserRequestSchema = z.object({\n" +
+			"  email: z.string().email(),\n" +
+			"  role: z.enum(['admin', 'member']).optional(),\n" +
+			"  tags: z.array(z.string()).optional(),\n});",
+		"export type CreateUserRequest = z.infer<typeof CreateUserRequestSchema>;",
+		"export const CreateUserResponseSchema = UserSchema;",
+		"export const GetUserResponseSchema = z.record(z.unknown());",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("schemas module missing %q\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "GetUserRequest") {
+		t.Error("GET operations should not declare a request type")
+	}
+}
+
+func TestSchemaGenerator_Generate_SchemasModule(t *testing.T) {
+	// given
+	i := newFixturesTestIR(t)
+
+	// when
+	output, err := NewSchemaGenerator().Generate(i)
+
+	// then
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := output.Files[usecaseSchemasPath()]; !ok {
+		t.Errorf("%s not found in output", usecaseSchemasPath())
+	}
+}
+
+func TestZodSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *openapi.Schema
+		want   string
+	}{
+		{"nil", nil, "z.unknown()"},
+		{"nullable string", &openapi.Schema{Type: "string", Nullable: true}, "z.string().nullable()"},
+		{"numeric enum", &openapi.Schema{Type: "integer", Enum: []interface{}{1, 2}}, "z.union([z.literal(1), z.literal(2)])"},
+		{"free-form object", &openapi.Schema{Type: "object"}, "z.record(z.unknown())"},
+		{"ref", &openapi.Schema{Ref: "#/components/schemas/order-item"}, "OrderItemSchema"},
+		{"oneOf", &openapi.Schema{OneOf: []*openapi.Schema{&openapi.Schema{Ref: "#/components/schemas/Cat"}, &openapi.Schema{Ref: "#/components/schemas/Dog"}}}, "z.union([CatSchema, DogSchema])"},
+		{"allOf with properties", &openapi.Schema{
+			AllOf:      []*openapi.Schema{&openapi.Schema{Ref: "#/components/schemas/Cat"}},
+			Properties: map[string]*openapi.Schema{"lives": {Type: "integer"}},
+			Required:   []string{"lives"},
+		}, "z.object({\n  lives: z.number().int(),\n}).and(CatSchema)"},
+		{"map", &openapi.Schema{Type: "object", AdditionalProperties: &openapi.Schema{Type: "string"}}, "z.record(z.string())"},
+		{"closed object", &openapi.Schema{Type: "object", NoAdditionalProperties: true, Properties: map[string]*openapi.Schema{
+			"id": {Type: "string"},
+		}}, "z.object({\n  id: z.string().optional(),\n}).strict()"},
+		{"bounded integer", &openapi.Schema{Type: "integer", Minimum: ptr(1.0), Maximum: ptr(10.0)}, "z.number().int().min(1).max(10)"},
+		{"exclusive number", &openapi.Schema{Type: "number", Minimum: ptr(0.0), ExclusiveMinimum: true, Maximum: ptr(2.5), ExclusiveMaximum: true}, "z.number().gt(0).lt(2.5)"},
+		{"bounded string", &openapi.Schema{Type: "string", Format: "email", MinLength: 3, MaxLength: ptr(uint64(40))}, "z.string().email().min(3).max(40)"},
+		{"bounded array", &openapi.Schema{Type: "array", Items: &openapi.Schema{Type: "string"}, MinItems: 1, MaxItems: ptr(uint64(5))}, "z.array(z.string()).min(1).max(5)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := zodSchema(tt.schema, ""); got != tt.want {
+				t.Errorf("zodSchema() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTSType(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *openapi.Schema
+		want   string
+	}{
+		{"nullable ref", &openapi.Schema{Ref: "#/components/schemas/User", Nullable: true}, "User | null"},
+		{"string enum", &openapi.Schema{Type: "string", Enum: []interface{}{"a", "b"}}, "'a' | 'b'"},
+		{"array", &openapi.Schema{Type: "array", Items: &openapi.Schema{Type: "number"}}, "Array<number>"},
+		{"quoted key", &openapi.Schema{Type: "object", Required: []string{"x-id"}, Properties: map[string]*openapi.Schema{
+			"x-id": {Type: "string"},
+		}}, "{\n  'x-id': string;\n}"},
+		{"nullable anyOf", &openapi.Schema{AnyOf: []*openapi.Schema{&openapi.Schema{Ref: "#/components/schemas/Cat"}, {Type: "string"}}, Nullable: true}, "Cat | string | null"},
+		{"allOf of a union", &openapi.Schema{AllOf: []*openapi.Schema{&openapi.Schema{Ref: "#/components/schemas/Dog"}}, OneOf: []*openapi.Schema{{Type: "string"}, {Type: "number"}}}, "Dog & (string | number)"},
+		{"map", &openapi.Schema{Type: "object", AdditionalProperties: &openapi.Schema{Type: "integer"}}, "Record<string, number>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tsType(tt.schema, ""); got != tt.want {
+				t.Errorf("tsType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// ptr returns a pointer to v, for the optional fields of schemas.
+func ptr[T any](v T) *T {
+	return &v
+}
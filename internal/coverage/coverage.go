@@ -0,0 +1,159 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package coverage maps usecase acceptance criteria to the tests that cover them.
+package coverage
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// Criterion is a single acceptance criterion of a usecase.
+type Criterion struct {
+	ID        string // e.g., "AC-usecase.create-user-2"
+	UsecaseID string
+	Text      string
+}
+
+// CriterionID returns the stable ID of the index-th (1-based) acceptance
+// criterion of a usecase.
+func CriterionID(usecaseID string, index int) string {
+	return fmt.Sprintf("AC-%s-%d", usecaseID, index)
+}
+
+// Criteria returns every acceptance criterion in the IR, sorted by usecase ID
+// and then by position.
+func Criteria(i *ir.IR) []Criterion {
+	var usecases []*ir.Component
+	for _, comp := range i.Components {
+		if comp.Kind == ir.KindUsecase && comp.Usecase != nil {
+			usecases = append(usecases, comp)
+		}
+	}
+	sort.Slice(usecases, func(a, b int) bool {
+		return usecases[a].ID < usecases[b].ID
+	})
+
+	var criteria []Criterion
+	for _, uc := range usecases {
+		for n, text := range uc.Usecase.AcceptanceCriteria {
+			criteria = append(criteria, Criterion{
+				ID:        CriterionID(uc.ID, n+1),
+				UsecaseID: uc.ID,
+				Text:      text,
+			})
+		}
+	}
+	return criteria
+}
+
+var (
+	criterionIDPattern = regexp.MustCompile(`AC-[a-z][a-z0-9-]*(?:\.[a-z][a-z0-9-]*)+-[0-9]+`)
+
+	// testCallPattern matches a Vitest/Jest/Playwright test declaration and
+	// its modifiers (e.g., "it(", "test.skip(", "it.concurrent.todo(", "xit(").
+	testCallPattern = regexp.MustCompile(`\b(x?it|x?test)((?:\.[a-zA-Z]+)*)\s*\(`)
+
+	testFilePattern = regexp.MustCompile(`\.(test|spec)\.[cm]?[jt]sx?$`)
+)
+
+// ScanDir walks root for test files and returns, for each criterion ID found
+// inside an active (not skipped or todo) test, the files that reference it.
+// node_modules and hidden directories are skipped.
+func ScanDir(root string) (map[string][]string, error) {
+	covered := make(map[string][]string)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (name == "node_modules" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !testFilePattern.MatchString(d.Name()) {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil {
+			rel = path
+		}
+		for _, id := range ActiveIDs(string(content)) {
+			covered[id] = append(covered[id], filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return covered, nil
+}
+
+// ActiveIDs returns the distinct criterion IDs in source that appear inside an
+// active test. An ID is attributed to the closest test declaration before it;
+// IDs under it.skip, it.todo, test.fixme, xit and friends do not count.
+func ActiveIDs(source string) []string {
+	calls := testCallPattern.FindAllStringSubmatchIndex(source, -1)
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, loc := range criterionIDPattern.FindAllStringIndex(source, -1) {
+		id := source[loc[0]:loc[1]]
+		if seen[id] {
+			continue
+		}
+
+		// Find the last test declaration starting before the ID.
+		owner := -1
+		for n, call := range calls {
+			if call[0] >= loc[0] {
+				break
+			}
+			owner = n
+		}
+		if owner == -1 {
+			continue
+		}
+
+		call := calls[owner]
+		name := source[call[2]:call[3]]
+		modifiers := source[call[4]:call[5]]
+		if strings.HasPrefix(name, "x") || strings.Contains(modifiers, ".skip") || strings.Contains(modifiers, ".todo") || strings.Contains(modifiers, ".fixme") {
+			continue
+		}
+
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+	return ids
+}
+
+// Uncovered returns the criteria whose IDs do not appear in covered.
+func Uncovered(criteria []Criterion, covered map[string][]string) []Criterion {
+	var missing []Criterion
+	for _, c := range criteria {
+		if len(covered[c.ID]) == 0 {
+			missing = append(missing, c)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+func TestCriterionID(t *testing.T) {
+	if got := CriterionID("usecase.create-user", 2); got != "AC-usecase.create-user-2" {
+		t.Errorf("CriterionID() = %q, want %q", got, "AC-usecase.create-user-2")
+	}
+}
+
+func TestCriteria(t *testing.T) {
+	// given
+	i := &ir.IR{
+		Components: map[string]*ir.Component{
+			"usecase.b": {
+				ID:      "usecase.b",
+				Kind:    ir.KindUsecase,
+				Usecase: &ir.UsecaseSpec{AcceptanceCriteria: []string{"B one"}},
+			},
+			"usecase.a": {
+				ID:      "usecase.a",
+				Kind:    ir.KindUsecase,
+				Usecase: &ir.UsecaseSpec{AcceptanceCriteria: []string{"A one", "A two"}},
+			},
+			"http.server.api": {
+				ID:   "http.server.api",
+				Kind: ir.KindHTTPServer,
+			},
+		},
+	}
+
+	// when
+	criteria := Criteria(i)
+
+	// then
+	want := []Criterion{
+		{ID: "AC-usecase.a-1", UsecaseID: "usecase.a", Text: "A one"},
+		{ID: "AC-usecase.a-2", UsecaseID: "usecase.a", Text: "A two"},
+		{ID: "AC-usecase.b-1", UsecaseID: "usecase.b", Text: "B one"},
+	}
+	if !reflect.DeepEqual(criteria, want) {
+		t.Errorf("Criteria() = %+v, want %+v", criteria, want)
+	}
+}
+
+func TestActiveIDs(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{
+			name:   "active test",
+			source: "it('AC-usecase.create-user-1: creates the user', async () => {});",
+			want:   []string{"AC-usecase.create-user-1"},
+		},
+		{
+			name:   "id in test body",
+			source: "test('creates', () => {\n  // covers AC-usecase.create-user-2\n});",
+			want:   []string{"AC-usecase.create-user-2"},
+		},
+		{
+			name: "todo and skipped tests do not count",
+			source: "it.todo('AC-usecase.a-1: pending');\n" +
+				"it.skip('AC-usecase.a-2: skipped', () => {});\n" +
+				"xit('AC-usecase.a-3: skipped', () => {});\n" +
+				"test.concurrent.skip('AC-usecase.a-4', () => {});\n" +
+				"test.fixme('AC-usecase.a-5', async () => {});",
+			want: nil,
+		},
+		{
+			name:   "outside any test",
+			source: "// AC-usecase.a-1\ndescribe('x', () => {});",
+			want:   nil,
+		},
+		{
+			name: "later active test covers repeated id",
+			source: "it.todo('AC-usecase.a-1');\n" +
+				"it('AC-usecase.a-1 implemented', () => {});",
+			want: []string{"AC-usecase.a-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ActiveIDs(tt.source); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ActiveIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanDir(t *testing.T) {
+	// given
+	root := t.TempDir()
+	files := map[string]string{
+		"src/users.test.ts":            "it('AC-usecase.a-1', () => {});",
+		"e2e/users.spec.ts":            "test('AC-usecase.a-2', async () => {});",
+		"src/users.ts":                 "it('AC-usecase.a-3', () => {});",
+		"node_modules/x/index.test.ts": "it('AC-usecase.a-4', () => {});",
+	}
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// when
+	covered, err := ScanDir(root)
+
+	// then
+	if err != nil {
+		t.Fatalf("ScanDir() error = %v", err)
+	}
+	want := map[string][]string{
+		"AC-usecase.a-1": {"src/users.test.ts"},
+		"AC-usecase.a-2": {"e2e/users.spec.ts"},
+	}
+	if !reflect.DeepEqual(covered, want) {
+		t.Errorf("ScanDir() = %v, want %v", covered, want)
+	}
+}
+
+func TestUncovered(t *testing.T) {
+	criteria := []Criterion{{ID: "AC-usecase.a-1"}, {ID: "AC-usecase.a-2"}}
+	covered := map[string][]string{"AC-usecase.a-1": {"a.test.ts"}}
+
+	missing := Uncovered(criteria, covered)
+
+	if len(missing) != 1 || missing[0].ID != "AC-usecase.a-2" {
+		t.Errorf("Uncovered() = %+v, want only AC-usecase.a-2", missing)
+	}
+}
@@ -4,8 +4,18 @@
 package ir
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"math"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
 
+	"github.com/openboundary/openboundary/internal/casbin"
 	"github.com/openboundary/openboundary/internal/openapi"
 	"github.com/openboundary/openboundary/internal/parser"
 )
@@ -13,6 +23,7 @@ import (
 // Builder builds a typed IR from a parsed spec.
 type Builder struct {
 	baseDir string // Base directory for resolving relative paths
+	fsys    fs.FS  // Read from instead of baseDir, if set
 }
 
 // NewBuilder creates a new IR builder.
@@ -26,51 +37,88 @@ func (b *Builder) WithBaseDir(dir string) *Builder {
 	return b
 }
 
+// WithFS reads the files the spec references (e.g., OpenAPI documents and
+// casbin models) from fsys instead of the base directory, for callers
+// without an operating system file system such as a WebAssembly build.
+// Paths are relative to the root of fsys.
+func (b *Builder) WithFS(fsys fs.FS) *Builder {
+	b.fsys = fsys
+	return b
+}
+
 // Build creates a typed IR from the given spec.
 // It resolves all references and builds the dependency graph.
 func (b *Builder) Build(spec *parser.Spec) (*IR, []error) {
 	ir := New(spec)
 	ir.BaseDir = b.baseDir
+	ir.FS = b.fsys
 	var errs []error
 
-	// Phase 1: Create components and populate symbol table
+	// Phase 1: Create components and populate symbol table. Components that
+	// fail become placeholders, so later phases still report problems in the
+	// rest of the spec without repeating this one as unresolved references.
+	var ordered []*Component
 	for i := range spec.Components {
 		comp := &spec.Components[i]
 		kind, err := ParseKind(comp.Kind)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("component %q: %w", comp.ID, err))
+			if err := ir.Symbols.DefinePlaceholder(comp.ID, Kind(comp.Kind)); err != nil {
+				errs = append(errs, err)
+			}
 			continue
 		}
 
 		irComp := &Component{
 			ID:           comp.ID,
 			Kind:         kind,
+			Labels:       comp.Labels,
+			Description:  comp.Description,
+			Owner:        comp.Owner,
+			Links:        comp.Links,
+			Deprecated:   comp.Deprecated,
+			Replacement:  comp.Replacement,
 			Position:     comp.Pos(),
 			Dependencies: []*Component{},
 			Dependents:   []*Component{},
 		}
 
+		if comp.Resources != nil {
+			resources, err := parseResources(comp.Resources)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("component %q: resources: %w", comp.ID, err))
+			}
+			irComp.Resources = resources
+		}
+
+		// The first definition of a duplicated ID wins
+		if err := ir.Symbols.Define(comp.ID, kind, irComp); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
 		// Parse kind-specific spec
 		b.parseComponentSpec(irComp, comp.Spec)
 
 		ir.Components[comp.ID] = irComp
+		ordered = append(ordered, irComp)
 
-		if err := ir.Symbols.Define(comp.ID, kind, irComp); err != nil {
-			errs = append(errs, err)
+		if irComp.Middleware != nil {
+			members, chainErrs := b.expandChain(ir, irComp, comp.Spec)
+			errs = append(errs, chainErrs...)
+			ordered = append(ordered, members...)
 		}
 	}
 
-	// If we had errors creating components, don't try to resolve references
-	if len(errs) > 0 {
-		return ir, errs
-	}
-
 	// Phase 2: Parse OpenAPI specs for http.server components
 	openAPIErrs := b.parseOpenAPISpecs(ir)
 	errs = append(errs, openAPIErrs...)
 
-	// Phase 3: Resolve references and build edges
-	for _, comp := range ir.Components {
+	// Phase 2b: Parse casbin models for middleware components
+	errs = append(errs, b.parseCasbinModels(ir)...)
+
+	// Phase 3: Resolve references and build edges, in spec order
+	for _, comp := range ordered {
 		refErrs := b.resolveReferences(ir, comp)
 		errs = append(errs, refErrs...)
 	}
@@ -79,6 +127,9 @@ func (b *Builder) Build(spec *parser.Spec) (*IR, []error) {
 	linkErrs := b.linkUsecasesToOperations(ir)
 	errs = append(errs, linkErrs...)
 
+	// Phase 5: Synthesize OpenAPI documents for servers without one
+	b.synthesizeOpenAPISpecs(ir)
+
 	return ir, errs
 }
 
@@ -86,6 +137,9 @@ func (b *Builder) Build(spec *parser.Spec) (*IR, []error) {
 func (b *Builder) parseOpenAPISpecs(ir *IR) []error {
 	var errs []error
 	oaParser := openapi.NewParser(b.baseDir)
+	if b.fsys != nil {
+		oaParser = openapi.NewFSParser(b.fsys)
+	}
 
 	for _, comp := range ir.Components {
 		if comp.Kind != KindHTTPServer || comp.HTTPServer == nil {
@@ -96,7 +150,23 @@ func (b *Builder) parseOpenAPISpecs(ir *IR) []error {
 			continue
 		}
 
-		doc, err := oaParser.ParseFile(comp.HTTPServer.OpenAPI)
+		file := comp.HTTPServer.OpenAPI
+		if b.fsys != nil {
+			var err error
+			if file, err = FSPath(file); err != nil {
+				errs = append(errs, fmt.Errorf("component %q: failed to parse OpenAPI spec: %w", comp.ID, err))
+				continue
+			}
+		}
+		doc, err := oaParser.ParseFile(file)
+		var invalid *openapi.InvalidDocumentError
+		if errors.As(err, &invalid) {
+			for _, issue := range invalid.Issues {
+				errs = append(errs, fmt.Errorf("component %q: %s:%d:%d: %s",
+					comp.ID, comp.HTTPServer.OpenAPI, issue.Line, issue.Column, issue))
+			}
+			continue
+		}
 		if err != nil {
 			errs = append(errs, fmt.Errorf("component %q: failed to parse OpenAPI spec %q: %w",
 				comp.ID, comp.HTTPServer.OpenAPI, err))
@@ -104,11 +174,71 @@ func (b *Builder) parseOpenAPISpecs(ir *IR) []error {
 		}
 
 		comp.HTTPServer.ParsedOpenAPI = doc
+		comp.ReadFiles = append(comp.ReadFiles, doc.Files...)
+	}
+
+	return errs
+}
+
+// parseCasbinModels parses the model files of casbin middleware so that
+// grammar problems are reported during validation. Missing files are left to
+// code generation, which reports every source file it cannot copy.
+func (b *Builder) parseCasbinModels(ir *IR) []error {
+	var errs []error
+
+	for _, comp := range ir.Components {
+		if comp.Kind != KindMiddleware || comp.Middleware == nil {
+			continue
+		}
+		if comp.Middleware.Provider != "casbin" || comp.Middleware.Model == "" {
+			continue
+		}
+
+		model, err := b.parseCasbinModel(comp.Middleware.Model)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("component %q: failed to read casbin model %q: %w",
+				comp.ID, comp.Middleware.Model, err))
+			continue
+		}
+
+		comp.Middleware.ParsedModel = model
+		file := model.File
+		if b.fsys == nil {
+			if abs, err := filepath.Abs(file); err == nil {
+				file = abs
+			}
+		}
+		comp.ReadFiles = append(comp.ReadFiles, file)
 	}
 
 	return errs
 }
 
+// parseCasbinModel reads and parses a casbin model file.
+func (b *Builder) parseCasbinModel(file string) (*casbin.Model, error) {
+	if b.fsys == nil {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(b.baseDir, file)
+		}
+		return casbin.ParseModelFile(file)
+	}
+
+	file, err := FSPath(file)
+	if err != nil {
+		return nil, err
+	}
+	data, err := fs.ReadFile(b.fsys, file)
+	if err != nil {
+		return nil, err
+	}
+	model := casbin.ParseModel(data)
+	model.File = file
+	return model, nil
+}
+
 // linkUsecasesToOperations parses binds_to and links usecases to their OpenAPI operations.
 func (b *Builder) linkUsecasesToOperations(ir *IR) []error {
 	var errs []error
@@ -141,6 +271,9 @@ func (b *Builder) linkUsecasesToOperations(ir *IR) []error {
 			errs = append(errs, fmt.Errorf("component %q: server %q not found", comp.ID, serverID))
 			continue
 		}
+		if serverSym.Placeholder {
+			continue
+		}
 
 		if serverSym.Kind != KindHTTPServer {
 			errs = append(errs, fmt.Errorf("component %q: %q is not an http.server", comp.ID, serverID))
@@ -148,8 +281,9 @@ func (b *Builder) linkUsecasesToOperations(ir *IR) []error {
 		}
 
 		serverComp := serverSym.Component
-		if serverComp.HTTPServer == nil || serverComp.HTTPServer.ParsedOpenAPI == nil {
-			// Server has no OpenAPI spec, binding is still valid but no operation resolution
+		if serverComp.HTTPServer == nil || serverComp.HTTPServer.ParsedOpenAPI == nil || binding.IsCatchAll() {
+			// Server has no OpenAPI spec, or OpenAPI cannot describe the route:
+			// binding is still valid but no operation resolution
 			comp.Usecase.Binding = binding
 			continue
 		}
@@ -158,8 +292,13 @@ func (b *Builder) linkUsecasesToOperations(ir *IR) []error {
 		opKey := openapi.OperationKey(method, path)
 		op, ok := serverComp.HTTPServer.ParsedOpenAPI.Operations[opKey]
 		if !ok {
-			errs = append(errs, fmt.Errorf("component %q: operation %s not found in %q's OpenAPI spec",
-				comp.ID, opKey, serverID))
+			if renamed := operationWithShape(serverComp.HTTPServer.ParsedOpenAPI, binding); renamed != nil {
+				errs = append(errs, fmt.Errorf("component %q: operation %s not found in %q's OpenAPI spec; %s names its path parameters differently",
+					comp.ID, opKey, serverID, renamed.OperationKey()))
+			} else {
+				errs = append(errs, fmt.Errorf("component %q: operation %s not found in %q's OpenAPI spec",
+					comp.ID, opKey, serverID))
+			}
 			continue
 		}
 
@@ -170,6 +309,68 @@ func (b *Builder) linkUsecasesToOperations(ir *IR) []error {
 	return errs
 }
 
+// operationWithShape returns the operation of doc that routes the same
+// requests as binding under other parameter names, e.g. GET:/users/{userId}
+// for a binding to GET:/users/{id}.
+func operationWithShape(doc *openapi.Document, binding *Binding) *openapi.Operation {
+	for _, op := range doc.Operations {
+		if op.Method == binding.Method && pathShape(op.Path) == pathShape(binding.Path) {
+			return op
+		}
+	}
+	return nil
+}
+
+// synthesizeOpenAPISpecs gives every http.server without an openapi file a
+// document built from its usecase bindings, for generators and tools that
+// need one. Bindings stay unlinked: the document declares no payload types.
+func (b *Builder) synthesizeOpenAPISpecs(ir *IR) {
+	for _, server := range ir.Components {
+		if server.Kind != KindHTTPServer || server.HTTPServer == nil || server.HTTPServer.OpenAPI != "" {
+			continue
+		}
+
+		var bound []*Component
+		for _, comp := range ir.Components {
+			if comp.Kind == KindUsecase && comp.Usecase != nil && comp.Usecase.Binding != nil &&
+				comp.Usecase.Binding.ServerID == server.ID && !comp.Usecase.Binding.IsCatchAll() {
+				bound = append(bound, comp)
+			}
+		}
+		sort.Slice(bound, func(a, b int) bool { return bound[a].ID < bound[b].ID })
+
+		routes := make([]openapi.Route, len(bound))
+		for n, uc := range bound {
+			routes[n] = openapi.Route{
+				OperationID: synthesizedOperationID(uc.ID),
+				Method:      uc.Usecase.Binding.Method,
+				Path:        uc.Usecase.Binding.Path,
+				Summary:     uc.Usecase.Goal,
+			}
+		}
+		server.HTTPServer.ParsedOpenAPI = openapi.Synthesize(server.ID, routes)
+	}
+}
+
+// synthesizedOperationID derives an operationId from a usecase ID, e.g.
+// "createUser" for "usecase.create-user".
+func synthesizedOperationID(usecaseID string) string {
+	var sb strings.Builder
+	upper := false
+	for _, r := range strings.TrimPrefix(usecaseID, "usecase.") {
+		switch {
+		case r == '.' || r == '-' || r == '_':
+			upper = sb.Len() > 0
+		case upper:
+			sb.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
 // parseComponentSpec parses the untyped spec into typed fields.
 // Note: Unknown kinds are filtered out before this function is called,
 // so the switch is exhaustive for all valid kinds.
@@ -180,12 +381,22 @@ func (b *Builder) parseComponentSpec(comp *Component, spec map[string]any) {
 	switch comp.Kind {
 	case KindHTTPServer:
 		b.parseHTTPServerSpec(comp, spec)
+	case KindHTTPGateway:
+		b.parseHTTPGatewaySpec(comp, spec)
 	case KindMiddleware:
 		b.parseMiddlewareSpec(comp, spec)
 	case KindPostgres:
 		b.parsePostgresSpec(comp, spec)
 	case KindUsecase:
 		b.parseUsecaseSpec(comp, spec)
+	case KindWebhook:
+		b.parseWebhookSpec(comp, spec)
+	case KindHTTPClient:
+		b.parseHTTPClientSpec(comp, spec)
+	case KindCron:
+		b.parseCronSpec(comp, spec)
+	case KindExternal:
+		b.parseExternalSpec(comp, spec)
 	}
 }
 
@@ -199,6 +410,8 @@ func (b *Builder) parseHTTPServerSpec(comp *Component, spec map[string]interface
 		s.Port = v
 	} else if v, ok := spec["port"].(float64); ok {
 		s.Port = int(v)
+	} else if spec["port"] == PortAuto {
+		s.AutoPort = true
 	}
 	if v, ok := spec["openapi"].(string); ok {
 		s.OpenAPI = v
@@ -209,10 +422,106 @@ func (b *Builder) parseHTTPServerSpec(comp *Component, spec map[string]interface
 	if v, ok := spec["depends_on"].([]any); ok {
 		s.DependsOn = toStringSlice(v)
 	}
+	if v, ok := spec["base_path"].(string); ok {
+		s.BasePath = v
+	}
+	s.APIDocs = parseAPIDocsSpec(spec["api_docs"])
+	if v, ok := spec["limits"].(map[string]any); ok {
+		s.Limits = parseLimits(v)
+	}
+	if v, ok := spec["tls"].(map[string]any); ok {
+		s.TLS = parseTLSSpec(v)
+	}
+	if v, ok := spec["observability"].(map[string]any); ok {
+		s.Metrics = parseMetricsSpec(v)
+	}
 
 	comp.HTTPServer = s
 }
 
+func (b *Builder) parseHTTPGatewaySpec(comp *Component, spec map[string]any) {
+	s := &HTTPGatewaySpec{}
+
+	if v, ok := spec["port"].(int); ok {
+		s.Port = v
+	} else if v, ok := spec["port"].(float64); ok {
+		s.Port = int(v)
+	} else if spec["port"] == PortAuto {
+		s.AutoPort = true
+	}
+	if v, ok := spec["auth"].(string); ok {
+		s.Auth = v
+	}
+	if v, ok := spec["routes"].([]any); ok {
+		for _, item := range v {
+			if m, ok := item.(map[string]any); ok {
+				var r GatewayRoute
+				r.Prefix, _ = m["prefix"].(string)
+				r.Server, _ = m["server"].(string)
+				r.StripPrefix, _ = m["strip_prefix"].(bool)
+				r.Public, _ = m["public"].(bool)
+				s.Routes = append(s.Routes, r)
+			}
+		}
+	}
+
+	comp.HTTPGateway = s
+}
+
+// parseAPIDocsSpec returns the API docs of a server: on unless api_docs is
+// false, with defaults for the fields an object leaves out.
+func parseAPIDocsSpec(v any) *APIDocsSpec {
+	s := &APIDocsSpec{UI: APIDocsScalar, Path: "/docs"}
+
+	switch v := v.(type) {
+	case bool:
+		if !v {
+			return nil
+		}
+	case map[string]any:
+		if ui, ok := v["ui"].(string); ok {
+			s.UI = ui
+		}
+		if path, ok := v["path"].(string); ok {
+			s.Path = path
+		}
+		if production, ok := v["production"].(bool); ok {
+			s.Production = production
+		}
+	}
+
+	return s
+}
+
+// parseMetricsSpec returns the metrics of a server's observability: nil
+// unless metrics is true, served at /metrics unless metrics_path is set.
+func parseMetricsSpec(v map[string]any) *MetricsSpec {
+	if enabled, _ := v["metrics"].(bool); !enabled {
+		return nil
+	}
+	s := &MetricsSpec{Path: "/metrics"}
+	if path, ok := v["metrics_path"].(string); ok {
+		s.Path = path
+	}
+	return s
+}
+
+func parseTLSSpec(v map[string]any) *TLSSpec {
+	s := &TLSSpec{}
+
+	if certEnv, ok := v["cert_env"].(string); ok {
+		s.CertEnv = certEnv
+	}
+	if keyEnv, ok := v["key_env"].(string); ok {
+		s.KeyEnv = keyEnv
+	}
+	if selfSigned, ok := v["self_signed"].(bool); ok {
+		s.SelfSigned = selfSigned
+	}
+
+	return s
+}
+
 func (b *Builder) parseMiddlewareSpec(comp *Component, spec map[string]any) {
 	s := &MiddlewareSpec{}
 
@@ -228,6 +537,24 @@ func (b *Builder) parseMiddlewareSpec(comp *Component, spec map[string]any) {
 	if v, ok := spec["policy"].(string); ok {
 		s.Policy = v
 	}
+	if v, ok := spec["policy_adapter"].(string); ok {
+		s.PolicyAdapter = v
+	}
+	if v, ok := spec["admin_route"].(string); ok {
+		s.AdminRoute = v
+	}
+	if v, ok := spec["permissions"].(map[string]any); ok {
+		s.Permissions = parsePermissions(v)
+	}
+	if v, ok := spec["roles"].(map[string]any); ok {
+		s.Roles = parseRoles(v)
+	}
+	if v, ok := spec["session"].(map[string]any); ok {
+		s.Session = parseSessionSpec(v)
+	}
+	if v, ok := spec["oauth"].(map[string]any); ok {
+		s.OAuth = parseOAuthProviders(v)
+	}
 	if v, ok := spec["depends_on"].([]interface{}); ok {
 		s.DependsOn = toStringSlice(v)
 	}
@@ -235,6 +562,223 @@ func (b *Builder) parseMiddlewareSpec(comp *Component, spec map[string]any) {
 	comp.Middleware = s
 }
 
+// expandChain creates a middleware component for each provider in the chain
+// of a middleware component, named <id>.<provider>, and records their IDs in
+// its Chain. The members share the chain's labels, owner and depends_on.
+func (b *Builder) expandChain(ir *IR, comp *Component, spec map[string]any) ([]*Component, []error) {
+	entries, _ := spec["chain"].([]any)
+	var members []*Component
+	var errs []error
+	for _, raw := range entries {
+		entry, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		member := &Component{
+			Kind:         KindMiddleware,
+			Labels:       comp.Labels,
+			Owner:        comp.Owner,
+			Position:     comp.Position,
+			Dependencies: []*Component{},
+			Dependents:   []*Component{},
+		}
+		b.parseMiddlewareSpec(member, entry)
+		member.Middleware.DependsOn = comp.Middleware.DependsOn
+		if member.Middleware.Provider == "" {
+			continue
+		}
+		member.ID = comp.ID + "." + member.Middleware.Provider
+
+		if slices.Contains(comp.Middleware.Chain, member.ID) {
+			errs = append(errs, fmt.Errorf("component %q: chain lists provider %q more than once", comp.ID, member.Middleware.Provider))
+			continue
+		}
+		if err := ir.Symbols.Define(member.ID, KindMiddleware, member); err != nil {
+			errs = append(errs, fmt.Errorf("component %q: %w", comp.ID, err))
+			continue
+		}
+		comp.Middleware.Chain = append(comp.Middleware.Chain, member.ID)
+		ir.Components[member.ID] = member
+		members = append(members, member)
+	}
+	return members, errs
+}
+
+func parsePermissions(spec map[string]any) []Permission {
+	var permissions []Permission
+	for name, raw := range spec {
+		p := Permission{Name: name}
+		if m, ok := raw.(map[string]any); ok {
+			if v, ok := m["object"].(string); ok {
+				p.Object = v
+			}
+			if v, ok := m["action"].(string); ok {
+				p.Action = v
+			}
+		}
+		permissions = append(permissions, p)
+	}
+	sort.Slice(permissions, func(a, b int) bool {
+		return permissions[a].Name < permissions[b].Name
+	})
+	return permissions
+}
+
+func parseRoles(spec map[string]any) []Role {
+	var roles []Role
+	for name, raw := range spec {
+		r := Role{Name: name}
+		if m, ok := raw.(map[string]any); ok {
+			if v, ok := m["permissions"].([]interface{}); ok {
+				r.Permissions = toStringSlice(v)
+			}
+			if v, ok := m["inherits"].([]interface{}); ok {
+				r.Inherits = toStringSlice(v)
+			}
+		}
+		roles = append(roles, r)
+	}
+	sort.Slice(roles, func(a, b int) bool {
+		return roles[a].Name < roles[b].Name
+	})
+	return roles
+}
+
+func parseOAuthProviders(spec map[string]any) []OAuthProvider {
+	var providers []OAuthProvider
+	for name, raw := range spec {
+		p := OAuthProvider{Provider: name}
+		if m, ok := raw.(map[string]any); ok {
+			if v, ok := m["client_id_env"].(string); ok {
+				p.ClientIDEnv = v
+			}
+			if v, ok := m["client_secret_env"].(string); ok {
+				p.ClientSecretEnv = v
+			}
+		}
+		providers = append(providers, p)
+	}
+	sort.Slice(providers, func(a, b int) bool {
+		return providers[a].Provider < providers[b].Provider
+	})
+	return providers
+}
+
+func parseSessionSpec(spec map[string]any) *SessionSpec {
+	s := &SessionSpec{}
+
+	if v, ok := spec["storage"].(string); ok {
+		s.Storage = v
+	}
+	if v, ok := spec["store"].(string); ok {
+		s.Store = v
+	}
+	if v, ok := spec["max_age"].(int); ok {
+		s.MaxAge = v
+	} else if v, ok := spec["max_age"].(float64); ok {
+		s.MaxAge = int(v)
+	}
+
+	return s
+}
+
+// parseResources converts the quantities of a component's resources into
+// millicores and mebibytes.
+func parseResources(r *parser.Resources) (*Resources, error) {
+	s := &Resources{Replicas: r.Replicas}
+
+	if r.CPU != "" {
+		cores, milli := r.CPU, false
+		if strings.HasSuffix(cores, "m") {
+			cores, milli = strings.TrimSuffix(cores, "m"), true
+		}
+		n, err := strconv.ParseFloat(cores, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("cpu %q is not a quantity such as 500m or 2", r.CPU)
+		}
+		if !milli {
+			n *= 1000
+		}
+		s.MilliCPU = int(math.Round(n))
+	}
+	if r.Memory != "" {
+		size, unit := r.Memory, 1
+		switch {
+		case strings.HasSuffix(size, "Gi"):
+			size, unit = strings.TrimSuffix(size, "Gi"), 1024
+		case strings.HasSuffix(size, "Mi"):
+			size = strings.TrimSuffix(size, "Mi")
+		default:
+			return nil, fmt.Errorf("memory %q is not a quantity such as 512Mi or 1Gi", r.Memory)
+		}
+		n, err := strconv.Atoi(size)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("memory %q is not a quantity such as 512Mi or 1Gi", r.Memory)
+		}
+		s.MemoryMiB = n * unit
+	}
+
+	return s, nil
+}
+
+func parseLimits(spec map[string]any) *Limits {
+	l := &Limits{}
+
+	if v, ok := spec["timeout_ms"].(int); ok {
+		l.TimeoutMS = v
+	} else if v, ok := spec["timeout_ms"].(float64); ok {
+		l.TimeoutMS = int(v)
+	}
+	if v, ok := spec["max_body_kb"].(int); ok {
+		l.MaxBodyKB = v
+	} else if v, ok := spec["max_body_kb"].(float64); ok {
+		l.MaxBodyKB = int(v)
+	}
+
+	return l
+}
+
+func parseCacheSpec(spec map[string]any) *CacheSpec {
+	c := &CacheSpec{Visibility: CachePrivate}
+
+	if v, ok := spec["ttl"].(int); ok {
+		c.TTL = v
+	} else if v, ok := spec["ttl"].(float64); ok {
+		c.TTL = int(v)
+	}
+	if v, ok := spec["vary"].([]any); ok {
+		c.Vary = toStringSlice(v)
+	}
+	if v, ok := spec["visibility"].(string); ok {
+		c.Visibility = v
+	}
+	if v, ok := spec["server"].(bool); ok {
+		c.Server = v
+	}
+	if v, ok := spec["tables"].([]any); ok {
+		c.Tables = toStringSlice(v)
+	}
+
+	return c
+}
+
+func parseSLOSpec(spec map[string]any) *SLOSpec {
+	s := &SLOSpec{}
+
+	if v, ok := spec["p99_latency_ms"].(int); ok {
+		s.P99LatencyMS = v
+	} else if v, ok := spec["p99_latency_ms"].(float64); ok {
+		s.P99LatencyMS = int(v)
+	}
+	if v, ok := spec["error_budget"].(float64); ok {
+		s.ErrorBudget = v
+	} else if v, ok := spec["error_budget"].(int); ok {
+		s.ErrorBudget = float64(v)
+	}
+
+	return s
+}
+
 func (b *Builder) parsePostgresSpec(comp *Component, spec map[string]interface{}) {
 	s := &PostgresSpec{}
 
@@ -248,6 +792,106 @@ func (b *Builder) parsePostgresSpec(comp *Component, spec map[string]interface{}
 	comp.Postgres = s
 }
 
+func (b *Builder) parseWebhookSpec(comp *Component, spec map[string]any) {
+	s := &WebhookSpec{}
+
+	if v, ok := spec["url_env"].(string); ok {
+		s.URLEnv = v
+	}
+	if v, ok := spec["secret_env"].(string); ok {
+		s.SecretEnv = v
+	}
+	if v, ok := spec["events"].([]any); ok {
+		for _, item := range v {
+			if m, ok := item.(map[string]any); ok {
+				var e WebhookEvent
+				e.Name, _ = m["name"].(string)
+				e.Description, _ = m["description"].(string)
+				s.Events = append(s.Events, e)
+			}
+		}
+	}
+	if retry, ok := spec["retry"].(map[string]any); ok {
+		if v, ok := retry["max_attempts"].(int); ok {
+			s.MaxAttempts = v
+		} else if v, ok := retry["max_attempts"].(float64); ok {
+			s.MaxAttempts = int(v)
+		}
+		if v, ok := retry["backoff_ms"].(int); ok {
+			s.BackoffMS = v
+		} else if v, ok := retry["backoff_ms"].(float64); ok {
+			s.BackoffMS = int(v)
+		}
+	}
+
+	comp.Webhook = s
+}
+
+func (b *Builder) parseHTTPClientSpec(comp *Component, spec map[string]any) {
+	s := &HTTPClientSpec{Retries: DefaultHTTPClientRetries}
+
+	if v, ok := spec["base_url_env"].(string); ok {
+		s.BaseURLEnv = v
+	}
+	if resilience, ok := spec["resilience"].(map[string]any); ok {
+		if v, ok := resilience["retries"].(int); ok {
+			s.Retries = v
+		} else if v, ok := resilience["retries"].(float64); ok {
+			s.Retries = int(v)
+		}
+		if v, ok := resilience["backoff_ms"].(int); ok {
+			s.BackoffMS = v
+		} else if v, ok := resilience["backoff_ms"].(float64); ok {
+			s.BackoffMS = int(v)
+		}
+		if breaker, ok := resilience["circuit_breaker"].(map[string]any); ok {
+			if v, ok := breaker["failure_threshold"].(int); ok {
+				s.FailureThreshold = v
+			} else if v, ok := breaker["failure_threshold"].(float64); ok {
+				s.FailureThreshold = int(v)
+			}
+			if v, ok := breaker["reset_ms"].(int); ok {
+				s.ResetMS = v
+			} else if v, ok := breaker["reset_ms"].(float64); ok {
+				s.ResetMS = int(v)
+			}
+		}
+	}
+
+	comp.HTTPClient = s
+}
+
+func (b *Builder) parseCronSpec(comp *Component, spec map[string]any) {
+	s := &CronSpec{}
+
+	if v, ok := spec["schedule"].(string); ok {
+		s.Schedule = v
+	}
+	if v, ok := spec["depends_on"].([]any); ok {
+		s.DependsOn = toStringSlice(v)
+	}
+
+	comp.Cron = s
+}
+
+// parseExternalSpec keeps an external component's spec opaque apart from
+// depends_on, which places it in the dependency graph.
+func (b *Builder) parseExternalSpec(comp *Component, spec map[string]any) {
+	s := &ExternalSpec{Properties: make(map[string]any)}
+
+	for key, value := range spec {
+		if key == "depends_on" {
+			if v, ok := value.([]any); ok {
+				s.DependsOn = toStringSlice(v)
+			}
+			continue
+		}
+		s.Properties[key] = value
+	}
+
+	comp.External = s
+}
+
 func (b *Builder) parseUsecaseSpec(comp *Component, spec map[string]interface{}) {
 	s := &UsecaseSpec{}
 
@@ -257,6 +901,39 @@ func (b *Builder) parseUsecaseSpec(comp *Component, spec map[string]interface{})
 	if v, ok := spec["middleware"].([]interface{}); ok {
 		s.Middleware = toStringSlice(v)
 	}
+	if v, ok := spec["depends_on"].([]interface{}); ok {
+		s.DependsOn = toStringSlice(v)
+	}
+	if v, ok := spec["authorization"].(map[string]any); ok {
+		s.Authorization = parseAuthorizationSpec(v)
+	}
+	if v, ok := spec["input_mapping"].(map[string]any); ok {
+		s.InputMapping = parseInputMapping(v)
+	}
+	if v, ok := spec["emits"].([]any); ok {
+		s.Emits = toStringSlice(v)
+	}
+	if v, ok := spec["calls"].([]any); ok {
+		s.Calls = toStringSlice(v)
+	}
+	if v, ok := spec["limits"].(map[string]any); ok {
+		s.Limits = parseLimits(v)
+	}
+	if v, ok := spec["cache"].(map[string]any); ok {
+		s.Cache = parseCacheSpec(v)
+	}
+	if v, ok := spec["concurrency"].(string); ok {
+		s.Concurrency = v
+	}
+	if v, ok := spec["bulk"].(bool); ok {
+		s.Bulk = v
+	}
+	if v, ok := spec["async"].(bool); ok {
+		s.Async = v
+	}
+	if v, ok := spec["slo"].(map[string]any); ok {
+		s.SLO = parseSLOSpec(v)
+	}
 	if v, ok := spec["goal"].(string); ok {
 		s.Goal = v
 	}
@@ -272,10 +949,60 @@ func (b *Builder) parseUsecaseSpec(comp *Component, spec map[string]interface{})
 	if v, ok := spec["postconditions"].([]interface{}); ok {
 		s.Postconditions = toStringSlice(v)
 	}
+	if v, ok := spec["testing"].(map[string]any); ok {
+		s.Testing = parseTestingSpec(v)
+	}
 
 	comp.Usecase = s
 }
 
+func parseTestingSpec(spec map[string]any) *TestingSpec {
+	s := &TestingSpec{Unit: true, E2E: E2ESmoke}
+
+	if v, ok := spec["unit"].(bool); ok {
+		s.Unit = v
+	}
+	if v, ok := spec["e2e"].(string); ok {
+		s.E2E = v
+	}
+
+	return s
+}
+
+// parseInputMapping parses input_mapping, whose values are either a source
+// such as "body.email" or a map with from and transform.
+func parseInputMapping(spec map[string]any) []InputMapping {
+	mappings := make([]InputMapping, 0, len(spec))
+	for field, raw := range spec {
+		m := InputMapping{Field: field}
+		var from string
+		switch v := raw.(type) {
+		case string:
+			from = v
+		case map[string]any:
+			from, _ = v["from"].(string)
+			m.Transform, _ = v["transform"].(string)
+		}
+		m.Source, m.Name, _ = strings.Cut(from, ".")
+		mappings = append(mappings, m)
+	}
+	sort.Slice(mappings, func(a, b int) bool { return mappings[a].Field < mappings[b].Field })
+	return mappings
+}
+
+func parseAuthorizationSpec(spec map[string]any) *AuthorizationSpec {
+	s := &AuthorizationSpec{}
+
+	if v, ok := spec["roles"].([]interface{}); ok {
+		s.Roles = toStringSlice(v)
+	}
+	if v, ok := spec["permissions"].([]interface{}); ok {
+		s.Permissions = toStringSlice(v)
+	}
+
+	return s
+}
+
 // resolveReferences resolves all references from a component and creates edges.
 func (b *Builder) resolveReferences(ir *IR, comp *Component) []error {
 	var errs []error
@@ -294,13 +1021,39 @@ func (b *Builder) resolveReferences(ir *IR, comp *Component) []error {
 				}
 			}
 		}
+	case KindHTTPGateway:
+		if comp.HTTPGateway != nil {
+			if comp.HTTPGateway.Auth != "" {
+				if err := b.addEdge(ir, comp, comp.HTTPGateway.Auth, EdgeTypeMiddleware); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			for _, r := range comp.HTTPGateway.Routes {
+				if r.Server == "" {
+					continue
+				}
+				if err := b.addEdge(ir, comp, r.Server, EdgeTypeDependency); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
 	case KindMiddleware:
 		if comp.Middleware != nil {
+			for _, ref := range comp.Middleware.Chain {
+				if err := b.addEdge(ir, comp, ref, EdgeTypeMiddleware); err != nil {
+					errs = append(errs, err)
+				}
+			}
 			for _, ref := range comp.Middleware.DependsOn {
 				if err := b.addEdge(ir, comp, ref, EdgeTypeDependency); err != nil {
 					errs = append(errs, err)
 				}
 			}
+			if comp.Middleware.Session != nil && comp.Middleware.Session.Store != "" {
+				if err := b.addEdge(ir, comp, comp.Middleware.Session.Store, EdgeTypeDependency); err != nil {
+					errs = append(errs, err)
+				}
+			}
 		}
 	case KindUsecase:
 		if comp.Usecase != nil {
@@ -318,6 +1071,37 @@ func (b *Builder) resolveReferences(ir *IR, comp *Component) []error {
 					errs = append(errs, err)
 				}
 			}
+			for _, ref := range comp.Usecase.DependsOn {
+				if err := b.addEdge(ir, comp, ref, EdgeTypeDependency); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			for _, ref := range comp.Usecase.Emits {
+				if err := b.addEdge(ir, comp, ref, EdgeTypeDependency); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			for _, ref := range comp.Usecase.Calls {
+				if err := b.addEdge(ir, comp, ref, EdgeTypeDependency); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	case KindCron:
+		if comp.Cron != nil {
+			for _, ref := range comp.Cron.DependsOn {
+				if err := b.addEdge(ir, comp, ref, EdgeTypeDependency); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	case KindExternal:
+		if comp.External != nil {
+			for _, ref := range comp.External.DependsOn {
+				if err := b.addEdge(ir, comp, ref, EdgeTypeDependency); err != nil {
+					errs = append(errs, err)
+				}
+			}
 		}
 	}
 
@@ -330,6 +1114,9 @@ func (b *Builder) addEdge(ir *IR, from *Component, toRef string, edgeType EdgeTy
 	if !ok {
 		return fmt.Errorf("unresolved reference %q in component %q", toRef, from.ID)
 	}
+	if sym.Placeholder {
+		return nil
+	}
 
 	to := sym.Component
 	from.Dependencies = append(from.Dependencies, to)
@@ -4,8 +4,12 @@
 package ir
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/openboundary/openboundary/internal/parser"
 )
@@ -222,6 +226,7 @@ func TestBuilder_Build_HTTPServerSpec(t *testing.T) {
 					"framework": "hono",
 					"port":      float64(3000),
 					"openapi":   "./openapi.yaml",
+					"base_path": "/api/v1",
 				},
 			},
 		},
@@ -246,6 +251,48 @@ func TestBuilder_Build_HTTPServerSpec(t *testing.T) {
 	if comp.HTTPServer.OpenAPI != "./openapi.yaml" {
 		t.Errorf("OpenAPI = %q, expected %q", comp.HTTPServer.OpenAPI, "./openapi.yaml")
 	}
+	if comp.HTTPServer.BasePath != "/api/v1" {
+		t.Errorf("BasePath = %q, expected %q", comp.HTTPServer.BasePath, "/api/v1")
+	}
+}
+
+func TestBuilder_Build_HTTPServerAPIDocs(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiDocs  interface{}
+		expected *APIDocsSpec
+	}{
+		{"default", nil, &APIDocsSpec{UI: APIDocsScalar, Path: "/docs"}},
+		{"enabled", true, &APIDocsSpec{UI: APIDocsScalar, Path: "/docs"}},
+		{"disabled", false, nil},
+		{
+			"configured",
+			map[string]interface{}{"ui": "redoc", "path": "/reference", "production": true},
+			&APIDocsSpec{UI: APIDocsRedoc, Path: "/reference", Production: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			server := map[string]interface{}{"framework": "hono", "port": 3000}
+			if tt.apiDocs != nil {
+				server["api_docs"] = tt.apiDocs
+			}
+			spec := &parser.Spec{
+				Components: []parser.Component{{ID: "http.server.api", Kind: "http.server", Spec: server}},
+			}
+
+			// when
+			ir, _ := NewBuilder().Build(spec)
+
+			// then
+			got := ir.Components["http.server.api"].HTTPServer.APIDocs
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("APIDocs = %+v, expected %+v", got, tt.expected)
+			}
+		})
+	}
 }
 
 func TestBuilder_Build_MiddlewareSpec(t *testing.T) {
@@ -284,6 +331,268 @@ func TestBuilder_Build_MiddlewareSpec(t *testing.T) {
 	}
 }
 
+func TestBuilder_Build_CasbinModel(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	model := "[request_definition]\nr = sub, obj, act\n\n[policy_definition]\np = sub, obj, act\n\n" +
+		"[policy_effect]\ne = some(where (p.eft == allow))\n\n[matchers]\nm = r.sub == p.sub && r.act == p.verb\n"
+	if err := os.WriteFile(filepath.Join(dir, "model.conf"), []byte(model), 0644); err != nil {
+		t.Fatal(err)
+	}
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{
+				ID:   "middleware.authz",
+				Kind: "middleware",
+				Spec: map[string]interface{}{
+					"provider":       "casbin",
+					"model":          "./model.conf",
+					"policy":         "./policy.csv",
+					"policy_adapter": "postgres",
+					"admin_route":    "/admin/policies",
+				},
+			},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().WithBaseDir(dir).Build(spec)
+
+	// then
+	if len(errs) != 0 {
+		t.Fatalf("Build() returned errors: %v", errs)
+	}
+	mw := ir.Components["middleware.authz"].Middleware
+	if mw.PolicyAdapter != "postgres" || mw.AdminRoute != "/admin/policies" {
+		t.Errorf("PolicyAdapter = %q, AdminRoute = %q", mw.PolicyAdapter, mw.AdminRoute)
+	}
+	if mw.ParsedModel == nil {
+		t.Fatal("ParsedModel is nil")
+	}
+	if len(mw.ParsedModel.Issues) != 1 || mw.ParsedModel.Issues[0].Line != 11 {
+		t.Errorf("ParsedModel.Issues = %v, expected p.verb on line 11", mw.ParsedModel.Issues)
+	}
+	if files := ir.Components["middleware.authz"].ReadFiles; !reflect.DeepEqual(files, []string{filepath.Join(dir, "model.conf")}) {
+		t.Errorf("ReadFiles = %v, expected the model file", files)
+	}
+}
+
+func TestBuilder_Build_WithFS(t *testing.T) {
+	// given
+	fsys := fstest.MapFS{
+		"policy/model.conf": {Data: []byte("[request_definition]\nr = sub, obj, act\n")},
+	}
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "middleware.authz", Kind: "middleware", Spec: map[string]interface{}{
+				"provider": "casbin",
+				"model":    "./policy/model.conf",
+				"policy":   "./policy/policy.csv",
+			}},
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+				"openapi":   "./openapi.yaml",
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().WithBaseDir("/nonexistent").WithFS(fsys).Build(spec)
+
+	// then
+	model := ir.Components["middleware.authz"].Middleware.ParsedModel
+	if model == nil || model.File != "policy/model.conf" {
+		t.Errorf("ParsedModel = %+v, expected policy/model.conf read from the FS", model)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "open openapi.yaml: file does not exist") {
+		t.Errorf("Build() errors = %v, expected the OpenAPI file to be missing from the FS", errs)
+	}
+}
+
+func TestBuilder_Build_RenamedPathParam(t *testing.T) {
+	// given
+	fsys := fstest.MapFS{
+		"openapi.yaml": {Data: []byte(`openapi: 3.0.3
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /users/{userId}:
+    get:
+      parameters:
+        - name: userId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+`)},
+	}
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+				"openapi":   "./openapi.yaml",
+			}},
+			{ID: "usecase.get-user", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:GET:/users/{id}",
+				"goal":     "Get a user",
+			}},
+		},
+	}
+
+	// when
+	_, errs := NewBuilder().WithFS(fsys).Build(spec)
+
+	// then
+	want := `component "usecase.get-user": operation GET:/users/{id} not found in "http.server.api"'s OpenAPI spec; GET:/users/{userId} names its path parameters differently`
+	if len(errs) != 1 || errs[0].Error() != want {
+		t.Errorf("Build() errors = %v, expected %s", errs, want)
+	}
+}
+
+func TestBuilder_Build_BetterAuthSession(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "postgres.primary", Kind: "postgres", Spec: map[string]interface{}{
+				"provider": "drizzle",
+				"schema":   "./schema.ts",
+			}},
+			{ID: "middleware.auth", Kind: "middleware", Spec: map[string]interface{}{
+				"provider": "better-auth",
+				"config":   "./auth.config.ts",
+				"session": map[string]interface{}{
+					"storage": "database",
+					"store":   "postgres.primary",
+					"max_age": 3600,
+				},
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) != 0 {
+		t.Fatalf("Build() returned errors: %v", errs)
+	}
+	session := ir.Components["middleware.auth"].Middleware.Session
+	want := SessionSpec{Storage: SessionStorageDatabase, Store: "postgres.primary", MaxAge: 3600}
+	if session == nil || *session != want {
+		t.Fatalf("Session = %+v, expected %+v", session, want)
+	}
+	if len(ir.Edges) != 1 || ir.Edges[0].To.ID != "postgres.primary" || ir.Edges[0].Type != EdgeTypeDependency {
+		t.Errorf("Edges = %v, expected a dependency on postgres.primary", ir.Edges)
+	}
+}
+
+func TestBuilder_Build_BetterAuthSessionUnresolvedStore(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "middleware.auth", Kind: "middleware", Spec: map[string]interface{}{
+				"provider": "better-auth",
+				"config":   "./auth.config.ts",
+				"session":  map[string]interface{}{"storage": "database", "store": "postgres.missing"},
+			}},
+		},
+	}
+
+	// when
+	_, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) != 1 {
+		t.Fatalf("Build() returned %d errors, expected 1: %v", len(errs), errs)
+	}
+	want := `unresolved reference "postgres.missing" in component "middleware.auth"`
+	if errs[0].Error() != want {
+		t.Errorf("Error() = %q, expected %q", errs[0].Error(), want)
+	}
+}
+
+func TestBuilder_Build_BetterAuthOAuth(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "middleware.auth", Kind: "middleware", Spec: map[string]interface{}{
+				"provider": "better-auth",
+				"config":   "./auth.config.ts",
+				"oauth": map[string]interface{}{
+					"google": map[string]interface{}{"client_id_env": "GOOGLE_CLIENT_ID", "client_secret_env": "GOOGLE_CLIENT_SECRET"},
+					"github": map[string]interface{}{"client_id_env": "GITHUB_CLIENT_ID", "client_secret_env": "GITHUB_CLIENT_SECRET"},
+				},
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) != 0 {
+		t.Fatalf("Build() returned errors: %v", errs)
+	}
+	got := ir.Components["middleware.auth"].Middleware.OAuth
+	want := []OAuthProvider{
+		{Provider: "github", ClientIDEnv: "GITHUB_CLIENT_ID", ClientSecretEnv: "GITHUB_CLIENT_SECRET"},
+		{Provider: "google", ClientIDEnv: "GOOGLE_CLIENT_ID", ClientSecretEnv: "GOOGLE_CLIENT_SECRET"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("OAuth = %+v, expected %+v", got, want)
+	}
+}
+
+func TestBuilder_Build_CasbinRBAC(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "middleware.authz", Kind: "middleware", Spec: map[string]interface{}{
+				"provider": "casbin",
+				"model":    "./model.conf",
+				"policy":   "./policy.csv",
+				"permissions": map[string]interface{}{
+					"user.write": map[string]interface{}{"object": "/users", "action": "POST"},
+					"user.read":  map[string]interface{}{"object": "/users", "action": "GET"},
+				},
+				"roles": map[string]interface{}{
+					"viewer": map[string]interface{}{"permissions": []interface{}{"user.read"}},
+					"admin":  map[string]interface{}{"permissions": []interface{}{"user.write"}, "inherits": []interface{}{"viewer"}},
+				},
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) != 0 {
+		t.Fatalf("Build() returned errors: %v", errs)
+	}
+	mw := ir.Components["middleware.authz"].Middleware
+	wantPermissions := []Permission{
+		{Name: "user.read", Object: "/users", Action: "GET"},
+		{Name: "user.write", Object: "/users", Action: "POST"},
+	}
+	if !reflect.DeepEqual(mw.Permissions, wantPermissions) {
+		t.Errorf("Permissions = %+v, expected %+v", mw.Permissions, wantPermissions)
+	}
+	wantRoles := []Role{
+		{Name: "admin", Permissions: []string{"user.write"}, Inherits: []string{"viewer"}},
+		{Name: "viewer", Permissions: []string{"user.read"}},
+	}
+	if !reflect.DeepEqual(mw.Roles, wantRoles) {
+		t.Errorf("Roles = %+v, expected %+v", mw.Roles, wantRoles)
+	}
+}
+
 func TestBuilder_Build_PostgresSpec(t *testing.T) {
 	spec := &parser.Spec{
 		Components: []parser.Component{
@@ -337,6 +646,7 @@ func TestBuilder_Build_UsecaseSpec(t *testing.T) {
 					"preconditions":       []interface{}{"pre1"},
 					"acceptance_criteria": []interface{}{"ac1", "ac2"},
 					"postconditions":      []interface{}{"post1"},
+					"testing":             map[string]interface{}{"e2e": "full"},
 				},
 			},
 		},
@@ -370,6 +680,9 @@ func TestBuilder_Build_UsecaseSpec(t *testing.T) {
 	if len(comp.Usecase.Postconditions) != 1 {
 		t.Errorf("Postconditions = %v", comp.Usecase.Postconditions)
 	}
+	if !comp.Usecase.UnitTests() || comp.Usecase.E2ETests() != E2EFull {
+		t.Errorf("Testing = %+v, expected unit tests and full E2E tests", comp.Usecase.Testing)
+	}
 }
 
 func TestExtractServerFromBinding(t *testing.T) {
@@ -462,6 +775,77 @@ func TestBuilder_Build_MiddlewareDependsOn(t *testing.T) {
 	}
 }
 
+func TestBuilder_Build_MiddlewareChain(t *testing.T) {
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{
+				ID:     "middleware.auth",
+				Kind:   "middleware",
+				Labels: []string{"team:platform"},
+				Spec: map[string]interface{}{
+					"chain": []interface{}{
+						map[string]interface{}{"provider": "better-auth", "config": "./auth.ts"},
+						map[string]interface{}{"provider": "casbin", "model": "./model.conf", "policy": "./policy.csv"},
+					},
+				},
+			},
+		},
+	}
+
+	b := NewBuilder()
+	ir, errs := b.Build(spec)
+
+	if len(errs) != 0 {
+		t.Fatalf("Build() returned errors: %v", errs)
+	}
+	chain := ir.Components["middleware.auth"]
+	want := []string{"middleware.auth.better-auth", "middleware.auth.casbin"}
+	if strings.Join(chain.Middleware.Chain, ",") != strings.Join(want, ",") {
+		t.Errorf("Chain = %v, expected %v", chain.Middleware.Chain, want)
+	}
+	if len(chain.Dependencies) != 2 {
+		t.Errorf("chain has %d dependencies, expected 2", len(chain.Dependencies))
+	}
+
+	casbin := ir.Components["middleware.auth.casbin"]
+	if casbin == nil || casbin.Middleware.Provider != "casbin" || casbin.Middleware.Policy != "./policy.csv" {
+		t.Fatalf("middleware.auth.casbin = %+v, expected the casbin provider of the chain", casbin)
+	}
+	if len(casbin.Labels) != 1 || casbin.Labels[0] != "team:platform" {
+		t.Errorf("Labels = %v, expected the chain's labels", casbin.Labels)
+	}
+	if _, ok := ir.Symbols.Lookup("middleware.auth.better-auth"); !ok {
+		t.Error("member middleware.auth.better-auth is not in the symbol table")
+	}
+}
+
+func TestBuilder_Build_MiddlewareChainDuplicateProvider(t *testing.T) {
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{
+				ID:   "middleware.auth",
+				Kind: "middleware",
+				Spec: map[string]interface{}{
+					"chain": []interface{}{
+						map[string]interface{}{"provider": "better-auth", "config": "./a.ts"},
+						map[string]interface{}{"provider": "better-auth", "config": "./b.ts"},
+					},
+				},
+			},
+		},
+	}
+
+	b := NewBuilder()
+	ir, errs := b.Build(spec)
+
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `chain lists provider "better-auth" more than once`) {
+		t.Errorf("Build() errors = %v, expected a duplicate provider error", errs)
+	}
+	if chain := ir.Components["middleware.auth"].Middleware.Chain; len(chain) != 1 {
+		t.Errorf("Chain = %v, expected the first better-auth only", chain)
+	}
+}
+
 func TestBuilder_Build_DuplicateComponentID(t *testing.T) {
 	spec := &parser.Spec{
 		Components: []parser.Component{
@@ -485,11 +869,93 @@ func TestBuilder_Build_DuplicateComponentID(t *testing.T) {
 	}
 
 	b := NewBuilder()
-	_, errs := b.Build(spec)
+	ir, errs := b.Build(spec)
 
 	if len(errs) == 0 {
 		t.Error("Build() expected error for duplicate component ID")
 	}
+	// The first definition wins
+	if port := ir.Components["comp.dup"].HTTPServer.Port; port != 3000 {
+		t.Errorf("Port = %d, expected 3000", port)
+	}
+}
+
+func TestBuilder_Build_ContinuesAfterUnknownKind(t *testing.T) {
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{
+				ID:   "client.api",
+				Kind: "grpc.client",
+				Spec: map[string]interface{}{},
+			},
+			{
+				ID:   "http.server.api",
+				Kind: "http.server",
+				Spec: map[string]interface{}{
+					"framework":  "hono",
+					"port":       3000,
+					"depends_on": []interface{}{"client.api"},
+				},
+			},
+			{
+				ID:   "middleware.auth",
+				Kind: "middleware",
+				Spec: map[string]interface{}{
+					"provider":   "better-auth",
+					"depends_on": []interface{}{"postgres.missing"},
+				},
+			},
+		},
+	}
+
+	b := NewBuilder()
+	ir, errs := b.Build(spec)
+
+	// The unknown kind and the unresolved reference are both reported, but
+	// the reference to the unknown component is not
+	if len(errs) != 2 {
+		t.Fatalf("Build() errors = %v, expected 2", errs)
+	}
+	if !strings.Contains(errs[0].Error(), `component "client.api"`) {
+		t.Errorf("errs[0] = %v, expected unknown kind of client.api", errs[0])
+	}
+	if !strings.Contains(errs[1].Error(), `unresolved reference "postgres.missing"`) {
+		t.Errorf("errs[1] = %v, expected unresolved reference", errs[1])
+	}
+
+	if _, ok := ir.Components["client.api"]; ok {
+		t.Error("placeholder should not be a component")
+	}
+	if deps := ir.Components["http.server.api"].Dependencies; len(deps) != 0 {
+		t.Errorf("Dependencies = %v, expected none", deps)
+	}
+}
+
+func TestBuilder_Build_UsecaseBindsToUnknownKind(t *testing.T) {
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{
+				ID:   "http.server.api",
+				Kind: "http.gateway",
+				Spec: map[string]interface{}{},
+			},
+			{
+				ID:   "usecase.test",
+				Kind: "usecase",
+				Spec: map[string]interface{}{
+					"goal":     "Test",
+					"binds_to": "http.server.api:GET:/users",
+				},
+			},
+		},
+	}
+
+	b := NewBuilder()
+	_, errs := b.Build(spec)
+
+	if len(errs) != 1 {
+		t.Errorf("Build() errors = %v, expected only the unknown kind", errs)
+	}
 }
 
 func TestBuilder_Build_UsecaseNoBindsTo(t *testing.T) {
@@ -896,3 +1362,781 @@ func TestBuilder_Build_UsecaseBindsToNonServer(t *testing.T) {
 		t.Error("Build() expected error about binding to non-http.server")
 	}
 }
+
+func TestBuilder_Build_InvalidOpenAPIDocument(t *testing.T) {
+	// given: an OpenAPI document with a $ref that does not resolve
+	dir := t.TempDir()
+	content := `openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      responses:
+        '200':
+          $ref: '#/components/responses/Users'
+`
+	if err := os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{
+				ID:   "http.server.api",
+				Kind: "http.server",
+				Spec: map[string]interface{}{
+					"framework": "hono",
+					"port":      3000,
+					"openapi":   "./openapi.yaml",
+				},
+			},
+		},
+	}
+
+	// when
+	_, errs := NewBuilder().WithBaseDir(dir).Build(spec)
+
+	// then
+	if len(errs) != 1 {
+		t.Fatalf("Build() returned %d errors, expected 1: %v", len(errs), errs)
+	}
+	want := `component "http.server.api": ./openapi.yaml:11:17: $ref "#/components/responses/Users" does not resolve`
+	if !strings.HasPrefix(errs[0].Error(), want) {
+		t.Errorf("error = %q, expected prefix %q", errs[0].Error(), want)
+	}
+}
+
+func TestBuilder_Build_UsecaseDependsOn(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework":  "hono",
+				"port":       3000,
+				"depends_on": []interface{}{"postgres.primary"},
+			}},
+			{ID: "postgres.primary", Kind: "postgres", Spec: map[string]interface{}{
+				"provider": "drizzle",
+				"schema":   "./schema.ts",
+			}},
+			{ID: "usecase.list-users", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to":   "http.server.api:GET:/users",
+				"goal":       "List users",
+				"depends_on": []interface{}{"postgres.primary"},
+			}},
+			{ID: "usecase.health", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:GET:/ping",
+				"goal":     "Ping",
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	uc := ir.Components["usecase.list-users"]
+	if len(uc.Usecase.DependsOn) != 1 || uc.Usecase.DependsOn[0] != "postgres.primary" {
+		t.Errorf("DependsOn = %v, expected [postgres.primary]", uc.Usecase.DependsOn)
+	}
+	if ir.Components["usecase.health"].Usecase.DependsOn != nil {
+		t.Error("DependsOn should be nil when omitted")
+	}
+
+	found := false
+	for _, edge := range ir.Edges {
+		if edge.From.ID == "usecase.list-users" && edge.To.ID == "postgres.primary" && edge.Type == EdgeTypeDependency {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected dependency edge from usecase.list-users to postgres.primary")
+	}
+}
+
+func TestBuilder_Build_UsecaseAuthorization(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+			}},
+			{ID: "usecase.delete-user", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:DELETE:/users/{id}",
+				"goal":     "Delete a user",
+				"authorization": map[string]interface{}{
+					"roles":       []interface{}{"admin"},
+					"permissions": []interface{}{"user.delete"},
+				},
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	want := &AuthorizationSpec{Roles: []string{"admin"}, Permissions: []string{"user.delete"}}
+	if got := ir.Components["usecase.delete-user"].Usecase.Authorization; !reflect.DeepEqual(got, want) {
+		t.Errorf("Authorization = %+v, expected %+v", got, want)
+	}
+}
+
+func TestBuilder_Build_UsecaseInputMapping(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+			}},
+			{ID: "usecase.update-user", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:PATCH:/users/{id}",
+				"goal":     "Update a user",
+				"input_mapping": map[string]interface{}{
+					"userId": "path.id",
+					"email":  map[string]interface{}{"from": "body.email_address", "transform": "lowercase"},
+				},
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	want := []InputMapping{
+		{Field: "email", Source: InputFromBody, Name: "email_address", Transform: "lowercase"},
+		{Field: "userId", Source: InputFromPath, Name: "id"},
+	}
+	if got := ir.Components["usecase.update-user"].Usecase.InputMapping; !reflect.DeepEqual(got, want) {
+		t.Errorf("InputMapping = %+v, expected %+v", got, want)
+	}
+}
+
+func TestBuilder_Build_Webhook(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+			}},
+			{ID: "webhook.orders", Kind: "webhook", Spec: map[string]interface{}{
+				"url_env":    "ORDERS_WEBHOOK_URL",
+				"secret_env": "ORDERS_WEBHOOK_SECRET",
+				"events": []interface{}{
+					map[string]interface{}{"name": "order.created", "description": "An order was placed"},
+					map[string]interface{}{"name": "order.shipped"},
+				},
+				"retry": map[string]interface{}{"max_attempts": 3},
+			}},
+			{ID: "usecase.create-order", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:POST:/orders",
+				"goal":     "Place an order",
+				"emits":    []interface{}{"webhook.orders"},
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	webhook := ir.Components["webhook.orders"].Webhook
+	want := &WebhookSpec{
+		URLEnv:      "ORDERS_WEBHOOK_URL",
+		SecretEnv:   "ORDERS_WEBHOOK_SECRET",
+		Events:      []WebhookEvent{{Name: "order.created", Description: "An order was placed"}, {Name: "order.shipped"}},
+		MaxAttempts: 3,
+	}
+	if !reflect.DeepEqual(webhook, want) {
+		t.Errorf("Webhook = %+v, expected %+v", webhook, want)
+	}
+	if webhook.Attempts() != 3 || webhook.Backoff() != DefaultWebhookBackoffMS {
+		t.Errorf("Attempts(), Backoff() = %d, %d, expected 3, %d", webhook.Attempts(), webhook.Backoff(), DefaultWebhookBackoffMS)
+	}
+	found := false
+	for _, edge := range ir.Edges {
+		if edge.From.ID == "usecase.create-order" && edge.To.ID == "webhook.orders" && edge.Type == EdgeTypeDependency {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected a dependency edge from the usecase to the webhook it emits to")
+	}
+}
+
+func TestBuilder_Build_HTTPClient(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+			}},
+			{ID: "http.client.payments", Kind: "http.client", Spec: map[string]interface{}{
+				"base_url_env": "PAYMENTS_URL",
+				"resilience": map[string]interface{}{
+					"retries":         0,
+					"circuit_breaker": map[string]interface{}{"failure_threshold": 3},
+				},
+			}},
+			{ID: "http.client.shipping", Kind: "http.client", Spec: map[string]interface{}{
+				"base_url_env": "SHIPPING_URL",
+			}},
+			{ID: "usecase.create-order", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:POST:/orders",
+				"goal":     "Place an order",
+				"calls":    []interface{}{"http.client.payments"},
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	payments := ir.Components["http.client.payments"].HTTPClient
+	if want := (&HTTPClientSpec{BaseURLEnv: "PAYMENTS_URL", FailureThreshold: 3}); !reflect.DeepEqual(payments, want) {
+		t.Errorf("HTTPClient = %+v, expected %+v", payments, want)
+	}
+	if payments.Threshold() != 3 || payments.Backoff() != DefaultHTTPClientBackoffMS || payments.Reset() != DefaultHTTPClientResetMS {
+		t.Errorf("Threshold(), Backoff(), Reset() = %d, %d, %d", payments.Threshold(), payments.Backoff(), payments.Reset())
+	}
+	if got := ir.Components["http.client.shipping"].HTTPClient.Retries; got != DefaultHTTPClientRetries {
+		t.Errorf("Retries = %d, expected the default %d", got, DefaultHTTPClientRetries)
+	}
+	found := false
+	for _, edge := range ir.Edges {
+		if edge.From.ID == "usecase.create-order" && edge.To.ID == "http.client.payments" && edge.Type == EdgeTypeDependency {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected a dependency edge from the usecase to the client it calls")
+	}
+}
+
+func TestBuilder_Build_Cron(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "postgres.primary", Kind: "postgres", Spec: map[string]interface{}{
+				"provider": "drizzle",
+				"schema":   "./schema.ts",
+			}},
+			{ID: "cron.nightly-cleanup", Kind: "cron", Spec: map[string]interface{}{
+				"schedule":   "0 3 * * *",
+				"depends_on": []interface{}{"postgres.primary"},
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	cron := ir.Components["cron.nightly-cleanup"].Cron
+	if want := (&CronSpec{Schedule: "0 3 * * *", DependsOn: []string{"postgres.primary"}}); !reflect.DeepEqual(cron, want) {
+		t.Errorf("Cron = %+v, expected %+v", cron, want)
+	}
+	found := false
+	for _, edge := range ir.Edges {
+		if edge.From.ID == "cron.nightly-cleanup" && edge.To.ID == "postgres.primary" && edge.Type == EdgeTypeDependency {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected a dependency edge from the job to its database")
+	}
+}
+
+func TestBuilder_Build_HTTPGateway(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.orders", Kind: "http.server", Spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3001,
+			}},
+			{ID: "http.server.catalog", Kind: "http.server", Spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3002,
+			}},
+			{ID: "middleware.authn", Kind: "middleware", Spec: map[string]interface{}{
+				"provider": "better-auth",
+				"config":   "./auth.config.ts",
+			}},
+			{ID: "http.gateway.edge", Kind: "http.gateway", Spec: map[string]interface{}{
+				"port": 8080,
+				"auth": "middleware.authn",
+				"routes": []interface{}{
+					map[string]interface{}{"prefix": "/orders", "server": "http.server.orders"},
+					map[string]interface{}{"prefix": "/catalog", "server": "http.server.catalog", "strip_prefix": true, "public": true},
+				},
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	want := &HTTPGatewaySpec{
+		Port: 8080,
+		Auth: "middleware.authn",
+		Routes: []GatewayRoute{
+			{Prefix: "/orders", Server: "http.server.orders"},
+			{Prefix: "/catalog", Server: "http.server.catalog", StripPrefix: true, Public: true},
+		},
+	}
+	if got := ir.Components["http.gateway.edge"].HTTPGateway; !reflect.DeepEqual(got, want) {
+		t.Errorf("HTTPGateway = %+v, expected %+v", got, want)
+	}
+	edges := make(map[string]EdgeType)
+	for _, edge := range ir.Edges {
+		if edge.From.ID == "http.gateway.edge" {
+			edges[edge.To.ID] = edge.Type
+		}
+	}
+	wantEdges := map[string]EdgeType{
+		"middleware.authn":    EdgeTypeMiddleware,
+		"http.server.orders":  EdgeTypeDependency,
+		"http.server.catalog": EdgeTypeDependency,
+	}
+	if !reflect.DeepEqual(edges, wantEdges) {
+		t.Errorf("gateway edges = %v, expected %v", edges, wantEdges)
+	}
+}
+
+func TestBuilder_Build_External(t *testing.T) {
+	// given: a server depending on a legacy system that depends on its own database
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework":  "hono",
+				"port":       3000,
+				"depends_on": []interface{}{"external.billing"},
+			}},
+			{ID: "external.billing", Kind: "external", Spec: map[string]interface{}{
+				"depends_on": []interface{}{"external.billing-db"},
+				"system":     "mainframe",
+				"endpoints":  []interface{}{"/invoices"},
+			}},
+			{ID: "external.billing-db", Kind: "external", Spec: map[string]interface{}{}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	want := &ExternalSpec{
+		DependsOn:  []string{"external.billing-db"},
+		Properties: map[string]any{"system": "mainframe", "endpoints": []interface{}{"/invoices"}},
+	}
+	billing := ir.Components["external.billing"]
+	if !reflect.DeepEqual(billing.External, want) {
+		t.Errorf("External = %+v, expected %+v", billing.External, want)
+	}
+	if len(billing.Dependents) != 1 || billing.Dependents[0].ID != "http.server.api" {
+		t.Errorf("Dependents = %v, expected the server", billing.Dependents)
+	}
+	if len(billing.Dependencies) != 1 || billing.Dependencies[0].ID != "external.billing-db" {
+		t.Errorf("Dependencies = %v, expected external.billing-db", billing.Dependencies)
+	}
+}
+
+func TestBuilder_Build_Limits(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+				"limits":    map[string]interface{}{"timeout_ms": 30000, "max_body_kb": float64(1024)},
+			}},
+			{ID: "usecase.upload-avatar", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:POST:/avatars",
+				"goal":     "Upload an avatar",
+				"limits":   map[string]interface{}{"timeout_ms": 5000},
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	if got, want := ir.Components["http.server.api"].HTTPServer.Limits, (&Limits{TimeoutMS: 30000, MaxBodyKB: 1024}); !reflect.DeepEqual(got, want) {
+		t.Errorf("server Limits = %+v, expected %+v", got, want)
+	}
+	if got, want := ir.Components["usecase.upload-avatar"].Usecase.Limits, (&Limits{TimeoutMS: 5000}); !reflect.DeepEqual(got, want) {
+		t.Errorf("usecase Limits = %+v, expected %+v", got, want)
+	}
+}
+
+func TestBuilder_Build_Cache(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+			{ID: "usecase.list-products", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:GET:/products",
+				"goal":     "List products",
+				"cache":    map[string]interface{}{"ttl": float64(300), "vary": []interface{}{"Accept-Language"}, "visibility": "public", "server": true, "tables": []interface{}{"products"}},
+			}},
+			{ID: "usecase.get-profile", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:GET:/profile",
+				"goal":     "Get the caller's profile",
+				"cache":    map[string]interface{}{"ttl": 60},
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	products := &CacheSpec{TTL: 300, Vary: []string{"Accept-Language"}, Visibility: CachePublic, Server: true, Tables: []string{"products"}}
+	if got := ir.Components["usecase.list-products"].Usecase.Cache; !reflect.DeepEqual(got, products) {
+		t.Errorf("Cache = %+v, expected %+v", got, products)
+	}
+	profile := &CacheSpec{TTL: 60, Visibility: CachePrivate}
+	if got := ir.Components["usecase.get-profile"].Usecase.Cache; !reflect.DeepEqual(got, profile) {
+		t.Errorf("Cache = %+v, expected %+v", got, profile)
+	}
+}
+
+func TestBuilder_Build_Concurrency(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+			{ID: "usecase.update-product", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to":    "http.server.api:PUT:/products/{id}",
+				"goal":        "Update a product",
+				"concurrency": "etag",
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	if got := ir.Components["usecase.update-product"].Usecase.Concurrency; got != ConcurrencyETag {
+		t.Errorf("Concurrency = %q, expected %q", got, ConcurrencyETag)
+	}
+}
+
+func TestBuilder_Build_Bulk(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+			{ID: "usecase.import-products", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:POST:/products",
+				"goal":     "Import products",
+				"bulk":     true,
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	if !ir.Components["usecase.import-products"].Usecase.Bulk {
+		t.Error("Bulk = false, expected true")
+	}
+}
+
+func TestBuilder_Build_Async(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+			{ID: "usecase.export-orders", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:POST:/orders/exports",
+				"goal":     "Export orders",
+				"async":    true,
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	status := ir.Components["usecase.export-orders"].Usecase.JobStatus()
+	expected := &Binding{ServerID: "http.server.api", Method: "GET", Path: "/orders/exports/jobs/{jobId}"}
+	if !reflect.DeepEqual(status, expected) {
+		t.Errorf("JobStatus() = %+v, expected %+v", status, expected)
+	}
+}
+
+func TestBuilder_Build_TLS(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3443,
+				"tls": map[string]interface{}{
+					"cert_env":    "API_TLS_CERT",
+					"key_env":     "API_TLS_KEY",
+					"self_signed": true,
+				},
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	tls := ir.Components["http.server.api"].HTTPServer.TLS
+	expected := &TLSSpec{CertEnv: "API_TLS_CERT", KeyEnv: "API_TLS_KEY", SelfSigned: true}
+	if !reflect.DeepEqual(tls, expected) {
+		t.Errorf("TLS = %+v, expected %+v", tls, expected)
+	}
+}
+
+func TestBuilder_Build_ObservabilityAndSLO(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework":     "hono",
+				"port":          3000,
+				"observability": map[string]interface{}{"metrics": true},
+			}},
+			{ID: "http.server.admin", Kind: "http.server", Spec: map[string]interface{}{
+				"framework":     "hono",
+				"port":          3001,
+				"observability": map[string]interface{}{"metrics": false, "metrics_path": "/internal/metrics"},
+			}},
+			{ID: "usecase.get-user", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:GET:/users/{id}",
+				"goal":     "Get a user",
+				"slo":      map[string]interface{}{"p99_latency_ms": 250, "error_budget": 0.1},
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() unexpected errors: %v", errs)
+	}
+	if got, want := ir.Components["http.server.api"].HTTPServer.Metrics, (&MetricsSpec{Path: "/metrics"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("api Metrics = %+v, expected %+v", got, want)
+	}
+	if got := ir.Components["http.server.admin"].HTTPServer.Metrics; got != nil {
+		t.Errorf("admin Metrics = %+v, expected nil", got)
+	}
+	if got, want := ir.Components["usecase.get-user"].Usecase.SLO, (&SLOSpec{P99LatencyMS: 250, ErrorBudget: 0.1}); !reflect.DeepEqual(got, want) {
+		t.Errorf("SLO = %+v, expected %+v", got, want)
+	}
+}
+
+func TestBuilder_Build_SynthesizesOpenAPI(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+			{ID: "usecase.get-user", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:GET:/users/{id}",
+				"goal":     "Get a user",
+			}},
+			{ID: "usecase.admin.create-user", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:POST:/users",
+				"goal":     "Create a user",
+			}},
+		},
+	}
+
+	// when
+	ir, errs := NewBuilder().Build(spec)
+
+	// then
+	if len(errs) > 0 {
+		t.Fatalf("Build() errors = %v", errs)
+	}
+	doc := ir.Components["http.server.api"].HTTPServer.ParsedOpenAPI
+	if doc == nil || !doc.Synthesized {
+		t.Fatalf("ParsedOpenAPI = %+v, expected a synthesized document", doc)
+	}
+	for key, want := range map[string]string{"GET:/users/{id}": "getUser", "POST:/users": "adminCreateUser"} {
+		op := doc.Operations[key]
+		if op == nil {
+			t.Errorf("missing operation %s", key)
+			continue
+		}
+		if op.OperationID != want {
+			t.Errorf("%s operationId = %q, expected %q", key, op.OperationID, want)
+		}
+	}
+	if doc.Operations["GET:/users/{id}"].Summary != "Get a user" {
+		t.Errorf("summary = %q, expected the usecase goal", doc.Operations["GET:/users/{id}"].Summary)
+	}
+	// The document declares no types, so bindings are not linked to it
+	if op := ir.Components["usecase.get-user"].Usecase.Binding.Operation; op != nil {
+		t.Errorf("Binding.Operation = %+v, expected nil", op)
+	}
+}
+
+func TestBuilder_Build_Labels(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Labels: []string{"team:billing", "public"}, Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+		},
+	}
+
+	// when
+	ir, _ := NewBuilder().Build(spec)
+
+	// then
+	if got := ir.Components["http.server.api"].Labels; !reflect.DeepEqual(got, []string{"team:billing", "public"}) {
+		t.Errorf("Labels = %v, expected [team:billing public]", got)
+	}
+}
+
+func TestBuilder_Build_Resources(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources *parser.Resources
+		expected  *Resources
+		wantErr   bool
+	}{
+		{
+			name:      "millicores and mebibytes",
+			resources: &parser.Resources{CPU: "500m", Memory: "512Mi", Replicas: 3},
+			expected:  &Resources{MilliCPU: 500, MemoryMiB: 512, Replicas: 3},
+		},
+		{
+			name:      "cores and gibibytes",
+			resources: &parser.Resources{CPU: "1.5", Memory: "2Gi"},
+			expected:  &Resources{MilliCPU: 1500, MemoryMiB: 2048},
+		},
+		{
+			name:      "memory without unit",
+			resources: &parser.Resources{Memory: "512"},
+			wantErr:   true,
+		},
+		{
+			name:      "cpu that is no number",
+			resources: &parser.Resources{CPU: "half"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{
+						ID:        "http.server.api",
+						Kind:      "http.server",
+						Resources: tt.resources,
+						Spec:      map[string]interface{}{"framework": "hono", "port": 3000},
+					},
+				},
+			}
+
+			// when
+			ir, errs := NewBuilder().Build(spec)
+
+			// then
+			if (len(errs) > 0) != tt.wantErr {
+				t.Fatalf("Build() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+			if got := ir.Components["http.server.api"].Resources; !tt.wantErr && !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Resources = %+v, expected %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuilder_Build_Metadata(t *testing.T) {
+	// given
+	links := []parser.Link{{Title: "Runbook", URL: "https://runbooks.example.com/api"}}
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{
+				ID:          "http.server.api",
+				Kind:        "http.server",
+				Description: "Public API",
+				Owner:       "team-platform",
+				Links:       links,
+				Spec:        map[string]interface{}{"framework": "hono", "port": 3000},
+			},
+		},
+	}
+
+	// when
+	ir, _ := NewBuilder().Build(spec)
+
+	// then
+	comp := ir.Components["http.server.api"]
+	if comp.Description != "Public API" {
+		t.Errorf("Description = %q, expected %q", comp.Description, "Public API")
+	}
+	if comp.Owner != "team-platform" {
+		t.Errorf("Owner = %q, expected %q", comp.Owner, "team-platform")
+	}
+	if !reflect.DeepEqual(comp.Links, links) {
+		t.Errorf("Links = %v, expected %v", comp.Links, links)
+	}
+}
@@ -3,6 +3,8 @@
 
 package ir
 
+import "sort"
+
 // DetectCycles returns any cycles found in the dependency graph.
 func (ir *IR) DetectCycles() [][]string {
 	var cycles [][]string
@@ -138,3 +140,64 @@ type ComponentNotFoundError struct {
 func (e *ComponentNotFoundError) Error() string {
 	return "component not found: " + e.ID
 }
+
+// Graph is a JSON-encodable view of the dependency graph, for tools that
+// draw it, such as the compiler API and the in-browser playground.
+type Graph struct {
+	Name       string           `json:"name"`
+	Version    string           `json:"version"`
+	Components []GraphComponent `json:"components"`
+	Edges      []GraphEdge      `json:"edges"`
+}
+
+// GraphComponent is a component of a Graph. Dependencies are component IDs.
+type GraphComponent struct {
+	ID           string   `json:"id"`
+	Kind         Kind     `json:"kind"`
+	Labels       []string `json:"labels,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Owner        string   `json:"owner,omitempty"`
+	Deprecated   bool     `json:"deprecated,omitempty"`
+	Replacement  string   `json:"replacement,omitempty"`
+	Dependencies []string `json:"dependencies"`
+	Line         int      `json:"line,omitempty"`
+}
+
+// GraphEdge is an edge of a Graph between two component IDs.
+type GraphEdge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Type EdgeType `json:"type"`
+}
+
+// Graph returns the dependency graph, with components sorted by ID and
+// edges in the order they were built.
+func (ir *IR) Graph() *Graph {
+	g := &Graph{Components: []GraphComponent{}, Edges: []GraphEdge{}}
+	if ir.Spec != nil {
+		g.Name, g.Version = ir.Spec.Name, ir.Spec.Version
+	}
+	for _, comp := range ir.Components {
+		c := GraphComponent{
+			ID:           comp.ID,
+			Kind:         comp.Kind,
+			Labels:       comp.Labels,
+			Description:  comp.Description,
+			Owner:        comp.Owner,
+			Deprecated:   comp.Deprecated,
+			Replacement:  comp.Replacement,
+			Dependencies: []string{},
+			Line:         comp.Position.Line,
+		}
+		for _, dep := range comp.Dependencies {
+			c.Dependencies = append(c.Dependencies, dep.ID)
+		}
+		sort.Strings(c.Dependencies)
+		g.Components = append(g.Components, c)
+	}
+	sort.Slice(g.Components, func(a, b int) bool { return g.Components[a].ID < g.Components[b].ID })
+	for _, edge := range ir.Edges {
+		g.Edges = append(g.Edges, GraphEdge{From: edge.From.ID, To: edge.To.ID, Type: edge.Type})
+	}
+	return g
+}
@@ -313,3 +313,36 @@ func TestFormatCycle(t *testing.T) {
 		})
 	}
 }
+
+func TestIR_Graph(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Name:    "orders",
+		Version: "0.1.0",
+		Components: []parser.Component{
+			{ID: "usecase.list", Kind: "usecase", Spec: map[string]interface{}{"binds_to": "http.server.api:GET:/orders", "goal": "List"}},
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+		},
+	}
+	ir, errs := NewBuilder().Build(spec)
+	if len(errs) != 0 {
+		t.Fatalf("Build() returned errors: %v", errs)
+	}
+
+	// when
+	g := ir.Graph()
+
+	// then
+	if g.Name != "orders" || g.Version != "0.1.0" {
+		t.Errorf("Name, Version = %q, %q, expected orders, 0.1.0", g.Name, g.Version)
+	}
+	if len(g.Components) != 2 || g.Components[0].ID != "http.server.api" {
+		t.Fatalf("Components = %v, expected two sorted by ID", g.Components)
+	}
+	if deps := g.Components[1].Dependencies; len(deps) != 1 || deps[0] != "http.server.api" {
+		t.Errorf("usecase.list dependencies = %v, expected [http.server.api]", deps)
+	}
+	if len(g.Edges) != 1 || g.Edges[0] != (GraphEdge{From: "usecase.list", To: "http.server.api", Type: EdgeTypeBinding}) {
+		t.Errorf("Edges = %v, expected the binding", g.Edges)
+	}
+}
@@ -6,8 +6,15 @@ package ir
 
 import (
 	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
 	"slices"
+	"strings"
 
+	"github.com/openboundary/openboundary/internal/casbin"
 	"github.com/openboundary/openboundary/internal/openapi"
 	"github.com/openboundary/openboundary/internal/parser"
 )
@@ -20,6 +27,7 @@ type IR struct {
 	Edges      []Edge
 	Symbols    *SymbolTable
 	BaseDir    string // Base directory for resolving relative paths
+	FS         fs.FS  // Where referenced files are read from instead of BaseDir, if set
 }
 
 // New creates a new IR from a parsed spec.
@@ -32,19 +40,219 @@ func New(spec *parser.Spec) *IR {
 	}
 }
 
+// EnvVar returns the environment variable the generated code reads for
+// name: name itself, or prefixed when the spec namespaces its variables,
+// e.g. "ORDERS_DATABASE_URL" for "DATABASE_URL" in project "orders".
+func (i *IR) EnvVar(name string) string {
+	if i.Spec == nil || i.Spec.Env == nil {
+		return name
+	}
+	prefix := i.Spec.Env.Prefix
+	if prefix == "" {
+		if !i.Spec.Env.Namespaced {
+			return name
+		}
+		prefix = strings.ToUpper(strings.ReplaceAll(i.Spec.Name, "-", "_"))
+	}
+	return prefix + "_" + name
+}
+
+// ReadFile reads a file the spec references, such as a Drizzle schema or a
+// policy file: from FS if set, without leaving its root, and otherwise from
+// the file system relative to BaseDir.
+func (i *IR) ReadFile(name string) ([]byte, error) {
+	if i.FS == nil {
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(i.BaseDir, name)
+		}
+		return os.ReadFile(name)
+	}
+	file, err := FSPath(name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadFile(i.FS, file)
+}
+
+// FSPath returns the path within an fs.FS rooted at the spec's directory of
+// a file the spec references, e.g. "config/openapi.yaml" for
+// "./config/openapi.yaml". Absolute paths and paths that climb out of the
+// root with ".." are rejected.
+func FSPath(name string) (string, error) {
+	file := path.Clean(filepath.ToSlash(name))
+	if !fs.ValidPath(file) {
+		return "", fmt.Errorf("%q is outside the directory the spec's files are read from", name)
+	}
+	return file, nil
+}
+
 // Component represents a resolved component in the IR.
 type Component struct {
 	ID           string
 	Kind         Kind
+	Labels       []string
+	Description  string
+	Owner        string
+	Links        []parser.Link
+	Deprecated   bool
+	Replacement  string     // Component to use instead of a deprecated one, if any
+	Resources    *Resources // Deployment size, or nil to leave it to the platform
 	Position     parser.Position
+	ReadFiles    []string // Files read to build it, such as its OpenAPI document and the files that $refs, as ReadFile takes them
 	Dependencies []*Component
 	Dependents   []*Component
 
 	// Kind-specific typed specs
-	HTTPServer *HTTPServerSpec
-	Middleware *MiddlewareSpec
-	Postgres   *PostgresSpec
-	Usecase    *UsecaseSpec
+	HTTPServer  *HTTPServerSpec
+	HTTPGateway *HTTPGatewaySpec
+	Middleware  *MiddlewareSpec
+	Postgres    *PostgresSpec
+	Usecase     *UsecaseSpec
+	Webhook     *WebhookSpec
+	HTTPClient  *HTTPClientSpec
+	Cron        *CronSpec
+	External    *ExternalSpec
+}
+
+// Resources are the limits of each instance of a component and how many
+// instances run. Zero fields are unset.
+type Resources struct {
+	MilliCPU  int // Thousandths of a core
+	MemoryMiB int
+	Replicas  int
+}
+
+// Files returns the files the component references, such as its OpenAPI
+// document or Drizzle schema, as written in the spec.
+func (c *Component) Files() []string {
+	var files []string
+	switch {
+	case c.HTTPServer != nil:
+		files = append(files, c.HTTPServer.OpenAPI)
+	case c.Postgres != nil:
+		files = append(files, c.Postgres.Schema)
+	case c.Middleware != nil:
+		files = append(files, c.Middleware.Config, c.Middleware.Model, c.Middleware.Policy)
+	}
+	return slices.DeleteFunc(files, func(file string) bool { return file == "" })
+}
+
+// DeprecationNotice returns the message generated code logs for a deprecated
+// component, naming its replacement when it has one.
+func (c *Component) DeprecationNotice() string {
+	if c.Replacement != "" {
+		return fmt.Sprintf("%s is deprecated; use %s instead", c.ID, c.Replacement)
+	}
+	return c.ID + " is deprecated"
+}
+
+// DeprecatedReference is a reference from a component that is not
+// deprecated to one that is.
+type DeprecatedReference struct {
+	From *Component
+	To   *Component
+}
+
+// DeprecatedReferences returns the references to deprecated components from
+// components that are not deprecated, sorted by referencing and then by
+// referenced ID. Deprecated components may keep referencing each other, so
+// that they can be retired together.
+func (i *IR) DeprecatedReferences() []DeprecatedReference {
+	seen := make(map[[2]string]bool)
+	var refs []DeprecatedReference
+	for _, edge := range i.Edges {
+		key := [2]string{edge.From.ID, edge.To.ID}
+		if !edge.To.Deprecated || edge.From.Deprecated || seen[key] {
+			continue
+		}
+		seen[key] = true
+		refs = append(refs, DeprecatedReference{From: edge.From, To: edge.To})
+	}
+	slices.SortFunc(refs, func(a, b DeprecatedReference) int {
+		if c := strings.Compare(a.From.ID, b.From.ID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.To.ID, b.To.ID)
+	})
+	return refs
+}
+
+// DeprecatedComponentsRunBy returns the deprecated components a server runs:
+// the server with its dependencies and middleware, and the usecases bound to
+// it with their middleware, dependencies, webhooks and HTTP clients, sorted
+// by ID. Middleware chains count with the middleware they compose.
+func (i *IR) DeprecatedComponentsRunBy(server *Component) []*Component {
+	ids := map[string]bool{server.ID: true}
+	add := func(refs []string) {
+		for _, ref := range refs {
+			ids[ref] = true
+		}
+	}
+	add(server.HTTPServer.DependsOn)
+	add(server.HTTPServer.Middleware)
+	add(i.ExpandMiddleware(server.HTTPServer.Middleware))
+	for _, uc := range i.UsecasesBoundTo(server.ID) {
+		ids[uc.ID] = true
+		add(uc.Usecase.Middleware)
+		add(i.ExpandMiddleware(uc.Usecase.Middleware))
+		add(uc.Usecase.DependsOn)
+		add(uc.Usecase.Emits)
+		add(uc.Usecase.Calls)
+	}
+
+	var deprecated []*Component
+	for id := range ids {
+		if comp, ok := i.Components[id]; ok && comp.Deprecated {
+			deprecated = append(deprecated, comp)
+		}
+	}
+	slices.SortFunc(deprecated, func(a, b *Component) int { return strings.Compare(a.ID, b.ID) })
+	return deprecated
+}
+
+// ExpandMiddleware returns the middleware refs with each chain replaced by
+// the middleware it composes, for code that looks at providers.
+func (i *IR) ExpandMiddleware(refs []string) []string {
+	if refs == nil {
+		return nil
+	}
+	expanded := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		comp, ok := i.Components[ref]
+		if ok && comp.Middleware != nil && len(comp.Middleware.Chain) > 0 {
+			expanded = append(expanded, comp.Middleware.Chain...)
+			continue
+		}
+		expanded = append(expanded, ref)
+	}
+	return expanded
+}
+
+// HTTPServers returns every http.server component, sorted by ID.
+func (i *IR) HTTPServers() []*Component {
+	var servers []*Component
+	for _, comp := range i.Components {
+		if comp.Kind == KindHTTPServer && comp.HTTPServer != nil {
+			servers = append(servers, comp)
+		}
+	}
+	slices.SortFunc(servers, func(a, b *Component) int { return strings.Compare(a.ID, b.ID) })
+	return servers
+}
+
+// UsecasesBoundTo returns the usecases bound to a server, sorted by ID.
+func (i *IR) UsecasesBoundTo(serverID string) []*Component {
+	if i == nil {
+		return nil
+	}
+	var usecases []*Component
+	for _, comp := range i.Components {
+		if comp.Kind == KindUsecase && comp.Usecase != nil && comp.Usecase.Binding != nil && comp.Usecase.Binding.ServerID == serverID {
+			usecases = append(usecases, comp)
+		}
+	}
+	slices.SortFunc(usecases, func(a, b *Component) int { return strings.Compare(a.ID, b.ID) })
+	return usecases
 }
 
 // Kind represents a component kind.
@@ -53,13 +261,18 @@ type Kind string
 // Known component kinds.
 // TODO: Make kinds extendable via a KindPlugin interface so each kind ships its
 // own spec parser, reference resolver, validator, and schema fragment. Holding
-// off until a 5th kind (or a 3rd-party kind) forces the design — the abstraction
-// boundary between kinds isn't clear enough yet with only four first-party kinds.
+// off until a 3rd-party kind forces the design — the abstraction boundary
+// between kinds isn't clear enough yet with only first-party kinds.
 const (
-	KindHTTPServer Kind = "http.server"
-	KindMiddleware Kind = "middleware"
-	KindPostgres   Kind = "postgres"
-	KindUsecase    Kind = "usecase"
+	KindHTTPServer  Kind = "http.server"
+	KindHTTPGateway Kind = "http.gateway"
+	KindMiddleware  Kind = "middleware"
+	KindPostgres    Kind = "postgres"
+	KindUsecase     Kind = "usecase"
+	KindWebhook     Kind = "webhook"
+	KindHTTPClient  Kind = "http.client"
+	KindCron        Kind = "cron"
+	KindExternal    Kind = "external"
 )
 
 // ParseKind converts a string to a Kind.
@@ -67,12 +280,22 @@ func ParseKind(s string) (Kind, error) {
 	switch s {
 	case string(KindHTTPServer):
 		return KindHTTPServer, nil
+	case string(KindHTTPGateway):
+		return KindHTTPGateway, nil
 	case string(KindMiddleware):
 		return KindMiddleware, nil
 	case string(KindPostgres):
 		return KindPostgres, nil
 	case string(KindUsecase):
 		return KindUsecase, nil
+	case string(KindWebhook):
+		return KindWebhook, nil
+	case string(KindHTTPClient):
+		return KindHTTPClient, nil
+	case string(KindCron):
+		return KindCron, nil
+	case string(KindExternal):
+		return KindExternal, nil
 	default:
 		return "", fmt.Errorf("unknown kind: %s", s)
 	}
@@ -80,7 +303,7 @@ func ParseKind(s string) (Kind, error) {
 
 // AllKinds returns all known component kinds.
 func AllKinds() []Kind {
-	return []Kind{KindHTTPServer, KindMiddleware, KindPostgres, KindUsecase}
+	return []Kind{KindHTTPServer, KindHTTPGateway, KindMiddleware, KindPostgres, KindUsecase, KindWebhook, KindHTTPClient, KindCron, KindExternal}
 }
 
 // IsValidKind checks if the given kind is known.
@@ -92,21 +315,168 @@ func IsValidKind(k Kind) bool {
 type HTTPServerSpec struct {
 	Framework  string
 	Port       int
+	AutoPort   bool // Port was declared as PortAuto and is assigned by the compiler
 	OpenAPI    string
 	Middleware []string
 	DependsOn  []string
+	BasePath   string       // Prefix of every route the server serves (e.g., "/api/v1"), or empty
+	APIDocs    *APIDocsSpec // API reference page, or nil for none
+	Limits     *Limits      // Maximums for every route, or nil for none
+	TLS        *TLSSpec     // HTTPS certificate, or nil to serve plain HTTP
+	Metrics    *MetricsSpec // Prometheus endpoint, or nil when observability is off
 
 	// ParsedOpenAPI contains the parsed OpenAPI document (populated during build phase).
 	ParsedOpenAPI *openapi.Document
 }
 
+// RoutePath returns the path the server serves a route declared at path on,
+// e.g. "/api/v1/users" for "/users".
+func (s *HTTPServerSpec) RoutePath(path string) string {
+	if s.BasePath != "" && path == "/" {
+		return s.BasePath
+	}
+	return s.BasePath + path
+}
+
+// MetricsSpec makes a server record the requests of its routes and serve
+// them to Prometheus.
+type MetricsSpec struct {
+	Path string // Route of the Prometheus endpoint, e.g. "/metrics"
+}
+
+// PortAuto is the port of a server or gateway whose port the compiler
+// assigns, keeping it stable across compiles.
+const PortAuto = "auto"
+
+// TLSSpec configures a server to serve HTTPS. The certificate and key are
+// read from the files the environment variables point to; outside production
+// a server with SelfSigned generates a certificate for localhost when they
+// are unset.
+type TLSSpec struct {
+	CertEnv    string // Environment variable holding the certificate file path
+	KeyEnv     string // Environment variable holding the private key file path
+	SelfSigned bool   // Generate a certificate in development when the files are not set
+}
+
+// HTTPGatewaySpec contains typed fields for http.gateway components, which
+// put several servers behind one entrypoint.
+type HTTPGatewaySpec struct {
+	Port     int
+	AutoPort bool   // Port was declared as PortAuto and is assigned by the compiler
+	Auth     string // better-auth middleware that guards the non-public routes, or empty
+	Routes   []GatewayRoute
+}
+
+// GatewayRoute forwards the requests under a path prefix to a server.
+type GatewayRoute struct {
+	Prefix      string // e.g., "/orders"
+	Server      string // http.server component
+	StripPrefix bool   // Forward "/orders/1" as "/1"
+	Public      bool   // Skip the gateway's auth check
+}
+
+// Limits bounds how long a request may take and how large its body may be.
+// A zero field sets no limit.
+type Limits struct {
+	TimeoutMS int
+	MaxBodyKB int
+}
+
+// RouteLimits returns the limits a usecase's route enforces: the usecase's
+// own, falling back to the server's field by field.
+func RouteLimits(server *HTTPServerSpec, uc *UsecaseSpec) Limits {
+	var l Limits
+	if server != nil && server.Limits != nil {
+		l = *server.Limits
+	}
+	if uc != nil && uc.Limits != nil {
+		if uc.Limits.TimeoutMS > 0 {
+			l.TimeoutMS = uc.Limits.TimeoutMS
+		}
+		if uc.Limits.MaxBodyKB > 0 {
+			l.MaxBodyKB = uc.Limits.MaxBodyKB
+		}
+	}
+	return l
+}
+
+// API reference renderers.
+const (
+	APIDocsScalar = "scalar"
+	APIDocsRedoc  = "redoc"
+)
+
+// APIDocsSpec configures the page a server serves its OpenAPI document on.
+type APIDocsSpec struct {
+	UI         string // APIDocsScalar or APIDocsRedoc
+	Path       string // Route of the page, e.g. "/docs"; the document is served at Path + "/openapi.yaml"
+	Production bool   // Also serve it when NODE_ENV is production
+}
+
 // MiddlewareSpec contains typed fields for middleware components.
 type MiddlewareSpec struct {
-	Provider  string // todo - leaky abstraction - consider subtypes for authn & authz
+	Provider  string // todo - leaky abstraction - consider subtypes for authn & authz; empty for a chain
 	Config    string
 	Model     string
 	Policy    string
 	DependsOn []string
+
+	// PolicyAdapter is where a casbin middleware loads policies from in
+	// production: "file" (default) or "postgres".
+	PolicyAdapter string
+	// AdminRoute is the path of an optional route listing effective policies.
+	AdminRoute string
+	// Permissions and Roles declare RBAC for a casbin middleware, sorted by name.
+	Permissions []Permission
+	Roles       []Role
+	// Session configures session storage for a better-auth middleware.
+	Session *SessionSpec
+	// OAuth lists the OAuth providers of a better-auth middleware, sorted by provider.
+	OAuth []OAuthProvider
+
+	// Chain lists the middleware a chain middleware composes, in the order
+	// they run. The builder creates one middleware component per provider
+	// of the chain, named <id>.<provider>.
+	Chain []string
+
+	// ParsedModel contains the parsed casbin model (populated during build phase).
+	ParsedModel *casbin.Model
+}
+
+// Permission is a named policy object and action granted to roles.
+type Permission struct {
+	Name   string // e.g., "user.read"
+	Object string // e.g., "/users"
+	Action string // e.g., "GET"
+}
+
+// Role is an RBAC role seeded into a casbin policy.
+type Role struct {
+	Name        string
+	Permissions []string
+	Inherits    []string
+}
+
+// OAuthProvider is an OAuth provider of a better-auth middleware. Credentials
+// are referenced by environment variable name and never stored in the spec.
+type OAuthProvider struct {
+	Provider        string // e.g., "github", "google"
+	ClientIDEnv     string
+	ClientSecretEnv string
+}
+
+// Session storage backends for better-auth middleware.
+const (
+	SessionStorageDatabase = "database"
+	SessionStorageRedis    = "redis"
+	SessionStorageCookie   = "cookie"
+)
+
+// SessionSpec configures where a better-auth middleware stores sessions.
+type SessionSpec struct {
+	Storage string
+	Store   string // Postgres component holding the session table (database storage)
+	MaxAge  int    // Session lifetime in seconds; 0 means the default
 }
 
 // PostgresSpec contains typed fields for postgres components.
@@ -115,20 +485,269 @@ type PostgresSpec struct {
 	Schema   string
 }
 
+// WebhookSpec contains typed fields for webhook components: an endpoint
+// outside the system that usecases send events to.
+type WebhookSpec struct {
+	URLEnv      string // Environment variable holding the target URL
+	SecretEnv   string // Environment variable holding the HMAC signing secret
+	Events      []WebhookEvent
+	MaxAttempts int // Deliveries tried per event; 0 means the default
+	BackoffMS   int // Delay before the first retry, doubling after each; 0 means the default
+}
+
+// Defaults of webhook retries.
+const (
+	DefaultWebhookAttempts  = 5
+	DefaultWebhookBackoffMS = 1000
+)
+
+// Attempts returns how often a delivery is tried before it fails.
+func (s *WebhookSpec) Attempts() int {
+	if s.MaxAttempts == 0 {
+		return DefaultWebhookAttempts
+	}
+	return s.MaxAttempts
+}
+
+// Backoff returns the delay before the first retry in milliseconds.
+func (s *WebhookSpec) Backoff() int {
+	if s.BackoffMS == 0 {
+		return DefaultWebhookBackoffMS
+	}
+	return s.BackoffMS
+}
+
+// WebhookEvent is an event a webhook delivers.
+type WebhookEvent struct {
+	Name        string // e.g., order.created
+	Description string
+}
+
+// HTTPClientSpec contains typed fields for http.client components: a
+// service outside the system that usecases call, and how calls to it are
+// retried and cut off while it fails.
+type HTTPClientSpec struct {
+	BaseURLEnv       string // Environment variable holding the base URL of the service
+	Retries          int    // Retries of a failed idempotent request
+	BackoffMS        int    // Delay before the first retry, doubling after each; 0 means the default
+	FailureThreshold int    // Consecutive failed attempts that open the circuit; 0 means the default
+	ResetMS          int    // How long the circuit stays open before a trial request; 0 means the default
+}
+
+// Defaults of http.client resilience policies.
+const (
+	DefaultHTTPClientRetries          = 2
+	DefaultHTTPClientBackoffMS        = 200
+	DefaultHTTPClientFailureThreshold = 5
+	DefaultHTTPClientResetMS          = 30000
+)
+
+// Backoff returns the delay before the first retry in milliseconds.
+func (s *HTTPClientSpec) Backoff() int {
+	if s.BackoffMS == 0 {
+		return DefaultHTTPClientBackoffMS
+	}
+	return s.BackoffMS
+}
+
+// Threshold returns how many consecutive failed attempts open the circuit.
+func (s *HTTPClientSpec) Threshold() int {
+	if s.FailureThreshold == 0 {
+		return DefaultHTTPClientFailureThreshold
+	}
+	return s.FailureThreshold
+}
+
+// Reset returns how long the circuit stays open in milliseconds.
+func (s *HTTPClientSpec) Reset() int {
+	if s.ResetMS == 0 {
+		return DefaultHTTPClientResetMS
+	}
+	return s.ResetMS
+}
+
+// CronSpec contains typed fields for cron components: a job the worker
+// process runs on a schedule, apart from the HTTP servers.
+type CronSpec struct {
+	Schedule  string   // Cron expression: minute, hour, day of month, month and day of week
+	DependsOn []string // Databases the job uses
+}
+
+// ExternalSpec contains the fields of an external component: a part of the
+// system that is modeled for validation and the dependency graph but that
+// the compiler generates nothing for.
+type ExternalSpec struct {
+	DependsOn  []string
+	Properties map[string]any // The rest of the spec, kept as written
+}
+
 // UsecaseSpec contains typed fields for usecase components.
 type UsecaseSpec struct {
 	BindsTo            string
 	Middleware         []string
+	DependsOn          []string // nil = all of the server's databases
+	Authorization      *AuthorizationSpec
+	InputMapping       []InputMapping // nil passes the path parameters and body as they are; sorted by Field
+	Emits              []string       // Webhook components the usecase sends events to
+	Calls              []string       // http.client components the usecase sends requests to
+	Limits             *Limits        // Limits of the route, within the server's; nil for the server's
+	Cache              *CacheSpec     // How clients may cache responses of a GET route; nil for not at all
+	Concurrency        string         // ConcurrencyETag, or "" for last write wins
+	Bulk               bool           // The request body is an array of items that succeed or fail one by one
+	Async              bool           // The route answers 202 with a job whose status a generated route reports
+	SLO                *SLOSpec       // Service level objective of the route; nil for none
 	Goal               string
 	Actor              string
 	Preconditions      []string
 	AcceptanceCriteria []string
 	Postconditions     []string
+	Testing            *TestingSpec // nil generates the default tests
 
 	// Binding contains the parsed binding information (populated during build phase).
 	Binding *Binding
 }
 
+// JobStatus returns the generated route that reports the jobs of an async
+// usecase, GET <path>/jobs/{jobId}, or nil if the usecase is not async.
+func (s *UsecaseSpec) JobStatus() *Binding {
+	if !s.Async || s.Binding == nil {
+		return nil
+	}
+	return &Binding{ServerID: s.Binding.ServerID, Method: "GET", Path: s.Binding.Path + "/jobs/{jobId}"}
+}
+
+// UnitTests reports whether a unit test file is generated for the usecase.
+func (s *UsecaseSpec) UnitTests() bool {
+	return s.Testing == nil || s.Testing.Unit
+}
+
+// E2ETests returns the E2E tests generated for the usecase: E2ESmoke,
+// E2EFull or E2ESkip.
+func (s *UsecaseSpec) E2ETests() string {
+	if s.Testing == nil || s.Testing.E2E == "" {
+		return E2ESmoke
+	}
+	return s.Testing.E2E
+}
+
+// E2E test levels of a usecase.
+const (
+	E2ESmoke = "smoke" // A request checking that the route exists
+	E2EFull  = "full"  // The smoke test and a pending test per acceptance criterion
+	E2ESkip  = "skip"  // No E2E tests
+)
+
+// TestingSpec selects the tests generated for a usecase.
+type TestingSpec struct {
+	Unit bool   // Generate a unit test file; true unless the spec sets unit: false
+	E2E  string // E2ESmoke, E2EFull or E2ESkip
+}
+
+// SLOSpec declares the service level objective of a usecase's route.
+type SLOSpec struct {
+	P99LatencyMS int     // 99% of requests are answered within this many milliseconds; 0 for no target
+	ErrorBudget  float64 // Percentage of requests that may fail with a 5xx status; 0 for no target
+}
+
+// Cache visibilities of a usecase's responses.
+const (
+	CachePrivate = "private" // Only the caller's own cache may store responses
+	CachePublic  = "public"  // Shared caches and CDNs may store responses too
+)
+
+// CacheSpec declares how long clients, and optionally the server itself,
+// may reuse the responses of a route.
+type CacheSpec struct {
+	TTL        int      // Seconds a response stays fresh
+	Vary       []string // Request headers responses differ by
+	Visibility string   // CachePrivate or CachePublic
+	Server     bool     // The server keeps responses in redis for TTL too
+	Tables     []string // Tables whose repository writes drop the server's responses
+}
+
+// CacheControl returns the Cache-Control header of the route's responses,
+// e.g. "private, max-age=60".
+func (c *CacheSpec) CacheControl() string {
+	return fmt.Sprintf("%s, max-age=%d", c.Visibility, c.TTL)
+}
+
+// ConcurrencyETag makes a GET route answer with an ETag, and a PUT or PATCH
+// route on the same path require it in If-Match, so an update of a resource
+// someone else changed since it was read fails instead of overwriting it.
+const ConcurrencyETag = "etag"
+
+// AuthorizationSpec lists what a caller needs to invoke a usecase: any of
+// Roles and all of Permissions, as declared by a casbin middleware.
+type AuthorizationSpec struct {
+	Roles       []string
+	Permissions []string
+}
+
+// InputMapping sets a field of a usecase's input from a part of the
+// request, e.g. userId from path.id.
+type InputMapping struct {
+	Field     string // Input field of the usecase
+	Source    string // InputFromBody, InputFromPath or InputFromQuery
+	Name      string // Body field or parameter read
+	Transform string // Optional conversion: trim, lowercase, uppercase or number
+}
+
+// Parts of the request an input mapping reads from.
+const (
+	InputFromBody  = "body"
+	InputFromPath  = "path"
+	InputFromQuery = "query"
+)
+
+// MappedInput is a value of a usecase's input set by input_mapping.
+type MappedInput struct {
+	InputMapping
+	Optional bool // The request may lack the value
+}
+
+// MappedInputs returns the values of a usecase's input_mapping. A value is
+// optional unless it is a path parameter, or the bound operation marks it
+// required: as a query parameter, or in the JSON request body with its
+// allOf parts merged. Without an operation nothing is known about body and
+// query. Catch-all usecases receive the raw request and have none.
+func (i *IR) MappedInputs(uc *Component) []MappedInput {
+	binding := uc.Usecase.Binding
+	if len(uc.Usecase.InputMapping) == 0 || binding == nil || binding.IsCatchAll() {
+		return nil
+	}
+	var body *openapi.Schema
+	if op := binding.Operation; op != nil && op.RequestBody != nil {
+		if media, ok := op.RequestBody.Content["application/json"]; ok && media.Schema != nil {
+			body = media.Schema
+			if server, ok := i.Components[binding.ServerID]; ok && server.HTTPServer != nil && server.HTTPServer.ParsedOpenAPI != nil {
+				doc := server.HTTPServer.ParsedOpenAPI
+				body = doc.MergeAllOf(doc.ResolveSchema(body))
+			}
+		}
+	}
+
+	inputs := make([]MappedInput, 0, len(uc.Usecase.InputMapping))
+	for _, m := range uc.Usecase.InputMapping {
+		in := MappedInput{InputMapping: m, Optional: true}
+		switch m.Source {
+		case InputFromPath:
+			in.Optional = false
+		case InputFromBody:
+			in.Optional = body == nil || !slices.Contains(body.Required, m.Name)
+		case InputFromQuery:
+			if binding.Operation != nil {
+				for _, p := range binding.Operation.Parameters {
+					if p.In == "query" && p.Name == m.Name && p.Required {
+						in.Optional = false
+					}
+				}
+			}
+		}
+		inputs = append(inputs, in)
+	}
+	return inputs
+}
+
 // Binding represents a parsed binds_to value with resolved references.
 type Binding struct {
 	ServerID  string             // The server component ID
@@ -137,6 +756,76 @@ type Binding struct {
 	Operation *openapi.Operation // The resolved OpenAPI operation (may be nil if not found)
 }
 
+// MethodAll binds a usecase to every HTTP method of its path.
+const MethodAll = "ALL"
+
+// IsCatchAll reports whether the binding matches any method (ALL) or any
+// path below a prefix (a trailing "/*"). OpenAPI cannot describe such routes,
+// so they have no operation and their usecase handles the raw request.
+func (b *Binding) IsCatchAll() bool {
+	return b.Method == MethodAll || strings.HasSuffix(b.Path, "/*")
+}
+
+// pathParamPattern matches a parameter of a path template, e.g. {id}.
+var pathParamPattern = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// PathParams returns the names of the path parameters in the order they
+// appear, e.g. [userId postId] for /users/{userId}/posts/{postId}. Input
+// types, route extraction and generated tests all read this list, which the
+// validator checks against the bound operation.
+func (b *Binding) PathParams() []string {
+	var params []string
+	for _, match := range pathParamPattern.FindAllStringSubmatch(b.Path, -1) {
+		params = append(params, match[1])
+	}
+	return params
+}
+
+// PathParamTestValue returns a value for a path parameter in generated
+// tests that the bound operation's schema accepts: 1 for numbers, true for
+// booleans and test-<name> otherwise.
+func (b *Binding) PathParamTestValue(name string) string {
+	if b.Operation != nil {
+		for _, p := range b.Operation.Parameters {
+			if p.In != "path" || p.Name != name || p.Schema == nil {
+				continue
+			}
+			switch p.Schema.Type {
+			case "integer", "number":
+				return "1"
+			case "boolean":
+				return "true"
+			}
+		}
+	}
+	return "test-" + name
+}
+
+// Matches reports whether a request routed by other could also be routed by
+// this binding: the methods agree and the paths have the same shape, or this
+// binding's wildcard covers the other path.
+func (b *Binding) Matches(other *Binding) bool {
+	if b.Method != MethodAll && other.Method != MethodAll && b.Method != other.Method {
+		return false
+	}
+	path, otherPath := pathShape(b.Path), pathShape(other.Path)
+	if prefix, ok := strings.CutSuffix(path, "/*"); ok {
+		return otherPath == prefix || strings.HasPrefix(otherPath, prefix+"/")
+	}
+	return path == otherPath
+}
+
+// pathShape replaces the parameter names of a path with "{}".
+func pathShape(path string) string {
+	segments := strings.Split(path, "/")
+	for n, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[n] = "{}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
 // Edge represents a dependency edge between components.
 type Edge struct {
 	From *Component
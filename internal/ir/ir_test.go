@@ -4,8 +4,12 @@
 package ir
 
 import (
+	"slices"
+	"strings"
 	"testing"
+	"testing/fstest"
 
+	"github.com/openboundary/openboundary/internal/openapi"
 	"github.com/openboundary/openboundary/internal/parser"
 )
 
@@ -37,9 +41,14 @@ func TestParseKind(t *testing.T) {
 		wantErr  bool
 	}{
 		{"http.server", KindHTTPServer, false},
+		{"http.gateway", KindHTTPGateway, false},
 		{"middleware", KindMiddleware, false},
 		{"postgres", KindPostgres, false},
 		{"usecase", KindUsecase, false},
+		{"webhook", KindWebhook, false},
+		{"http.client", KindHTTPClient, false},
+		{"cron", KindCron, false},
+		{"external", KindExternal, false},
 		{"unknown", "", true},
 		{"", "", true},
 	}
@@ -66,15 +75,20 @@ func TestParseKind(t *testing.T) {
 
 func TestAllKinds(t *testing.T) {
 	kinds := AllKinds()
-	if len(kinds) != 4 {
-		t.Errorf("AllKinds() returned %d kinds, expected 4", len(kinds))
+	if len(kinds) != 9 {
+		t.Errorf("AllKinds() returned %d kinds, expected 9", len(kinds))
 	}
 
 	expected := map[Kind]bool{
-		KindHTTPServer: true,
-		KindMiddleware: true,
-		KindPostgres:   true,
-		KindUsecase:    true,
+		KindHTTPServer:  true,
+		KindHTTPGateway: true,
+		KindMiddleware:  true,
+		KindPostgres:    true,
+		KindUsecase:     true,
+		KindWebhook:     true,
+		KindHTTPClient:  true,
+		KindCron:        true,
+		KindExternal:    true,
 	}
 
 	for _, k := range kinds {
@@ -90,9 +104,13 @@ func TestIsValidKind(t *testing.T) {
 		expected bool
 	}{
 		{KindHTTPServer, true},
+		{KindHTTPGateway, true},
 		{KindMiddleware, true},
 		{KindPostgres, true},
 		{KindUsecase, true},
+		{KindWebhook, true},
+		{KindHTTPClient, true},
+		{KindCron, true},
 		{Kind("unknown"), false},
 		{Kind(""), false},
 	}
@@ -123,3 +141,403 @@ func TestEdgeTypeConstants(t *testing.T) {
 		}
 	}
 }
+
+func TestIR_HTTPServersAndUsecasesBoundTo(t *testing.T) {
+	// given
+	i := New(&parser.Spec{})
+	for _, comp := range []*Component{
+		{ID: "http.server.b", Kind: KindHTTPServer, HTTPServer: &HTTPServerSpec{}},
+		{ID: "http.server.a", Kind: KindHTTPServer, HTTPServer: &HTTPServerSpec{}},
+		{ID: "usecase.z", Kind: KindUsecase, Usecase: &UsecaseSpec{Binding: &Binding{ServerID: "http.server.a"}}},
+		{ID: "usecase.y", Kind: KindUsecase, Usecase: &UsecaseSpec{Binding: &Binding{ServerID: "http.server.a"}}},
+		{ID: "usecase.x", Kind: KindUsecase, Usecase: &UsecaseSpec{Binding: &Binding{ServerID: "http.server.b"}}},
+		{ID: "usecase.unbound", Kind: KindUsecase, Usecase: &UsecaseSpec{}},
+		{ID: "postgres.primary", Kind: KindPostgres},
+	} {
+		i.Components[comp.ID] = comp
+	}
+	ids := func(comps []*Component) []string {
+		var out []string
+		for _, c := range comps {
+			out = append(out, c.ID)
+		}
+		return out
+	}
+
+	// when
+	servers := ids(i.HTTPServers())
+	usecases := ids(i.UsecasesBoundTo("http.server.a"))
+
+	// then
+	if want := []string{"http.server.a", "http.server.b"}; !slices.Equal(servers, want) {
+		t.Errorf("HTTPServers() = %v, want %v", servers, want)
+	}
+	if want := []string{"usecase.y", "usecase.z"}; !slices.Equal(usecases, want) {
+		t.Errorf("UsecasesBoundTo() = %v, want %v", usecases, want)
+	}
+}
+
+func TestIR_ReadFile_FS(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		wantErr bool
+	}{
+		{name: "relative", file: "./openapi.yaml"},
+		{name: "cleaned", file: "./api/../openapi.yaml"},
+		{name: "parent", file: "./../openapi.yaml", wantErr: true},
+		{name: "escaping", file: "./api/../../openapi.yaml", wantErr: true},
+		{name: "absolute", file: "/etc/hostname", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			i := New(&parser.Spec{})
+			i.FS = fstest.MapFS{"openapi.yaml": {Data: []byte("openapi: 3.0.3")}}
+
+			// when
+			data, err := i.ReadFile(tt.file)
+
+			// then
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "is outside the directory") {
+					t.Errorf("ReadFile(%q) error = %v, want outside the directory", tt.file, err)
+				}
+				return
+			}
+			if err != nil || string(data) != "openapi: 3.0.3" {
+				t.Errorf("ReadFile(%q) = %q, %v", tt.file, data, err)
+			}
+		})
+	}
+}
+
+func TestHTTPServerSpec_RoutePath(t *testing.T) {
+	tests := []struct {
+		basePath string
+		path     string
+		expected string
+	}{
+		{"", "/users", "/users"},
+		{"", "/", "/"},
+		{"/api/v1", "/users", "/api/v1/users"},
+		{"/api/v1", "/users/{id}", "/api/v1/users/{id}"},
+		{"/api/v1", "/", "/api/v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.basePath+tt.path, func(t *testing.T) {
+			s := &HTTPServerSpec{BasePath: tt.basePath}
+			if got := s.RoutePath(tt.path); got != tt.expected {
+				t.Errorf("RoutePath(%q) = %q, expected %q", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCacheSpec_CacheControl(t *testing.T) {
+	tests := []struct {
+		cache    CacheSpec
+		expected string
+	}{
+		{CacheSpec{TTL: 60, Visibility: CachePrivate}, "private, max-age=60"},
+		{CacheSpec{TTL: 300, Visibility: CachePublic}, "public, max-age=300"},
+		{CacheSpec{Visibility: CachePrivate}, "private, max-age=0"},
+	}
+	for _, tt := range tests {
+		if got := tt.cache.CacheControl(); got != tt.expected {
+			t.Errorf("CacheControl() = %v, expected %v", got, tt.expected)
+		}
+	}
+}
+
+func TestRouteLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   *Limits
+		usecase  *Limits
+		expected Limits
+	}{
+		{"none", nil, nil, Limits{}},
+		{"server only", &Limits{TimeoutMS: 30000, MaxBodyKB: 1024}, nil, Limits{TimeoutMS: 30000, MaxBodyKB: 1024}},
+		{"usecase only", nil, &Limits{TimeoutMS: 5000}, Limits{TimeoutMS: 5000}},
+		{"usecase overrides a field", &Limits{TimeoutMS: 30000, MaxBodyKB: 1024}, &Limits{MaxBodyKB: 64}, Limits{TimeoutMS: 30000, MaxBodyKB: 64}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RouteLimits(&HTTPServerSpec{Limits: tt.server}, &UsecaseSpec{Limits: tt.usecase})
+			if got != tt.expected {
+				t.Errorf("RouteLimits() = %+v, expected %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBinding_Matches(t *testing.T) {
+	tests := []struct {
+		binding  string
+		other    string
+		expected bool
+	}{
+		{"GET:/users", "GET:/users", true},
+		{"GET:/users", "POST:/users", false},
+		{"GET:/users/{id}", "GET:/users/{userId}", true},
+		{"ALL:/users", "DELETE:/users", true},
+		{"ALL:/users", "GET:/orders", false},
+		{"ALL:/files/*", "GET:/files", true},
+		{"ALL:/files/*", "GET:/files/{id}/raw", true},
+		{"GET:/files/*", "POST:/files/{id}", false},
+		{"OPTIONS:/*", "OPTIONS:/users", true},
+		{"ALL:/files/*", "GET:/filesystem", false},
+		{"GET:/files/{id}", "ALL:/files/*", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.binding+" "+tt.other, func(t *testing.T) {
+			b, other := parseTestBinding(tt.binding), parseTestBinding(tt.other)
+			if got := b.Matches(other); got != tt.expected {
+				t.Errorf("Matches() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComponent_DeprecationNotice(t *testing.T) {
+	tests := []struct {
+		comp     *Component
+		expected string
+	}{
+		{&Component{ID: "usecase.export", Deprecated: true}, "usecase.export is deprecated"},
+		{&Component{ID: "usecase.export", Deprecated: true, Replacement: "usecase.export-v2"}, "usecase.export is deprecated; use usecase.export-v2 instead"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.comp.DeprecationNotice(); got != tt.expected {
+			t.Errorf("DeprecationNotice() = %q, expected %q", got, tt.expected)
+		}
+	}
+}
+
+func TestBinding_IsCatchAll(t *testing.T) {
+	tests := map[string]bool{
+		"GET:/users":       false,
+		"OPTIONS:/users":   false,
+		"ALL:/users":       true,
+		"OPTIONS:/files/*": true,
+	}
+
+	for binding, expected := range tests {
+		if got := parseTestBinding(binding).IsCatchAll(); got != expected {
+			t.Errorf("IsCatchAll(%s) = %v, expected %v", binding, got, expected)
+		}
+	}
+}
+
+func TestBinding_PathParams(t *testing.T) {
+	tests := []struct {
+		binding  string
+		expected []string
+	}{
+		{"GET:/users", nil},
+		{"GET:/users/{id}", []string{"id"}},
+		{"GET:/users/{userId}/posts/{postId}", []string{"userId", "postId"}},
+		{"GET:/files/{name}.{ext}", []string{"name", "ext"}},
+		{"ALL:/files/*", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.binding, func(t *testing.T) {
+			if got := parseTestBinding(tt.binding).PathParams(); !slices.Equal(got, tt.expected) {
+				t.Errorf("PathParams() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBinding_PathParamTestValue(t *testing.T) {
+	// given
+	b := &Binding{Method: "GET", Path: "/orgs/{org}/users/{id}/{active}", Operation: &openapi.Operation{
+		Parameters: []openapi.Parameter{
+			{Name: "org", In: "path", Schema: &openapi.Schema{Type: "string"}},
+			{Name: "id", In: "path", Schema: &openapi.Schema{Type: "integer"}},
+			{Name: "active", In: "path", Schema: &openapi.Schema{Type: "boolean"}},
+			{Name: "id", In: "query", Schema: &openapi.Schema{Type: "string"}},
+		},
+	}}
+	expected := map[string]string{"org": "test-org", "id": "1", "active": "true", "other": "test-other"}
+
+	for name, want := range expected {
+		// when
+		got := b.PathParamTestValue(name)
+
+		// then
+		if got != want {
+			t.Errorf("PathParamTestValue(%q) = %q, expected %q", name, got, want)
+		}
+	}
+}
+
+func parseTestBinding(s string) *Binding {
+	method, path, _ := strings.Cut(s, ":")
+	return &Binding{Method: method, Path: path}
+}
+
+func TestIR_EnvVar(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      *parser.Env
+		expected string
+	}{
+		{"no env", nil, "DATABASE_URL"},
+		{"not namespaced", &parser.Env{}, "DATABASE_URL"},
+		{"namespaced", &parser.Env{Namespaced: true}, "ORDERS_API_DATABASE_URL"},
+		{"prefix", &parser.Env{Prefix: "SHOP"}, "SHOP_DATABASE_URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(&parser.Spec{Name: "orders-api", Env: tt.env})
+			if got := i.EnvVar("DATABASE_URL"); got != tt.expected {
+				t.Errorf("EnvVar() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIR_ExpandMiddleware(t *testing.T) {
+	i := New(&parser.Spec{})
+	i.Components["middleware.auth"] = &Component{ID: "middleware.auth", Kind: KindMiddleware, Middleware: &MiddlewareSpec{
+		Chain: []string{"middleware.auth.better-auth", "middleware.auth.casbin"},
+	}}
+	i.Components["middleware.log"] = &Component{ID: "middleware.log", Kind: KindMiddleware, Middleware: &MiddlewareSpec{Provider: "casbin"}}
+
+	tests := []struct {
+		name     string
+		refs     []string
+		expected []string
+	}{
+		{"nil", nil, nil},
+		{"no chains", []string{"middleware.log"}, []string{"middleware.log"}},
+		{"chain in order", []string{"middleware.log", "middleware.auth"}, []string{"middleware.log", "middleware.auth.better-auth", "middleware.auth.casbin"}},
+		{"unknown refs kept", []string{"middleware.missing"}, []string{"middleware.missing"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := i.ExpandMiddleware(tt.refs)
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") || (got == nil) != (tt.expected == nil) {
+				t.Errorf("ExpandMiddleware(%v) = %v, expected %v", tt.refs, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIR_MappedInputs(t *testing.T) {
+	// given: a body that requires name through an allOf part
+	doc := &openapi.Document{Schemas: map[string]*openapi.Schema{
+		"Named": {Type: "object", Required: []string{"name"}, Properties: map[string]*openapi.Schema{"name": {Type: "string"}}},
+	}}
+	op := &openapi.Operation{
+		Parameters: []openapi.Parameter{{Name: "page", In: "query", Required: true}, {Name: "sort", In: "query"}},
+		RequestBody: &openapi.RequestBody{Content: map[string]*openapi.MediaType{"application/json": {Schema: &openapi.Schema{
+			AllOf: []*openapi.Schema{
+				{Ref: "#/components/schemas/Named"},
+				{Type: "object", Properties: map[string]*openapi.Schema{"note": {Type: "string"}}},
+			},
+		}}}},
+	}
+	i := New(&parser.Spec{})
+	i.Components["http.server.api"] = &Component{ID: "http.server.api", Kind: KindHTTPServer, HTTPServer: &HTTPServerSpec{ParsedOpenAPI: doc}}
+	uc := &Component{ID: "usecase.rename", Kind: KindUsecase, Usecase: &UsecaseSpec{
+		Binding: &Binding{ServerID: "http.server.api", Method: "PUT", Path: "/items/{id}", Operation: op},
+		InputMapping: []InputMapping{
+			{Field: "id", Source: InputFromPath, Name: "id"},
+			{Field: "name", Source: InputFromBody, Name: "name"},
+			{Field: "note", Source: InputFromBody, Name: "note"},
+			{Field: "page", Source: InputFromQuery, Name: "page"},
+			{Field: "sort", Source: InputFromQuery, Name: "sort"},
+		},
+	}}
+
+	// when
+	inputs := i.MappedInputs(uc)
+	uc.Usecase.Binding.Path = "/items/*"
+	catchAll := i.MappedInputs(uc)
+
+	// then
+	var optional []string
+	for _, in := range inputs {
+		if in.Optional {
+			optional = append(optional, in.Field)
+		}
+	}
+	if len(inputs) != 5 {
+		t.Fatalf("MappedInputs() returned %d inputs, want 5", len(inputs))
+	}
+	if want := []string{"note", "sort"}; !slices.Equal(optional, want) {
+		t.Errorf("optional inputs = %v, want %v", optional, want)
+	}
+	if catchAll != nil {
+		t.Errorf("MappedInputs() of a catch-all usecase = %v, want nil", catchAll)
+	}
+}
+
+func TestIR_DeprecatedReferences(t *testing.T) {
+	// given
+	i := New(&parser.Spec{})
+	server := &Component{ID: "http.server.old", Kind: KindHTTPServer, Deprecated: true}
+	db := &Component{ID: "postgres.old", Kind: KindPostgres, Deprecated: true}
+	b := &Component{ID: "usecase.b", Kind: KindUsecase}
+	a := &Component{ID: "usecase.a", Kind: KindUsecase}
+	i.Edges = []Edge{
+		{From: b, To: server}, {From: a, To: db}, {From: a, To: server}, {From: a, To: server},
+		{From: server, To: db}, // Deprecated components may reference each other
+	}
+
+	// when
+	refs := i.DeprecatedReferences()
+
+	// then
+	var got []string
+	for _, ref := range refs {
+		got = append(got, ref.From.ID+">"+ref.To.ID)
+	}
+	if want := []string{"usecase.a>http.server.old", "usecase.a>postgres.old", "usecase.b>http.server.old"}; !slices.Equal(got, want) {
+		t.Errorf("DeprecatedReferences() = %v, want %v", got, want)
+	}
+}
+
+func TestIR_DeprecatedComponentsRunBy(t *testing.T) {
+	// given
+	i := New(&parser.Spec{})
+	for _, comp := range []*Component{
+		{ID: "http.server.api", Kind: KindHTTPServer, HTTPServer: &HTTPServerSpec{DependsOn: []string{"postgres.old"}, Middleware: []string{"middleware.auth"}}},
+		{ID: "middleware.auth", Kind: KindMiddleware, Middleware: &MiddlewareSpec{Chain: []string{"middleware.session"}}},
+		{ID: "middleware.session", Kind: KindMiddleware, Deprecated: true, Middleware: &MiddlewareSpec{}},
+		{ID: "postgres.old", Kind: KindPostgres, Deprecated: true},
+		{ID: "webhook.old", Kind: KindWebhook, Deprecated: true},
+		{ID: "http.client.old", Kind: KindHTTPClient, Deprecated: true},
+		{ID: "http.client.unused", Kind: KindHTTPClient, Deprecated: true},
+		{ID: "usecase.a", Kind: KindUsecase, Deprecated: true, Usecase: &UsecaseSpec{
+			Binding: &Binding{ServerID: "http.server.api"},
+			Emits:   []string{"webhook.old"},
+			Calls:   []string{"http.client.old"},
+		}},
+	} {
+		i.Components[comp.ID] = comp
+	}
+
+	// when
+	deprecated := i.DeprecatedComponentsRunBy(i.Components["http.server.api"])
+
+	// then
+	var got []string
+	for _, comp := range deprecated {
+		got = append(got, comp.ID)
+	}
+	if want := []string{"http.client.old", "middleware.session", "postgres.old", "usecase.a", "webhook.old"}; !slices.Equal(got, want) {
+		t.Errorf("DeprecatedComponentsRunBy() = %v, want %v", got, want)
+	}
+}
@@ -15,6 +15,11 @@ type Symbol struct {
 	Name      string
 	Kind      Kind
 	Component *Component
+
+	// Placeholder marks a component that failed to build, such as one of an
+	// unknown kind. References to it resolve so that the failure is reported
+	// once, but it has no Component and takes no part in the graph.
+	Placeholder bool
 }
 
 // NewSymbolTable creates a new symbol table.
@@ -37,6 +42,19 @@ func (t *SymbolTable) Define(name string, kind Kind, comp *Component) error {
 	return nil
 }
 
+// DefinePlaceholder adds a placeholder symbol for a component that failed to build.
+func (t *SymbolTable) DefinePlaceholder(name string, kind Kind) error {
+	if existing, ok := t.symbols[name]; ok {
+		return fmt.Errorf("symbol %q already defined as %s", name, existing.Kind)
+	}
+	t.symbols[name] = &Symbol{
+		Name:        name,
+		Kind:        kind,
+		Placeholder: true,
+	}
+	return nil
+}
+
 // Lookup returns a symbol by name.
 func (t *SymbolTable) Lookup(name string) (*Symbol, bool) {
 	sym, ok := t.symbols[name]
@@ -47,6 +47,29 @@ func TestSymbolTable_Define_Duplicate(t *testing.T) {
 	}
 }
 
+func TestSymbolTable_DefinePlaceholder(t *testing.T) {
+	st := NewSymbolTable()
+
+	if err := st.DefinePlaceholder("test.comp", Kind("grpc.client")); err != nil {
+		t.Fatalf("DefinePlaceholder() error = %v", err)
+	}
+
+	sym, ok := st.Lookup("test.comp")
+	if !ok {
+		t.Fatal("Lookup() did not find placeholder")
+	}
+	if !sym.Placeholder {
+		t.Error("Placeholder = false, expected true")
+	}
+	if sym.Component != nil {
+		t.Errorf("Component = %v, expected nil", sym.Component)
+	}
+
+	if err := st.Define("test.comp", KindPostgres, &Component{ID: "test.comp"}); err == nil {
+		t.Error("Define() expected error for name taken by a placeholder, got nil")
+	}
+}
+
 func TestSymbolTable_Lookup(t *testing.T) {
 	st := NewSymbolTable()
 	comp := &Component{ID: "test.comp", Kind: KindHTTPServer}
@@ -0,0 +1,254 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package maturity scores the components of a spec against the OpenBoundary
+// conformance levels.
+package maturity
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/openboundary/openboundary/internal/coverage"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+// Level is a conformance level. Each level includes the ones below it.
+type Level int
+
+// Conformance levels.
+const (
+	L0 Level = iota // Nothing is bound
+	L1              // Routes bound: every usecase binds to a route of a server
+	L2              // Full OpenAPI schemas: every route has typed payloads in an OpenAPI document
+	L3              // Tested: every usecase has acceptance criteria and tests
+	L4              // Operable: observability and security are configured
+
+	MaxLevel = L4
+)
+
+var levelNames = []string{
+	L0: "unbound",
+	L1: "routes bound",
+	L2: "full OpenAPI schemas",
+	L3: "acceptance criteria and tests",
+	L4: "observability and security",
+}
+
+// String returns the short name of the level, e.g. "L2".
+func (l Level) String() string {
+	return fmt.Sprintf("L%d", int(l))
+}
+
+// Name describes what the level requires, e.g. "full OpenAPI schemas".
+func (l Level) Name() string {
+	if l < L0 || l > MaxLevel {
+		return ""
+	}
+	return levelNames[l]
+}
+
+// Component is the level a usecase or http.server reaches.
+type Component struct {
+	ID    string
+	Kind  ir.Kind
+	Level Level
+	Gaps  []string // What keeps the component from the next level; empty at MaxLevel
+}
+
+// Report is the maturity of a spec.
+type Report struct {
+	Components []Component // Usecases and http.servers, sorted by ID
+	Level      Level       // Lowest level of a component; L0 for a spec without any
+}
+
+// Assess scores every usecase and http.server of the IR. With covered (as
+// returned by coverage.ScanDir), L3 also requires an active test for each
+// acceptance criterion; nil only requires that tests are generated.
+//
+// A server reaches a level when it meets the level itself and every usecase
+// bound to it does too, so a server is never above its usecases.
+func Assess(i *ir.IR, covered map[string][]string) *Report {
+	ids := make([]string, 0, len(i.Components))
+	for id := range i.Components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	usecaseLevels := make(map[string]Level)
+	report := &Report{}
+	for _, id := range ids {
+		comp := i.Components[id]
+		if comp.Kind != ir.KindUsecase || comp.Usecase == nil {
+			continue
+		}
+		scored := score(comp, func(level Level) []string {
+			return usecaseGaps(i, comp, covered, level)
+		})
+		usecaseLevels[id] = scored.Level
+		report.Components = append(report.Components, scored)
+	}
+	for _, id := range ids {
+		comp := i.Components[id]
+		if comp.Kind != ir.KindHTTPServer || comp.HTTPServer == nil {
+			continue
+		}
+		report.Components = append(report.Components, score(comp, func(level Level) []string {
+			return serverGaps(i, comp, usecaseLevels, level)
+		}))
+	}
+	sort.Slice(report.Components, func(a, b int) bool {
+		return report.Components[a].ID < report.Components[b].ID
+	})
+
+	if len(report.Components) > 0 {
+		report.Level = MaxLevel
+		for _, c := range report.Components {
+			report.Level = min(report.Level, c.Level)
+		}
+	}
+	return report
+}
+
+// Count returns how many components reach exactly level.
+func (r *Report) Count(level Level) int {
+	n := 0
+	for _, c := range r.Components {
+		if c.Level == level {
+			n++
+		}
+	}
+	return n
+}
+
+// score climbs the levels until gaps reports what a component misses.
+func score(comp *ir.Component, gaps func(Level) []string) Component {
+	scored := Component{ID: comp.ID, Kind: comp.Kind}
+	for level := L1; level <= MaxLevel; level++ {
+		if missing := gaps(level); len(missing) > 0 {
+			scored.Gaps = missing
+			return scored
+		}
+		scored.Level = level
+	}
+	return scored
+}
+
+// usecaseGaps returns what a usecase misses to meet level.
+func usecaseGaps(i *ir.IR, uc *ir.Component, covered map[string][]string, level Level) []string {
+	spec := uc.Usecase
+	binding := spec.Binding
+	var gaps []string
+	switch level {
+	case L1:
+		if binding == nil {
+			gaps = append(gaps, "binds to no route (binds_to)")
+		}
+	case L2:
+		server := i.Components[binding.ServerID]
+		if server == nil || server.HTTPServer == nil || server.HTTPServer.ParsedOpenAPI == nil || server.HTTPServer.ParsedOpenAPI.Synthesized {
+			gaps = append(gaps, fmt.Sprintf("%s declares no openapi document", binding.ServerID))
+			break
+		}
+		// OpenAPI cannot describe catch-all routes; they handle the raw request.
+		if binding.IsCatchAll() {
+			break
+		}
+		if binding.Operation == nil {
+			gaps = append(gaps, fmt.Sprintf("the openapi document has no operation %s %s", binding.Method, binding.Path))
+			break
+		}
+		gaps = append(gaps, operationGaps(binding.Operation)...)
+	case L3:
+		if len(spec.AcceptanceCriteria) == 0 {
+			gaps = append(gaps, "declares no acceptance_criteria")
+		}
+		if !spec.UnitTests() && spec.E2ETests() == ir.E2ESkip {
+			gaps = append(gaps, "testing turns off unit and E2E tests")
+		}
+		if covered != nil {
+			for n := range spec.AcceptanceCriteria {
+				if id := coverage.CriterionID(uc.ID, n+1); len(covered[id]) == 0 {
+					gaps = append(gaps, fmt.Sprintf("%s has no active test", id))
+				}
+			}
+		}
+	case L4:
+		server := i.Components[binding.ServerID]
+		if server.HTTPServer.Metrics == nil {
+			gaps = append(gaps, fmt.Sprintf("%s does not enable observability.metrics", binding.ServerID))
+		}
+		if spec.SLO == nil {
+			gaps = append(gaps, "declares no slo")
+		}
+		middleware := spec.Middleware
+		if middleware == nil {
+			middleware = server.HTTPServer.Middleware
+		}
+		if len(middleware) == 0 && spec.Authorization == nil {
+			gaps = append(gaps, "runs no middleware and declares no authorization")
+		}
+	}
+	return gaps
+}
+
+// operationGaps returns the payloads of an operation that have no schema.
+func operationGaps(op *openapi.Operation) []string {
+	var gaps []string
+	if op.RequestBody != nil && !hasSchema(op.RequestBody.Content) {
+		gaps = append(gaps, "the request body has no schema")
+	}
+
+	success := false
+	for status, response := range op.Responses {
+		if len(status) != 3 || status[0] != '2' {
+			continue
+		}
+		success = true
+		if status != "204" && !hasSchema(response.Content) {
+			gaps = append(gaps, fmt.Sprintf("the %s response has no schema", status))
+		}
+	}
+	if !success {
+		gaps = append(gaps, "the operation declares no success response")
+	}
+	sort.Strings(gaps)
+	return gaps
+}
+
+// hasSchema reports whether some media type of content has a schema that
+// says more than "any JSON".
+func hasSchema(content map[string]*openapi.MediaType) bool {
+	for _, media := range content {
+		if s := media.Schema; s != nil && (s.Type != "" || s.Ref != "" || len(s.Properties) > 0 || len(s.Enum) > 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// serverGaps returns what a server misses to meet level.
+func serverGaps(i *ir.IR, server *ir.Component, usecaseLevels map[string]Level, level Level) []string {
+	var gaps []string
+	switch level {
+	case L1:
+		if len(i.UsecasesBoundTo(server.ID)) == 0 {
+			gaps = append(gaps, "serves no usecase")
+		}
+	case L2:
+		if doc := server.HTTPServer.ParsedOpenAPI; doc == nil || doc.Synthesized {
+			gaps = append(gaps, "declares no openapi document")
+		}
+	case L4:
+		if server.HTTPServer.Metrics == nil {
+			gaps = append(gaps, "does not enable observability.metrics")
+		}
+	}
+	for _, uc := range i.UsecasesBoundTo(server.ID) {
+		if usecaseLevels[uc.ID] < level {
+			gaps = append(gaps, fmt.Sprintf("%s is at %s", uc.ID, usecaseLevels[uc.ID]))
+		}
+	}
+	return gaps
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package maturity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
+)
+
+var userSchema = map[string]*openapi.MediaType{
+	"application/json": {Schema: &openapi.Schema{Ref: "#/components/schemas/User"}},
+}
+
+// operableIR returns a server with an OpenAPI document and metrics, and a
+// usecase bound to it that meets every level.
+func operableIR() *ir.IR {
+	op := &openapi.Operation{
+		Method:      "POST",
+		Path:        "/users",
+		RequestBody: &openapi.RequestBody{Content: userSchema},
+		Responses:   map[string]*openapi.Response{"201": {Content: userSchema}, "400": {}},
+	}
+	server := &ir.Component{
+		ID:   "http.server.api",
+		Kind: ir.KindHTTPServer,
+		HTTPServer: &ir.HTTPServerSpec{
+			OpenAPI:       "./openapi.yaml",
+			Middleware:    []string{"middleware.auth"},
+			Metrics:       &ir.MetricsSpec{Path: "/metrics"},
+			ParsedOpenAPI: &openapi.Document{Operations: map[string]*openapi.Operation{op.OperationKey(): op}},
+		},
+	}
+	uc := &ir.Component{
+		ID:   "usecase.create-user",
+		Kind: ir.KindUsecase,
+		Usecase: &ir.UsecaseSpec{
+			AcceptanceCriteria: []string{"User is stored"},
+			SLO:                &ir.SLOSpec{P99LatencyMS: 200},
+			Binding:            &ir.Binding{ServerID: server.ID, Method: "POST", Path: "/users", Operation: op},
+		},
+	}
+	return &ir.IR{Components: map[string]*ir.Component{server.ID: server, uc.ID: uc}}
+}
+
+func TestAssess(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(i *ir.IR)
+		want   []Component
+	}{
+		{
+			name:   "operable",
+			modify: func(i *ir.IR) {},
+			want: []Component{
+				{ID: "http.server.api", Kind: ir.KindHTTPServer, Level: L4},
+				{ID: "usecase.create-user", Kind: ir.KindUsecase, Level: L4},
+			},
+		},
+		{
+			name: "unbound",
+			modify: func(i *ir.IR) {
+				i.Components["usecase.create-user"].Usecase.Binding = nil
+			},
+			want: []Component{
+				{ID: "http.server.api", Kind: ir.KindHTTPServer, Level: L0, Gaps: []string{"serves no usecase"}},
+				{ID: "usecase.create-user", Kind: ir.KindUsecase, Level: L0, Gaps: []string{"binds to no route (binds_to)"}},
+			},
+		},
+		{
+			name: "synthesized document",
+			modify: func(i *ir.IR) {
+				i.Components["http.server.api"].HTTPServer.ParsedOpenAPI.Synthesized = true
+			},
+			want: []Component{
+				{ID: "http.server.api", Kind: ir.KindHTTPServer, Level: L1, Gaps: []string{"declares no openapi document", "usecase.create-user is at L1"}},
+				{ID: "usecase.create-user", Kind: ir.KindUsecase, Level: L1, Gaps: []string{"http.server.api declares no openapi document"}},
+			},
+		},
+		{
+			name: "untyped payloads",
+			modify: func(i *ir.IR) {
+				op := i.Components["usecase.create-user"].Usecase.Binding.Operation
+				op.RequestBody.Content = map[string]*openapi.MediaType{"application/json": {Schema: &openapi.Schema{}}}
+				op.Responses["201"].Content = nil
+			},
+			want: []Component{
+				{ID: "http.server.api", Kind: ir.KindHTTPServer, Level: L1, Gaps: []string{"usecase.create-user is at L1"}},
+				{ID: "usecase.create-user", Kind: ir.KindUsecase, Level: L1, Gaps: []string{"the 201 response has no schema", "the request body has no schema"}},
+			},
+		},
+		{
+			name: "untested",
+			modify: func(i *ir.IR) {
+				uc := i.Components["usecase.create-user"].Usecase
+				uc.AcceptanceCriteria = nil
+				uc.Testing = &ir.TestingSpec{E2E: ir.E2ESkip}
+			},
+			want: []Component{
+				{ID: "http.server.api", Kind: ir.KindHTTPServer, Level: L2, Gaps: []string{"usecase.create-user is at L2"}},
+				{ID: "usecase.create-user", Kind: ir.KindUsecase, Level: L2, Gaps: []string{"declares no acceptance_criteria", "testing turns off unit and E2E tests"}},
+			},
+		},
+		{
+			name: "unobserved and public",
+			modify: func(i *ir.IR) {
+				i.Components["http.server.api"].HTTPServer.Metrics = nil
+				uc := i.Components["usecase.create-user"].Usecase
+				uc.SLO = nil
+				uc.Middleware = []string{}
+			},
+			want: []Component{
+				{ID: "http.server.api", Kind: ir.KindHTTPServer, Level: L3, Gaps: []string{"does not enable observability.metrics", "usecase.create-user is at L3"}},
+				{ID: "usecase.create-user", Kind: ir.KindUsecase, Level: L3, Gaps: []string{
+					"http.server.api does not enable observability.metrics",
+					"declares no slo",
+					"runs no middleware and declares no authorization",
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			i := operableIR()
+			tt.modify(i)
+
+			// when
+			report := Assess(i, nil)
+
+			// then
+			if !reflect.DeepEqual(report.Components, tt.want) {
+				t.Errorf("Assess() components = %+v, want %+v", report.Components, tt.want)
+			}
+			if want := tt.want[1].Level; report.Level != want {
+				t.Errorf("Assess() level = %s, want %s", report.Level, want)
+			}
+		})
+	}
+}
+
+func TestAssess_Coverage(t *testing.T) {
+	// given
+	i := operableIR()
+
+	// when
+	uncovered := Assess(i, map[string][]string{})
+	covered := Assess(i, map[string][]string{"AC-usecase.create-user-1": {"users.test.ts"}})
+
+	// then
+	if uncovered.Level != L2 {
+		t.Errorf("uncovered level = %s, want L2", uncovered.Level)
+	}
+	if got := uncovered.Components[1].Gaps; !reflect.DeepEqual(got, []string{"AC-usecase.create-user-1 has no active test"}) {
+		t.Errorf("uncovered gaps = %v", got)
+	}
+	if covered.Level != L4 {
+		t.Errorf("covered level = %s, want L4", covered.Level)
+	}
+}
+
+func TestAssess_Empty(t *testing.T) {
+	report := Assess(&ir.IR{Components: map[string]*ir.Component{}}, nil)
+
+	if report.Level != L0 || len(report.Components) != 0 {
+		t.Errorf("Assess() = %+v, want L0 without components", report)
+	}
+}
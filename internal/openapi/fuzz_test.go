@@ -0,0 +1,41 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"strings"
+	"testing"
+)
+
+// FuzzParseBinding checks that every binding ParseBinding accepts splits
+// back into its input, with a known method and an absolute path.
+func FuzzParseBinding(f *testing.F) {
+	f.Add("http.server.api:GET:/users")
+	f.Add("http.server.api:POST:/users/{id}")
+	f.Add("http.server.api:GET:/a:b")
+	f.Add("http.server.api:get:/users")
+	f.Add("http.server.api:GET:users")
+	f.Add("::/")
+	f.Add(":")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, bindsTo string) {
+		serverID, method, path, err := ParseBinding(bindsTo)
+		if err != nil {
+			return
+		}
+		if got := serverID + ":" + method + ":" + path; got != bindsTo {
+			t.Errorf("ParseBinding(%q) parts join to %q", bindsTo, got)
+		}
+		if strings.Contains(serverID, ":") {
+			t.Errorf("ParseBinding(%q) server ID = %q, contains a colon", bindsTo, serverID)
+		}
+		if method != strings.ToUpper(method) || method == "" {
+			t.Errorf("ParseBinding(%q) method = %q, expected an uppercase HTTP method", bindsTo, method)
+		}
+		if !strings.HasPrefix(path, "/") {
+			t.Errorf("ParseBinding(%q) path = %q, expected a leading /", bindsTo, path)
+		}
+	})
+}
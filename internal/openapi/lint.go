@@ -0,0 +1,402 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Issue is a semantic problem found in an OpenAPI document.
+type Issue struct {
+	Code     string // One of the Issue* codes
+	Message  string
+	Location string // Dotted path into the document (e.g., "paths./users/{id}.get")
+	Line     int    // 1-indexed line number
+	Column   int    // 1-indexed column number
+}
+
+// Codes of the issues Lint reports.
+const (
+	IssueUnresolvedRef           = "unresolved-ref"
+	IssueDuplicateOperationID    = "duplicate-operation-id"
+	IssueUndeclaredPathParameter = "undeclared-path-parameter"
+	IssueNoSuccessResponse       = "no-success-response"
+	IssueUnsupportedKeyword      = "unsupported-keyword"
+)
+
+// Warning reports whether the issue leaves the document usable for code
+// generation: undeclared path parameters are still read from the path, an
+// operation without a success response just has no typed result, and a
+// schema keyword code generation does not support is left out of the
+// generated types and validation.
+func (i Issue) Warning() bool {
+	return i.Code == IssueUndeclaredPathParameter || i.Code == IssueNoSuccessResponse || i.Code == IssueUnsupportedKeyword
+}
+
+// unsupportedKeywords are the schema keywords code generation ignores, so
+// the generated validation accepts values the schema rejects.
+var unsupportedKeywords = map[string]bool{
+	"not":                   true,
+	"if":                    true,
+	"then":                  true,
+	"else":                  true,
+	"patternProperties":     true,
+	"propertyNames":         true,
+	"dependentSchemas":      true,
+	"dependentRequired":     true,
+	"unevaluatedProperties": true,
+	"unevaluatedItems":      true,
+	"prefixItems":           true,
+	"contains":              true,
+}
+
+func (i Issue) String() string {
+	if i.Location == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s (at %s)", i.Message, i.Location)
+}
+
+// InvalidDocumentError is returned when an OpenAPI document cannot be loaded
+// and linting explains why (e.g., a $ref that does not resolve).
+type InvalidDocumentError struct {
+	File   string
+	Issues []Issue
+	Err    error // Underlying loader error
+}
+
+func (e *InvalidDocumentError) Error() string {
+	msgs := make([]string, len(e.Issues))
+	for n, issue := range e.Issues {
+		msgs[n] = issue.String()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *InvalidDocumentError) Unwrap() error {
+	return e.Err
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+var lintMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Lint checks an OpenAPI document for problems the loader does not report:
+// duplicate operationIds, path template parameters without a matching
+// "in: path" parameter, operations without a 2xx or default response,
+// schema keywords code generation ignores and $refs that do not resolve. The
+// parameter, response and keyword issues are warnings (see Issue.Warning). dir is used to resolve external file references; when empty,
+// only local references are checked.
+func Lint(data []byte, dir string) ([]Issue, error) {
+	var exists func(file string) bool
+	if dir != "" {
+		exists = func(file string) bool {
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(dir, file)
+			}
+			_, err := os.Stat(file)
+			return err == nil
+		}
+	}
+	return lint(data, exists)
+}
+
+// lintFS is Lint for a document at name in fsys.
+func lintFS(data []byte, fsys fs.FS, name string) ([]Issue, error) {
+	return lint(data, func(file string) bool {
+		_, err := fs.Stat(fsys, path.Join(path.Dir(name), file))
+		return err == nil
+	})
+}
+
+// lint implements Lint. exists reports whether a file referenced by the
+// document exists; when nil, only local references are checked.
+func lint(data []byte, exists func(file string) bool) ([]Issue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	l := &linter{root: doc.Content[0], exists: exists}
+	l.lintRefs(l.root, "")
+	l.lintPaths()
+	l.lintKeywords(l.root, "", false)
+
+	sort.SliceStable(l.issues, func(a, b int) bool {
+		if l.issues[a].Line != l.issues[b].Line {
+			return l.issues[a].Line < l.issues[b].Line
+		}
+		return l.issues[a].Column < l.issues[b].Column
+	})
+	return l.issues, nil
+}
+
+type linter struct {
+	root   *yaml.Node
+	exists func(file string) bool
+	issues []Issue
+}
+
+func (l *linter) report(node *yaml.Node, code, location, format string, args ...any) {
+	l.issues = append(l.issues, Issue{
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Location: location,
+		Line:     node.Line,
+		Column:   node.Column,
+	})
+}
+
+// lintRefs walks the document and reports $refs that do not resolve.
+func (l *linter) lintRefs(node *yaml.Node, location string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for n := 0; n+1 < len(node.Content); n += 2 {
+			key, value := node.Content[n], node.Content[n+1]
+			if key.Value == "$ref" && value.Kind == yaml.ScalarNode {
+				l.lintRef(value, location)
+				continue
+			}
+			l.lintRefs(value, joinLocation(location, key.Value))
+		}
+	case yaml.SequenceNode:
+		for n, item := range node.Content {
+			l.lintRefs(item, joinLocation(location, fmt.Sprint(n)))
+		}
+	}
+}
+
+func (l *linter) lintRef(value *yaml.Node, location string) {
+	ref := value.Value
+	file, pointer, _ := strings.Cut(ref, "#")
+
+	if file != "" {
+		if l.exists != nil && !l.exists(file) {
+			l.report(value, IssueUnresolvedRef, location, "$ref %q points to a file that does not exist", ref)
+		}
+		return
+	}
+
+	if resolvePointer(l.root, pointer) == nil {
+		l.report(value, IssueUnresolvedRef, location, "$ref %q does not resolve", ref)
+	}
+}
+
+// lintKeywords walks the document and reports the unsupported keywords of
+// its schemas; schema is set when node is one.
+func (l *linter) lintKeywords(node *yaml.Node, location string, schema bool) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for n, item := range node.Content {
+			l.lintKeywords(item, joinLocation(location, fmt.Sprint(n)), false)
+		}
+		return
+	case yaml.MappingNode:
+	default:
+		return
+	}
+
+	for n := 0; n+1 < len(node.Content); n += 2 {
+		key, value := node.Content[n], node.Content[n+1]
+		at := joinLocation(location, key.Value)
+		switch {
+		case key.Value == "example" || key.Value == "examples" || key.Value == "default" || key.Value == "enum":
+			// Values, not schemas
+		case schema && unsupportedKeywords[key.Value]:
+			l.report(key, IssueUnsupportedKeyword, location, "schema keyword %q is not supported; the generated types and validation ignore it", key.Value)
+		case schema && key.Value == "properties" && value.Kind == yaml.MappingNode:
+			for m := 0; m+1 < len(value.Content); m += 2 {
+				l.lintKeywords(value.Content[m+1], joinLocation(at, value.Content[m].Value), true)
+			}
+		case schema && (key.Value == "allOf" || key.Value == "oneOf" || key.Value == "anyOf") && value.Kind == yaml.SequenceNode:
+			for m, item := range value.Content {
+				l.lintKeywords(item, joinLocation(at, fmt.Sprint(m)), true)
+			}
+		case schema && (key.Value == "items" || key.Value == "additionalProperties"):
+			l.lintKeywords(value, at, true)
+		case schema:
+			// Annotations and validation keywords hold no schemas
+		case key.Value == "schema":
+			l.lintKeywords(value, at, true)
+		case key.Value == "schemas" && location == "components" && value.Kind == yaml.MappingNode:
+			for m := 0; m+1 < len(value.Content); m += 2 {
+				l.lintKeywords(value.Content[m+1], joinLocation(at, value.Content[m].Value), true)
+			}
+		default:
+			l.lintKeywords(value, at, false)
+		}
+	}
+}
+
+// lintPaths checks every operation under paths.
+func (l *linter) lintPaths() {
+	paths := mappingValue(l.root, "paths")
+	if paths == nil || paths.Kind != yaml.MappingNode {
+		return
+	}
+
+	operationIDs := make(map[string]string)
+	for n := 0; n+1 < len(paths.Content); n += 2 {
+		pathKey, pathItem := paths.Content[n], paths.Content[n+1]
+		pathItem = l.deref(pathItem)
+		if pathItem.Kind != yaml.MappingNode {
+			continue
+		}
+		shared := l.parameters(mappingValue(pathItem, "parameters"))
+
+		for _, method := range lintMethods {
+			op := mappingValue(pathItem, method)
+			if op == nil || op.Kind != yaml.MappingNode {
+				continue
+			}
+			location := joinLocation(joinLocation("paths", pathKey.Value), method)
+			opNode := keyNode(pathItem, method)
+
+			if id := mappingValue(op, "operationId"); id != nil && id.Value != "" {
+				if previous, ok := operationIDs[id.Value]; ok {
+					l.report(id, IssueDuplicateOperationID, location, "duplicate operationId %q (already used by %s)", id.Value, previous)
+				} else {
+					operationIDs[id.Value] = strings.ToUpper(method) + " " + pathKey.Value
+				}
+			}
+
+			declared := make(map[string]bool)
+			for name := range shared {
+				declared[name] = true
+			}
+			for name := range l.parameters(mappingValue(op, "parameters")) {
+				declared[name] = true
+			}
+			for _, match := range pathParamPattern.FindAllStringSubmatch(pathKey.Value, -1) {
+				if !declared[match[1]] {
+					l.report(opNode, IssueUndeclaredPathParameter, location, "path parameter %q is not declared in parameters", match[1])
+				}
+			}
+
+			if !hasSuccessResponse(l.deref(mappingValue(op, "responses"))) {
+				l.report(opNode, IssueNoSuccessResponse, location, "operation has no 2xx or default response")
+			}
+		}
+	}
+}
+
+// parameters returns the names of the "in: path" parameters in a parameters list.
+func (l *linter) parameters(list *yaml.Node) map[string]bool {
+	names := make(map[string]bool)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return names
+	}
+	for _, item := range list.Content {
+		param := l.deref(item)
+		in := mappingValue(param, "in")
+		name := mappingValue(param, "name")
+		if in != nil && name != nil && in.Value == "path" {
+			names[name.Value] = true
+		}
+	}
+	return names
+}
+
+// deref follows a local $ref. Unresolvable or external references return the
+// node unchanged; lintRefs reports them separately.
+func (l *linter) deref(node *yaml.Node) *yaml.Node {
+	for depth := 0; node != nil && depth < 16; depth++ {
+		ref := mappingValue(node, "$ref")
+		if ref == nil || !strings.HasPrefix(ref.Value, "#") {
+			return node
+		}
+		target := resolvePointer(l.root, strings.TrimPrefix(ref.Value, "#"))
+		if target == nil {
+			return node
+		}
+		node = target
+	}
+	return node
+}
+
+// hasSuccessResponse reports whether responses has a 2xx or a default
+// response, which covers the statuses that are not listed.
+func hasSuccessResponse(responses *yaml.Node) bool {
+	if responses == nil || responses.Kind != yaml.MappingNode {
+		return false
+	}
+	for n := 0; n < len(responses.Content); n += 2 {
+		if status := strings.ToUpper(responses.Content[n].Value); strings.HasPrefix(status, "2") || status == "DEFAULT" {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvePointer resolves a JSON pointer (e.g., "/components/schemas/User")
+// against root. It returns nil when the pointer does not resolve.
+func resolvePointer(root *yaml.Node, pointer string) *yaml.Node {
+	if pointer == "" {
+		return root
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil
+	}
+
+	node := root
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch node.Kind {
+		case yaml.MappingNode:
+			node = mappingValue(node, token)
+		case yaml.SequenceNode:
+			var index int
+			if _, err := fmt.Sscanf(token, "%d", &index); err != nil || index < 0 || index >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[index]
+		default:
+			return nil
+		}
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for n := 0; n+1 < len(node.Content); n += 2 {
+		if node.Content[n].Value == key {
+			return node.Content[n+1]
+		}
+	}
+	return nil
+}
+
+func keyNode(node *yaml.Node, key string) *yaml.Node {
+	for n := 0; n+1 < len(node.Content); n += 2 {
+		if node.Content[n].Value == key {
+			return node.Content[n]
+		}
+	}
+	return node
+}
+
+func joinLocation(location, key string) string {
+	if location == "" {
+		return key
+	}
+	return location + "." + key
+}
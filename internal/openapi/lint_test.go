@@ -0,0 +1,309 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want []Issue
+	}{
+		{
+			name: "valid document",
+			yaml: `openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    parameters:
+      - $ref: '#/components/parameters/UserId'
+    get:
+      operationId: getUser
+      responses:
+        '200':
+          description: OK
+components:
+  parameters:
+    UserId:
+      name: id
+      in: path
+      required: true
+      schema:
+        type: string
+`,
+			want: nil,
+		},
+		{
+			name: "duplicate operationId",
+			yaml: `openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      operationId: users
+      responses:
+        '200':
+          description: OK
+    post:
+      operationId: users
+      responses:
+        '201':
+          description: Created
+`,
+			want: []Issue{{
+				Code:     IssueDuplicateOperationID,
+				Message:  `duplicate operationId "users" (already used by GET /users)`,
+				Location: "paths./users.post",
+				Line:     13,
+				Column:   20,
+			}},
+		},
+		{
+			name: "undeclared path parameter",
+			yaml: `openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+        - name: id
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+`,
+			want: []Issue{{
+				Code:     IssueUndeclaredPathParameter,
+				Message:  `path parameter "id" is not declared in parameters`,
+				Location: "paths./users/{id}.get",
+				Line:     7,
+				Column:   5,
+			}},
+		},
+		{
+			name: "missing 2xx response",
+			yaml: `openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    delete:
+      operationId: deleteUsers
+      responses:
+        '404':
+          description: Not Found
+`,
+			want: []Issue{{
+				Code:     IssueNoSuccessResponse,
+				Message:  "operation has no 2xx or default response",
+				Location: "paths./users.delete",
+				Line:     7,
+				Column:   5,
+			}},
+		},
+		{
+			name: "default response",
+			yaml: `openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    delete:
+      operationId: deleteUsers
+      responses:
+        default:
+          description: Result
+`,
+		},
+		{
+			name: "unresolvable ref",
+			yaml: `openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+`,
+			want: []Issue{{
+				Code:     IssueUnresolvedRef,
+				Message:  `$ref "#/components/schemas/User" does not resolve`,
+				Location: "paths./users.get.responses.200.content.application/json.schema",
+				Line:     15,
+				Column:   23,
+			}},
+		},
+		{
+			name: "unsupported schema keyword",
+			yaml: `openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        not:
+          type: string
+        role:
+          not:
+            enum: [root]
+      example:
+        not: ok
+`,
+			want: []Issue{{
+				Code:     IssueUnsupportedKeyword,
+				Message:  `schema keyword "not" is not supported; the generated types and validation ignore it`,
+				Location: "components.schemas.User.properties.role",
+				Line:     14,
+				Column:   11,
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			issues, err := Lint([]byte(tt.yaml), "")
+
+			// then
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			if len(issues) != len(tt.want) {
+				t.Fatalf("Lint() returned %d issues, want %d: %v", len(issues), len(tt.want), issues)
+			}
+			for n, want := range tt.want {
+				if issues[n] != want {
+					t.Errorf("issue[%d] = %#v, want %#v", n, issues[n], want)
+				}
+			}
+		})
+	}
+}
+
+func TestLint_ExternalRef(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	data := []byte(`openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    $ref: './missing.yaml#/paths/users'
+`)
+
+	// when
+	issues, err := Lint(data, dir)
+
+	// then
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(issues) != 1 || !strings.Contains(issues[0].Message, "does not exist") {
+		t.Errorf("Lint() = %v, want one missing-file issue", issues)
+	}
+}
+
+func TestParser_ParseFile_UnresolvableRef(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	content := `openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+`
+	if err := os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// when
+	_, err := NewParser(dir).ParseFile("openapi.yaml")
+
+	// then
+	var invalid *InvalidDocumentError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("ParseFile() error = %v, want *InvalidDocumentError", err)
+	}
+	if len(invalid.Issues) != 1 || invalid.Issues[0].Line != 15 {
+		t.Errorf("Issues = %+v, want one issue on line 15", invalid.Issues)
+	}
+}
+
+func TestParser_ParseBytes_RecordsIssues(t *testing.T) {
+	// given
+	data := []byte(`openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      operationId: getUser
+      responses:
+        '404':
+          description: Not Found
+`)
+
+	// when
+	doc, err := NewParser("").ParseBytes(data)
+
+	// then
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+	if len(doc.Issues) != 1 || doc.Issues[0].Code != IssueNoSuccessResponse || !doc.Issues[0].Warning() {
+		t.Errorf("Issues = %+v, want one no-success-response warning", doc.Issues)
+	}
+	op := doc.Operations["GET:/users/{id}"]
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "id" {
+		t.Errorf("Parameters = %+v, want path-level id parameter", op.Parameters)
+	}
+}
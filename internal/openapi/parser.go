@@ -5,15 +5,29 @@ package openapi
 
 import (
 	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
+// componentSchemaPrefix is the $ref prefix of named component schemas.
+const componentSchemaPrefix = "#/components/schemas/"
+
+// readFromURI reads the files and URLs a document references, like the
+// loader's default reader but without keeping what it read.
+var readFromURI = openapi3.ReadFromURIs(openapi3.ReadFromHTTP(http.DefaultClient), openapi3.ReadFromFile)
+
 // Parser parses OpenAPI specification files.
 type Parser struct {
 	baseDir string
+	fsys    fs.FS // Read from instead of the operating system, if set
 }
 
 // NewParser creates a new OpenAPI parser.
@@ -22,39 +36,112 @@ func NewParser(baseDir string) *Parser {
 	return &Parser{baseDir: baseDir}
 }
 
+// NewFSParser creates an OpenAPI parser that reads documents, and the files
+// they reference, from fsys. File paths are relative to the root of fsys.
+func NewFSParser(fsys fs.FS) *Parser {
+	return &Parser{fsys: fsys}
+}
+
 // ParseFile parses an OpenAPI file and returns a Document.
 func (p *Parser) ParseFile(filename string) (*Document, error) {
 	// Resolve relative path
-	path := filename
-	if !filepath.IsAbs(filename) {
-		path = filepath.Join(p.baseDir, filename)
+	file := filename
+	if p.fsys != nil {
+		file = path.Clean(filename)
+	} else if !filepath.IsAbs(filename) {
+		file = filepath.Join(p.baseDir, filename)
 	}
 
+	// Lint the raw document first so that problems which stop the loader,
+	// such as unresolvable $refs, can be reported with their location.
+	var issues []Issue
+	if p.fsys != nil {
+		if data, err := fs.ReadFile(p.fsys, file); err == nil {
+			issues, _ = lintFS(data, p.fsys, file)
+		}
+	} else if data, err := os.ReadFile(file); err == nil {
+		issues, _ = Lint(data, filepath.Dir(file))
+	}
+
+	// Record the files the loader reads, the document and every file it
+	// $refs, for callers that must notice when any of them changes. Files
+	// are read afresh: the loader's default reader keeps them for the life
+	// of the process, which would hide an edit from a later parse.
+	files := make(map[string]bool)
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(l *openapi3.Loader, u *url.URL) ([]byte, error) {
+		if p.fsys != nil {
+			name := path.Clean(u.Path)
+			files[name] = true
+			return fs.ReadFile(p.fsys, name)
+		}
+		if u.Host == "" && (u.Scheme == "" || u.Scheme == "file") {
+			if name, err := filepath.Abs(filepath.FromSlash(u.Path)); err == nil {
+				files[name] = true
+			}
+		}
+		return readFromURI(l, u)
+	}
 
-	spec, err := loader.LoadFromFile(path)
+	spec, err := loader.LoadFromFile(file)
 	if err != nil {
+		if errs := errorIssues(issues); len(errs) > 0 {
+			return nil, &InvalidDocumentError{File: file, Issues: errs, Err: err}
+		}
 		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
 	}
 
-	return p.convertSpec(spec)
+	doc, err := p.convertSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	doc.File = file
+	doc.Issues = issues
+	for name := range files {
+		doc.Files = append(doc.Files, name)
+	}
+	sort.Strings(doc.Files)
+	return doc, nil
+}
+
+// errorIssues returns the issues that are not warnings, which are the ones
+// that can explain why the loader rejected a document.
+func errorIssues(issues []Issue) []Issue {
+	var errs []Issue
+	for _, issue := range issues {
+		if !issue.Warning() {
+			errs = append(errs, issue)
+		}
+	}
+	return errs
 }
 
 // ParseBytes parses OpenAPI content from bytes.
 func (p *Parser) ParseBytes(data []byte) (*Document, error) {
 	loader := openapi3.NewLoader()
+	issues, _ := Lint(data, "")
+
 	spec, err := loader.LoadFromData(data)
 	if err != nil {
+		if errs := errorIssues(issues); len(errs) > 0 {
+			return nil, &InvalidDocumentError{Issues: errs, Err: err}
+		}
 		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
 	}
 
-	return p.convertSpec(spec)
+	doc, err := p.convertSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	doc.Issues = issues
+	return doc, nil
 }
 
 func (p *Parser) convertSpec(spec *openapi3.T) (*Document, error) {
 	doc := &Document{
 		Operations: make(map[string]*Operation),
+		Schemas:    make(map[string]*Schema),
 	}
 
 	if spec.Info != nil {
@@ -62,6 +149,13 @@ func (p *Parser) convertSpec(spec *openapi3.T) (*Document, error) {
 		doc.Version = spec.Info.Version
 	}
 
+	// Extract component schemas
+	if spec.Components != nil {
+		for name, ref := range spec.Components.Schemas {
+			doc.Schemas[name] = p.convertSchemaRef(ref)
+		}
+	}
+
 	// Extract operations from paths
 	for path, pathItem := range spec.Paths.Map() {
 		if pathItem == nil {
@@ -84,6 +178,7 @@ func (p *Parser) convertSpec(spec *openapi3.T) (*Document, error) {
 			}
 
 			operation := p.convertOperation(method, path, op)
+			p.mergePathParameters(operation, pathItem.Parameters)
 			key := operation.OperationKey()
 			doc.Operations[key] = operation
 		}
@@ -151,7 +246,8 @@ func (p *Parser) convertOperation(method, path string, op *openapi3.Operation) *
 			}
 			for mediaType, content := range resp.Content {
 				response.Content[mediaType] = &MediaType{
-					Schema: p.convertSchemaRef(content.Schema),
+					Schema:  p.convertSchemaRef(content.Schema),
+					Example: mediaTypeExample(content),
 				}
 			}
 			operation.Responses[status] = response
@@ -161,6 +257,49 @@ func (p *Parser) convertOperation(method, path string, op *openapi3.Operation) *
 	return operation
 }
 
+// mediaTypeExample returns the example of a media type: its example, else
+// the first of its named examples, else the example of its schema.
+func mediaTypeExample(content *openapi3.MediaType) any {
+	if content.Example != nil {
+		return content.Example
+	}
+	names := make([]string, 0, len(content.Examples))
+	for name := range content.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if ref := content.Examples[name]; ref != nil && ref.Value != nil && ref.Value.Value != nil {
+			return ref.Value.Value
+		}
+	}
+	if content.Schema != nil && content.Schema.Value != nil {
+		return content.Schema.Value.Example
+	}
+	return nil
+}
+
+// mergePathParameters adds path-level parameters that the operation does not
+// override (matched by name and location).
+func (p *Parser) mergePathParameters(operation *Operation, params openapi3.Parameters) {
+	for _, paramRef := range params {
+		if paramRef == nil || paramRef.Value == nil {
+			continue
+		}
+		param := paramRef.Value
+		if operation.hasParameter(param.Name, param.In) {
+			continue
+		}
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:        param.Name,
+			In:          param.In,
+			Required:    param.Required,
+			Description: param.Description,
+			Schema:      p.convertSchemaRef(param.Schema),
+		})
+	}
+}
+
 func (p *Parser) convertSchemaRef(ref *openapi3.SchemaRef) *Schema {
 	if ref == nil {
 		return nil
@@ -168,8 +307,9 @@ func (p *Parser) convertSchemaRef(ref *openapi3.SchemaRef) *Schema {
 
 	schema := &Schema{}
 
-	// Check for $ref
-	if ref.Ref != "" {
+	// Keep references to named component schemas; inline anything else
+	// (e.g., schemas in external files) so the document is fully resolved.
+	if strings.HasPrefix(ref.Ref, componentSchemaPrefix) {
 		schema.Ref = ref.Ref
 		return schema
 	}
@@ -187,6 +327,11 @@ func (p *Parser) convertSchemaRef(ref *openapi3.SchemaRef) *Schema {
 	schema.Description = s.Description
 	schema.Nullable = s.Nullable
 	schema.Required = s.Required
+	schema.Example = s.Example
+	schema.Minimum, schema.Maximum = s.Min, s.Max
+	schema.ExclusiveMinimum, schema.ExclusiveMaximum = s.ExclusiveMin, s.ExclusiveMax
+	schema.MinLength, schema.MaxLength = s.MinLength, s.MaxLength
+	schema.MinItems, schema.MaxItems = s.MinItems, s.MaxItems
 
 	// Handle enum
 	if len(s.Enum) > 0 {
@@ -206,9 +351,32 @@ func (p *Parser) convertSchemaRef(ref *openapi3.SchemaRef) *Schema {
 		schema.Items = p.convertSchemaRef(s.Items)
 	}
 
+	// Handle composition
+	schema.AllOf = p.convertSchemaRefs(s.AllOf)
+	schema.OneOf = p.convertSchemaRefs(s.OneOf)
+	schema.AnyOf = p.convertSchemaRefs(s.AnyOf)
+
+	// Handle properties that are not listed
+	if s.AdditionalProperties.Schema != nil {
+		schema.AdditionalProperties = p.convertSchemaRef(s.AdditionalProperties.Schema)
+	} else if has := s.AdditionalProperties.Has; has != nil && !*has {
+		schema.NoAdditionalProperties = true
+	}
+
 	return schema
 }
 
+func (p *Parser) convertSchemaRefs(refs openapi3.SchemaRefs) []*Schema {
+	if len(refs) == 0 {
+		return nil
+	}
+	schemas := make([]*Schema, len(refs))
+	for n, ref := range refs {
+		schemas[n] = p.convertSchemaRef(ref)
+	}
+	return schemas
+}
+
 // ParseBinding parses a binds_to value into server ID, method, and path.
 // Format: server-id:METHOD:/path
 func ParseBinding(bindsTo string) (serverID, method, path string, err error) {
@@ -234,10 +402,10 @@ func ParseBinding(bindsTo string) (serverID, method, path string, err error) {
 	method = rest[:secondColon]
 	path = rest[secondColon+1:]
 
-	// Validate method
+	// Validate method; ALL matches every method
 	validMethods := map[string]bool{
 		"GET": true, "POST": true, "PUT": true, "PATCH": true,
-		"DELETE": true, "HEAD": true, "OPTIONS": true,
+		"DELETE": true, "HEAD": true, "OPTIONS": true, "ALL": true,
 	}
 	if !validMethods[method] {
 		return "", "", "", fmt.Errorf("invalid HTTP method: %s", method)
@@ -248,6 +416,11 @@ func ParseBinding(bindsTo string) (serverID, method, path string, err error) {
 		return "", "", "", fmt.Errorf("path must start with /: %s", path)
 	}
 
+	// A wildcard matches the rest of the path, so it can only end it
+	if n := strings.Count(path, "*"); n > 0 && (n > 1 || !strings.HasSuffix(path, "/*")) {
+		return "", "", "", fmt.Errorf("wildcard must be the last path segment (e.g., /files/*): %s", path)
+	}
+
 	return serverID, method, path, nil
 }
 
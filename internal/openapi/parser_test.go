@@ -4,7 +4,13 @@
 package openapi
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestParser_ParseBytes(t *testing.T) {
@@ -393,6 +399,130 @@ components:
 				}
 			},
 		},
+		{
+			name: "exposes component schemas",
+			// given
+			yaml: `
+openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    User:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+          format: uuid
+        tags:
+          type: array
+          items:
+            type: string
+`,
+			wantOps: 0,
+			wantErr: false,
+			// then
+			validateDoc: func(t *testing.T, doc *Document) {
+				user, ok := doc.Schemas["User"]
+				if !ok {
+					t.Fatal("missing component schema User")
+				}
+				if user.Type != "object" {
+					t.Errorf("Type = %q, want %q", user.Type, "object")
+				}
+				if user.Properties["id"].Format != "uuid" {
+					t.Errorf("id format = %q, want %q", user.Properties["id"].Format, "uuid")
+				}
+				if user.Properties["tags"].Items == nil || user.Properties["tags"].Items.Type != "string" {
+					t.Error("tags should be an array of strings")
+				}
+			},
+		},
+		{
+			name: "parses response examples",
+			// given
+			yaml: `
+openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+              example:
+                id: u1
+                name: Ada
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+              examples:
+                second:
+                  value: [b]
+                first:
+                  value: [a]
+    post:
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: object
+                example: { id: u2 }
+  /health:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+`,
+			wantOps: 4,
+			// then: the media type example wins, then the first named
+			// example, then the schema example
+			validateDoc: func(t *testing.T, doc *Document) {
+				want := map[string]any{
+					"GET:/users/{id}": map[string]any{"id": "u1", "name": "Ada"},
+					"GET:/users":      []any{"a"},
+					"POST:/users":     map[string]any{"id": "u2"},
+					"GET:/health":     nil,
+				}
+				for key, example := range want {
+					op := doc.Operations[key]
+					var got any
+					for _, resp := range op.Responses {
+						got = resp.Content["application/json"].Example
+					}
+					if !reflect.DeepEqual(got, example) {
+						t.Errorf("%s example = %#v, want %#v", key, got, example)
+					}
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -421,6 +551,112 @@ components:
 	}
 }
 
+func TestParser_ParseFile_FS(t *testing.T) {
+	// given
+	fsys := fstest.MapFS{
+		"api/openapi.yaml": {Data: []byte(`openapi: 3.0.0
+info: {title: Orders, version: "1"}
+paths:
+  /orders:
+    get:
+      operationId: listOrders
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: ./schemas.yaml#/Order
+    post:
+      responses:
+        "201":
+          description: created
+          content:
+            application/json:
+              schema:
+                $ref: ./missing.yaml#/Order
+`)},
+		"api/schemas.yaml": {Data: []byte("Order:\n  type: object\n  properties:\n    id: {type: string}\n")},
+	}
+
+	// when
+	_, err := NewFSParser(fsys).ParseFile("./api/openapi.yaml")
+
+	// then
+	var invalid *InvalidDocumentError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("ParseFile() error = %v, expected an InvalidDocumentError", err)
+	}
+	if len(invalid.Issues) != 1 || !strings.Contains(invalid.Issues[0].Message, "./missing.yaml#/Order") {
+		t.Errorf("Issues = %v, expected the missing file only", invalid.Issues)
+	}
+
+	// when
+	fsys["api/missing.yaml"] = fsys["api/schemas.yaml"]
+	doc, err := NewFSParser(fsys).ParseFile("api/openapi.yaml")
+
+	// then
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	if doc.File != "api/openapi.yaml" {
+		t.Errorf("File = %q, expected %q", doc.File, "api/openapi.yaml")
+	}
+	op := doc.Operations["GET:/orders"]
+	if op == nil || op.Responses["200"].Content["application/json"].Schema.Properties["id"] == nil {
+		t.Errorf("GET /orders = %+v, expected the response schema from schemas.yaml", op)
+	}
+	if want := []string{"api/missing.yaml", "api/openapi.yaml", "api/schemas.yaml"}; !reflect.DeepEqual(doc.Files, want) {
+		t.Errorf("Files = %v, expected %v", doc.Files, want)
+	}
+}
+
+func TestParser_ParseFile_RereadsReferencedFiles(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	document := `openapi: 3.0.0
+info: {title: Orders, version: "1"}
+paths:
+  /orders:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: ./schemas.yaml#/Order
+`
+	schemas := filepath.Join(dir, "schemas.yaml")
+	if err := os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(document), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(schemas, []byte("Order:\n  type: object\n  properties:\n    id: {type: string}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewParser(dir).ParseFile("openapi.yaml"); err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	// when
+	if err := os.WriteFile(schemas, []byte("Order:\n  type: object\n  properties:\n    total: {type: number}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := NewParser(dir).ParseFile("openapi.yaml")
+
+	// then
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	schema := doc.Operations["GET:/orders"].Responses["200"].Content["application/json"].Schema
+	if schema.Properties["total"] == nil {
+		t.Errorf("Properties = %v, expected the edited schemas.yaml", schema.Properties)
+	}
+	if want := []string{filepath.Join(dir, "openapi.yaml"), schemas}; !reflect.DeepEqual(doc.Files, want) {
+		t.Errorf("Files = %v, expected %v", doc.Files, want)
+	}
+}
+
 func TestParseBinding(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -457,6 +693,34 @@ func TestParseBinding(t *testing.T) {
 			wantPath:   "/users/{userId}/posts/{postId}",
 			wantErr:    false,
 		},
+		{
+			name: "parses catch-all binding",
+			// given
+			bindsTo:    "http.server.api:ALL:/proxy/*",
+			wantServer: "http.server.api",
+			wantMethod: "ALL",
+			wantPath:   "/proxy/*",
+			wantErr:    false,
+		},
+		{
+			name: "parses root wildcard",
+			// given
+			bindsTo:    "http.server.api:OPTIONS:/*",
+			wantServer: "http.server.api",
+			wantMethod: "OPTIONS",
+			wantPath:   "/*",
+			wantErr:    false,
+		},
+		{
+			name:    "fails on wildcard inside the path",
+			bindsTo: "http.server.api:GET:/files/*/raw",
+			wantErr: true,
+		},
+		{
+			name:    "fails on wildcard inside a segment",
+			bindsTo: "http.server.api:GET:/files*",
+			wantErr: true,
+		},
 		{
 			name:    "fails on empty binding",
 			bindsTo: "",
@@ -591,3 +855,164 @@ func TestOperation_OperationKey(t *testing.T) {
 		t.Errorf("OperationKey() = %q, want %q", key, "POST:/users")
 	}
 }
+
+func TestOperation_SuccessResponseSchema(t *testing.T) {
+	// given
+	created := &Schema{Ref: "#/components/schemas/User"}
+	op := &Operation{
+		Responses: map[string]*Response{
+			"400": {Content: map[string]*MediaType{"application/json": {Schema: &Schema{Type: "object"}}}},
+			"204": {Description: "No Content"},
+			"201": {Content: map[string]*MediaType{"application/json": {Schema: created}}},
+		},
+	}
+
+	// when
+	schema := op.SuccessResponseSchema()
+
+	// then
+	if schema != created {
+		t.Errorf("SuccessResponseSchema() = %+v, want the 201 schema", schema)
+	}
+	if got := (&Operation{}).SuccessResponseSchema(); got != nil {
+		t.Errorf("SuccessResponseSchema() without responses = %+v, want nil", got)
+	}
+}
+
+func TestDocument_ResolveSchema(t *testing.T) {
+	// given
+	doc := &Document{
+		Schemas: map[string]*Schema{
+			"User":  {Type: "object"},
+			"Alias": {Ref: "#/components/schemas/User"},
+			"Loop":  {Ref: "#/components/schemas/Loop"},
+		},
+	}
+
+	// when / then
+	if got := doc.ResolveSchema(&Schema{Ref: "#/components/schemas/Alias"}); got != doc.Schemas["User"] {
+		t.Errorf("ResolveSchema(Alias) = %+v, want User", got)
+	}
+	if got := doc.ResolveSchema(&Schema{Ref: "#/components/schemas/Missing"}); got != nil {
+		t.Errorf("ResolveSchema(Missing) = %+v, want nil", got)
+	}
+	if got := doc.ResolveSchema(&Schema{Ref: "#/components/schemas/Loop"}); got != nil {
+		t.Errorf("ResolveSchema(Loop) = %+v, want nil", got)
+	}
+	if names := doc.SchemaNames(); strings.Join(names, ",") != "Alias,Loop,User" {
+		t.Errorf("SchemaNames() = %v, want sorted names", names)
+	}
+}
+
+func TestParser_ParseBytes_Composition(t *testing.T) {
+	// given
+	data := []byte(`
+openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+    Cat:
+      allOf:
+        - $ref: '#/components/schemas/Named'
+        - type: object
+          properties:
+            lives: {type: integer}
+      additionalProperties: false
+    Dog:
+      anyOf:
+        - type: string
+        - type: integer
+    Named:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+    Labels:
+      type: object
+      additionalProperties: {type: string}
+`)
+
+	// when
+	doc, err := NewParser("").ParseBytes(data)
+
+	// then
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+	if pet := doc.Schemas["Pet"]; len(pet.OneOf) != 2 || pet.OneOf[0].RefName() != "Cat" {
+		t.Errorf("Pet.OneOf = %+v, want Cat and Dog", pet.OneOf)
+	}
+	cat := doc.Schemas["Cat"]
+	if len(cat.AllOf) != 2 || !cat.NoAdditionalProperties {
+		t.Errorf("Cat = %+v, want two allOf parts and no additional properties", cat)
+	}
+	if dog := doc.Schemas["Dog"]; len(dog.AnyOf) != 2 || dog.AnyOf[1].Type != "integer" {
+		t.Errorf("Dog.AnyOf = %+v, want string and integer", dog.AnyOf)
+	}
+	if labels := doc.Schemas["Labels"]; labels.AdditionalProperties == nil || labels.AdditionalProperties.Type != "string" {
+		t.Errorf("Labels.AdditionalProperties = %+v, want string", labels.AdditionalProperties)
+	}
+
+	merged := doc.MergeAllOf(cat)
+	if merged == nil || len(merged.Properties) != 2 || strings.Join(merged.Required, ",") != "name" || !merged.NoAdditionalProperties {
+		t.Errorf("MergeAllOf(Cat) = %+v, want name and lives, name required", merged)
+	}
+	if got := doc.MergeAllOf(&Schema{AllOf: []*Schema{{Ref: "#/components/schemas/Dog"}}}); got != nil {
+		t.Errorf("MergeAllOf(Dog) = %+v, want nil for a part that is not an object", got)
+	}
+}
+
+func TestParser_ParseBytes_Bounds(t *testing.T) {
+	// given
+	data := []byte(`
+openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Product:
+      type: object
+      example: {name: Lamp}
+      properties:
+        price: {type: number, minimum: 0, exclusiveMinimum: true, maximum: 1000}
+        name: {type: string, minLength: 3, maxLength: 40}
+        tags:
+          type: array
+          items: {type: string}
+          minItems: 2
+          maxItems: 5
+`)
+
+	// when
+	doc, err := NewParser("").ParseBytes(data)
+
+	// then
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+	product := doc.Schemas["Product"]
+	if example, ok := product.Example.(map[string]any); !ok || example["name"] != "Lamp" {
+		t.Errorf("Product.Example = %#v, want {name: Lamp}", product.Example)
+	}
+	price := product.Properties["price"]
+	if price.Minimum == nil || *price.Minimum != 0 || !price.ExclusiveMinimum || price.Maximum == nil || *price.Maximum != 1000 || price.ExclusiveMaximum {
+		t.Errorf("price = %+v, want (0, 1000]", price)
+	}
+	name := product.Properties["name"]
+	if name.MinLength != 3 || name.MaxLength == nil || *name.MaxLength != 40 {
+		t.Errorf("name = %+v, want a length of 3 to 40", name)
+	}
+	tags := product.Properties["tags"]
+	if tags.MinItems != 2 || tags.MaxItems == nil || *tags.MaxItems != 5 {
+		t.Errorf("tags = %+v, want 2 to 5 items", tags)
+	}
+}
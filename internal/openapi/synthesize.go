@@ -0,0 +1,170 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"bytes"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Route is an operation a synthesized document declares.
+type Route struct {
+	OperationID string
+	Method      string
+	Path        string
+	Summary     string
+}
+
+// Synthesize builds a minimal document for a server that has no OpenAPI
+// file. Each route becomes an operation with its path parameters, typed as
+// strings, and untyped JSON bodies; there are no component schemas.
+func Synthesize(title string, routes []Route) *Document {
+	doc := &Document{
+		Title:       title,
+		Version:     "0.0.0",
+		Operations:  make(map[string]*Operation),
+		Schemas:     make(map[string]*Schema),
+		Synthesized: true,
+	}
+	for _, route := range routes {
+		op := &Operation{
+			OperationID: route.OperationID,
+			Method:      route.Method,
+			Path:        route.Path,
+			Summary:     route.Summary,
+			Tags:        []string{title},
+			Parameters:  []Parameter{},
+			Responses:   make(map[string]*Response),
+		}
+		if route.Method == "POST" || route.Method == "PUT" || route.Method == "PATCH" {
+			op.RequestBody = &RequestBody{Required: true, Content: anyJSON()}
+		}
+		status := successStatus(route.Method)
+		op.Responses[status] = &Response{Description: "Success"}
+		if status != "204" {
+			op.Responses[status].Content = anyJSON()
+		}
+		for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:     match[1],
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+		doc.Operations[op.OperationKey()] = op
+	}
+	return doc
+}
+
+func anyJSON() map[string]*MediaType {
+	return map[string]*MediaType{"application/json": {Schema: &Schema{}}}
+}
+
+func successStatus(method string) string {
+	switch method {
+	case "POST":
+		return "201"
+	case "DELETE":
+		return "204"
+	}
+	return "200"
+}
+
+// Encode renders the document as OpenAPI 3.0 YAML. It covers what
+// Synthesize produces: operations, parameters and response descriptions.
+func (d *Document) Encode() ([]byte, error) {
+	type parameter struct {
+		Name     string         `yaml:"name"`
+		In       string         `yaml:"in"`
+		Required bool           `yaml:"required"`
+		Schema   map[string]any `yaml:"schema"`
+	}
+	type mediaType struct {
+		Schema map[string]any `yaml:"schema"`
+	}
+	type requestBody struct {
+		Required bool                 `yaml:"required"`
+		Content  map[string]mediaType `yaml:"content"`
+	}
+	type response struct {
+		Description string               `yaml:"description"`
+		Content     map[string]mediaType `yaml:"content,omitempty"`
+	}
+	type operation struct {
+		OperationID string              `yaml:"operationId,omitempty"`
+		Summary     string              `yaml:"summary,omitempty"`
+		Tags        []string            `yaml:"tags,omitempty"`
+		Parameters  []parameter         `yaml:"parameters,omitempty"`
+		RequestBody *requestBody        `yaml:"requestBody,omitempty"`
+		Responses   map[string]response `yaml:"responses"`
+	}
+	content := func(media map[string]*MediaType) map[string]mediaType {
+		if len(media) == 0 {
+			return nil
+		}
+		out := make(map[string]mediaType, len(media))
+		for name, m := range media {
+			out[name] = mediaType{Schema: schemaType(m.Schema)}
+		}
+		return out
+	}
+	type info struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	}
+	type document struct {
+		OpenAPI string                          `yaml:"openapi"`
+		Info    info                            `yaml:"info"`
+		Paths   map[string]map[string]operation `yaml:"paths"`
+	}
+
+	out := document{
+		OpenAPI: "3.0.3",
+		Info:    info{Title: d.Title, Version: d.Version},
+		Paths:   make(map[string]map[string]operation),
+	}
+	for _, op := range d.Operations {
+		o := operation{
+			OperationID: op.OperationID,
+			Summary:     op.Summary,
+			Tags:        op.Tags,
+			Responses:   make(map[string]response),
+		}
+		for _, p := range op.Parameters {
+			o.Parameters = append(o.Parameters, parameter{Name: p.Name, In: p.In, Required: p.Required, Schema: schemaType(p.Schema)})
+		}
+		if op.RequestBody != nil {
+			o.RequestBody = &requestBody{Required: op.RequestBody.Required, Content: content(op.RequestBody.Content)}
+		}
+		for status, r := range op.Responses {
+			o.Responses[status] = response{Description: r.Description, Content: content(r.Content)}
+		}
+		if out.Paths[op.Path] == nil {
+			out.Paths[op.Path] = make(map[string]operation)
+		}
+		out.Paths[op.Path][strings.ToLower(op.Method)] = o
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(out); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// schemaType encodes the type of s, or an empty schema accepting any value.
+func schemaType(s *Schema) map[string]any {
+	if s == nil || s.Type == "" {
+		return map[string]any{}
+	}
+	return map[string]any{"type": s.Type}
+}
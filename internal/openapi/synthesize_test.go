@@ -0,0 +1,98 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSynthesize(t *testing.T) {
+	// given
+	routes := []Route{
+		{OperationID: "createUser", Method: "POST", Path: "/users", Summary: "Create a user"},
+		{OperationID: "deleteUser", Method: "DELETE", Path: "/orgs/{org}/users/{id}"},
+	}
+
+	// when
+	doc := Synthesize("http.server.api", routes)
+
+	// then
+	if !doc.Synthesized {
+		t.Error("Synthesized = false, expected true")
+	}
+	if doc.Title != "http.server.api" {
+		t.Errorf("Title = %q, expected %q", doc.Title, "http.server.api")
+	}
+	if len(doc.Schemas) != 0 {
+		t.Errorf("Schemas = %v, expected none", doc.Schemas)
+	}
+
+	create := doc.Operations["POST:/users"]
+	if create == nil {
+		t.Fatal("missing POST:/users")
+	}
+	if create.OperationID != "createUser" || create.Summary != "Create a user" {
+		t.Errorf("POST:/users = %+v", create)
+	}
+	if create.RequestBody == nil || create.RequestBody.Content["application/json"] == nil {
+		t.Error("POST:/users should accept a JSON body")
+	}
+	if create.Responses["201"] == nil || create.Responses["201"].Content["application/json"] == nil {
+		t.Error("POST:/users should return JSON with 201")
+	}
+
+	del := doc.Operations["DELETE:/orgs/{org}/users/{id}"]
+	if del == nil {
+		t.Fatal("missing DELETE:/orgs/{org}/users/{id}")
+	}
+	if del.RequestBody != nil {
+		t.Error("DELETE should not accept a body")
+	}
+	if r := del.Responses["204"]; r == nil || len(r.Content) != 0 {
+		t.Errorf("DELETE responses = %v, expected an empty 204", del.Responses)
+	}
+	var params []string
+	for _, p := range del.Parameters {
+		if p.In != "path" || !p.Required || p.Schema.Type != "string" {
+			t.Errorf("parameter %+v should be a required string path parameter", p)
+		}
+		params = append(params, p.Name)
+	}
+	if strings.Join(params, ",") != "org,id" {
+		t.Errorf("parameters = %v, expected [org id]", params)
+	}
+}
+
+func TestDocument_Encode(t *testing.T) {
+	// given
+	doc := Synthesize("http.server.api", []Route{
+		{OperationID: "getUser", Method: "GET", Path: "/users/{id}", Summary: "Get a user"},
+	})
+
+	// when
+	data, err := doc.Encode()
+
+	// then
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	parsed, err := NewParser("").ParseBytes(data)
+	if err != nil {
+		t.Fatalf("encoded document does not parse: %v\n%s", err, data)
+	}
+	op := parsed.Operations["GET:/users/{id}"]
+	if op == nil {
+		t.Fatalf("encoded document lacks GET:/users/{id}\n%s", data)
+	}
+	if op.OperationID != "getUser" || op.Summary != "Get a user" {
+		t.Errorf("operation = %+v", op)
+	}
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "id" || op.Parameters[0].In != "path" {
+		t.Errorf("parameters = %+v, expected the id path parameter", op.Parameters)
+	}
+	if op.Responses["200"] == nil {
+		t.Errorf("responses = %v, expected 200", op.Responses)
+	}
+}
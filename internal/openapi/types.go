@@ -4,11 +4,101 @@
 // Package openapi provides OpenAPI specification parsing for code generation.
 package openapi
 
+import (
+	"sort"
+	"strings"
+)
+
 // Document represents a parsed OpenAPI document.
 type Document struct {
 	Title      string
 	Version    string
 	Operations map[string]*Operation // keyed by "METHOD:/path"
+	Schemas    map[string]*Schema    // keyed by components/schemas name
+	File       string                // Resolved source path (empty for ParseBytes)
+	Files      []string              // Files read to load it, File and those it $refs: absolute, or within the parser's fs.FS
+	Issues     []Issue               // Semantic problems found by Lint
+
+	// Synthesized is set on documents built from usecase bindings for a
+	// server without an OpenAPI file.
+	Synthesized bool
+}
+
+// SchemaNames returns the names of the component schemas in sorted order.
+func (d *Document) SchemaNames() []string {
+	names := make([]string, 0, len(d.Schemas))
+	for name := range d.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveSchema follows $refs to named component schemas and returns the
+// referenced schema. It returns nil if a reference does not resolve.
+func (d *Document) ResolveSchema(s *Schema) *Schema {
+	seen := make(map[string]bool)
+	for s.IsRef() {
+		name := s.RefName()
+		if seen[name] {
+			return nil
+		}
+		seen[name] = true
+		s = d.Schemas[name]
+	}
+	return s
+}
+
+// MergeAllOf returns the object schema that s describes with its allOf
+// schemas merged in: the properties and required names of s and of every
+// part, after resolving $refs. It returns s itself when it has no allOf, and
+// nil when a part is not an object or does not resolve.
+func (d *Document) MergeAllOf(s *Schema) *Schema {
+	return d.mergeAllOf(s, make(map[*Schema]bool))
+}
+
+func (d *Document) mergeAllOf(s *Schema, seen map[*Schema]bool) *Schema {
+	if s == nil || len(s.AllOf) == 0 {
+		return s
+	}
+	if seen[s] {
+		return nil
+	}
+	seen[s] = true
+	defer delete(seen, s)
+
+	merged := &Schema{
+		Type:                   "object",
+		Description:            s.Description,
+		Nullable:               s.Nullable,
+		Properties:             make(map[string]*Schema),
+		AdditionalProperties:   s.AdditionalProperties,
+		NoAdditionalProperties: s.NoAdditionalProperties,
+	}
+	required := make(map[string]bool)
+	add := func(part *Schema) {
+		for name, prop := range part.Properties {
+			if _, ok := merged.Properties[name]; !ok {
+				merged.Properties[name] = prop
+			}
+		}
+		for _, name := range part.Required {
+			if !required[name] {
+				required[name] = true
+				merged.Required = append(merged.Required, name)
+			}
+		}
+	}
+
+	add(s)
+	for _, part := range s.AllOf {
+		part = d.mergeAllOf(d.ResolveSchema(part), seen)
+		if part == nil || (part.Type != "object" && part.Type != "") || len(part.OneOf) > 0 || len(part.AnyOf) > 0 {
+			return nil
+		}
+		add(part)
+	}
+	return merged
 }
 
 // Operation represents an OpenAPI operation (endpoint).
@@ -29,6 +119,37 @@ func (o *Operation) OperationKey() string {
 	return o.Method + ":" + o.Path
 }
 
+// SuccessResponseSchema returns the JSON schema of the lowest 2xx response, if any.
+func (o *Operation) SuccessResponseSchema() *Schema {
+	statuses := make([]string, 0, len(o.Responses))
+	for status := range o.Responses {
+		if strings.HasPrefix(status, "2") {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+
+	for _, status := range statuses {
+		resp := o.Responses[status]
+		if resp == nil {
+			continue
+		}
+		if media, ok := resp.Content["application/json"]; ok && media.Schema != nil {
+			return media.Schema
+		}
+	}
+	return nil
+}
+
+func (o *Operation) hasParameter(name, in string) bool {
+	for _, param := range o.Parameters {
+		if param.Name == name && param.In == in {
+			return true
+		}
+	}
+	return false
+}
+
 // Parameter represents an OpenAPI parameter (path, query, header, cookie).
 type Parameter struct {
 	Name        string
@@ -53,7 +174,8 @@ type Response struct {
 
 // MediaType represents a media type with its schema.
 type MediaType struct {
-	Schema *Schema
+	Schema  *Schema
+	Example any // Example payload, if the document gives one
 }
 
 // Schema represents a simplified JSON Schema for type generation.
@@ -67,6 +189,29 @@ type Schema struct {
 	Enum        []interface{}      // enum values
 	Description string
 	Nullable    bool
+	Example     any // Example value of the schema, if the document gives one
+
+	// Bounds of a number; the exclusive flags leave the bound itself out
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum bool
+	ExclusiveMaximum bool
+
+	// Bounds of the length of a string and of an array; nil when unbounded
+	MinLength uint64
+	MaxLength *uint64
+	MinItems  uint64
+	MaxItems  *uint64
+
+	AllOf []*Schema // A value must match every one of these
+	OneOf []*Schema // A value must match exactly one of these
+	AnyOf []*Schema // A value must match at least one of these
+
+	// AdditionalProperties is the schema of the properties of an object
+	// that Properties does not list; NoAdditionalProperties is set when
+	// the schema forbids them. Otherwise any are allowed.
+	AdditionalProperties   *Schema
+	NoAdditionalProperties bool
 }
 
 // IsRef returns true if this schema is a $ref reference.
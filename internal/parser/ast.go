@@ -4,6 +4,12 @@
 // Package parser provides YAML parsing with position tracking and AST definitions.
 package parser
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
 // Position tracks the location of a node in the source file.
 type Position struct {
 	File   string // Source file path
@@ -22,15 +28,53 @@ type Spec struct {
 	Name        string      `yaml:"name" json:"name"`
 	Description string      `yaml:"description,omitempty" json:"description,omitempty"`
 	Components  []Component `yaml:"components" json:"components"`
+	Docs        *Docs       `yaml:"docs,omitempty" json:"docs,omitempty"`
+	Env         *Env        `yaml:"env,omitempty" json:"env,omitempty"`
+	Testing     *Testing    `yaml:"testing,omitempty" json:"testing,omitempty"`
+	Node        string      `yaml:"node,omitempty" json:"node,omitempty"` // Node.js major version the generated service targets, "20" or "22"
+
+	// Vars are values string fields can use in ${...} expressions, e.g.
+	// port: ${base_port + 1}. Expressions are evaluated while parsing.
+	Vars map[string]any `yaml:"vars,omitempty" json:"vars,omitempty"`
 
 	position Position
 }
 
+// Docs configures the documentation generated alongside the code.
+type Docs struct {
+	ADR bool `yaml:"adr" json:"adr"` // Write an ADR stub when components change
+}
+
+// Env configures the environment variables the generated code reads.
+type Env struct {
+	Namespaced bool   `yaml:"namespaced,omitempty" json:"namespaced,omitempty"` // Prefix them with the project name, e.g. ORDERS_DATABASE_URL
+	Prefix     string `yaml:"prefix,omitempty" json:"prefix,omitempty"`         // Prefix to use instead of the project name
+}
+
+// Testing configures the generated tests.
+type Testing struct {
+	HTTPFixtures string `yaml:"http_fixtures,omitempty" json:"http_fixtures,omitempty"` // "record" or "replay" calls to external APIs in E2E tests
+}
+
 // Pos returns the position of the Spec in the source file.
 func (s *Spec) Pos() Position {
 	return s.position
 }
 
+// SemanticHash returns the hex SHA-256 of what the spec declares, ignoring
+// how it is written: formatting, comments, key order and vars, whose
+// expressions are already evaluated into the fields that use them.
+func (s *Spec) SemanticHash() (string, error) {
+	semantic := *s
+	semantic.Vars = nil
+	data, err := json.Marshal(&semantic)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 // Component represents a single component in the specification.
 // ID follows the pattern: type.subtype.name (e.g., "http.server.api", "middleware.authn")
 type Component struct {
@@ -38,9 +82,41 @@ type Component struct {
 	Kind string         `yaml:"kind" json:"kind"`
 	Spec map[string]any `yaml:"spec" json:"spec"`
 
+	// Labels are freeform tags such as "team:billing", used to select
+	// components, e.g. with compile --only.
+	Labels []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+
+	// Description, Owner and Links document the component. Generated files
+	// and docs repeat them.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Owner       string `yaml:"owner,omitempty" json:"owner,omitempty"` // Team or person responsible, e.g. "team-billing"
+	Links       []Link `yaml:"links,omitempty" json:"links,omitempty"`
+
+	// Deprecated marks a component that is being phased out; Replacement
+	// optionally names the component to use instead.
+	Deprecated  bool   `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
+	Replacement string `yaml:"replacement,omitempty" json:"replacement,omitempty"`
+
+	// Resources size the deployment of a server, gateway or database.
+	Resources *Resources `yaml:"resources,omitempty" json:"resources,omitempty"`
+
 	position Position
 }
 
+// Resources are the CPU and memory an instance of a component may use and
+// how many instances run, written as Kubernetes quantities.
+type Resources struct {
+	CPU      string `yaml:"cpu,omitempty" json:"cpu,omitempty"`       // Cores, e.g. "500m" or "2"
+	Memory   string `yaml:"memory,omitempty" json:"memory,omitempty"` // e.g. "512Mi" or "1Gi"
+	Replicas int    `yaml:"replicas,omitempty" json:"replicas,omitempty"`
+}
+
+// Link points to a resource about a component, such as a runbook.
+type Link struct {
+	Title string `yaml:"title,omitempty" json:"title,omitempty"`
+	URL   string `yaml:"url" json:"url"`
+}
+
 // Pos returns the position of the Component in the source file.
 func (c *Component) Pos() Position {
 	return c.position
@@ -4,6 +4,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -102,3 +103,90 @@ func TestComponent_Fields(t *testing.T) {
 		t.Errorf("Component.Spec[port] = %v, expected %v", comp.Spec["port"], 3000)
 	}
 }
+
+func TestSpec_SemanticHash(t *testing.T) {
+	// given
+	base := `
+version: "0.0.1"
+name: test-api
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      port: 3000
+      framework: hono
+`
+	tests := []struct {
+		name     string
+		yaml     string
+		expected bool // Whether the hash matches that of base
+	}{
+		{"same spec", base, true},
+		{"comments and key order", `
+# The API
+name: test-api
+version: "0.0.1"
+components:
+  - id: http.server.api
+    kind: http.server
+    spec: {framework: hono, port: 3000} # inline
+`, true},
+		{"vars evaluated to the same value", `
+version: "0.0.1"
+name: test-api
+vars:
+  base_port: 2999
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      port: ${base_port + 1}
+      framework: hono
+`, true},
+		{"changed port", `
+version: "0.0.1"
+name: test-api
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      port: 3001
+      framework: hono
+`, false},
+	}
+
+	baseSpec, err := NewParser("spec.yaml").ParseBytes([]byte(base))
+	if err != nil {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+	baseHash, err := baseSpec.SemanticHash()
+	if err != nil {
+		t.Fatalf("SemanticHash() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := NewParser("spec.yaml").ParseBytes([]byte(tt.yaml))
+			if err != nil {
+				t.Fatalf("ParseBytes() error = %v", err)
+			}
+
+			// when
+			hash, err := spec.SemanticHash()
+
+			// then
+			if err != nil {
+				t.Fatalf("SemanticHash() error = %v", err)
+			}
+			if len(hash) != 64 {
+				t.Errorf("len(SemanticHash()) = %d, expected 64", len(hash))
+			}
+			if (hash == baseHash) != tt.expected {
+				t.Errorf("SemanticHash() == base hash is %v, expected %v", hash == baseHash, tt.expected)
+			}
+			if spec.Vars == nil && strings.Contains(tt.yaml, "vars:") {
+				t.Errorf("SemanticHash() cleared the spec's Vars")
+			}
+		})
+	}
+}
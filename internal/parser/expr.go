@@ -0,0 +1,385 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// evaluateExpressions replaces ${...} expressions in string values with
+// their result. Expressions use the spec's top-level vars, integer and
+// string literals, + - * / % with parentheses, and template functions via
+// "|", e.g. ${base_port + 1} or ${region | upper}. A value that is a single
+// expression takes the type of its result, so "port: ${base_port + 1}"
+// decodes as an integer; otherwise results are interpolated. "$${" escapes
+// a literal "${".
+//
+// Evaluation happens after template expansion, on the YAML tree, so
+// positions still point at the expression.
+func evaluateExpressions(root *yaml.Node) error {
+	vars := make(map[string]any)
+	if node := mappingValue(root, "vars"); node != nil {
+		if node.Kind != yaml.MappingNode {
+			return fmt.Errorf("line %d: vars must be a mapping", node.Line)
+		}
+		// Vars can use the vars declared above them.
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if !templateVarPattern.MatchString(key.Value) {
+				return fmt.Errorf("line %d: var name %q must be a lowercase identifier", key.Line, key.Value)
+			}
+			if err := evaluateNode(value, vars); err != nil {
+				return err
+			}
+			v, err := varValue(value)
+			if err != nil {
+				return fmt.Errorf("line %d: var %q %w", value.Line, key.Value, err)
+			}
+			vars[key.Value] = v
+		}
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "vars" {
+			continue
+		}
+		if err := evaluateNode(root.Content[i+1], vars); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// varValue returns the int64 or string a var node holds.
+func varValue(node *yaml.Node) (any, error) {
+	if node.Kind == yaml.ScalarNode {
+		switch node.Tag {
+		case "!!int":
+			n, err := strconv.ParseInt(node.Value, 0, 64)
+			if err == nil {
+				return n, nil
+			}
+		case "!!str":
+			return node.Value, nil
+		}
+	}
+	return nil, fmt.Errorf("must be an integer or a string")
+}
+
+// evaluateNode evaluates the expressions in the values below node, in place.
+func evaluateNode(node *yaml.Node, vars map[string]any) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag != "!!str" || !strings.Contains(node.Value, "${") {
+			return nil
+		}
+		value, whole, err := interpolate(node.Value, vars)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		if n, ok := value.(int64); ok && whole {
+			node.Tag, node.Value, node.Style = "!!int", strconv.FormatInt(n, 10), 0
+			return nil
+		}
+		node.Value = fmt.Sprint(value)
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			if err := evaluateNode(node.Content[i], vars); err != nil {
+				return err
+			}
+		}
+	default:
+		for _, child := range node.Content {
+			if err := evaluateNode(child, vars); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// interpolate evaluates the expressions in s. When s is exactly one
+// expression it returns the expression's value and whole is true.
+func interpolate(s string, vars map[string]any) (value any, whole bool, err error) {
+	var sb strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			sb.WriteString(s)
+			return sb.String(), false, nil
+		}
+		if start > 0 && s[start-1] == '$' {
+			sb.WriteString(s[:start-1] + "${")
+			s = s[start+2:]
+			continue
+		}
+		end := expressionEnd(s, start+2)
+		if end < 0 {
+			return nil, false, fmt.Errorf("%s: missing closing }", s[start:])
+		}
+		src := s[start+2 : end]
+		v, err := evaluate(src, vars)
+		if err != nil {
+			return nil, false, fmt.Errorf("${%s}: %w", src, err)
+		}
+		if start == 0 && end == len(s)-1 && sb.Len() == 0 {
+			return v, true, nil
+		}
+		sb.WriteString(s[:start])
+		sb.WriteString(fmt.Sprint(v))
+		s = s[end+1:]
+	}
+}
+
+// expressionEnd returns the index of the } closing an expression starting
+// at from, skipping string literals, or -1.
+func expressionEnd(s string, from int) int {
+	for i := from; i < len(s); i++ {
+		switch s[i] {
+		case '}':
+			return i
+		case '\'', '"':
+			end := strings.IndexByte(s[i+1:], s[i])
+			if end < 0 {
+				return -1
+			}
+			i += end + 1
+		}
+	}
+	return -1
+}
+
+// evaluate evaluates a single expression.
+func evaluate(src string, vars map[string]any) (any, error) {
+	p := &exprParser{src: src, vars: vars}
+	p.skipSpace()
+	if p.done() {
+		return nil, fmt.Errorf("empty expression")
+	}
+	v, err := p.pipeline()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q", p.src[p.pos:])
+	}
+	return v, nil
+}
+
+// exprParser is a recursive descent parser for:
+//
+//	pipeline = sum { "|" name }
+//	sum      = term { ("+" | "-") term }
+//	term     = unary { ("*" | "/" | "%") unary }
+//	unary    = "-" unary | "(" pipeline ")" | integer | string | name
+//
+// Values are int64 or string; + joins strings, the other operators take
+// integers only.
+type exprParser struct {
+	src  string
+	pos  int
+	vars map[string]any
+}
+
+func (p *exprParser) pipeline() (any, error) {
+	v, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept('|') {
+		name := p.name()
+		fn, ok := templateFuncs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown template function %q", name)
+		}
+		v = fn(fmt.Sprint(v))
+	}
+	return v, nil
+}
+
+func (p *exprParser) sum() (any, error) {
+	v, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() && (p.peek() == '+' || p.peek() == '-') {
+		op := p.next()
+		rhs, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		if op == '+' {
+			if a, ok := v.(int64); ok {
+				if b, ok := rhs.(int64); ok {
+					v = a + b
+					continue
+				}
+			}
+			v = fmt.Sprint(v) + fmt.Sprint(rhs)
+			continue
+		}
+		if v, err = arithmetic(op, v, rhs); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func (p *exprParser) term() (any, error) {
+	v, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() && (p.peek() == '*' || p.peek() == '/' || p.peek() == '%') {
+		op := p.next()
+		rhs, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if v, err = arithmetic(op, v, rhs); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func (p *exprParser) unary() (any, error) {
+	if p.done() {
+		return nil, fmt.Errorf("expression ends early")
+	}
+	switch c := p.peek(); {
+	case c == '-':
+		p.next()
+		v, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return arithmetic('-', int64(0), v)
+	case c == '(':
+		p.next()
+		v, err := p.pipeline()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(')') {
+			return nil, fmt.Errorf("missing )")
+		}
+		return v, nil
+	case c == '\'' || c == '"':
+		end := strings.IndexByte(p.src[p.pos+1:], c)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		s := p.src[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		p.skipSpace()
+		return s, nil
+	case c >= '0' && c <= '9':
+		start := p.pos
+		for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+			p.pos++
+		}
+		n, err := strconv.ParseInt(p.src[start:p.pos], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %s", p.src[start:p.pos])
+		}
+		p.skipSpace()
+		return n, nil
+	}
+
+	name := p.name()
+	if name == "" {
+		return nil, fmt.Errorf("unexpected %q", p.src[p.pos:])
+	}
+	v, ok := p.vars[name]
+	if !ok {
+		return nil, unknownVar(name, p.vars)
+	}
+	return v, nil
+}
+
+// name reads an identifier, or returns "" when there is none.
+func (p *exprParser) name() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || p.pos > start && c >= '0' && c <= '9') {
+			break
+		}
+		p.pos++
+	}
+	name := p.src[start:p.pos]
+	p.skipSpace()
+	return name
+}
+
+func (p *exprParser) accept(c byte) bool {
+	if p.done() || p.peek() != c {
+		return false
+	}
+	p.next()
+	return true
+}
+
+func (p *exprParser) next() byte {
+	c := p.src[p.pos]
+	p.pos++
+	p.skipSpace()
+	return c
+}
+
+func (p *exprParser) peek() byte { return p.src[p.pos] }
+
+func (p *exprParser) done() bool { return p.pos >= len(p.src) }
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func arithmetic(op byte, lhs, rhs any) (any, error) {
+	a, aOK := lhs.(int64)
+	b, bOK := rhs.(int64)
+	if !aOK || !bOK {
+		return nil, fmt.Errorf("%c needs integers, got %s and %s", op, describe(lhs), describe(rhs))
+	}
+	switch op {
+	case '-':
+		return a - b, nil
+	case '*':
+		return a * b, nil
+	}
+	if b == 0 {
+		return nil, fmt.Errorf("division by zero")
+	}
+	if op == '/' {
+		return a / b, nil
+	}
+	return a % b, nil
+}
+
+func describe(v any) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
+
+func unknownVar(name string, vars map[string]any) error {
+	if len(vars) == 0 {
+		return fmt.Errorf("unknown variable %q (the spec declares no vars)", name)
+	}
+	names := make([]string, 0, len(vars))
+	for n := range vars {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unknown variable %q (vars declares %s)", name, strings.Join(names, ", "))
+}
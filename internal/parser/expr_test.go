@@ -0,0 +1,124 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParser_ParseBytes_Vars(t *testing.T) {
+	yaml := `
+version: "0.0.1"
+name: test-api
+vars:
+  base_port: 3000
+  admin_port: ${base_port + 1}
+  region: eu
+components:
+  - id: http.server.public
+    kind: http.server
+    spec:
+      framework: hono
+      port: ${base_port}
+  - id: http.server.admin
+    kind: http.server
+    spec:
+      framework: hono
+      port: ${(admin_port - base_port) * 4000 % 5000}
+  - foreach: [orders, refunds]
+    id: usecase.list-${item}
+    kind: usecase
+    spec:
+      binds_to: http.server.public:GET:/${region}/${item}
+      goal: List ${item} in ${region | upper} (${'port ' + base_port}, $${raw})
+`
+	spec, err := NewParser("test.yaml").ParseBytes([]byte(yaml))
+	if err != nil {
+		t.Fatalf("ParseBytes() unexpected error: %v", err)
+	}
+
+	if spec.Components[0].Spec["port"] != 3000 {
+		t.Errorf("public port = %#v, expected 3000", spec.Components[0].Spec["port"])
+	}
+	if spec.Components[1].Spec["port"] != 4000 {
+		t.Errorf("admin port = %#v, expected 4000", spec.Components[1].Spec["port"])
+	}
+	comp := spec.Components[3]
+	if comp.Spec["binds_to"] != "http.server.public:GET:/eu/refunds" {
+		t.Errorf("binds_to = %v, expected %q", comp.Spec["binds_to"], "http.server.public:GET:/eu/refunds")
+	}
+	if want := "List refunds in EU (port 3000, ${raw})"; comp.Spec["goal"] != want {
+		t.Errorf("goal = %v, expected %q", comp.Spec["goal"], want)
+	}
+	if spec.Vars["admin_port"] != 3001 {
+		t.Errorf("Vars[admin_port] = %#v, expected 3001", spec.Vars["admin_port"])
+	}
+}
+
+func TestParser_ParseBytes_VarsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "unknown variable",
+			yaml:    "vars:\n  base_port: 3000\nname: ${base_prot + 1}\n",
+			wantErr: `line 3: ${base_prot + 1}: unknown variable "base_prot" (vars declares base_port)`,
+		},
+		{
+			name:    "no vars",
+			yaml:    "name: ${port}\n",
+			wantErr: `unknown variable "port" (the spec declares no vars)`,
+		},
+		{
+			name:    "arithmetic on a string",
+			yaml:    "vars:\n  region: eu\nname: ${region * 2}\n",
+			wantErr: `${region * 2}: * needs integers, got "eu" and 2`,
+		},
+		{
+			name:    "division by zero",
+			yaml:    "name: ${1 / (2 - 2)}\n",
+			wantErr: "division by zero",
+		},
+		{
+			name:    "missing closing brace",
+			yaml:    "name: api-${1 + 2\n",
+			wantErr: "${1 + 2: missing closing }",
+		},
+		{
+			name:    "trailing tokens",
+			yaml:    "name: ${1 2}\n",
+			wantErr: `${1 2}: unexpected "2"`,
+		},
+		{
+			name:    "unknown function",
+			yaml:    "name: ${'a' | shout}\n",
+			wantErr: `unknown template function "shout"`,
+		},
+		{
+			name:    "var of another type",
+			yaml:    "vars:\n  debug: true\n",
+			wantErr: `var "debug" must be an integer or a string`,
+		},
+		{
+			name:    "var used before it is declared",
+			yaml:    "vars:\n  a: ${b}\n  b: 1\n",
+			wantErr: `unknown variable "b"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser("test.yaml").ParseBytes([]byte(tt.yaml))
+			if err == nil {
+				t.Fatal("ParseBytes() expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, expected to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
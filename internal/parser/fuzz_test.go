@@ -0,0 +1,35 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package parser
+
+import (
+	"os"
+	"testing"
+)
+
+// FuzzParser_ParseBytes checks that ParseBytes returns either a spec or an
+// error for any input, and never panics. Run with `make fuzz`.
+func FuzzParser_ParseBytes(f *testing.F) {
+	if data, err := os.ReadFile("../../examples/basic/spec.yaml"); err == nil {
+		f.Add(data)
+	}
+	f.Add([]byte(""))
+	f.Add([]byte("version: \"0.1.0\"\nname: test\ncomponents: []\n"))
+	f.Add([]byte("components:\n  - id: a\n    kind: http.server\n    spec: {port: 3000}\n"))
+	f.Add([]byte("components:\n  - foreach: [{name: a}, {name: b}]\n    id: usecase.${name}\n    kind: usecase\n    spec: {goal: ${name}}\n"))
+	f.Add([]byte("components:\n  - foreach: x\n"))
+	f.Add([]byte("components: {}\n"))
+	f.Add([]byte("- a\n- b\n"))
+	f.Add([]byte("a: &a [*a]\n"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		spec, err := NewParser("fuzz.yaml").ParseBytes(data)
+		if err == nil && spec == nil {
+			t.Fatal("ParseBytes() returned neither a spec nor an error")
+		}
+		if err != nil && spec != nil {
+			t.Fatalf("ParseBytes() returned a spec with error %v", err)
+		}
+	})
+}
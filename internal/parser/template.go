@@ -0,0 +1,218 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultTemplateVar is the loop variable name used when a template omits "as".
+const defaultTemplateVar = "item"
+
+// placeholderPattern matches ${name}, ${name.field} and ${name | func}.
+var placeholderPattern = regexp.MustCompile(`\$\{\s*([a-z][a-z0-9_]*)(?:\.([a-z][a-z0-9_]*))?\s*(?:\|\s*([a-z]+)\s*)?\}`)
+
+// templateVarPattern restricts loop variable names declared with "as".
+var templateVarPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// templateFuncs are the functions available in placeholders via "|".
+var templateFuncs = map[string]func(string) string{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"title": func(s string) string {
+		if s == "" {
+			return s
+		}
+		return strings.ToUpper(s[:1]) + s[1:]
+	},
+}
+
+// expandTemplates replaces component entries carrying a "foreach" key with one
+// component per item, substituting ${var} placeholders in every string value.
+// Items are either scalars (${var}) or flat mappings (${var.field}).
+//
+// Expansion happens on the YAML tree before decoding, so later stages only
+// ever see concrete components and positions still point at the template.
+func expandTemplates(root *yaml.Node) error {
+	components := mappingValue(root, "components")
+	if components == nil || components.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	expanded := make([]*yaml.Node, 0, len(components.Content))
+	for _, comp := range components.Content {
+		foreach := mappingValue(comp, "foreach")
+		if foreach == nil {
+			expanded = append(expanded, comp)
+			continue
+		}
+
+		nodes, err := expandTemplate(comp, foreach)
+		if err != nil {
+			return err
+		}
+		expanded = append(expanded, nodes...)
+	}
+	components.Content = expanded
+
+	return nil
+}
+
+func expandTemplate(comp, foreach *yaml.Node) ([]*yaml.Node, error) {
+	if foreach.Kind != yaml.SequenceNode || len(foreach.Content) == 0 {
+		return nil, fmt.Errorf("line %d: foreach must be a non-empty list", foreach.Line)
+	}
+
+	name := defaultTemplateVar
+	if as := mappingValue(comp, "as"); as != nil {
+		if as.Kind != yaml.ScalarNode || !templateVarPattern.MatchString(as.Value) {
+			return nil, fmt.Errorf("line %d: as must be a lowercase identifier", as.Line)
+		}
+		name = as.Value
+	}
+
+	id := mappingValue(comp, "id")
+	if id == nil || !referencesVar(id.Value, name) {
+		return nil, fmt.Errorf("line %d: templated component id must reference ${%s} to stay unique", comp.Line, name)
+	}
+
+	template := withoutKeys(comp, "foreach", "as")
+
+	nodes := make([]*yaml.Node, 0, len(foreach.Content))
+	for _, item := range foreach.Content {
+		vars, err := templateVars(item)
+		if err != nil {
+			return nil, err
+		}
+		node, err := substituteNode(template, name, vars)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+// templateVars flattens a foreach item into lookup values. The empty key holds
+// the scalar value; mapping items expose their fields by name.
+func templateVars(item *yaml.Node) (map[string]string, error) {
+	switch item.Kind {
+	case yaml.ScalarNode:
+		return map[string]string{"": item.Value}, nil
+	case yaml.MappingNode:
+		vars := make(map[string]string, len(item.Content)/2)
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			value := item.Content[i+1]
+			if value.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: foreach item field %q must be a scalar", value.Line, item.Content[i].Value)
+			}
+			vars[item.Content[i].Value] = value.Value
+		}
+		return vars, nil
+	default:
+		return nil, fmt.Errorf("line %d: foreach items must be scalars or mappings", item.Line)
+	}
+}
+
+// substituteNode deep-copies node, expanding placeholders for the loop variable.
+// Placeholders naming other variables are left untouched.
+func substituteNode(node *yaml.Node, name string, vars map[string]string) (*yaml.Node, error) {
+	out := *node
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" {
+		value, err := substitute(node.Value, node.Line, name, vars)
+		if err != nil {
+			return nil, err
+		}
+		out.Value = value
+		return &out, nil
+	}
+
+	if len(node.Content) > 0 {
+		out.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			copied, err := substituteNode(child, name, vars)
+			if err != nil {
+				return nil, err
+			}
+			out.Content[i] = copied
+		}
+	}
+	return &out, nil
+}
+
+func substitute(s string, line int, name string, vars map[string]string) (string, error) {
+	var err error
+	result := placeholderPattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := placeholderPattern.FindStringSubmatch(match)
+		if parts[1] != name || err != nil {
+			return match
+		}
+
+		value, ok := vars[parts[2]]
+		if !ok {
+			if parts[2] == "" {
+				err = fmt.Errorf("line %d: ${%s} needs a field; foreach items are mappings", line, name)
+			} else {
+				err = fmt.Errorf("line %d: foreach item has no field %q", line, parts[2])
+			}
+			return match
+		}
+
+		if parts[3] != "" {
+			fn, ok := templateFuncs[parts[3]]
+			if !ok {
+				err = fmt.Errorf("line %d: unknown template function %q", line, parts[3])
+				return match
+			}
+			value = fn(value)
+		}
+		return value
+	})
+	return result, err
+}
+
+func referencesVar(s, name string) bool {
+	for _, parts := range placeholderPattern.FindAllStringSubmatch(s, -1) {
+		if parts[1] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// withoutKeys returns a shallow copy of a mapping node without the given keys.
+func withoutKeys(node *yaml.Node, keys ...string) *yaml.Node {
+	drop := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		drop[k] = true
+	}
+
+	out := *node
+	out.Content = make([]*yaml.Node, 0, len(node.Content))
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if drop[node.Content[i].Value] {
+			continue
+		}
+		out.Content = append(out.Content, node.Content[i], node.Content[i+1])
+	}
+	return &out
+}
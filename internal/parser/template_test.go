@@ -0,0 +1,172 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParser_ParseBytes_Foreach(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		validate func(*testing.T, *Spec)
+	}{
+		{
+			name: "scalar items with default variable",
+			yaml: `
+version: "0.0.1"
+name: test-api
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 3000
+  - foreach: [contacts, companies]
+    id: usecase.list-${item}
+    kind: usecase
+    spec:
+      binds_to: http.server.api:GET:/${item}
+      goal: List ${item}
+      acceptance_criteria:
+        - ${item | title} are returned
+`,
+			validate: func(t *testing.T, spec *Spec) {
+				if len(spec.Components) != 3 {
+					t.Fatalf("len(Components) = %d, expected 3", len(spec.Components))
+				}
+				wantIDs := []string{"http.server.api", "usecase.list-contacts", "usecase.list-companies"}
+				for i, want := range wantIDs {
+					if spec.Components[i].ID != want {
+						t.Errorf("Components[%d].ID = %q, expected %q", i, spec.Components[i].ID, want)
+					}
+				}
+				comp := spec.Components[2]
+				if comp.Spec["binds_to"] != "http.server.api:GET:/companies" {
+					t.Errorf("binds_to = %v, expected %q", comp.Spec["binds_to"], "http.server.api:GET:/companies")
+				}
+				criteria, _ := comp.Spec["acceptance_criteria"].([]any)
+				if len(criteria) != 1 || criteria[0] != "Companies are returned" {
+					t.Errorf("acceptance_criteria = %v, expected [Companies are returned]", criteria)
+				}
+				if _, ok := comp.Spec["foreach"]; ok {
+					t.Error("foreach key leaked into spec")
+				}
+			},
+		},
+		{
+			name: "mapping items with named variable",
+			yaml: `
+version: "0.0.1"
+name: test-api
+components:
+  - foreach:
+      - { name: contact, plural: contacts }
+      - { name: deal, plural: deals }
+    as: resource
+    id: usecase.get-${resource.name}
+    kind: usecase
+    spec:
+      binds_to: http.server.api:GET:/${resource.plural}/{id}
+      goal: Get a ${resource.name} ($${other} stays as-is)
+`,
+			validate: func(t *testing.T, spec *Spec) {
+				if len(spec.Components) != 2 {
+					t.Fatalf("len(Components) = %d, expected 2", len(spec.Components))
+				}
+				comp := spec.Components[1]
+				if comp.ID != "usecase.get-deal" {
+					t.Errorf("ID = %q, expected %q", comp.ID, "usecase.get-deal")
+				}
+				if comp.Spec["binds_to"] != "http.server.api:GET:/deals/{id}" {
+					t.Errorf("binds_to = %v, expected %q", comp.Spec["binds_to"], "http.server.api:GET:/deals/{id}")
+				}
+				if comp.Spec["goal"] != "Get a deal (${other} stays as-is)" {
+					t.Errorf("goal = %v", comp.Spec["goal"])
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := NewParser("test.yaml").ParseBytes([]byte(tt.yaml))
+			if err != nil {
+				t.Fatalf("ParseBytes() unexpected error: %v", err)
+			}
+			tt.validate(t, spec)
+		})
+	}
+}
+
+func TestParser_ParseBytes_ForeachErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name: "empty foreach",
+			yaml: `
+components:
+  - foreach: []
+    id: usecase.list-${item}
+`,
+			wantErr: "foreach must be a non-empty list",
+		},
+		{
+			name: "id without variable",
+			yaml: `
+components:
+  - foreach: [a, b]
+    id: usecase.list
+`,
+			wantErr: "must reference ${item}",
+		},
+		{
+			name: "missing field",
+			yaml: `
+components:
+  - foreach: [{ name: a }]
+    id: usecase.get-${item.name}
+    spec:
+      goal: ${item.plural}
+`,
+			wantErr: `no field "plural"`,
+		},
+		{
+			name: "unknown function",
+			yaml: `
+components:
+  - foreach: [a]
+    id: usecase.get-${item | shout}
+`,
+			wantErr: `unknown template function "shout"`,
+		},
+		{
+			name: "invalid variable name",
+			yaml: `
+components:
+  - foreach: [a]
+    as: Resource
+    id: usecase.get-${item}
+`,
+			wantErr: "as must be a lowercase identifier",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser("test.yaml").ParseBytes([]byte(tt.yaml))
+			if err == nil {
+				t.Fatal("ParseBytes() expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseBytes() error = %q, expected to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
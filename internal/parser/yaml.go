@@ -60,11 +60,33 @@ func (p *Parser) parseSpec(node *yaml.Node) (*Spec, error) {
 		position: WithPosition(p.filename, root.Line, root.Column),
 	}
 
+	if err := expandTemplates(root); err != nil {
+		return nil, fmt.Errorf("failed to expand templates: %w", err)
+	}
+	if err := evaluateExpressions(root); err != nil {
+		return nil, fmt.Errorf("failed to evaluate expressions: %w", err)
+	}
+
 	// TODO: Implement full position-aware parsing
-	// For now, use simple unmarshal
+	// For now, use simple unmarshal and record where each component starts
 	if err := root.Decode(spec); err != nil {
 		return nil, fmt.Errorf("failed to decode spec: %w", err)
 	}
+	p.positionComponents(root, spec)
 
 	return spec, nil
 }
+
+// positionComponents records the position of each component's mapping node.
+func (p *Parser) positionComponents(root *yaml.Node, spec *Spec) {
+	for n := 0; n+1 < len(root.Content); n += 2 {
+		if root.Content[n].Value != "components" || root.Content[n+1].Kind != yaml.SequenceNode {
+			continue
+		}
+		for idx, item := range root.Content[n+1].Content {
+			if idx < len(spec.Components) {
+				spec.Components[idx].position = WithPosition(p.filename, item.Line, item.Column)
+			}
+		}
+	}
+}
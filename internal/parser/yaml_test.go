@@ -92,6 +92,29 @@ components:
 				}
 			},
 		},
+		{
+			name: "component resources",
+			yaml: `
+version: "0.0.1"
+name: test-api
+components:
+  - id: http.server.api
+    kind: http.server
+    resources:
+      cpu: 0.5
+      memory: 512Mi
+      replicas: 2
+    spec:
+      port: 3000
+`,
+			expectError: false,
+			validate: func(t *testing.T, spec *Spec) {
+				expected := Resources{CPU: "0.5", Memory: "512Mi", Replicas: 2}
+				if got := spec.Components[0].Resources; got == nil || *got != expected {
+					t.Errorf("Component.Resources = %+v, expected %+v", got, expected)
+				}
+			},
+		},
 		{
 			name:        "invalid yaml",
 			yaml:        `invalid: yaml: syntax`,
@@ -160,6 +183,32 @@ components: []
 	}
 }
 
+func TestParser_ParseBytes_ComponentPositions(t *testing.T) {
+	p := NewParser("spec.yaml")
+
+	yaml := `version: "0.0.1"
+name: test
+components:
+  - id: postgres.primary
+    kind: postgres
+    spec: {}
+  - id: http.server.api
+    kind: http.server
+    spec: {}
+`
+	spec, err := p.ParseBytes([]byte(yaml))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Position{{File: "spec.yaml", Line: 4, Column: 5}, {File: "spec.yaml", Line: 7, Column: 5}}
+	for n, comp := range spec.Components {
+		if got := comp.Pos(); got != expected[n] {
+			t.Errorf("Components[%d].Pos() = %+v, expected %+v", n, got, expected[n])
+		}
+	}
+}
+
 func TestParser_parseSpec_NotDocument(t *testing.T) {
 	p := NewParser("test.yaml")
 
@@ -0,0 +1,99 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"fmt"
+)
+
+// Default thresholds above which a compile warns that its output is unusually
+// large, which usually means a templated spec stamped out far more
+// components than intended.
+const (
+	DefaultWarnArtifacts = 2000
+	DefaultWarnBytes     = 50 << 20
+)
+
+// Budget bounds how much a compile generates.
+type Budget struct {
+	WarnArtifacts int   // Warn above this many files; 0 never warns
+	WarnBytes     int64 // Warn above this many bytes in total; 0 never warns
+	MaxArtifacts  int   // Fail above this many files; 0 is unlimited
+}
+
+// DefaultBudget warns above DefaultWarnArtifacts files or DefaultWarnBytes
+// and sets no limit.
+func DefaultBudget() Budget {
+	return Budget{WarnArtifacts: DefaultWarnArtifacts, WarnBytes: DefaultWarnBytes}
+}
+
+// budgetStage checks the size of the generated output against a Budget.
+type budgetStage struct {
+	budget Budget
+}
+
+// CheckBudget returns a stage that warns when the artifacts exceed the
+// budget's warning thresholds and fails when there are more than
+// MaxArtifacts. It runs before the write stage, so an oversized output
+// never reaches the disk.
+func CheckBudget(budget Budget) Stage { return &budgetStage{budget: budget} }
+
+func (s *budgetStage) Name() string { return StageBudget }
+
+func (s *budgetStage) Run(ctx *Context) error {
+	count := len(ctx.Artifacts)
+	var size int64
+	perOwner := make(map[string]int)
+	for _, artifact := range ctx.Artifacts {
+		size += int64(len(artifact.Content))
+		perOwner[artifact.Owner]++
+	}
+	components := 0
+	if ctx.IR != nil {
+		components = len(ctx.IR.Components)
+	}
+	summary := fmt.Sprintf("%d files (%s) from %d components, most from %s", count, formatBytes(size), components, largestOwner(perOwner))
+
+	if s.budget.MaxArtifacts > 0 && count > s.budget.MaxArtifacts {
+		return &StageError{
+			Stage:   StageBudget,
+			Message: fmt.Sprintf("generation exceeds the limit of %d files", s.budget.MaxArtifacts),
+			Errors:  []error{fmt.Errorf("compile would write %s; raise --max-artifacts if this is intended", summary)},
+		}
+	}
+	if (s.budget.WarnArtifacts > 0 && count > s.budget.WarnArtifacts) || (s.budget.WarnBytes > 0 && size > s.budget.WarnBytes) {
+		ctx.Warnings = append(ctx.Warnings, fmt.Errorf("unusually large output: %s; check the spec for runaway templates, or set --max-artifacts to cap it", summary))
+	}
+	return nil
+}
+
+// largestOwner returns the generator with the most artifacts and its count,
+// preferring the first name on ties so the message is deterministic.
+func largestOwner(perOwner map[string]int) string {
+	best, bestCount := "", -1
+	for owner, n := range perOwner {
+		if n > bestCount || (n == bestCount && owner < best) {
+			best, bestCount = owner, n
+		}
+	}
+	if best == "" {
+		best = "unowned stages"
+	}
+	return fmt.Sprintf("%s (%d)", best, bestCount)
+}
+
+// formatBytes renders a size in the largest binary unit that keeps it at
+// least 1, e.g. "52.4 MB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
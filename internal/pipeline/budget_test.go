@@ -0,0 +1,76 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+func budgetArtifacts(n, size int) []codegen.Artifact {
+	artifacts := make([]codegen.Artifact, n)
+	for i := range artifacts {
+		owner := "typescript-usecase"
+		if i == 0 {
+			owner = "typescript-project"
+		}
+		artifacts[i] = codegen.Artifact{Owner: owner, Path: "f", Content: make([]byte, size)}
+	}
+	return artifacts
+}
+
+func TestCheckBudget(t *testing.T) {
+	tests := []struct {
+		name      string
+		budget    Budget
+		artifacts []codegen.Artifact
+		wantWarn  string
+		wantErr   string
+	}{
+		{name: "within budget", budget: DefaultBudget(), artifacts: budgetArtifacts(10, 100)},
+		{name: "too many files", budget: Budget{WarnArtifacts: 5}, artifacts: budgetArtifacts(6, 1),
+			wantWarn: "unusually large output: 6 files (6 B) from 2 components, most from typescript-usecase (5)"},
+		{name: "too many bytes", budget: Budget{WarnBytes: 2 << 20}, artifacts: budgetArtifacts(3, 1<<20),
+			wantWarn: "unusually large output: 3 files (3.0 MB)"},
+		{name: "over the limit", budget: Budget{WarnArtifacts: 1, MaxArtifacts: 5}, artifacts: budgetArtifacts(6, 1),
+			wantErr: "generation exceeds the limit of 5 files"},
+		{name: "at the limit", budget: Budget{MaxArtifacts: 6}, artifacts: budgetArtifacts(6, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			ctx := &Context{
+				IR:        &ir.IR{Components: map[string]*ir.Component{"a": {}, "b": {}}},
+				Artifacts: tt.artifacts,
+			}
+
+			// when
+			err := CheckBudget(tt.budget).Run(ctx)
+
+			// then
+			if tt.wantErr != "" {
+				var stageErr *StageError
+				require.ErrorAs(t, err, &stageErr)
+				assert.Equal(t, StageBudget, stageErr.Stage)
+				assert.Contains(t, stageErr.Error(), tt.wantErr)
+				require.Len(t, stageErr.Errors, 1)
+				assert.Contains(t, stageErr.Errors[0].Error(), "raise --max-artifacts")
+				assert.Empty(t, ctx.Warnings)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantWarn == "" {
+				assert.Empty(t, ctx.Warnings)
+				return
+			}
+			require.Len(t, ctx.Warnings, 1)
+			assert.Contains(t, ctx.Warnings[0].Error(), tt.wantWarn)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/openboundary/openboundary/internal/parser"
+	"github.com/openboundary/openboundary/internal/validator"
+)
+
+// Severity classifies a diagnostic.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is an error or warning reported while running a pipeline, in
+// the form the CLI prints and emits as JSON.
+type Diagnostic struct {
+	Severity  Severity `json:"severity"`
+	Stage     string   `json:"stage,omitempty"`
+	Component string   `json:"component,omitempty"`
+	Message   string   `json:"message"`
+	MessageID string   `json:"message_id,omitempty"`
+	Rule      string   `json:"rule,omitempty"`
+	Path      string   `json:"path,omitempty"`
+	File      string   `json:"file,omitempty"`
+	Line      int      `json:"line,omitempty"`
+	Column    int      `json:"column,omitempty"`
+}
+
+// schemaComponentPattern matches the component index in a JSON Schema
+// instance path such as "/components/2/spec/port".
+var schemaComponentPattern = regexp.MustCompile(`^/components/(\d+)`)
+
+// Diagnostics gathers the warnings collected in ctx and the errors of err,
+// the error a pipeline run returned, if any. Messages are rendered in lang.
+// Diagnostics without a source position take that of their component. The
+// result is sorted by file, line and column, then errors before warnings.
+func Diagnostics(ctx *Context, err error, lang string) []Diagnostic {
+	var diags []Diagnostic
+	for _, w := range ctx.Warnings {
+		diags = append(diags, newDiagnostic(ctx, SeverityWarning, "", w, lang))
+	}
+
+	var stageErr *StageError
+	switch {
+	case errors.As(err, &stageErr):
+		for _, e := range stageErr.Errors {
+			diags = append(diags, newDiagnostic(ctx, SeverityError, stageErr.Stage, e, lang))
+		}
+	case err != nil:
+		diags = append(diags, newDiagnostic(ctx, SeverityError, "", err, lang))
+	}
+
+	SortDiagnostics(diags)
+	return diags
+}
+
+func newDiagnostic(ctx *Context, severity Severity, stage string, err error, lang string) Diagnostic {
+	d := Diagnostic{Severity: severity, Stage: stage, Message: err.Error()}
+
+	var ve validator.ValidationError
+	if !errors.As(err, &ve) {
+		return d
+	}
+	ve = ve.Localize(lang)
+	d.Component = ve.ID
+	d.Message = ve.Message
+	d.MessageID = string(ve.MessageID)
+	d.Rule = ve.Rule
+	d.Path = ve.Path
+
+	pos := ve.Position
+	if d.Component == "" && ctx.AST != nil {
+		if m := schemaComponentPattern.FindStringSubmatch(ve.Path); m != nil {
+			if n, _ := strconv.Atoi(m[1]); n < len(ctx.AST.Components) {
+				d.Component = ctx.AST.Components[n].ID
+				if pos.Line == 0 {
+					pos = ctx.AST.Components[n].Pos()
+				}
+			}
+		}
+	}
+	if pos.Line == 0 && d.Component != "" && ctx.IR != nil {
+		if comp, ok := ctx.IR.Components[d.Component]; ok {
+			pos = comp.Position
+		}
+	}
+	if pos == (parser.Position{}) && ctx.AST != nil {
+		pos.File = ctx.AST.Pos().File
+	}
+	d.File, d.Line, d.Column = pos.File, pos.Line, pos.Column
+	return d
+}
+
+// SortDiagnostics orders diagnostics by file, line and column, then errors
+// before warnings, then by component and message.
+func SortDiagnostics(diags []Diagnostic) {
+	rank := map[Severity]int{SeverityError: 0, SeverityWarning: 1}
+	sort.SliceStable(diags, func(a, b int) bool {
+		x, y := diags[a], diags[b]
+		switch {
+		case x.File != y.File:
+			return x.File < y.File
+		case x.Line != y.Line:
+			return x.Line < y.Line
+		case x.Column != y.Column:
+			return x.Column < y.Column
+		case x.Severity != y.Severity:
+			return rank[x.Severity] < rank[y.Severity]
+		case x.Component != y.Component:
+			return x.Component < y.Component
+		}
+		return x.Message < y.Message
+	})
+}
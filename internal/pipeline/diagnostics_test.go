@@ -0,0 +1,71 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+	"github.com/openboundary/openboundary/internal/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnostics_AggregatesAndSorts(t *testing.T) {
+	spec, err := parser.NewParser("spec.yaml").ParseBytes([]byte(`version: "0.1.0"
+name: app
+components:
+  - id: http.server.api
+    kind: http.server
+    spec: {}
+  - id: postgres.primary
+    kind: postgres
+    spec: {}
+`))
+	require.NoError(t, err)
+
+	ctx := &Context{
+		AST: spec,
+		Warnings: []error{
+			validator.ValidationError{Message: "unused postgres", Rule: validator.RuleUnusedComponent, Position: parser.Position{File: "spec.yaml", Line: 7, Column: 5}, ID: "postgres.primary"},
+		},
+	}
+	runErr := &StageError{Stage: "validate-schema", Message: "schema validation failed", Errors: []error{
+		validator.ValidationError{Message: "missing provider", Path: "/components/1/spec", Position: parser.Position{File: "spec.yaml"}},
+		validator.ValidationError{Message: "missing port", Path: "/components/0/spec", Position: parser.Position{File: "spec.yaml"}},
+	}}
+
+	diags := Diagnostics(ctx, runErr, "en")
+
+	require.Len(t, diags, 3)
+	assert.Equal(t, Diagnostic{Severity: SeverityError, Stage: "validate-schema", Component: "http.server.api", Message: "missing port", Path: "/components/0/spec", File: "spec.yaml", Line: 4, Column: 5}, diags[0])
+	assert.Equal(t, "missing provider", diags[1].Message)
+	assert.Equal(t, SeverityError, diags[1].Severity)
+	assert.Equal(t, SeverityWarning, diags[2].Severity)
+	assert.Equal(t, validator.RuleUnusedComponent, diags[2].Rule)
+}
+
+func TestDiagnostics_PlainError(t *testing.T) {
+	diags := Diagnostics(&Context{}, errors.New("parse error: bad YAML"), "en")
+
+	require.Len(t, diags, 1)
+	assert.Equal(t, Diagnostic{Severity: SeverityError, Message: "parse error: bad YAML"}, diags[0])
+}
+
+func TestDiagnostics_Localized(t *testing.T) {
+	ctx := &Context{IR: &ir.IR{Components: map[string]*ir.Component{
+		"http.server.api": {ID: "http.server.api", Kind: ir.KindHTTPServer, HTTPServer: &ir.HTTPServerSpec{Framework: "hono"}},
+	}}}
+	errs := validator.NewIRValidator().Validate(ctx.IR)
+	require.Len(t, errs, 1)
+	runErr := &StageError{Stage: "validate-ir", Errors: []error{errs[0]}}
+
+	diags := Diagnostics(ctx, runErr, "de")
+
+	require.Len(t, diags, 1)
+	assert.Equal(t, "port muss zwischen 1 und 65535 liegen", diags[0].Message)
+	assert.Equal(t, string(validator.MsgPortRange), diags[0].MessageID)
+}
@@ -3,7 +3,10 @@
 
 package pipeline
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // StageError wraps multiple errors from a pipeline stage.
 // The CLI layer can type-assert to format these errors to stderr.
@@ -16,3 +19,36 @@ type StageError struct {
 func (e *StageError) Error() string {
 	return fmt.Sprintf("stage %s: %s (%d error(s))", e.Stage, e.Message, len(e.Errors))
 }
+
+// stageFailure records which stage returned an error that is not a
+// StageError. It does not change the error's message.
+type stageFailure struct {
+	stage string
+	err   error
+}
+
+func (e *stageFailure) Error() string { return e.err.Error() }
+func (e *stageFailure) Unwrap() error { return e.err }
+
+// WithStage marks err as returned by the named stage, so that FailedStage
+// reports it. It returns nil for a nil err.
+func WithStage(stage string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &stageFailure{stage: stage, err: err}
+}
+
+// FailedStage returns the name of the stage that produced err, or "" when
+// err did not come from a stage.
+func FailedStage(err error) string {
+	var stageErr *StageError
+	if errors.As(err, &stageErr) {
+		return stageErr.Stage
+	}
+	var failure *stageFailure
+	if errors.As(err, &failure) {
+		return failure.stage
+	}
+	return ""
+}
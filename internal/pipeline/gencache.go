@@ -0,0 +1,299 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+// GeneratorCacheDir is where compile keeps the output of each generator,
+// relative to the output directory: a manifest of the files each generator
+// produced from which inputs and, under objects/, their content, stored once
+// per SHA-256. A generator whose inputs are unchanged is not run again.
+const GeneratorCacheDir = ".bound/cache"
+
+// generatorCacheVersion changes whenever the digests or the manifest change
+// meaning, which drops every cached output.
+const generatorCacheVersion = 2
+
+// generatorCache is the manifest of GeneratorCacheDir.
+type generatorCache struct {
+	Version    int                            `json:"version"`
+	Generators map[string]generatorCacheEntry `json:"generators"`
+}
+
+// generatorCacheEntry is the output of a generator for the inputs of Digest.
+type generatorCacheEntry struct {
+	Digest string               `json:"digest"`
+	Files  []generatorCacheFile `json:"files"`
+}
+
+type generatorCacheFile struct {
+	Path      string `json:"path"`
+	SHA256    string `json:"sha256"`
+	Component string `json:"component,omitempty"`
+}
+
+// withGeneratorCacheLock runs fn holding the lock of the generator cache of
+// outputDir, so compiles sharing the output directory do not read a cache
+// another one is updating, or update it at the same time.
+func withGeneratorCacheLock(outputDir string, fn func() error) error {
+	dir := filepath.Join(outputDir, GeneratorCacheDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	release, err := acquireFileLock(filepath.Join(dir, "lock"), cacheLockTimeout, cacheLockStale)
+	if err != nil {
+		return fmt.Errorf("generator cache not used: %w", err)
+	}
+	fnErr := fn()
+	if err := release(); err != nil && fnErr == nil {
+		return fmt.Errorf("failed to release generator cache lock: %w", err)
+	}
+	return fnErr
+}
+
+// loadGeneratorCache reads the manifest of outputDir. A missing or unreadable
+// one is an empty cache: the generators just run.
+func loadGeneratorCache(outputDir string) *generatorCache {
+	cache := &generatorCache{Version: generatorCacheVersion, Generators: make(map[string]generatorCacheEntry)}
+	data, err := os.ReadFile(filepath.Join(outputDir, GeneratorCacheDir, "manifest.json"))
+	if err != nil {
+		return cache
+	}
+	var loaded generatorCache
+	if json.Unmarshal(data, &loaded) != nil || loaded.Version != generatorCacheVersion || loaded.Generators == nil {
+		return cache
+	}
+	return &loaded
+}
+
+// lookup returns the cached output of a generator for digest. An entry whose
+// content is missing or damaged is a miss.
+func (c *generatorCache) lookup(outputDir, generator, digest string) (*codegen.Output, bool) {
+	entry, ok := c.Generators[generator]
+	if !ok || entry.Digest != digest {
+		return nil, false
+	}
+	output := codegen.NewOutput()
+	for _, f := range entry.Files {
+		content, err := os.ReadFile(filepath.Join(outputDir, GeneratorCacheDir, "objects", f.SHA256))
+		if err != nil || hashHex(content) != f.SHA256 {
+			return nil, false
+		}
+		output.AddComponentFile(f.Path, content, f.Component)
+	}
+	return output, true
+}
+
+// save records the outputs of generators, keyed by their digests, and drops
+// the entries and content of generators that no longer run. The manifest
+// and content are written atomically, so a compile that does not honor the
+// lock still reads either the old or the new version of a file.
+func (c *generatorCache) save(outputDir string, generators, digests []string, outputs []*codegen.Output) error {
+	dir := filepath.Join(outputDir, GeneratorCacheDir)
+	if err := os.MkdirAll(filepath.Join(dir, "objects"), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	entries := make(map[string]generatorCacheEntry, len(generators))
+	for n, name := range generators {
+		entry := generatorCacheEntry{Digest: digests[n], Files: []generatorCacheFile{}}
+		paths := make([]string, 0, len(outputs[n].Files))
+		for path := range outputs[n].Files {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			file := outputs[n].Files[path]
+			sum := hashHex(file.Content)
+			object := filepath.Join(dir, "objects", sum)
+			if _, err := os.Stat(object); err != nil {
+				if err := writeFileAtomic(object, file.Content); err != nil {
+					return fmt.Errorf("failed to write %s: %w", object, err)
+				}
+			}
+			entry.Files = append(entry.Files, generatorCacheFile{Path: path, SHA256: sum, Component: file.ComponentID})
+		}
+		entries[name] = entry
+	}
+	c.Generators = entries
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode generator cache: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(dir, "manifest.json"), append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write generator cache: %w", err)
+	}
+	return c.pruneObjects(dir)
+}
+
+// pruneObjects deletes the content no cached output references.
+func (c *generatorCache) pruneObjects(dir string) error {
+	referenced := make(map[string]bool)
+	for _, entry := range c.Generators {
+		for _, f := range entry.Files {
+			referenced[f.SHA256] = true
+		}
+	}
+	objects, err := os.ReadDir(filepath.Join(dir, "objects"))
+	if err != nil {
+		return fmt.Errorf("failed to read generator cache: %w", err)
+	}
+	for _, object := range objects {
+		if referenced[object.Name()] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, "objects", object.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to prune generator cache: %w", err)
+		}
+	}
+	return nil
+}
+
+// generatorDigests hashes what the output of each plugin depends on: the
+// compiler and generator versions, the spec apart from its components, and
+// the components the generator reads, with the files they reference.
+func generatorDigests(i *ir.IR, plugins []codegen.GeneratorPlugin, compiler string) ([]string, error) {
+	specDigest, err := specSettingsDigest(i.Spec)
+	if err != nil {
+		return nil, err
+	}
+	components, err := componentDigests(i)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(components))
+	for id := range components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	digests := make([]string, len(plugins))
+	for n, plugin := range plugins {
+		h := sha256.New()
+		fmt.Fprintf(h, "cache:%d\ncompiler:%s\ngenerator:%s@%s\nspec:%s\n", generatorCacheVersion, compiler, plugin.Name, plugin.Version, specDigest)
+		for _, id := range ids {
+			if reads(plugin, i.Components[id].Kind) {
+				fmt.Fprintf(h, "component:%s=%s\n", id, components[id])
+			}
+		}
+		digests[n] = hex.EncodeToString(h.Sum(nil))
+	}
+	return digests, nil
+}
+
+// reads reports whether a plugin's output depends on components of kind.
+func reads(plugin codegen.GeneratorPlugin, kind ir.Kind) bool {
+	if len(plugin.Reads) == 0 {
+		return true
+	}
+	for _, k := range plugin.Reads {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// specSettingsDigest hashes the spec apart from its components, such as its
+// name and env settings, which any generator may read.
+func specSettingsDigest(spec *parser.Spec) (string, error) {
+	if spec == nil {
+		return "", nil
+	}
+	settings := *spec
+	settings.Components = nil
+	settings.Vars = nil
+	data, err := json.Marshal(&settings)
+	if err != nil {
+		return "", err
+	}
+	return hashHex(data), nil
+}
+
+// componentDigests hashes each component of the spec by ID: its declaration,
+// its port, and the content of the files generators read for it: those its
+// spec references, such as an OpenAPI document or a schema, and those read
+// while building the IR, such as the files an OpenAPI document $refs.
+func componentDigests(i *ir.IR) (map[string]string, error) {
+	digests := make(map[string]string)
+	if i.Spec == nil {
+		return digests, nil
+	}
+	for _, comp := range i.Spec.Components {
+		built, ok := i.Components[comp.ID]
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(&comp)
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %w", comp.ID, err)
+		}
+		var buf bytes.Buffer
+		buf.Write(data)
+		// An auto port is not in the declaration
+		if built.HTTPServer != nil || built.HTTPGateway != nil {
+			port, _ := componentPort(built)
+			fmt.Fprintf(&buf, "\nport:%d", *port)
+		}
+		files := append(referencedFiles(comp.Spec), built.ReadFiles...)
+		sort.Strings(files)
+		for _, file := range slices.Compact(files) {
+			content, err := i.ReadFile(file)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(&buf, "\nfile:%s=%s", file, hashHex(content))
+		}
+		digests[comp.ID] = hashHex(buf.Bytes())
+	}
+	return digests, nil
+}
+
+// referencedFiles returns the paths among the string values of a component
+// spec that name a file, sorted.
+func referencedFiles(spec any) []string {
+	seen := make(map[string]bool)
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for _, value := range v {
+				walk(value)
+			}
+		case []any:
+			for _, value := range v {
+				walk(value)
+			}
+		case string:
+			if v != "" && !strings.Contains(v, "://") && strings.ContainsAny(v, "./") {
+				seen[filepath.Clean(v)] = true
+			}
+		}
+	}
+	walk(spec)
+
+	files := make([]string, 0, len(seen))
+	for path := range seen {
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingGenerator writes one file per component of its kind and counts
+// how often it ran.
+type countingGenerator struct {
+	name string
+	kind ir.Kind
+	runs *int
+}
+
+func (g *countingGenerator) Name() string { return g.name }
+
+func (g *countingGenerator) Generate(i *ir.IR) (*codegen.Output, error) {
+	*g.runs++
+	output := codegen.NewOutput()
+	for id, comp := range i.Components {
+		if comp.Kind == g.kind {
+			output.AddComponentFile(g.name+"/"+id+".txt", []byte(id+"\n"), id)
+		}
+	}
+	return output, nil
+}
+
+// cachedCompile builds the IR of spec and runs the cached generate stage on
+// it, returning the context.
+func cachedCompile(t *testing.T, outDir, compiler string, spec *parser.Spec, plugins ...codegen.GeneratorPlugin) *Context {
+	t.Helper()
+	i, errs := ir.NewBuilder().WithBaseDir(outDir).Build(spec)
+	require.Empty(t, errs)
+	newRegistry := func() (*codegen.PluginRegistry, error) {
+		registry := codegen.NewPluginRegistry()
+		for _, plugin := range plugins {
+			if err := registry.Register(plugin); err != nil {
+				return nil, err
+			}
+		}
+		return registry, nil
+	}
+	ctx := &Context{OutputDir: outDir, AST: spec, IR: i}
+	require.NoError(t, GenerateCached(newRegistry, compiler).Run(ctx))
+	return ctx
+}
+
+func TestGenerateCached(t *testing.T) {
+	outDir := t.TempDir()
+	spec := &parser.Spec{
+		Name: "app",
+		Components: []parser.Component{
+			{ID: "postgres.primary", Kind: "postgres", Spec: map[string]any{"provider": "drizzle"}},
+			{ID: "webhook.events", Kind: "webhook", Spec: map[string]any{"url_env": "EVENTS_URL", "secret_env": "EVENTS_SECRET"}},
+		},
+	}
+	var postgresRuns, webhookRuns int
+	plugins := []codegen.GeneratorPlugin{
+		{Name: "postgres", NewGenerator: func() codegen.Generator {
+			return &countingGenerator{name: "postgres", kind: ir.KindPostgres, runs: &postgresRuns}
+		}},
+		{Name: "webhooks", Reads: []ir.Kind{ir.KindWebhook}, NewGenerator: func() codegen.Generator {
+			return &countingGenerator{name: "webhooks", kind: ir.KindWebhook, runs: &webhookRuns}
+		}},
+	}
+
+	// The first compile runs every generator
+	ctx := cachedCompile(t, outDir, "0.1.0", spec, plugins...)
+	assert.Empty(t, ctx.Cached)
+	assert.Equal(t, 1, postgresRuns)
+	assert.Equal(t, 1, webhookRuns)
+
+	// An unchanged spec reuses every output
+	ctx = cachedCompile(t, outDir, "0.1.0", spec, plugins...)
+	assert.Equal(t, []string{"postgres", "webhooks"}, ctx.Cached)
+	assert.Equal(t, 1, postgresRuns)
+	assert.Equal(t, 1, webhookRuns)
+	require.Len(t, ctx.Artifacts, 2)
+	assert.Equal(t, "postgres/postgres.primary.txt", ctx.Artifacts[0].Path)
+	assert.Equal(t, "postgres.primary", ctx.Artifacts[0].ComponentID)
+	assert.Equal(t, "postgres.primary\n", string(ctx.Artifacts[0].Content))
+
+	// A component only reruns the generators that read its kind
+	spec.Components[0].Spec["provider"] = "prisma"
+	ctx = cachedCompile(t, outDir, "0.1.0", spec, plugins...)
+	assert.Equal(t, []string{"webhooks"}, ctx.Cached)
+	assert.Equal(t, 2, postgresRuns)
+	assert.Equal(t, 1, webhookRuns)
+
+	// A changed generator reruns, the others are reused
+	plugins[1].Version = "2"
+	ctx = cachedCompile(t, outDir, "0.1.0", spec, plugins...)
+	assert.Equal(t, []string{"postgres"}, ctx.Cached)
+	assert.Equal(t, 2, postgresRuns)
+	assert.Equal(t, 2, webhookRuns)
+
+	// A new compiler reruns everything
+	ctx = cachedCompile(t, outDir, "0.2.0", spec, plugins...)
+	assert.Empty(t, ctx.Cached)
+	assert.Equal(t, 3, postgresRuns)
+	assert.Equal(t, 3, webhookRuns)
+}
+
+func TestGenerateCached_ReferencedFile(t *testing.T) {
+	outDir := t.TempDir()
+	schema := filepath.Join(outDir, "schema.ts")
+	require.NoError(t, os.WriteFile(schema, []byte("export const users = {};\n"), 0644))
+	spec := &parser.Spec{
+		Name: "app",
+		Components: []parser.Component{
+			{ID: "postgres.primary", Kind: "postgres", Spec: map[string]any{"provider": "drizzle", "schema": "./schema.ts"}},
+		},
+	}
+	var runs int
+	plugin := codegen.GeneratorPlugin{Name: "postgres", NewGenerator: func() codegen.Generator {
+		return &countingGenerator{name: "postgres", kind: ir.KindPostgres, runs: &runs}
+	}}
+	cachedCompile(t, outDir, "0.1.0", spec, plugin)
+
+	// when
+	require.NoError(t, os.WriteFile(schema, []byte("export const orders = {};\n"), 0644))
+	ctx := cachedCompile(t, outDir, "0.1.0", spec, plugin)
+
+	// then
+	assert.Empty(t, ctx.Cached)
+	assert.Equal(t, 2, runs)
+}
+
+func TestGenerateCached_AbsoluteReferencedFile(t *testing.T) {
+	outDir := t.TempDir()
+	schema := filepath.Join(t.TempDir(), "schema.ts")
+	require.NoError(t, os.WriteFile(schema, []byte("export const users = {};\n"), 0644))
+	spec := &parser.Spec{
+		Name: "app",
+		Components: []parser.Component{
+			{ID: "postgres.primary", Kind: "postgres", Spec: map[string]any{"provider": "drizzle", "schema": schema}},
+		},
+	}
+	var runs int
+	plugin := codegen.GeneratorPlugin{Name: "postgres", NewGenerator: func() codegen.Generator {
+		return &countingGenerator{name: "postgres", kind: ir.KindPostgres, runs: &runs}
+	}}
+	cachedCompile(t, outDir, "0.1.0", spec, plugin)
+
+	// when
+	require.NoError(t, os.WriteFile(schema, []byte("export const orders = {};\n"), 0644))
+	ctx := cachedCompile(t, outDir, "0.1.0", spec, plugin)
+
+	// then
+	assert.Empty(t, ctx.Cached)
+	assert.Equal(t, 2, runs)
+}
+
+func TestGenerateCached_OpenAPIRef(t *testing.T) {
+	outDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outDir, "openapi.yaml"), []byte(`openapi: 3.0.0
+info: {title: Users, version: "1"}
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: ./schemas/user.yaml#/User
+`), 0644))
+	user := filepath.Join(outDir, "schemas", "user.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(user), 0755))
+	require.NoError(t, os.WriteFile(user, []byte("User:\n  type: object\n  properties:\n    id: {type: string}\n"), 0644))
+	spec := &parser.Spec{
+		Name: "app",
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]any{"framework": "hono", "port": 3000, "openapi": "./openapi.yaml"}},
+		},
+	}
+	var runs int
+	plugin := codegen.GeneratorPlugin{Name: "server", NewGenerator: func() codegen.Generator {
+		return &countingGenerator{name: "server", kind: ir.KindHTTPServer, runs: &runs}
+	}}
+	cachedCompile(t, outDir, "0.1.0", spec, plugin)
+
+	// An unchanged document is cached
+	ctx := cachedCompile(t, outDir, "0.1.0", spec, plugin)
+	assert.Equal(t, []string{"server"}, ctx.Cached)
+	assert.Equal(t, 1, runs)
+
+	// when: a file the document $refs, which the spec does not name, changes
+	require.NoError(t, os.WriteFile(user, []byte("User:\n  type: object\n  properties:\n    email: {type: string}\n"), 0644))
+	ctx = cachedCompile(t, outDir, "0.1.0", spec, plugin)
+
+	// then
+	assert.Empty(t, ctx.Cached)
+	assert.Equal(t, 2, runs)
+	schema := ctx.IR.Components["http.server.api"].HTTPServer.ParsedOpenAPI.Operations["GET:/users"].Responses["200"].Content["application/json"].Schema
+	assert.Contains(t, schema.Properties, "email")
+}
+
+func TestGenerateCached_DamagedObject(t *testing.T) {
+	outDir := t.TempDir()
+	spec := &parser.Spec{
+		Name:       "app",
+		Components: []parser.Component{{ID: "postgres.primary", Kind: "postgres", Spec: map[string]any{"provider": "drizzle"}}},
+	}
+	var runs int
+	plugin := codegen.GeneratorPlugin{Name: "postgres", NewGenerator: func() codegen.Generator {
+		return &countingGenerator{name: "postgres", kind: ir.KindPostgres, runs: &runs}
+	}}
+	cachedCompile(t, outDir, "0.1.0", spec, plugin)
+	objects, err := os.ReadDir(filepath.Join(outDir, GeneratorCacheDir, "objects"))
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	require.NoError(t, os.WriteFile(filepath.Join(outDir, GeneratorCacheDir, "objects", objects[0].Name()), []byte("damaged"), 0644))
+
+	// when
+	ctx := cachedCompile(t, outDir, "0.1.0", spec, plugin)
+
+	// then
+	assert.Empty(t, ctx.Cached)
+	assert.Equal(t, 2, runs)
+	assert.Equal(t, "postgres.primary\n", string(ctx.Artifacts[0].Content))
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+// HistoryDir is where compile keeps its recent runs, relative to the output
+// directory: one entry per compile and, under objects/, the content of every
+// file they generated, stored once per SHA-256.
+const HistoryDir = ".bound/history"
+
+// DefaultHistoryLimit is how many compiles the history keeps by default.
+const DefaultHistoryLimit = 5
+
+// HistoryEntry records a successful compile.
+type HistoryEntry struct {
+	Seq    int               `json:"seq"` // Increases with every recorded compile
+	Report *Report           `json:"report"`
+	Files  map[string]string `json:"files"` // Path to SHA-256 of every generated file after the compile
+}
+
+// FileChange is a file that differs between two compiles.
+type FileChange struct {
+	Path   string
+	Status string // "added", "modified" or "removed"
+}
+
+// RecordHistory adds a compile to the history of outputDir and drops the
+// entries beyond the newest limit, with the content only they referenced. A
+// compile restricted with --only keeps the other files of the entry before.
+func RecordHistory(outputDir string, report *Report, artifacts []codegen.Artifact, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+	entries, err := LoadHistory(outputDir)
+	if err != nil {
+		return err
+	}
+
+	entry := HistoryEntry{Seq: 1, Report: report, Files: make(map[string]string)}
+	if len(entries) > 0 {
+		entry.Seq = entries[0].Seq + 1
+		if report.Options["only"] != "" {
+			for path, sum := range entries[0].Files {
+				entry.Files[path] = sum
+			}
+		}
+	}
+	for _, f := range report.Files {
+		entry.Files[f.Path] = f.SHA256
+	}
+
+	dir := filepath.Join(outputDir, HistoryDir)
+	if err := os.MkdirAll(filepath.Join(dir, "objects"), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	for _, artifact := range artifacts {
+		object := filepath.Join(dir, "objects", hashHex(artifact.Content))
+		if _, err := os.Stat(object); err == nil {
+			continue
+		}
+		if err := writeFileAtomic(object, artifact.Content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", object, err)
+		}
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(dir, entryName(entry.Seq)), append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write history entry: %w", err)
+	}
+
+	entries = append([]HistoryEntry{entry}, entries...)
+	if len(entries) > limit {
+		for _, old := range entries[limit:] {
+			if err := os.Remove(filepath.Join(dir, entryName(old.Seq))); err != nil {
+				return fmt.Errorf("failed to remove history entry: %w", err)
+			}
+		}
+		entries = entries[:limit]
+	}
+	return pruneObjects(dir, entries)
+}
+
+// LoadHistory returns the recorded compiles of outputDir, newest first.
+func LoadHistory(outputDir string) ([]HistoryEntry, error) {
+	dir := filepath.Join(outputDir, HistoryDir)
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	var entries []HistoryEntry
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read history entry: %w", err)
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("invalid history entry %s: %w", f.Name(), err)
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].Seq > entries[b].Seq })
+	return entries, nil
+}
+
+// DiffFiles returns the files that differ between two compiles, by path.
+func DiffFiles(older, newer map[string]string) []FileChange {
+	var changes []FileChange
+	for path, sum := range newer {
+		switch prev, ok := older[path]; {
+		case !ok:
+			changes = append(changes, FileChange{Path: path, Status: "added"})
+		case prev != sum:
+			changes = append(changes, FileChange{Path: path, Status: "modified"})
+		}
+	}
+	for path := range older {
+		if _, ok := newer[path]; !ok {
+			changes = append(changes, FileChange{Path: path, Status: "removed"})
+		}
+	}
+	sort.Slice(changes, func(a, b int) bool { return changes[a].Path < changes[b].Path })
+	return changes
+}
+
+// Rollback restores the files of the latest compile in the history of
+// outputDir to the compile before it, deleting the files that compile did not
+// generate, and drops the latest entry. It returns the changes it made and
+// the entry that is now the latest.
+func Rollback(outputDir string) ([]FileChange, *HistoryEntry, error) {
+	entries, err := LoadHistory(outputDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(entries) < 2 {
+		return nil, nil, fmt.Errorf("no previous compile to roll back to in %s", outputDir)
+	}
+	latest, previous := entries[0], entries[1]
+	dir := filepath.Join(outputDir, HistoryDir)
+	absOutput, err := filepath.Abs(outputDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve output directory: %w", err)
+	}
+
+	changes := DiffFiles(latest.Files, previous.Files)
+	for _, c := range changes {
+		target := filepath.Join(absOutput, c.Path)
+		if !strings.HasPrefix(target, absOutput+string(filepath.Separator)) {
+			return nil, nil, fmt.Errorf("history path %q escapes output directory", c.Path)
+		}
+		if c.Status == "removed" {
+			if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, nil, fmt.Errorf("failed to delete %s: %w", c.Path, err)
+			}
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, "objects", previous.Files[c.Path]))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read the previous content of %s: %w", c.Path, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, nil, fmt.Errorf("failed to create directory %s: %w", filepath.Dir(target), err)
+		}
+		if err := writeFileAtomic(target, content); err != nil {
+			return nil, nil, fmt.Errorf("failed to write %s: %w", c.Path, err)
+		}
+	}
+
+	if err := previous.Report.Write(outputDir); err != nil {
+		return nil, nil, err
+	}
+	if err := os.Remove(filepath.Join(dir, entryName(latest.Seq))); err != nil {
+		return nil, nil, fmt.Errorf("failed to remove history entry: %w", err)
+	}
+	if err := pruneObjects(dir, entries[1:]); err != nil {
+		return nil, nil, err
+	}
+	return changes, &previous, nil
+}
+
+// pruneObjects deletes the stored content no entry references.
+func pruneObjects(dir string, entries []HistoryEntry) error {
+	referenced := make(map[string]bool)
+	for _, entry := range entries {
+		for _, sum := range entry.Files {
+			referenced[sum] = true
+		}
+	}
+	objects, err := os.ReadDir(filepath.Join(dir, "objects"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+	for _, object := range objects {
+		if referenced[object.Name()] || strings.HasPrefix(object.Name(), ".") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, "objects", object.Name())); err != nil {
+			return fmt.Errorf("failed to prune history: %w", err)
+		}
+	}
+	return nil
+}
+
+func entryName(seq int) string {
+	return fmt.Sprintf("%06d.json", seq)
+}
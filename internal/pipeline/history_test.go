@@ -0,0 +1,125 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+// compileInto writes files to dir as a compile would and records it.
+func compileInto(t *testing.T, dir string, files map[string]string, options map[string]string, limit int) {
+	t.Helper()
+	report := &Report{Version: ReportVersion, Status: "ok", Options: options}
+	var artifacts []codegen.Artifact
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+		artifacts = append(artifacts, codegen.Artifact{Path: path, Content: []byte(content)})
+		report.Files = append(report.Files, ReportFile{Path: path, SHA256: hashHex([]byte(content)), Size: len(content), Status: "written"})
+	}
+	require.NoError(t, RecordHistory(dir, report, artifacts, limit))
+}
+
+func TestRecordHistory(t *testing.T) {
+	// given
+	dir := t.TempDir()
+
+	// when
+	compileInto(t, dir, map[string]string{"a.ts": "1", "b.ts": "1"}, nil, 2)
+	compileInto(t, dir, map[string]string{"a.ts": "2", "b.ts": "1"}, nil, 2)
+	compileInto(t, dir, map[string]string{"a.ts": "3", "c.ts": "1"}, nil, 2)
+
+	// then
+	entries, err := LoadHistory(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "entries beyond the limit are dropped")
+	assert.Equal(t, 3, entries[0].Seq)
+	assert.Equal(t, 2, entries[1].Seq)
+	objects, err := os.ReadDir(filepath.Join(dir, HistoryDir, "objects"))
+	require.NoError(t, err)
+	assert.Len(t, objects, 3, "content is stored once and pruned with its last entry")
+}
+
+func TestRecordHistory_Disabled(t *testing.T) {
+	dir := t.TempDir()
+
+	compileInto(t, dir, map[string]string{"a.ts": "1"}, nil, 0)
+
+	assert.NoDirExists(t, filepath.Join(dir, HistoryDir))
+}
+
+func TestRecordHistory_Only(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	compileInto(t, dir, map[string]string{"a.ts": "1", "b.ts": "1"}, nil, 5)
+
+	// when
+	compileInto(t, dir, map[string]string{"b.ts": "2"}, map[string]string{"only": "kind=usecase"}, 5)
+
+	// then
+	entries, err := LoadHistory(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a.ts": hashHex([]byte("1")), "b.ts": hashHex([]byte("2"))}, entries[0].Files,
+		"files outside the selection keep their previous content")
+}
+
+func TestDiffFiles(t *testing.T) {
+	older := map[string]string{"a.ts": "1", "b.ts": "1", "c.ts": "1"}
+	newer := map[string]string{"a.ts": "1", "b.ts": "2", "d.ts": "1"}
+
+	changes := DiffFiles(older, newer)
+
+	assert.Equal(t, []FileChange{
+		{Path: "b.ts", Status: "modified"},
+		{Path: "c.ts", Status: "removed"},
+		{Path: "d.ts", Status: "added"},
+	}, changes)
+}
+
+func TestRollback(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	compileInto(t, dir, map[string]string{"a.ts": "1", "src/b.ts": "1"}, map[string]string{"target": "typescript"}, 5)
+	compileInto(t, dir, map[string]string{"a.ts": "2", "c.ts": "1"}, map[string]string{"target": "python"}, 5)
+
+	// when
+	changes, current, err := Rollback(dir)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 1, current.Seq)
+	assert.Len(t, changes, 3)
+	assertFile(t, filepath.Join(dir, "a.ts"), "1")
+	assertFile(t, filepath.Join(dir, "src/b.ts"), "1")
+	assert.NoFileExists(t, filepath.Join(dir, "c.ts"))
+	report, err := os.ReadFile(filepath.Join(dir, ReportPath))
+	require.NoError(t, err)
+	assert.Contains(t, string(report), `"target": "typescript"`)
+	entries, err := LoadHistory(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestRollback_NoPrevious(t *testing.T) {
+	dir := t.TempDir()
+	compileInto(t, dir, map[string]string{"a.ts": "1"}, nil, 5)
+
+	_, _, err := Rollback(dir)
+
+	assert.ErrorContains(t, err, "no previous compile")
+}
+
+func assertFile(t *testing.T, path, want string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got))
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"errors"
+	"time"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/validator"
+)
+
+// Hooks receives events from a pipeline run, for embedders such as editors
+// or CI wrappers that instrument it. Events are delivered in process and
+// synchronously; a slow hook slows the run down. Generator events can
+// arrive from several goroutines at once.
+type Hooks interface {
+	StageStart(stage string)
+	// StageEnd reports how long the stage ran and the error it returned.
+	StageEnd(stage string, d time.Duration, err error)
+
+	codegen.GeneratorHooks
+
+	// Diagnostic is called for each warning or error as the stage that
+	// found it finishes, rendered in validator.DefaultLanguage.
+	Diagnostic(d Diagnostic)
+}
+
+// NopHooks ignores every event. Embed it to implement only some of Hooks.
+type NopHooks struct{}
+
+func (NopHooks) StageStart(string)                         {}
+func (NopHooks) StageEnd(string, time.Duration, error)     {}
+func (NopHooks) GeneratorStart(string)                     {}
+func (NopHooks) GeneratorEnd(string, time.Duration, error) {}
+func (NopHooks) Diagnostic(Diagnostic)                     {}
+
+// hooks returns the hooks of the run, NopHooks when none are set.
+func (c *Context) hooks() Hooks {
+	if c.Hooks == nil {
+		return NopHooks{}
+	}
+	return c.Hooks
+}
+
+// emitDiagnostics passes the warnings a stage added to ctx.Warnings after
+// the first seen, and the errors of err, to the run's hooks.
+func emitDiagnostics(ctx *Context, stage string, seen int, err error) {
+	hooks := ctx.hooks()
+	for _, w := range ctx.Warnings[seen:] {
+		hooks.Diagnostic(newDiagnostic(ctx, SeverityWarning, "", w, validator.DefaultLanguage))
+	}
+
+	var stageErr *StageError
+	switch {
+	case errors.As(err, &stageErr):
+		for _, e := range stageErr.Errors {
+			hooks.Diagnostic(newDiagnostic(ctx, SeverityError, stageErr.Stage, e, validator.DefaultLanguage))
+		}
+	case err != nil:
+		hooks.Diagnostic(newDiagnostic(ctx, SeverityError, stage, err, validator.DefaultLanguage))
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openboundary/openboundary/internal/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHooks records stage events and diagnostics in order.
+type recordingHooks struct {
+	NopHooks
+	events []string
+}
+
+func (h *recordingHooks) StageStart(stage string) {
+	h.events = append(h.events, "start "+stage)
+}
+
+func (h *recordingHooks) StageEnd(stage string, _ time.Duration, err error) {
+	h.events = append(h.events, fmt.Sprintf("end %s: %v", stage, err))
+}
+
+func (h *recordingHooks) Diagnostic(d Diagnostic) {
+	h.events = append(h.events, fmt.Sprintf("%s %s: %s", d.Severity, d.Component, d.Message))
+}
+
+// warnStage adds a warning to the run.
+type warnStage struct{}
+
+func (warnStage) Name() string { return StageValidateIR }
+
+func (warnStage) Run(ctx *Context) error {
+	ctx.Warnings = append(ctx.Warnings, validator.ValidationError{ID: "postgres.legacy", Message: "unused"})
+	return nil
+}
+
+func TestPipeline_Hooks(t *testing.T) {
+	// given
+	hooks := &recordingHooks{}
+	ctx := &Context{Hooks: hooks, Warnings: []error{errors.New("earlier")}}
+	failing := &stubStage{name: StageGenerate, err: errors.New("boom")}
+
+	// when
+	err := New(warnStage{}, failing, &stubStage{name: StageWrite}).Run(ctx)
+
+	// then
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		"start validate-ir",
+		"warning postgres.legacy: unused",
+		"end validate-ir: <nil>",
+		"start generate",
+		"error : boom",
+		"end generate: boom",
+	}, hooks.events)
+}
+
+func TestPipeline_HooksStageErrors(t *testing.T) {
+	// given
+	hooks := &recordingHooks{}
+	stage := ValidateSchemaWith(&stubSchemaValidator{errs: []validator.ValidationError{{Message: "bad spec"}}})
+
+	// when
+	err := New(stage).Run(&Context{Hooks: hooks})
+
+	// then
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		"start validate-schema",
+		"error : bad spec",
+		"end validate-schema: stage validate-schema: schema validation failed (1 error(s))",
+	}, hooks.events)
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Timing of the generator cache lock. A compile holds it only while it
+// reads or updates the cache, so a lock file older than cacheLockStale was
+// left by a process that died holding it.
+var (
+	cacheLockTimeout = 10 * time.Second
+	cacheLockStale   = 30 * time.Second
+)
+
+const lockPollInterval = 25 * time.Millisecond
+
+// errLockTimeout is returned when a lock is still held by another process
+// after the timeout.
+var errLockTimeout = errors.New("timed out waiting for lock")
+
+// acquireFileLock takes an advisory lock by creating the file at path, which
+// processes that honor it, such as a watch mode, an editor integration and a
+// manual compile sharing an output directory, wait for. A lock file older
+// than stale is removed as abandoned. The returned function releases the
+// lock.
+func acquireFileLock(path string, timeout, stale time.Duration) (func() error, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			if err := f.Close(); err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+			return func() error { return os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > stale {
+			// Only remove the file found stale, not a fresh lock another
+			// waiter took after removing it first
+			if again, statErr := os.Stat(path); statErr == nil && os.SameFile(info, again) {
+				os.Remove(path)
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w %s", errLockTimeout, path)
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
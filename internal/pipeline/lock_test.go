@@ -0,0 +1,97 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireFileLock(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "lock")
+	release, err := acquireFileLock(path, time.Second, time.Minute)
+	require.NoError(t, err)
+
+	// when
+	_, err = acquireFileLock(path, 50*time.Millisecond, time.Minute)
+
+	// then
+	assert.True(t, errors.Is(err, errLockTimeout), "a held lock times out, got %v", err)
+	require.NoError(t, release())
+	release, err = acquireFileLock(path, 50*time.Millisecond, time.Minute)
+	require.NoError(t, err, "a released lock can be taken again")
+	require.NoError(t, release())
+	assert.NoFileExists(t, path)
+}
+
+func TestAcquireFileLock_Stale(t *testing.T) {
+	// given: a lock left by a process that died holding it
+	path := filepath.Join(t.TempDir(), "lock")
+	require.NoError(t, os.WriteFile(path, []byte("4242\n"), 0644))
+	abandoned := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, abandoned, abandoned))
+
+	// when
+	release, err := acquireFileLock(path, time.Second, time.Minute)
+
+	// then
+	require.NoError(t, err)
+	require.NoError(t, release())
+}
+
+func TestAcquireFileLock_Exclusive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lock")
+	var holders, overlaps int32
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := acquireFileLock(path, 5*time.Second, time.Minute)
+			if !assert.NoError(t, err) {
+				return
+			}
+			if atomic.AddInt32(&holders, 1) > 1 {
+				atomic.AddInt32(&overlaps, 1)
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&holders, -1)
+			assert.NoError(t, release())
+		}()
+	}
+	wg.Wait()
+
+	assert.Zero(t, overlaps, "the lock is held by one goroutine at a time")
+}
+
+func TestWithGeneratorCacheLock_Held(t *testing.T) {
+	// given: another compile holds the lock
+	outDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(outDir, GeneratorCacheDir), 0755))
+	release, err := acquireFileLock(filepath.Join(outDir, GeneratorCacheDir, "lock"), time.Second, time.Minute)
+	require.NoError(t, err)
+	defer release()
+	defer func(timeout time.Duration) { cacheLockTimeout = timeout }(cacheLockTimeout)
+	cacheLockTimeout = 50 * time.Millisecond
+
+	// when
+	ran := false
+	err = withGeneratorCacheLock(outDir, func() error {
+		ran = true
+		return nil
+	})
+
+	// then
+	assert.ErrorContains(t, err, "generator cache not used")
+	assert.False(t, ran)
+}
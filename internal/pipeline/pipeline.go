@@ -4,6 +4,12 @@
 package pipeline
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
 	"github.com/openboundary/openboundary/internal/codegen"
 	"github.com/openboundary/openboundary/internal/ir"
 	"github.com/openboundary/openboundary/internal/parser"
@@ -16,6 +22,56 @@ type Context struct {
 	AST       *parser.Spec
 	IR        *ir.IR
 	Artifacts []codegen.Artifact
+	Warnings  []error // Non-fatal findings reported by validation stages
+	Quiet     bool    // Suppress progress output, e.g. when stdout carries JSON
+	Touch     bool    // Update the modification time of files the write stage skips
+
+	// SpecFS, if set, is where the files the spec references are read from
+	// instead of the spec's directory, with paths relative to its root.
+	SpecFS fs.FS
+
+	Timings    []StageTiming // One per stage run, filled in by Pipeline.Run
+	Generators []string      // Generators of the generate stage, in order
+	Cached     []string      // Generators whose output came from the generator cache instead of running
+	Files      []FileResult  // Files the write stage wrote or skipped
+
+	// Ctx cancels the run, e.g. on an interrupt. No stage starts once it is
+	// done, and long stages stop early. Nil never cancels.
+	Ctx context.Context
+
+	// Hooks is told about stages, generators and diagnostics as the run
+	// progresses. Nil reports nothing.
+	Hooks Hooks
+}
+
+// ErrCancelled is returned by a run that was cancelled through Context.Ctx.
+var ErrCancelled = errors.New("cancelled")
+
+// Err returns ErrCancelled once Ctx is done, and nil before.
+func (c *Context) Err() error {
+	if c.Ctx != nil && c.Ctx.Err() != nil {
+		return ErrCancelled
+	}
+	return nil
+}
+
+// StageTiming records how long a stage ran.
+type StageTiming struct {
+	Stage    string
+	Duration time.Duration
+}
+
+// FileResult records what the write stage did with an artifact.
+type FileResult struct {
+	Path    string // Relative to the output directory
+	SHA256  string // Hex digest of the content
+	Size    int
+	Skipped bool // The file already had this content and was left alone
+
+	// Provenance of the file, from its codegen.Artifact
+	Generator   string
+	ComponentID string
+	Strategy    string
 }
 
 // Stage is a single step in a pipeline.
@@ -34,11 +90,28 @@ func New(stages ...Stage) *Pipeline {
 	return &Pipeline{stages: stages}
 }
 
-// Run executes each stage in order, stopping on the first error.
+// Run executes each stage in order, stopping on the first error or when
+// the run is cancelled. Errors are marked with the stage that returned them.
 func (p *Pipeline) Run(ctx *Context) error {
+	hooks := ctx.hooks()
 	for _, s := range p.stages {
-		if err := s.Run(ctx); err != nil {
-			return err
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("%w before %s", err, s.Name())
+		}
+		hooks.StageStart(s.Name())
+		warnings := len(ctx.Warnings)
+		start := time.Now()
+		err := s.Run(ctx)
+		elapsed := time.Since(start)
+		ctx.Timings = append(ctx.Timings, StageTiming{Stage: s.Name(), Duration: elapsed})
+		emitDiagnostics(ctx, s.Name(), warnings, err)
+		hooks.StageEnd(s.Name(), elapsed, err)
+		if err != nil {
+			var stageErr *StageError
+			if errors.As(err, &stageErr) {
+				return err
+			}
+			return WithStage(s.Name(), err)
 		}
 	}
 	return nil
@@ -0,0 +1,231 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// PortRegistryFile records the ports of the servers and gateways of every
+// spec under a directory: the root of the git repository holding the spec,
+// or the spec's directory outside one. Ports declared as auto are assigned
+// so they collide with no port in it and keep the port recorded for them,
+// so committing the file gives every checkout the same ports.
+const PortRegistryFile = ".bound/ports.json"
+
+// AutoPortBase is the lowest port assigned to a server or gateway declared
+// with port: auto.
+const AutoPortBase = 4000
+
+// portRegistryVersion changes whenever the registry changes meaning.
+const portRegistryVersion = 1
+
+// portRegistry is the content of PortRegistryFile.
+type portRegistry struct {
+	Version int `json:"version"`
+
+	// Ports are keyed by the path of the spec, relative to the registry's
+	// directory and with forward slashes, and the component ID, joined by
+	// "#", e.g. "services/orders/spec.yaml#http.server.api".
+	Ports map[string]int `json:"ports"`
+}
+
+// portRegistryDir returns the directory whose registry the spec at specPath
+// uses.
+func portRegistryDir(specPath string) string {
+	dir, err := filepath.Abs(filepath.Dir(specPath))
+	if err != nil {
+		return filepath.Dir(specPath)
+	}
+	for d := dir; ; {
+		if _, err := os.Stat(filepath.Join(d, ".git")); err == nil {
+			return d
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
+
+// portKeyPrefix returns the prefix of the registry keys of the spec at
+// specPath.
+func portKeyPrefix(registryDir, specPath string) string {
+	path, err := filepath.Abs(specPath)
+	if err == nil {
+		if rel, err := filepath.Rel(registryDir, path); err == nil {
+			path = rel
+		}
+	}
+	return filepath.ToSlash(path) + "#"
+}
+
+// loadPortRegistry reads the registry in dir. A missing one is empty.
+func loadPortRegistry(dir string) (*portRegistry, error) {
+	registry := &portRegistry{Version: portRegistryVersion, Ports: make(map[string]int)}
+	path := filepath.Join(dir, PortRegistryFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, registry); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if registry.Version != portRegistryVersion {
+		return nil, fmt.Errorf("%s has unsupported version %d", path, registry.Version)
+	}
+	if registry.Ports == nil {
+		registry.Ports = make(map[string]int)
+	}
+	return registry, nil
+}
+
+// listeningComponents returns the servers and gateways of i, sorted by ID.
+func listeningComponents(i *ir.IR) []*ir.Component {
+	var comps []*ir.Component
+	for _, comp := range i.Components {
+		if comp.HTTPServer != nil || comp.HTTPGateway != nil {
+			comps = append(comps, comp)
+		}
+	}
+	sort.Slice(comps, func(a, b int) bool { return comps[a].ID < comps[b].ID })
+	return comps
+}
+
+// componentPort returns the port of a server or gateway and whether it is
+// assigned by the compiler.
+func componentPort(comp *ir.Component) (*int, bool) {
+	if comp.HTTPServer != nil {
+		return &comp.HTTPServer.Port, comp.HTTPServer.AutoPort
+	}
+	return &comp.HTTPGateway.Port, comp.HTTPGateway.AutoPort
+}
+
+// assign sets the ports of the servers and gateways of i declared as auto,
+// whose keys start with prefix. Each keeps its recorded port unless another
+// component now uses it; the others get the lowest port from AutoPortBase
+// that no component of the spec declares and the registry does not record
+// for another component.
+func (r *portRegistry) assign(prefix string, i *ir.IR) {
+	comps := listeningComponents(i)
+	auto := make(map[string]bool)
+	taken := make(map[int]bool)
+	for _, comp := range comps {
+		if port, isAuto := componentPort(comp); isAuto {
+			auto[prefix+comp.ID] = true
+		} else {
+			taken[*port] = true
+		}
+	}
+	for key, port := range r.Ports {
+		if !auto[key] {
+			taken[port] = true
+		}
+	}
+
+	var unassigned []*int
+	for _, comp := range comps {
+		port, isAuto := componentPort(comp)
+		if !isAuto {
+			continue
+		}
+		if recorded, ok := r.Ports[prefix+comp.ID]; ok && !taken[recorded] {
+			*port = recorded
+			taken[recorded] = true
+		} else {
+			unassigned = append(unassigned, port)
+		}
+	}
+	next := AutoPortBase
+	for _, port := range unassigned {
+		for taken[next] {
+			next++
+		}
+		*port = next
+		taken[next] = true
+	}
+}
+
+// record sets the entries of the spec whose keys start with prefix to the
+// ports of i, dropping those of components it no longer has, and reports
+// whether the registry changed.
+func (r *portRegistry) record(prefix string, i *ir.IR) bool {
+	current := make(map[string]int)
+	for _, comp := range listeningComponents(i) {
+		port, _ := componentPort(comp)
+		current[prefix+comp.ID] = *port
+	}
+	changed := false
+	for key := range r.Ports {
+		if _, ok := current[key]; strings.HasPrefix(key, prefix) && !ok {
+			delete(r.Ports, key)
+			changed = true
+		}
+	}
+	for key, port := range current {
+		if r.Ports[key] != port {
+			r.Ports[key] = port
+			changed = true
+		}
+	}
+	return changed
+}
+
+// conflicts describes the ports of the spec whose keys start with prefix
+// that the registry also records for a component of another spec.
+func (r *portRegistry) conflicts(prefix string) []string {
+	users := make(map[int][]string)
+	for key, port := range r.Ports {
+		if !strings.HasPrefix(key, prefix) {
+			users[port] = append(users[port], key)
+		}
+	}
+	var found []string
+	for key, port := range r.Ports {
+		if strings.HasPrefix(key, prefix) && len(users[port]) > 0 {
+			others := users[port]
+			sort.Strings(others)
+			found = append(found, fmt.Sprintf("port %d of %s is also used by %s", port, strings.TrimPrefix(key, prefix), strings.Join(others, ", ")))
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+// save writes the registry to dir.
+func (r *portRegistry) save(dir string) error {
+	path := filepath.Join(dir, PortRegistryFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode port registry: %w", err)
+	}
+	if err := writeFileAtomic(path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// hasAutoPorts reports whether a server or gateway of i is declared as auto.
+func hasAutoPorts(i *ir.IR) bool {
+	for _, comp := range listeningComponents(i) {
+		if _, isAuto := componentPort(comp); isAuto {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func autoServer(id string) *ir.Component {
+	return &ir.Component{ID: id, Kind: ir.KindHTTPServer, HTTPServer: &ir.HTTPServerSpec{AutoPort: true}}
+}
+
+func portIR(comps ...*ir.Component) *ir.IR {
+	i := &ir.IR{Components: make(map[string]*ir.Component)}
+	for _, comp := range comps {
+		i.Components[comp.ID] = comp
+	}
+	return i
+}
+
+func TestPortRegistry_Assign(t *testing.T) {
+	// given: a recorded port, an explicit port and another spec's port
+	registry := &portRegistry{Ports: map[string]int{
+		"orders/spec.yaml#http.server.b":    4003,
+		"billing/spec.yaml#http.server.api": 4000,
+	}}
+	a, b, c := autoServer("http.server.a"), autoServer("http.server.b"), autoServer("http.server.c")
+	explicit := &ir.Component{ID: "http.server.admin", Kind: ir.KindHTTPServer, HTTPServer: &ir.HTTPServerSpec{Port: 4001}}
+	gateway := &ir.Component{ID: "http.gateway.public", Kind: ir.KindHTTPGateway, HTTPGateway: &ir.HTTPGatewaySpec{AutoPort: true}}
+
+	// when
+	registry.assign("orders/spec.yaml#", portIR(a, b, c, explicit, gateway))
+
+	// then: new ports are assigned in ID order
+	assert.Equal(t, 4002, gateway.HTTPGateway.Port)
+	assert.Equal(t, 4003, b.HTTPServer.Port, "a recorded port is kept")
+	assert.Equal(t, 4004, a.HTTPServer.Port)
+	assert.Equal(t, 4005, c.HTTPServer.Port)
+	assert.Equal(t, 4001, explicit.HTTPServer.Port)
+}
+
+func TestPortRegistry_AssignTakenRecordedPort(t *testing.T) {
+	// given: the recorded port is now declared by another component
+	registry := &portRegistry{Ports: map[string]int{"spec.yaml#http.server.a": 4000}}
+	a := autoServer("http.server.a")
+	explicit := &ir.Component{ID: "http.server.b", Kind: ir.KindHTTPServer, HTTPServer: &ir.HTTPServerSpec{Port: 4000}}
+
+	// when
+	registry.assign("spec.yaml#", portIR(a, explicit))
+
+	// then
+	assert.Equal(t, 4001, a.HTTPServer.Port)
+}
+
+func TestPortRegistry_Record(t *testing.T) {
+	registry := &portRegistry{Ports: map[string]int{
+		"spec.yaml#http.server.removed": 4001,
+		"other.yaml#http.server.api":    4000,
+	}}
+	a := autoServer("http.server.a")
+	a.HTTPServer.Port = 4000
+
+	changed := registry.record("spec.yaml#", portIR(a))
+
+	assert.True(t, changed)
+	assert.Equal(t, map[string]int{"spec.yaml#http.server.a": 4000, "other.yaml#http.server.api": 4000}, registry.Ports)
+	assert.False(t, registry.record("spec.yaml#", portIR(a)))
+	assert.Equal(t, []string{"port 4000 of http.server.a is also used by other.yaml#http.server.api"}, registry.conflicts("spec.yaml#"))
+}
+
+func TestPortRegistryDir(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+	specDir := filepath.Join(root, "services", "orders")
+	require.NoError(t, os.MkdirAll(specDir, 0755))
+	specPath := filepath.Join(specDir, "spec.yaml")
+
+	dir := portRegistryDir(specPath)
+
+	assert.Equal(t, root, dir)
+	assert.Equal(t, "services/orders/spec.yaml#", portKeyPrefix(dir, specPath))
+}
+
+func TestRecordPortsStage(t *testing.T) {
+	// given: a spec with an auto port compiled twice
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0755))
+	specPath := filepath.Join(dir, "spec.yaml")
+	first := &Context{SpecPath: specPath, IR: portIR(autoServer("http.server.api"))}
+	require.NoError(t, assignPorts(first))
+	require.NoError(t, RecordPorts().Run(first))
+
+	// when: another spec in the repository gets an auto port
+	other := &Context{SpecPath: filepath.Join(dir, "billing", "spec.yaml"), IR: portIR(autoServer("http.server.api"))}
+	require.NoError(t, assignPorts(other))
+	require.NoError(t, RecordPorts().Run(other))
+
+	// then
+	assert.Equal(t, 4000, first.IR.Components["http.server.api"].HTTPServer.Port)
+	assert.Equal(t, 4001, other.IR.Components["http.server.api"].HTTPServer.Port)
+	registry, err := loadPortRegistry(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"spec.yaml#http.server.api": 4000, "billing/spec.yaml#http.server.api": 4001}, registry.Ports)
+	assert.Empty(t, first.Warnings)
+	assert.NoFileExists(t, filepath.Join(dir, PortRegistryFile+".lock"))
+}
+
+func TestRecordPortsStage_NoAutoPorts(t *testing.T) {
+	dir := t.TempDir()
+	explicit := &ir.Component{ID: "http.server.api", Kind: ir.KindHTTPServer, HTTPServer: &ir.HTTPServerSpec{Port: 3000}}
+	ctx := &Context{SpecPath: filepath.Join(dir, "spec.yaml"), IR: portIR(explicit)}
+
+	require.NoError(t, RecordPorts().Run(ctx))
+
+	assert.NoFileExists(t, filepath.Join(dir, PortRegistryFile), "no registry is created for explicit ports")
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ReportPath is where compile writes its report, relative to the output
+// directory.
+const ReportPath = ".bound/report.json"
+
+// ReportVersion is the version of the report format. It changes only when a
+// field is removed or changes meaning; new fields may appear at any time.
+const ReportVersion = 1
+
+// Report describes a compile run for CI and for tools that inspect the
+// output later.
+type Report struct {
+	Version        int               `json:"version"`
+	Status         string            `json:"status"`             // "ok" or "failed"
+	Compiler       string            `json:"compiler,omitempty"` // Version of bound that compiled
+	FailedStage    string            `json:"failed_stage,omitempty"`
+	Spec           ReportSpec        `json:"spec"`
+	CacheKey       string            `json:"cache_key"`
+	Options        map[string]string `json:"options,omitempty"`
+	DurationMS     float64           `json:"duration_ms"`
+	Stages         []ReportStage     `json:"stages"`
+	Generators     []string          `json:"generators"`
+	GeneratorStats []ReportGenerator `json:"generator_stats"`
+	Files          []ReportFile      `json:"files"`
+	Written        int               `json:"written"`
+	Skipped        int               `json:"skipped"`
+	Diagnostics    []Diagnostic      `json:"diagnostics"`
+}
+
+// ReportSpec identifies the compiled spec file.
+type ReportSpec struct {
+	Path         string `json:"path"`
+	SHA256       string `json:"sha256,omitempty"`        // Empty when the file could not be read
+	SemanticHash string `json:"semantic_hash,omitempty"` // See parser.Spec.SemanticHash; empty when the spec did not parse
+}
+
+// ReportStage is the timing of one stage.
+type ReportStage struct {
+	Name       string  `json:"name"`
+	DurationMS float64 `json:"duration_ms"`
+}
+
+// ReportGenerator is how the generate stage produced a generator's output.
+type ReportGenerator struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // "ran", or "cached" when reused from the generator cache
+}
+
+// ReportFile is a file the write stage handled, and where it came from.
+type ReportFile struct {
+	Path      string `json:"path"`
+	SHA256    string `json:"sha256"`
+	Size      int    `json:"size"`
+	Status    string `json:"status"`              // "written" or "skipped"
+	Generator string `json:"generator,omitempty"` // Generator or stage that produced the file
+	Component string `json:"component,omitempty"` // Component the file belongs to; empty for shared files
+	Strategy  string `json:"strategy,omitempty"`  // codegen.StrategyOverwrite or codegen.StrategyMerge
+}
+
+// NewReport describes the run recorded in ctx, which ended with err.
+// Options are the settings that shape the output, such as the target; they
+// go into the cache key with the spec hash and the generators.
+func NewReport(ctx *Context, err error, lang string, options map[string]string) *Report {
+	r := &Report{
+		Version:        ReportVersion,
+		Status:         "ok",
+		Spec:           ReportSpec{Path: ctx.SpecPath},
+		Options:        options,
+		Stages:         []ReportStage{},
+		Generators:     append([]string{}, ctx.Generators...),
+		GeneratorStats: []ReportGenerator{},
+		Files:          []ReportFile{},
+		Diagnostics:    Diagnostics(ctx, err, lang),
+	}
+	if err != nil {
+		r.Status = "failed"
+		r.FailedStage = FailedStage(err)
+	}
+	cached := make(map[string]bool, len(ctx.Cached))
+	for _, name := range ctx.Cached {
+		cached[name] = true
+	}
+	for _, name := range ctx.Generators {
+		stat := ReportGenerator{Name: name, Status: "ran"}
+		if cached[name] {
+			stat.Status = "cached"
+		}
+		r.GeneratorStats = append(r.GeneratorStats, stat)
+	}
+	if r.Diagnostics == nil {
+		r.Diagnostics = []Diagnostic{}
+	}
+	if content, readErr := os.ReadFile(ctx.SpecPath); readErr == nil {
+		r.Spec.SHA256 = hashHex(content)
+	}
+	if ctx.AST != nil {
+		r.Spec.SemanticHash, _ = ctx.AST.SemanticHash()
+	}
+
+	var total time.Duration
+	for _, t := range ctx.Timings {
+		total += t.Duration
+		r.Stages = append(r.Stages, ReportStage{Name: t.Stage, DurationMS: milliseconds(t.Duration)})
+	}
+	r.DurationMS = milliseconds(total)
+
+	for _, f := range ctx.Files {
+		file := ReportFile{
+			Path:      f.Path,
+			SHA256:    f.SHA256,
+			Size:      f.Size,
+			Status:    "written",
+			Generator: f.Generator,
+			Component: f.ComponentID,
+			Strategy:  f.Strategy,
+		}
+		if f.Skipped {
+			file.Status = "skipped"
+			r.Skipped++
+		} else {
+			r.Written++
+		}
+		r.Files = append(r.Files, file)
+	}
+	sort.Slice(r.Files, func(a, b int) bool { return r.Files[a].Path < r.Files[b].Path })
+
+	r.CacheKey = r.cacheKey()
+	return r
+}
+
+// cacheKey hashes the inputs that determine the generated files: the spec,
+// the options and the generators. It is empty when the spec was unreadable.
+func (r *Report) cacheKey() string {
+	if r.Spec.SHA256 == "" {
+		return ""
+	}
+	keys := make([]string, 0, len(r.Options))
+	for key := range r.Options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "report:%d\nspec:%s\n", ReportVersion, r.Spec.SHA256)
+	for _, key := range keys {
+		fmt.Fprintf(h, "option:%s=%s\n", key, r.Options[key])
+	}
+	for _, gen := range r.Generators {
+		fmt.Fprintf(h, "generator:%s\n", gen)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Write writes the report to ReportPath under outputDir.
+func (r *Report) Write(outputDir string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	path := filepath.Join(outputDir, ReportPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+	if err := writeFileAtomic(path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write report %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadReport reads the report of the last compile into outputDir.
+func LoadReport(outputDir string) (*Report, error) {
+	path := filepath.Join(outputDir, ReportPath)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to decode report %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+func hashHex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openboundary/openboundary/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReport(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte("name: app\n"), 0644))
+	ctx := &Context{
+		SpecPath:   specPath,
+		Timings:    []StageTiming{{Stage: StageParse, Duration: 1500 * time.Microsecond}, {Stage: StageWrite, Duration: 2 * time.Millisecond}},
+		AST:        &parser.Spec{Name: "app"},
+		Generators: []string{"typescript-project", "typescript-readme"},
+		Cached:     []string{"typescript-readme"},
+		Files: []FileResult{
+			{Path: "src/index.ts", SHA256: "bb", Size: 2, Generator: "typescript-server", ComponentID: "http.server.api", Strategy: "overwrite"},
+			{Path: "README.md", SHA256: "aa", Size: 1, Skipped: true},
+		},
+	}
+
+	r := NewReport(ctx, nil, "en", map[string]string{"target": "typescript"})
+
+	assert.Equal(t, "ok", r.Status)
+	assert.Equal(t, hashHex([]byte("name: app\n")), r.Spec.SHA256)
+	semanticHash, err := ctx.AST.SemanticHash()
+	require.NoError(t, err)
+	assert.Equal(t, semanticHash, r.Spec.SemanticHash)
+	assert.Equal(t, []ReportStage{{Name: StageParse, DurationMS: 1.5}, {Name: StageWrite, DurationMS: 2}}, r.Stages)
+	assert.Equal(t, 3.5, r.DurationMS)
+	assert.Equal(t, []ReportGenerator{{Name: "typescript-project", Status: "ran"}, {Name: "typescript-readme", Status: "cached"}}, r.GeneratorStats)
+	assert.Equal(t, []ReportFile{
+		{Path: "README.md", SHA256: "aa", Size: 1, Status: "skipped"},
+		{Path: "src/index.ts", SHA256: "bb", Size: 2, Status: "written", Generator: "typescript-server", Component: "http.server.api", Strategy: "overwrite"},
+	}, r.Files)
+	assert.Equal(t, 1, r.Written)
+	assert.Equal(t, 1, r.Skipped)
+	assert.Empty(t, r.Diagnostics)
+	assert.Len(t, r.CacheKey, 64)
+}
+
+func TestNewReport_Failed(t *testing.T) {
+	ctx := &Context{SpecPath: filepath.Join(t.TempDir(), "missing.yaml")}
+
+	r := NewReport(ctx, WithStage(StageParse, errors.New("no such file")), "en", nil)
+
+	assert.Equal(t, "failed", r.Status)
+	assert.Equal(t, StageParse, r.FailedStage)
+	assert.Empty(t, r.Spec.SHA256)
+	assert.Empty(t, r.CacheKey, "no cache key without the spec")
+	require.Len(t, r.Diagnostics, 1)
+	assert.Equal(t, "no such file", r.Diagnostics[0].Message)
+}
+
+func TestReport_CacheKey(t *testing.T) {
+	base := Report{Spec: ReportSpec{SHA256: "aa"}, Options: map[string]string{"target": "typescript"}, Generators: []string{"a"}}
+	tests := []struct {
+		name   string
+		change func(r *Report)
+		same   bool
+	}{
+		{"unchanged", func(r *Report) {}, true},
+		{"timings ignored", func(r *Report) { r.DurationMS = 10 }, true},
+		{"spec changed", func(r *Report) { r.Spec.SHA256 = "bb" }, false},
+		{"option changed", func(r *Report) { r.Options = map[string]string{"target": "python"} }, false},
+		{"generator added", func(r *Report) { r.Generators = []string{"a", "b"} }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := base
+			tt.change(&r)
+			assert.Equal(t, tt.same, r.cacheKey() == base.cacheKey())
+		})
+	}
+}
+
+func TestReport_Write(t *testing.T) {
+	outDir := t.TempDir()
+	r := NewReport(&Context{SpecPath: "spec.yaml"}, nil, "en", nil)
+
+	require.NoError(t, r.Write(outDir))
+
+	data, err := os.ReadFile(filepath.Join(outDir, ReportPath))
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.EqualValues(t, ReportVersion, decoded["version"])
+	assert.Equal(t, []any{}, decoded["files"])
+}
+
+func TestLoadReport(t *testing.T) {
+	outDir := t.TempDir()
+	r := NewReport(&Context{SpecPath: "spec.yaml"}, nil, "en", map[string]string{"target": "python"})
+	r.Compiler = "0.1.0"
+	require.NoError(t, r.Write(outDir))
+
+	loaded, err := LoadReport(outDir)
+
+	require.NoError(t, err)
+	assert.Equal(t, "0.1.0", loaded.Compiler)
+	assert.Equal(t, "python", loaded.Options["target"])
+}
+
+func TestLoadReport_Missing(t *testing.T) {
+	_, err := LoadReport(t.TempDir())
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
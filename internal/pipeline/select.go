@@ -0,0 +1,120 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// Selector matches components by one field, e.g. "kind=usecase" or
+// "label=team:billing". Patterns are globs as in path.Match, and a component
+// matches when any of them does.
+type Selector struct {
+	Field    string // "kind", "label" or "id"
+	Patterns []string
+}
+
+// ParseSelector parses "field=pattern[,pattern...]".
+func ParseSelector(s string) (Selector, error) {
+	field, value, ok := strings.Cut(s, "=")
+	if !ok || value == "" {
+		return Selector{}, fmt.Errorf("invalid selector %q (expected kind=, label= or id= followed by a value)", s)
+	}
+	switch field {
+	case "kind", "label", "id":
+	default:
+		return Selector{}, fmt.Errorf("invalid selector %q: unknown field %q (expected kind, label or id)", s, field)
+	}
+
+	sel := Selector{Field: field, Patterns: strings.Split(value, ",")}
+	for _, pattern := range sel.Patterns {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			return Selector{}, fmt.Errorf("invalid selector %q: bad pattern %q", s, pattern)
+		}
+	}
+	return sel, nil
+}
+
+func (s Selector) String() string {
+	return s.Field + "=" + strings.Join(s.Patterns, ",")
+}
+
+// Matches reports whether the component matches the selector.
+func (s Selector) Matches(c *ir.Component) bool {
+	var values []string
+	switch s.Field {
+	case "kind":
+		values = []string{string(c.Kind)}
+	case "label":
+		values = c.Labels
+	case "id":
+		values = []string{c.ID}
+	}
+	for _, pattern := range s.Patterns {
+		for _, v := range values {
+			if ok, _ := path.Match(pattern, v); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// selectStage keeps only the artifacts of selected components.
+type selectStage struct {
+	selectors []Selector
+}
+
+// Select returns a stage that keeps the artifacts of the components matching
+// every selector and drops all others, including shared artifacts such as
+// package.json, so that the files of other components are left as they are.
+// Without selectors it keeps everything.
+func Select(selectors ...Selector) Stage {
+	return &selectStage{selectors: selectors}
+}
+
+func (s *selectStage) Name() string { return StageSelect }
+
+func (s *selectStage) Run(ctx *Context) error {
+	if len(s.selectors) == 0 {
+		return nil
+	}
+
+	selected := make(map[string]bool)
+	for id, comp := range ctx.IR.Components {
+		if s.matches(comp) {
+			selected[id] = true
+		}
+	}
+	if len(selected) == 0 {
+		names := make([]string, len(s.selectors))
+		for n, sel := range s.selectors {
+			names[n] = sel.String()
+		}
+		return fmt.Errorf("no component matches %s", strings.Join(names, " and "))
+	}
+
+	var kept []codegen.Artifact
+	for _, artifact := range ctx.Artifacts {
+		if selected[artifact.ComponentID] {
+			kept = append(kept, artifact)
+		}
+	}
+	ctx.Artifacts = kept
+	return nil
+}
+
+func (s *selectStage) matches(c *ir.Component) bool {
+	for _, sel := range s.selectors {
+		if !sel.Matches(c) {
+			return false
+		}
+	}
+	return true
+}
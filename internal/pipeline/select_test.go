@@ -0,0 +1,114 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Selector
+		wantErr string
+	}{
+		{input: "kind=usecase", want: Selector{Field: "kind", Patterns: []string{"usecase"}}},
+		{input: "label=team:billing,team:search", want: Selector{Field: "label", Patterns: []string{"team:billing", "team:search"}}},
+		{input: "id=usecase.billing.*", want: Selector{Field: "id", Patterns: []string{"usecase.billing.*"}}},
+		{input: "usecase", wantErr: "expected kind=, label= or id="},
+		{input: "kind=", wantErr: "expected kind=, label= or id="},
+		{input: "team=billing", wantErr: `unknown field "team"`},
+		{input: "id=[", wantErr: `bad pattern "["`},
+		{input: "kind=usecase,", wantErr: `bad pattern ""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSelector(tt.input)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.input, got.String())
+		})
+	}
+}
+
+func TestSelector_Matches(t *testing.T) {
+	comp := &ir.Component{ID: "usecase.billing.refund", Kind: ir.KindUsecase, Labels: []string{"team:billing", "critical"}}
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"kind=usecase", true},
+		{"kind=middleware,usecase", true},
+		{"kind=middleware", false},
+		{"label=team:billing", true},
+		{"label=team:*", true},
+		{"label=team:search", false},
+		{"id=usecase.billing.*", true},
+		{"id=usecase.*", true},
+		{"id=middleware.*", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			sel, err := ParseSelector(tt.selector)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sel.Matches(comp))
+		})
+	}
+}
+
+func TestSelectStage_Name(t *testing.T) {
+	assert.Equal(t, "select", Select().Name())
+}
+
+func TestSelectStage_KeepsArtifactsOfSelectedComponents(t *testing.T) {
+	// given
+	ctx := &Context{
+		IR: &ir.IR{Components: map[string]*ir.Component{
+			"http.server.api":  {ID: "http.server.api", Kind: ir.KindHTTPServer},
+			"usecase.refund":   {ID: "usecase.refund", Kind: ir.KindUsecase, Labels: []string{"team:billing"}},
+			"usecase.checkout": {ID: "usecase.checkout", Kind: ir.KindUsecase, Labels: []string{"team:orders"}},
+		}},
+		Artifacts: []codegen.Artifact{
+			{Path: "package.json"},
+			{Path: "server.ts", ComponentID: "http.server.api"},
+			{Path: "refund.ts", ComponentID: "usecase.refund"},
+			{Path: "checkout.ts", ComponentID: "usecase.checkout"},
+		},
+	}
+
+	// when
+	err := Select(
+		Selector{Field: "kind", Patterns: []string{"usecase"}},
+		Selector{Field: "label", Patterns: []string{"team:billing"}},
+	).Run(ctx)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, ctx.Artifacts, 1)
+	assert.Equal(t, "refund.ts", ctx.Artifacts[0].Path)
+}
+
+func TestSelectStage_NoMatch(t *testing.T) {
+	// given
+	ctx := &Context{
+		IR:        &ir.IR{Components: map[string]*ir.Component{"usecase.refund": {ID: "usecase.refund", Kind: ir.KindUsecase}}},
+		Artifacts: []codegen.Artifact{{Path: "refund.ts", ComponentID: "usecase.refund"}},
+	}
+
+	// when
+	err := Select(Selector{Field: "kind", Patterns: []string{"postgres"}}).Run(ctx)
+
+	// then
+	assert.EqualError(t, err, "no component matches kind=postgres")
+	assert.Len(t, ctx.Artifacts, 1)
+}
@@ -4,23 +4,56 @@
 package pipeline
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
 
+	"github.com/openboundary/openboundary/internal/adr"
 	"github.com/openboundary/openboundary/internal/codegen"
 	"github.com/openboundary/openboundary/internal/ir"
 	"github.com/openboundary/openboundary/internal/parser"
 	"github.com/openboundary/openboundary/internal/validator"
 )
 
+// Names of the built-in stages, as reported in StageError.Stage and by
+// FailedStage.
+const (
+	StageParse          = "parse"
+	StageValidateSchema = "validate-schema"
+	StageBuildIR        = "build-ir"
+	StageValidateIR     = "validate-ir"
+	StageCheckTarget    = "check-target"
+	StageCheckLinks     = "check-links"
+	StageGenerate       = "generate"
+	StageStamp          = "stamp"
+	StageSelect         = "select"
+	StageScanSecrets    = "scan-secrets"
+	StageRecordADR      = "record-adr"
+	StageLayout         = "layout"
+	StageWorkspace      = "workspace"
+	StageModuleFormat   = "module-format"
+	StageMerge          = "merge"
+	StageCheckEngines   = "check-engines"
+	StageBudget         = "budget"
+	StageWrite          = "write"
+	StageRecordPorts    = "record-ports"
+	StageVerify         = "verify"
+)
+
 // parseStage parses a spec file into an AST.
 type parseStage struct{}
 
 func Parse() Stage { return &parseStage{} }
 
-func (s *parseStage) Name() string { return "parse" }
+func (s *parseStage) Name() string { return StageParse }
 
 func (s *parseStage) Run(ctx *Context) error {
 	p := parser.NewParser(ctx.SpecPath)
@@ -32,17 +65,54 @@ func (s *parseStage) Run(ctx *Context) error {
 	return nil
 }
 
+// SchemaValidator validates a parsed spec against the JSON Schema.
+// *validator.JSONSchemaValidator implements it.
+type SchemaValidator interface {
+	Validate(spec *parser.Spec) []validator.ValidationError
+}
+
+// parseBytesStage parses a spec held in memory.
+type parseBytesStage struct {
+	data []byte
+}
+
+// ParseBytes parses data as the spec at ctx.SpecPath, for callers that
+// receive the spec rather than read it from disk. ctx.SpecPath names it in
+// diagnostics and its directory resolves relative file references.
+func ParseBytes(data []byte) Stage { return &parseBytesStage{data: data} }
+
+func (s *parseBytesStage) Name() string { return StageParse }
+
+func (s *parseBytesStage) Run(ctx *Context) error {
+	spec, err := parser.NewParser(ctx.SpecPath).ParseBytes(s.data)
+	if err != nil {
+		return fmt.Errorf("parse error: %w", err)
+	}
+	ctx.AST = spec
+	return nil
+}
+
 // validateSchemaStage validates the AST against JSON Schema.
-type validateSchemaStage struct{}
+type validateSchemaStage struct {
+	validator SchemaValidator
+}
 
 func ValidateSchema() Stage { return &validateSchemaStage{} }
 
-func (s *validateSchemaStage) Name() string { return "validate-schema" }
+// ValidateSchemaWith validates the AST with v, so that long-running callers
+// can reuse one validator across runs.
+func ValidateSchemaWith(v SchemaValidator) Stage { return &validateSchemaStage{validator: v} }
+
+func (s *validateSchemaStage) Name() string { return StageValidateSchema }
 
 func (s *validateSchemaStage) Run(ctx *Context) error {
-	jsValidator, err := validator.NewJSONSchemaValidator()
-	if err != nil {
-		return fmt.Errorf("failed to initialize schema validator: %w", err)
+	jsValidator := s.validator
+	if jsValidator == nil {
+		v, err := validator.NewJSONSchemaValidator()
+		if err != nil {
+			return fmt.Errorf("failed to initialize schema validator: %w", err)
+		}
+		jsValidator = v
 	}
 
 	schemaErrors := jsValidator.Validate(ctx.AST)
@@ -61,11 +131,14 @@ type buildIRStage struct{}
 
 func BuildIR() Stage { return &buildIRStage{} }
 
-func (s *buildIRStage) Name() string { return "build-ir" }
+func (s *buildIRStage) Name() string { return StageBuildIR }
 
 func (s *buildIRStage) Run(ctx *Context) error {
 	baseDir := filepath.Dir(ctx.SpecPath)
 	builder := ir.NewBuilder().WithBaseDir(baseDir)
+	if ctx.SpecFS != nil {
+		builder.WithFS(ctx.SpecFS)
+	}
 	typedIR, buildErrors := builder.Build(ctx.AST)
 	if len(buildErrors) > 0 {
 		return &StageError{
@@ -75,6 +148,27 @@ func (s *buildIRStage) Run(ctx *Context) error {
 		}
 	}
 	ctx.IR = typedIR
+	return assignPorts(ctx)
+}
+
+// assignPorts assigns the ports declared as auto from the port registry of
+// the spec. A spec read from SpecFS has no registry, so its auto ports are
+// assigned from AutoPortBase.
+func assignPorts(ctx *Context) error {
+	if !hasAutoPorts(ctx.IR) {
+		return nil
+	}
+	registry := &portRegistry{Ports: make(map[string]int)}
+	prefix := filepath.ToSlash(ctx.SpecPath) + "#"
+	if ctx.SpecFS == nil {
+		dir := portRegistryDir(ctx.SpecPath)
+		loaded, err := loadPortRegistry(dir)
+		if err != nil {
+			return &StageError{Stage: StageBuildIR, Message: "port assignment failed", Errors: []error{err}}
+		}
+		registry, prefix = loaded, portKeyPrefix(dir, ctx.SpecPath)
+	}
+	registry.assign(prefix, ctx.IR)
 	return nil
 }
 
@@ -83,7 +177,7 @@ type validateIRStage struct{}
 
 func ValidateIR() Stage { return &validateIRStage{} }
 
-func (s *validateIRStage) Name() string { return "validate-ir" }
+func (s *validateIRStage) Name() string { return StageValidateIR }
 
 func (s *validateIRStage) Run(ctx *Context) error {
 	v := validator.NewIRValidator()
@@ -95,19 +189,73 @@ func (s *validateIRStage) Run(ctx *Context) error {
 			Errors:  toErrors(errs),
 		}
 	}
+	ctx.Warnings = append(ctx.Warnings, toErrors(v.Warnings(ctx.IR))...)
+	return nil
+}
+
+// checkTargetStage fails on what the spec asks of the service that the
+// target cannot generate.
+type checkTargetStage struct {
+	target string
+}
+
+// CheckTarget fails when the spec uses features target cannot enforce.
+func CheckTarget(target string) Stage { return &checkTargetStage{target: target} }
+
+func (s *checkTargetStage) Name() string { return StageCheckTarget }
+
+func (s *checkTargetStage) Run(ctx *Context) error {
+	if errs := validator.CheckTarget(ctx.IR, s.target); len(errs) > 0 {
+		return &StageError{
+			Stage:   s.Name(),
+			Message: "target cannot generate the spec",
+			Errors:  toErrors(errs),
+		}
+	}
+	return nil
+}
+
+// checkLinksStage warns about component links that do not resolve.
+type checkLinksStage struct {
+	client *http.Client
+}
+
+// CheckLinks requests every component link, giving each request timeout
+// to answer.
+func CheckLinks(timeout time.Duration) Stage {
+	return &checkLinksStage{client: &http.Client{Timeout: timeout}}
+}
+
+func (s *checkLinksStage) Name() string { return StageCheckLinks }
+
+func (s *checkLinksStage) Run(ctx *Context) error {
+	reqCtx := ctx.Ctx
+	if reqCtx == nil {
+		reqCtx = context.Background()
+	}
+	ctx.Warnings = append(ctx.Warnings, toErrors(validator.CheckLinks(reqCtx, ctx.IR, s.client))...)
 	return nil
 }
 
 // generateStage resolves generators from a plugin registry and produces artifacts.
 type generateStage struct {
 	newRegistry func() (*codegen.PluginRegistry, error)
+	cache       bool   // Reuse outputs from GeneratorCacheDir
+	compiler    string // Compiler version, part of the cache digests
 }
 
 func Generate(newRegistry func() (*codegen.PluginRegistry, error)) Stage {
 	return &generateStage{newRegistry: newRegistry}
 }
 
-func (s *generateStage) Name() string { return "generate" }
+// GenerateCached is Generate, reusing the output a generator produced in an
+// earlier compile into the output directory while its inputs and the
+// compiler version are unchanged.
+func GenerateCached(newRegistry func() (*codegen.PluginRegistry, error), compiler string) Stage {
+	return &generateStage{newRegistry: newRegistry, cache: true, compiler: compiler}
+}
+
+func (s *generateStage) Name() string { return StageGenerate }
 
 func (s *generateStage) Run(ctx *Context) error {
 	pluginRegistry, err := s.newRegistry()
@@ -115,40 +263,390 @@ func (s *generateStage) Run(ctx *Context) error {
 		return fmt.Errorf("failed to initialize plugin registry: %w", err)
 	}
 
-	generators, err := pluginRegistry.GeneratorsForIR(ctx.IR)
+	plugins := pluginRegistry.PluginsForIR(ctx.IR)
+	generators := make([]codegen.Generator, len(plugins))
+	names := make([]string, len(plugins))
+	for n, plugin := range plugins {
+		generators[n] = plugin.NewGenerator()
+		names[n] = generators[n].Name()
+	}
+
+	// Outputs come from the cache under its lock, which is released while
+	// the other generators run
+	outputs := make([]*codegen.Output, len(generators))
+	var cache *generatorCache
+	var digests []string
+	if s.cache && ctx.OutputDir != "" {
+		if digests, err = generatorDigests(ctx.IR, plugins, s.compiler); err != nil {
+			return fmt.Errorf("failed to hash generator inputs: %w", err)
+		}
+		lockErr := withGeneratorCacheLock(ctx.OutputDir, func() error {
+			cache = loadGeneratorCache(ctx.OutputDir)
+			for n, name := range names {
+				if output, ok := cache.lookup(ctx.OutputDir, name, digests[n]); ok {
+					outputs[n] = output
+					ctx.Cached = append(ctx.Cached, name)
+				}
+			}
+			return nil
+		})
+		if lockErr != nil {
+			ctx.Warnings = append(ctx.Warnings, lockErr)
+			cache = nil
+		}
+	}
+	var run []codegen.Generator
+	var runIndex []int
+	for n, gen := range generators {
+		if outputs[n] == nil {
+			run = append(run, gen)
+			runIndex = append(runIndex, n)
+		}
+	}
+
+	// Generators, and the components of component-scoped generators, run
+	// concurrently; outputs are planned in generator order.
+	ran, err := codegen.RunGenerators(ctx.Ctx, ctx.IR, run, runtime.GOMAXPROCS(0), ctx.hooks())
 	if err != nil {
-		return fmt.Errorf("failed to resolve generators: %w", err)
+		if cancelErr := ctx.Err(); cancelErr != nil {
+			return cancelErr
+		}
+		return err
+	}
+	for k, n := range runIndex {
+		outputs[n] = ran[k]
 	}
 
 	planner := codegen.NewArtifactPlanner()
-	for _, gen := range generators {
-		output, genErr := gen.Generate(ctx.IR)
-		if genErr != nil {
-			return fmt.Errorf("generator %s failed: %w", gen.Name(), genErr)
-		}
-		if planErr := planner.AddOutput(gen.Name(), output); planErr != nil {
+	for n, gen := range generators {
+		if planErr := planner.AddOutput(gen.Name(), outputs[n]); planErr != nil {
 			return fmt.Errorf("artifact planning failed for %s: %w", gen.Name(), planErr)
 		}
+		ctx.Generators = append(ctx.Generators, gen.Name())
+	}
+
+	// The cache only saves work, so failing to update it does not fail the
+	// compile.
+	if cache != nil && len(run) > 0 {
+		saveErr := withGeneratorCacheLock(ctx.OutputDir, func() error {
+			return cache.save(ctx.OutputDir, names, digests, outputs)
+		})
+		if saveErr != nil {
+			ctx.Warnings = append(ctx.Warnings, saveErr)
+		}
 	}
 
 	ctx.Artifacts = planner.Artifacts()
 	return nil
 }
 
+// stampStage records the compiler version and spec hash in the generated
+// files, for provenance checks between deployed services and their spec.
+type stampStage struct {
+	version       string
+	now           func() time.Time
+	buildInfoFile func(codegen.BuildInfo) codegen.Artifact
+}
+
+// Stamp returns a stage that adds the provenance line of codegen.Stamp to
+// every generated file with a banner, and the file made by buildInfoFile
+// when it is not nil. The generation time is only recorded when now is not
+// nil, so that by default the same spec and compiler give the same output.
+func Stamp(version string, now func() time.Time, buildInfoFile func(codegen.BuildInfo) codegen.Artifact) Stage {
+	return &stampStage{version: version, now: now, buildInfoFile: buildInfoFile}
+}
+
+func (s *stampStage) Name() string { return StageStamp }
+
+func (s *stampStage) Run(ctx *Context) error {
+	hash, err := ctx.AST.SemanticHash()
+	if err != nil {
+		return fmt.Errorf("failed to hash spec: %w", err)
+	}
+	info := codegen.BuildInfo{Version: s.version, SpecHash: hash}
+	if s.now != nil {
+		info.GeneratedAt = s.now()
+	}
+
+	if s.buildInfoFile != nil {
+		ctx.Artifacts = append(ctx.Artifacts, s.buildInfoFile(info))
+	}
+	for n := range ctx.Artifacts {
+		ctx.Artifacts[n].Content = codegen.Stamp(ctx.Artifacts[n].Content, info)
+	}
+	return nil
+}
+
+// scanSecretsStage warns about generated artifacts that contain credentials.
+type scanSecretsStage struct{}
+
+func ScanSecrets() Stage { return &scanSecretsStage{} }
+
+func (s *scanSecretsStage) Name() string { return StageScanSecrets }
+
+func (s *scanSecretsStage) Run(ctx *Context) error {
+	for _, artifact := range ctx.Artifacts {
+		ctx.Warnings = append(ctx.Warnings, toErrors(validator.ScanArtifactSecrets(artifact.Path, artifact.Content))...)
+	}
+	return nil
+}
+
+// checkEnginesStage warns about dependencies of the generated packages that
+// need a newer Node.js than the packages target. It runs after merge, so it
+// also checks the dependencies users added.
+type checkEnginesStage struct{}
+
+func CheckEngines() Stage { return &checkEnginesStage{} }
+
+func (s *checkEnginesStage) Name() string { return StageCheckEngines }
+
+func (s *checkEnginesStage) Run(ctx *Context) error {
+	for _, artifact := range ctx.Artifacts {
+		ctx.Warnings = append(ctx.Warnings, toErrors(validator.CheckNodeEngines(artifact.Path, artifact.Content))...)
+	}
+	return nil
+}
+
+// recordADRStage writes an ADR stub when components changed since the last
+// compile into the output directory. It only runs when the spec sets docs.adr.
+type recordADRStage struct {
+	now func() time.Time
+}
+
+func RecordADR() Stage { return &recordADRStage{now: time.Now} }
+
+func (s *recordADRStage) Name() string { return StageRecordADR }
+
+func (s *recordADRStage) Run(ctx *Context) error {
+	if ctx.AST == nil || ctx.AST.Docs == nil || !ctx.AST.Docs.ADR {
+		return nil
+	}
+
+	snapshot, err := adr.Take(ctx.AST)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot components: %w", err)
+	}
+	encoded, err := snapshot.Encode()
+	if err != nil {
+		return fmt.Errorf("failed to encode component snapshot: %w", err)
+	}
+	ctx.Artifacts = append(ctx.Artifacts, codegen.Artifact{Owner: StageRecordADR, Path: adr.SnapshotPath, Content: encoded})
+
+	// The first compile only records the snapshot to diff against
+	prev, err := adr.Load(filepath.Join(ctx.OutputDir, adr.SnapshotPath))
+	if err != nil || prev == nil {
+		return err
+	}
+	changes := adr.Diff(prev, snapshot)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	number, err := adr.NextNumber(filepath.Join(ctx.OutputDir, adr.Dir))
+	if err != nil {
+		return err
+	}
+	path, content := adr.Stub(number, s.now(), filepath.Base(ctx.SpecPath), changes)
+	ctx.Artifacts = append(ctx.Artifacts, codegen.Artifact{Owner: StageRecordADR, Path: path, Content: content})
+	return nil
+}
+
+// recordPortsStage records the ports of the compiled spec in its port
+// registry, so its auto ports keep their values and the auto ports of other
+// specs avoid them. Specs without auto ports only update a registry that
+// exists.
+type recordPortsStage struct{}
+
+func RecordPorts() Stage { return &recordPortsStage{} }
+
+func (s *recordPortsStage) Name() string { return StageRecordPorts }
+
+func (s *recordPortsStage) Run(ctx *Context) error {
+	if ctx.IR == nil || ctx.SpecFS != nil {
+		return nil
+	}
+	dir := portRegistryDir(ctx.SpecPath)
+	if _, err := os.Stat(filepath.Join(dir, PortRegistryFile)); err != nil && !hasAutoPorts(ctx.IR) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(PortRegistryFile)), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(PortRegistryFile), err)
+	}
+	release, err := acquireFileLock(filepath.Join(dir, PortRegistryFile+".lock"), cacheLockTimeout, cacheLockStale)
+	if err != nil {
+		return fmt.Errorf("failed to lock port registry: %w", err)
+	}
+	defer release()
+
+	// Read again under the lock, in case another compile recorded its ports
+	registry, err := loadPortRegistry(dir)
+	if err != nil {
+		return err
+	}
+	prefix := portKeyPrefix(dir, ctx.SpecPath)
+	if registry.record(prefix, ctx.IR) {
+		if err := registry.save(dir); err != nil {
+			return err
+		}
+	}
+	for _, conflict := range registry.conflicts(prefix) {
+		ctx.Warnings = append(ctx.Warnings, errors.New(conflict))
+	}
+	return nil
+}
+
+// layoutStage rearranges generated artifacts, e.g. to colocate component files.
+type layoutStage struct {
+	arrange func([]codegen.Artifact) ([]codegen.Artifact, error)
+}
+
+func Layout(arrange func([]codegen.Artifact) ([]codegen.Artifact, error)) Stage {
+	return &layoutStage{arrange: arrange}
+}
+
+func (s *layoutStage) Name() string { return StageLayout }
+
+func (s *layoutStage) Run(ctx *Context) error {
+	artifacts, err := s.arrange(ctx.Artifacts)
+	if err != nil {
+		return fmt.Errorf("failed to arrange artifacts: %w", err)
+	}
+	ctx.Artifacts = artifacts
+	return nil
+}
+
+// workspaceStage adapts the generated project files to a package of an
+// enclosing workspace.
+type workspaceStage struct {
+	adapt func([]codegen.Artifact) ([]codegen.Artifact, error)
+}
+
+// Workspace returns a stage that adapts the artifacts with adapt, e.g. to
+// scope the package name. It runs before the merge stage, so user edits to
+// the adapted files are merged as usual.
+func Workspace(adapt func([]codegen.Artifact) ([]codegen.Artifact, error)) Stage {
+	return &workspaceStage{adapt: adapt}
+}
+
+func (s *workspaceStage) Name() string { return StageWorkspace }
+
+func (s *workspaceStage) Run(ctx *Context) error {
+	artifacts, err := s.adapt(ctx.Artifacts)
+	if err != nil {
+		return fmt.Errorf("failed to adapt artifacts to the workspace: %w", err)
+	}
+	ctx.Artifacts = artifacts
+	return nil
+}
+
+// moduleFormatStage adapts the generated files to the module format of the
+// package, e.g. to build CommonJS.
+type moduleFormatStage struct {
+	adapt func([]codegen.Artifact) ([]codegen.Artifact, error)
+}
+
+// ModuleFormat returns a stage that adapts the artifacts with adapt. It runs
+// after the layout stage, so it sees the final path of every file.
+func ModuleFormat(adapt func([]codegen.Artifact) ([]codegen.Artifact, error)) Stage {
+	return &moduleFormatStage{adapt: adapt}
+}
+
+func (s *moduleFormatStage) Name() string { return StageModuleFormat }
+
+func (s *moduleFormatStage) Run(ctx *Context) error {
+	artifacts, err := s.adapt(ctx.Artifacts)
+	if err != nil {
+		return fmt.Errorf("failed to adapt artifacts to the module format: %w", err)
+	}
+	ctx.Artifacts = artifacts
+	return nil
+}
+
+// MergeBaseDir is where the output directory keeps the last generated
+// content of merged files, relative to its root.
+const MergeBaseDir = ".openboundary/base"
+
+// mergeStage merges user edits to generated JSON files into their new
+// content, so that edits such as added dependencies survive a compile.
+type mergeStage struct {
+	paths map[string]bool
+}
+
+// Merge returns a stage that merges the artifacts at paths, relative to the
+// output directory, with the files already there. See codegen.MergeJSON.
+func Merge(paths ...string) Stage {
+	s := &mergeStage{paths: make(map[string]bool, len(paths))}
+	for _, p := range paths {
+		s.paths[p] = true
+	}
+	return s
+}
+
+func (s *mergeStage) Name() string { return StageMerge }
+
+func (s *mergeStage) Run(ctx *Context) error {
+	var bases []codegen.Artifact
+	for n := range ctx.Artifacts {
+		artifact := &ctx.Artifacts[n]
+		if !s.paths[artifact.Path] {
+			continue
+		}
+		artifact.Strategy = codegen.StrategyMerge
+		basePath := path.Join(MergeBaseDir, artifact.Path)
+		bases = append(bases, codegen.Artifact{Owner: artifact.Owner, Path: basePath, Content: artifact.Content, ComponentID: artifact.ComponentID})
+
+		current, err := os.ReadFile(filepath.Join(ctx.OutputDir, artifact.Path))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", artifact.Path, err)
+		}
+		base, err := os.ReadFile(filepath.Join(ctx.OutputDir, basePath))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to read %s: %w", basePath, err)
+		}
+
+		merged, conflicts, err := codegen.MergeJSON(base, artifact.Content, current)
+		if err != nil {
+			return fmt.Errorf("failed to merge %s: %w (fix or delete it to regenerate)", artifact.Path, err)
+		}
+		artifact.Content = merged
+		for _, c := range conflicts {
+			ctx.Warnings = append(ctx.Warnings, fmt.Errorf("%s: %w", artifact.Path, c))
+		}
+	}
+	ctx.Artifacts = append(ctx.Artifacts, bases...)
+	return nil
+}
+
 // writeStage writes artifacts to the output directory.
 type writeStage struct{}
 
 func Write() Stage { return &writeStage{} }
 
-func (s *writeStage) Name() string { return "write" }
+func (s *writeStage) Name() string { return StageWrite }
 
+// Run writes each artifact atomically, so an interrupted compile never
+// leaves a partly written file. Files that already hold the artifact's
+// content are skipped, keeping their modification time unless ctx.Touch is
+// set. When the run is cancelled it stops before the next file and restores
+// the files it already wrote.
 func (s *writeStage) Run(ctx *Context) error {
 	absOutput, err := filepath.Abs(ctx.OutputDir)
 	if err != nil {
 		return fmt.Errorf("failed to resolve output directory: %w", err)
 	}
 
+	var written []writtenFile
 	for _, artifact := range ctx.Artifacts {
+		if err := ctx.Err(); err != nil {
+			if rollbackErr := rollback(written); rollbackErr != nil {
+				return fmt.Errorf("%w during write, and restoring the output failed: %v", err, rollbackErr)
+			}
+			return fmt.Errorf("%w during write, restored %d file(s)", err, len(written))
+		}
+
 		fullPath := filepath.Join(absOutput, artifact.Path)
 
 		// Prevent path traversal: ensure the resolved path stays within the output directory.
@@ -162,15 +660,93 @@ func (s *writeStage) Run(ctx *Context) error {
 			return fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
 
-		if err := os.WriteFile(fullPath, artifact.Content, 0644); err != nil {
-			return fmt.Errorf("failed to write file %s: %w", fullPath, err)
+		previous, err := os.ReadFile(fullPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to read file %s: %w", fullPath, err)
+		}
+		existed := err == nil
+		result := FileResult{
+			Path:        artifact.Path,
+			SHA256:      hashHex(artifact.Content),
+			Size:        len(artifact.Content),
+			Generator:   artifact.Owner,
+			ComponentID: artifact.ComponentID,
+			Strategy:    artifact.Strategy,
+		}
+		if result.Strategy == "" {
+			result.Strategy = codegen.StrategyOverwrite
+		}
+		if existed && bytes.Equal(previous, artifact.Content) {
+			result.Skipped = true
+			if ctx.Touch {
+				now := time.Now()
+				if err := os.Chtimes(fullPath, now, now); err != nil {
+					return fmt.Errorf("failed to touch file %s: %w", fullPath, err)
+				}
+			}
+		} else {
+			if err := writeFileAtomic(fullPath, artifact.Content); err != nil {
+				return fmt.Errorf("failed to write file %s: %w", fullPath, err)
+			}
+			written = append(written, writtenFile{path: fullPath, previous: previous, existed: existed})
+		}
+		ctx.Files = append(ctx.Files, result)
+
+		if !ctx.Quiet {
+			if result.Skipped {
+				fmt.Printf("  = %s (unchanged)\n", artifact.Path)
+			} else {
+				fmt.Printf("  → %s\n", artifact.Path)
+			}
 		}
-
-		fmt.Printf("  → %s\n", artifact.Path)
 	}
 	return nil
 }
 
+// writtenFile remembers what a written file held before, for rollback.
+type writtenFile struct {
+	path     string
+	previous []byte
+	existed  bool
+}
+
+// rollback restores written files to their previous content, newest first,
+// and removes the ones that did not exist.
+func rollback(written []writtenFile) error {
+	var errs []error
+	for n := len(written) - 1; n >= 0; n-- {
+		f := written[n]
+		if f.existed {
+			errs = append(errs, writeFileAtomic(f.path, f.previous))
+		} else {
+			errs = append(errs, os.Remove(f.path))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// writeFileAtomic writes content to a temporary file next to path and
+// renames it into place.
+func writeFileAtomic(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
 // toErrors converts a slice of ValidationErrors to a slice of errors.
 func toErrors(ves []validator.ValidationError) []error {
 	errs := make([]error, len(ves))
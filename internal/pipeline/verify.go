@@ -0,0 +1,50 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"fmt"
+)
+
+// Checker checks generated files, for example that they parse.
+type Checker interface {
+	// Checks reports whether the checker applies to the file at path.
+	Checks(path string) bool
+	// Check returns the first problem in the file's content, or nil.
+	Check(path string, content []byte) error
+}
+
+// verifyStage checks the written artifacts with a Checker.
+type verifyStage struct {
+	checker Checker
+}
+
+// Verify returns a stage that runs checker over every artifact it applies
+// to and fails with one error per broken file, naming the generator that
+// produced it.
+func Verify(checker Checker) Stage { return &verifyStage{checker: checker} }
+
+func (s *verifyStage) Name() string { return StageVerify }
+
+func (s *verifyStage) Run(ctx *Context) error {
+	var errs []error
+	for _, artifact := range ctx.Artifacts {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !s.checker.Checks(artifact.Path) {
+			continue
+		}
+		if err := s.checker.Check(artifact.Path, artifact.Content); err != nil {
+			if artifact.Owner != "" {
+				err = fmt.Errorf("%w (generated by %s)", err, artifact.Owner)
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return &StageError{Stage: StageVerify, Message: "generated files failed verification", Errors: errs}
+	}
+	return nil
+}
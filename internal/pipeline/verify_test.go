@@ -0,0 +1,54 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package pipeline
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openboundary/openboundary/internal/codegen"
+)
+
+// fakeChecker rejects .ts files containing "broken".
+type fakeChecker struct{}
+
+func (fakeChecker) Checks(path string) bool { return strings.HasSuffix(path, ".ts") }
+
+func (fakeChecker) Check(path string, content []byte) error {
+	if strings.Contains(string(content), "broken") {
+		return errors.New(path + ": unexpected token")
+	}
+	return nil
+}
+
+func TestVerify(t *testing.T) {
+	// given
+	ctx := &Context{Artifacts: []codegen.Artifact{
+		{Owner: "typescript-server", Path: "src/a.ts", Content: []byte("broken")},
+		{Owner: "typescript-usecase", Path: "src/b.ts", Content: []byte("export {};")},
+		{Owner: "typescript-project", Path: "README.md", Content: []byte("broken")},
+		{Path: "src/c.ts", Content: []byte("broken")},
+	}}
+
+	// when
+	err := Verify(fakeChecker{}).Run(ctx)
+
+	// then
+	var stageErr *StageError
+	require.ErrorAs(t, err, &stageErr)
+	assert.Equal(t, StageVerify, stageErr.Stage)
+	require.Len(t, stageErr.Errors, 2)
+	assert.EqualError(t, stageErr.Errors[0], "src/a.ts: unexpected token (generated by typescript-server)")
+	assert.EqualError(t, stageErr.Errors[1], "src/c.ts: unexpected token")
+}
+
+func TestVerify_Passes(t *testing.T) {
+	ctx := &Context{Artifacts: []codegen.Artifact{{Path: "src/a.ts", Content: []byte("export {};")}}}
+
+	assert.NoError(t, Verify(fakeChecker{}).Run(ctx))
+}
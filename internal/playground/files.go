@@ -0,0 +1,56 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package playground
+
+import (
+	"bytes"
+	"io/fs"
+	"path"
+	"time"
+)
+
+// files is a read-only fs.FS over the files a playground spec references,
+// keyed by cleaned slash-separated path.
+type files map[string][]byte
+
+// newFiles cleans the names of contents, so "./api/openapi.yaml" is found
+// under the cleaned path the IR builder and OpenAPI parser open.
+func newFiles(contents map[string][]byte) files {
+	fsys := make(files, len(contents))
+	for name, content := range contents {
+		fsys[path.Clean(name)] = content
+	}
+	return fsys
+}
+
+// Open opens the named file. Directories are not listed.
+func (f files) Open(name string) (fs.File, error) {
+	content, ok := f[name]
+	if !fs.ValidPath(name) || !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &file{Reader: bytes.NewReader(content), info: fileInfo{name: path.Base(name), size: int64(len(content))}}, nil
+}
+
+// file is an open file of files.
+type file struct {
+	*bytes.Reader
+	info fileInfo
+}
+
+func (f *file) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *file) Close() error               { return nil }
+
+// fileInfo describes a file of files.
+type fileInfo struct {
+	name string
+	size int64
+}
+
+func (i fileInfo) Name() string       { return i.name }
+func (i fileInfo) Size() int64        { return i.size }
+func (i fileInfo) Mode() fs.FileMode  { return 0444 }
+func (i fileInfo) ModTime() time.Time { return time.Time{} }
+func (i fileInfo) IsDir() bool        { return false }
+func (i fileInfo) Sys() any           { return nil }
@@ -0,0 +1,47 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package playground validates specs without touching the file system, for
+// the WebAssembly build behind the in-browser spec playground.
+package playground
+
+import (
+	"context"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/pipeline"
+)
+
+// SpecPath names the checked spec in diagnostics.
+const SpecPath = "spec.yaml"
+
+// Result is the outcome of checking a spec.
+type Result struct {
+	Valid       bool                  `json:"valid"`
+	Diagnostics []pipeline.Diagnostic `json:"diagnostics"`
+	Graph       *ir.Graph             `json:"graph"` // Nil unless the IR could be built
+}
+
+// Check parses and validates spec and returns its diagnostics, in lang, and
+// its dependency graph. files holds the files the spec references, such as
+// OpenAPI documents, by path relative to the spec as written in it
+// ("./api/openapi.yaml") or cleaned ("api/openapi.yaml"); nothing else is read.
+func Check(ctx context.Context, spec []byte, files map[string][]byte, lang string) *Result {
+	pc := &pipeline.Context{SpecPath: SpecPath, SpecFS: newFiles(files), Quiet: true, Ctx: ctx}
+	err := pipeline.New(
+		pipeline.ParseBytes(spec),
+		pipeline.ValidateSchema(),
+		pipeline.BuildIR(),
+		pipeline.ValidateIR(),
+	).Run(pc)
+
+	result := &Result{Valid: err == nil, Diagnostics: pipeline.Diagnostics(pc, err, lang)}
+	if result.Diagnostics == nil {
+		result.Diagnostics = []pipeline.Diagnostic{}
+	}
+	// A spec that fails semantic validation still has a graph worth drawing
+	if pc.IR != nil {
+		result.Graph = pc.IR.Graph()
+	}
+	return result
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package playground
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/validator"
+)
+
+const spec = `version: "0.1.0"
+name: orders
+components:
+  - id: http.server.api
+    kind: http.server
+    spec:
+      framework: hono
+      port: 3000
+      openapi: ./api/openapi.yaml
+  - id: usecase.list-orders
+    kind: usecase
+    spec:
+      binds_to: http.server.api:GET:/orders
+      goal: List orders
+`
+
+const openAPI = `openapi: 3.0.0
+info: {title: Orders, version: "1"}
+paths:
+  /orders:
+    get:
+      operationId: listOrders
+      responses:
+        "200": {description: ok}
+`
+
+func TestCheck(t *testing.T) {
+	// given
+	files := map[string][]byte{"api/openapi.yaml": []byte(openAPI)}
+
+	// when
+	result := Check(context.Background(), []byte(spec), files, "en")
+
+	// then
+	if !result.Valid || len(result.Diagnostics) != 0 {
+		t.Fatalf("Check() = %+v, expected a valid spec", result)
+	}
+	if result.Graph == nil || len(result.Graph.Components) != 2 {
+		t.Fatalf("Graph = %+v, expected two components", result.Graph)
+	}
+	if deps := result.Graph.Components[1].Dependencies; len(deps) != 1 || deps[0] != "http.server.api" {
+		t.Errorf("usecase.list-orders dependencies = %v, expected [http.server.api]", deps)
+	}
+}
+
+func TestCheck_DotSlashFileNames(t *testing.T) {
+	// given: file names as written in the spec
+	files := map[string][]byte{"./api/openapi.yaml": []byte(openAPI)}
+
+	// when
+	result := Check(context.Background(), []byte(spec), files, "en")
+
+	// then
+	if !result.Valid || len(result.Diagnostics) != 0 {
+		t.Fatalf("Check() = %+v, expected ./api/openapi.yaml to be found", result)
+	}
+}
+
+func TestCheck_MissingFile(t *testing.T) {
+	// when
+	result := Check(context.Background(), []byte(spec), nil, "en")
+
+	// then
+	if result.Valid || len(result.Diagnostics) == 0 {
+		t.Fatalf("Check() = %+v, expected the OpenAPI file to be missing", result)
+	}
+	if !strings.Contains(result.Diagnostics[0].Message, "open api/openapi.yaml: file does not exist") {
+		t.Errorf("Diagnostics[0].Message = %q", result.Diagnostics[0].Message)
+	}
+	if result.Graph != nil {
+		t.Errorf("Graph = %+v, expected none without an IR", result.Graph)
+	}
+}
+
+func TestCheck_InvalidSpecKeepsGraph(t *testing.T) {
+	// given
+	invalid := strings.Replace(spec, "      openapi: ./api/openapi.yaml\n", "", 1) +
+		"    deprecated: true\n    replacement: usecase.list-all\n"
+
+	// when
+	result := Check(context.Background(), []byte(invalid), nil, "de")
+
+	// then
+	if result.Valid || len(result.Diagnostics) != 1 {
+		t.Fatalf("Check() = %+v, expected one error", result)
+	}
+	d := result.Diagnostics[0]
+	if d.Component != "usecase.list-orders" || d.MessageID != string(validator.MsgReplacementUnknown) || d.File != SpecPath {
+		t.Errorf("Diagnostics[0] = %+v, expected the unknown replacement in %s", d, SpecPath)
+	}
+	if d.Message == validator.FormatMessage("en", validator.MsgReplacementUnknown, "usecase.list-all") {
+		t.Errorf("Message = %q, expected it in German", d.Message)
+	}
+	if result.Graph == nil || len(result.Graph.Components) != 2 {
+		t.Errorf("Graph = %+v, expected the graph of the invalid spec", result.Graph)
+	}
+}
@@ -11,19 +11,29 @@ type Kind string
 
 // Known component kinds.
 const (
-	KindHTTPServer Kind = "http.server"
-	KindMiddleware Kind = "middleware"
-	KindPostgres   Kind = "postgres"
-	KindUsecase    Kind = "usecase"
+	KindHTTPServer  Kind = "http.server"
+	KindHTTPGateway Kind = "http.gateway"
+	KindMiddleware  Kind = "middleware"
+	KindPostgres    Kind = "postgres"
+	KindUsecase     Kind = "usecase"
+	KindWebhook     Kind = "webhook"
+	KindHTTPClient  Kind = "http.client"
+	KindCron        Kind = "cron"
+	KindExternal    Kind = "external"
 )
 
 // AllKinds returns all known component kinds.
 func AllKinds() []Kind {
 	return []Kind{
 		KindHTTPServer,
+		KindHTTPGateway,
 		KindMiddleware,
 		KindPostgres,
 		KindUsecase,
+		KindWebhook,
+		KindHTTPClient,
+		KindCron,
+		KindExternal,
 	}
 }
 
@@ -9,7 +9,7 @@ import (
 
 func TestAllKinds(t *testing.T) {
 	kinds := AllKinds()
-	expected := []Kind{KindHTTPServer, KindMiddleware, KindPostgres, KindUsecase}
+	expected := []Kind{KindHTTPServer, KindHTTPGateway, KindMiddleware, KindPostgres, KindUsecase, KindWebhook, KindHTTPClient, KindCron, KindExternal}
 
 	if len(kinds) != len(expected) {
 		t.Errorf("AllKinds() returned %d kinds, expected %d", len(kinds), len(expected))
@@ -29,9 +29,13 @@ func TestIsValidKind(t *testing.T) {
 		expected bool
 	}{
 		{"http.server is valid", KindHTTPServer, true},
+		{"http.gateway is valid", KindHTTPGateway, true},
 		{"middleware is valid", KindMiddleware, true},
 		{"postgres is valid", KindPostgres, true},
 		{"usecase is valid", KindUsecase, true},
+		{"webhook is valid", KindWebhook, true},
+		{"http.client is valid", KindHTTPClient, true},
+		{"cron is valid", KindCron, true},
 		{"unknown kind is invalid", Kind("unknown"), false},
 		{"empty kind is invalid", Kind(""), false},
 		{"http.server.extra is invalid", Kind("http.server.extra"), false},
@@ -0,0 +1,20 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package schema
+
+// CronSchema validates cron component specs.
+type CronSchema struct{}
+
+// Kind returns the component kind.
+func (s *CronSchema) Kind() Kind {
+	return KindCron
+}
+
+// Validate validates the cron spec.
+func (s *CronSchema) Validate(spec map[string]interface{}) error {
+	// TODO: Implement validation
+	// Required fields: schedule
+	// Optional fields: depends_on
+	return nil
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package schema
+
+import (
+	"testing"
+)
+
+func TestCronSchema_Kind(t *testing.T) {
+	s := &CronSchema{}
+	if s.Kind() != KindCron {
+		t.Errorf("Kind() = %q, expected %q", s.Kind(), KindCron)
+	}
+}
+
+func TestCronSchema_Validate(t *testing.T) {
+	s := &CronSchema{}
+	err := s.Validate(map[string]interface{}{
+		"schedule":   "0 3 * * *",
+		"depends_on": []interface{}{"postgres.primary"},
+	})
+	if err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+func TestCronSchema_ImplementsSchema(t *testing.T) {
+	var _ Schema = &CronSchema{}
+}
@@ -0,0 +1,18 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package schema
+
+// ExternalSchema validates external component specs.
+type ExternalSchema struct{}
+
+// Kind returns the component kind.
+func (s *ExternalSchema) Kind() Kind {
+	return KindExternal
+}
+
+// Validate validates the external spec. The spec is opaque apart from the
+// optional depends_on, so any object is accepted.
+func (s *ExternalSchema) Validate(spec map[string]interface{}) error {
+	return nil
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package schema
+
+import (
+	"testing"
+)
+
+func TestExternalSchema_Kind(t *testing.T) {
+	s := &ExternalSchema{}
+	if s.Kind() != KindExternal {
+		t.Errorf("Kind() = %q, expected %q", s.Kind(), KindExternal)
+	}
+}
+
+func TestExternalSchema_Validate(t *testing.T) {
+	s := &ExternalSchema{}
+	err := s.Validate(map[string]interface{}{
+		"depends_on": []interface{}{"postgres.legacy"},
+		"system":     "billing-mainframe",
+	})
+	if err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+func TestExternalSchema_ImplementsSchema(t *testing.T) {
+	var _ Schema = &ExternalSchema{}
+}
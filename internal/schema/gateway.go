@@ -0,0 +1,30 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package schema
+
+import "fmt"
+
+// HTTPGatewaySchema validates http.gateway component specs.
+type HTTPGatewaySchema struct{}
+
+// Kind returns the component kind.
+func (s *HTTPGatewaySchema) Kind() Kind {
+	return KindHTTPGateway
+}
+
+// Validate validates the http.gateway spec.
+func (s *HTTPGatewaySchema) Validate(spec map[string]interface{}) error {
+	// TODO: Implement validation
+	// Required fields: port, routes
+	// Optional fields: auth
+
+	if _, ok := spec["port"]; !ok {
+		return fmt.Errorf("http.gateway requires 'port' field")
+	}
+	if _, ok := spec["routes"]; !ok {
+		return fmt.Errorf("http.gateway requires 'routes' field")
+	}
+
+	return nil
+}
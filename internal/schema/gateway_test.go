@@ -0,0 +1,60 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package schema
+
+import (
+	"testing"
+)
+
+func TestHTTPGatewaySchema_Kind(t *testing.T) {
+	s := &HTTPGatewaySchema{}
+	if s.Kind() != KindHTTPGateway {
+		t.Errorf("Kind() = %q, expected %q", s.Kind(), KindHTTPGateway)
+	}
+}
+
+func TestHTTPGatewaySchema_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        map[string]interface{}
+		expectError bool
+	}{
+		{
+			name: "valid spec",
+			spec: map[string]interface{}{
+				"port":   8080,
+				"routes": []interface{}{map[string]interface{}{"prefix": "/orders", "server": "http.server.orders"}},
+			},
+			expectError: false,
+		},
+		{
+			name:        "missing port",
+			spec:        map[string]interface{}{"routes": []interface{}{}},
+			expectError: true,
+		},
+		{
+			name:        "missing routes",
+			spec:        map[string]interface{}{"port": 8080},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &HTTPGatewaySchema{}
+			err := s.Validate(tt.spec)
+
+			if tt.expectError && err == nil {
+				t.Error("Validate() expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestHTTPGatewaySchema_ImplementsSchema(t *testing.T) {
+	var _ Schema = &HTTPGatewaySchema{}
+}
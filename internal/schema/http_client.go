@@ -0,0 +1,20 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package schema
+
+// HTTPClientSchema validates http.client component specs.
+type HTTPClientSchema struct{}
+
+// Kind returns the component kind.
+func (s *HTTPClientSchema) Kind() Kind {
+	return KindHTTPClient
+}
+
+// Validate validates the http.client spec.
+func (s *HTTPClientSchema) Validate(spec map[string]interface{}) error {
+	// TODO: Implement validation
+	// Required fields: base_url_env
+	// Optional fields: resilience
+	return nil
+}
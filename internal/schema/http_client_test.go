@@ -0,0 +1,30 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package schema
+
+import (
+	"testing"
+)
+
+func TestHTTPClientSchema_Kind(t *testing.T) {
+	s := &HTTPClientSchema{}
+	if s.Kind() != KindHTTPClient {
+		t.Errorf("Kind() = %q, expected %q", s.Kind(), KindHTTPClient)
+	}
+}
+
+func TestHTTPClientSchema_Validate(t *testing.T) {
+	s := &HTTPClientSchema{}
+	err := s.Validate(map[string]interface{}{
+		"base_url_env": "PAYMENTS_URL",
+		"resilience":   map[string]interface{}{"retries": 3},
+	})
+	if err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+func TestHTTPClientSchema_ImplementsSchema(t *testing.T) {
+	var _ Schema = &HTTPClientSchema{}
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package schema
+
+// WebhookSchema validates webhook component specs.
+type WebhookSchema struct{}
+
+// Kind returns the component kind.
+func (s *WebhookSchema) Kind() Kind {
+	return KindWebhook
+}
+
+// Validate validates the webhook spec.
+func (s *WebhookSchema) Validate(spec map[string]interface{}) error {
+	// TODO: Implement validation
+	// Required fields: url_env, secret_env, events
+	// Optional fields: retry
+	return nil
+}
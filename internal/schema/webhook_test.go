@@ -0,0 +1,31 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package schema
+
+import (
+	"testing"
+)
+
+func TestWebhookSchema_Kind(t *testing.T) {
+	s := &WebhookSchema{}
+	if s.Kind() != KindWebhook {
+		t.Errorf("Kind() = %q, expected %q", s.Kind(), KindWebhook)
+	}
+}
+
+func TestWebhookSchema_Validate(t *testing.T) {
+	s := &WebhookSchema{}
+	err := s.Validate(map[string]interface{}{
+		"url_env":    "ORDERS_WEBHOOK_URL",
+		"secret_env": "ORDERS_WEBHOOK_SECRET",
+		"events":     []interface{}{map[string]interface{}{"name": "order.created"}},
+	})
+	if err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+func TestWebhookSchema_ImplementsSchema(t *testing.T) {
+	var _ Schema = &WebhookSchema{}
+}
@@ -0,0 +1,420 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package sqlite writes SQLite database files, so data bound exports can be
+// queried with SQL without a database driver. It writes a new database of
+// plain tables in one pass, in the file format documented at
+// https://www.sqlite.org/fileformat2.html, and reads none.
+package sqlite
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// pageSize is the size of every page of the database; no bytes of a page
+// are reserved, so all of it is usable.
+const pageSize = 4096
+
+// Page types of table b-trees.
+const (
+	interiorPage = 0x05
+	leafPage     = 0x0d
+)
+
+// sqliteVersion is the SQLite version the header claims wrote the file.
+const sqliteVersion = 3045000
+
+// Column is a column of a table. Type is its declared type: "TEXT" or
+// "INTEGER".
+type Column struct {
+	Name string
+	Type string
+}
+
+// Text returns a TEXT column.
+func Text(name string) Column {
+	return Column{Name: name, Type: "TEXT"}
+}
+
+// Integer returns an INTEGER column, which also holds booleans as 0 and 1.
+func Integer(name string) Column {
+	return Column{Name: name, Type: "INTEGER"}
+}
+
+// Table is a table of a Database and the rows inserted into it.
+type Table struct {
+	name    string
+	columns []Column
+	rows    [][]any
+}
+
+// Database is a database being built in memory.
+type Database struct {
+	tables []*Table
+}
+
+// New returns an empty database.
+func New() *Database {
+	return &Database{}
+}
+
+var identifierPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// CreateTable adds a table. Names are lowercase identifiers, so they need no
+// quoting in SQL.
+func (db *Database) CreateTable(name string, columns ...Column) (*Table, error) {
+	if !identifierPattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid table name %q", name)
+	}
+	for _, table := range db.tables {
+		if table.name == name {
+			return nil, fmt.Errorf("table %s already exists", name)
+		}
+	}
+	for _, column := range columns {
+		if !identifierPattern.MatchString(column.Name) {
+			return nil, fmt.Errorf("table %s: invalid column name %q", name, column.Name)
+		}
+		if column.Type != "TEXT" && column.Type != "INTEGER" {
+			return nil, fmt.Errorf("table %s: column %s has unsupported type %q", name, column.Name, column.Type)
+		}
+	}
+	table := &Table{name: name, columns: columns}
+	db.tables = append(db.tables, table)
+	return table, nil
+}
+
+// Insert adds a row with a value for each column: a string, an int, an
+// int64, a bool (stored as 0 or 1) or nil for NULL.
+func (t *Table) Insert(values ...any) error {
+	if len(values) != len(t.columns) {
+		return fmt.Errorf("table %s: %d values for %d columns", t.name, len(values), len(t.columns))
+	}
+	row := make([]any, len(values))
+	for n, value := range values {
+		switch v := value.(type) {
+		case nil, string, int64:
+			row[n] = v
+		case int:
+			row[n] = int64(v)
+		case bool:
+			row[n] = int64(0)
+			if v {
+				row[n] = int64(1)
+			}
+		default:
+			return fmt.Errorf("table %s: column %s: unsupported value of type %T", t.name, t.columns[n].Name, value)
+		}
+	}
+	t.rows = append(t.rows, row)
+	return nil
+}
+
+// sql returns the statement that creates the table, as kept in the schema.
+func (t *Table) sql() string {
+	columns := make([]string, len(t.columns))
+	for n, column := range t.columns {
+		columns[n] = column.Name + " " + column.Type
+	}
+	return fmt.Sprintf("CREATE TABLE %s(%s)", t.name, strings.Join(columns, ", "))
+}
+
+// WriteFile writes the database to path, replacing the file atomically.
+func (db *Database) WriteFile(path string) error {
+	data, err := db.Bytes()
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Bytes returns the database file.
+func (db *Database) Bytes() ([]byte, error) {
+	// Page 1 holds the file header and the schema table, whose rows need
+	// the root pages of the other tables, so it is written last.
+	w := &writer{pages: [][]byte{nil}}
+	schema := make([][]byte, 0, len(db.tables))
+	for n, table := range db.tables {
+		cells := make([][]byte, len(table.rows))
+		for r, row := range table.rows {
+			cells[r] = w.leafCell(int64(r+1), encodeRecord(row))
+		}
+		root := w.tree(cells)
+		record := encodeRecord([]any{"table", table.name, table.name, int64(root), table.sql()})
+		schema = append(schema, w.leafCell(int64(n+1), record))
+	}
+
+	page, ok := leafPageBytes(schema, 100)
+	if !ok {
+		return nil, fmt.Errorf("the schema of %d tables does not fit on the first page", len(db.tables))
+	}
+	writeHeader(page, len(w.pages))
+	w.pages[0] = page
+
+	out := make([]byte, 0, len(w.pages)*pageSize)
+	for _, page := range w.pages {
+		out = append(out, page...)
+	}
+	return out, nil
+}
+
+// writeHeader writes the database file header to the start of page 1.
+func writeHeader(page []byte, pages int) {
+	copy(page, "SQLite format 3\x00")
+	binary.BigEndian.PutUint16(page[16:], pageSize)
+	page[18], page[19] = 1, 1                 // Legacy (rollback journal) write and read versions
+	page[20] = 0                              // Reserved bytes per page
+	page[21], page[22], page[23] = 64, 32, 32 // Payload fractions, fixed by the format
+	binary.BigEndian.PutUint32(page[24:], 1)  // File change counter
+	binary.BigEndian.PutUint32(page[28:], uint32(pages))
+	binary.BigEndian.PutUint32(page[40:], 1) // Schema cookie
+	binary.BigEndian.PutUint32(page[44:], 4) // Schema format
+	binary.BigEndian.PutUint32(page[56:], 1) // UTF-8
+	binary.BigEndian.PutUint32(page[92:], 1) // Version-valid-for, the change counter
+	binary.BigEndian.PutUint32(page[96:], sqliteVersion)
+}
+
+// writer allocates the pages of a database after page 1.
+type writer struct {
+	pages [][]byte // Page n is pages[n-1]
+}
+
+// allocate adds a page and returns its number.
+func (w *writer) allocate(page []byte) int {
+	w.pages = append(w.pages, page)
+	return len(w.pages)
+}
+
+// leafCell returns the cell of a row on a table leaf page, moving the part
+// of the payload that does not fit the page to overflow pages.
+func (w *writer) leafCell(rowid int64, payload []byte) []byte {
+	cell := putVarint(nil, uint64(len(payload)))
+	cell = putVarint(cell, uint64(rowid))
+	local := localPayload(len(payload))
+	cell = append(cell, payload[:local]...)
+	if local == len(payload) {
+		return cell
+	}
+	return binary.BigEndian.AppendUint32(cell, uint32(w.overflow(payload[local:])))
+}
+
+// overflow writes data to a chain of overflow pages and returns the first.
+func (w *writer) overflow(data []byte) int {
+	const capacity = pageSize - 4
+	var first, previous int
+	for len(data) > 0 {
+		n := min(len(data), capacity)
+		page := make([]byte, pageSize)
+		copy(page[4:], data[:n])
+		data = data[n:]
+		number := w.allocate(page)
+		if previous == 0 {
+			first = number
+		} else {
+			binary.BigEndian.PutUint32(w.pages[previous-1], uint32(number))
+		}
+		previous = number
+	}
+	return first
+}
+
+// localPayload returns how many bytes of a payload of size p a table leaf
+// cell keeps, the rest going to overflow pages.
+func localPayload(p int) int {
+	const (
+		maxLocal = pageSize - 35
+		minLocal = (pageSize-12)*32/255 - 23
+	)
+	if p <= maxLocal {
+		return p
+	}
+	if k := minLocal + (p-minLocal)%(pageSize-4); k <= maxLocal {
+		return k
+	}
+	return minLocal
+}
+
+// child is a page of a b-tree and the largest rowid under it.
+type child struct {
+	page   int
+	maxRow int64
+}
+
+// tree writes a table b-tree holding cells, the leaf cells of rowids 1 to
+// len(cells) in order, and returns its root page.
+func (w *writer) tree(cells [][]byte) int {
+	var level []child
+	for start := 0; ; {
+		end, used := start, 0
+		for end < len(cells) && used+len(cells[end])+2 <= pageSize-8 {
+			used += len(cells[end]) + 2
+			end++
+		}
+		page, _ := leafPageBytes(cells[start:end], 0)
+		level = append(level, child{page: w.allocate(page), maxRow: int64(end)})
+		if end == len(cells) {
+			break
+		}
+		start = end
+	}
+
+	for len(level) > 1 {
+		var next []child
+		for len(level) > 0 {
+			// All children but the last of a page get a cell; the last is
+			// its right-most pointer
+			n, used := 1, 0
+			for n < len(level) {
+				size := 4 + len(putVarint(nil, uint64(level[n-1].maxRow))) + 2
+				if used+size > pageSize-12 {
+					break
+				}
+				used += size
+				n++
+			}
+			page := interiorPageBytes(level[:n])
+			next = append(next, child{page: w.allocate(page), maxRow: level[n-1].maxRow})
+			level = level[n:]
+		}
+		level = next
+	}
+	return level[0].page
+}
+
+// leafPageBytes returns a table leaf page holding cells, with its b-tree
+// header at offset, or false if they do not fit.
+func leafPageBytes(cells [][]byte, offset int) ([]byte, bool) {
+	page := make([]byte, pageSize)
+	page[offset] = leafPage
+	return page, placeCells(page, offset, 8, cells)
+}
+
+// interiorPageBytes returns a table interior page pointing to children.
+func interiorPageBytes(children []child) []byte {
+	cells := make([][]byte, len(children)-1)
+	for n, c := range children[:len(children)-1] {
+		cell := binary.BigEndian.AppendUint32(nil, uint32(c.page))
+		cells[n] = putVarint(cell, uint64(c.maxRow))
+	}
+	page := make([]byte, pageSize)
+	page[0] = interiorPage
+	binary.BigEndian.PutUint32(page[8:], uint32(children[len(children)-1].page))
+	placeCells(page, 0, 12, cells)
+	return page
+}
+
+// placeCells writes cells to the end of a page, their pointers after its
+// b-tree header at offset, and the header's counts, or reports false if they
+// do not fit.
+func placeCells(page []byte, offset, headerSize int, cells [][]byte) bool {
+	top := len(page)
+	pointers := offset + headerSize
+	for n, cell := range cells {
+		if top-len(cell) < pointers+2*(n+1) {
+			return false
+		}
+		top -= len(cell)
+		copy(page[top:], cell)
+		binary.BigEndian.PutUint16(page[pointers+2*n:], uint16(top))
+	}
+	binary.BigEndian.PutUint16(page[offset+3:], uint16(len(cells)))
+	binary.BigEndian.PutUint16(page[offset+5:], uint16(top))
+	return true
+}
+
+// encodeRecord returns the record format of a row: a header of serial types
+// followed by the values.
+func encodeRecord(values []any) []byte {
+	var types, body []byte
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+			types = putVarint(types, 0)
+		case string:
+			types = putVarint(types, uint64(13+2*len(v)))
+			body = append(body, v...)
+		case int64:
+			serial, size := integerSerialType(v)
+			types = putVarint(types, serial)
+			for n := size - 1; n >= 0; n-- {
+				body = append(body, byte(v>>(8*n)))
+			}
+		}
+	}
+
+	// The header size counts its own varint
+	headerSize := len(types) + 1
+	for len(putVarint(nil, uint64(headerSize)))+len(types) != headerSize {
+		headerSize++
+	}
+	record := putVarint(nil, uint64(headerSize))
+	record = append(record, types...)
+	return append(record, body...)
+}
+
+// integerSerialType returns the serial type of the smallest encoding of v
+// and its size in bytes.
+func integerSerialType(v int64) (uint64, int) {
+	switch {
+	case v == 0:
+		return 8, 0
+	case v == 1:
+		return 9, 0
+	case v >= -1<<7 && v < 1<<7:
+		return 1, 1
+	case v >= -1<<15 && v < 1<<15:
+		return 2, 2
+	case v >= -1<<23 && v < 1<<23:
+		return 3, 3
+	case v >= -1<<31 && v < 1<<31:
+		return 4, 4
+	case v >= -1<<47 && v < 1<<47:
+		return 5, 6
+	default:
+		return 6, 8
+	}
+}
+
+// putVarint appends the SQLite varint encoding of v: big-endian groups of
+// seven bits, the high bit set on all bytes but the last, and a ninth byte
+// of eight bits for values above 56 bits.
+func putVarint(buf []byte, v uint64) []byte {
+	if v > 1<<56-1 {
+		var b [9]byte
+		b[8] = byte(v)
+		v >>= 8
+		for n := 7; n >= 0; n-- {
+			b[n] = byte(v&0x7f) | 0x80
+			v >>= 7
+		}
+		return append(buf, b[:]...)
+	}
+	var b [9]byte
+	n := 8
+	b[n] = byte(v & 0x7f)
+	for v >>= 7; v > 0; v >>= 7 {
+		n--
+		b[n] = byte(v&0x7f) | 0x80
+	}
+	return append(buf, b[n:]...)
+}
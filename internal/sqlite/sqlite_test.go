@@ -0,0 +1,200 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package sqlite
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readTable returns the rows of the table b-tree rooted at page root, in
+// rowid order, decoding what the writer encodes.
+func readTable(t *testing.T, data []byte, root int) [][]any {
+	t.Helper()
+	page := data[(root-1)*pageSize : root*pageSize]
+	offset := 0
+	if root == 1 {
+		offset = 100
+	}
+	cells := int(binary.BigEndian.Uint16(page[offset+3:]))
+
+	var rows [][]any
+	switch page[offset] {
+	case interiorPage:
+		for n := 0; n < cells; n++ {
+			cell := binary.BigEndian.Uint16(page[offset+12+2*n:])
+			rows = append(rows, readTable(t, data, int(binary.BigEndian.Uint32(page[cell:])))...)
+		}
+		return append(rows, readTable(t, data, int(binary.BigEndian.Uint32(page[offset+8:])))...)
+	case leafPage:
+		for n := 0; n < cells; n++ {
+			cell := page[binary.BigEndian.Uint16(page[offset+8+2*n:]):]
+			size, k := readVarint(cell)
+			_, r := readVarint(cell[k:])
+			cell = cell[k+r:]
+			local := localPayload(int(size))
+			payload := append([]byte{}, cell[:local]...)
+			for next := 0; local < int(size); {
+				if next == 0 {
+					next = int(binary.BigEndian.Uint32(cell[local:]))
+				}
+				overflow := data[(next-1)*pageSize : next*pageSize]
+				payload = append(payload, overflow[4:4+min(pageSize-4, int(size)-len(payload))]...)
+				next = int(binary.BigEndian.Uint32(overflow))
+				if len(payload) == int(size) {
+					break
+				}
+			}
+			rows = append(rows, decodeRecord(payload))
+		}
+		return rows
+	}
+	t.Fatalf("page %d has unknown type %#x", root, page[offset])
+	return nil
+}
+
+func readVarint(b []byte) (uint64, int) {
+	var v uint64
+	for n := 0; n < 8; n++ {
+		v = v<<7 | uint64(b[n]&0x7f)
+		if b[n]&0x80 == 0 {
+			return v, n + 1
+		}
+	}
+	return v<<8 | uint64(b[8]), 9
+}
+
+func decodeRecord(record []byte) []any {
+	headerSize, n := readVarint(record)
+	header, body := record[n:headerSize], record[headerSize:]
+	var values []any
+	for len(header) > 0 {
+		serial, k := readVarint(header)
+		header = header[k:]
+		switch {
+		case serial == 0:
+			values = append(values, nil)
+		case serial == 8, serial == 9:
+			values = append(values, int64(serial-8))
+		case serial >= 13:
+			size := int(serial-13) / 2
+			values = append(values, string(body[:size]))
+			body = body[size:]
+		default:
+			size := []int{0, 1, 2, 3, 4, 6, 8}[serial]
+			v := int64(int8(body[0]))
+			for _, b := range body[1:size] {
+				v = v<<8 | int64(b)
+			}
+			values = append(values, v)
+			body = body[size:]
+		}
+	}
+	return values
+}
+
+func TestPutVarint(t *testing.T) {
+	tests := []struct {
+		value    uint64
+		expected []byte
+	}{
+		{0, []byte{0x00}},
+		{127, []byte{0x7f}},
+		{128, []byte{0x81, 0x00}},
+		{300, []byte{0x82, 0x2c}},
+		{1<<56 - 1, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}},
+		{1 << 63, []byte{0xc0, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00}},
+	}
+
+	for _, tt := range tests {
+		got := putVarint(nil, tt.value)
+		assert.Equal(t, tt.expected, got, "putVarint(%d)", tt.value)
+		value, n := readVarint(got)
+		assert.Equal(t, tt.value, value)
+		assert.Equal(t, len(got), n)
+	}
+}
+
+func TestEncodeRecord(t *testing.T) {
+	values := []any{nil, int64(0), int64(1), int64(-2), int64(40000), int64(1) << 40, int64(-1) << 62, "é"}
+
+	record := encodeRecord(values)
+
+	assert.Equal(t, values, decodeRecord(record))
+	assert.Equal(t, []byte{9, 0, 8, 9, 1, 3, 5, 6, 17}, record[:9], "header size and serial types")
+}
+
+func TestDatabase_Bytes(t *testing.T) {
+	// given: enough rows for interior pages and a value on overflow pages
+	db := New()
+	items, err := db.CreateTable("items", Column{"id", "INTEGER"}, Column{"name", "TEXT"}, Column{"active", "INTEGER"})
+	require.NoError(t, err)
+	var expected [][]any
+	for n := 0; n < 3000; n++ {
+		name := strings.Repeat("x", n%40)
+		require.NoError(t, items.Insert(n, name, n%2 == 0))
+		expected = append(expected, []any{int64(n), name, int64(1 - n%2)})
+	}
+	docs, err := db.CreateTable("docs", Column{"body", "TEXT"})
+	require.NoError(t, err)
+	long := strings.Repeat("0123456789", 2000)
+	require.NoError(t, docs.Insert(long))
+	require.NoError(t, docs.Insert(nil))
+	_, err = db.CreateTable("empty", Column{"x", "TEXT"})
+	require.NoError(t, err)
+
+	// when
+	data, err := db.Bytes()
+
+	// then
+	require.NoError(t, err)
+	require.Zero(t, len(data)%pageSize)
+	assert.Equal(t, "SQLite format 3\x00", string(data[:16]))
+	assert.Equal(t, uint32(len(data)/pageSize), binary.BigEndian.Uint32(data[28:]), "page count")
+
+	schema := readTable(t, data, 1)
+	require.Len(t, schema, 3)
+	assert.Equal(t, []any{"table", "items", "items"}, schema[0][:3])
+	assert.Equal(t, "CREATE TABLE items(id INTEGER, name TEXT, active INTEGER)", schema[0][4])
+	roots := make(map[string]int)
+	for _, row := range schema {
+		roots[row[1].(string)] = int(row[3].(int64))
+	}
+	assert.Equal(t, expected, readTable(t, data, roots["items"]))
+	assert.Equal(t, [][]any{{long}, {nil}}, readTable(t, data, roots["docs"]))
+	assert.Empty(t, readTable(t, data, roots["empty"]))
+}
+
+func TestDatabase_Errors(t *testing.T) {
+	db := New()
+	_, err := db.CreateTable("Items", Column{"id", "INTEGER"})
+	assert.ErrorContains(t, err, "invalid table name")
+	_, err = db.CreateTable("items", Column{"id", "REAL"})
+	assert.ErrorContains(t, err, "unsupported type")
+	items, err := db.CreateTable("items", Column{"id", "INTEGER"})
+	require.NoError(t, err)
+	_, err = db.CreateTable("items", Column{"id", "INTEGER"})
+	assert.ErrorContains(t, err, "already exists")
+	assert.ErrorContains(t, items.Insert(1, 2), "2 values for 1 columns")
+	assert.ErrorContains(t, items.Insert(1.5), "unsupported value of type float64")
+}
+
+func TestDatabase_WriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.db")
+	db := New()
+	_, err := db.CreateTable("items", Column{"id", "INTEGER"})
+	require.NoError(t, err)
+
+	require.NoError(t, db.WriteFile(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, data, 2*pageSize)
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"encoding/json"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+// RuleNodeVersion identifies warnings for dependencies that need a newer
+// Node.js than the package allows.
+const RuleNodeVersion = "node-version"
+
+// nodeRequirements are the lowest Node.js major versions packages need from
+// a major version of theirs on, as their engines declare. @types/node
+// describes the APIs of the Node.js version it is numbered after.
+var nodeRequirements = []struct {
+	pkg  string
+	from int // First major version of pkg with the requirement
+	node int
+}{
+	{"@types/node", 22, 22},
+	{"@types/node", 24, 24},
+	{"better-sqlite3", 12, 20},
+	{"commander", 14, 20},
+	{"eslint", 10, 20},
+	{"undici", 7, 20},
+	{"vite", 7, 20},
+	{"vitest", 4, 20},
+}
+
+// majorVersionPattern matches the major version a version range starts
+// from, e.g. 22 in "^22.0.0" or ">=22".
+var majorVersionPattern = regexp.MustCompile(`^\s*[\^~>=v]*\s*(\d+)`)
+
+// CheckNodeEngines warns about the dependencies of a package.json at path
+// that need a newer Node.js than its engines field allows. Other files and
+// packages without engines are not checked.
+func CheckNodeEngines(filePath string, content []byte) []ValidationError {
+	if path.Base(filePath) != "package.json" {
+		return nil
+	}
+	var pkg struct {
+		Engines         map[string]string `json:"engines"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if json.Unmarshal(content, &pkg) != nil {
+		return nil
+	}
+	target, ok := majorVersion(pkg.Engines["node"])
+	if !ok {
+		return nil
+	}
+
+	deps := make(map[string]string, len(pkg.Dependencies)+len(pkg.DevDependencies))
+	for name, version := range pkg.DevDependencies {
+		deps[name] = version
+	}
+	for name, version := range pkg.Dependencies {
+		deps[name] = version
+	}
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var warnings []ValidationError
+	for _, name := range names {
+		major, ok := majorVersion(deps[name])
+		if !ok {
+			continue
+		}
+		needed := 0
+		for _, r := range nodeRequirements {
+			if r.pkg == name && major >= r.from && r.node > needed {
+				needed = r.node
+			}
+		}
+		if needed <= target {
+			continue
+		}
+		warning := newError("", MsgNodeDependency, filePath, name, deps[name], needed, target, name)
+		warning.Position = parser.Position{File: filePath}
+		warning.Rule = RuleNodeVersion
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// majorVersion returns the major version a version range starts from.
+func majorVersion(versionRange string) (int, bool) {
+	m := majorVersionPattern.FindStringSubmatch(versionRange)
+	if m == nil {
+		return 0, false
+	}
+	major, err := strconv.Atoi(m[1])
+	return major, err == nil
+}
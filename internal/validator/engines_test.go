@@ -0,0 +1,66 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"testing"
+)
+
+func TestCheckNodeEngines(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "dependency needs a newer node",
+			path:    "package.json",
+			content: `{"engines": {"node": ">=20"}, "devDependencies": {"@types/node": "^22.0.0", "vitest": "^4.0.0"}}`,
+			want:    []string{"package.json: @types/node ^22.0.0 needs Node.js 22 or newer, but engines allows Node.js 20; target a newer node version or depend on an older @types/node"},
+		},
+		{
+			name:    "dependencies fit the target",
+			path:    "services/orders/package.json",
+			content: `{"engines": {"node": ">=22"}, "dependencies": {"hono": "^4.0.0"}, "devDependencies": {"@types/node": "^22.0.0"}}`,
+		},
+		{
+			name:    "older major",
+			path:    "package.json",
+			content: `{"engines": {"node": ">=20"}, "devDependencies": {"@types/node": "~20.11.0", "commander": "^13.0.0"}}`,
+		},
+		{
+			name:    "no engines",
+			path:    "package.json",
+			content: `{"devDependencies": {"@types/node": "^24.0.0"}}`,
+		},
+		{
+			name:    "not a package.json",
+			path:    "src/package.ts",
+			content: `{"engines": {"node": ">=20"}, "devDependencies": {"@types/node": "^24.0.0"}}`,
+		},
+		{
+			name:    "non-numeric range",
+			path:    "package.json",
+			content: `{"engines": {"node": ">=20"}, "dependencies": {"@types/node": "latest"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := CheckNodeEngines(tt.path, []byte(tt.content))
+			if len(warnings) != len(tt.want) {
+				t.Fatalf("CheckNodeEngines() = %v, want %d warnings", warnings, len(tt.want))
+			}
+			for n, w := range warnings {
+				if w.Message != tt.want[n] {
+					t.Errorf("warning %d = %q, want %q", n, w.Message, tt.want[n])
+				}
+				if w.Rule != RuleNodeVersion || w.Position.File != tt.path {
+					t.Errorf("warning %d rule = %q, file = %q", n, w.Rule, w.Position.File)
+				}
+			}
+		})
+	}
+}
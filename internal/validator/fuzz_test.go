@@ -0,0 +1,52 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+// exampleDir holds the example spec and the files it references.
+const exampleDir = "../../examples/basic"
+
+// FuzzValidate runs a spec through schema validation, IR building and IR
+// validation, the way `bound validate` does, and checks that none of them
+// panic. Build runs even when the schema is invalid, so it must cope with
+// any structure the parser accepts. Run with `make fuzz`.
+func FuzzValidate(f *testing.F) {
+	if data, err := os.ReadFile(exampleDir + "/spec.yaml"); err == nil {
+		f.Add(data)
+	}
+	f.Add([]byte("components:\n  - id: a\n    kind: http.server\n    spec: {framework: hono, port: 3000}\n"))
+	f.Add([]byte("components:\n  - id: u\n    kind: usecase\n    spec: {binds_to: 'a:GET:/x', goal: g}\n"))
+	f.Add([]byte("components:\n  - id: m\n    kind: middleware\n    spec: {provider: casbin, depends_on: [m], roles: [{name: r, inherits: [r]}]}\n"))
+	f.Add([]byte("components:\n  - id: m\n    kind: middleware\n    spec: {provider: better-auth, session: {store: x}, oauth: [{provider: github}]}\n"))
+	f.Add([]byte("components:\n  - id: p\n    kind: postgres\n    spec: {provider: drizzle, port: x}\n"))
+	f.Add([]byte("components:\n  - id: x\n    kind: unknown\n    spec: []\n"))
+
+	jsValidator, err := NewJSONSchemaValidator()
+	if err != nil {
+		f.Fatalf("NewJSONSchemaValidator() error = %v", err)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		spec, err := parser.NewParser("spec.yaml").ParseBytes(data)
+		if err != nil {
+			return
+		}
+		jsValidator.Validate(spec)
+
+		i, errs := ir.NewBuilder().WithBaseDir(exampleDir).Build(spec)
+		if len(errs) > 0 {
+			return
+		}
+		v := NewIRValidator()
+		v.Validate(i)
+		v.Warnings(i)
+	})
+}
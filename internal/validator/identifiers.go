@@ -0,0 +1,132 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// idSegmentPattern matches a segment of a component ID that every target can
+// turn into an identifier: ASCII only and starting with a letter, so no
+// generated name starts with a digit or depends on case mapping rules.
+var idSegmentPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*$`)
+
+// validateIdentifiers reports component IDs that cannot be turned into
+// identifiers, and pairs of IDs that the generators would turn into the same
+// file name or identifier. Targets differ in how they case and join words
+// (usecase.get-user is UsecaseGetuser in TypeScript and usecase_get_user in
+// Python), so names are compared ignoring case and separators. Each
+// collision is reported once, on the later ID. External components generate
+// nothing and are skipped.
+func validateIdentifiers(i *ir.IR) []ValidationError {
+	ids := make([]string, 0, len(i.Components))
+	for id, comp := range i.Components {
+		if comp.Kind != ir.KindExternal {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var errs []ValidationError
+	files := make(map[string]string)
+	names := make(map[string]string)
+	functions := make(map[string]string)
+	for _, id := range ids {
+		if !validIDSegments(id) {
+			errs = append(errs, newError(id, MsgIdentifierInvalid, identifierName(id)))
+			continue
+		}
+
+		// IDs with the same file slug also share their identifiers; the
+		// file collision is the one reported.
+		slug := fileSlug(id)
+		key := identifierKey(id)
+		if other, ok := files[slug]; ok {
+			errs = append(errs, newError(id, MsgIdentifierFileCollision, other, slug))
+		} else if other, ok := names[key]; ok {
+			errs = append(errs, newError(id, MsgIdentifierNameCollision, identifierName(id), other))
+		} else {
+			files[slug] = id
+			names[key] = id
+		}
+
+		// Usecase functions are named after the last segment alone and
+		// share a namespace in the servers that import them.
+		if i.Components[id].Kind == ir.KindUsecase {
+			last := id[strings.LastIndex(id, ".")+1:]
+			key := identifierKey(last)
+			if other, ok := functions[key]; ok {
+				errs = append(errs, newError(id, MsgIdentifierNameCollision, usecaseFunctionName(last), other))
+			} else {
+				functions[key] = id
+			}
+		}
+	}
+	return errs
+}
+
+// validIDSegments reports whether every dot-separated segment of id matches
+// idSegmentPattern.
+func validIDSegments(id string) bool {
+	for _, segment := range strings.Split(id, ".") {
+		if !idSegmentPattern.MatchString(segment) {
+			return false
+		}
+	}
+	return true
+}
+
+// fileSlug returns the file name prefix the generators derive from a
+// component ID (e.g., "http.server.api" -> "http-server-api").
+func fileSlug(id string) string {
+	return strings.NewReplacer(".", "-", "/", "-").Replace(id)
+}
+
+// identifierKey normalizes a component ID to the letters and digits that
+// survive in every target's identifiers, lowercased.
+func identifierKey(id string) string {
+	var sb strings.Builder
+	for _, r := range id {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return sb.String()
+}
+
+// identifierName renders a component ID the way the TypeScript generators
+// name its types (e.g., "usecase.get-user" -> "UsecaseGetuser").
+func identifierName(id string) string {
+	var sb strings.Builder
+	for _, segment := range strings.Split(id, ".") {
+		sb.WriteString(strings.ReplaceAll(upperFirst(segment), "-", ""))
+	}
+	return sb.String()
+}
+
+// usecaseFunctionName renders the last segment of a usecase ID the way the
+// TypeScript generators name its function (e.g., "get-user" ->
+// "getUserUsecase").
+func usecaseFunctionName(segment string) string {
+	words := strings.Split(segment, "-")
+	for n := 1; n < len(words); n++ {
+		words[n] = upperFirst(words[n])
+	}
+	return strings.Join(words, "") + "Usecase"
+}
+
+// upperFirst upper-cases the first rune of s.
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
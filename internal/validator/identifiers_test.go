@@ -0,0 +1,111 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+func identifierTestIR(components map[string]ir.Kind) *ir.IR {
+	i := &ir.IR{Components: make(map[string]*ir.Component)}
+	for id, kind := range components {
+		i.Components[id] = &ir.Component{ID: id, Kind: kind}
+	}
+	return i
+}
+
+func TestValidateIdentifiers(t *testing.T) {
+	tests := []struct {
+		name       string
+		components map[string]ir.Kind
+		want       []string
+	}{
+		{
+			name: "distinct",
+			components: map[string]ir.Kind{
+				"http.server.api":     ir.KindHTTPServer,
+				"usecase.get-user":    ir.KindUsecase,
+				"usecase.create-user": ir.KindUsecase,
+			},
+		},
+		{
+			name: "hyphen against camel case",
+			components: map[string]ir.Kind{
+				"usecase.get-user": ir.KindUsecase,
+				"usecase.getUser":  ir.KindUsecase,
+			},
+			want: []string{
+				"usecase.getUser: generates the identifier UsecaseGetUser, as usecase.get-user does; rename one of them",
+				"usecase.getUser: generates the identifier getUserUsecase, as usecase.get-user does; rename one of them",
+			},
+		},
+		{
+			name: "same file slug",
+			components: map[string]ir.Kind{
+				"middleware.a.b-c": ir.KindMiddleware,
+				"middleware.a-b.c": ir.KindMiddleware,
+			},
+			want: []string{
+				"middleware.a.b-c: generates the same file names as middleware.a-b.c (middleware-a-b-c.*); rename one of them",
+			},
+		},
+		{
+			name: "usecase function in different groups",
+			components: map[string]ir.Kind{
+				"usecase.orders.create": ir.KindUsecase,
+				"usecase.users.create":  ir.KindUsecase,
+			},
+			want: []string{
+				"usecase.users.create: generates the identifier createUsecase, as usecase.orders.create does; rename one of them",
+			},
+		},
+		{
+			name: "last segment shared by other kinds",
+			components: map[string]ir.Kind{
+				"middleware.create": ir.KindMiddleware,
+				"usecase.create":    ir.KindUsecase,
+			},
+		},
+		{
+			name: "digit-leading segment",
+			components: map[string]ir.Kind{
+				"usecase.2fa": ir.KindUsecase,
+			},
+			want: []string{
+				"usecase.2fa: component ID generates the identifier Usecase2fa, which is not valid; use lowercase letters, digits and hyphens, starting each segment with a letter",
+			},
+		},
+		{
+			name: "non-ASCII segment",
+			components: map[string]ir.Kind{
+				"usecase.überweisung": ir.KindUsecase,
+			},
+			want: []string{
+				"usecase.überweisung: component ID generates the identifier UsecaseÜberweisung, which is not valid; use lowercase letters, digits and hyphens, starting each segment with a letter",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			i := identifierTestIR(tt.components)
+
+			// when
+			errs := validateIdentifiers(i)
+
+			// then
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateIdentifiers() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,12 +5,24 @@ package validator
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/openboundary/openboundary/internal/casbin"
 	"github.com/openboundary/openboundary/internal/ir"
 	"github.com/openboundary/openboundary/internal/openapi"
+	"github.com/openboundary/openboundary/internal/parser"
 )
 
+// envVarPattern matches environment variable names such as GITHUB_CLIENT_ID.
+var envVarPattern = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// oauthProviders are the OAuth providers the better-auth generator wires up.
+var oauthProviders = map[string]bool{"github": true, "google": true}
+
 // IRValidator validates the IR for semantic correctness.
 // Call after building the IR to check for cycles, required fields,
 // cross-component constraints, etc.
@@ -28,33 +40,317 @@ func (v *IRValidator) Validate(i *ir.IR) []ValidationError {
 	// Check for cycles
 	cycles := i.DetectCycles()
 	for _, cycle := range cycles {
-		errs = append(errs, ValidationError{
-			Message: fmt.Sprintf("dependency cycle: %s", formatCycle(cycle)),
-		})
+		errs = append(errs, newError("", MsgDependencyCycle, formatCycle(cycle)))
 	}
 
 	// Validate each component
 	for _, comp := range i.Components {
 		compErrs := v.validateComponent(i, comp)
 		errs = append(errs, compErrs...)
+		errs = append(errs, validateReplacement(i, comp)...)
+		errs = append(errs, validateResources(comp)...)
+		errs = append(errs, validateFiles(i, comp)...)
 	}
 
 	// Cross-component validations
 	errs = append(errs, v.validateBetterAuthRequirements(i)...)
+	errs = append(errs, validateIdentifiers(i)...)
+	errs = append(errs, validateMiddlewareOrder(i)...)
+	errs = append(errs, validateReplicas(i)...)
+
+	// Credentials must come from the environment, not the spec
+	secretErrs, _ := specSecrets(i)
+	errs = append(errs, secretErrs...)
 
 	return errs
 }
 
+// RuleUnusedComponent identifies warnings for components that are not
+// connected to any other component.
+const RuleUnusedComponent = "unused-component"
+
+// RuleTestsDisabled identifies warnings for usecases with acceptance criteria
+// that generate no tests.
+const RuleTestsDisabled = "tests-disabled"
+
+// RuleDeprecatedReference identifies warnings for references to deprecated
+// components.
+const RuleDeprecatedReference = "deprecated-reference"
+
+// RuleMissingOperationID identifies warnings for bound operations without an
+// operationId.
+const RuleMissingOperationID = "missing-operation-id"
+
+// RuleSLOWithoutMetrics identifies warnings for usecases with an SLO that
+// their server does not measure.
+const RuleSLOWithoutMetrics = "slo-without-metrics"
+
+// RuleOpenAPIUndeclaredPathParameter identifies warnings for path template
+// parameters an OpenAPI document does not declare.
+const RuleOpenAPIUndeclaredPathParameter = "openapi-undeclared-path-parameter"
+
+// RuleOpenAPINoSuccessResponse identifies warnings for OpenAPI operations
+// without a 2xx or default response.
+const RuleOpenAPINoSuccessResponse = "openapi-no-success-response"
+
+// RuleOpenAPIUnsupportedKeyword identifies warnings for OpenAPI schema
+// keywords that code generation ignores.
+const RuleOpenAPIUnsupportedKeyword = "openapi-unsupported-keyword"
+
+// openAPIWarningRules maps the OpenAPI issues that are warnings to their rule.
+var openAPIWarningRules = map[string]string{
+	openapi.IssueUndeclaredPathParameter: RuleOpenAPIUndeclaredPathParameter,
+	openapi.IssueNoSuccessResponse:       RuleOpenAPINoSuccessResponse,
+	openapi.IssueUnsupportedKeyword:      RuleOpenAPIUnsupportedKeyword,
+}
+
+// Warnings reports problems that do not prevent code generation, such as
+// components that nothing references and that reference nothing, references
+// to deprecated components, or spec values that might be credentials.
+func (v *IRValidator) Warnings(i *ir.IR) []ValidationError {
+	connected := make(map[string]bool)
+	for _, edge := range i.Edges {
+		connected[edge.From.ID] = true
+		connected[edge.To.ID] = true
+	}
+
+	ids := make([]string, 0, len(i.Components))
+	for id := range i.Components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var warnings []ValidationError
+	for _, id := range ids {
+		if connected[id] {
+			continue
+		}
+		comp := i.Components[id]
+		warning := newError(id, MsgUnusedComponent, comp.Kind)
+		warning.Position = comp.Position
+		warning.Rule = RuleUnusedComponent
+		warnings = append(warnings, warning)
+	}
+	for _, id := range ids {
+		uc := i.Components[id].Usecase
+		if uc == nil || uc.UnitTests() || uc.E2ETests() != ir.E2ESkip || len(uc.AcceptanceCriteria) == 0 {
+			continue
+		}
+		warning := newError(id, MsgTestsDisabled, len(uc.AcceptanceCriteria))
+		warning.Position = i.Components[id].Position
+		warning.Rule = RuleTestsDisabled
+		warnings = append(warnings, warning)
+	}
+
+	warnings = append(warnings, deprecatedReferences(i)...)
+	warnings = append(warnings, missingOperationIDs(i)...)
+	warnings = append(warnings, openAPIWarnings(i)...)
+	warnings = append(warnings, unmeasuredSLOs(i)...)
+	warnings = append(warnings, specVersionWarnings(i.Spec)...)
+
+	_, secretWarnings := specSecrets(i)
+	return append(warnings, secretWarnings...)
+}
+
+// missingOperationIDs warns about each usecase bound to an operation of an
+// OpenAPI document that has no operationId. Generated types are named after
+// the operationId; without one, compile names them after the usecase and
+// client generators such as orval after the route, so renaming either
+// renames the types and breaks the code that imports them. Synthesized
+// documents always have operationIds.
+func missingOperationIDs(i *ir.IR) []ValidationError {
+	ids := make([]string, 0, len(i.Components))
+	for id := range i.Components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var warnings []ValidationError
+	for _, id := range ids {
+		uc := i.Components[id].Usecase
+		if uc == nil || uc.Binding == nil || uc.Binding.Operation == nil || uc.Binding.Operation.OperationID != "" {
+			continue
+		}
+		server, ok := i.Components[uc.Binding.ServerID]
+		if !ok || server.HTTPServer == nil || server.HTTPServer.ParsedOpenAPI == nil || server.HTTPServer.ParsedOpenAPI.Synthesized {
+			continue
+		}
+		warning := newError(id, MsgOperationIDMissing, uc.Binding.Method, uc.Binding.Path, server.HTTPServer.OpenAPI)
+		warning.Position = i.Components[id].Position
+		warning.Rule = RuleMissingOperationID
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// openAPIWarnings reports the issues of each server's OpenAPI document that
+// leave it usable for code generation.
+func openAPIWarnings(i *ir.IR) []ValidationError {
+	var warnings []ValidationError
+	for _, server := range i.HTTPServers() {
+		if server.HTTPServer.ParsedOpenAPI == nil {
+			continue
+		}
+		for _, issue := range server.HTTPServer.ParsedOpenAPI.Issues {
+			if !issue.Warning() {
+				continue
+			}
+			warning := openAPIIssue(server, issue)
+			warning.Rule = openAPIWarningRules[issue.Code]
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
+
+// unmeasuredSLOs warns about each bound usecase with an SLO whose server
+// does not record metrics, so nothing measures the objective.
+func unmeasuredSLOs(i *ir.IR) []ValidationError {
+	ids := make([]string, 0, len(i.Components))
+	for id := range i.Components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var warnings []ValidationError
+	for _, id := range ids {
+		uc := i.Components[id].Usecase
+		if uc == nil || uc.SLO == nil || uc.Binding == nil {
+			continue
+		}
+		server, ok := i.Components[uc.Binding.ServerID]
+		if !ok || server.HTTPServer == nil || server.HTTPServer.Metrics != nil {
+			continue
+		}
+		warning := newError(id, MsgSLOWithoutMetrics, server.ID)
+		warning.Position = i.Components[id].Position
+		warning.Rule = RuleSLOWithoutMetrics
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// deprecatedReferences warns about each component that references a
+// deprecated one.
+func deprecatedReferences(i *ir.IR) []ValidationError {
+	var warnings []ValidationError
+	for _, ref := range i.DeprecatedReferences() {
+		warnings = append(warnings, DeprecatedReferenceWarning(ref))
+	}
+	return warnings
+}
+
+// DeprecatedReferenceWarning returns the warning validation reports for
+// ref, naming the replacement of the deprecated component if it has one.
+func DeprecatedReferenceWarning(ref ir.DeprecatedReference) ValidationError {
+	warning := newError(ref.From.ID, MsgDeprecatedReference, ref.To.Kind, ref.To.ID)
+	if ref.To.Replacement != "" {
+		warning = newError(ref.From.ID, MsgDeprecatedReferenceReplacement, ref.To.Kind, ref.To.ID, ref.To.Replacement)
+	}
+	warning.Position = ref.From.Position
+	warning.Rule = RuleDeprecatedReference
+	return warning
+}
+
+// validateReplacement checks that a component's replacement is another
+// component of the same kind, set only on a deprecated component.
+func validateReplacement(i *ir.IR, comp *ir.Component) []ValidationError {
+	if comp.Replacement == "" {
+		return nil
+	}
+	if !comp.Deprecated {
+		return []ValidationError{newError(comp.ID, MsgReplacementNotDeprecated)}
+	}
+	replacement, ok := i.Components[comp.Replacement]
+	if !ok {
+		return []ValidationError{newError(comp.ID, MsgReplacementUnknown, comp.Replacement)}
+	}
+	if replacement.Kind != comp.Kind {
+		return []ValidationError{newError(comp.ID, MsgReplacementKind, comp.Replacement, replacement.Kind, comp.Kind)}
+	}
+	return nil
+}
+
+// validateResources checks that a component's resources size something
+// that is deployed and fall in ranges a deployment can schedule.
+func validateResources(comp *ir.Component) []ValidationError {
+	r := comp.Resources
+	if r == nil {
+		return nil
+	}
+	switch comp.Kind {
+	case ir.KindHTTPServer, ir.KindHTTPGateway, ir.KindPostgres:
+	default:
+		return []ValidationError{newError(comp.ID, MsgResourcesKind, comp.Kind)}
+	}
+
+	var errs []ValidationError
+	if r.MilliCPU != 0 && (r.MilliCPU < 10 || r.MilliCPU > 64000) {
+		errs = append(errs, newError(comp.ID, MsgResourcesCPURange, r.MilliCPU))
+	}
+	if r.MemoryMiB != 0 && (r.MemoryMiB < 64 || r.MemoryMiB > 256*1024) {
+		errs = append(errs, newError(comp.ID, MsgResourcesMemoryRange, r.MemoryMiB))
+	}
+	if r.Replicas < 0 || r.Replicas > 100 {
+		errs = append(errs, newError(comp.ID, MsgResourcesReplicasRange, r.Replicas))
+	} else if r.Replicas > 1 && comp.Kind == ir.KindPostgres {
+		errs = append(errs, newError(comp.ID, MsgResourcesPostgresReplicas))
+	}
+	return errs
+}
+
+// validateReplicas checks that servers and gateways, which the generated
+// code runs in one process, ask for the same number of replicas.
+func validateReplicas(i *ir.IR) []ValidationError {
+	ids := make([]string, 0, len(i.Components))
+	for id := range i.Components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var errs []ValidationError
+	var first *ir.Component
+	for _, id := range ids {
+		comp := i.Components[id]
+		if comp.Kind != ir.KindHTTPServer && comp.Kind != ir.KindHTTPGateway {
+			continue
+		}
+		if comp.Resources == nil || comp.Resources.Replicas == 0 {
+			continue
+		}
+		if first == nil {
+			first = comp
+			continue
+		}
+		if comp.Resources.Replicas != first.Resources.Replicas {
+			errs = append(errs, newError(comp.ID, MsgResourcesReplicasDiffer, comp.Resources.Replicas, first.Resources.Replicas, first.ID))
+		}
+	}
+	return errs
+}
+
 func (v *IRValidator) validateComponent(i *ir.IR, comp *ir.Component) []ValidationError {
 	switch comp.Kind {
 	case ir.KindHTTPServer:
 		return v.validateHTTPServer(i, comp)
+	case ir.KindHTTPGateway:
+		return v.validateHTTPGateway(i, comp)
 	case ir.KindMiddleware:
-		return v.validateMiddleware(comp)
+		return v.validateMiddleware(i, comp)
 	case ir.KindPostgres:
 		return v.validatePostgres(comp)
 	case ir.KindUsecase:
 		return v.validateUsecase(i, comp)
+	case ir.KindWebhook:
+		return v.validateWebhook(comp)
+	case ir.KindHTTPClient:
+		return v.validateHTTPClient(comp)
+	case ir.KindCron:
+		return v.validateCron(i, comp)
+	case ir.KindExternal:
+		if comp.External == nil {
+			return []ValidationError{newError(comp.ID, MsgMissingSpec, ir.KindExternal)}
+		}
 	}
 	return nil
 }
@@ -64,24 +360,37 @@ func (v *IRValidator) validateHTTPServer(i *ir.IR, comp *ir.Component) []Validat
 	s := comp.HTTPServer
 
 	if s == nil {
-		return []ValidationError{{ID: comp.ID, Message: "missing http.server spec"}}
+		return []ValidationError{newError(comp.ID, MsgMissingSpec, ir.KindHTTPServer)}
 	}
 
 	if s.Framework == "" {
-		errs = append(errs, ValidationError{ID: comp.ID, Message: "missing required field: framework"})
+		errs = append(errs, newError(comp.ID, MsgMissingField, "framework"))
 	}
 	if s.Port < 1 || s.Port > 65535 {
-		errs = append(errs, ValidationError{ID: comp.ID, Message: "port must be between 1 and 65535"})
+		errs = append(errs, newError(comp.ID, MsgPortRange))
+	}
+	if s.BasePath != "" && (!strings.HasPrefix(s.BasePath, "/") || strings.HasSuffix(s.BasePath, "/")) {
+		errs = append(errs, newError(comp.ID, MsgBasePathFormat, s.BasePath))
+	}
+	if s.TLS != nil {
+		errs = append(errs, v.validateTLS(comp)...)
 	}
 
 	// Validate middleware references point to middleware components
 	for _, ref := range s.Middleware {
 		if sym, ok := i.Symbols.Lookup(ref); ok {
 			if sym.Kind != ir.KindMiddleware {
-				errs = append(errs, ValidationError{
-					ID:      comp.ID,
-					Message: fmt.Sprintf("middleware reference %q points to %s, expected middleware", ref, sym.Kind),
-				})
+				errs = append(errs, newError(comp.ID, MsgReferenceKind, "middleware", ref, sym.Kind, ir.KindMiddleware))
+			}
+		}
+	}
+
+	// Report semantic problems found in the referenced OpenAPI document;
+	// Warnings reports the ones that leave it usable
+	if s.ParsedOpenAPI != nil {
+		for _, issue := range s.ParsedOpenAPI.Issues {
+			if !issue.Warning() {
+				errs = append(errs, openAPIIssue(comp, issue))
 			}
 		}
 	}
@@ -89,33 +398,314 @@ func (v *IRValidator) validateHTTPServer(i *ir.IR, comp *ir.Component) []Validat
 	return errs
 }
 
-func (v *IRValidator) validateMiddleware(comp *ir.Component) []ValidationError {
+// openAPIIssue reports an issue of a server's OpenAPI document at its
+// location in the document.
+func openAPIIssue(server *ir.Component, issue openapi.Issue) ValidationError {
+	s := server.HTTPServer
+	return ValidationError{
+		ID:      server.ID,
+		Message: fmt.Sprintf("%s:%d:%d: %s", s.OpenAPI, issue.Line, issue.Column, issue),
+		Path:    issue.Location,
+		Position: parser.Position{
+			File:   s.ParsedOpenAPI.File,
+			Line:   issue.Line,
+			Column: issue.Column,
+		},
+	}
+}
+
+// validateFiles checks that the files a component references stay within
+// the root of the IR's file system, when it reads through one.
+func validateFiles(i *ir.IR, comp *ir.Component) []ValidationError {
+	if i.FS == nil {
+		return nil
+	}
+	var errs []ValidationError
+	for _, file := range comp.Files() {
+		if _, err := ir.FSPath(file); err != nil {
+			errs = append(errs, newError(comp.ID, MsgFileOutsideRoot, file))
+		}
+	}
+	return errs
+}
+
+// validateTLS checks that a server's certificate and key are referenced by
+// environment variable name, so neither the key nor its path is in the spec.
+func (v *IRValidator) validateTLS(comp *ir.Component) []ValidationError {
+	var errs []ValidationError
+	s := comp.HTTPServer.TLS
+
+	prefix := strings.ToUpper(strings.ReplaceAll(comp.ID[strings.LastIndex(comp.ID, ".")+1:], "-", "_"))
+	fields := []struct{ name, value, example string }{
+		{"cert_env", s.CertEnv, prefix + "_TLS_CERT"},
+		{"key_env", s.KeyEnv, prefix + "_TLS_KEY"},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			errs = append(errs, newError(comp.ID, MsgMissingField, "tls."+f.name))
+			continue
+		}
+		if !envVarPattern.MatchString(f.value) {
+			errs = append(errs, newError(comp.ID, MsgTLSInlineValue, f.name, f.value, f.example))
+		}
+	}
+	if s.CertEnv != "" && s.CertEnv == s.KeyEnv {
+		errs = append(errs, newError(comp.ID, MsgTLSSameVariable))
+	}
+	return errs
+}
+
+func (v *IRValidator) validateMiddleware(i *ir.IR, comp *ir.Component) []ValidationError {
 	var errs []ValidationError
 	s := comp.Middleware
 
 	if s == nil {
-		return []ValidationError{{ID: comp.ID, Message: "missing middleware spec"}}
+		return []ValidationError{newError(comp.ID, MsgMissingSpec, ir.KindMiddleware)}
+	}
+	// The providers of a chain are components of their own, validated in turn
+	if len(s.Chain) > 0 {
+		return nil
 	}
 
 	if s.Provider == "" {
-		errs = append(errs, ValidationError{ID: comp.ID, Message: "missing required field: provider"})
+		errs = append(errs, newError(comp.ID, MsgMissingField, "provider"))
 	}
 
 	// Provider-specific validation
 	switch s.Provider {
 	case "better-auth":
 		if s.Config == "" {
-			errs = append(errs, ValidationError{ID: comp.ID, Message: "better-auth provider requires config field"})
+			errs = append(errs, newError(comp.ID, MsgProviderRequiresField, "better-auth", "config"))
 		}
+		errs = append(errs, v.validateBetterAuthSession(i, comp)...)
+		errs = append(errs, v.validateOAuthProviders(comp)...)
 	case "casbin":
 		if s.Model == "" {
-			errs = append(errs, ValidationError{ID: comp.ID, Message: "casbin provider requires model field"})
+			errs = append(errs, newError(comp.ID, MsgProviderRequiresField, "casbin", "model"))
 		}
 		if s.Policy == "" {
-			errs = append(errs, ValidationError{ID: comp.ID, Message: "casbin provider requires policy field"})
+			errs = append(errs, newError(comp.ID, MsgProviderRequiresField, "casbin", "policy"))
 		}
+		errs = append(errs, v.validateCasbinPolicyAdapter(i, comp)...)
+		errs = append(errs, v.validateCasbinModel(comp)...)
+		errs = append(errs, v.validateRBAC(comp)...)
 	}
 
+	if s.Provider != "casbin" {
+		if s.PolicyAdapter != "" {
+			errs = append(errs, newError(comp.ID, MsgProviderOnlyField, "policy_adapter", "casbin"))
+		}
+		if s.AdminRoute != "" {
+			errs = append(errs, newError(comp.ID, MsgProviderOnlyField, "admin_route", "casbin"))
+		}
+		if len(s.Permissions) > 0 || len(s.Roles) > 0 {
+			errs = append(errs, newError(comp.ID, MsgProviderOnlyRBAC))
+		}
+	}
+	if s.Provider != "better-auth" {
+		if s.Session != nil {
+			errs = append(errs, newError(comp.ID, MsgProviderOnlyField, "session", "better-auth"))
+		}
+		if len(s.OAuth) > 0 {
+			errs = append(errs, newError(comp.ID, MsgProviderOnlyField, "oauth", "better-auth"))
+		}
+	}
+
+	return errs
+}
+
+// validateBetterAuthSession checks that database session storage points at a
+// postgres component and that other storages do not name one.
+func (v *IRValidator) validateBetterAuthSession(i *ir.IR, comp *ir.Component) []ValidationError {
+	s := comp.Middleware.Session
+	if s == nil {
+		return nil
+	}
+
+	switch s.Storage {
+	case ir.SessionStorageDatabase:
+		if s.Store == "" {
+			return []ValidationError{newError(comp.ID, MsgSessionStoreRequired)}
+		}
+		if dep, ok := i.Components[s.Store]; ok && dep.Kind != ir.KindPostgres {
+			return []ValidationError{newError(comp.ID, MsgSessionStoreKind, s.Store, dep.Kind)}
+		}
+	case ir.SessionStorageRedis, ir.SessionStorageCookie:
+		if s.Store != "" {
+			return []ValidationError{newError(comp.ID, MsgSessionStoreUnused, s.Storage)}
+		}
+	default:
+		return []ValidationError{newError(comp.ID, MsgSessionStorageUnsupported, s.Storage)}
+	}
+	return nil
+}
+
+// validateOAuthProviders checks that OAuth credentials are referenced by
+// environment variable name rather than inlined into the spec.
+func (v *IRValidator) validateOAuthProviders(comp *ir.Component) []ValidationError {
+	var errs []ValidationError
+
+	for _, p := range comp.Middleware.OAuth {
+		if !oauthProviders[p.Provider] {
+			errs = append(errs, newError(comp.ID, MsgOAuthProviderUnsupported, p.Provider))
+			continue
+		}
+		fields := []struct{ name, value, example string }{
+			{"client_id_env", p.ClientIDEnv, "CLIENT_ID"},
+			{"client_secret_env", p.ClientSecretEnv, "CLIENT_SECRET"},
+		}
+		for _, f := range fields {
+			if f.value == "" {
+				errs = append(errs, newError(comp.ID, MsgOAuthMissingField, p.Provider, f.name))
+				continue
+			}
+			if !envVarPattern.MatchString(f.value) {
+				errs = append(errs, newError(comp.ID, MsgOAuthInlineCredential,
+					p.Provider, f.name, f.value, strings.ToUpper(p.Provider)+"_"+f.example))
+			}
+		}
+		if p.ClientIDEnv != "" && p.ClientIDEnv == p.ClientSecretEnv {
+			errs = append(errs, newError(comp.ID, MsgOAuthSameVariable, p.Provider))
+		}
+	}
+	return errs
+}
+
+// validateRBAC checks that roles grant declared permissions, inherit declared
+// roles without cycles, and fit the casbin model they are seeded into.
+func (v *IRValidator) validateRBAC(comp *ir.Component) []ValidationError {
+	var errs []ValidationError
+	s := comp.Middleware
+	if len(s.Permissions) == 0 && len(s.Roles) == 0 {
+		return nil
+	}
+
+	permissions := make(map[string]bool, len(s.Permissions))
+	for _, p := range s.Permissions {
+		permissions[p.Name] = true
+	}
+	roles := make(map[string]ir.Role, len(s.Roles))
+	for _, r := range s.Roles {
+		roles[r.Name] = r
+	}
+
+	inherits := false
+	for _, r := range s.Roles {
+		for _, p := range r.Permissions {
+			if !permissions[p] {
+				errs = append(errs, newError(comp.ID, MsgRoleUndeclaredPermission, r.Name, p))
+			}
+		}
+		for _, parent := range r.Inherits {
+			inherits = true
+			if _, ok := roles[parent]; !ok {
+				errs = append(errs, newError(comp.ID, MsgRoleUndeclaredParent, r.Name, parent))
+			}
+		}
+	}
+	if cycle := roleInheritanceCycle(s.Roles, roles); cycle != nil {
+		errs = append(errs, newError(comp.ID, MsgRoleInheritanceCycle, strings.Join(cycle, " -> ")))
+	}
+
+	// Seeded rows are "p, role, object, action" and "g, role, parent"
+	if s.ParsedModel != nil {
+		if p, ok := s.ParsedModel.Assertion(casbin.SectionPolicy, "p"); ok && len(strings.Split(p.Value, ",")) != 3 {
+			errs = append(errs, newError(comp.ID, MsgRolePolicyFields, p.Value))
+		}
+		if _, ok := s.ParsedModel.Assertion(casbin.SectionRole, "g"); inherits && !ok {
+			errs = append(errs, newError(comp.ID, MsgRoleDefinitionMissing))
+		}
+	}
+	return errs
+}
+
+// roleInheritanceCycle returns the first cycle in the inherits graph, if any.
+func roleInheritanceCycle(ordered []ir.Role, roles map[string]ir.Role) []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(roles))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			for n, p := range path {
+				if p == name {
+					return append(append([]string{}, path[n:]...), name)
+				}
+			}
+		case done:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, parent := range roles[name].Inherits {
+			if _, ok := roles[parent]; !ok {
+				continue
+			}
+			if cycle := visit(parent); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+
+	for _, r := range ordered {
+		if cycle := visit(r.Name); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// validateCasbinPolicyAdapter checks that a postgres policy adapter has
+// exactly one database to load policies from.
+func (v *IRValidator) validateCasbinPolicyAdapter(i *ir.IR, comp *ir.Component) []ValidationError {
+	s := comp.Middleware
+	if s.PolicyAdapter != "postgres" {
+		return nil
+	}
+
+	var databases []string
+	for _, ref := range s.DependsOn {
+		if dep, ok := i.Components[ref]; ok && dep.Kind == ir.KindPostgres {
+			databases = append(databases, ref)
+		}
+	}
+	switch len(databases) {
+	case 1:
+		return nil
+	case 0:
+		return []ValidationError{newError(comp.ID, MsgPolicyAdapterNoDatabase)}
+	default:
+		return []ValidationError{newError(comp.ID, MsgPolicyAdapterDatabases, len(databases))}
+	}
+}
+
+// validateCasbinModel reports grammar problems in the casbin model file.
+func (v *IRValidator) validateCasbinModel(comp *ir.Component) []ValidationError {
+	var errs []ValidationError
+	s := comp.Middleware
+	if s.ParsedModel == nil {
+		return nil
+	}
+
+	for _, issue := range s.ParsedModel.Issues {
+		errs = append(errs, ValidationError{
+			ID:      comp.ID,
+			Message: fmt.Sprintf("%s: %s", s.Model, issue),
+			Position: parser.Position{
+				File: s.ParsedModel.File,
+				Line: issue.Line,
+			},
+		})
+	}
 	return errs
 }
 
@@ -124,32 +714,212 @@ func (v *IRValidator) validatePostgres(comp *ir.Component) []ValidationError {
 	s := comp.Postgres
 
 	if s == nil {
-		return []ValidationError{{ID: comp.ID, Message: "missing postgres spec"}}
+		return []ValidationError{newError(comp.ID, MsgMissingSpec, ir.KindPostgres)}
 	}
 
 	if s.Provider == "" {
-		errs = append(errs, ValidationError{ID: comp.ID, Message: "missing required field: provider"})
+		errs = append(errs, newError(comp.ID, MsgMissingField, "provider"))
 	}
 	if s.Schema == "" {
-		errs = append(errs, ValidationError{ID: comp.ID, Message: "missing required field: schema"})
+		errs = append(errs, newError(comp.ID, MsgMissingField, "schema"))
+	}
+
+	return errs
+}
+
+// validateWebhook checks that the target URL and signing secret are read
+// from environment variables and that every event is declared once.
+func (v *IRValidator) validateHTTPGateway(i *ir.IR, comp *ir.Component) []ValidationError {
+	var errs []ValidationError
+	s := comp.HTTPGateway
+
+	if s == nil {
+		return []ValidationError{newError(comp.ID, MsgMissingSpec, ir.KindHTTPGateway)}
+	}
+
+	if s.Port < 1 || s.Port > 65535 {
+		errs = append(errs, newError(comp.ID, MsgPortRange))
+	}
+	// The gateway listens in the same process as the servers
+	var taken []string
+	for id, other := range i.Components {
+		if other.HTTPServer != nil && other.HTTPServer.Port == s.Port {
+			taken = append(taken, id)
+		}
+	}
+	sort.Strings(taken)
+	for _, id := range taken {
+		errs = append(errs, newError(comp.ID, MsgGatewayPortInUse, s.Port, id))
+	}
+
+	if s.Auth != "" {
+		if sym, ok := i.Symbols.Lookup(s.Auth); ok {
+			if sym.Kind != ir.KindMiddleware {
+				errs = append(errs, newError(comp.ID, MsgReferenceKind, "auth", s.Auth, sym.Kind, ir.KindMiddleware))
+			} else if mw := i.Components[s.Auth]; mw != nil && mw.Middleware != nil && mw.Middleware.Provider != "better-auth" {
+				errs = append(errs, newError(comp.ID, MsgGatewayAuthProvider, s.Auth, mw.Middleware.Provider))
+			}
+		}
+	}
+
+	if len(s.Routes) == 0 {
+		errs = append(errs, newError(comp.ID, MsgMissingField, "routes"))
+	}
+	seen := make(map[string]bool, len(s.Routes))
+	for _, r := range s.Routes {
+		if !strings.HasPrefix(r.Prefix, "/") || (r.Prefix != "/" && strings.HasSuffix(r.Prefix, "/")) {
+			errs = append(errs, newError(comp.ID, MsgGatewayPrefixFormat, r.Prefix))
+		} else if seen[r.Prefix] {
+			errs = append(errs, newError(comp.ID, MsgGatewayDuplicatePrefix, r.Prefix))
+		}
+		seen[r.Prefix] = true
+
+		if r.Server == "" {
+			errs = append(errs, newError(comp.ID, MsgMissingField, "server"))
+		} else if sym, ok := i.Symbols.Lookup(r.Server); ok && sym.Kind != ir.KindHTTPServer {
+			errs = append(errs, newError(comp.ID, MsgReferenceKind, "server", r.Server, sym.Kind, ir.KindHTTPServer))
+		}
 	}
 
 	return errs
 }
 
+func (v *IRValidator) validateWebhook(comp *ir.Component) []ValidationError {
+	var errs []ValidationError
+	s := comp.Webhook
+
+	if s == nil {
+		return []ValidationError{newError(comp.ID, MsgMissingSpec, ir.KindWebhook)}
+	}
+
+	prefix := strings.ToUpper(strings.ReplaceAll(comp.ID[strings.LastIndex(comp.ID, ".")+1:], "-", "_"))
+	fields := []struct{ name, value, example string }{
+		{"url_env", s.URLEnv, prefix + "_WEBHOOK_URL"},
+		{"secret_env", s.SecretEnv, prefix + "_WEBHOOK_SECRET"},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			errs = append(errs, newError(comp.ID, MsgMissingField, f.name))
+			continue
+		}
+		if !envVarPattern.MatchString(f.value) {
+			errs = append(errs, newError(comp.ID, MsgWebhookInlineValue, f.name, f.value, f.example))
+		}
+	}
+	if s.URLEnv != "" && s.URLEnv == s.SecretEnv {
+		errs = append(errs, newError(comp.ID, MsgWebhookSameVariable))
+	}
+
+	if len(s.Events) == 0 {
+		errs = append(errs, newError(comp.ID, MsgMissingField, "events"))
+	}
+	seen := make(map[string]bool, len(s.Events))
+	for _, e := range s.Events {
+		if seen[e.Name] {
+			errs = append(errs, newError(comp.ID, MsgWebhookDuplicateEvent, e.Name))
+		}
+		seen[e.Name] = true
+	}
+
+	return errs
+}
+
+// validateHTTPClient checks that the base URL of a client is read from an
+// environment variable.
+func (v *IRValidator) validateHTTPClient(comp *ir.Component) []ValidationError {
+	s := comp.HTTPClient
+	if s == nil {
+		return []ValidationError{newError(comp.ID, MsgMissingSpec, ir.KindHTTPClient)}
+	}
+
+	if s.BaseURLEnv == "" {
+		return []ValidationError{newError(comp.ID, MsgMissingField, "base_url_env")}
+	}
+	if !envVarPattern.MatchString(s.BaseURLEnv) {
+		example := strings.ToUpper(strings.ReplaceAll(comp.ID[strings.LastIndex(comp.ID, ".")+1:], "-", "_")) + "_URL"
+		return []ValidationError{newError(comp.ID, MsgHTTPClientInlineValue, s.BaseURLEnv, example)}
+	}
+	return nil
+}
+
+// validateCron checks a job's schedule and that it only depends on
+// databases, the one kind the worker creates clients for.
+func (v *IRValidator) validateCron(i *ir.IR, comp *ir.Component) []ValidationError {
+	var errs []ValidationError
+	s := comp.Cron
+
+	if s == nil {
+		return []ValidationError{newError(comp.ID, MsgMissingSpec, ir.KindCron)}
+	}
+
+	if s.Schedule == "" {
+		errs = append(errs, newError(comp.ID, MsgMissingField, "schedule"))
+	} else if !validCronSchedule(s.Schedule) {
+		errs = append(errs, newError(comp.ID, MsgCronSchedule, s.Schedule))
+	}
+	for _, ref := range s.DependsOn {
+		if sym, ok := i.Symbols.Lookup(ref); ok && sym.Kind != ir.KindPostgres {
+			errs = append(errs, newError(comp.ID, MsgReferenceKind, "depends_on", ref, sym.Kind, ir.KindPostgres))
+		}
+	}
+
+	return errs
+}
+
+// cronFieldRanges are the values each of the five fields of a cron
+// expression allows; day of week 7 is Sunday, like 0.
+var cronFieldRanges = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+// validCronSchedule reports whether expr is a five-field cron expression:
+// each field a list of "*", values or ranges in the field's range, each
+// with an optional step.
+func validCronSchedule(expr string) bool {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFieldRanges) {
+		return false
+	}
+	for n, field := range fields {
+		low, high := cronFieldRanges[n][0], cronFieldRanges[n][1]
+		for _, item := range strings.Split(field, ",") {
+			base, step, stepped := strings.Cut(item, "/")
+			if stepped {
+				if s, err := strconv.Atoi(step); err != nil || s < 1 {
+					return false
+				}
+			}
+			if base == "*" {
+				continue
+			}
+			from, to, ranged := strings.Cut(base, "-")
+			if !ranged {
+				to = from
+			}
+			first, err := strconv.Atoi(from)
+			if err != nil || first < low || first > high {
+				return false
+			}
+			last, err := strconv.Atoi(to)
+			if err != nil || last < first || last > high {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func (v *IRValidator) validateUsecase(i *ir.IR, comp *ir.Component) []ValidationError {
 	var errs []ValidationError
 	s := comp.Usecase
 
 	if s == nil {
-		return []ValidationError{{ID: comp.ID, Message: "missing usecase spec"}}
+		return []ValidationError{newError(comp.ID, MsgMissingSpec, ir.KindUsecase)}
 	}
 
 	if s.BindsTo == "" {
-		errs = append(errs, ValidationError{ID: comp.ID, Message: "missing required field: binds_to"})
+		errs = append(errs, newError(comp.ID, MsgMissingField, "binds_to"))
 	} else {
 		// Use the canonical ParseBinding from the openapi package
-		serverID, _, _, err := openapi.ParseBinding(s.BindsTo)
+		serverID, _, path, err := openapi.ParseBinding(s.BindsTo)
 		if err != nil {
 			errs = append(errs, ValidationError{ID: comp.ID, Message: err.Error()})
 		}
@@ -158,28 +928,489 @@ func (v *IRValidator) validateUsecase(i *ir.IR, comp *ir.Component) []Validation
 		if serverID != "" {
 			if sym, ok := i.Symbols.Lookup(serverID); ok {
 				if sym.Kind != ir.KindHTTPServer {
-					errs = append(errs, ValidationError{
-						ID:      comp.ID,
-						Message: fmt.Sprintf("binds_to references %q which is %s, expected http.server", serverID, sym.Kind),
-					})
+					errs = append(errs, newError(comp.ID, MsgBindingTargetKind, serverID, sym.Kind))
 				}
 			}
 		}
+
+		// The server adds its base path, so bindings must not repeat it
+		if server, ok := i.Components[serverID]; ok && server.HTTPServer != nil {
+			if base := server.HTTPServer.BasePath; base != "" && (path == base || strings.HasPrefix(path, base+"/")) {
+				rest := strings.TrimPrefix(path, base)
+				if rest == "" {
+					rest = "/"
+				}
+				errs = append(errs, newError(comp.ID, MsgBindingRepeatsBasePath, path, base, serverID, rest))
+			}
+			// The API reference routes are registered first and would win
+			if docs := server.HTTPServer.APIDocs; docs != nil && s.Binding != nil && s.Binding.Method == "GET" &&
+				(path == docs.Path || path == docs.Path+"/openapi.yaml") {
+				errs = append(errs, newError(comp.ID, MsgBindingShadowedByAPIDocs, path, serverID))
+			}
+			if metrics := server.HTTPServer.Metrics; metrics != nil && s.Binding != nil && s.Binding.Method == "GET" && path == metrics.Path {
+				errs = append(errs, newError(comp.ID, MsgBindingShadowedByMetrics, path, serverID))
+			}
+		}
+
+		errs = append(errs, v.validateCatchAllBinding(i, comp)...)
+		errs = append(errs, v.validateBindingPathParams(comp)...)
 	}
 
 	if s.Goal == "" {
-		errs = append(errs, ValidationError{ID: comp.ID, Message: "missing required field: goal"})
+		errs = append(errs, newError(comp.ID, MsgMissingField, "goal"))
 	}
 
 	// Validate middleware references
 	for _, ref := range s.Middleware {
 		if sym, ok := i.Symbols.Lookup(ref); ok {
 			if sym.Kind != ir.KindMiddleware {
-				errs = append(errs, ValidationError{
-					ID:      comp.ID,
-					Message: fmt.Sprintf("middleware reference %q points to %s, expected middleware", ref, sym.Kind),
-				})
+				errs = append(errs, newError(comp.ID, MsgReferenceKind, "middleware", ref, sym.Kind, ir.KindMiddleware))
+			}
+		}
+	}
+
+	for _, ref := range s.Emits {
+		if sym, ok := i.Symbols.Lookup(ref); ok && sym.Kind != ir.KindWebhook {
+			errs = append(errs, newError(comp.ID, MsgReferenceKind, "emits", ref, sym.Kind, ir.KindWebhook))
+		}
+	}
+	for _, ref := range s.Calls {
+		if sym, ok := i.Symbols.Lookup(ref); ok && sym.Kind != ir.KindHTTPClient {
+			errs = append(errs, newError(comp.ID, MsgReferenceKind, "calls", ref, sym.Kind, ir.KindHTTPClient))
+		}
+	}
+
+	errs = append(errs, v.validateUsecaseDatabases(i, comp)...)
+	errs = append(errs, v.validateUsecaseAuthorization(i, comp)...)
+	errs = append(errs, v.validateInputMapping(i, comp)...)
+	errs = append(errs, v.validateUsecaseLimits(i, comp)...)
+	errs = append(errs, v.validateUsecaseCache(comp)...)
+	errs = append(errs, v.validateUsecaseConcurrency(i, comp)...)
+	errs = append(errs, v.validateUsecaseBulk(i, comp)...)
+	errs = append(errs, v.validateUsecaseAsync(i, comp)...)
+
+	return errs
+}
+
+// validateUsecaseBulk checks that a bulk usecase is bound to a route with a
+// request body, and that its operation declares the body as an array.
+func (v *IRValidator) validateUsecaseBulk(i *ir.IR, comp *ir.Component) []ValidationError {
+	s := comp.Usecase
+	if !s.Bulk || s.Binding == nil {
+		return nil
+	}
+
+	if s.Binding.IsCatchAll() || (s.Binding.Method != "POST" && s.Binding.Method != "PUT" && s.Binding.Method != "PATCH") {
+		return []ValidationError{newError(comp.ID, MsgBulkMethod, s.Binding.Method, s.Binding.Path)}
+	}
+	var errs []ValidationError
+	if len(s.InputMapping) > 0 {
+		errs = append(errs, newError(comp.ID, MsgBulkInputMapping))
+	}
+	if kind := requestBodyKind(i, s.Binding); kind != "array" {
+		errs = append(errs, newError(comp.ID, MsgBulkNotArray, s.Binding.Method+" "+s.Binding.Path, kind))
+	}
+	return errs
+}
+
+// validateUsecaseAsync checks that an async usecase is bound to a route
+// that changes something, has a database to keep its jobs in, and that no
+// other usecase is bound to the route reporting them.
+func (v *IRValidator) validateUsecaseAsync(i *ir.IR, comp *ir.Component) []ValidationError {
+	s := comp.Usecase
+	status := s.JobStatus()
+	if status == nil {
+		return nil
+	}
+
+	if s.Binding.IsCatchAll() || s.Binding.Method == "GET" || s.Binding.Method == "HEAD" || s.Binding.Method == "OPTIONS" {
+		return []ValidationError{newError(comp.ID, MsgAsyncMethod, s.Binding.Method, s.Binding.Path)}
+	}
+	var errs []ValidationError
+	if !usesDatabase(i, s) {
+		errs = append(errs, newError(comp.ID, MsgAsyncNoDatabase))
+	}
+	var taken []string
+	for _, other := range i.Components {
+		if o := other.Usecase; o != nil && o.Binding != nil && o.Binding.ServerID == status.ServerID && o.Binding.Matches(status) {
+			taken = append(taken, other.ID)
+		}
+	}
+	sort.Strings(taken)
+	for _, id := range taken {
+		errs = append(errs, newError(comp.ID, MsgAsyncStatusRoute, status.Path, id))
+	}
+	return errs
+}
+
+// usesDatabase reports whether a usecase uses a postgres database: one of
+// its depends_on, or of its server's if it declares none.
+func usesDatabase(i *ir.IR, s *ir.UsecaseSpec) bool {
+	refs := s.DependsOn
+	if refs == nil {
+		if server, ok := i.Components[s.Binding.ServerID]; ok && server.HTTPServer != nil {
+			refs = server.HTTPServer.DependsOn
+		}
+	}
+	for _, ref := range refs {
+		if dep, ok := i.Components[ref]; ok && dep.Kind == ir.KindPostgres {
+			return true
+		}
+	}
+	return false
+}
+
+// requestBodyKind describes the JSON request body a binding's operation
+// declares, following references to the server's component schemas: its
+// type, "untyped", or "not declared".
+func requestBodyKind(i *ir.IR, binding *ir.Binding) string {
+	op := binding.Operation
+	if op == nil || op.RequestBody == nil {
+		return "not declared"
+	}
+	media, ok := op.RequestBody.Content["application/json"]
+	if !ok || media.Schema == nil {
+		return "not declared"
+	}
+	schema := media.Schema
+	if server, ok := i.Components[binding.ServerID]; ok && server.HTTPServer != nil && server.HTTPServer.ParsedOpenAPI != nil {
+		schema = server.HTTPServer.ParsedOpenAPI.ResolveSchema(schema)
+	}
+	switch {
+	case schema == nil:
+		return "not declared"
+	case schema.Type == "":
+		return "untyped"
+	}
+	return schema.Type
+}
+
+// validateUsecaseConcurrency checks that ETags are computed on GET routes
+// and required on PUT and PATCH routes, and that an update can compare
+// If-Match against the ETag of a GET route on its path.
+func (v *IRValidator) validateUsecaseConcurrency(i *ir.IR, comp *ir.Component) []ValidationError {
+	s := comp.Usecase
+	if s.Concurrency == "" || s.Binding == nil {
+		return nil
+	}
+
+	switch {
+	case s.Binding.IsCatchAll() || (s.Binding.Method != "GET" && s.Binding.Method != "PUT" && s.Binding.Method != "PATCH"):
+		return []ValidationError{newError(comp.ID, MsgConcurrencyMethod, s.Binding.Method, s.Binding.Path)}
+	case s.Binding.Method == "GET":
+		return nil
+	}
+	for _, other := range i.Components {
+		o := other.Usecase
+		if o != nil && o.Binding != nil && o.Concurrency == ir.ConcurrencyETag && o.Binding.Method == "GET" &&
+			o.Binding.ServerID == s.Binding.ServerID && o.Binding.Path == s.Binding.Path {
+			return nil
+		}
+	}
+	return []ValidationError{newError(comp.ID, MsgConcurrencyNoRead, s.Binding.Method, s.Binding.Path)}
+}
+
+// validateUsecaseCache checks that only GET routes are cached, and that
+// responses depending on the caller stay in the caller's cache.
+func (v *IRValidator) validateUsecaseCache(comp *ir.Component) []ValidationError {
+	s := comp.Usecase
+	if s.Cache == nil {
+		return nil
+	}
+
+	var errs []ValidationError
+	if s.Binding != nil && s.Binding.Method != "GET" {
+		errs = append(errs, newError(comp.ID, MsgCacheMethod, s.Binding.Method))
+	}
+	if s.Cache.Visibility == ir.CachePublic && s.Authorization != nil {
+		errs = append(errs, newError(comp.ID, MsgCachePublicAuthorized))
+	}
+	if s.Cache.Server && s.Cache.Visibility != ir.CachePublic {
+		errs = append(errs, newError(comp.ID, MsgCacheServerPrivate))
+	}
+	if len(s.Cache.Tables) > 0 && !s.Cache.Server {
+		errs = append(errs, newError(comp.ID, MsgCacheTablesWithoutServer))
+	}
+	return errs
+}
+
+// validateUsecaseLimits checks that a usecase's limits stay within its
+// server's, which apply to every route.
+func (v *IRValidator) validateUsecaseLimits(i *ir.IR, comp *ir.Component) []ValidationError {
+	s := comp.Usecase
+	if s.Limits == nil || s.Binding == nil {
+		return nil
+	}
+	server, ok := i.Components[s.Binding.ServerID]
+	if !ok || server.HTTPServer == nil || server.HTTPServer.Limits == nil {
+		return nil
+	}
+	max := server.HTTPServer.Limits
+
+	var errs []ValidationError
+	if max.TimeoutMS > 0 && s.Limits.TimeoutMS > max.TimeoutMS {
+		errs = append(errs, newError(comp.ID, MsgLimitExceedsServer, "timeout_ms", s.Limits.TimeoutMS, max.TimeoutMS, server.ID))
+	}
+	if max.MaxBodyKB > 0 && s.Limits.MaxBodyKB > max.MaxBodyKB {
+		errs = append(errs, newError(comp.ID, MsgLimitExceedsServer, "max_body_kb", s.Limits.MaxBodyKB, max.MaxBodyKB, server.ID))
+	}
+	return errs
+}
+
+// validateInputMapping checks that every input_mapping entry reads a part of
+// the request its route has: a path parameter of the binding, or a body
+// field or query parameter declared by the bound OpenAPI operation. Without
+// an operation there is no schema to check body fields and query parameters
+// against.
+func (v *IRValidator) validateInputMapping(i *ir.IR, comp *ir.Component) []ValidationError {
+	s := comp.Usecase
+	if len(s.InputMapping) == 0 || s.Binding == nil {
+		return nil
+	}
+	if s.Binding.IsCatchAll() {
+		return []ValidationError{newError(comp.ID, MsgInputMappingCatchAll)}
+	}
+
+	var errs []ValidationError
+	op := s.Binding.Operation
+	for _, m := range s.InputMapping {
+		switch m.Transform {
+		case "", "trim", "lowercase", "uppercase", "number":
+		default:
+			errs = append(errs, newError(comp.ID, MsgInputMappingTransform, m.Field, m.Transform))
+		}
+
+		switch m.Source {
+		case ir.InputFromPath:
+			if !slices.Contains(s.Binding.PathParams(), m.Name) {
+				errs = append(errs, newError(comp.ID, MsgInputMappingUnknownPathParam, m.Field, m.Name, s.Binding.Path))
+			}
+		case ir.InputFromBody:
+			switch s.Binding.Method {
+			case "POST", "PUT", "PATCH":
+			default:
+				errs = append(errs, newError(comp.ID, MsgInputMappingNoBody, m.Field, s.Binding.Method))
+				continue
+			}
+			if op != nil && !requestBodyDeclares(i, s.Binding.ServerID, op, m.Name) {
+				errs = append(errs, newError(comp.ID, MsgInputMappingUnknownBodyField, m.Field, m.Name, op.OperationKey()))
+			}
+		case ir.InputFromQuery:
+			if op != nil && !operationDeclaresQuery(op, m.Name) {
+				errs = append(errs, newError(comp.ID, MsgInputMappingUnknownQueryParam, m.Field, m.Name, op.OperationKey()))
+			}
+		default:
+			errs = append(errs, newError(comp.ID, MsgInputMappingSource, m.Field, m.Source+"."+m.Name))
+		}
+	}
+	return errs
+}
+
+// requestBodyDeclares reports whether the JSON request body of op declares
+// a property name, following references to the server's component schemas
+// and merging allOf schemas.
+func requestBodyDeclares(i *ir.IR, serverID string, op *openapi.Operation, name string) bool {
+	if op.RequestBody == nil {
+		return false
+	}
+	media, ok := op.RequestBody.Content["application/json"]
+	if !ok || media.Schema == nil {
+		return false
+	}
+	schema := media.Schema
+	if server, ok := i.Components[serverID]; ok && server.HTTPServer != nil && server.HTTPServer.ParsedOpenAPI != nil {
+		doc := server.HTTPServer.ParsedOpenAPI
+		schema = doc.MergeAllOf(doc.ResolveSchema(schema))
+	}
+	if schema == nil {
+		return false
+	}
+	_, ok = schema.Properties[name]
+	return ok
+}
+
+// validateBindingPathParams checks that the path parameters of a binding
+// agree with those of its operation. The OpenAPI linter reports parameters
+// of the path that the operation does not declare; this reports the other
+// direction and declarations a path segment cannot satisfy.
+func (v *IRValidator) validateBindingPathParams(comp *ir.Component) []ValidationError {
+	binding := comp.Usecase.Binding
+	if binding == nil {
+		return nil
+	}
+
+	var errs []ValidationError
+	params := binding.PathParams()
+	for n, name := range params {
+		if slices.Contains(params[:n], name) {
+			errs = append(errs, newError(comp.ID, MsgPathParamDuplicate, binding.Path, name))
+		}
+	}
+
+	op := binding.Operation
+	if op == nil {
+		return errs
+	}
+	for _, p := range op.Parameters {
+		if p.In != "path" {
+			continue
+		}
+		if !slices.Contains(params, p.Name) {
+			errs = append(errs, newError(comp.ID, MsgPathParamNotInPath, op.OperationKey(), p.Name, binding.Path))
+			continue
+		}
+		if !p.Required {
+			errs = append(errs, newError(comp.ID, MsgPathParamOptional, p.Name, op.OperationKey()))
+		}
+		if p.Schema != nil && (p.Schema.Type == "object" || p.Schema.Type == "array") {
+			errs = append(errs, newError(comp.ID, MsgPathParamType, p.Name, op.OperationKey(), p.Schema.Type))
+		}
+	}
+	return errs
+}
+
+func operationDeclaresQuery(op *openapi.Operation, name string) bool {
+	for _, p := range op.Parameters {
+		if p.In == "query" && p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateCatchAllBinding checks that a catch-all binding matches no other
+// usecase's route on its server, which would make the route depend on the
+// order of registration. Two overlapping catch-all bindings are reported once,
+// on the first by ID.
+func (v *IRValidator) validateCatchAllBinding(i *ir.IR, comp *ir.Component) []ValidationError {
+	b := comp.Usecase.Binding
+	if b == nil || !b.IsCatchAll() {
+		return nil
+	}
+
+	var others []*ir.Component
+	for _, other := range i.Components {
+		ob := other.Usecase
+		if other == comp || ob == nil || ob.Binding == nil || ob.Binding.ServerID != b.ServerID {
+			continue
+		}
+		if ob.Binding.IsCatchAll() && other.ID < comp.ID {
+			continue
+		}
+		if b.Matches(ob.Binding) || ob.Binding.Matches(b) {
+			others = append(others, other)
+		}
+	}
+	sort.Slice(others, func(a, b int) bool { return others[a].ID < others[b].ID })
+
+	var errs []ValidationError
+	for _, other := range others {
+		ob := other.Usecase.Binding
+		errs = append(errs, newError(comp.ID, MsgBindingOverlap, b.Method, b.Path, ob.Method, ob.Path, other.ID))
+	}
+	return errs
+}
+
+// validateUsecaseAuthorization checks that the roles and permissions a
+// usecase requires are declared by the first casbin middleware that runs for
+// it, and that a better-auth middleware identifies the caller.
+func (v *IRValidator) validateUsecaseAuthorization(i *ir.IR, comp *ir.Component) []ValidationError {
+	var errs []ValidationError
+	s := comp.Usecase
+	if s.Authorization == nil {
+		return nil
+	}
+
+	// Nil middleware means the usecase inherits its server's chain
+	middleware := s.Middleware
+	if middleware == nil && s.Binding != nil {
+		if server, ok := i.Components[s.Binding.ServerID]; ok && server.HTTPServer != nil {
+			middleware = server.HTTPServer.Middleware
+		}
+	}
+
+	var authorizer *ir.Component
+	hasAuth := false
+	for _, ref := range i.ExpandMiddleware(middleware) {
+		mw, ok := i.Components[ref]
+		if !ok || mw.Middleware == nil {
+			continue
+		}
+		switch mw.Middleware.Provider {
+		case "casbin":
+			if authorizer == nil {
+				authorizer = mw
 			}
+		case "better-auth":
+			hasAuth = true
+		}
+	}
+	if authorizer == nil {
+		return []ValidationError{newError(comp.ID, MsgAuthorizationNeedsCasbin)}
+	}
+	if !hasAuth {
+		errs = append(errs, newError(comp.ID, MsgAuthorizationNeedsAuth))
+	}
+
+	roles := make(map[string]bool, len(authorizer.Middleware.Roles))
+	for _, r := range authorizer.Middleware.Roles {
+		roles[r.Name] = true
+	}
+	permissions := make(map[string]bool, len(authorizer.Middleware.Permissions))
+	for _, p := range authorizer.Middleware.Permissions {
+		permissions[p.Name] = true
+	}
+	for _, r := range s.Authorization.Roles {
+		if !roles[r] {
+			errs = append(errs, newError(comp.ID, MsgAuthorizationUndeclaredRole, r, authorizer.ID))
+		}
+	}
+	for _, p := range s.Authorization.Permissions {
+		if !permissions[p] {
+			errs = append(errs, newError(comp.ID, MsgAuthorizationUndeclaredPermission, p, authorizer.ID))
+		}
+	}
+	return errs
+}
+
+// validateUsecaseDatabases checks that a usecase's depends_on names databases
+// of its server, and that usecases on servers with several databases say
+// which ones they use.
+func (v *IRValidator) validateUsecaseDatabases(i *ir.IR, comp *ir.Component) []ValidationError {
+	var errs []ValidationError
+	s := comp.Usecase
+
+	for _, ref := range s.DependsOn {
+		if sym, ok := i.Symbols.Lookup(ref); ok && sym.Kind != ir.KindPostgres {
+			errs = append(errs, newError(comp.ID, MsgReferenceKind, "depends_on", ref, sym.Kind, ir.KindPostgres))
+		}
+	}
+
+	if s.Binding == nil {
+		return errs
+	}
+	server, ok := i.Components[s.Binding.ServerID]
+	if !ok || server.HTTPServer == nil {
+		return errs
+	}
+
+	serverDBs := make(map[string]bool)
+	for _, ref := range server.HTTPServer.DependsOn {
+		if dep, ok := i.Components[ref]; ok && dep.Kind == ir.KindPostgres {
+			serverDBs[ref] = true
+		}
+	}
+
+	if s.DependsOn == nil {
+		if len(serverDBs) > 1 {
+			errs = append(errs, newError(comp.ID, MsgUsecaseDatabaseAmbiguous, server.ID, len(serverDBs)))
+		}
+		return errs
+	}
+
+	for _, ref := range s.DependsOn {
+		if dep, ok := i.Components[ref]; ok && dep.Kind == ir.KindPostgres && !serverDBs[ref] {
+			errs = append(errs, newError(comp.ID, MsgUsecaseDatabaseNotOnServer, ref, server.ID))
 		}
 	}
 
@@ -208,7 +1439,7 @@ func (v *IRValidator) validateBetterAuthRequirements(i *ir.IR) []ValidationError
 		switch comp.Kind {
 		case ir.KindHTTPServer:
 			if comp.HTTPServer != nil {
-				for _, ref := range comp.HTTPServer.Middleware {
+				for _, ref := range i.ExpandMiddleware(comp.HTTPServer.Middleware) {
 					if betterAuthSet[ref] {
 						required = true
 						break
@@ -217,7 +1448,7 @@ func (v *IRValidator) validateBetterAuthRequirements(i *ir.IR) []ValidationError
 			}
 		case ir.KindUsecase:
 			if comp.Usecase != nil {
-				for _, ref := range comp.Usecase.Middleware {
+				for _, ref := range i.ExpandMiddleware(comp.Usecase.Middleware) {
 					if betterAuthSet[ref] {
 						required = true
 						break
@@ -250,14 +1481,10 @@ func (v *IRValidator) validateBetterAuthRequirements(i *ir.IR) []ValidationError
 
 	var errs []ValidationError
 	if !hasServer {
-		errs = append(errs, ValidationError{
-			Message: "better-auth middleware requires at least one http.server component",
-		})
+		errs = append(errs, newError("", MsgBetterAuthNeedsServer))
 	}
 	if !hasDrizzle {
-		errs = append(errs, ValidationError{
-			Message: "better-auth middleware requires a postgres component with provider \"drizzle\"",
-		})
+		errs = append(errs, newError("", MsgBetterAuthNeedsDrizzle))
 	}
 
 	return errs
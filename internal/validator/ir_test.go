@@ -4,9 +4,13 @@
 package validator
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/openboundary/openboundary/internal/casbin"
 	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/openapi"
 	"github.com/openboundary/openboundary/internal/parser"
 )
 
@@ -141,6 +145,79 @@ func TestIRValidator_HTTPServer(t *testing.T) {
 			},
 			wantErrors: 1,
 		},
+		{
+			name: "valid base path",
+			spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+				"base_path": "/api/v1",
+			},
+			wantErrors: 0,
+		},
+		{
+			name: "base path without leading slash",
+			spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+				"base_path": "api/v1",
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "base path with trailing slash",
+			spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+				"base_path": "/api/v1/",
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "valid tls",
+			spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+				"tls": map[string]any{
+					"cert_env":    "API_TLS_CERT",
+					"key_env":     "API_TLS_KEY",
+					"self_signed": true,
+				},
+			},
+			wantErrors: 0,
+		},
+		{
+			name: "tls with inlined key path",
+			spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+				"tls": map[string]any{
+					"cert_env": "API_TLS_CERT",
+					"key_env":  "./certs/key.pem",
+				},
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "tls without key",
+			spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+				"tls":       map[string]any{"cert_env": "API_TLS_CERT"},
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "tls with one variable for both",
+			spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+				"tls": map[string]any{
+					"cert_env": "API_TLS",
+					"key_env":  "API_TLS",
+				},
+			},
+			wantErrors: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -224,6 +301,107 @@ func TestIRValidator_Middleware(t *testing.T) {
 			},
 			wantErrors: 2,
 		},
+		{
+			name: "casbin postgres adapter without database",
+			spec: map[string]interface{}{
+				"provider":       "casbin",
+				"model":          "./model.conf",
+				"policy":         "./policy.csv",
+				"policy_adapter": "postgres",
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "policy options on better-auth",
+			spec: map[string]interface{}{
+				"provider":       "better-auth",
+				"config":         "./auth.config.ts",
+				"policy_adapter": "file",
+				"admin_route":    "/admin/policies",
+			},
+			wantErrors: 2,
+		},
+		{
+			name: "better-auth database session without store",
+			spec: map[string]interface{}{
+				"provider": "better-auth",
+				"config":   "./auth.config.ts",
+				"session":  map[string]interface{}{"storage": "database"},
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "better-auth redis session with store",
+			spec: map[string]interface{}{
+				"provider": "better-auth",
+				"config":   "./auth.config.ts",
+				"session":  map[string]interface{}{"storage": "redis", "store": "postgres.primary"},
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "better-auth cookie session",
+			spec: map[string]interface{}{
+				"provider": "better-auth",
+				"config":   "./auth.config.ts",
+				"session":  map[string]interface{}{"storage": "cookie", "max_age": 3600},
+			},
+			wantErrors: 0,
+		},
+		{
+			name: "better-auth oauth with env vars",
+			spec: map[string]interface{}{
+				"provider": "better-auth",
+				"config":   "./auth.config.ts",
+				"oauth": map[string]interface{}{
+					"github": map[string]interface{}{"client_id_env": "GITHUB_CLIENT_ID", "client_secret_env": "GITHUB_CLIENT_SECRET"},
+				},
+			},
+			wantErrors: 0,
+		},
+		{
+			name: "better-auth oauth unsupported provider and shared variable",
+			spec: map[string]interface{}{
+				"provider": "better-auth",
+				"config":   "./auth.config.ts",
+				"oauth": map[string]interface{}{
+					"gitlab": map[string]interface{}{"client_id_env": "GITLAB_ID", "client_secret_env": "GITLAB_SECRET"},
+					"google": map[string]interface{}{"client_id_env": "GOOGLE_OAUTH", "client_secret_env": "GOOGLE_OAUTH"},
+				},
+			},
+			wantErrors: 2,
+		},
+		{
+			name: "oauth on casbin",
+			spec: map[string]interface{}{
+				"provider": "casbin",
+				"model":    "./model.conf",
+				"policy":   "./policy.csv",
+				"oauth": map[string]interface{}{
+					"github": map[string]interface{}{"client_id_env": "GITHUB_CLIENT_ID", "client_secret_env": "GITHUB_CLIENT_SECRET"},
+				},
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "roles on better-auth",
+			spec: map[string]interface{}{
+				"provider": "better-auth",
+				"config":   "./auth.config.ts",
+				"roles":    map[string]interface{}{"admin": map[string]interface{}{}},
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "session on casbin",
+			spec: map[string]interface{}{
+				"provider": "casbin",
+				"model":    "./model.conf",
+				"policy":   "./policy.csv",
+				"session":  map[string]interface{}{"storage": "cookie"},
+			},
+			wantErrors: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -307,140 +485,1153 @@ func TestIRValidator_Postgres(t *testing.T) {
 	}
 }
 
-func TestIRValidator_Usecase(t *testing.T) {
-	baseComponents := []parser.Component{
-		{
-			ID:   "http.server.api",
-			Kind: "http.server",
-			Spec: map[string]interface{}{
-				"framework": "hono",
-				"port":      3000,
-			},
-		},
-	}
-
+func TestIRValidator_Webhook(t *testing.T) {
+	events := []interface{}{map[string]interface{}{"name": "order.created"}}
 	tests := []struct {
-		name       string
-		spec       map[string]interface{}
-		wantErrors int
+		name    string
+		spec    map[string]interface{}
+		wantErr string
 	}{
 		{
 			name: "valid",
 			spec: map[string]interface{}{
-				"binds_to": "http.server.api:POST:/users",
-				"goal":     "Create user",
+				"url_env":    "ORDERS_WEBHOOK_URL",
+				"secret_env": "ORDERS_WEBHOOK_SECRET",
+				"events":     events,
 			},
-			wantErrors: 0,
 		},
 		{
-			name: "missing binds_to",
+			name: "inline url",
 			spec: map[string]interface{}{
-				"goal": "Create user",
+				"url_env":    "https://example.com/hooks",
+				"secret_env": "ORDERS_WEBHOOK_SECRET",
+				"events":     events,
 			},
-			wantErrors: 1,
+			wantErr: `webhook.orders: url_env "https://example.com/hooks" is not an environment variable name; ` +
+				"name the variable that holds the value (e.g., ORDERS_WEBHOOK_URL) instead of inlining it",
 		},
 		{
-			name: "missing goal",
+			name: "same variable",
 			spec: map[string]interface{}{
-				"binds_to": "http.server.api:POST:/users",
+				"url_env":    "ORDERS_WEBHOOK",
+				"secret_env": "ORDERS_WEBHOOK",
+				"events":     events,
 			},
-			wantErrors: 1,
-		},
-		{
-			name:       "missing both",
-			spec:       map[string]interface{}{},
-			wantErrors: 2,
+			wantErr: "webhook.orders: url_env and secret_env must be different variables",
 		},
 		{
-			name: "invalid binds_to format",
+			name: "duplicate event",
 			spec: map[string]interface{}{
-				"binds_to": "invalid",
-				"goal":     "Test",
+				"url_env":    "ORDERS_WEBHOOK_URL",
+				"secret_env": "ORDERS_WEBHOOK_SECRET",
+				"events":     append(events, map[string]interface{}{"name": "order.created"}),
 			},
-			wantErrors: 1,
+			wantErr: `webhook.orders: event "order.created" is declared more than once`,
 		},
 		{
-			name: "invalid HTTP method",
+			name: "missing events",
 			spec: map[string]interface{}{
-				"binds_to": "http.server.api:INVALID:/users",
-				"goal":     "Test",
+				"url_env":    "ORDERS_WEBHOOK_URL",
+				"secret_env": "ORDERS_WEBHOOK_SECRET",
 			},
-			wantErrors: 1,
+			wantErr: "webhook.orders: missing required field: events",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "webhook.orders", Kind: "webhook", Spec: tt.spec},
+				},
+			}
+			builtIR, _ := ir.NewBuilder().Build(spec)
+
+			// when
+			errs := NewIRValidator().Validate(builtIR)
+
+			// then
+			if tt.wantErr == "" {
+				if len(errs) != 0 {
+					t.Errorf("Validate() errors = %v, expected none", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Error() != tt.wantErr {
+				t.Errorf("Validate() errors = %v, expected %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIRValidator_HTTPClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "valid",
+			spec: map[string]interface{}{"base_url_env": "PAYMENTS_URL"},
 		},
 		{
-			name: "invalid path no slash",
-			spec: map[string]interface{}{
-				"binds_to": "http.server.api:GET:users",
-				"goal":     "Test",
-			},
-			wantErrors: 1,
+			name: "inline url",
+			spec: map[string]interface{}{"base_url_env": "https://payments.example.com"},
+			wantErr: `http.client.payments: base_url_env "https://payments.example.com" is not an environment variable name; ` +
+				"name the variable that holds the URL (e.g., PAYMENTS_URL) instead of inlining it",
+		},
+		{
+			name:    "missing base url",
+			spec:    map[string]interface{}{},
+			wantErr: "http.client.payments: missing required field: base_url_env",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			components := append(baseComponents, parser.Component{
-				ID:   "usecase.test",
-				Kind: "usecase",
-				Spec: tt.spec,
-			})
-
-			spec := &parser.Spec{Components: components}
+			// given
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "http.client.payments", Kind: "http.client", Spec: tt.spec},
+				},
+			}
+			builtIR, _ := ir.NewBuilder().Build(spec)
 
-			b := ir.NewBuilder()
-			builtIR, _ := b.Build(spec)
-			v := NewIRValidator()
-			errs := v.Validate(builtIR)
+			// when
+			errs := NewIRValidator().Validate(builtIR)
 
-			if len(errs) != tt.wantErrors {
-				t.Errorf("Validate() returned %d errors, expected %d", len(errs), tt.wantErrors)
-				for _, e := range errs {
-					t.Logf("  error: %v", e)
+			// then
+			if tt.wantErr == "" {
+				if len(errs) != 0 {
+					t.Errorf("Validate() errors = %v, expected none", errs)
 				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Error() != tt.wantErr {
+				t.Errorf("Validate() errors = %v, expected %q", errs, tt.wantErr)
 			}
 		})
 	}
 }
 
-func TestIRValidator_MiddlewareTypeCheck(t *testing.T) {
-	spec := &parser.Spec{
-		Components: []parser.Component{
-			{
-				ID:   "http.server.api",
-				Kind: "http.server",
-				Spec: map[string]interface{}{
-					"framework":  "hono",
-					"port":       3000,
-					"middleware": []interface{}{"postgres.primary"},
-				},
-			},
-			{
-				ID:   "postgres.primary",
-				Kind: "postgres",
-				Spec: map[string]interface{}{
-					"provider": "drizzle",
-					"schema":   "./schema.ts",
-				},
-			},
+func TestIRValidator_Cron(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "valid",
+			spec: map[string]interface{}{"schedule": "*/15 9-17 * * 1-5", "depends_on": []interface{}{"postgres.primary"}},
+		},
+		{
+			name:    "missing schedule",
+			spec:    map[string]interface{}{},
+			wantErr: "cron.cleanup: missing required field: schedule",
+		},
+		{
+			name: "hour out of range",
+			spec: map[string]interface{}{"schedule": "0 24 * * *"},
+			wantErr: `cron.cleanup: schedule "0 24 * * *" is not a cron expression; ` +
+				`give minute, hour, day of month, month and day of week (e.g., "0 3 * * *" for 03:00 every day)`,
+		},
+		{
+			name:    "depends on a webhook",
+			spec:    map[string]interface{}{"schedule": "0 3 * * *", "depends_on": []interface{}{"webhook.orders"}},
+			wantErr: `cron.cleanup: depends_on reference "webhook.orders" points to webhook, expected postgres`,
 		},
 	}
 
-	b := ir.NewBuilder()
-	builtIR, _ := b.Build(spec)
-	v := NewIRValidator()
-	errs := v.Validate(builtIR)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "postgres.primary", Kind: "postgres", Spec: map[string]interface{}{"provider": "drizzle", "schema": "./schema.ts"}},
+					{ID: "webhook.orders", Kind: "webhook", Spec: map[string]interface{}{
+						"url_env": "ORDERS_WEBHOOK_URL", "secret_env": "ORDERS_WEBHOOK_SECRET",
+						"events": []interface{}{map[string]interface{}{"name": "order.created"}},
+					}},
+					{ID: "cron.cleanup", Kind: "cron", Spec: tt.spec},
+				},
+			}
+			builtIR, _ := ir.NewBuilder().Build(spec)
 
-	if len(errs) != 1 {
-		t.Errorf("Validate() returned %d errors, expected 1 (wrong middleware type)", len(errs))
-		for _, e := range errs {
-			t.Logf("  error: %v", e)
-		}
+			// when
+			errs := NewIRValidator().Validate(builtIR)
+
+			// then
+			if tt.wantErr == "" {
+				if len(errs) != 0 {
+					t.Errorf("Validate() errors = %v, expected none", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Error() != tt.wantErr {
+				t.Errorf("Validate() errors = %v, expected %q", errs, tt.wantErr)
+			}
+		})
 	}
 }
 
-func TestIRValidator_BindsToTypeCheck(t *testing.T) {
-	spec := &parser.Spec{
+func TestValidCronSchedule(t *testing.T) {
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"0 3 * * *", true},
+		{"*/5 * * * *", true},
+		{"0,30 8-18/2 1 1-12 0-7", true},
+		{"0 3 * *", false},
+		{"0 3 * * * *", false},
+		{"60 * * * *", false},
+		{"* * 0 * *", false},
+		{"* * * 13 *", false},
+		{"5-1 * * * *", false},
+		{"*/0 * * * *", false},
+		{"@daily", false},
+		{"0 3 * * MON", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			if got := validCronSchedule(tt.expr); got != tt.want {
+				t.Errorf("validCronSchedule(%q) = %v, expected %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIRValidator_HTTPGateway(t *testing.T) {
+	route := func(prefix, server string) map[string]interface{} {
+		return map[string]interface{}{"prefix": prefix, "server": server}
+	}
+	tests := []struct {
+		name    string
+		spec    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "valid",
+			spec: map[string]interface{}{
+				"port":   8080,
+				"auth":   "middleware.authn",
+				"routes": []interface{}{route("/orders", "http.server.orders"), route("/", "http.server.orders")},
+			},
+		},
+		{
+			name: "port of a server",
+			spec: map[string]interface{}{
+				"port":   3001,
+				"routes": []interface{}{route("/orders", "http.server.orders")},
+			},
+			wantErr: "http.gateway.edge: port 3001 is already used by http.server.orders",
+		},
+		{
+			name: "route to a database",
+			spec: map[string]interface{}{
+				"port":   8080,
+				"routes": []interface{}{route("/orders", "postgres.primary")},
+			},
+			wantErr: `http.gateway.edge: server reference "postgres.primary" points to postgres, expected http.server`,
+		},
+		{
+			name: "prefix with trailing slash",
+			spec: map[string]interface{}{
+				"port":   8080,
+				"routes": []interface{}{route("/orders/", "http.server.orders")},
+			},
+			wantErr: `http.gateway.edge: route prefix "/orders/" must start with / and not end with / (e.g., /orders)`,
+		},
+		{
+			name: "duplicate prefix",
+			spec: map[string]interface{}{
+				"port":   8080,
+				"routes": []interface{}{route("/orders", "http.server.orders"), route("/orders", "http.server.orders")},
+			},
+			wantErr: `http.gateway.edge: route prefix "/orders" is declared more than once`,
+		},
+		{
+			name: "auth on a database",
+			spec: map[string]interface{}{
+				"port":   8080,
+				"auth":   "postgres.primary",
+				"routes": []interface{}{route("/orders", "http.server.orders")},
+			},
+			wantErr: `http.gateway.edge: auth reference "postgres.primary" points to postgres, expected middleware`,
+		},
+		{
+			name:    "missing routes",
+			spec:    map[string]interface{}{"port": 8080},
+			wantErr: "http.gateway.edge: missing required field: routes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "http.server.orders", Kind: "http.server", Spec: map[string]interface{}{
+						"framework": "hono", "port": 3001, "middleware": []interface{}{"middleware.authn"},
+					}},
+					{ID: "middleware.authn", Kind: "middleware", Spec: map[string]interface{}{"provider": "better-auth", "config": "./auth.config.ts"}},
+					{ID: "postgres.primary", Kind: "postgres", Spec: map[string]interface{}{"provider": "drizzle", "schema": "./schema.ts"}},
+					{ID: "http.gateway.edge", Kind: "http.gateway", Spec: tt.spec},
+				},
+			}
+			builtIR, _ := ir.NewBuilder().Build(spec)
+
+			// when
+			errs := NewIRValidator().Validate(builtIR)
+
+			// then
+			if tt.wantErr == "" {
+				if len(errs) != 0 {
+					t.Errorf("Validate() errors = %v, expected none", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Error() != tt.wantErr {
+				t.Errorf("Validate() errors = %v, expected %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIRValidator_Usecase_Limits(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:   "within the server's",
+			limits: map[string]interface{}{"timeout_ms": 5000, "max_body_kb": 1024},
+		},
+		{
+			name:    "longer timeout",
+			limits:  map[string]interface{}{"timeout_ms": 60000},
+			wantErr: "usecase.upload: limits.timeout_ms 60000 exceeds the 30000 http.server.api allows; usecases may only lower the server's limits",
+		},
+		{
+			name:    "larger body",
+			limits:  map[string]interface{}{"max_body_kb": 4096},
+			wantErr: "usecase.upload: limits.max_body_kb 4096 exceeds the 1024 http.server.api allows; usecases may only lower the server's limits",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+						"framework": "hono", "port": 3000,
+						"limits": map[string]interface{}{"timeout_ms": 30000, "max_body_kb": 1024},
+					}},
+					{ID: "usecase.upload", Kind: "usecase", Spec: map[string]interface{}{
+						"binds_to": "http.server.api:POST:/uploads",
+						"goal":     "Upload a file",
+						"limits":   tt.limits,
+					}},
+				},
+			}
+			builtIR, _ := ir.NewBuilder().Build(spec)
+
+			// when
+			errs := NewIRValidator().Validate(builtIR)
+
+			// then
+			if tt.wantErr == "" {
+				if len(errs) != 0 {
+					t.Errorf("Validate() errors = %v, expected none", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Error() != tt.wantErr {
+				t.Errorf("Validate() errors = %v, expected %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIRValidator_Usecase_Cache(t *testing.T) {
+	tests := []struct {
+		name       string
+		bindsTo    string
+		visibility string
+		authorized bool
+		server     bool
+		tables     []interface{}
+		wantErr    string
+	}{
+		{name: "private on a GET route", bindsTo: "http.server.api:GET:/users", visibility: "private", authorized: true},
+		{name: "public on the server", bindsTo: "http.server.api:GET:/users", visibility: "public", server: true, tables: []interface{}{"users"}},
+		{
+			name:       "private on the server",
+			bindsTo:    "http.server.api:GET:/users",
+			visibility: "private",
+			server:     true,
+			wantErr:    "usecase.list-users: cache server shares responses between callers, which visibility private forbids; use visibility public",
+		},
+		{
+			name:       "tables without the server",
+			bindsTo:    "http.server.api:GET:/users",
+			visibility: "public",
+			tables:     []interface{}{"users"},
+			wantErr:    "usecase.list-users: cache tables invalidate the server's cache, which is off; set server: true or remove tables",
+		},
+		{name: "public on a GET route", bindsTo: "http.server.api:GET:/users", visibility: "public"},
+		{
+			name:       "on a POST route",
+			bindsTo:    "http.server.api:POST:/users",
+			visibility: "private",
+			wantErr:    "usecase.list-users: cache applies to GET routes only, not POST",
+		},
+		{
+			name:       "public with authorization",
+			bindsTo:    "http.server.api:GET:/users",
+			visibility: "public",
+			authorized: true,
+			wantErr:    "usecase.list-users: cache visibility public would share responses between callers of a route with authorization; use private",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			usecase := map[string]interface{}{
+				"binds_to": tt.bindsTo,
+				"goal":     "List users",
+				"cache":    map[string]interface{}{"ttl": 60, "visibility": tt.visibility, "server": tt.server},
+			}
+			if tt.tables != nil {
+				usecase["cache"].(map[string]interface{})["tables"] = tt.tables
+			}
+			if tt.authorized {
+				usecase["authorization"] = map[string]interface{}{"roles": []interface{}{"admin"}}
+			}
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+						"framework": "hono", "port": 3000, "middleware": []interface{}{"middleware.authn", "middleware.authz"},
+					}},
+					{ID: "middleware.authn", Kind: "middleware", Spec: map[string]interface{}{"provider": "better-auth"}},
+					{ID: "middleware.authz", Kind: "middleware", Spec: map[string]interface{}{
+						"provider": "casbin",
+						"model":    "./model.conf",
+						"roles":    map[string]interface{}{"admin": map[string]interface{}{}},
+					}},
+					{ID: "usecase.list-users", Kind: "usecase", Spec: usecase},
+				},
+			}
+			builtIR, _ := ir.NewBuilder().Build(spec)
+
+			// when
+			errs := NewIRValidator().Validate(builtIR)
+
+			// then - only the usecase's errors
+			var got []string
+			for _, err := range errs {
+				if err.ID == "usecase.list-users" {
+					got = append(got, err.Error())
+				}
+			}
+			if tt.wantErr == "" {
+				if len(got) != 0 {
+					t.Errorf("Validate() errors = %v, expected none", got)
+				}
+				return
+			}
+			if len(got) != 1 || got[0] != tt.wantErr {
+				t.Errorf("Validate() errors = %v, expected %q", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIRValidator_Usecase_Concurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		bindsTo string
+		read    bool // Whether GET /products/{id} declares concurrency too
+		wantErr string
+	}{
+		{name: "on a GET route", bindsTo: "http.server.api:GET:/products/{id}"},
+		{name: "on a PUT route with a read", bindsTo: "http.server.api:PUT:/products/{id}", read: true},
+		{name: "on a PATCH route with a read", bindsTo: "http.server.api:PATCH:/products/{id}", read: true},
+		{
+			name:    "on a PUT route without a read",
+			bindsTo: "http.server.api:PUT:/products/{id}",
+			wantErr: "usecase.change-product: concurrency etag on PUT /products/{id} needs a GET usecase on the same path with concurrency etag, whose ETag If-Match is checked against",
+		},
+		{
+			name:    "on a POST route",
+			bindsTo: "http.server.api:POST:/products/{id}",
+			read:    true,
+			wantErr: "usecase.change-product: concurrency applies to GET, PUT and PATCH routes without wildcards only, not POST /products/{id}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			read := map[string]interface{}{"binds_to": "http.server.api:GET:/products/{id}", "goal": "Get a product"}
+			if tt.read {
+				read["concurrency"] = "etag"
+			}
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+					{ID: "usecase.get-product", Kind: "usecase", Spec: read},
+					{ID: "usecase.change-product", Kind: "usecase", Spec: map[string]interface{}{
+						"binds_to": tt.bindsTo, "goal": "Change a product", "concurrency": "etag",
+					}},
+				},
+			}
+			builtIR, _ := ir.NewBuilder().Build(spec)
+
+			// when
+			errs := NewIRValidator().Validate(builtIR)
+
+			// then - only the usecase's errors
+			var got []string
+			for _, err := range errs {
+				if err.ID == "usecase.change-product" {
+					got = append(got, err.Error())
+				}
+			}
+			if tt.wantErr == "" {
+				if len(got) != 0 {
+					t.Errorf("Validate() errors = %v, expected none", got)
+				}
+				return
+			}
+			if len(got) != 1 || got[0] != tt.wantErr {
+				t.Errorf("Validate() errors = %v, expected %q", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIRValidator_Usecase_Bulk(t *testing.T) {
+	newIR := func(bindsTo string, body *openapi.Schema) *ir.IR {
+		spec := &parser.Spec{
+			Components: []parser.Component{
+				{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+				{ID: "usecase.import-users", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": bindsTo,
+					"goal":     "Import users",
+					"bulk":     true,
+				}},
+			},
+		}
+		built, _ := ir.NewBuilder().Build(spec)
+		built.Components["http.server.api"].HTTPServer.ParsedOpenAPI = &openapi.Document{
+			Schemas: map[string]*openapi.Schema{
+				"UserList": {Type: "array", Items: &openapi.Schema{Type: "object"}},
+				"User":     {Type: "object"},
+			},
+		}
+		op := &openapi.Operation{OperationID: "importUsers"}
+		if body != nil {
+			op.RequestBody = &openapi.RequestBody{Content: map[string]*openapi.MediaType{"application/json": {Schema: body}}}
+		}
+		built.Components["usecase.import-users"].Usecase.Binding.Operation = op
+		return built
+	}
+
+	tests := []struct {
+		name    string
+		bindsTo string
+		body    *openapi.Schema
+		want    []string
+	}{
+		{"array body", "http.server.api:POST:/users", &openapi.Schema{Type: "array"}, nil},
+		{"referenced array body", "http.server.api:PUT:/users", &openapi.Schema{Ref: "#/components/schemas/UserList"}, nil},
+		{"object body", "http.server.api:POST:/users", &openapi.Schema{Ref: "#/components/schemas/User"}, []string{
+			"usecase.import-users: bulk needs the JSON request body of POST /users to be an array, but it is object",
+		}},
+		{"no body", "http.server.api:PATCH:/users", nil, []string{
+			"usecase.import-users: bulk needs the JSON request body of PATCH /users to be an array, but it is not declared",
+		}},
+		{"GET route", "http.server.api:GET:/users", &openapi.Schema{Type: "array"}, []string{
+			"usecase.import-users: bulk applies to POST, PUT and PATCH routes without wildcards only, not GET /users",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			errs := NewIRValidator().Validate(newIR(tt.bindsTo, tt.body))
+
+			// then
+			var got []string
+			for _, err := range errs {
+				if err.ID == "usecase.import-users" {
+					got = append(got, err.Error())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestIRValidator_Usecase_Async(t *testing.T) {
+	tests := []struct {
+		name      string
+		bindsTo   string
+		dependsOn []interface{}
+		other     string // binds_to of another usecase, if any
+		want      []string
+	}{
+		{name: "POST route", bindsTo: "http.server.api:POST:/reports"},
+		{name: "DELETE route", bindsTo: "http.server.api:DELETE:/reports/{id}"},
+		{name: "GET route", bindsTo: "http.server.api:GET:/reports", want: []string{
+			"usecase.create-report: async applies to POST, PUT, PATCH and DELETE routes without wildcards only, not GET /reports",
+		}},
+		{name: "without database", bindsTo: "http.server.api:POST:/reports", dependsOn: []interface{}{}, want: []string{
+			"usecase.create-report: async usecases keep their jobs in a postgres database, but this usecase uses none; add one to depends_on",
+		}},
+		{name: "status route taken", bindsTo: "http.server.api:POST:/reports", other: "http.server.api:GET:/reports/jobs/{id}", want: []string{
+			"usecase.create-report: async job status route GET /reports/jobs/{jobId} is also bound by usecase.other",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			usecase := map[string]interface{}{"binds_to": tt.bindsTo, "goal": "Create a report", "async": true}
+			if tt.dependsOn != nil {
+				usecase["depends_on"] = tt.dependsOn
+			}
+			components := []parser.Component{
+				{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+					"framework": "hono", "port": 3000, "depends_on": []interface{}{"postgres.primary"},
+				}},
+				{ID: "postgres.primary", Kind: "postgres", Spec: map[string]interface{}{"provider": "drizzle", "schema": "./schema.ts"}},
+				{ID: "usecase.create-report", Kind: "usecase", Spec: usecase},
+			}
+			if tt.other != "" {
+				components = append(components, parser.Component{ID: "usecase.other", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": tt.other, "goal": "Something else",
+				}})
+			}
+			builtIR, _ := ir.NewBuilder().Build(&parser.Spec{Components: components})
+
+			// when
+			errs := NewIRValidator().Validate(builtIR)
+
+			// then - only the usecase's errors
+			var got []string
+			for _, err := range errs {
+				if err.ID == "usecase.create-report" {
+					got = append(got, err.Error())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestIRValidator_Usecase_EmitsTypeCheck(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+			{ID: "postgres.primary", Kind: "postgres", Spec: map[string]interface{}{"provider": "drizzle", "schema": "./schema.ts"}},
+			{ID: "usecase.create-order", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:POST:/orders",
+				"goal":     "Place an order",
+				"emits":    []interface{}{"postgres.primary"},
+			}},
+		},
+	}
+	builtIR, _ := ir.NewBuilder().Build(spec)
+
+	// when
+	errs := NewIRValidator().Validate(builtIR)
+
+	// then
+	want := `usecase.create-order: emits reference "postgres.primary" points to postgres, expected webhook`
+	if len(errs) != 1 || errs[0].Error() != want {
+		t.Errorf("Validate() errors = %v, expected %q", errs, want)
+	}
+}
+
+func TestIRValidator_Usecase_CallsTypeCheck(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+			{ID: "webhook.orders", Kind: "webhook", Spec: map[string]interface{}{
+				"url_env": "ORDERS_WEBHOOK_URL", "secret_env": "ORDERS_WEBHOOK_SECRET",
+				"events": []interface{}{map[string]interface{}{"name": "order.created"}},
+			}},
+			{ID: "usecase.create-order", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:POST:/orders",
+				"goal":     "Place an order",
+				"calls":    []interface{}{"webhook.orders"},
+			}},
+		},
+	}
+	builtIR, _ := ir.NewBuilder().Build(spec)
+
+	// when
+	errs := NewIRValidator().Validate(builtIR)
+
+	// then
+	want := `usecase.create-order: calls reference "webhook.orders" points to webhook, expected http.client`
+	if len(errs) != 1 || errs[0].Error() != want {
+		t.Errorf("Validate() errors = %v, expected %q", errs, want)
+	}
+}
+
+func TestIRValidator_Usecase(t *testing.T) {
+	baseComponents := []parser.Component{
+		{
+			ID:   "http.server.api",
+			Kind: "http.server",
+			Spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		spec       map[string]interface{}
+		wantErrors int
+	}{
+		{
+			name: "valid",
+			spec: map[string]interface{}{
+				"binds_to": "http.server.api:POST:/users",
+				"goal":     "Create user",
+			},
+			wantErrors: 0,
+		},
+		{
+			name: "missing binds_to",
+			spec: map[string]interface{}{
+				"goal": "Create user",
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "missing goal",
+			spec: map[string]interface{}{
+				"binds_to": "http.server.api:POST:/users",
+			},
+			wantErrors: 1,
+		},
+		{
+			name:       "missing both",
+			spec:       map[string]interface{}{},
+			wantErrors: 2,
+		},
+		{
+			name: "invalid binds_to format",
+			spec: map[string]interface{}{
+				"binds_to": "invalid",
+				"goal":     "Test",
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "invalid HTTP method",
+			spec: map[string]interface{}{
+				"binds_to": "http.server.api:INVALID:/users",
+				"goal":     "Test",
+			},
+			wantErrors: 1,
+		},
+		{
+			name: "invalid path no slash",
+			spec: map[string]interface{}{
+				"binds_to": "http.server.api:GET:users",
+				"goal":     "Test",
+			},
+			wantErrors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			components := append(baseComponents, parser.Component{
+				ID:   "usecase.test",
+				Kind: "usecase",
+				Spec: tt.spec,
+			})
+
+			spec := &parser.Spec{Components: components}
+
+			b := ir.NewBuilder()
+			builtIR, _ := b.Build(spec)
+			v := NewIRValidator()
+			errs := v.Validate(builtIR)
+
+			if len(errs) != tt.wantErrors {
+				t.Errorf("Validate() returned %d errors, expected %d", len(errs), tt.wantErrors)
+				for _, e := range errs {
+					t.Logf("  error: %v", e)
+				}
+			}
+		})
+	}
+}
+
+func TestIRValidator_Usecase_BasePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		bindsTo  string
+		wantErrs []string
+	}{
+		{"relative binding", "http.server.api:GET:/users", nil},
+		{"binding sharing a prefix", "http.server.api:GET:/api/v10/users", nil},
+		{"binding repeats base path", "http.server.api:GET:/api/v1/users", []string{
+			`binds_to path "/api/v1/users" already starts with base_path "/api/v1" of http.server.api, which is added to every route; bind to "/users"`,
+		}},
+		{"binding is base path", "http.server.api:GET:/api/v1", []string{
+			`binds_to path "/api/v1" already starts with base_path "/api/v1" of http.server.api, which is added to every route; bind to "/"`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+						"framework": "hono",
+						"port":      3000,
+						"base_path": "/api/v1",
+					}},
+					{ID: "usecase.list-users", Kind: "usecase", Spec: map[string]interface{}{
+						"binds_to": tt.bindsTo,
+						"goal":     "List users",
+					}},
+				},
+			}
+			builtIR, _ := ir.NewBuilder().Build(spec)
+
+			// when
+			errs := NewIRValidator().Validate(builtIR)
+
+			// then
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Message)
+			}
+			if !reflect.DeepEqual(got, tt.wantErrs) {
+				t.Errorf("Validate() = %q, expected %q", got, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestIRValidator_Usecase_APIDocs(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiDocs  interface{}
+		bindsTo  string
+		wantErrs []string
+	}{
+		{"default docs route", nil, "http.server.api:GET:/docs", []string{
+			"binds_to GET /docs is served by the API reference of http.server.api; move the route or set api_docs.path",
+		}},
+		{"docs document", map[string]interface{}{"path": "/reference"}, "http.server.api:GET:/reference/openapi.yaml", []string{
+			"binds_to GET /reference/openapi.yaml is served by the API reference of http.server.api; move the route or set api_docs.path",
+		}},
+		{"other method", nil, "http.server.api:POST:/docs", nil},
+		{"moved docs", map[string]interface{}{"path": "/reference"}, "http.server.api:GET:/docs", nil},
+		{"disabled docs", false, "http.server.api:GET:/docs", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			server := map[string]interface{}{"framework": "hono", "port": 3000}
+			if tt.apiDocs != nil {
+				server["api_docs"] = tt.apiDocs
+			}
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "http.server.api", Kind: "http.server", Spec: server},
+					{ID: "usecase.docs", Kind: "usecase", Spec: map[string]interface{}{
+						"binds_to": tt.bindsTo,
+						"goal":     "Serve documents",
+					}},
+				},
+			}
+			builtIR, _ := ir.NewBuilder().Build(spec)
+
+			// when
+			errs := NewIRValidator().Validate(builtIR)
+
+			// then
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Message)
+			}
+			if !reflect.DeepEqual(got, tt.wantErrs) {
+				t.Errorf("Validate() = %q, expected %q", got, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestIRValidator_Usecase_MetricsPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		observability map[string]interface{}
+		bindsTo       string
+		wantErrs      []string
+	}{
+		{"default metrics route", map[string]interface{}{"metrics": true}, "http.server.api:GET:/metrics", []string{
+			"binds_to GET /metrics is served by the metrics endpoint of http.server.api; move the route or set observability.metrics_path",
+		}},
+		{"moved metrics", map[string]interface{}{"metrics": true, "metrics_path": "/internal/metrics"}, "http.server.api:GET:/metrics", nil},
+		{"other method", map[string]interface{}{"metrics": true}, "http.server.api:POST:/metrics", nil},
+		{"metrics off", nil, "http.server.api:GET:/metrics", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			server := map[string]interface{}{"framework": "hono", "port": 3000}
+			if tt.observability != nil {
+				server["observability"] = tt.observability
+			}
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "http.server.api", Kind: "http.server", Spec: server},
+					{ID: "usecase.metrics", Kind: "usecase", Spec: map[string]interface{}{
+						"binds_to": tt.bindsTo,
+						"goal":     "Report metrics",
+					}},
+				},
+			}
+			builtIR, _ := ir.NewBuilder().Build(spec)
+
+			// when
+			errs := NewIRValidator().Validate(builtIR)
+
+			// then
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Message)
+			}
+			if !reflect.DeepEqual(got, tt.wantErrs) {
+				t.Errorf("Validate() = %q, expected %q", got, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestIRValidator_Usecase_CatchAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		bindings map[string]string
+		wantErrs []string
+	}{
+		{
+			name:     "separate prefixes",
+			bindings: map[string]string{"usecase.proxy": "ALL:/proxy/*", "usecase.get-file": "GET:/files/{id}"},
+		},
+		{
+			name:     "wildcard covers a route",
+			bindings: map[string]string{"usecase.proxy": "ALL:/files/*", "usecase.get-file": "GET:/files/{id}"},
+			wantErrs: []string{"binds_to ALL /files/* also matches GET /files/{id} of usecase.get-file; a catch-all binding must not overlap other routes"},
+		},
+		{
+			name:     "preflight next to a route",
+			bindings: map[string]string{"usecase.preflight": "OPTIONS:/files/*", "usecase.get-file": "GET:/files/{id}"},
+		},
+		{
+			name:     "two catch-all bindings",
+			bindings: map[string]string{"usecase.a": "ALL:/*", "usecase.b": "OPTIONS:/files/*"},
+			wantErrs: []string{"binds_to ALL /* also matches OPTIONS /files/* of usecase.b; a catch-all binding must not overlap other routes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+						"framework": "hono",
+						"port":      3000,
+					}},
+				},
+			}
+			for id, binding := range tt.bindings {
+				spec.Components = append(spec.Components, parser.Component{ID: id, Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:" + binding,
+					"goal":     "Handle requests",
+				}})
+			}
+			builtIR, _ := ir.NewBuilder().Build(spec)
+
+			// when
+			errs := NewIRValidator().Validate(builtIR)
+
+			// then
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Message)
+			}
+			if !reflect.DeepEqual(got, tt.wantErrs) {
+				t.Errorf("Validate() = %q, expected %q", got, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestIRValidator_MiddlewareTypeCheck(t *testing.T) {
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{
+				ID:   "http.server.api",
+				Kind: "http.server",
+				Spec: map[string]interface{}{
+					"framework":  "hono",
+					"port":       3000,
+					"middleware": []interface{}{"postgres.primary"},
+				},
+			},
+			{
+				ID:   "postgres.primary",
+				Kind: "postgres",
+				Spec: map[string]interface{}{
+					"provider": "drizzle",
+					"schema":   "./schema.ts",
+				},
+			},
+		},
+	}
+
+	b := ir.NewBuilder()
+	builtIR, _ := b.Build(spec)
+	v := NewIRValidator()
+	errs := v.Validate(builtIR)
+
+	if len(errs) != 1 {
+		t.Errorf("Validate() returned %d errors, expected 1 (wrong middleware type)", len(errs))
+		for _, e := range errs {
+			t.Logf("  error: %v", e)
+		}
+	}
+}
+
+func TestIRValidator_BindsToTypeCheck(t *testing.T) {
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{
+				ID:   "postgres.primary",
+				Kind: "postgres",
+				Spec: map[string]interface{}{
+					"provider": "drizzle",
+					"schema":   "./schema.ts",
+				},
+			},
+			{
+				ID:   "usecase.test",
+				Kind: "usecase",
+				Spec: map[string]interface{}{
+					"binds_to": "postgres.primary:GET:/test",
+					"goal":     "Test",
+				},
+			},
+		},
+	}
+
+	b := ir.NewBuilder()
+	builtIR, _ := b.Build(spec)
+	v := NewIRValidator()
+	errs := v.Validate(builtIR)
+
+	found := false
+	for _, e := range errs {
+		if e.ID == "usecase.test" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Validate() should error on binds_to pointing to postgres")
+	}
+}
+
+func TestIRValidator_NilSpecs(t *testing.T) {
+	builtIR := ir.New(&parser.Spec{})
+
+	builtIR.Components["http.server.api"] = &ir.Component{ID: "http.server.api", Kind: ir.KindHTTPServer, HTTPServer: nil}
+	builtIR.Components["middleware.auth"] = &ir.Component{ID: "middleware.auth", Kind: ir.KindMiddleware, Middleware: nil}
+	builtIR.Components["postgres.db"] = &ir.Component{ID: "postgres.db", Kind: ir.KindPostgres, Postgres: nil}
+	builtIR.Components["usecase.test"] = &ir.Component{ID: "usecase.test", Kind: ir.KindUsecase, Usecase: nil}
+
+	v := NewIRValidator()
+	errs := v.Validate(builtIR)
+	if len(errs) != 4 {
+		t.Errorf("Validate() returned %d errors, expected 4 (one for each nil spec)", len(errs))
+	}
+}
+
+func TestIRValidator_WithCycle(t *testing.T) {
+	builtIR := ir.New(&parser.Spec{})
+
+	compA := &ir.Component{
+		ID:   "middleware.a",
+		Kind: ir.KindMiddleware,
+		Middleware: &ir.MiddlewareSpec{
+			Provider: "better-auth",
+			Config:   "./auth.ts",
+		},
+		Dependencies: []*ir.Component{},
+	}
+	compB := &ir.Component{
+		ID:   "middleware.b",
+		Kind: ir.KindMiddleware,
+		Middleware: &ir.MiddlewareSpec{
+			Provider: "better-auth",
+			Config:   "./auth.ts",
+		},
+		Dependencies: []*ir.Component{},
+	}
+
+	compA.Dependencies = append(compA.Dependencies, compB)
+	compB.Dependencies = append(compB.Dependencies, compA)
+
+	builtIR.Components["middleware.a"] = compA
+	builtIR.Components["middleware.b"] = compB
+	_ = builtIR.Symbols.Define("middleware.a", ir.KindMiddleware, compA)
+	_ = builtIR.Symbols.Define("middleware.b", ir.KindMiddleware, compB)
+
+	v := NewIRValidator()
+	errs := v.Validate(builtIR)
+	if len(errs) == 0 {
+		t.Error("Validate() should detect cycle")
+	}
+}
+
+func TestIRValidator_UsecaseMiddlewareTypeCheck(t *testing.T) {
+	spec := &parser.Spec{
 		Components: []parser.Component{
+			{
+				ID:   "http.server.api",
+				Kind: "http.server",
+				Spec: map[string]interface{}{
+					"framework": "hono",
+					"port":      3000,
+				},
+			},
 			{
 				ID:   "postgres.primary",
 				Kind: "postgres",
@@ -453,164 +1644,1069 @@ func TestIRValidator_BindsToTypeCheck(t *testing.T) {
 				ID:   "usecase.test",
 				Kind: "usecase",
 				Spec: map[string]interface{}{
-					"binds_to": "postgres.primary:GET:/test",
-					"goal":     "Test",
+					"binds_to":   "http.server.api:POST:/test",
+					"goal":       "Test",
+					"middleware": []interface{}{"postgres.primary"},
+				},
+			},
+		},
+	}
+
+	b := ir.NewBuilder()
+	builtIR, _ := b.Build(spec)
+	v := NewIRValidator()
+	errs := v.Validate(builtIR)
+
+	found := false
+	for _, e := range errs {
+		if e.ID == "usecase.test" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Validate() should error on usecase middleware pointing to postgres")
+	}
+}
+
+func TestIRValidator_AllHTTPMethods(t *testing.T) {
+	methods := []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+	for _, method := range methods {
+		t.Run(method, func(t *testing.T) {
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{
+						ID:   "http.server.api",
+						Kind: "http.server",
+						Spec: map[string]interface{}{
+							"framework": "hono",
+							"port":      3000,
+						},
+					},
+					{
+						ID:   "usecase.test",
+						Kind: "usecase",
+						Spec: map[string]interface{}{
+							"binds_to": "http.server.api:" + method + ":/test",
+							"goal":     "Test",
+						},
+					},
+				},
+			}
+
+			b := ir.NewBuilder()
+			builtIR, _ := b.Build(spec)
+			v := NewIRValidator()
+			errs := v.Validate(builtIR)
+
+			for _, e := range errs {
+				if e.ID == "usecase.test" {
+					t.Errorf("Validate() should accept %s method, got error: %v", method, e)
+				}
+			}
+		})
+	}
+}
+
+// openAPIIssueIR returns a server whose OpenAPI document has issues.
+func openAPIIssueIR(issues ...openapi.Issue) *ir.IR {
+	return &ir.IR{
+		Components: map[string]*ir.Component{
+			"http.server.api": {
+				ID:   "http.server.api",
+				Kind: ir.KindHTTPServer,
+				HTTPServer: &ir.HTTPServerSpec{
+					Framework:     "hono",
+					Port:          3000,
+					OpenAPI:       "./openapi.yaml",
+					ParsedOpenAPI: &openapi.Document{File: "/project/openapi.yaml", Issues: issues},
+				},
+			},
+		},
+	}
+}
+
+func TestIRValidator_HTTPServer_OpenAPIIssues(t *testing.T) {
+	// given
+	i := &ir.IR{
+		Components: map[string]*ir.Component{
+			"http.server.api": {
+				ID:   "http.server.api",
+				Kind: ir.KindHTTPServer,
+				HTTPServer: &ir.HTTPServerSpec{
+					Framework: "hono",
+					Port:      3000,
+					OpenAPI:   "./openapi.yaml",
+					ParsedOpenAPI: &openapi.Document{
+						File: "/project/openapi.yaml",
+						Issues: []openapi.Issue{{
+							Code:     openapi.IssueDuplicateOperationID,
+							Message:  `duplicate operationId "users" (already used by GET /users)`,
+							Location: "paths./users.get",
+							Line:     7,
+							Column:   5,
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	// when
+	errs := NewIRValidator().Validate(i)
+
+	// then
+	if len(errs) != 1 {
+		t.Fatalf("Validate() returned %d errors, expected 1: %v", len(errs), errs)
+	}
+	want := `http.server.api: ./openapi.yaml:7:5: duplicate operationId "users" (already used by GET /users) (at paths./users.get)`
+	if errs[0].Error() != want {
+		t.Errorf("Error() = %q, expected %q", errs[0].Error(), want)
+	}
+	if errs[0].Position != (parser.Position{File: "/project/openapi.yaml", Line: 7, Column: 5}) {
+		t.Errorf("Position = %+v, expected the OpenAPI file location", errs[0].Position)
+	}
+}
+
+func TestIRValidator_Warnings_OpenAPIIssues(t *testing.T) {
+	// given
+	i := openAPIIssueIR(
+		openapi.Issue{Code: openapi.IssueNoSuccessResponse, Message: "operation has no 2xx or default response", Location: "paths./users.delete", Line: 7, Column: 5},
+		openapi.Issue{Code: openapi.IssueUndeclaredPathParameter, Message: `path parameter "id" is not declared in parameters`, Location: "paths./users/{id}.get", Line: 12, Column: 5},
+	)
+	v := NewIRValidator()
+
+	// when
+	errs := v.Validate(i)
+	warnings := v.Warnings(i)
+
+	// then
+	if len(errs) != 0 {
+		t.Errorf("Validate() = %v, expected no errors", errs)
+	}
+	var got []string
+	for _, w := range warnings {
+		if w.Rule == RuleOpenAPINoSuccessResponse || w.Rule == RuleOpenAPIUndeclaredPathParameter {
+			got = append(got, w.Rule+": "+w.Error())
+		}
+	}
+	want := []string{
+		"openapi-no-success-response: http.server.api: ./openapi.yaml:7:5: operation has no 2xx or default response (at paths./users.delete)",
+		`openapi-undeclared-path-parameter: http.server.api: ./openapi.yaml:12:5: path parameter "id" is not declared in parameters (at paths./users/{id}.get)`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Warnings() = %q, expected %q", got, want)
+	}
+}
+
+func TestIRValidator_Middleware_CasbinPolicyAdapter(t *testing.T) {
+	// given
+	i := &ir.IR{
+		Components: map[string]*ir.Component{
+			"middleware.authz": {
+				ID:   "middleware.authz",
+				Kind: ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{
+					Provider:      "casbin",
+					Model:         "./model.conf",
+					Policy:        "./policy.csv",
+					PolicyAdapter: "postgres",
+					DependsOn:     []string{"postgres.primary", "postgres.audit"},
+				},
+			},
+			"postgres.primary": {ID: "postgres.primary", Kind: ir.KindPostgres, Postgres: &ir.PostgresSpec{Provider: "drizzle", Schema: "./schema.ts"}},
+			"postgres.audit":   {ID: "postgres.audit", Kind: ir.KindPostgres, Postgres: &ir.PostgresSpec{Provider: "drizzle", Schema: "./audit.ts"}},
+		},
+	}
+
+	// when
+	errs := NewIRValidator().Validate(i)
+
+	// then
+	if len(errs) != 1 {
+		t.Fatalf("Validate() returned %d errors, expected 1: %v", len(errs), errs)
+	}
+	want := "middleware.authz: policy_adapter postgres requires exactly one postgres component in depends_on, found 2"
+	if errs[0].Error() != want {
+		t.Errorf("Error() = %q, expected %q", errs[0].Error(), want)
+	}
+}
+
+func TestIRValidator_Middleware_BetterAuthSessionStore(t *testing.T) {
+	// given
+	i := &ir.IR{
+		Components: map[string]*ir.Component{
+			"middleware.auth": {
+				ID:   "middleware.auth",
+				Kind: ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{
+					Provider: "better-auth",
+					Config:   "./auth.config.ts",
+					Session:  &ir.SessionSpec{Storage: ir.SessionStorageDatabase, Store: "middleware.authz"},
+				},
+			},
+			"middleware.authz": {
+				ID:         "middleware.authz",
+				Kind:       ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{Provider: "casbin", Model: "./model.conf", Policy: "./policy.csv"},
+			},
+		},
+	}
+
+	// when
+	errs := NewIRValidator().Validate(i)
+
+	// then
+	if len(errs) != 1 {
+		t.Fatalf("Validate() returned %d errors, expected 1: %v", len(errs), errs)
+	}
+	want := `middleware.auth: session store "middleware.authz" must be a postgres component, got middleware`
+	if errs[0].Error() != want {
+		t.Errorf("Error() = %q, expected %q", errs[0].Error(), want)
+	}
+}
+
+func TestIRValidator_Middleware_OAuthInlineSecret(t *testing.T) {
+	// given
+	i := &ir.IR{
+		Components: map[string]*ir.Component{
+			"middleware.auth": {
+				ID:   "middleware.auth",
+				Kind: ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{
+					Provider: "better-auth",
+					Config:   "./auth.config.ts",
+					OAuth: []ir.OAuthProvider{
+						{Provider: "github", ClientIDEnv: "GITHUB_CLIENT_ID", ClientSecretEnv: "3f9a1c0d2b7e"},
+					},
+				},
+			},
+		},
+	}
+
+	// when
+	errs := NewIRValidator().Validate(i)
+
+	// then
+	if len(errs) != 1 {
+		t.Fatalf("Validate() returned %d errors, expected 1: %v", len(errs), errs)
+	}
+	want := `middleware.auth: oauth github: client_secret_env "3f9a1c0d2b7e" is not an environment variable name; ` +
+		"name the variable that holds the value (e.g., GITHUB_CLIENT_SECRET) instead of inlining it"
+	if errs[0].Error() != want {
+		t.Errorf("Error() = %q, expected %q", errs[0].Error(), want)
+	}
+}
+
+func TestIRValidator_Middleware_CasbinRBAC(t *testing.T) {
+	// given
+	model := casbin.ParseModel([]byte("[request_definition]\nr = sub, obj, act, dom\n\n[policy_definition]\np = sub, obj, act, dom\n\n" +
+		"[policy_effect]\ne = some(where (p.eft == allow))\n\n[matchers]\nm = r.sub == p.sub && r.obj == p.obj && r.act == p.act && r.dom == p.dom\n"))
+	i := &ir.IR{
+		Components: map[string]*ir.Component{
+			"middleware.authz": {
+				ID:   "middleware.authz",
+				Kind: ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{
+					Provider:    "casbin",
+					Model:       "./model.conf",
+					Policy:      "./policy.csv",
+					ParsedModel: model,
+					Permissions: []ir.Permission{{Name: "user.read", Object: "/users", Action: "GET"}},
+					Roles: []ir.Role{
+						{Name: "admin", Permissions: []string{"user.read", "user.delete"}, Inherits: []string{"editor"}},
+						{Name: "editor", Inherits: []string{"admin", "owner"}},
+					},
+				},
+			},
+		},
+	}
+
+	// when
+	errs := NewIRValidator().Validate(i)
+
+	// then
+	got := make([]string, len(errs))
+	for n, err := range errs {
+		got[n] = err.Error()
+	}
+	want := []string{
+		`middleware.authz: role "admin" grants undeclared permission "user.delete"`,
+		`middleware.authz: role "editor" inherits undeclared role "owner"`,
+		"middleware.authz: role inheritance cycle: admin -> editor -> admin",
+		"middleware.authz: roles need a policy definition with three fields (p = sub, obj, act), model has p = sub, obj, act, dom",
+		"middleware.authz: role inheritance needs a role definition (g = _, _) in the model",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestIRValidator_Usecase_Authorization(t *testing.T) {
+	newIR := func(serverMiddleware []interface{}) *ir.IR {
+		spec := &parser.Spec{
+			Components: []parser.Component{
+				{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+					"framework":  "hono",
+					"port":       3000,
+					"middleware": serverMiddleware,
+				}},
+				{ID: "middleware.authn", Kind: "middleware", Spec: map[string]interface{}{
+					"provider": "better-auth",
+				}},
+				{ID: "middleware.authz", Kind: "middleware", Spec: map[string]interface{}{
+					"provider": "casbin",
+					"model":    "./model.conf",
+					"policy":   "./policy.csv",
+					"permissions": map[string]interface{}{
+						"user.read": map[string]interface{}{"object": "/users", "action": "GET"},
+					},
+					"roles": map[string]interface{}{
+						"admin": map[string]interface{}{"permissions": []interface{}{"user.read"}},
+					},
+				}},
+				{ID: "usecase.list-users", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:GET:/users",
+					"goal":     "List users",
+					"authorization": map[string]interface{}{
+						"roles":       []interface{}{"admin", "auditor"},
+						"permissions": []interface{}{"user.read", "user.list"},
+					},
+				}},
+			},
+		}
+		built, _ := ir.NewBuilder().Build(spec)
+		return built
+	}
+
+	tests := []struct {
+		name             string
+		serverMiddleware []interface{}
+		want             []string
+	}{
+		{"undeclared role and permission", []interface{}{"middleware.authn", "middleware.authz"}, []string{
+			`usecase.list-users: authorization requires role "auditor", which middleware.authz does not declare`,
+			`usecase.list-users: authorization requires permission "user.list", which middleware.authz does not declare`,
+		}},
+		{"no casbin middleware", []interface{}{"middleware.authn"}, []string{
+			"usecase.list-users: authorization needs a casbin middleware to run for this usecase",
+		}},
+		{"no better-auth middleware", []interface{}{"middleware.authz"}, []string{
+			"usecase.list-users: authorization needs a better-auth middleware to identify the caller",
+			`usecase.list-users: authorization requires role "auditor", which middleware.authz does not declare`,
+			`usecase.list-users: authorization requires permission "user.list", which middleware.authz does not declare`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			errs := NewIRValidator().Validate(newIR(tt.serverMiddleware))
+
+			// then - only the usecase's errors; the middleware are incomplete on purpose
+			var got []string
+			for _, err := range errs {
+				if err.ID == "usecase.list-users" {
+					got = append(got, err.Error())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestIRValidator_Middleware_Chain(t *testing.T) {
+	// given - a chain whose casbin provider lacks its policy, guarding a usecase
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework":  "hono",
+				"port":       3000,
+				"middleware": []interface{}{"middleware.auth"},
+			}},
+			{ID: "middleware.auth", Kind: "middleware", Spec: map[string]interface{}{
+				"chain": []interface{}{
+					map[string]interface{}{"provider": "better-auth", "config": "./auth.ts"},
+					map[string]interface{}{
+						"provider": "casbin",
+						"model":    "./model.conf",
+						"roles":    map[string]interface{}{"admin": map[string]interface{}{}},
+					},
+				},
+			}},
+			{ID: "usecase.list-users", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to":      "http.server.api:GET:/users",
+				"goal":          "List users",
+				"authorization": map[string]interface{}{"roles": []interface{}{"admin"}},
+			}},
+		},
+	}
+	built, _ := ir.NewBuilder().Build(spec)
+
+	// when
+	errs := NewIRValidator().Validate(built)
+
+	// then - the provider is validated on its own and authorizes the usecase
+	var got []string
+	for _, err := range errs {
+		if strings.HasPrefix(err.ID, "middleware.auth") || err.ID == "usecase.list-users" {
+			got = append(got, err.Error())
+		}
+	}
+	want := []string{"middleware.auth.casbin: casbin provider requires policy field"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestIRValidator_Usecase_InputMapping(t *testing.T) {
+	op := &openapi.Operation{
+		OperationID: "updateUser",
+		Method:      "PATCH",
+		Path:        "/users/{id}",
+		Parameters: []openapi.Parameter{
+			{Name: "id", In: "path", Required: true},
+			{Name: "notify", In: "query"},
+		},
+		RequestBody: &openapi.RequestBody{Content: map[string]*openapi.MediaType{
+			"application/json": {Schema: &openapi.Schema{Ref: "#/components/schemas/UserPatch"}},
+		}},
+	}
+	newIR := func(bindsTo string, mapping map[string]interface{}) *ir.IR {
+		spec := &parser.Spec{
+			Components: []parser.Component{
+				{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+				{ID: "usecase.update-user", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to":      bindsTo,
+					"goal":          "Update a user",
+					"input_mapping": mapping,
+				}},
+			},
+		}
+		built, _ := ir.NewBuilder().Build(spec)
+		built.Components["http.server.api"].HTTPServer.ParsedOpenAPI = &openapi.Document{
+			Operations: map[string]*openapi.Operation{op.OperationKey(): op},
+			Schemas: map[string]*openapi.Schema{
+				"UserPatch": {Type: "object", Properties: map[string]*openapi.Schema{"email_address": {Type: "string"}}},
+			},
+		}
+		if binding := built.Components["usecase.update-user"].Usecase.Binding; binding.Path == op.Path {
+			binding.Operation = op
+		}
+		return built
+	}
+
+	tests := []struct {
+		name    string
+		bindsTo string
+		mapping map[string]interface{}
+		want    []string
+	}{
+		{"declared fields", "http.server.api:PATCH:/users/{id}", map[string]interface{}{
+			"userId": "path.id",
+			"email":  map[string]interface{}{"from": "body.email_address", "transform": "lowercase"},
+			"notify": "query.notify",
+		}, nil},
+		{"undeclared fields", "http.server.api:PATCH:/users/{id}", map[string]interface{}{
+			"userId": "path.user_id",
+			"email":  "body.email",
+			"dryRun": "query.dry_run",
+		}, []string{
+			`usecase.update-user: input_mapping dryRun reads query parameter "dry_run", which PATCH:/users/{id} does not declare`,
+			`usecase.update-user: input_mapping email reads body field "email", which the request body of PATCH:/users/{id} does not declare`,
+			`usecase.update-user: input_mapping userId reads path parameter "user_id", which binds_to path /users/{id} does not declare`,
+		}},
+		{"body of a GET", "http.server.api:GET:/users/{id}", map[string]interface{}{
+			"email": "body.email_address",
+		}, []string{
+			"usecase.update-user: input_mapping email reads the body, but GET requests have none",
+		}},
+		{"catch-all binding", "http.server.api:ALL:/users/*", map[string]interface{}{
+			"email": "body.email_address",
+		}, []string{
+			"usecase.update-user: input_mapping is not supported on catch-all bindings, whose usecase receives the raw request",
+		}},
+		{"unknown source and transform", "http.server.api:PATCH:/users/{id}", map[string]interface{}{
+			"email": map[string]interface{}{"from": "header.email", "transform": "reverse"},
+		}, []string{
+			`usecase.update-user: input_mapping email: unsupported transform "reverse" (supported: trim, lowercase, uppercase, number)`,
+			`usecase.update-user: input_mapping email reads "header.email"; use body.<field>, path.<param> or query.<param>`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			errs := NewIRValidator().Validate(newIR(tt.bindsTo, tt.mapping))
+
+			// then
+			var got []string
+			for _, err := range errs {
+				if err.ID == "usecase.update-user" {
+					got = append(got, err.Error())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestIRValidator_Usecase_PathParams(t *testing.T) {
+	newIR := func(path string, params []openapi.Parameter) *ir.IR {
+		spec := &parser.Spec{
+			Components: []parser.Component{
+				{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+				{ID: "usecase.get-post", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:GET:" + path,
+					"goal":     "Get a post",
+				}},
+			},
+		}
+		built, _ := ir.NewBuilder().Build(spec)
+		if params != nil {
+			built.Components["usecase.get-post"].Usecase.Binding.Operation = &openapi.Operation{
+				Method: "GET", Path: path, Parameters: params,
+			}
+		}
+		return built
+	}
+	str := &openapi.Schema{Type: "string"}
+
+	tests := []struct {
+		name   string
+		path   string
+		params []openapi.Parameter
+		want   []string
+	}{
+		{"matching parameters", "/users/{userId}/posts/{id}", []openapi.Parameter{
+			{Name: "userId", In: "path", Required: true, Schema: str},
+			{Name: "id", In: "path", Required: true, Schema: &openapi.Schema{Type: "integer"}},
+			{Name: "expand", In: "query", Schema: str},
+		}, nil},
+		{"no operation", "/users/{userId}/posts/{id}", nil, nil},
+		{"repeated parameter", "/users/{id}/posts/{id}", nil, []string{
+			`usecase.get-post: binds_to path /users/{id}/posts/{id} names path parameter "id" more than once`,
+		}},
+		{"parameter missing from the path", "/posts/{id}", []openapi.Parameter{
+			{Name: "id", In: "path", Required: true, Schema: str},
+			{Name: "userId", In: "path", Required: true, Schema: str},
+		}, []string{
+			`usecase.get-post: GET:/posts/{id} declares path parameter "userId", which binds_to path /posts/{id} does not contain`,
+		}},
+		{"optional and structured parameters", "/posts/{id}/{tags}", []openapi.Parameter{
+			{Name: "id", In: "path", Schema: str},
+			{Name: "tags", In: "path", Required: true, Schema: &openapi.Schema{Type: "array", Items: str}},
+		}, []string{
+			`usecase.get-post: path parameter "id" of GET:/posts/{id}/{tags} must be required, since a path segment cannot be left out`,
+			`usecase.get-post: path parameter "tags" of GET:/posts/{id}/{tags} has type array; path parameters must be string, integer, number or boolean`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			errs := NewIRValidator().Validate(newIR(tt.path, tt.params))
+
+			// then
+			var got []string
+			for _, err := range errs {
+				if err.ID == "usecase.get-post" {
+					got = append(got, err.Error())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestIRValidator_Middleware_CasbinModelIssues(t *testing.T) {
+	// given
+	i := &ir.IR{
+		Components: map[string]*ir.Component{
+			"middleware.authz": {
+				ID:   "middleware.authz",
+				Kind: ir.KindMiddleware,
+				Middleware: &ir.MiddlewareSpec{
+					Provider: "casbin",
+					Model:    "./model.conf",
+					Policy:   "./policy.csv",
+					ParsedModel: &casbin.Model{
+						File:   "/project/model.conf",
+						Issues: []casbin.Issue{{Message: "line 8: m: r.act is not defined by r", Line: 8}},
+					},
 				},
 			},
 		},
 	}
 
-	b := ir.NewBuilder()
-	builtIR, _ := b.Build(spec)
-	v := NewIRValidator()
-	errs := v.Validate(builtIR)
+	// when
+	errs := NewIRValidator().Validate(i)
 
-	found := false
-	for _, e := range errs {
-		if e.ID == "usecase.test" {
-			found = true
-		}
+	// then
+	if len(errs) != 1 {
+		t.Fatalf("Validate() returned %d errors, expected 1: %v", len(errs), errs)
 	}
-	if !found {
-		t.Error("Validate() should error on binds_to pointing to postgres")
+	want := "middleware.authz: ./model.conf: line 8: m: r.act is not defined by r"
+	if errs[0].Error() != want {
+		t.Errorf("Error() = %q, expected %q", errs[0].Error(), want)
+	}
+	if errs[0].Position != (parser.Position{File: "/project/model.conf", Line: 8}) {
+		t.Errorf("Position = %+v, expected the model file location", errs[0].Position)
 	}
 }
 
-func TestIRValidator_NilSpecs(t *testing.T) {
-	builtIR := ir.New(&parser.Spec{})
+func TestIRValidator_Warnings_UnusedComponents(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework": "hono",
+				"port":      3000,
+			}},
+			{ID: "usecase.list-users", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to": "http.server.api:GET:/users",
+				"goal":     "List users",
+			}},
+			{ID: "middleware.audit", Kind: "middleware", Spec: map[string]interface{}{
+				"provider": "casbin",
+			}},
+			{ID: "postgres.analytics", Kind: "postgres", Spec: map[string]interface{}{
+				"provider": "drizzle",
+			}},
+		},
+	}
+	builtIR, errs := ir.NewBuilder().Build(spec)
+	if len(errs) > 0 {
+		t.Fatalf("Build() errors: %v", errs)
+	}
 
-	builtIR.Components["http.server.api"] = &ir.Component{ID: "http.server.api", Kind: ir.KindHTTPServer, HTTPServer: nil}
-	builtIR.Components["middleware.auth"] = &ir.Component{ID: "middleware.auth", Kind: ir.KindMiddleware, Middleware: nil}
-	builtIR.Components["postgres.db"] = &ir.Component{ID: "postgres.db", Kind: ir.KindPostgres, Postgres: nil}
-	builtIR.Components["usecase.test"] = &ir.Component{ID: "usecase.test", Kind: ir.KindUsecase, Usecase: nil}
+	// when
+	warnings := NewIRValidator().Warnings(builtIR)
 
-	v := NewIRValidator()
-	errs := v.Validate(builtIR)
-	if len(errs) != 4 {
-		t.Errorf("Validate() returned %d errors, expected 4 (one for each nil spec)", len(errs))
+	// then
+	if len(warnings) != 2 {
+		t.Fatalf("Warnings() returned %d warnings, expected 2: %v", len(warnings), warnings)
+	}
+	if warnings[0].ID != "middleware.audit" || warnings[1].ID != "postgres.analytics" {
+		t.Errorf("Warnings() IDs = %q, %q; expected middleware.audit, postgres.analytics", warnings[0].ID, warnings[1].ID)
+	}
+	for _, w := range warnings {
+		if w.Rule != RuleUnusedComponent {
+			t.Errorf("Rule = %q, expected %q", w.Rule, RuleUnusedComponent)
+		}
 	}
 }
 
-func TestIRValidator_WithCycle(t *testing.T) {
-	builtIR := ir.New(&parser.Spec{})
+func TestIRValidator_Warnings_TestsDisabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		testing  map[string]any
+		criteria []any
+		want     bool
+	}{
+		{name: "defaults", criteria: []any{"Users are listed"}},
+		{name: "unit only", testing: map[string]any{"e2e": "skip"}, criteria: []any{"Users are listed"}},
+		{name: "e2e only", testing: map[string]any{"unit": false}, criteria: []any{"Users are listed"}},
+		{name: "all disabled", testing: map[string]any{"unit": false, "e2e": "skip"}, criteria: []any{"Users are listed"}, want: true},
+		{name: "all disabled without criteria", testing: map[string]any{"unit": false, "e2e": "skip"}},
+	}
 
-	compA := &ir.Component{
-		ID:   "middleware.a",
-		Kind: ir.KindMiddleware,
-		Middleware: &ir.MiddlewareSpec{
-			Provider: "better-auth",
-			Config:   "./auth.ts",
-		},
-		Dependencies: []*ir.Component{},
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			usecaseSpec := map[string]interface{}{
+				"binds_to": "http.server.api:GET:/users",
+				"goal":     "List users",
+			}
+			if tt.testing != nil {
+				usecaseSpec["testing"] = tt.testing
+			}
+			if tt.criteria != nil {
+				usecaseSpec["acceptance_criteria"] = tt.criteria
+			}
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+					{ID: "usecase.list-users", Kind: "usecase", Spec: usecaseSpec},
+				},
+			}
+			builtIR, errs := ir.NewBuilder().Build(spec)
+			if len(errs) > 0 {
+				t.Fatalf("Build() errors: %v", errs)
+			}
+
+			// when
+			warnings := NewIRValidator().Warnings(builtIR)
+
+			// then
+			if got := len(warnings) == 1 && warnings[0].Rule == RuleTestsDisabled; got != tt.want {
+				t.Errorf("tests-disabled warning = %v, expected %v (warnings: %v)", got, tt.want, warnings)
+			}
+		})
 	}
-	compB := &ir.Component{
-		ID:   "middleware.b",
-		Kind: ir.KindMiddleware,
-		Middleware: &ir.MiddlewareSpec{
-			Provider: "better-auth",
-			Config:   "./auth.ts",
-		},
-		Dependencies: []*ir.Component{},
+}
+
+func TestIRValidator_Warnings_SLOWithoutMetrics(t *testing.T) {
+	tests := []struct {
+		name          string
+		observability map[string]any
+		slo           map[string]any
+		want          bool
+	}{
+		{name: "no slo"},
+		{name: "slo without observability", slo: map[string]any{"p99_latency_ms": 200}, want: true},
+		{name: "slo with metrics off", observability: map[string]any{"metrics": false}, slo: map[string]any{"error_budget": 0.1}, want: true},
+		{name: "slo with metrics", observability: map[string]any{"metrics": true}, slo: map[string]any{"p99_latency_ms": 200}},
 	}
 
-	compA.Dependencies = append(compA.Dependencies, compB)
-	compB.Dependencies = append(compB.Dependencies, compA)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			serverSpec := map[string]interface{}{"framework": "hono", "port": 3000}
+			if tt.observability != nil {
+				serverSpec["observability"] = tt.observability
+			}
+			usecaseSpec := map[string]interface{}{
+				"binds_to": "http.server.api:GET:/users",
+				"goal":     "List users",
+			}
+			if tt.slo != nil {
+				usecaseSpec["slo"] = tt.slo
+			}
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "http.server.api", Kind: "http.server", Spec: serverSpec},
+					{ID: "usecase.list-users", Kind: "usecase", Spec: usecaseSpec},
+				},
+			}
+			builtIR, errs := ir.NewBuilder().Build(spec)
+			if len(errs) > 0 {
+				t.Fatalf("Build() errors: %v", errs)
+			}
 
-	builtIR.Components["middleware.a"] = compA
-	builtIR.Components["middleware.b"] = compB
-	_ = builtIR.Symbols.Define("middleware.a", ir.KindMiddleware, compA)
-	_ = builtIR.Symbols.Define("middleware.b", ir.KindMiddleware, compB)
+			// when
+			warnings := NewIRValidator().Warnings(builtIR)
 
-	v := NewIRValidator()
-	errs := v.Validate(builtIR)
-	if len(errs) == 0 {
-		t.Error("Validate() should detect cycle")
+			// then
+			got := len(warnings) == 1 && warnings[0].Rule == RuleSLOWithoutMetrics
+			if got != tt.want {
+				t.Errorf("slo-without-metrics warning = %v, expected %v (warnings: %v)", got, tt.want, warnings)
+			}
+			if got && warnings[0].Message != "slo cannot be measured: http.server.api does not enable observability.metrics" {
+				t.Errorf("Message = %q", warnings[0].Message)
+			}
+		})
 	}
 }
 
-func TestIRValidator_UsecaseMiddlewareTypeCheck(t *testing.T) {
+func TestIRValidator_Warnings_DeprecatedReferences(t *testing.T) {
+	// given
 	spec := &parser.Spec{
 		Components: []parser.Component{
-			{
-				ID:   "http.server.api",
-				Kind: "http.server",
-				Spec: map[string]interface{}{
-					"framework": "hono",
-					"port":      3000,
-				},
-			},
-			{
-				ID:   "postgres.primary",
-				Kind: "postgres",
-				Spec: map[string]interface{}{
-					"provider": "drizzle",
-					"schema":   "./schema.ts",
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework":  "hono",
+				"port":       3000,
+				"middleware": []interface{}{"middleware.legacy-auth"},
+			}},
+			{ID: "http.server.v1", Kind: "http.server", Deprecated: true, Replacement: "http.server.api", Spec: map[string]interface{}{
+				"framework":  "hono",
+				"port":       3001,
+				"middleware": []interface{}{"middleware.legacy-auth"},
+			}},
+			{ID: "middleware.legacy-auth", Kind: "middleware", Deprecated: true, Spec: map[string]interface{}{
+				"provider": "casbin",
+			}},
+			{ID: "usecase.list-users", Kind: "usecase", Spec: map[string]interface{}{
+				"binds_to":   "http.server.v1:GET:/users",
+				"goal":       "List users",
+				"middleware": []interface{}{"middleware.legacy-auth"},
+			}},
+		},
+	}
+	builtIR, errs := ir.NewBuilder().Build(spec)
+	if len(errs) > 0 {
+		t.Fatalf("Build() errors: %v", errs)
+	}
+
+	// when
+	warnings := NewIRValidator().Warnings(builtIR)
+
+	// then
+	var got []string
+	for _, w := range warnings {
+		if w.Rule == RuleDeprecatedReference {
+			got = append(got, w.Error())
+		}
+	}
+	want := []string{
+		"http.server.api: references deprecated middleware middleware.legacy-auth",
+		"usecase.list-users: references deprecated http.server http.server.v1; use http.server.api instead",
+		"usecase.list-users: references deprecated middleware middleware.legacy-auth",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Warnings() =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestIRValidator_Warnings_MissingOperationID(t *testing.T) {
+	// given - one operation of an OpenAPI document without an operationId,
+	// and a synthesized document, whose operations always have one
+	binding := func(server, path, operationID string) *ir.UsecaseSpec {
+		return &ir.UsecaseSpec{Binding: &ir.Binding{
+			ServerID:  server,
+			Method:    "GET",
+			Path:      path,
+			Operation: &openapi.Operation{OperationID: operationID},
+		}}
+	}
+	i := &ir.IR{Components: map[string]*ir.Component{
+		"http.server.api": {ID: "http.server.api", Kind: ir.KindHTTPServer, HTTPServer: &ir.HTTPServerSpec{
+			OpenAPI:       "./openapi.yaml",
+			ParsedOpenAPI: &openapi.Document{},
+		}},
+		"http.server.admin": {ID: "http.server.admin", Kind: ir.KindHTTPServer, HTTPServer: &ir.HTTPServerSpec{
+			ParsedOpenAPI: &openapi.Document{Synthesized: true},
+		}},
+		"usecase.list-orders": {ID: "usecase.list-orders", Kind: ir.KindUsecase, Usecase: binding("http.server.api", "/orders", "")},
+		"usecase.get-order":   {ID: "usecase.get-order", Kind: ir.KindUsecase, Usecase: binding("http.server.api", "/orders/{id}", "getOrder")},
+		"usecase.list-users":  {ID: "usecase.list-users", Kind: ir.KindUsecase, Usecase: binding("http.server.admin", "/users", "")},
+	}}
+
+	// when
+	warnings := NewIRValidator().Warnings(i)
+
+	// then
+	var got []string
+	for _, w := range warnings {
+		if w.Rule == RuleMissingOperationID {
+			got = append(got, w.Error())
+		}
+	}
+	want := []string{
+		"usecase.list-orders: operation GET /orders in ./openapi.yaml has no operationId, so its generated types are named after the usecase or route and change when they are renamed; add an operationId",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Warnings() =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestIRValidator_Replacement(t *testing.T) {
+	tests := []struct {
+		name        string
+		deprecated  bool
+		replacement string
+		want        string
+	}{
+		{name: "same kind", deprecated: true, replacement: "usecase.list-users-v2"},
+		{name: "without deprecated", replacement: "usecase.list-users-v2", want: "usecase.list-users: replacement requires deprecated: true"},
+		{name: "unknown", deprecated: true, replacement: "usecase.list-accounts", want: `usecase.list-users: replacement "usecase.list-accounts" is not a component of the spec`},
+		{name: "other kind", deprecated: true, replacement: "http.server.api", want: `usecase.list-users: replacement "http.server.api" is a http.server, not a usecase`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+					{ID: "usecase.list-users", Kind: "usecase", Deprecated: tt.deprecated, Replacement: tt.replacement, Spec: map[string]interface{}{
+						"binds_to": "http.server.api:GET:/users",
+						"goal":     "List users",
+					}},
+					{ID: "usecase.list-users-v2", Kind: "usecase", Spec: map[string]interface{}{
+						"binds_to": "http.server.api:GET:/v2/users",
+						"goal":     "List users",
+					}},
 				},
+			}
+			builtIR, errs := ir.NewBuilder().Build(spec)
+			if len(errs) > 0 {
+				t.Fatalf("Build() errors: %v", errs)
+			}
+
+			// when
+			verrs := NewIRValidator().Validate(builtIR)
+
+			// then
+			var got string
+			if len(verrs) > 0 {
+				got = verrs[0].Error()
+			}
+			if len(verrs) > 1 || got != tt.want {
+				t.Errorf("Validate() = %v, expected %q", verrs, tt.want)
+			}
+		})
+	}
+}
+
+func TestIRValidator_Resources(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources map[string]*parser.Resources
+		want      string
+	}{
+		{
+			name: "server and database",
+			resources: map[string]*parser.Resources{
+				"http.server.api":  {CPU: "500m", Memory: "512Mi", Replicas: 3},
+				"postgres.primary": {CPU: "2", Memory: "4Gi"},
 			},
-			{
-				ID:   "usecase.test",
-				Kind: "usecase",
-				Spec: map[string]interface{}{
-					"binds_to":   "http.server.api:POST:/test",
-					"goal":       "Test",
-					"middleware": []interface{}{"postgres.primary"},
-				},
+		},
+		{
+			name:      "usecase",
+			resources: map[string]*parser.Resources{"usecase.list-users": {CPU: "100m"}},
+			want:      "usecase.list-users: resources apply to http.server, http.gateway and postgres components, not usecase",
+		},
+		{
+			name:      "cpu too small",
+			resources: map[string]*parser.Resources{"http.server.api": {CPU: "1m"}},
+			want:      "http.server.api: resources cpu 1m is outside 10m to 64",
+		},
+		{
+			name:      "memory too large",
+			resources: map[string]*parser.Resources{"http.server.api": {Memory: "512Gi"}},
+			want:      "http.server.api: resources memory 524288Mi is outside 64Mi to 256Gi",
+		},
+		{
+			name:      "too many replicas",
+			resources: map[string]*parser.Resources{"http.server.api": {Replicas: 500}},
+			want:      "http.server.api: resources replicas 500 is outside 1 to 100",
+		},
+		{
+			name:      "database replicas",
+			resources: map[string]*parser.Resources{"postgres.primary": {Replicas: 2}},
+			want:      "postgres.primary: postgres runs a single instance; replicas apply to http.server and http.gateway components",
+		},
+		{
+			name: "servers with different replicas",
+			resources: map[string]*parser.Resources{
+				"http.server.api":   {Replicas: 3},
+				"http.server.admin": {Replicas: 2},
 			},
+			want: "http.server.api: replicas 3 differ from the 2 of http.server.admin; servers and gateways run in one process and scale together",
 		},
 	}
 
-	b := ir.NewBuilder()
-	builtIR, _ := b.Build(spec)
-	v := NewIRValidator()
-	errs := v.Validate(builtIR)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			spec := &parser.Spec{
+				Components: []parser.Component{
+					{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000}},
+					{ID: "http.server.admin", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 4000}},
+					{ID: "postgres.primary", Kind: "postgres", Spec: map[string]interface{}{"provider": "drizzle", "schema": "./schema.ts"}},
+					{ID: "usecase.list-users", Kind: "usecase", Spec: map[string]interface{}{
+						"binds_to": "http.server.api:GET:/users",
+						"goal":     "List users",
+					}},
+				},
+			}
+			for n := range spec.Components {
+				spec.Components[n].Resources = tt.resources[spec.Components[n].ID]
+			}
+			builtIR, errs := ir.NewBuilder().Build(spec)
+			if len(errs) > 0 {
+				t.Fatalf("Build() errors: %v", errs)
+			}
 
-	found := false
-	for _, e := range errs {
-		if e.ID == "usecase.test" {
-			found = true
-		}
-	}
-	if !found {
-		t.Error("Validate() should error on usecase middleware pointing to postgres")
+			// when
+			verrs := NewIRValidator().Validate(builtIR)
+
+			// then
+			var got string
+			if len(verrs) > 0 {
+				got = verrs[0].Error()
+			}
+			if len(verrs) > 1 || got != tt.want {
+				t.Errorf("Validate() = %v, expected %q", verrs, tt.want)
+			}
+		})
 	}
 }
 
-func TestIRValidator_AllHTTPMethods(t *testing.T) {
-	methods := []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+func TestIRValidator_UsecaseDatabases(t *testing.T) {
+	tests := []struct {
+		name       string
+		dependsOn  interface{}
+		wantErrors int
+	}{
+		{name: "declared database", dependsOn: []interface{}{"postgres.primary"}, wantErrors: 0},
+		{name: "no database", dependsOn: []interface{}{}, wantErrors: 0},
+		{name: "undeclared on multi-database server", dependsOn: nil, wantErrors: 1},
+		{name: "database not used by server", dependsOn: []interface{}{"postgres.other"}, wantErrors: 1},
+		{name: "not a database", dependsOn: []interface{}{"http.server.api"}, wantErrors: 1},
+	}
 
-	for _, method := range methods {
-		t.Run(method, func(t *testing.T) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			usecaseSpec := map[string]interface{}{
+				"binds_to": "http.server.api:GET:/orders",
+				"goal":     "List orders",
+			}
+			if tt.dependsOn != nil {
+				usecaseSpec["depends_on"] = tt.dependsOn
+			}
 			spec := &parser.Spec{
 				Components: []parser.Component{
-					{
-						ID:   "http.server.api",
-						Kind: "http.server",
-						Spec: map[string]interface{}{
-							"framework": "hono",
-							"port":      3000,
-						},
-					},
-					{
-						ID:   "usecase.test",
-						Kind: "usecase",
-						Spec: map[string]interface{}{
-							"binds_to": "http.server.api:" + method + ":/test",
-							"goal":     "Test",
-						},
-					},
+					{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+						"framework":  "hono",
+						"port":       3000,
+						"depends_on": []interface{}{"postgres.primary", "postgres.replica"},
+					}},
+					{ID: "postgres.primary", Kind: "postgres", Spec: map[string]interface{}{"provider": "drizzle", "schema": "./a.ts"}},
+					{ID: "postgres.replica", Kind: "postgres", Spec: map[string]interface{}{"provider": "drizzle", "schema": "./b.ts"}},
+					{ID: "postgres.other", Kind: "postgres", Spec: map[string]interface{}{"provider": "drizzle", "schema": "./c.ts"}},
+					{ID: "usecase.list-orders", Kind: "usecase", Spec: usecaseSpec},
 				},
 			}
+			builtIR, _ := ir.NewBuilder().Build(spec)
 
-			b := ir.NewBuilder()
-			builtIR, _ := b.Build(spec)
-			v := NewIRValidator()
-			errs := v.Validate(builtIR)
+			// when
+			errs := NewIRValidator().Validate(builtIR)
 
-			for _, e := range errs {
-				if e.ID == "usecase.test" {
-					t.Errorf("Validate() should accept %s method, got error: %v", method, e)
+			// then
+			if len(errs) != tt.wantErrors {
+				t.Errorf("Validate() returned %d errors, expected %d", len(errs), tt.wantErrors)
+				for _, e := range errs {
+					t.Logf("  error: %v", e)
 				}
 			}
 		})
 	}
 }
+
+func TestIRValidator_External(t *testing.T) {
+	// given: a server depending on an external system with an opaque spec
+	spec := &parser.Spec{
+		Components: []parser.Component{
+			{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+				"framework": "hono", "port": 3000, "depends_on": []interface{}{"external.billing"},
+			}},
+			{ID: "external.billing", Kind: "external", Spec: map[string]interface{}{"system": "mainframe"}},
+		},
+	}
+	builtIR, errs := ir.NewBuilder().Build(spec)
+	if len(errs) > 0 {
+		t.Fatalf("Build() errors: %v", errs)
+	}
+	v := NewIRValidator()
+
+	// when
+	verrs := v.Validate(builtIR)
+	warnings := v.Warnings(builtIR)
+
+	// then: the external is valid and connected
+	if len(verrs) > 0 {
+		t.Errorf("Validate() errors: %v", verrs)
+	}
+	if len(warnings) > 0 {
+		t.Errorf("Warnings() = %v", warnings)
+	}
+}
@@ -8,7 +8,10 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/openboundary/openboundary/internal/parser"
 	"github.com/santhosh-tekuri/jsonschema/v6"
@@ -17,13 +20,42 @@ import (
 //go:embed openboundary.schema.json
 var schemaJSON []byte
 
+// SchemaVersion returns the version of the embedded schema, taken from its
+// $id, e.g. "0.0.1".
+func SchemaVersion() string {
+	var doc struct {
+		ID string `json:"$id"`
+	}
+	if err := json.Unmarshal(schemaJSON, &doc); err != nil {
+		return ""
+	}
+	for _, part := range strings.Split(doc.ID, "/") {
+		if strings.HasPrefix(part, "v") && strings.Count(part, ".") == 2 {
+			return strings.TrimPrefix(part, "v")
+		}
+	}
+	return ""
+}
+
 // JSONSchemaValidator validates specifications against the openboundary JSON Schema.
 type JSONSchemaValidator struct {
 	schema *jsonschema.Schema
 }
 
+// compiledSchema compiles the embedded schema on first use. The compiled
+// schema is immutable and shared by all validators.
+var compiledSchema = sync.OnceValues(compileSchema)
+
 // NewJSONSchemaValidator creates a new JSON Schema validator.
 func NewJSONSchemaValidator() (*JSONSchemaValidator, error) {
+	schema, err := compiledSchema()
+	if err != nil {
+		return nil, err
+	}
+	return &JSONSchemaValidator{schema: schema}, nil
+}
+
+func compileSchema() (*jsonschema.Schema, error) {
 	var schemaDoc any
 	if err := json.Unmarshal(schemaJSON, &schemaDoc); err != nil {
 		return nil, fmt.Errorf("failed to parse schema JSON: %w", err)
@@ -38,23 +70,20 @@ func NewJSONSchemaValidator() (*JSONSchemaValidator, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile schema: %w", err)
 	}
-
-	return &JSONSchemaValidator{schema: schema}, nil
+	return schema, nil
 }
 
 // Validate validates the parsed spec against the JSON Schema.
 func (v *JSONSchemaValidator) Validate(spec *parser.Spec) []ValidationError {
-	// Convert spec to map for JSON Schema validation
-	specMap := map[string]any{
-		"version":     spec.Version,
-		"name":        spec.Name,
-		"description": spec.Description,
-		"components":  convertComponents(spec.Components),
+	// A spec for another compiler may not match this schema at all
+	if errs := validateSpecVersion(spec); errs != nil {
+		return errs
 	}
 
-	// Round-trip through JSON to get proper interface{} types
-	// that the jsonschema library expects
-	jsonBytes, err := json.Marshal(specMap)
+	// The jsonschema library expects the types encoding/json decodes to.
+	// Component specs and vars, the bulk of a spec, are decoded YAML that
+	// already holds them, so only the typed parts are converted.
+	value, err := jsonValue(typedJSON(reflect.ValueOf(spec).Elem()))
 	if err != nil {
 		return []ValidationError{{
 			Message:  fmt.Sprintf("failed to marshal spec: %v", err),
@@ -62,15 +91,7 @@ func (v *JSONSchemaValidator) Validate(spec *parser.Spec) []ValidationError {
 		}}
 	}
 
-	var specData interface{}
-	if err := json.Unmarshal(jsonBytes, &specData); err != nil {
-		return []ValidationError{{
-			Message:  fmt.Sprintf("failed to unmarshal spec: %v", err),
-			Position: spec.Pos(),
-		}}
-	}
-
-	err = v.schema.Validate(specData)
+	err = v.schema.Validate(value)
 	if err == nil {
 		return nil
 	}
@@ -79,26 +100,115 @@ func (v *JSONSchemaValidator) Validate(spec *parser.Spec) []ValidationError {
 	return convertSchemaErrors(err, spec.Pos().File)
 }
 
-// convertComponents converts parsed components to map format for validation.
-func convertComponents(components []parser.Component) []map[string]interface{} {
-	result := make([]map[string]interface{}, len(components))
-	for i, c := range components {
-		result[i] = map[string]interface{}{
-			"id":   c.ID,
-			"kind": c.Kind,
-			"spec": c.Spec,
+// typedJSON converts a value of the parser's typed structs into maps,
+// slices and scalars the way encoding/json would marshal it, following the
+// json tags of the struct fields, so a new field is validated without
+// further code. Maps of decoded YAML are returned as they are, and a nil
+// slice is an empty array.
+func typedJSON(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return typedJSON(v.Elem())
+	case reflect.Struct:
+		object := make(map[string]any)
+		t := v.Type()
+		for n := 0; n < t.NumField(); n++ {
+			field := t.Field(n)
+			tag, ok := field.Tag.Lookup("json")
+			if !field.IsExported() || !ok || tag == "-" {
+				continue
+			}
+			name, options, _ := strings.Cut(tag, ",")
+			if slices.Contains(strings.Split(options, ","), "omitempty") && v.Field(n).IsZero() {
+				continue
+			}
+			object[name] = typedJSON(v.Field(n))
+		}
+		return object
+	case reflect.Slice:
+		items := make([]any, v.Len())
+		for n := range items {
+			items[n] = typedJSON(v.Index(n))
+		}
+		return items
+	case reflect.Map:
+		if m, ok := v.Interface().(map[string]any); ok {
+			return m
+		}
+		object := make(map[string]any, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			object[fmt.Sprint(iter.Key().Interface())] = typedJSON(iter.Value())
+		}
+		return object
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	return v.Interface()
+}
+
+// jsonValue returns v in the types encoding/json decodes to. Decoded YAML
+// usually needs no conversion and is returned as is; anything else takes a
+// JSON round trip.
+func jsonValue(v any) (any, error) {
+	if isJSONValue(v) {
+		return v, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// isJSONValue reports whether the jsonschema library can validate v
+// without converting it.
+func isJSONValue(v any) bool {
+	switch v := v.(type) {
+	case nil, bool, string, int, int64, uint64, float64:
+		return true
+	case map[string]any:
+		for _, item := range v {
+			if !isJSONValue(item) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		for _, item := range v {
+			if !isJSONValue(item) {
+				return false
+			}
 		}
+		return true
 	}
-	return result
+	return false
 }
 
 // ValidationError represents a validation error with location info.
 // Used by both JSON schema validation and IR semantic validation.
 type ValidationError struct {
-	Message  string
-	ID       string          // Component ID (for IR validation)
-	Path     string          // JSON/YAML path (for schema validation)
-	Position parser.Position // Source location
+	Message   string
+	ID        string          // Component ID (for IR validation)
+	Path      string          // JSON/YAML path (for schema validation)
+	Position  parser.Position // Source location
+	Rule      string          // Rule that produced a warning (e.g., RuleUnusedComponent)
+	MessageID MessageID       // Stable ID of the message, empty for relayed text
+	Args      []any           // Arguments of the message, for Localize
 }
 
 func (e ValidationError) Error() string {
@@ -4,7 +4,13 @@
 package validator
 
 import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/openboundary/openboundary/internal/parser"
 )
@@ -22,6 +28,43 @@ func TestNewJSONSchemaValidator(t *testing.T) {
 	}
 }
 
+func TestNewJSONSchemaValidator_SharesCompiledSchema(t *testing.T) {
+	// given
+	first, err := NewJSONSchemaValidator()
+	if err != nil {
+		t.Fatalf("NewJSONSchemaValidator() error = %v", err)
+	}
+
+	// when
+	second, err := NewJSONSchemaValidator()
+	if err != nil {
+		t.Fatalf("NewJSONSchemaValidator() error = %v", err)
+	}
+
+	// then
+	if first.schema != second.schema {
+		t.Error("NewJSONSchemaValidator() compiled the schema again")
+	}
+}
+
+func TestSchemaVersion(t *testing.T) {
+	if got := SchemaVersion(); got != "0.1.0" {
+		t.Errorf("SchemaVersion() = %q, want %q", got, "0.1.0")
+	}
+}
+
+// The schema is embedded into the binary from this package; the copy under
+// schemas/ is the one published for editors and must not drift from it.
+func TestEmbeddedSchema_MatchesPublishedSchema(t *testing.T) {
+	published, err := os.ReadFile("../../schemas/openboundary.schema.json")
+	if err != nil {
+		t.Fatalf("read published schema: %v", err)
+	}
+	if !bytes.Equal(published, schemaJSON) {
+		t.Error("schemas/openboundary.schema.json differs from the embedded internal/validator/openboundary.schema.json")
+	}
+}
+
 func TestJSONSchemaValidator_Validate(t *testing.T) {
 	v, err := NewJSONSchemaValidator()
 	if err != nil {
@@ -133,6 +176,430 @@ func TestJSONSchemaValidator_Validate(t *testing.T) {
 			},
 			wantErrors: true,
 		},
+		{
+			name: "valid component metadata",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000},
+				Labels: []string{"team:platform"}, Description: "Public API", Owner: "team-platform",
+				Links: []parser.Link{{Title: "Runbook", URL: "https://runbooks.example.com/api"}},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "link without URL scheme",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000},
+				Links: []parser.Link{{URL: "runbooks/api"}},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "label with whitespace",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000},
+				Labels: []string{"team billing"},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "catch-all binding",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.proxy", Kind: "usecase", Spec: map[string]interface{}{"binds_to": "http.server.api:ALL:/proxy/*", "goal": "Proxy requests"},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "wildcard inside a binding path",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.proxy", Kind: "usecase", Spec: map[string]interface{}{"binds_to": "http.server.api:GET:/files/*/raw", "goal": "Proxy requests"},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "middleware chain",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "middleware.auth", Kind: "middleware", Spec: map[string]interface{}{"chain": []interface{}{
+					map[string]interface{}{"provider": "better-auth", "config": "./auth.ts"},
+					map[string]interface{}{"provider": "casbin", "model": "./model.conf", "policy": "./policy.csv"},
+				}},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "middleware chain member missing provider fields",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "middleware.auth", Kind: "middleware", Spec: map[string]interface{}{"chain": []interface{}{
+					map[string]interface{}{"provider": "better-auth", "config": "./auth.ts"},
+					map[string]interface{}{"provider": "casbin", "model": "./model.conf"},
+				}},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "middleware chain with a provider",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "middleware.auth", Kind: "middleware", Spec: map[string]interface{}{"provider": "better-auth", "config": "./auth.ts", "chain": []interface{}{
+					map[string]interface{}{"provider": "better-auth", "config": "./auth.ts"},
+					map[string]interface{}{"provider": "casbin", "model": "./model.conf", "policy": "./policy.csv"},
+				}},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "middleware chain of one",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "middleware.auth", Kind: "middleware", Spec: map[string]interface{}{"chain": []interface{}{
+					map[string]interface{}{"provider": "better-auth", "config": "./auth.ts"},
+				}},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "middleware without provider or chain",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "middleware.auth", Kind: "middleware", Spec: map[string]interface{}{"config": "./auth.ts"},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "usecase input mapping",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.update-user", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:PATCH:/users/{id}", "goal": "Update a user",
+					"input_mapping": map[string]interface{}{
+						"userId": "path.id",
+						"email":  map[string]interface{}{"from": "body.email_address", "transform": "lowercase"},
+					},
+				},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "usecase input mapping from a header",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.update-user", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:PATCH:/users/{id}", "goal": "Update a user",
+					"input_mapping": map[string]interface{}{"token": "header.authorization"},
+				},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "webhook",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "webhook.orders", Kind: "webhook", Spec: map[string]interface{}{
+					"url_env": "ORDERS_WEBHOOK_URL", "secret_env": "ORDERS_WEBHOOK_SECRET",
+					"events": []interface{}{map[string]interface{}{"name": "order.created", "description": "An order was placed"}},
+					"retry":  map[string]interface{}{"max_attempts": 3, "backoff_ms": 500},
+				},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "webhook event with uppercase name",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "webhook.orders", Kind: "webhook", Spec: map[string]interface{}{
+					"url_env": "ORDERS_WEBHOOK_URL", "secret_env": "ORDERS_WEBHOOK_SECRET",
+					"events": []interface{}{map[string]interface{}{"name": "OrderCreated"}},
+				},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "webhook without secret",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "webhook.orders", Kind: "webhook", Spec: map[string]interface{}{
+					"url_env": "ORDERS_WEBHOOK_URL",
+					"events":  []interface{}{map[string]interface{}{"name": "order.created"}},
+				},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "usecase emitting to a webhook",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.create-order", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:POST:/orders", "goal": "Place an order",
+					"emits": []interface{}{"webhook.orders"},
+				},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "http client",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "http.client.payments", Kind: "http.client", Spec: map[string]interface{}{
+					"base_url_env": "PAYMENTS_URL",
+					"resilience": map[string]interface{}{
+						"retries": 3, "backoff_ms": 100,
+						"circuit_breaker": map[string]interface{}{"failure_threshold": 5, "reset_ms": 10000},
+					},
+				},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "http client with too many retries",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "http.client.payments", Kind: "http.client", Spec: map[string]interface{}{
+					"base_url_env": "PAYMENTS_URL",
+					"resilience":   map[string]interface{}{"retries": 11},
+				},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "usecase calling a client",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.create-order", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:POST:/orders", "goal": "Place an order",
+					"calls": []interface{}{"http.client.payments"},
+				},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "cron",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "cron.cleanup", Kind: "cron", Spec: map[string]interface{}{
+					"schedule": "0 3 * * *", "depends_on": []interface{}{"postgres.primary"},
+				},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "cron without a schedule",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "cron.cleanup", Kind: "cron", Spec: map[string]interface{}{"depends_on": []interface{}{"postgres.primary"}},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "http gateway",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "http.gateway.edge", Kind: "http.gateway", Spec: map[string]interface{}{
+					"port": 8080, "auth": "middleware.authn",
+					"routes": []interface{}{
+						map[string]interface{}{"prefix": "/orders", "server": "http.server.orders", "strip_prefix": true},
+						map[string]interface{}{"prefix": "/", "server": "http.server.web", "public": true},
+					},
+				},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "http gateway route without server",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "http.gateway.edge", Kind: "http.gateway", Spec: map[string]interface{}{
+					"port":   8080,
+					"routes": []interface{}{map[string]interface{}{"prefix": "/orders"}},
+				},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "server and usecase limits",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{
+				{ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+					"framework": "hono", "port": 3000,
+					"limits": map[string]interface{}{"timeout_ms": 30000, "max_body_kb": 1024},
+				}},
+				{ID: "usecase.upload", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:POST:/uploads", "goal": "Upload a file",
+					"limits": map[string]interface{}{"max_body_kb": 512},
+				}},
+			}},
+			wantErrors: false,
+		},
+		{
+			name: "usecase cache",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.list-products", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:GET:/products", "goal": "List products",
+					"cache": map[string]interface{}{"ttl": 300, "vary": []interface{}{"Accept-Language"}, "visibility": "public"},
+				},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "usecase cache without ttl",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.list-products", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:GET:/products", "goal": "List products",
+					"cache": map[string]interface{}{"visibility": "shared"},
+				},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "usecase concurrency",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.update-product", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:PUT:/products/{id}", "goal": "Update a product", "concurrency": "etag",
+				},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "unknown usecase concurrency",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.update-product", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:PUT:/products/{id}", "goal": "Update a product", "concurrency": "lock",
+				},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "usecase bulk",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.import-products", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:POST:/products", "goal": "Import products", "bulk": true,
+				},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "non-boolean usecase bulk",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.import-products", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:POST:/products", "goal": "Import products", "bulk": "yes",
+				},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "usecase async",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.export-orders", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:POST:/orders/exports", "goal": "Export orders", "async": true,
+				},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "non-boolean usecase async",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "usecase.export-orders", Kind: "usecase", Spec: map[string]interface{}{
+					"binds_to": "http.server.api:POST:/orders/exports", "goal": "Export orders", "async": "later",
+				},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "component resources",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000},
+				Resources: &parser.Resources{CPU: "500m", Memory: "512Mi", Replicas: 2},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "component memory without unit",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{"framework": "hono", "port": 3000},
+				Resources: &parser.Resources{Memory: "512"},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "server tls",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+					"framework": "hono", "port": 3000,
+					"tls": map[string]interface{}{"cert_env": "API_TLS_CERT", "key_env": "API_TLS_KEY", "self_signed": true},
+				},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "server tls without key",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+					"framework": "hono", "port": 3000,
+					"tls": map[string]interface{}{"cert_env": "API_TLS_CERT", "self_signed": true},
+				},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "zero timeout",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+					"framework": "hono", "port": 3000,
+					"limits": map[string]interface{}{"timeout_ms": 0},
+				},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "external with opaque fields",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "external.billing", Kind: "external", Spec: map[string]interface{}{
+					"depends_on": []interface{}{"external.billing-db"},
+					"system":     "mainframe",
+					"contacts":   map[string]interface{}{"team": "billing"},
+				},
+			}}},
+			wantErrors: false,
+		},
+		{
+			name: "external with invalid depends_on",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "external.billing", Kind: "external", Spec: map[string]interface{}{
+					"depends_on": []interface{}{"Billing DB"},
+				},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name: "server fields still checked per kind",
+			spec: &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{{
+				ID: "http.server.api", Kind: "http.server", Spec: map[string]interface{}{
+					"framework": "hono", "port": 3000, "system": "mainframe",
+				},
+			}}},
+			wantErrors: true,
+		},
+		{
+			name:       "valid env prefix",
+			spec:       &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{}, Env: &parser.Env{Prefix: "TEST_API"}},
+			wantErrors: false,
+		},
+		{
+			name:       "lowercase env prefix",
+			spec:       &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{}, Env: &parser.Env{Prefix: "test_"}},
+			wantErrors: true,
+		},
+		{
+			name:       "valid http fixtures mode",
+			spec:       &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{}, Testing: &parser.Testing{HTTPFixtures: "replay"}},
+			wantErrors: false,
+		},
+		{
+			name:       "unknown http fixtures mode",
+			spec:       &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{}, Testing: &parser.Testing{HTTPFixtures: "live"}},
+			wantErrors: true,
+		},
+		{
+			name:       "supported node version",
+			spec:       &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{}, Node: "22"},
+			wantErrors: false,
+		},
+		{
+			name:       "unsupported node version",
+			spec:       &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{}, Node: "18"},
+			wantErrors: true,
+		},
+		{
+			name:       "valid vars",
+			spec:       &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{}, Vars: map[string]any{"base_port": 3000, "region": "eu"}},
+			wantErrors: false,
+		},
+		{
+			name:       "uppercase var name",
+			spec:       &parser.Spec{Version: "0.0.1", Name: "test-api", Components: []parser.Component{}, Vars: map[string]any{"BasePort": 3000}},
+			wantErrors: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -183,41 +650,120 @@ func TestValidationError_Error(t *testing.T) {
 	}
 }
 
-func TestConvertComponents(t *testing.T) {
-	components := []parser.Component{
-		{
-			ID:   "http.server.api",
-			Kind: "http.server",
-			Spec: map[string]interface{}{"port": 3000},
-		},
-		{
-			ID:   "postgres.primary",
-			Kind: "postgres",
-			Spec: map[string]interface{}{"provider": "drizzle"},
-		},
+func TestTypedJSON(t *testing.T) {
+	// given
+	spec := &parser.Spec{
+		Version: "0.0.1",
+		Name:    "test-api",
+		Components: []parser.Component{{
+			ID:        "http.server.api",
+			Kind:      "http.server",
+			Spec:      map[string]any{"port": 3000},
+			Links:     []parser.Link{{URL: "https://example.com/runbook"}},
+			Resources: &parser.Resources{Replicas: 2},
+		}},
+		Env: &parser.Env{Namespaced: true},
 	}
 
-	result := convertComponents(components)
+	// when
+	got := typedJSON(reflect.ValueOf(spec).Elem())
 
-	if len(result) != 2 {
-		t.Fatalf("convertComponents() returned %d items, expected 2", len(result))
+	// then
+	want := map[string]any{
+		"version": "0.0.1",
+		"name":    "test-api",
+		"components": []any{map[string]any{
+			"id":        "http.server.api",
+			"kind":      "http.server",
+			"spec":      map[string]any{"port": 3000},
+			"links":     []any{map[string]any{"url": "https://example.com/runbook"}},
+			"resources": map[string]any{"replicas": int64(2)},
+		}},
+		"env": map[string]any{"namespaced": true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("typedJSON() = %#v, expected %#v", got, want)
 	}
+	if !isJSONValue(got) {
+		t.Errorf("typedJSON() = %#v, expected a value the jsonschema library validates as is", got)
+	}
+}
 
-	if result[0]["id"] != "http.server.api" {
-		t.Errorf("result[0][id] = %v, expected %q", result[0]["id"], "http.server.api")
+// TestTypedJSON_EveryField fails when a json field of parser.Spec or
+// parser.Component does not reach schema validation. Set new fields below.
+func TestTypedJSON_EveryField(t *testing.T) {
+	// given
+	component := parser.Component{
+		ID:          "http.server.api",
+		Kind:        "http.server",
+		Spec:        map[string]any{"port": 3000},
+		Labels:      []string{"team:api"},
+		Description: "API",
+		Owner:       "team-api",
+		Links:       []parser.Link{{URL: "https://example.com"}},
+		Deprecated:  true,
+		Replacement: "http.server.v2",
+		Resources:   &parser.Resources{CPU: "1"},
 	}
-	if result[0]["kind"] != "http.server" {
-		t.Errorf("result[0][kind] = %v, expected %q", result[0]["kind"], "http.server")
+	spec := &parser.Spec{
+		Version:     "0.0.1",
+		Name:        "test-api",
+		Description: "Test API",
+		Components:  []parser.Component{component},
+		Docs:        &parser.Docs{ADR: true},
+		Env:         &parser.Env{Prefix: "TEST"},
+		Testing:     &parser.Testing{HTTPFixtures: "replay"},
+		Node:        "22",
+		Vars:        map[string]any{"region": "eu"},
 	}
-	if result[1]["id"] != "postgres.primary" {
-		t.Errorf("result[1][id] = %v, expected %q", result[1]["id"], "postgres.primary")
+
+	// when
+	specJSON := typedJSON(reflect.ValueOf(spec).Elem()).(map[string]any)
+	componentJSON := specJSON["components"].([]any)[0].(map[string]any)
+
+	// then
+	for _, tc := range []struct {
+		typ    reflect.Type
+		object map[string]any
+	}{
+		{reflect.TypeOf(parser.Spec{}), specJSON},
+		{reflect.TypeOf(parser.Component{}), componentJSON},
+	} {
+		for n := 0; n < tc.typ.NumField(); n++ {
+			tag, ok := tc.typ.Field(n).Tag.Lookup("json")
+			if !ok {
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+			if _, ok := tc.object[name]; !ok {
+				t.Errorf("%s field %s is missing from the validated value %v", tc.typ.Name(), name, tc.object)
+			}
+		}
 	}
 }
 
-func TestConvertComponents_Empty(t *testing.T) {
-	result := convertComponents([]parser.Component{})
-	if len(result) != 0 {
-		t.Errorf("convertComponents([]) returned %d items, expected 0", len(result))
+func TestJSONValue(t *testing.T) {
+	// given
+	spec := map[string]any{"port": 3000, "tags": []any{"a", true}}
+	typed := map[string]any{"created": time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), "ports": []int{1}}
+
+	// when
+	same, err := jsonValue(spec)
+	if err != nil {
+		t.Fatalf("jsonValue() error = %v", err)
+	}
+	converted, err := jsonValue(typed)
+	if err != nil {
+		t.Fatalf("jsonValue() error = %v", err)
+	}
+
+	// then
+	if same.(map[string]any)["port"] != 3000 {
+		t.Errorf("jsonValue() = %v, expected the value unchanged", same)
+	}
+	expected := map[string]any{"created": "2026-01-02T00:00:00Z", "ports": []any{float64(1)}}
+	if !reflect.DeepEqual(converted, expected) {
+		t.Errorf("jsonValue() = %#v, expected %#v", converted, expected)
 	}
 }
 
@@ -278,3 +824,50 @@ func TestJSONSchemaValidator_Validate_ExtractsPath(t *testing.T) {
 		t.Error("Validate() errors should have messages")
 	}
 }
+
+// benchmarkSpec parses a spec with a server, a database and n usecases.
+func benchmarkSpec(b *testing.B, n int) *parser.Spec {
+	b.Helper()
+	var sb strings.Builder
+	sb.WriteString("version: \"0.1.0\"\nname: bench\ncomponents:\n")
+	sb.WriteString("  - id: http.server.api\n    kind: http.server\n    labels: [team:core]\n    spec:\n      framework: hono\n      port: 3000\n      depends_on: [postgres.primary]\n")
+	sb.WriteString("  - id: postgres.primary\n    kind: postgres\n    spec:\n      provider: drizzle\n      schema: ./schema.ts\n")
+	for k := 0; k < n; k++ {
+		fmt.Fprintf(&sb, "  - id: usecase.u%d\n    kind: usecase\n    description: Usecase %d\n    links:\n      - title: Runbook\n        url: https://example.com/u%d\n", k, k, k)
+		fmt.Fprintf(&sb, "    spec:\n      binds_to: http.server.api:GET:/u%d/{id}\n      goal: Usecase %d\n      acceptance_criteria:\n        - It answers\n        - It is fast\n", k, k)
+	}
+	spec, err := parser.NewParser("bench.yaml").ParseBytes([]byte(sb.String()))
+	if err != nil {
+		b.Fatalf("ParseBytes() error = %v", err)
+	}
+	return spec
+}
+
+func BenchmarkNewJSONSchemaValidator(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		if _, err := NewJSONSchemaValidator(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkJSONSchemaValidator_Validate(b *testing.B) {
+	for _, size := range []int{10, 500} {
+		b.Run(fmt.Sprintf("usecases=%d", size), func(b *testing.B) {
+			spec := benchmarkSpec(b, size)
+			v, err := NewJSONSchemaValidator()
+			if err != nil {
+				b.Fatal(err)
+			}
+			if errs := v.Validate(spec); len(errs) > 0 {
+				b.Fatalf("Validate() errors = %v", errs)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				v.Validate(spec)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// RuleDeadLink identifies warnings for component links that do not resolve.
+const RuleDeadLink = "dead-link"
+
+// maxLinkChecks caps the number of links checked at the same time.
+const maxLinkChecks = 8
+
+// CheckLinks sends a HEAD request to every URL in the components' links
+// and reports the ones that fail or answer with an error status. Servers
+// that do not support HEAD are asked again with GET. Each URL is checked
+// once; the client's timeout bounds every request.
+func CheckLinks(ctx context.Context, i *ir.IR, client *http.Client) []ValidationError {
+	ids := make([]string, 0, len(i.Components))
+	for id := range i.Components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var urls []string
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		for _, link := range i.Components[id].Links {
+			if !seen[link.URL] {
+				seen[link.URL] = true
+				urls = append(urls, link.URL)
+			}
+		}
+	}
+
+	problems := make([]string, len(urls))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxLinkChecks)
+	for n, u := range urls {
+		wg.Add(1)
+		go func(n int, u string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			problems[n] = checkLink(ctx, client, u)
+		}(n, u)
+	}
+	wg.Wait()
+
+	dead := make(map[string]string)
+	for n, u := range urls {
+		if problems[n] != "" {
+			dead[u] = problems[n]
+		}
+	}
+
+	var warnings []ValidationError
+	for _, id := range ids {
+		comp := i.Components[id]
+		for _, link := range comp.Links {
+			problem, ok := dead[link.URL]
+			if !ok {
+				continue
+			}
+			warning := newError(id, MsgDeadLink, link.URL, problem)
+			warning.Position = comp.Position
+			warning.Rule = RuleDeadLink
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
+
+// checkLink requests u and describes why it does not resolve, or returns ""
+// when it does.
+func checkLink(ctx context.Context, client *http.Client, u string) string {
+	status, err := requestLink(ctx, client, http.MethodHead, u)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = requestLink(ctx, client, http.MethodGet, u)
+	}
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &urlErr) && urlErr.Timeout():
+		return "request timed out"
+	case errors.As(err, &urlErr):
+		return urlErr.Err.Error()
+	case err != nil:
+		return err.Error()
+	case status >= 400:
+		return fmt.Sprintf("HTTP %d %s", status, http.StatusText(status))
+	}
+	return ""
+}
+
+func requestLink(ctx context.Context, client *http.Client, method, u string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "openboundary-link-check")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
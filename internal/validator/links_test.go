@@ -0,0 +1,99 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+func TestCheckLinks(t *testing.T) {
+	// given
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/runbook":
+		case "/get-only":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	i := &ir.IR{Components: map[string]*ir.Component{}}
+	i.Components["http.server.api"] = &ir.Component{
+		ID:       "http.server.api",
+		Kind:     ir.KindHTTPServer,
+		Position: parser.Position{File: "spec.yaml", Line: 4},
+		Links: []parser.Link{
+			{Title: "Runbook", URL: srv.URL + "/runbook"},
+			{URL: srv.URL + "/get-only"},
+			{URL: srv.URL + "/moved"},
+		},
+	}
+	i.Components["usecase.create-user"] = &ir.Component{
+		ID:    "usecase.create-user",
+		Kind:  ir.KindUsecase,
+		Links: []parser.Link{{URL: srv.URL + "/moved"}, {URL: srv.URL + "/slow"}},
+	}
+
+	// when
+	warnings := CheckLinks(context.Background(), i, &http.Client{Timeout: 50 * time.Millisecond})
+
+	// then
+	expected := []string{
+		"http.server.api: link " + srv.URL + "/moved does not resolve: HTTP 404 Not Found",
+		"usecase.create-user: link " + srv.URL + "/moved does not resolve: HTTP 404 Not Found",
+		"usecase.create-user: link " + srv.URL + "/slow does not resolve: request timed out",
+	}
+	if len(warnings) != len(expected) {
+		t.Fatalf("CheckLinks() = %v, expected %d warnings", warnings, len(expected))
+	}
+	for n, w := range warnings {
+		if w.Error() != expected[n] {
+			t.Errorf("warning %d = %q, expected %q", n, w.Error(), expected[n])
+		}
+		if w.Rule != RuleDeadLink || w.MessageID != MsgDeadLink {
+			t.Errorf("warning %d rule = %q, message = %q, expected %q", n, w.Rule, w.MessageID, RuleDeadLink)
+		}
+	}
+	if warnings[0].Position.Line != 4 {
+		t.Errorf("Position.Line = %d, expected 4", warnings[0].Position.Line)
+	}
+	// The shared URL is checked once; the GET-only URL twice.
+	if got := requests.Load(); got != 5 {
+		t.Errorf("requests = %d, expected 5", got)
+	}
+}
+
+func TestCheckLinks_Unreachable(t *testing.T) {
+	// given
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL + "/runbook"
+	srv.Close()
+	i := &ir.IR{Components: map[string]*ir.Component{}}
+	i.Components["postgres.primary"] = &ir.Component{ID: "postgres.primary", Links: []parser.Link{{URL: url}}}
+
+	// when
+	warnings := CheckLinks(context.Background(), i, &http.Client{Timeout: time.Second})
+
+	// then
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Message, "connection refused") {
+		t.Errorf("CheckLinks() = %v, expected a connection refused warning", warnings)
+	}
+}
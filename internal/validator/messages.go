@@ -0,0 +1,407 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MessageID identifies a diagnostic independently of its wording, so tools
+// can match on it whatever language the message is rendered in. IDs are
+// stable across releases.
+type MessageID string
+
+// Message IDs of the diagnostics the validator reports. Diagnostics that
+// relay another tool's text, such as OpenAPI or casbin model issues, have none.
+const (
+	MsgDependencyCycle                   MessageID = "dependency-cycle"
+	MsgUnusedComponent                   MessageID = "unused-component"
+	MsgTestsDisabled                     MessageID = "tests-disabled"
+	MsgMissingSpec                       MessageID = "missing-spec"
+	MsgMissingField                      MessageID = "missing-field"
+	MsgPortRange                         MessageID = "port-range"
+	MsgReferenceKind                     MessageID = "reference-kind"
+	MsgBindingTargetKind                 MessageID = "binding-target-kind"
+	MsgBasePathFormat                    MessageID = "base-path-format"
+	MsgBindingRepeatsBasePath            MessageID = "binding-repeats-base-path"
+	MsgBindingShadowedByAPIDocs          MessageID = "binding-shadowed-by-api-docs"
+	MsgBindingShadowedByMetrics          MessageID = "binding-shadowed-by-metrics"
+	MsgBindingOverlap                    MessageID = "binding-overlap"
+	MsgProviderRequiresField             MessageID = "provider-requires-field"
+	MsgProviderOnlyField                 MessageID = "provider-only-field"
+	MsgProviderOnlyRBAC                  MessageID = "provider-only-rbac"
+	MsgSessionStoreRequired              MessageID = "session-store-required"
+	MsgSessionStoreKind                  MessageID = "session-store-kind"
+	MsgSessionStoreUnused                MessageID = "session-store-unused"
+	MsgSessionStorageUnsupported         MessageID = "session-storage-unsupported"
+	MsgOAuthProviderUnsupported          MessageID = "oauth-provider-unsupported"
+	MsgOAuthMissingField                 MessageID = "oauth-missing-field"
+	MsgOAuthInlineCredential             MessageID = "oauth-inline-credential"
+	MsgOAuthSameVariable                 MessageID = "oauth-same-variable"
+	MsgRoleUndeclaredPermission          MessageID = "role-undeclared-permission"
+	MsgRoleUndeclaredParent              MessageID = "role-undeclared-parent"
+	MsgRoleInheritanceCycle              MessageID = "role-inheritance-cycle"
+	MsgRolePolicyFields                  MessageID = "role-policy-fields"
+	MsgRoleDefinitionMissing             MessageID = "role-definition-missing"
+	MsgPolicyAdapterNoDatabase           MessageID = "policy-adapter-no-database"
+	MsgPolicyAdapterDatabases            MessageID = "policy-adapter-databases"
+	MsgAuthorizationNeedsCasbin          MessageID = "authorization-needs-casbin"
+	MsgAuthorizationNeedsAuth            MessageID = "authorization-needs-auth"
+	MsgAuthorizationUndeclaredRole       MessageID = "authorization-undeclared-role"
+	MsgAuthorizationUndeclaredPermission MessageID = "authorization-undeclared-permission"
+	MsgInputMappingCatchAll              MessageID = "input-mapping-catch-all"
+	MsgInputMappingSource                MessageID = "input-mapping-source"
+	MsgInputMappingTransform             MessageID = "input-mapping-transform"
+	MsgInputMappingNoBody                MessageID = "input-mapping-no-body"
+	MsgInputMappingUnknownPathParam      MessageID = "input-mapping-unknown-path-param"
+	MsgInputMappingUnknownBodyField      MessageID = "input-mapping-unknown-body-field"
+	MsgInputMappingUnknownQueryParam     MessageID = "input-mapping-unknown-query-param"
+	MsgWebhookInlineValue                MessageID = "webhook-inline-value"
+	MsgWebhookSameVariable               MessageID = "webhook-same-variable"
+	MsgWebhookDuplicateEvent             MessageID = "webhook-duplicate-event"
+	MsgHTTPClientInlineValue             MessageID = "http-client-inline-value"
+	MsgCronSchedule                      MessageID = "cron-schedule"
+	MsgTargetMiddleware                  MessageID = "target-middleware"
+	MsgTargetAuthorization               MessageID = "target-authorization"
+	MsgTargetServerCache                 MessageID = "target-server-cache"
+	MsgLimitExceedsServer                MessageID = "limit-exceeds-server"
+	MsgCacheMethod                       MessageID = "cache-method"
+	MsgCachePublicAuthorized             MessageID = "cache-public-authorized"
+	MsgCacheServerPrivate                MessageID = "cache-server-private"
+	MsgCacheTablesWithoutServer          MessageID = "cache-tables-without-server"
+	MsgConcurrencyMethod                 MessageID = "concurrency-method"
+	MsgConcurrencyNoRead                 MessageID = "concurrency-no-read"
+	MsgBulkMethod                        MessageID = "bulk-method"
+	MsgBulkInputMapping                  MessageID = "bulk-input-mapping"
+	MsgBulkNotArray                      MessageID = "bulk-not-array"
+	MsgAsyncMethod                       MessageID = "async-method"
+	MsgAsyncNoDatabase                   MessageID = "async-no-database"
+	MsgAsyncStatusRoute                  MessageID = "async-status-route"
+	MsgTLSInlineValue                    MessageID = "tls-inline-value"
+	MsgTLSSameVariable                   MessageID = "tls-same-variable"
+	MsgResourcesKind                     MessageID = "resources-kind"
+	MsgResourcesCPURange                 MessageID = "resources-cpu-range"
+	MsgResourcesMemoryRange              MessageID = "resources-memory-range"
+	MsgResourcesReplicasRange            MessageID = "resources-replicas-range"
+	MsgResourcesPostgresReplicas         MessageID = "resources-postgres-replicas"
+	MsgResourcesReplicasDiffer           MessageID = "resources-replicas-differ"
+	MsgSpecVersionTooNew                 MessageID = "spec-version-too-new"
+	MsgSpecVersionTooOld                 MessageID = "spec-version-too-old"
+	MsgSpecVersionShim                   MessageID = "spec-version-shim"
+	MsgPathParamDuplicate                MessageID = "path-param-duplicate"
+	MsgPathParamNotInPath                MessageID = "path-param-not-in-path"
+	MsgPathParamOptional                 MessageID = "path-param-optional"
+	MsgPathParamType                     MessageID = "path-param-type"
+	MsgGatewayPortInUse                  MessageID = "gateway-port-in-use"
+	MsgGatewayPrefixFormat               MessageID = "gateway-prefix-format"
+	MsgGatewayDuplicatePrefix            MessageID = "gateway-duplicate-prefix"
+	MsgGatewayAuthProvider               MessageID = "gateway-auth-provider"
+	MsgUsecaseDatabaseAmbiguous          MessageID = "usecase-database-ambiguous"
+	MsgUsecaseDatabaseNotOnServer        MessageID = "usecase-database-not-on-server"
+	MsgBetterAuthNeedsServer             MessageID = "better-auth-needs-server"
+	MsgBetterAuthNeedsDrizzle            MessageID = "better-auth-needs-drizzle"
+	MsgSecretInSpec                      MessageID = "secret-in-spec"
+	MsgSecretInArtifact                  MessageID = "secret-in-artifact"
+	MsgNodeDependency                    MessageID = "node-dependency"
+	MsgDeadLink                          MessageID = "dead-link"
+	MsgDeprecatedReference               MessageID = "deprecated-reference"
+	MsgDeprecatedReferenceReplacement    MessageID = "deprecated-reference-replacement"
+	MsgReplacementNotDeprecated          MessageID = "replacement-not-deprecated"
+	MsgReplacementUnknown                MessageID = "replacement-unknown"
+	MsgReplacementKind                   MessageID = "replacement-kind"
+	MsgIdentifierInvalid                 MessageID = "identifier-invalid"
+	MsgIdentifierFileCollision           MessageID = "identifier-file-collision"
+	MsgIdentifierNameCollision           MessageID = "identifier-name-collision"
+	MsgMiddlewareDependencyMissing       MessageID = "middleware-dependency-missing"
+	MsgMiddlewareDependencyOrder         MessageID = "middleware-dependency-order"
+	MsgOperationIDMissing                MessageID = "operation-id-missing"
+	MsgSLOWithoutMetrics                 MessageID = "slo-without-metrics"
+	MsgFileOutsideRoot                   MessageID = "file-outside-root"
+)
+
+// DefaultLanguage is the language of the built-in messages, used for
+// languages and messages a catalog lacks.
+const DefaultLanguage = "en"
+
+// catalogs maps a language to the format strings of its messages. Each
+// translation takes the same arguments, in the same order, as the English one.
+var catalogs = map[string]map[MessageID]string{
+	"en": {
+		MsgDependencyCycle:                   "dependency cycle: %s",
+		MsgUnusedComponent:                   "unused %s: nothing references it and it references nothing",
+		MsgTestsDisabled:                     "testing disables unit and E2E tests, so none of the %d acceptance criteria get a generated test",
+		MsgMissingSpec:                       "missing %s spec",
+		MsgMissingField:                      "missing required field: %s",
+		MsgPortRange:                         "port must be between 1 and 65535",
+		MsgReferenceKind:                     "%s reference %q points to %s, expected %s",
+		MsgBindingTargetKind:                 "binds_to references %q which is %s, expected http.server",
+		MsgBasePathFormat:                    "base_path %q must start with / and not end with / (e.g., /api/v1)",
+		MsgBindingRepeatsBasePath:            "binds_to path %q already starts with base_path %q of %s, which is added to every route; bind to %q",
+		MsgBindingShadowedByAPIDocs:          "binds_to GET %s is served by the API reference of %s; move the route or set api_docs.path",
+		MsgBindingShadowedByMetrics:          "binds_to GET %s is served by the metrics endpoint of %s; move the route or set observability.metrics_path",
+		MsgBindingOverlap:                    "binds_to %s %s also matches %s %s of %s; a catch-all binding must not overlap other routes",
+		MsgProviderRequiresField:             "%s provider requires %s field",
+		MsgProviderOnlyField:                 "%s is only supported by the %s provider",
+		MsgProviderOnlyRBAC:                  "permissions and roles are only supported by the casbin provider",
+		MsgSessionStoreRequired:              "session storage database requires a store",
+		MsgSessionStoreKind:                  "session store %q must be a postgres component, got %s",
+		MsgSessionStoreUnused:                "session storage %s does not use a store",
+		MsgSessionStorageUnsupported:         "unsupported session storage %q",
+		MsgOAuthProviderUnsupported:          "unsupported oauth provider %q (supported: github, google)",
+		MsgOAuthMissingField:                 "oauth %s: missing required field: %s",
+		MsgOAuthInlineCredential:             "oauth %s: %s %q is not an environment variable name; name the variable that holds the value (e.g., %s) instead of inlining it",
+		MsgOAuthSameVariable:                 "oauth %s: client_id_env and client_secret_env must be different variables",
+		MsgRoleUndeclaredPermission:          "role %q grants undeclared permission %q",
+		MsgRoleUndeclaredParent:              "role %q inherits undeclared role %q",
+		MsgRoleInheritanceCycle:              "role inheritance cycle: %s",
+		MsgRolePolicyFields:                  "roles need a policy definition with three fields (p = sub, obj, act), model has p = %s",
+		MsgRoleDefinitionMissing:             "role inheritance needs a role definition (g = _, _) in the model",
+		MsgPolicyAdapterNoDatabase:           "policy_adapter postgres requires a postgres component in depends_on",
+		MsgPolicyAdapterDatabases:            "policy_adapter postgres requires exactly one postgres component in depends_on, found %d",
+		MsgAuthorizationNeedsCasbin:          "authorization needs a casbin middleware to run for this usecase",
+		MsgAuthorizationNeedsAuth:            "authorization needs a better-auth middleware to identify the caller",
+		MsgAuthorizationUndeclaredRole:       "authorization requires role %q, which %s does not declare",
+		MsgAuthorizationUndeclaredPermission: "authorization requires permission %q, which %s does not declare",
+		MsgInputMappingCatchAll:              "input_mapping is not supported on catch-all bindings, whose usecase receives the raw request",
+		MsgInputMappingSource:                "input_mapping %s reads %q; use body.<field>, path.<param> or query.<param>",
+		MsgInputMappingTransform:             "input_mapping %s: unsupported transform %q (supported: trim, lowercase, uppercase, number)",
+		MsgInputMappingNoBody:                "input_mapping %s reads the body, but %s requests have none",
+		MsgInputMappingUnknownPathParam:      "input_mapping %s reads path parameter %q, which binds_to path %s does not declare",
+		MsgInputMappingUnknownBodyField:      "input_mapping %s reads body field %q, which the request body of %s does not declare",
+		MsgInputMappingUnknownQueryParam:     "input_mapping %s reads query parameter %q, which %s does not declare",
+		MsgWebhookInlineValue:                "%s %q is not an environment variable name; name the variable that holds the value (e.g., %s) instead of inlining it",
+		MsgWebhookSameVariable:               "url_env and secret_env must be different variables",
+		MsgWebhookDuplicateEvent:             "event %q is declared more than once",
+		MsgHTTPClientInlineValue:             "base_url_env %q is not an environment variable name; name the variable that holds the URL (e.g., %s) instead of inlining it",
+		MsgCronSchedule:                      "schedule %q is not a cron expression; give minute, hour, day of month, month and day of week (e.g., \"0 3 * * *\" for 03:00 every day)",
+		MsgTargetMiddleware:                  "the %s target cannot run %s middleware, so the routes it guards would be open to every request; compile for typescript or leave the middleware out",
+		MsgTargetAuthorization:               "the %s target cannot enforce authorization, so every caller could invoke this usecase; compile for typescript or remove authorization",
+		MsgTargetServerCache:                 "the %s target cannot cache responses on the server, so every request would reach the usecase; compile for typescript or remove cache.server",
+		MsgLimitExceedsServer:                "limits.%s %d exceeds the %d %s allows; usecases may only lower the server's limits",
+		MsgCacheMethod:                       "cache applies to GET routes only, not %s",
+		MsgCachePublicAuthorized:             "cache visibility public would share responses between callers of a route with authorization; use private",
+		MsgCacheServerPrivate:                "cache server shares responses between callers, which visibility private forbids; use visibility public",
+		MsgCacheTablesWithoutServer:          "cache tables invalidate the server's cache, which is off; set server: true or remove tables",
+		MsgConcurrencyMethod:                 "concurrency applies to GET, PUT and PATCH routes without wildcards only, not %s %s",
+		MsgConcurrencyNoRead:                 "concurrency etag on %s %s needs a GET usecase on the same path with concurrency etag, whose ETag If-Match is checked against",
+		MsgBulkMethod:                        "bulk applies to POST, PUT and PATCH routes without wildcards only, not %s %s",
+		MsgBulkInputMapping:                  "bulk usecases receive the items and path parameters as input; remove input_mapping",
+		MsgBulkNotArray:                      "bulk needs the JSON request body of %s to be an array, but it is %s",
+		MsgAsyncMethod:                       "async applies to POST, PUT, PATCH and DELETE routes without wildcards only, not %s %s",
+		MsgAsyncNoDatabase:                   "async usecases keep their jobs in a postgres database, but this usecase uses none; add one to depends_on",
+		MsgAsyncStatusRoute:                  "async job status route GET %s is also bound by %s",
+		MsgTLSInlineValue:                    "tls %s %q is not an environment variable name; name the variable that holds the file path (e.g., %s) instead of inlining it",
+		MsgTLSSameVariable:                   "tls cert_env and key_env must be different variables",
+		MsgResourcesKind:                     "resources apply to http.server, http.gateway and postgres components, not %s",
+		MsgResourcesCPURange:                 "resources cpu %dm is outside 10m to 64",
+		MsgResourcesMemoryRange:              "resources memory %dMi is outside 64Mi to 256Gi",
+		MsgResourcesReplicasRange:            "resources replicas %d is outside 1 to 100",
+		MsgResourcesPostgresReplicas:         "postgres runs a single instance; replicas apply to http.server and http.gateway components",
+		MsgResourcesReplicasDiffer:           "replicas %d differ from the %d of %s; servers and gateways run in one process and scale together",
+		MsgSpecVersionTooNew:                 "spec version %s needs bound %s or newer; this compiler reads spec versions %s",
+		MsgSpecVersionTooOld:                 "spec version %s is too old; this compiler reads spec versions %s, so run bound migrate of bound %s first",
+		MsgSpecVersionShim:                   "spec version %s is read through a compatibility shim; run bound migrate to update it to %s",
+		MsgPathParamDuplicate:                "binds_to path %s names path parameter %q more than once",
+		MsgPathParamNotInPath:                "%s declares path parameter %q, which binds_to path %s does not contain",
+		MsgPathParamOptional:                 "path parameter %q of %s must be required, since a path segment cannot be left out",
+		MsgPathParamType:                     "path parameter %q of %s has type %s; path parameters must be string, integer, number or boolean",
+		MsgGatewayPortInUse:                  "port %d is already used by %s",
+		MsgGatewayPrefixFormat:               "route prefix %q must start with / and not end with / (e.g., /orders)",
+		MsgGatewayDuplicatePrefix:            "route prefix %q is declared more than once",
+		MsgGatewayAuthProvider:               "auth %q uses provider %q; the gateway can only check better-auth sessions",
+		MsgUsecaseDatabaseAmbiguous:          "server %q uses %d databases; declare which this usecase uses in depends_on",
+		MsgUsecaseDatabaseNotOnServer:        "depends_on %q is not a dependency of server %q",
+		MsgBetterAuthNeedsServer:             "better-auth middleware requires at least one http.server component",
+		MsgBetterAuthNeedsDrizzle:            "better-auth middleware requires a postgres component with provider \"drizzle\"",
+		MsgSecretInSpec:                      "%s looks like %s; " + secretGuidance,
+		MsgSecretInArtifact:                  "%s:%d looks like it contains %s; " + secretGuidance,
+		MsgNodeDependency:                    "%s: %s %s needs Node.js %d or newer, but engines allows Node.js %d; target a newer node version or depend on an older %s",
+		MsgDeadLink:                          "link %s does not resolve: %s",
+		MsgDeprecatedReference:               "references deprecated %s %s",
+		MsgDeprecatedReferenceReplacement:    "references deprecated %s %s; use %s instead",
+		MsgReplacementNotDeprecated:          "replacement requires deprecated: true",
+		MsgReplacementUnknown:                "replacement %q is not a component of the spec",
+		MsgReplacementKind:                   "replacement %q is a %s, not a %s",
+		MsgIdentifierInvalid:                 "component ID generates the identifier %s, which is not valid; use lowercase letters, digits and hyphens, starting each segment with a letter",
+		MsgIdentifierFileCollision:           "generates the same file names as %s (%s.*); rename one of them",
+		MsgIdentifierNameCollision:           "generates the identifier %s, as %s does; rename one of them",
+		MsgMiddlewareDependencyMissing:       "middleware chain %s runs %s without %s, which it depends on; add it earlier in the chain",
+		MsgMiddlewareDependencyOrder:         "middleware chain %s runs %s before %s, which it depends on; move it earlier in the chain",
+		MsgOperationIDMissing:                "operation %s %s in %s has no operationId, so its generated types are named after the usecase or route and change when they are renamed; add an operationId",
+		MsgSLOWithoutMetrics:                 "slo cannot be measured: %s does not enable observability.metrics",
+		MsgFileOutsideRoot:                   "file %q is outside the directory the spec's files are read from",
+	},
+	"de": {
+		MsgDependencyCycle:                   "Abhängigkeitszyklus: %s",
+		MsgUnusedComponent:                   "unbenutzte Komponente %s: nichts verweist darauf und sie verweist auf nichts",
+		MsgTestsDisabled:                     "testing deaktiviert Unit- und E2E-Tests, daher erhält keines der %d Akzeptanzkriterien einen generierten Test",
+		MsgMissingSpec:                       "%s-Spezifikation fehlt",
+		MsgMissingField:                      "Pflichtfeld fehlt: %s",
+		MsgPortRange:                         "port muss zwischen 1 und 65535 liegen",
+		MsgReferenceKind:                     "%s-Verweis %q zeigt auf %s, erwartet wird %s",
+		MsgBindingTargetKind:                 "binds_to verweist auf %q vom Typ %s, erwartet wird http.server",
+		MsgBasePathFormat:                    "base_path %q muss mit / beginnen und darf nicht mit / enden (z. B. /api/v1)",
+		MsgBindingRepeatsBasePath:            "binds_to-Pfad %q beginnt bereits mit base_path %q von %s, der jeder Route vorangestellt wird; binden Sie an %q",
+		MsgBindingShadowedByAPIDocs:          "binds_to GET %s wird von der API-Referenz von %s bedient; verschieben Sie die Route oder setzen Sie api_docs.path",
+		MsgBindingShadowedByMetrics:          "binds_to GET %s wird vom Metrik-Endpunkt von %s bedient; verschieben Sie die Route oder setzen Sie observability.metrics_path",
+		MsgBindingOverlap:                    "binds_to %s %s passt auch auf %s %s von %s; eine Catch-all-Bindung darf sich nicht mit anderen Routen überschneiden",
+		MsgProviderRequiresField:             "Provider %s benötigt das Feld %s",
+		MsgProviderOnlyField:                 "%s wird nur vom Provider %s unterstützt",
+		MsgProviderOnlyRBAC:                  "permissions und roles werden nur vom Provider casbin unterstützt",
+		MsgSessionStoreRequired:              "Sitzungsspeicher database benötigt einen store",
+		MsgSessionStoreKind:                  "Sitzungs-store %q muss eine postgres-Komponente sein, ist aber %s",
+		MsgSessionStoreUnused:                "Sitzungsspeicher %s verwendet keinen store",
+		MsgSessionStorageUnsupported:         "nicht unterstützter Sitzungsspeicher %q",
+		MsgOAuthProviderUnsupported:          "nicht unterstützter OAuth-Provider %q (unterstützt: github, google)",
+		MsgOAuthMissingField:                 "oauth %s: Pflichtfeld fehlt: %s",
+		MsgOAuthInlineCredential:             "oauth %s: %s %q ist kein Umgebungsvariablenname; nennen Sie die Variable, die den Wert enthält (z. B. %s), statt ihn einzutragen",
+		MsgOAuthSameVariable:                 "oauth %s: client_id_env und client_secret_env müssen verschiedene Variablen sein",
+		MsgRoleUndeclaredPermission:          "Rolle %q gewährt die nicht deklarierte Berechtigung %q",
+		MsgRoleUndeclaredParent:              "Rolle %q erbt die nicht deklarierte Rolle %q",
+		MsgRoleInheritanceCycle:              "Zyklus in der Rollenvererbung: %s",
+		MsgRolePolicyFields:                  "Rollen benötigen eine Policy-Definition mit drei Feldern (p = sub, obj, act), das Modell hat p = %s",
+		MsgRoleDefinitionMissing:             "Rollenvererbung benötigt eine Rollendefinition (g = _, _) im Modell",
+		MsgPolicyAdapterNoDatabase:           "policy_adapter postgres benötigt eine postgres-Komponente in depends_on",
+		MsgPolicyAdapterDatabases:            "policy_adapter postgres benötigt genau eine postgres-Komponente in depends_on, gefunden: %d",
+		MsgAuthorizationNeedsCasbin:          "authorization benötigt eine casbin-Middleware, die für diesen Usecase läuft",
+		MsgAuthorizationNeedsAuth:            "authorization benötigt eine better-auth-Middleware, die den Aufrufer identifiziert",
+		MsgAuthorizationUndeclaredRole:       "authorization verlangt die Rolle %q, die %s nicht deklariert",
+		MsgAuthorizationUndeclaredPermission: "authorization verlangt die Berechtigung %q, die %s nicht deklariert",
+		MsgInputMappingCatchAll:              "input_mapping wird bei Catch-all-Bindungen nicht unterstützt, deren Usecase die rohe Anfrage erhält",
+		MsgInputMappingSource:                "input_mapping %s liest %q; verwenden Sie body.<feld>, path.<param> oder query.<param>",
+		MsgInputMappingTransform:             "input_mapping %s: nicht unterstützte Transformation %q (unterstützt: trim, lowercase, uppercase, number)",
+		MsgInputMappingNoBody:                "input_mapping %s liest den Body, aber %s-Anfragen haben keinen",
+		MsgInputMappingUnknownPathParam:      "input_mapping %s liest den Pfadparameter %q, den der binds_to-Pfad %s nicht deklariert",
+		MsgInputMappingUnknownBodyField:      "input_mapping %s liest das Body-Feld %q, das der Request-Body von %s nicht deklariert",
+		MsgInputMappingUnknownQueryParam:     "input_mapping %s liest den Query-Parameter %q, den %s nicht deklariert",
+		MsgWebhookInlineValue:                "%s %q ist kein Umgebungsvariablenname; nennen Sie die Variable, die den Wert enthält (z. B. %s), statt ihn einzutragen",
+		MsgWebhookSameVariable:               "url_env und secret_env müssen verschiedene Variablen sein",
+		MsgWebhookDuplicateEvent:             "Event %q ist mehrfach deklariert",
+		MsgHTTPClientInlineValue:             "base_url_env %q ist kein Umgebungsvariablenname; nennen Sie die Variable, die die URL enthält (z. B. %s), statt sie einzutragen",
+		MsgCronSchedule:                      "schedule %q ist kein Cron-Ausdruck; geben Sie Minute, Stunde, Tag des Monats, Monat und Wochentag an (z. B. \"0 3 * * *\" für täglich 03:00)",
+		MsgTargetMiddleware:                  "das Ziel %s kann %s-Middleware nicht ausführen, die Routen, die sie schützt, wären also für jede Anfrage offen; kompilieren Sie für typescript oder lassen Sie die Middleware weg",
+		MsgTargetAuthorization:               "das Ziel %s kann authorization nicht durchsetzen, jeder Aufrufer könnte diesen Usecase also aufrufen; kompilieren Sie für typescript oder entfernen Sie authorization",
+		MsgTargetServerCache:                 "das Ziel %s kann Antworten nicht auf dem Server cachen, jede Anfrage würde also den Usecase erreichen; kompilieren Sie für typescript oder entfernen Sie cache.server",
+		MsgLimitExceedsServer:                "limits.%s %d überschreitet die %d, die %s erlaubt; Usecases dürfen die Limits des Servers nur senken",
+		MsgCacheMethod:                       "cache gilt nur für GET-Routen, nicht für %s",
+		MsgCachePublicAuthorized:             "Cache-Sichtbarkeit public würde Antworten einer Route mit Autorisierung zwischen Aufrufern teilen; verwenden Sie private",
+		MsgCacheServerPrivate:                "cache server teilt Antworten zwischen Aufrufern, was die Sichtbarkeit private verbietet; verwenden Sie visibility public",
+		MsgCacheTablesWithoutServer:          "cache tables invalidieren den Cache des Servers, der aus ist; setzen Sie server: true oder entfernen Sie tables",
+		MsgConcurrencyMethod:                 "concurrency gilt nur für GET-, PUT- und PATCH-Routen ohne Platzhalter, nicht für %s %s",
+		MsgConcurrencyNoRead:                 "concurrency etag auf %s %s benötigt einen GET-Usecase auf demselben Pfad mit concurrency etag, gegen dessen ETag If-Match geprüft wird",
+		MsgBulkMethod:                        "bulk gilt nur für POST-, PUT- und PATCH-Routen ohne Platzhalter, nicht für %s %s",
+		MsgBulkInputMapping:                  "Bulk-Usecases erhalten die Elemente und Pfadparameter als Eingabe; entfernen Sie input_mapping",
+		MsgBulkNotArray:                      "bulk erfordert, dass der JSON-Request-Body von %s ein Array ist, er ist jedoch %s",
+		MsgAsyncMethod:                       "async gilt nur für POST-, PUT-, PATCH- und DELETE-Routen ohne Platzhalter, nicht für %s %s",
+		MsgAsyncNoDatabase:                   "Async-Usecases speichern ihre Jobs in einer Postgres-Datenbank, dieser Usecase verwendet jedoch keine; fügen Sie eine zu depends_on hinzu",
+		MsgAsyncStatusRoute:                  "Job-Status-Route GET %s ist auch durch %s gebunden",
+		MsgTLSInlineValue:                    "tls %s %q ist kein Umgebungsvariablenname; nennen Sie die Variable, die den Dateipfad enthält (z. B. %s), statt ihn einzutragen",
+		MsgTLSSameVariable:                   "tls cert_env und key_env müssen verschiedene Variablen sein",
+		MsgResourcesKind:                     "resources gilt für http.server-, http.gateway- und postgres-Komponenten, nicht für %s",
+		MsgResourcesCPURange:                 "resources cpu %dm liegt außerhalb von 10m bis 64",
+		MsgResourcesMemoryRange:              "resources memory %dMi liegt außerhalb von 64Mi bis 256Gi",
+		MsgResourcesReplicasRange:            "resources replicas %d liegt außerhalb von 1 bis 100",
+		MsgResourcesPostgresReplicas:         "postgres läuft als einzelne Instanz; replicas gilt für http.server- und http.gateway-Komponenten",
+		MsgResourcesReplicasDiffer:           "replicas %d weicht von %d in %s ab; Server und Gateways laufen in einem Prozess und skalieren gemeinsam",
+		MsgSpecVersionTooNew:                 "Spec-Version %s erfordert bound %s oder neuer; dieser Compiler liest die Spec-Versionen %s",
+		MsgSpecVersionTooOld:                 "Spec-Version %s ist zu alt; dieser Compiler liest die Spec-Versionen %s, führen Sie daher zuerst bound migrate von bound %s aus",
+		MsgSpecVersionShim:                   "Spec-Version %s wird über eine Kompatibilitätsschicht gelesen; aktualisieren Sie sie mit bound migrate auf %s",
+		MsgPathParamDuplicate:                "binds_to-Pfad %s benennt den Pfadparameter %q mehrfach",
+		MsgPathParamNotInPath:                "%s deklariert den Pfadparameter %q, den der binds_to-Pfad %s nicht enthält",
+		MsgPathParamOptional:                 "Pfadparameter %q von %s muss required sein, da ein Pfadsegment nicht weggelassen werden kann",
+		MsgPathParamType:                     "Pfadparameter %q von %s hat den Typ %s; Pfadparameter müssen string, integer, number oder boolean sein",
+		MsgGatewayPortInUse:                  "Port %d wird bereits von %s verwendet",
+		MsgGatewayPrefixFormat:               "Routenpräfix %q muss mit / beginnen und darf nicht mit / enden (z. B. /orders)",
+		MsgGatewayDuplicatePrefix:            "Routenpräfix %q ist mehrfach deklariert",
+		MsgGatewayAuthProvider:               "auth %q verwendet den Provider %q; das Gateway kann nur better-auth-Sitzungen prüfen",
+		MsgUsecaseDatabaseAmbiguous:          "Server %q verwendet %d Datenbanken; geben Sie in depends_on an, welche dieser Usecase verwendet",
+		MsgUsecaseDatabaseNotOnServer:        "depends_on %q ist keine Abhängigkeit von Server %q",
+		MsgBetterAuthNeedsServer:             "better-auth-Middleware benötigt mindestens eine http.server-Komponente",
+		MsgBetterAuthNeedsDrizzle:            "better-auth-Middleware benötigt eine postgres-Komponente mit provider \"drizzle\"",
+		MsgSecretInSpec:                      "%s sieht aus wie %s; deklarieren Sie eine Umgebungsvariable (z. B. ein *_env-Feld) und lesen Sie den Wert daraus",
+		MsgSecretInArtifact:                  "%s:%d scheint %s zu enthalten; deklarieren Sie eine Umgebungsvariable (z. B. ein *_env-Feld) und lesen Sie den Wert daraus",
+		MsgNodeDependency:                    "%s: %s %s benötigt Node.js %d oder neuer, engines erlaubt aber Node.js %d; wählen Sie eine neuere node-Version oder hängen Sie von einer älteren Version von %s ab",
+		MsgDeadLink:                          "Link %s ist nicht erreichbar: %s",
+		MsgDeprecatedReference:               "verweist auf veraltete Komponente %s %s",
+		MsgDeprecatedReferenceReplacement:    "verweist auf veraltete Komponente %s %s; verwenden Sie stattdessen %s",
+		MsgReplacementNotDeprecated:          "replacement erfordert deprecated: true",
+		MsgReplacementUnknown:                "replacement %q ist keine Komponente der Spezifikation",
+		MsgReplacementKind:                   "replacement %q ist vom Typ %s, nicht %s",
+		MsgIdentifierInvalid:                 "die Komponenten-ID erzeugt den ungültigen Bezeichner %s; verwenden Sie Kleinbuchstaben, Ziffern und Bindestriche und beginnen Sie jedes Segment mit einem Buchstaben",
+		MsgIdentifierFileCollision:           "erzeugt dieselben Dateinamen wie %s (%s.*); benennen Sie eine der Komponenten um",
+		MsgIdentifierNameCollision:           "erzeugt den Bezeichner %s, wie auch %s; benennen Sie eine der Komponenten um",
+		MsgMiddlewareDependencyMissing:       "Middleware-Kette %s führt %s ohne %s aus, wovon sie abhängt; fügen Sie sie weiter vorne in der Kette hinzu",
+		MsgMiddlewareDependencyOrder:         "Middleware-Kette %s führt %s vor %s aus, wovon sie abhängt; verschieben Sie sie weiter nach vorne",
+		MsgOperationIDMissing:                "Operation %s %s in %s hat keine operationId, daher werden ihre generierten Typen nach dem Usecase oder der Route benannt und ändern sich, wenn diese umbenannt werden; fügen Sie eine operationId hinzu",
+		MsgSLOWithoutMetrics:                 "slo kann nicht gemessen werden: %s aktiviert observability.metrics nicht",
+		MsgFileOutsideRoot:                   "Datei %q liegt außerhalb des Verzeichnisses, aus dem die Dateien der Spezifikation gelesen werden",
+	},
+}
+
+// Languages returns the languages with a message catalog, sorted.
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// FormatMessage renders a message in lang, falling back to English when the
+// language or the message is missing from the catalog.
+func FormatMessage(lang string, id MessageID, args ...any) string {
+	format, ok := catalogs[lang][id]
+	if !ok {
+		format = catalogs[DefaultLanguage][id]
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// LanguageFromEnv returns the language diagnostics should use according to
+// OPENBOUNDARY_LANG, then the POSIX locale variables LC_ALL, LC_MESSAGES and
+// LANG. A locale such as "de_DE.UTF-8" selects "de"; unset, "C" and "POSIX"
+// select English.
+func LanguageFromEnv(getenv func(string) string) string {
+	for _, name := range []string{"OPENBOUNDARY_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := getenv(name); value != "" {
+			return normalizeLanguage(value)
+		}
+	}
+	return DefaultLanguage
+}
+
+// normalizeLanguage reduces a locale name to its language code.
+func normalizeLanguage(locale string) string {
+	lang := strings.ToLower(locale)
+	if n := strings.IndexAny(lang, "_.@-"); n >= 0 {
+		lang = lang[:n]
+	}
+	if lang == "" || lang == "c" || lang == "posix" {
+		return DefaultLanguage
+	}
+	return lang
+}
+
+// newError returns a diagnostic about a component with its message rendered
+// in English; Localize renders it in another language.
+func newError(id string, msg MessageID, args ...any) ValidationError {
+	return ValidationError{
+		ID:        id,
+		Message:   FormatMessage(DefaultLanguage, msg, args...),
+		MessageID: msg,
+		Args:      args,
+	}
+}
+
+// Localize returns the diagnostic with its message rendered in lang.
+// Diagnostics without a MessageID are returned unchanged.
+func (e ValidationError) Localize(lang string) ValidationError {
+	if e.MessageID != "" {
+		e.Message = FormatMessage(lang, e.MessageID, e.Args...)
+	}
+	return e
+}
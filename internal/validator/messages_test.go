@@ -0,0 +1,115 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+var formatVerbPattern = regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z]`)
+
+func TestCatalogs_MatchEnglishArguments(t *testing.T) {
+	english := catalogs[DefaultLanguage]
+	for lang, catalog := range catalogs {
+		for id, format := range catalog {
+			base, ok := english[id]
+			if !ok {
+				t.Errorf("%s: message %q is not in the English catalog", lang, id)
+				continue
+			}
+			got := formatVerbPattern.FindAllString(format, -1)
+			want := formatVerbPattern.FindAllString(base, -1)
+			if len(got) != len(want) {
+				t.Errorf("%s: message %q has verbs %v, expected %v", lang, id, got, want)
+				continue
+			}
+			for n := range got {
+				if got[n] != want[n] {
+					t.Errorf("%s: message %q has verbs %v, expected %v", lang, id, got, want)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestLanguageFromEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected string
+	}{
+		{"unset", nil, "en"},
+		{"locale", map[string]string{"LANG": "de_DE.UTF-8"}, "de"},
+		{"C locale", map[string]string{"LANG": "C"}, "en"},
+		{"LC_ALL wins over LANG", map[string]string{"LC_ALL": "fr_FR", "LANG": "de_DE"}, "fr"},
+		{"OPENBOUNDARY_LANG wins", map[string]string{"OPENBOUNDARY_LANG": "de", "LC_ALL": "en_US.UTF-8"}, "de"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			lang := LanguageFromEnv(func(name string) string { return tt.env[name] })
+
+			// then
+			if lang != tt.expected {
+				t.Errorf("LanguageFromEnv() = %q, expected %q", lang, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidationError_Localize(t *testing.T) {
+	// given
+	ve := newError("middleware.authz", MsgPolicyAdapterDatabases, 2)
+	relayed := ValidationError{ID: "http.server.api", Message: "openapi.yaml:3:1: missing info"}
+
+	tests := []struct {
+		name     string
+		err      ValidationError
+		lang     string
+		expected string
+	}{
+		{"english", ve, "en", "middleware.authz: policy_adapter postgres requires exactly one postgres component in depends_on, found 2"},
+		{"german", ve, "de", "middleware.authz: policy_adapter postgres benötigt genau eine postgres-Komponente in depends_on, gefunden: 2"},
+		{"missing language falls back to english", ve, "fr", "middleware.authz: policy_adapter postgres requires exactly one postgres component in depends_on, found 2"},
+		{"relayed text is unchanged", relayed, "de", "http.server.api: openapi.yaml:3:1: missing info"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			localized := tt.err.Localize(tt.lang)
+
+			// then
+			if got := localized.Error(); got != tt.expected {
+				t.Errorf("Error() = %q, expected %q", got, tt.expected)
+			}
+			if localized.MessageID != tt.err.MessageID {
+				t.Errorf("MessageID = %q, expected %q", localized.MessageID, tt.err.MessageID)
+			}
+		})
+	}
+}
+
+func TestIRValidator_Validate_MessageIDs(t *testing.T) {
+	// given
+	i := &ir.IR{Components: map[string]*ir.Component{
+		"http.server.api": {ID: "http.server.api", Kind: ir.KindHTTPServer, HTTPServer: &ir.HTTPServerSpec{Framework: "hono"}},
+	}}
+
+	// when
+	errs := NewIRValidator().Validate(i)
+
+	// then
+	if len(errs) != 1 || errs[0].MessageID != MsgPortRange {
+		t.Fatalf("Validate() = %v, expected one %s error", errs, MsgPortRange)
+	}
+	if got := errs[0].Localize("de").Message; got != "port muss zwischen 1 und 65535 liegen" {
+		t.Errorf("Localize(de).Message = %q", got)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// validateMiddlewareOrder checks that every middleware chain runs the
+// middleware a middleware depends_on before it, e.g. the authentication an
+// authorizer reads the caller from. Chains are checked where they are
+// declared: on servers, and on usecases that replace their server's chain.
+// Usecases that inherit it are covered by the server.
+func validateMiddlewareOrder(i *ir.IR) []ValidationError {
+	ids := make([]string, 0, len(i.Components))
+	for id := range i.Components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var errs []ValidationError
+	for _, id := range ids {
+		comp := i.Components[id]
+		switch {
+		case comp.HTTPServer != nil:
+			errs = append(errs, validateChainDependencies(i, id, comp.HTTPServer.Middleware)...)
+		case comp.Usecase != nil && comp.Usecase.Middleware != nil:
+			errs = append(errs, validateChainDependencies(i, id, comp.Usecase.Middleware)...)
+		}
+	}
+	return errs
+}
+
+// validateChainDependencies reports each middleware of a chain that runs
+// without, or before, a middleware it depends on. Chain middleware are
+// expanded into their members on both sides.
+func validateChainDependencies(i *ir.IR, id string, middleware []string) []ValidationError {
+	chain := i.ExpandMiddleware(middleware)
+	if len(chain) == 0 {
+		return nil
+	}
+	formatted := strings.Join(chain, " -> ")
+
+	var errs []ValidationError
+	for n, ref := range chain {
+		mw, ok := i.Components[ref]
+		if !ok || mw.Middleware == nil {
+			continue
+		}
+		for _, dep := range mw.Middleware.DependsOn {
+			if target, ok := i.Components[dep]; !ok || target.Kind != ir.KindMiddleware {
+				continue
+			}
+			for _, required := range i.ExpandMiddleware([]string{dep}) {
+				switch {
+				case required == ref || slices.Contains(chain[:n], required):
+				case slices.Contains(chain[n+1:], required):
+					errs = append(errs, newError(id, MsgMiddlewareDependencyOrder, formatted, ref, required))
+				default:
+					errs = append(errs, newError(id, MsgMiddlewareDependencyMissing, formatted, ref, required))
+				}
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// middlewareOrderTestIR returns an IR with an authentication middleware, an
+// authorizer depending on it, and a server and usecase with the given chains.
+func middlewareOrderTestIR(server, usecase []string) *ir.IR {
+	return &ir.IR{Components: map[string]*ir.Component{
+		"middleware.authn": {ID: "middleware.authn", Kind: ir.KindMiddleware, Middleware: &ir.MiddlewareSpec{Provider: "better-auth"}},
+		"middleware.authz": {ID: "middleware.authz", Kind: ir.KindMiddleware, Middleware: &ir.MiddlewareSpec{
+			Provider:  "casbin",
+			DependsOn: []string{"middleware.authn", "postgres.main"},
+		}},
+		"postgres.main":   {ID: "postgres.main", Kind: ir.KindPostgres, Postgres: &ir.PostgresSpec{Provider: "drizzle"}},
+		"http.server.api": {ID: "http.server.api", Kind: ir.KindHTTPServer, HTTPServer: &ir.HTTPServerSpec{Middleware: server}},
+		"usecase.list-users": {ID: "usecase.list-users", Kind: ir.KindUsecase, Usecase: &ir.UsecaseSpec{
+			Middleware: usecase,
+		}},
+	}}
+}
+
+func TestValidateMiddlewareOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		server  []string
+		usecase []string
+		want    []string
+	}{
+		{
+			name:   "dependency runs first",
+			server: []string{"middleware.authn", "middleware.authz"},
+		},
+		{
+			name:   "dependency runs later",
+			server: []string{"middleware.authz", "middleware.authn"},
+			want: []string{
+				"http.server.api: middleware chain middleware.authz -> middleware.authn runs middleware.authz before middleware.authn, which it depends on; move it earlier in the chain",
+			},
+		},
+		{
+			name:    "usecase replaces the chain without the dependency",
+			server:  []string{"middleware.authn", "middleware.authz"},
+			usecase: []string{"middleware.authz"},
+			want: []string{
+				"usecase.list-users: middleware chain middleware.authz runs middleware.authz without middleware.authn, which it depends on; add it earlier in the chain",
+			},
+		},
+		{
+			name:    "usecase without middleware",
+			server:  []string{"middleware.authn", "middleware.authz"},
+			usecase: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			i := middlewareOrderTestIR(tt.server, tt.usecase)
+
+			// when
+			errs := validateMiddlewareOrder(i)
+
+			// then
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateMiddlewareOrder() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMiddlewareOrder_Chain(t *testing.T) {
+	// given - a chain middleware whose members inherit its dependency on
+	// authentication, used before it
+	i := middlewareOrderTestIR([]string{"middleware.guard", "middleware.authn"}, nil)
+	i.Components["middleware.guard"] = &ir.Component{ID: "middleware.guard", Kind: ir.KindMiddleware, Middleware: &ir.MiddlewareSpec{
+		Chain: []string{"middleware.guard.casbin"},
+	}}
+	i.Components["middleware.guard.casbin"] = &ir.Component{ID: "middleware.guard.casbin", Kind: ir.KindMiddleware, Middleware: &ir.MiddlewareSpec{
+		Provider:  "casbin",
+		DependsOn: []string{"middleware.authn"},
+	}}
+
+	// when
+	errs := validateMiddlewareOrder(i)
+
+	// then - the error names the expanded chain
+	var got []string
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		"http.server.api: middleware chain middleware.guard.casbin -> middleware.authn runs middleware.guard.casbin before middleware.authn, which it depends on; move it earlier in the chain",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("validateMiddlewareOrder() =\n%q\nwant\n%q", got, want)
+	}
+}
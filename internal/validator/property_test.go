@@ -0,0 +1,122 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+// specGenerator builds random specs out of the kinds, field names and
+// reference strings the builder understands, with values of random types.
+// The YAML parser accepts all of these shapes, so Build and Validate must
+// handle them without panicking.
+type specGenerator struct {
+	rand *rand.Rand
+	ids  []string
+}
+
+var (
+	propertyKinds  = []string{"http.server", "middleware", "postgres", "usecase", "http.client", "cron", "grpc.client"}
+	propertyFields = []string{
+		"framework", "port", "openapi", "middleware", "depends_on",
+		"provider", "config", "model", "policy", "policy_adapter", "admin_route", "roles", "permissions",
+		"session", "store", "storage", "max_age", "oauth", "client_id_env", "client_secret_env",
+		"schema", "binds_to", "goal", "actor", "preconditions", "acceptance_criteria", "postconditions",
+		"authorization", "name", "inherits", "object", "action", "calls", "base_url_env", "resilience", "schedule",
+	}
+	propertyStrings = []string{
+		"", "hono", "better-auth", "casbin", "drizzle", "redis", "GET", "/users",
+		"./openapi.yaml", "./src/auth/model.conf", "./src/auth/policy.csv", "missing.yaml",
+		"http.server.api:GET:/users", "http.server.api:POST:/users", "c0:GET:/x", "c1", "bad:binding",
+	}
+)
+
+func (g *specGenerator) spec() *parser.Spec {
+	n := g.rand.Intn(6)
+	g.ids = make([]string, n)
+	for k := range g.ids {
+		g.ids[k] = fmt.Sprintf("c%d", k)
+	}
+	if n > 0 && g.rand.Intn(2) == 0 {
+		g.ids[0] = "http.server.api"
+	}
+
+	spec := &parser.Spec{Version: "0.1.0", Name: "property"}
+	for _, id := range g.ids {
+		comp := parser.Component{
+			ID:   id,
+			Kind: propertyKinds[g.rand.Intn(len(propertyKinds))],
+			Spec: map[string]interface{}{},
+		}
+		for f := g.rand.Intn(8); f > 0; f-- {
+			comp.Spec[propertyFields[g.rand.Intn(len(propertyFields))]] = g.value(2)
+		}
+		spec.Components = append(spec.Components, comp)
+	}
+	return spec
+}
+
+// value returns a random YAML value, nesting at most depth levels.
+func (g *specGenerator) value(depth int) interface{} {
+	choices := 5
+	if depth > 0 {
+		choices = 7
+	}
+	switch g.rand.Intn(choices) {
+	case 0:
+		return nil
+	case 1:
+		return g.rand.Intn(70000) - 1000
+	case 2:
+		return g.rand.Intn(2) == 0
+	case 3:
+		if len(g.ids) > 0 && g.rand.Intn(2) == 0 {
+			return g.ids[g.rand.Intn(len(g.ids))]
+		}
+		return propertyStrings[g.rand.Intn(len(propertyStrings))]
+	case 4:
+		return g.rand.Float64()
+	case 5:
+		list := []interface{}{}
+		for k := g.rand.Intn(4); k > 0; k-- {
+			list = append(list, g.value(depth-1))
+		}
+		return list
+	}
+	m := map[string]interface{}{}
+	for k := g.rand.Intn(4); k > 0; k-- {
+		m[propertyFields[g.rand.Intn(len(propertyFields))]] = g.value(depth - 1)
+	}
+	return m
+}
+
+func TestBuildAndValidate_NeverPanic(t *testing.T) {
+	// given
+	g := &specGenerator{rand: rand.New(rand.NewSource(1))}
+
+	for n := 0; n < 2000; n++ {
+		spec := g.spec()
+
+		// when / then
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("panic on spec %+v: %v", spec.Components, r)
+				}
+			}()
+			i, errs := ir.NewBuilder().WithBaseDir(exampleDir).Build(spec)
+			if len(errs) > 0 {
+				return
+			}
+			v := NewIRValidator()
+			v.Validate(i)
+			v.Warnings(i)
+		}()
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+// RuleSecret identifies findings for values that look like credentials.
+const RuleSecret = "secret"
+
+// secretGuidance tells users what to do instead of inlining a credential.
+const secretGuidance = "declare an environment variable (e.g., an *_env field) and read the value from it instead"
+
+// secretPatterns match well-known credential formats. A match is reported
+// as an error in the spec and as a warning in generated files.
+var secretPatterns = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{"a private key", regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`)},
+	{"a JWT", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+	{"an AWS access key", regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`)},
+	{"a GitHub token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"a Stripe secret key", regexp.MustCompile(`\b[rs]k_live_[A-Za-z0-9]{16,}\b`)},
+	{"a Slack token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+}
+
+// connectionStringPattern matches URLs with inline credentials; group 1 is
+// the password and group 2 the host.
+var connectionStringPattern = regexp.MustCompile(`\b[a-z][a-z0-9+.-]*://[^\s:/@'"]+:([^\s@/'"]+)@([^\s:/'"]+)`)
+
+// secretKeyPattern matches names of fields and variables that hold credentials.
+var secretKeyPattern = regexp.MustCompile(`(?i)(secret|passw(or)?d|token|api[_-]?key|private[_-]?key|credential)`)
+
+// secretAssignmentPattern matches a quoted literal assigned to a
+// credential-like name in generated code or config (e.g., secret: 'abc').
+var secretAssignmentPattern = regexp.MustCompile(`(?i)\b\w*(?:secret|passw(?:or)?d|token|api_?key)\w*['"]?\s*[:=]\s*['"]([^'"\s]{8,})['"]`)
+
+// tokenPattern matches strings made only of characters typical of
+// generated credentials.
+var tokenPattern = regexp.MustCompile(`^[A-Za-z0-9+/=_-]{20,}$`)
+
+// placeholderPasswords are inline passwords that are obviously not real.
+var placeholderPasswords = map[string]bool{
+	"password": true, "pass": true, "postgres": true, "secret": true,
+	"changeme": true, "example": true, "xxx": true, "test": true,
+}
+
+// minSecretEntropy is the Shannon entropy, in bits per character, above
+// which a credential-like value is considered random rather than a word.
+const minSecretEntropy = 3.5
+
+// secretFinding describes a value that looks like a credential. Certain
+// findings match a known credential format; the others are heuristic.
+type secretFinding struct {
+	kind    string
+	certain bool
+}
+
+// detectSecret reports whether value, held by a field named key, looks
+// like a credential.
+func detectSecret(key, value string) (secretFinding, bool) {
+	for _, p := range secretPatterns {
+		if p.pattern.MatchString(value) {
+			return secretFinding{kind: p.kind, certain: true}, true
+		}
+	}
+	if m := connectionStringPattern.FindStringSubmatch(value); m != nil && !isPlaceholderCredential(m[1], m[2]) {
+		return secretFinding{kind: "a connection string with a password", certain: true}, true
+	}
+
+	// Fields that reference credentials by name are fine
+	if strings.HasSuffix(strings.ToLower(key), "_env") || envVarPattern.MatchString(value) {
+		return secretFinding{}, false
+	}
+	if key != "" && secretKeyPattern.MatchString(key) && len(value) >= 8 &&
+		!strings.ContainsAny(value, " /") && shannonEntropy(value) >= minSecretEntropy {
+		return secretFinding{kind: "a credential"}, true
+	}
+	if tokenPattern.MatchString(value) && hasLettersAndDigits(value) && shannonEntropy(value) >= 4 {
+		return secretFinding{kind: "a high-entropy string"}, true
+	}
+	return secretFinding{}, false
+}
+
+// isPlaceholderCredential reports whether an inline password is a
+// placeholder or points at a local development host.
+func isPlaceholderCredential(password, host string) bool {
+	if strings.ContainsAny(password, "$<{*") || placeholderPasswords[strings.ToLower(password)] {
+		return true
+	}
+	return host == "localhost" || host == "127.0.0.1"
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per character.
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+	var entropy float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+func hasLettersAndDigits(s string) bool {
+	return strings.ContainsAny(s, "0123456789") &&
+		strings.ContainsFunc(s, func(r rune) bool { return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') })
+}
+
+// specSecrets scans every spec value for credentials. Values matching a known
+// credential format are errors; heuristic findings are warnings.
+func specSecrets(i *ir.IR) (errs, warnings []ValidationError) {
+	if i.Spec == nil {
+		return nil, nil
+	}
+
+	report := func(id, path string, pos parser.Position, value any) {
+		s, ok := value.(string)
+		if !ok {
+			return
+		}
+		key := path[strings.LastIndex(path, ".")+1:]
+		finding, found := detectSecret(key, s)
+		if !found {
+			return
+		}
+		ve := newError(id, MsgSecretInSpec, path, finding.kind)
+		ve.Position = pos
+		ve.Rule = RuleSecret
+		if finding.certain {
+			errs = append(errs, ve)
+		} else {
+			warnings = append(warnings, ve)
+		}
+	}
+
+	report("", "description", i.Spec.Pos(), i.Spec.Description)
+	for n := range i.Spec.Components {
+		comp := &i.Spec.Components[n]
+		walkSpecValues("spec", comp.Spec, func(path string, value any) {
+			report(comp.ID, path, comp.Pos(), value)
+		})
+	}
+	return errs, warnings
+}
+
+// walkSpecValues calls fn for every scalar below value, with its dotted path
+// (e.g., "spec.oauth.github.client_secret_env", "spec.roles.admin.permissions[0]").
+func walkSpecValues(path string, value any, fn func(path string, value any)) {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			walkSpecValues(path+"."+k, v[k], fn)
+		}
+	case []any:
+		for n, item := range v {
+			walkSpecValues(fmt.Sprintf("%s[%d]", path, n), item, fn)
+		}
+	default:
+		fn(path, v)
+	}
+}
+
+// ScanArtifactSecrets reports lines of a generated file that contain
+// credentials, usually copied from a source file the spec references.
+// Findings are warnings: the value lives in that source file, not the spec.
+func ScanArtifactSecrets(path string, content []byte) []ValidationError {
+	var warnings []ValidationError
+	for n, line := range strings.Split(string(content), "\n") {
+		kind := ""
+		for _, p := range secretPatterns {
+			if p.pattern.MatchString(line) {
+				kind = p.kind
+				break
+			}
+		}
+		if m := connectionStringPattern.FindStringSubmatch(line); kind == "" && m != nil && !isPlaceholderCredential(m[1], m[2]) {
+			kind = "a connection string with a password"
+		}
+		if m := secretAssignmentPattern.FindStringSubmatch(line); kind == "" && m != nil &&
+			!envVarPattern.MatchString(m[1]) && shannonEntropy(m[1]) >= minSecretEntropy {
+			kind = "a credential"
+		}
+		if kind == "" {
+			continue
+		}
+		warning := newError("", MsgSecretInArtifact, path, n+1, kind)
+		warning.Position = parser.Position{File: path, Line: n + 1}
+		warning.Rule = RuleSecret
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
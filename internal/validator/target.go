@@ -0,0 +1,50 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"sort"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+// TargetPython is the code generation target that generates a FastAPI
+// service.
+const TargetPython = "python"
+
+// CheckTarget reports what the spec asks of the generated service that
+// target cannot generate, where leaving it out would weaken the service,
+// such as authentication a route relies on or the server cache that keeps
+// its load down. Compile fails on them rather than emit a service that
+// silently lacks it. Every other target supports
+// the whole spec.
+func CheckTarget(i *ir.IR, target string) []ValidationError {
+	if target != TargetPython {
+		return nil
+	}
+
+	ids := make([]string, 0, len(i.Components))
+	for id := range i.Components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var errs []ValidationError
+	for _, id := range ids {
+		comp := i.Components[id]
+		if comp.Middleware != nil && len(comp.Middleware.Chain) == 0 {
+			errs = append(errs, newError(id, MsgTargetMiddleware, target, comp.Middleware.Provider))
+		}
+		if comp.Usecase == nil {
+			continue
+		}
+		if comp.Usecase.Authorization != nil {
+			errs = append(errs, newError(id, MsgTargetAuthorization, target))
+		}
+		if comp.Usecase.Cache != nil && comp.Usecase.Cache.Server {
+			errs = append(errs, newError(id, MsgTargetServerCache, target))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+)
+
+func TestCheckTarget(t *testing.T) {
+	i := &ir.IR{Components: map[string]*ir.Component{
+		"middleware.authn": {ID: "middleware.authn", Kind: ir.KindMiddleware, Middleware: &ir.MiddlewareSpec{Provider: "better-auth"}},
+		"middleware.secured": {ID: "middleware.secured", Kind: ir.KindMiddleware, Middleware: &ir.MiddlewareSpec{
+			Chain: []string{"middleware.authn"},
+		}},
+		"usecase.delete-user": {ID: "usecase.delete-user", Kind: ir.KindUsecase, Usecase: &ir.UsecaseSpec{
+			Authorization: &ir.AuthorizationSpec{Roles: []string{"admin"}},
+		}},
+		"usecase.list-users": {ID: "usecase.list-users", Kind: ir.KindUsecase, Usecase: &ir.UsecaseSpec{}},
+		"usecase.list-products": {ID: "usecase.list-products", Kind: ir.KindUsecase, Usecase: &ir.UsecaseSpec{
+			Cache: &ir.CacheSpec{TTL: 60, Visibility: ir.CachePublic, Server: true},
+		}},
+		"usecase.get-product": {ID: "usecase.get-product", Kind: ir.KindUsecase, Usecase: &ir.UsecaseSpec{
+			Cache: &ir.CacheSpec{TTL: 60, Visibility: ir.CachePublic},
+		}},
+	}}
+
+	tests := []struct {
+		name   string
+		target string
+		want   []string
+	}{
+		{
+			name:   "python",
+			target: TargetPython,
+			want: []string{
+				"middleware.authn: the python target cannot run better-auth middleware, so the routes it guards would be open to every request; compile for typescript or leave the middleware out",
+				"usecase.delete-user: the python target cannot enforce authorization, so every caller could invoke this usecase; compile for typescript or remove authorization",
+				"usecase.list-products: the python target cannot cache responses on the server, so every request would reach the usecase; compile for typescript or remove cache.server",
+			},
+		},
+		{name: "typescript", target: "typescript"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			errs := CheckTarget(i, tt.target)
+
+			// then
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckTarget() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+// A spec's version names the spec format it is written in, which is the
+// version of the schema it validates against. A compiler reads specs of its
+// embedded schema's major and minor version, and through a compatibility
+// shim those of the minor version before, which bound migrate rewrites. Each
+// spec format is introduced by the compiler release of the same major and
+// minor version, so a spec of 0.2.x needs bound 0.2.0 or newer.
+
+// RuleSpecVersion identifies warnings for specs read through the
+// compatibility shim.
+const RuleSpecVersion = "spec-version"
+
+// SpecVersionSupport is how a compiler reads a spec version.
+type SpecVersionSupport int
+
+const (
+	SpecVersionCurrent    SpecVersionSupport = iota // Written in the current format
+	SpecVersionMigratable                           // One minor version back, read through the shim
+	SpecVersionTooOld                               // Older than the shim reaches
+	SpecVersionTooNew                               // Written for a newer compiler
+)
+
+// specVersion is a parsed major.minor.patch version.
+type specVersion struct {
+	Major, Minor, Patch int
+}
+
+func parseSpecVersion(s string) (specVersion, bool) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return specVersion{}, false
+	}
+	var n [3]int
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil || v < 0 {
+			return specVersion{}, false
+		}
+		n[i] = v
+	}
+	return specVersion{n[0], n[1], n[2]}, true
+}
+
+// minor returns the major and minor version, e.g. "0.1".
+func (v specVersion) minor() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// currentSpecVersion returns the version of the embedded schema.
+func currentSpecVersion() specVersion {
+	v, _ := parseSpecVersion(SchemaVersion())
+	return v
+}
+
+// CheckSpecVersion reports how this compiler reads specs of version. A
+// version that is not major.minor.patch is left to the schema and reported
+// as current.
+func CheckSpecVersion(version string) SpecVersionSupport {
+	v, ok := parseSpecVersion(version)
+	if !ok {
+		return SpecVersionCurrent
+	}
+	current := currentSpecVersion()
+	switch {
+	case v.Major > current.Major || (v.Major == current.Major && v.Minor > current.Minor):
+		return SpecVersionTooNew
+	case v.Major == current.Major && v.Minor == current.Minor:
+		return SpecVersionCurrent
+	case v.Major == current.Major && v.Minor == current.Minor-1:
+		return SpecVersionMigratable
+	default:
+		return SpecVersionTooOld
+	}
+}
+
+// SupportedSpecVersions describes the spec versions this compiler reads,
+// e.g. "0.0.x and 0.1.x".
+func SupportedSpecVersions() string {
+	current := currentSpecVersion()
+	if current.Minor == 0 {
+		return current.minor() + ".x"
+	}
+	previous := specVersion{current.Major, current.Minor - 1, 0}
+	return previous.minor() + ".x and " + current.minor() + ".x"
+}
+
+// validateSpecVersion returns the error for a spec this compiler cannot
+// read, or nil.
+func validateSpecVersion(spec *parser.Spec) []ValidationError {
+	v, ok := parseSpecVersion(spec.Version)
+	if !ok {
+		return nil
+	}
+	var err ValidationError
+	switch CheckSpecVersion(spec.Version) {
+	case SpecVersionTooNew:
+		err = newError("", MsgSpecVersionTooNew, spec.Version, v.minor()+".0", SupportedSpecVersions())
+	case SpecVersionTooOld:
+		next := specVersion{v.Major, v.Minor + 1, 0}
+		err = newError("", MsgSpecVersionTooOld, spec.Version, SupportedSpecVersions(), next.minor()+".0")
+	default:
+		return nil
+	}
+	err.Path = "/version"
+	err.Position = spec.Pos()
+	return []ValidationError{err}
+}
+
+// specVersionWarnings warns about a spec read through the compatibility shim.
+func specVersionWarnings(spec *parser.Spec) []ValidationError {
+	if spec == nil || CheckSpecVersion(spec.Version) != SpecVersionMigratable {
+		return nil
+	}
+	warning := newError("", MsgSpecVersionShim, spec.Version, SchemaVersion())
+	warning.Rule = RuleSpecVersion
+	warning.Position = spec.Pos()
+	return []ValidationError{warning}
+}
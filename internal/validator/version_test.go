@@ -0,0 +1,80 @@
+// Copyright 2026 OpenBoundary Contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openboundary/openboundary/internal/ir"
+	"github.com/openboundary/openboundary/internal/parser"
+)
+
+func TestCheckSpecVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected SpecVersionSupport
+	}{
+		{version: "0.1.0", expected: SpecVersionCurrent},
+		{version: "0.1.7", expected: SpecVersionCurrent},
+		{version: "0.0.1", expected: SpecVersionMigratable},
+		{version: "0.2.0", expected: SpecVersionTooNew},
+		{version: "1.0.0", expected: SpecVersionTooNew},
+		{version: "invalid", expected: SpecVersionCurrent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := CheckSpecVersion(tt.version); got != tt.expected {
+				t.Errorf("CheckSpecVersion(%q) = %d, expected %d", tt.version, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSupportedSpecVersions(t *testing.T) {
+	if got, expected := SupportedSpecVersions(), "0.0.x and 0.1.x"; got != expected {
+		t.Errorf("SupportedSpecVersions() = %q, expected %q", got, expected)
+	}
+}
+
+func TestJSONSchemaValidator_Validate_SpecVersionTooNew(t *testing.T) {
+	// given
+	v, _ := NewJSONSchemaValidator()
+	spec := &parser.Spec{Version: "0.2.0", Name: "test-api"}
+
+	// when
+	errs := v.Validate(spec)
+
+	// then
+	if len(errs) != 1 {
+		t.Fatalf("Validate() returned %d errors, expected 1: %v", len(errs), errs)
+	}
+	if errs[0].Path != "/version" {
+		t.Errorf("Path = %q, expected %q", errs[0].Path, "/version")
+	}
+	if want := "spec version 0.2.0 needs bound 0.2.0 or newer"; !strings.Contains(errs[0].Message, want) {
+		t.Errorf("Message = %q, expected it to contain %q", errs[0].Message, want)
+	}
+}
+
+func TestIRValidator_Warnings_SpecVersionShim(t *testing.T) {
+	// given
+	spec := &parser.Spec{Version: "0.0.1", Name: "test-api"}
+	builtIR, errs := ir.NewBuilder().Build(spec)
+	if len(errs) > 0 {
+		t.Fatalf("Build() errors: %v", errs)
+	}
+
+	// when
+	warnings := NewIRValidator().Warnings(builtIR)
+
+	// then
+	if len(warnings) != 1 {
+		t.Fatalf("Warnings() returned %d warnings, expected 1: %v", len(warnings), warnings)
+	}
+	if warnings[0].Rule != RuleSpecVersion {
+		t.Errorf("Rule = %q, expected %q", warnings[0].Rule, RuleSpecVersion)
+	}
+}
@@ -3,7 +3,22 @@
 
 package templates
 
-import "embed"
+import (
+	"embed"
+	"io/fs"
+)
 
 //go:embed blank basic
 var FS embed.FS
+
+// Names returns the names of the embedded project templates, sorted.
+func Names() []string {
+	entries, _ := fs.ReadDir(FS, ".")
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}